@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/errors"
+)
+
+const ErrAnonymousMethods errors.ErrorCode = "INVALID_ANONYMOUS_METHODS"
+
+// DefaultAnonymousMethods are always served without authentication. Clients call these to discover how to
+// authenticate in the first place.
+var DefaultAnonymousMethods = []string{
+	"/flyteidl.service.AuthMetadataService/GetOAuth2Metadata",
+	"/flyteidl.service.AuthMetadataService/GetPublicClientConfig",
+}
+
+// AnonymousMethodMatcher decides whether a fully qualified gRPC method can be called without authentication.
+type AnonymousMethodMatcher struct {
+	exact    map[string]bool
+	patterns []string
+}
+
+// Matches returns true if fullMethodName (e.g. /flyteidl.service.AdminService/GetTask) is allowed anonymously.
+func (m *AnonymousMethodMatcher) Matches(fullMethodName string) bool {
+	if m == nil {
+		return false
+	}
+
+	if m.exact[fullMethodName] {
+		return true
+	}
+
+	for _, pattern := range m.patterns {
+		// Patterns are validated at construction time so matching can't fail here.
+		if matched, _ := path.Match(pattern, fullMethodName); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateAnonymousMethod(pattern string) error {
+	parts := strings.Split(pattern, "/")
+	if len(parts) != 3 || len(parts[0]) > 0 || len(parts[1]) == 0 || len(parts[2]) == 0 {
+		return fmt.Errorf("expected a method of the form /<package>.<Service>/<Method>")
+	}
+
+	_, err := path.Match(pattern, "")
+	return err
+}
+
+// NewAnonymousMethodMatcher compiles the configured patterns, merged with DefaultAnonymousMethods, into a matcher.
+// Patterns use path.Match syntax, where '*' matches any sequence of characters within the service or method name.
+func NewAnonymousMethodMatcher(patterns []string) (*AnonymousMethodMatcher, error) {
+	matcher := &AnonymousMethodMatcher{
+		exact: make(map[string]bool, len(DefaultAnonymousMethods)+len(patterns)),
+	}
+
+	for _, pattern := range append(append([]string{}, DefaultAnonymousMethods...), patterns...) {
+		pattern = strings.TrimSpace(pattern)
+		if err := validateAnonymousMethod(pattern); err != nil {
+			return nil, errors.Wrapf(ErrAnonymousMethods, err, "invalid allowed anonymous method [%s]", pattern)
+		}
+
+		if strings.ContainsAny(pattern, `*?[\`) {
+			matcher.patterns = append(matcher.patterns, pattern)
+		} else {
+			matcher.exact[pattern] = true
+		}
+	}
+
+	return matcher, nil
+}
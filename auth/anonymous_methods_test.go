@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymousMethodMatcher(t *testing.T) {
+	matcher, err := NewAnonymousMethodMatcher([]string{
+		"/flyteidl.service.AdminService/GetVersion",
+		"/flyteidl.service.AdminService/Get*",
+		"/grpc.health.v1.Health/*",
+	})
+	assert.NoError(t, err)
+
+	t.Run("defaults", func(t *testing.T) {
+		assert.True(t, matcher.Matches("/flyteidl.service.AuthMetadataService/GetOAuth2Metadata"))
+		assert.True(t, matcher.Matches("/flyteidl.service.AuthMetadataService/GetPublicClientConfig"))
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		assert.True(t, matcher.Matches("/flyteidl.service.AdminService/GetVersion"))
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		assert.True(t, matcher.Matches("/flyteidl.service.AdminService/GetTask"))
+		assert.True(t, matcher.Matches("/grpc.health.v1.Health/Check"))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		assert.False(t, matcher.Matches("/flyteidl.service.AdminService/CreateExecution"))
+		assert.False(t, matcher.Matches("/flyteidl.service.IdentityService/UserInfo"))
+		assert.False(t, matcher.Matches("/flyteidl.service.AdminService/Get/Nested"))
+	})
+}
+
+func TestAnonymousMethodMatcher_DefaultsOnly(t *testing.T) {
+	matcher, err := NewAnonymousMethodMatcher(nil)
+	assert.NoError(t, err)
+	assert.True(t, matcher.Matches("/flyteidl.service.AuthMetadataService/GetOAuth2Metadata"))
+	assert.False(t, matcher.Matches("/flyteidl.service.AdminService/GetVersion"))
+}
+
+func TestNewAnonymousMethodMatcher_Malformed(t *testing.T) {
+	for _, pattern := range []string{
+		"/flyteidl.service.AdminService/Get[",
+		"flyteidl.service.AdminService/GetVersion",
+		"/flyteidl.service.AdminService",
+		"",
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := NewAnonymousMethodMatcher([]string{pattern})
+			assert.Error(t, err)
+		})
+	}
+}
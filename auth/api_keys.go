@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// #nosec
+	ErrInvalidAPIKey errors.ErrorCode = "INVALID_API_KEY"
+	// #nosec
+	ErrNotAPIKey errors.ErrorCode = "NOT_AN_API_KEY"
+	// #nosec
+	ErrAPIKeysLoad errors.ErrorCode = "API_KEYS_LOAD_FAILED"
+)
+
+// Keys shorter than this can't have been generated with enough entropy and are rejected without being looked up.
+const minAPIKeyLength = 32
+
+// Failed attempts with a key that doesn't belong to anyone are counted under this principal.
+const apiKeyUnknownPrincipal = "unknown"
+
+// Reasons an API key can be rejected for, used to label the failures metric.
+const (
+	apiKeyMalformed = "malformed"
+	apiKeyUnknown   = "unknown_key"
+	apiKeyRevoked   = "revoked"
+)
+
+// APIKey describes the principal an API key was issued to, as stored in the API keys secret under the hash of the key.
+type APIKey struct {
+	// Principal is used as the user id of callers authenticating with the key.
+	Principal string `json:"principal"`
+	// Scopes granted to callers authenticating with the key. Defaults to all.
+	Scopes []string `json:"scopes"`
+	// Revoked keys are rejected.
+	Revoked bool `json:"revoked"`
+}
+
+type hashedAPIKey struct {
+	hash []byte
+	key  APIKey
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash of key, as expected in the API keys secret.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Access and id tokens are JWTs and are left to the token validators.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+func isWellFormedAPIKey(key string) bool {
+	if len(key) < minAPIKeyLength {
+		return false
+	}
+
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apiKeyAuthenticator implements interfaces.APIKeyAuthenticator. Only the hashes of the keys are loaded.
+type apiKeyAuthenticator struct {
+	options config.APIKeyOptions
+	sm      core.SecretManager
+
+	// Holds a []hashedAPIKey, replaced as a whole when the keys are reloaded.
+	keys atomic.Value
+
+	successes *prometheus.CounterVec
+	failures  *prometheus.CounterVec
+}
+
+func (a *apiKeyAuthenticator) load(ctx context.Context) error {
+	raw, err := a.sm.Get(ctx, a.options.SecretName)
+	if err != nil {
+		return errors.Wrapf(ErrAPIKeysLoad, err, "failed to read API keys secret [%v]", a.options.SecretName)
+	}
+
+	keys := map[string]APIKey{}
+	if err = json.Unmarshal([]byte(raw), &keys); err != nil {
+		return errors.Wrapf(ErrAPIKeysLoad, err, "failed to parse API keys secret [%v]", a.options.SecretName)
+	}
+
+	hashed := make([]hashedAPIKey, 0, len(keys))
+	for hash, key := range keys {
+		decoded, err := hex.DecodeString(hash)
+		if err != nil || len(decoded) != sha256.Size {
+			return errors.Errorf(ErrAPIKeysLoad, "API key hash for principal [%v] isn't a hex encoded SHA-256 hash",
+				key.Principal)
+		}
+
+		if len(key.Principal) == 0 {
+			return errors.Errorf(ErrAPIKeysLoad, "API key [%v] has no principal", hash)
+		}
+
+		hashed = append(hashed, hashedAPIKey{hash: decoded, key: key})
+	}
+
+	a.keys.Store(hashed)
+	return nil
+}
+
+func (a *apiKeyAuthenticator) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := a.load(ctx); err != nil {
+					logger.Warnf(ctx, "Failed to reload API keys. Will keep using the current ones. Error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (a *apiKeyAuthenticator) reject(principal, reason, format string, args ...interface{}) error {
+	a.failures.WithLabelValues(principal, reason).Inc()
+	return errors.Errorf(ErrInvalidAPIKey, format, args...)
+}
+
+func (a *apiKeyAuthenticator) Authenticate(ctx context.Context, key string) (interfaces.IdentityContext, error) {
+	if isJWT(key) {
+		return nil, errors.Errorf(ErrNotAPIKey, "bearer token is a JWT")
+	}
+
+	if !isWellFormedAPIKey(key) {
+		return nil, a.reject(apiKeyUnknownPrincipal, apiKeyMalformed, "malformed API key")
+	}
+
+	// Every hash is compared, in constant time, so that the time taken doesn't tell how much of a hash matched.
+	sum := sha256.Sum256([]byte(key))
+	var match *APIKey
+	keys := a.keys.Load().([]hashedAPIKey)
+	for i := range keys {
+		if subtle.ConstantTimeCompare(sum[:], keys[i].hash) == 1 {
+			match = &keys[i].key
+		}
+	}
+
+	if match == nil {
+		return nil, a.reject(apiKeyUnknownPrincipal, apiKeyUnknown, "unknown API key")
+	}
+
+	if match.Revoked {
+		return nil, a.reject(match.Principal, apiKeyRevoked, "API key of [%v] was revoked", match.Principal)
+	}
+
+	scopes := sets.NewString(match.Scopes...)
+	if scopes.Len() == 0 {
+		scopes.Insert(ScopeAll)
+	}
+
+	a.successes.WithLabelValues(match.Principal).Inc()
+	logger.Debugf(ctx, "Authenticated [%v] with an API key", match.Principal)
+	return NewIdentityContext("", match.Principal, "", time.Now(), scopes, &service.UserInfoResponse{
+		Subject: match.Principal,
+		Name:    match.Principal,
+	}), nil
+}
+
+// newAPIKeyAuthenticator loads the API keys and keeps reloading them on the configured interval until ctx is done.
+func newAPIKeyAuthenticator(ctx context.Context, options config.APIKeyOptions, sm core.SecretManager,
+	scope promutils.Scope) (*apiKeyAuthenticator, error) {
+	authenticator := &apiKeyAuthenticator{
+		options: options,
+		sm:      sm,
+		successes: scope.MustNewCounterVec("api_key_auth_successes",
+			"number of requests authenticated with an API key", "principal"),
+		failures: scope.MustNewCounterVec("api_key_auth_failures",
+			"number of requests that presented an invalid API key", "principal", "reason"),
+	}
+
+	if err := authenticator.load(ctx); err != nil {
+		return nil, err
+	}
+
+	if options.RefreshInterval.Duration > 0 {
+		authenticator.start(ctx, options.RefreshInterval.Duration)
+	}
+
+	return authenticator, nil
+}
+
+// GRPCGetIdentityFromAPIKey extracts the bearer token from the context and authenticates it as an API key.
+func GRPCGetIdentityFromAPIKey(ctx context.Context, authenticator interfaces.APIKeyAuthenticator) (
+	interfaces.IdentityContext, error) {
+
+	tokenStr, err := grpcauth.AuthFromMD(ctx, BearerScheme)
+	if err != nil {
+		return nil, errors.Wrapf(ErrNotAPIKey, err, "Could not retrieve bearer token from metadata")
+	}
+
+	return authenticator.Authenticate(ctx, tokenStr)
+}
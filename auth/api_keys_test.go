@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces/mocks"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	testCIKey      = "ci_0123456789abcdefghijklmnopqrstuvwxyz"
+	testRevokedKey = "old_0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+func newTestAPIKeysSecret() string {
+	return fmt.Sprintf(`{
+		"%v": {"principal": "ci", "scopes": ["all", "offline"]},
+		"%v": {"principal": "legacy-ci", "revoked": true}
+	}`, HashAPIKey(testCIKey), HashAPIKey(testRevokedKey))
+}
+
+func newTestAPIKeyAuthenticator(t *testing.T, sm *fakeSecretManager) *apiKeyAuthenticator {
+	authenticator, err := newAPIKeyAuthenticator(context.Background(), config.APIKeyOptions{SecretName: "api_keys"}, sm,
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+	return authenticator
+}
+
+func TestAPIKeyAuthenticator_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	authenticator := newTestAPIKeyAuthenticator(t, &fakeSecretManager{secret: newTestAPIKeysSecret()})
+
+	t.Run("valid", func(t *testing.T) {
+		identity, err := authenticator.Authenticate(ctx, testCIKey)
+		assert.NoError(t, err)
+		assert.Equal(t, "ci", identity.UserID())
+		assert.Equal(t, "ci", identity.UserInfo().Subject)
+		assert.True(t, identity.Scopes().HasAll(ScopeAll, "offline"))
+		assert.Equal(t, float64(1), testutil.ToFloat64(authenticator.successes.WithLabelValues("ci")))
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, testRevokedKey)
+		assert.True(t, errors.IsCausedBy(err, ErrInvalidAPIKey))
+		assert.Equal(t, float64(1), testutil.ToFloat64(authenticator.failures.WithLabelValues("legacy-ci",
+			apiKeyRevoked)))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, "ci_zyxwvutsrqponmlkjihgfedcba9876543210")
+		assert.True(t, errors.IsCausedBy(err, ErrInvalidAPIKey))
+		assert.Equal(t, float64(1), testutil.ToFloat64(authenticator.failures.WithLabelValues(apiKeyUnknownPrincipal,
+			apiKeyUnknown)))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, key := range []string{"", "short", testCIKey + " ", "ci/0123456789abcdefghijklmnopqrstuvwxyz"} {
+			_, err := authenticator.Authenticate(ctx, key)
+			assert.True(t, errors.IsCausedBy(err, ErrInvalidAPIKey))
+		}
+
+		assert.Equal(t, float64(4), testutil.ToFloat64(authenticator.failures.WithLabelValues(apiKeyUnknownPrincipal,
+			apiKeyMalformed)))
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, "header.payload.signature")
+		assert.True(t, errors.IsCausedBy(err, ErrNotAPIKey))
+	})
+}
+
+func TestAPIKeyAuthenticator_Reload(t *testing.T) {
+	ctx := context.Background()
+	sm := &fakeSecretManager{secret: newTestAPIKeysSecret()}
+	authenticator := newTestAPIKeyAuthenticator(t, sm)
+
+	sm.rotate(fmt.Sprintf(`{"%v": {"principal": "legacy-ci"}}`, HashAPIKey(testRevokedKey)))
+	assert.NoError(t, authenticator.load(ctx))
+	_, err := authenticator.Authenticate(ctx, testCIKey)
+	assert.Error(t, err)
+	identity, err := authenticator.Authenticate(ctx, testRevokedKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy-ci", identity.UserID())
+
+	t.Run("invalid secret keeps current keys", func(t *testing.T) {
+		sm.rotate(`{"not-a-hash": {"principal": "ci"}}`)
+		assert.Error(t, authenticator.load(ctx))
+		_, err := authenticator.Authenticate(ctx, testRevokedKey)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetAuthenticationInterceptor_APIKey(t *testing.T) {
+	authenticator := newTestAPIKeyAuthenticator(t, &fakeSecretManager{secret: newTestAPIKeysSecret()})
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockAuthCtx.OnAPIKeyAuthenticator().Return(authenticator)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(DefaultAuthorizationHeader,
+		BearerScheme+" "+testCIKey))
+	ctx, err := GetAuthenticationInterceptor(mockAuthCtx)(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", IdentityContextFromContext(ctx).UserID())
+	assert.True(t, IdentityContextFromContext(ctx).Scopes().Has(ScopeAll))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
 	"golang.org/x/oauth2"
 )
 
@@ -35,7 +36,7 @@ var (
 
 // Please see the comment on the corresponding AuthenticationContext for more information.
 type Context struct {
-	oauth2Client         *oauth2.Config
+	oauth2Client         *clientConfigProvider
 	cookieManager        interfaces.CookieHandler
 	oidcProvider         *oidc.Provider
 	options              *config.Config
@@ -43,6 +44,8 @@ type Context struct {
 	oauth2ResourceServer interfaces.OAuth2ResourceServer
 	authServiceImpl      service.AuthMetadataServiceServer
 	identityServiceIml   service.IdentityServiceServer
+	anonymousMethods     *AnonymousMethodMatcher
+	apiKeyAuthenticator  interfaces.APIKeyAuthenticator
 
 	userInfoURL       *url.URL
 	oauth2MetadataURL *url.URL
@@ -55,19 +58,24 @@ func (c Context) OAuth2Provider() interfaces.OAuth2Provider {
 }
 
 func (c Context) OAuth2ClientConfig(requestURL *url.URL) *oauth2.Config {
-	if requestURL == nil || strings.HasPrefix(c.oauth2Client.RedirectURL, requestURL.ResolveReference(rootRelativeURL).String()) {
-		return c.oauth2Client
+	oauth2Client := c.oauth2Client.Get()
+	if requestURL == nil || strings.HasPrefix(oauth2Client.RedirectURL, requestURL.ResolveReference(rootRelativeURL).String()) {
+		return oauth2Client
 	}
 
 	return &oauth2.Config{
 		RedirectURL:  requestURL.ResolveReference(callbackRelativeURL).String(),
-		ClientID:     c.oauth2Client.ClientID,
-		ClientSecret: c.oauth2Client.ClientSecret,
-		Scopes:       c.oauth2Client.Scopes,
-		Endpoint:     c.oauth2Client.Endpoint,
+		ClientID:     oauth2Client.ClientID,
+		ClientSecret: oauth2Client.ClientSecret,
+		Scopes:       oauth2Client.Scopes,
+		Endpoint:     oauth2Client.Endpoint,
 	}
 }
 
+func (c Context) ReloadOAuth2ClientConfig(ctx context.Context) error {
+	return c.oauth2Client.Reload(ctx, clientConfigReloadInvalidClient)
+}
+
 func (c Context) OidcProvider() *oidc.Provider {
 	return c.oidcProvider
 }
@@ -107,9 +115,19 @@ func (c Context) IdentityService() service.IdentityServiceServer {
 func (c Context) OAuth2ResourceServer() interfaces.OAuth2ResourceServer {
 	return c.oauth2ResourceServer
 }
+
+func (c Context) IsAnonymousMethod(fullMethodName string) bool {
+	return c.anonymousMethods.Matches(fullMethodName)
+}
+
+func (c Context) APIKeyAuthenticator() interfaces.APIKeyAuthenticator {
+	return c.apiKeyAuthenticator
+}
+
 func NewAuthenticationContext(ctx context.Context, sm core.SecretManager, oauth2Provider interfaces.OAuth2Provider,
 	oauth2ResourceServer interfaces.OAuth2ResourceServer, authMetadataService service.AuthMetadataServiceServer,
-	identityService service.IdentityServiceServer, options *config.Config) (Context, error) {
+	identityService service.IdentityServiceServer, anonymousMethods *AnonymousMethodMatcher, options *config.Config,
+	scope promutils.Scope) (Context, error) {
 
 	// Construct the cookie manager object.
 	hashKeyBase64, err := sm.Get(ctx, options.UserAuth.CookieHashKeySecretName)
@@ -122,7 +140,7 @@ func NewAuthenticationContext(ctx context.Context, sm core.SecretManager, oauth2
 		return Context{}, errors.Wrapf(ErrConfigFileRead, err, "Could not read hash key file")
 	}
 
-	cookieManager, err := NewCookieManager(ctx, hashKeyBase64, blockKeyBase64)
+	cookieManager, err := NewCookieManager(ctx, hashKeyBase64, blockKeyBase64, options.UserAuth.Cookies)
 	if err != nil {
 		logger.Errorf(ctx, "Error creating cookie manager %s", err)
 		return Context{}, errors.Wrapf(ErrauthCtx, err, "Error creating cookie manager")
@@ -142,7 +160,8 @@ func NewAuthenticationContext(ctx context.Context, sm core.SecretManager, oauth2
 	}
 
 	// Construct the golang OAuth2 library's own internal configuration object from this package's config
-	oauth2Config, err := GetOAuth2ClientConfig(ctx, options.UserAuth.OpenID, provider.Endpoint(), sm)
+	// The client secret is re-read when it may have been rotated.
+	oauth2Config, err := newClientConfigProvider(ctx, options.UserAuth.OpenID, provider.Endpoint(), sm, scope)
 	if err != nil {
 		return Context{}, errors.Wrapf(ErrauthCtx, err, "Error creating OAuth2 library configuration")
 	}
@@ -169,17 +188,27 @@ func NewAuthenticationContext(ctx context.Context, sm core.SecretManager, oauth2
 		options:              options,
 		oidcMetadataURL:      oidcMetadataURL,
 		oauth2MetadataURL:    oauth2MetadataURL,
-		oauth2Client:         &oauth2Config,
+		oauth2Client:         oauth2Config,
 		oidcProvider:         provider,
 		httpClient:           httpClient,
 		cookieManager:        cookieManager,
 		oauth2Provider:       oauth2Provider,
 		oauth2ResourceServer: oauth2ResourceServer,
+		anonymousMethods:     anonymousMethods,
 	}
 
 	authCtx.authServiceImpl = authMetadataService
 	authCtx.identityServiceIml = identityService
 
+	if options.APIKeys.Enabled {
+		apiKeyAuthenticator, err := newAPIKeyAuthenticator(ctx, options.APIKeys, sm, scope)
+		if err != nil {
+			return Context{}, errors.Wrapf(ErrauthCtx, err, "Error loading API keys")
+		}
+
+		authCtx.apiKeyAuthenticator = apiKeyAuthenticator
+	}
+
 	return authCtx, nil
 }
 
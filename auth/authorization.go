@@ -0,0 +1,416 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const ErrInvalidAuthorizationConfig errors.ErrorCode = "INVALID_AUTHORIZATION_CONFIG"
+
+// Only admin service methods are authorized, the identity and auth metadata services are available to any
+// authenticated caller.
+const adminServicePrefix = "/flyteidl.service.AdminService/"
+
+type role int
+
+const (
+	roleNone role = iota
+	roleViewer
+	roleContributor
+	roleAdmin
+)
+
+var roles = map[string]role{
+	"":                     roleNone,
+	config.RoleViewer:      roleViewer,
+	config.RoleContributor: roleContributor,
+	config.RoleAdmin:       roleAdmin,
+}
+
+// Methods that change how projects and their executions are configured, or that delete executions in bulk. Other
+// methods that don't start with Get, List, Watch, Count or Search require the contributor role.
+var adminMethods = sets.NewString(
+	"RegisterProject",
+	"UpdateProject",
+	"UpdateProjectDomainAttributes",
+	"DeleteProjectDomainAttributes",
+	"UpdateWorkflowAttributes",
+	"DeleteWorkflowAttributes",
+	"UpdateScopedAttributes",
+	"DeleteScopedAttributes",
+	"UpdateScheduleCheckpoint",
+	"UpdateActiveExecutionQuota",
+	"DeleteActiveExecutionQuota",
+	"PurgeExecutions",
+)
+
+// Methods any authenticated caller can call. Listing projects is needed to navigate the console before picking a
+// project.
+var unrestrictedMethods = sets.NewString(
+	"ListProjects",
+	"GetVersion",
+)
+
+var readOnlyMethodPrefixes = []string{"Get", "List", "Watch", "Count", "Search"}
+
+type roleBinding struct {
+	role     role
+	groups   sets.String
+	subjects sets.String
+	project  string
+	domain   string
+}
+
+// Returns true if the binding grants its role on scope. Bindings restricted to a project don't apply to requests that
+// aren't scoped to a project, and bindings restricted to a domain don't apply to requests on the whole project.
+func (b roleBinding) appliesTo(scope interfaces.ResourceScope) bool {
+	if len(b.project) == 0 {
+		return true
+	}
+
+	if b.project != scope.Project {
+		return false
+	}
+
+	return len(b.domain) == 0 || b.domain == scope.Domain
+}
+
+func (b roleBinding) matches(identity interfaces.IdentityContext) bool {
+	if b.groups.HasAny(identity.Groups().UnsortedList()...) {
+		return true
+	}
+
+	return (len(identity.UserID()) > 0 && b.subjects.Has(identity.UserID())) ||
+		(len(identity.AppID()) > 0 && b.subjects.Has(identity.AppID()))
+}
+
+// roleBasedPolicy is the default AuthorizationPolicy. It grants callers the highest role bound to them on the
+// requested project and domain, and compares it with the role the method requires.
+type roleBasedPolicy struct {
+	defaultRole role
+	bindings    []roleBinding
+	methodRoles map[string]role
+}
+
+func (p roleBasedPolicy) requiredRole(fullMethod string) role {
+	method := common.ShortMethodName(fullMethod)
+	if required, found := p.methodRoles[method]; found {
+		return required
+	}
+
+	if unrestrictedMethods.Has(method) {
+		return roleNone
+	}
+
+	if adminMethods.Has(method) {
+		return roleAdmin
+	}
+
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return roleViewer
+		}
+	}
+
+	return roleContributor
+}
+
+func (p roleBasedPolicy) grantedRole(identity interfaces.IdentityContext, scope interfaces.ResourceScope) role {
+	granted := p.defaultRole
+	for _, binding := range p.bindings {
+		if binding.role > granted && binding.appliesTo(scope) && binding.matches(identity) {
+			granted = binding.role
+		}
+	}
+
+	return granted
+}
+
+func (p roleBasedPolicy) IsAuthorized(ctx context.Context, identity interfaces.IdentityContext, fullMethod string,
+	scope interfaces.ResourceScope) (bool, error) {
+	return p.grantedRole(identity, scope) >= p.requiredRole(fullMethod), nil
+}
+
+// Bindings restricted to a domain don't grant the method on their project, since requests spanning projects can only
+// be limited to whole projects.
+func (p roleBasedPolicy) AuthorizedProjects(ctx context.Context, identity interfaces.IdentityContext,
+	fullMethod string) ([]string, bool, error) {
+	required := p.requiredRole(fullMethod)
+	if p.defaultRole >= required {
+		return nil, true, nil
+	}
+
+	projects := sets.NewString()
+	for _, binding := range p.bindings {
+		if binding.role < required || len(binding.domain) > 0 || !binding.matches(identity) {
+			continue
+		}
+		if len(binding.project) == 0 {
+			return nil, true, nil
+		}
+		projects.Insert(binding.project)
+	}
+
+	return projects.List(), false, nil
+}
+
+// NewRoleBasedAuthorizationPolicy creates the default AuthorizationPolicy, which maps the groups and subjects of
+// callers to roles as configured in options.
+func NewRoleBasedAuthorizationPolicy(options config.AuthorizationConfig) (interfaces.AuthorizationPolicy, error) {
+	if err := options.Validate(); err != nil {
+		return nil, errors.Wrapf(ErrInvalidAuthorizationConfig, err, "Invalid authorization config")
+	}
+
+	policy := roleBasedPolicy{
+		defaultRole: roles[options.DefaultRole],
+		bindings:    make([]roleBinding, 0, len(options.RoleBindings)),
+		methodRoles: make(map[string]role, len(options.MethodRoles)),
+	}
+
+	for _, binding := range options.RoleBindings {
+		policy.bindings = append(policy.bindings, roleBinding{
+			role:     roles[binding.Role],
+			groups:   sets.NewString(binding.Groups...),
+			subjects: sets.NewString(binding.Subjects...),
+			project:  binding.Project,
+			domain:   binding.Domain,
+		})
+	}
+
+	for method, required := range options.MethodRoles {
+		policy.methodRoles[method] = roles[required]
+	}
+
+	return policy, nil
+}
+
+var registeredAuthorizationPolicy = struct {
+	lock   sync.RWMutex
+	policy interfaces.AuthorizationPolicy
+}{}
+
+// RegisterAuthorizationPolicy replaces the role based policy with a custom one. It must be called before the server
+// starts.
+func RegisterAuthorizationPolicy(policy interfaces.AuthorizationPolicy) {
+	registeredAuthorizationPolicy.lock.Lock()
+	defer registeredAuthorizationPolicy.lock.Unlock()
+	registeredAuthorizationPolicy.policy = policy
+}
+
+// GetAuthorizationPolicy returns the registered AuthorizationPolicy or, if none was, the role based policy configured
+// in options.
+func GetAuthorizationPolicy(options config.AuthorizationConfig) (interfaces.AuthorizationPolicy, error) {
+	registeredAuthorizationPolicy.lock.RLock()
+	defer registeredAuthorizationPolicy.lock.RUnlock()
+	if registeredAuthorizationPolicy.policy != nil {
+		return registeredAuthorizationPolicy.policy, nil
+	}
+
+	return NewRoleBasedAuthorizationPolicy(options)
+}
+
+// Returns a PermissionDenied error unless the policy authorizes the call to the admin service method with the request
+// given, or an Unauthenticated one for calls without an identity to methods which aren't allowed anonymously.
+func authorizeCall(ctx context.Context, authCtx interfaces.AuthenticationContext, policy interfaces.AuthorizationPolicy,
+	fullMethod string, req interface{}) error {
+	identityContext := IdentityContextFromContext(ctx)
+	if identityContext.IsEmpty() {
+		if authCtx.IsAnonymousMethod(fullMethod) {
+			return nil
+		}
+		logger.Infof(ctx, "Denied anonymous call to [%v]", fullMethod)
+		return status.Errorf(codes.Unauthenticated, "authentication is required to call %v",
+			common.ShortMethodName(fullMethod))
+	}
+
+	scope := ResourceScopeFromRequest(fullMethod, req)
+	authorized, err := policy.IsAuthorized(ctx, identityContext, fullMethod, scope)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to authorize call to [%v]. Error: %v", fullMethod, err)
+		return status.Errorf(codes.Internal, "failed to authorize request")
+	}
+
+	if !authorized {
+		logger.Infof(ctx, "Denied call to [%v] on project [%v] domain [%v] for user [%v] app [%v]",
+			fullMethod, scope.Project, scope.Domain, identityContext.UserID(), identityContext.AppID())
+		return status.Errorf(codes.PermissionDenied, "not allowed to call %v on project [%v] domain [%v]",
+			common.ShortMethodName(fullMethod), scope.Project, scope.Domain)
+	}
+
+	return nil
+}
+
+// GetAuthorizationInterceptor returns an interceptor that denies admin service calls the policy doesn't authorize
+// with PermissionDenied. It must run after the authentication interceptor. Calls without an identity are denied with
+// Unauthenticated unless the method is one of the allowed anonymous methods of authCtx.
+func GetAuthorizationInterceptor(authCtx interfaces.AuthenticationContext,
+	policy interfaces.AuthorizationPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		if err := authorizeCall(ctx, authCtx, policy, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Authorizes the call once its request is received, since the project and domain it acts on are only known then.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	authCtx    interfaces.AuthenticationContext
+	policy     interfaces.AuthorizationPolicy
+	fullMethod string
+	authorized bool
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.authorized {
+		return nil
+	}
+
+	if err := authorizeCall(s.Context(), s.authCtx, s.policy, s.fullMethod, m); err != nil {
+		return err
+	}
+
+	s.authorized = true
+	return nil
+}
+
+// GetAuthorizationStreamInterceptor is the streaming counterpart of GetAuthorizationInterceptor. Admin service streams
+// are authorized when their first request is received, and fail with PermissionDenied before it reaches the handler
+// if the policy doesn't authorize it.
+func GetAuthorizationStreamInterceptor(authCtx interfaces.AuthenticationContext,
+	policy interfaces.AuthorizationPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &authorizedServerStream{
+			ServerStream: ss,
+			authCtx:      authCtx,
+			policy:       policy,
+			fullMethod:   info.FullMethod,
+		})
+	}
+}
+
+// AuthorizedHTTPHandler is implemented by HTTP handlers which are served directly rather than through the gRPC gateway,
+// and whose requests are authorized as calls to the admin service method they stand in for.
+type AuthorizedHTTPHandler interface {
+	http.Handler
+	// Returns the short name of the admin service method the request stands in for, e.g. ListExecutions, along with
+	// the project and domain it acts on.
+	AuthorizationMethod(request *http.Request) (string, interfaces.ResourceScope)
+}
+
+// ProjectLimitedHTTPHandler is implemented by AuthorizedHTTPHandlers whose requests may span projects.
+type ProjectLimitedHTTPHandler interface {
+	AuthorizedHTTPHandler
+	// Returns true if requests authorized without a project are served to callers who can only call their method on
+	// some projects too, limited to those projects. See AuthorizedProjectsFromContext.
+	LimitsToAuthorizedProjects() bool
+}
+
+// AuthorizedProjectsFromContext returns the projects a request spanning projects is limited to, along with false if it
+// isn't limited to any because the caller can call its method on every project.
+func AuthorizedProjectsFromContext(ctx context.Context) ([]string, bool) {
+	projects, ok := ctx.Value(ContextKeyAuthorizedProjects).([]string)
+	return projects, ok
+}
+
+// Limits a request spanning projects to the projects the caller can call method on if the handler serves such requests
+// and the policy can tell which projects those are. Returns the context of the request along with whether it was
+// limited, which it isn't if the caller can call method regardless of the project.
+func limitToAuthorizedProjects(ctx context.Context, identity interfaces.IdentityContext,
+	policy interfaces.AuthorizationPolicy, handler AuthorizedHTTPHandler, method string,
+	scope interfaces.ResourceScope) (context.Context, bool, error) {
+	limitedHandler, ok := handler.(ProjectLimitedHTTPHandler)
+	if !ok || !limitedHandler.LimitsToAuthorizedProjects() || !scope.IsGlobal() {
+		return ctx, false, nil
+	}
+	projectPolicy, ok := policy.(interfaces.ProjectAuthorizationPolicy)
+	if !ok {
+		return ctx, false, nil
+	}
+
+	projects, all, err := projectPolicy.AuthorizedProjects(ctx, identity, adminServicePrefix+method)
+	if err != nil || all {
+		return ctx, false, err
+	}
+
+	return context.WithValue(ctx, ContextKeyAuthorizedProjects, projects), true, nil
+}
+
+// GetHTTPAuthorizationHandler returns a handler that denies requests the policy doesn't authorize with 403 Forbidden.
+// Like the authorization interceptor, it must wrap the handler after authentication, and denies requests without an
+// identity with 401 Unauthorized unless the method is one of the allowed anonymous methods of authCtx. Requests a
+// ProjectLimitedHTTPHandler serves across projects are limited to the projects the caller can call their method on
+// instead, and only denied if there are none.
+func GetHTTPAuthorizationHandler(authCtx interfaces.AuthenticationContext, policy interfaces.AuthorizationPolicy,
+	handler AuthorizedHTTPHandler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		method, scope := handler.AuthorizationMethod(request)
+		identityContext := IdentityContextFromContext(ctx)
+		if identityContext.IsEmpty() {
+			if authCtx.IsAnonymousMethod(adminServicePrefix + method) {
+				handler.ServeHTTP(writer, request)
+				return
+			}
+			logger.Infof(ctx, "Denied anonymous request to [%v]", request.URL.Path)
+			http.Error(writer, fmt.Sprintf("authentication is required to call %v", method), http.StatusUnauthorized)
+			return
+		}
+
+		limitedCtx, limited, err := limitToAuthorizedProjects(ctx, identityContext, policy, handler, method, scope)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to authorize request to [%v]. Error: %v", request.URL.Path, err)
+			http.Error(writer, "failed to authorize request", http.StatusInternalServerError)
+			return
+		}
+
+		var authorized bool
+		if limited {
+			projects, _ := AuthorizedProjectsFromContext(limitedCtx)
+			authorized = len(projects) > 0
+		} else if authorized, err = policy.IsAuthorized(ctx, identityContext, adminServicePrefix+method,
+			scope); err != nil {
+			logger.Errorf(ctx, "Failed to authorize request to [%v]. Error: %v", request.URL.Path, err)
+			http.Error(writer, "failed to authorize request", http.StatusInternalServerError)
+			return
+		}
+
+		if !authorized {
+			logger.Infof(ctx, "Denied request to [%v] on project [%v] domain [%v] for user [%v] app [%v]",
+				request.URL.Path, scope.Project, scope.Domain, identityContext.UserID(), identityContext.AppID())
+			http.Error(writer, fmt.Sprintf("not allowed to call %v on project [%v] domain [%v]", method,
+				scope.Project, scope.Domain), http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(writer, request.WithContext(limitedCtx))
+	})
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// scopeResolver extracts the project and domain a request targets. It returns false if the request doesn't have the
+// expected type.
+type scopeResolver func(req interface{}) (interfaces.ResourceScope, bool)
+
+// Implemented by the identifiers and requests that carry the project and domain directly.
+type projectDomainGetter interface {
+	GetProject() string
+	GetDomain() string
+}
+
+func newResourceScope(getter projectDomainGetter) interfaces.ResourceScope {
+	return interfaces.ResourceScope{
+		Project: getter.GetProject(),
+		Domain:  getter.GetDomain(),
+	}
+}
+
+// Resolves the scope of requests that carry the project and domain as top level fields.
+func fromProjectDomain(req interface{}) (interfaces.ResourceScope, bool) {
+	getter, ok := req.(projectDomainGetter)
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(getter), true
+}
+
+func fromIdentifier(req interface{}) (interfaces.ResourceScope, bool) {
+	r, ok := req.(interface{ GetId() *core.Identifier })
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(r.GetId()), true
+}
+
+func fromNamedEntityIdentifier(req interface{}) (interfaces.ResourceScope, bool) {
+	r, ok := req.(interface {
+		GetId() *admin.NamedEntityIdentifier
+	})
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(r.GetId()), true
+}
+
+func fromExecutionIdentifier(req interface{}) (interfaces.ResourceScope, bool) {
+	r, ok := req.(interface {
+		GetId() *core.WorkflowExecutionIdentifier
+	})
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(r.GetId()), true
+}
+
+func fromNodeExecutionIdentifier(req interface{}) (interfaces.ResourceScope, bool) {
+	r, ok := req.(interface {
+		GetId() *core.NodeExecutionIdentifier
+	})
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(r.GetId().GetExecutionId()), true
+}
+
+func fromTaskExecutionIdentifier(req interface{}) (interfaces.ResourceScope, bool) {
+	r, ok := req.(interface {
+		GetId() *core.TaskExecutionIdentifier
+	})
+	if !ok {
+		return interfaces.ResourceScope{}, false
+	}
+
+	return newResourceScope(r.GetId().GetNodeExecutionId().GetExecutionId()), true
+}
+
+// Resolvers for the admin service methods that act on a project. Methods missing here, such as ListProjects and
+// GetVersion, aren't scoped to a project.
+var scopeResolvers = map[string]scopeResolver{
+	"CreateTask":            fromIdentifier,
+	"GetTask":               fromIdentifier,
+	"ListTaskIds":           fromProjectDomain,
+	"ListTasks":             fromNamedEntityIdentifier,
+	"CreateWorkflow":        fromIdentifier,
+	"GetWorkflow":           fromIdentifier,
+	"ListWorkflowIds":       fromProjectDomain,
+	"ListWorkflows":         fromNamedEntityIdentifier,
+	"CreateLaunchPlan":      fromIdentifier,
+	"GetLaunchPlan":         fromIdentifier,
+	"GetActiveLaunchPlan":   fromNamedEntityIdentifier,
+	"ListActiveLaunchPlans": fromProjectDomain,
+	"ListLaunchPlanIds":     fromProjectDomain,
+	"ListLaunchPlans":       fromNamedEntityIdentifier,
+	"UpdateLaunchPlan":      fromIdentifier,
+	"CreateExecution":       fromProjectDomain,
+	"RelaunchExecution":     fromExecutionIdentifier,
+	"RecoverExecution":      fromExecutionIdentifier,
+	"GetExecution":          fromExecutionIdentifier,
+	"GetExecutionData":      fromExecutionIdentifier,
+	"ListExecutions":        fromNamedEntityIdentifier,
+	"TerminateExecution":    fromExecutionIdentifier,
+	"WatchExecution":        fromExecutionIdentifier,
+	"GetNodeExecution":      fromNodeExecutionIdentifier,
+	"ListNodeExecutions": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.NodeExecutionListRequest)
+		return newResourceScope(r.GetWorkflowExecutionId()), ok
+	},
+	"ListNodeExecutionsForTask": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.NodeExecutionForTaskListRequest)
+		return newResourceScope(r.GetTaskExecutionId().GetNodeExecutionId().GetExecutionId()), ok
+	},
+	"GetNodeExecutionData": fromNodeExecutionIdentifier,
+	"RegisterProject": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.ProjectRegisterRequest)
+		return interfaces.ResourceScope{Project: r.GetProject().GetId()}, ok
+	},
+	"UpdateProject": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.Project)
+		return interfaces.ResourceScope{Project: r.GetId()}, ok
+	},
+	"CreateWorkflowEvent": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.WorkflowExecutionEventRequest)
+		return newResourceScope(r.GetEvent().GetExecutionId()), ok
+	},
+	"CreateNodeEvent": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.NodeExecutionEventRequest)
+		return newResourceScope(r.GetEvent().GetId().GetExecutionId()), ok
+	},
+	"CreateTaskEvent": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.TaskExecutionEventRequest)
+		return newResourceScope(r.GetEvent().GetParentNodeExecutionId().GetExecutionId()), ok
+	},
+	"GetTaskExecution": fromTaskExecutionIdentifier,
+	"ListTaskExecutions": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.TaskExecutionListRequest)
+		return newResourceScope(r.GetNodeExecutionId().GetExecutionId()), ok
+	},
+	"GetTaskExecutionData": fromTaskExecutionIdentifier,
+	"UpdateProjectDomainAttributes": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.ProjectDomainAttributesUpdateRequest)
+		return newResourceScope(r.GetAttributes()), ok
+	},
+	"GetProjectDomainAttributes":    fromProjectDomain,
+	"DeleteProjectDomainAttributes": fromProjectDomain,
+	"UpdateWorkflowAttributes": func(req interface{}) (interfaces.ResourceScope, bool) {
+		r, ok := req.(*admin.WorkflowAttributesUpdateRequest)
+		return newResourceScope(r.GetAttributes()), ok
+	},
+	"GetWorkflowAttributes":    fromProjectDomain,
+	"DeleteWorkflowAttributes": fromProjectDomain,
+	"ListNamedEntities":        fromProjectDomain,
+	"GetNamedEntity":           fromNamedEntityIdentifier,
+	"UpdateNamedEntity":        fromNamedEntityIdentifier,
+}
+
+// ResourceScopeFromRequest returns the project and domain targeted by a call to the given admin service method. The
+// scope is global if the method isn't scoped to a project or the request doesn't name one, which policies should treat
+// as requiring a role on all projects.
+func ResourceScopeFromRequest(fullMethod string, req interface{}) interfaces.ResourceScope {
+	resolver, found := scopeResolvers[common.ShortMethodName(fullMethod)]
+	if !found {
+		return interfaces.ResourceScope{}
+	}
+
+	scope, ok := resolver(req)
+	if !ok || scope.IsGlobal() {
+		return interfaces.ResourceScope{}
+	}
+
+	return scope
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/stretchr/testify/assert"
+)
+
+const testProject = "flytesnacks"
+const testDomain = "development"
+
+var testScope = interfaces.ResourceScope{Project: testProject, Domain: testDomain}
+
+func TestResourceScopeFromRequest_CreateExecution(t *testing.T) {
+	method := adminServicePrefix + "CreateExecution"
+	assert.Equal(t, testScope, ResourceScopeFromRequest(method, &admin.ExecutionCreateRequest{
+		Project: testProject,
+		Domain:  testDomain,
+		Name:    "name",
+		Spec: &admin.ExecutionSpec{
+			// The launch plan can live in another project, the execution is created in the requested one.
+			LaunchPlan: &core.Identifier{Project: "other", Domain: "production", Name: "lp"},
+		},
+	}))
+
+	t.Run("missing project", func(t *testing.T) {
+		assert.True(t, ResourceScopeFromRequest(method, &admin.ExecutionCreateRequest{Domain: testDomain}).IsGlobal())
+	})
+
+	t.Run("unexpected type", func(t *testing.T) {
+		assert.True(t, ResourceScopeFromRequest(method, &admin.ObjectGetRequest{
+			Id: &core.Identifier{Project: testProject, Domain: testDomain},
+		}).IsGlobal())
+	})
+}
+
+func TestResourceScopeFromRequest_RegisterProject(t *testing.T) {
+	method := adminServicePrefix + "RegisterProject"
+	assert.Equal(t, interfaces.ResourceScope{Project: testProject}, ResourceScopeFromRequest(method,
+		&admin.ProjectRegisterRequest{
+			Project: &admin.Project{
+				Id:      testProject,
+				Domains: []*admin.Domain{{Id: testDomain}},
+			},
+		}))
+
+	t.Run("missing project", func(t *testing.T) {
+		assert.True(t, ResourceScopeFromRequest(method, &admin.ProjectRegisterRequest{}).IsGlobal())
+	})
+}
+
+func TestResourceScopeFromRequest_UpdateProjectDomainAttributes(t *testing.T) {
+	method := adminServicePrefix + "UpdateProjectDomainAttributes"
+	assert.Equal(t, testScope, ResourceScopeFromRequest(method, &admin.ProjectDomainAttributesUpdateRequest{
+		Attributes: &admin.ProjectDomainAttributes{
+			Project: testProject,
+			Domain:  testDomain,
+		},
+	}))
+
+	t.Run("missing attributes", func(t *testing.T) {
+		assert.True(t, ResourceScopeFromRequest(method, &admin.ProjectDomainAttributesUpdateRequest{}).IsGlobal())
+	})
+}
+
+func TestResourceScopeFromRequest(t *testing.T) {
+	executionID := &core.WorkflowExecutionIdentifier{Project: testProject, Domain: testDomain, Name: "name"}
+	nodeExecutionID := &core.NodeExecutionIdentifier{NodeId: "n0", ExecutionId: executionID}
+	tests := []struct {
+		method string
+		req    interface{}
+	}{
+		{"CreateTask", &admin.TaskCreateRequest{Id: &core.Identifier{Project: testProject, Domain: testDomain}}},
+		{"ListWorkflows", &admin.ResourceListRequest{
+			Id: &admin.NamedEntityIdentifier{Project: testProject, Domain: testDomain}}},
+		{"ListLaunchPlanIds", &admin.NamedEntityIdentifierListRequest{Project: testProject, Domain: testDomain}},
+		{"TerminateExecution", &admin.ExecutionTerminateRequest{Id: executionID}},
+		{"GetNodeExecution", &admin.NodeExecutionGetRequest{Id: nodeExecutionID}},
+		{"ListNodeExecutions", &admin.NodeExecutionListRequest{WorkflowExecutionId: executionID}},
+		{"ListTaskExecutions", &admin.TaskExecutionListRequest{NodeExecutionId: nodeExecutionID}},
+		{"GetTaskExecution", &admin.TaskExecutionGetRequest{
+			Id: &core.TaskExecutionIdentifier{NodeExecutionId: nodeExecutionID}}},
+		{"CreateNodeEvent", &admin.NodeExecutionEventRequest{Event: &event.NodeExecutionEvent{Id: nodeExecutionID}}},
+		{"CreateTaskEvent", &admin.TaskExecutionEventRequest{
+			Event: &event.TaskExecutionEvent{ParentNodeExecutionId: nodeExecutionID}}},
+		{"UpdateWorkflowAttributes", &admin.WorkflowAttributesUpdateRequest{
+			Attributes: &admin.WorkflowAttributes{Project: testProject, Domain: testDomain, Workflow: "wf"}}},
+		{"UpdateNamedEntity", &admin.NamedEntityUpdateRequest{
+			Id: &admin.NamedEntityIdentifier{Project: testProject, Domain: testDomain}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			assert.Equal(t, testScope, ResourceScopeFromRequest(adminServicePrefix+test.method, test.req))
+		})
+	}
+
+	t.Run("unscoped methods", func(t *testing.T) {
+		assert.True(t, ResourceScopeFromRequest(adminServicePrefix+"ListProjects", &admin.ProjectListRequest{}).IsGlobal())
+		assert.True(t, ResourceScopeFromRequest(adminServicePrefix+"ListMatchableAttributes",
+			&admin.ListMatchableAttributesRequest{}).IsGlobal())
+	})
+}
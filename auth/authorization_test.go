@@ -0,0 +1,473 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/auth/interfaces/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func newTestIdentity(userID string, groups ...string) IdentityContext {
+	return NewIdentityContext("", userID, "", time.Now(), sets.NewString(ScopeAll), nil).
+		WithGroups(sets.NewString(groups...))
+}
+
+// Returns an authentication context allowing the anonymous methods given, such as the configured ones.
+func newTestAuthContext(t *testing.T, anonymousMethods ...string) interfaces.AuthenticationContext {
+	matcher, err := NewAnonymousMethodMatcher(anonymousMethods)
+	assert.NoError(t, err)
+	return Context{anonymousMethods: matcher}
+}
+
+func TestRoleBasedPolicy_IsAuthorized(t *testing.T) {
+	ctx := context.Background()
+	policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{
+		RoleBindings: []config.RoleBinding{
+			{Role: config.RoleAdmin, Groups: []string{"platform"}},
+			{Role: config.RoleContributor, Groups: []string{"ml"}, Project: testProject},
+			{Role: config.RoleAdmin, Subjects: []string{"alice"}, Project: testProject, Domain: testDomain},
+			{Role: config.RoleViewer, Groups: []string{"auditors"}},
+		},
+		MethodRoles: map[string]string{"GetExecutionData": config.RoleContributor},
+	})
+	assert.NoError(t, err)
+
+	otherDomain := interfaces.ResourceScope{Project: testProject, Domain: "production"}
+	otherProject := interfaces.ResourceScope{Project: "other", Domain: testDomain}
+	projectScope := interfaces.ResourceScope{Project: testProject}
+	tests := []struct {
+		name       string
+		identity   IdentityContext
+		method     string
+		scope      interfaces.ResourceScope
+		authorized bool
+	}{
+		{"global admin", newTestIdentity("bob", "platform"), "RegisterProject", projectScope, true},
+		{"global admin unscoped", newTestIdentity("bob", "platform"), "ListMatchableAttributes",
+			interfaces.ResourceScope{}, true},
+		{"contributor creates", newTestIdentity("bob", "ml"), "CreateExecution", testScope, true},
+		{"contributor terminates", newTestIdentity("bob", "ml"), "TerminateExecution", otherDomain, true},
+		{"contributor in other project", newTestIdentity("bob", "ml"), "TerminateExecution", otherProject, false},
+		{"contributor updates attributes", newTestIdentity("bob", "ml"), "UpdateProjectDomainAttributes", testScope,
+			false},
+		{"project binding unscoped", newTestIdentity("bob", "ml"), "ListMatchableAttributes",
+			interfaces.ResourceScope{}, false},
+		{"contributor sets checkpoints", newTestIdentity("bob", "ml"), "UpdateScheduleCheckpoint", testScope, false},
+		{"domain admin by subject", newTestIdentity("alice"), "UpdateProjectDomainAttributes", testScope, true},
+		{"domain admin in other domain", newTestIdentity("alice"), "GetExecution", otherDomain, false},
+		{"domain admin on project", newTestIdentity("alice"), "UpdateProject", projectScope, false},
+		{"domain admin sets checkpoints", newTestIdentity("alice"), "UpdateScheduleCheckpoint", testScope, true},
+		{"contributor sets scoped attributes", newTestIdentity("bob", "ml"), "UpdateScopedAttributes", testScope,
+			false},
+		{"domain admin purges executions", newTestIdentity("alice"), "PurgeExecutions", testScope, true},
+		{"contributor purges executions", newTestIdentity("bob", "ml"), "PurgeExecutions", testScope, false},
+		{"contributor sets quotas", newTestIdentity("bob", "ml"), "UpdateActiveExecutionQuota", testScope, false},
+		{"domain admin deletes quotas", newTestIdentity("alice"), "DeleteActiveExecutionQuota", testScope, true},
+		{"viewer reads", newTestIdentity("bob", "auditors"), "ListExecutions", testScope, true},
+		{"viewer terminates", newTestIdentity("bob", "auditors"), "TerminateExecution", testScope, false},
+		{"viewer watches", newTestIdentity("bob", "auditors"), "WatchExecution", testScope, true},
+		{"viewer counts", newTestIdentity("bob", "auditors"), "CountExecutions", testScope, true},
+		{"viewer searches", newTestIdentity("bob", "auditors"), "SearchNamedEntities", testScope, true},
+		{"method role override", newTestIdentity("bob", "auditors"), "GetExecutionData", testScope, false},
+		{"no role", newTestIdentity("bob"), "GetExecution", testScope, false},
+		{"no role lists projects", newTestIdentity("bob"), "ListProjects", interfaces.ResourceScope{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorized, err := policy.IsAuthorized(ctx, test.identity, adminServicePrefix+test.method, test.scope)
+			assert.NoError(t, err)
+			assert.Equal(t, test.authorized, authorized)
+		})
+	}
+
+	t.Run("default role", func(t *testing.T) {
+		policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{DefaultRole: config.RoleViewer})
+		assert.NoError(t, err)
+		authorized, err := policy.IsAuthorized(ctx, newTestIdentity("bob"), adminServicePrefix+"GetExecution", testScope)
+		assert.NoError(t, err)
+		assert.True(t, authorized)
+		authorized, err = policy.IsAuthorized(ctx, newTestIdentity("bob"), adminServicePrefix+"CreateExecution",
+			testScope)
+		assert.NoError(t, err)
+		assert.False(t, authorized)
+	})
+}
+
+func TestRoleBasedPolicy_AuthorizedProjects(t *testing.T) {
+	ctx := context.Background()
+	policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{
+		RoleBindings: []config.RoleBinding{
+			{Role: config.RoleAdmin, Groups: []string{"platform"}},
+			{Role: config.RoleViewer, Groups: []string{"ml"}, Project: testProject},
+			{Role: config.RoleContributor, Groups: []string{"ml"}, Project: "other"},
+			{Role: config.RoleViewer, Groups: []string{"ml"}, Project: "restricted", Domain: testDomain},
+		},
+	})
+	assert.NoError(t, err)
+	searchMethod := adminServicePrefix + "SearchNamedEntities"
+
+	projects, all, err := policy.(interfaces.ProjectAuthorizationPolicy).AuthorizedProjects(ctx,
+		newTestIdentity("bob", "platform"), searchMethod)
+	assert.NoError(t, err)
+	assert.True(t, all)
+	assert.Empty(t, projects)
+
+	// Bindings restricted to a domain don't grant the whole project.
+	projects, all, err = policy.(interfaces.ProjectAuthorizationPolicy).AuthorizedProjects(ctx,
+		newTestIdentity("bob", "ml"), searchMethod)
+	assert.NoError(t, err)
+	assert.False(t, all)
+	assert.Equal(t, []string{testProject, "other"}, projects)
+
+	projects, all, err = policy.(interfaces.ProjectAuthorizationPolicy).AuthorizedProjects(ctx,
+		newTestIdentity("bob", "ml"), adminServicePrefix+"CreateExecution")
+	assert.NoError(t, err)
+	assert.False(t, all)
+	assert.Equal(t, []string{"other"}, projects)
+
+	projects, all, err = policy.(interfaces.ProjectAuthorizationPolicy).AuthorizedProjects(ctx,
+		newTestIdentity("bob"), searchMethod)
+	assert.NoError(t, err)
+	assert.False(t, all)
+	assert.Empty(t, projects)
+
+	t.Run("default role", func(t *testing.T) {
+		policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{DefaultRole: config.RoleViewer})
+		assert.NoError(t, err)
+		_, all, err := policy.(interfaces.ProjectAuthorizationPolicy).AuthorizedProjects(ctx, newTestIdentity("bob"),
+			searchMethod)
+		assert.NoError(t, err)
+		assert.True(t, all)
+	})
+}
+
+func TestNewRoleBasedAuthorizationPolicy_InvalidConfig(t *testing.T) {
+	_, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{
+		RoleBindings: []config.RoleBinding{{Role: "owner", Groups: []string{"platform"}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestGetAuthorizationPolicy(t *testing.T) {
+	policy, err := GetAuthorizationPolicy(config.AuthorizationConfig{})
+	assert.NoError(t, err)
+	assert.IsType(t, roleBasedPolicy{}, policy)
+
+	custom := &mocks.AuthorizationPolicy{}
+	RegisterAuthorizationPolicy(custom)
+	defer RegisterAuthorizationPolicy(nil)
+	policy, err = GetAuthorizationPolicy(config.AuthorizationConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, custom, policy)
+}
+
+func TestGetAuthorizationInterceptor(t *testing.T) {
+	authCtx := newTestAuthContext(t, adminServicePrefix+"Get*")
+	identity := newTestIdentity("bob", "ml")
+	ctx := identity.WithContext(context.Background())
+	req := &admin.ExecutionCreateRequest{Project: testProject, Domain: testDomain}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: adminServicePrefix + "CreateExecution"}
+
+	t.Run("authorized", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, info.FullMethod, testScope).Return(true, nil)
+		resp, err := GetAuthorizationInterceptor(authCtx, policy)(ctx, req, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "response", resp)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, info.FullMethod, testScope).Return(false, nil)
+		_, err := GetAuthorizationInterceptor(authCtx, policy)(ctx, req, info, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("policy error", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, info.FullMethod, testScope).Return(false,
+			fmt.Errorf("policy unavailable"))
+		_, err := GetAuthorizationInterceptor(authCtx, policy)(ctx, req, info, handler)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		_, err := GetAuthorizationInterceptor(authCtx, policy)(context.Background(), req, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("anonymous method", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		resp, err := GetAuthorizationInterceptor(authCtx, policy)(context.Background(), &admin.GetVersionRequest{},
+			&grpc.UnaryServerInfo{FullMethod: adminServicePrefix + "GetVersion"}, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "response", resp)
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("anonymous unrestricted", func(t *testing.T) {
+		// Methods any authenticated caller can call still require an identity unless they're allowed anonymously.
+		policy := &mocks.AuthorizationPolicy{}
+		_, err := GetAuthorizationInterceptor(authCtx, policy)(context.Background(), &admin.ProjectListRequest{},
+			&grpc.UnaryServerInfo{FullMethod: adminServicePrefix + "ListProjects"}, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("other service", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		resp, err := GetAuthorizationInterceptor(authCtx, policy)(ctx, req, &grpc.UnaryServerInfo{
+			FullMethod: "/flyteidl.service.IdentityService/UserInfo",
+		}, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "response", resp)
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req proto.Message
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestGetAuthorizationStreamInterceptor(t *testing.T) {
+	authCtx := newTestAuthContext(t, adminServicePrefix+"Get*")
+	identity := newTestIdentity("bob", "ml")
+	ctx := identity.WithContext(context.Background())
+	req := &admin.WorkflowExecutionGetRequest{Id: &core.WorkflowExecutionIdentifier{
+		Project: testProject,
+		Domain:  testDomain,
+		Name:    "name",
+	}}
+	handlerCalls := 0
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		handlerCalls++
+		for i := 0; i < 2; i++ {
+			var received admin.WorkflowExecutionGetRequest
+			if err := stream.RecvMsg(&received); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: adminServicePrefix + "WatchExecution", IsServerStream: true}
+
+	t.Run("authorized", func(t *testing.T) {
+		handlerCalls = 0
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, info.FullMethod, testScope).Return(true, nil)
+		err := GetAuthorizationStreamInterceptor(authCtx, policy)(nil, &testServerStream{ctx: ctx, req: req}, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, handlerCalls)
+		// Only the first request is authorized.
+		policy.AssertNumberOfCalls(t, "IsAuthorized", 1)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, info.FullMethod, testScope).Return(false, nil)
+		err := GetAuthorizationStreamInterceptor(authCtx, policy)(nil, &testServerStream{ctx: ctx, req: req}, info, handler)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		err := GetAuthorizationStreamInterceptor(authCtx, policy)(nil,
+			&testServerStream{ctx: context.Background(), req: req}, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("other service", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		err := GetAuthorizationStreamInterceptor(authCtx, policy)(nil, &testServerStream{ctx: ctx, req: req},
+			&grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}, handler)
+		assert.NoError(t, err)
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+}
+
+type testAuthorizedHTTPHandler struct {
+	http.Handler
+	method string
+}
+
+func (h testAuthorizedHTTPHandler) AuthorizationMethod(request *http.Request) (string, interfaces.ResourceScope) {
+	return h.method, interfaces.ResourceScope{
+		Project: request.URL.Query().Get("project"),
+		Domain:  request.URL.Query().Get("domain"),
+	}
+}
+
+func TestGetHTTPAuthorizationHandler(t *testing.T) {
+	authCtx := newTestAuthContext(t, adminServicePrefix+"Get*")
+	identity := newTestIdentity("bob", "ml")
+	handler := testAuthorizedHTTPHandler{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		method: "UpdateScheduleCheckpoint",
+	}
+	fullMethod := adminServicePrefix + "UpdateScheduleCheckpoint"
+	newRequest := func(ctx context.Context) *http.Request {
+		request := httptest.NewRequest(http.MethodPost,
+			fmt.Sprintf("/api/v1/schedule_checkpoints?project=%s&domain=%s", testProject, testDomain), nil)
+		return request.WithContext(ctx)
+	}
+
+	t.Run("authorized", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, fullMethod, testScope).Return(true, nil)
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w, newRequest(identity.WithContext(context.Background())))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, fullMethod, testScope).Return(false, nil)
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w, newRequest(identity.WithContext(context.Background())))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("policy error", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, fullMethod, testScope).Return(false,
+			fmt.Errorf("policy unavailable"))
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w, newRequest(identity.WithContext(context.Background())))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w, newRequest(context.Background()))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("anonymous method", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		anonymous := handler
+		anonymous.method = "GetScheduleCheckpoint"
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, anonymous).ServeHTTP(w, newRequest(context.Background()))
+		assert.Equal(t, http.StatusOK, w.Code)
+		policy.AssertNotCalled(t, "IsAuthorized")
+	})
+
+	t.Run("role based", func(t *testing.T) {
+		policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{DefaultRole: config.RoleContributor})
+		assert.NoError(t, err)
+		w := httptest.NewRecorder()
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w, newRequest(identity.WithContext(context.Background())))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+type testProjectLimitedHTTPHandler struct {
+	testAuthorizedHTTPHandler
+}
+
+func (h testProjectLimitedHTTPHandler) LimitsToAuthorizedProjects() bool {
+	return true
+}
+
+func TestGetHTTPAuthorizationHandler_ProjectLimited(t *testing.T) {
+	authCtx := newTestAuthContext(t)
+	policy, err := NewRoleBasedAuthorizationPolicy(config.AuthorizationConfig{
+		RoleBindings: []config.RoleBinding{
+			{Role: config.RoleViewer, Groups: []string{"platform"}},
+			{Role: config.RoleViewer, Groups: []string{"ml"}, Project: testProject},
+		},
+	})
+	assert.NoError(t, err)
+	var servedProjects []string
+	var limited bool
+	handler := testProjectLimitedHTTPHandler{testAuthorizedHTTPHandler{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			servedProjects, limited = AuthorizedProjectsFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}),
+		method: "SearchNamedEntities",
+	}}
+	serve := func(identity IdentityContext, target string) int {
+		servedProjects, limited = nil, false
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, target, nil)
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w,
+			request.WithContext(identity.WithContext(context.Background())))
+		return w.Code
+	}
+
+	t.Run("every project", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(newTestIdentity("bob", "platform"), "/api/v1/named_entities/search"))
+		assert.False(t, limited)
+	})
+
+	t.Run("some projects", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(newTestIdentity("bob", "ml"), "/api/v1/named_entities/search"))
+		assert.True(t, limited)
+		assert.Equal(t, []string{testProject}, servedProjects)
+	})
+
+	t.Run("no project", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(newTestIdentity("bob"), "/api/v1/named_entities/search"))
+	})
+
+	t.Run("scoped to a project", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(newTestIdentity("bob", "ml"),
+			fmt.Sprintf("/api/v1/named_entities/search?project=%s&domain=%s", testProject, testDomain)))
+		assert.False(t, limited)
+		assert.Equal(t, http.StatusForbidden, serve(newTestIdentity("bob", "ml"),
+			fmt.Sprintf("/api/v1/named_entities/search?project=other&domain=%s", testDomain)))
+	})
+
+	t.Run("policy without projects", func(t *testing.T) {
+		policy := &mocks.AuthorizationPolicy{}
+		identity := newTestIdentity("bob", "ml")
+		policy.OnIsAuthorizedMatch(mock.Anything, identity, adminServicePrefix+"SearchNamedEntities",
+			interfaces.ResourceScope{}).Return(true, nil)
+		w := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/named_entities/search", nil)
+		GetHTTPAuthorizationHandler(authCtx, policy, handler).ServeHTTP(w,
+			request.WithContext(identity.WithContext(context.Background())))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, limited)
+	})
+}
@@ -0,0 +1,142 @@
+package authzserver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// Bounds how often tokens signed with unknown keys can trigger fetching the key set outside of the background refresh.
+const minJwksRefreshInterval = 10 * time.Second
+
+// cachedKeySet verifies token signatures against a cached copy of an issuer's JSON Web Key Set. The keys are refreshed
+// periodically in the background, and on demand when a token is signed with a key that isn't cached yet (e.g. right
+// after the issuer rotated its keys).
+type cachedKeySet struct {
+	jwksURL string
+
+	lock        sync.RWMutex
+	keys        jose.JSONWebKeySet
+	lastFetched time.Time
+}
+
+func (k *cachedKeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequest(http.MethodGet, k.jwksURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := doRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	keys := jose.JSONWebKeySet{}
+	if err = unmarshalResp(resp, body, &keys); err != nil {
+		return fmt.Errorf("failed to decode key set: %v", err)
+	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.keys = keys
+	k.lastFetched = time.Now()
+	return nil
+}
+
+func (k *cachedKeySet) keysFor(keyID string) []jose.JSONWebKey {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	if len(keyID) == 0 {
+		return k.keys.Keys
+	}
+
+	return k.keys.Key(keyID)
+}
+
+func (k *cachedKeySet) isStale() bool {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return time.Since(k.lastFetched) > minJwksRefreshInterval
+}
+
+// VerifySignature implements oidc.KeySet.
+func (k *cachedKeySet) VerifySignature(ctx context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt: %v", err)
+	}
+
+	keyID := ""
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	keys := k.keysFor(keyID)
+	if len(keys) == 0 && k.isStale() {
+		if err = k.refresh(ctx); err != nil {
+			return nil, fmt.Errorf("failed to fetch keys from [%v]: %v", k.jwksURL, err)
+		}
+
+		keys = k.keysFor(keyID)
+	}
+
+	for i := range keys {
+		if payload, err := jws.Verify(&keys[i]); err == nil {
+			return payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to verify token signature using keys from [%v]", k.jwksURL)
+}
+
+func (k *cachedKeySet) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := k.refresh(ctx); err != nil {
+					logger.Warnf(ctx, "Failed to refresh keys from [%v]. Error: %v", k.jwksURL, err)
+				}
+			}
+		}
+	}()
+}
+
+// newCachedKeySet fetches the key set at jwksURL and keeps refreshing it every refreshInterval until ctx is done. A
+// failure to fetch the keys initially isn't fatal, they're fetched again when the first token needs to be verified.
+func newCachedKeySet(ctx context.Context, jwksURL string, refreshInterval time.Duration) *cachedKeySet {
+	keySet := &cachedKeySet{
+		jwksURL: jwksURL,
+	}
+
+	if err := keySet.refresh(ctx); err != nil {
+		logger.Warnf(ctx, "Failed to fetch keys from [%v]. Will retry on demand. Error: %v", jwksURL, err)
+	}
+
+	if refreshInterval > 0 {
+		keySet.start(ctx, refreshInterval)
+	}
+
+	return keySet
+}
@@ -12,15 +12,22 @@ import (
 	authConfig "github.com/flyteorg/flyteadmin/auth/config"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OAuth2MetadataProvider struct {
-	cfg *authConfig.Config
+	cfg              *authConfig.Config
+	anonymousMethods *auth.AnonymousMethodMatcher
 }
 
 // Override auth func to enforce anonymous access on the implemented APIs
 // Ref: https://github.com/grpc-ecosystem/go-grpc-middleware/blob/master/auth/auth.go#L31
 func (s OAuth2MetadataProvider) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	if !s.anonymousMethods.Matches(fullMethodName) {
+		return ctx, status.Errorf(codes.Unauthenticated, "method [%s] does not allow anonymous access", fullMethodName)
+	}
+
 	return ctx, nil
 }
 
@@ -93,8 +100,9 @@ func (s OAuth2MetadataProvider) GetPublicClientConfig(context.Context, *service.
 	}, nil
 }
 
-func NewService(config *authConfig.Config) OAuth2MetadataProvider {
+func NewService(config *authConfig.Config, anonymousMethods *auth.AnonymousMethodMatcher) OAuth2MetadataProvider {
 	return OAuth2MetadataProvider{
-		cfg: config,
+		cfg:              config,
+		anonymousMethods: anonymousMethods,
 	}
 }
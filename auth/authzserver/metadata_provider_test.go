@@ -10,10 +10,13 @@ import (
 
 	config2 "github.com/flyteorg/flytestdlib/config"
 
+	"github.com/flyteorg/flyteadmin/auth"
 	"github.com/flyteorg/flyteadmin/auth/config"
 	authConfig "github.com/flyteorg/flyteadmin/auth/config"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestOAuth2MetadataProvider_FlyteClient(t *testing.T) {
@@ -27,7 +30,7 @@ func TestOAuth2MetadataProvider_FlyteClient(t *testing.T) {
 				},
 			},
 		},
-	})
+	}, nil)
 
 	ctx := context.Background()
 	resp, err := provider.GetPublicClientConfig(ctx, &service.PublicClientAuthConfigRequest{})
@@ -41,7 +44,7 @@ func TestOAuth2MetadataProvider_OAuth2Metadata(t *testing.T) {
 	t.Run("Self AuthServer", func(t *testing.T) {
 		provider := NewService(&authConfig.Config{
 			AuthorizedURIs: []config2.URL{{URL: *config.MustParseURL("https://issuer/")}},
-		})
+		}, nil)
 
 		ctx := context.Background()
 		resp, err := provider.GetOAuth2Metadata(ctx, &service.OAuth2MetadataRequest{})
@@ -82,7 +85,7 @@ func TestOAuth2MetadataProvider_OAuth2Metadata(t *testing.T) {
 					BaseURL: config2.URL{URL: *config.MustParseURL(s.URL)},
 				},
 			},
-		})
+		}, nil)
 
 		ctx := context.Background()
 		resp, err := provider.GetOAuth2Metadata(ctx, &service.OAuth2MetadataRequest{})
@@ -101,7 +104,7 @@ func TestOAuth2MetadataProvider_OAuth2Metadata(t *testing.T) {
 					BaseURL: config2.URL{URL: *config.MustParseURL(s.URL)},
 				},
 			},
-		})
+		}, nil)
 
 		ctx := context.Background()
 		resp, err := provider.GetOAuth2Metadata(ctx, &service.OAuth2MetadataRequest{})
@@ -109,3 +112,16 @@ func TestOAuth2MetadataProvider_OAuth2Metadata(t *testing.T) {
 		assert.Equal(t, "https://dev-14186422.okta.com", resp.Issuer)
 	})
 }
+
+func TestOAuth2MetadataProvider_AuthFuncOverride(t *testing.T) {
+	anonymousMethods, err := auth.NewAnonymousMethodMatcher(nil)
+	assert.NoError(t, err)
+	provider := NewService(&authConfig.Config{}, anonymousMethods)
+
+	ctx := context.Background()
+	_, err = provider.AuthFuncOverride(ctx, "/flyteidl.service.AuthMetadataService/GetOAuth2Metadata")
+	assert.NoError(t, err)
+
+	_, err = provider.AuthFuncOverride(ctx, "/flyteidl.service.AdminService/CreateExecution")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -40,6 +40,7 @@ const (
 	UserIDClaim   = "user_info"
 	ScopeClaim    = "scp"
 	KeyIDClaim    = "key_id"
+	GroupsClaim   = "groups"
 )
 
 // Provider implements OAuth2 Authorization Server.
@@ -128,10 +129,12 @@ func (p Provider) ValidateAccessToken(ctx context.Context, expectedAudience, tok
 	}
 
 	claimsRaw := parsedToken.Claims.(jwtgo.MapClaims)
-	return verifyClaims(sets.NewString(expectedAudience), claimsRaw)
+	return verifyClaims(sets.NewString(expectedAudience), GroupsClaim, claimsRaw)
 }
 
-func verifyClaims(expectedAudience sets.String, claimsRaw map[string]interface{}) (interfaces.IdentityContext, error) {
+// Builds the identity of the caller from the claims of a verified access token. The caller's groups are read from the
+// groupsClaim, if set, so that role bindings apply to access tokens the same way they do to ID tokens.
+func verifyClaims(expectedAudience sets.String, groupsClaim string, claimsRaw map[string]interface{}) (interfaces.IdentityContext, error) {
 	claims := jwtx.ParseMapStringInterfaceClaims(claimsRaw)
 	if len(claims.Audience) != 1 {
 		return nil, fmt.Errorf("expected exactly one granted audience. found [%v]", len(claims.Audience))
@@ -170,7 +173,13 @@ func verifyClaims(expectedAudience sets.String, claimsRaw map[string]interface{}
 		scopes.Insert(auth.ScopeAll)
 	}
 
-	return auth.NewIdentityContext(claims.Audience[0], claims.Subject, clientID, claims.IssuedAt, scopes, userInfo), nil
+	var groups []string
+	if len(groupsClaim) > 0 {
+		groups = auth.StringsFromClaim(claimsRaw, groupsClaim)
+	}
+
+	return auth.NewIdentityContext(claims.Audience[0], claims.Subject, clientID, claims.IssuedAt, scopes, userInfo).
+		WithGroups(sets.NewString(groups...)), nil
 }
 
 // NewProvider creates a new OAuth2 Provider that is able to do OAuth 2-legged and 3-legged flows. It'll lookup
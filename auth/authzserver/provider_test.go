@@ -216,12 +216,12 @@ func TestProvider_ValidateAccessToken(t *testing.T) {
 
 func Test_verifyClaims(t *testing.T) {
 	t.Run("Empty claims, fail", func(t *testing.T) {
-		_, err := verifyClaims(sets.NewString("https://myserver"), map[string]interface{}{})
+		_, err := verifyClaims(sets.NewString("https://myserver"), GroupsClaim, map[string]interface{}{})
 		assert.Error(t, err)
 	})
 
 	t.Run("All filled", func(t *testing.T) {
-		identityCtx, err := verifyClaims(sets.NewString("https://myserver"), map[string]interface{}{
+		identityCtx, err := verifyClaims(sets.NewString("https://myserver"), GroupsClaim, map[string]interface{}{
 			"aud": []string{"https://myserver"},
 			"user_info": map[string]interface{}{
 				"preferred_name": "John Doe",
@@ -236,4 +236,18 @@ func Test_verifyClaims(t *testing.T) {
 		assert.Equal(t, "my-client", identityCtx.AppID())
 		assert.Equal(t, "123", identityCtx.UserID())
 	})
+	t.Run("Groups", func(t *testing.T) {
+		claims := map[string]interface{}{
+			"aud":    []string{"https://myserver"},
+			"sub":    "123",
+			"groups": []interface{}{"admins", "viewers"},
+		}
+		identityCtx, err := verifyClaims(sets.NewString("https://myserver"), GroupsClaim, claims)
+		assert.NoError(t, err)
+		assert.Equal(t, sets.NewString("admins", "viewers"), identityCtx.Groups())
+
+		identityCtx, err = verifyClaims(sets.NewString("https://myserver"), "", claims)
+		assert.NoError(t, err)
+		assert.Empty(t, identityCtx.Groups())
+	})
 }
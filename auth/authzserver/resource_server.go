@@ -19,16 +19,52 @@ import (
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2"
 )
 
-// ResourceServer authorizes access requests issued by an external Authorization Server.
-type ResourceServer struct {
+type trustedIssuer struct {
 	signatureVerifier oidc.KeySet
 	allowedAudience   []string
+	groupsClaim       string
+}
+
+// ResourceServer authorizes access requests issued by one or more external Authorization Servers.
+type ResourceServer struct {
+	// Trusted issuers keyed by the iss claim of the tokens they issue.
+	issuers map[string]trustedIssuer
+}
+
+// Reads the iss claim of the token without verifying it so that the token can be verified with the issuer's keys.
+func unverifiedIssuer(tokenStr string) (string, error) {
+	jws, err := jose.ParseSigned(tokenStr)
+	if err != nil {
+		return "", err
+	}
+
+	claims := struct {
+		Issuer string `json:"iss"`
+	}{}
+	if err = json.Unmarshal(jws.UnsafePayloadWithoutVerification(), &claims); err != nil {
+		return "", err
+	}
+
+	return claims.Issuer, nil
 }
 
 func (r ResourceServer) ValidateAccessToken(ctx context.Context, expectedAudience, tokenStr string) (interfaces.IdentityContext, error) {
-	raw, err := r.signatureVerifier.VerifySignature(ctx, tokenStr)
+	issuer, err := unverifiedIssuer(tokenStr)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to read access token issuer: %v", err)
+	}
+
+	trusted, found := r.issuers[issuer]
+	if !found {
+		return nil, status.Errorf(codes.Unauthenticated, "access token issuer [%v] is not trusted", issuer)
+	}
+
+	raw, err := trusted.signatureVerifier.VerifySignature(ctx, tokenStr)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +74,7 @@ func (r ResourceServer) ValidateAccessToken(ctx context.Context, expectedAudienc
 		return nil, fmt.Errorf("failed to unmarshal user info claim into UserInfo type. Error: %w", err)
 	}
 
-	return verifyClaims(sets.NewString(append(r.allowedAudience, expectedAudience)...), claimsRaw)
+	return verifyClaims(sets.NewString(trusted.allowedAudience...).Insert(expectedAudience), trusted.groupsClaim, claimsRaw)
 }
 
 func doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
@@ -62,7 +98,7 @@ func unmarshalResp(r *http.Response, body []byte, v interface{}) error {
 	return fmt.Errorf("expected Content-Type = application/json, got %q: %v", ct, err)
 }
 
-func getJwksForIssuer(ctx context.Context, issuerBaseURL url.URL, customMetadataURL url.URL) (keySet oidc.KeySet, err error) {
+func getIssuerMetadata(ctx context.Context, issuerBaseURL url.URL, customMetadataURL url.URL) (*service.OAuth2MetadataResponse, error) {
 	issuerBaseURL.Path = strings.TrimSuffix(issuerBaseURL.Path, "/") + "/"
 	var wellKnown *url.URL
 	if len(customMetadataURL.String()) > 0 {
@@ -98,23 +134,67 @@ func getJwksForIssuer(ctx context.Context, issuerBaseURL url.URL, customMetadata
 		return nil, fmt.Errorf("failed to decode provider discovery object: %v", err)
 	}
 
-	return oidc.NewRemoteKeySet(ctx, p.JwksUri), nil
+	return p, nil
 }
 
-// NewOAuth2ResourceServer initializes a new OAuth2ResourceServer.
+// NewOAuth2ResourceServer initializes a new OAuth2ResourceServer. Access tokens are accepted from the configured
+// authorization server as well as from any of the additional issuers.
 func NewOAuth2ResourceServer(ctx context.Context, cfg authConfig.ExternalAuthorizationServer, fallbackBaseURL config.URL) (ResourceServer, error) {
+	if err := cfg.Validate(); err != nil {
+		return ResourceServer{}, err
+	}
+
 	u := cfg.BaseURL
 	if len(u.String()) == 0 {
 		u = fallbackBaseURL
 	}
 
-	verifier, err := getJwksForIssuer(ctx, u.URL, cfg.MetadataEndpointURL.URL)
+	metadata, err := getIssuerMetadata(ctx, u.URL, cfg.MetadataEndpointURL.URL)
 	if err != nil {
 		return ResourceServer{}, err
 	}
 
+	issuer := metadata.Issuer
+	if len(issuer) == 0 {
+		issuer = u.String()
+	}
+
+	issuers := map[string]trustedIssuer{
+		issuer: {
+			signatureVerifier: newCachedKeySet(ctx, metadata.JwksUri, cfg.JwksRefreshInterval.Duration),
+			allowedAudience:   cfg.AllowedAudience,
+			groupsClaim:       cfg.GroupsClaim,
+		},
+	}
+
+	for _, additional := range cfg.AdditionalIssuers {
+		if _, found := issuers[additional.Issuer]; found {
+			return ResourceServer{}, fmt.Errorf("additional issuer [%v] duplicates the issuer of [%v]", additional.Issuer, u.String())
+		}
+
+		jwksURL := additional.JwksURL.String()
+		if len(jwksURL) == 0 {
+			issuerURL, err := url.Parse(additional.Issuer)
+			if err != nil {
+				return ResourceServer{}, fmt.Errorf("invalid issuer [%v]: %w", additional.Issuer, err)
+			}
+
+			additionalMetadata, err := getIssuerMetadata(ctx, *issuerURL, url.URL{})
+			if err != nil {
+				return ResourceServer{}, err
+			}
+
+			jwksURL = additionalMetadata.JwksUri
+		}
+
+		issuers[additional.Issuer] = trustedIssuer{
+			signatureVerifier: newCachedKeySet(ctx, jwksURL, cfg.JwksRefreshInterval.Duration),
+			allowedAudience:   additional.AllowedAudience,
+			groupsClaim:       cfg.GroupsClaim,
+		}
+	}
+
 	return ResourceServer{
-		signatureVerifier: verifier,
-		allowedAudience:   cfg.AllowedAudience,
+		issuers: issuers,
 	}, nil
 }
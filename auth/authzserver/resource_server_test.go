@@ -2,23 +2,31 @@ package authzserver
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/lestrrat-go/jwx/jwk"
 
-	"github.com/coreos/go-oidc"
 	"github.com/flyteorg/flyteadmin/auth/config"
 	authConfig "github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 	stdlibConfig "github.com/flyteorg/flytestdlib/config"
+	jwtgo "github.com/golang-jwt/jwt/v4"
 )
 
 func newMockResourceServer(t testing.TB) ResourceServer {
@@ -114,7 +122,7 @@ func Test_doRequest(t *testing.T) {
 	}
 }
 
-func Test_getJwksForIssuer(t *testing.T) {
+func Test_getIssuerMetadata(t *testing.T) {
 	type args struct {
 		ctx           context.Context
 		issuerBaseURL url.URL
@@ -123,20 +131,20 @@ func Test_getJwksForIssuer(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    oidc.KeySet
+		want    *service.OAuth2MetadataResponse
 		wantErr bool
 	}{
 		// TODO: Add test cases.
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getJwksForIssuer(tt.args.ctx, tt.args.issuerBaseURL, tt.args.customMetaURL)
+			got, err := getIssuerMetadata(tt.args.ctx, tt.args.issuerBaseURL, tt.args.customMetaURL)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("getJwksForIssuer() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("getIssuerMetadata() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("getJwksForIssuer() got = %v, want %v", got, tt.want)
+				t.Errorf("getIssuerMetadata() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -163,3 +171,135 @@ func Test_unmarshalResp(t *testing.T) {
 		})
 	}
 }
+
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+// Starts an authorization server publishing its metadata and signing keys.
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	issuer := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		}))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		}))
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) newAccessToken(t *testing.T, issuer, audience string) string {
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, jwtgo.StandardClaims{
+		Issuer:    issuer,
+		Audience:  audience,
+		Subject:   "user",
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "key"
+	signed, err := token.SignedString(i.key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func TestResourceServer_MultipleIssuers(t *testing.T) {
+	ctx := context.Background()
+	okta := newTestIssuer(t)
+	auth0 := newTestIssuer(t)
+	auth0Discovered := newTestIssuer(t)
+	r, err := NewOAuth2ResourceServer(ctx, authConfig.ExternalAuthorizationServer{
+		BaseURL: stdlibConfig.URL{URL: *config.MustParseURL(okta.server.URL)},
+		AdditionalIssuers: []authConfig.ExternalIssuer{
+			{
+				Issuer:          "https://auth0.example.com/",
+				JwksURL:         stdlibConfig.URL{URL: *config.MustParseURL(auth0.server.URL + "/keys")},
+				AllowedAudience: []string{"flyteadmin"},
+			},
+			{
+				Issuer: auth0Discovered.server.URL,
+			},
+		},
+	}, stdlibConfig.URL{})
+	assert.NoError(t, err)
+
+	t.Run("primary issuer", func(t *testing.T) {
+		identity, err := r.ValidateAccessToken(ctx, "https://admin",
+			okta.newAccessToken(t, okta.server.URL, "https://admin"))
+		assert.NoError(t, err)
+		assert.Equal(t, "user", identity.UserID())
+	})
+
+	t.Run("additional issuer", func(t *testing.T) {
+		identity, err := r.ValidateAccessToken(ctx, "https://admin",
+			auth0.newAccessToken(t, "https://auth0.example.com/", "flyteadmin"))
+		assert.NoError(t, err)
+		assert.Equal(t, "user", identity.UserID())
+	})
+
+	t.Run("additional issuer with discovered keys", func(t *testing.T) {
+		_, err := r.ValidateAccessToken(ctx, "https://admin",
+			auth0Discovered.newAccessToken(t, auth0Discovered.server.URL, "https://admin"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("audience of another issuer", func(t *testing.T) {
+		_, err := r.ValidateAccessToken(ctx, "https://admin",
+			okta.newAccessToken(t, okta.server.URL, "flyteadmin"))
+		assert.Error(t, err)
+	})
+
+	t.Run("signed by another issuer", func(t *testing.T) {
+		_, err := r.ValidateAccessToken(ctx, "https://admin",
+			okta.newAccessToken(t, "https://auth0.example.com/", "flyteadmin"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown issuer", func(t *testing.T) {
+		_, err := r.ValidateAccessToken(ctx, "https://admin",
+			okta.newAccessToken(t, "https://unknown.example.com/", "https://admin"))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Contains(t, err.Error(), "https://unknown.example.com/")
+	})
+}
+
+func TestNewOAuth2ResourceServer_DuplicateIssuers(t *testing.T) {
+	okta := newTestIssuer(t)
+	_, err := NewOAuth2ResourceServer(context.Background(), authConfig.ExternalAuthorizationServer{
+		BaseURL: stdlibConfig.URL{URL: *config.MustParseURL(okta.server.URL)},
+		AdditionalIssuers: []authConfig.ExternalIssuer{
+			{Issuer: "https://auth0.example.com/"},
+			{Issuer: "https://auth0.example.com/"},
+		},
+	}, stdlibConfig.URL{})
+	assert.Error(t, err)
+
+	_, err = NewOAuth2ResourceServer(context.Background(), authConfig.ExternalAuthorizationServer{
+		BaseURL: stdlibConfig.URL{URL: *config.MustParseURL(okta.server.URL)},
+		AdditionalIssuers: []authConfig.ExternalIssuer{
+			{Issuer: okta.server.URL},
+		},
+	}, stdlibConfig.URL{})
+	assert.Error(t, err)
+}
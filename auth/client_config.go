@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/oauth2"
+)
+
+const ErrClientSecretReload errors.ErrorCode = "CLIENT_SECRET_RELOAD_FAILED"
+
+// Reasons the client config can be reloaded for, used to label the reloads metric.
+const (
+	clientConfigReloadInterval      = "interval"
+	clientConfigReloadFileChanged   = "file_changed"
+	clientConfigReloadInvalidClient = "invalid_client"
+)
+
+// Bounds how often token requests rejected by the IdP can trigger re-reading the client secret.
+const minClientConfigReloadInterval = 10 * time.Second
+
+// clientConfigProvider holds the OAuth2 client config and swaps it with a freshly read one when the client secret may
+// have been rotated.
+type clientConfigProvider struct {
+	options  config.OpenIDOptions
+	endpoint oauth2.Endpoint
+	sm       core.SecretManager
+
+	// Holds an *oauth2.Config. Loaded configs are never modified, only replaced.
+	current atomic.Value
+
+	// Serializes reloads.
+	lock              sync.Mutex
+	lastLoaded        time.Time
+	secretFileModTime time.Time
+
+	reloads        *prometheus.CounterVec
+	reloadFailures prometheus.Counter
+}
+
+func (p *clientConfigProvider) Get() *oauth2.Config {
+	return p.current.Load().(*oauth2.Config)
+}
+
+func (p *clientConfigProvider) statSecretFile() (time.Time, error) {
+	info, err := os.Stat(p.options.DeprecatedClientSecretFile)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// load must be called with the lock held.
+func (p *clientConfigProvider) load(ctx context.Context) error {
+	var modTime time.Time
+	if len(p.options.DeprecatedClientSecretFile) > 0 {
+		var err error
+		if modTime, err = p.statSecretFile(); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := GetOAuth2ClientConfig(ctx, p.options, p.endpoint, p.sm)
+	if err != nil {
+		return err
+	}
+
+	if previous, ok := p.current.Load().(*oauth2.Config); ok && previous.ClientSecret != cfg.ClientSecret {
+		logger.Infof(ctx, "Loaded a new OAuth2 client secret")
+	}
+
+	p.current.Store(&cfg)
+	p.lastLoaded = time.Now()
+	p.secretFileModTime = modTime
+	return nil
+}
+
+// Reload re-reads the client secret. Reloads triggered by rejected client credentials are skipped if the secret was
+// read recently, since concurrent requests failing with the old secret would otherwise all re-read it.
+func (p *clientConfigProvider) Reload(ctx context.Context, reason string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if reason == clientConfigReloadInvalidClient && time.Since(p.lastLoaded) < minClientConfigReloadInterval {
+		return nil
+	}
+
+	if err := p.load(ctx); err != nil {
+		p.reloadFailures.Inc()
+		return errors.Wrapf(ErrClientSecretReload, err, "failed to reload the OAuth2 client secret")
+	}
+
+	p.reloads.WithLabelValues(reason).Inc()
+	return nil
+}
+
+// reloadIfStale reloads the client secret on the refresh interval. The deprecated client secret file is only re-read
+// if it was modified.
+func (p *clientConfigProvider) reloadIfStale(ctx context.Context) error {
+	if len(p.options.DeprecatedClientSecretFile) == 0 {
+		return p.Reload(ctx, clientConfigReloadInterval)
+	}
+
+	modTime, err := p.statSecretFile()
+	if err != nil {
+		p.reloadFailures.Inc()
+		return errors.Wrapf(ErrClientSecretReload, err, "failed to stat the OAuth2 client secret file")
+	}
+
+	p.lock.Lock()
+	changed := !modTime.Equal(p.secretFileModTime)
+	p.lock.Unlock()
+	if !changed {
+		return nil
+	}
+
+	return p.Reload(ctx, clientConfigReloadFileChanged)
+}
+
+func (p *clientConfigProvider) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.reloadIfStale(ctx); err != nil {
+					logger.Warnf(ctx, "Failed to refresh the OAuth2 client secret. Will keep using the current one. Error: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// newClientConfigProvider reads the client secret and, if options set a refresh interval, keeps re-reading it until ctx
+// is done.
+func newClientConfigProvider(ctx context.Context, options config.OpenIDOptions, endpoint oauth2.Endpoint,
+	sm core.SecretManager, scope promutils.Scope) (*clientConfigProvider, error) {
+	provider := &clientConfigProvider{
+		options:  options,
+		endpoint: endpoint,
+		sm:       sm,
+		reloads: scope.MustNewCounterVec("client_secret_reloads",
+			"number of times the OAuth2 client secret was reloaded", "reason"),
+		reloadFailures: scope.MustNewCounter("client_secret_reload_failures",
+			"number of times the OAuth2 client secret failed to reload"),
+	}
+
+	provider.lock.Lock()
+	err := provider.load(ctx)
+	provider.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if options.ClientSecretRefreshInterval.Duration > 0 {
+		provider.start(ctx, options.ClientSecretRefreshInterval.Duration)
+	}
+
+	return provider, nil
+}
+
+// Returns true if err is a token endpoint response rejecting the client credentials.
+// See https://tools.ietf.org/html/rfc6749#section-5.2 for more information.
+func isInvalidClientError(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !stderrors.As(err, &retrieveErr) {
+		return false
+	}
+
+	resp := struct {
+		Error string `json:"error"`
+	}{}
+
+	if jsonErr := json.Unmarshal(retrieveErr.Body, &resp); jsonErr != nil {
+		// Some providers respond with form encoded errors.
+		values, parseErr := url.ParseQuery(string(retrieveErr.Body))
+		if parseErr == nil {
+			resp.Error = values.Get("error")
+		}
+	}
+
+	if resp.Error == "invalid_client" {
+		return true
+	}
+
+	return len(resp.Error) == 0 && retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusUnauthorized
+}
+
+// withClientConfigReload calls the token endpoint through fn with the current client config. If the IdP rejects the
+// client credentials, the client secret is reloaded and fn retried once, so that rotating the secret doesn't break
+// logins until the next restart.
+func withClientConfigReload(ctx context.Context, authCtx interfaces.AuthenticationContext, requestURL *url.URL,
+	fn func(cfg *oauth2.Config) (*oauth2.Token, error)) (*oauth2.Token, error) {
+
+	token, err := fn(authCtx.OAuth2ClientConfig(requestURL))
+	if err == nil || !isInvalidClientError(err) {
+		return token, err
+	}
+
+	logger.Infof(ctx, "The IdP rejected the client credentials, reloading the client secret. Error: %v", err)
+	if reloadErr := authCtx.ReloadOAuth2ClientConfig(ctx); reloadErr != nil {
+		logger.Errorf(ctx, "Failed to reload the client secret. Error: %v", reloadErr)
+		return nil, err
+	}
+
+	return fn(authCtx.OAuth2ClientConfig(requestURL))
+}
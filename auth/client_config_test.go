@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+type fakeSecretManager struct {
+	lock   sync.Mutex
+	secret string
+}
+
+func (f *fakeSecretManager) Get(ctx context.Context, key string) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.secret, nil
+}
+
+func (f *fakeSecretManager) rotate(secret string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.secret = secret
+}
+
+// Starts a token endpoint that only accepts the secret currently held by sm.
+func newTestTokenEndpoint(t *testing.T, sm *fakeSecretManager) oauth2.Endpoint {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.NoError(t, request.ParseForm())
+		current, _ := sm.Get(request.Context(), "")
+		writer.Header().Set("Content-Type", "application/json")
+		if request.PostForm.Get("client_secret") != current {
+			writer.WriteHeader(http.StatusUnauthorized)
+			_, _ = writer.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+
+		_, _ = writer.Write([]byte(`{"access_token":"at","token_type":"bearer"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return oauth2.Endpoint{
+		TokenURL:  server.URL + "/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+}
+
+func TestWithClientConfigReload_SecretRotation(t *testing.T) {
+	ctx := context.Background()
+	sm := &fakeSecretManager{secret: "old"}
+	provider, err := newClientConfigProvider(ctx, config.OpenIDOptions{ClientID: "client", ClientSecretName: "secret"},
+		newTestTokenEndpoint(t, sm), sm, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	authCtx := Context{oauth2Client: provider}
+	exchange := func(cfg *oauth2.Config) (*oauth2.Token, error) {
+		return cfg.Exchange(ctx, "code")
+	}
+
+	_, err = withClientConfigReload(ctx, authCtx, nil, exchange)
+	assert.NoError(t, err)
+
+	// Pretend the secret was loaded a while ago so the rotation below isn't throttled.
+	provider.lastLoaded = time.Now().Add(-minClientConfigReloadInterval)
+	sm.rotate("new")
+	token, err := withClientConfigReload(ctx, authCtx, nil, exchange)
+	assert.NoError(t, err)
+	assert.Equal(t, "at", token.AccessToken)
+	assert.Equal(t, "new", provider.Get().ClientSecret)
+	assert.Equal(t, float64(1), testutil.ToFloat64(provider.reloads.WithLabelValues(clientConfigReloadInvalidClient)))
+
+	t.Run("throttled", func(t *testing.T) {
+		sm.rotate("newer")
+		_, err := withClientConfigReload(ctx, authCtx, nil, exchange)
+		assert.True(t, isInvalidClientError(err))
+		assert.Equal(t, "new", provider.Get().ClientSecret)
+		assert.Equal(t, float64(1), testutil.ToFloat64(provider.reloads.WithLabelValues(clientConfigReloadInvalidClient)))
+	})
+}
+
+func TestClientConfigProvider_ReloadIfStale(t *testing.T) {
+	ctx := context.Background()
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	assert.NoError(t, ioutil.WriteFile(secretFile, []byte("old\n"), 0600))
+
+	provider, err := newClientConfigProvider(ctx, config.OpenIDOptions{DeprecatedClientSecretFile: secretFile},
+		oauth2.Endpoint{}, nil, promutils.NewTestScope())
+	assert.NoError(t, err)
+	assert.Equal(t, "old", provider.Get().ClientSecret)
+
+	assert.NoError(t, provider.reloadIfStale(ctx))
+	assert.Equal(t, float64(0), testutil.ToFloat64(provider.reloads.WithLabelValues(clientConfigReloadFileChanged)))
+
+	assert.NoError(t, ioutil.WriteFile(secretFile, []byte("new\n"), 0600))
+	modTime := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(secretFile, modTime, modTime))
+	assert.NoError(t, provider.reloadIfStale(ctx))
+	assert.Equal(t, "new", provider.Get().ClientSecret)
+	assert.Equal(t, float64(1), testutil.ToFloat64(provider.reloads.WithLabelValues(clientConfigReloadFileChanged)))
+
+	assert.NoError(t, os.Remove(secretFile))
+	assert.Error(t, provider.reloadIfStale(ctx))
+	assert.Equal(t, "new", provider.Get().ClientSecret)
+	assert.Equal(t, float64(1), testutil.ToFloat64(provider.reloadFailures))
+}
+
+func TestIsInvalidClientError(t *testing.T) {
+	newRetrieveError := func(status int, body string) error {
+		return &oauth2.RetrieveError{Response: &http.Response{StatusCode: status}, Body: []byte(body)}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"json", newRetrieveError(http.StatusBadRequest, `{"error":"invalid_client"}`), true},
+		{"form", newRetrieveError(http.StatusBadRequest, "error=invalid_client"), true},
+		{"unauthorized without error", newRetrieveError(http.StatusUnauthorized, ""), true},
+		{"other error", newRetrieveError(http.StatusBadRequest, `{"error":"invalid_grant"}`), false},
+		{"wrapped", errors.Wrapf(ErrRefreshingToken, newRetrieveError(http.StatusUnauthorized,
+			`{"error":"invalid_client"}`), "Error refreshing token"), true},
+		{"not a token endpoint error", fmt.Errorf("connection refused"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isInvalidClientError(test.err))
+		})
+	}
+}
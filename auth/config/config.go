@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ory/fosite"
@@ -33,6 +36,9 @@ const (
 	// This is used to support key rotation. When present, it'll only be used to validate incoming tokens. New tokens
 	// will not be issued using this key.
 	SecretNameOldTokenSigningRSAKey SecretName = "token_rsa_key_old.pem"
+	// #nosec
+	// JSON object mapping the hex encoded SHA-256 hashes of API keys to the principal each key was issued to.
+	SecretNameAPIKeys SecretName = "api_keys"
 )
 
 // AuthorizationServerType defines the type of Authorization Server to use.
@@ -67,6 +73,10 @@ var (
 				},
 			},
 		},
+		APIKeys: APIKeyOptions{
+			SecretName:      SecretNameAPIKeys,
+			RefreshInterval: config.Duration{Duration: time.Minute},
+		},
 		AppAuth: OAuth2Options{
 			AuthServerType: AuthorizationServerTypeSelf,
 			ThirdParty: ThirdPartyConfigOptions{
@@ -76,6 +86,9 @@ var (
 					Scopes:      []string{"all", "offline"},
 				},
 			},
+			ExternalAuthServer: ExternalAuthorizationServer{
+				JwksRefreshInterval: config.Duration{Duration: time.Hour},
+			},
 			SelfAuthServer: AuthorizationServer{
 				AccessTokenLifespan:                   config.Duration{Duration: 30 * time.Minute},
 				RefreshTokenLifespan:                  config.Duration{Duration: 60 * time.Minute},
@@ -134,6 +147,12 @@ type Config struct {
 	DisableForHTTP bool `json:"disableForHttp" pflag:",Disables auth enforcement on HTTP Endpoints."`
 	DisableForGrpc bool `json:"disableForGrpc" pflag:",Disables auth enforcement on Grpc Endpoints."`
 
+	// AllowedAnonymousMethods lists additional gRPC methods that can be called without authentication. Entries are
+	// fully qualified method names (e.g. /flyteidl.service.AdminService/GetVersion) and may use '*' to match any
+	// sequence of characters within the method name (e.g. /flyteidl.service.AdminService/Get*). These are merged with
+	// the built-in set of methods that are always served anonymously.
+	AllowedAnonymousMethods []string `json:"allowedAnonymousMethods" pflag:",Additional gRPC methods that can be called without authentication. Supports '*' wildcards."`
+
 	// AuthorizedURIs is optional and defines the set of URIs that clients are allowed to visit the service on. If set,
 	// the system will attempt to match the incoming host to the first authorized URIs and use that (including the scheme)
 	// when generating metadata endpoints and when validating audience and issuer claims. If no matching authorizedUri
@@ -146,6 +165,104 @@ type Config struct {
 
 	// AppAuth settings used to authenticate and control/limit access scopes for apps.
 	AppAuth OAuth2Options `json:"appAuth" pflag:",Defines Auth options for apps. UserAuth must be enabled for AppAuth to work."`
+
+	// Authorization settings used to decide which authenticated users and apps can call which admin service methods.
+	Authorization AuthorizationConfig `json:"authorization" pflag:",Defines role based authorization of gRPC requests."`
+
+	// APIKeys settings used to authenticate machine clients that can't go through an OAuth2 flow.
+	APIKeys APIKeyOptions `json:"apiKeys" pflag:",Defines pre-shared API keys machine clients can authenticate with."`
+}
+
+// APIKeyOptions defines how pre-shared API keys are loaded. Clients present a key as a bearer token, keys are checked
+// before access and id tokens.
+type APIKeyOptions struct {
+	// Enabled turns on authenticating with API keys.
+	Enabled bool `json:"enabled" pflag:",Enables authenticating machine clients with pre-shared API keys."`
+
+	// SecretName is the secret holding a JSON object that maps the hex encoded SHA-256 hash of each key to the
+	// principal it was issued to, e.g. {"<hash>": {"principal": "ci", "scopes": ["all"]}}. Keys are revoked by
+	// removing them or by setting "revoked": true.
+	SecretName string `json:"secretName" pflag:",Secret name to use to retrieve the hashed API keys."`
+
+	// RefreshInterval is how often the keys are re-read from the secret, which bounds how long revoked keys are
+	// still accepted.
+	RefreshInterval config.Duration `json:"refreshInterval" pflag:",How often API keys are re-read from the secret."`
+}
+
+// Roles that can be granted through role bindings, from least to most privileged. Each role includes the permissions of
+// the ones before it.
+const (
+	// RoleViewer can call the Get* and List* admin service methods.
+	RoleViewer = "viewer"
+	// RoleContributor can additionally register entities and create, relaunch and terminate executions.
+	RoleContributor = "contributor"
+	// RoleAdmin can additionally register and update projects and update matchable attributes.
+	RoleAdmin = "admin"
+)
+
+// AuthorizationConfig defines the roles granted to authenticated callers. Roles are granted on a project and domain,
+// callers without a binding on the project and domain targeted by a request are denied with PermissionDenied.
+type AuthorizationConfig struct {
+	// Enabled turns on authorization. When disabled, any authenticated caller can call any method.
+	Enabled bool `json:"enabled" pflag:",Enables role based authorization of gRPC requests."`
+
+	// DefaultRole is granted on all projects and domains to every authenticated caller.
+	DefaultRole string `json:"defaultRole" pflag:",OPTIONAL: Role granted to every authenticated caller. One of viewer, contributor or admin."`
+
+	// RoleBindings grant roles to users and apps, matched by the groups they belong to or by their subject.
+	RoleBindings []RoleBinding `json:"roleBindings" pflag:"-,Grants roles to groups and subjects."`
+
+	// MethodRoles overrides the role required to call admin service methods, keyed by the short method name (e.g.
+	// TerminateExecution).
+	MethodRoles map[string]string `json:"methodRoles" pflag:"-,OPTIONAL: Overrides the role required to call admin service methods."`
+}
+
+// RoleBinding grants a role to the listed groups and subjects, optionally restricted to a project and domain.
+type RoleBinding struct {
+	// Role is one of viewer, contributor or admin.
+	Role string `json:"role"`
+
+	// Groups as reported in the claim configured by userAuth.openId.groupsClaim.
+	Groups []string `json:"groups"`
+
+	// Subjects are user ids or app (client) ids.
+	Subjects []string `json:"subjects"`
+
+	// Project the role is granted on. Empty grants it on all projects.
+	Project string `json:"project"`
+
+	// Domain the role is granted on. Empty grants it on all domains of the project.
+	Domain string `json:"domain"`
+}
+
+func isKnownRole(role string) bool {
+	return role == RoleViewer || role == RoleContributor || role == RoleAdmin
+}
+
+// Validate checks that all roles are known and that bindings only restrict the domain of a given project.
+func (c AuthorizationConfig) Validate() error {
+	if len(c.DefaultRole) > 0 && !isKnownRole(c.DefaultRole) {
+		return fmt.Errorf("invalid defaultRole [%v], expected one of viewer, contributor or admin", c.DefaultRole)
+	}
+
+	for i, binding := range c.RoleBindings {
+		if !isKnownRole(binding.Role) {
+			return fmt.Errorf("invalid role [%v] in roleBindings[%v], expected one of viewer, contributor or admin",
+				binding.Role, i)
+		}
+
+		if len(binding.Project) == 0 && len(binding.Domain) > 0 {
+			return fmt.Errorf("roleBindings[%v] restricts the domain without a project", i)
+		}
+	}
+
+	for method, role := range c.MethodRoles {
+		if !isKnownRole(role) {
+			return fmt.Errorf("invalid role [%v] for method [%v], expected one of viewer, contributor or admin", role, method)
+		}
+	}
+
+	return nil
 }
 
 type AuthorizationServer struct {
@@ -176,6 +293,48 @@ type ExternalAuthorizationServer struct {
 	BaseURL             config.URL `json:"baseUrl" pflag:",This should be the base url of the authorization server that you are trying to hit. With Okta for instance, it will look something like https://company.okta.com/oauth2/abcdef123456789/"`
 	AllowedAudience     []string   `json:"allowedAudience" pflag:",Optional: A list of allowed audiences. If not provided, the audience is expected to be the public Uri of the service."`
 	MetadataEndpointURL config.URL `json:"metadataUrl" pflag:",Optional: If the server doesn't support /.well-known/oauth-authorization-server, you can set a custom metadata url here.'"`
+
+	// GroupsClaim names the access token claim that lists the groups of the caller, for role bindings to match against.
+	// It applies to the tokens of every trusted issuer. Groups aren't read from access tokens if it's empty.
+	GroupsClaim string `json:"groupsClaim" pflag:",Optional: The access token claim that lists the groups of the caller."`
+
+	// AdditionalIssuers lists other authorization servers whose access tokens are accepted alongside the one above (e.g.
+	// while migrating between IdPs). Tokens are matched to an issuer based on their iss claim.
+	AdditionalIssuers []ExternalIssuer `json:"additionalIssuers" pflag:"-,Optional: Other authorization servers whose access tokens are accepted."`
+
+	// JwksRefreshInterval defines how often the signing keys of each issuer are fetched in the background.
+	JwksRefreshInterval config.Duration `json:"jwksRefreshInterval" pflag:",Defines how often the signing keys of each issuer are refreshed."`
+}
+
+// ExternalIssuer defines an additional authorization server trusted to issue access tokens.
+type ExternalIssuer struct {
+	// Issuer must match the iss claim of the access tokens issued by this authorization server.
+	Issuer string `json:"issuer"`
+
+	// JwksURL is the location of the issuer's signing keys. If not provided, it's discovered through the issuer's
+	// /.well-known/oauth-authorization-server metadata.
+	JwksURL config.URL `json:"jwksUrl"`
+
+	// AllowedAudience is the list of audiences accepted in tokens from this issuer on top of the public Uri of the service.
+	AllowedAudience []string `json:"allowedAudience"`
+}
+
+// Validate checks that every additional issuer is named exactly once.
+func (c ExternalAuthorizationServer) Validate() error {
+	seen := make(map[string]bool, len(c.AdditionalIssuers))
+	for _, issuer := range c.AdditionalIssuers {
+		if len(issuer.Issuer) == 0 {
+			return fmt.Errorf("additional issuers must define an issuer")
+		}
+
+		if seen[issuer.Issuer] {
+			return fmt.Errorf("duplicate issuer [%v] in additional issuers", issuer.Issuer)
+		}
+
+		seen[issuer.Issuer] = true
+	}
+
+	return nil
 }
 
 // OAuth2Options defines settings for app auth.
@@ -200,6 +359,10 @@ type UserAuthConfig struct {
 	// See the login handler code for more comments.
 	RedirectURL config.URL `json:"redirectUrl"`
 
+	// PostLogoutRedirectURL is where the OpenID provider sends the user after ending their session there. It's only used
+	// when the provider advertises an end_session_endpoint and must be registered with the provider.
+	PostLogoutRedirectURL config.URL `json:"postLogoutRedirectUrl" pflag:",OPTIONAL: Where the OpenID provider should redirect users after they log out."`
+
 	// OpenID defines settings for connecting and trusting an OpenIDConnect provider.
 	OpenID OpenIDOptions `json:"openId" pflag:",OpenID Configuration for User Auth"`
 	// Possibly add basicAuth & SAML/p support.
@@ -207,6 +370,61 @@ type UserAuthConfig struct {
 	// Secret names, defaults are set in DefaultConfig variable above but are possible to override through configs.
 	CookieHashKeySecretName  string `json:"cookieHashKeySecretName" pflag:",OPTIONAL: Secret name to use for cookie hash key."`
 	CookieBlockKeySecretName string `json:"cookieBlockKeySecretName" pflag:",OPTIONAL: Secret name to use for cookie block key."`
+
+	// Cookies defines the attributes of the cookies set while authenticating users.
+	Cookies CookieOptions `json:"cookies" pflag:",Attributes of the cookies set while authenticating users."`
+}
+
+// CookieOptions defines the attributes of the cookies set while authenticating users. The defaults leave the attributes
+// unset, which restricts cookies to the host that served them.
+type CookieOptions struct {
+	// Domain allows the cookies to be sent to subdomains of the given domain. Set it to a parent domain (e.g.
+	// corp.example.com) when the console and admin are served from different subdomains.
+	Domain string `json:"domain" pflag:",OPTIONAL: Domain attribute of the auth cookies."`
+
+	// SameSite is one of Lax, Strict or None. None requires Secure to be set.
+	SameSite string `json:"sameSite" pflag:",OPTIONAL: SameSite attribute of the auth cookies. One of Lax, Strict or None."`
+
+	// Secure restricts the cookies to https requests.
+	Secure bool `json:"secure" pflag:",OPTIONAL: Whether to set the Secure attribute on the auth cookies."`
+
+	// MaxAge bounds how long browsers keep the token cookies. If not set, they're dropped when the browser is closed.
+	MaxAge config.Duration `json:"maxAge" pflag:",OPTIONAL: How long browsers should keep the token cookies."`
+
+	// NamePrefix is prepended to the names of the cookies so that multiple deployments sharing a domain don't
+	// overwrite each other's cookies.
+	NamePrefix string `json:"namePrefix" pflag:",OPTIONAL: Prefix to add to the names of the auth cookies."`
+}
+
+var sameSiteModes = map[string]http.SameSite{
+	"":       http.SameSiteDefaultMode,
+	"lax":    http.SameSiteLaxMode,
+	"strict": http.SameSiteStrictMode,
+	"none":   http.SameSiteNoneMode,
+}
+
+// SameSiteMode returns the SameSite attribute to set on cookies.
+func (c CookieOptions) SameSiteMode() http.SameSite {
+	return sameSiteModes[strings.ToLower(c.SameSite)]
+}
+
+// Validate checks SameSite is a known mode and that cookies sent cross-site are restricted to https, which browsers
+// require.
+func (c CookieOptions) Validate() error {
+	mode, found := sameSiteModes[strings.ToLower(c.SameSite)]
+	if !found {
+		return fmt.Errorf("invalid sameSite [%v], expected one of Lax, Strict or None", c.SameSite)
+	}
+
+	if mode == http.SameSiteNoneMode && !c.Secure {
+		return fmt.Errorf("sameSite None requires secure to be set")
+	}
+
+	if c.MaxAge.Duration < 0 {
+		return fmt.Errorf("maxAge can't be negative")
+	}
+
+	return nil
 }
 
 type OpenIDOptions struct {
@@ -221,6 +439,11 @@ type OpenIDOptions struct {
 	// Deprecated: Please use ClientSecretName instead.
 	DeprecatedClientSecretFile string `json:"clientSecretFile"`
 
+	// ClientSecretRefreshInterval defines how often the client secret is re-read so that it can be rotated without
+	// restarting. With the deprecated client secret file, the file is only re-read when it was modified. The secret is
+	// also re-read whenever the IdP rejects the client credentials. Set to 0 to disable the periodic refresh.
+	ClientSecretRefreshInterval config.Duration `json:"clientSecretRefreshInterval"`
+
 	// This should be the base url of the authorization server that you are trying to hit. With Okta for instance, it
 	// will look something like https://company.okta.com/oauth2/abcdef123456789/
 	BaseURL config.URL `json:"baseUrl"`
@@ -229,6 +452,19 @@ type OpenIDOptions struct {
 	// be supported by any OIdC server. Refer to https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims for
 	// a complete list. Other providers might support additional scopes that you can define in a config.
 	Scopes []string `json:"scopes"`
+
+	// UsePKCE makes the authorization code flow use Proof Key for Code Exchange (https://tools.ietf.org/html/rfc7636).
+	// Some IdPs require it even for confidential clients.
+	UsePKCE bool `json:"usePkce"`
+
+	// UserIDClaims lists the ID token claims that hold the user's identity, in order of preference. Some IdPs put the
+	// user's email in non-standard claims (e.g. preferred_username, or upn for federated users). The first claim present
+	// in the token is used, falling back to the standard sub claim.
+	UserIDClaims []string `json:"userIdClaims"`
+
+	// GroupsClaim is the ID token claim that holds the groups the user belongs to. The claim can either be a list of
+	// strings or a single string. If empty, no groups are attached to the identity.
+	GroupsClaim string `json:"groupsClaim"`
 }
 
 func GetConfig() *Config {
@@ -54,15 +54,26 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "grpcAuthorizationHeader"), DefaultConfig.GrpcAuthorizationHeader, "")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "disableForHttp"), DefaultConfig.DisableForHTTP, "Disables auth enforcement on HTTP Endpoints.")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "disableForGrpc"), DefaultConfig.DisableForGrpc, "Disables auth enforcement on Grpc Endpoints.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "allowedAnonymousMethods"), []string{}, "Additional gRPC methods that can be called without authentication. Supports '*' wildcards.")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "authorizedUris"), []string{}, "Optional: Defines the set of URIs that clients are allowed to visit the service on. If set,  the system will attempt to match the incoming host to the first authorized URIs and use that (including the scheme) when generating metadata endpoints and when validating audience and issuer claims. If not provided,  the urls will be deduced based on the request url and the 'secure' setting.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.redirectUrl"), DefaultConfig.UserAuth.RedirectURL.String(), "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.postLogoutRedirectUrl"), DefaultConfig.UserAuth.PostLogoutRedirectURL.String(), "OPTIONAL: Where the OpenID provider should redirect users after they log out.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.clientId"), DefaultConfig.UserAuth.OpenID.ClientID, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.clientSecretName"), DefaultConfig.UserAuth.OpenID.ClientSecretName, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.clientSecretFile"), DefaultConfig.UserAuth.OpenID.DeprecatedClientSecretFile, "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.clientSecretRefreshInterval"), DefaultConfig.UserAuth.OpenID.ClientSecretRefreshInterval.String(), "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.baseUrl"), DefaultConfig.UserAuth.OpenID.BaseURL.String(), "")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "userAuth.openId.scopes"), []string{}, "")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "userAuth.openId.usePkce"), DefaultConfig.UserAuth.OpenID.UsePKCE, "")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "userAuth.openId.userIdClaims"), []string{}, "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.openId.groupsClaim"), DefaultConfig.UserAuth.OpenID.GroupsClaim, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookieHashKeySecretName"), DefaultConfig.UserAuth.CookieHashKeySecretName, "OPTIONAL: Secret name to use for cookie hash key.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookieBlockKeySecretName"), DefaultConfig.UserAuth.CookieBlockKeySecretName, "OPTIONAL: Secret name to use for cookie block key.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookies.domain"), DefaultConfig.UserAuth.Cookies.Domain, "OPTIONAL: Domain attribute of the auth cookies.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookies.sameSite"), DefaultConfig.UserAuth.Cookies.SameSite, "OPTIONAL: SameSite attribute of the auth cookies. One of Lax, Strict or None.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "userAuth.cookies.secure"), DefaultConfig.UserAuth.Cookies.Secure, "OPTIONAL: Whether to set the Secure attribute on the auth cookies.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookies.maxAge"), DefaultConfig.UserAuth.Cookies.MaxAge.String(), "OPTIONAL: How long browsers should keep the token cookies.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "userAuth.cookies.namePrefix"), DefaultConfig.UserAuth.Cookies.NamePrefix, "OPTIONAL: Prefix to add to the names of the auth cookies.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.selfAuthServer.issuer"), DefaultConfig.AppAuth.SelfAuthServer.Issuer, "Defines the issuer to use when issuing and validating tokens. The default value is https://<requestUri.HostAndPort>/")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.selfAuthServer.accessTokenLifespan"), DefaultConfig.AppAuth.SelfAuthServer.AccessTokenLifespan.String(), "Defines the lifespan of issued access tokens.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.selfAuthServer.refreshTokenLifespan"), DefaultConfig.AppAuth.SelfAuthServer.RefreshTokenLifespan.String(), "Defines the lifespan of issued access tokens.")
@@ -73,8 +84,15 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.externalAuthServer.baseUrl"), DefaultConfig.AppAuth.ExternalAuthServer.BaseURL.String(), "This should be the base url of the authorization server that you are trying to hit. With Okta for instance,  it will look something like https://company.okta.com/oauth2/abcdef123456789/")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "appAuth.externalAuthServer.allowedAudience"), []string{}, "Optional: A list of allowed audiences. If not provided,  the audience is expected to be the public Uri of the service.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.externalAuthServer.metadataUrl"), DefaultConfig.AppAuth.ExternalAuthServer.MetadataEndpointURL.String(), "Optional: If the server doesn't support /.well-known/oauth-authorization-server,  you can set a custom metadata url here.'")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.externalAuthServer.groupsClaim"), DefaultConfig.AppAuth.ExternalAuthServer.GroupsClaim, "Optional: The access token claim that lists the groups of the caller.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.externalAuthServer.jwksRefreshInterval"), DefaultConfig.AppAuth.ExternalAuthServer.JwksRefreshInterval.String(), "Defines how often the signing keys of each issuer are refreshed.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.thirdPartyConfig.flyteClient.clientId"), DefaultConfig.AppAuth.ThirdParty.FlyteClientConfig.ClientID, "public identifier for the app which handles authorization for a Flyte deployment")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "appAuth.thirdPartyConfig.flyteClient.redirectUri"), DefaultConfig.AppAuth.ThirdParty.FlyteClientConfig.RedirectURI, "This is the callback uri registered with the app which handles authorization for a Flyte deployment")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "appAuth.thirdPartyConfig.flyteClient.scopes"), []string{}, "Recommended scopes for the client to request.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "authorization.enabled"), DefaultConfig.Authorization.Enabled, "Enables role based authorization of gRPC requests.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "authorization.defaultRole"), DefaultConfig.Authorization.DefaultRole, "OPTIONAL: Role granted to every authenticated caller. One of viewer, contributor or admin.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "apiKeys.enabled"), DefaultConfig.APIKeys.Enabled, "Enables authenticating machine clients with pre-shared API keys.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "apiKeys.secretName"), DefaultConfig.APIKeys.SecretName, "Secret name to use to retrieve the hashed API keys.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "apiKeys.refreshInterval"), DefaultConfig.APIKeys.RefreshInterval.String(), "How often API keys are re-read from the secret.")
 	return cmdFlags
 }
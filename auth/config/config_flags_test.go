@@ -155,6 +155,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_allowedAnonymousMethods", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config("1,1", ",")
+
+			cmdFlags.Set("allowedAnonymousMethods", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("allowedAnonymousMethods"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.AllowedAnonymousMethods)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_authorizedUris", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -183,6 +197,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_userAuth.postLogoutRedirectUrl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.UserAuth.PostLogoutRedirectURL.String()
+
+			cmdFlags.Set("userAuth.postLogoutRedirectUrl", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.postLogoutRedirectUrl"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.PostLogoutRedirectURL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_userAuth.openId.clientId", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -225,6 +253,20 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_userAuth.openId.clientSecretRefreshInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.UserAuth.OpenID.ClientSecretRefreshInterval.String()
+
+			cmdFlags.Set("userAuth.openId.clientSecretRefreshInterval", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.openId.clientSecretRefreshInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.OpenID.ClientSecretRefreshInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_userAuth.openId.baseUrl", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -253,6 +295,48 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_userAuth.openId.usePkce", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.openId.usePkce", testValue)
+			if vBool, err := cmdFlags.GetBool("userAuth.openId.usePkce"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.UserAuth.OpenID.UsePKCE)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.openId.userIdClaims", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config("1,1", ",")
+
+			cmdFlags.Set("userAuth.openId.userIdClaims", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("userAuth.openId.userIdClaims"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.UserAuth.OpenID.UserIDClaims)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.openId.groupsClaim", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.openId.groupsClaim", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.openId.groupsClaim"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.OpenID.GroupsClaim)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_userAuth.cookieHashKeySecretName", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -281,6 +365,76 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_userAuth.cookies.domain", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.cookies.domain", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.cookies.domain"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.Cookies.Domain)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.cookies.sameSite", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.cookies.sameSite", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.cookies.sameSite"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.Cookies.SameSite)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.cookies.secure", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.cookies.secure", testValue)
+			if vBool, err := cmdFlags.GetBool("userAuth.cookies.secure"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.UserAuth.Cookies.Secure)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.cookies.maxAge", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.UserAuth.Cookies.MaxAge.String()
+
+			cmdFlags.Set("userAuth.cookies.maxAge", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.cookies.maxAge"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.Cookies.MaxAge)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_userAuth.cookies.namePrefix", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("userAuth.cookies.namePrefix", testValue)
+			if vString, err := cmdFlags.GetString("userAuth.cookies.namePrefix"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.UserAuth.Cookies.NamePrefix)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_appAuth.selfAuthServer.issuer", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -421,6 +575,34 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_appAuth.externalAuthServer.groupsClaim", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("appAuth.externalAuthServer.groupsClaim", testValue)
+			if vString, err := cmdFlags.GetString("appAuth.externalAuthServer.groupsClaim"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AppAuth.ExternalAuthServer.GroupsClaim)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_appAuth.externalAuthServer.jwksRefreshInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.AppAuth.ExternalAuthServer.JwksRefreshInterval.String()
+
+			cmdFlags.Set("appAuth.externalAuthServer.jwksRefreshInterval", testValue)
+			if vString, err := cmdFlags.GetString("appAuth.externalAuthServer.jwksRefreshInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.AppAuth.ExternalAuthServer.JwksRefreshInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_appAuth.thirdPartyConfig.flyteClient.clientId", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -463,4 +645,74 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_authorization.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("authorization.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("authorization.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Authorization.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_authorization.defaultRole", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("authorization.defaultRole", testValue)
+			if vString, err := cmdFlags.GetString("authorization.defaultRole"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Authorization.DefaultRole)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_apiKeys.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("apiKeys.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("apiKeys.enabled"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.APIKeys.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_apiKeys.secretName", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("apiKeys.secretName", testValue)
+			if vString, err := cmdFlags.GetString("apiKeys.secretName"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.APIKeys.SecretName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_apiKeys.refreshInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := DefaultConfig.APIKeys.RefreshInterval.String()
+
+			cmdFlags.Set("apiKeys.refreshInterval", testValue)
+			if vString, err := cmdFlags.GetString("apiKeys.refreshInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.APIKeys.RefreshInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -3,8 +3,10 @@ package config
 import (
 	"context"
 	"io/ioutil"
+	"net/http"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/logger"
 
@@ -49,3 +51,36 @@ func TestParseClientSecretConfig(t *testing.T) {
 	assert.NoError(t, accessor.UpdateConfig(context.Background()))
 	assert.Equal(t, "my-client", GetConfig().AppAuth.SelfAuthServer.StaticClients["my-client"].ID)
 }
+
+func TestCookieOptions_Validate(t *testing.T) {
+	assert.NoError(t, CookieOptions{}.Validate())
+	assert.NoError(t, CookieOptions{SameSite: "Strict"}.Validate())
+	assert.NoError(t, CookieOptions{SameSite: "None", Secure: true}.Validate())
+	assert.Error(t, CookieOptions{SameSite: "None"}.Validate())
+	assert.Error(t, CookieOptions{SameSite: "Sometimes"}.Validate())
+	assert.Error(t, CookieOptions{MaxAge: config.Duration{Duration: -time.Hour}}.Validate())
+}
+
+func TestCookieOptions_SameSiteMode(t *testing.T) {
+	assert.Equal(t, http.SameSiteDefaultMode, CookieOptions{}.SameSiteMode())
+	assert.Equal(t, http.SameSiteLaxMode, CookieOptions{SameSite: "lax"}.SameSiteMode())
+	assert.Equal(t, http.SameSiteStrictMode, CookieOptions{SameSite: "Strict"}.SameSiteMode())
+	assert.Equal(t, http.SameSiteNoneMode, CookieOptions{SameSite: "None"}.SameSiteMode())
+}
+
+func TestAuthorizationConfig_Validate(t *testing.T) {
+	assert.NoError(t, AuthorizationConfig{}.Validate())
+	assert.NoError(t, AuthorizationConfig{
+		DefaultRole: RoleViewer,
+		RoleBindings: []RoleBinding{
+			{Role: RoleAdmin, Groups: []string{"platform"}},
+			{Role: RoleContributor, Subjects: []string{"flytepropeller"}, Project: "flytesnacks", Domain: "development"},
+		},
+		MethodRoles: map[string]string{"TerminateExecution": RoleAdmin},
+	}.Validate())
+
+	assert.Error(t, AuthorizationConfig{DefaultRole: "owner"}.Validate())
+	assert.Error(t, AuthorizationConfig{RoleBindings: []RoleBinding{{Role: "owner"}}}.Validate())
+	assert.Error(t, AuthorizationConfig{RoleBindings: []RoleBinding{{Role: RoleViewer, Domain: "production"}}}.Validate())
+	assert.Error(t, AuthorizationConfig{MethodRoles: map[string]string{"TerminateExecution": "owner"}}.Validate())
+}
@@ -20,5 +20,10 @@ const (
 	OIdCMetadataEndpoint = ".well-known/openid-configuration"
 
 	ContextKeyIdentityContext = contextutils.Key("identity_context")
-	ScopeAll                  = "all"
+	// Holds the id token obtained by the TokenRefresher, which supersedes the expired one still in the request cookies.
+	ContextKeyRefreshedIDToken = contextutils.Key("refreshed_id_token")
+	ScopeAll                   = "all"
+
+	// Holds the projects a request spanning projects is limited to, see AuthorizedProjectsFromContext.
+	ContextKeyAuthorizedProjects = contextutils.Key("authorized_projects")
 )
@@ -2,12 +2,8 @@ package auth
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"math/rand"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flytestdlib/errors"
@@ -23,7 +19,7 @@ const (
 	// #nosec
 	refreshTokenCookieName = "flyte_rt"
 	// #nosec
-	csrfStateCookieName = "flyte_csrf_state"
+	loginStateCookieName = "flyte_login_state"
 	// #nosec
 	redirectURLCookieName = "flyte_redirect_location"
 
@@ -35,22 +31,15 @@ const (
 
 	// #nosec
 	userInfoCookieName = "flyte_user_info"
+
+	// #nosec
+	codeVerifierCookieName = "flyte_code_verifier"
 )
 
 const (
 	ErrSecureCookie errors.ErrorCode = "SECURE_COOKIE_ERROR"
-	// #nosec
-	ErrInvalidCsrfToken errors.ErrorCode = "CSRF_TOKEN_VALIDATION_FAILED"
 )
 
-var AllowedChars = []rune("abcdefghijklmnopqrstuvwxyz1234567890")
-
-func HashCsrfState(csrf string) string {
-	shaBytes := sha256.Sum256([]byte(csrf))
-	hash := hex.EncodeToString(shaBytes[:])
-	return hash
-}
-
 func NewSecureCookie(cookieName, value string, hashKey, blockKey []byte) (http.Cookie, error) {
 	var s = securecookie.New(hashKey, blockKey)
 	encoded, err := s.Encode(cookieName, value)
@@ -59,6 +48,7 @@ func NewSecureCookie(cookieName, value string, hashKey, blockKey []byte) (http.C
 		return http.Cookie{
 			Name:  cookieName,
 			Value: encoded,
+			Path:  "/",
 		}, nil
 	}
 
@@ -103,42 +93,6 @@ func ReadSecureCookie(ctx context.Context, cookie http.Cookie, hashKey, blockKey
 	return "", errors.Wrapf(ErrSecureCookie, err, "Error reading secure cookie %s", cookie.Name)
 }
 
-func NewCsrfToken(seed int64) string {
-	rand.Seed(seed)
-	csrfToken := [10]rune{}
-	for i := 0; i < len(csrfToken); i++ {
-		/* #nosec */
-		csrfToken[i] = AllowedChars[rand.Intn(len(AllowedChars))]
-	}
-	return string(csrfToken[:])
-}
-
-func NewCsrfCookie() http.Cookie {
-	csrfStateToken := NewCsrfToken(time.Now().UnixNano())
-	return http.Cookie{
-		Name:     csrfStateCookieName,
-		Value:    csrfStateToken,
-		SameSite: http.SameSiteLaxMode,
-		HttpOnly: true,
-	}
-}
-
-func VerifyCsrfCookie(ctx context.Context, request *http.Request) error {
-	csrfState := request.FormValue(CsrfFormKey)
-	if csrfState == "" {
-		return errors.Errorf(ErrInvalidCsrfToken, "Empty state in callback, %s", request.Form)
-	}
-	csrfCookie, err := request.Cookie(csrfStateCookieName)
-	if csrfCookie == nil || err != nil {
-		return errors.Errorf(ErrInvalidCsrfToken, "Could not find csrf cookie %s", err)
-	}
-	if HashCsrfState(csrfCookie.Value) != csrfState {
-		return errors.Errorf(ErrInvalidCsrfToken, "CSRF token does not match state %s, %s vs %s", csrfCookie.Value,
-			HashCsrfState(csrfCookie.Value), csrfState)
-	}
-	return nil
-}
-
 // This function takes in a string and returns a cookie that's used to keep track of where to send the user after
 // the OAuth2 login flow is complete.
 func NewRedirectCookie(ctx context.Context, redirectURL string) *http.Cookie {
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 
 	"github.com/flyteorg/flytestdlib/errors"
@@ -18,6 +20,7 @@ import (
 type CookieManager struct {
 	hashKey  []byte
 	blockKey []byte
+	options  config.CookieOptions
 }
 
 const (
@@ -25,12 +28,18 @@ const (
 	// #nosec
 	ErrTokenNil errors.ErrorCode = "EMPTY_OAUTH_TOKEN"
 	// #nosec
-	ErrNoIDToken errors.ErrorCode = "NO_ID_TOKEN_IN_RESPONSE"
+	ErrNoIDToken            errors.ErrorCode = "NO_ID_TOKEN_IN_RESPONSE"
+	ErrInvalidCookieOptions errors.ErrorCode = "INVALID_COOKIE_OPTIONS"
 )
 
-func NewCookieManager(ctx context.Context, hashKeyEncoded, blockKeyEncoded string) (CookieManager, error) {
+func NewCookieManager(ctx context.Context, hashKeyEncoded, blockKeyEncoded string, options config.CookieOptions) (
+	CookieManager, error) {
 	logger.Infof(ctx, "Instantiating cookie manager")
 
+	if err := options.Validate(); err != nil {
+		return CookieManager{}, errors.Wrapf(ErrInvalidCookieOptions, err, "Invalid cookie options")
+	}
+
 	hashKey, err := base64.RawStdEncoding.DecodeString(hashKeyEncoded)
 	if err != nil {
 		return CookieManager{}, errors.Wrapf(ErrB64Decoding, err, "Error decoding hash key bytes")
@@ -44,9 +53,55 @@ func NewCookieManager(ctx context.Context, hashKeyEncoded, blockKeyEncoded strin
 	return CookieManager{
 		hashKey:  hashKey,
 		blockKey: blockKey,
+		options:  options,
 	}, nil
 }
 
+func (c CookieManager) cookieName(name string) string {
+	return c.options.NamePrefix + name
+}
+
+// Applies the configured attributes to a cookie created by NewSecureCookie.
+func (c CookieManager) applyOptions(cookie *http.Cookie) {
+	cookie.Domain = c.options.Domain
+	cookie.Secure = c.options.Secure
+	cookie.SameSite = c.options.SameSiteMode()
+}
+
+// newTokenCookie creates a cookie holding a token, or session information derived from it, with the configured
+// attributes.
+func (c CookieManager) newTokenCookie(name, value string) (http.Cookie, error) {
+	cookie, err := NewSecureCookie(c.cookieName(name), value, c.hashKey, c.blockKey)
+	if err != nil {
+		return http.Cookie{}, err
+	}
+
+	c.applyOptions(&cookie)
+	cookie.MaxAge = int(c.options.MaxAge.Seconds())
+	return cookie, nil
+}
+
+// newLoginFlowCookie creates a cookie holding state that must survive the redirect to the IdP and back. These are
+// HttpOnly and, since the IdP redirecting back is a cross-site navigation, never SameSite=Strict.
+func (c CookieManager) newLoginFlowCookie(name, value string) (http.Cookie, error) {
+	cookie, err := NewSecureCookie(c.cookieName(name), value, c.hashKey, c.blockKey)
+	if err != nil {
+		return http.Cookie{}, err
+	}
+
+	c.applyOptions(&cookie)
+	cookie.HttpOnly = true
+	if cookie.SameSite != http.SameSiteNoneMode {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+
+	return cookie, nil
+}
+
+func (c CookieManager) retrieveSecureCookie(ctx context.Context, request *http.Request, name string) (string, error) {
+	return retrieveSecureCookie(ctx, request, c.cookieName(name), c.hashKey, c.blockKey)
+}
+
 // TODO: Separate refresh token from access token, remove named returns, and use stdlib errors.
 // RetrieveTokenValues retrieves id, access and refresh tokens from cookies if they exist. The existence of a refresh token
 // in a cookie is optional and hence failure to find or read that cookie is tolerated. An error is returned in case of failure
@@ -54,17 +109,17 @@ func NewCookieManager(ctx context.Context, hashKeyEncoded, blockKeyEncoded strin
 func (c CookieManager) RetrieveTokenValues(ctx context.Context, request *http.Request) (idToken, accessToken,
 	refreshToken string, err error) {
 
-	idToken, err = retrieveSecureCookie(ctx, request, idTokenCookieName, c.hashKey, c.blockKey)
+	idToken, err = c.retrieveSecureCookie(ctx, request, idTokenCookieName)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	accessToken, err = retrieveSecureCookie(ctx, request, accessTokenCookieName, c.hashKey, c.blockKey)
+	accessToken, err = c.retrieveSecureCookie(ctx, request, accessTokenCookieName)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	refreshToken, err = retrieveSecureCookie(ctx, request, refreshTokenCookieName, c.hashKey, c.blockKey)
+	refreshToken, err = c.retrieveSecureCookie(ctx, request, refreshTokenCookieName)
 	if err != nil {
 		// Refresh tokens are optional. Depending on the auth url (IdP specific) we might or might not receive a refresh
 		// token. In case we do not, we will just have to redirect to IdP whenever access/id tokens expire.
@@ -81,7 +136,7 @@ func (c CookieManager) SetUserInfoCookie(ctx context.Context, writer http.Respon
 		return fmt.Errorf("failed to marshal user info to store in a cookie. Error: %w", err)
 	}
 
-	userInfoCookie, err := NewSecureCookie(userInfoCookieName, string(raw), c.hashKey, c.blockKey)
+	userInfoCookie, err := c.newTokenCookie(userInfoCookieName, string(raw))
 	if err != nil {
 		logger.Errorf(ctx, "Error generating encrypted user info cookie %s", err)
 		return err
@@ -94,7 +149,7 @@ func (c CookieManager) SetUserInfoCookie(ctx context.Context, writer http.Respon
 }
 
 func (c CookieManager) RetrieveUserInfo(ctx context.Context, request *http.Request) (*service.UserInfoResponse, error) {
-	userInfoCookie, err := retrieveSecureCookie(ctx, request, userInfoCookieName, c.hashKey, c.blockKey)
+	userInfoCookie, err := c.retrieveSecureCookie(ctx, request, userInfoCookieName)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +165,7 @@ func (c CookieManager) RetrieveUserInfo(ctx context.Context, request *http.Reque
 }
 
 func (c CookieManager) RetrieveAuthCodeRequest(ctx context.Context, request *http.Request) (authRequestURL string, err error) {
-	authCodeCookie, err := retrieveSecureCookie(ctx, request, authCodeCookieName, c.hashKey, c.blockKey)
+	authCodeCookie, err := c.retrieveSecureCookie(ctx, request, authCodeCookieName)
 	if err != nil {
 		return "", err
 	}
@@ -119,7 +174,7 @@ func (c CookieManager) RetrieveAuthCodeRequest(ctx context.Context, request *htt
 }
 
 func (c CookieManager) SetAuthCodeCookie(ctx context.Context, writer http.ResponseWriter, authRequestURL string) error {
-	authCodeCookie, err := NewSecureCookie(authCodeCookieName, authRequestURL, c.hashKey, c.blockKey)
+	authCodeCookie, err := c.newLoginFlowCookie(authCodeCookieName, authRequestURL)
 	if err != nil {
 		logger.Errorf(ctx, "Error generating encrypted accesstoken cookie %s", err)
 		return err
@@ -130,13 +185,62 @@ func (c CookieManager) SetAuthCodeCookie(ctx context.Context, writer http.Respon
 	return nil
 }
 
+func (c CookieManager) RetrieveCodeVerifier(ctx context.Context, request *http.Request) (codeVerifier string, err error) {
+	return c.retrieveSecureCookie(ctx, request, codeVerifierCookieName)
+}
+
+func (c CookieManager) SetCodeVerifierCookie(ctx context.Context, writer http.ResponseWriter, codeVerifier string) error {
+	codeVerifierCookie, err := c.newLoginFlowCookie(codeVerifierCookieName, codeVerifier)
+	if err != nil {
+		logger.Errorf(ctx, "Error generating encrypted code verifier cookie %s", err)
+		return err
+	}
+
+	http.SetCookie(writer, &codeVerifierCookie)
+
+	return nil
+}
+
+func (c CookieManager) RetrieveLoginState(ctx context.Context, request *http.Request) (interfaces.LoginState, error) {
+	raw, err := c.retrieveSecureCookie(ctx, request, loginStateCookieName)
+	if err != nil {
+		return interfaces.LoginState{}, err
+	}
+
+	loginState := interfaces.LoginState{}
+	if err = json.Unmarshal([]byte(raw), &loginState); err != nil {
+		return interfaces.LoginState{}, fmt.Errorf("failed to unmarshal login state cookie. Error: %w", err)
+	}
+
+	return loginState, nil
+}
+
+func (c CookieManager) SetLoginStateCookie(ctx context.Context, writer http.ResponseWriter, loginState interfaces.LoginState) error {
+	raw, err := json.Marshal(loginState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal login state to store in a cookie. Error: %w", err)
+	}
+
+	loginStateCookie, err := c.newLoginFlowCookie(loginStateCookieName, string(raw))
+	if err != nil {
+		logger.Errorf(ctx, "Error generating encrypted login state cookie %s", err)
+		return err
+	}
+
+	// The expiry is enforced again when the cookie is read back, browsers dropping the cookie is only a courtesy.
+	loginStateCookie.Expires = loginState.ExpiresAt
+	http.SetCookie(writer, &loginStateCookie)
+
+	return nil
+}
+
 func (c CookieManager) SetTokenCookies(ctx context.Context, writer http.ResponseWriter, token *oauth2.Token) error {
 	if token == nil {
 		logger.Errorf(ctx, "Attempting to set cookies with nil token")
 		return errors.Errorf(ErrTokenNil, "Attempting to set cookies with nil token")
 	}
 
-	atCookie, err := NewSecureCookie(accessTokenCookieName, token.AccessToken, c.hashKey, c.blockKey)
+	atCookie, err := c.newTokenCookie(accessTokenCookieName, token.AccessToken)
 	if err != nil {
 		logger.Errorf(ctx, "Error generating encrypted accesstoken cookie %s", err)
 		return err
@@ -145,7 +249,7 @@ func (c CookieManager) SetTokenCookies(ctx context.Context, writer http.Response
 	http.SetCookie(writer, &atCookie)
 
 	if idTokenRaw, converted := token.Extra(idTokenExtra).(string); converted {
-		idCookie, err := NewSecureCookie(idTokenCookieName, idTokenRaw, c.hashKey, c.blockKey)
+		idCookie, err := c.newTokenCookie(idTokenCookieName, idTokenRaw)
 		if err != nil {
 			logger.Errorf(ctx, "Error generating encrypted id token cookie %s", err)
 			return err
@@ -159,7 +263,7 @@ func (c CookieManager) SetTokenCookies(ctx context.Context, writer http.Response
 
 	// Set the refresh cookie if there is a refresh token
 	if token.RefreshToken != "" {
-		refreshCookie, err := NewSecureCookie(refreshTokenCookieName, token.RefreshToken, c.hashKey, c.blockKey)
+		refreshCookie, err := c.newTokenCookie(refreshTokenCookieName, token.RefreshToken)
 		if err != nil {
 			logger.Errorf(ctx, "Error generating encrypted refresh token cookie %s", err)
 			return err
@@ -170,27 +274,28 @@ func (c CookieManager) SetTokenCookies(ctx context.Context, writer http.Response
 	return nil
 }
 
-func getLogoutAccessCookie() *http.Cookie {
+// Returns a cookie that expires the cookie with the given name. The path and domain must match the ones the cookie was
+// set with for browsers to clear it.
+func (c CookieManager) getLogoutCookie(name string) *http.Cookie {
 	return &http.Cookie{
-		Name:     accessTokenCookieName,
+		Name:     c.cookieName(name),
 		Value:    "",
-		MaxAge:   0,
+		Path:     "/",
+		Domain:   c.options.Domain,
+		MaxAge:   -1,
 		HttpOnly: true,
 		Expires:  time.Now().Add(-1 * time.Hour),
 	}
 }
 
-func getLogoutRefreshCookie() *http.Cookie {
-	return &http.Cookie{
-		Name:     refreshTokenCookieName,
-		Value:    "",
-		MaxAge:   0,
-		HttpOnly: true,
-		Expires:  time.Now().Add(-1 * time.Hour),
+func (c CookieManager) DeleteCookies(ctx context.Context, writer http.ResponseWriter) {
+	for _, name := range []string{accessTokenCookieName, refreshTokenCookieName, idTokenCookieName, userInfoCookieName} {
+		http.SetCookie(writer, c.getLogoutCookie(name))
 	}
 }
 
-func (c CookieManager) DeleteCookies(ctx context.Context, writer http.ResponseWriter) {
-	http.SetCookie(writer, getLogoutAccessCookie())
-	http.SetCookie(writer, getLogoutRefreshCookie())
+func (c CookieManager) DeleteLoginStateCookies(ctx context.Context, writer http.ResponseWriter) {
+	for _, name := range []string{loginStateCookieName, codeVerifierCookieName} {
+		http.SetCookie(writer, c.getLogoutCookie(name))
+	}
 }
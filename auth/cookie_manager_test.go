@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteadmin/auth/config"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2"
 )
@@ -18,7 +20,7 @@ func TestCookieManager_SetTokenCookies(t *testing.T) {
 	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
 	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
 
-	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded)
+	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
 	assert.NoError(t, err)
 
 	token := &oauth2.Token{
@@ -46,7 +48,7 @@ func TestCookieManager_RetrieveTokenValues(t *testing.T) {
 	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
 	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
 
-	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded)
+	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
 	assert.NoError(t, err)
 
 	token := &oauth2.Token{
@@ -76,14 +78,12 @@ func TestCookieManager_RetrieveTokenValues(t *testing.T) {
 	assert.Equal(t, "refresh", refresh)
 }
 
-func TestGetLogoutAccessCookie(t *testing.T) {
-	cookie := getLogoutAccessCookie()
-	assert.True(t, time.Now().After(cookie.Expires))
-}
-
-func TestGetLogoutRefreshCookie(t *testing.T) {
-	cookie := getLogoutRefreshCookie()
+func TestGetLogoutCookie(t *testing.T) {
+	cookie := CookieManager{}.getLogoutCookie(accessTokenCookieName)
+	assert.Equal(t, accessTokenCookieName, cookie.Name)
 	assert.True(t, time.Now().After(cookie.Expires))
+	assert.Equal(t, "/", cookie.Path)
+	assert.Equal(t, -1, cookie.MaxAge)
 }
 
 func TestCookieManager_DeleteCookies(t *testing.T) {
@@ -93,13 +93,89 @@ func TestCookieManager_DeleteCookies(t *testing.T) {
 	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
 	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
 
-	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded)
+	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
 	assert.NoError(t, err)
 
 	w := httptest.NewRecorder()
 	manager.DeleteCookies(ctx, w)
 	cookies := w.Result().Cookies()
-	assert.Equal(t, 2, len(cookies))
-	assert.True(t, time.Now().After(cookies[0].Expires))
-	assert.True(t, time.Now().After(cookies[1].Expires))
+	assert.Equal(t, 4, len(cookies))
+	for _, cookie := range cookies {
+		assert.True(t, time.Now().After(cookie.Expires))
+		assert.Equal(t, "/", cookie.Path)
+		assert.Equal(t, -1, cookie.MaxAge)
+		assert.Contains(t, []string{accessTokenCookieName, refreshTokenCookieName, idTokenCookieName,
+			userInfoCookieName}, cookie.Name)
+	}
+}
+
+func TestNewCookieManager_InvalidOptions(t *testing.T) {
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+
+	_, err := NewCookieManager(context.Background(), hashKeyEncoded, blockKeyEncoded, config.CookieOptions{
+		SameSite: "None",
+	})
+	assert.Error(t, err)
+}
+
+func TestCookieManager_CookieOptions(t *testing.T) {
+	ctx := context.Background()
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+
+	manager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{
+		Domain:     "corp.example.com",
+		SameSite:   "Strict",
+		Secure:     true,
+		MaxAge:     stdConfig.Duration{Duration: time.Hour},
+		NamePrefix: "dev_",
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	assert.NoError(t, manager.SetTokenCookies(ctx, w, (&oauth2.Token{AccessToken: "access"}).WithExtra(
+		map[string]interface{}{"id_token": "id token"})))
+	assert.NoError(t, manager.SetCodeVerifierCookie(ctx, w, "verifier"))
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 3)
+	for _, cookie := range cookies[:2] {
+		assert.Equal(t, "corp.example.com", cookie.Domain)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		assert.Equal(t, 3600, cookie.MaxAge)
+	}
+
+	assert.Equal(t, "dev_flyte_at", cookies[0].Name)
+	assert.Equal(t, "dev_flyte_idt", cookies[1].Name)
+	// Cookies that must survive the redirect back from the IdP can't be SameSite=Strict and only live for the session.
+	assert.Equal(t, "dev_flyte_code_verifier", cookies[2].Name)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[2].SameSite)
+	assert.Equal(t, 0, cookies[2].MaxAge)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
+	}
+
+	idToken, accessToken, _, err := manager.RetrieveTokenValues(ctx, r)
+	assert.NoError(t, err)
+	assert.Equal(t, "id token", idToken)
+	assert.Equal(t, "access", accessToken)
+
+	// Another deployment sharing the domain doesn't pick up these cookies.
+	other, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{NamePrefix: "prod_"})
+	assert.NoError(t, err)
+	_, _, _, err = other.RetrieveTokenValues(ctx, r)
+	assert.Error(t, err)
+
+	w = httptest.NewRecorder()
+	manager.DeleteCookies(ctx, w)
+	for _, cookie := range w.Result().Cookies() {
+		assert.Equal(t, "corp.example.com", cookie.Domain)
+		assert.Contains(t, cookie.Name, "dev_")
+	}
 }
@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -43,62 +42,6 @@ func TestSecureCookieLifecycle(t *testing.T) {
 	assert.Equal(t, "chip", value)
 }
 
-func TestNewCsrfToken(t *testing.T) {
-	csrf := NewCsrfToken(5)
-	assert.Equal(t, "5qz3p9w8qo", csrf)
-}
-
-func TestNewCsrfCookie(t *testing.T) {
-	cookie := NewCsrfCookie()
-	assert.Equal(t, "flyte_csrf_state", cookie.Name)
-	assert.True(t, cookie.HttpOnly)
-}
-
-func TestHashCsrfState(t *testing.T) {
-	h := HashCsrfState("hello world")
-	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", h)
-}
-
-func TestVerifyCsrfCookie(t *testing.T) {
-	t.Run("test no state", func(t *testing.T) {
-		var buf bytes.Buffer
-		request, err := http.NewRequest(http.MethodPost, "/test", &buf)
-		assert.NoError(t, err)
-		err = VerifyCsrfCookie(context.Background(), request)
-		assert.Error(t, err)
-	})
-
-	t.Run("test incorrect token", func(t *testing.T) {
-		var buf bytes.Buffer
-		request, err := http.NewRequest(http.MethodPost, "/test", &buf)
-		assert.NoError(t, err)
-		v := url.Values{
-			"state": []string{"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
-		}
-		cookie := NewCsrfCookie()
-		cookie.Value = "helloworld"
-		request.Form = v
-		request.AddCookie(&cookie)
-		err = VerifyCsrfCookie(context.Background(), request)
-		assert.Error(t, err)
-	})
-
-	t.Run("test correct token", func(t *testing.T) {
-		var buf bytes.Buffer
-		request, err := http.NewRequest(http.MethodPost, "/test", &buf)
-		assert.NoError(t, err)
-		v := url.Values{
-			"state": []string{"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
-		}
-		cookie := NewCsrfCookie()
-		cookie.Value = "hello world"
-		request.Form = v
-		request.AddCookie(&cookie)
-		err = VerifyCsrfCookie(context.Background(), request)
-		assert.NoError(t, err)
-	})
-}
-
 func TestNewRedirectCookie(t *testing.T) {
 	t.Run("test local path", func(t *testing.T) {
 		ctx := context.Background()
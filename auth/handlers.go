@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -16,11 +17,15 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"google.golang.org/grpc/peer"
 
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 
+	"github.com/coreos/go-oidc"
+	"github.com/flyteorg/flyteadmin/auth/config"
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flytestdlib/errors"
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -35,11 +40,12 @@ const (
 
 type HTTPRequestToMetadataAnnotator func(ctx context.Context, request *http.Request) metadata.MD
 
-func RegisterHandlers(ctx context.Context, handler interfaces.HandlerRegisterer, authCtx interfaces.AuthenticationContext) {
+func RegisterHandlers(ctx context.Context, handler interfaces.HandlerRegisterer, authCtx interfaces.AuthenticationContext,
+	scope promutils.Scope) {
 	// Add HTTP handlers for OAuth2 endpoints
 	handler.HandleFunc("/login", RefreshTokensIfExists(ctx, authCtx,
 		GetLoginHandler(ctx, authCtx)))
-	handler.HandleFunc("/callback", GetCallbackHandler(ctx, authCtx))
+	handler.HandleFunc("/callback", GetCallbackHandler(ctx, authCtx, scope))
 
 	// The metadata endpoint is an RFC-defined constant, but we need a leading / for the handler to pattern match correctly.
 	handler.HandleFunc(fmt.Sprintf("/%s", OIdCMetadataEndpoint), GetOIdCMetadataEndpointRedirectHandler(ctx, authCtx))
@@ -65,7 +71,10 @@ func RefreshTokensIfExists(ctx context.Context, authCtx interfaces.Authenticatio
 		_, err = ParseIDTokenAndValidate(ctx, authCtx.Options().UserAuth.OpenID.ClientID, idToken, authCtx.OidcProvider())
 		if err != nil && errors.IsCausedBy(err, ErrTokenExpired) && len(refreshToken) > 0 {
 			logger.Debugf(ctx, "Expired id token found, attempting to refresh")
-			newToken, err := GetRefreshedToken(ctx, authCtx.OAuth2ClientConfig(GetPublicURL(ctx, request, authCtx.Options())), accessToken, refreshToken)
+			newToken, err := withClientConfigReload(ctx, authCtx, GetPublicURL(ctx, request, authCtx.Options()),
+				func(cfg *oauth2.Config) (*oauth2.Token, error) {
+					return GetRefreshedToken(ctx, cfg, accessToken, refreshToken)
+				})
 			if err != nil {
 				logger.Infof(ctx, "Failed to refresh tokens. Restarting login flow. Error: %s", err)
 				authHandler(writer, request)
@@ -105,16 +114,42 @@ func RefreshTokensIfExists(ctx context.Context, authCtx interfaces.Authenticatio
 }
 
 // GetLoginHandler builds an http handler that handles authentication calls. Before redirecting to the authentication
-// provider, it saves a cookie that contains the redirect url for after the authentication flow is done.
+// provider, it saves a cookie that contains the redirect url for after the authentication flow is done, along with
+// the login state the callback is validated against.
 func GetLoginHandler(ctx context.Context, authCtx interfaces.AuthenticationContext) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
-		csrfCookie := NewCsrfCookie()
-		csrfToken := csrfCookie.Value
-		http.SetCookie(writer, &csrfCookie)
+		loginState, err := NewLoginState()
+		if err != nil {
+			logger.Errorf(ctx, "Failed to generate login state. Error: %v", err)
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if err = authCtx.CookieManager().SetLoginStateCookie(ctx, writer, loginState); err != nil {
+			logger.Errorf(ctx, "Failed to set login state cookie. Error: %v", err)
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		authCodeOptions := []oauth2.AuthCodeOption{oidc.Nonce(loginState.Nonce)}
+		if authCtx.Options().UserAuth.OpenID.UsePKCE {
+			codeVerifier, err := NewCodeVerifier()
+			if err != nil {
+				logger.Errorf(ctx, "Failed to generate code verifier. Error: %v", err)
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if err = authCtx.CookieManager().SetCodeVerifierCookie(ctx, writer, codeVerifier); err != nil {
+				logger.Errorf(ctx, "Failed to set code verifier cookie. Error: %v", err)
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 
-		state := HashCsrfState(csrfToken)
-		logger.Debugf(ctx, "Setting CSRF state cookie to %s and state to %s\n", csrfToken, state)
-		url := authCtx.OAuth2ClientConfig(GetPublicURL(ctx, request, authCtx.Options())).AuthCodeURL(state)
+			authCodeOptions = append(authCodeOptions, codeChallengeOptions(codeVerifier)...)
+		}
+
+		url := authCtx.OAuth2ClientConfig(GetPublicURL(ctx, request, authCtx.Options())).AuthCodeURL(loginState.State, authCodeOptions...)
 		queryParams := request.URL.Query()
 		if flowEndRedirectURL := queryParams.Get(RedirectURLParameter); flowEndRedirectURL != "" {
 			redirectCookie := NewRedirectCookie(ctx, flowEndRedirectURL)
@@ -131,25 +166,64 @@ func GetLoginHandler(ctx context.Context, authCtx interfaces.AuthenticationConte
 
 // GetCallbackHandler returns a handler that is called by the OIdC provider with the authorization code to complete
 // the user authentication flow.
-func GetCallbackHandler(ctx context.Context, authCtx interfaces.AuthenticationContext) http.HandlerFunc {
+func GetCallbackHandler(ctx context.Context, authCtx interfaces.AuthenticationContext, scope promutils.Scope) http.HandlerFunc {
+	loginStateValidator := newLoginStateValidator(scope)
 	return func(writer http.ResponseWriter, request *http.Request) {
 		logger.Debugf(ctx, "Running callback handler... for RequestURI %v", request.RequestURI)
 		authorizationCode := request.FormValue(AuthorizationResponseCodeType)
 
-		err := VerifyCsrfCookie(ctx, request)
+		loginState, err := loginStateValidator.VerifyState(ctx, request, authCtx.CookieManager())
 		if err != nil {
-			logger.Errorf(ctx, "Invalid CSRF token cookie %s", err)
-			writer.WriteHeader(http.StatusUnauthorized)
+			logger.Errorf(ctx, "Invalid login state. Error: %v", err)
+			writer.WriteHeader(http.StatusForbidden)
 			return
 		}
 
-		token, err := authCtx.OAuth2ClientConfig(GetPublicURL(ctx, request, authCtx.Options())).Exchange(ctx, authorizationCode)
+		// The login state can only be used once, there is no reason for the browser to hold on to it.
+		authCtx.CookieManager().DeleteLoginStateCookies(ctx, writer)
+
+		var exchangeOptions []oauth2.AuthCodeOption
+		if authCtx.Options().UserAuth.OpenID.UsePKCE {
+			codeVerifier, err := authCtx.CookieManager().RetrieveCodeVerifier(ctx, request)
+			if err != nil {
+				logger.Errorf(ctx, "Failed to retrieve code verifier cookie. Error: %v", err)
+				writer.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			exchangeOptions = append(exchangeOptions, codeVerifierOption(codeVerifier))
+		}
+
+		token, err := withClientConfigReload(ctx, authCtx, GetPublicURL(ctx, request, authCtx.Options()),
+			func(cfg *oauth2.Config) (*oauth2.Token, error) {
+				return cfg.Exchange(ctx, authorizationCode, exchangeOptions...)
+			})
 		if err != nil {
 			logger.Errorf(ctx, "Error when exchanging code %s", err)
 			writer.WriteHeader(http.StatusForbidden)
 			return
 		}
 
+		rawIDToken, ok := token.Extra(idTokenExtra).(string)
+		if !ok {
+			logger.Errorf(ctx, "Response does not contain an id_token.")
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		idToken, err := ParseIDTokenAndValidate(ctx, authCtx.Options().UserAuth.OpenID.ClientID, rawIDToken, authCtx.OidcProvider())
+		if err != nil {
+			logger.Errorf(ctx, "Failed to validate id token. Error: %v", err)
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if err = loginStateValidator.VerifyNonce(idToken, loginState); err != nil {
+			logger.Errorf(ctx, "Invalid id token. Error: %v", err)
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		err = authCtx.CookieManager().SetTokenCookies(ctx, writer, token)
 		if err != nil {
 			logger.Errorf(ctx, "Error setting encrypted JWT cookie %s", err)
@@ -184,6 +258,26 @@ func AuthenticationLoggingInterceptor(ctx context.Context, req interface{}, info
 	return handler(ctx, req)
 }
 
+// Copies the token passed in the custom authorization header, if any, to the default one.
+func withDefaultAuthorizationHeader(ctx context.Context, authCtx interfaces.AuthenticationContext) context.Context {
+	if authCtx.Options().GrpcAuthorizationHeader == DefaultAuthorizationHeader {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logger.Debugf(ctx, "Could not extract incoming metadata from context, continuing with original ctx...")
+		return ctx
+	}
+	existingHeader := md.Get(authCtx.Options().GrpcAuthorizationHeader)
+	if len(existingHeader) == 0 {
+		return ctx
+	}
+	logger.Debugf(ctx, "Found existing metadata %s", existingHeader[0])
+	newAuthorizationMetadata := metadata.Pairs(DefaultAuthorizationHeader, existingHeader[0])
+	joinedMetadata := metadata.Join(md, newAuthorizationMetadata)
+	return metadata.NewIncomingContext(ctx, joinedMetadata)
+}
+
 // GetAuthenticationCustomMetadataInterceptor produces a gRPC middleware interceptor intended to be used when running
 // authentication with non-default gRPC headers (metadata). Because the default `authorization` header is reserved for
 // use by Envoy, clients wishing to pass tokens to Admin will need to use a different string, specified in this
@@ -191,22 +285,17 @@ func AuthenticationLoggingInterceptor(ctx context.Context, req interface{}, info
 // string, which the downstream auth/auditing interceptors will detect and validate.
 func GetAuthenticationCustomMetadataInterceptor(authCtx interfaces.AuthenticationContext) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if authCtx.Options().GrpcAuthorizationHeader != DefaultAuthorizationHeader {
-			md, ok := metadata.FromIncomingContext(ctx)
-			if ok {
-				existingHeader := md.Get(authCtx.Options().GrpcAuthorizationHeader)
-				if len(existingHeader) > 0 {
-					logger.Debugf(ctx, "Found existing metadata %s", existingHeader[0])
-					newAuthorizationMetadata := metadata.Pairs(DefaultAuthorizationHeader, existingHeader[0])
-					joinedMetadata := metadata.Join(md, newAuthorizationMetadata)
-					newCtx := metadata.NewIncomingContext(ctx, joinedMetadata)
-					return handler(newCtx, req)
-				}
-			} else {
-				logger.Debugf(ctx, "Could not extract incoming metadata from context, continuing with original ctx...")
-			}
-		}
-		return handler(ctx, req)
+		return handler(withDefaultAuthorizationHeader(ctx, authCtx), req)
+	}
+}
+
+// GetAuthenticationCustomMetadataStreamInterceptor is the streaming counterpart of
+// GetAuthenticationCustomMetadataInterceptor.
+func GetAuthenticationCustomMetadataStreamInterceptor(authCtx interfaces.AuthenticationContext) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpcMiddleware.WrapServerStream(ss)
+		wrapped.WrappedContext = withDefaultAuthorizationHeader(ss.Context(), authCtx)
+		return handler(srv, wrapped)
 	}
 }
 
@@ -221,7 +310,8 @@ func SetContextForIdentity(ctx context.Context, identityContext interfaces.Ident
 }
 
 // GetAuthenticationInterceptor chooses to enforce or not enforce authentication. It will attempt to get the token
-// from the incoming context, validate it, and decide whether or not to let the request through.
+// from the incoming context, validate it, and decide whether or not to let the request through. Methods allowed
+// anonymously by the auth context are let through when no valid token is presented.
 func GetAuthenticationInterceptor(authCtx interfaces.AuthenticationContext) func(context.Context) (context.Context, error) {
 	return func(ctx context.Context) (context.Context, error) {
 		logger.Debugf(ctx, "Running authentication gRPC interceptor")
@@ -229,6 +319,16 @@ func GetAuthenticationInterceptor(authCtx interfaces.AuthenticationContext) func
 		fromHTTP := metautils.ExtractIncoming(ctx).Get(FromHTTPKey)
 		isFromHTTP := fromHTTP == FromHTTPVal
 
+		if apiKeyAuthenticator := authCtx.APIKeyAuthenticator(); apiKeyAuthenticator != nil {
+			identityContext, err := GRPCGetIdentityFromAPIKey(ctx, apiKeyAuthenticator)
+			if err == nil {
+				return SetContextForIdentity(ctx, identityContext), nil
+			}
+
+			logger.Debugf(ctx, "Failed to authenticate with an API key. Will attempt to validate Access Token. Error: %v",
+				err)
+		}
+
 		identityContext, err := GRPCGetIdentityFromAccessToken(ctx, authCtx)
 		if err == nil {
 			return SetContextForIdentity(ctx, identityContext), nil
@@ -236,13 +336,18 @@ func GetAuthenticationInterceptor(authCtx interfaces.AuthenticationContext) func
 
 		logger.Infof(ctx, "Failed to parse Access Token from context. Will attempt to find IDToken. Error: %v", err)
 
-		identityContext, err = GRPCGetIdentityFromIDToken(ctx, authCtx.Options().UserAuth.OpenID.ClientID,
+		identityContext, err = GRPCGetIdentityFromIDToken(ctx, authCtx.Options().UserAuth.OpenID,
 			authCtx.OidcProvider())
 
 		if err == nil {
 			return SetContextForIdentity(ctx, identityContext), nil
 		}
 
+		if method, ok := grpc.Method(ctx); ok && authCtx.IsAnonymousMethod(method) {
+			logger.Debugf(ctx, "Allowing anonymous access to [%s]", method)
+			return ctx, nil
+		}
+
 		// Only enforcement logic is present. The default case is to let things through.
 		if (isFromHTTP && !authCtx.Options().DisableForHTTP) ||
 			(!isFromHTTP && !authCtx.Options().DisableForGrpc) {
@@ -253,6 +358,26 @@ func GetAuthenticationInterceptor(authCtx interfaces.AuthenticationContext) func
 	}
 }
 
+// Authenticates requests to HTTP handlers which are served directly rather than through the gRPC gateway, and so aren't
+// covered by the gRPC authentication interceptor. Unauthenticated requests are rejected unless auth enforcement is
+// disabled for HTTP.
+func GetHTTPAuthenticationHandler(authCtx interfaces.AuthenticationContext, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		identityContext, err := IdentityContextFromRequest(ctx, request, authCtx)
+		if err == nil {
+			handler.ServeHTTP(writer, request.WithContext(SetContextForIdentity(ctx, identityContext)))
+			return
+		}
+		if !authCtx.Options().DisableForHTTP {
+			logger.Infof(ctx, "Rejecting unauthenticated request to %s. Error: %v", request.URL.Path, err)
+			http.Error(writer, "unauthenticated request", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
+
 func WithUserEmail(ctx context.Context, email string) context.Context {
 	return context.WithValue(ctx, common.PrincipalContextKey, email)
 }
@@ -280,6 +405,10 @@ func GetHTTPRequestCookieToMetadataHandler(authCtx interfaces.AuthenticationCont
 	return func(ctx context.Context, request *http.Request) metadata.MD {
 		// TODO: Improve error handling
 		idToken, _, _, _ := authCtx.CookieManager().RetrieveTokenValues(ctx, request)
+		if refreshedIDToken, ok := ctx.Value(ContextKeyRefreshedIDToken).(string); ok {
+			idToken = refreshedIDToken
+		}
+
 		if len(idToken) == 0 {
 			// If no token was found in the cookies, look for an authorization header, starting with a potentially
 			// custom header set in the Config object
@@ -362,7 +491,7 @@ func IdentityContextFromRequest(ctx context.Context, req *http.Request, authCtx
 		return nil, fmt.Errorf("unauthenticated request. Error: %w", err)
 	}
 
-	return IdentityContextFromIDTokenToken(ctx, idToken, authCtx.Options().UserAuth.OpenID.ClientID,
+	return IdentityContextFromIDTokenToken(ctx, idToken, authCtx.Options().UserAuth.OpenID,
 		authCtx.OidcProvider(), userInfo)
 }
 
@@ -421,14 +550,90 @@ func GetOIdCMetadataEndpointRedirectHandler(ctx context.Context, authCtx interfa
 	}
 }
 
+// Returns the end_session_endpoint advertised in the OpenID provider metadata, if any.
+// See https://openid.net/specs/openid-connect-rpinitiated-1_0.html for more information.
+func getEndSessionEndpoint(provider *oidc.Provider) (*url.URL, error) {
+	if provider == nil {
+		return nil, nil
+	}
+
+	claims := struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}{}
+	if err := provider.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	if len(claims.EndSessionEndpoint) == 0 {
+		return nil, nil
+	}
+
+	return url.Parse(claims.EndSessionEndpoint)
+}
+
+// Only relative urls and urls pointing to one of the authorized uris of the service are allowed as redirect targets to
+// avoid turning the endpoint into an open redirect.
+func isAllowedRedirectURL(cfg *config.Config, redirectURL string) bool {
+	u, err := url.Parse(redirectURL)
+	if err != nil {
+		return false
+	}
+
+	if len(u.Scheme) == 0 && len(u.Host) == 0 {
+		return strings.HasPrefix(u.Path, "/") && !strings.HasPrefix(u.Path, "//")
+	}
+
+	for _, authorized := range cfg.AuthorizedURIs {
+		if u.Scheme == authorized.Scheme && u.Host == authorized.Host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLogoutEndpointHandler returns a handler that deletes the auth cookies. If the OpenID provider supports ending the
+// session on its side, the user is then redirected there. Otherwise, the user is redirected to the redirect_url query
+// parameter if one was given and it's allowed.
 func GetLogoutEndpointHandler(ctx context.Context, authCtx interfaces.AuthenticationContext) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
+		idToken, _, _, err := authCtx.CookieManager().RetrieveTokenValues(ctx, request)
+		if err != nil {
+			logger.Debugf(ctx, "Failed to retrieve id token to hint the OpenID provider. Error: %v", err)
+		}
+
 		logger.Debugf(ctx, "Deleting auth cookies")
 		authCtx.CookieManager().DeleteCookies(ctx, writer)
 
+		endSessionURL, err := getEndSessionEndpoint(authCtx.OidcProvider())
+		if err != nil {
+			logger.Warnf(ctx, "Failed to read the end session endpoint of the OpenID provider. Error: %v", err)
+		}
+
+		if endSessionURL != nil {
+			queryParams := endSessionURL.Query()
+			if postLogoutRedirectURL := authCtx.Options().UserAuth.PostLogoutRedirectURL.String(); len(postLogoutRedirectURL) > 0 {
+				queryParams.Set("post_logout_redirect_uri", postLogoutRedirectURL)
+			}
+
+			if len(idToken) > 0 {
+				queryParams.Set("id_token_hint", idToken)
+			} else if clientID := authCtx.Options().UserAuth.OpenID.ClientID; len(clientID) > 0 {
+				queryParams.Set("client_id", clientID)
+			}
+
+			endSessionURL.RawQuery = queryParams.Encode()
+			http.Redirect(writer, request, endSessionURL.String(), http.StatusTemporaryRedirect)
+			return
+		}
+
 		// Redirect if one was given
-		queryParams := request.URL.Query()
-		if redirectURL := queryParams.Get(RedirectURLParameter); redirectURL != "" {
+		if redirectURL := request.URL.Query().Get(RedirectURLParameter); redirectURL != "" {
+			if !isAllowedRedirectURL(authCtx.Options(), redirectURL) {
+				logger.Infof(ctx, "Ignoring logout redirect to [%v] which isn't an authorized uri", redirectURL)
+				return
+			}
+
 			http.Redirect(writer, request, redirectURL, http.StatusTemporaryRedirect)
 		}
 	}
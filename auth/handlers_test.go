@@ -9,16 +9,22 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	"github.com/flyteorg/flyteadmin/auth/interfaces/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	jwtgo "github.com/golang-jwt/jwt/v4"
 
 	"github.com/coreos/go-oidc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -45,23 +51,27 @@ func setupMockedAuthContextAtEndpoint(endpoint string) *mocks.AuthenticationCont
 	mockAuthCtx.OnCookieManagerMatch().Return(mockCookieHandler)
 	mockCookieHandler.OnSetTokenCookiesMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mockCookieHandler.OnSetUserInfoCookieMatch(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCookieHandler.OnRetrieveLoginStateMatch(mock.Anything, mock.Anything).Return(testLoginState(), nil)
+	mockCookieHandler.On("DeleteLoginStateCookies", mock.Anything, mock.Anything).Return()
 	mockAuthCtx.OnOAuth2ClientConfigMatch(mock.Anything).Return(&dummyOAuth2Config)
 	return mockAuthCtx
 }
 
+func testLoginState() interfaces.LoginState {
+	return interfaces.LoginState{
+		State:     "state",
+		Nonce:     "nonce",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+}
+
 func addStateString(request *http.Request) {
 	v := url.Values{
-		"state": []string{"b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
+		"state": []string{"state"},
 	}
 	request.Form = v
 }
 
-func addCsrfCookie(request *http.Request) {
-	cookie := NewCsrfCookie()
-	cookie.Value = "hello world"
-	request.AddCookie(&cookie)
-}
-
 func TestGetCallbackHandlerWithErrorOnToken(t *testing.T) {
 	ctx := context.Background()
 	hf := func(w http.ResponseWriter, r *http.Request) {
@@ -74,115 +84,79 @@ func TestGetCallbackHandlerWithErrorOnToken(t *testing.T) {
 	defer localServer.Close()
 	http.DefaultClient = localServer.Client()
 	mockAuthCtx := setupMockedAuthContextAtEndpoint(localServer.URL)
-	callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx)
+	callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx, promutils.NewTestScope())
 	request := httptest.NewRequest("GET", localServer.URL+"/callback", nil)
-	addCsrfCookie(request)
 	addStateString(request)
 	writer := httptest.NewRecorder()
 	callbackHandlerFunc(writer, request)
 	assert.Equal(t, "403 Forbidden", writer.Result().Status)
 }
 
-func TestGetCallbackHandlerWithUnAuthorized(t *testing.T) {
+func TestGetCallbackHandlerWithoutLoginState(t *testing.T) {
 	ctx := context.Background()
-	hf := func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == oauth2TokenURL {
-			w.WriteHeader(403)
-			return
-		}
-	}
-	localServer := httptest.NewServer(http.HandlerFunc(hf))
-	defer localServer.Close()
-	http.DefaultClient = localServer.Client()
-	mockAuthCtx := setupMockedAuthContextAtEndpoint(localServer.URL)
-	callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx)
-	request := httptest.NewRequest("GET", localServer.URL+"/callback", nil)
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockCookieHandler := new(mocks.CookieHandler)
+	mockCookieHandler.OnRetrieveLoginStateMatch(mock.Anything, mock.Anything).Return(interfaces.LoginState{},
+		fmt.Errorf("no cookie"))
+	mockAuthCtx.OnCookieManagerMatch().Return(mockCookieHandler)
+	callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx, promutils.NewTestScope())
+	request := httptest.NewRequest("GET", "/callback", nil)
 	writer := httptest.NewRecorder()
 	callbackHandlerFunc(writer, request)
-	assert.Equal(t, "401 Unauthorized", writer.Result().Status)
+	assert.Equal(t, "403 Forbidden", writer.Result().Status)
 }
 
 func TestGetCallbackHandler(t *testing.T) {
-	var openIDConfigJSON string
-	var userInfoJSON string
 	ctx := context.Background()
-	hf := func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == oauth2TokenURL {
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = io.WriteString(w, `{"access_token":"Sample.Access.Token",
-									"issued_token_type":"urn:ietf:params:oauth:token-type:access_token",	
-									"token_type":"Bearer",	
-									"expires_in":3600,	
-									"scope":"all"}`)
-			return
-		}
-		if r.URL.Path == "/.well-known/openid-configuration" {
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = io.WriteString(w, openIDConfigJSON)
-			return
-		}
-		if r.URL.Path == "/userinfo" {
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = io.WriteString(w, userInfoJSON)
-			return
-		}
-	}
-	localServer := httptest.NewServer(http.HandlerFunc(hf))
-	defer localServer.Close()
+	localServer, mux, oidcProvider, newIDToken := newTestIdP(t, "abc")
 	http.DefaultClient = localServer.Client()
-	issuer := localServer.URL
-	userInfoJSON = `{
+	idTokenClaims := jwtgo.MapClaims{"nonce": "nonce"}
+	mux.HandleFunc(oauth2TokenURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"access_token":"Sample.Access.Token",
+									"issued_token_type":"urn:ietf:params:oauth:token-type:access_token",
+									"token_type":"Bearer",
+									"expires_in":3600,
+									"scope":"all",
+									"id_token":"%s"}`, newIDToken(idTokenClaims)))
+	})
+	userInfoStatus := http.StatusForbidden
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(userInfoStatus)
+		_, _ = io.WriteString(w, `{
 							"subject" : "dummySubject",
 							"profile" : "dummyProfile",
 							"email"   : "dummyEmail"
-					}`
-	openIDConfigJSON = fmt.Sprintf(`{
-				"issuer": "%v",
-				"authorization_endpoint": "%v/auth",
-				"token_endpoint": "%v/token",
-				"jwks_uri": "%v/keys",
-				"id_token_signing_alg_values_supported": ["RS256"]
-			}`, issuer, issuer, issuer, issuer)
+					}`)
+	})
 
-	t.Run("forbidden request when accessing user info", func(t *testing.T) {
+	newCallback := func() (http.HandlerFunc, *httptest.ResponseRecorder, *http.Request) {
 		mockAuthCtx := setupMockedAuthContextAtEndpoint(localServer.URL)
-		callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx)
+		mockAuthCtx.OnOidcProviderMatch().Return(oidcProvider)
 		request := httptest.NewRequest("GET", localServer.URL+"/callback", nil)
-		addCsrfCookie(request)
 		addStateString(request)
-		writer := httptest.NewRecorder()
-		openIDConfigJSON = fmt.Sprintf(`{
-				"issuer": "%v",
-				"authorization_endpoint": "%v/auth",
-				"token_endpoint": "%v/token",
-				"jwks_uri": "%v/keys",
-				"id_token_signing_alg_values_supported": ["RS256"]
-			}`, issuer, issuer, issuer, issuer)
-		oidcProvider, err := oidc.NewProvider(ctx, issuer)
-		assert.Nil(t, err)
-		mockAuthCtx.OnOidcProviderMatch().Return(oidcProvider)
+		return GetCallbackHandler(ctx, mockAuthCtx, promutils.NewTestScope()), httptest.NewRecorder(), request
+	}
+
+	t.Run("forbidden request when accessing user info", func(t *testing.T) {
+		callbackHandlerFunc, writer, request := newCallback()
+		callbackHandlerFunc(writer, request)
+		assert.Equal(t, "403 Forbidden", writer.Result().Status)
+	})
+
+	t.Run("forbidden when the nonce does not match", func(t *testing.T) {
+		userInfoStatus = http.StatusOK
+		idTokenClaims["nonce"] = "other"
+		defer func() { idTokenClaims["nonce"] = "nonce" }()
+		callbackHandlerFunc, writer, request := newCallback()
 		callbackHandlerFunc(writer, request)
 		assert.Equal(t, "403 Forbidden", writer.Result().Status)
 	})
 
 	t.Run("successful callback and redirect", func(t *testing.T) {
-		mockAuthCtx := setupMockedAuthContextAtEndpoint(localServer.URL)
-		callbackHandlerFunc := GetCallbackHandler(ctx, mockAuthCtx)
-		request := httptest.NewRequest("GET", localServer.URL+"/callback", nil)
-		addCsrfCookie(request)
-		addStateString(request)
-		writer := httptest.NewRecorder()
-		openIDConfigJSON = fmt.Sprintf(`{
-				"userinfo_endpoint": "%v/userinfo",
-				"issuer": "%v",
-				"authorization_endpoint": "%v/auth",
-				"token_endpoint": "%v/token",
-				"jwks_uri": "%v/keys",
-				"id_token_signing_alg_values_supported": ["RS256"]
-			}`, issuer, issuer, issuer, issuer, issuer)
-		oidcProvider, err := oidc.NewProvider(ctx, issuer)
-		assert.Nil(t, err)
-		mockAuthCtx.OnOidcProviderMatch().Return(oidcProvider)
+		userInfoStatus = http.StatusOK
+		callbackHandlerFunc, writer, request := newCallback()
 		callbackHandlerFunc(writer, request)
 		assert.Equal(t, "307 Temporary Redirect", writer.Result().Status)
 	})
@@ -194,8 +168,14 @@ func TestGetLoginHandler(t *testing.T) {
 		ClientID: "abc",
 		Scopes:   []string{"openid", "other"},
 	}
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
 	mockAuthCtx := mocks.AuthenticationContext{}
 	mockAuthCtx.OnOptions().Return(&config.Config{})
+	mockAuthCtx.OnCookieManager().Return(&cookieManager)
 	mockAuthCtx.OnOAuth2ClientConfigMatch(mock.Anything).Return(&dummyOAuth2Config)
 	handler := GetLoginHandler(ctx, &mockAuthCtx)
 	req, err := http.NewRequest("GET", "/login", nil)
@@ -204,7 +184,20 @@ func TestGetLoginHandler(t *testing.T) {
 	handler(w, req)
 	assert.Equal(t, 307, w.Code)
 	assert.True(t, strings.Contains(w.Header().Get("Location"), "response_type=code&scope=openid+other"))
-	assert.True(t, strings.Contains(w.Header().Get("Set-Cookie"), "flyte_csrf_state="))
+	assert.True(t, strings.Contains(w.Header().Get("Set-Cookie"), "flyte_login_state="))
+
+	// The state and nonce sent to the IdP are the ones stored in the login state cookie.
+	location, err := url.Parse(w.Header().Get("Location"))
+	assert.NoError(t, err)
+	callback := httptest.NewRequest("GET", "/callback", nil)
+	for _, cookie := range w.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+
+	loginState, err := cookieManager.RetrieveLoginState(ctx, callback)
+	assert.NoError(t, err)
+	assert.Equal(t, loginState.State, location.Query().Get("state"))
+	assert.Equal(t, loginState.Nonce, location.Query().Get("nonce"))
 }
 
 func TestGetHTTPRequestCookieToMetadataHandler(t *testing.T) {
@@ -212,7 +205,7 @@ func TestGetHTTPRequestCookieToMetadataHandler(t *testing.T) {
 	// These were generated for unit testing only.
 	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
 	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
-	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded)
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
 	assert.NoError(t, err)
 	mockAuthCtx := mocks.AuthenticationContext{}
 	mockAuthCtx.OnCookieManager().Return(&cookieManager)
@@ -241,12 +234,49 @@ func TestGetHTTPMetadataTaggingHandler(t *testing.T) {
 	assert.Equal(t, FromHTTPVal, md.Get(FromHTTPKey)[0])
 }
 
+func TestGetHTTPAuthenticationHandler(t *testing.T) {
+	ctx := context.Background()
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
+	var served bool
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		served = true
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		served = false
+		mockAuthCtx := mocks.AuthenticationContext{}
+		mockAuthCtx.OnCookieManager().Return(&cookieManager)
+		mockAuthCtx.OnOptions().Return(&config.Config{})
+		recorder := httptest.NewRecorder()
+		GetHTTPAuthenticationHandler(&mockAuthCtx, next).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodPost, "/api/v1/dataproxy/artifact_urn", nil))
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.False(t, served)
+	})
+
+	t.Run("disabled for http", func(t *testing.T) {
+		served = false
+		mockAuthCtx := mocks.AuthenticationContext{}
+		mockAuthCtx.OnCookieManager().Return(&cookieManager)
+		mockAuthCtx.OnOptions().Return(&config.Config{DisableForHTTP: true})
+		recorder := httptest.NewRecorder()
+		GetHTTPAuthenticationHandler(&mockAuthCtx, next).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodPost, "/api/v1/dataproxy/artifact_urn", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.True(t, served)
+	})
+}
+
 func TestGetHTTPRequestCookieToMetadataHandler_CustomHeader(t *testing.T) {
 	ctx := context.Background()
 	// These were generated for unit testing only.
 	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
 	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
-	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded)
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
 	assert.NoError(t, err)
 	mockAuthCtx := mocks.AuthenticationContext{}
 	mockAuthCtx.On("CookieManager").Return(&cookieManager)
@@ -261,6 +291,22 @@ func TestGetHTTPRequestCookieToMetadataHandler_CustomHeader(t *testing.T) {
 	assert.Equal(t, "Bearer a.b.c", handler(ctx, req)["authorization"][0])
 }
 
+func TestGetAuthenticationCustomMetadataStreamInterceptor(t *testing.T) {
+	mockAuthCtx := mocks.AuthenticationContext{}
+	mockAuthCtx.OnOptions().Return(&config.Config{
+		GrpcAuthorizationHeader: "flyte-authorization",
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("flyte-authorization", "Bearer a.b.c"))
+	err := GetAuthenticationCustomMetadataStreamInterceptor(&mockAuthCtx)(nil, &testServerStream{ctx: ctx}, nil,
+		func(srv interface{}, stream grpc.ServerStream) error {
+			md, ok := metadata.FromIncomingContext(stream.Context())
+			assert.True(t, ok)
+			assert.Equal(t, []string{"Bearer a.b.c"}, md.Get(DefaultAuthorizationHeader))
+			return nil
+		})
+	assert.NoError(t, err)
+}
+
 func TestGetOIdCMetadataEndpointRedirectHandler(t *testing.T) {
 	ctx := context.Background()
 	metadataPath := mustParseURL(t, OIdCMetadataEndpoint)
@@ -282,3 +328,200 @@ func TestGetOIdCMetadataEndpointRedirectHandler(t *testing.T) {
 	assert.Equal(t, http.StatusSeeOther, w.Code)
 	assert.Equal(t, "http://www.google.com/.well-known/openid-configuration", w.Header()["Location"][0])
 }
+
+func newLogoutTestAuthContext(t *testing.T, provider *oidc.Provider) (*mocks.AuthenticationContext, CookieManager) {
+	ctx := context.Background()
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
+
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockAuthCtx.OnCookieManager().Return(&cookieManager)
+	mockAuthCtx.OnOidcProvider().Return(provider)
+	mockAuthCtx.OnOptions().Return(&config.Config{
+		AuthorizedURIs: []stdConfig.URL{{URL: mustParseURL(t, "https://flyte.example.com")}},
+		UserAuth: config.UserAuthConfig{
+			PostLogoutRedirectURL: stdConfig.URL{URL: mustParseURL(t, "https://flyte.example.com/console")},
+			OpenID: config.OpenIDOptions{
+				ClientID: "client",
+			},
+		},
+	})
+
+	return mockAuthCtx, cookieManager
+}
+
+func TestGetLogoutEndpointHandler(t *testing.T) {
+	ctx := context.Background()
+	mockAuthCtx, _ := newLogoutTestAuthContext(t, nil)
+	handler := GetLogoutEndpointHandler(ctx, mockAuthCtx)
+
+	t.Run("expires cookies", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/logout", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookies := w.Result().Cookies()
+		assert.Len(t, cookies, 4)
+		for _, cookie := range cookies {
+			assert.Empty(t, cookie.Value)
+			assert.Equal(t, "/", cookie.Path)
+			assert.Empty(t, cookie.Domain)
+			assert.Equal(t, -1, cookie.MaxAge)
+			assert.True(t, cookie.HttpOnly)
+		}
+	})
+
+	t.Run("allowed redirect", func(t *testing.T) {
+		for _, redirectURL := range []string{"/console", "https://flyte.example.com/console"} {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, "/logout?redirect_url="+url.QueryEscape(redirectURL), nil))
+			assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+			assert.Equal(t, redirectURL, w.Header().Get("Location"))
+		}
+	})
+
+	t.Run("disallowed redirect", func(t *testing.T) {
+		for _, redirectURL := range []string{"https://evil.example.com/console", "//evil.example.com", "http://flyte.example.com"} {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, "/logout?redirect_url="+url.QueryEscape(redirectURL), nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Location"))
+			assert.Len(t, w.Result().Cookies(), 4)
+		}
+	})
+}
+
+func TestGetLogoutEndpointHandler_EndSession(t *testing.T) {
+	ctx := context.Background()
+	var issuer string
+	localServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, strings.ReplaceAll(`{
+			"issuer": "ISSUER",
+			"authorization_endpoint": "ISSUER/authorize",
+			"token_endpoint": "ISSUER/token",
+			"jwks_uri": "ISSUER/keys",
+			"end_session_endpoint": "ISSUER/logout?foo=bar"
+		}`, "ISSUER", issuer))
+	}))
+	defer localServer.Close()
+	issuer = localServer.URL
+
+	provider, err := oidc.NewProvider(ctx, issuer)
+	assert.NoError(t, err)
+	mockAuthCtx, cookieManager := newLogoutTestAuthContext(t, provider)
+	handler := GetLogoutEndpointHandler(ctx, mockAuthCtx)
+
+	t.Run("with id token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/logout?redirect_url=/console", nil)
+		for _, name := range []string{accessTokenCookieName, idTokenCookieName} {
+			cookie, err := NewSecureCookie(name, "a.b.c", cookieManager.hashKey, cookieManager.blockKey)
+			assert.NoError(t, err)
+			req.AddCookie(&cookie)
+		}
+
+		w := httptest.NewRecorder()
+		handler(w, req)
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Len(t, w.Result().Cookies(), 4)
+		location, err := url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.Equal(t, issuer+"/logout", location.Scheme+"://"+location.Host+location.Path)
+		assert.Equal(t, "bar", location.Query().Get("foo"))
+		assert.Equal(t, "https://flyte.example.com/console", location.Query().Get("post_logout_redirect_uri"))
+		assert.Equal(t, "a.b.c", location.Query().Get("id_token_hint"))
+	})
+
+	t.Run("without id token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/logout", nil))
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		location, err := url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.Equal(t, "client", location.Query().Get("client_id"))
+		assert.Empty(t, location.Query().Get("id_token_hint"))
+	})
+}
+
+func TestGetCallbackHandler_CookieOptions(t *testing.T) {
+	ctx := context.Background()
+	var authorizeURL *url.URL
+	_, mux, provider, newIDToken := newTestIdP(t, "client")
+	mux.HandleFunc(oauth2TokenURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, fmt.Sprintf(`{"access_token":"access","token_type":"Bearer","expires_in":3600,
+			"refresh_token":"refresh","id_token":"%s"}`, newIDToken(jwtgo.MapClaims{"nonce": authorizeURL.Query().Get("nonce")})))
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"subject":"user"}`)
+	})
+
+	cookieOptions := config.CookieOptions{
+		Domain:     "corp.example.com",
+		SameSite:   "None",
+		Secure:     true,
+		MaxAge:     stdConfig.Duration{Duration: 24 * time.Hour},
+		NamePrefix: "dev_",
+	}
+
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, cookieOptions)
+	assert.NoError(t, err)
+
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockAuthCtx.OnOptions().Return(&config.Config{
+		UserAuth: config.UserAuthConfig{
+			OpenID:  config.OpenIDOptions{ClientID: "client"},
+			Cookies: cookieOptions,
+		},
+	})
+	mockAuthCtx.OnCookieManager().Return(&cookieManager)
+	mockAuthCtx.OnOidcProvider().Return(provider)
+	mockAuthCtx.OnOAuth2ClientConfigMatch(mock.Anything).Return(&oauth2.Config{
+		ClientID: "client",
+		Endpoint: provider.Endpoint(),
+	})
+
+	login := httptest.NewRecorder()
+	GetLoginHandler(ctx, mockAuthCtx)(login, httptest.NewRequest(http.MethodGet, "/login", nil))
+	assert.Equal(t, http.StatusTemporaryRedirect, login.Code)
+	authorizeURL, err = url.Parse(login.Header().Get("Location"))
+	assert.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+authorizeURL.Query().Get("state"), nil)
+	for _, cookie := range login.Result().Cookies() {
+		request.AddCookie(cookie)
+	}
+
+	callback := httptest.NewRecorder()
+	GetCallbackHandler(ctx, mockAuthCtx, promutils.NewTestScope())(callback, request)
+	assert.Equal(t, http.StatusTemporaryRedirect, callback.Code)
+
+	setCookies := map[string]string{}
+	for _, header := range callback.Header()["Set-Cookie"] {
+		setCookies[strings.SplitN(header, "=", 2)[0]] = header
+	}
+
+	for _, name := range []string{"dev_flyte_at", "dev_flyte_idt", "dev_flyte_rt", "dev_flyte_user_info"} {
+		header, found := setCookies[name]
+		if assert.True(t, found, "missing Set-Cookie for %v", name) {
+			assert.Contains(t, header, "; Path=/")
+			assert.Contains(t, header, "; Domain=corp.example.com")
+			assert.Contains(t, header, "; Max-Age=86400")
+			assert.Contains(t, header, "; Secure")
+			assert.Contains(t, header, "; SameSite=None")
+		}
+	}
+
+	// The login state is cleared once used, on the same domain it was set on.
+	header, found := setCookies["dev_flyte_login_state"]
+	if assert.True(t, found) {
+		assert.Contains(t, header, "; Domain=corp.example.com")
+		assert.Contains(t, header, "; Max-Age=0")
+	}
+}
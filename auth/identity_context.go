@@ -25,6 +25,7 @@ type IdentityContext struct {
 	userInfo        *service.UserInfoResponse
 	// Set to pointer just to keep this struct go-simple to support equal operator
 	scopes *sets.String
+	groups *sets.String
 }
 
 func (c IdentityContext) Audience() string {
@@ -59,6 +60,21 @@ func (c IdentityContext) Scopes() sets.String {
 	return sets.NewString()
 }
 
+// Groups returns the groups the user belongs to, as reported by the IdP.
+func (c IdentityContext) Groups() sets.String {
+	if c.groups != nil {
+		return *c.groups
+	}
+
+	return sets.NewString()
+}
+
+// WithGroups returns a copy of the IdentityContext carrying the given groups.
+func (c IdentityContext) WithGroups(groups sets.String) IdentityContext {
+	c.groups = &groups
+	return c
+}
+
 func (c IdentityContext) WithContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, ContextKeyIdentityContext, c)
 }
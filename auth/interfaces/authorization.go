@@ -0,0 +1,31 @@
+package interfaces
+
+import "context"
+
+// ResourceScope identifies the project and domain a request acts on. Both are empty for requests that aren't scoped to
+// a project, Domain is empty for requests that act on a whole project.
+type ResourceScope struct {
+	Project string
+	Domain  string
+}
+
+// IsGlobal returns true if the request isn't scoped to a project.
+func (s ResourceScope) IsGlobal() bool {
+	return len(s.Project) == 0
+}
+
+// AuthorizationPolicy decides whether an authenticated user or app can call a gRPC method.
+type AuthorizationPolicy interface {
+	// IsAuthorized returns true if identity can call the fully qualified gRPC method on resources in scope.
+	IsAuthorized(ctx context.Context, identity IdentityContext, fullMethod string, scope ResourceScope) (bool, error)
+}
+
+// ProjectAuthorizationPolicy is implemented by AuthorizationPolicies which can tell the projects a caller can call a
+// method on, so that requests spanning projects can be limited to those.
+type ProjectAuthorizationPolicy interface {
+	AuthorizationPolicy
+	// AuthorizedProjects returns the projects identity can call the fully qualified gRPC method on in every domain, or
+	// all as true if it can call it regardless of the project.
+	AuthorizedProjects(ctx context.Context, identity IdentityContext, fullMethod string) (
+		projects []string, all bool, err error)
+}
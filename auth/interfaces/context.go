@@ -45,6 +45,9 @@ type AuthenticationContext interface {
 	OAuth2Provider() OAuth2Provider
 	OAuth2ResourceServer() OAuth2ResourceServer
 	OAuth2ClientConfig(requestURL *url.URL) *oauth2.Config
+	// ReloadOAuth2ClientConfig re-reads the client secret, e.g. after the IdP rejected the current one because it was
+	// rotated.
+	ReloadOAuth2ClientConfig(ctx context.Context) error
 	OidcProvider() *oidc.Provider
 	CookieManager() CookieHandler
 	Options() *config.Config
@@ -53,6 +56,16 @@ type AuthenticationContext interface {
 	GetHTTPClient() *http.Client
 	AuthMetadataService() service.AuthMetadataServiceServer
 	IdentityService() service.IdentityServiceServer
+	// IsAnonymousMethod returns true if the fully qualified gRPC method can be called without authentication.
+	IsAnonymousMethod(fullMethodName string) bool
+	// APIKeyAuthenticator returns nil unless authenticating with API keys is enabled.
+	APIKeyAuthenticator() APIKeyAuthenticator
+}
+
+// APIKeyAuthenticator authenticates machine clients that present a pre-shared API key as their bearer token.
+type APIKeyAuthenticator interface {
+	// Authenticate returns the identity of the principal the key was issued to.
+	Authenticate(ctx context.Context, key string) (IdentityContext, error)
 }
 
 // IdentityContext represents the authenticated identity and can be used to abstract the way the user/app authenticated
@@ -63,6 +76,7 @@ type IdentityContext interface {
 	UserInfo() *service.UserInfoResponse
 	AuthenticatedAt() time.Time
 	Scopes() sets.String
+	Groups() sets.String
 
 	IsEmpty() bool
 	WithContext(ctx context.Context) context.Context
@@ -3,12 +3,24 @@ package interfaces
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 
 	"golang.org/x/oauth2"
 )
 
+// LoginState holds the values generated when a user starts the login flow and that must be presented back on the
+// callback to complete it.
+type LoginState struct {
+	// State is round-tripped through the IdP as the state parameter to protect against login CSRF.
+	State string `json:"state"`
+	// Nonce is sent on the authorization request and must be echoed in the nonce claim of the issued id token.
+	Nonce string `json:"nonce"`
+	// ExpiresAt bounds how long after /login the callback can be completed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type CookieHandler interface {
 	SetTokenCookies(ctx context.Context, writer http.ResponseWriter, token *oauth2.Token) error
 	RetrieveTokenValues(ctx context.Context, request *http.Request) (idToken, accessToken, refreshToken string, err error)
@@ -23,5 +35,21 @@ type CookieHandler interface {
 	// RetrieveAuthCodeRequest retrieves the /authorize request url from stored cookie to complete the OAuth2 app auth
 	// flow.
 	RetrieveAuthCodeRequest(ctx context.Context, request *http.Request) (authRequestURL string, err error)
+
+	// SetCodeVerifierCookie stores, in a cookie, the PKCE code verifier generated for a login attempt so that it can be
+	// sent along with the authorization code when the user is redirected back to the callback.
+	SetCodeVerifierCookie(ctx context.Context, writer http.ResponseWriter, codeVerifier string) error
+
+	// RetrieveCodeVerifier retrieves the PKCE code verifier stored by SetCodeVerifierCookie.
+	RetrieveCodeVerifier(ctx context.Context, request *http.Request) (codeVerifier string, err error)
+
+	// SetLoginStateCookie stores, in an encrypted short-lived cookie, the state and nonce generated for a login attempt.
+	SetLoginStateCookie(ctx context.Context, writer http.ResponseWriter, loginState LoginState) error
+
+	// RetrieveLoginState retrieves the login state stored by SetLoginStateCookie.
+	RetrieveLoginState(ctx context.Context, request *http.Request) (LoginState, error)
+
+	// DeleteLoginStateCookies clears the cookies only needed to complete a login attempt.
+	DeleteLoginStateCookies(ctx context.Context, writer http.ResponseWriter)
 	DeleteCookies(ctx context.Context, writer http.ResponseWriter)
 }
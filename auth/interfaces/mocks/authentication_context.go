@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	http "net/http"
 
 	config "github.com/flyteorg/flyteadmin/auth/config"
@@ -25,6 +27,40 @@ type AuthenticationContext struct {
 	mock.Mock
 }
 
+type AuthenticationContext_APIKeyAuthenticator struct {
+	*mock.Call
+}
+
+func (_m AuthenticationContext_APIKeyAuthenticator) Return(_a0 interfaces.APIKeyAuthenticator) *AuthenticationContext_APIKeyAuthenticator {
+	return &AuthenticationContext_APIKeyAuthenticator{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *AuthenticationContext) OnAPIKeyAuthenticator() *AuthenticationContext_APIKeyAuthenticator {
+	c := _m.On("APIKeyAuthenticator")
+	return &AuthenticationContext_APIKeyAuthenticator{Call: c}
+}
+
+func (_m *AuthenticationContext) OnAPIKeyAuthenticatorMatch(matchers ...interface{}) *AuthenticationContext_APIKeyAuthenticator {
+	c := _m.On("APIKeyAuthenticator", matchers...)
+	return &AuthenticationContext_APIKeyAuthenticator{Call: c}
+}
+
+// APIKeyAuthenticator provides a mock function with given fields:
+func (_m *AuthenticationContext) APIKeyAuthenticator() interfaces.APIKeyAuthenticator {
+	ret := _m.Called()
+
+	var r0 interfaces.APIKeyAuthenticator
+	if rf, ok := ret.Get(0).(func() interfaces.APIKeyAuthenticator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interfaces.APIKeyAuthenticator)
+		}
+	}
+
+	return r0
+}
+
 type AuthenticationContext_AuthMetadataService struct {
 	*mock.Call
 }
@@ -229,6 +265,38 @@ func (_m *AuthenticationContext) IdentityService() service.IdentityServiceServer
 	return r0
 }
 
+type AuthenticationContext_IsAnonymousMethod struct {
+	*mock.Call
+}
+
+func (_m AuthenticationContext_IsAnonymousMethod) Return(_a0 bool) *AuthenticationContext_IsAnonymousMethod {
+	return &AuthenticationContext_IsAnonymousMethod{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *AuthenticationContext) OnIsAnonymousMethod(fullMethodName string) *AuthenticationContext_IsAnonymousMethod {
+	c := _m.On("IsAnonymousMethod", fullMethodName)
+	return &AuthenticationContext_IsAnonymousMethod{Call: c}
+}
+
+func (_m *AuthenticationContext) OnIsAnonymousMethodMatch(matchers ...interface{}) *AuthenticationContext_IsAnonymousMethod {
+	c := _m.On("IsAnonymousMethod", matchers...)
+	return &AuthenticationContext_IsAnonymousMethod{Call: c}
+}
+
+// IsAnonymousMethod provides a mock function with given fields: fullMethodName
+func (_m *AuthenticationContext) IsAnonymousMethod(fullMethodName string) bool {
+	ret := _m.Called(fullMethodName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(fullMethodName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 type AuthenticationContext_OAuth2ClientConfig struct {
 	*mock.Call
 }
@@ -398,3 +466,35 @@ func (_m *AuthenticationContext) Options() *config.Config {
 
 	return r0
 }
+
+type AuthenticationContext_ReloadOAuth2ClientConfig struct {
+	*mock.Call
+}
+
+func (_m AuthenticationContext_ReloadOAuth2ClientConfig) Return(_a0 error) *AuthenticationContext_ReloadOAuth2ClientConfig {
+	return &AuthenticationContext_ReloadOAuth2ClientConfig{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *AuthenticationContext) OnReloadOAuth2ClientConfig(ctx context.Context) *AuthenticationContext_ReloadOAuth2ClientConfig {
+	c := _m.On("ReloadOAuth2ClientConfig", ctx)
+	return &AuthenticationContext_ReloadOAuth2ClientConfig{Call: c}
+}
+
+func (_m *AuthenticationContext) OnReloadOAuth2ClientConfigMatch(matchers ...interface{}) *AuthenticationContext_ReloadOAuth2ClientConfig {
+	c := _m.On("ReloadOAuth2ClientConfig", matchers...)
+	return &AuthenticationContext_ReloadOAuth2ClientConfig{Call: c}
+}
+
+// ReloadOAuth2ClientConfig provides a mock function with given fields: ctx
+func (_m *AuthenticationContext) ReloadOAuth2ClientConfig(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
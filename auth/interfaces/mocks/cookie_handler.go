@@ -6,6 +6,8 @@ import (
 	context "context"
 	http "net/http"
 
+	interfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+
 	mock "github.com/stretchr/testify/mock"
 
 	oauth2 "golang.org/x/oauth2"
@@ -23,6 +25,11 @@ func (_m *CookieHandler) DeleteCookies(ctx context.Context, writer http.Response
 	_m.Called(ctx, writer)
 }
 
+// DeleteLoginStateCookies provides a mock function with given fields: ctx, writer
+func (_m *CookieHandler) DeleteLoginStateCookies(ctx context.Context, writer http.ResponseWriter) {
+	_m.Called(ctx, writer)
+}
+
 type CookieHandler_RetrieveAuthCodeRequest struct {
 	*mock.Call
 }
@@ -62,6 +69,84 @@ func (_m *CookieHandler) RetrieveAuthCodeRequest(ctx context.Context, request *h
 	return r0, r1
 }
 
+type CookieHandler_RetrieveCodeVerifier struct {
+	*mock.Call
+}
+
+func (_m CookieHandler_RetrieveCodeVerifier) Return(codeVerifier string, err error) *CookieHandler_RetrieveCodeVerifier {
+	return &CookieHandler_RetrieveCodeVerifier{Call: _m.Call.Return(codeVerifier, err)}
+}
+
+func (_m *CookieHandler) OnRetrieveCodeVerifier(ctx context.Context, request *http.Request) *CookieHandler_RetrieveCodeVerifier {
+	c := _m.On("RetrieveCodeVerifier", ctx, request)
+	return &CookieHandler_RetrieveCodeVerifier{Call: c}
+}
+
+func (_m *CookieHandler) OnRetrieveCodeVerifierMatch(matchers ...interface{}) *CookieHandler_RetrieveCodeVerifier {
+	c := _m.On("RetrieveCodeVerifier", matchers...)
+	return &CookieHandler_RetrieveCodeVerifier{Call: c}
+}
+
+// RetrieveCodeVerifier provides a mock function with given fields: ctx, request
+func (_m *CookieHandler) RetrieveCodeVerifier(ctx context.Context, request *http.Request) (string, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request) string); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *http.Request) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type CookieHandler_RetrieveLoginState struct {
+	*mock.Call
+}
+
+func (_m CookieHandler_RetrieveLoginState) Return(_a0 interfaces.LoginState, _a1 error) *CookieHandler_RetrieveLoginState {
+	return &CookieHandler_RetrieveLoginState{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *CookieHandler) OnRetrieveLoginState(ctx context.Context, request *http.Request) *CookieHandler_RetrieveLoginState {
+	c := _m.On("RetrieveLoginState", ctx, request)
+	return &CookieHandler_RetrieveLoginState{Call: c}
+}
+
+func (_m *CookieHandler) OnRetrieveLoginStateMatch(matchers ...interface{}) *CookieHandler_RetrieveLoginState {
+	c := _m.On("RetrieveLoginState", matchers...)
+	return &CookieHandler_RetrieveLoginState{Call: c}
+}
+
+// RetrieveLoginState provides a mock function with given fields: ctx, request
+func (_m *CookieHandler) RetrieveLoginState(ctx context.Context, request *http.Request) (interfaces.LoginState, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 interfaces.LoginState
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request) interfaces.LoginState); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(interfaces.LoginState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *http.Request) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type CookieHandler_RetrieveTokenValues struct {
 	*mock.Call
 }
@@ -188,6 +273,70 @@ func (_m *CookieHandler) SetAuthCodeCookie(ctx context.Context, writer http.Resp
 	return r0
 }
 
+type CookieHandler_SetCodeVerifierCookie struct {
+	*mock.Call
+}
+
+func (_m CookieHandler_SetCodeVerifierCookie) Return(_a0 error) *CookieHandler_SetCodeVerifierCookie {
+	return &CookieHandler_SetCodeVerifierCookie{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *CookieHandler) OnSetCodeVerifierCookie(ctx context.Context, writer http.ResponseWriter, codeVerifier string) *CookieHandler_SetCodeVerifierCookie {
+	c := _m.On("SetCodeVerifierCookie", ctx, writer, codeVerifier)
+	return &CookieHandler_SetCodeVerifierCookie{Call: c}
+}
+
+func (_m *CookieHandler) OnSetCodeVerifierCookieMatch(matchers ...interface{}) *CookieHandler_SetCodeVerifierCookie {
+	c := _m.On("SetCodeVerifierCookie", matchers...)
+	return &CookieHandler_SetCodeVerifierCookie{Call: c}
+}
+
+// SetCodeVerifierCookie provides a mock function with given fields: ctx, writer, codeVerifier
+func (_m *CookieHandler) SetCodeVerifierCookie(ctx context.Context, writer http.ResponseWriter, codeVerifier string) error {
+	ret := _m.Called(ctx, writer, codeVerifier)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, http.ResponseWriter, string) error); ok {
+		r0 = rf(ctx, writer, codeVerifier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type CookieHandler_SetLoginStateCookie struct {
+	*mock.Call
+}
+
+func (_m CookieHandler_SetLoginStateCookie) Return(_a0 error) *CookieHandler_SetLoginStateCookie {
+	return &CookieHandler_SetLoginStateCookie{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *CookieHandler) OnSetLoginStateCookie(ctx context.Context, writer http.ResponseWriter, loginState interfaces.LoginState) *CookieHandler_SetLoginStateCookie {
+	c := _m.On("SetLoginStateCookie", ctx, writer, loginState)
+	return &CookieHandler_SetLoginStateCookie{Call: c}
+}
+
+func (_m *CookieHandler) OnSetLoginStateCookieMatch(matchers ...interface{}) *CookieHandler_SetLoginStateCookie {
+	c := _m.On("SetLoginStateCookie", matchers...)
+	return &CookieHandler_SetLoginStateCookie{Call: c}
+}
+
+// SetLoginStateCookie provides a mock function with given fields: ctx, writer, loginState
+func (_m *CookieHandler) SetLoginStateCookie(ctx context.Context, writer http.ResponseWriter, loginState interfaces.LoginState) error {
+	ret := _m.Called(ctx, writer, loginState)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, http.ResponseWriter, interfaces.LoginState) error); ok {
+		r0 = rf(ctx, writer, loginState)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type CookieHandler_SetTokenCookies struct {
 	*mock.Call
 }
@@ -83,6 +83,40 @@ func (_m *IdentityContext) AuthenticatedAt() time.Time {
 	return r0
 }
 
+type IdentityContext_Groups struct {
+	*mock.Call
+}
+
+func (_m IdentityContext_Groups) Return(_a0 sets.String) *IdentityContext_Groups {
+	return &IdentityContext_Groups{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *IdentityContext) OnGroups() *IdentityContext_Groups {
+	c := _m.On("Groups")
+	return &IdentityContext_Groups{Call: c}
+}
+
+func (_m *IdentityContext) OnGroupsMatch(matchers ...interface{}) *IdentityContext_Groups {
+	c := _m.On("Groups", matchers...)
+	return &IdentityContext_Groups{Call: c}
+}
+
+// Groups provides a mock function with given fields:
+func (_m *IdentityContext) Groups() sets.String {
+	ret := _m.Called()
+
+	var r0 sets.String
+	if rf, ok := ret.Get(0).(func() sets.String); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sets.String)
+		}
+	}
+
+	return r0
+}
+
 type IdentityContext_IsEmpty struct {
 	*mock.Call
 }
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// #nosec
+	ErrInvalidLoginState errors.ErrorCode = "LOGIN_STATE_VALIDATION_FAILED"
+
+	// LoginStateTTL bounds how long users have to complete the login at the IdP before the callback is rejected.
+	LoginStateTTL = 10 * time.Minute
+
+	loginStateTokenBytes = 32
+)
+
+// Reasons a callback can be rejected for, used to label the validation failures metric.
+const (
+	loginStateMissing  = "missing"
+	loginStateExpired  = "expired"
+	loginStateMismatch = "mismatch"
+	loginStateReplayed = "replayed"
+	loginStateNonce    = "nonce_mismatch"
+)
+
+func newRandomToken() (string, error) {
+	raw := make([]byte, loginStateTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// NewLoginState generates a random state and nonce for a new login attempt.
+func NewLoginState() (interfaces.LoginState, error) {
+	state, err := newRandomToken()
+	if err != nil {
+		return interfaces.LoginState{}, errors.Wrapf(ErrInvalidLoginState, err, "failed to generate state")
+	}
+
+	nonce, err := newRandomToken()
+	if err != nil {
+		return interfaces.LoginState{}, errors.Wrapf(ErrInvalidLoginState, err, "failed to generate nonce")
+	}
+
+	return interfaces.LoginState{
+		State:     state,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().Add(LoginStateTTL),
+	}, nil
+}
+
+// consumedLoginStates remembers the states of callbacks that went through until they expire, so that a callback
+// can't be replayed with a copy of the login state cookie. The record is kept in memory and isn't shared between
+// replicas, the IdP refusing to exchange the same authorization code twice covers replays across replicas.
+type consumedLoginStates struct {
+	lock   sync.Mutex
+	states map[string]time.Time
+}
+
+// consume records state as used and returns false if it was already used.
+func (c *consumedLoginStates) consume(state string, expiresAt time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for s, exp := range c.states {
+		if now.After(exp) {
+			delete(c.states, s)
+		}
+	}
+
+	if _, found := c.states[state]; found {
+		return false
+	}
+
+	c.states[state] = expiresAt
+	return true
+}
+
+// loginStateValidator validates the login state presented on callbacks against the one stored at /login time.
+type loginStateValidator struct {
+	consumed           *consumedLoginStates
+	validationFailures *prometheus.CounterVec
+}
+
+func (v *loginStateValidator) reject(reason, format string, args ...interface{}) error {
+	v.validationFailures.WithLabelValues(reason).Inc()
+	return errors.Errorf(ErrInvalidLoginState, format, args...)
+}
+
+// VerifyState retrieves the login state cookie and checks it matches the state returned by the IdP. A login state
+// is only accepted once.
+func (v *loginStateValidator) VerifyState(ctx context.Context, request *http.Request,
+	cookieHandler interfaces.CookieHandler) (interfaces.LoginState, error) {
+
+	loginState, err := cookieHandler.RetrieveLoginState(ctx, request)
+	if err != nil {
+		return interfaces.LoginState{}, v.reject(loginStateMissing, "Could not read login state cookie. Error: %v", err)
+	}
+
+	if time.Now().After(loginState.ExpiresAt) {
+		return interfaces.LoginState{}, v.reject(loginStateExpired, "Login state expired at %v", loginState.ExpiresAt)
+	}
+
+	state := request.FormValue(CsrfFormKey)
+	if len(state) == 0 || subtle.ConstantTimeCompare([]byte(state), []byte(loginState.State)) != 1 {
+		return interfaces.LoginState{}, v.reject(loginStateMismatch, "State in callback does not match the login state")
+	}
+
+	if !v.consumed.consume(loginState.State, loginState.ExpiresAt) {
+		return interfaces.LoginState{}, v.reject(loginStateReplayed, "Login state was already used")
+	}
+
+	logger.Debugf(ctx, "Login state verified")
+	return loginState, nil
+}
+
+// VerifyNonce checks the id token was issued for the login attempt the login state was generated for.
+func (v *loginStateValidator) VerifyNonce(idToken *oidc.IDToken, loginState interfaces.LoginState) error {
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(loginState.Nonce)) != 1 {
+		return v.reject(loginStateNonce, "Nonce in id token does not match the login state")
+	}
+
+	return nil
+}
+
+func newLoginStateValidator(scope promutils.Scope) *loginStateValidator {
+	return &loginStateValidator{
+		consumed: &consumedLoginStates{
+			states: map[string]time.Time{},
+		},
+		validationFailures: scope.MustNewCounterVec("login_state_validation_failures",
+			"callbacks rejected because their login state failed validation", "reason"),
+	}
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCookieManager(t *testing.T) CookieManager {
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(context.Background(), hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
+	return cookieManager
+}
+
+// Builds a callback request carrying the given login state in its cookie and state as the state parameter.
+func newTestCallbackRequest(t *testing.T, cookieManager CookieManager, loginState interfaces.LoginState,
+	state string) *http.Request {
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, cookieManager.SetLoginStateCookie(context.Background(), recorder, loginState))
+	request := httptest.NewRequest(http.MethodGet, "/callback?"+url.Values{CsrfFormKey: {state}}.Encode(), nil)
+	for _, cookie := range recorder.Result().Cookies() {
+		request.AddCookie(cookie)
+	}
+
+	return request
+}
+
+func TestNewLoginState(t *testing.T) {
+	loginState, err := NewLoginState()
+	assert.NoError(t, err)
+	assert.Len(t, loginState.State, 43)
+	assert.Len(t, loginState.Nonce, 43)
+	assert.NotEqual(t, loginState.State, loginState.Nonce)
+	assert.WithinDuration(t, time.Now().Add(LoginStateTTL), loginState.ExpiresAt, time.Minute)
+
+	other, err := NewLoginState()
+	assert.NoError(t, err)
+	assert.NotEqual(t, loginState.State, other.State)
+}
+
+func TestCookieManager_LoginStateCookie(t *testing.T) {
+	ctx := context.Background()
+	cookieManager := newTestCookieManager(t)
+	loginState, err := NewLoginState()
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, cookieManager.SetLoginStateCookie(ctx, recorder, loginState))
+	cookies := recorder.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, loginStateCookieName, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	// The cookie is encrypted, neither value can be read off it.
+	assert.NotContains(t, cookies[0].Value, loginState.State)
+	assert.NotContains(t, cookies[0].Value, loginState.Nonce)
+
+	request := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	request.AddCookie(cookies[0])
+	retrieved, err := cookieManager.RetrieveLoginState(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, loginState.State, retrieved.State)
+	assert.Equal(t, loginState.Nonce, retrieved.Nonce)
+	assert.True(t, loginState.ExpiresAt.Equal(retrieved.ExpiresAt))
+}
+
+func TestLoginStateValidator_VerifyState(t *testing.T) {
+	ctx := context.Background()
+	cookieManager := newTestCookieManager(t)
+
+	t.Run("valid", func(t *testing.T) {
+		validator := newLoginStateValidator(promutils.NewTestScope())
+		loginState, err := NewLoginState()
+		assert.NoError(t, err)
+		verified, err := validator.VerifyState(ctx, newTestCallbackRequest(t, cookieManager, loginState, loginState.State),
+			cookieManager)
+		assert.NoError(t, err)
+		assert.Equal(t, loginState.Nonce, verified.Nonce)
+	})
+
+	t.Run("missing cookie", func(t *testing.T) {
+		validator := newLoginStateValidator(promutils.NewTestScope())
+		_, err := validator.VerifyState(ctx, httptest.NewRequest(http.MethodGet, "/callback?state=abc", nil),
+			cookieManager)
+		assert.Error(t, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(validator.validationFailures.WithLabelValues(loginStateMissing)))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		validator := newLoginStateValidator(promutils.NewTestScope())
+		loginState, err := NewLoginState()
+		assert.NoError(t, err)
+		_, err = validator.VerifyState(ctx, newTestCallbackRequest(t, cookieManager, loginState, "forged"), cookieManager)
+		assert.Error(t, err)
+		_, err = validator.VerifyState(ctx, newTestCallbackRequest(t, cookieManager, loginState, ""), cookieManager)
+		assert.Error(t, err)
+		assert.Equal(t, float64(2), testutil.ToFloat64(validator.validationFailures.WithLabelValues(loginStateMismatch)))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		validator := newLoginStateValidator(promutils.NewTestScope())
+		loginState, err := NewLoginState()
+		assert.NoError(t, err)
+		loginState.ExpiresAt = time.Now().Add(-time.Second)
+		_, err = validator.VerifyState(ctx, newTestCallbackRequest(t, cookieManager, loginState, loginState.State),
+			cookieManager)
+		assert.Error(t, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(validator.validationFailures.WithLabelValues(loginStateExpired)))
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		validator := newLoginStateValidator(promutils.NewTestScope())
+		loginState, err := NewLoginState()
+		assert.NoError(t, err)
+		request := newTestCallbackRequest(t, cookieManager, loginState, loginState.State)
+		_, err = validator.VerifyState(ctx, request, cookieManager)
+		assert.NoError(t, err)
+		_, err = validator.VerifyState(ctx, request, cookieManager)
+		assert.Error(t, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(validator.validationFailures.WithLabelValues(loginStateReplayed)))
+	})
+}
+
+func TestLoginStateValidator_VerifyNonce(t *testing.T) {
+	validator := newLoginStateValidator(promutils.NewTestScope())
+	loginState := interfaces.LoginState{Nonce: "nonce"}
+	assert.NoError(t, validator.VerifyNonce(&oidc.IDToken{Nonce: "nonce"}, loginState))
+	assert.Error(t, validator.VerifyNonce(&oidc.IDToken{Nonce: "other"}, loginState))
+	assert.Error(t, validator.VerifyNonce(&oidc.IDToken{}, loginState))
+	assert.Equal(t, float64(2), testutil.ToFloat64(validator.validationFailures.WithLabelValues(loginStateNonce)))
+}
+
+func TestConsumedLoginStates_PrunesExpired(t *testing.T) {
+	consumed := &consumedLoginStates{states: map[string]time.Time{}}
+	assert.True(t, consumed.consume("expired", time.Now().Add(-time.Second)))
+	assert.True(t, consumed.consume("live", time.Now().Add(time.Minute)))
+	assert.NotContains(t, consumed.states, "expired")
+	assert.False(t, consumed.consume("live", time.Now().Add(time.Minute)))
+}
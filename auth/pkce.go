@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/flyteorg/flytestdlib/errors"
+	"golang.org/x/oauth2"
+)
+
+const (
+	ErrCodeVerifier errors.ErrorCode = "CODE_VERIFIER_FAILURE"
+
+	codeChallengeParam       = "code_challenge"
+	codeChallengeMethodParam = "code_challenge_method"
+	codeVerifierParam        = "code_verifier"
+	codeChallengeMethodS256  = "S256"
+
+	// 32 random bytes encode into a 43 characters verifier, the minimum length allowed.
+	codeVerifierBytes = 32
+)
+
+// NewCodeVerifier generates a random PKCE code verifier.
+// See https://tools.ietf.org/html/rfc7636#section-4.1 for more information.
+func NewCodeVerifier() (string, error) {
+	raw := make([]byte, codeVerifierBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.Wrapf(ErrCodeVerifier, err, "failed to generate code verifier")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// CodeChallengeS256 derives the S256 code challenge of a code verifier.
+// See https://tools.ietf.org/html/rfc7636#section-4.2 for more information.
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// codeChallengeOptions returns the parameters to add to the authorization request for the given code verifier.
+func codeChallengeOptions(codeVerifier string) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam(codeChallengeParam, CodeChallengeS256(codeVerifier)),
+		oauth2.SetAuthURLParam(codeChallengeMethodParam, codeChallengeMethodS256),
+	}
+}
+
+// codeVerifierOption returns the parameter to add to the token exchange request for the given code verifier.
+func codeVerifierOption(codeVerifier string) oauth2.AuthCodeOption {
+	return oauth2.SetAuthURLParam(codeVerifierParam, codeVerifier)
+}
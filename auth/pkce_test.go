@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/coreos/go-oidc"
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/oauth2"
+)
+
+func TestCodeChallengeS256(t *testing.T) {
+	// Test vector from https://tools.ietf.org/html/rfc7636#appendix-B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestNewCodeVerifier(t *testing.T) {
+	codeVerifier, err := NewCodeVerifier()
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`), codeVerifier)
+
+	other, err := NewCodeVerifier()
+	assert.NoError(t, err)
+	assert.NotEqual(t, codeVerifier, other)
+}
+
+// Starts a fake IdP whose token endpoint only issues tokens when the code verifier matches the challenge sent on the
+// authorization request. The issued id token carries the nonce sent on the authorization request.
+func newPKCETestIdP(t *testing.T, authorizeURL **url.URL) *oidc.Provider {
+	_, mux, provider, newIDToken := newTestIdP(t, "client")
+	mux.HandleFunc(oauth2TokenURL, func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		query := (*authorizeURL).Query()
+		if CodeChallengeS256(r.PostForm.Get(codeVerifierParam)) != query.Get(codeChallengeParam) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     newIDToken(jwtgo.MapClaims{"nonce": query.Get("nonce")}),
+		}))
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"subject":"user"}`))
+	})
+
+	return provider
+}
+
+func TestLoginAndCallbackWithPKCE(t *testing.T) {
+	ctx := context.Background()
+	var authorizeURL *url.URL
+	provider := newPKCETestIdP(t, &authorizeURL)
+
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
+
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockAuthCtx.OnOptions().Return(&config.Config{
+		UserAuth: config.UserAuthConfig{
+			OpenID: config.OpenIDOptions{
+				ClientID: "client",
+				UsePKCE:  true,
+			},
+		},
+	})
+	mockAuthCtx.OnCookieManager().Return(&cookieManager)
+	mockAuthCtx.OnOidcProvider().Return(provider)
+	mockAuthCtx.OnOAuth2ClientConfigMatch(mock.Anything).Return(&oauth2.Config{
+		ClientID: "client",
+		Endpoint: provider.Endpoint(),
+	})
+
+	// Each attempt goes through /login since a login state can only be used once.
+	login := func(t *testing.T) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		GetLoginHandler(ctx, mockAuthCtx)(recorder, httptest.NewRequest(http.MethodGet, "/login", nil))
+		assert.Equal(t, http.StatusTemporaryRedirect, recorder.Code)
+		authorizeURL, err = url.Parse(recorder.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, authorizeURL.Query().Get(codeChallengeParam))
+		assert.Equal(t, codeChallengeMethodS256, authorizeURL.Query().Get(codeChallengeMethodParam))
+		return recorder
+	}
+
+	newCallbackRequest := func(login *httptest.ResponseRecorder, codeVerifierCookie *http.Cookie) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+authorizeURL.Query().Get("state"), nil)
+		for _, cookie := range login.Result().Cookies() {
+			if cookie.Name == codeVerifierCookieName && codeVerifierCookie != nil {
+				cookie = codeVerifierCookie
+			}
+
+			req.AddCookie(cookie)
+		}
+
+		return req
+	}
+
+	callbackHandler := GetCallbackHandler(ctx, mockAuthCtx, promutils.NewTestScope())
+	t.Run("matching verifier", func(t *testing.T) {
+		callback := httptest.NewRecorder()
+		callbackHandler(callback, newCallbackRequest(login(t), nil))
+		assert.Equal(t, http.StatusTemporaryRedirect, callback.Code)
+	})
+
+	t.Run("tampered verifier", func(t *testing.T) {
+		tampered, err := NewSecureCookie(codeVerifierCookieName, "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+			cookieManager.hashKey, cookieManager.blockKey)
+		assert.NoError(t, err)
+		callback := httptest.NewRecorder()
+		callbackHandler(callback, newCallbackRequest(login(t), &tampered))
+		assert.Equal(t, http.StatusForbidden, callback.Code)
+	})
+}
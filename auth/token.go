@@ -12,6 +12,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/flyteorg/flyteadmin/auth/config"
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 
 	"github.com/coreos/go-oidc"
@@ -96,7 +97,7 @@ func GRPCGetIdentityFromAccessToken(ctx context.Context, authCtx interfaces.Auth
 
 // GRPCGetIdentityFromIDToken attempts to extract a token from the context, and will then call the validation function,
 // passing up any errors.
-func GRPCGetIdentityFromIDToken(ctx context.Context, clientID string, provider *oidc.Provider) (
+func GRPCGetIdentityFromIDToken(ctx context.Context, options config.OpenIDOptions, provider *oidc.Provider) (
 	interfaces.IdentityContext, error) {
 
 	tokenStr, err := grpcauth.AuthFromMD(ctx, IDTokenScheme)
@@ -120,18 +121,63 @@ func GRPCGetIdentityFromIDToken(ctx context.Context, clientID string, provider *
 		}
 	}
 
-	return IdentityContextFromIDTokenToken(ctx, tokenStr, clientID, provider, userInfo)
+	return IdentityContextFromIDTokenToken(ctx, tokenStr, options, provider, userInfo)
 }
 
-func IdentityContextFromIDTokenToken(ctx context.Context, tokenStr, clientID string, provider *oidc.Provider,
-	userInfo *service.UserInfoResponse) (interfaces.IdentityContext, error) {
+// StringsFromClaim reads a claim that IdPs encode either as a single string or as a list of strings. Returns nil if the claim is
+// missing or has any other shape.
+func StringsFromClaim(claims map[string]interface{}, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		if len(value) == 0 {
+			return nil
+		}
+
+		return []string{value}
+	case []interface{}:
+		res := make([]string, 0, len(value))
+		for _, item := range value {
+			if str, ok := item.(string); ok && len(str) > 0 {
+				res = append(res, str)
+			}
+		}
+
+		return res
+	default:
+		return nil
+	}
+}
+
+// Picks the user id from the first configured claim present in the token, falling back to the subject.
+func userIDFromClaims(claims map[string]interface{}, userIDClaims []string, subject string) string {
+	for _, claim := range userIDClaims {
+		if values := StringsFromClaim(claims, claim); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return subject
+}
+
+func IdentityContextFromIDTokenToken(ctx context.Context, tokenStr string, options config.OpenIDOptions,
+	provider *oidc.Provider, userInfo *service.UserInfoResponse) (interfaces.IdentityContext, error) {
 
-	idToken, err := ParseIDTokenAndValidate(ctx, clientID, tokenStr, provider)
+	idToken, err := ParseIDTokenAndValidate(ctx, options.ClientID, tokenStr, provider)
 	if err != nil {
 		return nil, err
 	}
 
+	claims := map[string]interface{}{}
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, errors.Wrapf(ErrJwtValidation, err, "failed to read id token claims")
+	}
+
+	var groups []string
+	if len(options.GroupsClaim) > 0 {
+		groups = StringsFromClaim(claims, options.GroupsClaim)
+	}
+
 	// TODO: Document why automatically specify "all" scope
-	return NewIdentityContext(idToken.Audience[0], idToken.Subject, "", idToken.IssuedAt,
-		sets.NewString(ScopeAll), userInfo), nil
+	return NewIdentityContext(idToken.Audience[0], userIDFromClaims(claims, options.UserIDClaims, idToken.Subject), "",
+		idToken.IssuedAt, sets.NewString(ScopeAll), userInfo).WithGroups(sets.NewString(groups...)), nil
 }
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flytestdlib/logger"
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+// TokenRefresher refreshes expired tokens stored in cookies before requests reach the grpc-gateway. Without it, only
+// the /login handler refreshes tokens and console users get Unauthenticated errors once their id token expires even
+// though their refresh token is still valid.
+type TokenRefresher struct {
+	authCtx interfaces.AuthenticationContext
+	// Deduplicates concurrent refreshes of the same refresh token so that parallel requests carrying the same expired
+	// cookies only hit the IdP once.
+	group singleflight.Group
+}
+
+// Returns true if the raw JWT carries an expiry in the past. The signature is not verified here, the authentication
+// interceptor validates whichever token ends up being forwarded.
+func isTokenExpired(rawToken string) bool {
+	claims := jwtgo.StandardClaims{}
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(rawToken, &claims); err != nil {
+		return false
+	}
+
+	return claims.ExpiresAt > 0 && !claims.VerifyExpiresAt(time.Now().Unix(), true)
+}
+
+func (r *TokenRefresher) refresh(ctx context.Context, request *http.Request, accessToken, refreshToken string) (
+	*oauth2.Token, error) {
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(refreshToken)))
+	result, err, shared := r.group.Do(key, func() (interface{}, error) {
+		return withClientConfigReload(ctx, r.authCtx, GetPublicURL(ctx, request, r.authCtx.Options()),
+			func(cfg *oauth2.Config) (*oauth2.Token, error) {
+				return GetRefreshedToken(ctx, cfg, accessToken, refreshToken)
+			})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if shared {
+		logger.Debugf(ctx, "Reusing tokens refreshed by a concurrent request")
+	}
+
+	return result.(*oauth2.Token), nil
+}
+
+// Handler wraps next so that requests carrying an expired id token and a refresh token in their cookies get new
+// tokens first. The refreshed cookies are set on the response and the new id token is passed on through the request
+// context, where GetHTTPRequestCookieToMetadataHandler picks it up. If refreshing fails, the request proceeds with the
+// original cookies and fails authentication as usual, which prompts the client to go through /login.
+func (r *TokenRefresher) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		idToken, accessToken, refreshToken, err := r.authCtx.CookieManager().RetrieveTokenValues(ctx, request)
+		if err != nil || len(refreshToken) == 0 || !isTokenExpired(idToken) {
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		logger.Debugf(ctx, "Expired id token found while requesting %s, attempting to refresh", request.RequestURI)
+		newToken, err := r.refresh(ctx, request, accessToken, refreshToken)
+		if err != nil {
+			logger.Infof(ctx, "Failed to refresh tokens. Error: %v", err)
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		newIDToken, ok := newToken.Extra(idTokenExtra).(string)
+		if !ok {
+			logger.Infof(ctx, "Refreshed tokens do not contain an id_token.")
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		if err = r.authCtx.CookieManager().SetTokenCookies(ctx, writer, newToken); err != nil {
+			logger.Infof(ctx, "Failed to set refreshed token cookies. Error: %v", err)
+			next.ServeHTTP(writer, request)
+			return
+		}
+
+		next.ServeHTTP(writer, request.WithContext(context.WithValue(ctx, ContextKeyRefreshedIDToken, newIDToken)))
+	})
+}
+
+func NewTokenRefresher(authCtx interfaces.AuthenticationContext) *TokenRefresher {
+	return &TokenRefresher{
+		authCtx: authCtx,
+	}
+}
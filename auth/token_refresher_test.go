@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/auth/interfaces/mocks"
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/oauth2"
+)
+
+func newTestIDToken(t *testing.T, expiresAt time.Time) string {
+	token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, jwtgo.StandardClaims{
+		Subject:   "user",
+		ExpiresAt: expiresAt.Unix(),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	return token
+}
+
+// Starts a fake IdP token endpoint and returns it along with the number of refresh requests it served.
+func newFakeTokenEndpoint(t *testing.T, status int, idToken string) (*httptest.Server, *int32) {
+	hits := new(int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "refresh", r.PostForm.Get("refresh_token"))
+		// Give concurrent requests a chance to pile up on the same refresh.
+		time.Sleep(100 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "new-access",
+			"token_type":    "Bearer",
+			"refresh_token": "new-refresh",
+			"expires_in":    3600,
+			"id_token":      idToken,
+		}))
+	}))
+	t.Cleanup(server.Close)
+	return server, hits
+}
+
+func setupTokenRefresher(t *testing.T, tokenURL string) (*mocks.AuthenticationContext, CookieManager) {
+	ctx := context.Background()
+	// These were generated for unit testing only.
+	hashKeyEncoded := "wG4pE1ccdw/pHZ2ml8wrD5VJkOtLPmBpWbKHmezWXktGaFbRoAhXidWs8OpbA3y7N8vyZhz1B1E37+tShWC7gA" //nolint:goconst
+	blockKeyEncoded := "afyABVgGOvWJFxVyOvCWCupoTn6BkNl4SOHmahho16Q"                                           //nolint:goconst
+	cookieManager, err := NewCookieManager(ctx, hashKeyEncoded, blockKeyEncoded, config.CookieOptions{})
+	assert.NoError(t, err)
+
+	mockAuthCtx := &mocks.AuthenticationContext{}
+	mockAuthCtx.OnCookieManager().Return(&cookieManager)
+	mockAuthCtx.OnOptions().Return(&config.Config{})
+	mockAuthCtx.OnOAuth2ClientConfigMatch(mock.Anything).Return(&oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	})
+	return mockAuthCtx, cookieManager
+}
+
+func newRequestWithTokenCookies(t *testing.T, cookieManager CookieManager, idToken string) *http.Request {
+	token := (&oauth2.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+	}).WithExtra(map[string]interface{}{
+		idTokenExtra: idToken,
+	})
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, cookieManager.SetTokenCookies(context.Background(), recorder, token))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+	for _, cookie := range recorder.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+
+	return req
+}
+
+// Returns a handler recording the authorization metadata the grpc-gateway would forward for each request.
+func newAuthorizationRecorder(authCtx *mocks.AuthenticationContext, authorization *sync.Map) http.Handler {
+	annotator := GetHTTPRequestCookieToMetadataHandler(authCtx)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r, annotator(r.Context(), r).Get(DefaultAuthorizationHeader)[0])
+	})
+}
+
+func TestTokenRefresher_RefreshesExpiredToken(t *testing.T) {
+	freshIDToken := newTestIDToken(t, time.Now().Add(time.Hour))
+	server, hits := newFakeTokenEndpoint(t, http.StatusOK, freshIDToken)
+	authCtx, cookieManager := setupTokenRefresher(t, server.URL)
+
+	authorization := &sync.Map{}
+	handler := NewTokenRefresher(authCtx).Handler(newAuthorizationRecorder(authCtx, authorization))
+	req := newRequestWithTokenCookies(t, cookieManager, newTestIDToken(t, time.Now().Add(-time.Hour)))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+	forwarded := ""
+	authorization.Range(func(_, value interface{}) bool {
+		forwarded = value.(string)
+		return false
+	})
+	assert.Equal(t, IDTokenScheme+" "+freshIDToken, forwarded)
+
+	refreshedReq := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+	for _, cookie := range recorder.Result().Cookies() {
+		refreshedReq.AddCookie(cookie)
+	}
+
+	idToken, accessToken, refreshToken, err := cookieManager.RetrieveTokenValues(context.Background(), refreshedReq)
+	assert.NoError(t, err)
+	assert.Equal(t, freshIDToken, idToken)
+	assert.Equal(t, "new-access", accessToken)
+	assert.Equal(t, "new-refresh", refreshToken)
+}
+
+func TestTokenRefresher_ValidTokenNotRefreshed(t *testing.T) {
+	server, hits := newFakeTokenEndpoint(t, http.StatusOK, "")
+	authCtx, cookieManager := setupTokenRefresher(t, server.URL)
+
+	validIDToken := newTestIDToken(t, time.Now().Add(time.Hour))
+	authorization := &sync.Map{}
+	handler := NewTokenRefresher(authCtx).Handler(newAuthorizationRecorder(authCtx, authorization))
+	req := newRequestWithTokenCookies(t, cookieManager, validIDToken)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(hits))
+	assert.Empty(t, recorder.Result().Cookies())
+	forwarded, _ := authorization.Load(req)
+	assert.Equal(t, IDTokenScheme+" "+validIDToken, forwarded)
+}
+
+func TestTokenRefresher_RefreshFailureFallsThrough(t *testing.T) {
+	server, hits := newFakeTokenEndpoint(t, http.StatusBadRequest, "")
+	authCtx, cookieManager := setupTokenRefresher(t, server.URL)
+
+	expiredIDToken := newTestIDToken(t, time.Now().Add(-time.Hour))
+	authorization := &sync.Map{}
+	handler := NewTokenRefresher(authCtx).Handler(newAuthorizationRecorder(authCtx, authorization))
+	req := newRequestWithTokenCookies(t, cookieManager, expiredIDToken)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+	assert.Empty(t, recorder.Result().Cookies())
+	// The expired token is forwarded so that the authentication interceptor rejects the request.
+	forwarded, _ := authorization.Load(req)
+	assert.Equal(t, IDTokenScheme+" "+expiredIDToken, forwarded)
+}
+
+func TestTokenRefresher_ConcurrentRequestsRefreshOnce(t *testing.T) {
+	freshIDToken := newTestIDToken(t, time.Now().Add(time.Hour))
+	server, hits := newFakeTokenEndpoint(t, http.StatusOK, freshIDToken)
+	authCtx, cookieManager := setupTokenRefresher(t, server.URL)
+
+	authorization := &sync.Map{}
+	handler := NewTokenRefresher(authCtx).Handler(newAuthorizationRecorder(authCtx, authorization))
+	expiredIDToken := newTestIDToken(t, time.Now().Add(-time.Hour))
+
+	const parallelRequests = 5
+	start := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < parallelRequests; i++ {
+		req := newRequestWithTokenCookies(t, cookieManager, expiredIDToken)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(hits))
+	authorization.Range(func(_, value interface{}) bool {
+		assert.Equal(t, IDTokenScheme+" "+freshIDToken, value)
+		return true
+	})
+}
+
+func TestIsTokenExpired(t *testing.T) {
+	assert.True(t, isTokenExpired(newTestIDToken(t, time.Now().Add(-time.Minute))))
+	assert.False(t, isTokenExpired(newTestIDToken(t, time.Now().Add(time.Minute))))
+	assert.False(t, isTokenExpired("not a jwt"))
+}
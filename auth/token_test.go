@@ -2,11 +2,20 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/coreos/go-oidc"
+	"github.com/flyteorg/flyteadmin/auth/config"
+	jwtgo "github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +39,129 @@ func TestExpiredToken(t *testing.T) {
 	t.Log(err.Error())
 	assert.True(t, strings.Contains(err.Error(), "token is expired"))
 }
+
+// Starts a minimal OIdC provider serving discovery and key set documents. Returns the provider along with a function to
+// mint id tokens carrying the given claims on top of the standard ones.
+func newTestOIdCProvider(t *testing.T, clientID string) (*oidc.Provider, func(claims jwtgo.MapClaims) string) {
+	_, _, provider, newIDToken := newTestIdP(t, clientID)
+	return provider, newIDToken
+}
+
+// Starts a fake IdP serving its discovery document and signing keys. Tests can register the other endpoints they need
+// on the returned mux, the discovery document advertises /oauth2/token and /userinfo.
+func newTestIdP(t *testing.T, clientID string) (*httptest.Server, *http.ServeMux, *oidc.Provider,
+	func(claims jwtgo.MapClaims) string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/oauth2/authorize",
+			"token_endpoint":         server.URL + oauth2TokenURL,
+			"userinfo_endpoint":      server.URL + "/userinfo",
+			"jwks_uri":               server.URL + "/keys",
+		}))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		}))
+	})
+
+	provider, err := oidc.NewProvider(context.Background(), server.URL)
+	assert.NoError(t, err)
+
+	return server, mux, provider, func(claims jwtgo.MapClaims) string {
+		allClaims := jwtgo.MapClaims{
+			"iss": server.URL,
+			"aud": clientID,
+			"sub": "subject",
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+
+		for k, v := range claims {
+			allClaims[k] = v
+		}
+
+		token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, allClaims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(key)
+		assert.NoError(t, err)
+		return signed
+	}
+}
+
+func TestIdentityContextFromIDTokenToken_ClaimMapping(t *testing.T) {
+	ctx := context.Background()
+	provider, newIDToken := newTestOIdCProvider(t, "client")
+	options := config.OpenIDOptions{
+		ClientID:     "client",
+		UserIDClaims: []string{"preferred_username", "upn"},
+		GroupsClaim:  "groups",
+	}
+
+	testCases := []struct {
+		name           string
+		claims         jwtgo.MapClaims
+		expectedUserID string
+		expectedGroups []string
+	}{
+		{
+			name:           "preferred username and group list",
+			claims:         jwtgo.MapClaims{"preferred_username": "user@example.com", "groups": []string{"admins", "users"}},
+			expectedUserID: "user@example.com",
+			expectedGroups: []string{"admins", "users"},
+		},
+		{
+			name:           "federated user with a single group string",
+			claims:         jwtgo.MapClaims{"upn": "federated@example.com", "groups": "admins"},
+			expectedUserID: "federated@example.com",
+			expectedGroups: []string{"admins"},
+		},
+		{
+			name:           "falls back to subject",
+			claims:         jwtgo.MapClaims{"preferred_username": ""},
+			expectedUserID: "subject",
+			expectedGroups: []string{},
+		},
+		{
+			name:           "ignores malformed groups",
+			claims:         jwtgo.MapClaims{"groups": map[string]string{"name": "admins"}},
+			expectedUserID: "subject",
+			expectedGroups: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := IdentityContextFromIDTokenToken(ctx, newIDToken(tc.claims), options, provider, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUserID, identity.UserID())
+			assert.Equal(t, "client", identity.(IdentityContext).Audience())
+			assert.Equal(t, tc.expectedGroups, identity.Groups().List())
+		})
+	}
+}
+
+func TestIdentityContextFromIDTokenToken_DefaultClaims(t *testing.T) {
+	provider, newIDToken := newTestOIdCProvider(t, "client")
+	identity, err := IdentityContextFromIDTokenToken(context.Background(),
+		newIDToken(jwtgo.MapClaims{"preferred_username": "user@example.com", "groups": []string{"admins"}}),
+		config.OpenIDOptions{ClientID: "client"}, provider, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "subject", identity.UserID())
+	assert.Empty(t, identity.Groups())
+}
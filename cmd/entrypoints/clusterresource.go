@@ -2,6 +2,7 @@ package entrypoints
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/clusterresource"
 	executioncluster "github.com/flyteorg/flyteadmin/pkg/executioncluster/impl"
@@ -12,9 +13,9 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	repositoryConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	_ "gorm.io/driver/postgres" // Required to import database driver.
-	gormLogger "gorm.io/gorm/logger"
 )
 
 var parentClusterResourceCmd = &cobra.Command{
@@ -31,91 +32,91 @@ func GetLocalDbConfig() repositoryConfig.DbConfig {
 	}
 }
 
+// Creates a cluster resource controller from the configuration, with its metrics under the clusterresource scope.
+func newClusterResourceController(ctx context.Context) (clusterresource.Controller, error) {
+	configuration := runtime.NewConfigurationProvider()
+	scope := promutils.NewScope(configuration.ApplicationConfiguration().GetTopLevelConfig().MetricsScope).NewSubScope("clusterresource")
+	dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
+	dbConfig := repositoryConfig.NewDbConfig(dbConfigValues)
+	repoConfig, err := repositories.GetRepoConfig(dbConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to select the database repositories")
+	}
+	db := repositories.GetRepository(repoConfig, dbConfig, scope.NewSubScope("database"))
+
+	cfg := config.GetConfig()
+	executionCluster := executioncluster.GetExecutionCluster(
+		ctx,
+		scope.NewSubScope("cluster"),
+		cfg.KubeConfig,
+		cfg.Master,
+		configuration,
+		db)
+
+	return clusterresource.NewClusterResourceController(db, executionCluster, scope), nil
+}
+
+var (
+	// The interval the run command syncs at, the configured refresh interval when zero.
+	clusterResourceSyncInterval time.Duration
+	clusterResourceDryRun       bool
+)
+
 var controllerRunCmd = &cobra.Command{
 	Use:   "run",
 	Short: "This command will start a cluster resource controller to periodically sync cluster resources",
-	Run: func(cmd *cobra.Command, args []string) {
-		ctx := context.Background()
-		configuration := runtime.NewConfigurationProvider()
-		scope := promutils.NewScope(configuration.ApplicationConfiguration().GetTopLevelConfig().MetricsScope).NewSubScope("clusterresource")
-		dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
-		dbLogLevel := gormLogger.Silent
-		if dbConfigValues.Debug {
-			dbLogLevel = gormLogger.Info
-		}
-		dbConfig := repositoryConfig.DbConfig{
-			BaseConfig: repositoryConfig.BaseConfig{
-				LogLevel: dbLogLevel,
-			},
-			Host:         dbConfigValues.Host,
-			Port:         dbConfigValues.Port,
-			DbName:       dbConfigValues.DbName,
-			User:         dbConfigValues.User,
-			Password:     dbConfigValues.Password,
-			ExtraOptions: dbConfigValues.ExtraOptions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		clusterResourceController, err := newClusterResourceController(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the ClusterResourceController")
 		}
-		db := repositories.GetRepository(
-			repositories.POSTGRES, dbConfig, scope.NewSubScope("database"))
-
-		cfg := config.GetConfig()
-		executionCluster := executioncluster.GetExecutionCluster(
-			scope.NewSubScope("cluster"),
-			cfg.KubeConfig,
-			cfg.Master,
-			configuration,
-			db)
-
-		clusterResourceController := clusterresource.NewClusterResourceController(db, executionCluster, scope)
-		clusterResourceController.Run()
+		stop, stopNotify := newShutdownSignalChannel()
+		defer stopNotify()
+		go func() {
+			select {
+			case sig := <-stop:
+				logger.Infof(ctx, "Received signal [%v], stopping", sig)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
 		logger.Infof(ctx, "ClusterResourceController started successfully")
+		clusterResourceController.Run(ctx, clusterResourceSyncInterval)
+		return nil
 	},
 }
 
 var controllerSyncCmd = &cobra.Command{
 	Use:   "sync",
-	Short: "This command will sync cluster resources",
-	Run: func(cmd *cobra.Command, args []string) {
+	Short: "This command will sync cluster resources once, failing if any template failed to apply",
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		configuration := runtime.NewConfigurationProvider()
-		scope := promutils.NewScope(configuration.ApplicationConfiguration().GetTopLevelConfig().MetricsScope).NewSubScope("clusterresource")
-		dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
-		dbLogLevel := gormLogger.Silent
-		if dbConfigValues.Debug {
-			dbLogLevel = gormLogger.Info
+		clusterResourceController, err := newClusterResourceController(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the ClusterResourceController")
 		}
-		dbConfig := repositoryConfig.DbConfig{
-			BaseConfig: repositoryConfig.BaseConfig{
-				LogLevel: dbLogLevel,
-			},
-			Host:         dbConfigValues.Host,
-			Port:         dbConfigValues.Port,
-			DbName:       dbConfigValues.DbName,
-			User:         dbConfigValues.User,
-			Password:     dbConfigValues.Password,
-			ExtraOptions: dbConfigValues.ExtraOptions,
+		if clusterResourceDryRun {
+			err = clusterResourceController.DryRun(ctx, cmd.OutOrStdout())
+		} else {
+			err = clusterResourceController.Sync(ctx)
 		}
-		db := repositories.GetRepository(
-			repositories.POSTGRES, dbConfig, scope.NewSubScope("database"))
-
-		cfg := config.GetConfig()
-		executionCluster := executioncluster.GetExecutionCluster(
-			scope.NewSubScope("cluster"),
-			cfg.KubeConfig,
-			cfg.Master,
-			configuration,
-			db)
-
-		clusterResourceController := clusterresource.NewClusterResourceController(db, executionCluster, scope)
-		err := clusterResourceController.Sync(ctx)
 		if err != nil {
-			logger.Fatalf(ctx, "Failed to sync cluster resources [%+v]", err)
+			return errors.Wrap(err, "failed to sync cluster resources")
 		}
-		logger.Infof(ctx, "ClusterResourceController started successfully")
+		logger.Infof(ctx, "Synced cluster resources successfully")
+		return nil
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(parentClusterResourceCmd)
+	controllerRunCmd.Flags().DurationVar(&clusterResourceSyncInterval, "interval", 0,
+		"The interval to sync cluster resources at, the configured refresh interval by default")
 	parentClusterResourceCmd.AddCommand(controllerRunCmd)
+	controllerSyncCmd.Flags().BoolVar(&clusterResourceDryRun, "dry-run", false,
+		"Prints the kubernetes objects which would be created and a diff of those which would be updated, "+
+			"without applying them")
 	parentClusterResourceCmd.AddCommand(controllerSyncCmd)
 }
@@ -0,0 +1,83 @@
+package entrypoints
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authConfig "github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// Gets an execution through the gateway configured with the options given, and returns its JSON.
+func getExecutionJSONForTest(t *testing.T, options config.JSONMarshalingOptions, contentType string) map[string]interface{} {
+	ctx := context.Background()
+	mux, err := newHTTPServer(ctx, &config.ServerConfig{JSONMarshaling: options}, &authConfig.Config{}, nil, nil, nil, nil,
+		testAdminAddress, grpc.WithInsecure())
+	assert.NoError(t, err)
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/executions/project/domain/name", nil)
+	if len(contentType) > 0 {
+		request.Header.Set("Content-Type", contentType)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var execution map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &execution))
+	return execution
+}
+
+func TestJSONMarshaling(t *testing.T) {
+	executionManager := managerMocks.MockExecutionManager{}
+	executionManager.SetGetCallback(func(ctx context.Context, request admin.WorkflowExecutionGetRequest) (
+		*admin.Execution, error) {
+		return &admin.Execution{
+			Id: request.Id,
+			Spec: &admin.ExecutionSpec{
+				LaunchPlan: &core.Identifier{Project: "project", Domain: "domain", Name: "lp", Version: "v"},
+			},
+			Closure: &admin.ExecutionClosure{
+				Phase: core.WorkflowExecution_RUNNING,
+			},
+		}, nil
+	})
+	serveAdminForTest(t, &adminservice.AdminService{
+		ExecutionManager: &executionManager,
+		Metrics:          adminservice.InitMetrics(promutils.NewTestScope()),
+	})
+
+	t.Run("default", func(t *testing.T) {
+		for _, contentType := range []string{"", "application/json"} {
+			execution := getExecutionJSONForTest(t, config.JSONMarshalingOptions{}, contentType)
+			spec := execution["spec"].(map[string]interface{})
+			assert.Contains(t, spec, "launch_plan")
+			assert.NotContains(t, spec, "launchPlan")
+			assert.NotContains(t, spec, "max_parallelism")
+			assert.Equal(t, "RUNNING", execution["closure"].(map[string]interface{})["phase"])
+		}
+	})
+	t.Run("camel case", func(t *testing.T) {
+		execution := getExecutionJSONForTest(t, config.JSONMarshalingOptions{UseCamelCase: true}, "application/json")
+		spec := execution["spec"].(map[string]interface{})
+		assert.Contains(t, spec, "launchPlan")
+		assert.NotContains(t, spec, "launch_plan")
+	})
+	t.Run("enums as ints", func(t *testing.T) {
+		execution := getExecutionJSONForTest(t, config.JSONMarshalingOptions{EnumsAsInts: true}, "application/json")
+		assert.Equal(t, float64(core.WorkflowExecution_RUNNING), execution["closure"].(map[string]interface{})["phase"])
+	})
+	t.Run("emit defaults", func(t *testing.T) {
+		execution := getExecutionJSONForTest(t, config.JSONMarshalingOptions{EmitDefaults: true}, "application/json")
+		spec := execution["spec"].(map[string]interface{})
+		assert.Equal(t, float64(0), spec["max_parallelism"])
+	})
+}
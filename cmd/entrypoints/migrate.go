@@ -2,18 +2,18 @@ package entrypoints
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/runtime"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 
-	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/spf13/cobra"
-	"gorm.io/driver/postgres"
-	_ "gorm.io/driver/postgres" // Required to import database driver.
 	"gorm.io/gorm"
-	gormLogger "gorm.io/gorm/logger"
 )
 
 var parentMigrateCmd = &cobra.Command{
@@ -23,170 +23,243 @@ var parentMigrateCmd = &cobra.Command{
 
 var migrationsScope = promutils.NewScope("migrations")
 var migrateScope = migrationsScope.NewSubScope("migrate")
-var rollbackScope = promutils.NewScope("migrations").NewSubScope("rollback")
+var rollbackScope = migrationsScope.NewSubScope("rollback")
+var versionScope = migrationsScope.NewSubScope("version")
+
+var (
+	// Whether the migrate commands print JSON rather than text.
+	migrateJSONOutput bool
+	rollbackSteps     int
+)
+
+// What the migrate commands print, as JSON with --json.
+type migrateRunOutput struct {
+	Applied []string `json:"applied"`
+	Version string   `json:"version"`
+}
+
+type migrateRollbackOutput struct {
+	RolledBack []string `json:"rolledBack"`
+	Version    string   `json:"version"`
+}
+
+type seedProjectsOutput struct {
+	Created  []string `json:"created"`
+	Existing []string `json:"existing"`
+	// The domains every project has.
+	Domains []string `json:"domains"`
+}
+
+// Opens the configured database for the migrate commands, along with a function closing it. Statements aren't bounded
+// by the configured statement timeout, which is meant for requests rather than for migrations rewriting whole tables.
+func openMigrationDB(ctx context.Context, scope promutils.Scope) (*gorm.DB, func(), error) {
+	configuration := runtime.NewConfigurationProvider()
+	dbConfig := config.NewDbConfig(configuration.ApplicationConfiguration().GetDbConfig())
+	dbConfig.DisableForeignKeyConstraintWhenMigrating = true
+	dbConfig.StatementTimeout = 0
+	dbConfigProvider, err := config.NewDbConnectionConfigProvider(dbConfig, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := config.OpenDbConnection(dbConfigProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+	closeDB := func() {
+		if err := sqlDB.Close(); err != nil {
+			logger.Errorf(ctx, "Failed to close the database: %v", err)
+		}
+	}
+	if err = sqlDB.Ping(); err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+	return db, closeDB, nil
+}
+
+// Prints the output of a migrate command as JSON with --json, and as text otherwise.
+func printMigrateOutput(out io.Writer, jsonOutput bool, output interface{}, text string) error {
+	if !jsonOutput {
+		_, err := fmt.Fprintln(out, text)
+		return err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func formatList(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	return strings.Join(ids, ", ")
+}
+
+func formatVersion(version string) string {
+	if len(version) == 0 {
+		return "none, no migrations are applied"
+	}
+	return version
+}
 
 // This runs all the migrations
 var migrateCmd = &cobra.Command{
 	Use:   "run",
-	Short: "This command will run all the migrations for the database",
-	Run: func(cmd *cobra.Command, args []string) {
+	Short: "This command will run all the pending migrations for the database",
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		configuration := runtime.NewConfigurationProvider()
-		databaseConfig := configuration.ApplicationConfiguration().GetDbConfig()
-		dbLogLevel := gormLogger.Silent
-		if databaseConfig.Debug {
-			dbLogLevel = gormLogger.Info
-		}
-		postgresConfigProvider := config.NewPostgresConfigProvider(config.DbConfig{
-			BaseConfig: config.BaseConfig{
-				LogLevel:                                 dbLogLevel,
-				DisableForeignKeyConstraintWhenMigrating: true,
-			},
-			Host:         databaseConfig.Host,
-			Port:         databaseConfig.Port,
-			DbName:       databaseConfig.DbName,
-			User:         databaseConfig.User,
-			Password:     databaseConfig.Password,
-			ExtraOptions: databaseConfig.ExtraOptions,
-		}, migrateScope)
-		db, err := gorm.Open(postgres.Open(postgresConfigProvider.GetDSN()), &gorm.Config{
-			Logger:                                   gormLogger.Default.LogMode(postgresConfigProvider.GetDBConfig().LogLevel),
-			DisableForeignKeyConstraintWhenMigrating: postgresConfigProvider.GetDBConfig().DisableForeignKeyConstraintWhenMigrating,
-		})
+		db, closeDB, err := openMigrationDB(ctx, migrateScope)
 		if err != nil {
-			logger.Fatal(ctx, err)
+			return err
 		}
+		defer closeDB()
 
-		sqlDB, err := db.DB()
+		migrator := config.NewMigrator(db, config.Migrations)
+		applied, err := migrator.Migrate()
 		if err != nil {
-			logger.Fatal(ctx, err)
-		}
-
-		defer func(deferCtx context.Context) {
-			if err = sqlDB.Close(); err != nil {
-				logger.Fatal(deferCtx, err)
-			}
-		}(ctx)
-
-		if err = sqlDB.Ping(); err != nil {
-			logger.Fatal(ctx, err)
+			return fmt.Errorf("could not migrate: %w", err)
 		}
-		m := gormigrate.New(db, gormigrate.DefaultOptions, config.Migrations)
-		if err = m.Migrate(); err != nil {
-			logger.Fatalf(ctx, "Could not migrate: %v", err)
+		status, err := migrator.Status()
+		if err != nil {
+			return err
 		}
 		logger.Infof(ctx, "Migration ran successfully")
+		return printMigrateOutput(cmd.OutOrStdout(), migrateJSONOutput, migrateRunOutput{
+			Applied: append([]string{}, applied...),
+			Version: status.Version,
+		}, fmt.Sprintf("Applied migrations: %s\nVersion: %s", formatList(applied),
+			formatVersion(status.Version)))
 	},
 }
 
-// Rollback the latest migration
+// Rolls back the latest migrations
 var rollbackCmd = &cobra.Command{
 	Use:   "rollback",
-	Short: "This command will rollback one migration",
-	Run: func(cmd *cobra.Command, args []string) {
+	Short: "This command will rollback the latest migrations, one unless --steps is set",
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		configuration := runtime.NewConfigurationProvider()
-		databaseConfig := configuration.ApplicationConfiguration().GetDbConfig()
-		dbLogLevel := gormLogger.Silent
-		if databaseConfig.Debug {
-			dbLogLevel = gormLogger.Info
-		}
-		postgresConfigProvider := config.NewPostgresConfigProvider(config.DbConfig{
-			BaseConfig: config.BaseConfig{
-				LogLevel: dbLogLevel,
-			},
-			Host:         databaseConfig.Host,
-			Port:         databaseConfig.Port,
-			DbName:       databaseConfig.DbName,
-			User:         databaseConfig.User,
-			Password:     databaseConfig.Password,
-			ExtraOptions: databaseConfig.ExtraOptions,
-		}, rollbackScope)
-
-		db, err := gorm.Open(postgres.Open(postgresConfigProvider.GetDSN()), &gorm.Config{
-			Logger: gormLogger.Default.LogMode(postgresConfigProvider.GetDBConfig().LogLevel),
-		})
+		db, closeDB, err := openMigrationDB(ctx, rollbackScope)
 		if err != nil {
-			logger.Fatal(ctx, err)
+			return err
 		}
-		sqlDB, err := db.DB()
+		defer closeDB()
+
+		migrator := config.NewMigrator(db, config.Migrations)
+		rolledBack, err := migrator.Rollback(rollbackSteps)
 		if err != nil {
-			logger.Fatal(ctx, err)
-		}
-		defer func(deferCtx context.Context) {
-			if err = sqlDB.Close(); err != nil {
-				logger.Fatal(deferCtx, err)
+			if len(rolledBack) > 0 {
+				return fmt.Errorf("could not rollback after rolling back migrations %v: %w", rolledBack, err)
 			}
-		}(ctx)
+			return fmt.Errorf("could not rollback: %w", err)
+		}
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		logger.Infof(ctx, "Rolled back %d migrations successfully", len(rolledBack))
+		return printMigrateOutput(cmd.OutOrStdout(), migrateJSONOutput, migrateRollbackOutput{
+			RolledBack: append([]string{}, rolledBack...),
+			Version:    status.Version,
+		}, fmt.Sprintf("Rolled back migrations: %s\nVersion: %s", formatList(rolledBack),
+			formatVersion(status.Version)))
+	},
+}
 
-		if err = sqlDB.Ping(); err != nil {
-			logger.Fatal(ctx, err)
+// Prints the migrations the database applied
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "This command prints the latest migration applied to the database, along with the pending ones",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		db, closeDB, err := openMigrationDB(ctx, versionScope)
+		if err != nil {
+			return err
 		}
+		defer closeDB()
 
-		m := gormigrate.New(db, gormigrate.DefaultOptions, config.Migrations)
-		err = m.RollbackLast()
+		status, err := config.NewMigrator(db, config.Migrations).Status()
 		if err != nil {
-			logger.Fatalf(ctx, "Could not rollback latest migration: %v", err)
+			return err
+		}
+		status.Applied = append([]string{}, status.Applied...)
+		status.Pending = append([]string{}, status.Pending...)
+		text := fmt.Sprintf("Version: %s\nPending migrations: %s", formatVersion(status.Version),
+			formatList(status.Pending))
+		if len(status.Unknown) > 0 {
+			text += fmt.Sprintf("\nUnknown migrations: %s, the database was migrated by a newer flyteadmin",
+				formatList(status.Unknown))
 		}
-		logger.Infof(ctx, "Rolled back one migration successfully")
+		return printMigrateOutput(cmd.OutOrStdout(), migrateJSONOutput, status, text)
 	},
 }
 
+// Returns the projects listed by the arguments, each of which may list several separated by commas.
+func getSeedProjects(args []string) []string {
+	var projects []string
+	for _, arg := range args {
+		for _, project := range strings.Split(arg, ",") {
+			if project = strings.TrimSpace(project); len(project) > 0 {
+				projects = append(projects, project)
+			}
+		}
+	}
+	return projects
+}
+
 // This seeds the database with project values
 var seedProjectsCmd = &cobra.Command{
-	Use:   "seed-projects",
-	Short: "Seed projects in the database.",
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:   "seed-projects name1,name2",
+	Short: "Seed projects in the database, skipping those that already exist. Projects have the configured domains.",
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		configuration := runtime.NewConfigurationProvider()
-		databaseConfig := configuration.ApplicationConfiguration().GetDbConfig()
-		dbLogLevel := gormLogger.Silent
-		if databaseConfig.Debug {
-			dbLogLevel = gormLogger.Info
-		}
-		postgresConfigProvider := config.NewPostgresConfigProvider(config.DbConfig{
-			BaseConfig: config.BaseConfig{
-				LogLevel: dbLogLevel,
-			},
-			Host:         databaseConfig.Host,
-			Port:         databaseConfig.Port,
-			DbName:       databaseConfig.DbName,
-			User:         databaseConfig.User,
-			Password:     databaseConfig.Password,
-			ExtraOptions: databaseConfig.ExtraOptions,
-		}, migrateScope)
-		db, err := gorm.Open(postgres.Open(postgresConfigProvider.GetDSN()), &gorm.Config{
-			Logger: gormLogger.Default.LogMode(postgresConfigProvider.GetDBConfig().LogLevel),
-		})
+		projects := getSeedProjects(args)
+		if len(projects) == 0 {
+			return fmt.Errorf("no projects to seed")
+		}
+		db, closeDB, err := openMigrationDB(ctx, migrateScope)
 		if err != nil {
-			logger.Fatal(ctx, err)
+			return err
 		}
+		defer closeDB()
 
-		sqlDB, err := db.DB()
+		created, err := config.SeedProjects(db, projects)
 		if err != nil {
-			logger.Fatal(ctx, err)
+			return fmt.Errorf("could not add projects to database with err: %w", err)
 		}
-
-		defer func(deferCtx context.Context) {
-			if err = sqlDB.Close(); err != nil {
-				logger.Fatal(deferCtx, err)
+		output := seedProjectsOutput{
+			Created:  append([]string{}, created...),
+			Existing: []string{},
+			Domains:  []string{},
+		}
+		isCreated := make(map[string]bool, len(created))
+		for _, project := range created {
+			isCreated[project] = true
+		}
+		for _, project := range projects {
+			if !isCreated[project] {
+				output.Existing = append(output.Existing, project)
 			}
-		}(ctx)
-
-		if err = sqlDB.Ping(); err != nil {
-			logger.Fatal(ctx, err)
 		}
-
-		if err = config.SeedProjects(db, args); err != nil {
-			logger.Fatalf(ctx, "Could not add projects to database with err: %v", err)
+		for _, domain := range *runtime.NewConfigurationProvider().ApplicationConfiguration().GetDomainsConfig() {
+			output.Domains = append(output.Domains, domain.ID)
 		}
 		logger.Infof(ctx, "Successfully added projects to database")
+		return printMigrateOutput(cmd.OutOrStdout(), migrateJSONOutput, output, fmt.Sprintf(
+			"Created projects: %s\nExisting projects: %s\nDomains: %s", formatList(output.Created),
+			formatList(output.Existing), strings.Join(output.Domains, ", ")))
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(parentMigrateCmd)
+	parentMigrateCmd.PersistentFlags().BoolVar(&migrateJSONOutput, "json", false, "Prints the output as JSON")
 	parentMigrateCmd.AddCommand(migrateCmd)
+	rollbackCmd.Flags().IntVar(&rollbackSteps, "steps", 1, "The number of migrations to roll back")
 	parentMigrateCmd.AddCommand(rollbackCmd)
+	parentMigrateCmd.AddCommand(versionCmd)
 	parentMigrateCmd.AddCommand(seedProjectsCmd)
 }
@@ -0,0 +1,36 @@
+package entrypoints
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSeedProjects(t *testing.T) {
+	assert.Equal(t, []string{"flytesnacks", "flytetester", "examples"},
+		getSeedProjects([]string{"flytesnacks, flytetester,", "examples"}))
+	assert.Empty(t, getSeedProjects([]string{","}))
+}
+
+func TestPrintMigrateOutput(t *testing.T) {
+	output := migrateRollbackOutput{
+		RolledBack: []string{"2021-11-10-named-entity-search-indexes"},
+		Version:    "2021-11-08-keyset-pagination-indexes",
+	}
+	text := "Rolled back migrations: 2021-11-10-named-entity-search-indexes"
+
+	var out bytes.Buffer
+	assert.NoError(t, printMigrateOutput(&out, false, output, text))
+	assert.Equal(t, text+"\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, printMigrateOutput(&out, true, output, text))
+	assert.JSONEq(t, `{"rolledBack": ["2021-11-10-named-entity-search-indexes"],
+		"version": "2021-11-08-keyset-pagination-indexes"}`, out.String())
+}
+
+func TestFormatVersion(t *testing.T) {
+	assert.Equal(t, "none, no migrations are applied", formatVersion(""))
+	assert.Equal(t, "2019-05-22-projects", formatVersion("2019-05-22-projects"))
+}
@@ -0,0 +1,59 @@
+package entrypoints
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	runtimeConfig "github.com/flyteorg/flyteadmin/pkg/runtime"
+	"github.com/flyteorg/flyteadmin/pkg/server"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+const profilerShutdownTimeout = 5 * time.Second
+
+// Starts serving pprof and metrics on the configured profiler port, separately from the public API. Profiling is
+// disabled entirely when no port is configured, the metrics are then served alone on the metrics port of the
+// application config (flyteadmin.profilerPort). The returned function shuts the profiler server down and is meant to
+// be called alongside the main servers' shutdown.
+func startProfilerServer(ctx context.Context, cfg *config.ServerConfig) func() {
+	return startProfilerServerWithMetricsPort(ctx, cfg,
+		runtimeConfig.NewConfigurationProvider().ApplicationConfiguration().GetTopLevelConfig().GetProfilerPort())
+}
+
+func startProfilerServerWithMetricsPort(ctx context.Context, cfg *config.ServerConfig, metricsPort int) func() {
+	var profilerServer *http.Server
+	switch {
+	case cfg.ProfilerPort > 0:
+		profilerServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", cfg.ProfilerPort),
+			Handler: server.NewProfilerHandler(),
+		}
+		logger.Infof(ctx, "Serving pprof and metrics on: %s", profilerServer.Addr)
+	case metricsPort > 0:
+		profilerServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", metricsPort),
+			Handler: server.NewMetricsHandler(),
+		}
+		logger.Infof(ctx, "Profiler port not configured, pprof is disabled. Serving metrics on: %s",
+			profilerServer.Addr)
+	default:
+		logger.Infof(ctx, "Profiler port not configured, pprof is disabled")
+		return func() {}
+	}
+	go func() {
+		if err := profilerServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf(ctx, "Failed to serve profiler, err: %v", err)
+		}
+	}()
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), profilerShutdownTimeout)
+		defer cancel()
+		if err := profilerServer.Shutdown(shutdownCtx); err != nil {
+			logger.Warningf(ctx, "Failed to shut down profiler server, err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package entrypoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authConfig "github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flyteadmin/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestPprofOnlyServedOnProfilerPort(t *testing.T) {
+	ctx := context.Background()
+	publicMux, err := newHTTPServer(ctx, &config.ServerConfig{}, &authConfig.Config{}, nil, nil, nil, nil, "localhost:0",
+		grpc.WithInsecure())
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	publicMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.NewProfilerHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestStartProfilerServer_Disabled(t *testing.T) {
+	// Stopping a profiler which was never started is a no-op.
+	startProfilerServerWithMetricsPort(context.Background(), &config.ServerConfig{}, 0)()
+}
+
+func TestNewMetricsHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	server.NewMetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/pprof/heap", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.NewMetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	for _, path := range []string{"/healthcheck", "/version", "/config"} {
+		recorder = httptest.NewRecorder()
+		server.NewMetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, recorder.Code, path)
+	}
+}
@@ -0,0 +1,93 @@
+package entrypoints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice"
+	"github.com/flyteorg/flyteadmin/pkg/server"
+	workflowengineInterfaces "github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	flyteclientFake "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequestID(t *testing.T) {
+	flyteClient := flyteclientFake.NewSimpleClientset()
+	executor := newK8sExecutorForTest(flyteClient)
+	var managerRequestID string
+	executionManager := managerMocks.MockExecutionManager{}
+	executionManager.SetCreateCallback(func(ctx context.Context, request admin.ExecutionCreateRequest,
+		requestedAt time.Time) (*admin.ExecutionCreateResponse, error) {
+		managerRequestID = common.GetRequestID(ctx)
+		executionID := &core.WorkflowExecutionIdentifier{
+			Project: request.Project,
+			Domain:  request.Domain,
+			Name:    request.Name,
+		}
+		if _, err := executor.Execute(ctx, workflowengineInterfaces.ExecutionData{
+			Namespace:       "project-domain",
+			ExecutionID:     executionID,
+			WorkflowClosure: &core.CompiledWorkflowClosure{},
+		}); err != nil {
+			return nil, err
+		}
+		return &admin.ExecutionCreateResponse{Id: executionID}, nil
+	})
+	executionManager.SetGetCallback(func(ctx context.Context, request admin.WorkflowExecutionGetRequest) (
+		*admin.Execution, error) {
+		return nil, errors.NewFlyteAdminErrorf(codes.NotFound, "missing entity of type execution")
+	})
+	client := service.NewAdminServiceClient(serveAdminForTest(t, &adminservice.AdminService{
+		ExecutionManager: &executionManager,
+		Metrics:          adminservice.InitMetrics(promutils.NewTestScope()),
+	}))
+
+	t.Run("propagated through the handler", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), server.RequestIDMetadataKey, "abc-123")
+		var header metadata.MD
+		_, err := client.CreateExecution(ctx, &admin.ExecutionCreateRequest{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		}, grpc.Header(&header))
+		assert.NoError(t, err)
+		assert.Equal(t, "abc-123", managerRequestID)
+		assert.Equal(t, []string{"abc-123"}, header.Get(server.RequestIDMetadataKey))
+		workflow, err := flyteClient.FlyteworkflowV1alpha1().FlyteWorkflows("project-domain").Get(
+			context.Background(), "name", v1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "abc-123", workflow.Annotations["flyte.org/request-id"])
+	})
+	t.Run("attached to errors", func(t *testing.T) {
+		var header metadata.MD
+		_, err := client.GetExecution(context.Background(), &admin.WorkflowExecutionGetRequest{
+			Id: &core.WorkflowExecutionIdentifier{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "name",
+			},
+		}, grpc.Header(&header))
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		requestID := header.Get(server.RequestIDMetadataKey)
+		if !assert.Len(t, requestID, 1) || !assert.Len(t, st.Details(), 1) {
+			return
+		}
+		assert.Equal(t, requestID[0], st.Details()[0].(*errdetails.RequestInfo).RequestId)
+		assert.Contains(t, st.Message(), requestID[0])
+	})
+}
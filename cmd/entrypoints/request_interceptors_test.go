@@ -0,0 +1,57 @@
+package entrypoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flyteadmin/pkg/server"
+)
+
+type testStandInHandler struct{}
+
+func (h *testStandInHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return "UpdateThing", authInterfaces.ResourceScope{}
+}
+
+func (h *testStandInHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestWithRequestInterceptors(t *testing.T) {
+	cfg := &config.ServerConfig{
+		RateLimit: config.RateLimitOptions{
+			Enabled: true,
+			Write: config.RateLimitSpec{
+				RequestsPerSecond: 0.001,
+				Burst:             1,
+			},
+		},
+	}
+	handlers := withRequestInterceptors(map[string]http.Handler{
+		"/api/v1/stand_in":        &testStandInHandler{},
+		server.ExecutionWatchPath: &testStandInHandler{},
+	}, getRequestInterceptors(cfg))
+	serve := func(path string) int {
+		recorder := httptest.NewRecorder()
+		handlers[path].ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, nil))
+		return recorder.Code
+	}
+
+	// Stand-ins are rate limited like the rpcs they stand in for.
+	assert.Equal(t, http.StatusOK, serve("/api/v1/stand_in"))
+	assert.Equal(t, http.StatusTooManyRequests, serve("/api/v1/stand_in"))
+	// Like streaming rpcs, watches aren't.
+	assert.Equal(t, http.StatusOK, serve(server.ExecutionWatchPath))
+	assert.Equal(t, http.StatusOK, serve(server.ExecutionWatchPath))
+
+	unintercepted := withRequestInterceptors(map[string]http.Handler{
+		"/api/v1/stand_in": &testStandInHandler{},
+	}, nil)
+	_, ok := unintercepted["/api/v1/stand_in"].(*testStandInHandler)
+	assert.True(t, ok)
+}
@@ -13,8 +13,6 @@ import (
 
 	"github.com/flyteorg/flyteadmin/auth/authzserver"
 
-	"github.com/gorilla/handlers"
-
 	"github.com/flyteorg/flyteadmin/auth"
 	"github.com/flyteorg/flyteadmin/auth/interfaces"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -22,10 +20,16 @@ import (
 
 	"net"
 	"net/http"
-	_ "net/http/pprof" // Required to serve application.
+	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/audit"
+	"github.com/flyteorg/flyteadmin/pkg/ratelimit"
+	runtimeConfig "github.com/flyteorg/flyteadmin/pkg/runtime"
 	"github.com/flyteorg/flyteadmin/pkg/server"
+	"github.com/flyteorg/flyteadmin/pkg/tracing"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
@@ -39,16 +43,23 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/config"
 	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice"
 	"github.com/spf13/cobra"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	adminversion "github.com/flyteorg/flytestdlib/version"
 	grpcPrometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 var defaultCorsHeaders = []string{"Content-Type"}
 
+const defaultCertificateReloadInterval = time.Minute
+
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve",
@@ -56,12 +67,11 @@ var serveCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		serverConfig := config.GetConfig()
+		adminversion.LogBuildInformation("flyteadmin")
 
-		if serverConfig.Security.Secure {
-			return serveGatewaySecure(ctx, serverConfig, authConfig.GetConfig())
-		}
-
-		return serveGatewayInsecure(ctx, serverConfig, authConfig.GetConfig())
+		stop, stopNotify := newShutdownSignalChannel()
+		defer stopNotify()
+		return serveGateway(ctx, serverConfig, authConfig.GetConfig(), stop)
 	},
 }
 
@@ -91,32 +101,103 @@ func blanketAuthorization(ctx context.Context, req interface{}, _ *grpc.UnarySer
 	return handler(ctx, req)
 }
 
+func blanketAuthorizationStream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	identityContext := auth.IdentityContextFromContext(ss.Context())
+	if identityContext.IsEmpty() {
+		return handler(srv, ss)
+	}
+
+	if !identityContext.Scopes().Has(auth.ScopeAll) {
+		return status.Errorf(codes.Unauthenticated, "authenticated user doesn't have required scope")
+	}
+
+	return handler(srv, ss)
+}
+
+// Returns the interceptors which rate limit, audit and bound the time spent handling unary requests, in the order they
+// run. They're shared by the gRPC server and the http handlers which stand in for admin service methods, so that a
+// caller's requests draw from the same rate limits whichever way they're sent.
+func getRequestInterceptors(cfg *config.ServerConfig) []grpc.UnaryServerInterceptor {
+	adminScope := promutils.NewScope(runtimeConfig.NewConfigurationProvider().ApplicationConfiguration().
+		GetTopLevelConfig().MetricsScope).NewSubScope("admin")
+	var interceptors []grpc.UnaryServerInterceptor
+	if cfg.RateLimit.Enabled {
+		// Runs after authentication so that callers are identified by their principal rather than their address.
+		interceptors = append(interceptors,
+			ratelimit.NewUnaryServerInterceptor(cfg.RateLimit, adminScope.NewSubScope("ratelimit")))
+	}
+	if cfg.Security.AuditAccess {
+		// Runs after authentication so that the resolved principal is available to the audit log.
+		interceptors = append(interceptors,
+			audit.NewUnaryServerInterceptor(cfg.Security.AuditedMethods, audit.NewLoggerSink()))
+	}
+	// Innermost so that the deadline only bounds the time spent in the handler itself.
+	return append(interceptors, server.NewTimeoutInterceptor(cfg.RequestTimeout.Default.Duration,
+		getMethodTimeouts(cfg), adminScope.NewSubScope("grpc")).UnaryServerInterceptor())
+}
+
 // Creates a new gRPC Server with all the configuration
-func newGRPCServer(ctx context.Context, cfg *config.ServerConfig, authCtx interfaces.AuthenticationContext,
+func newGRPCServer(ctx context.Context, cfg *config.ServerConfig, adminServer *adminservice.AdminService,
+	authCtx interfaces.AuthenticationContext, requestInterceptors []grpc.UnaryServerInterceptor,
 	opts ...grpc.ServerOption) (*grpc.Server, error) {
-	// Not yet implemented for streaming
-	var chainedUnaryInterceptors grpc.UnaryServerInterceptor
+	configuration := runtimeConfig.NewConfigurationProvider()
+	adminScope := promutils.NewScope(configuration.ApplicationConfiguration().GetTopLevelConfig().MetricsScope).
+		NewSubScope("admin")
+	// Request ids are assigned first so that every interceptor logs them, and so that the errors converted from panics
+	// carry them too. Recovery comes next so that panics anywhere else in the chain are converted to Internal errors.
+	// Tracing comes next so that the time spent authenticating and rate limiting is part of the request's span.
+	recoveryInterceptor := server.NewRecoveryInterceptor(adminScope.NewSubScope("grpc"))
+
+	// Rate limiting, auditing and timeouts are only applied to unary calls.
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	streamInterceptors := []grpc.StreamServerInterceptor{server.RequestIDStreamServerInterceptor(),
+		recoveryInterceptor.StreamServerInterceptor(), otelgrpc.StreamServerInterceptor(),
+		grpcPrometheus.StreamServerInterceptor}
 	if cfg.Security.UseAuth {
 		logger.Infof(ctx, "Creating gRPC server with authentication")
-		chainedUnaryInterceptors = grpc_middleware.ChainUnaryServer(grpcPrometheus.UnaryServerInterceptor,
+		unaryInterceptors = []grpc.UnaryServerInterceptor{server.RequestIDUnaryServerInterceptor(),
+			recoveryInterceptor.UnaryServerInterceptor(),
+			otelgrpc.UnaryServerInterceptor(),
+			grpcPrometheus.UnaryServerInterceptor,
 			auth.GetAuthenticationCustomMetadataInterceptor(authCtx),
 			grpcauth.UnaryServerInterceptor(auth.GetAuthenticationInterceptor(authCtx)),
 			auth.AuthenticationLoggingInterceptor,
 			blanketAuthorization,
+		}
+		streamInterceptors = append(streamInterceptors,
+			auth.GetAuthenticationCustomMetadataStreamInterceptor(authCtx),
+			grpcauth.StreamServerInterceptor(auth.GetAuthenticationInterceptor(authCtx)),
+			blanketAuthorizationStream,
 		)
+		if authCtx.Options().Authorization.Enabled {
+			policy, err := auth.GetAuthorizationPolicy(authCtx.Options().Authorization)
+			if err != nil {
+				return nil, err
+			}
+
+			unaryInterceptors = append(unaryInterceptors, auth.GetAuthorizationInterceptor(authCtx, policy))
+			streamInterceptors = append(streamInterceptors, auth.GetAuthorizationStreamInterceptor(authCtx, policy))
+		}
 	} else {
 		logger.Infof(ctx, "Creating gRPC server without authentication")
-		chainedUnaryInterceptors = grpc_middleware.ChainUnaryServer(grpcPrometheus.UnaryServerInterceptor)
+		unaryInterceptors = []grpc.UnaryServerInterceptor{server.RequestIDUnaryServerInterceptor(),
+			recoveryInterceptor.UnaryServerInterceptor(),
+			otelgrpc.UnaryServerInterceptor(),
+			grpcPrometheus.UnaryServerInterceptor}
 	}
+	unaryInterceptors = append(unaryInterceptors, requestInterceptors...)
+	chainedUnaryInterceptors := grpc_middleware.ChainUnaryServer(unaryInterceptors...)
 
 	serverOpts := []grpc.ServerOption{
-		grpc.StreamInterceptor(grpcPrometheus.StreamServerInterceptor),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(chainedUnaryInterceptors),
 	}
 	serverOpts = append(serverOpts, opts...)
 	grpcServer := grpc.NewServer(serverOpts...)
 	grpcPrometheus.Register(grpcServer)
-	flyteService.RegisterAdminServiceServer(grpcServer, adminservice.NewAdminServer(cfg.KubeConfig, cfg.Master))
+	flyteService.RegisterAdminServiceServer(grpcServer, adminServer)
 	if cfg.Security.UseAuth {
 		flyteService.RegisterAuthMetadataServiceServer(grpcServer, authCtx.AuthMetadataService())
 		flyteService.RegisterIdentityServiceServer(grpcServer, authCtx.IdentityService())
@@ -131,46 +212,187 @@ func newGRPCServer(ctx context.Context, cfg *config.ServerConfig, authCtx interf
 	return grpcServer, nil
 }
 
-func GetHandleOpenapiSpec(ctx context.Context) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		swaggerBytes, err := flyteService.Asset("admin.swagger.json")
-		if err != nil {
-			logger.Warningf(ctx, "Err %v", err)
-			w.WriteHeader(http.StatusFailedDependency)
-		} else {
-			w.WriteHeader(http.StatusOK)
-			_, err := w.Write(swaggerBytes)
-			if err != nil {
-				logger.Errorf(ctx, "failed to write openAPI information, error: %s", err.Error())
-			}
+func getMethodTimeouts(cfg *config.ServerConfig) map[string]time.Duration {
+	methodTimeouts := make(map[string]time.Duration, len(cfg.RequestTimeout.Methods))
+	for method, timeout := range cfg.RequestTimeout.Methods {
+		methodTimeouts[method] = timeout.Duration
+	}
+	return methodTimeouts
+}
+
+const openAPISpecPath = "/api/v1/openapi"
+
+func GetHandleOpenapiSpec(ctx context.Context, cfg *config.ServerConfig) http.Handler {
+	swaggerBytes, err := flyteService.Asset("admin.swagger.json")
+	if err == nil {
+		var handler http.Handler
+		handler, err = server.NewOpenAPIHandler(swaggerBytes, cfg.OpenAPI.PublicURI)
+		if err == nil {
+			return handler
+		}
+	}
+	logger.Warningf(ctx, "Err %v", err)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusFailedDependency)
+	})
+}
+
+// Selects the configured readiness checks from those available.
+func getReadinessChecks(ctx context.Context, cfg *config.ServerConfig,
+	available map[string]server.ReadinessCheck) map[string]server.ReadinessCheck {
+	checks := make(map[string]server.ReadinessCheck, len(cfg.Readiness.Checks))
+	for _, name := range cfg.Readiness.Checks {
+		check, ok := available[name]
+		if !ok {
+			logger.Warningf(ctx, "Ignoring unknown readiness check [%s]", name)
+			continue
 		}
+		checks[name] = check
 	}
+	return checks
 }
 
 func healthCheckFunc(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&draining) != 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// Wraps the readiness handler so that, like the healthcheck, it reports unavailability once the server starts draining
+// whatever its checks report.
+func withDrainingReadiness(readiness http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&draining) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		readiness.ServeHTTP(w, r)
+	})
+}
+
+// Returns the handlers served over http by the admin service which aren't part of its service definition, keyed by path.
+func getAdminHTTPHandlers(adminServer *adminservice.AdminService) map[string]http.Handler {
+	handlers := make(map[string]http.Handler)
+	if adminServer.ExecutionManager != nil {
+		handlers[server.ExecutionCountPath] = server.NewExecutionCountHandler(adminServer.ExecutionManager)
+		handlers[server.RelaunchWithInputsPath] = server.NewRelaunchWithInputsHandler(adminServer.ExecutionManager)
+		handlers[server.BulkTerminateExecutionsPath] = server.NewBulkTerminateExecutionsHandler(
+			adminServer.ExecutionManager)
+		handlers[server.ExecutionTagsPath] = server.NewExecutionTagsHandler(adminServer.ExecutionManager)
+		handlers[server.PurgeExecutionsPath] = server.NewPurgeExecutionsHandler(adminServer.ExecutionManager)
+	}
+	if adminServer.TaskManager != nil {
+		handlers[server.TaskCountPath] = server.NewTaskCountHandler(adminServer.TaskManager)
+	}
+	if adminServer.ResourceManager != nil {
+		handlers[server.EffectiveAttributesPath] = server.NewEffectiveAttributesHandler(adminServer.ResourceManager)
+		handlers[server.ActiveExecutionQuotasPath] = server.NewActiveExecutionQuotasHandler(adminServer.ResourceManager)
+		handlers[server.ProjectDomainAttributesPath] = server.NewProjectDomainAttributesHandler(
+			adminServer.ResourceManager)
+		handlers[server.ScopedAttributesPath] = server.NewScopedAttributesHandler(adminServer.ResourceManager)
+	}
+	if adminServer.NamedEntityManager != nil {
+		handlers[server.NamedEntitySearchPath] = server.NewNamedEntitySearchHandler(adminServer.NamedEntityManager)
+	}
+	if adminServer.DataProxyManager != nil {
+		handlers[server.CreateUploadLocationPath] = server.NewCreateUploadLocationHandler(adminServer.DataProxyManager)
+	}
+	if adminServer.StatisticsManager != nil {
+		handlers[server.ExecutionStatisticsPath] = server.NewExecutionStatisticsHandler(adminServer.StatisticsManager)
+	}
+	if adminServer.ScheduleCheckpointManager != nil {
+		handlers[server.ScheduleCheckpointsPath] = server.NewScheduleCheckpointsHandler(
+			adminServer.ScheduleCheckpointManager)
+	}
+	if adminServer.ExecutionWatchManager != nil {
+		handlers[server.ExecutionWatchPath] = server.NewExecutionWatchHandler(adminServer.ExecutionWatchManager)
+	}
+	if adminServer.DescriptionEntityManager != nil {
+		handlers[server.DescriptionEntitiesPath] = server.NewDescriptionEntitiesHandler(
+			adminServer.DescriptionEntityManager)
+	}
+	return handlers
+}
+
+// Returns the handlers standing in for admin service methods served through the interceptors applied to unary rpcs.
+// Like streaming rpcs, watches aren't subject to them.
+func withRequestInterceptors(adminHandlers map[string]http.Handler,
+	requestInterceptors []grpc.UnaryServerInterceptor) map[string]http.Handler {
+	if len(requestInterceptors) == 0 {
+		return adminHandlers
+	}
+	interceptor := grpc_middleware.ChainUnaryServer(requestInterceptors...)
+	intercepted := make(map[string]http.Handler, len(adminHandlers))
+	for path, handler := range adminHandlers {
+		if authorizedHandler, ok := handler.(auth.AuthorizedHTTPHandler); ok && path != server.ExecutionWatchPath {
+			handler = server.NewInterceptedHTTPHandler(authorizedHandler, interceptor)
+		}
+		intercepted[path] = handler
+	}
+	return intercepted
+}
+
+// Returns the marshaler the gateway serves JSON with, which only differs from its default one when configured to.
+func getJSONMarshaler(options config.JSONMarshalingOptions) runtime.Marshaler {
+	return &runtime.JSONPb{
+		OrigName:     !options.UseCamelCase,
+		EnumsAsInts:  options.EnumsAsInts,
+		EmitDefaults: options.EmitDefaults,
+	}
+}
+
 func newHTTPServer(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config, authCtx interfaces.AuthenticationContext,
-	grpcAddress string, grpcConnectionOpts ...grpc.DialOption) (*http.ServeMux, error) {
+	readinessChecks map[string]server.ReadinessCheck, adminHandlers map[string]http.Handler,
+	requestInterceptors []grpc.UnaryServerInterceptor, grpcAddress string,
+	grpcConnectionOpts ...grpc.DialOption) (*http.ServeMux, error) {
 
 	// Register the server that will serve HTTP/REST Traffic
 	mux := http.NewServeMux()
 
-	// Register healthcheck
+	// Register healthcheck (liveness) and readiness
 	mux.HandleFunc("/healthcheck", healthCheckFunc)
+	mux.Handle("/ready", withDrainingReadiness(server.NewReadinessHandler(getReadinessChecks(ctx, cfg, readinessChecks),
+		cfg.Readiness.Timeout.Duration, cfg.Readiness.CacheDuration.Duration)))
 
 	// Register OpenAPI endpoint
 	// This endpoint will serve the OpenAPI2 spec generated by the swagger protoc plugin, and bundled by go-bindata
-	mux.HandleFunc("/api/v1/openapi", GetHandleOpenapiSpec(ctx))
+	mux.Handle(openAPISpecPath, GetHandleOpenapiSpec(ctx, cfg))
+	if cfg.OpenAPI.EnableUI {
+		mux.Handle(openAPISpecPath+"/ui", server.NewOpenAPIUIHandler(openAPISpecPath))
+	}
 
 	var gwmuxOptions = make([]runtime.ServeMuxOption, 0)
 	// This option means that http requests are served with protobufs, instead of json. We always want this.
 	gwmuxOptions = append(gwmuxOptions, runtime.WithMarshalerOption("application/octet-stream", &runtime.ProtoMarshaller{}))
+	// Every other request, including those without a content type, is served with JSON.
+	gwmuxOptions = append(gwmuxOptions, runtime.WithMarshalerOption(runtime.MIMEWildcard,
+		getJSONMarshaler(cfg.JSONMarshaling)))
+	// Proxied requests keep the id assigned by server.NewRequestIDHandler.
+	gwmuxOptions = append(gwmuxOptions, runtime.WithMetadata(server.GetRequestIDMetadata))
 
+	adminHandlers = withRequestInterceptors(adminHandlers, requestInterceptors)
 	if cfg.Security.UseAuth {
 		// Add HTTP handlers for OIDC endpoints
-		auth.RegisterHandlers(ctx, mux, authCtx)
+		configuration := runtimeConfig.NewConfigurationProvider()
+		authScope := promutils.NewScope(configuration.ApplicationConfiguration().GetTopLevelConfig().MetricsScope).
+			NewSubScope("admin").NewSubScope("auth")
+		auth.RegisterHandlers(ctx, mux, authCtx, authScope)
+		var policy interfaces.AuthorizationPolicy
+		if authCtx.Options().Authorization.Enabled {
+			var err error
+			if policy, err = auth.GetAuthorizationPolicy(authCtx.Options().Authorization); err != nil {
+				return nil, err
+			}
+		}
+		for path, handler := range adminHandlers {
+			// Handlers which stand in for admin service methods are authorized like the methods are over gRPC.
+			if authorizedHandler, ok := handler.(auth.AuthorizedHTTPHandler); ok && policy != nil {
+				handler = auth.GetHTTPAuthorizationHandler(authCtx, policy, authorizedHandler)
+			}
+			mux.Handle(path, auth.GetHTTPAuthenticationHandler(authCtx, handler))
+		}
 
 		// Add HTTP handlers for OAuth2 endpoints
 		authzserver.RegisterHandlers(mux, authCtx)
@@ -181,6 +403,10 @@ func newHTTPServer(ctx context.Context, cfg *config.ServerConfig, authCfg *authC
 		// In an attempt to be able to selectively enforce whether or not authentication is required, we're going to tag
 		// the requests that come from the HTTP gateway. See the enforceHttp/Grpc options for more information.
 		gwmuxOptions = append(gwmuxOptions, runtime.WithMetadata(auth.GetHTTPMetadataTaggingHandler()))
+	} else {
+		for path, handler := range adminHandlers {
+			mux.Handle(path, handler)
+		}
 	}
 
 	// Create the grpc-gateway server with the options specified
@@ -201,12 +427,49 @@ func newHTTPServer(ctx context.Context, cfg *config.ServerConfig, authCfg *authC
 		return nil, errors.Wrap(err, "error registering identity service")
 	}
 
-	mux.Handle("/", gwmux)
+	if cfg.Security.UseAuth {
+		// Refresh expired tokens in cookies before the gateway translates them into gRPC metadata.
+		mux.Handle("/", auth.NewTokenRefresher(authCtx).Handler(gwmux))
+	} else {
+		mux.Handle("/", gwmux)
+	}
 
 	return mux, nil
 }
 
-func serveGatewayInsecure(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config) error {
+// Wraps the http handler with CORS handling when enabled. This covers the auth endpoints (/login, /callback) as well
+// so that credentialed requests from a console served on a different origin behave consistently.
+func withCors(cfg *config.ServerConfig, handler http.Handler) http.Handler {
+	if !cfg.Security.AllowCors {
+		return handler
+	}
+	return server.NewCorsHandler(server.CorsOptions{
+		AllowedOrigins:   cfg.Security.AllowedOrigins,
+		AllowedHeaders:   append(defaultCorsHeaders, cfg.Security.AllowedHeaders...),
+		AllowCredentials: cfg.Security.AllowCredentials,
+	}, handler)
+}
+
+// Serves the admin service until a signal arrives on stop or the context is cancelled.
+func serveGateway(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config, stop <-chan os.Signal) error {
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Flushes the spans of the last requests served.
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warningf(ctx, "Failed to flush traces: %v", err)
+		}
+	}()
+	if cfg.Security.Secure {
+		return serveGatewaySecure(ctx, cfg, authCfg, stop)
+	}
+	return serveGatewayInsecure(ctx, cfg, authCfg, stop)
+}
+
+func serveGatewayInsecure(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config,
+	stop <-chan os.Signal) error {
 	logger.Infof(ctx, "Serving Flyte Admin Insecure")
 
 	// This will parse configuration and create the necessary objects for dealing with auth
@@ -236,52 +499,75 @@ func serveGatewayInsecure(ctx context.Context, cfg *config.ServerConfig, authCfg
 			}
 		}
 
-		oauth2MetadataProvider := authzserver.NewService(authCfg)
+		var anonymousMethods *auth.AnonymousMethodMatcher
+		anonymousMethods, err = auth.NewAnonymousMethodMatcher(authCfg.AllowedAnonymousMethods)
+		if err != nil {
+			logger.Errorf(ctx, "Error compiling allowed anonymous methods %s", err)
+			return err
+		}
+
+		oauth2MetadataProvider := authzserver.NewService(authCfg, anonymousMethods)
 		oidcUserInfoProvider := auth.NewUserInfoProvider()
 
-		authCtx, err = auth.NewAuthenticationContext(ctx, sm, oauth2Provider, oauth2ResourceServer, oauth2MetadataProvider, oidcUserInfoProvider, authCfg)
+		authScope := promutils.NewScope(runtimeConfig.NewConfigurationProvider().ApplicationConfiguration().
+			GetTopLevelConfig().MetricsScope).NewSubScope("admin").NewSubScope("auth")
+		authCtx, err = auth.NewAuthenticationContext(ctx, sm, oauth2Provider, oauth2ResourceServer, oauth2MetadataProvider,
+			oidcUserInfoProvider, anonymousMethods, authCfg, authScope)
 		if err != nil {
 			logger.Errorf(ctx, "Error creating auth context %s", err)
 			return err
 		}
 	}
 
-	grpcServer, err := newGRPCServer(ctx, cfg, authCtx)
+	adminServer := adminservice.NewAdminServer(cfg.KubeConfig, cfg.Master)
+	defer adminServer.Stop()
+	requestInterceptors := getRequestInterceptors(cfg)
+	grpcServer, err := newGRPCServer(ctx, cfg, adminServer, authCtx, requestInterceptors)
 	if err != nil {
 		return errors.Wrap(err, "failed to create GRPC server")
 	}
 
-	logger.Infof(ctx, "Serving GRPC Traffic on: %s", cfg.GetGrpcHostAddress())
-	lis, err := net.Listen("tcp", cfg.GetGrpcHostAddress())
-	if err != nil {
-		return errors.Wrapf(err, "failed to listen on GRPC port: %s", cfg.GetGrpcHostAddress())
-	}
+	grpcAddress := cfg.GetGrpcHostAddress()
+	if cfg.SinglePort {
+		// The gateway dials back into the same port, where gRPC requests are picked out by grpcHandlerFunc.
+		grpcAddress = cfg.GetHostAddress()
+	} else {
+		logger.Infof(ctx, "Serving GRPC Traffic on: %s", cfg.GetGrpcHostAddress())
+		lis, err := net.Listen("tcp", cfg.GetGrpcHostAddress())
+		if err != nil {
+			return errors.Wrapf(err, "failed to listen on GRPC port: %s", cfg.GetGrpcHostAddress())
+		}
 
-	go func() {
-		err := grpcServer.Serve(lis)
-		logger.Fatalf(ctx, "Failed to create GRPC Server, Err: ", err)
-	}()
+		go func() {
+			err := grpcServer.Serve(lis)
+			if err != nil {
+				logger.Fatalf(ctx, "Failed to create GRPC Server, Err: ", err)
+			}
+		}()
+	}
 
 	logger.Infof(ctx, "Starting HTTP/1 Gateway server on %s", cfg.GetHostAddress())
-	httpServer, err := newHTTPServer(ctx, cfg, authCfg, authCtx, cfg.GetGrpcHostAddress(), grpc.WithInsecure(),
-		grpc.WithMaxHeaderListSize(common.MaxResponseStatusBytes))
+	httpServer, err := newHTTPServer(ctx, cfg, authCfg, authCtx, adminServer.ReadinessChecks,
+		getAdminHTTPHandlers(adminServer), requestInterceptors, grpcAddress, grpc.WithInsecure(), grpc.WithMaxHeaderListSize(common.MaxResponseStatusBytes))
 	if err != nil {
 		return err
 	}
 
-	var handler http.Handler
-	if cfg.Security.AllowCors {
-		handler = handlers.CORS(
-			handlers.AllowCredentials(),
-			handlers.AllowedOrigins(cfg.Security.AllowedOrigins),
-			handlers.AllowedHeaders(append(defaultCorsHeaders, cfg.Security.AllowedHeaders...)),
-			handlers.AllowedMethods([]string{"GET", "POST", "DELETE", "HEAD", "PUT", "PATCH"}),
-		)(httpServer)
-	} else {
-		handler = httpServer
+	handler := withCors(cfg, server.NewRequestIDHandler(httpServer))
+	if cfg.SinglePort {
+		logger.Infof(ctx, "Serving GRPC Traffic on: %s", cfg.GetHostAddress())
+		handler = singlePortHandler(grpcServer, handler)
+	}
+	srv := &http.Server{
+		Addr:    cfg.GetHostAddress(),
+		Handler: handler,
 	}
 
-	err = http.ListenAndServe(cfg.GetHostAddress(), handler)
+	stopProfiler := startProfilerServer(ctx, cfg)
+	defer stopProfiler()
+
+	err = serveUntilStopped(ctx, getGracefulShutdownDrainDelay(cfg), getGracefulShutdownTimeout(cfg), grpcServer, srv,
+		stop, srv.ListenAndServe)
 	if err != nil {
 		return errors.Wrapf(err, "failed to Start HTTP Server")
 	}
@@ -303,11 +589,29 @@ func grpcHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http.Ha
 	})
 }
 
-func serveGatewaySecure(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config) error {
-	certPool, cert, err := server.GetSslCredentials(ctx, cfg.Security.Ssl.CertificateFile, cfg.Security.Ssl.KeyFile)
+// singlePortHandler serves both gRPC and HTTP traffic on a cleartext listener. gRPC clients connect using HTTP/2 with
+// prior knowledge (h2c) and are routed to grpcServer, while HTTP/1.1 requests fall through to otherHandler.
+func singlePortHandler(grpcServer *grpc.Server, otherHandler http.Handler) http.Handler {
+	return h2c.NewHandler(grpcHandlerFunc(grpcServer, otherHandler), &http2.Server{})
+}
+
+func getCertificateReloadInterval(cfg *config.ServerConfig) time.Duration {
+	if cfg.Security.Ssl.ReloadInterval.Duration <= 0 {
+		return defaultCertificateReloadInterval
+	}
+	return cfg.Security.Ssl.ReloadInterval.Duration
+}
+
+func serveGatewaySecure(ctx context.Context, cfg *config.ServerConfig, authCfg *authConfig.Config,
+	stop <-chan os.Signal) error {
+	// Serve the certificate through a reloader so that rotated certificates are picked up by new connections.
+	certReloader, err := server.NewCertificateReloader(ctx, cfg.Security.Ssl.CertificateFile, cfg.Security.Ssl.KeyFile)
 	if err != nil {
 		return err
 	}
+	reloadCtx, cancelReload := context.WithCancel(ctx)
+	defer cancelReload()
+	certReloader.Start(reloadCtx, getCertificateReloadInterval(cfg))
 	// This will parse configuration and create the necessary objects for dealing with auth
 	var authCtx interfaces.AuthenticationContext
 	if cfg.Security.UseAuth {
@@ -330,28 +634,41 @@ func serveGatewaySecure(ctx context.Context, cfg *config.ServerConfig, authCfg *
 			}
 		}
 
-		oauth2MetadataProvider := authzserver.NewService(authCfg)
+		var anonymousMethods *auth.AnonymousMethodMatcher
+		anonymousMethods, err = auth.NewAnonymousMethodMatcher(authCfg.AllowedAnonymousMethods)
+		if err != nil {
+			logger.Errorf(ctx, "Error compiling allowed anonymous methods %s", err)
+			return err
+		}
+
+		oauth2MetadataProvider := authzserver.NewService(authCfg, anonymousMethods)
 		oidcUserInfoProvider := auth.NewUserInfoProvider()
 
-		authCtx, err = auth.NewAuthenticationContext(ctx, sm, oauth2Provider, oauth2ResourceServer, oauth2MetadataProvider, oidcUserInfoProvider, authCfg)
+		authScope := promutils.NewScope(runtimeConfig.NewConfigurationProvider().ApplicationConfiguration().
+			GetTopLevelConfig().MetricsScope).NewSubScope("admin").NewSubScope("auth")
+		authCtx, err = auth.NewAuthenticationContext(ctx, sm, oauth2Provider, oauth2ResourceServer, oauth2MetadataProvider,
+			oidcUserInfoProvider, anonymousMethods, authCfg, authScope)
 		if err != nil {
 			logger.Errorf(ctx, "Error creating auth context %s", err)
 			return err
 		}
 	}
 
-	grpcServer, err := newGRPCServer(ctx, cfg, authCtx,
-		grpc.Creds(credentials.NewServerTLSFromCert(cert)))
+	adminServer := adminservice.NewAdminServer(cfg.KubeConfig, cfg.Master)
+	defer adminServer.Stop()
+	requestInterceptors := getRequestInterceptors(cfg)
+	grpcServer, err := newGRPCServer(ctx, cfg, adminServer, authCtx, requestInterceptors,
+		grpc.Creds(credentials.NewTLS(&tls.Config{GetCertificate: certReloader.GetCertificate})))
 	if err != nil {
 		return errors.Wrap(err, "failed to create GRPC server")
 	}
 
-	// Whatever certificate is used, pass it along for easier development
-	dialCreds := credentials.NewTLS(&tls.Config{
-		ServerName: cfg.GetHostAddress(),
-		RootCAs:    certPool,
-	})
-	httpServer, err := newHTTPServer(ctx, cfg, authCfg, authCtx, cfg.GetHostAddress(), grpc.WithTransportCredentials(dialCreds))
+	// Whatever certificate is used, pass it along for easier development. The gateway keeps trusting the certificate
+	// served once it's rotated.
+	dialCreds := credentials.NewTLS(certReloader.GetClientTLSConfig(cfg.GetHostAddress()))
+	httpServer, err := newHTTPServer(ctx, cfg, authCfg, authCtx, adminServer.ReadinessChecks,
+		getAdminHTTPHandlers(adminServer), requestInterceptors, cfg.GetHostAddress(),
+		grpc.WithTransportCredentials(dialCreds))
 	if err != nil {
 		return err
 	}
@@ -363,15 +680,20 @@ func serveGatewaySecure(ctx context.Context, cfg *config.ServerConfig, authCfg *
 
 	srv := &http.Server{
 		Addr:    cfg.GetHostAddress(),
-		Handler: grpcHandlerFunc(grpcServer, httpServer),
+		Handler: grpcHandlerFunc(grpcServer, withCors(cfg, server.NewRequestIDHandler(httpServer))),
 		TLSConfig: &tls.Config{
-			Certificates: []tls.Certificate{*cert},
-			NextProtos:   []string{"h2"},
+			GetCertificate: certReloader.GetCertificate,
+			NextProtos:     []string{"h2"},
 		},
 	}
 
-	err = srv.Serve(tls.NewListener(conn, srv.TLSConfig))
+	stopProfiler := startProfilerServer(ctx, cfg)
+	defer stopProfiler()
 
+	err = serveUntilStopped(ctx, getGracefulShutdownDrainDelay(cfg), getGracefulShutdownTimeout(cfg), grpcServer, srv,
+		stop, func() error {
+			return srv.Serve(tls.NewListener(conn, srv.TLSConfig))
+		})
 	if err != nil {
 		return errors.Wrapf(err, "failed to Start HTTP/2 Server")
 	}
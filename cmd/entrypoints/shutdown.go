@@ -0,0 +1,109 @@
+package entrypoints
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc"
+)
+
+const defaultGracefulShutdownTimeout = 30 * time.Second
+
+// Set to a non-zero value once the server starts draining so that the healthcheck and readiness endpoints report
+// unavailability and load balancers take this instance out of rotation before its connections are closed.
+var draining int32
+
+// The drain delay is skipped when it's unset, so that deployments without a load balancer polling the healthcheck
+// don't wait for nothing.
+func getGracefulShutdownDrainDelay(cfg *config.ServerConfig) time.Duration {
+	if cfg.GracefulShutdownDrainDelay.Duration <= 0 {
+		return 0
+	}
+	return cfg.GracefulShutdownDrainDelay.Duration
+}
+
+func getGracefulShutdownTimeout(cfg *config.ServerConfig) time.Duration {
+	if cfg.GracefulShutdownTimeout.Duration <= 0 {
+		return defaultGracefulShutdownTimeout
+	}
+	return cfg.GracefulShutdownTimeout.Duration
+}
+
+// Gracefully stops both the gRPC and the HTTP servers. The healthcheck reports unavailability right away, but new
+// connections keep being accepted for drainDelay, giving load balancers time to notice and stop routing traffic here.
+// New connections are refused after that while in-flight requests are given up to timeout to complete before the
+// remaining connections are forcefully closed.
+func gracefulShutdown(ctx context.Context, drainDelay, timeout time.Duration, grpcServer *grpc.Server,
+	httpServer *http.Server) error {
+	atomic.StoreInt32(&draining, 1)
+	if drainDelay > 0 {
+		logger.Infof(ctx, "Waiting %v for load balancers to stop routing requests before draining", drainDelay)
+		time.Sleep(drainDelay)
+	}
+	// The shutdown is bounded by its own timeout rather than by ctx, whose cancellation may be what triggered it.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	err := httpServer.Shutdown(shutdownCtx)
+	if err != nil {
+		logger.Warningf(ctx, "Failed to gracefully shut down HTTP server, err: %v", err)
+	}
+
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Warningf(ctx, "Timed out after %v waiting for in-flight gRPC requests, forcing stop", timeout)
+		grpcServer.Stop()
+	}
+	return err
+}
+
+// Runs serve until it either returns, a signal arrives on stop or the context is cancelled, in which case both servers
+// are gracefully shut down.
+func serveUntilStopped(ctx context.Context, drainDelay, timeout time.Duration, grpcServer *grpc.Server,
+	httpServer *http.Server, stop <-chan os.Signal, serve func() error) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-stop:
+		logger.Infof(ctx, "Received signal [%v], draining in-flight requests for up to %v", sig, timeout)
+	case <-ctx.Done():
+		logger.Infof(ctx, "Stopping, draining in-flight requests for up to %v", timeout)
+	}
+
+	if err := gracefulShutdown(ctx, drainDelay, timeout, grpcServer, httpServer); err != nil {
+		return err
+	}
+	// Serve always returns http.ErrServerClosed after a shutdown, which is expected.
+	if err := <-serveErr; err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	logger.Infof(ctx, "Flyte admin server shut down gracefully")
+	return nil
+}
+
+func newShutdownSignalChannel() (chan os.Signal, func()) {
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	return stop, func() {
+		signal.Stop(stop)
+	}
+}
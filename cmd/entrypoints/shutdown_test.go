@@ -0,0 +1,186 @@
+package entrypoints
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flyteadmin/pkg/server"
+	flyteConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func startSlowServer(t *testing.T, handlerStarted chan<- struct{}, release <-chan struct{}) (*http.Server, net.Listener) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(handlerStarted)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	return &http.Server{Handler: mux}, lis
+}
+
+func TestServeUntilStopped_DrainsInFlightRequests(t *testing.T) {
+	defer atomic.StoreInt32(&draining, 0)
+	handlerStarted := make(chan struct{})
+	release := make(chan struct{})
+	srv, lis := startSlowServer(t, handlerStarted, release)
+	stop := make(chan os.Signal, 1)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveUntilStopped(context.Background(), 0, 5*time.Second, grpc.NewServer(), srv, stop, func() error {
+			return srv.Serve(lis)
+		})
+	}()
+
+	responseCode := make(chan int, 1)
+	go func() {
+		resp, err := http.Get("http://" + lis.Addr().String() + "/slow")
+		if err != nil {
+			responseCode <- -1
+			return
+		}
+		_ = resp.Body.Close()
+		responseCode <- resp.StatusCode
+	}()
+
+	<-handlerStarted
+	stop <- syscall.SIGTERM
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&draining) != 0
+	}, time.Second, 10*time.Millisecond)
+	recorder := httptest.NewRecorder()
+	healthCheckFunc(recorder, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	recorder = httptest.NewRecorder()
+	withDrainingReadiness(server.NewReadinessHandler(nil, 0, 0)).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-responseCode)
+	assert.NoError(t, <-served)
+}
+
+func TestServeUntilStopped_DrainDelay(t *testing.T) {
+	defer atomic.StoreInt32(&draining, 0)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthcheck", healthCheckFunc)
+	srv := &http.Server{Handler: mux}
+	stop := make(chan os.Signal, 1)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveUntilStopped(context.Background(), time.Second, 5*time.Second, grpc.NewServer(), srv, stop,
+			func() error {
+				return srv.Serve(lis)
+			})
+	}()
+	stop <- syscall.SIGTERM
+
+	// New requests are still served while the healthcheck takes this instance out of rotation.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&draining) != 0
+	}, time.Second, 10*time.Millisecond)
+	resp, err := http.Get("http://" + lis.Addr().String() + "/healthcheck")
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.NoError(t, <-served)
+}
+
+func TestServeUntilStopped_DrainTimeout(t *testing.T) {
+	defer atomic.StoreInt32(&draining, 0)
+	handlerStarted := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv, lis := startSlowServer(t, handlerStarted, release)
+	stop := make(chan os.Signal, 1)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- serveUntilStopped(context.Background(), 0, 50*time.Millisecond, grpc.NewServer(), srv, stop,
+			func() error {
+				return srv.Serve(lis)
+			})
+	}()
+	go func() {
+		resp, err := http.Get("http://" + lis.Addr().String() + "/slow")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	<-handlerStarted
+	stop <- syscall.SIGINT
+	assert.EqualError(t, <-served, context.DeadlineExceeded.Error())
+}
+
+func TestServeUntilStopped_ServeError(t *testing.T) {
+	err := serveUntilStopped(context.Background(), 0, time.Second, grpc.NewServer(), &http.Server{}, make(chan os.Signal),
+		func() error {
+			return http.ErrServerClosed
+		})
+	assert.Equal(t, http.ErrServerClosed, err)
+}
+
+func TestHealthCheckFunc(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	healthCheckFunc(recorder, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestWithDrainingReadiness(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	withDrainingReadiness(server.NewReadinessHandler(nil, 0, 0)).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestGetGracefulShutdownDrainDelay(t *testing.T) {
+	assert.Zero(t, getGracefulShutdownDrainDelay(&config.ServerConfig{}))
+	assert.Equal(t, time.Second, getGracefulShutdownDrainDelay(&config.ServerConfig{
+		GracefulShutdownDrainDelay: flyteConfig.Duration{Duration: time.Second},
+	}))
+}
+
+func TestGetGracefulShutdownTimeout(t *testing.T) {
+	assert.Equal(t, defaultGracefulShutdownTimeout, getGracefulShutdownTimeout(&config.ServerConfig{}))
+	assert.Equal(t, time.Minute, getGracefulShutdownTimeout(&config.ServerConfig{
+		GracefulShutdownTimeout: flyteConfig.Duration{Duration: time.Minute},
+	}))
+}
+
+func TestServeUntilStopped_ContextCancelled(t *testing.T) {
+	defer atomic.StoreInt32(&draining, 0)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := &http.Server{Handler: http.NewServeMux()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serveUntilStopped(ctx, 0, 5*time.Second, grpc.NewServer(), srv, nil, func() error {
+			return srv.Serve(lis)
+		})
+	}()
+
+	cancel()
+	assert.NoError(t, <-served)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&draining))
+}
@@ -0,0 +1,51 @@
+package entrypoints
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestSinglePortHandler(t *testing.T) {
+	ctx := context.Background()
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("flyteadmin", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthcheck", healthCheckFunc)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := &http.Server{Handler: singlePortHandler(grpcServer, mux)}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer func() {
+		assert.NoError(t, srv.Shutdown(ctx))
+	}()
+
+	// HTTP/2 prior-knowledge gRPC client.
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	assert.NoError(t, err)
+	defer conn.Close()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: "flyteadmin",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	// Plain HTTP/1.1 REST client on the same listener.
+	httpResp, err := http.Get("http://" + lis.Addr().String() + "/healthcheck")
+	assert.NoError(t, err)
+	defer httpResp.Body.Close()
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	assert.Equal(t, 1, httpResp.ProtoMajor)
+}
@@ -0,0 +1,152 @@
+package entrypoints
+
+import (
+	"context"
+
+	authConfig "github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flyteadmin/scheduler"
+	"github.com/flyteorg/flytestdlib/logger"
+	adminversion "github.com/flyteorg/flytestdlib/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// Which components the start command runs.
+var startComponents = struct {
+	Admin           bool
+	Scheduler       bool
+	ClusterResource bool
+}{}
+
+// A long running part of the single binary, such as the admin service or the scheduler.
+type component struct {
+	name string
+	// Runs the component until the context is cancelled. Returning any earlier fails the whole process.
+	run func(ctx context.Context) error
+}
+
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Launches the Flyte admin server, the native scheduler and the cluster resource controller in one process",
+	Long: `
+Runs the Flyte admin server, the native scheduler and the cluster resource controller from a single process, sharing
+its configuration. The scheduler launches executions through the admin endpoint of the admin client config, which
+should point at this process. Each component can be disabled, e.g.
+
+    flyteadmin start --scheduler=false
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		serverConfig := config.GetConfig()
+		adminversion.LogBuildInformation("flyteadmin")
+
+		components := getStartComponents(serverConfig, authConfig.GetConfig())
+		if len(components) == 0 {
+			return errors.New("no components are enabled")
+		}
+		if !startComponents.Admin {
+			// The admin server serves the metrics of all components, in its absence the profiler serves them.
+			stopProfiler := startProfilerServer(ctx, serverConfig)
+			defer stopProfiler()
+		}
+
+		stop, stopNotify := newShutdownSignalChannel()
+		defer stopNotify()
+		go func() {
+			select {
+			case sig := <-stop:
+				logger.Infof(ctx, "Received signal [%v], stopping", sig)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		return runComponents(ctx, components)
+	},
+}
+
+func getStartComponents(cfg *config.ServerConfig, authCfg *authConfig.Config) []component {
+	var components []component
+	if startComponents.Admin {
+		components = append(components, component{
+			name: "admin server",
+			run: func(ctx context.Context) error {
+				return serveGateway(ctx, cfg, authCfg, nil)
+			},
+		})
+	}
+	if startComponents.ClusterResource {
+		components = append(components, component{
+			name: "cluster resource controller",
+			run: func(ctx context.Context) error {
+				controller, err := newClusterResourceController(ctx)
+				if err != nil {
+					return err
+				}
+				controller.Run(ctx, 0)
+				return nil
+			},
+		})
+	}
+	if startComponents.Scheduler {
+		components = append(components, component{
+			name: "scheduler",
+			run:  scheduler.StartScheduler,
+		})
+	}
+	return components
+}
+
+// Starts the components in order and runs them until the context is cancelled or any of them fails, at which point
+// they're stopped in the reverse order, each one once the components started after it have returned. That way the
+// admin server, which the scheduler depends on, is the last to stop. The first failure, if any, is returned.
+func runComponents(ctx context.Context, components []component) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	cancels := make([]context.CancelFunc, len(components))
+	stopped := make([]chan struct{}, len(components))
+	for i, c := range components {
+		// Components aren't stopped through groupCtx, so that they can be stopped one at a time.
+		componentCtx, cancel := context.WithCancel(context.Background())
+		cancels[i] = cancel
+		stopped[i] = make(chan struct{})
+		c, done := c, stopped[i]
+		logger.Infof(ctx, "Starting %s", c.name)
+		group.Go(func() error {
+			defer close(done)
+			err := c.run(componentCtx)
+			if componentCtx.Err() != nil {
+				if err != nil {
+					return errors.Wrapf(err, "%s failed to stop", c.name)
+				}
+				logger.Infof(ctx, "Stopped %s", c.name)
+				return nil
+			}
+			if err == nil {
+				err = errors.New("stopped unexpectedly")
+			}
+			logger.Errorf(ctx, "The %s failed, stopping the other components: %v", c.name, err)
+			return errors.Wrapf(err, "%s failed", c.name)
+		})
+	}
+
+	group.Go(func() error {
+		<-groupCtx.Done()
+		for i := len(components) - 1; i >= 0; i-- {
+			logger.Infof(ctx, "Stopping %s", components[i].name)
+			cancels[i]()
+			<-stopped[i]
+		}
+		return nil
+	})
+	return group.Wait()
+}
+
+func init() {
+	RootCmd.AddCommand(startCmd)
+	startCmd.Flags().BoolVar(&startComponents.Admin, "admin", true, "Runs the admin server")
+	startCmd.Flags().BoolVar(&startComponents.Scheduler, "scheduler", true, "Runs the native scheduler")
+	startCmd.Flags().BoolVar(&startComponents.ClusterResource, "clusterresource", true,
+		"Runs the cluster resource controller")
+}
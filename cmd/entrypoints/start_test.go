@@ -0,0 +1,165 @@
+package entrypoints
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	authConfig "github.com/flyteorg/flyteadmin/auth/config"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Records the order in which the stubbed components start and stop.
+type componentEvents struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (e *componentEvents) add(event string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *componentEvents) get() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return append([]string{}, e.events...)
+}
+
+// Returns a component which runs until it's stopped, and signals on started once it's running.
+func newStubComponent(name string, events *componentEvents, started chan<- string) component {
+	return component{
+		name: name,
+		run: func(ctx context.Context) error {
+			events.add("start " + name)
+			started <- name
+			<-ctx.Done()
+			// Stopping takes a while, so that components stopped too early would stop out of order.
+			time.Sleep(10 * time.Millisecond)
+			events.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestRunComponents_StopsInReverseOrder(t *testing.T) {
+	events := &componentEvents{}
+	started := make(chan string, 3)
+	components := []component{
+		newStubComponent("admin", events, started),
+		newStubComponent("clusterresource", events, started),
+		newStubComponent("scheduler", events, started),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runComponents(ctx, components)
+	}()
+	for range components {
+		<-started
+	}
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.ElementsMatch(t, []string{"start admin", "start clusterresource", "start scheduler"}, events.get()[:3])
+	assert.Equal(t, []string{"stop scheduler", "stop clusterresource", "stop admin"}, events.get()[3:])
+}
+
+func TestRunComponents_FailureStopsTheRest(t *testing.T) {
+	events := &componentEvents{}
+	started := make(chan string, 2)
+	fail := make(chan struct{})
+	components := []component{
+		newStubComponent("admin", events, started),
+		{
+			name: "clusterresource",
+			run: func(ctx context.Context) error {
+				<-fail
+				events.add("fail clusterresource")
+				return errors.New("kubernetes unreachable")
+			},
+		},
+		newStubComponent("scheduler", events, started),
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runComponents(context.Background(), components)
+	}()
+	<-started
+	<-started
+	close(fail)
+
+	assert.EqualError(t, <-done, "clusterresource failed: kubernetes unreachable")
+	assert.Equal(t, []string{"fail clusterresource", "stop scheduler", "stop admin"}, events.get()[2:])
+}
+
+func TestRunComponents_UnexpectedStop(t *testing.T) {
+	events := &componentEvents{}
+	started := make(chan string, 1)
+	components := []component{
+		newStubComponent("admin", events, started),
+		{
+			name: "scheduler",
+			run: func(ctx context.Context) error {
+				<-started
+				return nil
+			},
+		},
+	}
+
+	err := runComponents(context.Background(), components)
+	assert.EqualError(t, err, "scheduler failed: stopped unexpectedly")
+	assert.Equal(t, []string{"start admin", "stop admin"}, events.get())
+}
+
+func TestRunComponents_StopFailure(t *testing.T) {
+	started := make(chan string, 1)
+	components := []component{
+		{
+			name: "admin",
+			run: func(ctx context.Context) error {
+				started <- "admin"
+				<-ctx.Done()
+				return context.DeadlineExceeded
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runComponents(ctx, components)
+	}()
+	<-started
+	cancel()
+	assert.EqualError(t, <-done, "admin failed to stop: "+context.DeadlineExceeded.Error())
+}
+
+func TestGetStartComponents(t *testing.T) {
+	defer func() {
+		startComponents.Admin, startComponents.Scheduler, startComponents.ClusterResource = true, true, true
+	}()
+
+	getNames := func() []string {
+		var names []string
+		for _, c := range getStartComponents(&config.ServerConfig{}, &authConfig.Config{}) {
+			names = append(names, c.name)
+		}
+		return names
+	}
+
+	startComponents.Admin, startComponents.Scheduler, startComponents.ClusterResource = true, true, true
+	assert.Equal(t, []string{"admin server", "cluster resource controller", "scheduler"}, getNames())
+
+	startComponents.Scheduler = false
+	assert.Equal(t, []string{"admin server", "cluster resource controller"}, getNames())
+
+	startComponents.Admin, startComponents.ClusterResource = false, false
+	assert.Empty(t, getNames())
+}
@@ -0,0 +1,183 @@
+package entrypoints
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
+	clusterMocks "github.com/flyteorg/flyteadmin/pkg/executioncluster/mocks"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	repositoryConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/tracing"
+	workflowengineImpl "github.com/flyteorg/flyteadmin/pkg/workflowengine/impl"
+	workflowengineInterfaces "github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	workflowengineMocks "github.com/flyteorg/flyteadmin/pkg/workflowengine/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	flyteclientFake "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/fake"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Returns an executor creating workflows with the client given.
+func newK8sExecutorForTest(flyteClient *flyteclientFake.Clientset) workflowengineInterfaces.WorkflowExecutor {
+	cluster := clusterMocks.MockCluster{}
+	cluster.SetGetTargetCallback(func(ctx context.Context, spec *executioncluster.ExecutionTargetSpec) (
+		*executioncluster.ExecutionTarget, error) {
+		return &executioncluster.ExecutionTarget{
+			ID:          "cluster",
+			FlyteClient: flyteClient,
+		}, nil
+	})
+	builder := workflowengineMocks.FlyteWorkflowBuilder{}
+	builder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&v1alpha1.FlyteWorkflow{
+		WorkflowSpec: &v1alpha1.WorkflowSpec{},
+	}, nil)
+	return workflowengineImpl.NewK8sWorkflowExecutor(&cluster, &builder, runtimeInterfaces.WorkflowCreateRetryConfig{},
+		runtimeInterfaces.ServiceAccountCheckConfig{})
+}
+
+// newGRPCServer registers its metrics, so it's only created once for all tests, serving the admin service of the test
+// running.
+var (
+	testAdminServer     = &adminservice.AdminService{}
+	testAdminServerOnce sync.Once
+	testAdminAddress    string
+)
+
+// Serves the admin service with the interceptors of newGRPCServer, and returns a connection to it closed when the test
+// ends.
+func serveAdminForTest(t *testing.T, adminServer *adminservice.AdminService, opts ...grpc.DialOption) *grpc.ClientConn {
+	ctx := context.Background()
+	*testAdminServer = *adminServer
+	testAdminServerOnce.Do(func() {
+		grpcServer, err := newGRPCServer(ctx, &config.ServerConfig{}, testAdminServer, nil, nil)
+		assert.NoError(t, err)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		go func() {
+			_ = grpcServer.Serve(lis)
+		}()
+		testAdminAddress = lis.Addr().String()
+	})
+
+	conn, err := grpc.DialContext(ctx, testAdminAddress, append(opts, grpc.WithInsecure(), grpc.WithBlock())...)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+// Returns the spans recorded, keyed by name.
+func getSpansByName(spans []sdktrace.ReadOnlySpan) map[string]sdktrace.ReadOnlySpan {
+	spansByName := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, span := range spans {
+		spansByName[span.Name()] = span
+	}
+	return spansByName
+}
+
+func TestTracing_CreateExecution(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	mocket.Catcher.Register()
+	mocket.Catcher.Reset()
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: mocket.DriverName}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(repositoryConfig.NewQueryTracer()))
+
+	executor := newK8sExecutorForTest(flyteclientFake.NewSimpleClientset())
+
+	executionManager := managerMocks.MockExecutionManager{}
+	executionManager.SetCreateCallback(func(ctx context.Context, request admin.ExecutionCreateRequest,
+		requestedAt time.Time) (*admin.ExecutionCreateResponse, error) {
+		var executions []models.Execution
+		if err := db.WithContext(ctx).Where("execution_project = ?", request.Project).Find(&executions).Error; err != nil {
+			return nil, err
+		}
+		executionID := &core.WorkflowExecutionIdentifier{
+			Project: request.Project,
+			Domain:  request.Domain,
+			Name:    request.Name,
+		}
+		if _, err := executor.Execute(ctx, workflowengineInterfaces.ExecutionData{
+			Namespace:       "project-domain",
+			ExecutionID:     executionID,
+			WorkflowClosure: &core.CompiledWorkflowClosure{},
+		}); err != nil {
+			return nil, err
+		}
+		return &admin.ExecutionCreateResponse{Id: executionID}, nil
+	})
+	adminServer := &adminservice.AdminService{
+		ExecutionManager: &executionManager,
+		Metrics:          adminservice.InitMetrics(promutils.NewTestScope()),
+	}
+
+	ctx := context.Background()
+	conn := serveAdminForTest(t, adminServer, grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()))
+	callerCtx, caller := tracing.Tracer().Start(ctx, "caller")
+	_, err = service.NewAdminServiceClient(conn).CreateExecution(callerCtx, &admin.ExecutionCreateRequest{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+	})
+	caller.End()
+	assert.NoError(t, err)
+
+	spans := getSpansByName(recorder.Ended())
+	const method = "flyteidl.service.AdminService/CreateExecution"
+	// The client and server spans of the call share its name.
+	var clientSpan, serverSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() != method {
+			continue
+		}
+		if span.SpanKind() == trace.SpanKindServer {
+			serverSpan = span
+		} else {
+			clientSpan = span
+		}
+	}
+	if !assert.NotNil(t, clientSpan) || !assert.NotNil(t, serverSpan) || !assert.Contains(t, spans, "gorm.query") ||
+		!assert.Contains(t, spans, "k8s.CreateFlyteWorkflow") {
+		return
+	}
+	traceID := caller.SpanContext().TraceID()
+	for _, span := range []sdktrace.ReadOnlySpan{clientSpan, serverSpan, spans["gorm.query"], spans["k8s.CreateFlyteWorkflow"]} {
+		assert.Equal(t, traceID, span.SpanContext().TraceID(), span.Name())
+	}
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, caller.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+	// The trace is continued from the metadata of the request.
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.True(t, serverSpan.Parent().IsRemote())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), spans["gorm.query"].Parent().SpanID())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), spans["k8s.CreateFlyteWorkflow"].Parent().SpanID())
+	for _, kv := range spans["gorm.query"].Attributes() {
+		if kv.Key == "db.sql.table" {
+			assert.Equal(t, "executions", kv.Value.AsString())
+		}
+	}
+}
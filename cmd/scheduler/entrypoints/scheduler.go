@@ -2,19 +2,13 @@ package entrypoints
 
 import (
 	"context"
-	"fmt"
-	"runtime/debug"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
-	repositoryCommonConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/runtime"
 	"github.com/flyteorg/flyteadmin/scheduler"
-	schdulerRepoConfig "github.com/flyteorg/flyteadmin/scheduler/repositories"
-	"github.com/flyteorg/flyteidl/clients/go/admin"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/profutils"
-	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
 	"github.com/spf13/cobra"
@@ -27,38 +21,8 @@ var schedulerRunCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 		configuration := runtime.NewConfigurationProvider()
-		applicationConfiguration := configuration.ApplicationConfiguration().GetTopLevelConfig()
 		schedulerConfiguration := configuration.ApplicationConfiguration().GetSchedulerConfig()
 
-		// Define the schedulerScope for prometheus metrics
-		schedulerScope := promutils.NewScope(applicationConfiguration.MetricsScope).NewSubScope("flytescheduler")
-		schedulerPanics := schedulerScope.MustNewCounter("initialization_panic",
-			"panics encountered initializing the flyte native scheduler")
-
-		defer func() {
-			if err := recover(); err != nil {
-				schedulerPanics.Inc()
-				logger.Fatalf(ctx, fmt.Sprintf("caught panic: %v [%+v]", err, string(debug.Stack())))
-			}
-		}()
-
-		dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
-		dbConfig := repositoryCommonConfig.NewDbConfig(dbConfigValues)
-		db := schdulerRepoConfig.GetRepository(
-			schdulerRepoConfig.POSTGRES, dbConfig, schedulerScope.NewSubScope("database"))
-
-		clientSet, err := admin.ClientSetBuilder().WithConfig(admin.GetConfig(ctx)).Build(ctx)
-		if err != nil {
-			logger.Fatalf(ctx, "Flyte native scheduler failed to start due to %v", err)
-			return err
-		}
-		adminServiceClient := clientSet.AdminClient()
-
-		scheduleExecutor := scheduler.NewScheduledExecutor(db,
-			configuration.ApplicationConfiguration().GetSchedulerConfig().GetWorkflowExecutorConfig(), schedulerScope, adminServiceClient)
-
-		logger.Info(ctx, "Successfully initialized a native flyte scheduler")
-
 		// Serve profiling endpoints.
 		go func() {
 			err := profutils.StartProfilingServerWithDefaultHandlers(
@@ -68,7 +32,7 @@ var schedulerRunCmd = &cobra.Command{
 			}
 		}()
 
-		err = scheduleExecutor.Run(ctx)
+		err := scheduler.StartScheduler(ctx)
 		if err != nil {
 			logger.Fatalf(ctx, "Flyte native scheduler failed to start due to %v", err)
 			return err
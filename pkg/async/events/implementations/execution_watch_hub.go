@@ -0,0 +1,163 @@
+package implementations
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/events/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type executionWatchMetrics struct {
+	ActiveWatchers  prometheus.Gauge
+	DroppedWatchers prometheus.Counter
+	PublishedEvents prometheus.Counter
+}
+
+type executionWatchSubscription struct {
+	hub    *executionWatchHub
+	key    string
+	events chan interfaces.ExecutionWatchEvent
+	// Set before the events channel is closed, so reading it once the channel is closed doesn't race.
+	dropped bool
+}
+
+func (s *executionWatchSubscription) Events() <-chan interfaces.ExecutionWatchEvent {
+	return s.events
+}
+
+func (s *executionWatchSubscription) Dropped() bool {
+	return s.dropped
+}
+
+func (s *executionWatchSubscription) Close() {
+	s.hub.mutex.Lock()
+	defer s.hub.mutex.Unlock()
+	s.hub.remove(s)
+}
+
+// The watchers of executions are kept in memory, so a replica only delivers the events it processes itself.
+type executionWatchHub struct {
+	bufferSize int
+	metrics    executionWatchMetrics
+	mutex      sync.Mutex
+	watchers   map[string]map[*executionWatchSubscription]bool
+}
+
+func getExecutionWatchKey(executionID *core.WorkflowExecutionIdentifier) string {
+	return fmt.Sprintf("%s/%s/%s", executionID.GetProject(), executionID.GetDomain(), executionID.GetName())
+}
+
+// Unregisters the subscription and closes its events channel, unless it's already been. Must be called holding the
+// mutex.
+func (h *executionWatchHub) remove(subscription *executionWatchSubscription) {
+	watchers, ok := h.watchers[subscription.key]
+	if !ok || !watchers[subscription] {
+		return
+	}
+	delete(watchers, subscription)
+	if len(watchers) == 0 {
+		delete(h.watchers, subscription.key)
+	}
+	close(subscription.events)
+	h.metrics.ActiveWatchers.Dec()
+}
+
+func (h *executionWatchHub) Subscribe(executionID core.WorkflowExecutionIdentifier) interfaces.ExecutionWatchSubscription {
+	subscription := &executionWatchSubscription{
+		hub:    h,
+		key:    getExecutionWatchKey(&executionID),
+		events: make(chan interfaces.ExecutionWatchEvent, h.bufferSize),
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	watchers, ok := h.watchers[subscription.key]
+	if !ok {
+		watchers = make(map[*executionWatchSubscription]bool)
+		h.watchers[subscription.key] = watchers
+	}
+	watchers[subscription] = true
+	h.metrics.ActiveWatchers.Inc()
+	return subscription
+}
+
+func (h *executionWatchHub) publish(executionID *core.WorkflowExecutionIdentifier, watchEvent interfaces.ExecutionWatchEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for subscription := range h.watchers[getExecutionWatchKey(executionID)] {
+		select {
+		case subscription.events <- watchEvent:
+			h.metrics.PublishedEvents.Inc()
+		default:
+			// Event ingestion never waits on watchers, those whose buffer is full are dropped and left to reconnect.
+			subscription.dropped = true
+			h.remove(subscription)
+			h.metrics.DroppedWatchers.Inc()
+		}
+	}
+}
+
+func (h *executionWatchHub) PublishWorkflowEvent(workflowEvent *event.WorkflowExecutionEvent) {
+	h.publish(workflowEvent.GetExecutionId(), interfaces.ExecutionWatchEvent{WorkflowEvent: workflowEvent})
+}
+
+func (h *executionWatchHub) PublishNodeEvent(nodeEvent *event.NodeExecutionEvent) {
+	h.publish(nodeEvent.GetId().GetExecutionId(), interfaces.ExecutionWatchEvent{NodeEvent: nodeEvent})
+}
+
+// NewExecutionWatchHub returns a hub buffering up to bufferSize events for each watcher.
+func NewExecutionWatchHub(bufferSize int, scope promutils.Scope) interfaces.ExecutionWatchHub {
+	return &executionWatchHub{
+		bufferSize: bufferSize,
+		metrics: executionWatchMetrics{
+			ActiveWatchers: scope.MustNewGauge("active_watchers", "number of watchers of executions"),
+			DroppedWatchers: scope.MustNewCounter("dropped_watchers",
+				"count of watchers dropped because they fell behind the events of their execution"),
+			PublishedEvents: scope.MustNewCounter("published_events", "count of events delivered to watchers"),
+		},
+		watchers: make(map[string]map[*executionWatchSubscription]bool),
+	}
+}
+
+// Publishes the workflow execution events processed by admin to the watchers of their execution before they're
+// persisted.
+type watchedWorkflowExecutionEventWriter struct {
+	interfaces.WorkflowExecutionEventWriter
+	hub interfaces.ExecutionWatchHub
+}
+
+func (w *watchedWorkflowExecutionEventWriter) Write(event admin.WorkflowExecutionEventRequest) {
+	w.hub.PublishWorkflowEvent(event.Event)
+	w.WorkflowExecutionEventWriter.Write(event)
+}
+
+func NewWatchedWorkflowExecutionEventWriter(
+	writer interfaces.WorkflowExecutionEventWriter, hub interfaces.ExecutionWatchHub) interfaces.WorkflowExecutionEventWriter {
+	return &watchedWorkflowExecutionEventWriter{
+		WorkflowExecutionEventWriter: writer,
+		hub:                          hub,
+	}
+}
+
+// Publishes the node execution events processed by admin to the watchers of their execution before they're persisted.
+type watchedNodeExecutionEventWriter struct {
+	interfaces.NodeExecutionEventWriter
+	hub interfaces.ExecutionWatchHub
+}
+
+func (w *watchedNodeExecutionEventWriter) Write(event admin.NodeExecutionEventRequest) {
+	w.hub.PublishNodeEvent(event.Event)
+	w.NodeExecutionEventWriter.Write(event)
+}
+
+func NewWatchedNodeExecutionEventWriter(
+	writer interfaces.NodeExecutionEventWriter, hub interfaces.ExecutionWatchHub) interfaces.NodeExecutionEventWriter {
+	return &watchedNodeExecutionEventWriter{
+		NodeExecutionEventWriter: writer,
+		hub:                      hub,
+	}
+}
@@ -0,0 +1,133 @@
+package implementations
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/events/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/async/events/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var watchedExecutionID = core.WorkflowExecutionIdentifier{
+	Project: "project",
+	Domain:  "domain",
+	Name:    "name",
+}
+
+var otherExecutionID = core.WorkflowExecutionIdentifier{
+	Project: "project",
+	Domain:  "domain",
+	Name:    "other",
+}
+
+func getWatchedWorkflowEvent(executionID core.WorkflowExecutionIdentifier,
+	phase core.WorkflowExecution_Phase) *event.WorkflowExecutionEvent {
+	return &event.WorkflowExecutionEvent{
+		ExecutionId: &executionID,
+		Phase:       phase,
+	}
+}
+
+func getWatchedNodeEvent(executionID core.WorkflowExecutionIdentifier, nodeID string) *event.NodeExecutionEvent {
+	return &event.NodeExecutionEvent{
+		Id: &core.NodeExecutionIdentifier{
+			NodeId:      nodeID,
+			ExecutionId: &executionID,
+		},
+		Phase: core.NodeExecution_RUNNING,
+	}
+}
+
+func TestExecutionWatchHub(t *testing.T) {
+	t.Run("fans out to the watchers of the execution", func(t *testing.T) {
+		hub := NewExecutionWatchHub(10, mockScope.NewTestScope())
+		first := hub.Subscribe(watchedExecutionID)
+		second := hub.Subscribe(watchedExecutionID)
+		other := hub.Subscribe(otherExecutionID)
+
+		hub.PublishWorkflowEvent(getWatchedWorkflowEvent(watchedExecutionID, core.WorkflowExecution_RUNNING))
+		hub.PublishNodeEvent(getWatchedNodeEvent(watchedExecutionID, "n0"))
+		for _, subscription := range []interfaces.ExecutionWatchSubscription{first, second} {
+			watchEvent := <-subscription.Events()
+			assert.Equal(t, core.WorkflowExecution_RUNNING, watchEvent.WorkflowEvent.Phase)
+			assert.Nil(t, watchEvent.NodeEvent)
+			watchEvent = <-subscription.Events()
+			assert.Equal(t, "n0", watchEvent.NodeEvent.Id.NodeId)
+			assert.Nil(t, watchEvent.WorkflowEvent)
+		}
+		assert.Empty(t, other.Events())
+		assert.Equal(t, float64(3), testutil.ToFloat64(hub.(*executionWatchHub).metrics.ActiveWatchers))
+		assert.Equal(t, float64(4), testutil.ToFloat64(hub.(*executionWatchHub).metrics.PublishedEvents))
+	})
+	t.Run("drops watchers which fall behind", func(t *testing.T) {
+		hub := NewExecutionWatchHub(1, mockScope.NewTestScope())
+		slow := hub.Subscribe(watchedExecutionID)
+		fast := hub.Subscribe(watchedExecutionID)
+
+		hub.PublishNodeEvent(getWatchedNodeEvent(watchedExecutionID, "n0"))
+		<-fast.Events()
+		hub.PublishNodeEvent(getWatchedNodeEvent(watchedExecutionID, "n1"))
+
+		// The slow watcher gets the events buffered before it was dropped.
+		watchEvent, ok := <-slow.Events()
+		assert.True(t, ok)
+		assert.Equal(t, "n0", watchEvent.NodeEvent.Id.NodeId)
+		_, ok = <-slow.Events()
+		assert.False(t, ok)
+		assert.True(t, slow.Dropped())
+		watchEvent = <-fast.Events()
+		assert.Equal(t, "n1", watchEvent.NodeEvent.Id.NodeId)
+		assert.False(t, fast.Dropped())
+
+		metrics := hub.(*executionWatchHub).metrics
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.DroppedWatchers))
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ActiveWatchers))
+		// Closing a dropped subscription is a no-op.
+		slow.Close()
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ActiveWatchers))
+	})
+	t.Run("close", func(t *testing.T) {
+		hub := NewExecutionWatchHub(10, mockScope.NewTestScope())
+		subscription := hub.Subscribe(watchedExecutionID)
+		subscription.Close()
+		subscription.Close()
+		_, ok := <-subscription.Events()
+		assert.False(t, ok)
+		assert.False(t, subscription.Dropped())
+		assert.Empty(t, hub.(*executionWatchHub).watchers)
+		assert.Zero(t, testutil.ToFloat64(hub.(*executionWatchHub).metrics.ActiveWatchers))
+
+		// Publishing without watchers doesn't block.
+		hub.PublishWorkflowEvent(getWatchedWorkflowEvent(watchedExecutionID, core.WorkflowExecution_SUCCEEDED))
+	})
+}
+
+func TestWatchedEventWriters(t *testing.T) {
+	hub := NewExecutionWatchHub(10, mockScope.NewTestScope())
+	subscription := hub.Subscribe(watchedExecutionID)
+	defer subscription.Close()
+
+	workflowEventRequest := admin.WorkflowExecutionEventRequest{
+		Event: getWatchedWorkflowEvent(watchedExecutionID, core.WorkflowExecution_RUNNING),
+	}
+	workflowEventWriter := mocks.WorkflowExecutionEventWriter{}
+	workflowEventWriter.On("Write", workflowEventRequest).Return()
+	NewWatchedWorkflowExecutionEventWriter(&workflowEventWriter, hub).Write(workflowEventRequest)
+	workflowEventWriter.AssertExpectations(t)
+
+	nodeEventRequest := admin.NodeExecutionEventRequest{
+		Event: getWatchedNodeEvent(watchedExecutionID, "n0"),
+	}
+	nodeEventWriter := mocks.NodeExecutionEventWriter{}
+	nodeEventWriter.On("Write", nodeEventRequest).Return()
+	NewWatchedNodeExecutionEventWriter(&nodeEventWriter, hub).Write(nodeEventRequest)
+	nodeEventWriter.AssertExpectations(t)
+
+	assert.Equal(t, workflowEventRequest.Event, (<-subscription.Events()).WorkflowEvent)
+	assert.Equal(t, nodeEventRequest.Event, (<-subscription.Events()).NodeEvent)
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+)
+
+// An event processed for a watched execution, either a workflow execution event or a node execution event.
+type ExecutionWatchEvent struct {
+	WorkflowEvent *event.WorkflowExecutionEvent
+	NodeEvent     *event.NodeExecutionEvent
+}
+
+// The subscription of a watcher to the events of an execution.
+type ExecutionWatchSubscription interface {
+	// Events returns the channel the events of the execution are delivered on. It's closed once the subscription is
+	// closed or dropped.
+	Events() <-chan ExecutionWatchEvent
+	// Dropped returns whether the subscription was dropped because its watcher fell behind, and is only meaningful once
+	// the events channel is closed.
+	Dropped() bool
+	// Close unsubscribes the watcher.
+	Close()
+}
+
+// Fans out the events processed by this admin replica to the watchers of their executions. Events are published
+// without blocking, watchers which fall behind are dropped instead.
+type ExecutionWatchHub interface {
+	Subscribe(executionID core.WorkflowExecutionIdentifier) ExecutionWatchSubscription
+	PublishWorkflowEvent(workflowEvent *event.WorkflowExecutionEvent)
+	PublishNodeEvent(nodeEvent *event.NodeExecutionEvent)
+}
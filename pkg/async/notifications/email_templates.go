@@ -0,0 +1,184 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"io/ioutil"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/implementations"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const consoleExecutionURL = "%s/projects/%s/domains/%s/executions/%s"
+
+// Variables without a value render empty rather than failing.
+const missingKeyOption = "missingkey=zero"
+
+// Common interface of text and HTML templates.
+type emailTemplate interface {
+	Execute(wr io.Writer, data interface{}) error
+}
+
+// Renders notification emails from the Go templates set in the application config. Emails fall back to the
+// substituted subject and body of the emailer config for any template which isn't set.
+type EmailTemplates struct {
+	subject        *textTemplate.Template
+	htmlBody       *htmlTemplate.Template
+	textBody       *textTemplate.Template
+	consoleURL     string
+	maxErrorLength int
+}
+
+func truncateError(message string, maxLength int) string {
+	runes := []rune(message)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return message
+	}
+	return string(runes[:maxLength]) + "..."
+}
+
+func (t *EmailTemplates) getTemplateData(request admin.WorkflowExecutionEventRequest,
+	execution *admin.Execution) map[string]string {
+	data := map[string]string{
+		"Project":    execution.GetId().GetProject(),
+		"Domain":     execution.GetId().GetDomain(),
+		"Name":       execution.GetId().GetName(),
+		"Workflow":   execution.GetClosure().GetWorkflowId().GetName(),
+		"LaunchPlan": execution.GetSpec().GetLaunchPlan().GetName(),
+		"Phase":      strings.ToLower(request.GetEvent().GetPhase().String()),
+	}
+	if executionError := request.GetEvent().GetError(); executionError != nil {
+		data["ErrorKind"] = strings.ToLower(executionError.Kind.String())
+		data["Error"] = truncateError(executionError.Message, t.maxErrorLength)
+	}
+	startedAt, startedAtErr := ptypes.Timestamp(execution.GetClosure().GetStartedAt())
+	occurredAt, occurredAtErr := ptypes.Timestamp(request.GetEvent().GetOccurredAt())
+	if startedAtErr == nil && occurredAtErr == nil {
+		data["Duration"] = occurredAt.Sub(startedAt).Round(time.Second).String()
+	}
+	if len(t.consoleURL) > 0 {
+		data["ConsoleURL"] = fmt.Sprintf(consoleExecutionURL, t.consoleURL, execution.GetId().GetProject(),
+			execution.GetId().GetDomain(), execution.GetId().GetName())
+	}
+	return data
+}
+
+func renderEmailTemplate(template emailTemplate, data map[string]string) (string, error) {
+	var rendered bytes.Buffer
+	if err := template.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// Converts a terminal execution event and existing execution model to an admin.EmailMessage proto, rendering the
+// configured email templates.
+func (t *EmailTemplates) ToEmailMessage(
+	config runtimeInterfaces.NotificationsConfig,
+	emailNotification admin.EmailNotification,
+	request admin.WorkflowExecutionEventRequest,
+	execution *admin.Execution) (*admin.EmailMessage, error) {
+
+	message := ToEmailMessageFromWorkflowExecutionEvent(config, emailNotification, request, execution)
+	if t.subject == nil && t.htmlBody == nil && t.textBody == nil {
+		return message, nil
+	}
+	data := t.getTemplateData(request, execution)
+	var err error
+	if t.subject != nil {
+		if message.SubjectLine, err = renderEmailTemplate(t.subject, data); err != nil {
+			return nil, fmt.Errorf("failed to render the email subject: %v", err)
+		}
+		message.SubjectLine = strings.TrimSpace(message.SubjectLine)
+	}
+	htmlBody := message.Body
+	if t.htmlBody != nil {
+		if htmlBody, err = renderEmailTemplate(t.htmlBody, data); err != nil {
+			return nil, fmt.Errorf("failed to render the html email body: %v", err)
+		}
+	}
+	var textBody string
+	if t.textBody != nil {
+		if textBody, err = renderEmailTemplate(t.textBody, data); err != nil {
+			return nil, fmt.Errorf("failed to render the text email body: %v", err)
+		}
+	}
+	message.Body = implementations.JoinEmailBodies(htmlBody, textBody)
+	return message, nil
+}
+
+// Returns the template given inline or read from a file, if any.
+func loadEmailTemplate(name, inline, file string) (string, error) {
+	if len(file) == 0 {
+		return inline, nil
+	}
+	if len(inline) > 0 {
+		return "", fmt.Errorf("the email %s template can't be set both inline and as a file", name)
+	}
+	template, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the email %s template: %v", name, err)
+	}
+	return string(template), nil
+}
+
+func parseTextEmailTemplate(name, inline, file string) (*textTemplate.Template, error) {
+	text, err := loadEmailTemplate(name, inline, file)
+	if err != nil || len(text) == 0 {
+		return nil, err
+	}
+	template, err := textTemplate.New(name).Option(missingKeyOption).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email %s template: %v", name, err)
+	}
+	if _, err = renderEmailTemplate(template, map[string]string{}); err != nil {
+		return nil, fmt.Errorf("invalid email %s template: %v", name, err)
+	}
+	return template, nil
+}
+
+func parseHTMLEmailTemplate(name, inline, file string) (*htmlTemplate.Template, error) {
+	text, err := loadEmailTemplate(name, inline, file)
+	if err != nil || len(text) == 0 {
+		return nil, err
+	}
+	template, err := htmlTemplate.New(name).Option(missingKeyOption).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email %s template: %v", name, err)
+	}
+	if _, err = renderEmailTemplate(template, map[string]string{}); err != nil {
+		return nil, fmt.Errorf("invalid email %s template: %v", name, err)
+	}
+	return template, nil
+}
+
+// Parses the configured email templates, reading those set as files. Templates which fail to parse or render are
+// reported so that misconfigurations surface at startup rather than when sending notifications.
+func NewEmailTemplates(config runtimeInterfaces.EmailTemplatesConfig) (*EmailTemplates, error) {
+	subject, err := parseTextEmailTemplate("subject", config.Subject, config.SubjectFile)
+	if err != nil {
+		return nil, err
+	}
+	htmlBody, err := parseHTMLEmailTemplate("html body", config.HTMLBody, config.HTMLBodyFile)
+	if err != nil {
+		return nil, err
+	}
+	textBody, err := parseTextEmailTemplate("text body", config.TextBody, config.TextBodyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailTemplates{
+		subject:        subject,
+		htmlBody:       htmlBody,
+		textBody:       textBody,
+		consoleURL:     strings.TrimSuffix(config.ConsoleURL, "/"),
+		maxErrorLength: config.MaxErrorLength,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/implementations"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var emailTemplatesConfig = runtimeInterfaces.EmailTemplatesConfig{
+	SubjectFile:    filepath.Join("testdata", "email_subject.tmpl"),
+	HTMLBodyFile:   filepath.Join("testdata", "email_body.html.tmpl"),
+	TextBodyFile:   filepath.Join("testdata", "email_body.txt.tmpl"),
+	ConsoleURL:     "https://console.example.com/",
+	MaxErrorLength: 25,
+}
+
+var emailTemplatesNotificationsConfig = runtimeInterfaces.NotificationsConfig{
+	NotificationsEmailerConfig: runtimeInterfaces.NotificationsEmailerConfig{
+		Sender: "no-reply@example.com",
+	},
+}
+
+func readGoldenEmail(t *testing.T, name string) string {
+	golden, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	assert.NoError(t, err)
+	return string(golden)
+}
+
+func getEmailTemplatesTestRequest(phase core.WorkflowExecution_Phase) (admin.WorkflowExecutionEventRequest, *admin.Execution) {
+	startedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	startedAtProto, _ := ptypes.TimestampProto(startedAt)
+	occurredAtProto, _ := ptypes.TimestampProto(startedAt.Add(5*time.Minute + 30*time.Second))
+	execution := proto.Clone(workflowExecution).(*admin.Execution)
+	execution.Closure.StartedAt = startedAtProto
+	return admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase:      phase,
+			OccurredAt: occurredAtProto,
+		},
+	}, execution
+}
+
+func TestEmailTemplates_Failure(t *testing.T) {
+	emailTemplates, err := NewEmailTemplates(emailTemplatesConfig)
+	assert.NoError(t, err)
+	request, execution := getEmailTemplatesTestRequest(core.WorkflowExecution_FAILED)
+	request.Event.OutputResult = &event.WorkflowExecutionEvent_Error{
+		Error: &core.ExecutionError{
+			Kind:    core.ExecutionError_USER,
+			Message: `task "t1" failed: x < 1 is required`,
+		},
+	}
+
+	message, err := emailTemplates.ToEmailMessage(emailTemplatesNotificationsConfig, admin.EmailNotification{
+		RecipientsEmail: []string{"team@example.com"},
+	}, request, execution)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team@example.com"}, message.RecipientsEmail)
+	assert.Equal(t, "no-reply@example.com", message.SenderEmail)
+	assert.Equal(t, "proj/prod: execution e124 failed", message.SubjectLine)
+	assert.Equal(t, implementations.JoinEmailBodies(
+		readGoldenEmail(t, "email_failure.html"), readGoldenEmail(t, "email_failure.txt")), message.Body)
+}
+
+func TestEmailTemplates_Success(t *testing.T) {
+	emailTemplates, err := NewEmailTemplates(emailTemplatesConfig)
+	assert.NoError(t, err)
+	request, execution := getEmailTemplatesTestRequest(core.WorkflowExecution_SUCCEEDED)
+
+	message, err := emailTemplates.ToEmailMessage(emailTemplatesNotificationsConfig, admin.EmailNotification{
+		RecipientsEmail: []string{"team@example.com"},
+	}, request, execution)
+	assert.NoError(t, err)
+	assert.Equal(t, "proj/prod: execution e124 succeeded", message.SubjectLine)
+	assert.Equal(t, implementations.JoinEmailBodies(
+		readGoldenEmail(t, "email_success.html"), readGoldenEmail(t, "email_success.txt")), message.Body)
+}
+
+func TestEmailTemplates_MissingVariables(t *testing.T) {
+	emailTemplates, err := NewEmailTemplates(runtimeInterfaces.EmailTemplatesConfig{
+		HTMLBody: "[{{ .Error }}|{{ .Duration }}|{{ .ConsoleURL }}|{{ .Unknown }}]",
+	})
+	assert.NoError(t, err)
+
+	message, err := emailTemplates.ToEmailMessage(runtimeInterfaces.NotificationsConfig{
+		NotificationsEmailerConfig: runtimeInterfaces.NotificationsEmailerConfig{
+			Subject: "Execution {{ name }}",
+		},
+	}, admin.EmailNotification{}, admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase: core.WorkflowExecution_SUCCEEDED,
+		},
+	}, workflowExecution)
+	assert.NoError(t, err)
+	// Templates which aren't set fall back to the emailer config.
+	assert.Equal(t, "Execution e124", message.SubjectLine)
+	assert.Equal(t, "[|||]", message.Body)
+}
+
+func TestNewEmailTemplates_Invalid(t *testing.T) {
+	_, err := NewEmailTemplates(runtimeInterfaces.EmailTemplatesConfig{
+		Subject: "Execution {{ .Name }",
+	})
+	assert.Error(t, err)
+
+	_, err = NewEmailTemplates(runtimeInterfaces.EmailTemplatesConfig{
+		HTMLBody: "{{ template \"missing\" }}",
+	})
+	assert.Error(t, err)
+
+	_, err = NewEmailTemplates(runtimeInterfaces.EmailTemplatesConfig{
+		TextBodyFile: filepath.Join("testdata", "missing.tmpl"),
+	})
+	assert.Error(t, err)
+
+	_, err = NewEmailTemplates(runtimeInterfaces.EmailTemplatesConfig{
+		Subject:     "Execution {{ .Name }}",
+		SubjectFile: filepath.Join("testdata", "email_subject.tmpl"),
+	})
+	assert.EqualError(t, err, "the email subject template can't be set both inline and as a file")
+}
@@ -3,6 +3,7 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/async"
@@ -20,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ses"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
 	"github.com/flyteorg/flytestdlib/promutils"
 )
 
@@ -27,6 +29,17 @@ const maxRetries = 3
 
 var enable64decoding = false
 
+// The in-process queue shared by the publisher and processor of the local type.
+var localQueue *implementations.LocalQueue
+var localQueueOnce sync.Once
+
+func getLocalQueue(config runtimeInterfaces.NotificationsConfig, scope promutils.Scope) *implementations.LocalQueue {
+	localQueueOnce.Do(func() {
+		localQueue = implementations.NewLocalQueue(config.NotificationsLocalConfig.QueueSize, scope)
+	})
+	return localQueue
+}
+
 type PublisherConfig struct {
 	TopicName string
 }
@@ -74,7 +87,30 @@ func GetEmailer(config runtimeInterfaces.NotificationsConfig, scope promutils.Sc
 	}
 }
 
-func NewNotificationsProcessor(config runtimeInterfaces.NotificationsConfig, scope promutils.Scope) interfaces.Processor {
+// Connects a Kafka publisher to the brokers, failing unless lazy connect is set or the brokers are reachable within the
+// reconnect attempts.
+func newKafkaPublisher(config runtimeInterfaces.KafkaConfig, topic string, secretManager core.SecretManager,
+	reconnectAttempts int, reconnectDelay time.Duration) pubsub.Publisher {
+	saramaConfig, err := implementations.NewKafkaClientConfig(context.TODO(), config, secretManager)
+	if err != nil {
+		panic(err)
+	}
+	var publisher pubsub.Publisher
+	err = async.Retry(reconnectAttempts, reconnectDelay, func() error {
+		publisher, err = implementations.NewKafkaPublisher(config, topic, saramaConfig)
+		if err != nil {
+			logger.Warnf(context.TODO(), "Failed to initialize new kafka publisher with brokers %v and err: %v", config.Brokers, err)
+		}
+		return err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return publisher
+}
+
+func NewNotificationsProcessor(config runtimeInterfaces.NotificationsConfig, secretManager core.SecretManager,
+	scope promutils.Scope) interfaces.Processor {
 	reconnectAttempts := config.ReconnectAttempts
 	reconnectDelay := time.Duration(config.ReconnectDelaySeconds) * time.Second
 	var sub pubsub.Subscriber
@@ -120,8 +156,29 @@ func NewNotificationsProcessor(config runtimeInterfaces.NotificationsConfig, sco
 		}
 		emailer = GetEmailer(config, scope)
 		return implementations.NewGcpProcessor(sub, emailer, scope)
+	case common.Kafka:
+		topic := config.NotificationsProcessorConfig.QueueName
+		saramaConfig, err := implementations.NewKafkaClientConfig(context.TODO(), config.KafkaConfig, secretManager)
+		if err != nil {
+			panic(err)
+		}
+		err = async.Retry(reconnectAttempts, reconnectDelay, func() error {
+			sub, err = implementations.NewKafkaSubscriber(config.KafkaConfig, topic, saramaConfig)
+			if err != nil {
+				logger.Warnf(context.TODO(), "Failed to initialize new kafka subscriber with brokers %v and topic %s and err: %v", config.KafkaConfig.Brokers, topic, err)
+			}
+			return err
+		})
+		if err != nil {
+			panic(err)
+		}
+		emailer = GetEmailer(config, scope)
+		// Like GCP pub/sub messages, Kafka messages carry the serialized email protos.
+		return implementations.NewGcpProcessor(sub, emailer, scope)
 	case common.Local:
-		fallthrough
+		emailer = GetEmailer(config, scope)
+		return implementations.NewLocalProcessor(getLocalQueue(config, scope), emailer,
+			config.NotificationsLocalConfig.Workers, scope)
 	default:
 		logger.Infof(context.Background(),
 			"Using default noop notifications processor implementation for config type [%s]", config.Type)
@@ -129,7 +186,8 @@ func NewNotificationsProcessor(config runtimeInterfaces.NotificationsConfig, sco
 	}
 }
 
-func NewNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, scope promutils.Scope) interfaces.Publisher {
+func NewNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, secretManager core.SecretManager,
+	scope promutils.Scope) interfaces.Publisher {
 	reconnectAttempts := config.ReconnectAttempts
 	reconnectDelay := time.Duration(config.ReconnectDelaySeconds) * time.Second
 	switch config.Type {
@@ -171,8 +229,12 @@ func NewNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, sco
 			panic(err)
 		}
 		return implementations.NewPublisher(publisher, scope)
+	case common.Kafka:
+		publisher := newKafkaPublisher(config.KafkaConfig, config.NotificationsPublisherConfig.TopicName,
+			secretManager, reconnectAttempts, reconnectDelay)
+		return implementations.NewPublisher(publisher, scope)
 	case common.Local:
-		fallthrough
+		return implementations.NewPublisher(getLocalQueue(config, scope), scope)
 	default:
 		logger.Infof(context.Background(),
 			"Using default noop notifications publisher implementation for config type [%s]", config.Type)
@@ -180,7 +242,57 @@ func NewNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, sco
 	}
 }
 
-func NewEventsPublisher(config runtimeInterfaces.ExternalEventsConfig, scope promutils.Scope) interfaces.Publisher {
+// Wraps the notifications publisher so that Slack notifications to channels with a configured webhook are posted to
+// them rather than published.
+func NewSlackNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, secretManager core.SecretManager,
+	publisher interfaces.Publisher, scope promutils.Scope) interfaces.Publisher {
+	if len(config.NotificationsSlackConfig.Channels) == 0 {
+		return publisher
+	}
+	slackPublisher, err := implementations.NewSlackWebhookPublisher(
+		context.Background(), config.NotificationsSlackConfig, secretManager, publisher, scope)
+	if err != nil {
+		panic(err)
+	}
+	return slackPublisher
+}
+
+// Wraps the notifications publisher so that webhook notifications are sent to the configured webhooks rather than
+// published.
+func NewWebhookNotificationsPublisher(config runtimeInterfaces.NotificationsConfig, secretManager core.SecretManager,
+	publisher interfaces.Publisher, scope promutils.Scope) interfaces.Publisher {
+	if len(config.NotificationsWebhookConfig.Endpoints) == 0 {
+		return publisher
+	}
+	for webhook := range config.NotificationsWebhookConfig.Endpoints {
+		if err := ValidateWebhook(config, webhook); err != nil {
+			panic(err)
+		}
+	}
+	webhookPublisher, err := implementations.NewWebhookPublisher(
+		context.Background(), config.NotificationsWebhookConfig, secretManager, publisher, scope)
+	if err != nil {
+		panic(err)
+	}
+	return webhookPublisher
+}
+
+// Publishes events with the configured encoding.
+func newEncodedEventsPublisher(config runtimeInterfaces.ExternalEventsConfig, publisher pubsub.Publisher,
+	scope promutils.Scope) interfaces.Publisher {
+	switch config.Encoding {
+	case implementations.CloudEventsEncoding:
+		return implementations.NewCloudEventsPublisher(publisher, scope, config.EventsPublisherConfig.EventTypes,
+			config.CloudEventsSource)
+	case implementations.ProtoEventEncoding, "":
+		return implementations.NewEventsPublisher(publisher, scope, config.EventsPublisherConfig.EventTypes)
+	default:
+		panic(fmt.Errorf("unsupported events encoding [%s]", config.Encoding))
+	}
+}
+
+func NewEventsPublisher(config runtimeInterfaces.ExternalEventsConfig, secretManager core.SecretManager,
+	scope promutils.Scope) interfaces.Publisher {
 	if !config.Enable {
 		return implementations.NewNoopPublish()
 	}
@@ -204,7 +316,7 @@ func NewEventsPublisher(config runtimeInterfaces.ExternalEventsConfig, scope pro
 		if err != nil {
 			panic(err)
 		}
-		return implementations.NewEventsPublisher(publisher, scope, config.EventsPublisherConfig.EventTypes)
+		return newEncodedEventsPublisher(config, publisher, scope)
 	case common.GCP:
 		pubsubConfig := gizmoGCP.Config{
 			Topic: config.EventsPublisherConfig.TopicName,
@@ -220,7 +332,11 @@ func NewEventsPublisher(config runtimeInterfaces.ExternalEventsConfig, scope pro
 		if err != nil {
 			panic(err)
 		}
-		return implementations.NewEventsPublisher(publisher, scope, config.EventsPublisherConfig.EventTypes)
+		return newEncodedEventsPublisher(config, publisher, scope)
+	case common.Kafka:
+		publisher := newKafkaPublisher(config.KafkaConfig, config.EventsPublisherConfig.TopicName, secretManager,
+			reconnectAttempts, reconnectDelay)
+		return newEncodedEventsPublisher(config, publisher, scope)
 	case common.Local:
 		fallthrough
 	default:
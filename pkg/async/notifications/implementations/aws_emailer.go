@@ -29,6 +29,19 @@ func FlyteEmailToSesEmailInput(email admin.EmailMessage) ses.SendEmailInput {
 		toAddress = append(toAddress, &e)
 	}
 
+	htmlBody, textBody := splitEmailBodies(email.Body)
+	body := &ses.Body{}
+	if len(htmlBody) > 0 || len(textBody) == 0 {
+		body.Html = &ses.Content{
+			Data: &htmlBody,
+		}
+	}
+	if len(textBody) > 0 {
+		body.Text = &ses.Content{
+			Data: &textBody,
+		}
+	}
+
 	return ses.SendEmailInput{
 		Destination: &ses.Destination{
 			ToAddresses: toAddress,
@@ -38,11 +51,7 @@ func FlyteEmailToSesEmailInput(email admin.EmailMessage) ses.SendEmailInput {
 		// workaround and defer back to email.SenderEmail
 		Source: &email.SenderEmail,
 		Message: &ses.Message{
-			Body: &ses.Body{
-				Html: &ses.Content{
-					Data: &email.Body,
-				},
-			},
+			Body: body,
 			Subject: &ses.Content{
 				Data: &email.SubjectLine,
 			},
@@ -85,6 +85,20 @@ func TestFlyteEmailToSesEmailInput(t *testing.T) {
 	assert.Equal(t, *sesEmailInput.Message.Subject.Data, "Notice: Execution \"name\" has succeeded in \"domain\".")
 }
 
+func TestFlyteEmailToSesEmailInput_TextAlternative(t *testing.T) {
+	sesEmailInput := FlyteEmailToSesEmailInput(admin.EmailMessage{
+		Body: JoinEmailBodies("<p>Execution succeeded</p>", "Execution succeeded"),
+	})
+	assert.Equal(t, "<p>Execution succeeded</p>", *sesEmailInput.Message.Body.Html.Data)
+	assert.Equal(t, "Execution succeeded", *sesEmailInput.Message.Body.Text.Data)
+
+	sesEmailInput = FlyteEmailToSesEmailInput(admin.EmailMessage{
+		Body: "<p>Execution succeeded</p>",
+	})
+	assert.Equal(t, "<p>Execution succeeded</p>", *sesEmailInput.Message.Body.Html.Data)
+	assert.Nil(t, sesEmailInput.Message.Body.Text)
+}
+
 func TestAwsEmailer_SendEmailError(t *testing.T) {
 	mockAwsEmail := mocks.SESClient{}
 	var awsSES sesiface.SESAPI
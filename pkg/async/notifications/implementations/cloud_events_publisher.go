@@ -0,0 +1,199 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/NYTimes/gizmo/pubsub"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Encodings of published events.
+const (
+	// Publishes the admin event requests as serialized protos.
+	ProtoEventEncoding = "proto"
+	// Publishes the admin event requests wrapped in CloudEvents v1.0 JSON envelopes.
+	CloudEventsEncoding = "cloudevents"
+)
+
+const (
+	cloudEventsSpecVersion     = "1.0"
+	cloudEventsDataContentType = "application/json"
+	// The source of published events when none is configured.
+	defaultCloudEventsSource = "flyteadmin"
+)
+
+// CloudEvents types of the published events.
+const (
+	workflowExecutionCloudEventType = "org.flyte.workflow.execution"
+	nodeExecutionCloudEventType     = "org.flyte.node.execution"
+	taskExecutionCloudEventType     = "org.flyte.task.execution"
+)
+
+// A CloudEvents v1.0 envelope in the structured JSON format.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// The data of published events: the admin event request along with the identifiers of the execution it belongs to.
+type cloudEventData struct {
+	ExecutionID     json.RawMessage `json:"executionId"`
+	NodeExecutionID json.RawMessage `json:"nodeExecutionId,omitempty"`
+	TaskID          json.RawMessage `json:"taskId,omitempty"`
+	RetryAttempt    *uint32         `json:"retryAttempt,omitempty"`
+	Phase           string          `json:"phase"`
+	Event           json.RawMessage `json:"event"`
+}
+
+var cloudEventsMarshaler = jsonpb.Marshaler{}
+
+// Marshals protos to JSON, with missing protos marshalled as null.
+func marshalCloudEventsJSON(msg proto.Message) (json.RawMessage, error) {
+	if reflect.ValueOf(msg).IsNil() {
+		return nil, nil
+	}
+	raw, err := cloudEventsMarshaler.MarshalToString(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(raw), nil
+}
+
+// Publishes execution events wrapped in CloudEvents envelopes.
+type CloudEventsPublisher struct {
+	pub           pubsub.Publisher
+	systemMetrics eventPublisherSystemMetrics
+	events        sets.String
+	source        string
+}
+
+func (p *CloudEventsPublisher) Publish(ctx context.Context, notificationType string, msg proto.Message) error {
+	p.systemMetrics.PublishTotal.Inc()
+
+	if !p.events.Has(notificationType) {
+		return nil
+	}
+	payload, err := p.toCloudEvent(msg)
+	if err != nil {
+		p.systemMetrics.PublishError.Inc()
+		logger.Errorf(ctx, "Failed to convert message [%s] to a cloud event with error: %v", msg.String(), err)
+		return err
+	}
+	logger.Debugf(ctx, "Publishing the following cloud event [%s]", payload)
+
+	err = p.pub.PublishRaw(contextWithKafkaPartitionKey(ctx, msg), notificationType, payload)
+	if err != nil {
+		p.systemMetrics.PublishError.Inc()
+		logger.Errorf(ctx, "Failed to publish a cloud event with key [%s] and message [%s] and error: %v",
+			notificationType, msg.String(), err)
+	} else {
+		p.systemMetrics.PublishSuccess.Inc()
+	}
+	return err
+}
+
+func (p *CloudEventsPublisher) toCloudEvent(msg proto.Message) ([]byte, error) {
+	var eventType, subject string
+	var occurredAt *timestamp.Timestamp
+	var data cloudEventData
+	var err error
+	switch request := msg.(type) {
+	case *admin.WorkflowExecutionEventRequest:
+		executionID := request.GetEvent().GetExecutionId()
+		eventType = workflowExecutionCloudEventType
+		subject = fmt.Sprintf("%s/%s/%s", executionID.GetProject(), executionID.GetDomain(), executionID.GetName())
+		occurredAt = request.GetEvent().GetOccurredAt()
+		data.Phase = request.GetEvent().GetPhase().String()
+		if data.ExecutionID, err = marshalCloudEventsJSON(executionID); err != nil {
+			return nil, err
+		}
+	case *admin.NodeExecutionEventRequest:
+		nodeExecutionID := request.GetEvent().GetId()
+		executionID := nodeExecutionID.GetExecutionId()
+		eventType = nodeExecutionCloudEventType
+		subject = fmt.Sprintf("%s/%s/%s/%s", executionID.GetProject(), executionID.GetDomain(), executionID.GetName(),
+			nodeExecutionID.GetNodeId())
+		occurredAt = request.GetEvent().GetOccurredAt()
+		data.Phase = request.GetEvent().GetPhase().String()
+		if data.ExecutionID, err = marshalCloudEventsJSON(executionID); err != nil {
+			return nil, err
+		}
+		if data.NodeExecutionID, err = marshalCloudEventsJSON(nodeExecutionID); err != nil {
+			return nil, err
+		}
+	case *admin.TaskExecutionEventRequest:
+		nodeExecutionID := request.GetEvent().GetParentNodeExecutionId()
+		executionID := nodeExecutionID.GetExecutionId()
+		retryAttempt := request.GetEvent().GetRetryAttempt()
+		eventType = taskExecutionCloudEventType
+		subject = fmt.Sprintf("%s/%s/%s/%s/%s/%d", executionID.GetProject(), executionID.GetDomain(),
+			executionID.GetName(), nodeExecutionID.GetNodeId(), request.GetEvent().GetTaskId().GetName(), retryAttempt)
+		occurredAt = request.GetEvent().GetOccurredAt()
+		data.Phase = request.GetEvent().GetPhase().String()
+		data.RetryAttempt = &retryAttempt
+		if data.ExecutionID, err = marshalCloudEventsJSON(executionID); err != nil {
+			return nil, err
+		}
+		if data.NodeExecutionID, err = marshalCloudEventsJSON(nodeExecutionID); err != nil {
+			return nil, err
+		}
+		if data.TaskID, err = marshalCloudEventsJSON(request.GetEvent().GetTaskId()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported event type [%T]", msg)
+	}
+	if data.Event, err = marshalCloudEventsJSON(msg); err != nil {
+		return nil, err
+	}
+	eventTime, err := ptypes.Timestamp(occurredAt)
+	if err != nil {
+		eventTime = time.Now()
+	}
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.New().String(),
+		Source:          p.source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            eventTime.UTC().Format(time.RFC3339Nano),
+		DataContentType: cloudEventsDataContentType,
+		Data:            rawData,
+	})
+}
+
+func NewCloudEventsPublisher(pub pubsub.Publisher, scope promutils.Scope, eventTypes []string,
+	source string) interfaces.Publisher {
+	if len(source) == 0 {
+		source = defaultCloudEventsSource
+	}
+	return &CloudEventsPublisher{
+		pub:           pub,
+		systemMetrics: newEventPublisherSystemMetrics(scope.NewSubScope("cloud_events_publisher")),
+		events:        newEventSet(eventTypes),
+		source:        source,
+	}
+}
@@ -0,0 +1,138 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NYTimes/gizmo/pubsub/pubsubtest"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            struct {
+		ExecutionID     map[string]interface{} `json:"executionId"`
+		NodeExecutionID map[string]interface{} `json:"nodeExecutionId"`
+		TaskID          map[string]interface{} `json:"taskId"`
+		RetryAttempt    *uint32                `json:"retryAttempt"`
+		Phase           string                 `json:"phase"`
+		Event           json.RawMessage        `json:"event"`
+	} `json:"data"`
+}
+
+func publishTestCloudEvent(t *testing.T, msg proto.Message) testCloudEvent {
+	var testPublisher pubsubtest.TestPublisher
+	publisher := NewCloudEventsPublisher(&testPublisher, promutils.NewTestScope(), []string{"all"}, "https://flyte.example.com")
+
+	assert.NoError(t, publisher.Publish(context.Background(), proto.MessageName(msg), msg))
+	assert.Len(t, testPublisher.Published, 1)
+	assert.Equal(t, proto.MessageName(msg), testPublisher.Published[0].Key)
+	var cloudEvent testCloudEvent
+	assert.NoError(t, json.Unmarshal(testPublisher.Published[0].Body, &cloudEvent))
+
+	assert.Equal(t, "1.0", cloudEvent.SpecVersion)
+	assert.NotEmpty(t, cloudEvent.ID)
+	assert.Equal(t, "https://flyte.example.com", cloudEvent.Source)
+	assert.Equal(t, "application/json", cloudEvent.DataContentType)
+	assert.NotEmpty(t, cloudEvent.Time)
+	assert.Equal(t, map[string]interface{}{
+		"project": "project",
+		"domain":  "domain",
+		"name":    "name",
+	}, cloudEvent.Data.ExecutionID)
+
+	// The data carries the raw event request.
+	event := proto.Clone(msg)
+	event.Reset()
+	assert.NoError(t, jsonpb.UnmarshalString(string(cloudEvent.Data.Event), event))
+	assert.True(t, proto.Equal(msg, event))
+	return cloudEvent
+}
+
+func TestCloudEventsPublisher_WorkflowExecutionEvent(t *testing.T) {
+	cloudEvent := publishTestCloudEvent(t, workflowRequest)
+	assert.Equal(t, "org.flyte.workflow.execution", cloudEvent.Type)
+	assert.Equal(t, "project/domain/name", cloudEvent.Subject)
+	assert.Equal(t, "SUCCEEDED", cloudEvent.Data.Phase)
+	assert.Nil(t, cloudEvent.Data.NodeExecutionID)
+	assert.Nil(t, cloudEvent.Data.TaskID)
+	assert.Nil(t, cloudEvent.Data.RetryAttempt)
+}
+
+func TestCloudEventsPublisher_NodeExecutionEvent(t *testing.T) {
+	cloudEvent := publishTestCloudEvent(t, nodeRequest)
+	assert.Equal(t, "org.flyte.node.execution", cloudEvent.Type)
+	assert.Equal(t, "project/domain/name/node id", cloudEvent.Subject)
+	assert.Equal(t, occurredAt.Format(time.RFC3339Nano), cloudEvent.Time)
+	assert.Equal(t, "RUNNING", cloudEvent.Data.Phase)
+	assert.Equal(t, "node id", cloudEvent.Data.NodeExecutionID["nodeId"])
+	assert.Nil(t, cloudEvent.Data.TaskID)
+}
+
+func TestCloudEventsPublisher_TaskExecutionEvent(t *testing.T) {
+	cloudEvent := publishTestCloudEvent(t, taskRequest)
+	assert.Equal(t, "org.flyte.task.execution", cloudEvent.Type)
+	assert.Equal(t, "project/domain/name/node id/n/1", cloudEvent.Subject)
+	assert.Equal(t, occurredAt.Format(time.RFC3339Nano), cloudEvent.Time)
+	assert.Equal(t, "RUNNING", cloudEvent.Data.Phase)
+	assert.Equal(t, "node id", cloudEvent.Data.NodeExecutionID["nodeId"])
+	assert.Equal(t, map[string]interface{}{
+		"resourceType": "TASK",
+		"project":      "p",
+		"domain":       "d",
+		"name":         "n",
+		"version":      "v",
+	}, cloudEvent.Data.TaskID)
+	assert.Equal(t, uint32(1), *cloudEvent.Data.RetryAttempt)
+}
+
+func TestCloudEventsPublisher_EventTypes(t *testing.T) {
+	var testPublisher pubsubtest.TestPublisher
+	publisher := NewCloudEventsPublisher(&testPublisher, promutils.NewTestScope(), []string{"workflow"}, "")
+
+	assert.NoError(t, publisher.Publish(context.Background(), proto.MessageName(nodeRequest), nodeRequest))
+	assert.Empty(t, testPublisher.Published)
+	assert.NoError(t, publisher.Publish(context.Background(), proto.MessageName(workflowRequest), workflowRequest))
+	assert.Len(t, testPublisher.Published, 1)
+
+	var cloudEvent testCloudEvent
+	assert.NoError(t, json.Unmarshal(testPublisher.Published[0].Body, &cloudEvent))
+	assert.Equal(t, "flyteadmin", cloudEvent.Source)
+}
+
+func TestCloudEventsPublisher_PublishError(t *testing.T) {
+	testPublisher := pubsubtest.TestPublisher{
+		GivenError: errors.New("publish failed"),
+	}
+	publisher := NewCloudEventsPublisher(&testPublisher, promutils.NewTestScope(), []string{"all"}, "")
+
+	err := publisher.Publish(context.Background(), proto.MessageName(workflowRequest), workflowRequest)
+	assert.EqualError(t, err, "publish failed")
+}
+
+func TestCloudEventsPublisher_UnsupportedEvent(t *testing.T) {
+	var testPublisher pubsubtest.TestPublisher
+	publisher := &CloudEventsPublisher{
+		pub:           &testPublisher,
+		systemMetrics: newEventPublisherSystemMetrics(promutils.NewTestScope()),
+		events:        newEventSet([]string{"all"}),
+	}
+	msg := &admin.WorkflowExecutionEventResponse{}
+
+	err := publisher.Publish(context.Background(), proto.MessageName(workflowRequest), msg)
+	assert.EqualError(t, err, "unsupported event type [*admin.WorkflowExecutionEventResponse]")
+	assert.Empty(t, testPublisher.Published)
+}
@@ -1,7 +1,30 @@
 package implementations
 
+import "strings"
+
 type ExternalEmailer = string
 
 const (
 	Sendgrid ExternalEmailer = "sendgrid"
 )
+
+// Separates the HTML body of email messages from their plain text alternative. admin.EmailMessage only has a single
+// body, so emails with both carry the plain text after the HTML, following this separator.
+const textBodySeparator = "\n<!-- flyte:text/plain -->\n"
+
+// Joins the HTML body of an email with its plain text alternative into the body of an admin.EmailMessage.
+func JoinEmailBodies(htmlBody, textBody string) string {
+	if len(textBody) == 0 {
+		return htmlBody
+	}
+	return htmlBody + textBodySeparator + textBody
+}
+
+// Splits the body of an admin.EmailMessage into its HTML body and plain text alternative, if any.
+func splitEmailBodies(body string) (htmlBody, textBody string) {
+	index := strings.LastIndex(body, textBodySeparator)
+	if index < 0 {
+		return body, ""
+	}
+	return body[:index], body[index+len(textBodySeparator):]
+}
@@ -80,7 +80,8 @@ func newEventPublisherSystemMetrics(scope promutils.Scope) eventPublisherSystemM
 	}
 }
 
-func NewEventsPublisher(pub pubsub.Publisher, scope promutils.Scope, eventTypes []string) interfaces.Publisher {
+// Returns the message names of the configured event types.
+func newEventSet(eventTypes []string) sets.String {
 	eventSet := sets.NewString()
 
 	for _, event := range eventTypes {
@@ -93,13 +94,16 @@ func NewEventsPublisher(pub pubsub.Publisher, scope promutils.Scope, eventTypes
 		if e, found := supportedEvents[event]; found {
 			eventSet = eventSet.Insert(e)
 		} else {
-			logger.Errorf(context.Background(), "Unsupported event type [%s] in the config")
+			logger.Errorf(context.Background(), "Unsupported event type [%s] in the config", event)
 		}
 	}
+	return eventSet
+}
 
+func NewEventsPublisher(pub pubsub.Publisher, scope promutils.Scope, eventTypes []string) interfaces.Publisher {
 	return &EventPublisher{
 		pub:           pub,
 		systemMetrics: newEventPublisherSystemMetrics(scope.NewSubScope("events_publisher")),
-		events:        eventSet,
+		events:        newEventSet(eventTypes),
 	}
 }
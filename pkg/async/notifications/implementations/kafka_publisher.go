@@ -0,0 +1,213 @@
+package implementations
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	pluginCore "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/proto"
+)
+
+// Header of published Kafka messages holding the notification type they were published with.
+const KafkaNotificationTypeHeader = "notificationType"
+
+// Record headers were introduced in Kafka 0.11.0.
+var defaultKafkaVersion = sarama.V0_11_0_0
+
+type kafkaPartitionKeyContextKey struct{}
+
+// Returns the partition key of published messages: the execution the message belongs to, so that all messages of an
+// execution land in the same partition and keep their order. Messages without an execution, such as notification
+// emails, have no partition key and are spread across partitions.
+func getKafkaPartitionKey(msg proto.Message) string {
+	var executionID *core.WorkflowExecutionIdentifier
+	switch request := msg.(type) {
+	case *admin.WorkflowExecutionEventRequest:
+		executionID = request.GetEvent().GetExecutionId()
+	case *admin.NodeExecutionEventRequest:
+		executionID = request.GetEvent().GetId().GetExecutionId()
+	case *admin.TaskExecutionEventRequest:
+		executionID = request.GetEvent().GetParentNodeExecutionId().GetExecutionId()
+	}
+	if executionID == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s", executionID.GetProject(), executionID.GetDomain(), executionID.GetName())
+}
+
+// Sets the partition key of messages published raw, which can't be derived from their body.
+func contextWithKafkaPartitionKey(ctx context.Context, msg proto.Message) context.Context {
+	return context.WithValue(ctx, kafkaPartitionKeyContextKey{}, getKafkaPartitionKey(msg))
+}
+
+// Builds the Kafka client config shared by publishers and subscribers, reading the TLS CA and SASL password from the
+// secret manager.
+func NewKafkaClientConfig(ctx context.Context, config runtimeInterfaces.KafkaConfig,
+	secretManager pluginCore.SecretManager) (*sarama.Config, error) {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = defaultKafkaVersion
+	if len(config.Version) > 0 {
+		version, err := sarama.ParseKafkaVersion(config.Version)
+		if err != nil {
+			return nil, err
+		}
+		saramaConfig.Version = version
+	}
+	if config.TLS.Enable {
+		tlsConfig := &tls.Config{
+			// #nosec G402
+			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
+		}
+		if len(config.TLS.CACertSecretName) > 0 {
+			caCert, err := getSecret(ctx, secretManager, config.TLS.CACertSecretName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the Kafka CA certificate secret: %v", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+				return nil, fmt.Errorf("no certificates found in the Kafka CA certificate secret")
+			}
+		}
+		saramaConfig.Net.TLS.Enable = true
+		saramaConfig.Net.TLS.Config = tlsConfig
+	}
+	if config.SASL.Enable {
+		password, err := getSecret(ctx, secretManager, config.SASL.PasswordSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Kafka SASL password secret: %v", err)
+		}
+		saramaConfig.Net.SASL.Enable = true
+		saramaConfig.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		saramaConfig.Net.SASL.User = config.SASL.User
+		saramaConfig.Net.SASL.Password = password
+	}
+	if config.BufferSize > 0 {
+		saramaConfig.ChannelBufferSize = config.BufferSize
+	}
+	saramaConfig.Producer.Flush.Frequency = config.FlushInterval.Duration
+	saramaConfig.Producer.Flush.Messages = config.FlushMessages
+	return saramaConfig, nil
+}
+
+// Publishes messages to a Kafka topic. Messages are handed to a producer which sends them in the background, so that
+// slow brokers don't hold up publishing. Publishing fails rather than blocking while the producer's buffer is full, and
+// failures to send buffered messages are logged.
+type KafkaPublisher struct {
+	topic       string
+	newProducer func() (sarama.AsyncProducer, error)
+	producer    sarama.AsyncProducer
+	closed      bool
+	mutex       sync.Mutex
+}
+
+// Must hold the mutex.
+func (p *KafkaPublisher) getProducer() (sarama.AsyncProducer, error) {
+	if p.closed {
+		return nil, errors.New("the Kafka publisher is closed")
+	}
+	if p.producer == nil {
+		producer, err := p.newProducer()
+		if err != nil {
+			return nil, err
+		}
+		go logKafkaProducerErrors(p.topic, producer)
+		p.producer = producer
+	}
+	return p.producer, nil
+}
+
+// Runs until the producer is closed.
+func logKafkaProducerErrors(topic string, producer sarama.AsyncProducer) {
+	for err := range producer.Errors() {
+		logger.Errorf(context.Background(), "Failed to publish a message to Kafka topic [%s] with err: %v", topic, err.Err)
+	}
+}
+
+func (p *KafkaPublisher) send(key, partitionKey string, body []byte) error {
+	message := &sarama.ProducerMessage{
+		Topic: p.topic,
+		Value: sarama.ByteEncoder(body),
+		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte(KafkaNotificationTypeHeader),
+				Value: []byte(key),
+			},
+		},
+		Timestamp: time.Now(),
+	}
+	if len(partitionKey) > 0 {
+		message.Key = sarama.StringEncoder(partitionKey)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	producer, err := p.getProducer()
+	if err != nil {
+		return err
+	}
+	select {
+	case producer.Input() <- message:
+		return nil
+	default:
+		return fmt.Errorf("the buffer of messages published to Kafka topic [%s] is full", p.topic)
+	}
+}
+
+// Publishes the serialized message, the key is the notification type.
+func (p *KafkaPublisher) Publish(ctx context.Context, key string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return p.send(key, getKafkaPartitionKey(msg), body)
+}
+
+func (p *KafkaPublisher) PublishRaw(ctx context.Context, key string, body []byte) error {
+	partitionKey, _ := ctx.Value(kafkaPartitionKeyContextKey{}).(string)
+	return p.send(key, partitionKey, body)
+}
+
+// Blocks until the buffered messages are sent before closing the producer.
+func (p *KafkaPublisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed || p.producer == nil {
+		p.closed = true
+		return nil
+	}
+	p.closed = true
+	return p.producer.Close()
+}
+
+func newKafkaPublisher(topic string, newProducer func() (sarama.AsyncProducer, error), lazyConnect bool) (
+	*KafkaPublisher, error) {
+	publisher := &KafkaPublisher{
+		topic:       topic,
+		newProducer: newProducer,
+	}
+	if !lazyConnect {
+		publisher.mutex.Lock()
+		defer publisher.mutex.Unlock()
+		if _, err := publisher.getProducer(); err != nil {
+			return nil, err
+		}
+	}
+	return publisher, nil
+}
+
+// Connects to the brokers right away, failing when they're unreachable, unless lazy connect is set.
+func NewKafkaPublisher(config runtimeInterfaces.KafkaConfig, topic string, saramaConfig *sarama.Config) (
+	*KafkaPublisher, error) {
+	return newKafkaPublisher(topic, func() (sarama.AsyncProducer, error) {
+		return sarama.NewAsyncProducer(config.Brokers, saramaConfig)
+	}, config.LazyConnect)
+}
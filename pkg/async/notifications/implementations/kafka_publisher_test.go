@@ -0,0 +1,219 @@
+package implementations
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	pluginMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Buffers the messages published through it until they're read by the test.
+type fakeAsyncProducer struct {
+	input  chan *sarama.ProducerMessage
+	errors chan *sarama.ProducerError
+	closed bool
+}
+
+func (p *fakeAsyncProducer) AsyncClose() {
+	p.closed = true
+	close(p.errors)
+}
+
+func (p *fakeAsyncProducer) Close() error {
+	p.AsyncClose()
+	return nil
+}
+
+func (p *fakeAsyncProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *fakeAsyncProducer) Successes() <-chan *sarama.ProducerMessage {
+	return nil
+}
+
+func (p *fakeAsyncProducer) Errors() <-chan *sarama.ProducerError {
+	return p.errors
+}
+
+func getKafkaTestPublisher(t *testing.T, bufferSize int) (*KafkaPublisher, *fakeAsyncProducer) {
+	producer := &fakeAsyncProducer{
+		input:  make(chan *sarama.ProducerMessage, bufferSize),
+		errors: make(chan *sarama.ProducerError),
+	}
+	publisher, err := newKafkaPublisher("events", func() (sarama.AsyncProducer, error) {
+		return producer, nil
+	}, false)
+	assert.NoError(t, err)
+	return publisher, producer
+}
+
+func getKafkaMessageHeader(message *sarama.ProducerMessage, key string) string {
+	for _, header := range message.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func TestKafkaPublisher_Publish(t *testing.T) {
+	publisher, producer := getKafkaTestPublisher(t, 10)
+
+	assert.NoError(t, publisher.Publish(context.Background(), proto.MessageName(nodeRequest), nodeRequest))
+	message := <-producer.input
+	assert.Equal(t, "events", message.Topic)
+	assert.Equal(t, sarama.StringEncoder("project/domain/name"), message.Key)
+	assert.Equal(t, proto.MessageName(nodeRequest), getKafkaMessageHeader(message, KafkaNotificationTypeHeader))
+	var request admin.NodeExecutionEventRequest
+	assert.NoError(t, proto.Unmarshal(message.Value.(sarama.ByteEncoder), &request))
+	assert.True(t, proto.Equal(nodeRequest, &request))
+
+	assert.NoError(t, publisher.Close())
+	assert.True(t, producer.closed)
+	assert.Error(t, publisher.Publish(context.Background(), proto.MessageName(nodeRequest), nodeRequest))
+	assert.NoError(t, publisher.Close())
+}
+
+func TestKafkaPublisher_PartitionKeys(t *testing.T) {
+	publisher, producer := getKafkaTestPublisher(t, 10)
+
+	// All events of an execution share its partition key.
+	assert.NoError(t, publisher.Publish(context.Background(), "workflow", workflowRequest))
+	assert.NoError(t, publisher.Publish(context.Background(), "task", taskRequest))
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, sarama.StringEncoder("project/domain/name"), (<-producer.input).Key)
+	}
+
+	assert.NoError(t, publisher.Publish(context.Background(), "email", &admin.EmailMessage{
+		RecipientsEmail: []string{"team@example.com"},
+	}))
+	message := <-producer.input
+	assert.Nil(t, message.Key)
+	assert.Equal(t, "email", getKafkaMessageHeader(message, KafkaNotificationTypeHeader))
+
+	assert.NoError(t, publisher.PublishRaw(contextWithKafkaPartitionKey(context.Background(), taskRequest), "task",
+		[]byte("{}")))
+	message = <-producer.input
+	assert.Equal(t, sarama.StringEncoder("project/domain/name"), message.Key)
+	assert.Equal(t, sarama.ByteEncoder("{}"), message.Value)
+}
+
+func TestKafkaPublisher_BufferFull(t *testing.T) {
+	publisher, producer := getKafkaTestPublisher(t, 1)
+
+	// The brokers don't keep up, publishing fails rather than blocking.
+	assert.NoError(t, publisher.Publish(context.Background(), "workflow", workflowRequest))
+	err := publisher.Publish(context.Background(), "workflow", workflowRequest)
+	assert.EqualError(t, err, "the buffer of messages published to Kafka topic [events] is full")
+
+	<-producer.input
+	assert.NoError(t, publisher.Publish(context.Background(), "workflow", workflowRequest))
+}
+
+func TestNewKafkaPublisher_Unreachable(t *testing.T) {
+	var attempts int
+	newProducer := func() (sarama.AsyncProducer, error) {
+		attempts++
+		return nil, sarama.ErrOutOfBrokers
+	}
+	_, err := newKafkaPublisher("events", newProducer, false)
+	assert.Equal(t, sarama.ErrOutOfBrokers, err)
+	assert.Equal(t, 1, attempts)
+
+	// With lazy connect the publisher connects on publish, and keeps trying on later ones.
+	publisher, err := newKafkaPublisher("events", newProducer, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, sarama.ErrOutOfBrokers, publisher.Publish(context.Background(), "workflow", workflowRequest))
+	assert.Equal(t, sarama.ErrOutOfBrokers, publisher.Publish(context.Background(), "workflow", workflowRequest))
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, publisher.Close())
+}
+
+func TestNewKafkaClientConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "kafka-ca").Return(string(caCert), nil)
+	secretManager.OnGetMatch(mock.Anything, "kafka-password").Return("password\n", nil)
+
+	saramaConfig, err := NewKafkaClientConfig(context.Background(), runtimeInterfaces.KafkaConfig{
+		Brokers: []string{"localhost:9092"},
+		Version: "2.4.0",
+		TLS: runtimeInterfaces.KafkaTLSConfig{
+			Enable:           true,
+			CACertSecretName: "kafka-ca",
+		},
+		SASL: runtimeInterfaces.KafkaSASLConfig{
+			Enable:             true,
+			User:               "flyteadmin",
+			PasswordSecretName: "kafka-password",
+		},
+		FlushInterval: config.Duration{Duration: 100 * time.Millisecond},
+		FlushMessages: 50,
+	}, secretManager)
+	assert.NoError(t, err)
+	assert.NoError(t, saramaConfig.Validate())
+	assert.Equal(t, sarama.V2_4_0_0, saramaConfig.Version)
+	assert.True(t, saramaConfig.Net.TLS.Enable)
+	assert.NotNil(t, saramaConfig.Net.TLS.Config.RootCAs)
+	assert.True(t, saramaConfig.Net.SASL.Enable)
+	assert.Equal(t, "flyteadmin", saramaConfig.Net.SASL.User)
+	assert.Equal(t, "password", saramaConfig.Net.SASL.Password)
+	assert.Equal(t, 100*time.Millisecond, saramaConfig.Producer.Flush.Frequency)
+	assert.Equal(t, 50, saramaConfig.Producer.Flush.Messages)
+}
+
+func TestNewKafkaClientConfig_Defaults(t *testing.T) {
+	saramaConfig, err := NewKafkaClientConfig(context.Background(), runtimeInterfaces.KafkaConfig{},
+		&pluginMocks.SecretManager{})
+	assert.NoError(t, err)
+	assert.NoError(t, saramaConfig.Validate())
+	assert.Equal(t, sarama.V0_11_0_0, saramaConfig.Version)
+	assert.False(t, saramaConfig.Net.TLS.Enable)
+	assert.False(t, saramaConfig.Net.SASL.Enable)
+}
+
+func TestNewKafkaClientConfig_Errors(t *testing.T) {
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "kafka-ca").Return("not a certificate", nil)
+	secretManager.OnGetMatch(mock.Anything, "kafka-password").Return("", errors.New("secret not found"))
+
+	_, err := NewKafkaClientConfig(context.Background(), runtimeInterfaces.KafkaConfig{
+		Version: "latest",
+	}, secretManager)
+	assert.Error(t, err)
+
+	_, err = NewKafkaClientConfig(context.Background(), runtimeInterfaces.KafkaConfig{
+		TLS: runtimeInterfaces.KafkaTLSConfig{
+			Enable:           true,
+			CACertSecretName: "kafka-ca",
+		},
+	}, secretManager)
+	assert.EqualError(t, err, "no certificates found in the Kafka CA certificate secret")
+
+	_, err = NewKafkaClientConfig(context.Background(), runtimeInterfaces.KafkaConfig{
+		SASL: runtimeInterfaces.KafkaSASLConfig{
+			Enable:             true,
+			PasswordSecretName: "kafka-password",
+		},
+	}, secretManager)
+	assert.EqualError(t, err, "failed to read the Kafka SASL password secret: secret not found")
+}
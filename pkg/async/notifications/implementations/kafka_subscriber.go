@@ -0,0 +1,119 @@
+package implementations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NYTimes/gizmo/pubsub"
+	"github.com/Shopify/sarama"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+)
+
+const defaultKafkaConsumerGroup = "flyteadmin"
+
+type kafkaSubscriberMessage struct {
+	session sarama.ConsumerGroupSession
+	message *sarama.ConsumerMessage
+}
+
+func (m *kafkaSubscriberMessage) Message() []byte {
+	return m.message.Value
+}
+
+func (m *kafkaSubscriberMessage) ExtendDoneDeadline(time.Duration) error {
+	return nil
+}
+
+// Marks the message as consumed, its offset is committed along with the group session.
+func (m *kafkaSubscriberMessage) Done() error {
+	m.session.MarkMessage(m.message, "")
+	return nil
+}
+
+// Reads messages of a Kafka topic as a member of a consumer group, so that several processors share its partitions.
+type KafkaSubscriber struct {
+	group    sarama.ConsumerGroup
+	topic    string
+	messages chan pubsub.SubscriberMessage
+	cancel   context.CancelFunc
+	err      error
+	mutex    sync.Mutex
+}
+
+func (s *KafkaSubscriber) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (s *KafkaSubscriber) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (s *KafkaSubscriber) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for message := range claim.Messages() {
+		select {
+		case s.messages <- &kafkaSubscriberMessage{
+			session: session,
+			message: message,
+		}:
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// Joins the consumer group, the returned channel closes once the subscriber is stopped or consuming fails.
+func (s *KafkaSubscriber) Start() <-chan pubsub.SubscriberMessage {
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan pubsub.SubscriberMessage)
+	s.mutex.Lock()
+	s.messages = messages
+	s.cancel = cancel
+	s.err = nil
+	s.mutex.Unlock()
+	go func() {
+		defer close(messages)
+		// Consume returns whenever the group rebalances, and is called again to rejoin it.
+		for ctx.Err() == nil {
+			if err := s.group.Consume(ctx, []string{s.topic}, s); err != nil {
+				s.mutex.Lock()
+				s.err = err
+				s.mutex.Unlock()
+				return
+			}
+		}
+	}()
+	return messages
+}
+
+func (s *KafkaSubscriber) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+func (s *KafkaSubscriber) Stop() error {
+	s.mutex.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mutex.Unlock()
+	return s.group.Close()
+}
+
+func NewKafkaSubscriber(config runtimeInterfaces.KafkaConfig, topic string, saramaConfig *sarama.Config) (
+	pubsub.Subscriber, error) {
+	consumerGroup := config.ConsumerGroup
+	if len(consumerGroup) == 0 {
+		consumerGroup = defaultKafkaConsumerGroup
+	}
+	group, err := sarama.NewConsumerGroup(config.Brokers, consumerGroup, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaSubscriber{
+		group: group,
+		topic: topic,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package implementations
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConsumerGroupSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+	mutex  sync.Mutex
+}
+
+func (s *testConsumerGroupSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.marked = append(s.marked, msg)
+}
+
+type testConsumerGroupClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+// Hands the messages of a single claim to the subscriber, until they run out or the session ends.
+type testConsumerGroup struct {
+	sarama.ConsumerGroup
+	session  *testConsumerGroupSession
+	messages []*sarama.ConsumerMessage
+	err      error
+	closed   bool
+}
+
+func (g *testConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	if g.err != nil {
+		return g.err
+	}
+	g.session.ctx = ctx
+	claim := &testConsumerGroupClaim{
+		messages: make(chan *sarama.ConsumerMessage, len(g.messages)),
+	}
+	for _, message := range g.messages {
+		claim.messages <- message
+	}
+	close(claim.messages)
+	g.messages = nil
+	if err := handler.ConsumeClaim(g.session, claim); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (g *testConsumerGroup) Close() error {
+	g.closed = true
+	return nil
+}
+
+func TestKafkaSubscriber(t *testing.T) {
+	group := &testConsumerGroup{
+		session: &testConsumerGroupSession{},
+		messages: []*sarama.ConsumerMessage{
+			{Value: []byte("first"), Offset: 1},
+			{Value: []byte("second"), Offset: 2},
+		},
+	}
+	subscriber := &KafkaSubscriber{
+		group: group,
+		topic: "notifications",
+	}
+
+	messages := subscriber.Start()
+	first := <-messages
+	assert.Equal(t, []byte("first"), first.Message())
+	assert.NoError(t, first.Done())
+	second := <-messages
+	assert.Equal(t, []byte("second"), second.Message())
+	assert.NoError(t, second.Done())
+	assert.Len(t, group.session.marked, 2)
+	assert.Equal(t, int64(2), group.session.marked[1].Offset)
+
+	assert.NoError(t, subscriber.Stop())
+	_, ok := <-messages
+	assert.False(t, ok)
+	assert.NoError(t, subscriber.Err())
+	assert.True(t, group.closed)
+}
+
+func TestKafkaSubscriber_ConsumeError(t *testing.T) {
+	subscriber := &KafkaSubscriber{
+		group: &testConsumerGroup{
+			err: sarama.ErrClosedConsumerGroup,
+		},
+		topic: "notifications",
+	}
+
+	messages := subscriber.Start()
+	_, ok := <-messages
+	assert.False(t, ok)
+	assert.Equal(t, sarama.ErrClosedConsumerGroup, subscriber.Err())
+}
@@ -0,0 +1,142 @@
+package implementations
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/NYTimes/gizmo/pubsub"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type localQueueMetrics struct {
+	Scope           promutils.Scope
+	MessagesDropped prometheus.Counter
+}
+
+type localQueueMessage struct {
+	body []byte
+}
+
+func (m *localQueueMessage) Message() []byte {
+	return m.body
+}
+
+func (m *localQueueMessage) ExtendDoneDeadline(time.Duration) error {
+	return nil
+}
+
+func (m *localQueueMessage) Done() error {
+	return nil
+}
+
+// An in-process queue of serialized notifications, standing in for an external queue so that a single admin delivers
+// its own notifications. Notifications published while the queue is full are dropped rather than blocking.
+type LocalQueue struct {
+	messages chan pubsub.SubscriberMessage
+	stopped  bool
+	mutex    sync.RWMutex
+	metrics  localQueueMetrics
+}
+
+func (q *LocalQueue) Publish(ctx context.Context, key string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return q.PublishRaw(ctx, key, body)
+}
+
+func (q *LocalQueue) PublishRaw(ctx context.Context, key string, body []byte) error {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	if q.stopped {
+		return errors.New("the local notifications queue is stopped")
+	}
+	select {
+	case q.messages <- &localQueueMessage{body: body}:
+	default:
+		q.metrics.MessagesDropped.Inc()
+		logger.Warningf(ctx, "Dropping a notification with key [%s] as the local notifications queue is full", key)
+	}
+	return nil
+}
+
+func (q *LocalQueue) Start() <-chan pubsub.SubscriberMessage {
+	return q.messages
+}
+
+func (q *LocalQueue) Err() error {
+	return nil
+}
+
+// Stops accepting notifications, the queued ones are still delivered.
+func (q *LocalQueue) Stop() error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.stopped {
+		return errors.New("the local notifications queue is already stopped")
+	}
+	q.stopped = true
+	close(q.messages)
+	return nil
+}
+
+func NewLocalQueue(queueSize int, scope promutils.Scope) *LocalQueue {
+	scope = scope.NewSubScope("local_queue")
+	return &LocalQueue{
+		messages: make(chan pubsub.SubscriberMessage, queueSize),
+		metrics: localQueueMetrics{
+			Scope: scope,
+			MessagesDropped: scope.MustNewCounter("messages_dropped",
+				"count of notifications dropped as the queue was full"),
+		},
+	}
+}
+
+// Delivers the notifications of a local queue with a pool of workers, going through the same decoding and emailing
+// as the processors of external queues.
+type LocalProcessor struct {
+	processor *GcpProcessor
+	workers   int
+}
+
+// Blocks until the queue is stopped and drained.
+func (p *LocalProcessor) StartProcessing() {
+	logger.Infof(context.Background(), "Starting local notifications processor with %d workers", p.workers)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			if err := p.processor.run(); err != nil {
+				logger.Errorf(context.Background(), "error with running local processor err: [%v] ", err)
+			}
+		}()
+	}
+	waitGroup.Wait()
+}
+
+func (p *LocalProcessor) StopProcessing() error {
+	return p.processor.StopProcessing()
+}
+
+func NewLocalProcessor(queue *LocalQueue, emailer interfaces.Emailer, workers int,
+	scope promutils.Scope) interfaces.Processor {
+	if workers < 1 {
+		workers = 1
+	}
+	return &LocalProcessor{
+		processor: &GcpProcessor{
+			sub:           queue,
+			email:         emailer,
+			systemMetrics: newProcessorSystemMetrics(scope.NewSubScope("local_processor")),
+		},
+		workers: workers,
+	}
+}
@@ -0,0 +1,88 @@
+package implementations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLocalQueueTestEmail(subject string) *admin.EmailMessage {
+	return &admin.EmailMessage{
+		RecipientsEmail: []string{"team@example.com"},
+		SubjectLine:     subject,
+	}
+}
+
+func TestLocalQueue_Delivery(t *testing.T) {
+	queue := NewLocalQueue(10, promutils.NewTestScope())
+	publisher := NewPublisher(queue, promutils.NewTestScope())
+	var subjects []string
+	var emailer mocks.MockEmailer
+	emailer.SetSendEmailFunc(func(ctx context.Context, email admin.EmailMessage) error {
+		subjects = append(subjects, email.SubjectLine)
+		return nil
+	})
+	processor := NewLocalProcessor(queue, &emailer, 1, promutils.NewTestScope())
+
+	for _, subject := range []string{"first", "second", "third"} {
+		assert.NoError(t, publisher.Publish(context.Background(), "email", getLocalQueueTestEmail(subject)))
+	}
+	// Queued notifications are still delivered once the queue is stopped, in the order they were published.
+	assert.NoError(t, queue.Stop())
+	processor.StartProcessing()
+	assert.Equal(t, []string{"first", "second", "third"}, subjects)
+	assert.Error(t, publisher.Publish(context.Background(), "email", getLocalQueueTestEmail("late")))
+}
+
+func TestLocalQueue_Workers(t *testing.T) {
+	queue := NewLocalQueue(10, promutils.NewTestScope())
+	delivered := make(chan string, 10)
+	release := make(chan struct{})
+	var emailer mocks.MockEmailer
+	emailer.SetSendEmailFunc(func(ctx context.Context, email admin.EmailMessage) error {
+		delivered <- email.SubjectLine
+		<-release
+		return nil
+	})
+	processor := NewLocalProcessor(queue, &emailer, 2, promutils.NewTestScope())
+	done := make(chan struct{})
+	go func() {
+		processor.StartProcessing()
+		close(done)
+	}()
+
+	assert.NoError(t, queue.Publish(context.Background(), "email", getLocalQueueTestEmail("first")))
+	assert.NoError(t, queue.Publish(context.Background(), "email", getLocalQueueTestEmail("second")))
+	// Both notifications are delivered at once by separate workers.
+	assert.ElementsMatch(t, []string{"first", "second"}, []string{<-delivered, <-delivered})
+	close(release)
+	assert.NoError(t, processor.StopProcessing())
+	<-done
+}
+
+func TestLocalQueue_Overflow(t *testing.T) {
+	queue := NewLocalQueue(2, promutils.NewTestScope())
+	var subjects []string
+	var emailer mocks.MockEmailer
+	emailer.SetSendEmailFunc(func(ctx context.Context, email admin.EmailMessage) error {
+		subjects = append(subjects, email.SubjectLine)
+		return nil
+	})
+	processor := NewLocalProcessor(queue, &emailer, 1, promutils.NewTestScope())
+
+	for _, subject := range []string{"first", "second", "third"} {
+		// Notifications beyond the queue size are dropped without failing the publish.
+		assert.NoError(t, queue.Publish(context.Background(), "email", getLocalQueueTestEmail(subject)))
+	}
+	assert.Equal(t, float64(1), testutil.ToFloat64(queue.metrics.MessagesDropped))
+
+	assert.NoError(t, processor.StopProcessing())
+	processor.StartProcessing()
+	assert.Equal(t, []string{"first", "second"}, subjects)
+	assert.Error(t, queue.Stop())
+}
@@ -35,9 +35,15 @@ func getSendgridEmail(adminEmail admin.EmailMessage) *mail.SGMailV3 {
 	// This from email address is really here as a formality. For sendgrid specifically, the sender email is determined
 	// from the api key that's used, not what you send along here.
 	from := mail.NewEmail("Flyte Notifications", adminEmail.SenderEmail)
-	content := mail.NewContent("text/html", adminEmail.Body)
 	m.SetFrom(from)
-	m.AddContent(content)
+	// Plain text content has to precede HTML content.
+	htmlBody, textBody := splitEmailBodies(adminEmail.Body)
+	if len(textBody) > 0 {
+		m.AddContent(mail.NewContent("text/plain", textBody))
+	}
+	if len(htmlBody) > 0 || len(textBody) == 0 {
+		m.AddContent(mail.NewContent("text/html", htmlBody))
+	}
 
 	personalization := mail.NewPersonalization()
 	emailAddresses := getEmailAddresses(adminEmail.RecipientsEmail)
@@ -38,6 +38,17 @@ func TestGetEmail(t *testing.T) {
 	assert.Equal(t, `Execution "name" has succeeded in "domain". View details at <a href="https://example.com/executions/T/B/D">https://example.com/executions/T/B/D</a>.`, sgEmail.Content[0].Value)
 }
 
+func TestGetEmail_TextAlternative(t *testing.T) {
+	sgEmail := getSendgridEmail(admin.EmailMessage{
+		Body: JoinEmailBodies("<p>Execution succeeded</p>", "Execution succeeded"),
+	})
+	assert.Len(t, sgEmail.Content, 2)
+	assert.Equal(t, "text/plain", sgEmail.Content[0].Type)
+	assert.Equal(t, "Execution succeeded", sgEmail.Content[0].Value)
+	assert.Equal(t, "text/html", sgEmail.Content[1].Type)
+	assert.Equal(t, "<p>Execution succeeded</p>", sgEmail.Content[1].Value)
+}
+
 func TestCreateEmailer(t *testing.T) {
 	cfg := getNotificationsConfig()
 	cfg.NotificationsEmailerConfig.EmailerConfig.APIKeyEnvVar = "sendgrid_api_key"
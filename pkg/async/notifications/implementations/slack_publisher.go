@@ -0,0 +1,79 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+)
+
+// The body of messages posted to Slack incoming webhooks.
+type slackWebhookPayload struct {
+	Text string `json:"text"`
+}
+
+// Posts Slack webhook notifications to their channels and hands every other notification to the wrapped publisher.
+type SlackWebhookPublisher struct {
+	publisher interfaces.Publisher
+	endpoints map[string]webhookEndpoint
+	deliverer *webhookDeliverer
+}
+
+func (p *SlackWebhookPublisher) Publish(ctx context.Context, notificationType string, msg proto.Message) error {
+	if notificationType != interfaces.SlackWebhookNotificationType {
+		return p.publisher.Publish(ctx, notificationType, msg)
+	}
+	message, ok := msg.(*admin.EmailMessage)
+	if !ok {
+		return fmt.Errorf("unexpected slack notification message type %T", msg)
+	}
+	payload, err := json.Marshal(slackWebhookPayload{
+		Text: message.Body,
+	})
+	if err != nil {
+		return err
+	}
+	for _, channel := range message.RecipientsEmail {
+		endpoint, ok := p.endpoints[channel]
+		if !ok {
+			p.deliverer.systemMetrics.MessageDropped.Inc()
+			logger.Warningf(ctx, "Dropping slack notification to unknown channel [%s]", channel)
+			continue
+		}
+		p.deliverer.deliver(channel, endpoint, payload)
+	}
+	return nil
+}
+
+// Creates a publisher which posts Slack webhook notifications to the configured channels, reading their webhook URLs
+// through the secret manager, and hands every other notification to the given publisher.
+func NewSlackWebhookPublisher(ctx context.Context, config runtimeInterfaces.NotificationsSlackConfig,
+	secretManager core.SecretManager, publisher interfaces.Publisher, scope promutils.Scope) (
+	interfaces.Publisher, error) {
+	endpoints := make(map[string]webhookEndpoint, len(config.Channels))
+	for channel, channelConfig := range config.Channels {
+		webhookURL, err := getSecret(ctx, secretManager, channelConfig.WebhookURLSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the webhook url of slack channel [%s]: %v", channel, err)
+		}
+		endpoints[channel] = webhookEndpoint{
+			url:    webhookURL,
+			method: http.MethodPost,
+		}
+	}
+	return &SlackWebhookPublisher{
+		publisher: publisher,
+		endpoints: endpoints,
+		deliverer: newWebhookDeliverer(
+			config.MaxAttempts, config.RetryBackoff.Duration, config.Workers, config.QueueSize,
+			scope.NewSubScope("slack_publisher")),
+	}, nil
+}
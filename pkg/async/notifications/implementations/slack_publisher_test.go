@@ -0,0 +1,139 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	pluginMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const slackChannel = "#oncall"
+
+var slackMessage = admin.EmailMessage{
+	RecipientsEmail: []string{slackChannel},
+	Body:            "Execution project/domain/name has failed.",
+}
+
+// A Slack incoming webhook which fails the first given number of posts and records the payloads of the others.
+type slackWebhook struct {
+	t        *testing.T
+	failures int
+
+	mutex    sync.Mutex
+	posts    int
+	payloads []map[string]interface{}
+}
+
+func (w *slackWebhook) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	assert.Equal(w.t, http.MethodPost, request.Method)
+	assert.Equal(w.t, "application/json", request.Header.Get("Content-Type"))
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.posts++
+	if w.posts <= w.failures {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	payload := make(map[string]interface{})
+	assert.NoError(w.t, json.NewDecoder(request.Body).Decode(&payload))
+	w.payloads = append(w.payloads, payload)
+	writer.WriteHeader(http.StatusOK)
+}
+
+func getSlackTestPublisher(t *testing.T, webhookURL string, publisher interfaces.Publisher) *SlackWebhookPublisher {
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "oncall-webhook").Return(webhookURL+"\n", nil)
+	slackPublisher, err := NewSlackWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsSlackConfig{
+		Channels: map[string]runtimeInterfaces.SlackChannelConfig{
+			slackChannel: {
+				WebhookURLSecretName: "oncall-webhook",
+			},
+		},
+		MaxAttempts:  3,
+		RetryBackoff: config.Duration{Duration: time.Millisecond},
+	}, secretManager, publisher, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return slackPublisher.(*SlackWebhookPublisher)
+}
+
+func TestSlackWebhookPublisher_Publish(t *testing.T) {
+	webhook := &slackWebhook{t: t}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+	slackPublisher := getSlackTestPublisher(t, server.URL, &mocks.MockPublisher{})
+
+	assert.NoError(t, slackPublisher.Publish(context.Background(), interfaces.SlackWebhookNotificationType, &slackMessage))
+	slackPublisher.deliverer.wait()
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"text": "Execution project/domain/name has failed.",
+		},
+	}, webhook.payloads)
+}
+
+func TestSlackWebhookPublisher_Retries(t *testing.T) {
+	webhook := &slackWebhook{t: t, failures: 2}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+	slackPublisher := getSlackTestPublisher(t, server.URL, &mocks.MockPublisher{})
+
+	assert.NoError(t, slackPublisher.Publish(context.Background(), interfaces.SlackWebhookNotificationType, &slackMessage))
+	slackPublisher.deliverer.wait()
+	assert.Equal(t, 3, webhook.posts)
+	assert.Len(t, webhook.payloads, 1)
+}
+
+func TestSlackWebhookPublisher_Drops(t *testing.T) {
+	webhook := &slackWebhook{t: t, failures: 5}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+	slackPublisher := getSlackTestPublisher(t, server.URL, &mocks.MockPublisher{})
+
+	assert.NoError(t, slackPublisher.Publish(context.Background(), interfaces.SlackWebhookNotificationType, &slackMessage))
+	slackPublisher.deliverer.wait()
+	assert.Equal(t, 3, webhook.posts)
+	assert.Empty(t, webhook.payloads)
+}
+
+func TestSlackWebhookPublisher_PublishesOtherNotifications(t *testing.T) {
+	var published bool
+	publisher := &mocks.MockPublisher{}
+	publisher.SetPublishCallback(func(ctx context.Context, key string, msg proto.Message) error {
+		published = true
+		assert.Equal(t, "flyteidl.admin.EmailNotification", key)
+		return errors.New("publish failed")
+	})
+	slackPublisher := getSlackTestPublisher(t, "http://localhost", publisher)
+
+	err := slackPublisher.Publish(context.Background(), "flyteidl.admin.EmailNotification", &slackMessage)
+	assert.EqualError(t, err, "publish failed")
+	assert.True(t, published)
+}
+
+func TestNewSlackWebhookPublisher_MissingSecret(t *testing.T) {
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "oncall-webhook").Return("", errors.New("secret not found"))
+	_, err := NewSlackWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsSlackConfig{
+		Channels: map[string]runtimeInterfaces.SlackChannelConfig{
+			slackChannel: {
+				WebhookURLSecretName: "oncall-webhook",
+			},
+		},
+	}, secretManager, &mocks.MockPublisher{}, promutils.NewTestScope())
+	assert.EqualError(t, err, "failed to read the webhook url of slack channel [#oncall]: secret not found")
+}
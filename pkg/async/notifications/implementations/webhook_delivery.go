@@ -0,0 +1,185 @@
+package implementations
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Response timeout of webhooks which don't configure one.
+const defaultWebhookTimeout = 10 * time.Second
+
+// Header carrying the hex encoded HMAC-SHA256 of the payload posted to webhooks with a signing key.
+const webhookSignatureHeader = "X-Flyte-Signature"
+
+type webhookDeliverySystemMetrics struct {
+	Scope          promutils.Scope
+	MessageSuccess prometheus.Counter
+	PostError      prometheus.Counter
+	MessageDropped prometheus.Counter
+}
+
+// An HTTP endpoint notifications are delivered to.
+type webhookEndpoint struct {
+	url        string
+	method     string
+	headers    map[string]string
+	signingKey []byte
+	timeout    time.Duration
+}
+
+// A notification queued for delivery to a webhook.
+type webhookDelivery struct {
+	name     string
+	endpoint webhookEndpoint
+	payload  []byte
+}
+
+// Delivers notifications to webhooks asynchronously with a fixed pool of workers, so that slow or unavailable webhooks
+// don't hold up execution events. Notifications published while the delivery queue is full are dropped. Failed
+// deliveries are retried with exponential backoff and dropped after the configured number of attempts.
+type webhookDeliverer struct {
+	client        *http.Client
+	maxAttempts   int
+	retryBackoff  time.Duration
+	systemMetrics webhookDeliverySystemMetrics
+	queue         chan webhookDelivery
+	// Tracks queued and in-flight deliveries.
+	deliveries sync.WaitGroup
+}
+
+func (d *webhookDeliverer) deliver(name string, endpoint webhookEndpoint, payload []byte) {
+	d.deliveries.Add(1)
+	select {
+	case d.queue <- webhookDelivery{name: name, endpoint: endpoint, payload: payload}:
+	default:
+		d.deliveries.Done()
+		d.systemMetrics.MessageDropped.Inc()
+		logger.Warningf(context.Background(), "Dropping notification to [%s] as the delivery queue is full", name)
+	}
+}
+
+func (d *webhookDeliverer) work() {
+	for delivery := range d.queue {
+		d.attempt(delivery)
+		d.deliveries.Done()
+	}
+}
+
+func (d *webhookDeliverer) attempt(delivery webhookDelivery) {
+	ctx := context.Background()
+	backoff := d.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := d.post(ctx, delivery.endpoint, delivery.payload)
+		if err == nil {
+			d.systemMetrics.MessageSuccess.Inc()
+			return
+		}
+		d.systemMetrics.PostError.Inc()
+		if attempt >= d.maxAttempts {
+			d.systemMetrics.MessageDropped.Inc()
+			logger.Errorf(ctx, "Dropping notification to [%s] after %d attempts, last error: %v",
+				delivery.name, attempt, err)
+			return
+		}
+		logger.Infof(ctx, "Failed to deliver notification to [%s], retrying in %v: %v", delivery.name, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (d *webhookDeliverer) post(ctx context.Context, endpoint webhookEndpoint, payload []byte) error {
+	timeout := endpoint.timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, endpoint.method, endpoint.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for header, value := range endpoint.headers {
+		request.Header.Set(header, value)
+	}
+	if len(endpoint.signingKey) > 0 {
+		request.Header.Set(webhookSignatureHeader, signWebhookPayload(endpoint.signingKey, payload))
+	}
+	response, err := d.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+// Blocks until all queued and in-flight deliveries either succeeded or were dropped.
+func (d *webhookDeliverer) wait() {
+	d.deliveries.Wait()
+}
+
+// Reads a secret, such as a webhook URL or signing key, dropping the trailing newline secret files commonly end with.
+func getSecret(ctx context.Context, secretManager core.SecretManager, name string) (string, error) {
+	secret, err := secretManager.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(secret), nil
+}
+
+func signWebhookPayload(key, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Delivery settings of publishers which don't configure them.
+const (
+	defaultWebhookDeliveryWorkers   = 10
+	defaultWebhookDeliveryQueueSize = 1000
+)
+
+func newWebhookDeliverer(maxAttempts int, retryBackoff time.Duration, workers, queueSize int,
+	scope promutils.Scope) *webhookDeliverer {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if workers < 1 {
+		workers = defaultWebhookDeliveryWorkers
+	}
+	if queueSize < 1 {
+		queueSize = defaultWebhookDeliveryQueueSize
+	}
+	deliverer := &webhookDeliverer{
+		client:       &http.Client{},
+		maxAttempts:  maxAttempts,
+		retryBackoff: retryBackoff,
+		systemMetrics: webhookDeliverySystemMetrics{
+			Scope:          scope,
+			MessageSuccess: scope.MustNewCounter("message_ok", "count of notifications delivered to webhooks"),
+			PostError:      scope.MustNewCounter("post_errors", "count of failed attempts to deliver notifications to webhooks"),
+			MessageDropped: scope.MustNewCounter("message_dropped", "count of notifications dropped after failing to deliver them to webhooks"),
+		},
+		queue: make(chan webhookDelivery, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go deliverer.work()
+	}
+	return deliverer
+}
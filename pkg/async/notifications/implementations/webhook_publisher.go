@@ -0,0 +1,86 @@
+package implementations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+)
+
+// Sends webhook notifications to the configured webhooks and hands every other notification to the wrapped publisher.
+type WebhookPublisher struct {
+	publisher interfaces.Publisher
+	endpoints map[string]webhookEndpoint
+	deliverer *webhookDeliverer
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, notificationType string, msg proto.Message) error {
+	if notificationType != interfaces.WebhookNotificationType {
+		return p.publisher.Publish(ctx, notificationType, msg)
+	}
+	message, ok := msg.(*admin.EmailMessage)
+	if !ok {
+		return fmt.Errorf("unexpected webhook notification message type %T", msg)
+	}
+	for _, webhook := range message.RecipientsEmail {
+		endpoint, ok := p.endpoints[webhook]
+		if !ok {
+			p.deliverer.systemMetrics.MessageDropped.Inc()
+			logger.Warningf(ctx, "Dropping notification to unknown webhook [%s]", webhook)
+			continue
+		}
+		p.deliverer.deliver(webhook, endpoint, []byte(message.Body))
+	}
+	return nil
+}
+
+// Creates a publisher which sends webhook notifications to the configured webhooks, reading their secret headers and
+// signing keys through the secret manager, and hands every other notification to the given publisher.
+func NewWebhookPublisher(ctx context.Context, config runtimeInterfaces.NotificationsWebhookConfig,
+	secretManager core.SecretManager, publisher interfaces.Publisher, scope promutils.Scope) (
+	interfaces.Publisher, error) {
+	endpoints := make(map[string]webhookEndpoint, len(config.Endpoints))
+	for webhook, endpointConfig := range config.Endpoints {
+		endpoint := webhookEndpoint{
+			url:     endpointConfig.URL,
+			method:  endpointConfig.Method,
+			headers: make(map[string]string, len(endpointConfig.Headers)+len(endpointConfig.SecretHeaders)),
+			timeout: endpointConfig.Timeout.Duration,
+		}
+		if len(endpoint.method) == 0 {
+			endpoint.method = http.MethodPost
+		}
+		for header, value := range endpointConfig.Headers {
+			endpoint.headers[header] = value
+		}
+		for header, secretName := range endpointConfig.SecretHeaders {
+			value, err := getSecret(ctx, secretManager, secretName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read header [%s] of webhook [%s]: %v", header, webhook, err)
+			}
+			endpoint.headers[header] = value
+		}
+		if len(endpointConfig.SigningKeySecretName) > 0 {
+			signingKey, err := getSecret(ctx, secretManager, endpointConfig.SigningKeySecretName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the signing key of webhook [%s]: %v", webhook, err)
+			}
+			endpoint.signingKey = []byte(signingKey)
+		}
+		endpoints[webhook] = endpoint
+	}
+	return &WebhookPublisher{
+		publisher: publisher,
+		endpoints: endpoints,
+		deliverer: newWebhookDeliverer(
+			config.MaxAttempts, config.RetryBackoff.Duration, config.Workers, config.QueueSize,
+			scope.NewSubScope("webhook_publisher")),
+	}, nil
+}
@@ -0,0 +1,195 @@
+package implementations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	pluginMocks "github.com/flyteorg/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const webhookSigningKey = "signing-key"
+
+var webhookMessage = admin.EmailMessage{
+	RecipientsEmail: []string{"airflow"},
+	Body:            `{"conf": {"execution": "project/domain/name"}}`,
+}
+
+func getWebhookTestPublisher(t *testing.T, endpoint runtimeInterfaces.WebhookEndpointConfig) *WebhookPublisher {
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "airflow-token").Return("Bearer token\n", nil)
+	secretManager.OnGetMatch(mock.Anything, "airflow-signing-key").Return(webhookSigningKey, nil)
+	webhookPublisher, err := NewWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsWebhookConfig{
+		Endpoints: map[string]runtimeInterfaces.WebhookEndpointConfig{
+			"airflow": endpoint,
+		},
+		MaxAttempts:  2,
+		RetryBackoff: config.Duration{Duration: time.Millisecond},
+	}, secretManager, &mocks.MockPublisher{}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	return webhookPublisher.(*WebhookPublisher)
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodPut, request.Method)
+		assert.Equal(t, "flyte", request.Header.Get("X-Source"))
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		body, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, webhookMessage.Body, string(body))
+
+		// Receivers verify the signature with the shared signing key.
+		signature := request.Header.Get(webhookSignatureHeader)
+		assert.True(t, strings.HasPrefix(signature, "sha256="))
+		mac := hmac.New(sha256.New, []byte(webhookSigningKey))
+		mac.Write(body)
+		expected := mac.Sum(nil)
+		actual, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		assert.NoError(t, err)
+		assert.True(t, hmac.Equal(expected, actual))
+		writer.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	webhookPublisher := getWebhookTestPublisher(t, runtimeInterfaces.WebhookEndpointConfig{
+		URL:    server.URL,
+		Method: http.MethodPut,
+		Headers: map[string]string{
+			"X-Source": "flyte",
+		},
+		SecretHeaders: map[string]string{
+			"Authorization": "airflow-token",
+		},
+		SigningKeySecretName: "airflow-signing-key",
+	})
+
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	webhookPublisher.deliverer.wait()
+	assert.Equal(t, 1, requests)
+}
+
+func TestWebhookPublisher_Unsigned(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Empty(t, request.Header.Get(webhookSignatureHeader))
+	}))
+	defer server.Close()
+	webhookPublisher := getWebhookTestPublisher(t, runtimeInterfaces.WebhookEndpointConfig{
+		URL: server.URL,
+	})
+
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	webhookPublisher.deliverer.wait()
+	assert.Equal(t, 1, requests)
+}
+
+func TestWebhookPublisher_Timeout(t *testing.T) {
+	// Timed out requests may still be handled while the next attempt is made.
+	var requests int32
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		select {
+		case <-unblock:
+		case <-request.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(unblock)
+	webhookPublisher := getWebhookTestPublisher(t, runtimeInterfaces.WebhookEndpointConfig{
+		URL:     server.URL,
+		Timeout: config.Duration{Duration: 10 * time.Millisecond},
+	})
+
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	webhookPublisher.deliverer.wait()
+	// Both attempts time out before the webhook responds, after which the notification is dropped.
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestWebhookPublisher_QueueFull(t *testing.T) {
+	received := make(chan struct{})
+	unblock := make(chan struct{})
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		received <- struct{}{}
+		<-unblock
+	}))
+	defer server.Close()
+	webhookPublisher, err := NewWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsWebhookConfig{
+		Endpoints: map[string]runtimeInterfaces.WebhookEndpointConfig{
+			"airflow": {URL: server.URL},
+		},
+		Workers:   1,
+		QueueSize: 1,
+	}, &pluginMocks.SecretManager{}, &mocks.MockPublisher{}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	deliverer := webhookPublisher.(*WebhookPublisher).deliverer
+
+	// The only worker is busy with the first notification and the second fills the queue, so the third is dropped.
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	<-received
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), interfaces.WebhookNotificationType, &webhookMessage))
+	assert.Equal(t, float64(1), testutil.ToFloat64(deliverer.systemMetrics.MessageDropped))
+
+	close(unblock)
+	<-received
+	deliverer.wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, float64(2), testutil.ToFloat64(deliverer.systemMetrics.MessageSuccess))
+}
+
+func TestWebhookPublisher_PublishesOtherNotifications(t *testing.T) {
+	publisher := &mocks.MockPublisher{}
+	var published bool
+	publisher.SetPublishCallback(func(ctx context.Context, notificationType string, msg proto.Message) error {
+		published = true
+		assert.Equal(t, "email", notificationType)
+		return nil
+	})
+	webhookPublisher, err := NewWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsWebhookConfig{},
+		&pluginMocks.SecretManager{}, publisher, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	assert.NoError(t, webhookPublisher.Publish(context.Background(), "email", &webhookMessage))
+	assert.True(t, published)
+}
+
+func TestNewWebhookPublisher_MissingSecret(t *testing.T) {
+	secretManager := &pluginMocks.SecretManager{}
+	secretManager.OnGetMatch(mock.Anything, "airflow-signing-key").Return("", errors.New("secret not found"))
+	_, err := NewWebhookPublisher(context.Background(), runtimeInterfaces.NotificationsWebhookConfig{
+		Endpoints: map[string]runtimeInterfaces.WebhookEndpointConfig{
+			"airflow": {
+				URL:                  "http://localhost",
+				SigningKeySecretName: "airflow-signing-key",
+			},
+		},
+	}, secretManager, &mocks.MockPublisher{}, promutils.NewTestScope())
+	assert.EqualError(t, err, "failed to read the signing key of webhook [airflow]: secret not found")
+}
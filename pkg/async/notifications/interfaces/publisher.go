@@ -3,9 +3,18 @@ package interfaces
 import (
 	"context"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/golang/protobuf/proto"
 )
 
+// The notification type of messages posted to Slack channels through incoming webhooks. These are published as
+// admin.EmailMessage protos whose recipients are the Slack channels and whose body is the message text.
+var SlackWebhookNotificationType = proto.MessageName(&admin.SlackNotification{})
+
+// The notification type of messages sent to webhooks. These are published as admin.EmailMessage protos whose
+// recipients are the names of the webhooks and whose body is the rendered body template.
+const WebhookNotificationType = "webhook"
+
 // Note on Notifications
 
 // Notifications are handled in two steps.
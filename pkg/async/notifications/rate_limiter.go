@@ -0,0 +1,241 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/golang-lru/simplelru"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const digestSubjectLine = "Notice: %d notifications for launch plan [%s] in phase [%s] were suppressed"
+
+const digestBody = "%d further notifications for launch plan [%s] in phase [%s] were suppressed in the last %v, " +
+	"most recently for execution [%s].%s Notifications are rate limited by each admin replica separately, so " +
+	"further notifications may have been sent by other replicas."
+
+type rateLimiterMetrics struct {
+	Scope                   promutils.Scope
+	SuppressedNotifications prometheus.Counter
+	DigestsSent             prometheus.Counter
+	DigestErrors            prometheus.Counter
+}
+
+// Identifies the notifications which are rate limited together.
+type rateLimitKey struct {
+	project          string
+	domain           string
+	launchPlan       string
+	notificationType string
+	phase            core.WorkflowExecution_Phase
+	// The sorted recipients of the notifications, so that notifications to some recipients don't suppress those to
+	// others.
+	recipients string
+}
+
+// Tracks the notifications sent for a key within the current window.
+type rateLimitWindow struct {
+	end   time.Time
+	sent  int
+	timer *clock.Timer
+	// Details of the suppressed notifications, summarized by the digest sent at the end of the window.
+	suppressed      int
+	latestMessage   *admin.EmailMessage
+	latestExecution string
+	latestError     string
+}
+
+type pendingDigest struct {
+	key    rateLimitKey
+	window *rateLimitWindow
+}
+
+// Publishes notifications unless too many were already sent for the same launch plan, notification type, phase and
+// recipients within the configured window. Suppressed notifications are summarized by a single digest sent when the window ends.
+//
+// Windows are tracked in memory by each admin replica separately, so with several replicas up to the configured
+// limit of notifications may be sent by each of them.
+type RateLimiter struct {
+	publisher interfaces.Publisher
+	limit     int
+	window    time.Duration
+	clock     clock.Clock
+	metrics   rateLimiterMetrics
+	mutex     sync.Mutex
+	windows   *simplelru.LRU
+	// Digests of windows which ended or were evicted, sent once the mutex is released.
+	pending []pendingDigest
+}
+
+// Publishes a notification for an execution of the given launch plan in the given phase. Returns whether the
+// notification was published rather than suppressed.
+func (r *RateLimiter) Publish(ctx context.Context, launchPlan *core.Identifier, phase core.WorkflowExecution_Phase,
+	notificationType string, message *admin.EmailMessage, request admin.WorkflowExecutionEventRequest) (bool, error) {
+	if r.limit <= 0 {
+		return true, r.publisher.Publish(ctx, notificationType, message)
+	}
+	key := rateLimitKey{
+		project:          launchPlan.GetProject(),
+		domain:           launchPlan.GetDomain(),
+		launchPlan:       launchPlan.GetName(),
+		notificationType: notificationType,
+		phase:            phase,
+		recipients:       getRateLimitRecipients(message),
+	}
+
+	r.mutex.Lock()
+	now := r.clock.Now()
+	var window *rateLimitWindow
+	if value, ok := r.windows.Get(key); ok {
+		window = value.(*rateLimitWindow)
+		if !now.Before(window.end) {
+			r.windows.Remove(key)
+			window = nil
+		}
+	}
+	if window == nil {
+		window = &rateLimitWindow{
+			end: now.Add(r.window),
+		}
+		r.windows.Add(key, window)
+	}
+	allowed := window.sent < r.limit
+	if allowed {
+		window.sent++
+	} else {
+		window.suppressed++
+		window.latestMessage = message
+		window.latestExecution = request.GetEvent().GetExecutionId().GetName()
+		window.latestError = request.GetEvent().GetError().GetMessage()
+		if window.timer == nil {
+			window.timer = r.clock.AfterFunc(window.end.Sub(now), func() {
+				r.endWindow(key, window)
+			})
+		}
+	}
+	pending := r.takePending()
+	r.mutex.Unlock()
+
+	r.sendDigests(ctx, pending)
+	if !allowed {
+		r.metrics.SuppressedNotifications.Inc()
+		logger.Debugf(ctx, "Suppressed notification [%s] for launch plan [%+v] in phase [%s]",
+			notificationType, launchPlan, phase)
+		return false, nil
+	}
+	return true, r.publisher.Publish(ctx, notificationType, message)
+}
+
+func (r *RateLimiter) endWindow(key rateLimitKey, window *rateLimitWindow) {
+	r.mutex.Lock()
+	if value, ok := r.windows.Peek(key); ok && value == window {
+		// The timer already fired, there's nothing left to stop.
+		window.timer = nil
+		r.windows.Remove(key)
+	}
+	pending := r.takePending()
+	r.mutex.Unlock()
+	r.sendDigests(context.Background(), pending)
+}
+
+// Called by the LRU whenever a window is removed, whether it ended or was evicted. Must hold the mutex.
+func (r *RateLimiter) onRemove(key interface{}, value interface{}) {
+	window := value.(*rateLimitWindow)
+	if window.timer != nil {
+		window.timer.Stop()
+	}
+	if window.suppressed > 0 {
+		r.pending = append(r.pending, pendingDigest{
+			key:    key.(rateLimitKey),
+			window: window,
+		})
+	}
+}
+
+// Must hold the mutex.
+func (r *RateLimiter) takePending() []pendingDigest {
+	pending := r.pending
+	r.pending = nil
+	return pending
+}
+
+func (r *RateLimiter) sendDigests(ctx context.Context, pending []pendingDigest) {
+	for _, digest := range pending {
+		message := r.toDigestMessage(digest.key, digest.window)
+		if err := r.publisher.Publish(ctx, digest.key.notificationType, message); err != nil {
+			r.metrics.DigestErrors.Inc()
+			logger.Infof(ctx, "error publishing digest of %d suppressed notifications [%s] with err: [%v]",
+				digest.window.suppressed, digest.key.notificationType, err)
+			continue
+		}
+		r.metrics.DigestsSent.Inc()
+	}
+}
+
+// The digest goes to the recipients of the suppressed notifications.
+func (r *RateLimiter) toDigestMessage(key rateLimitKey, window *rateLimitWindow) *admin.EmailMessage {
+	launchPlan := fmt.Sprintf("%s/%s/%s", key.project, key.domain, key.launchPlan)
+	phase := key.phase.String()
+	var latestError string
+	if len(window.latestError) > 0 {
+		latestError = fmt.Sprintf(" The most recent error was: [%s].", window.latestError)
+	}
+	message := proto.Clone(window.latestMessage).(*admin.EmailMessage)
+	message.SubjectLine = fmt.Sprintf(digestSubjectLine, window.suppressed, launchPlan, phase)
+	message.Body = fmt.Sprintf(digestBody, window.suppressed, launchPlan, phase, r.window,
+		window.latestExecution, latestError)
+	return message
+}
+
+func getRateLimitRecipients(message *admin.EmailMessage) string {
+	recipients := append([]string{}, message.GetRecipientsEmail()...)
+	sort.Strings(recipients)
+	return strings.Join(recipients, ",")
+}
+
+func newRateLimiter(config runtimeInterfaces.NotificationsRateLimitConfig, publisher interfaces.Publisher,
+	clock clock.Clock, scope promutils.Scope) *RateLimiter {
+	rateLimiter := &RateLimiter{
+		publisher: publisher,
+		limit:     config.Limit,
+		window:    config.Window.Duration,
+		clock:     clock,
+		metrics: rateLimiterMetrics{
+			Scope: scope,
+			SuppressedNotifications: scope.MustNewCounter("suppressed_notifications",
+				"count of notifications suppressed for exceeding the rate limit"),
+			DigestsSent: scope.MustNewCounter("digests_sent",
+				"count of digests sent summarizing suppressed notifications"),
+			DigestErrors: scope.MustNewCounter("digest_errors",
+				"count of digests which failed to publish"),
+		},
+	}
+	cacheSize := config.CacheSize
+	if cacheSize <= 0 {
+		cacheSize = 1
+	}
+	windows, err := simplelru.NewLRU(cacheSize, rateLimiter.onRemove)
+	if err != nil {
+		panic(err)
+	}
+	rateLimiter.windows = windows
+	return rateLimiter
+}
+
+// Creates a rate limiter publishing notifications through the given publisher.
+func NewRateLimiter(config runtimeInterfaces.NotificationsRateLimitConfig, publisher interfaces.Publisher,
+	scope promutils.Scope) *RateLimiter {
+	return newRateLimiter(config, publisher, clock.New(), scope)
+}
@@ -0,0 +1,188 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+const rateLimitedNotificationType = "flyteidl.admin.EmailNotification"
+
+var rateLimitedLaunchPlan = &core.Identifier{
+	ResourceType: core.ResourceType_LAUNCH_PLAN,
+	Project:      "project",
+	Domain:       "domain",
+	Name:         "flapping",
+	Version:      "version",
+}
+
+func getRateLimiterTestRequest(execution string) admin.WorkflowExecutionEventRequest {
+	return admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			ExecutionId: &core.WorkflowExecutionIdentifier{
+				Project: "project",
+				Domain:  "domain",
+				Name:    execution,
+			},
+			Phase: core.WorkflowExecution_FAILED,
+			OutputResult: &event.WorkflowExecutionEvent_Error{
+				Error: &core.ExecutionError{
+					Message: fmt.Sprintf("%s failed", execution),
+				},
+			},
+		},
+	}
+}
+
+func getRateLimiterTestMessage(execution string) *admin.EmailMessage {
+	return &admin.EmailMessage{
+		RecipientsEmail: []string{"team@example.com"},
+		SenderEmail:     "flyte@example.com",
+		SubjectLine:     fmt.Sprintf("Notice: Execution %s has failed", execution),
+	}
+}
+
+func getTestRateLimiter(limit int) (*RateLimiter, *clock.Mock, *[]*admin.EmailMessage) {
+	var published []*admin.EmailMessage
+	publisher := &mocks.MockPublisher{}
+	publisher.SetPublishCallback(func(ctx context.Context, notificationType string, msg proto.Message) error {
+		published = append(published, msg.(*admin.EmailMessage))
+		return nil
+	})
+	mockClock := clock.NewMock()
+	return newRateLimiter(runtimeInterfaces.NotificationsRateLimitConfig{
+		Limit:     limit,
+		Window:    config.Duration{Duration: time.Hour},
+		CacheSize: 10,
+	}, publisher, mockClock, promutils.NewTestScope()), mockClock, &published
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	rateLimiter, mockClock, published := getTestRateLimiter(2)
+
+	// A launch plan failing every minute for most of an hour.
+	for i := 0; i < 50; i++ {
+		execution := fmt.Sprintf("e%d", i)
+		sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+			rateLimitedNotificationType, getRateLimiterTestMessage(execution), getRateLimiterTestRequest(execution))
+		assert.NoError(t, err)
+		assert.Equal(t, i < 2, sent)
+		mockClock.Add(time.Minute)
+	}
+	assert.Len(t, *published, 2)
+
+	mockClock.Add(10 * time.Minute)
+	assert.Len(t, *published, 3)
+	digest := (*published)[2]
+	assert.Equal(t, []string{"team@example.com"}, digest.RecipientsEmail)
+	assert.Equal(t, "flyte@example.com", digest.SenderEmail)
+	assert.Equal(t, "Notice: 48 notifications for launch plan [project/domain/flapping] in phase [FAILED] were "+
+		"suppressed", digest.SubjectLine)
+	assert.Equal(t, "48 further notifications for launch plan [project/domain/flapping] in phase [FAILED] were "+
+		"suppressed in the last 1h0m0s, most recently for execution [e49]. The most recent error was: "+
+		"[e49 failed]. Notifications are rate limited by each admin replica separately, so further "+
+		"notifications may have been sent by other replicas.", digest.Body)
+
+	// Exactly one digest goes out per window.
+	mockClock.Add(2 * time.Hour)
+	assert.Len(t, *published, 3)
+
+	// Notifications are sent again once the window ended.
+	sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+		rateLimitedNotificationType, getRateLimiterTestMessage("e50"), getRateLimiterTestRequest("e50"))
+	assert.NoError(t, err)
+	assert.True(t, sent)
+	assert.Len(t, *published, 4)
+}
+
+func TestRateLimiter_NoDigestWithoutSuppression(t *testing.T) {
+	rateLimiter, mockClock, published := getTestRateLimiter(2)
+
+	for i := 0; i < 2; i++ {
+		execution := fmt.Sprintf("e%d", i)
+		sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+			rateLimitedNotificationType, getRateLimiterTestMessage(execution), getRateLimiterTestRequest(execution))
+		assert.NoError(t, err)
+		assert.True(t, sent)
+	}
+	mockClock.Add(2 * time.Hour)
+	assert.Len(t, *published, 2)
+}
+
+func TestRateLimiter_SeparateKeys(t *testing.T) {
+	rateLimiter, _, published := getTestRateLimiter(1)
+
+	for _, phase := range []core.WorkflowExecution_Phase{core.WorkflowExecution_FAILED, core.WorkflowExecution_TIMED_OUT} {
+		for _, notificationType := range []string{rateLimitedNotificationType, "slack"} {
+			sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, phase, notificationType,
+				getRateLimiterTestMessage("e1"), getRateLimiterTestRequest("e1"))
+			assert.NoError(t, err)
+			assert.True(t, sent)
+		}
+	}
+	assert.Len(t, *published, 4)
+
+	// Notifications to other recipients aren't suppressed by those already sent.
+	message := getRateLimiterTestMessage("e1")
+	message.RecipientsEmail = []string{"oncall@example.com"}
+	sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+		rateLimitedNotificationType, message, getRateLimiterTestRequest("e1"))
+	assert.NoError(t, err)
+	assert.True(t, sent)
+	assert.Len(t, *published, 5)
+}
+
+func TestRateLimiter_EvictionSendsDigest(t *testing.T) {
+	var published []*admin.EmailMessage
+	publisher := &mocks.MockPublisher{}
+	publisher.SetPublishCallback(func(ctx context.Context, notificationType string, msg proto.Message) error {
+		published = append(published, msg.(*admin.EmailMessage))
+		return nil
+	})
+	rateLimiter := newRateLimiter(runtimeInterfaces.NotificationsRateLimitConfig{
+		Limit:     1,
+		Window:    config.Duration{Duration: time.Hour},
+		CacheSize: 1,
+	}, publisher, clock.NewMock(), promutils.NewTestScope())
+
+	for i := 0; i < 3; i++ {
+		_, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+			rateLimitedNotificationType, getRateLimiterTestMessage("e1"), getRateLimiterTestRequest("e1"))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, published, 1)
+
+	// Tracking another launch plan evicts the first, whose digest is sent early.
+	otherLaunchPlan := proto.Clone(rateLimitedLaunchPlan).(*core.Identifier)
+	otherLaunchPlan.Name = "other"
+	sent, err := rateLimiter.Publish(context.Background(), otherLaunchPlan, core.WorkflowExecution_FAILED,
+		rateLimitedNotificationType, getRateLimiterTestMessage("e2"), getRateLimiterTestRequest("e2"))
+	assert.NoError(t, err)
+	assert.True(t, sent)
+	assert.Len(t, published, 3)
+	assert.Contains(t, published[1].SubjectLine, "Notice: 2 notifications for launch plan [project/domain/flapping]")
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	rateLimiter, _, published := getTestRateLimiter(0)
+
+	for i := 0; i < 10; i++ {
+		sent, err := rateLimiter.Publish(context.Background(), rateLimitedLaunchPlan, core.WorkflowExecution_FAILED,
+			rateLimitedNotificationType, getRateLimiterTestMessage("e1"), getRateLimiterTestRequest("e1"))
+		assert.NoError(t, err)
+		assert.True(t, sent)
+	}
+	assert.Len(t, *published, 10)
+}
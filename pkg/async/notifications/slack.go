@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+const consoleExecutionLink = "\n<%s/projects/%s/domains/%s/executions/%s|View execution>"
+
+// Splits the recipients of a Slack notification into the channels with a configured webhook and the remaining
+// recipients, which are emailed.
+func SplitSlackRecipients(config runtimeInterfaces.NotificationsConfig, recipients []string) (
+	channels []string, emails []string) {
+	for _, recipient := range recipients {
+		if _, ok := config.NotificationsSlackConfig.Channels[recipient]; ok {
+			channels = append(channels, recipient)
+		} else {
+			emails = append(emails, recipient)
+		}
+	}
+	return channels, emails
+}
+
+// Converts a terminal execution event and existing execution model to the message posted to Slack channels,
+// substituting parameters in the message set in the flyteadmin application notifications config.
+func ToSlackMessageFromWorkflowExecutionEvent(
+	config runtimeInterfaces.NotificationsConfig,
+	channels []string,
+	request admin.WorkflowExecutionEventRequest,
+	execution *admin.Execution) *admin.EmailMessage {
+
+	text := substituteEmailParameters(config.NotificationsSlackConfig.Message, request, execution)
+	if consoleURL := strings.TrimSuffix(config.NotificationsSlackConfig.ConsoleURL, "/"); len(consoleURL) > 0 {
+		text += fmt.Sprintf(consoleExecutionLink, consoleURL, execution.Id.Project, execution.Id.Domain,
+			execution.Id.Name)
+	}
+	return &admin.EmailMessage{
+		RecipientsEmail: channels,
+		Body:            text,
+	}
+}
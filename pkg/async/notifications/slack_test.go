@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"testing"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/stretchr/testify/assert"
+)
+
+var slackNotificationsConfig = runtimeInterfaces.NotificationsConfig{
+	NotificationsSlackConfig: runtimeInterfaces.NotificationsSlackConfig{
+		Channels: map[string]runtimeInterfaces.SlackChannelConfig{
+			"#oncall": {
+				WebhookURLSecretName: "oncall-webhook",
+			},
+		},
+		Message:    "Execution {{ project }}/{{ domain }}/{{ name }} has {{ phase }}.{{ error }}",
+		ConsoleURL: "https://flyte.example.com/console/",
+	},
+}
+
+func TestSplitSlackRecipients(t *testing.T) {
+	channels, emails := SplitSlackRecipients(slackNotificationsConfig, []string{
+		"#oncall", "team@example.slack.com",
+	})
+	assert.Equal(t, []string{"#oncall"}, channels)
+	assert.Equal(t, []string{"team@example.slack.com"}, emails)
+}
+
+func TestToSlackMessageFromWorkflowExecutionEvent(t *testing.T) {
+	request := admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase: core.WorkflowExecution_FAILED,
+			OutputResult: &event.WorkflowExecutionEvent_Error{
+				Error: &core.ExecutionError{
+					Message: "uh-oh",
+				},
+			},
+		},
+	}
+	message := ToSlackMessageFromWorkflowExecutionEvent(
+		slackNotificationsConfig, []string{"#oncall"}, request, workflowExecution)
+	assert.Equal(t, []string{"#oncall"}, message.RecipientsEmail)
+	assert.Equal(t, "Execution proj/prod/e124 has failed. The execution failed with error: [uh-oh].\n"+
+		"<https://flyte.example.com/console/projects/proj/domains/prod/executions/e124|View execution>", message.Body)
+}
@@ -0,0 +1,97 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+// Prefix of notification recipients which name a configured webhook rather than an email address.
+const WebhookRecipientPrefix = "webhook:"
+
+// The values webhook body templates have access to, e.g. {"execution": {{ json .Name }}, "phase": {{ json .Phase }}}.
+// Values which don't apply to an execution, such as the error of a successful one, are empty.
+type WebhookTemplateData struct {
+	Project   string
+	Domain    string
+	Name      string
+	Phase     string
+	Error     string
+	OutputURI string
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	// Renders a value as JSON, so that values are quoted and escaped within JSON bodies.
+	"json": func(value interface{}) (string, error) {
+		rendered, err := json.Marshal(value)
+		return string(rendered), err
+	},
+}
+
+func renderWebhookBody(name, body string, data WebhookTemplateData) (string, error) {
+	bodyTemplate, err := template.New(name).Funcs(webhookTemplateFuncs).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("invalid body template of webhook [%s]: %v", name, err)
+	}
+	var rendered bytes.Buffer
+	if err := bodyTemplate.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render the body template of webhook [%s]: %v", name, err)
+	}
+	return rendered.String(), nil
+}
+
+// Splits notification recipients into the names of the webhooks they refer to and the remaining recipients.
+func SplitWebhookRecipients(recipients []string) (webhooks []string, others []string) {
+	for _, recipient := range recipients {
+		if strings.HasPrefix(recipient, WebhookRecipientPrefix) {
+			webhooks = append(webhooks, strings.TrimPrefix(recipient, WebhookRecipientPrefix))
+		} else {
+			others = append(others, recipient)
+		}
+	}
+	return webhooks, others
+}
+
+// Checks the webhook is configured and its body template renders.
+func ValidateWebhook(config runtimeInterfaces.NotificationsConfig, webhook string) error {
+	endpoint, ok := config.NotificationsWebhookConfig.Endpoints[webhook]
+	if !ok {
+		return fmt.Errorf("unknown webhook [%s]", webhook)
+	}
+	_, err := renderWebhookBody(webhook, endpoint.Body, WebhookTemplateData{})
+	return err
+}
+
+// Converts a terminal execution event and existing execution model to the message sent to a webhook, rendering the
+// body template set in the flyteadmin application notifications config.
+func ToWebhookMessageFromWorkflowExecutionEvent(
+	config runtimeInterfaces.NotificationsConfig,
+	webhook string,
+	request admin.WorkflowExecutionEventRequest,
+	execution *admin.Execution) (*admin.EmailMessage, error) {
+
+	endpoint, ok := config.NotificationsWebhookConfig.Endpoints[webhook]
+	if !ok {
+		return nil, fmt.Errorf("unknown webhook [%s]", webhook)
+	}
+	body, err := renderWebhookBody(webhook, endpoint.Body, WebhookTemplateData{
+		Project:   execution.GetId().GetProject(),
+		Domain:    execution.GetId().GetDomain(),
+		Name:      execution.GetId().GetName(),
+		Phase:     strings.ToLower(request.GetEvent().GetPhase().String()),
+		Error:     request.GetEvent().GetError().GetMessage(),
+		OutputURI: request.GetEvent().GetOutputUri(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin.EmailMessage{
+		RecipientsEmail: []string{webhook},
+		Body:            body,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package notifications
+
+import (
+	"testing"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/stretchr/testify/assert"
+)
+
+var webhookNotificationsConfig = runtimeInterfaces.NotificationsConfig{
+	NotificationsWebhookConfig: runtimeInterfaces.NotificationsWebhookConfig{
+		Endpoints: map[string]runtimeInterfaces.WebhookEndpointConfig{
+			"airflow": {
+				Body: `{"conf": {"execution": "{{ .Project }}/{{ .Domain }}/{{ .Name }}", "phase": {{ json .Phase }}, ` +
+					`"error": {{ json .Error }}, "outputs": {{ json .OutputURI }}}}`,
+			},
+			"unknown-field": {
+				Body: `{"workflow": {{ json .Workflow }}}`,
+			},
+		},
+	},
+}
+
+func TestSplitWebhookRecipients(t *testing.T) {
+	webhooks, others := SplitWebhookRecipients([]string{"webhook:airflow", "team@example.com"})
+	assert.Equal(t, []string{"airflow"}, webhooks)
+	assert.Equal(t, []string{"team@example.com"}, others)
+}
+
+func TestToWebhookMessageFromWorkflowExecutionEvent(t *testing.T) {
+	request := admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase: core.WorkflowExecution_FAILED,
+			OutputResult: &event.WorkflowExecutionEvent_Error{
+				Error: &core.ExecutionError{
+					Message: `task "t1" failed`,
+				},
+			},
+		},
+	}
+	message, err := ToWebhookMessageFromWorkflowExecutionEvent(webhookNotificationsConfig, "airflow", request, workflowExecution)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"airflow"}, message.RecipientsEmail)
+	assert.Equal(t, `{"conf": {"execution": "proj/prod/e124", "phase": "failed", `+
+		`"error": "task \"t1\" failed", "outputs": ""}}`, message.Body)
+}
+
+func TestToWebhookMessageFromWorkflowExecutionEvent_MissingFields(t *testing.T) {
+	// Successful executions have no error, which renders empty.
+	request := admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase: core.WorkflowExecution_SUCCEEDED,
+			OutputResult: &event.WorkflowExecutionEvent_OutputUri{
+				OutputUri: "s3://bucket/outputs.pb",
+			},
+		},
+	}
+	message, err := ToWebhookMessageFromWorkflowExecutionEvent(webhookNotificationsConfig, "airflow", request, workflowExecution)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"conf": {"execution": "proj/prod/e124", "phase": "succeeded", `+
+		`"error": "", "outputs": "s3://bucket/outputs.pb"}}`, message.Body)
+
+	// Fields the template data doesn't have fail rendering.
+	_, err = ToWebhookMessageFromWorkflowExecutionEvent(webhookNotificationsConfig, "unknown-field", request, workflowExecution)
+	assert.Error(t, err)
+
+	_, err = ToWebhookMessageFromWorkflowExecutionEvent(webhookNotificationsConfig, "missing", request, workflowExecution)
+	assert.EqualError(t, err, "unknown webhook [missing]")
+}
+
+func TestValidateWebhook(t *testing.T) {
+	assert.NoError(t, ValidateWebhook(webhookNotificationsConfig, "airflow"))
+	assert.Error(t, ValidateWebhook(webhookNotificationsConfig, "unknown-field"))
+	assert.EqualError(t, ValidateWebhook(webhookNotificationsConfig, "missing"), "unknown webhook [missing]")
+}
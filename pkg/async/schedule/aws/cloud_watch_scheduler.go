@@ -118,6 +118,10 @@ func (s *cloudWatchScheduler) AddSchedule(ctx context.Context, input scheduleInt
 		s.metrics.InvalidSchedules.Inc()
 		return err
 	}
+	if input.Offset > 0 {
+		logger.Warningf(ctx, "CloudWatch schedules fire at their nominal times, ignoring the offset [%v] of [%+v]",
+			input.Offset, input.Identifier)
+	}
 	scheduleName := getScheduleName(input.ScheduleNamePrefix, input.Identifier)
 	scheduleDescription := getScheduleDescription(input.Identifier)
 	// First define a rule which gets triggered on a schedule.
@@ -173,8 +177,10 @@ func (s *cloudWatchScheduler) AddSchedule(ctx context.Context, input scheduleInt
 func (s *cloudWatchScheduler) CreateScheduleInput(ctx context.Context, appConfig *appInterfaces.SchedulerConfig,
 	identifier core.Identifier, schedule *admin.Schedule) (scheduleInterfaces.AddScheduleInput, error) {
 
+	catchUpPolicy := appConfig.EventSchedulerConfig.GetCatchUpPolicy()
 	payload, err := SerializeScheduleWorkflowPayload(
 		schedule.GetKickoffTimeInputArg(),
+		catchUpPolicy,
 		admin.NamedEntityIdentifier{
 			Project: identifier.Project,
 			Domain:  identifier.Domain,
@@ -197,6 +203,7 @@ func (s *cloudWatchScheduler) CreateScheduleInput(ctx context.Context, appConfig
 		ScheduleExpression: *schedule,
 		Payload:            payload,
 		ScheduleNamePrefix: scheduleNamePrefix,
+		CatchUpPolicy:      catchUpPolicy,
 	}
 	return addScheduleInput, nil
 }
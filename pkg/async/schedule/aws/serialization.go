@@ -11,6 +11,7 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
@@ -26,6 +27,9 @@ type ScheduleWorkflowPayload struct {
 	KickoffTimeArg string `json:"kickoff_time_arg"`
 	// Serialized launch plan admin.Identifier.
 	Payload []byte `json:"payload"`
+	// Determines whether the event fires once its kickoff time is stale. Payloads enqueued before the policy existed
+	// leave it unset and always fire.
+	CatchUpPolicy runtimeInterfaces.CatchUpPolicy `json:"catch_up_policy"`
 }
 
 // Encapsulates the data necessary to trigger a scheduled workflow execution.
@@ -36,11 +40,14 @@ type ScheduledWorkflowExecutionRequest struct {
 	KickoffTimeArg string
 	// The desired launch plan identifier to trigger on schedule event firings.
 	LaunchPlanIdentifier admin.NamedEntityIdentifier
+	// Determines whether the event fires once its kickoff time is stale.
+	CatchUpPolicy runtimeInterfaces.CatchUpPolicy
 }
 
 // This produces a function that is used to serialize messages enqueued on the cloudwatch scheduler.
 func SerializeScheduleWorkflowPayload(
-	kickoffTimeArg string, launchPlanIdentifier admin.NamedEntityIdentifier) (*string, error) {
+	kickoffTimeArg string, catchUpPolicy runtimeInterfaces.CatchUpPolicy,
+	launchPlanIdentifier admin.NamedEntityIdentifier) (*string, error) {
 	payload, err := proto.Marshal(&launchPlanIdentifier)
 	if err != nil {
 		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "failed to marshall launch plan with err: %v", err)
@@ -49,7 +56,8 @@ func SerializeScheduleWorkflowPayload(
 	// Why not initialize a ScheduleWorkflowPayload struct and marshal to JSON? SQS doesn't actually use valid JSON
 	// and the <time> placeholder isn't a string - so we manually construct the input template as a string.
 	inputTemplateJSONStr := fmt.Sprintf(
-		`{"time":%s,"kickoff_time_arg":"%s","payload":"%s"}`, awsTimestampPlaceholder, kickoffTimeArg, sEnc)
+		`{"time":%s,"kickoff_time_arg":"%s","payload":"%s","catch_up_policy":"%s"}`, awsTimestampPlaceholder,
+		kickoffTimeArg, sEnc, catchUpPolicy)
 	logger.Debugf(context.Background(), "serialized schedule workflow payload for launch plan [%+v]: %s",
 		launchPlanIdentifier, inputTemplateJSONStr)
 	return &inputTemplateJSONStr, nil
@@ -82,5 +90,6 @@ func DeserializeScheduleWorkflowPayload(payload []byte) (ScheduledWorkflowExecut
 		KickoffTime:          kickoffTime,
 		KickoffTimeArg:       scheduleWorkflowPayload.KickoffTimeArg,
 		LaunchPlanIdentifier: launchPlanIdentifier,
+		CatchUpPolicy:        scheduleWorkflowPayload.CatchUpPolicy,
 	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/golang/protobuf/proto"
 
@@ -22,10 +23,11 @@ var testLaunchPlanIdentifier = admin.NamedEntityIdentifier{
 }
 
 func TestNewSerializeScheduleWorkflowPayloadFunc(t *testing.T) {
-	payload, err := SerializeScheduleWorkflowPayload(testKickoffTimeArg, testLaunchPlanIdentifier)
+	payload, err := SerializeScheduleWorkflowPayload(
+		testKickoffTimeArg, runtimeInterfaces.CatchUpPolicyLast, testLaunchPlanIdentifier)
 	assert.Nil(t, err)
 	expectedPayload := "{\"time\":<time>,\"kickoff_time_arg\":\"kickoff time arg\",\"payload\":" +
-		"\"Cgdwcm9qZWN0EgZkb21haW4aBG5hbWU=\"}"
+		"\"Cgdwcm9qZWN0EgZkb21haW4aBG5hbWU=\",\"catch_up_policy\":\"LAST\"}"
 	assert.Equal(t, expectedPayload, *payload)
 }
 
@@ -40,6 +42,15 @@ func TestDeserializeScheduleWorkflowPayload(t *testing.T) {
 	assert.Equal(t, testKickoffTimeArg, scheduledWorkflowExecutionRequest.KickoffTimeArg)
 	assert.True(t, proto.Equal(&testLaunchPlanIdentifier, &scheduledWorkflowExecutionRequest.LaunchPlanIdentifier),
 		fmt.Sprintf("scheduledWorkflowExecutionRequest.LaunchPlanIdentifier %v", &scheduledWorkflowExecutionRequest.LaunchPlanIdentifier))
+	assert.Empty(t, scheduledWorkflowExecutionRequest.CatchUpPolicy)
+}
+
+func TestDeserializeScheduleWorkflowPayload_CatchUpPolicy(t *testing.T) {
+	payload := "{\"time\":\"2017-12-22T18:43:48Z\",\"kickoff_time_arg\":\"kickoff time arg\",\"payload\":" +
+		"\"Cgdwcm9qZWN0EgZkb21haW4aBG5hbWU=\",\"catch_up_policy\":\"NONE\"}"
+	scheduledWorkflowExecutionRequest, err := DeserializeScheduleWorkflowPayload([]byte(payload))
+	assert.Nil(t, err)
+	assert.Equal(t, runtimeInterfaces.CatchUpPolicyNone, scheduledWorkflowExecutionRequest.CatchUpPolicy)
 }
 
 func TestDeserializeScheduleWorkflowPayload_MessageError(t *testing.T) {
@@ -41,6 +41,8 @@ type workflowExecutorMetrics struct {
 	FailedResolveKickoffTimeArg         prometheus.Counter
 	FailedKickoffExecution              prometheus.Counter
 	ScheduledEventsProcessed            prometheus.Counter
+	StaleScheduledEventsDropped         prometheus.Counter
+	QuotaExhaustedEventsDropped         prometheus.Counter
 	ScheduledExecutionSystemDelay       labeled.StopWatch
 	MessageReceivedDelay                labeled.StopWatch
 	ScheduledEventProcessingDelay       labeled.StopWatch
@@ -54,6 +56,8 @@ type workflowExecutor struct {
 	launchPlanManager interfaces.LaunchPlanInterface
 	executionManager  interfaces.ExecutionInterface
 	metrics           workflowExecutorMetrics
+	// Scheduled events with a kickoff time older than this are subject to the catch up policy of their schedule.
+	catchUpThreshold time.Duration
 }
 
 const workflowIdentifierFmt = "%s_%s_%s"
@@ -172,6 +176,13 @@ func (e *workflowExecutor) formulateExecutionCreateRequest(
 	return executionRequest
 }
 
+// CloudWatch delivers every missed kickoff time as an independent event, so only the NONE catch up policy can be
+// honored here: stale events are dropped. LAST and ALL both fire each event with its original kickoff time.
+func (e *workflowExecutor) shouldDropStaleEvent(request ScheduledWorkflowExecutionRequest, now time.Time) bool {
+	return request.CatchUpPolicy == runtimeInterfaces.CatchUpPolicyNone &&
+		now.Sub(request.KickoffTime) > e.catchUpThreshold
+}
+
 func (e *workflowExecutor) Run() {
 	for {
 		logger.Warningf(context.Background(), "Starting workflow executor")
@@ -194,6 +205,19 @@ func (e *workflowExecutor) run() error {
 
 		logger.Debugf(context.Background(), "Processing scheduled workflow execution event: %+v",
 			scheduledWorkflowExecutionRequest)
+		if e.shouldDropStaleEvent(scheduledWorkflowExecutionRequest, observedMessageTriggeredTime) {
+			logger.Infof(context.Background(),
+				"dropping stale scheduled event with kickoff time %v for launch plan %v per its catch up policy",
+				scheduledWorkflowExecutionRequest.KickoffTime, scheduledWorkflowExecutionRequest.LaunchPlanIdentifier)
+			e.metrics.StaleScheduledEventsDropped.Inc()
+			err = message.Done()
+			if err != nil {
+				e.metrics.FailedMarkMessageAsDone.Inc()
+				logger.Warningf(context.Background(),
+					"failed to delete dropped stale scheduled event from the queue with err: %v", err)
+			}
+			continue
+		}
 		launchPlan, err := e.getActiveLaunchPlanVersion(&scheduledWorkflowExecutionRequest.LaunchPlanIdentifier)
 		if err != nil {
 			// In the rare case that a scheduled event fires right before a user disables the currently active launch
@@ -230,7 +254,13 @@ func (e *workflowExecutor) run() error {
 
 		if err != nil {
 			ec, ok := err.(errors.FlyteAdminError)
-			if ok && ec.Code() != codes.AlreadyExists {
+			if errors.IsQuotaExceededError(err) {
+				// Redelivering the event until the active execution quota of the project frees up would launch the
+				// execution long after it was scheduled, if ever.
+				e.metrics.QuotaExhaustedEventsDropped.Inc()
+				logger.Warningf(context.Background(), "dropping scheduled workflow [%s:%s:%s] with err: %v",
+					executionRequest.Project, executionRequest.Domain, executionRequest.Name, err)
+			} else if ok && ec.Code() != codes.AlreadyExists {
 				e.metrics.FailedKickoffExecution.Inc()
 				logger.Errorf(context.Background(), "failed to execute scheduled workflow [%s:%s:%s] with err: %v",
 					executionRequest.Project, executionRequest.Domain, executionRequest.Name, err)
@@ -291,6 +321,10 @@ func newWorkflowExecutorMetrics(scope promutils.Scope) workflowExecutorMetrics {
 			"count of failures kicking-off workflow execution"),
 		ScheduledEventsProcessed: scope.MustNewCounter("scheduled_events_processed",
 			"total number of schedule events successfully processed"),
+		StaleScheduledEventsDropped: scope.MustNewCounter("stale_scheduled_events_dropped",
+			"total number of stale schedule events dropped due to the catch up policy of their schedule"),
+		QuotaExhaustedEventsDropped: scope.MustNewCounter("quota_exhausted_scheduled_events_dropped",
+			"total number of schedule events dropped because their project exhausted its active execution quota"),
 		ScheduledExecutionSystemDelay: labeled.NewStopWatch("schedule_execution_delay",
 			"observed time between when an execution was scheduled to be launched and when it was actually launched",
 			time.Second, scope, labeled.EmitUnlabeledMetric),
@@ -341,5 +375,6 @@ func NewWorkflowExecutor(
 		executionManager:  executionManager,
 		launchPlanManager: launchPlanManager,
 		metrics:           metrics,
+		catchUpThreshold:  schedulerConfig.WorkflowExecutorConfig.GetCatchUpThreshold(),
 	}
 }
@@ -14,7 +14,9 @@ import (
 	"github.com/NYTimes/gizmo/pubsub/pubsubtest"
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 
@@ -288,6 +290,121 @@ func TestRun(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRun_DropsStaleEventsForCatchUpPolicyNone(t *testing.T) {
+	launchPlanIdentifier := &admin.NamedEntityIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+	}
+	payload, _ := proto.Marshal(launchPlanIdentifier)
+	messages := []interface{}{
+		ScheduleWorkflowPayload{
+			Time:          "2017-12-22T18:43:48Z",
+			Payload:       payload,
+			CatchUpPolicy: runtimeInterfaces.CatchUpPolicyNone,
+		},
+	}
+	testSubscriber := pubsubtest.TestSubscriber{
+		JSONMessages: messages,
+	}
+	testExecutionManager := mocks.MockExecutionManager{}
+	testExecutionManager.SetCreateCallback(func(
+		ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
+		*admin.ExecutionCreateResponse, error) {
+		assert.Fail(t, "stale scheduled events shouldn't launch executions")
+		return nil, nil
+	})
+	launchPlanManager := mocks.NewMockLaunchPlanManager()
+	launchPlanManager.(*mocks.MockLaunchPlanManager).SetListLaunchPlansCallback(
+		func(ctx context.Context, request admin.ResourceListRequest) (
+			*admin.LaunchPlanList, error) {
+			assert.Fail(t, "stale scheduled events shouldn't look up launch plans")
+			return nil, nil
+		})
+	testExecutor := newWorkflowExecutorForTest(&testSubscriber, &testExecutionManager, launchPlanManager)
+	testExecutor.catchUpThreshold = time.Hour
+	err := testExecutor.run()
+	assert.Nil(t, err)
+}
+
+func TestRun_DropsEventsExhaustingQuota(t *testing.T) {
+	launchPlanIdentifier := &admin.NamedEntityIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+	}
+	payload, _ := proto.Marshal(launchPlanIdentifier)
+	testSubscriber := pubsubtest.TestSubscriber{
+		JSONMessages: []interface{}{
+			ScheduleWorkflowPayload{
+				Time:    "2017-12-22T18:43:48Z",
+				Payload: payload,
+			},
+		},
+	}
+	testExecutionManager := mocks.MockExecutionManager{}
+	testExecutionManager.SetCreateCallback(func(
+		ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
+		*admin.ExecutionCreateResponse, error) {
+		return nil, flyteAdminErrors.NewQuotaExceededError(ctx, "project:domain", "too many active executions")
+	})
+	launchPlanManager := mocks.NewMockLaunchPlanManager()
+	launchPlanManager.(*mocks.MockLaunchPlanManager).SetListLaunchPlansCallback(
+		func(ctx context.Context, request admin.ResourceListRequest) (
+			*admin.LaunchPlanList, error) {
+			return &admin.LaunchPlanList{
+				LaunchPlans: []*admin.LaunchPlan{
+					{
+						Id: &core.Identifier{
+							Project: "project",
+							Domain:  "domain",
+							Name:    "name",
+							Version: "foo",
+						},
+						Spec:    &admin.LaunchPlanSpec{},
+						Closure: &admin.LaunchPlanClosure{},
+					},
+				},
+			}, nil
+		})
+	dropped := testutil.ToFloat64(executorMetrics.QuotaExhaustedEventsDropped)
+	failed := testutil.ToFloat64(executorMetrics.FailedKickoffExecution)
+	processed := testutil.ToFloat64(executorMetrics.ScheduledEventsProcessed)
+	testExecutor := newWorkflowExecutorForTest(&testSubscriber, &testExecutionManager, launchPlanManager)
+	err := testExecutor.run()
+	assert.Nil(t, err)
+	// The event is processed, and won't be redelivered, without launching an execution.
+	assert.Equal(t, dropped+1, testutil.ToFloat64(executorMetrics.QuotaExhaustedEventsDropped))
+	assert.Equal(t, failed, testutil.ToFloat64(executorMetrics.FailedKickoffExecution))
+	assert.Equal(t, processed+1, testutil.ToFloat64(executorMetrics.ScheduledEventsProcessed))
+}
+
+func TestShouldDropStaleEvent(t *testing.T) {
+	testExecutor := newWorkflowExecutorForTest(nil, nil, nil)
+	testExecutor.catchUpThreshold = 5 * time.Minute
+	now := testKickoffTimestamp.Add(5 * time.Minute)
+	testCases := []struct {
+		name     string
+		policy   runtimeInterfaces.CatchUpPolicy
+		now      time.Time
+		expected bool
+	}{
+		{"none at the threshold", runtimeInterfaces.CatchUpPolicyNone, now, false},
+		{"none just over the threshold", runtimeInterfaces.CatchUpPolicyNone, now.Add(time.Nanosecond), true},
+		{"last just over the threshold", runtimeInterfaces.CatchUpPolicyLast, now.Add(time.Nanosecond), false},
+		{"all just over the threshold", runtimeInterfaces.CatchUpPolicyAll, now.Add(time.Nanosecond), false},
+		{"unset policy just over the threshold", "", now.Add(time.Nanosecond), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, testExecutor.shouldDropStaleEvent(ScheduledWorkflowExecutionRequest{
+				KickoffTime:   testKickoffTimestamp,
+				CatchUpPolicy: tc.policy,
+			}, tc.now))
+		})
+	}
+}
+
 func TestStop(t *testing.T) {
 	testSubscriber := pubsubtest.TestSubscriber{}
 	testExecutor := newWorkflowExecutorForTest(&testSubscriber, nil, nil)
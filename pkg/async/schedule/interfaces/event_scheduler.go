@@ -3,6 +3,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	appInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -18,6 +19,11 @@ type AddScheduleInput struct {
 	Payload *string
 	// Optional: The application-wide prefix to be applied for schedule names.
 	ScheduleNamePrefix string
+	// Determines which stale invocations of the schedule still fire, resolved when the schedule is activated.
+	CatchUpPolicy appInterfaces.CatchUpPolicy
+	// Delays the invocations of the schedule past their nominal times, which remain their kickoff times. Combines the
+	// jitter of the launch plan with the offset it requests. Only the native scheduler honors it.
+	Offset time.Duration
 }
 
 type RemoveScheduleInput struct {
@@ -21,12 +21,14 @@ func (s *MockEventScheduler) CreateScheduleInput(ctx context.Context, appConfig
 	identifier core.Identifier, schedule *admin.Schedule) (interfaces.AddScheduleInput, error) {
 	payload, _ := aws.SerializeScheduleWorkflowPayload(
 		schedule.GetKickoffTimeInputArg(),
+		appConfig.EventSchedulerConfig.GetCatchUpPolicy(),
 		admin.NamedEntityIdentifier{
 			Project: identifier.Project,
 			Domain:  identifier.Domain,
 			Name:    identifier.Name,
 		})
-	return interfaces.AddScheduleInput{Identifier: identifier, ScheduleExpression: *schedule, Payload: payload}, nil
+	return interfaces.AddScheduleInput{Identifier: identifier, ScheduleExpression: *schedule, Payload: payload,
+		CatchUpPolicy: appConfig.EventSchedulerConfig.GetCatchUpPolicy()}, nil
 }
 
 func (s *MockEventScheduler) AddSchedule(ctx context.Context, input interfaces.AddScheduleInput) error {
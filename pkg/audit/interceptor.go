@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+)
+
+// Recorded as the principal subject for requests which carry no authenticated identity (e.g. when auth is disabled).
+const AnonymousPrincipal = "anonymous"
+
+// Admin service methods which mutate state and are audited unless a custom set is configured.
+var DefaultMutatingMethods = []string{
+	"CreateTask",
+	"CreateWorkflow",
+	"CreateLaunchPlan",
+	"UpdateLaunchPlan",
+	"CreateExecution",
+	"RelaunchExecution",
+	"RecoverExecution",
+	"TerminateExecution",
+	"RegisterProject",
+	"UpdateProject",
+	"UpdateProjectDomainAttributes",
+	"DeleteProjectDomainAttributes",
+	"UpdateWorkflowAttributes",
+	"DeleteWorkflowAttributes",
+	"UpdateNamedEntity",
+	// Served by the http handlers standing in for admin service methods.
+	"BulkTerminateExecutions",
+	"PurgeExecutions",
+	"UpdateExecutionTags",
+	"UpdateScheduleCheckpoint",
+	"UpdateActiveExecutionQuota",
+	"DeleteActiveExecutionQuota",
+	"UpdateScopedAttributes",
+	"DeleteScopedAttributes",
+	"CreateDescriptionEntity",
+}
+
+type interceptedContextKey struct{}
+
+// Marks requests the interceptor records, so that the audit log the admin service writes for every request isn't
+// recorded a second time for them.
+func withIntercepted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, interceptedContextKey{}, true)
+}
+
+func isIntercepted(ctx context.Context) bool {
+	intercepted, _ := ctx.Value(interceptedContextKey{}).(bool)
+	return intercepted
+}
+
+// Sink receives a completed audit message for every audited request.
+type Sink interface {
+	Record(ctx context.Context, message Message)
+}
+
+type loggerSink struct{}
+
+func (s *loggerSink) Record(ctx context.Context, message Message) {
+	auditLog, err := json.Marshal(&message)
+	if err != nil {
+		logger.Warningf(ctx, "Failed to marshal audit log with err: %v", err)
+		return
+	}
+	logger.Info(ctx, fmt.Sprintf("Recording request: [%s]", auditLog))
+}
+
+// NewLoggerSink returns a Sink which writes audit messages as structured log lines.
+func NewLoggerSink() Sink {
+	return &loggerSink{}
+}
+
+func principalFromContext(ctx context.Context) (Principal, Client) {
+	client := Client{}
+	if peerInfo, ok := peer.FromContext(ctx); ok && peerInfo.Addr != nil {
+		client.ClientIP = peerInfo.Addr.String()
+	}
+	clientMeta, ok := ctx.Value(common.AuditFieldsContextKey).(AuthenticatedClientMeta)
+	if !ok || len(clientMeta.Subject) == 0 {
+		return Principal{
+			Subject: AnonymousPrincipal,
+		}, client
+	}
+	principal := Principal{
+		Subject:       clientMeta.Subject,
+		TokenIssuedAt: clientMeta.TokenIssuedAt,
+	}
+	if len(clientMeta.ClientIds) > 0 {
+		principal.ClientID = clientMeta.ClientIds[0]
+	}
+	if len(clientMeta.ClientIP) > 0 {
+		client.ClientIP = clientMeta.ClientIP
+	}
+	return principal, client
+}
+
+// Extracts only the identifying fields (project, domain, name...) of a request. Payloads such as inputs are never
+// recorded since they can contain sensitive data.
+func ParametersFromRequest(req interface{}) map[string]string {
+	switch r := req.(type) {
+	case interface{ GetId() *core.Identifier }:
+		return ParametersFromIdentifier(r.GetId())
+	case interface {
+		GetId() *core.WorkflowExecutionIdentifier
+	}:
+		return ParametersFromExecutionIdentifier(r.GetId())
+	case interface {
+		GetId() *admin.NamedEntityIdentifier
+	}:
+		return ParametersFromNamedEntityIdentifier(r.GetId())
+	case interface {
+		GetId() *core.NodeExecutionIdentifier
+	}:
+		return ParametersFromNodeExecutionIdentifier(r.GetId())
+	case interface {
+		GetId() *core.TaskExecutionIdentifier
+	}:
+		return ParametersFromTaskExecutionIdentifier(r.GetId())
+	case *admin.ProjectRegisterRequest:
+		return requestParameters{
+			Project: r.GetProject().GetId(),
+		}
+	case *admin.Project:
+		return requestParameters{
+			Project: r.GetId(),
+		}
+	case *admin.ProjectDomainAttributesUpdateRequest:
+		return requestParameters{
+			Project: r.GetAttributes().GetProject(),
+			Domain:  r.GetAttributes().GetDomain(),
+		}
+	case *admin.WorkflowAttributesUpdateRequest:
+		return requestParameters{
+			Project: r.GetAttributes().GetProject(),
+			Domain:  r.GetAttributes().GetDomain(),
+			Name:    r.GetAttributes().GetWorkflow(),
+		}
+	case *admin.WorkflowAttributesDeleteRequest:
+		return requestParameters{
+			Project:      r.GetProject(),
+			Domain:       r.GetDomain(),
+			Name:         r.GetWorkflow(),
+			ResourceType: r.GetResourceType().String(),
+		}
+	case *admin.WorkflowExecutionEventRequest:
+		return ParametersFromExecutionIdentifier(r.GetEvent().GetExecutionId())
+	case *admin.NodeExecutionEventRequest:
+		return ParametersFromNodeExecutionIdentifier(r.GetEvent().GetId())
+	case *admin.TaskExecutionEventRequest:
+		return taskEventParameters(r.GetEvent())
+	case interface {
+		GetProject() string
+		GetDomain() string
+		GetName() string
+	}:
+		return requestParameters{
+			Project: r.GetProject(),
+			Domain:  r.GetDomain(),
+			Name:    r.GetName(),
+		}
+	case interface {
+		GetProject() string
+		GetDomain() string
+	}:
+		return requestParameters{
+			Project: r.GetProject(),
+			Domain:  r.GetDomain(),
+		}
+	case interface{ GetProject() string }:
+		return requestParameters{
+			Project: r.GetProject(),
+		}
+	}
+	return requestParameters{}
+}
+
+func taskEventParameters(taskEvent *event.TaskExecutionEvent) map[string]string {
+	params := ParametersFromNodeExecutionIdentifier(taskEvent.GetParentNodeExecutionId())
+	if taskEvent.GetTaskId() != nil {
+		params[TaskProject] = taskEvent.GetTaskId().Project
+		params[TaskDomain] = taskEvent.GetTaskId().Domain
+		params[TaskName] = taskEvent.GetTaskId().Name
+		params[TaskVersion] = taskEvent.GetTaskId().Version
+	}
+	params[RetryAttempt] = fmt.Sprint(taskEvent.GetRetryAttempt())
+	return params
+}
+
+// NewUnaryServerInterceptor returns an interceptor which records an audit message to sink for each call to one of
+// the given methods. Methods may be specified by their short (CreateExecution) or fully qualified
+// (/flyteidl.service.AdminService/CreateExecution) name. When no methods are given DefaultMutatingMethods is used.
+func NewUnaryServerInterceptor(methods []string, sink Sink) grpc.UnaryServerInterceptor {
+	if len(methods) == 0 {
+		methods = DefaultMutatingMethods
+	}
+	auditedMethods := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		auditedMethods[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		if !auditedMethods[info.FullMethod] && !auditedMethods[common.ShortMethodName(info.FullMethod)] {
+			return handler(ctx, req)
+		}
+
+		receivedAt := time.Now()
+		resp, err := handler(withIntercepted(ctx), req)
+
+		principal, client := principalFromContext(ctx)
+		sink.Record(ctx, Message{
+			Principal: principal,
+			Client:    client,
+			Request: Request{
+				Method:     common.ShortMethodName(info.FullMethod),
+				Parameters: ParametersFromRequest(req),
+				Mode:       ReadWrite,
+				ReceivedAt: receivedAt,
+			},
+			Response: Response{
+				ResponseCode: status.Code(err).String(),
+				SentAt:       time.Now(),
+			},
+		})
+		return resp, err
+	}
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const createExecutionMethod = "/flyteidl.service.AdminService/CreateExecution"
+
+type recordingSink struct {
+	messages []Message
+}
+
+func (s *recordingSink) Record(ctx context.Context, message Message) {
+	s.messages = append(s.messages, message)
+}
+
+var createExecutionRequest = &admin.ExecutionCreateRequest{
+	Project: "project",
+	Domain:  "domain",
+	Name:    "name",
+	Inputs: &core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"secret": {},
+		},
+	},
+}
+
+func TestUnaryServerInterceptor_Anonymous(t *testing.T) {
+	sink := &recordingSink{}
+	interceptor := NewUnaryServerInterceptor(nil, sink)
+	resp, err := interceptor(context.Background(), createExecutionRequest, &grpc.UnaryServerInfo{
+		FullMethod: createExecutionMethod,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		// The admin service doesn't log the request again.
+		assert.True(t, isIntercepted(ctx))
+		return "resp", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "resp", resp)
+	assert.Len(t, sink.messages, 1)
+	message := sink.messages[0]
+	assert.Equal(t, AnonymousPrincipal, message.Principal.Subject)
+	assert.Equal(t, "CreateExecution", message.Request.Method)
+	assert.Equal(t, ReadWrite, message.Request.Mode)
+	assert.EqualValues(t, map[string]string{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+	}, message.Request.Parameters)
+	assert.Equal(t, codes.OK.String(), message.Response.ResponseCode)
+}
+
+func TestUnaryServerInterceptor_Authenticated(t *testing.T) {
+	sink := &recordingSink{}
+	tokenIssuedAt := time.Date(2020, time.January, 5, 10, 15, 0, 0, time.UTC)
+	ctx := context.WithValue(context.Background(), common.AuditFieldsContextKey, AuthenticatedClientMeta{
+		ClientIds:     []string{"12345"},
+		TokenIssuedAt: tokenIssuedAt,
+		ClientIP:      "192.0.2.1:25",
+		Subject:       "prince",
+	})
+	interceptor := NewUnaryServerInterceptor([]string{"CreateExecution"}, sink)
+	_, err := interceptor(ctx, createExecutionRequest, &grpc.UnaryServerInfo{
+		FullMethod: createExecutionMethod,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.NewFlyteAdminError(codes.AlreadyExists, "womp womp")
+	})
+	assert.Error(t, err)
+	assert.Len(t, sink.messages, 1)
+	message := sink.messages[0]
+	assert.Equal(t, Principal{
+		Subject:       "prince",
+		ClientID:      "12345",
+		TokenIssuedAt: tokenIssuedAt,
+	}, message.Principal)
+	assert.Equal(t, "192.0.2.1:25", message.Client.ClientIP)
+	assert.Equal(t, codes.AlreadyExists.String(), message.Response.ResponseCode)
+}
+
+func TestUnaryServerInterceptor_NotAudited(t *testing.T) {
+	sink := &recordingSink{}
+	interceptor := NewUnaryServerInterceptor([]string{"/flyteidl.service.AdminService/CreateTask"}, sink)
+	_, err := interceptor(context.Background(), createExecutionRequest, &grpc.UnaryServerInfo{
+		FullMethod: createExecutionMethod,
+	}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		assert.False(t, isIntercepted(ctx))
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, sink.messages)
+}
+
+func TestParametersFromRequest(t *testing.T) {
+	assert.EqualValues(t, map[string]string{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+		Version: "version",
+	}, ParametersFromRequest(&admin.TaskCreateRequest{
+		Id: &core.Identifier{
+			ResourceType: core.ResourceType_TASK,
+			Project:      "project",
+			Domain:       "domain",
+			Name:         "name",
+			Version:      "version",
+		},
+		Spec: &admin.TaskSpec{},
+	}))
+	assert.EqualValues(t, map[string]string{
+		Project: "project",
+	}, ParametersFromRequest(&admin.ProjectRegisterRequest{
+		Project: &admin.Project{
+			Id:          "project",
+			Description: "description",
+		},
+	}))
+}
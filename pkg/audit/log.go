@@ -82,6 +82,9 @@ func (b *logBuilder) formatLogString(ctx context.Context) string {
 }
 
 func (b *logBuilder) Log(ctx context.Context) {
+	if isIntercepted(ctx) {
+		return
+	}
 	if b.readOnly {
 		logger.Warningf(ctx, "Attempting to record audit log for request: [%+v] more than once. Aborting.", b.auditLog.Request)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -12,19 +13,19 @@ import (
 	"strings"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
 	"github.com/flyteorg/flyteadmin/pkg/runtime"
-	corev1 "k8s.io/api/core/v1"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
-	"k8s.io/apimachinery/pkg/util/mergepatch"
-	"k8s.io/apimachinery/pkg/util/strategicpatch"
-	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"k8s.io/apimachinery/pkg/api/meta"
-	k8sruntime "k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	yamlserializer "k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
@@ -36,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	managerinterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster/interfaces"
@@ -63,13 +65,23 @@ const projectVariable = "project"
 const domainVariable = "domain"
 const templateVariableFormat = "{{ %s }}"
 const replaceAllInstancesOfString = -1
-const noChange = "{}"
+
+// The field manager owning the fields of the kubernetes resources applied from templates.
+const fieldManager = "flyteadmin-cluster-resource-controller"
+
+// Label of the kubernetes resources applied from templates, naming the template file they were applied from.
+const templateOwnerLabel = "flyte.org/cluster-resource-template"
 
 // The clusterresource Controller manages applying desired templatized kubernetes resource files as resources
 // in the execution kubernetes cluster.
 type Controller interface {
 	Sync(ctx context.Context) error
-	Run()
+	// Syncs cluster resources without applying anything, writing the objects which would be created and a diff of
+	// those which would be updated to out.
+	DryRun(ctx context.Context, out io.Writer) error
+	// Syncs cluster resources at every interval until the context is cancelled. A zero interval stands for the
+	// configured refresh interval.
+	Run(ctx context.Context, interval time.Duration)
 }
 
 type controllerMetrics struct {
@@ -77,15 +89,34 @@ type controllerMetrics struct {
 	SyncStarted                     prometheus.Counter
 	KubernetesResourcesCreated      prometheus.Counter
 	KubernetesResourcesCreateErrors prometheus.Counter
+	KubernetesResourcesDeleted      prometheus.Counter
+	KubernetesResourcesDeleteErrors prometheus.Counter
 	ResourcesAdded                  prometheus.Counter
 	ResourceAddErrors               prometheus.Counter
 	TemplateReadErrors              prometheus.Counter
 	TemplateDecodeErrors            prometheus.Counter
-	AppliedTemplateExists           prometheus.Counter
-	TemplateUpdateErrors            prometheus.Counter
 	Panics                          prometheus.Counter
+	// The number of templates applied, which failed to apply and which were skipped, being unchanged since they were
+	// last applied, in each cluster by the last sync.
+	TemplatesApplied *prometheus.GaugeVec
+	TemplatesFailed  *prometheus.GaugeVec
+	TemplatesSkipped *prometheus.GaugeVec
+	// The unix time of the last sync which applied every template without error.
+	LastSuccessfulSync prometheus.Gauge
 }
 
+// The metric label of the cluster the templates are applied to.
+const clusterLabel = "cluster"
+
+// What became of a template in a cluster during a sync.
+type templateOutcome int
+
+const (
+	templateApplied templateOutcome = iota
+	templateFailed
+	templateSkipped
+)
+
 type FileName = string
 type NamespaceName = string
 type LastModTimeCache = map[FileName]time.Time
@@ -93,6 +124,19 @@ type NamespaceCache = map[NamespaceName]LastModTimeCache
 
 type templateValuesType = map[string]string
 
+// The kinds of resources looked up for objects of removed templates, besides the kinds applied since the controller
+// started. Objects of other kinds applied by templates removed while the controller wasn't running are left behind.
+var prunedKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "ServiceAccount"},
+	{Version: "v1", Kind: "ResourceQuota"},
+	{Version: "v1", Kind: "LimitRange"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+}
+
 type controller struct {
 	db                     repositories.RepositoryInterface
 	config                 runtimeInterfaces.Configuration
@@ -103,6 +147,15 @@ type controller struct {
 	lastAppliedTemplateDir string
 	// Map of [namespace -> [templateFileName -> last modified time]]
 	appliedTemplates NamespaceCache
+	// The kinds of the resources applied from templates, which are looked up for objects of removed templates.
+	appliedKinds map[schema.GroupVersionKind]bool
+	// Map of [namespace -> template file names] the resources of removed templates were last deleted for.
+	prunedTemplates map[NamespaceName]string
+	getRESTMapper   func(target executioncluster.ExecutionTarget) (meta.RESTMapper, error)
+	// Map of [cluster -> [outcome -> templates]] for the current sync, published as metrics once it completes.
+	templateOutcomes map[string]map[templateOutcome]int
+	// Where dry runs write the objects they would apply, nil unless dry running.
+	dryRunOut io.Writer
 }
 
 var descCreatedAtSortParam, _ = common.NewSortParameter(admin.Sort{
@@ -125,6 +178,34 @@ func (c *controller) templateAlreadyApplied(namespace NamespaceName, templateFil
 	return timestamp.Equal(templateFile.ModTime())
 }
 
+// Records the outcome of applying a template in a cluster.
+func (c *controller) countTemplate(targetID string, outcome templateOutcome) {
+	if c.templateOutcomes == nil {
+		c.templateOutcomes = make(map[string]map[templateOutcome]int)
+	}
+	if _, ok := c.templateOutcomes[targetID]; !ok {
+		c.templateOutcomes[targetID] = make(map[templateOutcome]int)
+	}
+	c.templateOutcomes[targetID][outcome]++
+}
+
+// Records the outcome of a template in every cluster, for templates which are skipped or fail before being applied.
+func (c *controller) countTemplateInAllTargets(outcome templateOutcome) {
+	for _, target := range c.executionCluster.GetAllValidTargets() {
+		c.countTemplate(target.ID, outcome)
+	}
+}
+
+// Publishes the outcomes of the templates of the sync which just completed, for every cluster.
+func (c *controller) publishTemplateOutcomes() {
+	for _, target := range c.executionCluster.GetAllValidTargets() {
+		outcomes := c.templateOutcomes[target.ID]
+		c.metrics.TemplatesApplied.WithLabelValues(target.ID).Set(float64(outcomes[templateApplied]))
+		c.metrics.TemplatesFailed.WithLabelValues(target.ID).Set(float64(outcomes[templateFailed]))
+		c.metrics.TemplatesSkipped.WithLabelValues(target.ID).Set(float64(outcomes[templateSkipped]))
+	}
+}
+
 // Given a map of templatized variable names -> data source, this function produces an output that maps the same
 // variable names to their fully resolved values (from the specified data source).
 func populateTemplateValues(data map[string]runtimeInterfaces.DataSource) (templateValuesType, error) {
@@ -241,18 +322,26 @@ type dynamicResource struct {
 	mapping *meta.RESTMapping
 }
 
+// Builds a REST mapper which discovers the resources served by the API server of the target.
+func newDiscoveryRESTMapper(target executioncluster.ExecutionTarget) (meta.RESTMapper, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(&target.Config)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc)), nil
+}
+
 // This function borrows heavily from the excellent example code here:
 // https://ymmt2005.hatenablog.com/entry/2020/04/14/An_example_of_using_dynamic_client_of_k8s.io/client-go#Background-Server-Side-Apply
 // to dynamically discover the GroupVersionResource for the templatized k8s object from the cluster resource config files
 // which a dynamic client can use to create or mutate the resource.
-func prepareDynamicCreate(target executioncluster.ExecutionTarget, config string) (dynamicResource, error) {
-	dc, err := discovery.NewDiscoveryClientForConfig(&target.Config)
+func (c *controller) prepareDynamicCreate(target executioncluster.ExecutionTarget, config string) (dynamicResource, error) {
+	mapper, err := c.getRESTMapper(target)
 	if err != nil {
 		return dynamicResource{}, err
 	}
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
 
-	decUnstructured := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	decUnstructured := yamlserializer.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 	obj := &unstructured.Unstructured{}
 	_, gvk, err := decUnstructured.Decode([]byte(config), nil, obj)
 	if err != nil {
@@ -271,12 +360,179 @@ func prepareDynamicCreate(target executioncluster.ExecutionTarget, config string
 	}, nil
 }
 
+// The label value identifying the objects applied from a template file.
+func getTemplateOwnerLabelValue(templateFileName FileName) (string, error) {
+	value := strings.TrimSuffix(templateFileName, filepath.Ext(templateFileName))
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return "", errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"template file name [%s] can't be used as a label value: %v", templateFileName, errs)
+	}
+	return value, nil
+}
+
+// Applies an object with server side apply. Only the fields set by the template are owned by the controller, fields
+// set by other field managers, such as labels added by admission controllers, are left untouched.
+func (c *controller) applyResource(ctx context.Context, target executioncluster.ExecutionTarget,
+	dynamicObj dynamicResource, namespace NamespaceName) error {
+	data, err := json.Marshal(dynamicObj.obj)
+	if err != nil {
+		return err
+	}
+	force := true
+	dr := getDynamicResourceInterface(dynamicObj.mapping, target.DynamicClient, namespace)
+	_, err = dr.Patch(ctx, dynamicObj.obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		// Fields of objects created before server side apply was used are owned by another field manager.
+		Force: &force,
+	})
+	return err
+}
+
+// Fields kubernetes sets on every object, which are left out of dry run diffs.
+var serverSetMetadataFields = []string{
+	"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink",
+}
+
+// Writes what applying an object would do to the dry run output: the whole object when it doesn't exist yet, and a
+// diff of the object when applying it would change it. Server side apply is approximated by merging the applied
+// configuration into the current object, which is what it amounts to for the maps templates usually set.
+func (c *controller) diffResource(ctx context.Context, target executioncluster.ExecutionTarget,
+	dynamicObj dynamicResource, namespace NamespaceName) error {
+	desired, err := json.Marshal(dynamicObj.obj)
+	if err != nil {
+		return err
+	}
+	description := fmt.Sprintf("%s [%s] for namespace [%s] in cluster [%s]",
+		dynamicObj.obj.GetKind(), dynamicObj.obj.GetName(), namespace, target.ID)
+	dr := getDynamicResourceInterface(dynamicObj.mapping, target.DynamicClient, namespace)
+	current, err := dr.Get(ctx, dynamicObj.obj.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		desiredYAML, err := yaml.JSONToYAML(desired)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.dryRunOut, "create %s\n%s\n", description, desiredYAML)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	for _, field := range serverSetMetadataFields {
+		unstructured.RemoveNestedField(current.Object, "metadata", field)
+	}
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	appliedJSON, err := jsonpatch.MergePatch(currentJSON, desired)
+	if err != nil {
+		return err
+	}
+	currentYAML, err := yaml.JSONToYAML(currentJSON)
+	if err != nil {
+		return err
+	}
+	appliedYAML, err := yaml.JSONToYAML(appliedJSON)
+	if err != nil {
+		return err
+	}
+	if string(currentYAML) == string(appliedYAML) {
+		_, err = fmt.Fprintf(c.dryRunOut, "unchanged %s\n\n", description)
+		return err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(currentYAML), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(appliedYAML), "\n")),
+		FromFile: "current",
+		ToFile:   "applied",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.dryRunOut, "update %s\n%s\n", description, diff)
+	return err
+}
+
+// Deletes the objects applied from template files which have since been removed. Objects are found by the label naming
+// the template they were applied from, so that the objects of templates removed while the controller wasn't running
+// are deleted too. Returns whether every such object was deleted.
+func (c *controller) pruneTemplates(ctx context.Context, namespace NamespaceName, currentOwners sets.String) bool {
+	kinds := make(map[schema.GroupVersionKind]bool)
+	for _, kind := range prunedKinds {
+		kinds[kind] = true
+	}
+	for kind := range c.appliedKinds {
+		kinds[kind] = true
+	}
+	pruned := true
+	for _, target := range c.executionCluster.GetAllValidTargets() {
+		mapper, err := c.getRESTMapper(target)
+		if err != nil {
+			logger.Warningf(ctx, "Failed to get the rest mapper of cluster [%s] with err: %v", target.ID, err)
+			pruned = false
+			continue
+		}
+		for kind := range kinds {
+			mapping, err := mapper.RESTMapping(kind.GroupKind(), kind.Version)
+			if err != nil {
+				// The kind isn't served by this cluster.
+				continue
+			}
+			if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+				continue
+			}
+			if !c.pruneKind(ctx, target, mapping, namespace, currentOwners) {
+				pruned = false
+			}
+		}
+	}
+	return pruned
+}
+
+func (c *controller) pruneKind(ctx context.Context, target executioncluster.ExecutionTarget, mapping *meta.RESTMapping,
+	namespace NamespaceName, currentOwners sets.String) bool {
+	dr := getDynamicResourceInterface(mapping, target.DynamicClient, namespace)
+	objects, err := dr.List(ctx, metav1.ListOptions{LabelSelector: templateOwnerLabel})
+	if err != nil {
+		if k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err) {
+			// Flyteadmin isn't permitted to manage this kind of resource, so it didn't apply any.
+			return true
+		}
+		logger.Warningf(ctx, "Failed to list %s resources in namespace [%s] in cluster [%s] with err: %v",
+			mapping.GroupVersionKind.Kind, namespace, target.ID, err)
+		c.metrics.KubernetesResourcesDeleteErrors.Inc()
+		return false
+	}
+	pruned := true
+	for _, object := range objects.Items {
+		owner := object.GetLabels()[templateOwnerLabel]
+		if currentOwners.Has(owner) {
+			continue
+		}
+		err = dr.Delete(ctx, object.GetName(), metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Warningf(ctx, "Failed to delete resource [%s] of removed template [%s] in namespace [%s] "+
+				"in cluster [%s] with err: %v", object.GetName(), owner, namespace, target.ID, err)
+			c.metrics.KubernetesResourcesDeleteErrors.Inc()
+			pruned = false
+			continue
+		}
+		logger.Infof(ctx, "Deleted resource [%s] of removed template [%s] in namespace [%s] in cluster [%s]",
+			object.GetName(), owner, namespace, target.ID)
+		c.metrics.KubernetesResourcesDeleted.Inc()
+	}
+	return pruned
+}
+
 // This function loops through the kubernetes resource template files in the configured template directory.
 // For each unapplied template file (wrt the namespace) this func attempts to
 //   1) create k8s object resource from template by performing:
 //      a) read template file
 //      b) substitute templatized variables with their resolved values
-//   2) create the resource on the kubernetes cluster and cache successful outcomes
+//   2) apply the resource on the kubernetes cluster and cache successful outcomes
+// Resources applied from template files which have since been removed are deleted. Dry runs only write the changes
+// they would make, neither caching nor deleting anything.
 func (c *controller) syncNamespace(ctx context.Context, project models.Project, domain runtimeInterfaces.Domain, namespace NamespaceName,
 	templateValues, customTemplateValues templateValuesType) error {
 	templateDir := c.config.ClusterResourceConfiguration().GetTemplatePath()
@@ -293,6 +549,14 @@ func (c *controller) syncNamespace(ctx context.Context, project models.Project,
 	}
 
 	collectedErrs := make([]error, 0)
+	if c.appliedKinds == nil {
+		c.appliedKinds = make(map[schema.GroupVersionKind]bool)
+	}
+	if c.prunedTemplates == nil {
+		c.prunedTemplates = make(map[NamespaceName]string)
+	}
+	currentOwners := sets.NewString()
+	templateFileNames := make([]string, 0, len(templateFiles))
 	for _, templateFile := range templateFiles {
 		templateFileName := templateFile.Name()
 		if filepath.Ext(templateFileName) != ".yaml" {
@@ -301,10 +565,22 @@ func (c *controller) syncNamespace(ctx context.Context, project models.Project,
 				namespace, templateFile.Name())
 			continue
 		}
+		templateFileNames = append(templateFileNames, templateFileName)
+		if ownerLabelValue, err := getTemplateOwnerLabelValue(templateFileName); err == nil {
+			currentOwners.Insert(ownerLabelValue)
+		}
 
-		if c.templateAlreadyApplied(namespace, templateFile) {
+		if c.dryRunOut == nil && c.templateAlreadyApplied(namespace, templateFile) {
 			// nothing to do.
 			logger.Debugf(ctx, "syncing namespace [%s]: templateFile [%s] already applied, nothing to do.", namespace, templateFile.Name())
+			c.countTemplateInAllTargets(templateSkipped)
+			continue
+		}
+
+		ownerLabelValue, err := getTemplateOwnerLabelValue(templateFileName)
+		if err != nil {
+			collectedErrs = append(collectedErrs, err)
+			c.countTemplateInAllTargets(templateFailed)
 			continue
 		}
 
@@ -312,140 +588,111 @@ func (c *controller) syncNamespace(ctx context.Context, project models.Project,
 		k8sManifest, err := c.createResourceFromTemplate(ctx, templateDir, templateFileName, project, domain, namespace, templateValues, customTemplateValues)
 		if err != nil {
 			collectedErrs = append(collectedErrs, err)
+			c.countTemplateInAllTargets(templateFailed)
 			continue
 		}
 
-		// 2) create the resource on the kubernetes cluster and cache successful outcomes
+		// 2) apply the resource on the kubernetes cluster and cache successful outcomes
 		if _, ok := c.appliedTemplates[namespace]; !ok {
 			c.appliedTemplates[namespace] = make(LastModTimeCache)
 		}
+		var applyFailed bool
 		for _, target := range c.executionCluster.GetAllValidTargets() {
-			dynamicObj, err := prepareDynamicCreate(target, k8sManifest)
+			dynamicObj, err := c.prepareDynamicCreate(target, k8sManifest)
 			if err != nil {
-				logger.Warningf(ctx, "Failed to transform kubernetes manifest for namespace [%s] "+
-					"into a dynamic unstructured mapping with err: %v, manifest: %v", namespace, err, k8sManifest)
+				logger.Warningf(ctx, "Failed to transform kubernetes manifest for namespace [%s] in cluster [%s] "+
+					"into a dynamic unstructured mapping with err: %v, manifest: %v", namespace, target.ID, err, k8sManifest)
 				collectedErrs = append(collectedErrs, err)
 				c.metrics.KubernetesResourcesCreateErrors.Inc()
+				c.countTemplate(target.ID, templateFailed)
+				applyFailed = true
+				continue
+			}
+			labels := dynamicObj.obj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[templateOwnerLabel] = ownerLabelValue
+			dynamicObj.obj.SetLabels(labels)
+
+			if c.dryRunOut != nil {
+				if err := c.diffResource(ctx, target, dynamicObj, namespace); err != nil {
+					logger.Warningf(ctx, "Failed to diff kubernetes object from config template [%s] for namespace [%s] "+
+						"in cluster [%s] with err: %v", templateFileName, namespace, target.ID, err)
+					collectedErrs = append(collectedErrs, errors.NewFlyteAdminErrorf(codes.Internal,
+						"Failed to diff kubernetes object from config template [%s] for namespace [%s] in cluster [%s] "+
+							"with err: %v", templateFileName, namespace, target.ID, err))
+					c.countTemplate(target.ID, templateFailed)
+					continue
+				}
+				c.countTemplate(target.ID, templateApplied)
 				continue
 			}
 
-			logger.Debugf(ctx, "Attempting to create resource [%+v] in cluster [%v] for namespace [%s]",
+			logger.Debugf(ctx, "Attempting to apply resource [%+v] in cluster [%v] for namespace [%s]",
 				dynamicObj.obj.GetKind(), target.ID, namespace)
-
-			dr := getDynamicResourceInterface(dynamicObj.mapping, target.DynamicClient, namespace)
-			_, err = dr.Create(ctx, dynamicObj.obj, metav1.CreateOptions{})
-
+			err = c.applyResource(ctx, target, dynamicObj, namespace)
 			if err != nil {
-				if k8serrors.IsAlreadyExists(err) {
-					logger.Debugf(ctx, "Type [%+v] in namespace [%s] already exists - attempting update instead",
-						dynamicObj.obj.GetKind(), namespace)
-					c.metrics.AppliedTemplateExists.Inc()
-
-					currentObj, err := dr.Get(ctx, dynamicObj.obj.GetName(), metav1.GetOptions{})
-					if err != nil {
-						c.metrics.TemplateUpdateErrors.Inc()
-						logger.Warningf(ctx, "Failed to get current resource from server [%+v] in namespace [%s] with err: %v",
-							dynamicObj.obj.GetKind(), namespace, err)
-						collectedErrs = append(collectedErrs, err)
-						continue
-					}
-
-					modified, err := json.Marshal(dynamicObj.obj)
-					if err != nil {
-						c.metrics.TemplateUpdateErrors.Inc()
-						logger.Warningf(ctx, "Failed to marshal resource [%+v] in namespace [%s] to json with err: %v",
-							dynamicObj.obj.GetKind(), namespace, err)
-						collectedErrs = append(collectedErrs, err)
-						continue
-					}
-
-					patch, patchType, err := c.createPatch(dynamicObj.mapping.GroupVersionKind, currentObj, modified, namespace)
-					if err != nil {
-						c.metrics.TemplateUpdateErrors.Inc()
-						logger.Warningf(ctx, "Failed to create patch for resource [%+v] in namespace [%s] err: %v",
-							dynamicObj.obj.GetKind(), namespace, err)
-						collectedErrs = append(collectedErrs, err)
-						continue
-					}
-
-					if string(patch) == noChange {
-						logger.Infof(ctx, "Resource [%+v] in namespace [%s] is not modified",
-							dynamicObj.obj.GetKind(), namespace)
-						continue
-					}
-
-					_, err = dr.Patch(ctx, dynamicObj.obj.GetName(),
-						patchType, patch, metav1.PatchOptions{})
-					if err != nil {
-						c.metrics.TemplateUpdateErrors.Inc()
-						logger.Warningf(ctx, "Failed to patch resource [%+v] in namespace [%s] with err: %v",
-							dynamicObj.obj.GetKind(), namespace, err)
-						collectedErrs = append(collectedErrs, err)
-						continue
-					}
-
-					logger.Debugf(ctx, "Successfully updated resource [%+v] in namespace [%s]",
-						dynamicObj.obj.GetKind(), namespace)
-					c.appliedTemplates[namespace][templateFile.Name()] = templateFile.ModTime()
+				c.metrics.KubernetesResourcesCreateErrors.Inc()
+				c.countTemplate(target.ID, templateFailed)
+				if k8serrors.IsForbidden(err) {
+					// Most likely flyteadmin isn't granted the permissions to manage this kind of resource.
+					logger.Errorf(ctx, "Not permitted to apply %s from config template [%s] for namespace [%s] "+
+						"in cluster [%s], check the RBAC rules of flyteadmin in this cluster: %v",
+						dynamicObj.obj.GetKind(), templateFileName, namespace, target.ID, err)
 				} else {
-					// Some error other than AlreadyExists was raised when we tried to Create the k8s object.
-					c.metrics.KubernetesResourcesCreateErrors.Inc()
-					logger.Warningf(ctx, "Failed to create kubernetes object from config template [%s] for namespace [%s] with err: %v",
-						templateFileName, namespace, err)
-					err := errors.NewFlyteAdminErrorf(codes.Internal,
-						"Failed to create kubernetes object from config template [%s] for namespace [%s] with err: %v",
-						templateFileName, namespace, err)
-					collectedErrs = append(collectedErrs, err)
+					logger.Warningf(ctx, "Failed to apply kubernetes object from config template [%s] for namespace [%s] "+
+						"in cluster [%s] with err: %v", templateFileName, namespace, target.ID, err)
 				}
-			} else {
-				logger.Debugf(ctx, "Created resource [%+v] for namespace [%s] in kubernetes",
-					dynamicObj.obj.GetKind(), namespace)
-				c.metrics.KubernetesResourcesCreated.Inc()
-				c.appliedTemplates[namespace][templateFile.Name()] = templateFile.ModTime()
+				err := errors.NewFlyteAdminErrorf(codes.Internal,
+					"Failed to apply kubernetes object from config template [%s] for namespace [%s] in cluster [%s] "+
+						"with err: %v", templateFileName, namespace, target.ID, err)
+				collectedErrs = append(collectedErrs, err)
+				applyFailed = true
+				continue
 			}
+			logger.Debugf(ctx, "Applied resource [%+v] for namespace [%s] in kubernetes",
+				dynamicObj.obj.GetKind(), namespace)
+			c.metrics.KubernetesResourcesCreated.Inc()
+			c.countTemplate(target.ID, templateApplied)
+			c.appliedKinds[dynamicObj.mapping.GroupVersionKind] = true
+		}
+		if c.dryRunOut != nil {
+			continue
+		}
+		if !applyFailed {
+			c.appliedTemplates[namespace][templateFileName] = templateFile.ModTime()
 		}
 	}
-	if len(collectedErrs) > 0 {
-		return errors.NewCollectedFlyteAdminError(codes.Internal, collectedErrs)
-	}
-	return nil
-}
-
-var metadataAccessor = meta.NewAccessor()
-
-// getLastApplied get last applied manifest from object's annotation
-func getLastApplied(obj k8sruntime.Object) ([]byte, error) {
-	annots, err := metadataAccessor.Annotations(obj)
-	if err != nil {
-		return nil, err
-	}
-
-	if annots == nil {
-		return nil, nil
+	if c.dryRunOut != nil {
+		if len(collectedErrs) > 0 {
+			return errors.NewCollectedFlyteAdminError(codes.Internal, collectedErrs)
+		}
+		return nil
+	}
+	// Resources of removed templates are only deleted once the remaining templates are applied, so that resources
+	// which moved to another template aren't deleted and recreated. They're looked up whenever the templates differ
+	// from the ones last pruned for, which includes the first sync after the controller starts.
+	currentTemplates := sets.NewString(templateFileNames...)
+	for templateFileName := range c.appliedTemplates[namespace] {
+		if !currentTemplates.Has(templateFileName) {
+			delete(c.appliedTemplates[namespace], templateFileName)
+		}
 	}
-
-	lastApplied, ok := annots[corev1.LastAppliedConfigAnnotation]
-	if !ok {
-		return nil, nil
+	templates := strings.Join(currentTemplates.List(), ",")
+	if lastPruned, ok := c.prunedTemplates[namespace]; !ok || lastPruned != templates {
+		if c.pruneTemplates(ctx, namespace, currentOwners) {
+			c.prunedTemplates[namespace] = templates
+		} else {
+			collectedErrs = append(collectedErrs, errors.NewFlyteAdminErrorf(codes.Internal,
+				"Failed to delete resources of removed templates for namespace [%s]", namespace))
+		}
 	}
 
-	return []byte(lastApplied), nil
-}
-
-func addResourceVersion(patch []byte, rv string) ([]byte, error) {
-	var patchMap map[string]interface{}
-	err := json.Unmarshal(patch, &patchMap)
-	if err != nil {
-		return nil, err
-	}
-	u := unstructured.Unstructured{Object: patchMap}
-	a, err := meta.Accessor(&u)
-	if err != nil {
-		return nil, err
+	if len(collectedErrs) > 0 {
+		return errors.NewCollectedFlyteAdminError(codes.Internal, collectedErrs)
 	}
-	a.SetResourceVersion(rv)
-
-	return json.Marshal(patchMap)
+	return nil
 }
 
 // createResourceFromTemplate this method perform following processes:
@@ -488,78 +735,16 @@ func (c *controller) createResourceFromTemplate(ctx context.Context, templateDir
 	return k8sManifest, nil
 }
 
-// createPatch create 3-way merge patch of current object, original object (retrieved from last applied annotation), and the modification
-// for native k8s resource, strategic merge patch is used
-// for custom resource, json merge patch is used
-// heavily inspired by kubectl's patcher
-func (c *controller) createPatch(gvk schema.GroupVersionKind, currentObj *unstructured.Unstructured, modified []byte, namespace string) ([]byte, types.PatchType, error) {
-	current, err := k8sruntime.Encode(unstructured.UnstructuredJSONScheme, currentObj)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to encode [%+v] in namespace [%s] to json with err: %v",
-			currentObj.GetKind(), namespace, err)
-	}
-
-	original, err := getLastApplied(currentObj)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get original resource [%+v] in namespace [%s] with err: %v",
-			currentObj.GetKind(), namespace, err)
-	}
-
-	var patch []byte
-	patchType := types.StrategicMergePatchType
-	obj, err := scheme.Scheme.New(gvk)
-	switch {
-	case err == nil:
-		lookupPatchMeta, err := strategicpatch.NewPatchMetaFromStruct(obj)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create lookup patch meta for [%+v] in namespace [%s] with err: %v",
-				currentObj.GetKind(), namespace, err)
-		}
-
-		patch, err = strategicpatch.CreateThreeWayMergePatch(original, modified, current, lookupPatchMeta, true)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create 3 way merge patch for resource [%+v] in namespace [%s] with err: %v\noriginal:\n%s\nmodified:\n%s\ncurrent:\n%s",
-				currentObj.GetKind(), namespace, err, original, modified, current)
-		}
-	case k8sruntime.IsNotRegisteredError(err):
-		patchType = types.MergePatchType
-		preconditions := []mergepatch.PreconditionFunc{mergepatch.RequireKeyUnchanged("apiVersion"),
-			mergepatch.RequireKeyUnchanged("kind"), mergepatch.RequireMetadataKeyUnchanged("name")}
-		patch, err = jsonmergepatch.CreateThreeWayJSONMergePatch(original, modified, current, preconditions...)
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create 3 way json merge patch for resource [%+v] in namespace [%s] with err: %v",
-				currentObj.GetKind(), namespace, err)
-		}
-	default:
-		return nil, "", fmt.Errorf("failed to create get instance of versioned object [%+v] in namespace [%s] with err: %v",
-			currentObj.GetKind(), namespace, err)
-
-	}
-
-	if string(patch) == noChange {
-		// not modified
-		return patch, patchType, nil
-	}
-
-	if len(currentObj.GetResourceVersion()) > 0 {
-		patch, err = addResourceVersion(patch, currentObj.GetResourceVersion())
-		if err != nil {
-			return nil, "", fmt.Errorf("failed adding resource version for object [%+v] in namespace [%s] with err: %v",
-				currentObj.GetKind(), namespace, err)
-		}
-	}
-
-	return patch, patchType, nil
-}
-
-func (c *controller) Sync(ctx context.Context) error {
+func (c *controller) Sync(ctx context.Context) (err error) {
 	defer func() {
-		if err := recover(); err != nil {
+		if r := recover(); r != nil {
 			c.metrics.Panics.Inc()
-			logger.Warningf(ctx, fmt.Sprintf("caught panic: %v [%+v]", err, string(debug.Stack())))
+			logger.Warningf(ctx, fmt.Sprintf("caught panic: %v [%+v]", r, string(debug.Stack())))
+			err = errors.NewFlyteAdminErrorf(codes.Internal, "caught panic while syncing cluster resources: %v", r)
 		}
 	}()
 	c.metrics.SyncStarted.Inc()
+	c.templateOutcomes = make(map[string]map[templateOutcome]int)
 	logger.Debugf(ctx, "Running an invocation of ClusterResource Sync")
 
 	// Prefer to sync projects most newly created to ensure their resources get created first when other resources exist.
@@ -589,35 +774,60 @@ func (c *controller) Sync(ctx context.Context) error {
 
 	for _, project := range projects {
 		for _, domain := range *domains {
-			namespace := common.GetNamespaceName(c.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), project.Identifier, domain.Name)
+			// The logs of the namespace carry its project, its domain and, once resolved, its name.
+			namespaceCtx := contextutils.WithProjectDomain(ctx, project.Identifier, domain.ID)
+			namespace, err := util.GetNamespace(
+				namespaceCtx, c.resourceManager, c.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), project, domain.Name)
+			if err != nil {
+				logger.Warningf(namespaceCtx, "Failed to resolve the namespace of project [%s] and domain [%s] with err: %v",
+					project.Identifier, domain.Name, err)
+				errs = append(errs, err)
+				continue
+			}
+			namespaceCtx = contextutils.WithNamespace(namespaceCtx, namespace)
 			customTemplateValues, err := c.getCustomTemplateValues(
-				ctx, project.Identifier, domain.ID, domainTemplateValues[domain.ID])
+				namespaceCtx, project.Identifier, domain.ID, domainTemplateValues[domain.ID])
 			if err != nil {
-				logger.Warningf(ctx, "Failed to get custom template values for %s with err: %v", namespace, err)
+				logger.Warningf(namespaceCtx, "Failed to get custom template values for %s with err: %v", namespace, err)
 				errs = append(errs, err)
 			}
-			err = c.syncNamespace(ctx, project, domain, namespace, templateValues, customTemplateValues)
+			err = c.syncNamespace(namespaceCtx, project, domain, namespace, templateValues, customTemplateValues)
 			if err != nil {
-				logger.Warningf(ctx, "Failed to create cluster resources for namespace [%s] with err: %v", namespace, err)
+				logger.Warningf(namespaceCtx, "Failed to create cluster resources for namespace [%s] with err: %v", namespace, err)
 				c.metrics.ResourceAddErrors.Inc()
 				errs = append(errs, err)
 			} else {
 				c.metrics.ResourcesAdded.Inc()
-				logger.Debugf(ctx, "Successfully created kubernetes resources for [%s]", namespace)
+				logger.Debugf(namespaceCtx, "Successfully created kubernetes resources for [%s]", namespace)
 			}
 		}
 	}
+	if c.dryRunOut == nil {
+		c.publishTemplateOutcomes()
+	}
 	if len(errs) > 0 {
 		return errors.NewCollectedFlyteAdminError(codes.Internal, errs)
 	}
+	if c.dryRunOut == nil {
+		c.metrics.LastSuccessfulSync.SetToCurrentTime()
+	}
 	return nil
 }
 
-func (c *controller) Run() {
-	ctx := context.Background()
+func (c *controller) DryRun(ctx context.Context, out io.Writer) error {
+	c.dryRunOut = out
+	defer func() {
+		c.dryRunOut = nil
+	}()
+	return c.Sync(ctx)
+}
+
+func (c *controller) Run(ctx context.Context, interval time.Duration) {
 	logger.Debugf(ctx, "Running ClusterResourceController")
-	interval := c.config.ClusterResourceConfiguration().GetRefreshInterval()
-	wait.Forever(func() {
+	if interval == 0 {
+		interval = c.config.ClusterResourceConfiguration().GetRefreshInterval()
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
 		err := c.Sync(ctx)
 		if err != nil {
 			logger.Warningf(ctx, "Failed cluster resource creation loop with: %v", err)
@@ -634,6 +844,10 @@ func newMetrics(scope promutils.Scope) controllerMetrics {
 			"overall count of successfully created resources in kubernetes"),
 		KubernetesResourcesCreateErrors: scope.MustNewCounter("k8s_resource_create_errors",
 			"overall count of errors encountered attempting to create resources in kubernetes"),
+		KubernetesResourcesDeleted: scope.MustNewCounter("k8s_resources_deleted",
+			"overall count of resources of removed templates deleted in kubernetes"),
+		KubernetesResourcesDeleteErrors: scope.MustNewCounter("k8s_resource_delete_errors",
+			"overall count of errors encountered attempting to delete resources of removed templates in kubernetes"),
 		ResourcesAdded: scope.MustNewCounter("resources_added",
 			"overall count of successfully added resources for namespaces"),
 		ResourceAddErrors: scope.MustNewCounter("resource_add_errors",
@@ -642,27 +856,34 @@ func newMetrics(scope promutils.Scope) controllerMetrics {
 			"errors encountered reading the yaml template file from the local filesystem"),
 		TemplateDecodeErrors: scope.MustNewCounter("template_decode_errors",
 			"errors encountered trying to decode yaml template into k8s go struct"),
-		AppliedTemplateExists: scope.MustNewCounter("applied_template_exists",
-			"Number of times the system to tried to apply an uncached resource the kubernetes reported as "+
-				"already existing"),
-		TemplateUpdateErrors: scope.MustNewCounter("template_update_errors",
-			"Number of times an attempt at updating an already existing kubernetes resource with a template"+
-				"file failed"),
 		Panics: scope.MustNewCounter("panics",
 			"overall count of panics encountered in primary ClusterResourceController loop"),
+		TemplatesApplied: scope.MustNewGaugeVec("templates_applied",
+			"number of templates applied in the cluster by the last sync", clusterLabel),
+		TemplatesFailed: scope.MustNewGaugeVec("templates_failed",
+			"number of templates which failed to apply in the cluster in the last sync", clusterLabel),
+		TemplatesSkipped: scope.MustNewGaugeVec("templates_skipped",
+			"number of templates skipped in the cluster by the last sync, being unchanged since they were applied",
+			clusterLabel),
+		LastSuccessfulSync: scope.MustNewGauge("last_successful_sync",
+			"unix time of the last sync which applied every template without error"),
 	}
 }
 
 func NewClusterResourceController(db repositories.RepositoryInterface, executionCluster interfaces.ClusterInterface, scope promutils.Scope) Controller {
 	config := runtime.NewConfigurationProvider()
+	if err := common.ValidateNamespaceTemplate(config.NamespaceMappingConfiguration().GetNamespaceTemplate()); err != nil {
+		panic(err)
+	}
 
 	return &controller{
 		db:               db,
 		config:           config,
 		executionCluster: executionCluster,
-		resourceManager:  resources.NewResourceManager(db, config.ApplicationConfiguration()),
+		resourceManager:  resources.NewResourceManager(db, config),
 		poller:           make(chan struct{}),
 		metrics:          newMetrics(scope),
 		appliedTemplates: make(map[string]map[string]time.Time),
+		getRESTMapper:    newDiscoveryRESTMapper,
 	}
 }
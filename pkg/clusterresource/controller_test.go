@@ -1,24 +1,39 @@
 package clusterresource
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/transformers"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
@@ -55,6 +70,11 @@ func (i *mockFileInfo) Sys() interface{} {
 	return nil
 }
 
+func getMockConfigForControllerTest() runtimeInterfaces.Configuration {
+	return runtimeMocks.NewMockConfigurationProvider(testutils.GetApplicationConfigWithDefaultDomains(), nil, nil, nil,
+		nil, nil)
+}
+
 func TestTemplateAlreadyApplied(t *testing.T) {
 	const namespace = "namespace"
 	const fileName = "fileName"
@@ -176,7 +196,7 @@ func TestGetCustomTemplateValues(t *testing.T) {
 	}
 	testController := controller{
 		db:              mockRepository,
-		resourceManager: resources.NewResourceManager(mockRepository, testutils.GetApplicationConfigWithDefaultDomains()),
+		resourceManager: resources.NewResourceManager(mockRepository, getMockConfigForControllerTest()),
 	}
 	domainTemplateValues := templateValuesType{
 		"{{ var1 }}": "i'm getting overwritten",
@@ -198,7 +218,7 @@ func TestGetCustomTemplateValues_NothingToOverride(t *testing.T) {
 	mockRepository := repositoryMocks.NewMockRepository()
 	testController := controller{
 		db:              mockRepository,
-		resourceManager: resources.NewResourceManager(mockRepository, testutils.GetApplicationConfigWithDefaultDomains()),
+		resourceManager: resources.NewResourceManager(mockRepository, getMockConfigForControllerTest()),
 	}
 	customTemplateValues, err := testController.getCustomTemplateValues(context.Background(), "project-foo", "domain-bar", templateValuesType{
 		"{{ var1 }}": "val1",
@@ -221,7 +241,7 @@ func TestGetCustomTemplateValues_InvalidDBModel(t *testing.T) {
 	}
 	testController := controller{
 		db:              mockRepository,
-		resourceManager: resources.NewResourceManager(mockRepository, testutils.GetApplicationConfigWithDefaultDomains()),
+		resourceManager: resources.NewResourceManager(mockRepository, getMockConfigForControllerTest()),
 	}
 	_, err := testController.getCustomTemplateValues(context.Background(), "project-foo", "domain-bar", templateValuesType{
 		"{{ var1 }}": "val1",
@@ -382,299 +402,376 @@ metadata:
 	}
 }
 
-func Test_controller_createPatch(t *testing.T) {
-	type args struct {
-		gvk       schema.GroupVersionKind
-		current   []byte
-		modified  []byte
-		namespace string
+const configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: flyte-config
+  namespace: {{ namespace }}
+data:
+  project: {{ project }}
+`
+
+var configMapResource = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// The fake dynamic client doesn't implement server side apply, which is approximated by merging the applied
+// configuration into the current object.
+func applyPatchReactor(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		patchAction := action.(k8stesting.PatchAction)
+		if patchAction.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		current, err := tracker.Get(
+			patchAction.GetResource(), patchAction.GetNamespace(), patchAction.GetName())
+		if k8serrors.IsNotFound(err) {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(patchAction.GetPatch()); err != nil {
+				return true, nil, err
+			}
+			return true, obj, tracker.Create(patchAction.GetResource(), obj, patchAction.GetNamespace())
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		currentJSON, err := json.Marshal(current)
+		if err != nil {
+			return true, nil, err
+		}
+		merged, err := jsonpatch.MergePatch(currentJSON, patchAction.GetPatch())
+		if err != nil {
+			return true, nil, err
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(merged); err != nil {
+			return true, nil, err
+		}
+		return true, obj, tracker.Update(patchAction.GetResource(), obj, patchAction.GetNamespace())
 	}
+}
 
-	tests := []struct {
-		name          string
-		args          args
-		want          []byte
-		wantPatchType types.PatchType
-		wantErr       bool
-	}{
-		{
-			name: "no modification for native resource",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"},
-				current: []byte(`{
-									"apiVersion": "v1",
-									"kind": "Namespace",
-									"metadata": {
-										"creationTimestamp": "2021-10-04T09:36:08Z",
-										"name": "flyteexamples",
-										"resourceVersion": "15127693",
-										"selfLink": "/api/v1/namespaces/flyteexamples",
-										"uid": "6341347f-af77-498b-ad52-5e70713d91b2"
-									},
-									"spec": {
-										"finalizers": [
-											"kubernetes"
-										]
-									},
-									"status": {
-										"phase": "Active"
-									}
-								}
-								`),
-				modified: []byte(`{
-								"apiVersion": "v1",
-								"kind": "Namespace",
-								"metadata": {
-									"name": "flyteexamples"
-								}
-							}`),
-				namespace: "flyteexamples",
-			},
-			want:          []byte("{}"),
-			wantPatchType: types.StrategicMergePatchType,
-			wantErr:       false,
-		},
-		{
-			name: "no modification for custom resource",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "iam.cnrm.cloud.google.com", Version: "v1beta1", Kind: "IAMServiceAccount"},
-				current: []byte(`{
-						"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
-						"kind": "IAMServiceAccount",
-						"metadata": {
-							"annotations": {
-								"cnrm.cloud.google.com/management-conflict-prevention-policy": "none",
-								"cnrm.cloud.google.com/project-id": "my-project",
-								"cnrm.cloud.google.com/state-into-spec": "merge"
-							},
-							"creationTimestamp": "2021-11-23T14:48:03Z",
-							"generation": 1,
-							"name": "my-project-gsa",
-							"namespace": "my-project",
-							"resourceVersion": "54948113",
-							"selfLink": "/apis/iam.cnrm.cloud.google.com/v1beta1/namespaces/my-project/iamserviceaccounts/my-project-gsa",
-							"uid": "ec76cccc-6b23-4638-b2ee-7cd886cbc031"
-						},
-						"status": {
-							"conditions": [
-								{
-									"lastTransitionTime": "2021-11-23T14:48:03Z",
-									"status": "True",
-									"type": "Ready"
-								}
-							],
-							"observedGeneration": 1
-						}
-					}`),
-				modified: []byte(`{
-								"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
-								"kind": "IAMServiceAccount",
-								"metadata": {
-									"name": "my-project-gsa",
-									"namespace": "my-project",
-									"annotations": {
-										"cnrm.cloud.google.com/project-id": "my-project"
-									}
-								}
-							}`),
-				namespace: "my-project",
-			},
-			want:          []byte("{}"),
-			wantPatchType: types.MergePatchType,
-			wantErr:       false,
-		},
-		{
-			name: "patch namespace",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"},
-				current: []byte(`{
-									"apiVersion": "v1",
-									"kind": "Namespace",
-									"metadata": {
-										"creationTimestamp": "2021-10-04T09:36:08Z",
-										"name": "flyteexamples",
-										"resourceVersion": "15127693",
-										"selfLink": "/api/v1/namespaces/flyteexamples",
-										"uid": "6341347f-af77-498b-ad52-5e70713d91b2"
-									},
-									"spec": {
-										"finalizers": [
-											"kubernetes"
-										]
-									},
-									"status": {
-										"phase": "Active"
-									}
-								}
-								`),
-				modified: []byte(`{
-								"apiVersion": "v1",
-								"kind": "Namespace",
-								"metadata": {
-									"name": "flyteexamples",
-									"annotations": {
-										"cnrm.cloud.google.com/project-id": "my-project"
-									}
-								}
-							}`),
-				namespace: "flyteexamples",
-			},
-			want:          []byte(`{"metadata":{"annotations":{"cnrm.cloud.google.com/project-id":"my-project"},"resourceVersion":"15127693"}}`),
-			wantPatchType: types.StrategicMergePatchType,
-			wantErr:       false,
-		},
-		{
-			name: "patch resource quota",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ResourceQuota"},
-				current: []byte(`{
-								"apiVersion": "v1",
-								"kind": "ResourceQuota",
-								"metadata": {
-									"creationTimestamp": "2021-10-04T09:36:08Z",
-									"name": "project-quota",
-									"namespace": "flyteexamples",
-									"resourceVersion": "54942626",
-									"selfLink": "/api/v1/namespaces/flyteexamples/resourcequotas/project-quota",
-									"uid": "a2776675-8b00-423f-aa13-20fd80b87e7c"
-								},
-								"spec": {
-									"hard": {
-										"limits.cpu": "32",
-										"limits.memory": "64Gi"
-									}
-								},
-								"status": {
-									"hard": {
-										"limits.cpu": "32",
-										"limits.memory": "64Gi"
-									},
-									"used": {
-										"limits.cpu": "0",
-										"limits.memory": "0"
-									}
-								}
-							}
-							`),
-				modified: []byte(`{
-								"apiVersion": "v1",
-								"kind": "ResourceQuota",
-								"spec": {
-									"hard": {
-										"limits.cpu": "64",
-										"limits.memory": "128Gi"
-									}
-								}
-							}`),
-				namespace: "flyteexamples",
-			},
-			want:          []byte(`{"metadata":{"resourceVersion":"54942626"},"spec":{"hard":{"limits.cpu":"64","limits.memory":"128Gi"}}}`),
-			wantPatchType: types.StrategicMergePatchType,
-			wantErr:       false,
-		},
-		{
-			name: "patch service account",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ServiceAccount"},
-				current: []byte(`{
-							"apiVersion": "v1",
-							"kind": "ServiceAccount",
-							"metadata": {
-								"creationTimestamp": "2021-09-26T11:05:03Z",
-								"name": "default",
-								"namespace": "flyte",
-								"resourceVersion": "8902329",
-								"selfLink": "/api/v1/namespaces/flyte/serviceaccounts/default",
-								"uid": "87c18ff7-f16c-4af3-b08a-8419134f2674"
-							},
-							"secrets": [
-								{
-									"name": "default-token-lgqjs"
-								}
-							]
-						}
-					`),
-				modified: []byte(`{
-								"apiVersion": "v1",
-								"kind": "ServiceAccount",
-								"metadata": {
-									"name": "default",
-									"namespace": "flyte",
-									"annotations": {
-										"cnrm.cloud.google.com/project-id": "my-project"
-									}
-								}
-							}`),
-				namespace: "flyteexamples",
-			},
-			want:          []byte(`{"metadata":{"annotations":{"cnrm.cloud.google.com/project-id":"my-project"},"resourceVersion":"8902329"}}`),
-			wantPatchType: types.StrategicMergePatchType,
-			wantErr:       false,
-		},
-		{
-			name: "patch custom resource",
-			args: args{
-				gvk: schema.GroupVersionKind{Group: "iam.cnrm.cloud.google.com", Version: "v1beta1", Kind: "IAMServiceAccount"},
-				current: []byte(`{
-						"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
-						"kind": "IAMServiceAccount",
-						"metadata": {
-							"annotations": {
-								"cnrm.cloud.google.com/management-conflict-prevention-policy": "none",
-								"cnrm.cloud.google.com/project-id": "my-project",
-								"cnrm.cloud.google.com/state-into-spec": "merge"
-							},
-							"creationTimestamp": "2021-11-23T14:48:03Z",
-							"generation": 1,
-							"name": "my-project-gsa",
-							"namespace": "my-project",
-							"resourceVersion": "54948113",
-							"selfLink": "/apis/iam.cnrm.cloud.google.com/v1beta1/namespaces/my-project/iamserviceaccounts/my-project-gsa",
-							"uid": "ec76cccc-6b23-4638-b2ee-7cd886cbc031"
-						},
-						"status": {
-							"conditions": [
-								{
-									"lastTransitionTime": "2021-11-23T14:48:03Z",
-									"status": "True",
-									"type": "Ready"
-								}
-							],
-							"observedGeneration": 1
-						}
-					}`),
-				modified: []byte(`{
-								"apiVersion": "iam.cnrm.cloud.google.com/v1beta1",
-								"kind": "IAMServiceAccount",
-								"metadata": {
-									"name": "my-project-gsa",
-									"namespace": "my-project",
-									"annotations": {
-										"cnrm.cloud.google.com/project-id": "my-new-project"
-									}
-								}
-							}`),
-				namespace: "my-project",
+func getSyncTestController(t *testing.T, templateDir string) (*controller, *dynamicfake.FakeDynamicClient) {
+	scheme := k8sruntime.NewScheme()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{configMapResource: "ConfigMapList"})
+	// The fake dynamic client doesn't expose its object tracker, so objects are tracked separately.
+	tracker := k8stesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())
+	dynamicClient.PrependReactor("*", "*", k8stesting.ObjectReaction(tracker))
+	dynamicClient.PrependReactor("patch", "*", applyPatchReactor(tracker))
+
+	applicationConfig := &runtimeMocks.MockApplicationProvider{}
+	applicationConfig.SetDomainsConfig(runtimeInterfaces.DomainsConfig{{ID: "dev", Name: "dev"}})
+	namespaceMappingConfig := &runtimeMocks.NamespaceMappingConfiguration{}
+	namespaceMappingConfig.OnGetNamespaceTemplate().Return("{{ project }}-{{ domain }}")
+	config := runtimeMocks.NewMockConfigurationProvider(applicationConfig, nil, nil, nil, nil, namespaceMappingConfig)
+	config.(*runtimeMocks.MockConfigurationProvider).AddClusterResourceConfiguration(
+		runtimeMocks.MockClusterResourceConfiguration{TemplatePath: templateDir})
+	mockRepository := repositoryMocks.NewMockRepository()
+	mockRepository.ProjectRepo().(*repositoryMocks.MockProjectRepo).ListProjectsFunction = func(
+		ctx context.Context, input interfaces.ListResourceInput) ([]models.Project, error) {
+		return []models.Project{{Identifier: "my-project"}}, nil
+	}
+	executionCluster := mocks.MockCluster{}
+	executionCluster.SetGetAllValidTargetsCallback(func() []executioncluster.ExecutionTarget {
+		return []executioncluster.ExecutionTarget{
+			{
+				ID:            "cluster",
+				DynamicClient: dynamicClient,
 			},
-			want:          []byte(`{"metadata":{"annotations":{"cnrm.cloud.google.com/project-id":"my-new-project"},"resourceVersion":"54948113"}}`),
-			wantPatchType: types.MergePatchType,
-			wantErr:       false,
+		}
+	})
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	return &controller{
+		db:               mockRepository,
+		config:           config,
+		executionCluster: &executionCluster,
+		resourceManager:  resources.NewResourceManager(mockRepository, config),
+		metrics:          newMetrics(mockScope.NewTestScope()),
+		appliedTemplates: make(NamespaceCache),
+		getRESTMapper: func(target executioncluster.ExecutionTarget) (meta.RESTMapper, error) {
+			return mapper, nil
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			currentObj, _, err := unstructured.UnstructuredJSONScheme.Decode(tt.args.current, nil, nil)
-			assert.NoError(t, err)
+	}, dynamicClient
+}
 
-			c := &controller{}
-			got, gotPatchType, err := c.createPatch(tt.args.gvk, currentObj.(*unstructured.Unstructured), tt.args.modified, tt.args.namespace)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("createPatch() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
+func getSyncTestTemplateDir(t *testing.T) string {
+	templateDir, err := ioutil.TempDir("", "cluster-resource-templates")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(templateDir, "config.yaml"), []byte(configMapTemplate), 0600)
+	assert.NoError(t, err)
+	return templateDir
+}
 
-			assert.Equal(t, string(tt.want), string(got))
-			assert.Equal(t, string(tt.wantPatchType), string(gotPatchType))
-		})
+func syncTestNamespace(t *testing.T, c *controller) {
+	err := c.syncNamespace(context.Background(), models.Project{Identifier: "my-project"},
+		runtimeInterfaces.Domain{ID: "dev", Name: "dev"}, "my-project-dev", templateValuesType{}, templateValuesType{})
+	assert.NoError(t, err)
+}
+
+func TestSyncNamespace_AppliesTemplates(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+
+	syncTestNamespace(t, c)
+	configMap, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "config", configMap.GetLabels()[templateOwnerLabel])
+	project, _, _ := unstructured.NestedString(configMap.Object, "data", "project")
+	assert.Equal(t, "my-project", project)
+}
+
+func TestSyncNamespace_PreservesForeignFields(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("v1")
+	existing.SetKind("ConfigMap")
+	existing.SetName("flyte-config")
+	existing.SetNamespace("my-project-dev")
+	existing.SetLabels(map[string]string{
+		"istio-injection": "enabled",
+	})
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "old-project", "data", "project"))
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "foreign", "data", "other"))
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Create(
+		context.Background(), existing, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	syncTestNamespace(t, c)
+	configMap, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"istio-injection":  "enabled",
+		templateOwnerLabel: "config",
+	}, configMap.GetLabels())
+	assert.Equal(t, map[string]interface{}{
+		"project": "my-project",
+		"other":   "foreign",
+	}, configMap.Object["data"])
+}
+
+func TestSyncNamespace_PrunesRemovedTemplates(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+
+	syncTestNamespace(t, c)
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.Remove(filepath.Join(templateDir, "config.yaml")))
+	syncTestNamespace(t, c)
+	_, err = dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Empty(t, c.appliedTemplates["my-project-dev"])
+}
+
+func TestSyncNamespace_PrunesTemplatesRemovedWhileStopped(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	syncTestNamespace(t, c)
+
+	// The controller restarts, forgetting what it applied, after the template was removed.
+	assert.NoError(t, os.Remove(filepath.Join(templateDir, "config.yaml")))
+	c.appliedTemplates = make(NamespaceCache)
+	c.appliedKinds = nil
+	c.prunedTemplates = nil
+	syncTestNamespace(t, c)
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestSyncNamespace_KeepsUnmanagedResources(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	unmanaged := &unstructured.Unstructured{}
+	unmanaged.SetAPIVersion("v1")
+	unmanaged.SetKind("ConfigMap")
+	unmanaged.SetName("unmanaged")
+	unmanaged.SetNamespace("my-project-dev")
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Create(
+		context.Background(), unmanaged, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.Remove(filepath.Join(templateDir, "config.yaml")))
+	syncTestNamespace(t, c)
+	_, err = dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "unmanaged", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestSyncNamespace_KeepsReclaimedResources(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	syncTestNamespace(t, c)
+
+	// The resource moved to another template, which owns it now.
+	assert.NoError(t, os.Remove(filepath.Join(templateDir, "config.yaml")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(templateDir, "renamed-config.yaml"), []byte(configMapTemplate), 0600))
+	syncTestNamespace(t, c)
+	configMap, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed-config", configMap.GetLabels()[templateOwnerLabel])
+}
+
+func TestSync_PublishesTemplateOutcomes(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+
+	assert.NoError(t, c.Sync(context.Background()))
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.metrics.TemplatesApplied.WithLabelValues("cluster")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.TemplatesFailed.WithLabelValues("cluster")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.TemplatesSkipped.WithLabelValues("cluster")))
+	lastSuccessfulSync := testutil.ToFloat64(c.metrics.LastSuccessfulSync)
+	assert.InDelta(t, float64(time.Now().Unix()), lastSuccessfulSync, 60)
+
+	// The template is unchanged, so the next sync skips it.
+	assert.NoError(t, c.Sync(context.Background()))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.TemplatesApplied.WithLabelValues("cluster")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.metrics.TemplatesSkipped.WithLabelValues("cluster")))
+}
+
+func TestSync_FailedTemplates(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(configMapResource.GroupResource(), "flyte-config",
+			errors.New("flyteadmin can't patch configmaps"))
+	})
+
+	err := c.Sync(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "for namespace [my-project-dev] in cluster [cluster]")
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.TemplatesApplied.WithLabelValues("cluster")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.metrics.TemplatesFailed.WithLabelValues("cluster")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.LastSuccessfulSync))
+	// Failed templates are applied again by the next sync.
+	assert.Empty(t, c.appliedTemplates["my-project-dev"])
+}
+
+func TestSync_Panic(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, _ := getSyncTestController(t, templateDir)
+	c.db.ProjectRepo().(*repositoryMocks.MockProjectRepo).ListProjectsFunction = func(
+		ctx context.Context, input interfaces.ListResourceInput) ([]models.Project, error) {
+		panic("database unreachable")
 	}
+
+	err := c.Sync(context.Background())
+	assert.EqualError(t, err, "caught panic while syncing cluster resources: database unreachable")
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.metrics.Panics))
+}
+
+func TestDryRun_Create(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, c.DryRun(context.Background(), out))
+	assert.Equal(t, `create ConfigMap [flyte-config] for namespace [my-project-dev] in cluster [cluster]
+apiVersion: v1
+data:
+  project: my-project
+kind: ConfigMap
+metadata:
+  labels:
+    flyte.org/cluster-resource-template: config
+  name: flyte-config
+  namespace: my-project-dev
+
+`, out.String())
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Empty(t, c.appliedTemplates["my-project-dev"])
+	assert.Equal(t, float64(0), testutil.ToFloat64(c.metrics.LastSuccessfulSync))
+}
+
+func TestDryRun_Update(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("v1")
+	existing.SetKind("ConfigMap")
+	existing.SetName("flyte-config")
+	existing.SetNamespace("my-project-dev")
+	existing.SetResourceVersion("42")
+	assert.NoError(t, unstructured.SetNestedField(existing.Object, "old-project", "data", "project"))
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Create(
+		context.Background(), existing, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, c.DryRun(context.Background(), out))
+	assert.Equal(t, `update ConfigMap [flyte-config] for namespace [my-project-dev] in cluster [cluster]
+--- current
++++ applied
+@@ -1,7 +1,9 @@
+ apiVersion: v1
+ data:
+-  project: old-project
++  project: my-project
+ kind: ConfigMap
+ metadata:
++  labels:
++    flyte.org/cluster-resource-template: config
+   name: flyte-config
+   namespace: my-project-dev
+
+`, out.String())
+	current, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	project, _, _ := unstructured.NestedString(current.Object, "data", "project")
+	assert.Equal(t, "old-project", project)
+
+	// Once applied, nothing changes.
+	assert.NoError(t, c.Sync(context.Background()))
+	out.Reset()
+	assert.NoError(t, c.DryRun(context.Background(), out))
+	assert.Equal(t, "unchanged ConfigMap [flyte-config] for namespace [my-project-dev] in cluster [cluster]\n\n",
+		out.String())
+}
+
+func TestRun(t *testing.T) {
+	templateDir := getSyncTestTemplateDir(t)
+	defer os.RemoveAll(templateDir)
+	c, dynamicClient := getSyncTestController(t, templateDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+	// The first sync applies the template, and those after it skip it.
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(c.metrics.SyncStarted) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	_, err := dynamicClient.Resource(configMapResource).Namespace("my-project-dev").Get(
+		context.Background(), "flyte-config", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.metrics.TemplatesSkipped.WithLabelValues("cluster")))
 }
@@ -8,5 +8,6 @@ const (
 	AWS   CloudProvider = "aws"
 	GCP   CloudProvider = "gcp"
 	Local CloudProvider = "local"
+	Kafka CloudProvider = "kafka"
 	None  CloudProvider = "none"
 )
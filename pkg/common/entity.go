@@ -17,6 +17,8 @@ const (
 	NamedEntity         = "nen"
 	NamedEntityMetadata = "nem"
 	Project             = "p"
+	DescriptionEntity   = "d"
+	ExecutionTag        = "et"
 )
 
 // ResourceTypeToEntity maps a resource type to an entity suitable for use with Database filters
@@ -2,6 +2,7 @@ package common
 
 import (
 	"math/rand"
+	"strings"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
@@ -12,6 +13,19 @@ const ExecutionIDLength = 10
 const AllowedExecutionIDStartCharStr = "abcdefghijklmnopqrstuvwxyz"
 const AllowedExecutionIDStr = "abcdefghijklmnopqrstuvwxyz1234567890"
 
+// The annotation reporting the execution queue the tasks of an execution were assigned to on its spec. It's only ever
+// set by admin, so it's dropped from the annotations executions are requested with.
+const ExecutionQueueAnnotation = "flyte.org/execution-queue"
+
+// The annotation an execution requests to be created in one of the clusters mapped to a cluster label with, taking
+// precedence over the cluster label attributes of its project, domain, workflow and launch plan.
+const ExecutionClusterLabelAnnotation = "flyte.org/execution-cluster-label"
+
+// The prefix of the annotations executions and launch plans, and of the cluster resource attributes projects and
+// domains, set environment variables for the tasks of an execution with, e.g. env.flyte.org/EXPERIMENT_ID. The workflow
+// execution config has no field for them yet.
+const ExecutionEnvAnnotationPrefix = "env.flyte.org/"
+
 var AllowedExecutionIDStartChars = []rune(AllowedExecutionIDStartCharStr)
 var AllowedExecutionIDChars = []rune(AllowedExecutionIDStr)
 
@@ -33,6 +47,12 @@ var terminalExecutionPhases = map[core.WorkflowExecution_Phase]bool{
 	core.WorkflowExecution_ABORTED:   true,
 }
 
+// Executions that were aborted were stopped on purpose and those that succeeded have nothing left to recover.
+var recoverableExecutionPhases = map[core.WorkflowExecution_Phase]bool{
+	core.WorkflowExecution_FAILED:    true,
+	core.WorkflowExecution_TIMED_OUT: true,
+}
+
 var terminalNodeExecutionPhases = map[core.NodeExecution_Phase]bool{
 	core.NodeExecution_SUCCEEDED: true,
 	core.NodeExecution_FAILED:    true,
@@ -52,6 +72,32 @@ func IsExecutionTerminal(phase core.WorkflowExecution_Phase) bool {
 	return terminalExecutionPhases[phase]
 }
 
+// Returns the names of the phases of executions which haven't terminated, in the order the phases are declared.
+func GetNonTerminalExecutionPhases() []string {
+	phases := make([]string, 0, len(core.WorkflowExecution_Phase_name))
+	for value := int32(0); value < int32(len(core.WorkflowExecution_Phase_name)); value++ {
+		if phase := core.WorkflowExecution_Phase(value); !IsExecutionTerminal(phase) {
+			phases = append(phases, phase.String())
+		}
+	}
+	return phases
+}
+
+// Returns the names of the phases of executions which have terminated, in the order the phases are declared.
+func GetTerminalExecutionPhases() []string {
+	phases := make([]string, 0, len(terminalExecutionPhases))
+	for value := int32(0); value < int32(len(core.WorkflowExecution_Phase_name)); value++ {
+		if phase := core.WorkflowExecution_Phase(value); IsExecutionTerminal(phase) {
+			phases = append(phases, phase.String())
+		}
+	}
+	return phases
+}
+
+func IsExecutionRecoverable(phase core.WorkflowExecution_Phase) bool {
+	return recoverableExecutionPhases[phase]
+}
+
 func IsNodeExecutionTerminal(phase core.NodeExecution_Phase) bool {
 	return terminalNodeExecutionPhases[phase]
 }
@@ -59,3 +105,37 @@ func IsNodeExecutionTerminal(phase core.NodeExecution_Phase) bool {
 func IsTaskExecutionTerminal(phase core.TaskExecution_Phase) bool {
 	return terminalTaskExecutionPhases[phase]
 }
+
+// Returns the environment variables set by the annotations given, keyed by their name.
+func GetExecutionEnvs(annotations map[string]string) map[string]string {
+	envs := make(map[string]string)
+	for key, value := range annotations {
+		if strings.HasPrefix(key, ExecutionEnvAnnotationPrefix) {
+			envs[strings.TrimPrefix(key, ExecutionEnvAnnotationPrefix)] = value
+		}
+	}
+	return envs
+}
+
+// Returns a copy of the annotations given without those setting environment variables.
+func WithoutExecutionEnvAnnotations(annotations map[string]string) map[string]string {
+	filtered := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, ExecutionEnvAnnotationPrefix) {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// Returns a copy of the annotations given without the keys given.
+func WithoutAnnotations(annotations map[string]string, keys ...string) map[string]string {
+	filtered := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		filtered[key] = value
+	}
+	for _, key := range keys {
+		delete(filtered, key)
+	}
+	return filtered
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,3 +16,36 @@ func TestGetExecutionName(t *testing.T) {
 		assert.Contains(t, AllowedExecutionIDChars, rune(randString[i]))
 	}
 }
+
+func TestIsExecutionRecoverable(t *testing.T) {
+	assert.True(t, IsExecutionRecoverable(core.WorkflowExecution_FAILED))
+	assert.True(t, IsExecutionRecoverable(core.WorkflowExecution_TIMED_OUT))
+	assert.False(t, IsExecutionRecoverable(core.WorkflowExecution_SUCCEEDED))
+	assert.False(t, IsExecutionRecoverable(core.WorkflowExecution_ABORTED))
+	assert.False(t, IsExecutionRecoverable(core.WorkflowExecution_RUNNING))
+}
+
+func TestGetNonTerminalExecutionPhases(t *testing.T) {
+	assert.Equal(t, []string{"UNDEFINED", "QUEUED", "RUNNING", "SUCCEEDING", "FAILING"},
+		GetNonTerminalExecutionPhases())
+}
+
+func TestGetTerminalExecutionPhases(t *testing.T) {
+	assert.Equal(t, []string{"SUCCEEDED", "FAILED", "ABORTED", "TIMED_OUT"}, GetTerminalExecutionPhases())
+}
+
+func TestExecutionEnvAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"annotation":          "value",
+		"env.flyte.org/FLAG":  "on",
+		"env.flyte.org/DEBUG": "",
+	}
+	assert.Equal(t, map[string]string{
+		"FLAG":  "on",
+		"DEBUG": "",
+	}, GetExecutionEnvs(annotations))
+	assert.Equal(t, map[string]string{
+		"annotation": "value",
+	}, WithoutExecutionEnvAnnotations(annotations))
+	assert.Empty(t, GetExecutionEnvs(nil))
+}
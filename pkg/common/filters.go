@@ -4,6 +4,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -84,6 +85,9 @@ var entityMetadataFields = map[string]bool{
 	"state":       true,
 }
 
+// Longer contains() values are rejected, matching them makes for expensive LIKE patterns.
+const maxContainsValueLength = 255
+
 const unrecognizedFilterFunction = "unrecognized filter function: %s"
 const unsupportedFilterExpression = "unsupported filter expression: %s"
 const invalidSingleValueFilter = "invalid single value filter expression: %s"
@@ -254,6 +258,10 @@ func NewSingleValueFilter(entity Entity, function FilterExpression, field string
 	if _, ok := singleValueFilters[function]; !ok {
 		return nil, GetInvalidSingleValueFilterErr(function)
 	}
+	if stringValue, ok := value.(string); ok && function == Contains && len(stringValue) > maxContainsValueLength {
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"contains filter value for %s exceeds the limit of %d characters", field, maxContainsValueLength)
+	}
 	customizedField := customizeField(field, entity)
 	customizedEntity := customizeEntity(field, entity)
 	return &inlineFilterImpl{
@@ -338,3 +346,48 @@ func NewWithDefaultValueFilter(defaultValue interface{}, filter InlineFilter) (I
 		defaultValue:     defaultValue,
 	}, nil
 }
+
+const queryCaseInsensitiveFmt = "LOWER(%s)"
+
+type caseInsensitiveFilter struct {
+	inlineFilterImpl
+}
+
+func (f *caseInsensitiveFilter) GetGormQueryExpr() (GormQueryExpr, error) {
+	return f.getGormQueryExpr(fmt.Sprintf(queryCaseInsensitiveFmt, f.GetField()))
+}
+
+func (f *caseInsensitiveFilter) GetGormJoinTableQueryExpr(tableName string) (GormQueryExpr, error) {
+	return f.getGormQueryExpr(fmt.Sprintf(queryCaseInsensitiveFmt, fmt.Sprintf(joinArgsFormat, tableName, f.GetField())))
+}
+
+func toLower(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case string:
+		return strings.ToLower(typedValue)
+	case []interface{}:
+		loweredValues := make([]interface{}, len(typedValue))
+		for idx, repeatedValue := range typedValue {
+			loweredValues[idx] = toLower(repeatedValue)
+		}
+		return loweredValues
+	default:
+		return value
+	}
+}
+
+// Returns a filter matching string values of the column regardless of their case.
+func NewCaseInsensitiveFilter(filter InlineFilter) (InlineFilter, error) {
+	inlineFilter, ok := filter.(*inlineFilterImpl)
+	if !ok {
+		return nil, errors.NewFlyteAdminErrorf(codes.Internal,
+			"Unable to create case insensitive filter for [%s] because the system encountered an unknown filter type",
+			filter.GetField())
+	}
+	caseInsensitive := &caseInsensitiveFilter{
+		inlineFilterImpl: *inlineFilter,
+	}
+	caseInsensitive.value = toLower(inlineFilter.value)
+	caseInsensitive.repeatedValue = toLower(inlineFilter.repeatedValue)
+	return caseInsensitive, nil
+}
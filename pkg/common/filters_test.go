@@ -1,6 +1,7 @@
 package common
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -166,3 +167,38 @@ func TestWithDefaultValueFilter(t *testing.T) {
 	assert.Equal(t, "COALESCE(named_entity_metadata.state, 0) = ?", queryExpression.Query)
 	assert.Equal(t, 1, queryExpression.Args)
 }
+
+func TestNewSingleValueFilter_ContainsValueLength(t *testing.T) {
+	_, err := NewSingleValueFilter(Execution, Contains, "error_message", strings.Repeat("a", maxContainsValueLength))
+	assert.NoError(t, err)
+
+	_, err = NewSingleValueFilter(Execution, Contains, "error_message", strings.Repeat("a", maxContainsValueLength+1))
+	assert.EqualError(t, err, "contains filter value for error_message exceeds the limit of 255 characters")
+}
+
+func TestCaseInsensitiveFilter(t *testing.T) {
+	filter, err := NewSingleValueFilter(Execution, Contains, "error_message", "OOMKilled")
+	assert.NoError(t, err)
+
+	caseInsensitiveFilter, err := NewCaseInsensitiveFilter(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, Execution, caseInsensitiveFilter.GetEntity())
+
+	queryExpression, err := caseInsensitiveFilter.GetGormQueryExpr()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOWER(error_message) LIKE ?", queryExpression.Query)
+	assert.Equal(t, "%oomkilled%", queryExpression.Args)
+
+	queryExpression, err = caseInsensitiveFilter.GetGormJoinTableQueryExpr("executions")
+	assert.NoError(t, err)
+	assert.Equal(t, "LOWER(executions.error_message) LIKE ?", queryExpression.Query)
+
+	filter, err = NewRepeatedValueFilter(Execution, ValueIn, "error_kind", []interface{}{"USER", "System"})
+	assert.NoError(t, err)
+	caseInsensitiveFilter, err = NewCaseInsensitiveFilter(filter)
+	assert.NoError(t, err)
+	queryExpression, err = caseInsensitiveFilter.GetGormQueryExpr()
+	assert.NoError(t, err)
+	assert.Equal(t, "LOWER(error_kind) in (?)", queryExpression.Query)
+	assert.Equal(t, []interface{}{"user", "system"}, queryExpression.Args)
+}
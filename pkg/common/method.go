@@ -0,0 +1,12 @@
+package common
+
+import "strings"
+
+// Returns the method name without the fully qualified service prefix, e.g. CreateExecution for
+// /flyteidl.service.AdminService/CreateExecution.
+func ShortMethodName(fullMethod string) string {
+	if idx := strings.LastIndex(fullMethod, "/"); idx >= 0 {
+		return fullMethod[idx+1:]
+	}
+	return fullMethod
+}
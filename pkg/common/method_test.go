@@ -0,0 +1,12 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortMethodName(t *testing.T) {
+	assert.Equal(t, "CreateExecution", ShortMethodName("/flyteidl.service.AdminService/CreateExecution"))
+	assert.Equal(t, "CreateExecution", ShortMethodName("CreateExecution"))
+}
@@ -1,19 +1,61 @@
 package common
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 )
 
-const projectTemplate = "{{ project }}"
-const domainTemplate = "{{ domain }}"
+const (
+	projectVariable     = "project"
+	domainVariable      = "domain"
+	labelVariablePrefix = "labels."
+)
 
-const replaceAllInstancesOfString = -1
+// Matches template variables such as {{ project }}, {{domain}} or {{ labels.team }}.
+var namespaceVariableRegex = regexp.MustCompile(`{{\s*([^{}\s]*)\s*}}`)
 
-// GetNamespaceName returns kubernetes namespace name according to user defined template from config
-func GetNamespaceName(template string, project, domain string) string {
-	var namespace = template
-	namespace = strings.Replace(namespace, projectTemplate, project, replaceAllInstancesOfString)
-	namespace = strings.Replace(namespace, domainTemplate, domain, replaceAllInstancesOfString)
+// ValidateNamespaceTemplate checks that a namespace template only refers to the project, the domain and project labels.
+func ValidateNamespaceTemplate(template string) error {
+	for _, match := range namespaceVariableRegex.FindAllStringSubmatch(template, -1) {
+		variable := match[1]
+		if variable == projectVariable || variable == domainVariable {
+			continue
+		}
+		if strings.HasPrefix(variable, labelVariablePrefix) && len(variable) > len(labelVariablePrefix) {
+			continue
+		}
+		return fmt.Errorf("unknown variable [%s] in namespace template %s", match[0], template)
+	}
+	if remainder := namespaceVariableRegex.ReplaceAllString(template, ""); strings.Contains(remainder, "{{") ||
+		strings.Contains(remainder, "}}") {
+		return fmt.Errorf("malformed variable in namespace template %s", template)
+	}
+	return nil
+}
 
-	return namespace
+// GetNamespaceName returns kubernetes namespace name according to user defined template from config. Project labels
+// referenced by the template must be set.
+func GetNamespaceName(template string, project, domain string, labels map[string]string) (string, error) {
+	var missingLabel string
+	namespace := namespaceVariableRegex.ReplaceAllStringFunc(template, func(match string) string {
+		variable := namespaceVariableRegex.FindStringSubmatch(match)[1]
+		switch variable {
+		case projectVariable:
+			return project
+		case domainVariable:
+			return domain
+		}
+		label := strings.TrimPrefix(variable, labelVariablePrefix)
+		value, ok := labels[label]
+		if !ok && missingLabel == "" {
+			missingLabel = label
+		}
+		return value
+	})
+	if missingLabel != "" {
+		return "", fmt.Errorf("project %s has no label [%s] required by namespace template %s",
+			project, missingLabel, template)
+	}
+	return namespace, nil
 }
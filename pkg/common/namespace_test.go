@@ -7,6 +7,9 @@ import (
 )
 
 func TestGetNamespaceName(t *testing.T) {
+	labels := map[string]string{
+		"team": "ml",
+	}
 	testCases := []struct {
 		template string
 		project  string
@@ -16,10 +19,36 @@ func TestGetNamespaceName(t *testing.T) {
 		{"prefix-{{ project }}-{{ domain }}", "flytesnacks", "production", "prefix-flytesnacks-production"},
 		{"{{ domain }}", "flytesnacks", "production", "production"},
 		{"{{ project }}", "flytesnacks", "production", "flytesnacks"},
+		{"{{project}}-flyte", "flytesnacks", "production", "flytesnacks-flyte"},
+		{"{{ labels.team }}-{{ domain }}", "flytesnacks", "production", "ml-production"},
 	}
 
 	for _, tc := range testCases {
-		got := GetNamespaceName(tc.template, tc.project, tc.domain)
+		got, err := GetNamespaceName(tc.template, tc.project, tc.domain, labels)
+		assert.NoError(t, err)
 		assert.Equal(t, got, tc.want)
 	}
 }
+
+func TestGetNamespaceName_MissingLabel(t *testing.T) {
+	_, err := GetNamespaceName("{{ labels.team }}-{{ domain }}", "flytesnacks", "production", nil)
+	assert.EqualError(t, err,
+		"project flytesnacks has no label [team] required by namespace template {{ labels.team }}-{{ domain }}")
+}
+
+func TestValidateNamespaceTemplate(t *testing.T) {
+	for _, template := range []string{
+		"{{ project }}-{{ domain }}",
+		"{{project}}-flyte",
+		"ml-prod",
+		"{{ labels.team }}-{{ domain }}",
+	} {
+		assert.NoError(t, ValidateNamespaceTemplate(template), template)
+	}
+	assert.EqualError(t, ValidateNamespaceTemplate("{{ project }}-{{ team }}"),
+		"unknown variable [{{ team }}] in namespace template {{ project }}-{{ team }}")
+	assert.EqualError(t, ValidateNamespaceTemplate("{{ labels. }}"),
+		"unknown variable [{{ labels. }}] in namespace template {{ labels. }}")
+	assert.EqualError(t, ValidateNamespaceTemplate("{{ project }-{{ domain }}"),
+		"malformed variable in namespace template {{ project }-{{ domain }}")
+}
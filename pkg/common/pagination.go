@@ -0,0 +1,56 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"google.golang.org/grpc/codes"
+)
+
+// Sorting by this key opts into keyset pagination: rows are ordered by their creation time and then their id, and page
+// tokens point past the last row returned rather than counting the rows to skip. Deep pages are then as fast to fetch
+// as the first one.
+const KeysetSortKey = "created_at,id"
+
+// The last row of a keyset paginated page, which the next page continues from.
+type KeysetCursor struct {
+	Direction admin.Sort_Direction `json:"direction"`
+	CreatedAt time.Time            `json:"createdAt"`
+	ID        uint                 `json:"id"`
+}
+
+func IsKeysetSort(sort *admin.Sort) bool {
+	return sort != nil && sort.Key == KeysetSortKey
+}
+
+// Encodes a cursor as an opaque page token.
+func EncodeKeysetToken(cursor KeysetCursor) (string, error) {
+	serialized, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(serialized), nil
+}
+
+// Decodes a page token issued by EncodeKeysetToken for a list sorted by the keyset sort key in the given direction.
+func DecodeKeysetToken(token string, sort admin.Sort) (KeysetCursor, error) {
+	var cursor KeysetCursor
+	serialized, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return KeysetCursor{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "malformed keyset token: %v", err)
+	}
+	if err := json.Unmarshal(serialized, &cursor); err != nil {
+		return KeysetCursor{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "malformed keyset token: %v", err)
+	}
+	if cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return KeysetCursor{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "incomplete keyset token")
+	}
+	if sort.Key != KeysetSortKey || cursor.Direction != sort.Direction {
+		return KeysetCursor{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"keyset token was issued for a %s sort by %s, not for %v", cursor.Direction, KeysetSortKey, sort)
+	}
+	return cursor, nil
+}
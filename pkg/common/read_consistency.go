@@ -0,0 +1,20 @@
+package common
+
+import "context"
+
+type primaryReadsContextKey struct{}
+
+// Sends the reads made with the returned context to the primary database even when a read replica is configured,
+// for reads which can't tolerate replication lag such as those made while handling a write.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsContextKey{}, true)
+}
+
+// Whether reads made with the context must be served by the primary database.
+func ReadsFromPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	primaryReads, _ := ctx.Value(primaryReadsContextKey{}).(bool)
+	return primaryReads
+}
@@ -0,0 +1,28 @@
+package common
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+)
+
+type requestIDContextKey struct{}
+
+// Tags the returned context with the id correlating the work done for a request. Loggers include the id with the
+// messages logged using the context, as the job id since those are the fields loggers pick up from contexts.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if len(requestID) == 0 {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, requestIDContextKey{}, requestID)
+	return contextutils.WithJobID(ctx, requestID)
+}
+
+// Returns the id of the request the context was created for, if any.
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
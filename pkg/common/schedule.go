@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+// The launch plan annotation delaying the invocations of its schedule past their nominal times, by a duration such as
+// "90s" or "5m".
+const ScheduleOffsetAnnotation = "flyte.org/schedule-offset"
+
+// Returns the schedule offset requested by the annotations of a launch plan, zero in its absence.
+func GetScheduleOffset(annotations *admin.Annotations) (time.Duration, error) {
+	value, ok := annotations.GetValues()[ScheduleOffsetAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	offset, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation [%s]: %v", ScheduleOffsetAnnotation, value, err)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("invalid %s annotation [%s]: the offset can't be negative", ScheduleOffsetAnnotation,
+			value)
+	}
+	return offset, nil
+}
+
+// The launch plan annotation choosing what happens when its schedule fires while a previous scheduled execution is
+// still running, one of ALLOW, SKIP or REPLACE.
+const ScheduleOverlapPolicyAnnotation = "flyte.org/schedule-overlap-policy"
+
+// The launch plan annotations configuring its schedule, which aren't passed on to the executions it launches.
+var scheduleAnnotations = []string{ScheduleOffsetAnnotation, ScheduleOverlapPolicyAnnotation}
+
+// Returns the overlap policy requested by the annotations of a launch plan, the default policy given in its absence.
+func GetScheduleOverlapPolicy(annotations *admin.Annotations,
+	defaultPolicy runtimeInterfaces.ScheduleOverlapPolicy) (runtimeInterfaces.ScheduleOverlapPolicy, error) {
+	value, ok := annotations.GetValues()[ScheduleOverlapPolicyAnnotation]
+	if !ok {
+		return defaultPolicy, nil
+	}
+	policy := runtimeInterfaces.ScheduleOverlapPolicy(strings.ToUpper(value))
+	switch policy {
+	case runtimeInterfaces.ScheduleOverlapPolicyAllow, runtimeInterfaces.ScheduleOverlapPolicySkip,
+		runtimeInterfaces.ScheduleOverlapPolicyReplace:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid %s annotation [%s]: expected one of %s, %s or %s", ScheduleOverlapPolicyAnnotation,
+		value, runtimeInterfaces.ScheduleOverlapPolicyAllow, runtimeInterfaces.ScheduleOverlapPolicySkip,
+		runtimeInterfaces.ScheduleOverlapPolicyReplace)
+}
+
+// Returns the annotations of a launch plan without those configuring its schedule.
+func WithoutScheduleAnnotations(annotations map[string]string) map[string]string {
+	return WithoutAnnotations(annotations, scheduleAnnotations...)
+}
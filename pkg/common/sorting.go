@@ -13,9 +13,15 @@ const gormAscending = "%s asc"
 
 type SortParameter interface {
 	GetGormOrderExpr() string
+	// The key to sort by, as requested.
+	GetKey() string
+	// Sorts by the expression, in place of the key, in the requested direction. For keys which aren't columns.
+	GetGormOrderExprFor(expression string) string
 }
 
 type sortParamImpl struct {
+	key                 string
+	orderFormat         string
 	gormOrderExpression string
 }
 
@@ -23,17 +29,27 @@ func (s *sortParamImpl) GetGormOrderExpr() string {
 	return s.gormOrderExpression
 }
 
+func (s *sortParamImpl) GetKey() string {
+	return s.key
+}
+
+func (s *sortParamImpl) GetGormOrderExprFor(expression string) string {
+	return fmt.Sprintf(s.orderFormat, expression)
+}
+
 func NewSortParameter(sort admin.Sort) (SortParameter, error) {
-	var gormOrderExpression string
+	var orderFormat string
 	switch sort.Direction {
 	case admin.Sort_DESCENDING:
-		gormOrderExpression = fmt.Sprintf(gormDescending, sort.Key)
+		orderFormat = gormDescending
 	case admin.Sort_ASCENDING:
-		gormOrderExpression = fmt.Sprintf(gormAscending, sort.Key)
+		orderFormat = gormAscending
 	default:
 		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid sort order specified: %v", sort)
 	}
 	return &sortParamImpl{
-		gormOrderExpression: gormOrderExpression,
+		key:                 sort.Key,
+		orderFormat:         orderFormat,
+		gormOrderExpression: fmt.Sprintf(orderFormat, sort.Key),
 	}, nil
 }
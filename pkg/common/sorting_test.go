@@ -24,3 +24,13 @@ func TestSortParameter_Descending(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "project desc", sortParameter.GetGormOrderExpr())
 }
+
+func TestSortParameter_Expression(t *testing.T) {
+	sortParameter, err := NewSortParameter(admin.Sort{
+		Direction: admin.Sort_DESCENDING,
+		Key:       "duration",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "duration", sortParameter.GetKey())
+	assert.Equal(t, "(b - a) desc", sortParameter.GetGormOrderExprFor("(b - a)"))
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	authConfig "github.com/flyteorg/flyteadmin/auth/config"
 	"github.com/flyteorg/flytestdlib/config"
@@ -18,6 +19,21 @@ type ServerConfig struct {
 	KubeConfig           string                `json:"kube-config" pflag:",Path to kubernetes client config file."`
 	Master               string                `json:"master" pflag:",The address of the Kubernetes API server."`
 	Security             ServerSecurityOptions `json:"security"`
+	// Upper bound on how long in-flight requests are given to complete once a termination signal is received.
+	GracefulShutdownTimeout config.Duration `json:"gracefulShutdownTimeout" pflag:",Maximum time to wait for in-flight requests to drain on shutdown."`
+	// How long the healthcheck reports unavailability before the servers stop accepting connections on shutdown.
+	GracefulShutdownDrainDelay config.Duration  `json:"gracefulShutdownDrainDelay" pflag:",Time to keep accepting requests after failing the healthcheck on shutdown."`
+	RateLimit                  RateLimitOptions `json:"rateLimit"`
+	// When set, insecure deployments serve both gRPC and HTTP traffic on the http port.
+	SinglePort     bool                  `json:"singlePort" pflag:",Serve gRPC and HTTP traffic on the same port in insecure mode."`
+	Readiness      ReadinessOptions      `json:"readiness"`
+	RequestTimeout RequestTimeoutOptions `json:"requestTimeout"`
+	// Port serving pprof and Prometheus metrics, kept separate from the public API. Profiling is disabled when unset.
+	ProfilerPort int            `json:"profilerPort" pflag:",Port on which to serve pprof and metrics. Disabled when zero."`
+	OpenAPI      OpenAPIOptions `json:"openapi"`
+	Tracing      TracingOptions `json:"tracing"`
+	// How the http gateway marshals responses to JSON.
+	JSONMarshaling JSONMarshalingOptions `json:"jsonMarshaling"`
 
 	// Deprecated: please use auth.AppAuth.ThirdPartyConfig instead.
 	DeprecatedThirdPartyConfig authConfig.ThirdPartyConfigOptions `json:"thirdPartyConfig" pflag:",Deprecated please use auth.appAuth.thirdPartyConfig instead."`
@@ -28,27 +44,139 @@ type ServerSecurityOptions struct {
 	Ssl         SslOptions `json:"ssl"`
 	UseAuth     bool       `json:"useAuth"`
 	AuditAccess bool       `json:"auditAccess"`
+	// Admin service methods recorded in the audit log when AuditAccess is enabled. Methods may be given by their short
+	// (CreateExecution) or fully qualified name. When empty, all mutating admin service methods are audited.
+	AuditedMethods []string `json:"auditedMethods"`
 
 	// These options are here to allow deployments where the Flyte UI (Console) is served from a different domain/port.
 	// Note that CORS only applies to Admin's API endpoints. The health check endpoint for instance is unaffected.
 	// Please obviously evaluate security concerns before turning this on.
 	AllowCors bool `json:"allowCors"`
-	// Defines origins which are allowed to make CORS requests. This list should _not_ contain "*", as that
-	// will make CORS header responses incompatible with the `withCredentials=true` setting.
+	// Defines origins which are allowed to make CORS requests. Entries may use a wildcard in place of a subdomain,
+	// e.g. https://*.example.com, or be "*" to allow every origin. Listed origins are echoed back individually so that
+	// responses stay compatible with the `withCredentials=true` setting, origins only allowed by "*" never are and
+	// can't send credentials.
 	AllowedOrigins []string `json:"allowedOrigins"`
 	// These are the Access-Control-Request-Headers that the server will respond to.
 	// By default, the server will allow Accept, Accept-Language, Content-Language, and Content-Type.
 	// User this setting to add any additional headers which are needed
 	AllowedHeaders []string `json:"allowedHeaders"`
+	// Whether browsers may send credentials (e.g. the auth cookies) along with CORS requests.
+	AllowCredentials bool `json:"allowCredentials"`
+}
+
+// Token bucket limits applied to gRPC requests, keyed by method and caller. The caller is the authenticated user (or
+// app) when auth is enabled and the peer address otherwise.
+type RateLimitOptions struct {
+	Enabled bool `json:"enabled" pflag:",Enable per-caller rate limiting of gRPC requests."`
+	// Default limit for read-only (Get* and List*) methods.
+	Read RateLimitSpec `json:"read"`
+	// Default limit for all remaining (mutating) methods.
+	Write RateLimitSpec `json:"write"`
+	// Per method overrides keyed by short (ListExecutions) or fully qualified method name.
+	Methods map[string]RateLimitSpec `json:"methods" pflag:"-,Per method rate limit overrides."`
+	// Methods which are never throttled, e.g. health checks. Accepts short or fully qualified method names.
+	ExemptMethods []string `json:"exemptMethods" pflag:",Methods which are never rate limited."`
+}
+
+type RateLimitSpec struct {
+	// Steady state rate at which tokens are added to a caller's bucket.
+	RequestsPerSecond float64 `json:"requestsPerSecond" pflag:",Sustained requests per second allowed per caller."`
+	// Maximum number of requests a caller can issue at once. Defaults to one second's worth of requests when unset.
+	Burst int `json:"burst" pflag:",Maximum burst of requests allowed per caller."`
+}
+
+// Configures the dependencies verified by the /ready endpoint.
+type ReadinessOptions struct {
+	Checks        []string        `json:"checks" pflag:",Dependencies verified by the readiness endpoint, any of database and cluster."`
+	Timeout       config.Duration `json:"timeout" pflag:",Maximum time allowed for the readiness checks to complete."`
+	CacheDuration config.Duration `json:"cacheDuration" pflag:",Duration for which readiness results are reused between probes."`
+}
+
+// Server side deadlines applied to gRPC requests. A client deadline still applies when it is shorter.
+type RequestTimeoutOptions struct {
+	Default config.Duration `json:"default" pflag:",Deadline applied to requests without a method specific timeout. Disabled when zero."`
+	// Per method timeouts keyed by short (CreateExecution) or fully qualified method name.
+	Methods map[string]config.Duration `json:"methods" pflag:"-,Per method request timeouts."`
+}
+
+type OpenAPIOptions struct {
+	// When set, the served spec's host, basePath and schemes are rewritten to point at this uri.
+	PublicURI string `json:"publicUri" pflag:",Public http uri of the service advertised in the served OpenAPI spec."`
+	EnableUI  bool   `json:"enableUi" pflag:",Serve a Swagger UI page for the OpenAPI spec under /api/v1/openapi/ui."`
+}
+
+// Traces requests with OpenTelemetry, along with the database queries and execution cluster calls made serving them.
+// Traces started by callers are continued from the trace context in the request metadata.
+type TracingOptions struct {
+	Enabled bool `json:"enabled" pflag:",Export traces of the requests served. Tracing is a no-op otherwise."`
+	// The host and port of the OTLP gRPC collector spans are exported to.
+	ExporterEndpoint string `json:"exporterEndpoint" pflag:",Host and port of the OTLP gRPC collector to export spans to."`
+	Insecure         bool   `json:"insecure" pflag:",Connect to the collector without TLS."`
+	// Traces continued from callers keep their caller's sampling decision.
+	SamplerRatio float64 `json:"samplerRatio" pflag:",Share of new traces sampled, between 0 and 1."`
+	ServiceName  string  `json:"serviceName" pflag:",Service name spans are reported under."`
+}
+
+// Configures the JSON marshaling of the http gateway. The zero value marshals fields by their proto (snake_case) names,
+// enums by their names, and omits fields with zero values, as the gateway always has.
+type JSONMarshalingOptions struct {
+	UseCamelCase bool `json:"useCamelCase" pflag:",Marshal fields by their lowerCamelCase JSON names rather than their proto names."`
+	EnumsAsInts  bool `json:"enumsAsInts" pflag:",Marshal enums by their numbers rather than their names."`
+	EmitDefaults bool `json:"emitDefaults" pflag:",Marshal fields with zero values rather than omitting them."`
 }
 
 type SslOptions struct {
 	CertificateFile string `json:"certificateFile"`
 	KeyFile         string `json:"keyFile"`
+	// How often the certificate and key files are checked for changes, e.g. after a rotation.
+	ReloadInterval config.Duration `json:"reloadInterval"`
 }
 
 var defaultServerConfig = &ServerConfig{
-	Security: ServerSecurityOptions{},
+	Security: ServerSecurityOptions{
+		Ssl: SslOptions{
+			ReloadInterval: config.Duration{
+				Duration: time.Minute,
+			},
+		},
+		AllowCredentials: true,
+	},
+	GracefulShutdownTimeout: config.Duration{
+		Duration: 30 * time.Second,
+	},
+	GracefulShutdownDrainDelay: config.Duration{
+		Duration: 5 * time.Second,
+	},
+	Readiness: ReadinessOptions{
+		Checks: []string{"database"},
+		Timeout: config.Duration{
+			Duration: 2 * time.Second,
+		},
+		CacheDuration: config.Duration{
+			Duration: time.Second,
+		},
+	},
+	Tracing: TracingOptions{
+		SamplerRatio: 1,
+		ServiceName:  "flyteadmin",
+	},
+	RateLimit: RateLimitOptions{
+		Read: RateLimitSpec{
+			RequestsPerSecond: 100,
+			Burst:             200,
+		},
+		Write: RateLimitSpec{
+			RequestsPerSecond: 20,
+			Burst:             40,
+		},
+		ExemptMethods: []string{
+			"/grpc.health.v1.Health/Check",
+			"GetVersion",
+			"GetOAuth2Metadata",
+			"GetPublicClientConfig",
+		},
+	},
 }
 var serverConfig = config.MustRegisterSection(SectionKey, defaultServerConfig)
 
@@ -58,11 +58,38 @@ func (cfg ServerConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "security.secure"), defaultServerConfig.Security.Secure, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "security.ssl.certificateFile"), defaultServerConfig.Security.Ssl.CertificateFile, "")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "security.ssl.keyFile"), defaultServerConfig.Security.Ssl.KeyFile, "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "security.ssl.reloadInterval"), defaultServerConfig.Security.Ssl.ReloadInterval.String(), "")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "security.useAuth"), defaultServerConfig.Security.UseAuth, "")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "security.auditAccess"), defaultServerConfig.Security.AuditAccess, "")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "security.auditedMethods"), []string{}, "")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "security.allowCors"), defaultServerConfig.Security.AllowCors, "")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "security.allowedOrigins"), []string{}, "")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "security.allowedHeaders"), []string{}, "")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "security.allowCredentials"), defaultServerConfig.Security.AllowCredentials, "")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "gracefulShutdownTimeout"), defaultServerConfig.GracefulShutdownTimeout.String(), "Maximum time to wait for in-flight requests to drain on shutdown.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "gracefulShutdownDrainDelay"), defaultServerConfig.GracefulShutdownDrainDelay.String(), "Time to keep accepting requests after failing the healthcheck on shutdown.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "rateLimit.enabled"), defaultServerConfig.RateLimit.Enabled, "Enable per-caller rate limiting of gRPC requests.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "rateLimit.read.requestsPerSecond"), defaultServerConfig.RateLimit.Read.RequestsPerSecond, "Sustained requests per second allowed per caller.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "rateLimit.read.burst"), defaultServerConfig.RateLimit.Read.Burst, "Maximum burst of requests allowed per caller.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "rateLimit.write.requestsPerSecond"), defaultServerConfig.RateLimit.Write.RequestsPerSecond, "Sustained requests per second allowed per caller.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "rateLimit.write.burst"), defaultServerConfig.RateLimit.Write.Burst, "Maximum burst of requests allowed per caller.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "rateLimit.exemptMethods"), defaultServerConfig.RateLimit.ExemptMethods, "Methods which are never rate limited.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "singlePort"), defaultServerConfig.SinglePort, "Serve gRPC and HTTP traffic on the same port in insecure mode.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "readiness.checks"), defaultServerConfig.Readiness.Checks, "Dependencies verified by the readiness endpoint, any of database and cluster.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "readiness.timeout"), defaultServerConfig.Readiness.Timeout.String(), "Maximum time allowed for the readiness checks to complete.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "readiness.cacheDuration"), defaultServerConfig.Readiness.CacheDuration.String(), "Duration for which readiness results are reused between probes.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "requestTimeout.default"), defaultServerConfig.RequestTimeout.Default.String(), "Deadline applied to requests without a method specific timeout. Disabled when zero.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "profilerPort"), defaultServerConfig.ProfilerPort, "Port on which to serve pprof and metrics. Disabled when zero.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "openapi.publicUri"), defaultServerConfig.OpenAPI.PublicURI, "Public http uri of the service advertised in the served OpenAPI spec.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "openapi.enableUi"), defaultServerConfig.OpenAPI.EnableUI, "Serve a Swagger UI page for the OpenAPI spec under /api/v1/openapi/ui.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "tracing.enabled"), defaultServerConfig.Tracing.Enabled, "Export traces of the requests served. Tracing is a no-op otherwise.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tracing.exporterEndpoint"), defaultServerConfig.Tracing.ExporterEndpoint, "Host and port of the OTLP gRPC collector to export spans to.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "tracing.insecure"), defaultServerConfig.Tracing.Insecure, "Connect to the collector without TLS.")
+	cmdFlags.Float64(fmt.Sprintf("%v%v", prefix, "tracing.samplerRatio"), defaultServerConfig.Tracing.SamplerRatio, "Share of new traces sampled, between 0 and 1.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tracing.serviceName"), defaultServerConfig.Tracing.ServiceName, "Service name spans are reported under.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "jsonMarshaling.useCamelCase"), defaultServerConfig.JSONMarshaling.UseCamelCase, "Marshal fields by their lowerCamelCase JSON names rather than their proto names.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "jsonMarshaling.enumsAsInts"), defaultServerConfig.JSONMarshaling.EnumsAsInts, "Marshal enums by their numbers rather than their names.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "jsonMarshaling.emitDefaults"), defaultServerConfig.JSONMarshaling.EmitDefaults, "Marshal fields with zero values rather than omitting them.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "thirdPartyConfig.flyteClient.clientId"), defaultServerConfig.DeprecatedThirdPartyConfig.FlyteClientConfig.ClientID, "public identifier for the app which handles authorization for a Flyte deployment")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "thirdPartyConfig.flyteClient.redirectUri"), defaultServerConfig.DeprecatedThirdPartyConfig.FlyteClientConfig.RedirectURI, "This is the callback uri registered with the app which handles authorization for a Flyte deployment")
 	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "thirdPartyConfig.flyteClient.scopes"), []string{}, "Recommended scopes for the client to request.")
@@ -211,6 +211,20 @@ func TestServerConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_security.ssl.reloadInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.Security.Ssl.ReloadInterval.String()
+
+			cmdFlags.Set("security.ssl.reloadInterval", testValue)
+			if vString, err := cmdFlags.GetString("security.ssl.reloadInterval"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.Security.Ssl.ReloadInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_security.useAuth", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -239,6 +253,20 @@ func TestServerConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_security.auditedMethods", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_ServerConfig("1,1", ",")
+
+			cmdFlags.Set("security.auditedMethods", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("security.auditedMethods"); err == nil {
+				testDecodeRaw_ServerConfig(t, join_ServerConfig(vStringSlice, ","), &actual.Security.AuditedMethods)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_security.allowCors", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
@@ -281,6 +309,356 @@ func TestServerConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_security.allowCredentials", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("security.allowCredentials", testValue)
+			if vBool, err := cmdFlags.GetBool("security.allowCredentials"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.Security.AllowCredentials)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_gracefulShutdownTimeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.GracefulShutdownTimeout.String()
+
+			cmdFlags.Set("gracefulShutdownTimeout", testValue)
+			if vString, err := cmdFlags.GetString("gracefulShutdownTimeout"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.GracefulShutdownTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_gracefulShutdownDrainDelay", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.GracefulShutdownDrainDelay.String()
+
+			cmdFlags.Set("gracefulShutdownDrainDelay", testValue)
+			if vString, err := cmdFlags.GetString("gracefulShutdownDrainDelay"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.GracefulShutdownDrainDelay)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("rateLimit.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("rateLimit.enabled"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.RateLimit.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.read.requestsPerSecond", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("rateLimit.read.requestsPerSecond", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("rateLimit.read.requestsPerSecond"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vFloat64), &actual.RateLimit.Read.RequestsPerSecond)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.read.burst", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("rateLimit.read.burst", testValue)
+			if vInt, err := cmdFlags.GetInt("rateLimit.read.burst"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vInt), &actual.RateLimit.Read.Burst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.write.requestsPerSecond", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("rateLimit.write.requestsPerSecond", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("rateLimit.write.requestsPerSecond"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vFloat64), &actual.RateLimit.Write.RequestsPerSecond)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.write.burst", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("rateLimit.write.burst", testValue)
+			if vInt, err := cmdFlags.GetInt("rateLimit.write.burst"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vInt), &actual.RateLimit.Write.Burst)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_rateLimit.exemptMethods", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_ServerConfig("1,1", ",")
+
+			cmdFlags.Set("rateLimit.exemptMethods", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("rateLimit.exemptMethods"); err == nil {
+				testDecodeRaw_ServerConfig(t, join_ServerConfig(vStringSlice, ","), &actual.RateLimit.ExemptMethods)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_singlePort", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("singlePort", testValue)
+			if vBool, err := cmdFlags.GetBool("singlePort"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.SinglePort)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_readiness.checks", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_ServerConfig("1,1", ",")
+
+			cmdFlags.Set("readiness.checks", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("readiness.checks"); err == nil {
+				testDecodeRaw_ServerConfig(t, join_ServerConfig(vStringSlice, ","), &actual.Readiness.Checks)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_readiness.timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.Readiness.Timeout.String()
+
+			cmdFlags.Set("readiness.timeout", testValue)
+			if vString, err := cmdFlags.GetString("readiness.timeout"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.Readiness.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_readiness.cacheDuration", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.Readiness.CacheDuration.String()
+
+			cmdFlags.Set("readiness.cacheDuration", testValue)
+			if vString, err := cmdFlags.GetString("readiness.cacheDuration"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.Readiness.CacheDuration)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_requestTimeout.default", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultServerConfig.RequestTimeout.Default.String()
+
+			cmdFlags.Set("requestTimeout.default", testValue)
+			if vString, err := cmdFlags.GetString("requestTimeout.default"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.RequestTimeout.Default)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_profilerPort", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("profilerPort", testValue)
+			if vInt, err := cmdFlags.GetInt("profilerPort"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vInt), &actual.ProfilerPort)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_openapi.publicUri", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("openapi.publicUri", testValue)
+			if vString, err := cmdFlags.GetString("openapi.publicUri"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.OpenAPI.PublicURI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_openapi.enableUi", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("openapi.enableUi", testValue)
+			if vBool, err := cmdFlags.GetBool("openapi.enableUi"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.OpenAPI.EnableUI)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("tracing.enabled"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.Tracing.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.exporterEndpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.exporterEndpoint", testValue)
+			if vString, err := cmdFlags.GetString("tracing.exporterEndpoint"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.Tracing.ExporterEndpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.insecure", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.insecure", testValue)
+			if vBool, err := cmdFlags.GetBool("tracing.insecure"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.Tracing.Insecure)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.samplerRatio", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.samplerRatio", testValue)
+			if vFloat64, err := cmdFlags.GetFloat64("tracing.samplerRatio"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vFloat64), &actual.Tracing.SamplerRatio)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.serviceName", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.serviceName", testValue)
+			if vString, err := cmdFlags.GetString("tracing.serviceName"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vString), &actual.Tracing.ServiceName)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_jsonMarshaling.useCamelCase", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("jsonMarshaling.useCamelCase", testValue)
+			if vBool, err := cmdFlags.GetBool("jsonMarshaling.useCamelCase"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.JSONMarshaling.UseCamelCase)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_jsonMarshaling.enumsAsInts", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("jsonMarshaling.enumsAsInts", testValue)
+			if vBool, err := cmdFlags.GetBool("jsonMarshaling.enumsAsInts"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.JSONMarshaling.EnumsAsInts)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_jsonMarshaling.emitDefaults", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("jsonMarshaling.emitDefaults", testValue)
+			if vBool, err := cmdFlags.GetBool("jsonMarshaling.emitDefaults"); err == nil {
+				testDecodeJson_ServerConfig(t, fmt.Sprintf("%v", vBool), &actual.JSONMarshaling.EmitDefaults)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 	t.Run("Test_thirdPartyConfig.flyteClient.clientId", func(t *testing.T) {
 
 		t.Run("Override", func(t *testing.T) {
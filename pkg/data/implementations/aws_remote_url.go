@@ -2,6 +2,7 @@ package implementations
 
 import (
 	"context"
+	"encoding/base64"
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -23,6 +24,7 @@ const s3Scheme = "s3"
 type s3Interface interface {
 	HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
 	GetObjectRequest(input *s3.GetObjectInput) (req *request.Request, output *s3.GetObjectOutput)
+	PutObjectRequest(input *s3.PutObjectInput) (req *request.Request, output *s3.PutObjectOutput)
 }
 
 // AWS-specific implementation of RemoteURLInterface
@@ -92,6 +94,34 @@ func (a *AWSRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, erro
 	}, nil
 }
 
+// The checksum and size of the upload are signed headers, S3 rejects uploads which don't match them.
+func (a *AWSRemoteURL) GetUploadURL(
+	ctx context.Context, uri string, properties interfaces.UploadProperties) (string, error) {
+	logger.Debugf(ctx, "Getting signed upload url for - %s", uri)
+	s3URI, err := a.splitURI(ctx, uri)
+	if err != nil {
+		logger.Debugf(ctx, "failed to extract s3 bucket and key from uri: %s", uri)
+		return "", errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid uri: %s", uri)
+	}
+	input := &s3.PutObjectInput{
+		Bucket:        &s3URI.bucket,
+		Key:           &s3URI.key,
+		ContentLength: aws.Int64(properties.ContentLength),
+	}
+	if len(properties.ContentMD5) > 0 {
+		input.ContentMD5 = aws.String(base64.StdEncoding.EncodeToString(properties.ContentMD5))
+	}
+	req, _ := a.s3Client.PutObjectRequest(input)
+	urlStr, err := req.Presign(properties.ExpiresIn)
+	if err != nil {
+		logger.Warningf(ctx,
+			"failed to presign upload url for uri [%s] for %v with err %v", uri, properties.ExpiresIn, err)
+		return "", errors.NewFlyteAdminErrorf(codes.Internal,
+			"failed to presign upload url for uri [%s] for %v with err %v", uri, properties.ExpiresIn, err)
+	}
+	return urlStr, nil
+}
+
 func NewAWSRemoteURL(config *aws.Config, presignDuration time.Duration) interfaces.RemoteURLInterface {
 	sesh, err := session.NewSession(config)
 	if err != nil {
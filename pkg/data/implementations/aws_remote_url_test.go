@@ -2,6 +2,7 @@ package implementations
 
 import (
 	"context"
+	"crypto/md5"
 	"net/http"
 	"net/url"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/flyteorg/flyteadmin/pkg/data/interfaces"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,6 +38,7 @@ func TestSplitURI_InvalidDataReference(t *testing.T) {
 type mockS3Impl struct {
 	headObjectFunc func(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
 	getObjectFunc  func(input *s3.GetObjectInput) (req *request.Request, output *s3.GetObjectOutput)
+	putObjectFunc  func(input *s3.PutObjectInput) (req *request.Request, output *s3.PutObjectOutput)
 }
 
 func (m *mockS3Impl) HeadObject(input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
@@ -46,6 +49,10 @@ func (m *mockS3Impl) GetObjectRequest(input *s3.GetObjectInput) (req *request.Re
 	return m.getObjectFunc(input)
 }
 
+func (m *mockS3Impl) PutObjectRequest(input *s3.PutObjectInput) (req *request.Request, output *s3.PutObjectOutput) {
+	return m.putObjectFunc(input)
+}
+
 func TestAWSGet(t *testing.T) {
 	contentLength := int64(100)
 	presignDuration := 3 * time.Minute
@@ -83,3 +90,37 @@ func TestAWSGet(t *testing.T) {
 	assert.Equal(t, "www://host/path", urlBlob.Url)
 	assert.Equal(t, contentLength, urlBlob.Bytes)
 }
+
+func TestAWSGetUploadURL(t *testing.T) {
+	contentMD5 := md5.Sum([]byte("hello"))
+	mockS3 := mockS3Impl{}
+	mockS3.putObjectFunc = func(input *s3.PutObjectInput) (req *request.Request, output *s3.PutObjectOutput) {
+		assert.Equal(t, "bucket", *input.Bucket)
+		assert.Equal(t, "key", *input.Key)
+		assert.Equal(t, int64(100), *input.ContentLength)
+		assert.Equal(t, "XUFAKrxLKna5cZ2REBfFkg==", *input.ContentMD5)
+		return &request.Request{
+			Operation: &request.Operation{},
+			HTTPRequest: &http.Request{
+				URL: &url.URL{
+					Scheme: "www",
+					Host:   "host",
+					Path:   "path",
+				},
+			},
+		}, &s3.PutObjectOutput{}
+	}
+	remoteURL := AWSRemoteURL{
+		s3Client: &mockS3,
+	}
+	urlStr, err := remoteURL.GetUploadURL(context.Background(), "s3://bucket/key", interfaces.UploadProperties{
+		ContentMD5:    contentMD5[:],
+		ContentLength: 100,
+		ExpiresIn:     time.Minute,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "www://host/path", urlStr)
+
+	_, err = remoteURL.GetUploadURL(context.Background(), "gs://bucket/key", interfaces.UploadProperties{})
+	assert.NotNil(t, err)
+}
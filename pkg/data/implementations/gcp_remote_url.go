@@ -2,6 +2,8 @@ package implementations
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -97,25 +99,21 @@ func (g *GCPRemoteURL) splitURI(ctx context.Context, uri string) (GCPGCSObject,
 	}, nil
 }
 
-func (g *GCPRemoteURL) signURL(ctx context.Context, gcsURI GCPGCSObject) (string, error) {
-	opts := &gcs.SignedURLOptions{
-		Method:         "GET",
-		GoogleAccessID: g.signingPrincipal,
-		SignBytes: func(b []byte) ([]byte, error) {
-			req := &credentialspb.SignBlobRequest{
-				Payload: b,
-				Name:    "projects/-/serviceAccounts/" + g.signingPrincipal,
-			}
-			resp, err := g.iamCredentialsClient.SignBlob(ctx, req)
-			if err != nil {
-				return nil, err
-			}
-			return resp.SignedBlob, nil
-		},
-		Expires: time.Now().Add(g.signDuration),
+func (g *GCPRemoteURL) signURL(ctx context.Context, gcsURI GCPGCSObject, opts gcs.SignedURLOptions) (string, error) {
+	opts.GoogleAccessID = g.signingPrincipal
+	opts.SignBytes = func(b []byte) ([]byte, error) {
+		req := &credentialspb.SignBlobRequest{
+			Payload: b,
+			Name:    "projects/-/serviceAccounts/" + g.signingPrincipal,
+		}
+		resp, err := g.iamCredentialsClient.SignBlob(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp.SignedBlob, nil
 	}
 
-	return gcs.SignedURL(gcsURI.bucket, gcsURI.object, opts)
+	return gcs.SignedURL(gcsURI.bucket, gcsURI.object, &opts)
 }
 
 func (g *GCPRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, error) {
@@ -134,7 +132,10 @@ func (g *GCPRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, erro
 			codes.Internal, "failed to get object size for %s with %v", uri, err)
 	}
 
-	urlStr, err := g.signURL(ctx, gcsURI)
+	urlStr, err := g.signURL(ctx, gcsURI, gcs.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(g.signDuration),
+	})
 	if err != nil {
 		logger.Warning(ctx,
 			"failed to presign url for uri [%s] for %v with err %v", uri, g.signDuration, err)
@@ -147,6 +148,36 @@ func (g *GCPRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, erro
 	}, nil
 }
 
+// The checksum and size of the upload are part of the signature, GCS rejects uploads which don't match them.
+func (g *GCPRemoteURL) GetUploadURL(
+	ctx context.Context, uri string, properties interfaces.UploadProperties) (string, error) {
+	logger.Debugf(ctx, "Getting signed upload url for - %s", uri)
+	gcsURI, err := g.splitURI(ctx, uri)
+	if err != nil {
+		logger.Debugf(ctx, "failed to extract gcs bucket and object from uri: %s", uri)
+		return "", errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid uri: %s", uri)
+	}
+
+	opts := gcs.SignedURLOptions{
+		Method:  "PUT",
+		Expires: time.Now().Add(properties.ExpiresIn),
+		Headers: []string{
+			fmt.Sprintf("x-goog-content-length-range:%d,%d", properties.ContentLength, properties.ContentLength),
+		},
+	}
+	if len(properties.ContentMD5) > 0 {
+		opts.MD5 = base64.StdEncoding.EncodeToString(properties.ContentMD5)
+	}
+	urlStr, err := g.signURL(ctx, gcsURI, opts)
+	if err != nil {
+		logger.Warningf(ctx,
+			"failed to presign upload url for uri [%s] for %v with err %v", uri, properties.ExpiresIn, err)
+		return "", errors.NewFlyteAdminErrorf(codes.Internal,
+			"failed to presign upload url for uri [%s] for %v with err %v", uri, properties.ExpiresIn, err)
+	}
+	return urlStr, nil
+}
+
 func (ts impersonationTokenSource) Token() (*oauth2.Token, error) {
 	req := credentialspb.GenerateAccessTokenRequest{
 		Name:  "projects/-/serviceAccounts/" + ts.signingPrincipal,
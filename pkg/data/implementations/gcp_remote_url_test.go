@@ -2,12 +2,14 @@ package implementations
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/base64"
 	"net/url"
 	"testing"
 	"time"
 
 	gcs "cloud.google.com/go/storage"
+	"github.com/flyteorg/flyteadmin/pkg/data/interfaces"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	gax "github.com/googleapis/gax-go/v2"
 	"github.com/stretchr/testify/assert"
@@ -110,6 +112,37 @@ func TestGCPGet(t *testing.T) {
 	assert.Equal(t, int64(100), urlBlob.Bytes)
 }
 
+func TestGCPGetUploadURL(t *testing.T) {
+	contentMD5 := md5.Sum([]byte("hello"))
+	signingPrincipal := "principal@example.com"
+	signedBlob := "signed"
+
+	mockIAMCredentials := mockIAMCredentialsImpl{}
+	mockIAMCredentials.signBlobFunc = func(ctx context.Context, req *credentialspb.SignBlobRequest, opts ...gax.CallOption) (*credentialspb.SignBlobResponse, error) {
+		// The checksum and size of the upload are part of the signed payload.
+		assert.Contains(t, string(req.Payload), "PUT")
+		assert.Contains(t, string(req.Payload), "XUFAKrxLKna5cZ2REBfFkg==")
+		assert.Contains(t, string(req.Payload), "x-goog-content-length-range:100,100")
+		return &credentialspb.SignBlobResponse{SignedBlob: []byte(signedBlob)}, nil
+	}
+
+	remoteURL := GCPRemoteURL{
+		iamCredentialsClient: &mockIAMCredentials,
+		gcsClient:            &mockGCSImpl{},
+		signingPrincipal:     signingPrincipal,
+	}
+	urlStr, err := remoteURL.GetUploadURL(context.Background(), "gs://bucket/key", interfaces.UploadProperties{
+		ContentMD5:    contentMD5[:],
+		ContentLength: 100,
+		ExpiresIn:     time.Minute,
+	})
+	assert.Nil(t, err)
+
+	u, _ := url.Parse(urlStr)
+	assert.Equal(t, "/bucket/key", u.Path)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(signedBlob)), u.Query().Get("Signature"))
+}
+
 func TestToken(t *testing.T) {
 	token := "token"
 	signingPrincipal := "principal@example.com"
@@ -27,6 +27,13 @@ func (n *NoopRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, err
 	}, nil
 }
 
+// Without a cloud provider to sign urls, uploads aren't supported.
+func (n *NoopRemoteURL) GetUploadURL(
+	ctx context.Context, uri string, properties interfaces.UploadProperties) (string, error) {
+	return "", errors.NewFlyteAdminErrorf(codes.Unimplemented,
+		"uploads aren't supported without a configured cloud provider, uri: %s", uri)
+}
+
 func NewNoopRemoteURL(remoteDataStoreClient storage.DataStore) interfaces.RemoteURLInterface {
 	return &NoopRemoteURL{
 		remoteDataStoreClient: remoteDataStoreClient,
@@ -2,12 +2,25 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
 
+// Describes the object an upload url is signed for. Backends which support it reject uploads which don't match.
+type UploadProperties struct {
+	// The MD5 digest of the object to upload.
+	ContentMD5 []byte
+	// The size of the object to upload in bytes.
+	ContentLength int64
+	// The amount of time for which the upload url is valid.
+	ExpiresIn time.Duration
+}
+
 // Defines an interface for fetching pre-signed URLs.
 type RemoteURLInterface interface {
 	// TODO: Refactor for URI to be of type DataReference. We should package a FromString-like function in flytestdlib
 	Get(ctx context.Context, uri string) (admin.UrlBlob, error)
+	// Returns a pre-signed URL to which the object described by the properties may be uploaded to the given uri.
+	GetUploadURL(ctx context.Context, uri string, properties UploadProperties) (string, error)
 }
@@ -9,7 +9,8 @@ import (
 
 // Mock implementation of a RemoteURLInterface
 type MockRemoteURL struct {
-	GetCallback func(ctx context.Context, uri string) (admin.UrlBlob, error)
+	GetCallback          func(ctx context.Context, uri string) (admin.UrlBlob, error)
+	GetUploadURLCallback func(ctx context.Context, uri string, properties interfaces.UploadProperties) (string, error)
 }
 
 func (m *MockRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, error) {
@@ -19,6 +20,14 @@ func (m *MockRemoteURL) Get(ctx context.Context, uri string) (admin.UrlBlob, err
 	return admin.UrlBlob{}, nil
 }
 
+func (m *MockRemoteURL) GetUploadURL(
+	ctx context.Context, uri string, properties interfaces.UploadProperties) (string, error) {
+	if m.GetUploadURLCallback != nil {
+		return m.GetUploadURLCallback(ctx, uri, properties)
+	}
+	return "", nil
+}
+
 func NewMockRemoteURL() interfaces.RemoteURLInterface {
 	return &MockRemoteURL{}
 }
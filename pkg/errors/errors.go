@@ -8,6 +8,7 @@ import (
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -106,3 +107,36 @@ func NewIncompatibleClusterError(ctx context.Context, errorMsg, curCluster strin
 	}
 	return statusErr
 }
+
+// NewQuotaExceededError returns a ResourceExhausted error detailing the quota a request exceeded, which tells it apart
+// from requests rejected for exhausting other resources, such as rate limits, which are worth retrying.
+func NewQuotaExceededError(ctx context.Context, subject, errorMsg string) FlyteAdminError {
+	s, transformationErr := status.New(codes.ResourceExhausted, errorMsg).WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{
+				Subject:     subject,
+				Description: errorMsg,
+			},
+		},
+	})
+	if transformationErr != nil {
+		logger.Panicf(ctx, "Failed to wrap grpc status in type 'Error': %v", transformationErr)
+		return NewFlyteAdminErrorf(codes.ResourceExhausted, errorMsg)
+	}
+	return NewFlyteAdminErrorFromStatus(s)
+}
+
+// IsQuotaExceededError returns whether the error, which may have been returned by a grpc client, rejected a request
+// for exceeding a quota.
+func IsQuotaExceededError(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.ResourceExhausted {
+		return false
+	}
+	for _, detail := range s.Details() {
+		if _, ok := detail.(*errdetails.QuotaFailure); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -43,3 +44,21 @@ func TestNewIncompatibleClusterError(t *testing.T) {
 	_, ok = details.GetReason().(*admin.EventFailureReason_IncompatibleCluster)
 	assert.True(t, ok)
 }
+
+func TestNewQuotaExceededError(t *testing.T) {
+	errorMsg := "too many executions"
+	quotaErr := NewQuotaExceededError(context.Background(), "project:domain", errorMsg)
+	s, ok := status.FromError(quotaErr)
+	assert.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, s.Code())
+	assert.Equal(t, errorMsg, s.Message())
+	assert.True(t, IsQuotaExceededError(quotaErr))
+	// The error keeps its details once returned by a grpc client, and along with those other interceptors add.
+	detailed, err := s.WithDetails(&errdetails.RequestInfo{RequestId: "abc"})
+	assert.NoError(t, err)
+	assert.True(t, IsQuotaExceededError(detailed.Err()))
+
+	assert.False(t, IsQuotaExceededError(NewFlyteAdminError(codes.ResourceExhausted, "rate limited")))
+	assert.False(t, IsQuotaExceededError(NewFlyteAdminError(codes.NotFound, "missing")))
+	assert.False(t, IsQuotaExceededError(nil))
+}
@@ -1,6 +1,7 @@
 package executioncluster
 
 import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	flyteclient "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
 	"github.com/flyteorg/flytestdlib/random"
 	"k8s.io/client-go/dynamic"
@@ -17,6 +18,9 @@ type ExecutionTargetSpec struct {
 	Domain      string
 	Workflow    string
 	LaunchPlan  string
+	// Assigned cluster label, when already resolved. Otherwise it's looked up from the matchable attributes of the
+	// project, domain, workflow and launch plan.
+	ExecutionClusterLabel *admin.ExecutionClusterLabel
 }
 
 // Client object of the target execution cluster
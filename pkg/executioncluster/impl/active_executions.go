@@ -0,0 +1,57 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
+)
+
+type countActiveExecutionsFunc func(ctx context.Context) (map[string]int64, error)
+
+// Tracks the number of non-terminal executions in each cluster for the least active placement strategy. Counting
+// groups every execution in the table, so the counts are only read from the database once they're older than the
+// refresh interval. In between, each placement adds to the count of the cluster it picked, which keeps bursts of
+// executions from all landing in the same cluster. Executions terminating in between are only seen by the next refresh.
+type activeExecutionCounter struct {
+	count           countActiveExecutionsFunc
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mutex       sync.Mutex
+	counts      map[string]int64
+	refreshedAt time.Time
+}
+
+// Picks the candidate running the fewest executions and counts the execution being placed against it. Ties go to the
+// first candidate.
+func (c *activeExecutionCounter) placeInLeastActive(ctx context.Context,
+	candidates []executioncluster.ExecutionTarget) (executioncluster.ExecutionTarget, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.counts == nil || c.now().Sub(c.refreshedAt) >= c.refreshInterval {
+		counts, err := c.count(ctx)
+		if err != nil {
+			return executioncluster.ExecutionTarget{}, err
+		}
+		c.counts = counts
+		c.refreshedAt = c.now()
+	}
+	leastActive := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if c.counts[candidate.ID] < c.counts[leastActive.ID] {
+			leastActive = candidate
+		}
+	}
+	c.counts[leastActive.ID]++
+	return leastActive, nil
+}
+
+func newActiveExecutionCounter(count countActiveExecutionsFunc, refreshInterval time.Duration) *activeExecutionCounter {
+	return &activeExecutionCounter{
+		count:           count,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
@@ -0,0 +1,88 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type pingFunc func(ctx context.Context, target executioncluster.ExecutionTarget) error
+
+// Asks the API server of the cluster for its version, which is about the cheapest request it serves.
+func pingAPIServer(ctx context.Context, target executioncluster.ExecutionTarget) error {
+	return target.FlyteClient.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
+// Tracks whether execution clusters answered their latest health check. Clusters are considered healthy until a check
+// fails, hence all clusters are healthy when health checks are disabled.
+type clusterHealthTracker struct {
+	targets     []executioncluster.ExecutionTarget
+	ping        pingFunc
+	healthGauge *prometheus.GaugeVec
+
+	mutex     sync.RWMutex
+	unhealthy map[string]bool
+}
+
+func (t *clusterHealthTracker) isHealthy(clusterID string) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return !t.unhealthy[clusterID]
+}
+
+// Pings every tracked cluster once, each ping is given at most the timeout to complete.
+func (t *clusterHealthTracker) checkAll(ctx context.Context, timeout time.Duration) {
+	for _, target := range t.targets {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := t.ping(pingCtx, target)
+		cancel()
+
+		t.mutex.Lock()
+		wasUnhealthy := t.unhealthy[target.ID]
+		t.unhealthy[target.ID] = err != nil
+		t.mutex.Unlock()
+
+		if err != nil {
+			t.healthGauge.WithLabelValues(target.ID).Set(0)
+			if !wasUnhealthy {
+				logger.Warningf(ctx, "Execution cluster [%s] failed its health check, no new executions will be placed "+
+					"in it until it recovers: %v", target.ID, err)
+			}
+			continue
+		}
+		t.healthGauge.WithLabelValues(target.ID).Set(1)
+		if wasUnhealthy {
+			logger.Infof(ctx, "Execution cluster [%s] recovered", target.ID)
+		}
+	}
+}
+
+// Checks the health of the tracked clusters every interval until the context is cancelled.
+func (t *clusterHealthTracker) start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			t.checkAll(ctx, interval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func newClusterHealthTracker(targets []executioncluster.ExecutionTarget, ping pingFunc,
+	healthGauge *prometheus.GaugeVec) *clusterHealthTracker {
+	return &clusterHealthTracker{
+		targets:     targets,
+		ping:        ping,
+		healthGauge: healthGauge,
+		unhealthy:   make(map[string]bool),
+	}
+}
@@ -1,13 +1,17 @@
 package impl
 
 import (
+	"context"
+
 	executioncluster_interface "github.com/flyteorg/flyteadmin/pkg/executioncluster/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
 )
 
-func GetExecutionCluster(scope promutils.Scope, kubeConfig, master string, config interfaces.Configuration, db repositories.RepositoryInterface) executioncluster_interface.ClusterInterface {
+// GetExecutionCluster returns the clusters executions are created in. The background jobs tracking them, such as their
+// health checks, run until ctx is cancelled.
+func GetExecutionCluster(ctx context.Context, scope promutils.Scope, kubeConfig, master string, config interfaces.Configuration, db repositories.RepositoryInterface) executioncluster_interface.ClusterInterface {
 	initializationErrorCounter := scope.MustNewCounter(
 		"flyteclient_initialization_error",
 		"count of errors encountered initializing a flyte client from kube config")
@@ -19,7 +23,7 @@ func GetExecutionCluster(scope promutils.Scope, kubeConfig, master string, confi
 		}
 		return cluster
 	default:
-		cluster, err := NewRandomClusterSelector(initializationErrorCounter, config, &clusterExecutionTargetProvider{}, db)
+		cluster, err := NewRandomClusterSelector(ctx, initializationErrorCounter, config, &clusterExecutionTargetProvider{}, db, scope)
 		if err != nil {
 			panic(err)
 		}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"hash/fnv"
 	"math/rand"
+	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
@@ -26,13 +29,29 @@ import (
 	runtime "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 )
 
+// Candidates of executions without a cluster label, or whose label maps to no enabled cluster.
+const allClustersKey = ""
+
+type clusterPlacementMetrics struct {
+	Placements *prometheus.CounterVec
+	Healthy    *prometheus.GaugeVec
+}
+
 // Implementation of Random cluster selector
-// Selects cluster based on weights and domains.
+// Selects cluster based on weights and domains, among the clusters which passed their latest health check. Despite its
+// name, clusters may also be picked in turn or by their number of running executions depending on the placement
+// strategy.
 type RandomClusterSelector struct {
-	equalWeightedAllClusters random.WeightedRandomList
-	labelWeightedRandomMap   map[string]random.WeightedRandomList
-	executionTargetMap       map[string]executioncluster.ExecutionTarget
-	resourceManager          managerInterfaces.ResourceInterface
+	// Enabled clusters which may run an execution, keyed by cluster label.
+	candidates         map[string][]random.Entry
+	executionTargetMap map[string]executioncluster.ExecutionTarget
+	resourceManager    managerInterfaces.ResourceInterface
+	placementStrategy  runtime.ClusterPlacementStrategy
+	health             *clusterHealthTracker
+	activeExecutions   *activeExecutionCounter
+	// Round robin positions, keyed by cluster label.
+	roundRobinCounters map[string]*uint64
+	metrics            clusterPlacementMetrics
 }
 
 func getRandSource(seed string) (rand.Source, error) {
@@ -45,8 +64,8 @@ func getRandSource(seed string) (rand.Source, error) {
 	return rand.NewSource(hashedSeed), nil
 }
 
-func getExecutionTargets(ctx context.Context, initializationErrorCounter prometheus.Counter, executionTargetProvider interfaces.ExecutionTargetProvider,
-	clusterConfig runtime.ClusterConfiguration) (random.WeightedRandomList, map[string]executioncluster.ExecutionTarget, error) {
+func getExecutionTargets(initializationErrorCounter prometheus.Counter, executionTargetProvider interfaces.ExecutionTargetProvider,
+	clusterConfig runtime.ClusterConfiguration) ([]random.Entry, map[string]executioncluster.ExecutionTarget, error) {
 	executionTargetMap := make(map[string]executioncluster.ExecutionTarget)
 	entries := make([]random.Entry, 0)
 	for _, cluster := range clusterConfig.GetClusterConfigs() {
@@ -65,16 +84,15 @@ func getExecutionTargets(ctx context.Context, initializationErrorCounter prometh
 			entries = append(entries, targetEntry)
 		}
 	}
-	weightedRandomList, err := random.NewWeightedRandom(ctx, entries)
-	if err != nil {
-		return nil, nil, err
+	if len(entries) == 0 {
+		return nil, nil, fmt.Errorf("no enabled execution cluster")
 	}
-	return weightedRandomList, executionTargetMap, nil
+	return entries, executionTargetMap, nil
 }
 
-func getLabeledWeightedRandomForCluster(ctx context.Context,
-	clusterConfig runtime.ClusterConfiguration, executionTargetMap map[string]executioncluster.ExecutionTarget) (map[string]random.WeightedRandomList, error) {
-	labeledWeightedRandomMap := make(map[string]random.WeightedRandomList)
+func getLabeledCandidatesForCluster(clusterConfig runtime.ClusterConfiguration,
+	executionTargetMap map[string]executioncluster.ExecutionTarget) map[string][]random.Entry {
+	labeledCandidates := make(map[string][]random.Entry)
 	for label, clusterEntities := range clusterConfig.GetLabelClusterMap() {
 		entries := make([]random.Entry, 0)
 		for _, clusterEntity := range clusterEntities {
@@ -90,14 +108,10 @@ func getLabeledWeightedRandomForCluster(ctx context.Context,
 			entries = append(entries, targetEntry)
 		}
 		if len(entries) > 0 {
-			weightedRandomList, err := random.NewWeightedRandom(ctx, entries)
-			if err != nil {
-				return nil, err
-			}
-			labeledWeightedRandomMap[label] = weightedRandomList
+			labeledCandidates[label] = entries
 		}
 	}
-	return labeledWeightedRandomMap, nil
+	return labeledCandidates
 }
 
 func (s RandomClusterSelector) GetAllValidTargets() []executioncluster.ExecutionTarget {
@@ -110,6 +124,52 @@ func (s RandomClusterSelector) GetAllValidTargets() []executioncluster.Execution
 	return v
 }
 
+func (s RandomClusterSelector) getHealthyCandidates(candidates []random.Entry) []random.Entry {
+	healthyCandidates := make([]random.Entry, 0, len(candidates))
+	for _, candidate := range candidates {
+		if s.health.isHealthy(candidate.Item.(executioncluster.ExecutionTarget).ID) {
+			healthyCandidates = append(healthyCandidates, candidate)
+		}
+	}
+	return healthyCandidates
+}
+
+func (s RandomClusterSelector) getWeightedRandomTarget(ctx context.Context, executionName string,
+	candidates []random.Entry) (executioncluster.ExecutionTarget, error) {
+	weightedRandomList, err := random.NewWeightedRandom(ctx, candidates)
+	if err != nil {
+		return executioncluster.ExecutionTarget{}, err
+	}
+	if executionName == "" {
+		return weightedRandomList.Get().(executioncluster.ExecutionTarget), nil
+	}
+	// Seeding with the execution name places an execution in the same cluster when its creation is retried.
+	randSrc, err := getRandSource(executionName)
+	if err != nil {
+		return executioncluster.ExecutionTarget{}, err
+	}
+	result, err := weightedRandomList.GetWithSeed(randSrc)
+	if err != nil {
+		return executioncluster.ExecutionTarget{}, err
+	}
+	return result.(executioncluster.ExecutionTarget), nil
+}
+
+func (s RandomClusterSelector) getRoundRobinTarget(candidatesKey string, candidates []random.Entry) executioncluster.ExecutionTarget {
+	position := atomic.AddUint64(s.roundRobinCounters[candidatesKey], 1) - 1
+	return candidates[position%uint64(len(candidates))].Item.(executioncluster.ExecutionTarget)
+}
+
+// Candidate weights are ignored.
+func (s RandomClusterSelector) getLeastActiveTarget(ctx context.Context, candidates []random.Entry) (
+	executioncluster.ExecutionTarget, error) {
+	targets := make([]executioncluster.ExecutionTarget, len(candidates))
+	for idx, candidate := range candidates {
+		targets[idx] = candidate.Item.(executioncluster.ExecutionTarget)
+	}
+	return s.activeExecutions.placeInLeastActive(ctx, targets)
+}
+
 func (s RandomClusterSelector) GetTarget(ctx context.Context, spec *executioncluster.ExecutionTargetSpec) (*executioncluster.ExecutionTarget, error) {
 	if spec == nil {
 		return nil, fmt.Errorf("empty executionTargetSpec")
@@ -120,24 +180,30 @@ func (s RandomClusterSelector) GetTarget(ctx context.Context, spec *executionclu
 		}
 		return nil, fmt.Errorf("invalid cluster target %s", spec.TargetID)
 	}
-	resource, err := s.resourceManager.GetResource(ctx, managerInterfaces.ResourceRequest{
-		Project:      spec.Project,
-		Domain:       spec.Domain,
-		Workflow:     spec.Workflow,
-		LaunchPlan:   spec.LaunchPlan,
-		ResourceType: admin.MatchableResource_EXECUTION_CLUSTER_LABEL,
-	})
-	if err != nil {
-		if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
-			return nil, err
+	executionClusterLabel := spec.ExecutionClusterLabel
+	if executionClusterLabel == nil {
+		resource, err := s.resourceManager.GetResource(ctx, managerInterfaces.ResourceRequest{
+			Project:      spec.Project,
+			Domain:       spec.Domain,
+			Workflow:     spec.Workflow,
+			LaunchPlan:   spec.LaunchPlan,
+			ResourceType: admin.MatchableResource_EXECUTION_CLUSTER_LABEL,
+		})
+		if err != nil {
+			if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
+				return nil, err
+			}
+		}
+		if resource != nil {
+			executionClusterLabel = resource.Attributes.GetExecutionClusterLabel()
 		}
 	}
-	var weightedRandomList random.WeightedRandomList
-	if resource != nil && resource.Attributes.GetExecutionClusterLabel() != nil {
-		label := resource.Attributes.GetExecutionClusterLabel().Value
+	candidatesKey := allClustersKey
+	if executionClusterLabel != nil {
+		label := executionClusterLabel.Value
 
-		if _, ok := s.labelWeightedRandomMap[label]; ok {
-			weightedRandomList = s.labelWeightedRandomMap[label]
+		if _, ok := s.candidates[label]; ok {
+			candidatesKey = label
 		} else {
 			logger.Debugf(ctx, "No cluster mapping found for the label %s", label)
 		}
@@ -145,41 +211,87 @@ func (s RandomClusterSelector) GetTarget(ctx context.Context, spec *executionclu
 		logger.Debugf(ctx, "No override found for the spec %v", spec)
 	}
 	// If there is no label associated (or) if the label is invalid, choose from all enabled clusters.
-	// Note that if there is a valid label with zero "Enabled" clusters, we still choose from all enabled ones.
-	if weightedRandomList == nil {
-		weightedRandomList = s.equalWeightedAllClusters
+	// Note that if there is a valid label with zero "Enabled" clusters, we still choose from all enabled ones. A label
+	// whose clusters all failed their health check doesn't spill over to other clusters though, executions are only
+	// ever placed in the clusters their label maps to.
+	candidates := s.getHealthyCandidates(s.candidates[candidatesKey])
+	if len(candidates) == 0 {
+		if candidatesKey != allClustersKey {
+			return nil, errors.NewFlyteAdminErrorf(codes.Unavailable,
+				"no healthy execution cluster available for the label %s", candidatesKey)
+		}
+		return nil, errors.NewFlyteAdminErrorf(codes.Unavailable, "no healthy execution cluster available")
 	}
 
-	executionName := spec.ExecutionID
-	if executionName != "" {
-		randSrc, err := getRandSource(executionName)
-		if err != nil {
-			return nil, err
-		}
-		result, err := weightedRandomList.GetWithSeed(randSrc)
-		if err != nil {
-			return nil, err
-		}
-		execTarget := result.(executioncluster.ExecutionTarget)
-		return &execTarget, nil
+	var execTarget executioncluster.ExecutionTarget
+	var err error
+	switch s.placementStrategy {
+	case runtime.ClusterPlacementRoundRobin:
+		execTarget = s.getRoundRobinTarget(candidatesKey, candidates)
+	case runtime.ClusterPlacementLeastActive:
+		execTarget, err = s.getLeastActiveTarget(ctx, candidates)
+	default:
+		execTarget, err = s.getWeightedRandomTarget(ctx, spec.ExecutionID, candidates)
 	}
-	execTarget := weightedRandomList.Get().(executioncluster.ExecutionTarget)
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.Placements.WithLabelValues(execTarget.ID).Inc()
 	return &execTarget, nil
 }
 
-func NewRandomClusterSelector(initializationErrorCounter prometheus.Counter, config runtime.Configuration, executionTargetProvider interfaces.ExecutionTargetProvider, db repositories.RepositoryInterface) (interfaces.ClusterInterface, error) {
-	equalWeightedAllClusters, executionTargetMap, err := getExecutionTargets(context.Background(), initializationErrorCounter, executionTargetProvider, config.ClusterConfiguration())
-	if err != nil {
-		return nil, err
+func newClusterPlacementMetrics(scope promutils.Scope) clusterPlacementMetrics {
+	return clusterPlacementMetrics{
+		Placements: scope.MustNewCounterVec("placements",
+			"number of executions placed in each cluster", "cluster"),
+		Healthy: scope.MustNewGaugeVec("healthy",
+			"whether each cluster passed its latest health check", "cluster"),
 	}
-	labelWeightedRandomMap, err := getLabeledWeightedRandomForCluster(context.Background(), config.ClusterConfiguration(), executionTargetMap)
+}
+
+// NewRandomClusterSelector returns a selector among the configured clusters. Their health is checked in the background
+// until ctx is cancelled.
+func NewRandomClusterSelector(ctx context.Context, initializationErrorCounter prometheus.Counter, config runtime.Configuration,
+	executionTargetProvider interfaces.ExecutionTargetProvider, db repositories.RepositoryInterface,
+	scope promutils.Scope) (interfaces.ClusterInterface, error) {
+	allCandidates, executionTargetMap, err := getExecutionTargets(initializationErrorCounter, executionTargetProvider, config.ClusterConfiguration())
 	if err != nil {
 		return nil, err
 	}
+	candidates := getLabeledCandidatesForCluster(config.ClusterConfiguration(), executionTargetMap)
+	candidates[allClustersKey] = allCandidates
+	roundRobinCounters := make(map[string]*uint64, len(candidates))
+	for label := range candidates {
+		roundRobinCounters[label] = new(uint64)
+	}
+
+	placementStrategy := config.ClusterConfiguration().GetPlacementStrategy()
+	switch placementStrategy {
+	case runtime.ClusterPlacementWeightedRandom, runtime.ClusterPlacementRoundRobin, runtime.ClusterPlacementLeastActive:
+	default:
+		return nil, fmt.Errorf("unrecognized cluster placement strategy %s", placementStrategy)
+	}
+
+	metrics := newClusterPlacementMetrics(scope)
+	enabledTargets := make([]executioncluster.ExecutionTarget, len(allCandidates))
+	for idx, candidate := range allCandidates {
+		enabledTargets[idx] = candidate.Item.(executioncluster.ExecutionTarget)
+	}
+	health := newClusterHealthTracker(enabledTargets, pingAPIServer, metrics.Healthy)
+	if interval := config.ClusterConfiguration().GetHealthCheckInterval(); interval > 0 {
+		health.start(ctx, interval)
+	}
+	activeExecutions := newActiveExecutionCounter(func(ctx context.Context) (map[string]int64, error) {
+		return db.ExecutionRepo().CountByCluster(ctx, common.GetNonTerminalExecutionPhases())
+	}, config.ClusterConfiguration().GetActiveExecutionCountsRefreshInterval())
 	return &RandomClusterSelector{
-		labelWeightedRandomMap:   labelWeightedRandomMap,
-		executionTargetMap:       executionTargetMap,
-		resourceManager:          resources.NewResourceManager(db, config.ApplicationConfiguration()),
-		equalWeightedAllClusters: equalWeightedAllClusters,
+		candidates:         candidates,
+		executionTargetMap: executionTargetMap,
+		resourceManager:    resources.NewResourceManager(db, config),
+		placementStrategy:  placementStrategy,
+		health:             health,
+		activeExecutions:   activeExecutions,
+		roundRobinCounters: roundRobinCounters,
+		metrics:            metrics,
 	}, nil
 }
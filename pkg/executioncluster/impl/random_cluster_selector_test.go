@@ -2,13 +2,17 @@ package impl
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	repo_interface "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repo_mock "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
@@ -20,8 +24,11 @@ import (
 	interfaces2 "github.com/flyteorg/flyteadmin/pkg/executioncluster/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/runtime"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/config/viper"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/random"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,7 +92,8 @@ func getRandomClusterSelectorForTest(t *testing.T) interfaces2.ClusterInterface
 	}
 	configProvider := runtime.NewConfigurationProvider()
 	var initializationErrorCounter prometheus.Counter
-	randomCluster, err := NewRandomClusterSelector(initializationErrorCounter, configProvider, &mocks.MockExecutionTargetProvider{}, db)
+	randomCluster, err := NewRandomClusterSelector(context.Background(), initializationErrorCounter, configProvider, &mocks.MockExecutionTargetProvider{}, db,
+		promutils.NewTestScope())
 	assert.NoError(t, err)
 	return randomCluster
 }
@@ -140,6 +148,29 @@ func TestRandomClusterSelectorGetTargetForDomainAndExecution2(t *testing.T) {
 	assert.True(t, target.Enabled)
 }
 
+func TestRandomClusterSelectorGetTargetForAssignedLabel(t *testing.T) {
+	err := initTestConfig("clusters_config.yaml")
+	assert.NoError(t, err)
+	db := repo_mock.NewMockRepository()
+	db.ResourceRepo().(*repo_mock.MockResourceRepo).GetFunction = func(ctx context.Context, ID repo_interface.ResourceID) (models.Resource, error) {
+		assert.Fail(t, "an assigned cluster label shouldn't be looked up again")
+		return models.Resource{}, nil
+	}
+	var initializationErrorCounter prometheus.Counter
+	cluster, err := NewRandomClusterSelector(context.Background(), initializationErrorCounter, runtime.NewConfigurationProvider(), &mocks.MockExecutionTargetProvider{}, db,
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+		Project:               testProject,
+		Domain:                testDomain,
+		ExecutionID:           "e1",
+		ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "all"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "testcluster3", target.ID)
+}
+
 func TestRandomClusterSelectorGetRandomTarget(t *testing.T) {
 	cluster := getRandomClusterSelectorForTest(t)
 	target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
@@ -169,3 +200,167 @@ func TestRandomClusterSelectorGetAllValidTargets(t *testing.T) {
 	targets := cluster.GetAllValidTargets()
 	assert.Equal(t, 2, len(targets))
 }
+
+func getPlacementTestSelector(strategy runtimeInterfaces.ClusterPlacementStrategy, db repositories.RepositoryInterface,
+	entries []random.Entry, unhealthy ...string) RandomClusterSelector {
+	targets := make([]executioncluster.ExecutionTarget, len(entries))
+	for idx, entry := range entries {
+		targets[idx] = entry.Item.(executioncluster.ExecutionTarget)
+	}
+	metrics := newClusterPlacementMetrics(promutils.NewTestScope())
+	health := newClusterHealthTracker(targets, func(ctx context.Context, target executioncluster.ExecutionTarget) error {
+		for _, clusterID := range unhealthy {
+			if target.ID == clusterID {
+				return fmt.Errorf("cluster %s is down", clusterID)
+			}
+		}
+		return nil
+	}, metrics.Healthy)
+	health.checkAll(context.Background(), time.Second)
+	activeExecutions := newActiveExecutionCounter(func(ctx context.Context) (map[string]int64, error) {
+		return db.ExecutionRepo().CountByCluster(ctx, common.GetNonTerminalExecutionPhases())
+	}, time.Minute)
+	return RandomClusterSelector{
+		candidates: map[string][]random.Entry{
+			allClustersKey: entries,
+			"label":        entries[:1],
+		},
+		placementStrategy:  strategy,
+		health:             health,
+		activeExecutions:   activeExecutions,
+		roundRobinCounters: map[string]*uint64{allClustersKey: new(uint64), "label": new(uint64)},
+		metrics:            metrics,
+	}
+}
+
+func getPlacementTestEntry(clusterID string, weight float32) random.Entry {
+	return random.Entry{
+		Item:   executioncluster.ExecutionTarget{ID: clusterID, Enabled: true},
+		Weight: weight,
+	}
+}
+
+func TestRandomClusterSelectorWeightedPlacement(t *testing.T) {
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementWeightedRandom, repo_mock.NewMockRepository(),
+		[]random.Entry{getPlacementTestEntry("heavy", 0.75), getPlacementTestEntry("light", 0.25)})
+	placements := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+			ExecutionID:           fmt.Sprintf("e%d", i),
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{},
+		})
+		assert.NoError(t, err)
+		placements[target.ID]++
+	}
+	assert.InDelta(t, 750, placements["heavy"], 50)
+	assert.InDelta(t, 250, placements["light"], 50)
+}
+
+func TestRandomClusterSelectorSkipsUnhealthyClusters(t *testing.T) {
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementWeightedRandom, repo_mock.NewMockRepository(),
+		[]random.Entry{getPlacementTestEntry("a", 0.5), getPlacementTestEntry("b", 0.5)}, "a")
+	for i := 0; i < 20; i++ {
+		target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+			ExecutionID:           fmt.Sprintf("e%d", i),
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "b", target.ID)
+	}
+}
+
+func TestRandomClusterSelectorUnhealthyLabel(t *testing.T) {
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementWeightedRandom, repo_mock.NewMockRepository(),
+		[]random.Entry{getPlacementTestEntry("a", 0.5), getPlacementTestEntry("b", 0.5)}, "a")
+	_, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+		ExecutionID:           "e",
+		ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "label"},
+	})
+	assert.Equal(t, codes.Unavailable, err.(errors.FlyteAdminError).Code())
+}
+
+func TestRandomClusterSelectorNoHealthyClusters(t *testing.T) {
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementWeightedRandom, repo_mock.NewMockRepository(),
+		[]random.Entry{getPlacementTestEntry("a", 0.5), getPlacementTestEntry("b", 0.5)}, "a", "b")
+	_, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+		ExecutionID:           "e",
+		ExecutionClusterLabel: &admin.ExecutionClusterLabel{},
+	})
+	assert.Equal(t, codes.Unavailable, err.(errors.FlyteAdminError).Code())
+}
+
+func TestRandomClusterSelectorRoundRobinPlacement(t *testing.T) {
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementRoundRobin, repo_mock.NewMockRepository(),
+		[]random.Entry{getPlacementTestEntry("a", 0.5), getPlacementTestEntry("b", 0.5), getPlacementTestEntry("c", 0.5)},
+		"b")
+	for _, expectedClusterID := range []string{"a", "c", "a", "c"} {
+		target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+			ExecutionID:           "e",
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedClusterID, target.ID)
+	}
+}
+
+func TestRandomClusterSelectorLeastActivePlacement(t *testing.T) {
+	db := repo_mock.NewMockRepository()
+	var countCalls int
+	db.ExecutionRepo().(*repo_mock.MockExecutionRepo).SetCountByClusterCallback(
+		func(ctx context.Context, phases []string) (map[string]int64, error) {
+			countCalls++
+			assert.Contains(t, phases, "RUNNING")
+			assert.NotContains(t, phases, "SUCCEEDED")
+			return map[string]int64{
+				"a": 10,
+				"b": 3,
+			}, nil
+		})
+	cluster := getPlacementTestSelector(runtimeInterfaces.ClusterPlacementLeastActive, db,
+		[]random.Entry{getPlacementTestEntry("a", 0.5), getPlacementTestEntry("b", 0.5), getPlacementTestEntry("c", 0.5)},
+		"c")
+	placeExecution := func() string {
+		target, err := cluster.GetTarget(context.Background(), &executioncluster.ExecutionTargetSpec{
+			ExecutionID:           "e",
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{},
+		})
+		assert.NoError(t, err)
+		return target.ID
+	}
+	assert.Equal(t, "b", placeExecution())
+	assert.Equal(t, 1, countCalls)
+
+	// Placements count towards their cluster until clusters are counted again.
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, "b", placeExecution())
+	}
+	assert.Equal(t, "a", placeExecution())
+	assert.Equal(t, 1, countCalls)
+
+	cluster.activeExecutions.now = func() time.Time {
+		return time.Now().Add(time.Hour)
+	}
+	assert.Equal(t, "b", placeExecution())
+	assert.Equal(t, 2, countCalls)
+}
+
+func TestClusterHealthTrackerStops(t *testing.T) {
+	pings := make(chan string, 100)
+	health := newClusterHealthTracker([]executioncluster.ExecutionTarget{{ID: "a"}},
+		func(ctx context.Context, target executioncluster.ExecutionTarget) error {
+			pings <- target.ID
+			return nil
+		}, newClusterPlacementMetrics(promutils.NewTestScope()).Healthy)
+	ctx, cancel := context.WithCancel(context.Background())
+	health.start(ctx, time.Millisecond)
+	assert.Equal(t, "a", <-pings)
+	cancel()
+
+	// At most the check in flight when the context was cancelled completes.
+	time.Sleep(20 * time.Millisecond)
+	for len(pings) > 0 {
+		<-pings
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, pings)
+}
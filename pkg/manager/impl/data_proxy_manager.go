@@ -0,0 +1,86 @@
+package impl
+
+import (
+	"context"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/storage"
+	"google.golang.org/grpc/codes"
+
+	dataInterfaces "github.com/flyteorg/flyteadmin/pkg/data/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+)
+
+// Content hashes are encoded in lowercase base32 so that they're safe to use in object keys.
+var contentHashEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type DataProxyManager struct {
+	db            repositories.RepositoryInterface
+	config        runtimeInterfaces.Configuration
+	storageClient *storage.DataStore
+	urlData       dataInterfaces.RemoteURLInterface
+}
+
+// Uploads are partitioned by project, domain and content hash, so that uploading identical artifacts always resolves to
+// the same location rather than storing duplicates.
+func getUploadLocation(ctx context.Context, storageClient *storage.DataStore, storagePrefix string,
+	request interfaces.CreateUploadLocationRequest) (storage.DataReference, error) {
+	contentHash := strings.ToLower(contentHashEncoding.EncodeToString(request.ContentMD5))
+	nestedKeys := make([]string, 0, 5)
+	if len(storagePrefix) > 0 {
+		nestedKeys = append(nestedKeys, storagePrefix)
+	}
+	nestedKeys = append(nestedKeys, request.Project, request.Domain, contentHash, request.Filename)
+	return storageClient.ConstructReference(ctx, storageClient.GetBaseContainerFQN(ctx), nestedKeys...)
+}
+
+func (m *DataProxyManager) CreateUploadLocation(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+	*interfaces.CreateUploadLocationResponse, error) {
+	uploadConfig := m.config.ApplicationConfiguration().GetRemoteDataConfig().Upload
+	if err := validation.ValidateCreateUploadLocationRequest(request, uploadConfig.MaxSizeInBytes); err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateProjectAndDomain(
+		ctx, m.db, m.config.ApplicationConfiguration(), request.Project, request.Domain); err != nil {
+		return nil, err
+	}
+
+	nativeURL, err := getUploadLocation(ctx, m.storageClient, uploadConfig.StoragePrefix, request)
+	if err != nil {
+		logger.Errorf(ctx, "failed to construct upload location for [%s] in project [%s] and domain [%s] with err: %v",
+			request.Filename, request.Project, request.Domain, err)
+		return nil, errors.NewFlyteAdminErrorf(codes.Internal, "failed to construct upload location: %v", err)
+	}
+	expiresIn := uploadConfig.MaxExpiresIn.Duration
+	expiresAt := time.Now().Add(expiresIn)
+	signedURL, err := m.urlData.GetUploadURL(ctx, nativeURL.String(), dataInterfaces.UploadProperties{
+		ContentMD5:    request.ContentMD5,
+		ContentLength: request.ContentLength,
+		ExpiresIn:     expiresIn,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.CreateUploadLocationResponse{
+		SignedURL: signedURL,
+		NativeURL: nativeURL.String(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func NewDataProxyManager(db repositories.RepositoryInterface, config runtimeInterfaces.Configuration,
+	storageClient *storage.DataStore, urlData dataInterfaces.RemoteURLInterface) interfaces.DataProxyInterface {
+	return &DataProxyManager{
+		db:            db,
+		config:        config,
+		storageClient: storageClient,
+		urlData:       urlData,
+	}
+}
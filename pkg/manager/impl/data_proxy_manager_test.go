@@ -0,0 +1,137 @@
+package impl
+
+import (
+	"context"
+	"crypto/md5" // #nosec
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
+	dataInterfaces "github.com/flyteorg/flyteadmin/pkg/data/interfaces"
+	dataMocks "github.com/flyteorg/flyteadmin/pkg/data/mocks"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+)
+
+var uploadContentMD5 = md5.Sum([]byte("archive")) // #nosec
+
+func getDataProxyManagerForTest(urlData dataInterfaces.RemoteURLInterface) interfaces.DataProxyInterface {
+	applicationConfig := runtimeMocks.MockApplicationProvider{}
+	applicationConfig.SetDomainsConfig(runtimeInterfaces.DomainsConfig{
+		{
+			ID:   "domain",
+			Name: "domain",
+		},
+	})
+	applicationConfig.SetRemoteDataConfig(runtimeInterfaces.RemoteDataConfig{
+		Upload: runtimeInterfaces.DataUploadConfig{
+			StoragePrefix:  "uploads",
+			MaxSizeInBytes: 1024,
+			MaxExpiresIn:   config.Duration{Duration: time.Hour},
+		},
+	})
+	configProvider := runtimeMocks.NewMockConfigurationProvider(&applicationConfig, nil, nil, nil, nil, nil)
+	return NewDataProxyManager(repositoryMocks.NewMockRepository(), configProvider,
+		commonMocks.GetMockStorageClient(), urlData)
+}
+
+func getUploadLocationRequestForTest() interfaces.CreateUploadLocationRequest {
+	return interfaces.CreateUploadLocationRequest{
+		Project:       "project",
+		Domain:        "domain",
+		Filename:      "fast-registration.tar.gz",
+		ContentMD5:    uploadContentMD5[:],
+		ContentLength: 512,
+	}
+}
+
+func TestCreateUploadLocation(t *testing.T) {
+	var signedProperties dataInterfaces.UploadProperties
+	urlData := dataMocks.MockRemoteURL{
+		GetUploadURLCallback: func(
+			ctx context.Context, uri string, properties dataInterfaces.UploadProperties) (string, error) {
+			assert.Equal(t, "s3://bucket/uploads/project/domain/rcgq5y3bv43ag43pgijr46zaui/fast-registration.tar.gz", uri)
+			signedProperties = properties
+			return "https://signed", nil
+		},
+	}
+	manager := getDataProxyManagerForTest(&urlData)
+
+	before := time.Now()
+	response, err := manager.CreateUploadLocation(context.Background(), getUploadLocationRequestForTest())
+	assert.NoError(t, err)
+	assert.Equal(t, "https://signed", response.SignedURL)
+	assert.Equal(t, "s3://bucket/uploads/project/domain/rcgq5y3bv43ag43pgijr46zaui/fast-registration.tar.gz",
+		response.NativeURL)
+	assert.False(t, response.ExpiresAt.Before(before.Add(time.Hour)))
+	// The upload is signed for exactly the requested content.
+	assert.Equal(t, dataInterfaces.UploadProperties{
+		ContentMD5:    uploadContentMD5[:],
+		ContentLength: 512,
+		ExpiresIn:     time.Hour,
+	}, signedProperties)
+}
+
+func TestCreateUploadLocation_Deterministic(t *testing.T) {
+	manager := getDataProxyManagerForTest(&dataMocks.MockRemoteURL{})
+	first, err := manager.CreateUploadLocation(context.Background(), getUploadLocationRequestForTest())
+	assert.NoError(t, err)
+
+	second, err := manager.CreateUploadLocation(context.Background(), getUploadLocationRequestForTest())
+	assert.NoError(t, err)
+	assert.Equal(t, first.NativeURL, second.NativeURL)
+
+	otherContent := getUploadLocationRequestForTest()
+	otherContentMD5 := md5.Sum([]byte("other archive")) // #nosec
+	otherContent.ContentMD5 = otherContentMD5[:]
+	third, err := manager.CreateUploadLocation(context.Background(), otherContent)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.NativeURL, third.NativeURL)
+
+	otherProject := getUploadLocationRequestForTest()
+	otherProject.Project = "other-project"
+	fourth, err := manager.CreateUploadLocation(context.Background(), otherProject)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.NativeURL, fourth.NativeURL)
+}
+
+func TestCreateUploadLocation_ExceedsMaxSize(t *testing.T) {
+	manager := getDataProxyManagerForTest(&dataMocks.MockRemoteURL{})
+	request := getUploadLocationRequestForTest()
+	request.ContentLength = 1025
+	_, err := manager.CreateUploadLocation(context.Background(), request)
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestCreateUploadLocation_UnregisteredProject(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ProjectRepo().(*repositoryMocks.MockProjectRepo).GetFunction = func(
+		ctx context.Context, projectID string) (models.Project, error) {
+		return models.Project{}, errors.New("not found")
+	}
+	manager := getDataProxyManagerForTest(&dataMocks.MockRemoteURL{}).(*DataProxyManager)
+	manager.db = repository
+	_, err := manager.CreateUploadLocation(context.Background(), getUploadLocationRequestForTest())
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestCreateUploadLocation_SigningError(t *testing.T) {
+	urlData := dataMocks.MockRemoteURL{
+		GetUploadURLCallback: func(
+			ctx context.Context, uri string, properties dataInterfaces.UploadProperties) (string, error) {
+			return "", flyteAdminErrors.NewFlyteAdminError(codes.Unimplemented, "uploads aren't supported")
+		},
+	}
+	manager := getDataProxyManagerForTest(&urlData)
+	_, err := manager.CreateUploadLocation(context.Background(), getUploadLocationRequestForTest())
+	assert.Equal(t, codes.Unimplemented, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
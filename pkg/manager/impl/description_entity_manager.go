@@ -0,0 +1,181 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/transformers"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+)
+
+// Long descriptions registered without a content type are assumed to be markdown.
+const defaultDescriptionContentType = "text/markdown"
+
+type DescriptionEntityMetrics struct {
+	Scope                    promutils.Scope
+	LongDescriptionSizeBytes prometheus.Summary
+}
+
+type DescriptionEntityManager struct {
+	db      repositories.RepositoryInterface
+	config  runtimeInterfaces.Configuration
+	metrics DescriptionEntityMetrics
+}
+
+// Verifies the workflow, task or launch plan version a description entity refers to has been registered.
+func (d *DescriptionEntityManager) getDescribedEntity(ctx context.Context, id core.Identifier) error {
+	var err error
+	switch id.ResourceType {
+	case core.ResourceType_WORKFLOW:
+		_, err = util.GetWorkflowModel(ctx, d.db, id)
+	case core.ResourceType_TASK:
+		_, err = util.GetTaskModel(ctx, d.db, &id)
+	case core.ResourceType_LAUNCH_PLAN:
+		_, err = util.GetLaunchPlanModel(ctx, d.db, id)
+	default:
+		err = errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"description entities are not supported for resource type %s", id.ResourceType.String())
+	}
+	return err
+}
+
+func (d *DescriptionEntityManager) CreateDescriptionEntity(
+	ctx context.Context, request interfaces.DescriptionEntity) error {
+	ctx = common.WithPrimaryReads(ctx)
+	if len(request.ContentType) == 0 {
+		request.ContentType = defaultDescriptionContentType
+	}
+	if err := validation.ValidateDescriptionEntity(request); err != nil {
+		logger.Debugf(ctx, "invalid description entity [%+v]: %v", request.ID, err)
+		return err
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.ID.Project, request.ID.Domain)
+	if err := d.getDescribedEntity(ctx, *request.ID); err != nil {
+		logger.Debugf(ctx, "failed to find entity [%+v] to describe: %v", request.ID, err)
+		return err
+	}
+
+	descriptionEntityModel := transformers.CreateDescriptionEntityModel(request)
+	if err := d.db.DescriptionEntityRepo().Create(ctx, descriptionEntityModel); err != nil {
+		logger.Debugf(ctx, "failed to create description entity for [%+v] with err %v", request.ID, err)
+		return err
+	}
+	d.metrics.LongDescriptionSizeBytes.Observe(float64(len(descriptionEntityModel.LongDescription)))
+	return nil
+}
+
+func (d *DescriptionEntityManager) GetDescriptionEntity(ctx context.Context, id core.Identifier) (
+	*interfaces.DescriptionEntity, error) {
+	if err := validation.ValidateDescriptionEntityIdentifier(&id); err != nil {
+		logger.Debugf(ctx, "invalid identifier [%+v]: %v", id, err)
+		return nil, err
+	}
+	ctx = contextutils.WithProjectDomain(ctx, id.Project, id.Domain)
+	descriptionEntityModel, err := d.db.DescriptionEntityRepo().Get(ctx, repoInterfaces.GetDescriptionEntityInput{
+		ResourceType: id.ResourceType,
+		Project:      id.Project,
+		Domain:       id.Domain,
+		Name:         id.Name,
+		Version:      id.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transformers.FromDescriptionEntityModel(descriptionEntityModel), nil
+}
+
+func (d *DescriptionEntityManager) ListDescriptionEntities(
+	ctx context.Context, resourceType core.ResourceType, request admin.ResourceListRequest) (
+	*interfaces.DescriptionEntityList, error) {
+	// Check required fields
+	if err := validation.ValidateResourceListRequest(request); err != nil {
+		logger.Debugf(ctx, "invalid request [%+v]: %v", request, err)
+		return nil, err
+	}
+	if _, ok := common.ResourceTypeToEntity[resourceType]; !ok {
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"description entities are not supported for resource type %s", resourceType.String())
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Id.Project, request.Id.Domain)
+
+	filters, err := util.GetDbFilters(util.FilterSpec{
+		Project:        request.Id.Project,
+		Domain:         request.Id.Domain,
+		Name:           request.Id.Name,
+		RequestFilters: request.Filters,
+	}, common.DescriptionEntity)
+	if err != nil {
+		return nil, err
+	}
+	resourceTypeFilter, err := common.NewSingleValueFilter(
+		common.DescriptionEntity, common.Equal, shared.ResourceType, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, resourceTypeFilter)
+
+	var sortParameter common.SortParameter
+	if request.SortBy != nil {
+		sortParameter, err = common.NewSortParameter(*request.SortBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	offset, err := validation.ValidateToken(request.Token)
+	if err != nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid pagination token %s for ListDescriptionEntities", request.Token)
+	}
+	output, err := d.db.DescriptionEntityRepo().List(ctx, repoInterfaces.ListResourceInput{
+		Limit:         int(request.Limit),
+		Offset:        offset,
+		InlineFilters: filters,
+		SortParameter: sortParameter,
+	})
+	if err != nil {
+		logger.Debugf(ctx, "Failed to list description entities with id [%+v] with err %v", request.Id, err)
+		return nil, err
+	}
+
+	var token string
+	if len(output.Entities) == int(request.Limit) {
+		token = strconv.Itoa(offset + len(output.Entities))
+	}
+	return &interfaces.DescriptionEntityList{
+		DescriptionEntities: transformers.FromDescriptionEntityModels(output.Entities),
+		Token:               token,
+	}, nil
+}
+
+func NewDescriptionEntityManager(
+	db repositories.RepositoryInterface,
+	config runtimeInterfaces.Configuration,
+	scope promutils.Scope) interfaces.DescriptionEntityInterface {
+
+	metrics := DescriptionEntityMetrics{
+		Scope: scope,
+		LongDescriptionSizeBytes: scope.MustNewSummary("long_description_size_bytes",
+			"size in bytes of registered long descriptions"),
+	}
+	return &DescriptionEntityManager{
+		db:      db,
+		config:  config,
+		metrics: metrics,
+	}
+}
@@ -0,0 +1,228 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	repositoryErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+)
+
+const longDescription = "# Workflow\nComputes things."
+
+var describedWorkflowIdentifier = core.Identifier{
+	ResourceType: core.ResourceType_WORKFLOW,
+	Project:      project,
+	Domain:       domain,
+	Name:         name,
+	Version:      version,
+}
+
+func getDescriptionEntityManagerForTest() (*repositoryMocks.MockRepository, managerInterfaces.DescriptionEntityInterface) {
+	repository := repositoryMocks.NewMockRepository().(*repositoryMocks.MockRepository)
+	mockConfig := runtimeMocks.NewMockConfigurationProvider(
+		testutils.GetApplicationConfigWithDefaultDomains(), nil, nil, nil, nil, nil)
+	return repository, NewDescriptionEntityManager(repository, mockConfig, mockScope.NewTestScope())
+}
+
+func TestDescriptionEntityManager_Create(t *testing.T) {
+	repository, manager := getDescriptionEntityManagerForTest()
+	var created bool
+	repository.DescriptionEntityRepo().(*repositoryMocks.MockDescriptionEntityRepo).SetCreateCallback(
+		func(input models.DescriptionEntity) error {
+			assert.Equal(t, core.ResourceType_WORKFLOW, input.ResourceType)
+			assert.Equal(t, version, input.Version)
+			assert.Equal(t, []byte(longDescription), input.LongDescription)
+			// No content type was specified so the long description is assumed to be markdown.
+			assert.Equal(t, "text/markdown", input.ContentType)
+			assert.Equal(t, "https://github.com/flyteorg/flytesnacks", input.SourceCodeLink)
+			created = true
+			return nil
+		})
+
+	err := manager.CreateDescriptionEntity(context.Background(), managerInterfaces.DescriptionEntity{
+		ID:              &describedWorkflowIdentifier,
+		LongDescription: longDescription,
+		SourceCodeLink:  "https://github.com/flyteorg/flytesnacks",
+	})
+	assert.NoError(t, err)
+	assert.True(t, created)
+}
+
+func TestDescriptionEntityManager_Create_TooLarge(t *testing.T) {
+	repository, manager := getDescriptionEntityManagerForTest()
+	repository.DescriptionEntityRepo().(*repositoryMocks.MockDescriptionEntityRepo).SetCreateCallback(
+		func(input models.DescriptionEntity) error {
+			assert.Fail(t, "description entity should not be created")
+			return nil
+		})
+
+	err := manager.CreateDescriptionEntity(context.Background(), managerInterfaces.DescriptionEntity{
+		ID:              &describedWorkflowIdentifier,
+		LongDescription: strings.Repeat("a", 64*1024+1),
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(adminErrors.FlyteAdminError).Code())
+}
+
+func TestDescriptionEntityManager_Create_MissingEntity(t *testing.T) {
+	repository, manager := getDescriptionEntityManagerForTest()
+	launchPlanIdentifier := describedWorkflowIdentifier
+	launchPlanIdentifier.ResourceType = core.ResourceType_LAUNCH_PLAN
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.LaunchPlan, error) {
+			return models.LaunchPlan{}, repositoryErrors.GetMissingEntityError("launch plan", &launchPlanIdentifier)
+		})
+	repository.DescriptionEntityRepo().(*repositoryMocks.MockDescriptionEntityRepo).SetCreateCallback(
+		func(input models.DescriptionEntity) error {
+			assert.Fail(t, "description entity should not be created")
+			return nil
+		})
+
+	err := manager.CreateDescriptionEntity(context.Background(), managerInterfaces.DescriptionEntity{
+		ID:              &launchPlanIdentifier,
+		LongDescription: longDescription,
+		ContentType:     "text/plain",
+	})
+	assert.Equal(t, codes.NotFound, err.(adminErrors.FlyteAdminError).Code())
+}
+
+func TestDescriptionEntityManager_Get(t *testing.T) {
+	repository, manager := getDescriptionEntityManagerForTest()
+	repository.DescriptionEntityRepo().(*repositoryMocks.MockDescriptionEntityRepo).SetGetCallback(
+		func(input interfaces.GetDescriptionEntityInput) (models.DescriptionEntity, error) {
+			assert.Equal(t, interfaces.GetDescriptionEntityInput{
+				ResourceType: core.ResourceType_WORKFLOW,
+				Project:      project,
+				Domain:       domain,
+				Name:         name,
+				Version:      version,
+			}, input)
+			return models.DescriptionEntity{
+				DescriptionEntityKey: models.DescriptionEntityKey{
+					ResourceType: input.ResourceType,
+					Project:      input.Project,
+					Domain:       input.Domain,
+					Name:         input.Name,
+					Version:      input.Version,
+				},
+				LongDescription: []byte(longDescription),
+				ContentType:     "text/markdown",
+			}, nil
+		})
+
+	descriptionEntity, err := manager.GetDescriptionEntity(context.Background(), describedWorkflowIdentifier)
+	assert.NoError(t, err)
+	assert.Equal(t, longDescription, descriptionEntity.LongDescription)
+	assert.Equal(t, "text/markdown", descriptionEntity.ContentType)
+	assert.Equal(t, version, descriptionEntity.ID.Version)
+
+	_, err = manager.GetDescriptionEntity(context.Background(), core.Identifier{
+		ResourceType: core.ResourceType_DATASET,
+		Project:      project,
+		Domain:       domain,
+		Name:         name,
+		Version:      version,
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(adminErrors.FlyteAdminError).Code())
+}
+
+func TestDescriptionEntityManager_List(t *testing.T) {
+	repository, manager := getDescriptionEntityManagerForTest()
+	repository.DescriptionEntityRepo().(*repositoryMocks.MockDescriptionEntityRepo).SetListCallback(
+		func(input interfaces.ListResourceInput) (interfaces.DescriptionEntityCollectionOutput, error) {
+			var projectFilter, domainFilter, nameFilter, resourceTypeFilter bool
+			for _, filter := range input.InlineFilters {
+				assert.Equal(t, common.DescriptionEntity, filter.GetEntity())
+				queryExpr, _ := filter.GetGormQueryExpr()
+				if queryExpr.Args == project && queryExpr.Query == testutils.ProjectQueryPattern {
+					projectFilter = true
+				}
+				if queryExpr.Args == domain && queryExpr.Query == testutils.DomainQueryPattern {
+					domainFilter = true
+				}
+				if queryExpr.Args == name && queryExpr.Query == testutils.NameQueryPattern {
+					nameFilter = true
+				}
+				if queryExpr.Args == core.ResourceType_TASK && queryExpr.Query == "resource_type = ?" {
+					resourceTypeFilter = true
+				}
+			}
+			assert.True(t, projectFilter, "Missing project equality filter")
+			assert.True(t, domainFilter, "Missing domain equality filter")
+			assert.True(t, nameFilter, "Missing name equality filter")
+			assert.True(t, resourceTypeFilter, "Missing resource type filter")
+			assert.Equal(t, 2, input.Limit)
+			assert.Equal(t, "created_at desc", input.SortParameter.GetGormOrderExpr())
+
+			entities := make([]models.DescriptionEntity, 0, 2)
+			for _, entityVersion := range []string{"v1", "v2"} {
+				entities = append(entities, models.DescriptionEntity{
+					DescriptionEntityKey: models.DescriptionEntityKey{
+						ResourceType: core.ResourceType_TASK,
+						Project:      project,
+						Domain:       domain,
+						Name:         name,
+						Version:      entityVersion,
+					},
+					LongDescription: []byte(longDescription),
+					ContentType:     "text/markdown",
+				})
+			}
+			return interfaces.DescriptionEntityCollectionOutput{Entities: entities}, nil
+		})
+
+	descriptionEntityList, err := manager.ListDescriptionEntities(context.Background(), core.ResourceType_TASK,
+		admin.ResourceListRequest{
+			Id: &admin.NamedEntityIdentifier{
+				Project: project,
+				Domain:  domain,
+				Name:    name,
+			},
+			Limit: 2,
+			SortBy: &admin.Sort{
+				Key:       "created_at",
+				Direction: admin.Sort_DESCENDING,
+			},
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", descriptionEntityList.Token)
+	assert.Len(t, descriptionEntityList.DescriptionEntities, 2)
+	assert.Equal(t, "v1", descriptionEntityList.DescriptionEntities[0].ID.Version)
+	assert.Equal(t, core.ResourceType_TASK, descriptionEntityList.DescriptionEntities[1].ID.ResourceType)
+}
+
+func TestDescriptionEntityManager_List_BadRequest(t *testing.T) {
+	_, manager := getDescriptionEntityManagerForTest()
+	_, err := manager.ListDescriptionEntities(context.Background(), core.ResourceType_WORKFLOW,
+		admin.ResourceListRequest{
+			Id: &admin.NamedEntityIdentifier{
+				Domain: domain,
+			},
+			Limit: 2,
+		})
+	assert.Error(t, err)
+
+	_, err = manager.ListDescriptionEntities(context.Background(), core.ResourceType_DATASET,
+		admin.ResourceListRequest{
+			Id: &admin.NamedEntityIdentifier{
+				Project: project,
+				Domain:  domain,
+			},
+			Limit: 2,
+		})
+	assert.Equal(t, codes.InvalidArgument, err.(adminErrors.FlyteAdminError).Code())
+}
@@ -3,7 +3,8 @@ package impl
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine"
@@ -12,8 +13,6 @@ import (
 
 	"github.com/flyteorg/flyteadmin/auth"
 
-	"k8s.io/apimachinery/pkg/api/resource"
-
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 
 	dataInterfaces "github.com/flyteorg/flyteadmin/pkg/data/interfaces"
@@ -44,6 +43,7 @@ import (
 	workflowengineInterfaces "github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"google.golang.org/grpc/codes"
 
 	"github.com/benbjohnson/clock"
@@ -57,20 +57,27 @@ const childContainerQueueKey = "child_queue"
 type projectDomainScopedStopWatchMap = map[string]map[string]*promutils.StopWatch
 
 type executionSystemMetrics struct {
-	Scope                      promutils.Scope
-	ActiveExecutions           prometheus.Gauge
-	ExecutionsCreated          prometheus.Counter
-	ExecutionsTerminated       prometheus.Counter
-	ExecutionEventsCreated     prometheus.Counter
-	PropellerFailures          prometheus.Counter
-	PublishNotificationError   prometheus.Counter
-	TransformerError           prometheus.Counter
-	UnexpectedDataError        prometheus.Counter
-	SpecSizeBytes              prometheus.Summary
-	ClosureSizeBytes           prometheus.Summary
-	AcceptanceDelay            prometheus.Summary
-	PublishEventError          prometheus.Counter
-	TerminateExecutionFailures prometheus.Counter
+	Scope                       promutils.Scope
+	ActiveExecutions            prometheus.Gauge
+	ExecutionsCreated           prometheus.Counter
+	ExecutionsTerminated        prometheus.Counter
+	ExecutionEventsCreated      prometheus.Counter
+	DuplicateExecutionEvents    prometheus.Counter
+	StaleExecutionEvents        prometheus.Counter
+	PropellerFailures           prometheus.Counter
+	PublishNotificationError    prometheus.Counter
+	TransformerError            prometheus.Counter
+	UnexpectedDataError         prometheus.Counter
+	SpecSizeBytes               prometheus.Summary
+	ClosureSizeBytes            prometheus.Summary
+	AcceptanceDelay             prometheus.Summary
+	PublishEventError           prometheus.Counter
+	TerminateExecutionFailures  prometheus.Counter
+	ScheduledExecutionsSkipped  prometheus.Counter
+	ScheduledExecutionsReplaced prometheus.Counter
+	ScheduledExecutionsDropped  prometheus.Counter
+	QueueingBudgetExceeded      prometheus.Counter
+	PurgedRows                  *prometheus.CounterVec
 }
 
 type executionUserMetrics struct {
@@ -89,14 +96,23 @@ type ExecutionManager struct {
 	_clock                    clock.Clock
 	systemMetrics             executionSystemMetrics
 	userMetrics               executionUserMetrics
+	projectMetrics            executionProjectMetrics
 	notificationClient        notificationInterfaces.Publisher
+	notificationRateLimiter   *notifications.RateLimiter
+	emailTemplates            *notifications.EmailTemplates
 	urlData                   dataInterfaces.RemoteURLInterface
 	workflowManager           interfaces.WorkflowInterface
 	namedEntityManager        interfaces.NamedEntityInterface
 	resourceManager           interfaces.ResourceInterface
+	attributesResolver        *resources.AttributesResolver
 	qualityOfServiceAllocator executions.QualityOfServiceAllocator
 	eventPublisher            notificationInterfaces.Publisher
 	dbEventWriter             eventWriter.WorkflowExecutionEventWriter
+	countCache                *util.CountCache
+	activeExecutionCounts     *util.CountCache
+	workflowClosures          *util.WorkflowClosureCache
+	lookupCache               *util.LookupCache
+	launcher                  *executions.Launcher
 }
 
 func getExecutionContext(ctx context.Context, id *core.WorkflowExecutionIdentifier) context.Context {
@@ -104,14 +120,31 @@ func getExecutionContext(ctx context.Context, id *core.WorkflowExecutionIdentifi
 	return contextutils.WithProjectDomain(ctx, id.Project, id.Domain)
 }
 
-// Returns the unique string which identifies the authenticated end user (if any).
+// Returns the unique string which identifies the authenticated end user (if any) or, for machine tokens that don't
+// identify a user, the app.
 func getUser(ctx context.Context) string {
 	identityContext := auth.IdentityContextFromContext(ctx)
-	return identityContext.UserID()
+	if len(identityContext.UserID()) > 0 {
+		return identityContext.UserID()
+	}
+
+	return identityContext.AppID()
+}
+
+// Records who launched an execution. The authenticated identity can't be overridden by the principal clients send, which
+// is only kept when the request isn't authenticated.
+func setPrincipal(ctx context.Context, metadata *admin.ExecutionMetadata) {
+	if auth.IdentityContextFromContext(ctx).IsEmpty() {
+		return
+	}
+
+	metadata.Principal = getUser(ctx)
 }
 
+// Assigns the tasks of the workflow to the execution queue matched for it, and returns the queue, which is empty when
+// none matched.
 func (m *ExecutionManager) populateExecutionQueue(
-	ctx context.Context, identifier core.Identifier, compiledWorkflow *core.CompiledWorkflowClosure) {
+	ctx context.Context, identifier core.Identifier, compiledWorkflow *core.CompiledWorkflowClosure) string {
 	queueConfig := m.queueAllocator.GetQueue(ctx, identifier)
 	for _, task := range compiledWorkflow.Tasks {
 		container := task.Template.GetContainer()
@@ -128,6 +161,7 @@ func (m *ExecutionManager) populateExecutionQueue(
 			})
 		}
 	}
+	return queueConfig.DynamicQueue
 }
 
 func validateMapSize(maxEntries int, candidate map[string]string, candidateName string) error {
@@ -142,16 +176,19 @@ func validateMapSize(maxEntries int, candidate map[string]string, candidateName
 	return nil
 }
 
-type mapWithValues interface {
-	GetValues() map[string]string
-}
-
-func resolveStringMap(preferredValues, defaultValues mapWithValues, valueName string, maxEntries int) (map[string]string, error) {
+// resolveStringMap merges the layers of labels or annotations applied to an execution, ordered from least to most
+// specific. A more specific layer wins whenever keys conflict, and an empty value in a more specific layer deletes the
+// key inherited from the layers before it.
+func resolveStringMap(valueName string, maxEntries int, layers ...map[string]string) (map[string]string, error) {
 	var response = make(map[string]string)
-	if preferredValues != nil && preferredValues.GetValues() != nil {
-		response = preferredValues.GetValues()
-	} else if defaultValues != nil && defaultValues.GetValues() != nil {
-		response = defaultValues.GetValues()
+	for _, layer := range layers {
+		for key, value := range layer {
+			if len(value) == 0 {
+				delete(response, key)
+				continue
+			}
+			response[key] = value
+		}
 	}
 
 	err := validateMapSize(maxEntries, response, valueName)
@@ -163,18 +200,16 @@ func resolveStringMap(preferredValues, defaultValues mapWithValues, valueName st
 
 func (m *ExecutionManager) addPluginOverrides(ctx context.Context, executionID *core.WorkflowExecutionIdentifier,
 	workflowName, launchPlanName string) ([]*admin.PluginOverride, error) {
-	override, err := m.resourceManager.GetResource(ctx, interfaces.ResourceRequest{
-		Project:      executionID.Project,
-		Domain:       executionID.Domain,
-		Workflow:     workflowName,
-		LaunchPlan:   launchPlanName,
-		ResourceType: admin.MatchableResource_PLUGIN_OVERRIDE,
-	})
+	override, err := m.attributesResolver.ResolveMatchingAttributes(ctx,
+		interfaces.ResourceRequest{
+			Project:      executionID.Project,
+			Domain:       executionID.Domain,
+			Workflow:     workflowName,
+			LaunchPlan:   launchPlanName,
+			ResourceType: admin.MatchableResource_PLUGIN_OVERRIDE,
+		})
 	if err != nil {
-		ec, ok := err.(errors.FlyteAdminError)
-		if !ok || ec.Code() != codes.NotFound {
-			return nil, err
-		}
+		return nil, err
 	}
 	if override != nil && override.Attributes != nil && override.Attributes.GetPluginOverrides() != nil {
 		return override.Attributes.GetPluginOverrides().Overrides, nil
@@ -194,14 +229,14 @@ func getTaskResourcesAsSet(ctx context.Context, identifier *core.Identifier,
 	for _, entry := range resourceEntries {
 		switch entry.Name {
 		case core.Resources_CPU:
-			result.CPU = parseQuantityNoError(ctx, identifier.String(), fmt.Sprintf("%v.cpu", resourceName), entry.Value)
+			result.CPU = resources.ParseQuantityNoError(ctx, identifier.String(), fmt.Sprintf("%v.cpu", resourceName), entry.Value)
 		case core.Resources_MEMORY:
-			result.Memory = parseQuantityNoError(ctx, identifier.String(), fmt.Sprintf("%v.memory", resourceName), entry.Value)
+			result.Memory = resources.ParseQuantityNoError(ctx, identifier.String(), fmt.Sprintf("%v.memory", resourceName), entry.Value)
 		case core.Resources_EPHEMERAL_STORAGE:
-			result.EphemeralStorage = parseQuantityNoError(ctx, identifier.String(),
+			result.EphemeralStorage = resources.ParseQuantityNoError(ctx, identifier.String(),
 				fmt.Sprintf("%v.ephemeral storage", resourceName), entry.Value)
 		case core.Resources_GPU:
-			result.GPU = parseQuantityNoError(ctx, identifier.String(), "gpu", entry.Value)
+			result.GPU = resources.ParseQuantityNoError(ctx, identifier.String(), "gpu", entry.Value)
 		}
 	}
 
@@ -330,66 +365,12 @@ func (m *ExecutionManager) setCompiledTaskDefaults(ctx context.Context, task *co
 	}
 }
 
-func parseQuantityNoError(ctx context.Context, ownerID, name, value string) resource.Quantity {
-	q, err := resource.ParseQuantity(value)
-	if err != nil {
-		logger.Infof(ctx, "Failed to parse owner's [%s] resource [%s]'s value [%s] with err: %v", ownerID, name, value, err)
-	}
-
-	return q
-}
-
-func fromAdminProtoTaskResourceSpec(ctx context.Context, spec *admin.TaskResourceSpec) runtimeInterfaces.TaskResourceSet {
-	result := runtimeInterfaces.TaskResourceSet{}
-	if len(spec.Cpu) > 0 {
-		result.CPU = parseQuantityNoError(ctx, "project", "cpu", spec.Cpu)
-	}
-
-	if len(spec.Memory) > 0 {
-		result.Memory = parseQuantityNoError(ctx, "project", "memory", spec.Memory)
-	}
-
-	if len(spec.Storage) > 0 {
-		result.Storage = parseQuantityNoError(ctx, "project", "storage", spec.Storage)
-	}
-
-	if len(spec.EphemeralStorage) > 0 {
-		result.EphemeralStorage = parseQuantityNoError(ctx, "project", "ephemeral storage", spec.EphemeralStorage)
-	}
-
-	if len(spec.Gpu) > 0 {
-		result.GPU = parseQuantityNoError(ctx, "project", "gpu", spec.Gpu)
-	}
-
-	return result
-}
-
-func (m *ExecutionManager) getTaskResources(ctx context.Context, workflow *core.Identifier) workflowengineInterfaces.TaskResources {
-	resource, err := m.resourceManager.GetResource(ctx, interfaces.ResourceRequest{
-		Project:      workflow.Project,
-		Domain:       workflow.Domain,
-		Workflow:     workflow.Name,
-		ResourceType: admin.MatchableResource_TASK_RESOURCE,
-	})
-
-	if err != nil {
-		logger.Warningf(ctx, "Failed to fetch override values when assigning task resource default values for [%+v]: %v",
-			workflow, err)
-	}
-
+func (m *ExecutionManager) getTaskResources(ctx context.Context, workflow *core.Identifier) (
+	workflowengineInterfaces.TaskResources, error) {
 	logger.Debugf(ctx, "Assigning task requested resources for [%+v]", workflow)
-	var taskResourceAttributes = workflowengineInterfaces.TaskResources{}
-	if resource != nil && resource.Attributes != nil && resource.Attributes.GetTaskResourceAttributes() != nil {
-		taskResourceAttributes.Defaults = fromAdminProtoTaskResourceSpec(ctx, resource.Attributes.GetTaskResourceAttributes().Defaults)
-		taskResourceAttributes.Limits = fromAdminProtoTaskResourceSpec(ctx, resource.Attributes.GetTaskResourceAttributes().Limits)
-	} else {
-		taskResourceAttributes = workflowengineInterfaces.TaskResources{
-			Defaults: m.config.TaskResourceConfiguration().GetDefaults(),
-			Limits:   m.config.TaskResourceConfiguration().GetLimits(),
-		}
-	}
-
-	return taskResourceAttributes
+	taskResources, _, err := m.attributesResolver.ResolveTaskResources(
+		ctx, workflow.Project, workflow.Domain, workflow.Name)
+	return taskResources, err
 }
 
 // Fetches inherited execution metadata including the parent node execution db model id and the source execution model id
@@ -429,6 +410,38 @@ func (m *ExecutionManager) getInheritedExecMetadata(ctx context.Context, request
 	return parentNodeExecutionID, sourceExecutionID, nil
 }
 
+// Executions launched from a launch plan node inherit the tags of their parent execution, so that the whole execution
+// tree can be listed or terminated by tag.
+func (m *ExecutionManager) getInheritedTags(ctx context.Context, requestSpec *admin.ExecutionSpec,
+	workflowExecutionID core.WorkflowExecutionIdentifier) ([]models.ExecutionTag, error) {
+	parentExecutionID := requestSpec.GetMetadata().GetParentNodeExecution().GetExecutionId()
+	if parentExecutionID == nil {
+		return nil, nil
+	}
+	parentTags, err := m.db.ExecutionRepo().GetTags(ctx, repositoryInterfaces.Identifier{
+		Project: parentExecutionID.Project,
+		Domain:  parentExecutionID.Domain,
+		Name:    parentExecutionID.Name,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the tags of workflow execution [%+v] that launched this execution [%+v] "+
+			"with error %v", parentExecutionID, workflowExecutionID, err)
+		return nil, err
+	}
+	tags := make([]models.ExecutionTag, len(parentTags))
+	for idx, parentTag := range parentTags {
+		tags[idx] = models.ExecutionTag{
+			ExecutionKey: models.ExecutionKey{
+				Project: workflowExecutionID.Project,
+				Domain:  workflowExecutionID.Domain,
+				Name:    workflowExecutionID.Name,
+			},
+			Tag: parentTag.Tag,
+		}
+	}
+	return tags, nil
+}
+
 // Produces execution-time attributes for workflow execution.
 // Defaults to overridable execution values set in the execution create request, then looks at the launch plan values
 // (if any) before defaulting to values set in the matchable resource db and further if matchable resources don't
@@ -446,29 +459,53 @@ func (m *ExecutionManager) getExecutionConfig(ctx context.Context, request *admi
 		}, nil
 	}
 
-	resource, err := m.resourceManager.GetResource(ctx, interfaces.ResourceRequest{
-		Project:      request.Project,
-		Domain:       request.Domain,
-		ResourceType: admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG,
-	})
-	if err != nil {
-		if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
-			logger.Errorf(ctx, "Failed to get workflow execution config overrides with error: %v", err)
-			return nil, err
+	executionConfig, _, err := m.attributesResolver.ResolveExecutionConfig(
+		ctx, request.Project, request.Domain)
+	return executionConfig, err
+}
+
+// Resolves the cluster label an execution is assigned to from the annotation requesting one on its spec, or else from
+// the matchable attributes of its project, domain, workflow and launch plan. The execution is created in one of the
+// clusters mapped to the label, executions without an assignment may run in any enabled cluster.
+func (m *ExecutionManager) getExecutionClusterLabel(ctx context.Context, workflowExecutionID *core.WorkflowExecutionIdentifier,
+	requestAnnotations *admin.Annotations, workflowName, launchPlanName string) (*admin.ExecutionClusterLabel, error) {
+	if label, ok := requestAnnotations.GetValues()[common.ExecutionClusterLabelAnnotation]; ok {
+		if m.config.ApplicationConfiguration().GetTopLevelConfig().DisableExecutionClusterOverrides {
+			return nil, errors.NewFlyteAdminErrorf(codes.PermissionDenied,
+				"executions can't choose the cluster they run in, remove the %s annotation",
+				common.ExecutionClusterLabelAnnotation)
+		}
+		if len(label) == 0 {
+			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"the %s annotation can't be empty", common.ExecutionClusterLabelAnnotation)
 		}
+		return &admin.ExecutionClusterLabel{Value: label}, nil
+	}
+	resource, err := m.attributesResolver.ResolveMatchingAttributes(ctx,
+		interfaces.ResourceRequest{
+			Project:      workflowExecutionID.Project,
+			Domain:       workflowExecutionID.Domain,
+			Workflow:     workflowName,
+			LaunchPlan:   launchPlanName,
+			ResourceType: admin.MatchableResource_EXECUTION_CLUSTER_LABEL,
+		})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the cluster assignment of execution [%+v] with error: %v",
+			workflowExecutionID, err)
+		return nil, err
 	}
-	if resource != nil && resource.Attributes.GetWorkflowExecutionConfig() != nil {
-		return resource.Attributes.GetWorkflowExecutionConfig(), nil
+	if resource == nil {
+		return nil, nil
 	}
-	// Defaults to one from the application config
-	return &admin.WorkflowExecutionConfig{
-		MaxParallelism: m.config.ApplicationConfiguration().GetTopLevelConfig().GetMaxParallelism(),
-	}, nil
+	return resource.Attributes.GetExecutionClusterLabel(), nil
 }
 
-func (m *ExecutionManager) launchSingleTaskExecution(
-	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
-	context.Context, *models.Execution, error) {
+// Prepares the execution of a single task, along with the workflow and launch plan wrapping it, recording it in the
+// given phase.
+func (m *ExecutionManager) prepareSingleTaskExecution(
+	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time,
+	phase core.WorkflowExecution_Phase) (context.Context, *models.Execution, *workflowengineInterfaces.ExecutionData,
+	error) {
 
 	taskModel, err := m.db.TaskRepo().Get(ctx, repositoryInterfaces.Identifier{
 		Project: request.Spec.LaunchPlan.Project,
@@ -477,11 +514,11 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 		Version: request.Spec.LaunchPlan.Version,
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	task, err := transformers.FromTaskModel(taskModel)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Prepare a skeleton workflow
@@ -490,15 +527,15 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 		util.CreateOrGetWorkflowModel(ctx, request, m.db, m.workflowManager, m.namedEntityManager, taskIdentifier, &task)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to created skeleton workflow for [%+v] with err: %v", taskIdentifier, err)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	workflow, err := transformers.FromWorkflowModel(*workflowModel)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	closure, err := util.FetchAndGetWorkflowClosure(ctx, m.storageClient, workflowModel.RemoteClosureIdentifier)
+	closure, err := m.workflowClosures.Get(ctx, workflowModel.RemoteClosureIdentifier)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	closure.CreatedAt = workflow.Closure.CreatedAt
 	workflow.Closure = closure
@@ -506,7 +543,7 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 	launchPlan, err := util.CreateOrGetLaunchPlan(ctx, m.db, m.config, taskIdentifier,
 		workflow.Closure.CompiledWorkflow.Primary.Template.Interface, workflowModel.ID, request.Spec)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	name := util.GetExecutionName(request)
@@ -516,76 +553,101 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 		Name:    name,
 	}
 	ctx = getExecutionContext(ctx, &workflowExecutionID)
-	namespace := common.GetNamespaceName(
-		m.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), workflowExecutionID.Project, workflowExecutionID.Domain)
+	namespace, err := m.getNamespace(ctx, workflowExecutionID.Project, workflowExecutionID.Domain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	requestSpec := request.Spec
 	if requestSpec.Metadata == nil {
 		requestSpec.Metadata = &admin.ExecutionMetadata{}
 	}
-	requestSpec.Metadata.Principal = getUser(ctx)
+	setPrincipal(ctx, requestSpec.Metadata)
 
 	// Get the node execution (if any) that launched this execution
 	var parentNodeExecutionID uint
 	var sourceExecutionID uint
 	parentNodeExecutionID, sourceExecutionID, err = m.getInheritedExecMetadata(ctx, requestSpec, &workflowExecutionID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Dynamically assign task resource defaults.
-	platformTaskResources := m.getTaskResources(ctx, workflow.Id)
+	platformTaskResources, err := m.getTaskResources(ctx, workflow.Id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	for _, t := range workflow.Closure.CompiledWorkflow.Tasks {
 		m.setCompiledTaskDefaults(ctx, t, platformTaskResources)
 	}
 
 	// Dynamically assign execution queues.
-	m.populateExecutionQueue(ctx, *workflow.Id, workflow.Closure.CompiledWorkflow)
+	queue := m.populateExecutionQueue(ctx, *workflow.Id, workflow.Closure.CompiledWorkflow)
 
 	inputsURI, err := common.OffloadLiteralMap(ctx, m.storageClient, request.Inputs, workflowExecutionID.Project, workflowExecutionID.Domain, workflowExecutionID.Name, shared.Inputs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	userInputsURI, err := common.OffloadLiteralMap(ctx, m.storageClient, request.Inputs, workflowExecutionID.Project, workflowExecutionID.Domain, workflowExecutionID.Name, shared.UserInputs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	executionConfig, err := m.getExecutionConfig(ctx, &request, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	var labels map[string]string
-	if requestSpec.Labels != nil {
-		labels = requestSpec.Labels.Values
+	labels, err := m.resolveLabels(ctx, request.Project, launchPlan.GetSpec().GetLabels(), requestSpec.GetLabels())
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	labels, err = m.addProjectLabels(ctx, request.Project, labels)
+	annotations, err := m.resolveAnnotations(launchPlan.GetSpec().GetAnnotations(), requestSpec.GetAnnotations())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	var annotations map[string]string
-	if requestSpec.Annotations != nil {
-		annotations = requestSpec.Annotations.Values
+	envs, err := m.resolveEnvs(ctx, request.Project, request.Domain, launchPlan.GetSpec().GetAnnotations(),
+		requestSpec.GetAnnotations())
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	resolvedAuthRole := resolveAuthRole(request, launchPlan)
-	resolvedSecurityCtx := resolveSecurityCtx(ctx, request, launchPlan, resolvedAuthRole)
+	resolvedAuthRole, resolvedSecurityCtx, err := m.resolvePermissions(ctx, request, launchPlan)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The stored spec records the resolved permissions, so that relaunches run as the same identity.
+	requestSpec.AuthRole = resolvedAuthRole
+	requestSpec.SecurityContext = resolvedSecurityCtx
+	qualityOfService, err := m.qualityOfServiceAllocator.GetQualityOfService(ctx, executions.GetQualityOfServiceInput{
+		Workflow:               &workflow,
+		LaunchPlan:             launchPlan,
+		ExecutionCreateRequest: &request,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get quality of service for [%+v] with error: %v", workflowExecutionID, err)
+		return nil, nil, nil, err
+	}
+	if err = m.checkQueueingBudget(qualityOfService.QueuingBudget, requestedAt); err != nil {
+		return nil, nil, nil, err
+	}
 	executionParameters := workflowengineInterfaces.ExecutionParameters{
 		Inputs:              request.Inputs,
 		AcceptedAt:          requestedAt,
 		Labels:              labels,
 		Annotations:         annotations,
+		Envs:                envs,
 		ExecutionConfig:     executionConfig,
 		SecurityContext:     resolvedSecurityCtx,
 		TaskResources:       &platformTaskResources,
 		EventVersion:        m.config.ApplicationConfiguration().GetTopLevelConfig().EventVersion,
 		RoleNameKey:         m.config.ApplicationConfiguration().GetTopLevelConfig().RoleNameKey,
 		RawOutputDataConfig: launchPlan.Spec.RawOutputDataConfig,
+		QueueingBudget:      qualityOfService.QueuingBudget,
 	}
 
 	overrides, err := m.addPluginOverrides(ctx, &workflowExecutionID, workflowExecutionID.Name, "")
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if overrides != nil {
 		executionParameters.TaskPluginOverrides = overrides
@@ -595,24 +657,20 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 		executionParameters.RecoveryExecution = request.Spec.Metadata.ReferenceExecution
 	}
 
-	execInfo, err := workflowengine.GetRegistry().GetExecutor().Execute(ctx, workflowengineInterfaces.ExecutionData{
+	executionClusterLabel, err := m.getExecutionClusterLabel(
+		ctx, &workflowExecutionID, requestSpec.GetAnnotations(), workflow.Id.Name, launchPlan.Id.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	executionData := &workflowengineInterfaces.ExecutionData{
 		Namespace:               namespace,
 		ExecutionID:             &workflowExecutionID,
 		ReferenceWorkflowName:   workflow.Id.Name,
 		ReferenceLaunchPlanName: launchPlan.Id.Name,
 		WorkflowClosure:         workflow.Closure.CompiledWorkflow,
 		ExecutionParameters:     executionParameters,
-	})
-
-	if err != nil {
-		m.systemMetrics.PropellerFailures.Inc()
-		logger.Infof(ctx, "Failed to execute workflow %+v with execution id %+v and inputs %+v with err %v",
-			request, workflowExecutionID, request.Inputs, err)
-		return nil, nil, err
+		ExecutionClusterLabel:   executionClusterLabel,
 	}
-	executionCreatedAt := time.Now()
-	acceptanceDelay := executionCreatedAt.Sub(requestedAt)
-	m.systemMetrics.AcceptanceDelay.Observe(acceptanceDelay.Seconds())
 
 	// Request notification settings takes precedence over the launch plan settings.
 	// If there is no notification in the request and DisableAll is not true, use the settings from the launch plan.
@@ -628,28 +686,30 @@ func (m *ExecutionManager) launchSingleTaskExecution(
 	}
 
 	executionModel, err := transformers.CreateExecutionModel(transformers.CreateExecutionModelInput{
-		WorkflowExecutionID: workflowExecutionID,
-		RequestSpec:         requestSpec,
-		TaskID:              taskModel.ID,
-		WorkflowID:          workflowModel.ID,
-		// The execution is not considered running until the propeller sends a specific event saying so.
-		Phase:                 core.WorkflowExecution_UNDEFINED,
+		WorkflowExecutionID:   workflowExecutionID,
+		RequestSpec:           requestSpec,
+		TaskID:                taskModel.ID,
+		WorkflowID:            workflowModel.ID,
+		Phase:                 phase,
 		CreatedAt:             m._clock.Now(),
 		Notifications:         notificationsSettings,
 		WorkflowIdentifier:    workflow.Id,
 		ParentNodeExecutionID: parentNodeExecutionID,
 		SourceExecutionID:     sourceExecutionID,
-		Cluster:               execInfo.Cluster,
+		Namespace:             namespace,
+		Queue:                 queue,
+		Envs:                  envs,
+		MaxParallelism:        executionConfig.GetMaxParallelism(),
 		InputsURI:             inputsURI,
 		UserInputsURI:         userInputsURI,
 	})
 	if err != nil {
 		logger.Infof(ctx, "Failed to create execution model in transformer for id: [%+v] with err: %v",
 			workflowExecutionID, err)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	m.userMetrics.WorkflowExecutionInputBytes.Observe(float64(proto.Size(request.Inputs)))
-	return ctx, executionModel, nil
+	return ctx, executionModel, executionData, nil
 }
 
 func resolveAuthRole(request admin.ExecutionCreateRequest, launchPlan *admin.LaunchPlan) *admin.AuthRole {
@@ -694,28 +754,151 @@ func resolveSecurityCtx(ctx context.Context, request admin.ExecutionCreateReques
 	}
 }
 
-func (m *ExecutionManager) launchExecutionAndPrepareModel(
-	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
-	context.Context, *models.Execution, error) {
+// Fills in the IAM role and kubernetes service account of executions whose request and launch plan leave both unset.
+// Defaults set with the cluster resource attributes of the project and domain take precedence over those of the
+// application config. The identity is always taken from a single one of these, so that an IAM role configured in one
+// place is never paired with a service account configured in another.
+func (m *ExecutionManager) resolvePermissions(ctx context.Context, request admin.ExecutionCreateRequest,
+	launchPlan *admin.LaunchPlan) (*admin.AuthRole, *core.SecurityContext, error) {
+	authRole := proto.Clone(resolveAuthRole(request, launchPlan)).(*admin.AuthRole)
+	securityCtx := proto.Clone(resolveSecurityCtx(ctx, request, launchPlan, authRole)).(*core.SecurityContext)
+	if securityCtx.RunAs == nil {
+		securityCtx.RunAs = &core.Identity{}
+	}
+	securityCtxUnset := len(securityCtx.RunAs.IamRole) == 0 && len(securityCtx.RunAs.K8SServiceAccount) == 0
+	authRoleUnset := len(authRole.AssumableIamRole) == 0 && len(authRole.KubernetesServiceAccount) == 0
+	if securityCtxUnset || authRoleUnset {
+		defaultAuthRole, err := m.attributesResolver.ResolveDefaultAuthRole(ctx, request.Project, request.Domain)
+		if err != nil {
+			return nil, nil, err
+		}
+		if securityCtxUnset {
+			securityCtx.RunAs.IamRole = defaultAuthRole.AssumableIamRole
+			securityCtx.RunAs.K8SServiceAccount = defaultAuthRole.KubernetesServiceAccount
+		}
+		if authRoleUnset {
+			authRole.AssumableIamRole = defaultAuthRole.AssumableIamRole
+			authRole.KubernetesServiceAccount = defaultAuthRole.KubernetesServiceAccount
+		}
+	}
+	if m.config.ApplicationConfiguration().GetTopLevelConfig().GetRequireAuthRole() &&
+		len(securityCtx.RunAs.IamRole) == 0 && len(securityCtx.RunAs.K8SServiceAccount) == 0 {
+		return nil, nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"executions of project [%s] and domain [%s] require an IAM role or kubernetes service account to run as",
+			request.Project, request.Domain)
+	}
+	return authRole, securityCtx, nil
+}
+
+// Returns the maximum number of active executions of a project in a domain, or 0 if they aren't limited.
+func (m *ExecutionManager) getMaxActiveExecutions(ctx context.Context, project, domain string) (int, error) {
+	quota, err := m.resourceManager.GetActiveExecutionQuota(ctx, interfaces.ActiveExecutionQuotaRequest{
+		Project: project,
+		Domain:  domain,
+	})
+	if err != nil {
+		if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
+			logger.Errorf(ctx, "Failed to get the active execution quota of project [%s] and domain [%s] with error: %v",
+				project, domain, err)
+			return 0, err
+		}
+	}
+	if quota == nil {
+		return m.config.ApplicationConfiguration().GetTopLevelConfig().GetActiveExecutionQuotaConfig().
+			GetMaxActiveExecutions(domain), nil
+	}
+	return int(quota.MaxActiveExecutions), nil
+}
+
+// Rejects the creation of an execution whose project already has as many active executions in its domain as it may
+// have at once. Counts may be reused for a short time, so the limit can be exceeded by executions created meanwhile.
+func (m *ExecutionManager) checkActiveExecutionQuota(ctx context.Context, request admin.ExecutionCreateRequest) error {
+	maxActiveExecutions, err := m.getMaxActiveExecutions(ctx, request.Project, request.Domain)
+	if err != nil || maxActiveExecutions == 0 {
+		return err
+	}
+	filters, err := getActiveExecutionFilters(request.Project, request.Domain)
+	if err != nil {
+		return err
+	}
+	cacheKey, err := util.GetCountCacheKey(common.Execution, filters)
+	if err != nil {
+		return err
+	}
+	activeExecutions, err := m.activeExecutionCounts.GetOrCount(cacheKey, func() (int64, error) {
+		return m.db.ExecutionRepo().Count(ctx, repositoryInterfaces.CountResourceInput{
+			InlineFilters: filters,
+		})
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to count the active executions of project [%s] and domain [%s] with err: %v",
+			request.Project, request.Domain, err)
+		return err
+	}
+	if activeExecutions < int64(maxActiveExecutions) {
+		return nil
+	}
+	if request.Spec.GetMetadata().GetMode() == admin.ExecutionMetadata_SCHEDULED {
+		// Schedulers don't retry executions rejected for exhausting a quota.
+		m.systemMetrics.ScheduledExecutionsDropped.Inc()
+		logger.Infof(ctx, "Dropping scheduled execution of launch plan [%+v], project [%s] has %d active executions "+
+			"in domain [%s]", request.Spec.LaunchPlan, request.Project, activeExecutions, request.Domain)
+	}
+	return errors.NewQuotaExceededError(ctx, fmt.Sprintf("%s:%s", request.Project, request.Domain), fmt.Sprintf(
+		"project [%s] has %d active executions in domain [%s], which may have at most %d at once",
+		request.Project, activeExecutions, request.Domain, maxActiveExecutions))
+}
+
+// Filters the executions of a project and domain which haven't terminated.
+func getActiveExecutionFilters(project, domain string) ([]common.InlineFilter, error) {
+	projectFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, shared.Project, project)
+	if err != nil {
+		return nil, err
+	}
+	domainFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, shared.Domain, domain)
+	if err != nil {
+		return nil, err
+	}
+	phaseFilter, err := common.NewRepeatedValueFilter(common.Execution, common.ValueIn, "phase",
+		common.GetNonTerminalExecutionPhases())
+	if err != nil {
+		return nil, err
+	}
+	return []common.InlineFilter{projectFilter, domainFilter, phaseFilter}, nil
+}
+
+// Validates the execution request and prepares both the execution model, recorded in the given phase, and the data
+// the executor needs to launch its workflow.
+func (m *ExecutionManager) prepareExecution(
+	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time,
+	phase core.WorkflowExecution_Phase) (context.Context, *models.Execution, *workflowengineInterfaces.ExecutionData,
+	error) {
 	err := validation.ValidateExecutionRequest(ctx, request, m.db, m.config.ApplicationConfiguration())
 	if err != nil {
 		logger.Debugf(ctx, "Failed to validate ExecutionCreateRequest %+v with err %v", request, err)
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	if err = m.checkActiveExecutionQuota(ctx, request); err != nil {
+		return nil, nil, nil, err
 	}
 	if request.Spec.LaunchPlan.ResourceType == core.ResourceType_TASK {
 		logger.Debugf(ctx, "Launching single task execution with [%+v]", request.Spec.LaunchPlan)
-		return m.launchSingleTaskExecution(ctx, request, requestedAt)
+		return m.prepareSingleTaskExecution(ctx, request, requestedAt, phase)
 	}
 
-	launchPlanModel, err := util.GetLaunchPlanModel(ctx, m.db, *request.Spec.LaunchPlan)
+	launchPlanModel, err := m.lookupCache.GetLaunchPlanModel(ctx, m.db, *request.Spec.LaunchPlan)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to get launch plan model for ExecutionCreateRequest %+v with err %v", request, err)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	launchPlan, err := transformers.FromLaunchPlanModel(launchPlanModel)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to transform launch plan model %+v with err %v", launchPlanModel, err)
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	exclusiveSchedule, replacedExecution, err := m.applyScheduleOverlapPolicy(ctx, request, launchPlanModel)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	executionInputs, err := validation.CheckAndFetchInputsForExecution(
 		request.Inputs,
@@ -727,14 +910,14 @@ func (m *ExecutionManager) launchExecutionAndPrepareModel(
 		logger.Debugf(ctx, "Failed to CheckAndFetchInputsForExecution with request.Inputs: %+v"+
 			"fixed inputs: %+v and expected inputs: %+v with err %v",
 			request.Inputs, launchPlan.Spec.FixedInputs, launchPlan.Closure.ExpectedInputs, err)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	workflow, err := util.GetWorkflow(ctx, m.db, m.storageClient, *launchPlan.Spec.WorkflowId)
+	workflow, err := m.lookupCache.GetWorkflow(ctx, m.db, m.workflowClosures, *launchPlan.Spec.WorkflowId)
 
 	if err != nil {
 		logger.Debugf(ctx, "Failed to get workflow with id %+v with err %v", launchPlan.Spec.WorkflowId, err)
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	name := util.GetExecutionName(request)
 	workflowExecutionID := core.WorkflowExecutionIdentifier{
@@ -747,73 +930,102 @@ func (m *ExecutionManager) launchExecutionAndPrepareModel(
 	if requestSpec.Metadata == nil {
 		requestSpec.Metadata = &admin.ExecutionMetadata{}
 	}
-	requestSpec.Metadata.Principal = getUser(ctx)
+	setPrincipal(ctx, requestSpec.Metadata)
 
 	// Get the node and parent execution (if any) that launched this execution
 	var parentNodeExecutionID uint
 	var sourceExecutionID uint
 	parentNodeExecutionID, sourceExecutionID, err = m.getInheritedExecMetadata(ctx, requestSpec, &workflowExecutionID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	tags, err := m.getInheritedTags(ctx, requestSpec, workflowExecutionID)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	platformTaskResources := m.getTaskResources(ctx, workflow.Id)
+	platformTaskResources, err := m.getTaskResources(ctx, workflow.Id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	// Dynamically assign task resource defaults.
 	for _, task := range workflow.Closure.CompiledWorkflow.Tasks {
 		m.setCompiledTaskDefaults(ctx, task, platformTaskResources)
 	}
 
 	// Dynamically assign execution queues.
-	m.populateExecutionQueue(ctx, *workflow.Id, workflow.Closure.CompiledWorkflow)
+	queue := m.populateExecutionQueue(ctx, *workflow.Id, workflow.Closure.CompiledWorkflow)
 
 	inputsURI, err := common.OffloadLiteralMap(ctx, m.storageClient, executionInputs, workflowExecutionID.Project, workflowExecutionID.Domain, workflowExecutionID.Name, shared.Inputs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	userInputsURI, err := common.OffloadLiteralMap(ctx, m.storageClient, request.Inputs, workflowExecutionID.Project, workflowExecutionID.Domain, workflowExecutionID.Name, shared.UserInputs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	executionConfig, err := m.getExecutionConfig(ctx, &request, launchPlan)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	namespace := common.GetNamespaceName(
-		m.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), workflowExecutionID.Project, workflowExecutionID.Domain)
+	namespace, err := m.getNamespace(ctx, workflowExecutionID.Project, workflowExecutionID.Domain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	labels, err := resolveStringMap(requestSpec.GetLabels(), launchPlan.Spec.Labels, "labels", m.config.RegistrationValidationConfiguration().GetMaxLabelEntries())
+	labels, err := m.resolveLabels(ctx, request.Project, launchPlan.Spec.Labels, requestSpec.GetLabels())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	labels, err = m.addProjectLabels(ctx, request.Project, labels)
+	annotations, err := m.resolveAnnotations(launchPlan.Spec.Annotations, requestSpec.GetAnnotations())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	annotations, err := resolveStringMap(requestSpec.GetAnnotations(), launchPlan.Spec.Annotations, "annotations", m.config.RegistrationValidationConfiguration().GetMaxAnnotationEntries())
+	envs, err := m.resolveEnvs(ctx, request.Project, request.Domain, launchPlan.Spec.Annotations,
+		requestSpec.GetAnnotations())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	resolvedAuthRole := resolveAuthRole(request, launchPlan)
-	resolvedSecurityCtx := resolveSecurityCtx(ctx, request, launchPlan, resolvedAuthRole)
+	resolvedAuthRole, resolvedSecurityCtx, err := m.resolvePermissions(ctx, request, launchPlan)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The stored spec records the resolved permissions, so that relaunches run as the same identity.
+	requestSpec.AuthRole = resolvedAuthRole
+	requestSpec.SecurityContext = resolvedSecurityCtx
+	qualityOfService, err := m.qualityOfServiceAllocator.GetQualityOfService(ctx, executions.GetQualityOfServiceInput{
+		Workflow:               workflow,
+		LaunchPlan:             launchPlan,
+		ExecutionCreateRequest: &request,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get quality of service for [%+v] with error: %v", workflowExecutionID, err)
+		return nil, nil, nil, err
+	}
+	if err = m.checkQueueingBudget(qualityOfService.QueuingBudget, requestedAt); err != nil {
+		return nil, nil, nil, err
+	}
 	executionParameters := workflowengineInterfaces.ExecutionParameters{
 		Inputs:              executionInputs,
 		AcceptedAt:          requestedAt,
 		Labels:              labels,
 		Annotations:         annotations,
+		Envs:                envs,
 		ExecutionConfig:     executionConfig,
 		SecurityContext:     resolvedSecurityCtx,
 		TaskResources:       &platformTaskResources,
 		EventVersion:        m.config.ApplicationConfiguration().GetTopLevelConfig().EventVersion,
 		RoleNameKey:         m.config.ApplicationConfiguration().GetTopLevelConfig().RoleNameKey,
 		RawOutputDataConfig: launchPlan.Spec.RawOutputDataConfig,
+		QueueingBudget:      qualityOfService.QueuingBudget,
 	}
 
 	overrides, err := m.addPluginOverrides(ctx, &workflowExecutionID, launchPlan.GetSpec().WorkflowId.Name, launchPlan.Id.Name)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if overrides != nil {
 		executionParameters.TaskPluginOverrides = overrides
@@ -824,24 +1036,20 @@ func (m *ExecutionManager) launchExecutionAndPrepareModel(
 		executionParameters.RecoveryExecution = request.Spec.Metadata.ReferenceExecution
 	}
 
-	execInfo, err := workflowengine.GetRegistry().GetExecutor().Execute(ctx, workflowengineInterfaces.ExecutionData{
+	executionClusterLabel, err := m.getExecutionClusterLabel(
+		ctx, &workflowExecutionID, requestSpec.GetAnnotations(), workflow.Id.Name, launchPlan.Id.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	executionData := &workflowengineInterfaces.ExecutionData{
 		Namespace:               namespace,
 		ExecutionID:             &workflowExecutionID,
 		ReferenceWorkflowName:   workflow.Id.Name,
 		ReferenceLaunchPlanName: launchPlan.Id.Name,
 		WorkflowClosure:         workflow.Closure.CompiledWorkflow,
 		ExecutionParameters:     executionParameters,
-	})
-
-	if err != nil {
-		m.systemMetrics.PropellerFailures.Inc()
-		logger.Infof(ctx, "Failed to execute workflow %+v with execution id %+v and inputs %+v with err %v",
-			request, workflowExecutionID, executionInputs, err)
-		return nil, nil, err
+		ExecutionClusterLabel:   executionClusterLabel,
 	}
-	executionCreatedAt := time.Now()
-	acceptanceDelay := executionCreatedAt.Sub(requestedAt)
-	m.systemMetrics.AcceptanceDelay.Observe(acceptanceDelay.Seconds())
 
 	// Request notification settings takes precedence over the launch plan settings.
 	// If there is no notification in the request and DisableAll is not true, use the settings from the launch plan.
@@ -857,29 +1065,215 @@ func (m *ExecutionManager) launchExecutionAndPrepareModel(
 	}
 
 	executionModel, err := transformers.CreateExecutionModel(transformers.CreateExecutionModelInput{
-		WorkflowExecutionID: workflowExecutionID,
-		RequestSpec:         requestSpec,
-		LaunchPlanID:        launchPlanModel.ID,
-		WorkflowID:          launchPlanModel.WorkflowID,
-		// The execution is not considered running until the propeller sends a specific event saying so.
-		Phase:                 core.WorkflowExecution_UNDEFINED,
+		WorkflowExecutionID:   workflowExecutionID,
+		RequestSpec:           requestSpec,
+		LaunchPlanID:          launchPlanModel.ID,
+		WorkflowID:            launchPlanModel.WorkflowID,
+		Phase:                 phase,
 		CreatedAt:             m._clock.Now(),
 		Notifications:         notificationsSettings,
 		WorkflowIdentifier:    workflow.Id,
 		ParentNodeExecutionID: parentNodeExecutionID,
 		SourceExecutionID:     sourceExecutionID,
-		Cluster:               execInfo.Cluster,
+		Namespace:             namespace,
+		Queue:                 queue,
+		Envs:                  envs,
+		MaxParallelism:        executionConfig.GetMaxParallelism(),
 		InputsURI:             inputsURI,
 		UserInputsURI:         userInputsURI,
 	})
 	if err != nil {
 		logger.Infof(ctx, "Failed to create execution model in transformer for id: [%+v] with err: %v",
 			workflowExecutionID, err)
+		return nil, nil, nil, err
+	}
+	if exclusiveSchedule {
+		executionModel.ActiveScheduledLaunchPlanID = &launchPlanModel.ID
+		executionModel.ReplacedExecution = replacedExecution
+	}
+	executionModel.Tags = tags
+	executionModel.ScheduleCheckpoint = getScheduleCheckpoint(requestSpec, launchPlanModel, workflowExecutionID.Name)
+	return ctx, executionModel, executionData, nil
+}
+
+// Returns the checkpoint a scheduled execution advances its launch plan version to, or nil for other executions.
+func getScheduleCheckpoint(spec *admin.ExecutionSpec, launchPlanModel models.LaunchPlan,
+	executionName string) *models.ScheduleCheckpoint {
+	if spec.GetMetadata().GetMode() != admin.ExecutionMetadata_SCHEDULED || spec.GetMetadata().GetScheduledAt() == nil {
+		return nil
+	}
+	scheduledAt, err := ptypes.Timestamp(spec.GetMetadata().GetScheduledAt())
+	if err != nil {
+		return nil
+	}
+	return &models.ScheduleCheckpoint{
+		ScheduleCheckpointKey: models.ScheduleCheckpointKey{
+			Project: launchPlanModel.Project,
+			Domain:  launchPlanModel.Domain,
+			Name:    launchPlanModel.Name,
+			Version: launchPlanModel.Version,
+		},
+		LastScheduledAt:   scheduledAt,
+		LastExecutionName: executionName,
+	}
+}
+
+// Hands the workflow of the execution to the executor and records the cluster it was created in on the model.
+func (m *ExecutionManager) launchWorkflow(ctx context.Context, executionModel *models.Execution,
+	executionData workflowengineInterfaces.ExecutionData, requestedAt time.Time) error {
+	execInfo, err := workflowengine.GetRegistry().GetExecutor().Execute(ctx, executionData)
+	if err != nil {
+		m.systemMetrics.PropellerFailures.Inc()
+		logger.Infof(ctx, "Failed to execute workflow %s with execution id %+v and inputs %+v with err %v",
+			executionData.ReferenceWorkflowName, executionData.ExecutionID, executionData.ExecutionParameters.Inputs, err)
+		return err
+	}
+	m.systemMetrics.AcceptanceDelay.Observe(time.Since(requestedAt).Seconds())
+	executionModel.Cluster = execInfo.Cluster
+	return nil
+}
+
+// Prepares the execution and launches its workflow right away. The execution is not considered running until
+// propeller sends a specific event saying so.
+func (m *ExecutionManager) launchExecutionAndPrepareModel(
+	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
+	context.Context, *models.Execution, error) {
+	ctx, executionModel, executionData, err := m.prepareExecution(
+		ctx, request, requestedAt, core.WorkflowExecution_UNDEFINED)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = m.launchWorkflow(ctx, executionModel, *executionData, requestedAt); err != nil {
 		return nil, nil, err
 	}
 	return ctx, executionModel, nil
 }
 
+// Returns the non-terminal scheduled execution of the launch plan version which excludes any other, if there is one.
+func (m *ExecutionManager) getActiveScheduledExecution(ctx context.Context, launchPlanID uint) (
+	*models.Execution, error) {
+	filter, err := common.NewSingleValueFilter(
+		common.Execution, common.Equal, "active_scheduled_launch_plan_id", launchPlanID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := m.db.ExecutionRepo().List(ctx, repositoryInterfaces.ListResourceInput{
+		Limit:         1,
+		InlineFilters: []common.InlineFilter{filter},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Executions) == 0 {
+		return nil, nil
+	}
+	return &output.Executions[0], nil
+}
+
+// Applies the overlap policy of the launch plan to scheduled executions. SKIP refuses to create the execution while a
+// previous scheduled execution of the launch plan version is running and REPLACE returns the running one, which the new
+// execution takes the launch plan over from as it's created and which is terminated once the new one is launched.
+// Returns whether the new execution must exclude other scheduled executions of the launch plan version, which the
+// unique index on the active scheduled launch plan of executions enforces against concurrent schedule events.
+func (m *ExecutionManager) applyScheduleOverlapPolicy(
+	ctx context.Context, request admin.ExecutionCreateRequest, launchPlanModel models.LaunchPlan) (
+	bool, *models.ExecutionKey, error) {
+	if request.Spec.GetMetadata().GetMode() != admin.ExecutionMetadata_SCHEDULED {
+		return false, nil, nil
+	}
+	policy := runtimeInterfaces.ScheduleOverlapPolicy(launchPlanModel.ScheduleOverlapPolicy)
+	if policy != runtimeInterfaces.ScheduleOverlapPolicySkip && policy != runtimeInterfaces.ScheduleOverlapPolicyReplace {
+		return false, nil, nil
+	}
+	activeExecution, err := m.getActiveScheduledExecution(ctx, launchPlanModel.ID)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to look up the active scheduled execution of launch plan [%+v] with err: %v",
+			launchPlanModel.LaunchPlanKey, err)
+		return false, nil, err
+	}
+	if activeExecution == nil {
+		return true, nil, nil
+	}
+	activeExecutionID := transformers.GetExecutionIdentifier(activeExecution)
+	if common.IsExecutionTerminal(core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[activeExecution.Phase])) {
+		// The execution terminated without releasing the launch plan, which is safe to do now.
+		return true, nil, m.db.ExecutionRepo().ClearActiveScheduledLaunchPlan(ctx, repositoryInterfaces.Identifier{
+			Project: activeExecutionID.Project,
+			Domain:  activeExecutionID.Domain,
+			Name:    activeExecutionID.Name,
+		})
+	}
+	if policy == runtimeInterfaces.ScheduleOverlapPolicySkip {
+		m.systemMetrics.ScheduledExecutionsSkipped.Inc()
+		logger.Infof(ctx, "Skipping scheduled execution of launch plan [%+v], [%+v] is still running",
+			launchPlanModel.LaunchPlanKey, activeExecutionID)
+		// Schedulers consider AlreadyExists errors as handled and won't retry.
+		return false, nil, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
+			"skipped scheduled execution of launch plan [%+v], the previous scheduled execution [%+v] is still running",
+			launchPlanModel.LaunchPlanKey, activeExecutionID)
+	}
+	logger.Infof(ctx, "Replacing running scheduled execution [%+v] of launch plan [%+v]",
+		activeExecutionID, launchPlanModel.LaunchPlanKey)
+	return true, &activeExecution.ExecutionKey, nil
+}
+
+// Terminates the scheduled execution a new one replaces, once the new one is launched. Should the new execution fail
+// to launch, the one it was to replace keeps running. Failures are only logged, as the new execution is already running.
+func (m *ExecutionManager) terminateReplacedScheduledExecution(ctx context.Context, executionModel *models.Execution) {
+	if executionModel.ReplacedExecution == nil {
+		return
+	}
+	replacedID := &core.WorkflowExecutionIdentifier{
+		Project: executionModel.ReplacedExecution.Project,
+		Domain:  executionModel.ReplacedExecution.Domain,
+		Name:    executionModel.ReplacedExecution.Name,
+	}
+	_, err := m.TerminateExecution(ctx, admin.ExecutionTerminateRequest{
+		Id:    replacedID,
+		Cause: fmt.Sprintf("replaced by scheduled execution %s", executionModel.Name),
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to terminate scheduled execution [%+v] replaced by [%s] with err: %v",
+			replacedID, executionModel.Name, err)
+		return
+	}
+	m.systemMetrics.ScheduledExecutionsReplaced.Inc()
+}
+
+// Aborts a scheduled execution which was launched but lost the race to become the active scheduled execution of its
+// launch plan version to a concurrent schedule event.
+func (m *ExecutionManager) abortOverlappingScheduledExecution(ctx context.Context, executionModel *models.Execution,
+	createErr error) {
+	if adminErr, ok := createErr.(errors.FlyteAdminError); !ok || adminErr.Code() != codes.AlreadyExists {
+		return
+	}
+	executionID := transformers.GetExecutionIdentifier(executionModel)
+	// A duplicate schedule event for the same kickoff time targets the execution which already exists, which must
+	// keep running.
+	if _, err := m.db.ExecutionRepo().Get(ctx, repositoryInterfaces.Identifier{
+		Project: executionID.Project,
+		Domain:  executionID.Domain,
+		Name:    executionID.Name,
+	}); err == nil {
+		return
+	}
+	m.systemMetrics.ScheduledExecutionsSkipped.Inc()
+	namespace, err := m.getExecutionNamespace(ctx, *executionModel)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to abort overlapping scheduled execution [%+v] with err: %v", executionID, err)
+		return
+	}
+	err = workflowengine.GetRegistry().GetExecutor().Abort(ctx, workflowengineInterfaces.AbortData{
+		Namespace:   namespace,
+		ExecutionID: &executionID,
+		Cluster:     executionModel.Cluster,
+	})
+	if err != nil {
+		m.systemMetrics.TerminateExecutionFailures.Inc()
+		logger.Errorf(ctx, "Failed to abort overlapping scheduled execution [%+v] with err: %v", executionID, err)
+	}
+}
+
 // Inserts an execution model into the database store and emits platform metrics.
 func (m *ExecutionManager) createExecutionModel(
 	ctx context.Context, executionModel *models.Execution) (*core.WorkflowExecutionIdentifier, error) {
@@ -896,42 +1290,321 @@ func (m *ExecutionManager) createExecutionModel(
 	}
 	m.systemMetrics.ActiveExecutions.Inc()
 	m.systemMetrics.ExecutionsCreated.Inc()
+	m.projectMetrics.recordCreated(executionModel.Project, executionModel.Domain)
 	m.systemMetrics.SpecSizeBytes.Observe(float64(len(executionModel.Spec)))
 	m.systemMetrics.ClosureSizeBytes.Observe(float64(len(executionModel.Closure)))
 	return &workflowExecutionIdentifier, nil
 }
 
-func (m *ExecutionManager) CreateExecution(
+func getLaunchKey(id *core.WorkflowExecutionIdentifier) string {
+	return fmt.Sprintf("%s/%s/%s", id.Project, id.Domain, id.Name)
+}
+
+// Records the execution as queued and leaves launching its workflow to the launcher, into the place already reserved in
+// its queue.
+func (m *ExecutionManager) createQueuedExecution(
 	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
 	*admin.ExecutionCreateResponse, error) {
-	// Prior to  flyteidl v0.15.0, Inputs was held in ExecutionSpec. Ensure older clients continue to work.
-	if request.Inputs == nil || len(request.Inputs.Literals) == 0 {
-		request.Inputs = request.GetSpec().GetInputs()
-	}
-	var executionModel *models.Execution
-	var err error
-	ctx, executionModel, err = m.launchExecutionAndPrepareModel(ctx, request, requestedAt)
+	ctx, executionModel, executionData, err := m.prepareExecution(
+		ctx, request, requestedAt, core.WorkflowExecution_QUEUED)
 	if err != nil {
+		m.launcher.Release()
 		return nil, err
 	}
 	workflowExecutionIdentifier, err := m.createExecutionModel(ctx, executionModel)
 	if err != nil {
+		m.launcher.Release()
 		return nil, err
 	}
+	// The launch outlives the request which created the execution, but keeps its id.
+	launchCtx := getExecutionContext(common.WithRequestID(context.Background(), common.GetRequestID(ctx)),
+		workflowExecutionIdentifier)
+	m.launcher.Enqueue(launchCtx, getLaunchKey(workflowExecutionIdentifier),
+		func(ctx context.Context) error {
+			launched, err := m.launchQueuedExecution(ctx, *executionData, requestedAt)
+			if launched {
+				m.terminateReplacedScheduledExecution(ctx, executionModel)
+			}
+			return err
+		},
+		func(ctx context.Context, err error) {
+			m.recordQueuedExecutionPhase(ctx, workflowExecutionIdentifier, core.WorkflowExecution_FAILED,
+				&core.ExecutionError{
+					Code:    "LaunchFailed",
+					Message: fmt.Sprintf("Failed to launch workflow: %v", err),
+					Kind:    core.ExecutionError_SYSTEM,
+				})
+		})
 	return &admin.ExecutionCreateResponse{
 		Id: workflowExecutionIdentifier,
 	}, nil
 }
 
-func (m *ExecutionManager) RelaunchExecution(
+// Returns whether the execution was terminated, including while propeller has yet to report it aborted.
+func isExecutionTerminated(executionModel *models.Execution) (bool, error) {
+	if common.IsExecutionTerminal(core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[executionModel.Phase])) {
+		return true, nil
+	}
+	var closure admin.ExecutionClosure
+	if err := proto.Unmarshal(executionModel.Closure, &closure); err != nil {
+		return false, errors.NewFlyteAdminErrorf(codes.Internal, "Failed to unmarshal execution closure: %v", err)
+	}
+	return closure.GetAbortMetadata() != nil, nil
+}
+
+// Returns an error when an execution requested at requestedAt has already waited longer than its queueing budget,
+// which applies to executions created synchronously as well as those waiting in the launch queue.
+func (m *ExecutionManager) checkQueueingBudget(budget time.Duration, requestedAt time.Time) error {
+	if budget <= 0 || time.Since(requestedAt) <= budget {
+		return nil
+	}
+	m.systemMetrics.QueueingBudgetExceeded.Inc()
+	return errors.NewFlyteAdminErrorf(codes.DeadlineExceeded,
+		"Execution waited longer than its queueing budget of %v to launch", budget)
+}
+
+// Launches the workflow of a queued execution and records the cluster it was created in. An execution terminated before
+// its workflow was created has it aborted and is recorded aborted, as propeller won't report it. Returns whether the
+// workflow was launched and left running.
+func (m *ExecutionManager) launchQueuedExecution(ctx context.Context,
+	executionData workflowengineInterfaces.ExecutionData, requestedAt time.Time) (bool, error) {
+	executionModel, err := util.GetExecutionModel(ctx, m.db, *executionData.ExecutionID)
+	if err != nil {
+		return false, err
+	}
+	terminated, err := isExecutionTerminated(executionModel)
+	if err != nil {
+		return false, err
+	}
+	if terminated {
+		m.recordQueuedExecutionPhase(ctx, executionData.ExecutionID, core.WorkflowExecution_ABORTED, nil)
+		return false, nil
+	}
+	if err = m.checkQueueingBudget(executionData.ExecutionParameters.QueueingBudget, requestedAt); err != nil {
+		m.recordQueuedExecutionPhase(ctx, executionData.ExecutionID, core.WorkflowExecution_FAILED,
+			&core.ExecutionError{
+				Code:    "QueueingBudgetExceeded",
+				Message: err.Error(),
+				Kind:    core.ExecutionError_SYSTEM,
+			})
+		return false, nil
+	}
+	// Recorded ahead of creating the workflow, such that an execution whose workflow may exist is never taken for one
+	// whose launch was interrupted. Only the flag is written, which leaves the rest of the execution untouched.
+	err = m.db.ExecutionRepo().Update(ctx, models.Execution{
+		ExecutionKey:    executionModel.ExecutionKey,
+		LaunchAttempted: true,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err = m.launchWorkflow(ctx, executionModel, executionData, requestedAt); err != nil {
+		return false, err
+	}
+
+	// The workflow now exists, so failures from here on aren't retried which would launch it again.
+	latestModel, err := util.GetExecutionModel(ctx, m.db, *executionData.ExecutionID)
+	if err == nil {
+		terminated, err = isExecutionTerminated(latestModel)
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Failed to check whether launched execution [%+v] was terminated with err: %v",
+			executionData.ExecutionID, err)
+		return true, nil
+	}
+	if terminated {
+		err = workflowengine.GetRegistry().GetExecutor().Abort(ctx, workflowengineInterfaces.AbortData{
+			Namespace:   executionData.Namespace,
+			ExecutionID: executionData.ExecutionID,
+			Cluster:     executionModel.Cluster,
+		})
+		if err != nil {
+			m.systemMetrics.TerminateExecutionFailures.Inc()
+			logger.Errorf(ctx, "Failed to abort execution [%+v] terminated while launching with err: %v",
+				executionData.ExecutionID, err)
+			return false, nil
+		}
+		m.recordQueuedExecutionPhase(ctx, executionData.ExecutionID, core.WorkflowExecution_ABORTED, nil)
+		return false, nil
+	}
+	// Only the cluster is written, which leaves the phase propeller may have reported meanwhile untouched.
+	err = m.db.ExecutionRepo().Update(ctx, models.Execution{
+		ExecutionKey: latestModel.ExecutionKey,
+		Cluster:      executionModel.Cluster,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to record the cluster of launched execution [%+v] with err: %v",
+			executionData.ExecutionID, err)
+	}
+	return true, nil
+}
+
+// Records a phase of an execution which propeller won't report, as its workflow was never launched. Failures are
+// logged, and returned for callers which count the executions recorded.
+func (m *ExecutionManager) recordQueuedExecutionPhase(ctx context.Context, id *core.WorkflowExecutionIdentifier,
+	phase core.WorkflowExecution_Phase, executionError *core.ExecutionError) error {
+	workflowEvent := &event.WorkflowExecutionEvent{
+		ExecutionId: id,
+		Phase:       phase,
+		OccurredAt:  ptypes.TimestampNow(),
+	}
+	if executionError != nil {
+		workflowEvent.OutputResult = &event.WorkflowExecutionEvent_Error{
+			Error: executionError,
+		}
+	}
+	_, err := m.CreateWorkflowEvent(ctx, admin.WorkflowExecutionEventRequest{
+		Event: workflowEvent,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to record execution [%+v] as %s with err: %v", id, phase, err)
+	}
+	return err
+}
+
+// Executions whose launch was interrupted are listed this many at a time.
+const interruptedLaunchesPageSize = 100
+
+func (m *ExecutionManager) FailInterruptedLaunches(ctx context.Context, queuedBefore time.Time) (int, error) {
+	// Executions are only recorded as queued by admin itself, and are flagged before their workflow is created. The
+	// cluster can't tell, as it's empty for in cluster deployments and only recorded once the workflow is created.
+	filters := make([]common.InlineFilter, 0, 4)
+	for _, filter := range []struct {
+		expression common.FilterExpression
+		field      string
+		value      interface{}
+	}{
+		{common.Equal, "phase", core.WorkflowExecution_QUEUED.String()},
+		{common.Equal, "launch_attempted", false},
+		{common.LessThan, "created_at", queuedBefore},
+	} {
+		inlineFilter, err := common.NewSingleValueFilter(common.Execution, filter.expression, filter.field, filter.value)
+		if err != nil {
+			return 0, err
+		}
+		filters = append(filters, inlineFilter)
+	}
+	// Failing the executions of a page removes them from the filters, so pages continue after the last id listed.
+	sortParameter, err := common.NewSortParameter(admin.Sort{
+		Key:       "executions.id",
+		Direction: admin.Sort_ASCENDING,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var failed int
+	var lastID uint
+	for {
+		pageFilters := filters
+		if lastID > 0 {
+			afterFilter, err := common.NewSingleValueFilter(common.Execution, common.GreaterThan, "id", lastID)
+			if err != nil {
+				return failed, err
+			}
+			pageFilters = append(append(make([]common.InlineFilter, 0, len(filters)+1), filters...), afterFilter)
+		}
+		output, err := m.db.ExecutionRepo().List(ctx, repositoryInterfaces.ListResourceInput{
+			Limit:         interruptedLaunchesPageSize,
+			InlineFilters: pageFilters,
+			SortParameter: sortParameter,
+		})
+		if err != nil {
+			logger.Errorf(ctx, "Failed to list the executions whose launch was interrupted with err: %v", err)
+			return failed, err
+		}
+		for _, executionModel := range output.Executions {
+			id := &core.WorkflowExecutionIdentifier{
+				Project: executionModel.Project,
+				Domain:  executionModel.Domain,
+				Name:    executionModel.Name,
+			}
+			executionCtx := getExecutionContext(ctx, id)
+			logger.Infof(executionCtx, "Failing execution [%+v] queued at %v whose launch was interrupted", id,
+				executionModel.CreatedAt)
+			err = m.recordQueuedExecutionPhase(executionCtx, id, core.WorkflowExecution_FAILED, &core.ExecutionError{
+				Code:    "LaunchInterrupted",
+				Message: "Admin stopped before launching the workflow of the execution",
+				Kind:    core.ExecutionError_SYSTEM,
+			})
+			if err == nil {
+				failed++
+			}
+		}
+		if len(output.Executions) < interruptedLaunchesPageSize {
+			return failed, nil
+		}
+		lastID = output.Executions[len(output.Executions)-1].ID
+	}
+}
+
+func (m *ExecutionManager) CreateExecution(
+	ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
+	*admin.ExecutionCreateResponse, error) {
+	defer m.projectMetrics.recordCreateDuration(request.Project, request.Domain, time.Now())
+	ctx = common.WithPrimaryReads(ctx)
+	// Prior to  flyteidl v0.15.0, Inputs was held in ExecutionSpec. Ensure older clients continue to work.
+	if request.Inputs == nil || len(request.Inputs.Literals) == 0 {
+		request.Inputs = request.GetSpec().GetInputs()
+	}
+	if m.launcher.Reserve() {
+		return m.createQueuedExecution(ctx, request, requestedAt)
+	}
+	var executionModel *models.Execution
+	var err error
+	ctx, executionModel, err = m.launchExecutionAndPrepareModel(ctx, request, requestedAt)
+	if err != nil {
+		return nil, err
+	}
+	workflowExecutionIdentifier, err := m.createExecutionModel(ctx, executionModel)
+	if err != nil {
+		if executionModel.ActiveScheduledLaunchPlanID != nil {
+			m.abortOverlappingScheduledExecution(ctx, executionModel, err)
+		}
+		return nil, err
+	}
+	m.terminateReplacedScheduledExecution(ctx, executionModel)
+	return &admin.ExecutionCreateResponse{
+		Id: workflowExecutionIdentifier,
+	}, nil
+}
+
+func (m *ExecutionManager) RelaunchExecution(
 	ctx context.Context, request admin.ExecutionRelaunchRequest, requestedAt time.Time) (
 	*admin.ExecutionCreateResponse, error) {
+	return m.RelaunchExecutionWithInputs(ctx, request, nil, requestedAt)
+}
+
+// mergeInputs returns the original inputs with the overrides applied on top. Neither map is modified.
+func mergeInputs(original, overrides *core.LiteralMap) *core.LiteralMap {
+	if len(overrides.GetLiterals()) == 0 {
+		return original
+	}
+	merged := make(map[string]*core.Literal, len(original.GetLiterals())+len(overrides.GetLiterals()))
+	for name, literal := range original.GetLiterals() {
+		merged[name] = literal
+	}
+	for name, literal := range overrides.GetLiterals() {
+		merged[name] = literal
+	}
+	return &core.LiteralMap{
+		Literals: merged,
+	}
+}
+
+func (m *ExecutionManager) RelaunchExecutionWithInputs(
+	ctx context.Context, request admin.ExecutionRelaunchRequest, inputOverrides *core.LiteralMap,
+	requestedAt time.Time) (*admin.ExecutionCreateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateRelaunchInputOverrides(inputOverrides); err != nil {
+		return nil, err
+	}
 	existingExecutionModel, err := util.GetExecutionModel(ctx, m.db, *request.Id)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to get execution model for request [%+v] with err %v", request, err)
 		return nil, err
 	}
-	existingExecution, err := transformers.FromExecutionModel(*existingExecutionModel)
+	existingExecution, err := transformers.FromExecutionModelAsRequested(*existingExecutionModel)
 	if err != nil {
 		return nil, err
 	}
@@ -955,6 +1628,11 @@ func (m *ExecutionManager) RelaunchExecution(
 		}
 		inputs = spec.Inputs
 	}
+	// The merged inputs go through the same launch plan validation as those of a new execution, which rejects
+	// overrides of fixed inputs and of the wrong type.
+	inputs = mergeInputs(inputs, inputOverrides)
+	// Whoever launched the original execution isn't the one relaunching it.
+	executionSpec.Metadata.Principal = ""
 	executionSpec.Metadata.Mode = admin.ExecutionMetadata_RELAUNCH
 	executionSpec.Metadata.ReferenceExecution = existingExecution.Id
 	var executionModel *models.Execution
@@ -974,6 +1652,7 @@ func (m *ExecutionManager) RelaunchExecution(
 		return nil, err
 	}
 	logger.Debugf(ctx, "Successfully relaunched [%+v] as [%+v]", request.Id, workflowExecutionIdentifier)
+	m.projectMetrics.recordRelaunched(request.Id.Project, request.Id.Domain)
 	return &admin.ExecutionCreateResponse{
 		Id: workflowExecutionIdentifier,
 	}, nil
@@ -982,15 +1661,21 @@ func (m *ExecutionManager) RelaunchExecution(
 func (m *ExecutionManager) RecoverExecution(
 	ctx context.Context, request admin.ExecutionRecoverRequest, requestedAt time.Time) (
 	*admin.ExecutionCreateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	existingExecutionModel, err := util.GetExecutionModel(ctx, m.db, *request.Id)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to get execution model for request [%+v] with err %v", request, err)
 		return nil, err
 	}
-	existingExecution, err := transformers.FromExecutionModel(*existingExecutionModel)
+	existingExecution, err := transformers.FromExecutionModelAsRequested(*existingExecutionModel)
 	if err != nil {
 		return nil, err
 	}
+	if phase := existingExecution.GetClosure().GetPhase(); !common.IsExecutionRecoverable(phase) {
+		return nil, errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"cannot recover execution [%+v] in phase %s, only failed executions can be recovered", request.Id,
+			phase.String())
+	}
 
 	executionSpec := existingExecution.Spec
 	if executionSpec.Metadata == nil {
@@ -1006,6 +1691,8 @@ func (m *ExecutionManager) RecoverExecution(
 	if request.Metadata != nil {
 		executionSpec.Metadata.ParentNodeExecution = request.Metadata.ParentNodeExecution
 	}
+	// Whoever launched the original execution isn't the one relaunching it.
+	executionSpec.Metadata.Principal = ""
 	executionSpec.Metadata.Mode = admin.ExecutionMetadata_RECOVERED
 	executionSpec.Metadata.ReferenceExecution = existingExecution.Id
 	var executionModel *models.Execution
@@ -1160,6 +1847,7 @@ func (m *ExecutionManager) emitOverallWorkflowExecutionTime(
 
 func (m *ExecutionManager) CreateWorkflowEvent(ctx context.Context, request admin.WorkflowExecutionEventRequest) (
 	*admin.WorkflowExecutionEventResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	err := validation.ValidateCreateWorkflowEventRequest(request, m.config.ApplicationConfiguration().GetRemoteDataConfig().MaxSizeInBytes)
 	if err != nil {
 		logger.Debugf(ctx, "received invalid CreateWorkflowEventRequest [%s]: %v", request.RequestId, err)
@@ -1177,20 +1865,27 @@ func (m *ExecutionManager) CreateWorkflowEvent(ctx context.Context, request admi
 	}
 
 	wfExecPhase := core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[executionModel.Phase])
-	// Subsequent queued events announcing a cluster reassignment are permitted.
-	if wfExecPhase == request.Event.Phase && request.Event.Phase != core.WorkflowExecution_QUEUED {
+	eventOrder := transformers.GetWorkflowExecutionEventOrder(*executionModel, request)
+	if eventOrder == transformers.EventDuplicate {
+		m.systemMetrics.DuplicateExecutionEvents.Inc()
 		logger.Debugf(ctx, "This phase %s was already recorded for workflow execution %v",
 			wfExecPhase.String(), request.Event.ExecutionId)
 		return nil, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
 			"This phase %s was already recorded for workflow execution %v",
 			wfExecPhase.String(), request.Event.ExecutionId)
-	} else if common.IsExecutionTerminal(wfExecPhase) {
+	}
+	if eventOrder == transformers.EventStale {
+		m.systemMetrics.StaleExecutionEvents.Inc()
+	}
+	if common.IsExecutionTerminal(wfExecPhase) {
 		// Cannot go backwards in time from a terminal state to anything else
 		curPhase := wfExecPhase.String()
 		errorMsg := fmt.Sprintf("Invalid phase change from %s to %s for workflow execution %v", curPhase, request.Event.Phase.String(), request.Event.ExecutionId)
 		return nil, errors.NewAlreadyInTerminalStateError(ctx, errorMsg, curPhase)
-	} else if wfExecPhase == core.WorkflowExecution_RUNNING && request.Event.Phase == core.WorkflowExecution_QUEUED {
-		// Cannot go back in time from RUNNING -> QUEUED
+	} else if eventOrder == transformers.EventStale {
+		// Cannot go back in time, e.g. from RUNNING -> QUEUED
+		logger.Debugf(ctx, "Rejecting stale event moving workflow execution %v from %s to %s",
+			request.Event.ExecutionId, wfExecPhase.String(), request.Event.Phase.String())
 		return nil, errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
 			"Cannot go from %s to %s for workflow execution %v",
 			wfExecPhase.String(), request.Event.Phase.String(), request.Event.ExecutionId)
@@ -1208,6 +1903,9 @@ func (m *ExecutionManager) CreateWorkflowEvent(ctx context.Context, request admi
 			request, err)
 		return nil, err
 	}
+	if common.IsExecutionTerminal(request.Event.Phase) {
+		m.releaseScheduledLaunchPlan(ctx, executionModel)
+	}
 	m.dbEventWriter.Write(request)
 
 	if request.Event.Phase == core.WorkflowExecution_RUNNING {
@@ -1219,6 +1917,8 @@ func (m *ExecutionManager) CreateWorkflowEvent(ctx context.Context, request admi
 	} else if common.IsExecutionTerminal(request.Event.Phase) {
 		m.systemMetrics.ActiveExecutions.Dec()
 		m.systemMetrics.ExecutionsTerminated.Inc()
+		m.projectMetrics.recordCompleted(executionModel.Project, executionModel.Domain, request.Event.Phase,
+			request.Event.GetError())
 		go m.emitOverallWorkflowExecutionTime(executionModel, request.Event.OccurredAt)
 		if request.Event.GetOutputData() != nil {
 			m.userMetrics.WorkflowExecutionOutputBytes.Observe(float64(proto.Size(request.Event.GetOutputData())))
@@ -1320,6 +2020,23 @@ func (m *ExecutionManager) GetExecutionData(
 	return response, nil
 }
 
+// Filters executions the way ListExecutions does, which hides the executions of archived workflows by default.
+func getExecutionListFilters(spec util.FilterSpec) ([]common.InlineFilter, error) {
+	filters, err := util.GetDbFilters(spec, common.Execution)
+	if err != nil {
+		return nil, err
+	}
+	return util.AddUnarchivedWorkflowFilter(filters)
+}
+
+func getJoinTableEntities(filters []common.InlineFilter) map[common.Entity]bool {
+	joinTableEntities := make(map[common.Entity]bool)
+	for _, filter := range filters {
+		joinTableEntities[filter.GetEntity()] = true
+	}
+	return joinTableEntities
+}
+
 func (m *ExecutionManager) ListExecutions(
 	ctx context.Context, request admin.ResourceListRequest) (*admin.ExecutionList, error) {
 	// Check required fields
@@ -1328,12 +2045,12 @@ func (m *ExecutionManager) ListExecutions(
 		return nil, err
 	}
 	ctx = contextutils.WithProjectDomain(ctx, request.Id.Project, request.Id.Domain)
-	filters, err := util.GetDbFilters(util.FilterSpec{
+	filters, err := getExecutionListFilters(util.FilterSpec{
 		Project:        request.Id.Project,
 		Domain:         request.Id.Domain,
 		Name:           request.Id.Name, // Optional, may be empty.
 		RequestFilters: request.Filters,
-	}, common.Execution)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -1345,21 +2062,18 @@ func (m *ExecutionManager) ListExecutions(
 		}
 	}
 
-	offset, err := validation.ValidateToken(request.Token)
+	offset, keysetCursor, err := validation.ValidatePaginationToken(request.Token, request.SortBy)
 	if err != nil {
 		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid pagination token %s for ListExecutions",
 			request.Token)
 	}
-	joinTableEntities := make(map[common.Entity]bool)
-	for _, filter := range filters {
-		joinTableEntities[filter.GetEntity()] = true
-	}
 	listExecutionsInput := repositoryInterfaces.ListResourceInput{
 		Limit:             int(request.Limit),
 		Offset:            offset,
 		InlineFilters:     filters,
 		SortParameter:     sortParameter,
-		JoinTableEntities: joinTableEntities,
+		KeysetCursor:      keysetCursor,
+		JoinTableEntities: getJoinTableEntities(filters),
 	}
 	output, err := m.db.ExecutionRepo().List(ctx, listExecutionsInput)
 	if err != nil {
@@ -1380,9 +2094,13 @@ func (m *ExecutionManager) ListExecutions(
 		execution.Closure.ComputedInputs = nil
 	}
 	// END TO BE DELETED
-	var token string
-	if len(executionList) == int(request.Limit) {
-		token = strconv.Itoa(offset + len(executionList))
+	var lastExecution models.BaseModel
+	if len(output.Executions) > 0 {
+		lastExecution = output.Executions[len(output.Executions)-1].BaseModel
+	}
+	token, err := util.GetNextPageToken(request.SortBy, offset, len(executionList), request.Limit, lastExecution)
+	if err != nil {
+		return nil, err
 	}
 	return &admin.ExecutionList{
 		Executions: executionList,
@@ -1390,6 +2108,50 @@ func (m *ExecutionManager) ListExecutions(
 	}, nil
 }
 
+func (m *ExecutionManager) CountExecutions(
+	ctx context.Context, request interfaces.CountResourceRequest) (*interfaces.CountResourceResponse, error) {
+	if err := validation.ValidateCountResourceRequest(request); err != nil {
+		logger.Debugf(ctx, "CountExecutions request [%+v] failed validation with err: %v", request, err)
+		return nil, err
+	}
+	if request.Approximate {
+		count, err := m.db.ExecutionRepo().Count(ctx, repositoryInterfaces.CountResourceInput{})
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.CountResourceResponse{
+			Count:       count,
+			Approximate: true,
+		}, nil
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+	filters, err := getExecutionListFilters(util.FilterSpec{
+		Project:        request.Project,
+		Domain:         request.Domain,
+		RequestFilters: request.Filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cacheKey, err := util.GetCountCacheKey(common.Execution, filters)
+	if err != nil {
+		return nil, err
+	}
+	count, err := m.countCache.GetOrCount(cacheKey, func() (int64, error) {
+		return m.db.ExecutionRepo().Count(ctx, repositoryInterfaces.CountResourceInput{
+			InlineFilters:     filters,
+			JoinTableEntities: getJoinTableEntities(filters),
+		})
+	})
+	if err != nil {
+		logger.Debugf(ctx, "Failed to count executions for request [%+v] with err %v", request, err)
+		return nil, err
+	}
+	return &interfaces.CountResourceResponse{
+		Count: count,
+	}, nil
+}
+
 // publishNotifications will only forward major errors because the assumption made is all of the objects
 // that are being manipulated have already been validated/manipulated by Flyte itself.
 // Note: This method should be refactored somewhere else once the interaction with pushing to SNS.
@@ -1419,15 +2181,13 @@ func (m *ExecutionManager) publishNotifications(ctx context.Context, request adm
 			continue
 		}
 
-		// Currently all three supported notifications use email underneath to send the notification.
-		// Convert Slack and PagerDuty into an EmailNotification type.
-		var emailNotification admin.EmailNotification
+		var recipients []string
 		if notification.GetEmail() != nil {
-			emailNotification.RecipientsEmail = notification.GetEmail().GetRecipientsEmail()
+			recipients = notification.GetEmail().GetRecipientsEmail()
 		} else if notification.GetPagerDuty() != nil {
-			emailNotification.RecipientsEmail = notification.GetPagerDuty().GetRecipientsEmail()
+			recipients = notification.GetPagerDuty().GetRecipientsEmail()
 		} else if notification.GetSlack() != nil {
-			emailNotification.RecipientsEmail = notification.GetSlack().GetRecipientsEmail()
+			recipients = notification.GetSlack().GetRecipientsEmail()
 		} else {
 			logger.Debugf(ctx, "failed to publish notification, encountered unrecognized type: %v", notification.Type)
 			m.systemMetrics.UnexpectedDataError.Inc()
@@ -1436,14 +2196,59 @@ func (m *ExecutionManager) publishNotifications(ctx context.Context, request adm
 				notification.Type, request.Event.ExecutionId)
 		}
 
+		// Recipients which name a webhook are sent to it, whatever the notification type.
+		var webhooks []string
+		webhooks, recipients = notifications.SplitWebhookRecipients(recipients)
+		for _, webhook := range webhooks {
+			var webhookMessage *admin.EmailMessage
+			webhookMessage, err = notifications.ToWebhookMessageFromWorkflowExecutionEvent(
+				*m.config.ApplicationConfiguration().GetNotificationsConfig(), webhook, request, adminExecution)
+			if err == nil {
+				err = m.notificationClient.Publish(ctx, notificationInterfaces.WebhookNotificationType, webhookMessage)
+			}
+			if err != nil {
+				m.systemMetrics.PublishNotificationError.Inc()
+				logger.Infof(ctx, "error publishing webhook notification [%+v] with err: [%v]", notification, err)
+			}
+		}
+		// Slack channels with a configured webhook are posted to through it.
+		if notification.GetSlack() != nil {
+			var channels []string
+			channels, recipients = notifications.SplitSlackRecipients(
+				*m.config.ApplicationConfiguration().GetNotificationsConfig(), recipients)
+			if len(channels) > 0 {
+				slackMessage := notifications.ToSlackMessageFromWorkflowExecutionEvent(
+					*m.config.ApplicationConfiguration().GetNotificationsConfig(), channels, request, adminExecution)
+				if _, err = m.notificationRateLimiter.Publish(ctx, adminExecution.Spec.LaunchPlan, request.Event.Phase,
+					notificationInterfaces.SlackWebhookNotificationType, slackMessage, request); err != nil {
+					m.systemMetrics.PublishNotificationError.Inc()
+					logger.Infof(ctx, "error publishing slack notification [%+v] with err: [%v]", notification, err)
+				}
+			}
+		}
+		if len(recipients) == 0 {
+			continue
+		}
+
+		// The remaining recipients of all three supported notifications are emailed.
+		emailNotification := admin.EmailNotification{
+			RecipientsEmail: recipients,
+		}
+
 		// Convert the email Notification into an email message to be published.
-		// Currently there are no possible errors while creating an email message.
-		// Once customizable content is specified, errors are possible.
-		email := notifications.ToEmailMessageFromWorkflowExecutionEvent(
+		var email *admin.EmailMessage
+		email, err = m.emailTemplates.ToEmailMessage(
 			*m.config.ApplicationConfiguration().GetNotificationsConfig(), emailNotification, request, adminExecution)
+		if err != nil {
+			m.systemMetrics.PublishNotificationError.Inc()
+			logger.Infof(ctx, "error rendering email notification [%+v] with err: [%v]", notification, err)
+			continue
+		}
 		// Errors seen while publishing a message are considered non-fatal to the method and will not result
-		// in the method returning an error.
-		if err = m.notificationClient.Publish(ctx, proto.MessageName(&emailNotification), email); err != nil {
+		// in the method returning an error. Flapping launch plans have their emails and Slack messages rate limited,
+		// webhooks usually trigger automation and always receive every notification.
+		if _, err = m.notificationRateLimiter.Publish(ctx, adminExecution.Spec.LaunchPlan, request.Event.Phase,
+			proto.MessageName(&emailNotification), email, request); err != nil {
 			m.systemMetrics.PublishNotificationError.Inc()
 			logger.Infof(ctx, "error publishing email notification [%+v] with err: [%v]", notification, err)
 		}
@@ -1453,6 +2258,7 @@ func (m *ExecutionManager) publishNotifications(ctx context.Context, request adm
 
 func (m *ExecutionManager) TerminateExecution(
 	ctx context.Context, request admin.ExecutionTerminateRequest) (*admin.ExecutionTerminateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateWorkflowExecutionIdentifier(request.Id); err != nil {
 		logger.Debugf(ctx, "received terminate execution request: %v with invalid identifier: %v", request, err)
 		return nil, err
@@ -1469,16 +2275,23 @@ func (m *ExecutionManager) TerminateExecution(
 		return nil, err
 	}
 
-	err = workflowengine.GetRegistry().GetExecutor().Abort(ctx, workflowengineInterfaces.AbortData{
-		Namespace: common.GetNamespaceName(
-			m.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), request.Id.Project, request.Id.Domain),
-
-		ExecutionID: request.Id,
-		Cluster:     executionModel.Cluster,
-	})
-	if err != nil {
-		m.systemMetrics.TerminateExecutionFailures.Inc()
-		return nil, err
+	// The workflow of an execution which is still queued was never launched, so there's nothing to abort and propeller
+	// won't report the execution aborted.
+	cancelled := m.launcher.Cancel(getLaunchKey(request.Id))
+	if !cancelled {
+		namespace, err := m.getExecutionNamespace(ctx, executionModel)
+		if err != nil {
+			return nil, err
+		}
+		err = workflowengine.GetRegistry().GetExecutor().Abort(ctx, workflowengineInterfaces.AbortData{
+			Namespace:   namespace,
+			ExecutionID: request.Id,
+			Cluster:     executionModel.Cluster,
+		})
+		if err != nil {
+			m.systemMetrics.TerminateExecutionFailures.Inc()
+			return nil, err
+		}
 	}
 
 	err = transformers.SetExecutionAborted(&executionModel, request.Cause, getUser(ctx))
@@ -1491,9 +2304,361 @@ func (m *ExecutionManager) TerminateExecution(
 		logger.Debugf(ctx, "failed to save abort cause for terminated execution: %+v with err: %v", request.Id, err)
 		return nil, err
 	}
+	if cancelled {
+		m.recordQueuedExecutionPhase(ctx, request.Id, core.WorkflowExecution_ABORTED, nil)
+	}
+	m.releaseScheduledLaunchPlan(ctx, &executionModel)
+	m.projectMetrics.recordTerminated(request.Id.Project, request.Id.Domain)
 	return &admin.ExecutionTerminateResponse{}, nil
 }
 
+// Lets new scheduled executions of the launch plan version be created once this one terminates. Failures are only
+// logged, the overlap policy releases launch plans whose active scheduled execution has terminated itself.
+func (m *ExecutionManager) releaseScheduledLaunchPlan(ctx context.Context, executionModel *models.Execution) {
+	if executionModel.ActiveScheduledLaunchPlanID == nil {
+		return
+	}
+	err := m.db.ExecutionRepo().ClearActiveScheduledLaunchPlan(ctx, repositoryInterfaces.Identifier{
+		Project: executionModel.Project,
+		Domain:  executionModel.Domain,
+		Name:    executionModel.Name,
+	})
+	if err != nil {
+		logger.Warningf(ctx, "Failed to release the launch plan of scheduled execution [%+v] with err: %v",
+			executionModel.ExecutionKey, err)
+	}
+}
+
+func (m *ExecutionManager) UpdateExecutionTags(
+	ctx context.Context, request interfaces.ExecutionTagsUpdateRequest) error {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateExecutionTagsUpdateRequest(request); err != nil {
+		logger.Debugf(ctx, "UpdateExecutionTags request [%+v] failed validation with err: %v", request, err)
+		return err
+	}
+	ctx = getExecutionContext(ctx, request.ExecutionID)
+	executionIdentifier := repositoryInterfaces.Identifier{
+		Project: request.ExecutionID.Project,
+		Domain:  request.ExecutionID.Domain,
+		Name:    request.ExecutionID.Name,
+	}
+	executionModel, err := m.db.ExecutionRepo().Get(ctx, executionIdentifier)
+	if err != nil {
+		logger.Debugf(ctx, "Failed to get execution [%+v] to update its tags with err: %v", request.ExecutionID, err)
+		return err
+	}
+	phase := core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[executionModel.Phase])
+	if common.IsExecutionTerminal(phase) {
+		return errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"can't update the tags of execution [%+v] which already reached terminal phase %s",
+			request.ExecutionID, executionModel.Phase)
+	}
+	if err := m.db.ExecutionRepo().UpdateTags(ctx, executionIdentifier, request.Add, request.Remove); err != nil {
+		logger.Errorf(ctx, "Failed to update the tags of execution [%+v] with err: %v", request.ExecutionID, err)
+		return err
+	}
+	return nil
+}
+
+// Executions to terminate are listed this many at a time and terminated this many at a time.
+const (
+	bulkTerminatePageSize    = 100
+	bulkTerminateConcurrency = 10
+)
+
+// Lists the executions selected by a bulk termination request a page at a time, whatever their phase, and hands each
+// page to process before the next one is listed.
+func (m *ExecutionManager) forEachExecutionToTerminate(ctx context.Context,
+	request interfaces.BulkTerminateExecutionsRequest, process func(executions []models.Execution)) error {
+	filters, err := util.GetDbFilters(util.FilterSpec{
+		Project:        request.Project,
+		Domain:         request.Domain,
+		RequestFilters: request.Filters,
+	}, common.Execution)
+	if err != nil {
+		return err
+	}
+	if request.LaunchPlan != nil {
+		launchPlanFields := []struct {
+			field string
+			value string
+		}{
+			{shared.Project, request.LaunchPlan.Project},
+			{shared.Domain, request.LaunchPlan.Domain},
+			{shared.Name, request.LaunchPlan.Name},
+			{shared.Version, request.LaunchPlan.Version},
+		}
+		for _, launchPlanField := range launchPlanFields {
+			if launchPlanField.value == "" {
+				continue
+			}
+			filter, err := util.GetSingleValueEqualityFilter(common.LaunchPlan, launchPlanField.field, launchPlanField.value)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, filter)
+		}
+	}
+	joinTableEntities := make(map[common.Entity]bool)
+	for _, filter := range filters {
+		joinTableEntities[filter.GetEntity()] = true
+	}
+	// Terminating the executions of a page can change which executions the filters select, for instance by phase,
+	// so pages continue after the last id listed rather than at an offset which would skip executions.
+	sortParameter, err := common.NewSortParameter(admin.Sort{
+		Key:       "executions.id",
+		Direction: admin.Sort_ASCENDING,
+	})
+	if err != nil {
+		return err
+	}
+
+	var lastID uint
+	for {
+		pageFilters := filters
+		if lastID > 0 {
+			afterFilter, err := common.NewSingleValueFilter(common.Execution, common.GreaterThan, "id", lastID)
+			if err != nil {
+				return err
+			}
+			pageFilters = append(append(make([]common.InlineFilter, 0, len(filters)+1), filters...), afterFilter)
+		}
+		output, err := m.db.ExecutionRepo().List(ctx, repositoryInterfaces.ListResourceInput{
+			Limit:             bulkTerminatePageSize,
+			InlineFilters:     pageFilters,
+			SortParameter:     sortParameter,
+			JoinTableEntities: joinTableEntities,
+		})
+		if err != nil {
+			logger.Debugf(ctx, "Failed to list executions to terminate for [%+v] with err %v", request, err)
+			return err
+		}
+		if len(output.Executions) == 0 {
+			return nil
+		}
+		process(output.Executions)
+		if len(output.Executions) < bulkTerminatePageSize {
+			return nil
+		}
+		lastID = output.Executions[len(output.Executions)-1].ID
+	}
+}
+
+// Terminates the executions given, at most bulkTerminateConcurrency at a time, and records the outcome of each in the
+// response.
+func (m *ExecutionManager) terminateExecutionsInBulk(ctx context.Context, ids []*core.WorkflowExecutionIdentifier,
+	cause string, response *interfaces.BulkTerminateExecutionsResponse) {
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, bulkTerminateConcurrency)
+	for _, id := range ids {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(id *core.WorkflowExecutionIdentifier) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			_, err := m.TerminateExecution(ctx, admin.ExecutionTerminateRequest{
+				Id:    id,
+				Cause: cause,
+			})
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				logger.Warningf(ctx, "Failed to terminate execution [%+v] in bulk: %v", id, err)
+				response.Failed[id.Name] = err
+				return
+			}
+			response.Terminated = append(response.Terminated, id)
+		}(id)
+	}
+	wg.Wait()
+}
+
+func (m *ExecutionManager) BulkTerminateExecutions(
+	ctx context.Context, request interfaces.BulkTerminateExecutionsRequest) (
+	*interfaces.BulkTerminateExecutionsResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateBulkTerminateExecutionsRequest(request); err != nil {
+		logger.Debugf(ctx, "received invalid bulk terminate executions request [%+v]: %v", request, err)
+		return nil, err
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+
+	response := &interfaces.BulkTerminateExecutionsResponse{
+		Failed: make(map[string]error),
+	}
+	err := m.forEachExecutionToTerminate(ctx, request, func(executions []models.Execution) {
+		var toTerminate []*core.WorkflowExecutionIdentifier
+		for i := range executions {
+			if common.IsExecutionTerminal(core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[executions[i].Phase])) {
+				response.AlreadyTerminal++
+				continue
+			}
+			id := transformers.GetExecutionIdentifier(&executions[i])
+			toTerminate = append(toTerminate, &id)
+		}
+		if request.DryRun {
+			response.Terminated = append(response.Terminated, toTerminate...)
+			return
+		}
+		m.terminateExecutionsInBulk(ctx, toTerminate, request.Cause, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(response.Terminated, func(i, j int) bool {
+		return response.Terminated[i].Name < response.Terminated[j].Name
+	})
+	if !request.DryRun {
+		logger.Infof(ctx, "Terminated %d executions in bulk with %d failures, %d were already terminal",
+			len(response.Terminated), len(response.Failed), response.AlreadyTerminal)
+	}
+	return response, nil
+}
+
+// Executions are purged this many at a time when the request doesn't say.
+const defaultPurgeBatchSize = 100
+
+func (m *ExecutionManager) PurgeExecutions(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+	*interfaces.PurgeExecutionsResponse, error) {
+	if err := validation.ValidatePurgeExecutionsRequest(request, m._clock.Now()); err != nil {
+		logger.Debugf(ctx, "received invalid purge executions request [%+v]: %v", request, err)
+		return nil, err
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+	input := repositoryInterfaces.PurgeExecutionsInput{
+		Project:       request.Project,
+		Domain:        request.Domain,
+		CreatedBefore: request.CreatedBefore,
+		Limit:         request.BatchSize,
+		DryRun:        request.DryRun,
+	}
+	if input.Limit == 0 {
+		input.Limit = defaultPurgeBatchSize
+	}
+
+	response := &interfaces.PurgeExecutionsResponse{
+		DeletedRows: make(map[string]int64),
+	}
+	for {
+		output, err := m.db.ExecutionRepo().PurgeBatch(ctx, input)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to purge executions after purging %d for [%+v] with err %v",
+				response.Executions, request, err)
+			return nil, err
+		}
+		response.Executions += output.Executions
+		for table, rows := range output.DeletedRows {
+			response.DeletedRows[table] += rows
+			if !request.DryRun {
+				m.systemMetrics.PurgedRows.WithLabelValues(table).Add(float64(rows))
+			}
+		}
+		if output.Executions < input.Limit {
+			break
+		}
+		input.AfterID = output.LastID
+	}
+	logger.Infof(ctx, "Purged %d executions created before %v (dry run: %v), deleting rows %v",
+		response.Executions, request.CreatedBefore, request.DryRun, response.DeletedRows)
+	return response, nil
+}
+
+// Returns the span from start to end, or an open span lasting until now when there's no end yet. Returns nil when the
+// start wasn't recorded. Spans never last less than nothing, even when clocks disagree.
+func newTimelineSpan(start, end *time.Time, now time.Time) *interfaces.TimelineSpan {
+	if start == nil || start.IsZero() {
+		return nil
+	}
+	span := &interfaces.TimelineSpan{
+		Start: *start,
+		End:   end,
+	}
+	if end == nil {
+		span.Open = true
+		end = &now
+	}
+	if end.After(*start) {
+		span.Duration = end.Sub(*start)
+	}
+	return span
+}
+
+// Returns the time a phase that lasted duration after start ended at, or nil when it didn't end yet.
+func getTimelineEnd(start *time.Time, duration time.Duration, terminal bool) *time.Time {
+	if start == nil || !terminal {
+		return nil
+	}
+	end := start.Add(duration)
+	return &end
+}
+
+// Returns the time spent queued, which for a terminal phase reached without ever starting lasts until it reached it.
+func getQueuedEnd(startedAt, updatedAt *time.Time, terminal bool) *time.Time {
+	if startedAt != nil {
+		return startedAt
+	}
+	if terminal {
+		return updatedAt
+	}
+	return nil
+}
+
+func (m *ExecutionManager) GetExecutionTimeline(ctx context.Context, id *core.WorkflowExecutionIdentifier) (
+	*interfaces.ExecutionTimeline, error) {
+	if err := validation.ValidateWorkflowExecutionIdentifier(id); err != nil {
+		logger.Debugf(ctx, "GetExecutionTimeline request [%+v] failed validation with err: %v", id, err)
+		return nil, err
+	}
+	ctx = getExecutionContext(ctx, id)
+	executionModel, err := util.GetExecutionModel(ctx, m.db, *id)
+	if err != nil {
+		logger.Debugf(ctx, "Failed to get execution model for [%+v] with err: %v", id, err)
+		return nil, err
+	}
+	var spec admin.ExecutionSpec
+	if err := proto.Unmarshal(executionModel.Spec, &spec); err != nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.Internal, "failed to unmarshal spec for [%+v]", id)
+	}
+	entries, err := m.db.NodeExecutionRepo().ListTimeline(ctx, *id)
+	if err != nil {
+		logger.Debugf(ctx, "Failed to list node execution timeline for [%+v] with err: %v", id, err)
+		return nil, err
+	}
+
+	now := m._clock.Now()
+	terminal := common.IsExecutionTerminal(
+		core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[executionModel.Phase]))
+	timeline := &interfaces.ExecutionTimeline{
+		StartLatency: newTimelineSpan(executionModel.ExecutionCreatedAt,
+			getQueuedEnd(executionModel.StartedAt, executionModel.ExecutionUpdatedAt, terminal), now),
+		RunTime: newTimelineSpan(executionModel.StartedAt,
+			getTimelineEnd(executionModel.StartedAt, executionModel.Duration, terminal), now),
+		Nodes: make([]interfaces.NodeExecutionTimeline, len(entries)),
+	}
+	// When admin accepted other executions isn't recorded, only their creation which is when it happened.
+	if spec.Metadata.GetMode() == admin.ExecutionMetadata_SCHEDULED && spec.Metadata.GetScheduledAt() != nil {
+		if scheduledAt, err := ptypes.Timestamp(spec.Metadata.ScheduledAt); err == nil {
+			timeline.AcceptLatency = newTimelineSpan(&scheduledAt, executionModel.ExecutionCreatedAt, now)
+		}
+	}
+	for i, entry := range entries {
+		phase := core.NodeExecution_Phase(core.NodeExecution_Phase_value[entry.Phase])
+		nodeTerminal := common.IsNodeExecutionTerminal(phase)
+		timeline.Nodes[i] = interfaces.NodeExecutionTimeline{
+			NodeID:  entry.NodeID,
+			Phase:   phase,
+			Queued:  newTimelineSpan(entry.CreatedAt, getQueuedEnd(entry.StartedAt, entry.UpdatedAt, nodeTerminal), now),
+			Run:     newTimelineSpan(entry.StartedAt, getTimelineEnd(entry.StartedAt, entry.Duration, nodeTerminal), now),
+			Retries: entry.Retries,
+		}
+	}
+	return timeline, nil
+}
+
 func newExecutionSystemMetrics(scope promutils.Scope) executionSystemMetrics {
 	return executionSystemMetrics{
 		Scope: scope,
@@ -1505,6 +2670,10 @@ func newExecutionSystemMetrics(scope promutils.Scope) executionSystemMetrics {
 			"overall count of terminated workflow executions"),
 		ExecutionEventsCreated: scope.MustNewCounter("execution_events_created",
 			"overall count of successfully completed WorkflowExecutionEventRequest"),
+		DuplicateExecutionEvents: scope.MustNewCounter("duplicate_execution_events",
+			"count of rejected WorkflowExecutionEventRequest repeating the recorded phase"),
+		StaleExecutionEvents: scope.MustNewCounter("stale_execution_events",
+			"count of rejected WorkflowExecutionEventRequest preceding the recorded phase"),
 		PropellerFailures: scope.MustNewCounter("propeller_failures",
 			"propeller failures in creating workflow executions"),
 		TransformerError: scope.MustNewCounter("transformer_error",
@@ -1521,6 +2690,16 @@ func newExecutionSystemMetrics(scope promutils.Scope) executionSystemMetrics {
 			"overall count of publish event errors when invoking publish()"),
 		TerminateExecutionFailures: scope.MustNewCounter("execution_termination_failure",
 			"count of failed workflow executions terminations"),
+		ScheduledExecutionsSkipped: scope.MustNewCounter("scheduled_executions_skipped",
+			"count of scheduled executions skipped because a previous one of the same launch plan was still running"),
+		ScheduledExecutionsReplaced: scope.MustNewCounter("scheduled_executions_replaced",
+			"count of running scheduled executions terminated to make way for a new one of the same launch plan"),
+		ScheduledExecutionsDropped: scope.MustNewCounter("scheduled_executions_dropped",
+			"count of scheduled executions dropped because their project had as many active executions as it may have"),
+		QueueingBudgetExceeded: scope.MustNewCounter("queueing_budget_exceeded",
+			"count of queued executions failed because they waited longer than their queueing budget to launch"),
+		PurgedRows: scope.MustNewCounterVec("purged_rows",
+			"count of rows deleted by execution purges", "table"),
 	}
 }
 
@@ -1528,8 +2707,22 @@ func NewExecutionManager(db repositories.RepositoryInterface, config runtimeInte
 	storageClient *storage.DataStore, systemScope promutils.Scope, userScope promutils.Scope,
 	publisher notificationInterfaces.Publisher, urlData dataInterfaces.RemoteURLInterface,
 	workflowManager interfaces.WorkflowInterface, namedEntityManager interfaces.NamedEntityInterface,
-	eventPublisher notificationInterfaces.Publisher, eventWriter eventWriter.WorkflowExecutionEventWriter) interfaces.ExecutionInterface {
-	queueAllocator := executions.NewQueueAllocator(config, db)
+	eventPublisher notificationInterfaces.Publisher, eventWriter eventWriter.WorkflowExecutionEventWriter,
+	lookupCache *util.LookupCache, workflowClosures *util.WorkflowClosureCache,
+	launcher *executions.Launcher) interfaces.ExecutionInterface {
+	if workflowClosures == nil {
+		// Managers created without the cache the admin service shares between them, as in tests, get their own.
+		workflowClosures = util.NewWorkflowClosureCache(
+			storageClient, config.ApplicationConfiguration().GetTopLevelConfig().GetWorkflowClosureCacheConfig())
+	}
+	if launcher == nil {
+		// Likewise for the launcher the admin service stops, which remains nil if launching in the background is
+		// disabled.
+		launcher = executions.NewLauncher(
+			config.ApplicationConfiguration().GetTopLevelConfig().GetAsyncExecutionLaunchConfig(),
+			systemScope.NewSubScope("execution_launcher"))
+	}
+	queueAllocator := executions.NewQueueAllocator(config, db, systemScope.NewSubScope("execution_queues"))
 	systemMetrics := newExecutionSystemMetrics(systemScope)
 
 	userMetrics := executionUserMetrics{
@@ -1542,7 +2735,19 @@ func NewExecutionManager(db repositories.RepositoryInterface, config runtimeInte
 			"size in bytes of serialized execution outputs"),
 	}
 
-	resourceManager := resources.NewResourceManager(db, config.ApplicationConfiguration())
+	projectMetrics := newExecutionProjectMetrics(systemScope.NewSubScope("project"),
+		config.ApplicationConfiguration().GetTopLevelConfig().GetExecutionMetricsConfig())
+
+	resourceManager := resources.NewResourceManager(db, config)
+	notificationRateLimiter := notifications.NewRateLimiter(
+		config.ApplicationConfiguration().GetNotificationsConfig().NotificationsRateLimitConfig, publisher,
+		systemScope.NewSubScope("notification_rate_limiter"))
+	emailTemplates, err := notifications.NewEmailTemplates(
+		config.ApplicationConfiguration().GetNotificationsConfig().NotificationsEmailerConfig.Templates)
+	if err != nil {
+		// Invalid email templates fail startup rather than every notification.
+		panic(err)
+	}
 	return &ExecutionManager{
 		db:                        db,
 		config:                    config,
@@ -1551,19 +2756,45 @@ func NewExecutionManager(db repositories.RepositoryInterface, config runtimeInte
 		_clock:                    clock.New(),
 		systemMetrics:             systemMetrics,
 		userMetrics:               userMetrics,
+		projectMetrics:            projectMetrics,
 		notificationClient:        publisher,
+		notificationRateLimiter:   notificationRateLimiter,
+		emailTemplates:            emailTemplates,
 		urlData:                   urlData,
 		workflowManager:           workflowManager,
 		namedEntityManager:        namedEntityManager,
 		resourceManager:           resourceManager,
+		attributesResolver:        resources.NewAttributesResolver(resourceManager, config),
 		qualityOfServiceAllocator: executions.NewQualityOfServiceAllocator(config, resourceManager),
 		eventPublisher:            eventPublisher,
 		dbEventWriter:             eventWriter,
+		countCache:                util.NewCountCache(config.ApplicationConfiguration().GetTopLevelConfig().GetCountCacheTTL()),
+		activeExecutionCounts: util.NewCountCache(
+			config.ApplicationConfiguration().GetTopLevelConfig().GetActiveExecutionQuotaConfig().CountCacheTTL.Duration),
+		workflowClosures: workflowClosures,
+		lookupCache:      lookupCache,
+		launcher:         launcher,
+	}
+}
+
+// Resolves the kubernetes namespace the executions of a project and domain run in.
+func (m *ExecutionManager) getNamespace(ctx context.Context, project, domain string) (string, error) {
+	return util.GetProjectNamespace(ctx, m.db, m.resourceManager,
+		m.config.NamespaceMappingConfiguration().GetNamespaceTemplate(), project, domain)
+}
+
+// Returns the namespace the workflow of an execution was created in. Executions which predate the namespace being
+// recorded fall back to the namespace their project and domain map to now.
+func (m *ExecutionManager) getExecutionNamespace(ctx context.Context, executionModel models.Execution) (string, error) {
+	if len(executionModel.Namespace) > 0 {
+		return executionModel.Namespace, nil
 	}
+	return m.getNamespace(ctx, executionModel.Project, executionModel.Domain)
 }
 
-// Adds project labels with higher precedence to workflow labels. Project labels are ignored if a corresponding label is set on the workflow.
-func (m *ExecutionManager) addProjectLabels(ctx context.Context, projectName string, initialLabels map[string]string) (map[string]string, error) {
+// Resolves the labels applied to an execution. From lowest to highest precedence these are the application config
+// defaults, the project labels, the launch plan labels and finally the labels set on the execution spec.
+func (m *ExecutionManager) resolveLabels(ctx context.Context, projectName string, launchPlanLabels, requestLabels *admin.Labels) (map[string]string, error) {
 	project, err := m.db.ProjectRepo().Get(ctx, projectName)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to get project for [%+v] with error: %v", project, err)
@@ -1572,14 +2803,41 @@ func (m *ExecutionManager) addProjectLabels(ctx context.Context, projectName str
 	// passing nil domain as not needed to retrieve labels
 	projectLabels := transformers.FromProjectModel(project, nil).Labels.GetValues()
 
-	if initialLabels == nil {
-		initialLabels = make(map[string]string)
-	}
+	return resolveStringMap("labels", m.config.RegistrationValidationConfiguration().GetMaxLabelEntries(),
+		m.config.ApplicationConfiguration().GetTopLevelConfig().GetLabels(), projectLabels,
+		launchPlanLabels.GetValues(), requestLabels.GetValues())
+}
 
-	for k, v := range projectLabels {
-		if _, ok := initialLabels[k]; !ok {
-			initialLabels[k] = v
-		}
+// Resolves the annotations applied to an execution. From lowest to highest precedence these are the application config
+// defaults, the launch plan annotations and finally the annotations set on the execution spec. The launch plan
+// annotations configuring its schedule are left out, as is the execution queue annotation, which admin sets itself, and
+// the annotation assigning the execution to a cluster.
+func (m *ExecutionManager) resolveAnnotations(launchPlanAnnotations, requestAnnotations *admin.Annotations) (map[string]string, error) {
+	return resolveStringMap("annotations", m.config.RegistrationValidationConfiguration().GetMaxAnnotationEntries(),
+		m.config.ApplicationConfiguration().GetTopLevelConfig().GetAnnotations(),
+		common.WithoutExecutionEnvAnnotations(common.WithoutScheduleAnnotations(launchPlanAnnotations.GetValues())),
+		common.WithoutExecutionEnvAnnotations(common.WithoutAnnotations(requestAnnotations.GetValues(),
+			common.ExecutionQueueAnnotation, common.ExecutionClusterLabelAnnotation)))
+}
+
+// Resolves the environment variables set for the tasks of an execution. From lowest to highest precedence these are
+// the matchable attributes of its project and domain, the annotations of its launch plan and finally those of its
+// request. An empty value unsets a variable of a lower level.
+func (m *ExecutionManager) resolveEnvs(ctx context.Context, project, domain string, launchPlanAnnotations,
+	requestAnnotations *admin.Annotations) (map[string]string, error) {
+	projectDomainEnvs, err := m.attributesResolver.ResolveExecutionEnvs(ctx, project, domain)
+	if err != nil {
+		return nil, err
+	}
+	envs, err := resolveStringMap("environment variables",
+		m.config.RegistrationValidationConfiguration().GetMaxAnnotationEntries(), projectDomainEnvs,
+		common.GetExecutionEnvs(launchPlanAnnotations.GetValues()), common.GetExecutionEnvs(requestAnnotations.GetValues()))
+	if err != nil {
+		return nil, err
+	}
+	if err = validation.ValidateExecutionEnvs(envs,
+		m.config.ApplicationConfiguration().GetTopLevelConfig().ExecutionEnvDenylist); err != nil {
+		return nil, err
 	}
-	return initialLabels, nil
+	return envs, nil
 }
@@ -3,7 +3,10 @@ package impl
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine"
@@ -13,6 +16,7 @@ import (
 	commonTestUtils "github.com/flyteorg/flyteadmin/pkg/common/testutils"
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/executions"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
 	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
@@ -34,15 +38,19 @@ import (
 
 	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/flyteorg/flytestdlib/storage"
 
 	"time"
 
 	"fmt"
 
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications"
+	notificationInterfaces "github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
 	notificationMocks "github.com/flyteorg/flyteadmin/pkg/async/notifications/mocks"
 	dataMocks "github.com/flyteorg/flyteadmin/pkg/data/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
@@ -57,6 +65,7 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -151,7 +160,16 @@ func setDefaultLpCallbackForExecTest(repository repositories.RepositoryInterface
 			"annotation4": "4",
 		},
 	}
-	lpSpecBytes, _ := proto.Marshal(&lpSpec)
+	setLpCallbackForExecTest(repository, &lpSpec)
+}
+
+func setLpCallbackForExecTest(repository repositories.RepositoryInterface, lpSpec *admin.LaunchPlanSpec) {
+	setLpCallbackWithOverlapPolicyForExecTest(repository, lpSpec, "")
+}
+
+func setLpCallbackWithOverlapPolicyForExecTest(repository repositories.RepositoryInterface, lpSpec *admin.LaunchPlanSpec,
+	overlapPolicy runtimeInterfaces.ScheduleOverlapPolicy) {
+	lpSpecBytes, _ := proto.Marshal(lpSpec)
 	lpClosure := admin.LaunchPlanClosure{
 		ExpectedInputs: lpSpec.DefaultInputs,
 	}
@@ -168,8 +186,9 @@ func setDefaultLpCallbackForExecTest(repository repositories.RepositoryInterface
 			BaseModel: models.BaseModel{
 				ID: uint(100),
 			},
-			Spec:    lpSpecBytes,
-			Closure: lpClosureBytes,
+			Spec:                  lpSpecBytes,
+			Closure:               lpClosureBytes,
+			ScheduleOverlapPolicy: string(overlapPolicy),
 		}
 		return lpModel, nil
 	}
@@ -202,6 +221,14 @@ func getMockStorageForExecTest(ctx context.Context) *storage.DataStore {
 	return mockStorage
 }
 
+// Replaces the resource manager of an execution manager, along with the resolver of its matchable attributes.
+func setResourceManagerForExecTest(
+	execManager managerInterfaces.ExecutionInterface, resourceManager managerInterfaces.ResourceInterface) {
+	executionManager := execManager.(*ExecutionManager)
+	executionManager.resourceManager = resourceManager
+	executionManager.attributesResolver = resources.NewAttributesResolver(resourceManager, executionManager.config)
+}
+
 func getMockRepositoryForExecTest() repositories.RepositoryInterface {
 	repository := repositoryMocks.NewMockRepository()
 	repository.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetGetCallback(
@@ -245,6 +272,7 @@ func TestCreateExecution(t *testing.T) {
 	repository.ProjectRepo().(*repositoryMocks.MockProjectRepo).GetFunction = func(
 		ctx context.Context, projectID string) (models.Project, error) {
 		return transformers.CreateProjectModel(&admin.Project{
+			Id:     projectID,
 			Labels: &labels}), nil
 	}
 
@@ -255,6 +283,7 @@ func TestCreateExecution(t *testing.T) {
 			err := proto.Unmarshal(input.Spec, &spec)
 			assert.NoError(t, err)
 			assert.Equal(t, principal, spec.Metadata.Principal)
+			assert.Equal(t, "project-domain", input.Namespace)
 			return nil
 		})
 	setDefaultLpCallbackForExecTest(repository)
@@ -318,7 +347,7 @@ func TestCreateExecution(t *testing.T) {
 	mockConfig := getMockExecutionsConfigProvider()
 	mockConfig.(*runtimeMocks.MockConfigurationProvider).AddQualityOfServiceConfiguration(qosProvider)
 
-	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := testutils.GetExecutionRequest()
 	request.Spec.Metadata = &admin.ExecutionMetadata{
 		Principal: "unused - populated from authenticated context",
@@ -388,8 +417,20 @@ func TestCreateExecutionFromWorkflowNode(t *testing.T) {
 		},
 	)
 
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetTagsCallback(
+		func(ctx context.Context, input interfaces.Identifier) ([]models.ExecutionTag, error) {
+			assert.Equal(t, parentNodeExecutionID.ExecutionId.Name, input.Name)
+			return []models.ExecutionTag{
+				{ExecutionKey: models.ExecutionKey{Project: "project", Domain: "domain", Name: "parent-name"}, Tag: "backfill"},
+			}, nil
+		},
+	)
+
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
 		func(ctx context.Context, input models.Execution) error {
+			assert.Equal(t, []models.ExecutionTag{
+				{ExecutionKey: input.ExecutionKey, Tag: "backfill"},
+			}, input.Tags)
 			assert.Equal(t, input.ParentNodeExecutionID, uint(1))
 			var spec admin.ExecutionSpec
 			err := proto.Unmarshal(input.Spec, &spec)
@@ -414,7 +455,7 @@ func TestCreateExecutionFromWorkflowNode(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := testutils.GetExecutionRequest()
 	request.Spec.Metadata = &admin.ExecutionMetadata{
 		Mode:                admin.ExecutionMetadata_CHILD_WORKFLOW,
@@ -451,7 +492,7 @@ func TestCreateExecution_NoAssignedName(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := testutils.GetExecutionRequest()
 	request.Name = ""
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
@@ -466,6 +507,100 @@ func TestCreateExecution_NoAssignedName(t *testing.T) {
 	assert.NotEmpty(t, response.Id.Name)
 }
 
+func TestCreateExecution_Principal(t *testing.T) {
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: testCluster,
+	}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	testCases := []struct {
+		name              string
+		identity          auth.IdentityContext
+		clientPrincipal   string
+		expectedPrincipal string
+	}{
+		{
+			name:              "unauthenticated keeps client principal",
+			clientPrincipal:   "alice@corp.com",
+			expectedPrincipal: "alice@corp.com",
+		},
+		{
+			name:              "authenticated user",
+			identity:          auth.NewIdentityContext("", "bob@corp.com", "flytectl", time.Now(), sets.NewString(), nil),
+			clientPrincipal:   "alice@corp.com",
+			expectedPrincipal: "bob@corp.com",
+		},
+		{
+			name:              "authenticated app",
+			identity:          auth.NewIdentityContext("", "", "flytepropeller", time.Now(), sets.NewString(), nil),
+			clientPrincipal:   "alice@corp.com",
+			expectedPrincipal: "flytepropeller",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := getMockRepositoryForExecTest()
+			setDefaultLpCallbackForExecTest(repository)
+			repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+				func(ctx context.Context, input models.Execution) error {
+					assert.Equal(t, tc.expectedPrincipal, input.User)
+					var spec admin.ExecutionSpec
+					assert.NoError(t, proto.Unmarshal(input.Spec, &spec))
+					assert.Equal(t, tc.expectedPrincipal, spec.Metadata.Principal)
+					return nil
+				})
+			execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+			request := testutils.GetExecutionRequest()
+			request.Spec.Metadata = &admin.ExecutionMetadata{
+				Principal: tc.clientPrincipal,
+			}
+			ctx := context.Background()
+			if !tc.identity.IsEmpty() {
+				ctx = tc.identity.WithContext(ctx)
+			}
+
+			_, err := execManager.CreateExecution(ctx, request, requestedAt)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateExecution_QueueingBudget(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+		return data.ExecutionParameters.QueueingBudget == 5*time.Minute
+	})).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: testCluster,
+	}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	request := testutils.GetExecutionRequest()
+	request.Spec.QualityOfService = &core.QualityOfService{
+		Designation: &core.QualityOfService_Spec{
+			Spec: &core.QualityOfServiceSpec{
+				QueueingBudget: ptypes.DurationProto(5 * time.Minute),
+			},
+		},
+	}
+	_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+	assert.NoError(t, err)
+	mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+
+	// An execution requested longer ago than its budget is rejected rather than launched.
+	_, err = execManager.CreateExecution(context.Background(), request, time.Now().Add(-10*time.Minute))
+	assert.Equal(t, codes.DeadlineExceeded, err.(flyteAdminErrors.FlyteAdminError).Code())
+	mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+}
+
 func TestCreateExecution_TaggedQueue(t *testing.T) {
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
@@ -486,6 +621,13 @@ func TestCreateExecution_TaggedQueue(t *testing.T) {
 		runtimeMocks.NewMockTaskResourceConfiguration(resourceDefaults, resourceLimits), nil, getMockNamespaceMappingConfig())
 	configProvider.(*runtimeMocks.MockConfigurationProvider).AddRegistrationValidationConfiguration(
 		runtimeMocks.NewMockRegistrationValidationProvider())
+	var createdQueue string
+	var createdSpec admin.ExecutionSpec
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			createdQueue = input.Queue
+			return proto.Unmarshal(input.Spec, &createdSpec)
+		})
 
 	mockExecutor := workflowengineMocks.WorkflowExecutor{}
 	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
@@ -495,6 +637,8 @@ func TestCreateExecution_TaggedQueue(t *testing.T) {
 			assert.Contains(t, childContainerQueueKey, task.Template.GetContainer().Config[0].Key)
 			assert.Contains(t, "dynamic Q", task.Template.GetContainer().Config[0].Value)
 		}
+		// The queue a relaunched execution was copied from isn't passed on.
+		assert.NotContains(t, data.ExecutionParameters.Annotations, common.ExecutionQueueAnnotation)
 		return true
 	})).Return(workflowengineInterfaces.ExecutionResponse{
 		Cluster: testCluster,
@@ -502,9 +646,12 @@ func TestCreateExecution_TaggedQueue(t *testing.T) {
 	mockExecutor.OnID().Return("customMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, configProvider, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, configProvider, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	request := testutils.GetExecutionRequest()
+	request.Spec.Annotations = &admin.Annotations{
+		Values: map[string]string{common.ExecutionQueueAnnotation: "previous Q"},
+	}
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
 	assert.Nil(t, err)
 
@@ -513,12 +660,14 @@ func TestCreateExecution_TaggedQueue(t *testing.T) {
 	}
 	assert.Nil(t, err)
 	assert.Equal(t, expectedResponse, response)
+	assert.Equal(t, "dynamic Q", createdQueue)
+	assert.Equal(t, "dynamic Q", createdSpec.GetAnnotations().GetValues()[common.ExecutionQueueAnnotation])
 }
 
 func TestCreateExecutionValidationError(t *testing.T) {
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	request := testutils.GetExecutionRequest()
 	request.Domain = ""
@@ -530,7 +679,7 @@ func TestCreateExecutionValidationError(t *testing.T) {
 func TestCreateExecution_InvalidLpIdentifier(t *testing.T) {
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	request := testutils.GetExecutionRequest()
 	request.Spec.LaunchPlan = nil
@@ -542,7 +691,7 @@ func TestCreateExecution_InvalidLpIdentifier(t *testing.T) {
 func TestCreateExecutionInCompatibleInputs(t *testing.T) {
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	request := testutils.GetExecutionRequest()
 	request.Inputs = &core.LiteralMap{
@@ -564,7 +713,7 @@ func TestCreateExecutionPropellerFailure(t *testing.T) {
 	mockExecutor.OnID().Return("customMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	request := testutils.GetExecutionRequest()
 
@@ -587,7 +736,7 @@ func TestCreateExecutionDatabaseFailure(t *testing.T) {
 	}
 
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(exCreateFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := testutils.GetExecutionRequest()
 
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
@@ -595,6 +744,40 @@ func TestCreateExecutionDatabaseFailure(t *testing.T) {
 	assert.Nil(t, response)
 }
 
+func TestCreateExecution_InputsOffloadFailure(t *testing.T) {
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	var createCalled bool
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			createCalled = true
+			return nil
+		})
+	storageClient := getMockStorageForExecTest(context.Background())
+	writeProtobuf := storageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).WriteProtobufCb
+	storageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).WriteProtobufCb = func(
+		ctx context.Context, reference storage.DataReference, opts storage.Options, msg proto.Message) error {
+		// Inputs are offloaded before user inputs, so this fails part way through writing the execution data.
+		if strings.HasSuffix(reference.String(), shared.UserInputs) {
+			return errors.New("expected storage error")
+		}
+		return writeProtobuf(ctx, reference, opts, msg)
+	}
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	request := testutils.GetExecutionRequest()
+
+	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+	assert.Equal(t, codes.Internal, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.Nil(t, response)
+	assert.False(t, createCalled)
+	mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestCreateExecutionVerifyDbModel(t *testing.T) {
 	request := testutils.GetExecutionRequest()
 	repository := getMockRepositoryForExecTest()
@@ -655,7 +838,7 @@ func TestCreateExecutionVerifyDbModel(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	execManager.(*ExecutionManager)._clock = mockClock
 
@@ -698,7 +881,7 @@ func TestCreateExecutionDefaultNotifications(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
 	assert.Nil(t, err)
@@ -737,7 +920,7 @@ func TestCreateExecutionDisableNotifications(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
 	assert.Nil(t, err)
@@ -807,7 +990,7 @@ func TestCreateExecutionNoNotifications(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	response, err := execManager.CreateExecution(context.Background(), request, requestedAt)
 	assert.Nil(t, err)
@@ -824,10 +1007,14 @@ func TestCreateExecutionDynamicLabelsAndAnnotations(t *testing.T) {
 	mockExecutor := workflowengineMocks.WorkflowExecutor{}
 	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(executionData workflowengineInterfaces.ExecutionData) bool {
 		assert.EqualValues(t, map[string]string{
+			"label1":        "1",
+			"label2":        "2",
 			"dynamiclabel1": "dynamic1",
 			"dynamiclabel2": "dynamic2",
 		}, executionData.ExecutionParameters.Labels)
 		assert.EqualValues(t, map[string]string{
+			"annotation3":        "3",
+			"annotation4":        "4",
 			"dynamicannotation3": "dynamic3",
 			"dynamicannotation4": "dynamic4",
 		}, executionData.ExecutionParameters.Annotations)
@@ -836,7 +1023,7 @@ func TestCreateExecutionDynamicLabelsAndAnnotations(t *testing.T) {
 	mockExecutor.OnID().Return("customMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := testutils.GetExecutionRequest()
 	request.Spec.Labels = &admin.Labels{
 		Values: map[string]string{
@@ -860,6 +1047,215 @@ func TestCreateExecutionDynamicLabelsAndAnnotations(t *testing.T) {
 	assert.Equal(t, expectedResponse, response)
 }
 
+func TestCreateExecution_ScheduleAnnotationsNotPassedOn(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	lpSpec := testutils.GetSampleLpSpecForTest()
+	lpSpec.Annotations = &admin.Annotations{
+		Values: map[string]string{
+			"annotation":                           "value",
+			common.ScheduleOffsetAnnotation:        "90s",
+			common.ScheduleOverlapPolicyAnnotation: "SKIP",
+		},
+	}
+	setLpCallbackForExecTest(repository, &lpSpec)
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(executionData workflowengineInterfaces.ExecutionData) bool {
+		assert.EqualValues(t, map[string]string{
+			"annotation": "value",
+		}, executionData.ExecutionParameters.Annotations)
+		return true
+	})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	_, err := execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.NoError(t, err)
+	mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+}
+
+func TestCreateExecution_Envs(t *testing.T) {
+	getExecutionManager := func(t *testing.T, denylist []string, expectedEnvs map[string]string) (
+		managerInterfaces.ExecutionInterface, *workflowengineMocks.WorkflowExecutor) {
+		repository := getMockRepositoryForExecTest()
+		lpSpec := testutils.GetSampleLpSpecForTest()
+		lpSpec.Annotations = &admin.Annotations{
+			Values: map[string]string{
+				"annotation": "value",
+				common.ExecutionEnvAnnotationPrefix + "REGION": "us-east-1",
+				common.ExecutionEnvAnnotationPrefix + "FLAG":   "off",
+				common.ExecutionEnvAnnotationPrefix + "DEBUG":  "1",
+			},
+		}
+		setLpCallbackForExecTest(repository, &lpSpec)
+		mockExecutor := &workflowengineMocks.WorkflowExecutor{}
+		mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(executionData workflowengineInterfaces.ExecutionData) bool {
+			assert.EqualValues(t, expectedEnvs, executionData.ExecutionParameters.Envs)
+			// The environment variables aren't passed on as annotations.
+			assert.EqualValues(t, map[string]string{
+				"annotation": "value",
+			}, executionData.ExecutionParameters.Annotations)
+			return true
+		})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+		mockExecutor.OnID().Return("customMockExecutor")
+		workflowengine.GetRegistry().Register(mockExecutor)
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+			func(ctx context.Context, input models.Execution) error {
+				// The resolved environment variables are recorded on the stored spec.
+				var spec admin.ExecutionSpec
+				assert.NoError(t, proto.Unmarshal(input.Spec, &spec))
+				assert.EqualValues(t, expectedEnvs, common.GetExecutionEnvs(spec.GetAnnotations().GetValues()))
+				return nil
+			})
+		mockConfig := getMockExecutionsConfigProvider()
+		mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+			runtimeInterfaces.ApplicationConfig{
+				ExecutionEnvDenylist: denylist,
+			})
+		execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()),
+			mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil,
+			&eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+		resourceManager := managerMocks.MockResourceManager{}
+		resourceManager.GetResourceFunc = func(ctx context.Context,
+			request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+			if request.ResourceType != admin.MatchableResource_CLUSTER_RESOURCE {
+				return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+			}
+			return &managerInterfaces.ResourceResponse{
+				Level: managerInterfaces.ResourceLevelProjectDomain,
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+						ClusterResourceAttributes: &admin.ClusterResourceAttributes{
+							Attributes: map[string]string{
+								"projectQuotaCpu": "16",
+								common.ExecutionEnvAnnotationPrefix + "REGION": "eu-west-1",
+								common.ExecutionEnvAnnotationPrefix + "TEAM":   "ml",
+								common.ExecutionEnvAnnotationPrefix + "FLAG":   "project",
+							},
+						},
+					},
+				},
+			}, nil
+		}
+		setResourceManagerForExecTest(execManager, &resourceManager)
+		return execManager, mockExecutor
+	}
+	getRequest := func(envs map[string]string) admin.ExecutionCreateRequest {
+		request := testutils.GetExecutionRequest()
+		request.Spec.Annotations = &admin.Annotations{Values: map[string]string{}}
+		for name, value := range envs {
+			request.Spec.Annotations.Values[common.ExecutionEnvAnnotationPrefix+name] = value
+		}
+		return request
+	}
+
+	t.Run("execution takes precedence", func(t *testing.T) {
+		// The launch plan takes precedence over the project and domain, and the execution over both.
+		execManager, mockExecutor := getExecutionManager(t, nil, map[string]string{
+			"REGION":        "us-east-1",
+			"TEAM":          "ml",
+			"FLAG":          "on",
+			"EXPERIMENT_ID": "42",
+		})
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(map[string]string{
+			"FLAG":          "on",
+			"EXPERIMENT_ID": "42",
+			// Unsets the variable of the launch plan.
+			"DEBUG": "",
+		}), requestedAt)
+		assert.NoError(t, err)
+		mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+	})
+	t.Run("denied", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, []string{"AWS_*"}, nil)
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(map[string]string{
+			"AWS_ACCESS_KEY_ID": "key",
+		}), requestedAt)
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+		mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+	t.Run("denied by the launch plan", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, []string{"REGION"}, nil)
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(nil), requestedAt)
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+		mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, nil, nil)
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(map[string]string{
+			"1FLAG": "on",
+		}), requestedAt)
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+		mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreateExecution_LabelAndAnnotationPrecedence(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	repository.ProjectRepo().(*repositoryMocks.MockProjectRepo).GetFunction = func(
+		ctx context.Context, projectID string) (models.Project, error) {
+		return transformers.CreateProjectModel(&admin.Project{
+			Id: projectID,
+			Labels: &admin.Labels{
+				Values: map[string]string{
+					"projectlabel": "project",
+					"label1":       "project",
+				},
+			}}), nil
+	}
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(executionData workflowengineInterfaces.ExecutionData) bool {
+		assert.EqualValues(t, map[string]string{
+			"configlabel":  "config",
+			"projectlabel": "project",
+			"label1":       "1",
+			"speclabel":    "spec",
+		}, executionData.ExecutionParameters.Labels)
+		assert.EqualValues(t, map[string]string{
+			"configannotation": "config",
+			"annotation3":      "3",
+		}, executionData.ExecutionParameters.Annotations)
+		return true
+	})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	mockConfig := getMockExecutionsConfigProvider()
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		Labels: map[string]string{
+			"configlabel": "config",
+			"label1":      "config",
+		},
+		Annotations: map[string]string{
+			"configannotation": "config",
+			"annotation3":      "config",
+		},
+	})
+	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	request := testutils.GetExecutionRequest()
+	request.Spec.Labels = &admin.Labels{
+		Values: map[string]string{
+			"speclabel": "spec",
+			// Drops the label inherited from the launch plan.
+			"label2": "",
+		},
+	}
+	request.Spec.Annotations = &admin.Annotations{
+		Values: map[string]string{
+			"annotation4": "",
+		},
+	}
+	_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+	assert.Nil(t, err)
+}
+
 func makeExecutionGetFunc(
 	t *testing.T, closureBytes []byte, startTime *time.Time) repositoryMocks.GetExecutionFunc {
 	return func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
@@ -922,7 +1318,7 @@ func TestRelaunchExecution(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := admin.ExecutionClosure{
@@ -980,7 +1376,7 @@ func TestRelaunchExecution_GetExistingFailure(t *testing.T) {
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	expectedErr := errors.New("expected error")
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
@@ -1020,7 +1416,7 @@ func TestRelaunchExecution_CreateFailure(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := admin.ExecutionClosure{
@@ -1051,6 +1447,150 @@ func TestRelaunchExecution_CreateFailure(t *testing.T) {
 	assert.EqualError(t, err, expectedErr.Error())
 }
 
+func TestMergeInputs(t *testing.T) {
+	original := &core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"foo": coreutils.MustMakeLiteral("foo-value-1"),
+			"baz": coreutils.MustMakeLiteral("baz-value"),
+		},
+	}
+	t.Run("no overrides", func(t *testing.T) {
+		assert.Equal(t, original, mergeInputs(original, nil))
+	})
+	t.Run("overrides take precedence", func(t *testing.T) {
+		merged := mergeInputs(original, &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"foo": coreutils.MustMakeLiteral("foo-value-2"),
+				"qux": coreutils.MustMakeLiteral("qux-value"),
+			},
+		})
+		assert.Len(t, merged.Literals, 3)
+		assert.True(t, proto.Equal(coreutils.MustMakeLiteral("foo-value-2"), merged.Literals["foo"]))
+		assert.True(t, proto.Equal(coreutils.MustMakeLiteral("baz-value"), merged.Literals["baz"]))
+		assert.True(t, proto.Equal(coreutils.MustMakeLiteral("qux-value"), merged.Literals["qux"]))
+		assert.True(t, proto.Equal(coreutils.MustMakeLiteral("foo-value-1"), original.Literals["foo"]))
+	})
+	t.Run("no original inputs", func(t *testing.T) {
+		overrides := &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"foo": coreutils.MustMakeLiteral("foo-value-2"),
+			},
+		}
+		assert.True(t, proto.Equal(overrides, mergeInputs(nil, overrides)))
+	})
+}
+
+func TestRelaunchExecutionWithInputs(t *testing.T) {
+	ctx := context.Background()
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: testCluster,
+	}, nil)
+	mockExecutor.OnID().Return("testMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	originalInputsURI := storage.DataReference("s3://bucket/metadata/project/domain/name/user_inputs")
+	existingClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{Phase: core.WorkflowExecution_FAILED})
+	setUp := func(t *testing.T) (repositories.RepositoryInterface, *storage.DataStore) {
+		repository := getMockRepositoryForExecTest()
+		setDefaultLpCallbackForExecTest(repository)
+		storageClient := getMockStorageForExecTest(ctx)
+		assert.NoError(t, storageClient.WriteProtobuf(ctx, originalInputsURI, storage.Options{}, &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"foo": coreutils.MustMakeLiteral("foo-value-1"),
+			},
+		}))
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+			func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+				return models.Execution{
+					ExecutionKey: models.ExecutionKey{
+						Project: "project",
+						Domain:  "domain",
+						Name:    "name",
+					},
+					Spec:          specBytes,
+					Closure:       existingClosureBytes,
+					UserInputsURI: originalInputsURI,
+				}, nil
+			})
+		return repository, storageClient
+	}
+	request := admin.ExecutionRelaunchRequest{
+		Id: &core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+		Name: "relaunchy",
+	}
+
+	t.Run("override", func(t *testing.T) {
+		repository, storageClient := setUp(t)
+		var createCalled bool
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+			func(ctx context.Context, input models.Execution) error {
+				createCalled = true
+				var userInputs, inputs core.LiteralMap
+				assert.NoError(t, storageClient.ReadProtobuf(ctx, input.UserInputsURI, &userInputs))
+				assert.NoError(t, storageClient.ReadProtobuf(ctx, input.InputsURI, &inputs))
+				assert.Len(t, userInputs.Literals, 1)
+				assert.True(t, proto.Equal(coreutils.MustMakeLiteral("foo-value-2"), userInputs.Literals["foo"]))
+				assert.True(t, proto.Equal(coreutils.MustMakeLiteral("foo-value-2"), inputs.Literals["foo"]))
+				assert.True(t, proto.Equal(coreutils.MustMakeLiteral("bar-value"), inputs.Literals["bar"]))
+				return nil
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		_, err := execManager.RelaunchExecutionWithInputs(ctx, request, &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"foo": coreutils.MustMakeLiteral("foo-value-2"),
+			},
+		}, requestedAt)
+		assert.NoError(t, err)
+		assert.True(t, createCalled)
+	})
+
+	invalidOverrides := map[string]*core.LiteralMap{
+		"wrong type": {
+			Literals: map[string]*core.Literal{
+				"foo": coreutils.MustMakeLiteral(1),
+			},
+		},
+		"fixed input": {
+			Literals: map[string]*core.Literal{
+				"bar": coreutils.MustMakeLiteral("bar-value-2"),
+			},
+		},
+		"unknown input": {
+			Literals: map[string]*core.Literal{
+				"baz": coreutils.MustMakeLiteral("baz-value"),
+			},
+		},
+		"missing literal": {
+			Literals: map[string]*core.Literal{
+				"foo": nil,
+			},
+		},
+	}
+	for name, overrides := range invalidOverrides {
+		t.Run(name, func(t *testing.T) {
+			repository, storageClient := setUp(t)
+			var createCalled bool
+			repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+				func(ctx context.Context, input models.Execution) error {
+					createCalled = true
+					return nil
+				})
+			execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+			_, err := execManager.RelaunchExecutionWithInputs(ctx, request, overrides, requestedAt)
+			assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+			assert.False(t, createCalled)
+		})
+	}
+}
+
 func TestRecoverExecution(t *testing.T) {
 	// Set up mocks.
 	repository := getMockRepositoryForExecTest()
@@ -1061,11 +1601,11 @@ func TestRecoverExecution(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := admin.ExecutionClosure{
-		Phase:     core.WorkflowExecution_SUCCEEDED,
+		Phase:     core.WorkflowExecution_FAILED,
 		StartedAt: startTimeProto,
 	}
 	existingClosureBytes, _ := proto.Marshal(&existingClosure)
@@ -1121,11 +1661,11 @@ func TestRecoverExecution_RecoveredChildNode(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := admin.ExecutionClosure{
-		Phase:     core.WorkflowExecution_SUCCEEDED,
+		Phase:     core.WorkflowExecution_FAILED,
 		StartedAt: startTimeProto,
 	}
 	existingClosureBytes, _ := proto.Marshal(&existingClosure)
@@ -1220,7 +1760,7 @@ func TestRecoverExecution_GetExistingFailure(t *testing.T) {
 	// Set up mocks.
 	repository := getMockRepositoryForExecTest()
 	setDefaultLpCallbackForExecTest(repository)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	expectedErr := errors.New("expected error")
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
@@ -1261,11 +1801,11 @@ func TestRecoverExecution_GetExistingInputsFailure(t *testing.T) {
 		ctx context.Context, reference storage.DataReference, msg proto.Message) error {
 		return expectedErr
 	}
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := admin.ExecutionClosure{
-		Phase:     core.WorkflowExecution_SUCCEEDED,
+		Phase:     core.WorkflowExecution_FAILED,
 		StartedAt: startTimeProto,
 	}
 	existingClosureBytes, _ := proto.Marshal(&existingClosure)
@@ -1286,30 +1826,132 @@ func TestRecoverExecution_GetExistingInputsFailure(t *testing.T) {
 	assert.EqualError(t, err, "Unable to read WorkflowClosure from location s3://flyte/metadata/admin/remote closure id : foo")
 }
 
-func TestCreateWorkflowEvent(t *testing.T) {
-	repository := repositoryMocks.NewMockRepository()
-	startTime := time.Now()
-	startTimeProto, _ := ptypes.TimestampProto(startTime)
-	duration := time.Second
-	durationProto := ptypes.DurationProto(duration)
-	existingClosure := admin.ExecutionClosure{
-		Phase:     core.WorkflowExecution_RUNNING,
-		StartedAt: startTimeProto,
-	}
-	existingClosureBytes, _ := proto.Marshal(&existingClosure)
-	executionGetFunc := makeExecutionGetFunc(t, existingClosureBytes, &startTime)
-	executionError := core.ExecutionError{
-		Code:    "foo",
-		Message: "bar baz",
+func TestRecoverExecution_CarriesForwardSpec(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	referenceExecutionID := core.WorkflowExecutionIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
 	}
-	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	endTime := startTime.Add(duration)
-	occurredAt, _ := ptypes.TimestampProto(endTime)
-	closure := admin.ExecutionClosure{
-		Phase:     core.WorkflowExecution_FAILED,
-		StartedAt: startTimeProto,
-		UpdatedAt: occurredAt,
-		Duration:  durationProto,
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+		return proto.Equal(&referenceExecutionID, data.ExecutionParameters.RecoveryExecution)
+	})).Return(workflowengineInterfaces.ExecutionResponse{Cluster: testCluster}, nil)
+	mockExecutor.OnID().Return("testMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	existingSpec := testutils.GetExecutionRequest().Spec
+	existingSpec.Labels = &admin.Labels{Values: map[string]string{"team": "ml"}}
+	existingSpec.Annotations = &admin.Annotations{Values: map[string]string{"owner": "alice"}}
+	existingSpec.AuthRole = &admin.AuthRole{KubernetesServiceAccount: "ml-sa"}
+	existingSpec.Metadata = &admin.ExecutionMetadata{Principal: "alice", Nesting: 1}
+	existingSpecBytes, _ := proto.Marshal(existingSpec)
+	existingClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{Phase: core.WorkflowExecution_TIMED_OUT})
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+			return models.Execution{
+				ExecutionKey: models.ExecutionKey{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "name",
+				},
+				BaseModel: models.BaseModel{
+					ID: uint(8),
+				},
+				Spec:    existingSpecBytes,
+				Phase:   core.WorkflowExecution_TIMED_OUT.String(),
+				Closure: existingClosureBytes,
+			}, nil
+		})
+
+	var createCalled bool
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			createCalled = true
+			var spec admin.ExecutionSpec
+			assert.NoError(t, proto.Unmarshal(input.Spec, &spec))
+			assert.True(t, proto.Equal(existingSpec.LaunchPlan, spec.LaunchPlan))
+			assert.True(t, proto.Equal(existingSpec.Labels, spec.Labels))
+			assert.True(t, proto.Equal(existingSpec.Annotations, spec.Annotations))
+			assert.True(t, proto.Equal(existingSpec.AuthRole, spec.AuthRole))
+			assert.Equal(t, admin.ExecutionMetadata_RECOVERED, spec.Metadata.Mode)
+			assert.True(t, proto.Equal(&referenceExecutionID, spec.Metadata.ReferenceExecution))
+			assert.Empty(t, spec.Metadata.Principal)
+			assert.Equal(t, uint(8), input.SourceExecutionID)
+			return nil
+		})
+
+	_, err := execManager.RecoverExecution(context.Background(), admin.ExecutionRecoverRequest{
+		Id:   &referenceExecutionID,
+		Name: "recovered",
+	}, requestedAt)
+	assert.NoError(t, err)
+	assert.True(t, createCalled)
+}
+
+func TestRecoverExecution_NotRecoverable(t *testing.T) {
+	for _, phase := range []core.WorkflowExecution_Phase{
+		core.WorkflowExecution_RUNNING,
+		core.WorkflowExecution_SUCCEEDED,
+		core.WorkflowExecution_ABORTED,
+	} {
+		t.Run(phase.String(), func(t *testing.T) {
+			repository := getMockRepositoryForExecTest()
+			setDefaultLpCallbackForExecTest(repository)
+			execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+			startTime := time.Now()
+			existingClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{Phase: phase})
+			repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+				makeExecutionGetFunc(t, existingClosureBytes, &startTime))
+
+			var createCalled bool
+			repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+				func(ctx context.Context, input models.Execution) error {
+					createCalled = true
+					return nil
+				})
+
+			_, err := execManager.RecoverExecution(context.Background(), admin.ExecutionRecoverRequest{
+				Id: &core.WorkflowExecutionIdentifier{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "name",
+				},
+				Name: "recovered",
+			}, requestedAt)
+			assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
+			assert.False(t, createCalled)
+		})
+	}
+}
+
+func TestCreateWorkflowEvent(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	startTime := time.Now()
+	startTimeProto, _ := ptypes.TimestampProto(startTime)
+	duration := time.Second
+	durationProto := ptypes.DurationProto(duration)
+	existingClosure := admin.ExecutionClosure{
+		Phase:     core.WorkflowExecution_RUNNING,
+		StartedAt: startTimeProto,
+	}
+	existingClosureBytes, _ := proto.Marshal(&existingClosure)
+	executionGetFunc := makeExecutionGetFunc(t, existingClosureBytes, &startTime)
+	executionError := core.ExecutionError{
+		Code:    "foo",
+		Message: "bar baz",
+	}
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
+	endTime := startTime.Add(duration)
+	occurredAt, _ := ptypes.TimestampProto(endTime)
+	closure := admin.ExecutionClosure{
+		Phase:     core.WorkflowExecution_FAILED,
+		StartedAt: startTimeProto,
+		UpdatedAt: occurredAt,
+		Duration:  durationProto,
 		OutputResult: &admin.ExecutionClosure_Error{
 			Error: &executionError,
 		},
@@ -1345,7 +1987,7 @@ func TestCreateWorkflowEvent(t *testing.T) {
 	}
 	mockDbEventWriter := &eventWriterMocks.WorkflowExecutionEventWriter{}
 	mockDbEventWriter.On("Write", request)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter)
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), request)
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
@@ -1373,7 +2015,7 @@ func TestCreateWorkflowEvent_TerminalState(t *testing.T) {
 		return nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateCallback(updateExecutionFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
@@ -1411,7 +2053,7 @@ func TestCreateWorkflowEvent_NoRunningToQueued(t *testing.T) {
 		return nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateCallback(updateExecutionFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
@@ -1467,7 +2109,7 @@ func TestCreateWorkflowEvent_StartedRunning(t *testing.T) {
 	}
 	mockDbEventWriter := &eventWriterMocks.WorkflowExecutionEventWriter{}
 	mockDbEventWriter.On("Write", request)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter)
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), request)
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
@@ -1498,7 +2140,7 @@ func TestCreateWorkflowEvent_DuplicateRunning(t *testing.T) {
 		},
 	)
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	occurredAtTimestamp, _ := ptypes.TimestampProto(occurredAt)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
@@ -1539,7 +2181,7 @@ func TestCreateWorkflowEvent_InvalidPhaseChange(t *testing.T) {
 		},
 	)
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	occurredAtTimestamp, _ := ptypes.TimestampProto(occurredAt)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
@@ -1573,7 +2215,7 @@ func TestCreateWorkflowEvent_InvalidEvent(t *testing.T) {
 		return nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateCallback(updateExecutionFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
 		Event: &event.WorkflowExecutionEvent{
@@ -1601,7 +2243,7 @@ func TestCreateWorkflowEvent_UpdateModelError(t *testing.T) {
 		Message: "bar baz",
 	}
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
 		Event: &event.WorkflowExecutionEvent{
@@ -1633,7 +2275,7 @@ func TestCreateWorkflowEvent_DatabaseGetError(t *testing.T) {
 		Code:    "foo",
 		Message: "bar baz",
 	}
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
 		Event: &event.WorkflowExecutionEvent{
@@ -1667,7 +2309,7 @@ func TestCreateWorkflowEvent_DatabaseUpdateError(t *testing.T) {
 		return expectedErr
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateCallback(updateExecutionFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	resp, err := execManager.CreateWorkflowEvent(context.Background(), admin.WorkflowExecutionEventRequest{
 		RequestId: "1",
 		Event: &event.WorkflowExecutionEvent{
@@ -1707,7 +2349,7 @@ func TestGetExecution(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
 		Id: &executionIdentifier,
 	})
@@ -1728,7 +2370,7 @@ func TestGetExecution_DatabaseError(t *testing.T) {
 		return models.Execution{}, expectedErr
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
 		Id: &executionIdentifier,
 	})
@@ -1758,7 +2400,7 @@ func TestGetExecution_TransformerError(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
 		Id: &executionIdentifier,
 	})
@@ -1770,10 +2412,15 @@ func TestListExecutions(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
 	executionListFunc := func(
 		ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
-		var projectFilter, domainFilter, nameFilter bool
+		var projectFilter, domainFilter, nameFilter, archivedFilter bool
 		for _, filter := range input.InlineFilters {
-			assert.Equal(t, common.Execution, filter.GetEntity())
 			queryExpr, _ := filter.GetGormQueryExpr()
+			if filter.GetEntity() == common.NamedEntityMetadata {
+				assert.Equal(t, "COALESCE(state, 0) <> ?", queryExpr.Query)
+				archivedFilter = true
+				continue
+			}
+			assert.Equal(t, common.Execution, filter.GetEntity())
 			if queryExpr.Args == projectValue && queryExpr.Query == "execution_project = ?" {
 				projectFilter = true
 			}
@@ -1787,11 +2434,13 @@ func TestListExecutions(t *testing.T) {
 		assert.True(t, projectFilter, "Missing project equality filter")
 		assert.True(t, domainFilter, "Missing domain equality filter")
 		assert.False(t, nameFilter, "Included name equality filter")
+		assert.True(t, archivedFilter, "Missing archived workflow filter")
 		assert.Equal(t, limit, input.Limit)
 		assert.Equal(t, "domain asc", input.SortParameter.GetGormOrderExpr())
 		assert.Equal(t, 2, input.Offset)
 		assert.EqualValues(t, map[common.Entity]bool{
-			common.Execution: true,
+			common.Execution:           true,
+			common.NamedEntityMetadata: true,
 		}, input.JoinTableEntities)
 		return interfaces.ExecutionCollectionOutput{
 			Executions: []models.Execution{
@@ -1818,7 +2467,7 @@ func TestListExecutions(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(executionListFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	executionList, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
@@ -1849,7 +2498,7 @@ func TestListExecutions(t *testing.T) {
 }
 
 func TestListExecutions_MissingParameters(t *testing.T) {
-	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	_, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
 			Domain: domainValue,
@@ -1886,7 +2535,7 @@ func TestListExecutions_DatabaseError(t *testing.T) {
 		return interfaces.ExecutionCollectionOutput{}, expectedErr
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(executionListFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	_, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
 			Project: projectValue,
@@ -1917,7 +2566,7 @@ func TestListExecutions_TransformerError(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(executionListFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	executionList, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
@@ -1930,9 +2579,157 @@ func TestListExecutions_TransformerError(t *testing.T) {
 	assert.Nil(t, executionList)
 }
 
+func TestListExecutions_KeysetPagination(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	keysetSort := &admin.Sort{Key: common.KeysetSortKey, Direction: admin.Sort_DESCENDING}
+	lastCreatedAt := time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC)
+	var listInput interfaces.ListResourceInput
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(func(
+		ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+		listInput = input
+		return interfaces.ExecutionCollectionOutput{
+			Executions: []models.Execution{
+				{
+					BaseModel: models.BaseModel{ID: 7, CreatedAt: lastCreatedAt},
+					ExecutionKey: models.ExecutionKey{
+						Project: projectValue,
+						Domain:  domainValue,
+						Name:    "name",
+					},
+					Spec:    specBytes,
+					Closure: closureBytes,
+				},
+			},
+		}, nil
+	})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	firstPage, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: projectValue,
+			Domain:  domainValue,
+		},
+		Limit:  1,
+		SortBy: keysetSort,
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, listInput.KeysetCursor)
+
+	// The token continues from the last execution of the page.
+	_, err = execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: projectValue,
+			Domain:  domainValue,
+		},
+		Limit:  1,
+		SortBy: keysetSort,
+		Token:  firstPage.Token,
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, listInput.Offset)
+	assert.Equal(t, &common.KeysetCursor{
+		Direction: admin.Sort_DESCENDING,
+		CreatedAt: lastCreatedAt,
+		ID:        7,
+	}, listInput.KeysetCursor)
+
+	// The token is only valid for the sort it was issued for.
+	_, err = execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: projectValue,
+			Domain:  domainValue,
+		},
+		Limit:  1,
+		SortBy: &admin.Sort{Key: "created_at", Direction: admin.Sort_DESCENDING},
+		Token:  firstPage.Token,
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestCountExecutions(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	var listInput interfaces.ListResourceInput
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(func(
+		ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+		listInput = input
+		return interfaces.ExecutionCollectionOutput{}, nil
+	})
+	var countInputs []interfaces.CountResourceInput
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCountCallback(func(
+		ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+		countInputs = append(countInputs, input)
+		return 42, nil
+	})
+	configProvider := getMockExecutionsConfigProvider()
+	configProvider.ApplicationConfiguration().GetTopLevelConfig().CountCacheTTL = config.Duration{Duration: time.Minute}
+	execManager := NewExecutionManager(repository, configProvider, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	requestFilters := "eq(execution_tag.tag,backfill)+eq(phase,RUNNING)"
+	_, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: projectValue,
+			Domain:  domainValue,
+		},
+		Filters: requestFilters,
+		Limit:   limit,
+	})
+	assert.NoError(t, err)
+	countRequest := managerInterfaces.CountResourceRequest{
+		Project: projectValue,
+		Domain:  domainValue,
+		Filters: requestFilters,
+	}
+	response, err := execManager.CountExecutions(context.Background(), countRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, &managerInterfaces.CountResourceResponse{Count: 42}, response)
+
+	// Executions are counted with the filters they are listed with, including the default exclusion of archived
+	// workflows.
+	assert.Len(t, countInputs, 1)
+	assert.Equal(t, listInput.JoinTableEntities, countInputs[0].JoinTableEntities)
+	assert.Len(t, countInputs[0].InlineFilters, len(listInput.InlineFilters))
+	for idx, filter := range listInput.InlineFilters {
+		listExpr, err := filter.GetGormQueryExpr()
+		assert.NoError(t, err)
+		countExpr, err := countInputs[0].InlineFilters[idx].GetGormQueryExpr()
+		assert.NoError(t, err)
+		assert.Equal(t, listExpr, countExpr)
+	}
+	assert.True(t, listInput.JoinTableEntities[common.NamedEntityMetadata])
+
+	// The count is reused for the same filters.
+	response, err = execManager.CountExecutions(context.Background(), countRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), response.Count)
+	assert.Len(t, countInputs, 1)
+}
+
+func TestCountExecutions_Approximate(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCountCallback(func(
+		ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+		assert.Empty(t, input.InlineFilters)
+		return 1000000, nil
+	})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	response, err := execManager.CountExecutions(context.Background(), managerInterfaces.CountResourceRequest{
+		Approximate: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &managerInterfaces.CountResourceResponse{Count: 1000000, Approximate: true}, response)
+
+	_, err = execManager.CountExecutions(context.Background(), managerInterfaces.CountResourceRequest{
+		Project:     projectValue,
+		Domain:      domainValue,
+		Approximate: true,
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
 func TestExecutionManager_PublishNotifications(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
-	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository)
+	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository, mockScope.NewTestScope())
 
 	mockApplicationConfig := runtimeMocks.MockApplicationProvider{}
 	mockApplicationConfig.SetNotificationsConfig(runtimeInterfaces.NotificationsConfig{
@@ -1954,6 +2751,9 @@ func TestExecutionManager_PublishNotifications(t *testing.T) {
 		_clock:             clock.New(),
 		systemMetrics:      newExecutionSystemMetrics(mockScope.NewTestScope()),
 		notificationClient: &mockPublisher,
+		notificationRateLimiter: notifications.NewRateLimiter(
+			runtimeInterfaces.NotificationsRateLimitConfig{}, &mockPublisher, mockScope.NewTestScope()),
+		emailTemplates: &notifications.EmailTemplates{},
 	}
 	// Currently this doesn't do anything special as the code to invoke pushing to SNS isn't enabled yet.
 	// This sets up the skeleton for it and appeases the go lint overlords.
@@ -2028,7 +2828,7 @@ func TestExecutionManager_PublishNotifications(t *testing.T) {
 
 func TestExecutionManager_PublishNotificationsTransformError(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
-	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository)
+	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository, mockScope.NewTestScope())
 	var execManager = &ExecutionManager{
 		db:                 repository,
 		config:             getMockExecutionsConfigProvider(),
@@ -2037,6 +2837,9 @@ func TestExecutionManager_PublishNotificationsTransformError(t *testing.T) {
 		_clock:             clock.New(),
 		systemMetrics:      newExecutionSystemMetrics(mockScope.NewTestScope()),
 		notificationClient: &mockPublisher,
+		notificationRateLimiter: notifications.NewRateLimiter(
+			runtimeInterfaces.NotificationsRateLimitConfig{}, &mockPublisher, mockScope.NewTestScope()),
+		emailTemplates: &notifications.EmailTemplates{},
 	}
 
 	workflowRequest := admin.WorkflowExecutionEventRequest{
@@ -2069,7 +2872,7 @@ func TestExecutionManager_PublishNotificationsTransformError(t *testing.T) {
 
 func TestExecutionManager_TestExecutionManager_PublishNotificationsTransformError(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
-	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository)
+	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository, mockScope.NewTestScope())
 	publishFunc := func(ctx context.Context, key string, msg proto.Message) error {
 		return errors.New("error publishing message")
 	}
@@ -2095,6 +2898,9 @@ func TestExecutionManager_TestExecutionManager_PublishNotificationsTransformErro
 		_clock:             clock.New(),
 		systemMetrics:      newExecutionSystemMetrics(mockScope.NewTestScope()),
 		notificationClient: &mockPublisher,
+		notificationRateLimiter: notifications.NewRateLimiter(
+			runtimeInterfaces.NotificationsRateLimitConfig{}, &mockPublisher, mockScope.NewTestScope()),
+		emailTemplates: &notifications.EmailTemplates{},
 	}
 	// Currently this doesn't do anything special as the code to invoke pushing to SNS isn't enabled yet.
 	// This sets up the skeleton for it and appeases the go lint overlords.
@@ -2140,7 +2946,7 @@ func TestExecutionManager_TestExecutionManager_PublishNotificationsTransformErro
 
 func TestExecutionManager_PublishNotificationsNoPhaseMatch(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
-	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository)
+	queue := executions.NewQueueAllocator(getMockExecutionsConfigProvider(), repository, mockScope.NewTestScope())
 
 	var myExecManager = &ExecutionManager{
 		db:                 repository,
@@ -2150,6 +2956,9 @@ func TestExecutionManager_PublishNotificationsNoPhaseMatch(t *testing.T) {
 		_clock:             clock.New(),
 		systemMetrics:      newExecutionSystemMetrics(mockScope.NewTestScope()),
 		notificationClient: &mockPublisher,
+		notificationRateLimiter: notifications.NewRateLimiter(
+			runtimeInterfaces.NotificationsRateLimitConfig{}, &mockPublisher, mockScope.NewTestScope()),
+		emailTemplates: &notifications.EmailTemplates{},
 	}
 	// Currently this doesn't do anything special as the code to invoke pushing to SNS isn't enabled yet.
 	// This sets up the skeleton for it and appeases the go lint overlords.
@@ -2180,6 +2989,81 @@ func TestExecutionManager_PublishNotificationsNoPhaseMatch(t *testing.T) {
 	assert.Nil(t, myExecManager.publishNotifications(context.Background(), workflowRequest, executionModel))
 }
 
+func TestExecutionManager_PublishNotificationsSlackWebhook(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	published := make(map[string][]string)
+	publisher := notificationMocks.MockPublisher{}
+	publisher.SetPublishCallback(func(ctx context.Context, key string, msg proto.Message) error {
+		published[key] = append(published[key], msg.(*admin.EmailMessage).RecipientsEmail...)
+		return nil
+	})
+	mockApplicationConfig := runtimeMocks.MockApplicationProvider{}
+	mockApplicationConfig.SetNotificationsConfig(runtimeInterfaces.NotificationsConfig{
+		NotificationsSlackConfig: runtimeInterfaces.NotificationsSlackConfig{
+			Channels: map[string]runtimeInterfaces.SlackChannelConfig{
+				"#oncall": {
+					WebhookURLSecretName: "oncall-webhook",
+				},
+			},
+			Message: "Execution {{ name }} has {{ phase }}.",
+		},
+	})
+	mockRuntime := runtimeMocks.NewMockConfigurationProvider(
+		&mockApplicationConfig,
+		runtimeMocks.NewMockQueueConfigurationProvider(
+			[]runtimeInterfaces.ExecutionQueue{}, []runtimeInterfaces.WorkflowConfig{}),
+		nil, nil, nil, nil)
+	var myExecManager = &ExecutionManager{
+		db:                 repository,
+		config:             mockRuntime,
+		storageClient:      getMockStorageForExecTest(context.Background()),
+		_clock:             clock.New(),
+		systemMetrics:      newExecutionSystemMetrics(mockScope.NewTestScope()),
+		notificationClient: &publisher,
+		notificationRateLimiter: notifications.NewRateLimiter(
+			runtimeInterfaces.NotificationsRateLimitConfig{}, &publisher, mockScope.NewTestScope()),
+		emailTemplates: &notifications.EmailTemplates{},
+	}
+	workflowRequest := admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase:       core.WorkflowExecution_FAILED,
+			ExecutionId: &executionIdentifier,
+		},
+	}
+	execClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{
+		WorkflowId: &workflowIdentifier,
+		Notifications: []*admin.Notification{
+			{
+				Phases: []core.WorkflowExecution_Phase{
+					core.WorkflowExecution_FAILED,
+				},
+				Type: &admin.Notification_Slack{
+					Slack: &admin.SlackNotification{
+						RecipientsEmail: []string{
+							"#oncall", "slack@example.com",
+						},
+					},
+				},
+			},
+		},
+	})
+	executionModel := models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+		Phase:   core.WorkflowExecution_FAILED.String(),
+		Closure: execClosureBytes,
+		Spec:    specBytes,
+	}
+	assert.Nil(t, myExecManager.publishNotifications(context.Background(), workflowRequest, executionModel))
+	assert.Equal(t, map[string][]string{
+		notificationInterfaces.SlackWebhookNotificationType: {"#oncall"},
+		"flyteidl.admin.EmailNotification":                  {"slack@example.com"},
+	}, published)
+}
+
 func TestTerminateExecution(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
 	startTime := time.Now()
@@ -2221,12 +3105,14 @@ func TestTerminateExecution(t *testing.T) {
 			Domain:  "domain",
 			Name:    "name",
 		}, data.ExecutionID))
+		// Executions are terminated in the cluster they were created in.
+		assert.Equal(t, testCluster, data.Cluster)
 		return true
 	})).Return(nil)
 	mockExecutor.OnID().Return("customMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	identity := auth.NewIdentityContext("", principal, "", time.Now(), sets.NewString(), nil)
 	ctx := identity.WithContext(context.Background())
@@ -2241,6 +3127,89 @@ func TestTerminateExecution(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
+	assert.Equal(t, float64(1), getLabeledValue(t, execManager.(*ExecutionManager).projectMetrics.Terminated.CounterVec,
+		map[string]string{"project": "project", "domain": "domain"}))
+}
+
+func TestTerminateExecution_RecordedNamespace(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	startTime := time.Now()
+	executionGetFunc := makeExecutionGetFunc(t, []byte{}, &startTime)
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+			execution, err := executionGetFunc(ctx, input)
+			execution.Namespace = "launched-namespace"
+			return execution, err
+		})
+
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnAbortMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.AbortData) bool {
+		// Rather than the namespace the project and domain map to now.
+		return data.Namespace == "launched-namespace"
+	})).Return(nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	_, err := execManager.TerminateExecution(context.Background(), admin.ExecutionTerminateRequest{
+		Id: &core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+		Cause: "abort cause",
+	})
+	assert.NoError(t, err)
+	mockExecutor.AssertNumberOfCalls(t, "Abort", 1)
+}
+
+func TestUpdateExecutionTags(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	startTime := time.Now()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		makeExecutionGetFunc(t, []byte{}, &startTime))
+	updateTagsCalled := false
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateTagsCallback(
+		func(ctx context.Context, input interfaces.Identifier, added, removed []string) error {
+			assert.Equal(t, interfaces.Identifier{Project: "project", Domain: "domain", Name: "name"}, input)
+			assert.Equal(t, []string{"backfill-2024-06"}, added)
+			assert.Equal(t, []string{"adhoc"}, removed)
+			updateTagsCalled = true
+			return nil
+		})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	err := execManager.UpdateExecutionTags(context.Background(), managerInterfaces.ExecutionTagsUpdateRequest{
+		ExecutionID: &executionIdentifier,
+		Add:         []string{"backfill-2024-06"},
+		Remove:      []string{"adhoc"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, updateTagsCalled)
+}
+
+func TestUpdateExecutionTags_TerminalExecution(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+			return models.Execution{
+				ExecutionKey: models.ExecutionKey{Project: input.Project, Domain: input.Domain, Name: input.Name},
+				Phase:        core.WorkflowExecution_SUCCEEDED.String(),
+			}, nil
+		})
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateTagsCallback(
+		func(ctx context.Context, input interfaces.Identifier, added, removed []string) error {
+			assert.Fail(t, "the tags of a terminal execution shouldn't be updated")
+			return nil
+		})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+	err := execManager.UpdateExecutionTags(context.Background(), managerInterfaces.ExecutionTagsUpdateRequest{
+		ExecutionID: &executionIdentifier,
+		Add:         []string{"backfill"},
+	})
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
 }
 
 func TestTerminateExecution_PropellerError(t *testing.T) {
@@ -2253,12 +3222,15 @@ func TestTerminateExecution_PropellerError(t *testing.T) {
 	defer resetExecutor()
 
 	repository := repositoryMocks.NewMockRepository()
+	startTime := time.Now()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		makeExecutionGetFunc(t, []byte{}, &startTime))
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateExecutionCallback(func(
 		context context.Context, execution models.Execution) error {
 		t.Fatal("update should not be called when propeller fails to terminate an execution")
 		return nil
 	})
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	resp, err := execManager.TerminateExecution(context.Background(), admin.ExecutionTerminateRequest{
 		Id: &core.WorkflowExecutionIdentifier{
@@ -2289,7 +3261,7 @@ func TestTerminateExecution_DatabaseError(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	resp, err := execManager.TerminateExecution(context.Background(), admin.ExecutionTerminateRequest{
 		Id: &core.WorkflowExecutionIdentifier{
 			Project: "project",
@@ -2303,30 +3275,276 @@ func TestTerminateExecution_DatabaseError(t *testing.T) {
 	assert.EqualError(t, err, expectedError.Error())
 }
 
-func TestGetExecutionData(t *testing.T) {
-	repository := repositoryMocks.NewMockRepository()
-	startedAt := time.Date(2018, 8, 30, 0, 0, 0, 0, time.UTC)
-	var closure = admin.ExecutionClosure{
-		Phase: core.WorkflowExecution_RUNNING,
-		OutputResult: &admin.ExecutionClosure_Outputs{
-			Outputs: &admin.LiteralMapBlob{
-				Data: &admin.LiteralMapBlob_Uri{
-					Uri: outputURI,
-				},
+func setBulkTerminateExecutions(t *testing.T, repository repositories.RepositoryInterface, phases []core.WorkflowExecution_Phase) {
+	executions := make([]models.Execution, len(phases))
+	for i, phase := range phases {
+		executions[i] = models.Execution{
+			BaseModel: models.BaseModel{
+				ID: uint(i + 1),
 			},
-		},
-	}
-	var closureBytes, _ = proto.Marshal(&closure)
-
-	executionGetFunc := func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
-		return models.Execution{
 			ExecutionKey: models.ExecutionKey{
 				Project: "project",
 				Domain:  "domain",
-				Name:    "name",
+				Name:    fmt.Sprintf("e%d", i),
 			},
-			Spec:         specBytes,
-			Phase:        phase,
+			Phase:   phase.String(),
+			Cluster: testCluster,
+		}
+	}
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(
+		func(ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+			assert.Equal(t, bulkTerminatePageSize, input.Limit)
+			assert.True(t, input.JoinTableEntities[common.LaunchPlan])
+			// Pages continue after the last execution listed rather than at an offset.
+			assert.Zero(t, input.Offset)
+			var afterID uint
+			for _, filter := range input.InlineFilters {
+				if filter.GetField() == "id" {
+					expr, err := filter.GetGormQueryExpr()
+					assert.NoError(t, err)
+					assert.Equal(t, "id > ?", expr.Query)
+					afterID = expr.Args.(uint)
+				}
+			}
+			start := int(afterID)
+			if start >= len(executions) {
+				return interfaces.ExecutionCollectionOutput{}, nil
+			}
+			end := start + input.Limit
+			if end > len(executions) {
+				end = len(executions)
+			}
+			return interfaces.ExecutionCollectionOutput{
+				Executions: executions[start:end],
+			}, nil
+		})
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+			for _, execution := range executions {
+				if execution.Name == input.Name {
+					return execution, nil
+				}
+			}
+			return models.Execution{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "no execution %s", input.Name)
+		})
+}
+
+func TestBulkTerminateExecutions(t *testing.T) {
+	// Spans two pages, with every tenth execution already succeeded.
+	phases := make([]core.WorkflowExecution_Phase, bulkTerminatePageSize+20)
+	for i := range phases {
+		phases[i] = core.WorkflowExecution_RUNNING
+		if i%10 == 0 {
+			phases[i] = core.WorkflowExecution_SUCCEEDED
+		}
+	}
+	request := managerInterfaces.BulkTerminateExecutionsRequest{
+		Project: "project",
+		Domain:  "domain",
+		LaunchPlan: &core.Identifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "bad_lp",
+			Version: "v1",
+		},
+		Cause: "bad launch plan version",
+	}
+
+	t.Run("partial failure", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		setBulkTerminateExecutions(t, repository, phases)
+		var updated int32
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetUpdateExecutionCallback(
+			func(ctx context.Context, execution models.Execution) error {
+				atomic.AddInt32(&updated, 1)
+				assert.Equal(t, request.Cause, execution.AbortCause)
+				return nil
+			})
+		mockExecutor := workflowengineMocks.WorkflowExecutor{}
+		mockExecutor.OnAbortMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.AbortData) bool {
+			return data.ExecutionID.Name == "e3" || data.ExecutionID.Name == "e111"
+		})).Run(func(args mock.Arguments) {
+			if args.Get(1).(workflowengineInterfaces.AbortData).ExecutionID.Name == "e111" {
+				// The first page is terminated before the second one is listed.
+				assert.GreaterOrEqual(t, atomic.LoadInt32(&updated), int32(89))
+			}
+		}).Return(errors.New("cluster unreachable"))
+		mockExecutor.OnAbortMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.AbortData) bool {
+			return data.ExecutionID.Name != "e3" && data.ExecutionID.Name != "e111"
+		})).Return(nil)
+		mockExecutor.OnID().Return("testMockExecutor")
+		workflowengine.GetRegistry().Register(&mockExecutor)
+		defer resetExecutor()
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		resp, err := execManager.BulkTerminateExecutions(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, resp.AlreadyTerminal)
+		assert.Len(t, resp.Terminated, 106)
+		assert.Len(t, resp.Failed, 2)
+		assert.EqualError(t, resp.Failed["e3"], "cluster unreachable")
+		assert.EqualError(t, resp.Failed["e111"], "cluster unreachable")
+		assert.Equal(t, int32(106), atomic.LoadInt32(&updated))
+		for _, id := range resp.Terminated {
+			assert.NotEqual(t, "e0", id.Name)
+			assert.NotEqual(t, "e3", id.Name)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		setBulkTerminateExecutions(t, repository, phases)
+		mockExecutor := workflowengineMocks.WorkflowExecutor{}
+		mockExecutor.OnID().Return("testMockExecutor")
+		workflowengine.GetRegistry().Register(&mockExecutor)
+		defer resetExecutor()
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		dryRun := request
+		dryRun.DryRun = true
+		dryRun.Cause = ""
+		resp, err := execManager.BulkTerminateExecutions(context.Background(), dryRun)
+		assert.NoError(t, err)
+		assert.Equal(t, 12, resp.AlreadyTerminal)
+		assert.Len(t, resp.Terminated, 108)
+		assert.Empty(t, resp.Failed)
+		mockExecutor.AssertNotCalled(t, "Abort", mock.Anything, mock.Anything)
+	})
+
+	t.Run("list failure", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(
+			func(ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+				return interfaces.ExecutionCollectionOutput{}, errors.New("db unavailable")
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		_, err := execManager.BulkTerminateExecutions(context.Background(), request)
+		assert.EqualError(t, err, "db unavailable")
+	})
+
+	t.Run("unselective request", func(t *testing.T) {
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		_, err := execManager.BulkTerminateExecutions(context.Background(), managerInterfaces.BulkTerminateExecutionsRequest{
+			Project: "project",
+			Domain:  "domain",
+			Cause:   "oops",
+		})
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+}
+
+func TestPurgeExecutions(t *testing.T) {
+	createdBefore := time.Now().Add(-time.Hour)
+	request := managerInterfaces.PurgeExecutionsRequest{
+		Project:       "project",
+		Domain:        "domain",
+		CreatedBefore: createdBefore,
+		BatchSize:     2,
+	}
+
+	t.Run("batches", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		// Two full batches followed by a partial one, each batch picking up after the last id of the previous one.
+		var afterIDs []uint
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetPurgeBatchCallback(
+			func(ctx context.Context, input interfaces.PurgeExecutionsInput) (interfaces.PurgeExecutionsOutput, error) {
+				assert.Equal(t, "project", input.Project)
+				assert.Equal(t, "domain", input.Domain)
+				assert.Equal(t, createdBefore, input.CreatedBefore)
+				assert.Equal(t, 2, input.Limit)
+				assert.False(t, input.DryRun)
+				afterIDs = append(afterIDs, input.AfterID)
+				executions := 2
+				if len(afterIDs) == 3 {
+					executions = 1
+				}
+				return interfaces.PurgeExecutionsOutput{
+					Executions: executions,
+					LastID:     uint(len(afterIDs) * 10),
+					DeletedRows: map[string]int64{
+						"executions":      int64(executions),
+						"node_executions": int64(executions * 3),
+					},
+				}, nil
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		resp, err := execManager.PurgeExecutions(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{0, 10, 20}, afterIDs)
+		assert.Equal(t, 5, resp.Executions)
+		assert.Equal(t, map[string]int64{
+			"executions":      5,
+			"node_executions": 15,
+		}, resp.DeletedRows)
+	})
+
+	t.Run("default batch size", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetPurgeBatchCallback(
+			func(ctx context.Context, input interfaces.PurgeExecutionsInput) (interfaces.PurgeExecutionsOutput, error) {
+				assert.Equal(t, defaultPurgeBatchSize, input.Limit)
+				assert.True(t, input.DryRun)
+				return interfaces.PurgeExecutionsOutput{}, nil
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		dryRun := request
+		dryRun.BatchSize = 0
+		dryRun.DryRun = true
+		resp, err := execManager.PurgeExecutions(context.Background(), dryRun)
+		assert.NoError(t, err)
+		assert.Zero(t, resp.Executions)
+	})
+
+	t.Run("purge failure", func(t *testing.T) {
+		repository := repositoryMocks.NewMockRepository()
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetPurgeBatchCallback(
+			func(ctx context.Context, input interfaces.PurgeExecutionsInput) (interfaces.PurgeExecutionsOutput, error) {
+				return interfaces.PurgeExecutionsOutput{}, errors.New("db unavailable")
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		_, err := execManager.PurgeExecutions(context.Background(), request)
+		assert.EqualError(t, err, "db unavailable")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+
+		_, err := execManager.PurgeExecutions(context.Background(), managerInterfaces.PurgeExecutionsRequest{
+			Domain: "domain",
+		})
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+}
+
+func TestGetExecutionData(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	startedAt := time.Date(2018, 8, 30, 0, 0, 0, 0, time.UTC)
+	var closure = admin.ExecutionClosure{
+		Phase: core.WorkflowExecution_RUNNING,
+		OutputResult: &admin.ExecutionClosure_Outputs{
+			Outputs: &admin.LiteralMapBlob{
+				Data: &admin.LiteralMapBlob_Uri{
+					Uri: outputURI,
+				},
+			},
+		},
+	}
+	var closureBytes, _ = proto.Marshal(&closure)
+
+	executionGetFunc := func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+		return models.Execution{
+			ExecutionKey: models.ExecutionKey{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "name",
+			},
+			Spec:         specBytes,
+			Phase:        phase,
 			Closure:      closureBytes,
 			LaunchPlanID: uint(1),
 			WorkflowID:   uint(2),
@@ -2377,7 +3595,7 @@ func TestGetExecutionData(t *testing.T) {
 	}
 
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	dataResponse, err := execManager.GetExecutionData(context.Background(), admin.WorkflowExecutionGetDataRequest{
 		Id: &executionIdentifier,
 	})
@@ -2396,18 +3614,96 @@ func TestGetExecutionData(t *testing.T) {
 	}, dataResponse))
 }
 
-func TestResolveStringMap_RuntimeLimitsObserved(t *testing.T) {
-	_, err := resolveStringMap(&admin.Labels{
-		Values: map[string]string{
-			"dynamiclabel1": "dynamic1",
-			"dynamiclabel2": "dynamic2",
+func TestResolveStringMap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		layers   []map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no layers",
+			expected: map[string]string{},
 		},
-	}, &admin.Labels{
-		Values: map[string]string{
-			"existing1": "value1",
+		{
+			name: "disjoint keys are merged",
+			layers: []map[string]string{
+				{"config": "c"},
+				{"project": "p"},
+				{"execution": "e"},
+			},
+			expected: map[string]string{
+				"config":    "c",
+				"project":   "p",
+				"execution": "e",
+			},
+		},
+		{
+			name: "more specific layer wins",
+			layers: []map[string]string{
+				{"team": "config", "env": "config"},
+				{"team": "project"},
+				{"env": "execution"},
+			},
+			expected: map[string]string{
+				"team": "project",
+				"env":  "execution",
+			},
+		},
+		{
+			name: "empty value deletes inherited key",
+			layers: []map[string]string{
+				{"team": "config", "env": "config"},
+				nil,
+				{"team": ""},
+			},
+			expected: map[string]string{
+				"env": "config",
+			},
+		},
+		{
+			name: "deleted key can be set again by a more specific layer",
+			layers: []map[string]string{
+				{"team": "config"},
+				{"team": ""},
+				{"team": "execution"},
+			},
+			expected: map[string]string{
+				"team": "execution",
+			},
 		},
-	}, "labels", 1)
-	assert.EqualError(t, err, "labels has too many entries [2 > 1]")
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolved, err := resolveStringMap("labels", 0, tc.layers...)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestResolveStringMap_RuntimeLimitsObserved(t *testing.T) {
+	_, err := resolveStringMap("labels", 2, map[string]string{
+		"existing1": "value1",
+	}, map[string]string{
+		"dynamiclabel1": "dynamic1",
+		"dynamiclabel2": "dynamic2",
+	})
+	assert.EqualError(t, err, "labels has too many entries [3 > 2]")
+}
+
+func TestResolveStringMap_RuntimeLimitsObservedAfterDeletions(t *testing.T) {
+	resolved, err := resolveStringMap("labels", 2, map[string]string{
+		"existing1": "value1",
+	}, map[string]string{
+		"existing1":     "",
+		"dynamiclabel1": "dynamic1",
+		"dynamiclabel2": "dynamic2",
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]string{
+		"dynamiclabel1": "dynamic1",
+		"dynamiclabel2": "dynamic2",
+	}, resolved)
 }
 
 func TestAddPluginOverrides(t *testing.T) {
@@ -2440,7 +3736,7 @@ func TestAddPluginOverrides(t *testing.T) {
 			Attributes: bytes,
 		}, nil
 	}
-	execManager := NewExecutionManager(db, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(db, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	taskPluginOverrides, err := execManager.(*ExecutionManager).addPluginOverrides(
 		context.Background(), executionID, workflowName, launchPlanName)
@@ -2471,7 +3767,7 @@ func TestPluginOverrides_ResourceGetFailure(t *testing.T) {
 		models.Resource, error) {
 		return models.Resource{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.Aborted, "uh oh")
 	}
-	execManager := NewExecutionManager(db, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(db, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	_, err := execManager.(*ExecutionManager).addPluginOverrides(
 		context.Background(), executionID, workflowName, launchPlanName)
@@ -2500,7 +3796,7 @@ func TestGetExecution_Legacy(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
 		Id: &executionIdentifier,
 	})
@@ -2558,7 +3854,7 @@ func TestGetExecutionData_LegacyModel(t *testing.T) {
 
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(executionGetFunc)
 	storageClient := getMockStorageForExecTest(context.Background())
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	dataResponse, err := execManager.GetExecutionData(context.Background(), admin.WorkflowExecutionGetDataRequest{
 		Id: &executionIdentifier,
 	})
@@ -2607,7 +3903,7 @@ func TestCreateExecution_LegacyClient(t *testing.T) {
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
 
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	response, err := execManager.CreateExecution(context.Background(), *getLegacyExecutionRequest(), requestedAt)
 	assert.Nil(t, err)
 
@@ -2629,7 +3925,7 @@ func TestRelaunchExecution_LegacyModel(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	startTime := time.Now()
 	startTimeProto, _ := ptypes.TimestampProto(startTime)
 	existingClosure := getLegacyClosure()
@@ -2697,6 +3993,9 @@ func TestListExecutions_LegacyModel(t *testing.T) {
 		ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
 		var projectFilter, domainFilter, nameFilter bool
 		for _, filter := range input.InlineFilters {
+			if filter.GetEntity() == common.NamedEntityMetadata {
+				continue
+			}
 			assert.Equal(t, common.Execution, filter.GetEntity())
 			queryExpr, _ := filter.GetGormQueryExpr()
 			if queryExpr.Args == projectValue && queryExpr.Query == "execution_project = ?" {
@@ -2740,7 +4039,7 @@ func TestListExecutions_LegacyModel(t *testing.T) {
 		}, nil
 	}
 	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(executionListFunc)
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 
 	executionList, err := execManager.ListExecutions(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
@@ -2877,7 +4176,7 @@ func TestSetDefaults(t *testing.T) {
 			},
 		},
 	}
-	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execManager.(*ExecutionManager).setCompiledTaskDefaults(context.Background(), task, workflowengineInterfaces.TaskResources{
 		Defaults: runtimeInterfaces.TaskResourceSet{
 			CPU:              resource.MustParse("200m"),
@@ -2959,7 +4258,7 @@ func TestSetDefaults_MissingRequests_ExistingRequestsPreserved(t *testing.T) {
 			},
 		},
 	}
-	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	execManager.(*ExecutionManager).setCompiledTaskDefaults(context.Background(), task, workflowengineInterfaces.TaskResources{
 		Defaults: runtimeInterfaces.TaskResourceSet{
 			CPU:    resource.MustParse("200m"),
@@ -3035,7 +4334,7 @@ func TestSetDefaults_OptionalRequiredResources(t *testing.T) {
 		},
 	}
 	t.Run("don't inject ephemeral storage or gpu when only the limit is set in config", func(t *testing.T) {
-		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 		execManager.(*ExecutionManager).setCompiledTaskDefaults(context.Background(), task, workflowengineInterfaces.TaskResources{
 			Defaults: runtimeInterfaces.TaskResourceSet{
 				CPU:    resource.MustParse("200m"),
@@ -3072,7 +4371,7 @@ func TestSetDefaults_OptionalRequiredResources(t *testing.T) {
 	})
 
 	t.Run("respect non-required resources when defaults exist in config", func(t *testing.T) {
-		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 		execManager.(*ExecutionManager).setCompiledTaskDefaults(context.Background(), task, workflowengineInterfaces.TaskResources{
 			Limits: taskConfigLimits,
 			Defaults: runtimeInterfaces.TaskResourceSet{
@@ -3242,7 +4541,7 @@ func TestCreateSingleTaskExecution(t *testing.T) {
 	workflowManager := NewWorkflowManager(
 		repository,
 		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorage,
-		storagePrefix, mockScope.NewTestScope())
+		storagePrefix, mockScope.NewTestScope(), nil)
 	namedEntityManager := NewNamedEntityManager(repository, getMockConfigForNETest(), mockScope.NewTestScope())
 
 	mockExecutor := workflowengineMocks.WorkflowExecutor{}
@@ -3250,7 +4549,7 @@ func TestCreateSingleTaskExecution(t *testing.T) {
 	mockExecutor.OnID().Return("testMockExecutor")
 	workflowengine.GetRegistry().Register(&mockExecutor)
 	defer resetExecutor()
-	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, workflowManager, namedEntityManager, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), mockStorage, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, workflowManager, namedEntityManager, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 	request := admin.ExecutionCreateRequest{
 		Project: "flytekit",
 		Domain:  "production",
@@ -3345,7 +4644,7 @@ func TestGetExecutionConfig(t *testing.T) {
 	}
 
 	executionManager := ExecutionManager{
-		resourceManager: &resourceManager,
+		attributesResolver: resources.NewAttributesResolver(&resourceManager, nil),
 	}
 	execConfig, err := executionManager.getExecutionConfig(context.TODO(), &admin.ExecutionCreateRequest{
 		Project: workflowIdentifier.Project,
@@ -3365,8 +4664,8 @@ func TestGetExecutionConfig_Spec(t *testing.T) {
 	}
 	applicationConfig := runtime.NewConfigurationProvider()
 	executionManager := ExecutionManager{
-		resourceManager: &resourceManager,
-		config:          applicationConfig,
+		config:             applicationConfig,
+		attributesResolver: resources.NewAttributesResolver(&resourceManager, applicationConfig),
 	}
 	execConfig, err := executionManager.getExecutionConfig(context.TODO(), &admin.ExecutionCreateRequest{
 		Project: workflowIdentifier.Project,
@@ -3400,8 +4699,8 @@ func TestGetExecutionConfig_Spec(t *testing.T) {
 		return nil, nil
 	}
 	executionManager = ExecutionManager{
-		resourceManager: &resourceManager,
-		config:          applicationConfig,
+		config:             applicationConfig,
+		attributesResolver: resources.NewAttributesResolver(&resourceManager, applicationConfig),
 	}
 
 	execConfig, err = executionManager.getExecutionConfig(context.TODO(), &admin.ExecutionCreateRequest{
@@ -3415,97 +4714,549 @@ func TestGetExecutionConfig_Spec(t *testing.T) {
 	assert.Equal(t, execConfig.MaxParallelism, int32(25))
 }
 
-func TestResolvePermissions(t *testing.T) {
-	assumableIamRole := "role"
-	k8sServiceAccount := "sa"
+func TestCreateExecution_MaxParallelism(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		requestParallelism    int32
+		launchPlanParallelism int32
+		executionConfig       *admin.WorkflowExecutionConfig
+		expected              int32
+	}{
+		{
+			name:                  "execution request",
+			requestParallelism:    10,
+			launchPlanParallelism: 20,
+			executionConfig:       &admin.WorkflowExecutionConfig{MaxParallelism: 30},
+			expected:              10,
+		},
+		{
+			name:                  "launch plan",
+			launchPlanParallelism: 20,
+			executionConfig:       &admin.WorkflowExecutionConfig{MaxParallelism: 30},
+			expected:              20,
+		},
+		{
+			name:            "matchable attributes",
+			executionConfig: &admin.WorkflowExecutionConfig{MaxParallelism: 30},
+			expected:        30,
+		},
+		{
+			name:            "unlimited matchable attributes",
+			executionConfig: &admin.WorkflowExecutionConfig{},
+			expected:        0,
+		},
+		{
+			name:     "application config",
+			expected: 40,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := getMockRepositoryForExecTest()
+			lpSpec := testutils.GetSampleLpSpecForTest()
+			lpSpec.MaxParallelism = tc.launchPlanParallelism
+			setLpCallbackForExecTest(repository, &lpSpec)
+			repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+				func(ctx context.Context, input models.Execution) error {
+					assert.Equal(t, tc.expected, *input.MaxParallelism)
+					// The spec keeps the requested value, which relaunches resolve anew.
+					var spec admin.ExecutionSpec
+					err := proto.Unmarshal(input.Spec, &spec)
+					assert.NoError(t, err)
+					assert.Equal(t, tc.requestParallelism, spec.MaxParallelism)
+					return nil
+				})
+
+			mockExecutor := workflowengineMocks.WorkflowExecutor{}
+			mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+				assert.Equal(t, tc.expected, data.ExecutionParameters.ExecutionConfig.GetMaxParallelism())
+				return true
+			})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+			mockExecutor.OnID().Return("customMockExecutor")
+			workflowengine.GetRegistry().Register(&mockExecutor)
+			defer resetExecutor()
+
+			mockConfig := getMockExecutionsConfigProvider()
+			mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+				runtimeInterfaces.ApplicationConfig{
+					MaxParallelism: 40,
+				})
+			execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+			resourceManager := managerMocks.MockResourceManager{}
+			resourceManager.GetResourceFunc = func(ctx context.Context,
+				request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+				if request.ResourceType != admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG || tc.executionConfig == nil {
+					return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+				}
+				return &managerInterfaces.ResourceResponse{
+					Attributes: &admin.MatchingAttributes{
+						Target: &admin.MatchingAttributes_WorkflowExecutionConfig{
+							WorkflowExecutionConfig: tc.executionConfig,
+						},
+					},
+				}, nil
+			}
+			setResourceManagerForExecTest(execManager, &resourceManager)
 
-	assumableIamRoleLp := "roleLp"
-	k8sServiceAccountLp := "saLp"
+			request := testutils.GetExecutionRequest()
+			request.Spec.MaxParallelism = tc.requestParallelism
+			_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+			assert.NoError(t, err)
+		})
+	}
+}
 
-	assumableIamRoleSc := "roleSc"
-	k8sServiceAccountSc := "saSc"
+func TestCreateExecution_EffectiveAttributes(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	var applied workflowengineInterfaces.ExecutionParameters
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+		applied = data.ExecutionParameters
+		return true
+	})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
 
-	t.Run("backward compat use request values from auth", func(t *testing.T) {
-		execRequest := admin.ExecutionCreateRequest{
-			Spec: &admin.ExecutionSpec{
-				AuthRole: &admin.AuthRole{
-					AssumableIamRole:         assumableIamRole,
-					KubernetesServiceAccount: k8sServiceAccount,
-				},
-			},
-		}
-		lp := &admin.LaunchPlan{
-			Spec: &admin.LaunchPlanSpec{
-				AuthRole: &admin.AuthRole{
-					AssumableIamRole:         "lp role",
-					KubernetesServiceAccount: "k8s sa",
-				},
-			},
-		}
-		authRole := resolveAuthRole(execRequest, lp)
-		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
-		assert.Equal(t, assumableIamRole, authRole.AssumableIamRole)
-		assert.Equal(t, k8sServiceAccount, authRole.KubernetesServiceAccount)
-		assert.Equal(t, &core.SecurityContext{
-			RunAs: &core.Identity{
-				IamRole:           assumableIamRole,
-				K8SServiceAccount: k8sServiceAccount,
-			}}, sc)
-	})
-	t.Run("use request values security context", func(t *testing.T) {
-		execRequest := admin.ExecutionCreateRequest{
-			Spec: &admin.ExecutionSpec{
-				SecurityContext: &core.SecurityContext{
-					RunAs: &core.Identity{
-						IamRole:           assumableIamRoleSc,
-						K8SServiceAccount: k8sServiceAccountSc,
+	var taskResourceRequest managerInterfaces.ResourceRequest
+	resourceManager := managerMocks.MockResourceManager{}
+	resourceManager.GetResourceFunc = func(ctx context.Context,
+		request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+		switch request.ResourceType {
+		case admin.MatchableResource_TASK_RESOURCE:
+			taskResourceRequest = request
+			return &managerInterfaces.ResourceResponse{
+				Level: managerInterfaces.ResourceLevelWorkflow,
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_TaskResourceAttributes{
+						TaskResourceAttributes: &admin.TaskResourceAttributes{
+							Defaults: &admin.TaskResourceSpec{Memory: "1Gi"},
+							Limits:   &admin.TaskResourceSpec{Cpu: "2", Memory: "2Gi"},
+						},
 					},
 				},
-			},
-		}
-		lp := &admin.LaunchPlan{
-			Spec: &admin.LaunchPlanSpec{
-				SecurityContext: &core.SecurityContext{
-					RunAs: &core.Identity{
-						IamRole:           assumableIamRoleSc,
-						K8SServiceAccount: k8sServiceAccountSc,
+			}, nil
+		case admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG:
+			return &managerInterfaces.ResourceResponse{
+				Level: managerInterfaces.ResourceLevelProjectDomain,
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_WorkflowExecutionConfig{
+						WorkflowExecutionConfig: &admin.WorkflowExecutionConfig{MaxParallelism: 30},
 					},
 				},
-			},
-		}
-		authRole := resolveAuthRole(execRequest, lp)
-		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
-		assert.Equal(t, "", authRole.AssumableIamRole)
-		assert.Equal(t, "", authRole.KubernetesServiceAccount)
-		assert.Equal(t, assumableIamRoleSc, sc.RunAs.IamRole)
-		assert.Equal(t, k8sServiceAccountSc, sc.RunAs.K8SServiceAccount)
-	})
-	t.Run("prefer lp auth role over auth", func(t *testing.T) {
-		execRequest := admin.ExecutionCreateRequest{
-			Spec: &admin.ExecutionSpec{},
-		}
-		lp := &admin.LaunchPlan{
-			Spec: &admin.LaunchPlanSpec{
-				AuthRole: &admin.AuthRole{
-					AssumableIamRole:         assumableIamRole,
-					KubernetesServiceAccount: k8sServiceAccount,
-				},
-				Auth: &admin.Auth{
-					AssumableIamRole:         "lp role",
-					KubernetesServiceAccount: "k8s sa",
+			}, nil
+		case admin.MatchableResource_PLUGIN_OVERRIDE:
+			return &managerInterfaces.ResourceResponse{
+				Level: managerInterfaces.ResourceLevelProject,
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_PluginOverrides{
+						PluginOverrides: &admin.PluginOverrides{
+							Overrides: []*admin.PluginOverride{
+								{TaskType: "python", PluginId: []string{"custom"}},
+							},
+						},
+					},
 				},
-			},
+			}, nil
 		}
-		authRole := resolveAuthRole(execRequest, lp)
-		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
-		assert.Equal(t, assumableIamRole, authRole.AssumableIamRole)
-		assert.Equal(t, k8sServiceAccount, authRole.KubernetesServiceAccount)
-		assert.Equal(t, &core.SecurityContext{
-			RunAs: &core.Identity{
-				IamRole:           assumableIamRole,
-				K8SServiceAccount: k8sServiceAccount,
-			},
-		}, sc)
+		return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+	}
+	mockConfig := getMockExecutionsConfigProvider()
+	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	setResourceManagerForExecTest(execManager, &resourceManager)
+	_, err := execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.NoError(t, err)
+
+	// The effective attributes of the workflow are those the execution was created with.
+	resolver := resources.NewAttributesResolver(&resourceManager, mockConfig)
+	getEffectiveAttributes := func(resourceType admin.MatchableResource) *managerInterfaces.EffectiveAttributesResponse {
+		response, err := resolver.ResolveEffectiveAttributes(context.Background(),
+			managerInterfaces.EffectiveAttributesRequest{
+				Project:      taskResourceRequest.Project,
+				Domain:       taskResourceRequest.Domain,
+				Workflow:     taskResourceRequest.Workflow,
+				ResourceType: resourceType,
+			})
+		assert.NoError(t, err)
+		return response
+	}
+	taskResources := getEffectiveAttributes(admin.MatchableResource_TASK_RESOURCE)
+	assert.EqualValues(t, *applied.TaskResources, workflowengineInterfaces.TaskResources{
+		Defaults: resources.FromAdminProtoTaskResourceSpec(context.Background(),
+			taskResources.Attributes.GetTaskResourceAttributes().Defaults),
+		Limits: resources.FromAdminProtoTaskResourceSpec(context.Background(),
+			taskResources.Attributes.GetTaskResourceAttributes().Limits),
+	})
+	assert.Equal(t, []managerInterfaces.AttributeSource{
+		{Field: "defaults.cpu", Level: managerInterfaces.ResourceLevelWorkflow, DefaultedFrom: "limits.cpu"},
+		{Field: "defaults.memory", Level: managerInterfaces.ResourceLevelWorkflow},
+		{Field: "limits.cpu", Level: managerInterfaces.ResourceLevelWorkflow},
+		{Field: "limits.memory", Level: managerInterfaces.ResourceLevelWorkflow},
+	}, taskResources.Sources)
+
+	executionConfig := getEffectiveAttributes(admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG)
+	assert.True(t, proto.Equal(applied.ExecutionConfig, executionConfig.Attributes.GetWorkflowExecutionConfig()))
+	assert.Equal(t, []managerInterfaces.AttributeSource{
+		{Field: "max_parallelism", Level: managerInterfaces.ResourceLevelProjectDomain},
+	}, executionConfig.Sources)
+
+	pluginOverrides := getEffectiveAttributes(admin.MatchableResource_PLUGIN_OVERRIDE)
+	assert.Len(t, applied.TaskPluginOverrides, 1)
+	assert.True(t, proto.Equal(applied.TaskPluginOverrides[0],
+		pluginOverrides.Attributes.GetPluginOverrides().GetOverrides()[0]))
+	assert.Equal(t, []managerInterfaces.AttributeSource{
+		{Field: "overrides.python", Level: managerInterfaces.ResourceLevelProject},
+	}, pluginOverrides.Sources)
+}
+
+func TestCreateExecution_ScheduleOverlapPolicy(t *testing.T) {
+	launchPlanID := uint(100)
+	activeExecution := models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "previous",
+		},
+		Phase:                       core.WorkflowExecution_RUNNING.String(),
+		LaunchPlanID:                launchPlanID,
+		Mode:                        int32(admin.ExecutionMetadata_SCHEDULED),
+		ActiveScheduledLaunchPlanID: &launchPlanID,
+	}
+	terminatedExecution := activeExecution
+	terminatedExecution.Phase = core.WorkflowExecution_SUCCEEDED.String()
+
+	testCases := []struct {
+		name            string
+		policy          runtimeInterfaces.ScheduleOverlapPolicy
+		mode            admin.ExecutionMetadata_ExecutionMode
+		activeExecution *models.Execution
+		expectedCode    codes.Code
+		expectExclusive bool
+		expectReplace   bool
+		expectAbort     bool
+		expectRelease   bool
+	}{
+		{
+			name:            "allow",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicyAllow,
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			activeExecution: &activeExecution,
+		},
+		{
+			name:            "unset policy",
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			activeExecution: &activeExecution,
+		},
+		{
+			name:            "skip doesn't apply to manual executions",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicySkip,
+			mode:            admin.ExecutionMetadata_MANUAL,
+			activeExecution: &activeExecution,
+		},
+		{
+			name:            "skip without a running execution",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicySkip,
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			expectExclusive: true,
+		},
+		{
+			name:            "skip with a running execution",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicySkip,
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			activeExecution: &activeExecution,
+			expectedCode:    codes.AlreadyExists,
+		},
+		{
+			name:            "skip with a terminated execution that wasn't released",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicySkip,
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			activeExecution: &terminatedExecution,
+			expectExclusive: true,
+			expectRelease:   true,
+		},
+		{
+			name:            "replace with a running execution",
+			policy:          runtimeInterfaces.ScheduleOverlapPolicyReplace,
+			mode:            admin.ExecutionMetadata_SCHEDULED,
+			activeExecution: &activeExecution,
+			expectExclusive: true,
+			expectReplace:   true,
+			expectAbort:     true,
+			expectRelease:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := getMockRepositoryForExecTest()
+			lpSpec := testutils.GetSampleLpSpecForTest()
+			setLpCallbackWithOverlapPolicyForExecTest(repository, &lpSpec, tc.policy)
+			executionRepo := repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo)
+			executionRepo.SetListCallback(func(ctx context.Context, input interfaces.ListResourceInput) (
+				interfaces.ExecutionCollectionOutput, error) {
+				if tc.activeExecution == nil {
+					return interfaces.ExecutionCollectionOutput{}, nil
+				}
+				return interfaces.ExecutionCollectionOutput{Executions: []models.Execution{*tc.activeExecution}}, nil
+			})
+			executionRepo.SetGetCallback(func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+				assert.Equal(t, activeExecution.Name, input.Name)
+				return *tc.activeExecution, nil
+			})
+			var released bool
+			executionRepo.SetClearActiveScheduledLaunchPlanCallback(
+				func(ctx context.Context, input interfaces.Identifier) error {
+					assert.Equal(t, activeExecution.Name, input.Name)
+					released = true
+					return nil
+				})
+			var created bool
+			var aborted bool
+			executionRepo.SetCreateCallback(func(ctx context.Context, input models.Execution) error {
+				created = true
+				if tc.expectExclusive {
+					assert.Equal(t, launchPlanID, *input.ActiveScheduledLaunchPlanID)
+				} else {
+					assert.Nil(t, input.ActiveScheduledLaunchPlanID)
+				}
+				if tc.expectReplace {
+					// The new execution takes the launch plan over, and the replaced one keeps running until then.
+					assert.Equal(t, activeExecution.ExecutionKey, *input.ReplacedExecution)
+					assert.False(t, aborted)
+				} else {
+					assert.Nil(t, input.ReplacedExecution)
+				}
+				return nil
+			})
+
+			mockExecutor := workflowengineMocks.WorkflowExecutor{}
+			mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				assert.False(t, aborted)
+			}).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+			mockExecutor.OnAbortMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.AbortData) bool {
+				assert.Equal(t, activeExecution.Name, data.ExecutionID.Name)
+				aborted = true
+				return true
+			})).Return(nil)
+			mockExecutor.OnID().Return("customMockExecutor")
+			workflowengine.GetRegistry().Register(&mockExecutor)
+			defer resetExecutor()
+
+			execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+			request := testutils.GetExecutionRequest()
+			request.Spec.Metadata = &admin.ExecutionMetadata{Mode: tc.mode}
+			_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, err.(flyteAdminErrors.FlyteAdminError).Code())
+				assert.False(t, created)
+				mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, created)
+			}
+			assert.Equal(t, tc.expectAbort, aborted)
+			assert.Equal(t, tc.expectRelease, released)
+		})
+	}
+}
+
+func TestCreateExecution_ConcurrentOverlappingScheduleEvents(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	lpSpec := testutils.GetSampleLpSpecForTest()
+	setLpCallbackWithOverlapPolicyForExecTest(repository, &lpSpec, runtimeInterfaces.ScheduleOverlapPolicySkip)
+	executionRepo := repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo)
+	// Both schedule events find no running execution, only the unique index decides between them.
+	executionRepo.SetListCallback(func(ctx context.Context, input interfaces.ListResourceInput) (
+		interfaces.ExecutionCollectionOutput, error) {
+		return interfaces.ExecutionCollectionOutput{}, nil
+	})
+	var lock sync.Mutex
+	activeLaunchPlans := make(map[uint]bool)
+	executionRepo.SetCreateCallback(func(ctx context.Context, input models.Execution) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if activeLaunchPlans[*input.ActiveScheduledLaunchPlanID] {
+			return flyteAdminErrors.NewFlyteAdminErrorf(codes.AlreadyExists, "duplicate key value violates unique constraint")
+		}
+		activeLaunchPlans[*input.ActiveScheduledLaunchPlanID] = true
+		return nil
+	})
+	executionRepo.SetGetCallback(func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+		return models.Execution{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+	})
+
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnAbortMatch(mock.Anything, mock.Anything).Return(nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, name := range []string{"first", "second"} {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			request := testutils.GetExecutionRequest()
+			request.Name = name
+			request.Spec.Metadata = &admin.ExecutionMetadata{Mode: admin.ExecutionMetadata_SCHEDULED}
+			_, errs[i] = execManager.CreateExecution(context.Background(), request, requestedAt)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			assert.Equal(t, codes.AlreadyExists, err.(flyteAdminErrors.FlyteAdminError).Code())
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	// The losing execution was already launched and must not keep running.
+	mockExecutor.AssertNumberOfCalls(t, "Abort", 1)
+}
+
+func TestCreateWorkflowEvent_ReleasesScheduledLaunchPlan(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	startTime := time.Now()
+	startTimeProto, _ := ptypes.TimestampProto(startTime)
+	existingClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{
+		Phase:     core.WorkflowExecution_RUNNING,
+		StartedAt: startTimeProto,
+	})
+	launchPlanID := uint(100)
+	executionGetFunc := makeExecutionGetFunc(t, existingClosureBytes, &startTime)
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+			execution, err := executionGetFunc(ctx, input)
+			execution.ActiveScheduledLaunchPlanID = &launchPlanID
+			return execution, err
+		})
+	var released bool
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetClearActiveScheduledLaunchPlanCallback(
+		func(ctx context.Context, input interfaces.Identifier) error {
+			assert.Equal(t, executionIdentifier.Name, input.Name)
+			released = true
+			return nil
+		})
+	occurredAt, _ := ptypes.TimestampProto(startTime.Add(time.Second))
+	request := admin.WorkflowExecutionEventRequest{
+		RequestId: "1",
+		Event: &event.WorkflowExecutionEvent{
+			ExecutionId: &executionIdentifier,
+			OccurredAt:  occurredAt,
+			Phase:       core.WorkflowExecution_FAILED,
+			OutputResult: &event.WorkflowExecutionEvent_Error{
+				Error: &core.ExecutionError{
+					Code:    "foo",
+					Message: "bar baz",
+				},
+			},
+			ProducerId: testCluster,
+		},
+	}
+	mockDbEventWriter := &eventWriterMocks.WorkflowExecutionEventWriter{}
+	mockDbEventWriter.On("Write", request)
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter, nil, nil, nil)
+	_, err := execManager.CreateWorkflowEvent(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, released)
+}
+
+func TestResolvePermissions(t *testing.T) {
+	assumableIamRole := "role"
+	k8sServiceAccount := "sa"
+
+	assumableIamRoleLp := "roleLp"
+	k8sServiceAccountLp := "saLp"
+
+	assumableIamRoleSc := "roleSc"
+	k8sServiceAccountSc := "saSc"
+
+	t.Run("backward compat use request values from auth", func(t *testing.T) {
+		execRequest := admin.ExecutionCreateRequest{
+			Spec: &admin.ExecutionSpec{
+				AuthRole: &admin.AuthRole{
+					AssumableIamRole:         assumableIamRole,
+					KubernetesServiceAccount: k8sServiceAccount,
+				},
+			},
+		}
+		lp := &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{
+				AuthRole: &admin.AuthRole{
+					AssumableIamRole:         "lp role",
+					KubernetesServiceAccount: "k8s sa",
+				},
+			},
+		}
+		authRole := resolveAuthRole(execRequest, lp)
+		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
+		assert.Equal(t, assumableIamRole, authRole.AssumableIamRole)
+		assert.Equal(t, k8sServiceAccount, authRole.KubernetesServiceAccount)
+		assert.Equal(t, &core.SecurityContext{
+			RunAs: &core.Identity{
+				IamRole:           assumableIamRole,
+				K8SServiceAccount: k8sServiceAccount,
+			}}, sc)
+	})
+	t.Run("use request values security context", func(t *testing.T) {
+		execRequest := admin.ExecutionCreateRequest{
+			Spec: &admin.ExecutionSpec{
+				SecurityContext: &core.SecurityContext{
+					RunAs: &core.Identity{
+						IamRole:           assumableIamRoleSc,
+						K8SServiceAccount: k8sServiceAccountSc,
+					},
+				},
+			},
+		}
+		lp := &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{
+				SecurityContext: &core.SecurityContext{
+					RunAs: &core.Identity{
+						IamRole:           assumableIamRoleSc,
+						K8SServiceAccount: k8sServiceAccountSc,
+					},
+				},
+			},
+		}
+		authRole := resolveAuthRole(execRequest, lp)
+		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
+		assert.Equal(t, "", authRole.AssumableIamRole)
+		assert.Equal(t, "", authRole.KubernetesServiceAccount)
+		assert.Equal(t, assumableIamRoleSc, sc.RunAs.IamRole)
+		assert.Equal(t, k8sServiceAccountSc, sc.RunAs.K8SServiceAccount)
+	})
+	t.Run("prefer lp auth role over auth", func(t *testing.T) {
+		execRequest := admin.ExecutionCreateRequest{
+			Spec: &admin.ExecutionSpec{},
+		}
+		lp := &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{
+				AuthRole: &admin.AuthRole{
+					AssumableIamRole:         assumableIamRole,
+					KubernetesServiceAccount: k8sServiceAccount,
+				},
+				Auth: &admin.Auth{
+					AssumableIamRole:         "lp role",
+					KubernetesServiceAccount: "k8s sa",
+				},
+			},
+		}
+		authRole := resolveAuthRole(execRequest, lp)
+		sc := resolveSecurityCtx(context.TODO(), execRequest, lp, authRole)
+		assert.Equal(t, assumableIamRole, authRole.AssumableIamRole)
+		assert.Equal(t, k8sServiceAccount, authRole.KubernetesServiceAccount)
+		assert.Equal(t, &core.SecurityContext{
+			RunAs: &core.Identity{
+				IamRole:           assumableIamRole,
+				K8SServiceAccount: k8sServiceAccount,
+			},
+		}, sc)
 	})
 	t.Run("prefer security context over auth context", func(t *testing.T) {
 		execRequest := admin.ExecutionCreateRequest{
@@ -3584,116 +5335,1273 @@ func TestResolvePermissions(t *testing.T) {
 	})
 }
 
-func TestGetTaskResources(t *testing.T) {
-	taskConfig := runtimeMocks.MockTaskResourceConfiguration{}
-	taskConfig.Defaults = runtimeInterfaces.TaskResourceSet{
-		CPU:              resource.MustParse("200m"),
-		GPU:              resource.MustParse("8"),
-		Memory:           resource.MustParse("200Gi"),
-		EphemeralStorage: resource.MustParse("500Mi"),
-		Storage:          resource.MustParse("400Mi"),
-	}
-	taskConfig.Limits = runtimeInterfaces.TaskResourceSet{
-		CPU:              resource.MustParse("300m"),
-		GPU:              resource.MustParse("8"),
-		Memory:           resource.MustParse("500Gi"),
-		EphemeralStorage: resource.MustParse("501Mi"),
-		Storage:          resource.MustParse("450Mi"),
-	}
-	mockConfig := runtimeMocks.NewMockConfigurationProvider(
-		testutils.GetApplicationConfigWithDefaultDomains(), nil, nil, &taskConfig,
-		runtimeMocks.NewMockWhitelistConfiguration(), nil)
-
-	t.Run("use runtime application values", func(t *testing.T) {
-		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{})
-		taskResourceAttrs := execManager.(*ExecutionManager).getTaskResources(context.TODO(), &workflowIdentifier)
-		assert.EqualValues(t, taskResourceAttrs, workflowengineInterfaces.TaskResources{
-			Defaults: runtimeInterfaces.TaskResourceSet{
-				CPU:              resource.MustParse("200m"),
-				GPU:              resource.MustParse("8"),
-				Memory:           resource.MustParse("200Gi"),
-				EphemeralStorage: resource.MustParse("500Mi"),
-				Storage:          resource.MustParse("400Mi"),
-			},
-			Limits: runtimeInterfaces.TaskResourceSet{
-				CPU:              resource.MustParse("300m"),
-				GPU:              resource.MustParse("8"),
-				Memory:           resource.MustParse("500Gi"),
-				EphemeralStorage: resource.MustParse("501Mi"),
-				Storage:          resource.MustParse("450Mi"),
-			},
-		})
-	})
-	t.Run("use specific overrides", func(t *testing.T) {
+func TestResolvePermissions_FallbackChain(t *testing.T) {
+	getExecManager := func(applicationConfig runtimeInterfaces.ApplicationConfig,
+		clusterResourceAttributes map[string]string) *ExecutionManager {
+		mockConfig := getMockExecutionsConfigProvider()
+		mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(applicationConfig)
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
 		resourceManager := managerMocks.MockResourceManager{}
 		resourceManager.GetResourceFunc = func(ctx context.Context,
 			request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
-			assert.EqualValues(t, request, managerInterfaces.ResourceRequest{
-				Project:      workflowIdentifier.Project,
-				Domain:       workflowIdentifier.Domain,
-				Workflow:     workflowIdentifier.Name,
-				ResourceType: admin.MatchableResource_TASK_RESOURCE,
-			})
+			assert.Equal(t, managerInterfaces.ResourceRequest{
+				Project:      "project",
+				Domain:       "domain",
+				ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+			}, request)
+			if clusterResourceAttributes == nil {
+				return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+			}
 			return &managerInterfaces.ResourceResponse{
 				Attributes: &admin.MatchingAttributes{
-					Target: &admin.MatchingAttributes_TaskResourceAttributes{
-						TaskResourceAttributes: &admin.TaskResourceAttributes{
-							Defaults: &admin.TaskResourceSpec{
-								Cpu:              "1200m",
-								Gpu:              "18",
-								Memory:           "1200Gi",
-								EphemeralStorage: "1500Mi",
-								Storage:          "1400Mi",
-							},
-							Limits: &admin.TaskResourceSpec{
-								Cpu:              "300m",
-								Gpu:              "8",
-								Memory:           "500Gi",
-								EphemeralStorage: "501Mi",
-								Storage:          "450Mi",
-							},
+					Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+						ClusterResourceAttributes: &admin.ClusterResourceAttributes{
+							Attributes: clusterResourceAttributes,
 						},
 					},
 				},
 			}, nil
 		}
-		executionManager := ExecutionManager{
-			resourceManager: &resourceManager,
-			config:          mockConfig,
-		}
-		taskResourceAttrs := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
-		assert.EqualValues(t, taskResourceAttrs, workflowengineInterfaces.TaskResources{
-			Defaults: runtimeInterfaces.TaskResourceSet{
-				CPU:              resource.MustParse("1200m"),
-				GPU:              resource.MustParse("18"),
-				Memory:           resource.MustParse("1200Gi"),
-				EphemeralStorage: resource.MustParse("1500Mi"),
-				Storage:          resource.MustParse("1400Mi"),
+		setResourceManagerForExecTest(execManager, &resourceManager)
+		return execManager.(*ExecutionManager)
+	}
+	applicationConfig := runtimeInterfaces.ApplicationConfig{
+		DefaultIamRole:        "config-role",
+		DefaultServiceAccount: "config-sa",
+	}
+	clusterResourceAttributes := map[string]string{
+		resources.DefaultIamRoleAttributeKey:        "project-role",
+		resources.DefaultServiceAccountAttributeKey: "project-sa",
+	}
+	getRequest := func(authRole *admin.AuthRole) admin.ExecutionCreateRequest {
+		return admin.ExecutionCreateRequest{
+			Project: "project",
+			Domain:  "domain",
+			Spec: &admin.ExecutionSpec{
+				AuthRole: authRole,
 			},
-			Limits: runtimeInterfaces.TaskResourceSet{
-				CPU:              resource.MustParse("300m"),
-				GPU:              resource.MustParse("8"),
-				Memory:           resource.MustParse("500Gi"),
-				EphemeralStorage: resource.MustParse("501Mi"),
-				Storage:          resource.MustParse("450Mi"),
+		}
+	}
+	getLaunchPlan := func(authRole *admin.AuthRole) *admin.LaunchPlan {
+		return &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{
+				AuthRole: authRole,
 			},
-		})
-	})
-}
+		}
+	}
 
-func TestFromAdminProtoTaskResourceSpec(t *testing.T) {
-	taskResourceSet := fromAdminProtoTaskResourceSpec(context.TODO(), &admin.TaskResourceSpec{
-		Cpu:              "1",
-		Memory:           "100",
-		Storage:          "200",
-		EphemeralStorage: "300",
-		Gpu:              "2",
+	t.Run("execution request", func(t *testing.T) {
+		authRole, securityCtx, err := getExecManager(applicationConfig, clusterResourceAttributes).resolvePermissions(
+			context.TODO(), getRequest(&admin.AuthRole{
+				AssumableIamRole:         "request-role",
+				KubernetesServiceAccount: "request-sa",
+			}), getLaunchPlan(&admin.AuthRole{
+				AssumableIamRole:         "lp-role",
+				KubernetesServiceAccount: "lp-sa",
+			}))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&admin.AuthRole{
+			AssumableIamRole:         "request-role",
+			KubernetesServiceAccount: "request-sa",
+		}, authRole))
+		assert.True(t, proto.Equal(&core.Identity{
+			IamRole:           "request-role",
+			K8SServiceAccount: "request-sa",
+		}, securityCtx.RunAs))
+	})
+	t.Run("launch plan", func(t *testing.T) {
+		_, securityCtx, err := getExecManager(applicationConfig, clusterResourceAttributes).resolvePermissions(
+			context.TODO(), getRequest(nil), getLaunchPlan(&admin.AuthRole{
+				AssumableIamRole:         "lp-role",
+				KubernetesServiceAccount: "lp-sa",
+			}))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&core.Identity{
+			IamRole:           "lp-role",
+			K8SServiceAccount: "lp-sa",
+		}, securityCtx.RunAs))
 	})
-	assert.EqualValues(t, runtimeInterfaces.TaskResourceSet{
-		CPU:              resource.MustParse("1"),
-		Memory:           resource.MustParse("100"),
-		Storage:          resource.MustParse("200"),
-		EphemeralStorage: resource.MustParse("300"),
-		GPU:              resource.MustParse("2"),
-	}, taskResourceSet)
+	t.Run("launch plan without a role", func(t *testing.T) {
+		authRole, securityCtx, err := getExecManager(applicationConfig, clusterResourceAttributes).resolvePermissions(
+			context.TODO(), getRequest(nil), getLaunchPlan(&admin.AuthRole{
+				KubernetesServiceAccount: "lp-sa",
+			}))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&admin.AuthRole{
+			KubernetesServiceAccount: "lp-sa",
+		}, authRole))
+		assert.True(t, proto.Equal(&core.Identity{
+			K8SServiceAccount: "lp-sa",
+		}, securityCtx.RunAs))
+	})
+	t.Run("matchable attributes", func(t *testing.T) {
+		authRole, securityCtx, err := getExecManager(applicationConfig, clusterResourceAttributes).resolvePermissions(
+			context.TODO(), getRequest(nil), getLaunchPlan(nil))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&admin.AuthRole{
+			AssumableIamRole:         "project-role",
+			KubernetesServiceAccount: "project-sa",
+		}, authRole))
+		assert.True(t, proto.Equal(&core.Identity{
+			IamRole:           "project-role",
+			K8SServiceAccount: "project-sa",
+		}, securityCtx.RunAs))
+
+		_, securityCtx, err = getExecManager(applicationConfig, map[string]string{
+			resources.DefaultServiceAccountAttributeKey: "project-sa",
+		}).resolvePermissions(context.TODO(), getRequest(nil), getLaunchPlan(nil))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&core.Identity{
+			K8SServiceAccount: "project-sa",
+		}, securityCtx.RunAs))
+	})
+	t.Run("application config", func(t *testing.T) {
+		_, securityCtx, err := getExecManager(applicationConfig, nil).resolvePermissions(
+			context.TODO(), getRequest(nil), getLaunchPlan(nil))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&core.Identity{
+			IamRole:           "config-role",
+			K8SServiceAccount: "config-sa",
+		}, securityCtx.RunAs))
+	})
+	t.Run("require auth role", func(t *testing.T) {
+		execManager := getExecManager(runtimeInterfaces.ApplicationConfig{RequireAuthRole: true}, nil)
+		_, _, err := execManager.resolvePermissions(context.TODO(), getRequest(nil), getLaunchPlan(nil))
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+
+		_, securityCtx, err := execManager.resolvePermissions(context.TODO(), getRequest(nil),
+			getLaunchPlan(&admin.AuthRole{
+				KubernetesServiceAccount: "lp-sa",
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, "lp-sa", securityCtx.RunAs.K8SServiceAccount)
+	})
+}
+
+func TestCreateExecution_StoresResolvedPermissions(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			var spec admin.ExecutionSpec
+			assert.NoError(t, proto.Unmarshal(input.Spec, &spec))
+			assert.Equal(t, "config-sa", spec.GetAuthRole().GetKubernetesServiceAccount())
+			assert.Equal(t, "config-sa", spec.GetSecurityContext().GetRunAs().GetK8SServiceAccount())
+			return nil
+		})
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+		return data.ExecutionParameters.SecurityContext.GetRunAs().GetK8SServiceAccount() == "config-sa"
+	})).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	mockConfig := getMockExecutionsConfigProvider()
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+		runtimeInterfaces.ApplicationConfig{
+			DefaultServiceAccount: "config-sa",
+		})
+	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	_, err := execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.NoError(t, err)
+}
+
+func TestCheckActiveExecutionQuota(t *testing.T) {
+	getExecManager := func(quotaConfig runtimeInterfaces.ActiveExecutionQuotaConfig,
+		quota *managerInterfaces.ActiveExecutionQuota, activeExecutions int64) *ExecutionManager {
+		repository := repositoryMocks.NewMockRepository()
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCountCallback(
+			func(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+				assert.Len(t, input.InlineFilters, 3)
+				assert.Equal(t, "phase", input.InlineFilters[2].GetField())
+				return activeExecutions, nil
+			})
+		mockConfig := getMockExecutionsConfigProvider()
+		mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+			runtimeInterfaces.ApplicationConfig{
+				ActiveExecutionQuota: quotaConfig,
+			})
+		execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+		resourceManager := managerMocks.MockResourceManager{}
+		resourceManager.GetQuotaFunc = func(ctx context.Context,
+			request managerInterfaces.ActiveExecutionQuotaRequest) (*managerInterfaces.ActiveExecutionQuota, error) {
+			assert.Equal(t, managerInterfaces.ActiveExecutionQuotaRequest{Project: "project", Domain: request.Domain},
+				request)
+			if quota == nil {
+				return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+			}
+			return quota, nil
+		}
+		setResourceManagerForExecTest(execManager, &resourceManager)
+		return execManager.(*ExecutionManager)
+	}
+	request := admin.ExecutionCreateRequest{
+		Project: "project",
+		Domain:  "domain",
+		Spec:    &admin.ExecutionSpec{},
+	}
+	quotaConfig := runtimeInterfaces.ActiveExecutionQuotaConfig{
+		MaxActiveExecutions: 200,
+		DomainMaxActiveExecutions: map[string]int{
+			"production": 0,
+		},
+	}
+
+	t.Run("below the limit", func(t *testing.T) {
+		assert.NoError(t, getExecManager(quotaConfig, nil, 199).checkActiveExecutionQuota(context.TODO(), request))
+	})
+	t.Run("at the limit", func(t *testing.T) {
+		err := getExecManager(quotaConfig, nil, 200).checkActiveExecutionQuota(context.TODO(), request)
+		assert.EqualError(t, err,
+			"project [project] has 200 active executions in domain [domain], which may have at most 200 at once")
+		assert.Equal(t, codes.ResourceExhausted, err.(flyteAdminErrors.FlyteAdminError).Code())
+		assert.True(t, flyteAdminErrors.IsQuotaExceededError(err))
+	})
+	t.Run("domain without a limit", func(t *testing.T) {
+		productionRequest := request
+		productionRequest.Domain = "production"
+		assert.NoError(t, getExecManager(quotaConfig, nil, 1000).checkActiveExecutionQuota(
+			context.TODO(), productionRequest))
+	})
+	t.Run("matchable override", func(t *testing.T) {
+		getQuota := func(maxActiveExecutions int32) *managerInterfaces.ActiveExecutionQuota {
+			return &managerInterfaces.ActiveExecutionQuota{
+				Project:             "project",
+				Domain:              "domain",
+				MaxActiveExecutions: maxActiveExecutions,
+			}
+		}
+		err := getExecManager(quotaConfig, getQuota(5), 5).checkActiveExecutionQuota(context.TODO(), request)
+		assert.EqualError(t, err,
+			"project [project] has 5 active executions in domain [domain], which may have at most 5 at once")
+
+		assert.NoError(t, getExecManager(quotaConfig, getQuota(0), 1000).checkActiveExecutionQuota(
+			context.TODO(), request))
+		assert.NoError(t, getExecManager(runtimeInterfaces.ActiveExecutionQuotaConfig{}, getQuota(300), 200).
+			checkActiveExecutionQuota(context.TODO(), request))
+	})
+	t.Run("scheduled execution", func(t *testing.T) {
+		scheduledRequest := request
+		scheduledRequest.Spec = &admin.ExecutionSpec{
+			Metadata: &admin.ExecutionMetadata{
+				Mode: admin.ExecutionMetadata_SCHEDULED,
+			},
+		}
+		execManager := getExecManager(quotaConfig, nil, 200)
+		err := execManager.checkActiveExecutionQuota(context.TODO(), scheduledRequest)
+		assert.Equal(t, codes.ResourceExhausted, err.(flyteAdminErrors.FlyteAdminError).Code())
+		assert.Equal(t, float64(1), testutil.ToFloat64(execManager.systemMetrics.ScheduledExecutionsDropped))
+	})
+}
+
+func TestCreateExecution_ActiveExecutionQuotaExhausted(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCountCallback(
+		func(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+			return 1, nil
+		})
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			assert.Fail(t, "executions exhausting the quota shouldn't be created")
+			return nil
+		})
+	repository.ResourceRepo().(*repositoryMocks.MockResourceRepo).GetFunction = func(
+		ctx context.Context, ID interfaces.ResourceID) (models.Resource, error) {
+		if ID.ResourceType == models.ActiveExecutionQuotaResourceType {
+			return models.Resource{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		return models.Resource{}, nil
+	}
+	mockConfig := getMockExecutionsConfigProvider()
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+		runtimeInterfaces.ApplicationConfig{
+			ActiveExecutionQuota: runtimeInterfaces.ActiveExecutionQuotaConfig{
+				MaxActiveExecutions: 1,
+			},
+		})
+	execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	_, err := execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.Equal(t, codes.ResourceExhausted, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.True(t, flyteAdminErrors.IsQuotaExceededError(err))
+}
+
+func TestCreateExecution_LookupCache(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	lpSpec := testutils.GetSampleLpSpecForTest()
+	lpSpecBytes, _ := proto.Marshal(&lpSpec)
+	lpClosureBytes, _ := proto.Marshal(&admin.LaunchPlanClosure{
+		ExpectedInputs: lpSpec.DefaultInputs,
+	})
+	var lpReads int
+	lpState := int32(admin.LaunchPlanState_INACTIVE)
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.LaunchPlan, error) {
+			lpReads++
+			state := lpState
+			return models.LaunchPlan{
+				BaseModel: models.BaseModel{
+					ID: uint(100),
+				},
+				LaunchPlanKey: models.LaunchPlanKey{
+					Project: input.Project,
+					Domain:  input.Domain,
+					Name:    input.Name,
+					Version: input.Version,
+				},
+				Spec:    lpSpecBytes,
+				Closure: lpClosureBytes,
+				State:   &state,
+			}, nil
+		})
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetSetActiveCallback(
+		func(toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
+			lpState = *toEnable.State
+			return nil, nil
+		})
+	var workflowReads int
+	repository.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.Workflow, error) {
+			workflowReads++
+			return models.Workflow{
+				WorkflowKey: models.WorkflowKey{
+					Project: input.Project,
+					Domain:  input.Domain,
+					Name:    input.Name,
+					Version: input.Version,
+				},
+				TypedInterface:          testutils.GetWorkflowRequestInterfaceBytes(),
+				RemoteClosureIdentifier: remoteClosureIdentifier,
+			}, nil
+		})
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: testCluster,
+	}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	lookupCache := util.NewLookupCache(runtimeInterfaces.LookupCacheConfig{
+		MaxEntries:     10,
+		MaxSizeInBytes: 1024 * 1024,
+	}, mockScope.NewTestScope())
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, lookupCache, nil, nil)
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), lookupCache)
+
+	for i := 0; i < 2; i++ {
+		request := testutils.GetExecutionRequest()
+		request.Name = fmt.Sprintf("cached%d", i)
+		_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, lpReads)
+	assert.Equal(t, 1, workflowReads)
+
+	launchPlanID := testutils.GetExecutionRequest().Spec.LaunchPlan
+	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
+		Id:    launchPlanID,
+		State: admin.LaunchPlanState_ACTIVE,
+	})
+	assert.NoError(t, err)
+	launchPlan, err := lpManager.GetLaunchPlan(context.Background(), admin.ObjectGetRequest{
+		Id: launchPlanID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, admin.LaunchPlanState_ACTIVE, launchPlan.Closure.State)
+
+	// Activating the launch plan invalidated it, unlike its workflow.
+	lpReads = 0
+	_, err = execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, lpReads)
+	assert.Equal(t, 1, workflowReads)
+}
+
+func TestNewExecutionManager_SharedWorkflowClosureCache(t *testing.T) {
+	storageClient := getMockStorageForExecTest(context.Background())
+	closures := util.NewWorkflowClosureCache(storageClient, runtimeInterfaces.WorkflowClosureCacheConfig{})
+	workflowManager := NewWorkflowManager(getMockRepositoryForExecTest(), getMockExecutionsConfigProvider(),
+		getMockWorkflowCompiler(), storageClient, storagePrefix, mockScope.NewTestScope(), closures)
+	execManager := NewExecutionManager(getMockRepositoryForExecTest(), getMockExecutionsConfigProvider(),
+		storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL,
+		workflowManager, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, closures, nil)
+	// Both managers hold the closures they read in the same cache.
+	assert.Same(t, closures, workflowManager.(*WorkflowManager).closures)
+	assert.Same(t, closures, execManager.(*ExecutionManager).workflowClosures)
+
+	// Managers created without one get their own.
+	execManager = NewExecutionManager(getMockRepositoryForExecTest(), getMockExecutionsConfigProvider(),
+		storageClient, mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL,
+		workflowManager, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	assert.NotNil(t, execManager.(*ExecutionManager).workflowClosures)
+	assert.NotSame(t, closures, execManager.(*ExecutionManager).workflowClosures)
+}
+
+func TestGetTaskResources(t *testing.T) {
+	taskConfig := runtimeMocks.MockTaskResourceConfiguration{}
+	taskConfig.Defaults = runtimeInterfaces.TaskResourceSet{
+		CPU:              resource.MustParse("200m"),
+		GPU:              resource.MustParse("8"),
+		Memory:           resource.MustParse("200Gi"),
+		EphemeralStorage: resource.MustParse("500Mi"),
+		Storage:          resource.MustParse("400Mi"),
+	}
+	taskConfig.Limits = runtimeInterfaces.TaskResourceSet{
+		CPU:              resource.MustParse("300m"),
+		GPU:              resource.MustParse("8"),
+		Memory:           resource.MustParse("500Gi"),
+		EphemeralStorage: resource.MustParse("501Mi"),
+		Storage:          resource.MustParse("450Mi"),
+	}
+	mockConfig := runtimeMocks.NewMockConfigurationProvider(
+		testutils.GetApplicationConfigWithDefaultDomains(), nil, nil, &taskConfig,
+		runtimeMocks.NewMockWhitelistConfiguration(), nil)
+
+	t.Run("use runtime application values", func(t *testing.T) {
+		execManager := NewExecutionManager(repositoryMocks.NewMockRepository(), mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+		taskResourceAttrs, err := execManager.(*ExecutionManager).getTaskResources(context.TODO(), &workflowIdentifier)
+		assert.NoError(t, err)
+		assert.EqualValues(t, taskResourceAttrs, workflowengineInterfaces.TaskResources{
+			Defaults: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("200m"),
+				GPU:              resource.MustParse("8"),
+				Memory:           resource.MustParse("200Gi"),
+				EphemeralStorage: resource.MustParse("500Mi"),
+				Storage:          resource.MustParse("400Mi"),
+			},
+			Limits: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("300m"),
+				GPU:              resource.MustParse("8"),
+				Memory:           resource.MustParse("500Gi"),
+				EphemeralStorage: resource.MustParse("501Mi"),
+				Storage:          resource.MustParse("450Mi"),
+			},
+		})
+	})
+	getResourceManager := func(attributes *admin.TaskResourceAttributes) managerInterfaces.ResourceInterface {
+		resourceManager := managerMocks.MockResourceManager{}
+		resourceManager.GetResourceFunc = func(ctx context.Context,
+			request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+			assert.EqualValues(t, request, managerInterfaces.ResourceRequest{
+				Project:      workflowIdentifier.Project,
+				Domain:       workflowIdentifier.Domain,
+				Workflow:     workflowIdentifier.Name,
+				ResourceType: admin.MatchableResource_TASK_RESOURCE,
+			})
+			return &managerInterfaces.ResourceResponse{
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_TaskResourceAttributes{
+						TaskResourceAttributes: attributes,
+					},
+				},
+			}, nil
+		}
+		return &resourceManager
+	}
+	t.Run("use specific overrides", func(t *testing.T) {
+		executionManager := ExecutionManager{
+			attributesResolver: resources.NewAttributesResolver(getResourceManager(&admin.TaskResourceAttributes{
+				Defaults: &admin.TaskResourceSpec{
+					Cpu:              "1200m",
+					Gpu:              "8",
+					Memory:           "1200Gi",
+					EphemeralStorage: "1500Mi",
+					Storage:          "1400Mi",
+				},
+				Limits: &admin.TaskResourceSpec{
+					Cpu:              "1300m",
+					Gpu:              "8",
+					Memory:           "1500Gi",
+					EphemeralStorage: "1501Mi",
+					Storage:          "1450Mi",
+				},
+			}), mockConfig),
+		}
+		taskResourceAttrs, err := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
+		assert.NoError(t, err)
+		assert.EqualValues(t, taskResourceAttrs, workflowengineInterfaces.TaskResources{
+			Defaults: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("1200m"),
+				GPU:              resource.MustParse("8"),
+				Memory:           resource.MustParse("1200Gi"),
+				EphemeralStorage: resource.MustParse("1500Mi"),
+				Storage:          resource.MustParse("1400Mi"),
+			},
+			Limits: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("1300m"),
+				GPU:              resource.MustParse("8"),
+				Memory:           resource.MustParse("1500Gi"),
+				EphemeralStorage: resource.MustParse("1501Mi"),
+				Storage:          resource.MustParse("1450Mi"),
+			},
+		})
+	})
+	t.Run("requests default to limits", func(t *testing.T) {
+		executionManager := ExecutionManager{
+			attributesResolver: resources.NewAttributesResolver(getResourceManager(&admin.TaskResourceAttributes{
+				Defaults: &admin.TaskResourceSpec{
+					Cpu: "1",
+				},
+				Limits: &admin.TaskResourceSpec{
+					Cpu:              "2",
+					Gpu:              "1",
+					Memory:           "1Gi",
+					EphemeralStorage: "500Mi",
+				},
+			}), mockConfig),
+		}
+		taskResourceAttrs, err := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
+		assert.NoError(t, err)
+		assert.EqualValues(t, workflowengineInterfaces.TaskResources{
+			Defaults: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("1"),
+				Memory:           resource.MustParse("1Gi"),
+				EphemeralStorage: resource.MustParse("500Mi"),
+			},
+			Limits: runtimeInterfaces.TaskResourceSet{
+				CPU:              resource.MustParse("2"),
+				GPU:              resource.MustParse("1"),
+				Memory:           resource.MustParse("1Gi"),
+				EphemeralStorage: resource.MustParse("500Mi"),
+			},
+		}, taskResourceAttrs)
+	})
+	t.Run("limits default to requests when configured", func(t *testing.T) {
+		limitsFromRequestsConfig := taskConfig
+		limitsFromRequestsConfig.DefaultLimitsFromRequests = true
+		limitsFromRequestsConfigProvider := runtimeMocks.NewMockConfigurationProvider(
+			testutils.GetApplicationConfigWithDefaultDomains(), nil, nil, &limitsFromRequestsConfig,
+			runtimeMocks.NewMockWhitelistConfiguration(), nil)
+		executionManager := ExecutionManager{
+			attributesResolver: resources.NewAttributesResolver(getResourceManager(&admin.TaskResourceAttributes{
+				Defaults: &admin.TaskResourceSpec{
+					Cpu:    "1",
+					Gpu:    "2",
+					Memory: "1Gi",
+				},
+			}), limitsFromRequestsConfigProvider),
+		}
+		taskResourceAttrs, err := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
+		assert.NoError(t, err)
+		assert.EqualValues(t, workflowengineInterfaces.TaskResources{
+			Defaults: runtimeInterfaces.TaskResourceSet{
+				CPU:    resource.MustParse("1"),
+				GPU:    resource.MustParse("2"),
+				Memory: resource.MustParse("1Gi"),
+			},
+			Limits: runtimeInterfaces.TaskResourceSet{
+				CPU:    resource.MustParse("1"),
+				GPU:    resource.MustParse("2"),
+				Memory: resource.MustParse("1Gi"),
+			},
+		}, taskResourceAttrs)
+	})
+	t.Run("limits left unset by default", func(t *testing.T) {
+		executionManager := ExecutionManager{
+			attributesResolver: resources.NewAttributesResolver(getResourceManager(&admin.TaskResourceAttributes{
+				Defaults: &admin.TaskResourceSpec{
+					Cpu: "1",
+				},
+			}), mockConfig),
+		}
+		taskResourceAttrs, err := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
+		assert.NoError(t, err)
+		assert.EqualValues(t, workflowengineInterfaces.TaskResources{
+			Defaults: runtimeInterfaces.TaskResourceSet{
+				CPU: resource.MustParse("1"),
+			},
+		}, taskResourceAttrs)
+	})
+	t.Run("invalid overrides", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			defaults *admin.TaskResourceSpec
+			limits   *admin.TaskResourceSpec
+			expected string
+		}{
+			{
+				name:     "cpu",
+				defaults: &admin.TaskResourceSpec{Cpu: "2"},
+				limits:   &admin.TaskResourceSpec{Cpu: "1"},
+				expected: "CPU request [2] is greater than the limit [1]",
+			},
+			{
+				name:     "memory",
+				defaults: &admin.TaskResourceSpec{Memory: "2Gi"},
+				limits:   &admin.TaskResourceSpec{Memory: "1Gi"},
+				expected: "MEMORY request [2Gi] is greater than the limit [1Gi]",
+			},
+			{
+				name:     "ephemeral storage",
+				defaults: &admin.TaskResourceSpec{EphemeralStorage: "2Gi"},
+				limits:   &admin.TaskResourceSpec{EphemeralStorage: "1Gi"},
+				expected: "EPHEMERAL_STORAGE request [2Gi] is greater than the limit [1Gi]",
+			},
+			{
+				name:     "gpu",
+				defaults: &admin.TaskResourceSpec{Gpu: "2"},
+				limits:   &admin.TaskResourceSpec{Gpu: "1"},
+				expected: "GPU request [2] is greater than the limit [1]",
+			},
+			{
+				name:     "fractional gpu",
+				defaults: &admin.TaskResourceSpec{Gpu: "500m"},
+				limits:   &admin.TaskResourceSpec{Gpu: "1"},
+				expected: "GPU request must be a whole number, got: 500m instead",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				executionManager := ExecutionManager{
+					attributesResolver: resources.NewAttributesResolver(getResourceManager(&admin.TaskResourceAttributes{
+						Defaults: tc.defaults,
+						Limits:   tc.limits,
+					}), mockConfig),
+				}
+				_, err := executionManager.getTaskResources(context.TODO(), &workflowIdentifier)
+				assert.EqualError(t, err, tc.expected)
+				assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+			})
+		}
+	})
+}
+
+func TestCreateExecution_ClusterAssignment(t *testing.T) {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetCreateCallback(
+		func(ctx context.Context, input models.Execution) error {
+			assert.Equal(t, "gpu-cluster", input.Cluster)
+			return nil
+		})
+
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+		return proto.Equal(&admin.ExecutionClusterLabel{Value: "gpu"}, data.ExecutionClusterLabel)
+	})).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: "gpu-cluster",
+	}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+
+	execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	resourceManager := managerMocks.MockResourceManager{}
+	resourceManager.GetResourceFunc = func(ctx context.Context,
+		request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+		if request.ResourceType != admin.MatchableResource_EXECUTION_CLUSTER_LABEL {
+			return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		assert.Equal(t, "project", request.Project)
+		assert.Equal(t, "domain", request.Domain)
+		assert.Equal(t, "name", request.LaunchPlan)
+		return &managerInterfaces.ResourceResponse{
+			Attributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_ExecutionClusterLabel{
+					ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "gpu"},
+				},
+			},
+		}, nil
+	}
+	setResourceManagerForExecTest(execManager, &resourceManager)
+
+	_, err := execManager.CreateExecution(context.Background(), testutils.GetExecutionRequest(), requestedAt)
+	assert.NoError(t, err)
+}
+
+func TestCreateExecution_ClusterAssignmentOverride(t *testing.T) {
+	getRequest := func() admin.ExecutionCreateRequest {
+		request := testutils.GetExecutionRequest()
+		request.Spec.Annotations = &admin.Annotations{
+			Values: map[string]string{
+				common.ExecutionClusterLabelAnnotation: "cpu",
+			},
+		}
+		return request
+	}
+	getExecutionManager := func(t *testing.T, disableOverrides bool) (*ExecutionManager, *workflowengineMocks.WorkflowExecutor) {
+		repository := getMockRepositoryForExecTest()
+		setDefaultLpCallbackForExecTest(repository)
+		mockExecutor := &workflowengineMocks.WorkflowExecutor{}
+		mockExecutor.OnExecuteMatch(mock.Anything, mock.MatchedBy(func(data workflowengineInterfaces.ExecutionData) bool {
+			// The annotation assigns the execution rather than being passed on to its workflow.
+			_, ok := data.ExecutionParameters.Annotations[common.ExecutionClusterLabelAnnotation]
+			return !ok && proto.Equal(&admin.ExecutionClusterLabel{Value: "cpu"}, data.ExecutionClusterLabel)
+		})).Return(workflowengineInterfaces.ExecutionResponse{
+			Cluster: "cpu-cluster",
+		}, nil)
+		mockExecutor.OnID().Return("customMockExecutor")
+		workflowengine.GetRegistry().Register(mockExecutor)
+		mockConfig := getMockExecutionsConfigProvider()
+		topLevelConfig := *mockConfig.ApplicationConfiguration().GetTopLevelConfig()
+		topLevelConfig.DisableExecutionClusterOverrides = disableOverrides
+		mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(topLevelConfig)
+		execManager := NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil).(*ExecutionManager)
+		resourceManager := managerMocks.MockResourceManager{}
+		resourceManager.GetResourceFunc = func(ctx context.Context,
+			request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+			if request.ResourceType != admin.MatchableResource_EXECUTION_CLUSTER_LABEL {
+				return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+			}
+			return &managerInterfaces.ResourceResponse{
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_ExecutionClusterLabel{
+						ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "gpu"},
+					},
+				},
+			}, nil
+		}
+		setResourceManagerForExecTest(execManager, &resourceManager)
+		return execManager, mockExecutor
+	}
+
+	t.Run("overrides the attributes", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, false)
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(), requestedAt)
+		assert.NoError(t, err)
+		mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+	})
+	t.Run("empty", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, false)
+		defer resetExecutor()
+		request := getRequest()
+		request.Spec.Annotations.Values[common.ExecutionClusterLabelAnnotation] = ""
+		_, err := execManager.CreateExecution(context.Background(), request, requestedAt)
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+		mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		execManager, mockExecutor := getExecutionManager(t, true)
+		defer resetExecutor()
+		_, err := execManager.CreateExecution(context.Background(), getRequest(), requestedAt)
+		assert.Equal(t, codes.PermissionDenied, err.(flyteAdminErrors.FlyteAdminError).Code())
+		mockExecutor.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	})
+}
+
+// Keeps the executions created through the mock repository in memory. Like the database, updates only write the
+// fields which are set.
+func getInMemoryExecutionRepositoryForTest(t *testing.T) repositories.RepositoryInterface {
+	repository := getMockRepositoryForExecTest()
+	setDefaultLpCallbackForExecTest(repository)
+	var mutex sync.Mutex
+	executions := make(map[string]models.Execution)
+	executionRepo := repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo)
+	executionRepo.SetCreateCallback(func(ctx context.Context, input models.Execution) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		executions[input.Name] = input
+		return nil
+	})
+	executionRepo.SetGetCallback(func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		execution, ok := executions[input.Name]
+		if !ok {
+			return models.Execution{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		return execution, nil
+	})
+	executionRepo.SetUpdateCallback(func(ctx context.Context, update models.Execution) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		execution, ok := executions[update.Name]
+		assert.True(t, ok)
+		existingValue := reflect.ValueOf(&execution).Elem()
+		updateValue := reflect.ValueOf(update)
+		for i := 0; i < updateValue.NumField(); i++ {
+			if !updateValue.Field(i).IsZero() {
+				existingValue.Field(i).Set(updateValue.Field(i))
+			}
+		}
+		executions[update.Name] = execution
+		return nil
+	})
+	return repository
+}
+
+func getAsyncExecutionManagerForTest(repository repositories.RepositoryInterface) *ExecutionManager {
+	mockConfig := getMockExecutionsConfigProvider()
+	topLevelConfig := *mockConfig.ApplicationConfiguration().GetTopLevelConfig()
+	topLevelConfig.AsyncExecutionLaunch = runtimeInterfaces.AsyncExecutionLaunchConfig{
+		Enabled:   true,
+		Workers:   1,
+		QueueSize: 10,
+	}
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(topLevelConfig)
+	mockDbEventWriter := &eventWriterMocks.WorkflowExecutionEventWriter{}
+	mockDbEventWriter.On("Write", mock.Anything)
+	return NewExecutionManager(repository, mockConfig, getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, &mockPublisher, mockDbEventWriter, nil, nil, nil).(*ExecutionManager)
+}
+
+func getExecutionPhaseForTest(t *testing.T, execManager *ExecutionManager, name string) core.WorkflowExecution_Phase {
+	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
+		Id: &core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    name,
+		},
+	})
+	assert.NoError(t, err)
+	return execution.GetClosure().GetPhase()
+}
+
+func getAsyncExecutionRequestForTest(name string) admin.ExecutionCreateRequest {
+	request := testutils.GetExecutionRequest()
+	request.Name = name
+	return request
+}
+
+// Returns an executor whose launches of the execution block until the returned channel is closed.
+func registerBlockingExecutorForTest(name string, launched *int32) (*workflowengineMocks.WorkflowExecutor, chan struct{}) {
+	blocked := make(chan struct{})
+	mockExecutor := &workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		atomic.AddInt32(launched, 1)
+		if args.Get(1).(workflowengineInterfaces.ExecutionData).ExecutionID.Name == name {
+			<-blocked
+		}
+	}).Return(workflowengineInterfaces.ExecutionResponse{
+		Cluster: testCluster,
+	}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(mockExecutor)
+	return mockExecutor, blocked
+}
+
+func TestCreateExecution_AsyncLaunch(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	var launched int32
+	_, blocked := registerBlockingExecutorForTest("async", &launched)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	response, err := execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("async"), requestedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, "async", response.Id.Name)
+	assert.Equal(t, core.WorkflowExecution_QUEUED, getExecutionPhaseForTest(t, execManager, "async"))
+
+	close(blocked)
+	assert.Eventually(t, func() bool {
+		execution, err := repository.ExecutionRepo().Get(context.Background(), interfaces.Identifier{Name: "async"})
+		return err == nil && execution.Cluster == testCluster
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, core.WorkflowExecution_QUEUED, getExecutionPhaseForTest(t, execManager, "async"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&launched))
+}
+
+func TestFailInterruptedLaunches_InCluster(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	// In cluster deployments launch every workflow into a cluster without an id.
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(workflowengineInterfaces.ExecutionResponse{}, nil)
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+	ctx := context.Background()
+
+	_, err := execManager.CreateExecution(ctx, getAsyncExecutionRequestForTest("launched"), requestedAt)
+	assert.NoError(t, err)
+	// Waits for the launch to complete.
+	execManager.launcher.Stop(ctx)
+	mockExecutor.AssertNumberOfCalls(t, "Execute", 1)
+
+	// An execution queued by a replica which stopped before launching it.
+	closure, err := proto.Marshal(&admin.ExecutionClosure{Phase: core.WorkflowExecution_QUEUED})
+	assert.NoError(t, err)
+	assert.NoError(t, repository.ExecutionRepo().Create(ctx, models.Execution{
+		ExecutionKey: models.ExecutionKey{Project: "project", Domain: "domain", Name: "interrupted"},
+		Phase:        core.WorkflowExecution_QUEUED.String(),
+		Closure:      closure,
+		Spec:         []byte{},
+	}))
+
+	// Lists the queued executions which weren't flagged as launched, as the database would.
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(
+		func(ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+			var fields []string
+			for _, filter := range input.InlineFilters {
+				fields = append(fields, filter.GetField())
+			}
+			assert.Equal(t, []string{"phase", "launch_attempted", "created_at"}, fields)
+			var output interfaces.ExecutionCollectionOutput
+			if len(input.InlineFilters) > 3 {
+				return output, nil
+			}
+			for _, name := range []string{"launched", "interrupted"} {
+				execution, err := repository.ExecutionRepo().Get(ctx, interfaces.Identifier{Name: name})
+				assert.NoError(t, err)
+				if execution.Phase == core.WorkflowExecution_QUEUED.String() && !execution.LaunchAttempted {
+					output.Executions = append(output.Executions, execution)
+				}
+			}
+			return output, nil
+		})
+	failed, err := execManager.FailInterruptedLaunches(ctx, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, core.WorkflowExecution_QUEUED, getExecutionPhaseForTest(t, execManager, "launched"))
+	assert.Equal(t, core.WorkflowExecution_FAILED, getExecutionPhaseForTest(t, execManager, "interrupted"))
+}
+
+func TestCreateExecution_AsyncLaunchFailure(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	mockExecutor := workflowengineMocks.WorkflowExecutor{}
+	mockExecutor.OnExecuteMatch(mock.Anything, mock.Anything).Return(
+		workflowengineInterfaces.ExecutionResponse{}, errors.New("cluster unavailable"))
+	mockExecutor.OnID().Return("customMockExecutor")
+	workflowengine.GetRegistry().Register(&mockExecutor)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	_, err := execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("failed"), requestedAt)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return getExecutionPhaseForTest(t, execManager, "failed") == core.WorkflowExecution_FAILED
+	}, time.Second, time.Millisecond)
+	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
+		Id: &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: "failed"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ExecutionError_SYSTEM, execution.Closure.GetError().Kind)
+	assert.Equal(t, "LaunchFailed", execution.Closure.GetError().Code)
+	assert.Contains(t, execution.Closure.GetError().Message, "cluster unavailable")
+}
+
+func TestCreateExecution_AsyncLaunchQueueingBudgetExceeded(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	var launched int32
+	_, blocked := registerBlockingExecutorForTest("blocker", &launched)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	// The only worker is busy launching the blocker, so the next execution waits in the queue.
+	_, err := execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("blocker"), time.Now())
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&launched) == 1
+	}, time.Second, time.Millisecond)
+	request := getAsyncExecutionRequestForTest("expired")
+	request.Spec.QualityOfService = &core.QualityOfService{
+		Designation: &core.QualityOfService_Spec{
+			Spec: &core.QualityOfServiceSpec{
+				QueueingBudget: ptypes.DurationProto(10 * time.Millisecond),
+			},
+		},
+	}
+	_, err = execManager.CreateExecution(context.Background(), request, time.Now())
+	assert.NoError(t, err)
+	// The execution waits in the queue longer than its budget allows.
+	time.Sleep(50 * time.Millisecond)
+	close(blocked)
+
+	assert.Eventually(t, func() bool {
+		return getExecutionPhaseForTest(t, execManager, "expired") == core.WorkflowExecution_FAILED
+	}, time.Second, time.Millisecond)
+	execution, err := execManager.GetExecution(context.Background(), admin.WorkflowExecutionGetRequest{
+		Id: &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: "expired"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "QueueingBudgetExceeded", execution.Closure.GetError().Code)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&launched))
+}
+
+func TestCreateExecution_AsyncLaunchQueueingBudgetExceededBeforeQueueing(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	var launched int32
+	_, blocked := registerBlockingExecutorForTest("", &launched)
+	close(blocked)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	request := getAsyncExecutionRequestForTest("expired")
+	request.Spec.QualityOfService = &core.QualityOfService{
+		Designation: &core.QualityOfService_Spec{
+			Spec: &core.QualityOfServiceSpec{
+				QueueingBudget: ptypes.DurationProto(time.Minute),
+			},
+		},
+	}
+	// The execution was requested longer ago than its budget allows it to wait, so it is never queued.
+	_, err := execManager.CreateExecution(context.Background(), request, time.Now().Add(-2*time.Minute))
+	assert.Equal(t, codes.DeadlineExceeded, err.(flyteAdminErrors.FlyteAdminError).Code())
+	_, err = repository.ExecutionRepo().Get(context.Background(), interfaces.Identifier{
+		Project: "project", Domain: "domain", Name: "expired",
+	})
+	assert.Error(t, err)
+	assert.Zero(t, atomic.LoadInt32(&launched))
+}
+
+func TestTerminateExecution_BeforeAsyncLaunch(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	var launched int32
+	// Aborting an execution which was never launched fails the test.
+	_, blocked := registerBlockingExecutorForTest("blocker", &launched)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	// Keeps the only worker busy such that the next execution stays queued.
+	_, err := execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("blocker"), requestedAt)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&launched) == 1
+	}, time.Second, time.Millisecond)
+	_, err = execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("queued"), requestedAt)
+	assert.NoError(t, err)
+
+	_, err = execManager.TerminateExecution(context.Background(), admin.ExecutionTerminateRequest{
+		Id:    &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: "queued"},
+		Cause: "no longer needed",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.WorkflowExecution_ABORTED, getExecutionPhaseForTest(t, execManager, "queued"))
+
+	close(blocked)
+	// The cancelled launch is taken off the queue ahead of this one, and never reaches the executor.
+	_, err = execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("next"), requestedAt)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&launched) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, core.WorkflowExecution_ABORTED, getExecutionPhaseForTest(t, execManager, "queued"))
+}
+
+func TestTerminateExecution_DuringAsyncLaunch(t *testing.T) {
+	repository := getInMemoryExecutionRepositoryForTest(t)
+	var launched int32
+	mockExecutor, blocked := registerBlockingExecutorForTest("launching", &launched)
+	var aborts int32
+	mockExecutor.OnAbortMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		atomic.AddInt32(&aborts, 1)
+	}).Return(nil)
+	defer resetExecutor()
+	execManager := getAsyncExecutionManagerForTest(repository)
+
+	_, err := execManager.CreateExecution(context.Background(), getAsyncExecutionRequestForTest("launching"), requestedAt)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&launched) == 1
+	}, time.Second, time.Millisecond)
+
+	// The worker has started to launch the execution, so terminating it aborts the workflow which may not exist yet.
+	_, err = execManager.TerminateExecution(context.Background(), admin.ExecutionTerminateRequest{
+		Id:    &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: "launching"},
+		Cause: "no longer needed",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&aborts))
+	assert.Equal(t, core.WorkflowExecution_QUEUED, getExecutionPhaseForTest(t, execManager, "launching"))
+
+	// The workflow created once the launch completes is aborted too.
+	close(blocked)
+	assert.Eventually(t, func() bool {
+		return getExecutionPhaseForTest(t, execManager, "launching") == core.WorkflowExecution_ABORTED
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&aborts))
+}
+
+func TestGetExecutionTimeline(t *testing.T) {
+	scheduledAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	createdAt := scheduledAt.Add(2 * time.Second)
+	startedAt := createdAt.Add(5 * time.Second)
+	now := startedAt.Add(time.Minute)
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	at := func(offset time.Duration) *time.Time {
+		ts := startedAt.Add(offset)
+		return &ts
+	}
+	// The execution the timeline is computed for, along with its top level node executions.
+	getTimeline := func(t *testing.T, execution models.Execution, spec *admin.ExecutionSpec,
+		entries []interfaces.NodeExecutionTimelineEntry) *managerInterfaces.ExecutionTimeline {
+		specBytes, _ := proto.Marshal(spec)
+		execution.Spec = specBytes
+		execution.ExecutionCreatedAt = &createdAt
+		repository := repositoryMocks.NewMockRepository()
+		repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetCallback(
+			func(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
+				return execution, nil
+			})
+		repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetListTimelineCallback(
+			func(ctx context.Context, executionID core.WorkflowExecutionIdentifier) (
+				[]interfaces.NodeExecutionTimelineEntry, error) {
+				assert.True(t, proto.Equal(&executionIdentifier, &executionID))
+				return entries, nil
+			})
+		execManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil, &eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+		execManager.(*ExecutionManager)._clock = mockClock
+		timeline, err := execManager.GetExecutionTimeline(context.Background(), &executionIdentifier)
+		assert.NoError(t, err)
+		return timeline
+	}
+
+	t.Run("running", func(t *testing.T) {
+		timeline := getTimeline(t, models.Execution{
+			Phase:     core.WorkflowExecution_RUNNING.String(),
+			StartedAt: &startedAt,
+		}, &admin.ExecutionSpec{
+			Metadata: &admin.ExecutionMetadata{
+				Mode:        admin.ExecutionMetadata_SCHEDULED,
+				ScheduledAt: ptypes.TimestampNow(),
+			},
+		}, []interfaces.NodeExecutionTimelineEntry{
+			{
+				NodeID:    "succeeded",
+				Phase:     core.NodeExecution_SUCCEEDED.String(),
+				CreatedAt: &startedAt,
+				StartedAt: at(3 * time.Second),
+				UpdatedAt: at(13 * time.Second),
+				Duration:  10 * time.Second,
+				Retries:   2,
+			},
+			{
+				NodeID:    "running",
+				Phase:     core.NodeExecution_RUNNING.String(),
+				CreatedAt: at(13 * time.Second),
+				StartedAt: at(20 * time.Second),
+				UpdatedAt: at(20 * time.Second),
+			},
+			{
+				NodeID:    "queued",
+				Phase:     core.NodeExecution_QUEUED.String(),
+				CreatedAt: at(50 * time.Second),
+				UpdatedAt: at(50 * time.Second),
+			},
+			{
+				NodeID: "undefined",
+			},
+		})
+
+		// The schedule time is in the future of the execution here, such that the latency is clamped.
+		assert.Equal(t, createdAt, *timeline.AcceptLatency.End)
+		assert.Equal(t, time.Duration(0), timeline.AcceptLatency.Duration)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    createdAt,
+			End:      &startedAt,
+			Duration: 5 * time.Second,
+		}, timeline.StartLatency)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    startedAt,
+			Duration: time.Minute,
+			Open:     true,
+		}, timeline.RunTime)
+
+		assert.Len(t, timeline.Nodes, 4)
+		assert.Equal(t, managerInterfaces.NodeExecutionTimeline{
+			NodeID: "succeeded",
+			Phase:  core.NodeExecution_SUCCEEDED,
+			Queued: &managerInterfaces.TimelineSpan{
+				Start:    startedAt,
+				End:      at(3 * time.Second),
+				Duration: 3 * time.Second,
+			},
+			Run: &managerInterfaces.TimelineSpan{
+				Start:    *at(3 * time.Second),
+				End:      at(13 * time.Second),
+				Duration: 10 * time.Second,
+			},
+			Retries: 2,
+		}, timeline.Nodes[0])
+		assert.Equal(t, 7*time.Second, timeline.Nodes[1].Queued.Duration)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    *at(20 * time.Second),
+			Duration: 40 * time.Second,
+			Open:     true,
+		}, timeline.Nodes[1].Run)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    *at(50 * time.Second),
+			Duration: 10 * time.Second,
+			Open:     true,
+		}, timeline.Nodes[2].Queued)
+		assert.Nil(t, timeline.Nodes[2].Run)
+		assert.Nil(t, timeline.Nodes[3].Queued)
+		assert.Nil(t, timeline.Nodes[3].Run)
+	})
+	t.Run("scheduled", func(t *testing.T) {
+		timeline := getTimeline(t, models.Execution{
+			Phase:     core.WorkflowExecution_SUCCEEDED.String(),
+			StartedAt: &startedAt,
+			Duration:  30 * time.Second,
+		}, &admin.ExecutionSpec{
+			Metadata: &admin.ExecutionMetadata{
+				Mode:        admin.ExecutionMetadata_SCHEDULED,
+				ScheduledAt: testutils.MockCreatedAtProto,
+			},
+		}, nil)
+
+		expectedScheduledAt, _ := ptypes.Timestamp(testutils.MockCreatedAtProto)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    expectedScheduledAt,
+			End:      &createdAt,
+			Duration: createdAt.Sub(expectedScheduledAt),
+		}, timeline.AcceptLatency)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    startedAt,
+			End:      at(30 * time.Second),
+			Duration: 30 * time.Second,
+		}, timeline.RunTime)
+		assert.Empty(t, timeline.Nodes)
+	})
+	t.Run("aborted before starting", func(t *testing.T) {
+		updatedAt := createdAt.Add(time.Second)
+		timeline := getTimeline(t, models.Execution{
+			Phase:              core.WorkflowExecution_ABORTED.String(),
+			ExecutionUpdatedAt: &updatedAt,
+		}, &admin.ExecutionSpec{
+			Metadata: &admin.ExecutionMetadata{
+				Mode: admin.ExecutionMetadata_MANUAL,
+			},
+		}, nil)
+
+		assert.Nil(t, timeline.AcceptLatency)
+		assert.Equal(t, &managerInterfaces.TimelineSpan{
+			Start:    createdAt,
+			End:      &updatedAt,
+			Duration: time.Second,
+		}, timeline.StartLatency)
+		assert.Nil(t, timeline.RunTime)
+	})
+}
+
+func TestGetScheduleCheckpoint(t *testing.T) {
+	launchPlanModel := models.LaunchPlan{
+		LaunchPlanKey: models.LaunchPlanKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+			Version: "version",
+		},
+	}
+	scheduledAt := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	scheduledAtProto, _ := ptypes.TimestampProto(scheduledAt)
+
+	checkpoint := getScheduleCheckpoint(&admin.ExecutionSpec{
+		Metadata: &admin.ExecutionMetadata{
+			Mode:        admin.ExecutionMetadata_SCHEDULED,
+			ScheduledAt: scheduledAtProto,
+		},
+	}, launchPlanModel, "execution")
+	assert.Equal(t, &models.ScheduleCheckpoint{
+		ScheduleCheckpointKey: models.ScheduleCheckpointKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+			Version: "version",
+		},
+		LastScheduledAt:   scheduledAt,
+		LastExecutionName: "execution",
+	}, checkpoint)
+
+	assert.Nil(t, getScheduleCheckpoint(&admin.ExecutionSpec{
+		Metadata: &admin.ExecutionMetadata{Mode: admin.ExecutionMetadata_SCHEDULED},
+	}, launchPlanModel, "execution"))
+	assert.Nil(t, getScheduleCheckpoint(&admin.ExecutionSpec{
+		Metadata: &admin.ExecutionMetadata{
+			Mode:        admin.ExecutionMetadata_MANUAL,
+			ScheduledAt: scheduledAtProto,
+		},
+	}, launchPlanModel, "execution"))
 }
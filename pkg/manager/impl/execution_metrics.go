@@ -0,0 +1,122 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+)
+
+// The project label of the metrics of projects which aren't labeled by name.
+const otherProjectLabel = "other"
+
+// The kind of error failed executions are labeled with.
+const errorKindKey contextutils.Key = "error_kind"
+
+// Decides which projects the execution metrics are labeled with. Projects are labeled by name in the order they're
+// first seen, until the maximum number of labeled projects is reached.
+type projectLabeler struct {
+	aggregated map[string]bool
+	maxLabeled int
+	mutex      sync.Mutex
+	labeled    map[string]bool
+}
+
+func (l *projectLabeler) label(project string) string {
+	if l.aggregated[project] {
+		return otherProjectLabel
+	}
+	if l.maxLabeled <= 0 {
+		return project
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.labeled[project] {
+		return project
+	}
+	if len(l.labeled) >= l.maxLabeled {
+		return otherProjectLabel
+	}
+	l.labeled[project] = true
+	return project
+}
+
+func newProjectLabeler(config runtimeInterfaces.ExecutionMetricsConfig) *projectLabeler {
+	aggregated := make(map[string]bool, len(config.AggregatedProjects))
+	for _, project := range config.AggregatedProjects {
+		aggregated[project] = true
+	}
+	return &projectLabeler{
+		aggregated: aggregated,
+		maxLabeled: config.MaxLabeledProjects,
+		labeled:    make(map[string]bool),
+	}
+}
+
+// Execution metrics labeled with the project and domain of the execution.
+type executionProjectMetrics struct {
+	projects       *projectLabeler
+	Created        labeled.Counter
+	CreateDuration labeled.StopWatch
+	Relaunched     labeled.Counter
+	Terminated     labeled.Counter
+	Completed      labeled.Counter
+	Failed         labeled.Counter
+}
+
+// Returns a context holding only the labels of the project and domain given, since the other metric keys, such as the
+// execution id, aren't bounded.
+func (m *executionProjectMetrics) context(project, domain string) context.Context {
+	return contextutils.WithProjectDomain(context.Background(), m.projects.label(project), domain)
+}
+
+func (m *executionProjectMetrics) recordCreated(project, domain string) {
+	m.Created.Inc(m.context(project, domain))
+}
+
+func (m *executionProjectMetrics) recordCreateDuration(project, domain string, start time.Time) {
+	m.CreateDuration.Observe(m.context(project, domain), start, time.Now())
+}
+
+func (m *executionProjectMetrics) recordRelaunched(project, domain string) {
+	m.Relaunched.Inc(m.context(project, domain))
+}
+
+func (m *executionProjectMetrics) recordTerminated(project, domain string) {
+	m.Terminated.Inc(m.context(project, domain))
+}
+
+// Records an execution reaching a terminal phase, and failures by the kind of their error.
+func (m *executionProjectMetrics) recordCompleted(project, domain string, phase core.WorkflowExecution_Phase,
+	executionError *core.ExecutionError) {
+	ctx := m.context(project, domain)
+	m.Completed.Inc(context.WithValue(ctx, contextutils.PhaseKey, strings.ToLower(phase.String())))
+	if phase == core.WorkflowExecution_FAILED {
+		errorKind := strings.ToLower(executionError.GetKind().String())
+		m.Failed.Inc(context.WithValue(ctx, errorKindKey, errorKind))
+	}
+}
+
+func newExecutionProjectMetrics(scope promutils.Scope, config runtimeInterfaces.ExecutionMetricsConfig) executionProjectMetrics {
+	return executionProjectMetrics{
+		projects: newProjectLabeler(config),
+		Created: labeled.NewCounter("executions_created",
+			"count of executions created, including relaunches and recoveries", scope),
+		CreateDuration: labeled.NewStopWatch("create_execution_duration",
+			"time taken to handle CreateExecution requests", time.Millisecond, scope),
+		Relaunched: labeled.NewCounter("executions_relaunched", "count of executions relaunched", scope),
+		Terminated: labeled.NewCounter("executions_terminated",
+			"count of executions terminated on request", scope),
+		Completed: labeled.NewCounter("executions_completed",
+			"count of executions reaching a terminal phase", scope,
+			labeled.AdditionalLabelsOption{Labels: []string{contextutils.PhaseKey.String()}}),
+		Failed: labeled.NewCounter("executions_failed", "count of failed executions by the kind of their error",
+			scope, labeled.AdditionalLabelsOption{Labels: []string{errorKindKey.String()}}),
+	}
+}
@@ -0,0 +1,108 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the value of the series of the collector with the labels given, which needn't include every label of the
+// series. Counters return their count, summaries their sample count.
+func getLabeledValue(t *testing.T, collector prometheus.Collector, labels map[string]string) float64 {
+	metrics := make(chan prometheus.Metric, 100)
+	collector.Collect(metrics)
+	close(metrics)
+	var value float64
+	for metric := range metrics {
+		var m dto.Metric
+		assert.NoError(t, metric.Write(&m))
+		matched := 0
+		for _, pair := range m.GetLabel() {
+			if expected, ok := labels[pair.GetName()]; ok && expected == pair.GetValue() {
+				matched++
+			}
+		}
+		if matched != len(labels) {
+			continue
+		}
+		if m.Counter != nil {
+			value += m.GetCounter().GetValue()
+		} else if m.Summary != nil {
+			value += float64(m.GetSummary().GetSampleCount())
+		}
+	}
+	return value
+}
+
+func TestProjectLabeler(t *testing.T) {
+	t.Run("aggregated projects", func(t *testing.T) {
+		labeler := newProjectLabeler(runtimeInterfaces.ExecutionMetricsConfig{
+			AggregatedProjects: []string{"noisy"},
+		})
+		assert.Equal(t, "project", labeler.label("project"))
+		assert.Equal(t, otherProjectLabel, labeler.label("noisy"))
+	})
+	t.Run("max labeled projects", func(t *testing.T) {
+		labeler := newProjectLabeler(runtimeInterfaces.ExecutionMetricsConfig{
+			AggregatedProjects: []string{"noisy"},
+			MaxLabeledProjects: 2,
+		})
+		assert.Equal(t, otherProjectLabel, labeler.label("noisy"))
+		assert.Equal(t, "first", labeler.label("first"))
+		assert.Equal(t, "second", labeler.label("second"))
+		assert.Equal(t, otherProjectLabel, labeler.label("third"))
+		// Projects keep their label once given one.
+		assert.Equal(t, "first", labeler.label("first"))
+	})
+	t.Run("unlimited", func(t *testing.T) {
+		labeler := newProjectLabeler(runtimeInterfaces.ExecutionMetricsConfig{})
+		for _, project := range []string{"first", "second", "third"} {
+			assert.Equal(t, project, labeler.label(project))
+		}
+	})
+}
+
+func TestExecutionProjectMetrics(t *testing.T) {
+	metrics := newExecutionProjectMetrics(mockScope.NewTestScope(), runtimeInterfaces.ExecutionMetricsConfig{
+		AggregatedProjects: []string{"noisy"},
+	})
+	metrics.recordCreated("project", "development")
+	metrics.recordCreated("project", "development")
+	metrics.recordCreated("noisy", "production")
+	metrics.recordCreateDuration("project", "development", time.Now())
+	metrics.recordRelaunched("project", "production")
+	metrics.recordTerminated("project", "development")
+	metrics.recordCompleted("project", "development", core.WorkflowExecution_SUCCEEDED, nil)
+	metrics.recordCompleted("project", "development", core.WorkflowExecution_FAILED, &core.ExecutionError{
+		Kind: core.ExecutionError_USER,
+	})
+	metrics.recordCompleted("project", "production", core.WorkflowExecution_FAILED, &core.ExecutionError{
+		Kind: core.ExecutionError_SYSTEM,
+	})
+
+	assert.Equal(t, float64(2), getLabeledValue(t, metrics.Created.CounterVec,
+		map[string]string{"project": "project", "domain": "development"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Created.CounterVec,
+		map[string]string{"project": otherProjectLabel, "domain": "production"}))
+	assert.Zero(t, getLabeledValue(t, metrics.Created.CounterVec, map[string]string{"project": "noisy"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.CreateDuration.StopWatchVec,
+		map[string]string{"project": "project", "domain": "development"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Relaunched.CounterVec,
+		map[string]string{"project": "project", "domain": "production"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Terminated.CounterVec,
+		map[string]string{"project": "project", "domain": "development"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Completed.CounterVec,
+		map[string]string{"project": "project", "domain": "development", "phase": "succeeded"}))
+	assert.Equal(t, float64(2), getLabeledValue(t, metrics.Completed.CounterVec,
+		map[string]string{"project": "project", "phase": "failed"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Failed.CounterVec,
+		map[string]string{"project": "project", "domain": "development", "error_kind": "user"}))
+	assert.Equal(t, float64(1), getLabeledValue(t, metrics.Failed.CounterVec,
+		map[string]string{"project": "project", "domain": "production", "error_kind": "system"}))
+}
@@ -0,0 +1,111 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
+
+	eventInterfaces "github.com/flyteorg/flyteadmin/pkg/async/events/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+// The number of node executions read at a time for the snapshot sent to new watchers.
+const executionWatchSnapshotPageSize = 100
+
+type ExecutionWatchManager struct {
+	executionManager     interfaces.ExecutionInterface
+	nodeExecutionManager interfaces.NodeExecutionInterface
+	hub                  eventInterfaces.ExecutionWatchHub
+}
+
+func (m *ExecutionWatchManager) getSnapshot(
+	ctx context.Context, request admin.WorkflowExecutionGetRequest) (interfaces.ExecutionWatchUpdate, error) {
+	execution, err := m.executionManager.GetExecution(ctx, request)
+	if err != nil {
+		return interfaces.ExecutionWatchUpdate{}, err
+	}
+	snapshot := interfaces.ExecutionWatchUpdate{
+		Execution: execution,
+	}
+	token := ""
+	for {
+		nodeExecutions, err := m.nodeExecutionManager.ListNodeExecutions(ctx, admin.NodeExecutionListRequest{
+			WorkflowExecutionId: request.Id,
+			Limit:               executionWatchSnapshotPageSize,
+			Token:               token,
+		})
+		if err != nil {
+			return interfaces.ExecutionWatchUpdate{}, err
+		}
+		snapshot.NodeExecutions = append(snapshot.NodeExecutions, nodeExecutions.NodeExecutions...)
+		if len(nodeExecutions.Token) == 0 {
+			return snapshot, nil
+		}
+		token = nodeExecutions.Token
+	}
+}
+
+func (m *ExecutionWatchManager) WatchExecution(
+	ctx context.Context, request admin.WorkflowExecutionGetRequest, send interfaces.ExecutionWatchSender) error {
+	if err := validation.ValidateWorkflowExecutionIdentifier(request.Id); err != nil {
+		logger.Debugf(ctx, "WatchExecution request [%+v] failed validation with err: %v", request, err)
+		return err
+	}
+	ctx = getExecutionContext(ctx, request.Id)
+	// Subscribe before reading the snapshot so that no event processed in between is missed. Events may predate the
+	// snapshot as a result, which watchers are expected to tolerate.
+	subscription := m.hub.Subscribe(*request.Id)
+	defer subscription.Close()
+
+	snapshot, err := m.getSnapshot(ctx, request)
+	if err != nil {
+		return err
+	}
+	if err := send(snapshot); err != nil {
+		return err
+	}
+	if common.IsExecutionTerminal(snapshot.Execution.GetClosure().GetPhase()) {
+		return nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case watchEvent, ok := <-subscription.Events():
+			if !ok {
+				if subscription.Dropped() {
+					logger.Infof(ctx, "dropped watcher of execution [%+v] which fell behind its events", request.Id)
+					return errors.NewFlyteAdminErrorf(codes.ResourceExhausted,
+						"fell behind the events of execution [%+v], watch it again to resume", request.Id)
+				}
+				return nil
+			}
+			if err := send(interfaces.ExecutionWatchUpdate{
+				WorkflowEvent: watchEvent.WorkflowEvent,
+				NodeEvent:     watchEvent.NodeEvent,
+			}); err != nil {
+				return err
+			}
+			if watchEvent.WorkflowEvent != nil && common.IsExecutionTerminal(watchEvent.WorkflowEvent.Phase) {
+				return nil
+			}
+		}
+	}
+}
+
+// NewExecutionWatchManager returns a manager streaming the updates of executions published to the hub, which only
+// receives the events processed by this replica.
+func NewExecutionWatchManager(executionManager interfaces.ExecutionInterface,
+	nodeExecutionManager interfaces.NodeExecutionInterface,
+	hub eventInterfaces.ExecutionWatchHub) interfaces.ExecutionWatchInterface {
+	return &ExecutionWatchManager{
+		executionManager:     executionManager,
+		nodeExecutionManager: nodeExecutionManager,
+		hub:                  hub,
+	}
+}
@@ -0,0 +1,148 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	eventWriter "github.com/flyteorg/flyteadmin/pkg/async/events/implementations"
+	eventInterfaces "github.com/flyteorg/flyteadmin/pkg/async/events/interfaces"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+)
+
+var watchedExecutionID = core.WorkflowExecutionIdentifier{
+	Project: "project",
+	Domain:  "domain",
+	Name:    "name",
+}
+
+func getExecutionWatchManagerForTest(
+	phase core.WorkflowExecution_Phase, bufferSize int) (interfaces.ExecutionWatchInterface, eventInterfaces.ExecutionWatchHub) {
+	executionManager := &managerMocks.MockExecutionManager{}
+	executionManager.SetGetCallback(func(
+		ctx context.Context, request admin.WorkflowExecutionGetRequest) (*admin.Execution, error) {
+		return &admin.Execution{
+			Id: request.Id,
+			Closure: &admin.ExecutionClosure{
+				Phase: phase,
+			},
+		}, nil
+	})
+	nodeExecutionManager := &managerMocks.MockNodeExecutionManager{}
+	nodeExecutionManager.SetListNodeExecutionsFunc(func(
+		ctx context.Context, request admin.NodeExecutionListRequest) (*admin.NodeExecutionList, error) {
+		if len(request.Token) == 0 {
+			return &admin.NodeExecutionList{
+				NodeExecutions: []*admin.NodeExecution{
+					{Id: &core.NodeExecutionIdentifier{NodeId: "n0", ExecutionId: request.WorkflowExecutionId}},
+				},
+				Token: "1",
+			}, nil
+		}
+		return &admin.NodeExecutionList{
+			NodeExecutions: []*admin.NodeExecution{
+				{Id: &core.NodeExecutionIdentifier{NodeId: "n1", ExecutionId: request.WorkflowExecutionId}},
+			},
+		}, nil
+	})
+	hub := eventWriter.NewExecutionWatchHub(bufferSize, mockScope.NewTestScope())
+	return NewExecutionWatchManager(executionManager, nodeExecutionManager, hub), hub
+}
+
+func TestWatchExecution(t *testing.T) {
+	t.Run("streams events until the execution terminates", func(t *testing.T) {
+		manager, hub := getExecutionWatchManagerForTest(core.WorkflowExecution_RUNNING, 10)
+		var updates []interfaces.ExecutionWatchUpdate
+		err := manager.WatchExecution(context.Background(), admin.WorkflowExecutionGetRequest{Id: &watchedExecutionID},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				if len(updates) == 0 {
+					// The watcher is subscribed by the time the snapshot is sent.
+					hub.PublishNodeEvent(&event.NodeExecutionEvent{
+						Id:    &core.NodeExecutionIdentifier{NodeId: "n1", ExecutionId: &watchedExecutionID},
+						Phase: core.NodeExecution_SUCCEEDED,
+					})
+					hub.PublishWorkflowEvent(&event.WorkflowExecutionEvent{
+						ExecutionId: &watchedExecutionID,
+						Phase:       core.WorkflowExecution_SUCCEEDED,
+					})
+				}
+				updates = append(updates, update)
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Len(t, updates, 3)
+		assert.Equal(t, core.WorkflowExecution_RUNNING, updates[0].Execution.Closure.Phase)
+		assert.Len(t, updates[0].NodeExecutions, 2)
+		assert.Equal(t, "n1", updates[0].NodeExecutions[1].Id.NodeId)
+		assert.Equal(t, core.NodeExecution_SUCCEEDED, updates[1].NodeEvent.Phase)
+		assert.Nil(t, updates[1].Execution)
+		assert.Equal(t, core.WorkflowExecution_SUCCEEDED, updates[2].WorkflowEvent.Phase)
+	})
+	t.Run("terminal snapshot", func(t *testing.T) {
+		manager, _ := getExecutionWatchManagerForTest(core.WorkflowExecution_FAILED, 10)
+		var updates []interfaces.ExecutionWatchUpdate
+		err := manager.WatchExecution(context.Background(), admin.WorkflowExecutionGetRequest{Id: &watchedExecutionID},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				updates = append(updates, update)
+				return nil
+			})
+		assert.NoError(t, err)
+		assert.Len(t, updates, 1)
+	})
+	t.Run("dropped", func(t *testing.T) {
+		manager, hub := getExecutionWatchManagerForTest(core.WorkflowExecution_RUNNING, 1)
+		var updates []interfaces.ExecutionWatchUpdate
+		err := manager.WatchExecution(context.Background(), admin.WorkflowExecutionGetRequest{Id: &watchedExecutionID},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				if len(updates) == 0 {
+					// Ingestion overruns the watcher while it's busy sending the snapshot.
+					for _, nodeID := range []string{"n0", "n1"} {
+						hub.PublishNodeEvent(&event.NodeExecutionEvent{
+							Id: &core.NodeExecutionIdentifier{NodeId: nodeID, ExecutionId: &watchedExecutionID},
+						})
+					}
+				}
+				updates = append(updates, update)
+				return nil
+			})
+		assert.Equal(t, codes.ResourceExhausted, err.(flyteAdminErrors.FlyteAdminError).Code())
+		assert.Len(t, updates, 2)
+		assert.Equal(t, "n0", updates[1].NodeEvent.Id.NodeId)
+	})
+	t.Run("send failure", func(t *testing.T) {
+		manager, _ := getExecutionWatchManagerForTest(core.WorkflowExecution_RUNNING, 10)
+		expectedErr := errors.New("gone")
+		err := manager.WatchExecution(context.Background(), admin.WorkflowExecutionGetRequest{Id: &watchedExecutionID},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				return expectedErr
+			})
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("context done", func(t *testing.T) {
+		manager, _ := getExecutionWatchManagerForTest(core.WorkflowExecution_RUNNING, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		err := manager.WatchExecution(ctx, admin.WorkflowExecutionGetRequest{Id: &watchedExecutionID},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				cancel()
+				return nil
+			})
+		assert.Equal(t, context.Canceled, err)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		manager, _ := getExecutionWatchManagerForTest(core.WorkflowExecution_RUNNING, 10)
+		err := manager.WatchExecution(context.Background(), admin.WorkflowExecutionGetRequest{},
+			func(update interfaces.ExecutionWatchUpdate) error {
+				return nil
+			})
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+}
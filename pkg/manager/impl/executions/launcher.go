@@ -0,0 +1,267 @@
+package executions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/async"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+)
+
+// Launches the workflow of an execution, it's retried on error.
+type LaunchFunc func(ctx context.Context) error
+
+// Records that the workflow of an execution failed to launch once it's out of retries.
+type LaunchFailureFunc func(ctx context.Context, err error)
+
+type launcherMetrics struct {
+	// Executions waiting for a worker to launch them.
+	QueueDepth prometheus.Gauge
+	// Time between an execution being queued and a worker starting to launch it.
+	LaunchLatency prometheus.Summary
+	// Executions whose workflow failed to launch after all retries.
+	LaunchFailures prometheus.Counter
+	// Executions terminated before a worker started to launch them.
+	CancelledLaunches prometheus.Counter
+	// Executions failed because their launch was interrupted by admin stopping.
+	InterruptedLaunches prometheus.Counter
+}
+
+type queuedLaunch struct {
+	ctx        context.Context
+	key        string
+	launch     LaunchFunc
+	fail       LaunchFailureFunc
+	enqueuedAt time.Time
+	cancelled  bool
+}
+
+// Launcher launches the workflows of executions in the background with a bounded pool of workers. A nil launcher
+// launches nothing, such that callers launch their workflows themselves.
+type Launcher struct {
+	config   runtimeInterfaces.AsyncExecutionLaunchConfig
+	launches chan *queuedLaunch
+	mutex    sync.Mutex
+	// Number of places in the queue taken, by executions waiting to be launched or about to be queued. Cancelled
+	// launches keep their place until a worker takes them off the queue, such that queueing never blocks.
+	reserved int
+	// Launches waiting for a worker, keyed by execution.
+	queued  map[string]*queuedLaunch
+	metrics launcherMetrics
+
+	// Set once the launcher stops taking executions. Places taken in the queue are counted by pending as well, such
+	// that Stop can wait for the queue to drain.
+	stopping bool
+	pending  sync.WaitGroup
+	// Closed once the workers are to stop taking launches off the queue.
+	stopped chan struct{}
+	workers sync.WaitGroup
+}
+
+// Takes a place in the queue for an execution about to be queued, or returns false if the queue is full. The place
+// must be either filled with Enqueue or given back with Release.
+func (l *Launcher) Reserve() bool {
+	if l == nil {
+		return false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.stopping || l.reserved >= l.config.QueueSize {
+		return false
+	}
+	l.reserved++
+	l.pending.Add(1)
+	return true
+}
+
+// Gives back a place reserved for an execution which won't be queued after all.
+func (l *Launcher) Release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.reserved--
+	l.pending.Done()
+}
+
+// Queues the launch of an execution into the place reserved for it. The key identifies the execution to Cancel.
+func (l *Launcher) Enqueue(ctx context.Context, key string, launch LaunchFunc, fail LaunchFailureFunc) {
+	queued := &queuedLaunch{
+		ctx:        ctx,
+		key:        key,
+		launch:     launch,
+		fail:       fail,
+		enqueuedAt: time.Now(),
+	}
+	l.mutex.Lock()
+	l.queued[key] = queued
+	l.metrics.QueueDepth.Set(float64(len(l.queued)))
+	l.mutex.Unlock()
+	l.launches <- queued
+}
+
+// Drops the launch of an execution from the queue. Returns false if the execution isn't queued, including when a worker
+// has already started to launch it.
+func (l *Launcher) Cancel(key string) bool {
+	if l == nil {
+		return false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	queued, ok := l.queued[key]
+	if !ok {
+		return false
+	}
+	queued.cancelled = true
+	delete(l.queued, key)
+	l.metrics.QueueDepth.Set(float64(len(l.queued)))
+	l.metrics.CancelledLaunches.Inc()
+	return true
+}
+
+// Takes the next launch off the queue, or returns nil if it was cancelled.
+func (l *Launcher) dequeue(queued *queuedLaunch) *queuedLaunch {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.reserved--
+	l.pending.Done()
+	if queued.cancelled {
+		return nil
+	}
+	delete(l.queued, queued.key)
+	l.metrics.QueueDepth.Set(float64(len(l.queued)))
+	return queued
+}
+
+func (l *Launcher) launch(queued *queuedLaunch) {
+	l.metrics.LaunchLatency.Observe(time.Since(queued.enqueuedAt).Seconds())
+	err := async.Retry(l.config.Retries, l.config.RetryDelay.Duration, func() error {
+		return queued.launch(queued.ctx)
+	})
+	if err != nil {
+		l.metrics.LaunchFailures.Inc()
+		logger.Errorf(queued.ctx, "Failed to launch execution [%s] with err: %v", queued.key, err)
+		queued.fail(queued.ctx, err)
+	}
+}
+
+func (l *Launcher) work() {
+	defer l.workers.Done()
+	for {
+		// Launches left in the queue once the launcher stops are failed by Stop rather than launched.
+		select {
+		case <-l.stopped:
+			return
+		default:
+		}
+		select {
+		case <-l.stopped:
+			return
+		case next := <-l.launches:
+			if queued := l.dequeue(next); queued != nil {
+				l.launch(queued)
+			}
+		}
+	}
+}
+
+// Stop stops the launcher from taking executions, which are then launched before CreateExecution returns, and waits
+// for the executions already queued to be launched. Those still queued once the context is done are failed instead,
+// since no other replica would launch them, and the workers stop after the launches they're running.
+func (l *Launcher) Stop(ctx context.Context) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	if l.stopping {
+		l.mutex.Unlock()
+		return
+	}
+	l.stopping = true
+	l.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		l.pending.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		logger.Warningf(ctx, "Stopped waiting for %d queued executions to be launched", len(l.launches))
+	}
+	close(l.stopped)
+	l.workers.Wait()
+	// Executions whose place was reserved are queued after the workers have stopped when they are still being
+	// recorded, such that the queue is only drained once all of them are.
+	interrupted := errors.NewFlyteAdminErrorf(codes.Unavailable, "admin stopped before launching the execution")
+	for {
+		select {
+		case <-drained:
+			return
+		case next := <-l.launches:
+			if queued := l.dequeue(next); queued != nil {
+				l.metrics.InterruptedLaunches.Inc()
+				queued.fail(queued.ctx, interrupted)
+			}
+		}
+	}
+}
+
+// Returns nil, which launches nothing, when launching in the background is disabled. The workers run until the launcher
+// is stopped.
+func NewLauncher(config runtimeInterfaces.AsyncExecutionLaunchConfig, scope promutils.Scope) *Launcher {
+	if !config.Enabled || config.Workers <= 0 || config.QueueSize <= 0 {
+		return nil
+	}
+	launcher := &Launcher{
+		config:   config,
+		launches: make(chan *queuedLaunch, config.QueueSize),
+		queued:   make(map[string]*queuedLaunch),
+		stopped:  make(chan struct{}),
+		metrics: launcherMetrics{
+			QueueDepth: scope.MustNewGauge("queue_depth",
+				"number of executions waiting for their workflow to be launched"),
+			LaunchLatency: scope.MustNewSummary("launch_latency",
+				"time in seconds executions wait in the queue before their workflow is launched"),
+			LaunchFailures: scope.MustNewCounter("launch_failures",
+				"count of executions whose workflow failed to launch after all retries"),
+			CancelledLaunches: scope.MustNewCounter("cancelled_launches",
+				"count of executions terminated before their workflow was launched"),
+			InterruptedLaunches: scope.MustNewCounter("interrupted_launches",
+				"count of queued executions failed because admin stopped before launching them"),
+		},
+	}
+	launcher.workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go launcher.work()
+	}
+	return launcher
+}
+
+// RecoverInterruptedLaunches fails the executions which were queued longer than interruptedAfter ago and never
+// launched, right away and then every interruptedAfter, until the context is cancelled. Launch queues are kept in
+// memory, so these are executions whose replica stopped without launching or failing them.
+func RecoverInterruptedLaunches(
+	ctx context.Context, executionManager interfaces.ExecutionInterface, interruptedAfter time.Duration) {
+	ticker := time.NewTicker(interruptedAfter)
+	defer ticker.Stop()
+	for {
+		failed, err := executionManager.FailInterruptedLaunches(ctx, time.Now().Add(-interruptedAfter))
+		if err != nil {
+			logger.Errorf(ctx, "Failed to fail executions whose launch was interrupted with err: %v", err)
+		} else if failed > 0 {
+			logger.Infof(ctx, "Failed %d executions whose launch was interrupted", failed)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
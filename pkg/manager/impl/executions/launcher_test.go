@@ -0,0 +1,199 @@
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var launcherConfig = runtimeInterfaces.AsyncExecutionLaunchConfig{
+	Enabled:   true,
+	Workers:   1,
+	QueueSize: 2,
+}
+
+func enqueueForTest(l *Launcher, key string, launch LaunchFunc, fail LaunchFailureFunc) {
+	if !l.Reserve() {
+		panic("queue full")
+	}
+	l.Enqueue(context.Background(), key, launch, fail)
+}
+
+func noFailure(ctx context.Context, err error) {
+	panic(err)
+}
+
+func TestLauncher_Launch(t *testing.T) {
+	launcher := NewLauncher(launcherConfig, promutils.NewTestScope())
+	launched := make(chan string)
+	enqueueForTest(launcher, "a", func(ctx context.Context) error {
+		launched <- "a"
+		return nil
+	}, noFailure)
+	assert.Equal(t, "a", <-launched)
+	assert.Equal(t, 1, testutil.CollectAndCount(launcher.metrics.LaunchLatency))
+	assert.False(t, launcher.Cancel("a"))
+}
+
+func TestLauncher_CancelBeforeLaunch(t *testing.T) {
+	launcher := NewLauncher(launcherConfig, promutils.NewTestScope())
+	blocked := make(chan struct{})
+	released := make(chan struct{})
+	launched := make(chan string, 2)
+	// Keeps the only worker busy while the next launch is cancelled.
+	enqueueForTest(launcher, "a", func(ctx context.Context) error {
+		close(blocked)
+		<-released
+		launched <- "a"
+		return nil
+	}, noFailure)
+	<-blocked
+	enqueueForTest(launcher, "b", func(ctx context.Context) error {
+		launched <- "b"
+		return nil
+	}, noFailure)
+	assert.Equal(t, float64(1), testutil.ToFloat64(launcher.metrics.QueueDepth))
+
+	assert.True(t, launcher.Cancel("b"))
+	assert.False(t, launcher.Cancel("b"))
+	assert.Equal(t, float64(0), testutil.ToFloat64(launcher.metrics.QueueDepth))
+	assert.Equal(t, float64(1), testutil.ToFloat64(launcher.metrics.CancelledLaunches))
+	// The cancelled launch keeps its place until the worker takes it off the queue.
+	assert.True(t, launcher.Reserve())
+	assert.False(t, launcher.Reserve())
+	launcher.Enqueue(context.Background(), "c", func(ctx context.Context) error {
+		launched <- "c"
+		return nil
+	}, noFailure)
+
+	close(released)
+	assert.Equal(t, "a", <-launched)
+	assert.Equal(t, "c", <-launched)
+	assert.True(t, launcher.Reserve())
+	assert.True(t, launcher.Reserve())
+}
+
+func TestLauncher_Retries(t *testing.T) {
+	retryingConfig := launcherConfig
+	retryingConfig.Retries = 2
+	launcher := NewLauncher(retryingConfig, promutils.NewTestScope())
+	errLaunch := errors.New("launch failed")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		var attempts int
+		launched := make(chan struct{})
+		enqueueForTest(launcher, "a", func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errLaunch
+			}
+			close(launched)
+			return nil
+		}, noFailure)
+		<-launched
+		assert.Equal(t, 3, attempts)
+	})
+	t.Run("fails after all retries", func(t *testing.T) {
+		var attempts int
+		failed := make(chan error)
+		enqueueForTest(launcher, "b", func(ctx context.Context) error {
+			attempts++
+			return errLaunch
+		}, func(ctx context.Context, err error) {
+			failed <- err
+		})
+		assert.Equal(t, errLaunch, <-failed)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, float64(1), testutil.ToFloat64(launcher.metrics.LaunchFailures))
+	})
+}
+
+func TestLauncher_QueueFull(t *testing.T) {
+	launcher := NewLauncher(launcherConfig, promutils.NewTestScope())
+	assert.True(t, launcher.Reserve())
+	assert.True(t, launcher.Reserve())
+	assert.False(t, launcher.Reserve())
+	launcher.Release()
+	assert.True(t, launcher.Reserve())
+}
+
+func TestLauncher_Stop(t *testing.T) {
+	t.Run("launches queued executions", func(t *testing.T) {
+		launcher := NewLauncher(launcherConfig, promutils.NewTestScope())
+		launched := make(chan string, 2)
+		for _, key := range []string{"a", "b"} {
+			key := key
+			enqueueForTest(launcher, key, func(ctx context.Context) error {
+				launched <- key
+				return nil
+			}, noFailure)
+		}
+		launcher.Stop(context.Background())
+		assert.Equal(t, "a", <-launched)
+		assert.Equal(t, "b", <-launched)
+		// Executions created once the launcher is stopped are launched by their caller.
+		assert.False(t, launcher.Reserve())
+		launcher.Stop(context.Background())
+	})
+	t.Run("fails executions still queued", func(t *testing.T) {
+		launcher := NewLauncher(launcherConfig, promutils.NewTestScope())
+		blocked := make(chan struct{})
+		released := make(chan struct{})
+		enqueueForTest(launcher, "a", func(ctx context.Context) error {
+			close(blocked)
+			<-released
+			return nil
+		}, noFailure)
+		<-blocked
+		failed := make(chan error, 1)
+		enqueueForTest(launcher, "b", func(ctx context.Context) error {
+			t.Error("launched an execution after the launcher stopped")
+			return nil
+		}, func(ctx context.Context, err error) {
+			failed <- err
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		stopped := make(chan struct{})
+		go func() {
+			launcher.Stop(ctx)
+			close(stopped)
+		}()
+		// The worker finishes the launch it's running once the launcher has stopped.
+		<-launcher.stopped
+		close(released)
+		<-stopped
+		assert.EqualError(t, <-failed, "admin stopped before launching the execution")
+		assert.Equal(t, float64(1), testutil.ToFloat64(launcher.metrics.InterruptedLaunches))
+		assert.Equal(t, float64(0), testutil.ToFloat64(launcher.metrics.QueueDepth))
+	})
+}
+
+func TestRecoverInterruptedLaunches(t *testing.T) {
+	executionManager := &mocks.MockExecutionManager{}
+	ctx, cancel := context.WithCancel(context.Background())
+	var queuedBefore time.Time
+	executionManager.SetFailInterruptedLaunchesCallback(func(_ context.Context, before time.Time) (int, error) {
+		queuedBefore = before
+		cancel()
+		return 1, nil
+	})
+	RecoverInterruptedLaunches(ctx, executionManager, time.Hour)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), queuedBefore, time.Minute)
+}
+
+func TestNewLauncher_Disabled(t *testing.T) {
+	launcher := NewLauncher(runtimeInterfaces.AsyncExecutionLaunchConfig{}, promutils.NewTestScope())
+	assert.Nil(t, launcher)
+	assert.False(t, launcher.Reserve())
+	assert.False(t, launcher.Cancel("a"))
+	launcher.Stop(context.Background())
+}
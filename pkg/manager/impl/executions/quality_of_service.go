@@ -2,6 +2,7 @@ package executions
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
@@ -28,6 +29,18 @@ type QualityOfServiceAllocator interface {
 	GetQualityOfService(ctx context.Context, input GetQualityOfServiceInput) (QualityOfServiceSpec, error)
 }
 
+// getQueueingBudget parses the queueing budget of a custom quality of service spec. Negative budgets are rejected.
+func getQueueingBudget(spec *core.QualityOfServiceSpec) (time.Duration, error) {
+	duration, err := ptypes.Duration(spec.GetQueueingBudget())
+	if err != nil {
+		return 0, err
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("queueing budget %v is negative", duration)
+	}
+	return duration, nil
+}
+
 type qualityOfServiceAllocator struct {
 	config          runtimeInterfaces.Configuration
 	resourceManager interfaces.ResourceInterface
@@ -86,7 +99,7 @@ func (q qualityOfServiceAllocator) GetQualityOfService(ctx context.Context, inpu
 			logger.Debugf(ctx, "Determining quality of service from execution spec for [%s/%s/%s]",
 				input.ExecutionCreateRequest.Project, input.ExecutionCreateRequest.Domain,
 				input.ExecutionCreateRequest.Name)
-			duration, err := ptypes.Duration(input.ExecutionCreateRequest.Spec.QualityOfService.GetSpec().QueueingBudget)
+			duration, err := getQueueingBudget(input.ExecutionCreateRequest.Spec.QualityOfService.GetSpec())
 			if err != nil {
 				return QualityOfServiceSpec{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 					"Invalid custom quality of service set in create execution request [%s/%s/%s], failed to parse duration [%v] with: %v",
@@ -104,37 +117,35 @@ func (q qualityOfServiceAllocator) GetQualityOfService(ctx context.Context, inpu
 			logger.Debugf(ctx, "Determining quality of service from launch plan spec for [%s/%s/%s]",
 				input.ExecutionCreateRequest.Project, input.ExecutionCreateRequest.Domain,
 				input.ExecutionCreateRequest.Name)
-			duration, err := ptypes.Duration(input.LaunchPlan.Spec.QualityOfService.GetSpec().QueueingBudget)
+			duration, err := getQueueingBudget(input.LaunchPlan.Spec.QualityOfService.GetSpec())
 			if err != nil {
 				return QualityOfServiceSpec{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 					"Invalid custom quality of service set in launch plan [%v], failed to parse duration [%v] with: %v",
 					input.LaunchPlan.Id,
-					input.ExecutionCreateRequest.Spec.QualityOfService.GetSpec().QueueingBudget, err)
+					input.LaunchPlan.Spec.QualityOfService.GetSpec().QueueingBudget, err)
 			}
 			return QualityOfServiceSpec{
 				QueuingBudget: duration,
 			}, nil
 		}
 		qualityOfServiceTier = input.LaunchPlan.Spec.QualityOfService.GetTier()
-	} else if input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata != nil &&
-		input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata.QualityOfService != nil {
+	} else if workflowQualityOfService := input.Workflow.GetClosure().GetCompiledWorkflow().GetPrimary().GetTemplate().
+		GetMetadata().GetQualityOfService(); workflowQualityOfService != nil {
 		logger.Debugf(ctx, "Determining quality of service from workflow spec for [%s/%s/%s]",
 			input.ExecutionCreateRequest.Project, input.ExecutionCreateRequest.Domain,
 			input.ExecutionCreateRequest.Name)
-		if input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata.QualityOfService.GetSpec() != nil {
-			duration, err := ptypes.Duration(input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata.QualityOfService.
-				GetSpec().QueueingBudget)
+		if workflowQualityOfService.GetSpec() != nil {
+			duration, err := getQueueingBudget(workflowQualityOfService.GetSpec())
 			if err != nil {
 				return QualityOfServiceSpec{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 					"Invalid custom quality of service set in workflow [%v], failed to parse duration [%v] with: %v",
-					workflowIdentifier,
-					input.ExecutionCreateRequest.Spec.QualityOfService.GetSpec().QueueingBudget, err)
+					workflowIdentifier, workflowQualityOfService.GetSpec().QueueingBudget, err)
 			}
 			return QualityOfServiceSpec{
 				QueuingBudget: duration,
 			}, nil
 		}
-		qualityOfServiceTier = input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata.QualityOfService.GetTier()
+		qualityOfServiceTier = workflowQualityOfService.GetTier()
 	}
 
 	// If nothing in the hierarchy of registrable entities has set the quality of service,
@@ -148,12 +159,12 @@ func (q qualityOfServiceAllocator) GetQualityOfService(ctx context.Context, inpu
 			logger.Debugf(ctx, "Determining quality of service from spec database override for [%s/%s/%s]",
 				input.ExecutionCreateRequest.Project, input.ExecutionCreateRequest.Domain,
 				input.ExecutionCreateRequest.Name)
-			duration, err := ptypes.Duration(qualityOfService.GetSpec().QueueingBudget)
+			duration, err := getQueueingBudget(qualityOfService.GetSpec())
 			if err != nil {
 				return QualityOfServiceSpec{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 					"Invalid custom quality of service set in overridable matching attributes for [%v],"+
 						"failed to parse duration [%v] with: %v", workflowIdentifier,
-					input.ExecutionCreateRequest.Spec.QualityOfService.GetSpec().QueueingBudget, err)
+					qualityOfService.GetSpec().QueueingBudget, err)
 			}
 			return QualityOfServiceSpec{
 				QueuingBudget: duration,
@@ -162,7 +173,7 @@ func (q qualityOfServiceAllocator) GetQualityOfService(ctx context.Context, inpu
 			logger.Debugf(ctx, "Determining quality of service tier from database override for [%s/%s/%s]",
 				input.ExecutionCreateRequest.Project, input.ExecutionCreateRequest.Domain,
 				input.ExecutionCreateRequest.Name)
-			qualityOfServiceTier = input.Workflow.Closure.CompiledWorkflow.Primary.Template.Metadata.QualityOfService.GetTier()
+			qualityOfServiceTier = qualityOfService.GetTier()
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
@@ -15,6 +16,7 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 var workflowIdentifier = &core.Identifier{
@@ -211,3 +213,58 @@ func TestGetQualityOfService_NoDefault(t *testing.T) {
 	assert.Nil(t, err)
 	assert.EqualValues(t, spec.QueuingBudget.Seconds(), 0)
 }
+
+func TestGetQualityOfService_MatchableResourceTier(t *testing.T) {
+	resourceManager := managerMocks.MockResourceManager{}
+	resourceManager.GetResourceFunc = func(ctx context.Context,
+		request interfaces.ResourceRequest) (*interfaces.ResourceResponse, error) {
+		return &interfaces.ResourceResponse{
+			Attributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_QualityOfService{
+					QualityOfService: &core.QualityOfService{
+						Designation: &core.QualityOfService_Tier_{
+							Tier: core.QualityOfService_MEDIUM,
+						},
+					},
+				},
+			},
+		}, nil
+	}
+
+	allocator := NewQualityOfServiceAllocator(getMockConfig(), &resourceManager)
+	spec, err := allocator.GetQualityOfService(context.Background(), GetQualityOfServiceInput{
+		Workflow: &admin.Workflow{
+			Id:      workflowIdentifier,
+			Closure: &admin.WorkflowClosure{},
+		},
+		LaunchPlan: &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{},
+		},
+		ExecutionCreateRequest: &admin.ExecutionCreateRequest{
+			Domain: "production",
+			Spec:   &admin.ExecutionSpec{},
+		},
+	})
+	assert.Nil(t, err)
+	assert.EqualValues(t, spec.QueuingBudget, 20*time.Minute)
+}
+
+func TestGetQualityOfService_NegativeQueueingBudget(t *testing.T) {
+	resourceManager := managerMocks.MockResourceManager{}
+	addGetResourceFunc(t, &resourceManager)
+
+	allocator := NewQualityOfServiceAllocator(getMockConfig(), &resourceManager)
+	_, err := allocator.GetQualityOfService(context.Background(), GetQualityOfServiceInput{
+		Workflow: getWorkflowWithQosSpec(nil),
+		LaunchPlan: &admin.LaunchPlan{
+			Spec: &admin.LaunchPlanSpec{
+				QualityOfService: getQualityOfServiceWithDuration(-2 * time.Minute),
+			},
+		},
+		ExecutionCreateRequest: &admin.ExecutionCreateRequest{
+			Domain: "production",
+			Spec:   &admin.ExecutionSpec{},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type tag = string
@@ -31,11 +33,22 @@ type QueueAllocator interface {
 	GetQueue(ctx context.Context, identifier core.Identifier) singleQueueConfiguration
 }
 
+type queueAllocatorMetrics struct {
+	// Executions assigned to each dynamic queue, labelled by queue.
+	Assignments *prometheus.CounterVec
+	// Executions whose execution queue attributes matched no queue, or which have none, and which were assigned a
+	// queue from the default workflow configs instead.
+	DefaultAssignments prometheus.Counter
+	// Executions no queue matched at all.
+	UnmatchedAssignments prometheus.Counter
+}
+
 type queueAllocatorImpl struct {
 	queueConfigMap  queueConfig
 	config          runtimeInterfaces.Configuration
 	db              repositories.RepositoryInterface
 	resourceManager interfaces.ResourceInterface
+	metrics         queueAllocatorMetrics
 }
 
 func (q *queueAllocatorImpl) refreshExecutionQueues(executionQueues []runtimeInterfaces.ExecutionQueue) {
@@ -55,6 +68,19 @@ func (q *queueAllocatorImpl) refreshExecutionQueues(executionQueues []runtimeInt
 	q.queueConfigMap = queueConfigMap
 }
 
+// Returns one of the queues of the first tag which any queue has.
+func (q *queueAllocatorImpl) matchQueue(tags []string) (singleQueueConfiguration, bool) {
+	for _, tag := range tags {
+		matches, ok := q.queueConfigMap[tag]
+		if !ok {
+			continue
+		}
+		/* #nosec */
+		return matches[rand.Intn(len(matches))], true
+	}
+	return singleQueueConfiguration{}, false
+}
+
 func (q *queueAllocatorImpl) GetQueue(ctx context.Context, identifier core.Identifier) singleQueueConfiguration {
 	// NOTE: If refreshing the execution queues & workflow configs on every call to GetQueue becomes too slow we should
 	// investigate caching the computed queue assignments.
@@ -74,14 +100,15 @@ func (q *queueAllocatorImpl) GetQueue(ctx context.Context, identifier core.Ident
 	}
 
 	if resource != nil && resource.Attributes != nil && resource.Attributes.GetExecutionQueueAttributes() != nil {
-		for _, tag := range resource.Attributes.GetExecutionQueueAttributes().Tags {
-			matches, ok := q.queueConfigMap[tag]
-			if !ok {
-				continue
-			}
-			/* #nosec */
-			return matches[rand.Intn(len(matches))]
+		candidateTags := resource.Attributes.GetExecutionQueueAttributes().Tags
+		logger.Debugf(ctx, "Assigning an execution queue to [%+v] from the candidate tags %v of its attributes",
+			identifier, candidateTags)
+		if queue, ok := q.matchQueue(candidateTags); ok {
+			q.metrics.Assignments.WithLabelValues(queue.DynamicQueue).Inc()
+			return queue
 		}
+		logger.Infof(ctx, "No execution queue has any of the tags %v of the attributes of [%+v], falling back on "+
+			"the default workflow configs", candidateTags, identifier)
 	}
 	var tags []string
 	var defaultTags []string
@@ -97,23 +124,31 @@ func (q *queueAllocatorImpl) GetQueue(ctx context.Context, identifier core.Ident
 		// Use the uber-default queue
 		tags = defaultTags
 	}
-	for _, tag := range tags {
-		matches, ok := q.queueConfigMap[tag]
-		if !ok {
-			continue
-		}
-		/* #nosec */
-		return matches[rand.Intn(len(matches))]
+	logger.Debugf(ctx, "Assigning an execution queue to [%+v] from the candidate default tags %v", identifier, tags)
+	if queue, ok := q.matchQueue(tags); ok {
+		q.metrics.Assignments.WithLabelValues(queue.DynamicQueue).Inc()
+		q.metrics.DefaultAssignments.Inc()
+		return queue
 	}
 	logger.Infof(ctx, "found no matching queue for [%+v]", identifier)
+	q.metrics.UnmatchedAssignments.Inc()
 	return singleQueueConfiguration{}
 }
 
-func NewQueueAllocator(config runtimeInterfaces.Configuration, db repositories.RepositoryInterface) QueueAllocator {
+func NewQueueAllocator(config runtimeInterfaces.Configuration, db repositories.RepositoryInterface,
+	scope promutils.Scope) QueueAllocator {
 	queueAllocator := queueAllocatorImpl{
 		config:          config,
 		db:              db,
-		resourceManager: resources.NewResourceManager(db, config.ApplicationConfiguration()),
+		resourceManager: resources.NewResourceManager(db, config),
+		metrics: queueAllocatorMetrics{
+			Assignments: scope.MustNewCounterVec("assignments",
+				"executions assigned to each execution queue", "queue"),
+			DefaultAssignments: scope.MustNewCounter("default_assignments",
+				"executions assigned an execution queue from the default workflow configs"),
+			UnmatchedAssignments: scope.MustNewCounter("unmatched_assignments",
+				"executions which no execution queue matched"),
+		},
 	}
 	return &queueAllocator
 }
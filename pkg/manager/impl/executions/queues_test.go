@@ -17,6 +17,8 @@ import (
 
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,7 +67,7 @@ func TestGetQueue(t *testing.T) {
 
 	queueAllocator := NewQueueAllocator(runtimeMocks.NewMockConfigurationProvider(
 		nil, runtimeMocks.NewMockQueueConfigurationProvider(executionQueues, nil),
-		nil, nil, nil, nil), db)
+		nil, nil, nil, nil), db, mockScope.NewTestScope())
 	queueConfig := singleQueueConfiguration{
 		DynamicQueue: "queue dynamic",
 	}
@@ -172,7 +174,7 @@ func TestGetQueueDefaults(t *testing.T) {
 
 	queueAllocator := NewQueueAllocator(runtimeMocks.NewMockConfigurationProvider(
 		nil, runtimeMocks.NewMockQueueConfigurationProvider(executionQueues, workflowConfigs), nil,
-		nil, nil, nil), db)
+		nil, nil, nil), db, mockScope.NewTestScope())
 	assert.Equal(t, singleQueueConfiguration{
 		DynamicQueue: "default dynamic",
 	}, queueAllocator.GetQueue(
@@ -206,3 +208,85 @@ func TestGetQueueDefaults(t *testing.T) {
 			Name:    "workflow",
 		}))
 }
+
+func TestGetQueue_TagMatching(t *testing.T) {
+	executionQueues := []runtimeInterfaces.ExecutionQueue{
+		{Dynamic: "queue a", Attributes: []string{"a"}},
+		{Dynamic: "queue b", Attributes: []string{"b"}},
+	}
+	defaultWorkflowConfigs := []runtimeInterfaces.WorkflowConfig{
+		{Domain: "production", Tags: []string{"b"}},
+		{Tags: []string{"a"}},
+	}
+	testCases := []struct {
+		name            string
+		tags            []string
+		domain          string
+		workflowConfigs []runtimeInterfaces.WorkflowConfig
+		expected        string
+		expectDefault   bool
+	}{
+		{name: "first matching tag", tags: []string{"a", "b"}, expected: "queue a"},
+		{name: "unknown tags skipped", tags: []string{"x", "b"}, expected: "queue b"},
+		{name: "no match falls back on the default", tags: []string{"x"}, expected: "queue a", expectDefault: true},
+		{name: "no match falls back on the domain default", tags: []string{"x"}, domain: "production",
+			expected: "queue b", expectDefault: true},
+		{name: "no attributes", expected: "queue a", expectDefault: true},
+		{name: "no match at all", tags: []string{"x"}, workflowConfigs: []runtimeInterfaces.WorkflowConfig{
+			{Tags: []string{"y"}},
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := mocks.NewMockRepository()
+			db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+				ctx context.Context, ID interfaces.ResourceID) (models.Resource, error) {
+				if len(tc.tags) == 0 {
+					return models.Resource{}, errors.NewFlyteAdminError(codes.NotFound, "foo")
+				}
+				marshalledMatchingAttributes, _ := proto.Marshal(&admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_ExecutionQueueAttributes{
+						ExecutionQueueAttributes: &admin.ExecutionQueueAttributes{Tags: tc.tags},
+					},
+				})
+				return models.Resource{
+					Project:      ID.Project,
+					Domain:       ID.Domain,
+					ResourceType: ID.ResourceType,
+					Priority:     models.ResourcePriorityProjectDomainLevel,
+					Attributes:   marshalledMatchingAttributes,
+				}, nil
+			}
+			workflowConfigs := defaultWorkflowConfigs
+			if tc.workflowConfigs != nil {
+				workflowConfigs = tc.workflowConfigs
+			}
+			domain := testDomain
+			if len(tc.domain) > 0 {
+				domain = tc.domain
+			}
+			queueAllocator := NewQueueAllocator(runtimeMocks.NewMockConfigurationProvider(
+				nil, runtimeMocks.NewMockQueueConfigurationProvider(executionQueues, workflowConfigs), nil,
+				nil, nil, nil), db, mockScope.NewTestScope())
+
+			queue := queueAllocator.GetQueue(context.Background(), core.Identifier{
+				Project: testProject,
+				Domain:  domain,
+				Name:    testWorkflow,
+			})
+			assert.Equal(t, tc.expected, queue.DynamicQueue)
+			metrics := queueAllocator.(*queueAllocatorImpl).metrics
+			if len(tc.expected) > 0 {
+				assert.Equal(t, float64(1), testutil.ToFloat64(metrics.Assignments.WithLabelValues(tc.expected)))
+				assert.Equal(t, float64(0), testutil.ToFloat64(metrics.UnmatchedAssignments))
+			} else {
+				assert.Equal(t, float64(1), testutil.ToFloat64(metrics.UnmatchedAssignments))
+			}
+			if tc.expectDefault {
+				assert.Equal(t, float64(1), testutil.ToFloat64(metrics.DefaultAssignments))
+			} else {
+				assert.Equal(t, float64(0), testutil.ToFloat64(metrics.DefaultAssignments))
+			}
+		})
+	}
+}
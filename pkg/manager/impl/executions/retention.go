@@ -0,0 +1,154 @@
+package executions
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	repositoryInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The name of the lease held by the replica running the retention job.
+const retentionJobLeaseName = "execution_retention"
+
+// How long a stopping job waits on the database to release its lease.
+const retentionLeaseReleaseTimeout = 5 * time.Second
+
+type retentionMetrics struct {
+	Scope            promutils.Scope
+	Runs             prometheus.Counter
+	SkippedRuns      prometheus.Counter
+	Failures         prometheus.Counter
+	PurgedExecutions prometheus.Counter
+}
+
+// RetentionJob periodically purges the executions of each domain which are older than the domain's retention period.
+// Every replica runs the job, but only the one holding its lease purges executions.
+type RetentionJob struct {
+	executionManager interfaces.ExecutionInterface
+	leases           repositoryInterfaces.JobLeaseRepoInterface
+	holder           string
+	config           runtimeInterfaces.ExecutionRetentionConfig
+	domains          runtimeInterfaces.DomainsConfig
+	clock            clock.Clock
+	metrics          retentionMetrics
+}
+
+// Acquires or renews the lease on the job. The lease outlasts the interval, so that its holder renews it before it
+// expires even when a run is late.
+func (j *RetentionJob) acquireLease(ctx context.Context, now time.Time) bool {
+	held, err := j.leases.Acquire(ctx, models.JobLease{
+		Name:      retentionJobLeaseName,
+		Holder:    j.holder,
+		ExpiresAt: now.Add(2 * j.config.Interval.Duration),
+	}, now)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to acquire the lease on the execution retention job with err: %v", err)
+		return false
+	}
+	return held
+}
+
+// Releases the lease on the job, if the replica holds it, so that another replica doesn't have to wait for it to expire
+// to take the job over.
+func (j *RetentionJob) releaseLease(ctx context.Context) {
+	// The context the job ran on is cancelled by the time it stops.
+	releaseCtx, cancel := context.WithTimeout(context.Background(), retentionLeaseReleaseTimeout)
+	defer cancel()
+	if err := j.leases.Release(releaseCtx, models.JobLease{
+		Name:   retentionJobLeaseName,
+		Holder: j.holder,
+	}); err != nil {
+		logger.Errorf(ctx, "Failed to release the lease on the execution retention job with err: %v", err)
+	}
+}
+
+// Purges the expired executions of every domain, as long as the replica holds the lease on the job. A domain failing
+// to be purged doesn't keep the others from being.
+func (j *RetentionJob) purge(ctx context.Context) {
+	now := j.clock.Now()
+	if !j.acquireLease(ctx, now) {
+		j.metrics.SkippedRuns.Inc()
+		return
+	}
+	j.metrics.Runs.Inc()
+	for _, domain := range j.domains {
+		period := j.config.GetRetentionPeriod(domain.ID)
+		if period <= 0 {
+			continue
+		}
+		response, err := j.executionManager.PurgeExecutions(ctx, interfaces.PurgeExecutionsRequest{
+			Domain:        domain.ID,
+			CreatedBefore: now.Add(-period),
+			BatchSize:     j.config.BatchSize,
+			DryRun:        j.config.DryRun,
+		})
+		if err != nil {
+			j.metrics.Failures.Inc()
+			logger.Errorf(ctx, "Failed to purge the executions of domain [%s] older than %v with err: %v",
+				domain.ID, period, err)
+			continue
+		}
+		if !j.config.DryRun {
+			j.metrics.PurgedExecutions.Add(float64(response.Executions))
+		}
+	}
+}
+
+// Run purges expired executions right away and then at every interval, until the context is cancelled. The job then
+// releases its lease.
+func (j *RetentionJob) Run(ctx context.Context) {
+	ticker := j.clock.Ticker(j.config.Interval.Duration)
+	defer ticker.Stop()
+	for {
+		j.purge(ctx)
+		select {
+		case <-ctx.Done():
+			j.releaseLease(ctx)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Returns the hostname, which is unique among the pods of a deployment, to hold the lease of the job with.
+func getRetentionLeaseHolder() string {
+	if hostname, err := os.Hostname(); err == nil && len(hostname) > 0 {
+		return hostname
+	}
+	return uuid.New().String()
+}
+
+func NewRetentionJob(executionManager interfaces.ExecutionInterface, leases repositoryInterfaces.JobLeaseRepoInterface,
+	config runtimeInterfaces.ExecutionRetentionConfig, domains runtimeInterfaces.DomainsConfig,
+	scope promutils.Scope) *RetentionJob {
+	if config.Interval.Duration <= 0 {
+		config.Interval.Duration = time.Hour
+	}
+	return &RetentionJob{
+		executionManager: executionManager,
+		leases:           leases,
+		holder:           getRetentionLeaseHolder(),
+		config:           config,
+		domains:          domains,
+		clock:            clock.New(),
+		metrics: retentionMetrics{
+			Scope: scope,
+			Runs:  scope.MustNewCounter("runs", "count of runs of the execution retention job"),
+			SkippedRuns: scope.MustNewCounter("skipped_runs",
+				"count of runs skipped for another replica holding the lease on the job"),
+			Failures: scope.MustNewCounter("failures",
+				"count of domains whose expired executions failed to be purged"),
+			PurgedExecutions: scope.MustNewCounter("purged_executions",
+				"count of executions purged for having outlived their retention period"),
+		},
+	}
+}
@@ -0,0 +1,164 @@
+package executions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var retentionDomains = runtimeInterfaces.DomainsConfig{
+	{ID: "development"},
+	{ID: "staging"},
+	{ID: "production"},
+}
+
+// Returns leases which are always held by the replica asking for them.
+func getHeldRetentionLeases() *repositoryMocks.JobLeaseRepoInterface {
+	leases := &repositoryMocks.JobLeaseRepoInterface{}
+	leases.OnAcquireMatch(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	leases.OnReleaseMatch(mock.Anything, mock.Anything).Return(nil)
+	return leases
+}
+
+func TestRetentionJob_Purge(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	retentionConfig := runtimeInterfaces.ExecutionRetentionConfig{
+		Enabled:         true,
+		RetentionPeriod: config.Duration{Duration: 24 * time.Hour},
+		DomainRetentionPeriods: map[string]config.Duration{
+			"staging":    {Duration: time.Hour},
+			"production": {},
+		},
+		BatchSize: 50,
+	}
+	executionManager := &mocks.MockExecutionManager{}
+	createdBefore := make(map[string]time.Time)
+	executionManager.SetPurgeExecutionsCallback(func(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+		*interfaces.PurgeExecutionsResponse, error) {
+		assert.Empty(t, request.Project)
+		assert.Equal(t, 50, request.BatchSize)
+		createdBefore[request.Domain] = request.CreatedBefore
+		if request.Domain == "development" {
+			return nil, errors.New("db unavailable")
+		}
+		return &interfaces.PurgeExecutionsResponse{Executions: 3}, nil
+	})
+	job := NewRetentionJob(executionManager, getHeldRetentionLeases(), retentionConfig, retentionDomains,
+		promutils.NewTestScope())
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	job.clock = mockClock
+
+	job.purge(context.Background())
+	// The executions of production are kept forever, and the failure to purge development doesn't keep staging from
+	// being purged.
+	assert.Equal(t, map[string]time.Time{
+		"development": now.Add(-24 * time.Hour),
+		"staging":     now.Add(-time.Hour),
+	}, createdBefore)
+}
+
+func TestRetentionJob_Run(t *testing.T) {
+	retentionConfig := runtimeInterfaces.ExecutionRetentionConfig{
+		Enabled:         true,
+		RetentionPeriod: config.Duration{Duration: time.Hour},
+		Interval:        config.Duration{Duration: time.Minute},
+	}
+	executionManager := &mocks.MockExecutionManager{}
+	var mutex sync.Mutex
+	purges := 0
+	purged := make(chan struct{}, 10)
+	executionManager.SetPurgeExecutionsCallback(func(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+		*interfaces.PurgeExecutionsResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		purges++
+		purged <- struct{}{}
+		return &interfaces.PurgeExecutionsResponse{}, nil
+	})
+	leases := getHeldRetentionLeases()
+	job := NewRetentionJob(executionManager, leases, retentionConfig,
+		runtimeInterfaces.DomainsConfig{{ID: "development"}}, promutils.NewTestScope())
+	job.holder = "replica-0"
+	mockClock := clock.NewMock()
+	job.clock = mockClock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+	// Executions are purged right away, and then once per interval.
+	<-purged
+	mockClock.Add(time.Minute)
+	<-purged
+	cancel()
+	<-done
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 2, purges)
+	// The stopped job releases its lease for another replica to take over.
+	leases.AssertCalled(t, "Release", mock.Anything, models.JobLease{Name: "execution_retention", Holder: "replica-0"})
+}
+
+func TestRetentionJob_Lease(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	retentionConfig := runtimeInterfaces.ExecutionRetentionConfig{
+		Enabled:         true,
+		RetentionPeriod: config.Duration{Duration: time.Hour},
+		Interval:        config.Duration{Duration: time.Minute},
+	}
+	executionManager := &mocks.MockExecutionManager{}
+	purges := 0
+	executionManager.SetPurgeExecutionsCallback(func(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+		*interfaces.PurgeExecutionsResponse, error) {
+		purges++
+		return &interfaces.PurgeExecutionsResponse{}, nil
+	})
+	leases := &repositoryMocks.JobLeaseRepoInterface{}
+	job := NewRetentionJob(executionManager, leases, retentionConfig,
+		runtimeInterfaces.DomainsConfig{{ID: "development"}}, promutils.NewTestScope())
+	job.holder = "replica-0"
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	job.clock = mockClock
+
+	// The lease outlasts the interval, so that it's renewed before it expires.
+	lease := models.JobLease{
+		Name:      "execution_retention",
+		Holder:    "replica-0",
+		ExpiresAt: now.Add(2 * time.Minute),
+	}
+	leases.OnAcquire(context.Background(), lease, now).Return(false, nil).Once()
+	job.purge(context.Background())
+	assert.Zero(t, purges)
+
+	leases.OnAcquire(context.Background(), lease, now).Return(false, errors.New("db unavailable")).Once()
+	job.purge(context.Background())
+	assert.Zero(t, purges)
+
+	leases.OnAcquire(context.Background(), lease, now).Return(true, nil).Once()
+	job.purge(context.Background())
+	assert.Equal(t, 1, purges)
+	leases.AssertExpectations(t)
+}
+
+func TestNewRetentionJob_DefaultInterval(t *testing.T) {
+	job := NewRetentionJob(&mocks.MockExecutionManager{}, getHeldRetentionLeases(),
+		runtimeInterfaces.ExecutionRetentionConfig{}, retentionDomains, promutils.NewTestScope())
+	assert.Equal(t, time.Hour, job.config.Interval.Duration)
+}
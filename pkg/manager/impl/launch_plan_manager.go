@@ -3,7 +3,9 @@ package impl
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/contextutils"
 
@@ -16,6 +18,7 @@ import (
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
 	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
@@ -38,10 +41,11 @@ type launchPlanMetrics struct {
 }
 
 type LaunchPlanManager struct {
-	db        repositories.RepositoryInterface
-	config    runtimeInterfaces.Configuration
-	scheduler scheduleInterfaces.EventScheduler
-	metrics   launchPlanMetrics
+	db          repositories.RepositoryInterface
+	config      runtimeInterfaces.Configuration
+	scheduler   scheduleInterfaces.EventScheduler
+	metrics     launchPlanMetrics
+	lookupCache *util.LookupCache
 }
 
 func getLaunchPlanContext(ctx context.Context, identifier *core.Identifier) context.Context {
@@ -57,6 +61,7 @@ func (m *LaunchPlanManager) getNamedEntityContext(ctx context.Context, identifie
 func (m *LaunchPlanManager) CreateLaunchPlan(
 	ctx context.Context,
 	request admin.LaunchPlanCreateRequest) (*admin.LaunchPlanCreateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateIdentifier(request.GetSpec().GetWorkflowId(), common.Workflow); err != nil {
 		logger.Debugf(ctx, "Failed to validate provided workflow ID for CreateLaunchPlan with err: %v", err)
 		return nil, err
@@ -108,6 +113,14 @@ func (m *LaunchPlanManager) CreateLaunchPlan(
 			request, workflowInterface.Outputs, err)
 		return nil, err
 	}
+	// The launch plan spec has no field for the overlap policy, which is requested by annotation instead. Versions that
+	// don't request one record the policy configured at their creation.
+	overlapPolicy, err := common.GetScheduleOverlapPolicy(launchPlan.GetSpec().GetAnnotations(),
+		m.config.ApplicationConfiguration().GetSchedulerConfig().EventSchedulerConfig.GetOverlapPolicy())
+	if err != nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "%v", err)
+	}
+	launchPlanModel.ScheduleOverlapPolicy = string(overlapPolicy)
 	err = m.db.LaunchPlanRepo().Create(ctx, launchPlanModel)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to save launch plan model %+v with err: %v", request.Id, err)
@@ -135,6 +148,10 @@ func (m *LaunchPlanManager) updateLaunchPlanModelState(launchPlan *models.Launch
 	launchPlan.Closure = marshalledClosure
 	stateInt := int32(state)
 	launchPlan.State = &stateInt
+	if state == admin.LaunchPlanState_ACTIVE {
+		activatedAt := time.Now()
+		launchPlan.ActivatedAt = &activatedAt
+	}
 	return nil
 }
 
@@ -164,6 +181,12 @@ func (m *LaunchPlanManager) enableSchedule(ctx context.Context, launchPlanIdenti
 	if err != nil {
 		return err
 	}
+	// The offset the launch plan requests adds to the jitter the scheduler assigned it.
+	offset, err := common.GetScheduleOffset(launchPlanSpec.GetAnnotations())
+	if err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "%v", err)
+	}
+	addScheduleInput.Offset += offset
 
 	return m.scheduler.AddSchedule(ctx, addScheduleInput)
 }
@@ -262,10 +285,66 @@ func (m *LaunchPlanManager) disableLaunchPlan(ctx context.Context, request admin
 		logger.Debugf(ctx, "Failed to update launchPlanModel with ID [%+v] with err %v", request.Id, err)
 		return nil, err
 	}
+	m.lookupCache.InvalidateLaunchPlan(*request.Id)
 	logger.Debugf(ctx, "disabled launch plan: [%+v]", request.Id)
 	return &admin.LaunchPlanUpdateResponse{}, nil
 }
 
+// Restores the launch plan version which was active before enableLaunchPlan activated another one, when the schedules
+// couldn't be updated to match. The schedule of the formerly active version may have been disabled already, so it's
+// enabled again. Failures are only logged, since the error updating the schedules is the one returned.
+func (m *LaunchPlanManager) restoreActiveLaunchPlan(
+	ctx context.Context, newlyActiveLaunchPlan models.LaunchPlan, formerlyActiveLaunchPlan *models.LaunchPlan) {
+	if formerlyActiveLaunchPlan == nil {
+		inactive := int32(admin.LaunchPlanState_INACTIVE)
+		newlyActiveLaunchPlan.State = &inactive
+		if err := m.db.LaunchPlanRepo().Update(ctx, newlyActiveLaunchPlan); err != nil {
+			logger.Errorf(ctx, "Failed to deactivate launch plan [%+v] after failing to update its schedule with err: %v",
+				newlyActiveLaunchPlan.LaunchPlanKey, err)
+		}
+		return
+	}
+	if err := m.updateSchedules(ctx, *formerlyActiveLaunchPlan, nil); err != nil {
+		logger.Errorf(ctx, "Failed to enable the schedule of formerly active launch plan [%+v] again with err: %v",
+			formerlyActiveLaunchPlan.LaunchPlanKey, err)
+	}
+	active := int32(admin.LaunchPlanState_ACTIVE)
+	formerlyActiveLaunchPlan.State = &active
+	if _, err := m.db.LaunchPlanRepo().SetActive(ctx, *formerlyActiveLaunchPlan); err != nil {
+		logger.Errorf(ctx, "Failed to activate formerly active launch plan [%+v] again with err: %v",
+			formerlyActiveLaunchPlan.LaunchPlanKey, err)
+	}
+}
+
+// A concurrent activation of another version of the launch plan may have disabled the schedule of the version given
+// before enableLaunchPlan enabled it, in which case it's disabled again once the version is found superseded.
+func (m *LaunchPlanManager) disableScheduleIfSuperseded(ctx context.Context, launchPlan models.LaunchPlan) error {
+	var launchPlanSpec admin.LaunchPlanSpec
+	if err := proto.Unmarshal(launchPlan.Spec, &launchPlanSpec); err != nil || isScheduleEmpty(launchPlanSpec) {
+		return nil
+	}
+	current, err := m.db.LaunchPlanRepo().Get(ctx, repoInterfaces.Identifier{
+		Project: launchPlan.Project,
+		Domain:  launchPlan.Domain,
+		Name:    launchPlan.Name,
+		Version: launchPlan.Version,
+	})
+	if err != nil {
+		return err
+	}
+	if current.State != nil && *current.State == int32(admin.LaunchPlanState_ACTIVE) {
+		return nil
+	}
+	logger.Infof(ctx, "Disabling the schedule of launch plan [%+v] superseded by a concurrent activation",
+		launchPlan.LaunchPlanKey)
+	return m.disableSchedule(ctx, core.Identifier{
+		Project: launchPlan.Project,
+		Domain:  launchPlan.Domain,
+		Name:    launchPlan.Name,
+		Version: launchPlan.Version,
+	})
+}
+
 func (m *LaunchPlanManager) enableLaunchPlan(ctx context.Context, request admin.LaunchPlanUpdateRequest) (
 	*admin.LaunchPlanUpdateResponse, error) {
 	newlyActiveLaunchPlanModel, err := m.db.LaunchPlanRepo().Get(ctx, repoInterfaces.Identifier{
@@ -284,53 +363,44 @@ func (m *LaunchPlanManager) enableLaunchPlan(ctx context.Context, request admin.
 		return nil, err
 	}
 
-	// Find currently active version, if it exists.
-	filters, err := util.GetActiveLaunchPlanVersionFilters(newlyActiveLaunchPlanModel.Project, newlyActiveLaunchPlanModel.Domain, newlyActiveLaunchPlanModel.Name)
+	// Only one version can be active at a time, so setting the desired launch plan to active also disables the
+	// existing active launch plan version, which the repo does in the same transaction.
+	formerlyActiveLaunchPlanModel, err := m.db.LaunchPlanRepo().SetActive(ctx, newlyActiveLaunchPlanModel)
 	if err != nil {
+		logger.Debugf(ctx,
+			"Failed to set launchPlanModel with ID [%+v] to active with err %v", request.Id, err)
 		return nil, err
 	}
-	formerlyActiveLaunchPlanModelOutput, err := m.db.LaunchPlanRepo().List(ctx, repoInterfaces.ListResourceInput{
-		InlineFilters: filters,
-		Limit:         1,
-	})
-	var formerlyActiveLaunchPlanModel *models.LaunchPlan
-	if err != nil {
-		// Not found is fine, there isn't always a guaranteed active launch plan model.
-		if err.(errors.FlyteAdminError).Code() != codes.NotFound {
-			logger.Infof(ctx, "Failed to search for an active launch plan model with project: %s, domain: %s, name: %s and err %v",
-				request.Id.Project, request.Id.Domain, request.Id.Name, err)
-			return nil, err
-		}
-		logger.Debugf(ctx, "No active launch plan model found to disable with project: %s, domain: %s, name: %s",
-			request.Id.Project, request.Id.Domain, request.Id.Name)
-	} else if formerlyActiveLaunchPlanModelOutput.LaunchPlans != nil &&
-		len(formerlyActiveLaunchPlanModelOutput.LaunchPlans) > 0 {
-		formerlyActiveLaunchPlanModel = &formerlyActiveLaunchPlanModelOutput.LaunchPlans[0]
-		err = m.updateLaunchPlanModelState(formerlyActiveLaunchPlanModel, admin.LaunchPlanState_INACTIVE)
-		if err != nil {
-			return nil, err
+	defer func() {
+		m.lookupCache.InvalidateLaunchPlan(*request.Id)
+		if formerlyActiveLaunchPlanModel != nil {
+			m.lookupCache.InvalidateLaunchPlan(core.Identifier{
+				Project: formerlyActiveLaunchPlanModel.Project,
+				Domain:  formerlyActiveLaunchPlanModel.Domain,
+				Name:    formerlyActiveLaunchPlanModel.Name,
+				Version: formerlyActiveLaunchPlanModel.Version,
+			})
 		}
-	}
+	}()
+
+	// The schedules are only updated once the new states are committed, so that they never follow states which
+	// weren't, and the states are restored when the schedules can't be updated.
 	err = m.updateSchedules(ctx, newlyActiveLaunchPlanModel, formerlyActiveLaunchPlanModel)
 	if err != nil {
 		m.metrics.FailedScheduleUpdates.Inc()
+		m.restoreActiveLaunchPlan(ctx, newlyActiveLaunchPlanModel, formerlyActiveLaunchPlanModel)
 		return nil, err
 	}
-
-	// This operation is takes in the (formerly) active launch plan version as only one version can be active at a time.
-	// Setting the desired launch plan to active also requires disabling the existing active launch plan version.
-	err = m.db.LaunchPlanRepo().SetActive(ctx, newlyActiveLaunchPlanModel, formerlyActiveLaunchPlanModel)
-	if err != nil {
-		logger.Debugf(ctx,
-			"Failed to set launchPlanModel with ID [%+v] to active with err %v", request.Id, err)
-		return nil, err
+	if err = m.disableScheduleIfSuperseded(ctx, newlyActiveLaunchPlanModel); err != nil {
+		m.metrics.FailedScheduleUpdates.Inc()
+		logger.Errorf(ctx, "Failed to check whether launch plan [%+v] was superseded with err: %v", request.Id, err)
 	}
 	return &admin.LaunchPlanUpdateResponse{}, nil
-
 }
 
 func (m *LaunchPlanManager) UpdateLaunchPlan(ctx context.Context, request admin.LaunchPlanUpdateRequest) (
 	*admin.LaunchPlanUpdateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateIdentifier(request.Id, common.LaunchPlan); err != nil {
 		logger.Debugf(ctx, "can't update launch plan [%+v] state, invalid identifier: %v", request.Id, err)
 	}
@@ -408,6 +478,10 @@ func (m *LaunchPlanManager) ListLaunchPlans(ctx context.Context, request admin.R
 	if err != nil {
 		return nil, err
 	}
+	filters, err = util.AddUnarchivedWorkflowFilter(filters)
+	if err != nil {
+		return nil, err
+	}
 
 	var sortParameter common.SortParameter
 	if request.SortBy != nil {
@@ -426,6 +500,9 @@ func (m *LaunchPlanManager) ListLaunchPlans(ctx context.Context, request admin.R
 		Offset:        offset,
 		InlineFilters: filters,
 		SortParameter: sortParameter,
+		JoinTableEntities: map[common.Entity]bool{
+			common.NamedEntityMetadata: true,
+		},
 	}
 
 	output, err := m.db.LaunchPlanRepo().List(ctx, listLaunchPlansInput)
@@ -449,31 +526,19 @@ func (m *LaunchPlanManager) ListLaunchPlans(ctx context.Context, request admin.R
 	}, nil
 }
 
-func (m *LaunchPlanManager) ListActiveLaunchPlans(ctx context.Context, request admin.ActiveLaunchPlanListRequest) (
-	*admin.LaunchPlanList, error) {
-
-	// Check required fields
-	if err := validation.ValidateActiveLaunchPlanListRequest(request); err != nil {
-		logger.Debugf(ctx, "")
-		return nil, err
-	}
-	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
-
-	filters, err := util.ListActiveLaunchPlanVersionsFilters(request.Project, request.Domain)
-	if err != nil {
-		return nil, err
-	}
-
-	var sortParameter common.SortParameter
+func (m *LaunchPlanManager) listActiveLaunchPlanModels(
+	ctx context.Context, request admin.ActiveLaunchPlanListRequest, filters []common.InlineFilter,
+	sortParameter common.SortParameter) ([]models.LaunchPlan, string, error) {
+	var err error
 	if request.SortBy != nil {
 		sortParameter, err = common.NewSortParameter(*request.SortBy)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 	offset, err := validation.ValidateToken(request.Token)
 	if err != nil {
-		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+		return nil, "", errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 			"invalid pagination token %s for ListActiveLaunchPlans", request.Token)
 	}
 	listLaunchPlansInput := repoInterfaces.ListResourceInput{
@@ -486,17 +551,39 @@ func (m *LaunchPlanManager) ListActiveLaunchPlans(ctx context.Context, request a
 	output, err := m.db.LaunchPlanRepo().List(ctx, listLaunchPlansInput)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to list active launch plans for request [%+v] with err %v", request, err)
+		return nil, "", err
+	}
+	var token string
+	if len(output.LaunchPlans) == int(request.Limit) {
+		token = strconv.Itoa(offset + len(output.LaunchPlans))
+	}
+	return output.LaunchPlans, token, nil
+}
+
+func (m *LaunchPlanManager) ListActiveLaunchPlans(ctx context.Context, request admin.ActiveLaunchPlanListRequest) (
+	*admin.LaunchPlanList, error) {
+
+	// Check required fields
+	if err := validation.ValidateActiveLaunchPlanListRequest(request); err != nil {
+		logger.Debugf(ctx, "")
 		return nil, err
 	}
-	launchPlanList, err := transformers.FromLaunchPlanModels(output.LaunchPlans)
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+
+	filters, err := util.ListActiveLaunchPlanVersionsFilters(request.Project, request.Domain)
 	if err != nil {
-		logger.Errorf(ctx,
-			"Failed to transform active launch plan models [%+v] with err: %v", output.LaunchPlans, err)
 		return nil, err
 	}
-	var token string
-	if len(output.LaunchPlans) == int(request.Limit) {
-		token = strconv.Itoa(offset + len(output.LaunchPlans))
+
+	launchPlanModels, token, err := m.listActiveLaunchPlanModels(ctx, request, filters, nil)
+	if err != nil {
+		return nil, err
+	}
+	launchPlanList, err := transformers.FromLaunchPlanModels(launchPlanModels)
+	if err != nil {
+		logger.Errorf(ctx,
+			"Failed to transform active launch plan models [%+v] with err: %v", launchPlanModels, err)
+		return nil, err
 	}
 	return &admin.LaunchPlanList{
 		LaunchPlans: launchPlanList,
@@ -504,6 +591,73 @@ func (m *LaunchPlanManager) ListActiveLaunchPlans(ctx context.Context, request a
 	}, nil
 }
 
+// Resolves a launch plan schedule to a single expression which can be used to compute upcoming runs.
+func resolveScheduleExpression(schedule *admin.Schedule) string {
+	if len(schedule.GetCronSchedule().GetSchedule()) > 0 {
+		return schedule.GetCronSchedule().GetSchedule()
+	}
+	if len(schedule.GetCronExpression()) > 0 {
+		return schedule.GetCronExpression()
+	}
+	if schedule.GetRate().GetValue() == 0 {
+		return ""
+	}
+	switch schedule.GetRate().GetUnit() {
+	case admin.FixedRateUnit_MINUTE:
+		return fmt.Sprintf("@every %dm", schedule.GetRate().GetValue())
+	case admin.FixedRateUnit_HOUR:
+		return fmt.Sprintf("@every %dh", schedule.GetRate().GetValue())
+	case admin.FixedRateUnit_DAY:
+		return fmt.Sprintf("@every %dh", schedule.GetRate().GetValue()*24)
+	}
+	return ""
+}
+
+// Active launch plans are ordered by most recent activation unless the request specifies its own sort.
+func (m *LaunchPlanManager) ListActiveLaunchPlansBySchedule(
+	ctx context.Context, request admin.ActiveLaunchPlanListRequest, scheduled bool) (
+	*interfaces.ActiveLaunchPlanList, error) {
+	if err := validation.ValidateActiveLaunchPlanListRequest(request); err != nil {
+		logger.Debugf(ctx, "invalid request [%+v] to list active launch plans by schedule: %v", request, err)
+		return nil, err
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+
+	filters, err := util.ListActiveLaunchPlanVersionsByScheduleFilters(request.Project, request.Domain, scheduled)
+	if err != nil {
+		return nil, err
+	}
+	activatedAtSort, err := common.NewSortParameter(admin.Sort{
+		Key:       shared.ActivatedAt,
+		Direction: admin.Sort_DESCENDING,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	launchPlanModels, token, err := m.listActiveLaunchPlanModels(ctx, request, filters, activatedAtSort)
+	if err != nil {
+		return nil, err
+	}
+	activeLaunchPlans := make([]interfaces.ActiveLaunchPlan, len(launchPlanModels))
+	for idx, launchPlanModel := range launchPlanModels {
+		launchPlan, err := transformers.FromLaunchPlanModel(launchPlanModel)
+		if err != nil {
+			logger.Errorf(ctx,
+				"Failed to transform active launch plan model [%+v] with err: %v", launchPlanModel.LaunchPlanKey, err)
+			return nil, err
+		}
+		activeLaunchPlans[idx] = interfaces.ActiveLaunchPlan{
+			LaunchPlan:         launchPlan,
+			ScheduleExpression: resolveScheduleExpression(launchPlan.GetSpec().GetEntityMetadata().GetSchedule()),
+		}
+	}
+	return &interfaces.ActiveLaunchPlanList{
+		LaunchPlans: activeLaunchPlans,
+		Token:       token,
+	}, nil
+}
+
 // At least project name and domain must be specified along with limit.
 func (m *LaunchPlanManager) ListLaunchPlanIds(ctx context.Context, request admin.NamedEntityIdentifierListRequest) (
 	*admin.NamedEntityIdentifierList, error) {
@@ -553,7 +707,8 @@ func NewLaunchPlanManager(
 	db repositories.RepositoryInterface,
 	config runtimeInterfaces.Configuration,
 	scheduler scheduleInterfaces.EventScheduler,
-	scope promutils.Scope) interfaces.LaunchPlanInterface {
+	scope promutils.Scope,
+	lookupCache *util.LookupCache) interfaces.LaunchPlanInterface {
 
 	metrics := launchPlanMetrics{
 		Scope: scope,
@@ -563,9 +718,10 @@ func NewLaunchPlanManager(
 		ClosureSizeBytes: scope.MustNewSummary("closure_size_bytes", "size in bytes of serialized launch plan closure"),
 	}
 	return &LaunchPlanManager{
-		db:        db,
-		config:    config,
-		scheduler: scheduler,
-		metrics:   metrics,
+		db:          db,
+		config:      config,
+		scheduler:   scheduler,
+		metrics:     metrics,
+		lookupCache: lookupCache,
 	}
 }
@@ -15,7 +15,6 @@ import (
 	"github.com/golang/protobuf/ptypes"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
-	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
@@ -31,7 +30,6 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
-	"google.golang.org/grpc/codes"
 )
 
 var active = int32(admin.LaunchPlanState_ACTIVE)
@@ -94,7 +92,7 @@ func TestCreateLaunchPlan(t *testing.T) {
 			return nil
 		})
 	setDefaultWorkflowCallbackForLpTest(repository)
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	response, err := lpManager.CreateLaunchPlan(context.Background(), request)
 	assert.Nil(t, err)
@@ -104,9 +102,53 @@ func TestCreateLaunchPlan(t *testing.T) {
 	assert.True(t, createCalled)
 }
 
+func TestCreateLaunchPlan_ScheduleOverlapPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		configured runtimeInterfaces.ScheduleOverlapPolicy
+		annotation string
+		expected   runtimeInterfaces.ScheduleOverlapPolicy
+	}{
+		{"", "", runtimeInterfaces.ScheduleOverlapPolicyAllow},
+		{runtimeInterfaces.ScheduleOverlapPolicySkip, "", runtimeInterfaces.ScheduleOverlapPolicySkip},
+		// The policy the launch plan requests wins over the configured one.
+		{runtimeInterfaces.ScheduleOverlapPolicySkip, "replace", runtimeInterfaces.ScheduleOverlapPolicyReplace},
+	} {
+		repository := getMockRepositoryForLpTest()
+		repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+			func(input interfaces.Identifier) (models.LaunchPlan, error) {
+				return models.LaunchPlan{}, errors.New("foo")
+			})
+		var createCalled bool
+		repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetCreateCallback(
+			func(input models.LaunchPlan) error {
+				assert.Equal(t, string(tc.expected), input.ScheduleOverlapPolicy)
+				createCalled = true
+				return nil
+			})
+		setDefaultWorkflowCallbackForLpTest(repository)
+		mockConfig := getMockConfigForLpTest()
+		mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetSchedulerConfig(
+			runtimeInterfaces.SchedulerConfig{
+				EventSchedulerConfig: runtimeInterfaces.EventSchedulerConfig{
+					OverlapPolicy: tc.configured,
+				},
+			})
+		lpManager := NewLaunchPlanManager(repository, mockConfig, mockScheduler, mockScope.NewTestScope(), nil)
+		request := testutils.GetLaunchPlanRequest()
+		if len(tc.annotation) > 0 {
+			request.Spec.Annotations = &admin.Annotations{
+				Values: map[string]string{common.ScheduleOverlapPolicyAnnotation: tc.annotation},
+			}
+		}
+		_, err := lpManager.CreateLaunchPlan(context.Background(), request)
+		assert.Nil(t, err)
+		assert.True(t, createCalled)
+	}
+}
+
 func TestLaunchPlanManager_GetLaunchPlan(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	state := int32(0)
 	lpRequest := testutils.GetLaunchPlanRequest()
 	workflowRequest := testutils.GetWorkflowRequest()
@@ -142,7 +184,7 @@ func TestLaunchPlanManager_GetLaunchPlan(t *testing.T) {
 
 func TestLaunchPlanManager_GetActiveLaunchPlan(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	state := int32(1)
 	lpRequest := testutils.GetLaunchPlanRequest()
 	workflowRequest := testutils.GetWorkflowRequest()
@@ -201,7 +243,7 @@ func TestLaunchPlanManager_GetActiveLaunchPlan(t *testing.T) {
 
 func TestLaunchPlanManager_GetActiveLaunchPlan_NoneActive(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	lpRequest := testutils.GetLaunchPlanRequest()
 
 	launchPlanListFunc := func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
@@ -221,7 +263,7 @@ func TestLaunchPlanManager_GetActiveLaunchPlan_NoneActive(t *testing.T) {
 
 func TestLaunchPlanManager_GetActiveLaunchPlan_InvalidRequest(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	response, err := lpManager.GetActiveLaunchPlan(context.Background(), admin.ActiveLaunchPlanRequest{
 		Id: &admin.NamedEntityIdentifier{
 			Domain: domain,
@@ -233,7 +275,7 @@ func TestLaunchPlanManager_GetActiveLaunchPlan_InvalidRequest(t *testing.T) {
 }
 
 func TestLaunchPlan_ValidationError(t *testing.T) {
-	lpManager := NewLaunchPlanManager(repositoryMocks.NewMockRepository(), getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repositoryMocks.NewMockRepository(), getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	request.Id = nil
 	response, err := lpManager.CreateLaunchPlan(context.Background(), request)
@@ -243,7 +285,7 @@ func TestLaunchPlan_ValidationError(t *testing.T) {
 
 func TestLaunchPlanManager_CreateLaunchPlanErrorDueToBadLabels(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	request.Spec.Labels = &admin.Labels{
 		Values: map[string]string{
@@ -268,7 +310,7 @@ func TestLaunchPlan_DatabaseError(t *testing.T) {
 	}
 
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetCreateCallback(lpCreateFunc)
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	response, err := lpManager.CreateLaunchPlan(context.Background(), request)
 	assert.EqualError(t, err, expectedErr.Error())
@@ -278,7 +320,7 @@ func TestLaunchPlan_DatabaseError(t *testing.T) {
 func TestCreateLaunchPlanInCompatibleInputs(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
 	setDefaultWorkflowCallbackForLpTest(repository)
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	request.Spec.DefaultInputs = &core.ParameterMap{
 		Parameters: map[string]*core.Parameter{
@@ -328,7 +370,7 @@ func TestCreateLaunchPlanValidateCreate(t *testing.T) {
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetCreateCallback(lpCreateFunc)
 
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	response, err := lpManager.CreateLaunchPlan(context.Background(), request)
 	assert.Nil(t, err)
@@ -369,7 +411,7 @@ func TestCreateLaunchPlanNoWorkflowInterface(t *testing.T) {
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetCreateCallback(lpCreateFunc)
 
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	request := testutils.GetLaunchPlanRequest()
 	request.Spec.FixedInputs = nil
 	request.Spec.DefaultInputs = nil
@@ -413,11 +455,11 @@ func TestEnableSchedule(t *testing.T) {
 			assert.True(t, proto.Equal(&launchPlanNamedIdentifier, &input.Identifier))
 			assert.True(t, proto.Equal(&scheduleExpression, &input.ScheduleExpression))
 			assert.Equal(t, "{\"time\":<time>,\"kickoff_time_arg\":\"\",\"payload\":"+
-				"\"Cgdwcm9qZWN0EgZkb21haW4aBG5hbWU=\"}",
+				"\"Cgdwcm9qZWN0EgZkb21haW4aBG5hbWU=\",\"catch_up_policy\":\"ALL\"}",
 				*input.Payload)
 			return nil
 		})
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).enableSchedule(
 		context.Background(),
 		launchPlanNamedIdentifier,
@@ -429,6 +471,39 @@ func TestEnableSchedule(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestEnableSchedule_Offset(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	mockScheduler := mocks.NewMockEventScheduler()
+	var offset time.Duration
+	mockScheduler.(*mocks.MockEventScheduler).SetAddScheduleFunc(
+		func(ctx context.Context, input scheduleInterfaces.AddScheduleInput) error {
+			offset = input.Offset
+			return nil
+		})
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
+	spec := admin.LaunchPlanSpec{
+		EntityMetadata: &admin.LaunchPlanMetadata{
+			Schedule: &admin.Schedule{
+				ScheduleExpression: &admin.Schedule_CronSchedule{
+					CronSchedule: &admin.CronSchedule{
+						Schedule: "0 * * * *",
+					},
+				},
+			},
+		},
+		Annotations: &admin.Annotations{
+			Values: map[string]string{common.ScheduleOffsetAnnotation: "90s"},
+		},
+	}
+	err := lpManager.(*LaunchPlanManager).enableSchedule(context.Background(), launchPlanNamedIdentifier, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, offset)
+
+	spec.Annotations.Values[common.ScheduleOffsetAnnotation] = "-90s"
+	err = lpManager.(*LaunchPlanManager).enableSchedule(context.Background(), launchPlanNamedIdentifier, spec)
+	assert.Error(t, err)
+}
+
 func TestEnableSchedule_Error(t *testing.T) {
 	expectedErr := errors.New("expected error")
 
@@ -438,7 +513,7 @@ func TestEnableSchedule_Error(t *testing.T) {
 		func(ctx context.Context, input scheduleInterfaces.AddScheduleInput) error {
 			return expectedErr
 		})
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).enableSchedule(
 		context.Background(),
 		launchPlanNamedIdentifier, admin.LaunchPlanSpec{
@@ -457,7 +532,7 @@ func TestDisableSchedule(t *testing.T) {
 			assert.True(t, proto.Equal(&launchPlanNamedIdentifier, &input.Identifier))
 			return nil
 		})
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).disableSchedule(context.Background(), launchPlanNamedIdentifier)
 	assert.Nil(t, err)
 }
@@ -471,7 +546,7 @@ func TestDisableSchedule_Error(t *testing.T) {
 		func(ctx context.Context, input scheduleInterfaces.RemoveScheduleInput) error {
 			return expectedErr
 		})
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).disableSchedule(context.Background(), launchPlanNamedIdentifier)
 	assert.EqualError(t, err, expectedErr.Error())
 }
@@ -519,7 +594,7 @@ func TestUpdateSchedules(t *testing.T) {
 			return nil
 		})
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).updateSchedules(
 		context.Background(),
 		models.LaunchPlan{
@@ -572,7 +647,7 @@ func TestUpdateSchedules_NothingToDisableButRedo(t *testing.T) {
 			return nil
 		})
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).updateSchedules(context.Background(), models.LaunchPlan{
 		LaunchPlanKey: models.LaunchPlanKey{
 			Project: project,
@@ -641,7 +716,7 @@ func TestUpdateSchedules_NothingToEnableButRedo(t *testing.T) {
 		})
 
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).updateSchedules(context.Background(), models.LaunchPlan{
 		LaunchPlanKey: models.LaunchPlanKey{
 			Project: project,
@@ -690,7 +765,7 @@ func TestUpdateSchedules_NothingToDoButRedo(t *testing.T) {
 		})
 
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).updateSchedules(context.Background(), models.LaunchPlan{
 		LaunchPlanKey: models.LaunchPlanKey{
 			Project: project,
@@ -751,7 +826,7 @@ func TestUpdateSchedules_EnableNoSchedule(t *testing.T) {
 		})
 
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	err := lpManager.(*LaunchPlanManager).updateSchedules(context.Background(), models.LaunchPlan{
 		LaunchPlanKey: models.LaunchPlanKey{
 			Project: project,
@@ -832,7 +907,7 @@ func TestDisableLaunchPlan(t *testing.T) {
 
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetUpdateCallback(disableFunc)
 
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_INACTIVE,
@@ -853,7 +928,7 @@ func TestDisableLaunchPlan_DatabaseError(t *testing.T) {
 		return models.LaunchPlan{}, expectedError
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(lpGetFunc)
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_INACTIVE,
@@ -886,7 +961,7 @@ func TestDisableLaunchPlan_DatabaseError(t *testing.T) {
 		return expectedError
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetUpdateCallback(disableFunc)
-	lpManager = NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager = NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err = lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_INACTIVE,
@@ -903,47 +978,31 @@ func TestEnableLaunchPlan(t *testing.T) {
 	closureBytes, _ := proto.Marshal(&admin.LaunchPlanClosure{
 		State: admin.LaunchPlanState_ACTIVE,
 	})
-	listFunc := func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
-		return interfaces.LaunchPlanCollectionOutput{
-			LaunchPlans: []models.LaunchPlan{
-				{
-					BaseModel: models.BaseModel{
-						CreatedAt: testutils.MockCreatedAtValue,
-					},
-					LaunchPlanKey: models.LaunchPlanKey{
-						Project: project,
-						Domain:  domain,
-						Name:    name,
-						Version: "old version",
-					},
-					State:   &active,
-					Closure: closureBytes,
-				},
-			},
-		}, nil
-	}
-	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(listFunc)
 
-	enableFunc := func(toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error {
+	enableFunc := func(toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
 		assert.Equal(t, project, toEnable.Project)
 		assert.Equal(t, domain, toEnable.Domain)
 		assert.Equal(t, name, toEnable.Name)
 		assert.Equal(t, version, toEnable.Version)
 		assert.Equal(t, active, *toEnable.State)
-
-		assert.Equal(t, project, toDisable.Project)
-		assert.Equal(t, domain, toDisable.Domain)
-		assert.Equal(t, name, toDisable.Name)
-		assert.Equal(t, "old version", toDisable.Version)
-		assert.Equal(t, inactive, *toDisable.State)
-		var closure admin.LaunchPlanClosure
-		err := proto.Unmarshal(toDisable.Closure, &closure)
-		assert.NoError(t, err)
-		return nil
+		assert.NotNil(t, toEnable.ActivatedAt)
+		return &models.LaunchPlan{
+			BaseModel: models.BaseModel{
+				CreatedAt: testutils.MockCreatedAtValue,
+			},
+			LaunchPlanKey: models.LaunchPlanKey{
+				Project: project,
+				Domain:  domain,
+				Name:    name,
+				Version: "old version",
+			},
+			State:   &inactive,
+			Closure: closureBytes,
+		}, nil
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetSetActiveCallback(enableFunc)
 
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_ACTIVE,
@@ -956,23 +1015,18 @@ func TestEnableLaunchPlan_NoCurrentlyActiveVersion(t *testing.T) {
 
 	lpGetFunc := makeLaunchPlanRepoGetCallback(t)
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(lpGetFunc)
-	listFunc := func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
-		return interfaces.LaunchPlanCollectionOutput{}, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "foo")
-	}
-	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(listFunc)
 
-	enableFunc := func(toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error {
+	enableFunc := func(toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
 		assert.Equal(t, project, toEnable.Project)
 		assert.Equal(t, domain, toEnable.Domain)
 		assert.Equal(t, name, toEnable.Name)
 		assert.Equal(t, version, toEnable.Version)
 		assert.Equal(t, active, *toEnable.State)
-		assert.Nil(t, toDisable)
-		return nil
+		return nil, nil
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetSetActiveCallback(enableFunc)
 
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_ACTIVE,
@@ -992,7 +1046,7 @@ func TestEnableLaunchPlan_DatabaseError(t *testing.T) {
 		return models.LaunchPlan{}, expectedError
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(lpGetFunc)
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_ACTIVE,
@@ -1000,65 +1054,103 @@ func TestEnableLaunchPlan_DatabaseError(t *testing.T) {
 	assert.EqualError(t, err, expectedError.Error(), "Failures on getting the existing launch plan should propagate")
 
 	lpGetFunc = makeLaunchPlanRepoGetCallback(t)
-	lpManager = NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
-	listFunc := func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
-		return interfaces.LaunchPlanCollectionOutput{}, expectedError
-	}
-	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(listFunc)
-	_, err = lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
-		Id:    &launchPlanIdentifier,
-		State: admin.LaunchPlanState_ACTIVE,
-	})
-	assert.EqualError(t, err, expectedError.Error(),
-		"Failures on listing the existing active launch plan version should propagate")
-
-	closureBytes, _ := proto.Marshal(&admin.LaunchPlanClosure{
-		State: admin.LaunchPlanState_ACTIVE,
-	})
-	listFunc = func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
-		return interfaces.LaunchPlanCollectionOutput{
-			LaunchPlans: []models.LaunchPlan{
-				{
-					LaunchPlanKey: models.LaunchPlanKey{
-						Project: project,
-						Domain:  domain,
-						Name:    name,
-						Version: "old version",
-					},
-					State:   &active,
-					Closure: closureBytes,
-				},
-			},
-		}, nil
-	}
-	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(listFunc)
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(lpGetFunc)
-	enableFunc := func(toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error {
+	enableFunc := func(toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
 		assert.Equal(t, project, toEnable.Project)
 		assert.Equal(t, domain, toEnable.Domain)
 		assert.Equal(t, name, toEnable.Name)
 		assert.Equal(t, version, toEnable.Version)
 		assert.Equal(t, active, *toEnable.State)
-
-		assert.Equal(t, project, toDisable.Project)
-		assert.Equal(t, domain, toDisable.Domain)
-		assert.Equal(t, name, toDisable.Name)
-		assert.Equal(t, "old version", toDisable.Version)
-		assert.Equal(t, inactive, *toDisable.State)
-		return expectedError
+		return nil, expectedError
 	}
 	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetSetActiveCallback(enableFunc)
-	lpManager = NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	schedulerUpdated := false
+	scheduler := mocks.NewMockEventScheduler()
+	scheduler.(*mocks.MockEventScheduler).SetAddScheduleFunc(func(ctx context.Context,
+		input scheduleInterfaces.AddScheduleInput) error {
+		schedulerUpdated = true
+		return nil
+	})
+	lpManager = NewLaunchPlanManager(repository, getMockConfigForLpTest(), scheduler, mockScope.NewTestScope(), nil)
 	_, err = lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
 		Id:    &launchPlanIdentifier,
 		State: admin.LaunchPlanState_ACTIVE,
 	})
 	assert.EqualError(t, err, expectedError.Error(), "Errors on setting the desired launch plan to active should propagate")
+	assert.False(t, schedulerUpdated, "Schedules shouldn't be updated unless the launch plan was activated")
+}
+
+func TestEnableLaunchPlan_ScheduleError(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	expectedError := errors.New("expected error")
+	specBytes, _ := proto.Marshal(&admin.LaunchPlanSpec{
+		EntityMetadata: &admin.LaunchPlanMetadata{
+			Schedule: &admin.Schedule{
+				ScheduleExpression: &admin.Schedule_CronExpression{
+					CronExpression: "* * * * *",
+				},
+			},
+		},
+	})
+	newLaunchPlanKey := models.LaunchPlanKey{
+		Project: project,
+		Domain:  domain,
+		Name:    name,
+		Version: version,
+	}
+	oldLaunchPlanKey := newLaunchPlanKey
+	oldLaunchPlanKey.Version = "old version"
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.LaunchPlan, error) {
+			return models.LaunchPlan{
+				LaunchPlanKey: newLaunchPlanKey,
+				Spec:          specBytes,
+				State:         &inactive,
+			}, nil
+		})
+	activeVersion := oldLaunchPlanKey
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetSetActiveCallback(
+		func(toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
+			assert.Equal(t, active, *toEnable.State)
+			formerlyActive := &models.LaunchPlan{
+				LaunchPlanKey: activeVersion,
+				Spec:          specBytes,
+				State:         &inactive,
+			}
+			activeVersion = toEnable.LaunchPlanKey
+			return formerlyActive, nil
+		})
+
+	var removedSchedules, addedSchedules []string
+	scheduler := mocks.NewMockEventScheduler()
+	scheduler.(*mocks.MockEventScheduler).SetRemoveScheduleFunc(func(ctx context.Context,
+		input scheduleInterfaces.RemoveScheduleInput) error {
+		removedSchedules = append(removedSchedules, input.Identifier.Version)
+		return nil
+	})
+	scheduler.(*mocks.MockEventScheduler).SetAddScheduleFunc(func(ctx context.Context,
+		input scheduleInterfaces.AddScheduleInput) error {
+		addedSchedules = append(addedSchedules, input.Identifier.Version)
+		if input.Identifier.Version == version {
+			return expectedError
+		}
+		return nil
+	})
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), scheduler, mockScope.NewTestScope(), nil)
+	_, err := lpManager.UpdateLaunchPlan(context.Background(), admin.LaunchPlanUpdateRequest{
+		Id:    &launchPlanIdentifier,
+		State: admin.LaunchPlanState_ACTIVE,
+	})
+	assert.EqualError(t, err, expectedError.Error())
+	assert.Equal(t, oldLaunchPlanKey, activeVersion, "The formerly active version should be active again")
+	assert.Equal(t, []string{"old version"}, removedSchedules)
+	assert.Equal(t, []string{version, "old version"}, addedSchedules,
+		"The schedule of the formerly active version should be enabled again")
 }
 
 func TestLaunchPlanManager_ListLaunchPlans(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	state := int32(0)
 	lpRequest := testutils.GetLaunchPlanRequest()
 	workflowRequest := testutils.GetWorkflowRequest()
@@ -1077,11 +1169,17 @@ func TestLaunchPlanManager_ListLaunchPlans(t *testing.T) {
 
 	launchPlanListFunc := func(input interfaces.ListResourceInput) (
 		interfaces.LaunchPlanCollectionOutput, error) {
-		var projectFilter, domainFilter, nameFilter bool
+		var projectFilter, domainFilter, nameFilter, archivedFilter bool
 
 		for _, filter := range input.InlineFilters {
-			assert.Equal(t, common.LaunchPlan, filter.GetEntity())
 			queryExpr, _ := filter.GetGormQueryExpr()
+			if filter.GetEntity() == common.NamedEntityMetadata {
+				assert.Equal(t, "COALESCE(state, 0) <> ?", queryExpr.Query)
+				assert.Equal(t, int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED), queryExpr.Args)
+				archivedFilter = true
+				continue
+			}
+			assert.Equal(t, common.LaunchPlan, filter.GetEntity())
 			if queryExpr.Args == project && queryExpr.Query == testutils.ProjectQueryPattern {
 				projectFilter = true
 			}
@@ -1095,6 +1193,8 @@ func TestLaunchPlanManager_ListLaunchPlans(t *testing.T) {
 		assert.True(t, projectFilter, "Missing project equality filter")
 		assert.True(t, domainFilter, "Missing domain equality filter")
 		assert.True(t, nameFilter, "Missing name equality filter")
+		assert.True(t, archivedFilter, "Missing archived workflow filter")
+		assert.True(t, input.JoinTableEntities[common.NamedEntityMetadata])
 		assert.Equal(t, 10, input.Limit)
 		assert.Equal(t, 2, input.Offset)
 		assert.Equal(t, "domain asc", input.SortParameter.GetGormOrderExpr())
@@ -1163,9 +1263,59 @@ func TestLaunchPlanManager_ListLaunchPlans(t *testing.T) {
 	}
 }
 
+func TestLaunchPlanManager_ListLaunchPlans_ShowArchived(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
+	lpRequest := testutils.GetLaunchPlanRequest()
+	specBytes, _ := proto.Marshal(lpRequest.Spec)
+	closureBytes, _ := proto.Marshal(&admin.LaunchPlanClosure{})
+
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(func(
+		input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error) {
+		var stateFilters int
+		for _, filter := range input.InlineFilters {
+			if filter.GetEntity() != common.NamedEntityMetadata {
+				continue
+			}
+			queryExpr, _ := filter.GetGormQueryExpr()
+			assert.Equal(t, "COALESCE(state, 0) in (?)", queryExpr.Query)
+			stateFilters++
+		}
+		assert.Equal(t, 1, stateFilters)
+		launchPlans := make([]models.LaunchPlan, input.Limit)
+		for i := range launchPlans {
+			launchPlans[i] = models.LaunchPlan{
+				LaunchPlanKey: models.LaunchPlanKey{
+					Project: project,
+					Domain:  domain,
+					Name:    name,
+					Version: fmt.Sprintf("%v", i),
+				},
+				Spec:    specBytes,
+				Closure: closureBytes,
+			}
+		}
+		return interfaces.LaunchPlanCollectionOutput{
+			LaunchPlans: launchPlans,
+		}, nil
+	})
+	lpList, err := lpManager.ListLaunchPlans(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: project,
+			Domain:  domain,
+		},
+		Filters: "value_in(named_entity_metadata.state, 0;1)",
+		Limit:   2,
+		Token:   "4",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, lpList.LaunchPlans, 2)
+	assert.Equal(t, "6", lpList.Token)
+}
+
 func TestLaunchPlanManager_ListLaunchPlanIds(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	state := int32(0)
 	lpRequest := testutils.GetLaunchPlanRequest()
 	workflowRequest := testutils.GetWorkflowRequest()
@@ -1248,7 +1398,7 @@ func TestLaunchPlanManager_ListLaunchPlanIds(t *testing.T) {
 
 func TestLaunchPlanManager_ListActiveLaunchPlans(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	state := int32(admin.LaunchPlanState_ACTIVE)
 	lpRequest := testutils.GetLaunchPlanRequest()
 	workflowRequest := testutils.GetWorkflowRequest()
@@ -1333,9 +1483,161 @@ func TestLaunchPlanManager_ListActiveLaunchPlans(t *testing.T) {
 	}
 }
 
+func TestLaunchPlanManager_ListActiveLaunchPlansBySchedule(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
+	state := int32(admin.LaunchPlanState_ACTIVE)
+	cronRequest := testutils.GetLaunchPlanRequestWithCronSchedule("0 * * * *")
+	rateRequest := testutils.GetLaunchPlanRequestWithFixedRateSchedule(2, admin.FixedRateUnit_DAY)
+	cronSpecBytes, _ := proto.Marshal(cronRequest.Spec)
+	rateSpecBytes, _ := proto.Marshal(rateRequest.Spec)
+	closureBytes, _ := proto.Marshal(&admin.LaunchPlanClosure{})
+
+	launchPlanListFunc := func(input interfaces.ListResourceInput) (
+		interfaces.LaunchPlanCollectionOutput, error) {
+		var projectFilter, domainFilter, activeFilter, scheduledFilter bool
+		for _, filter := range input.InlineFilters {
+			assert.Equal(t, common.LaunchPlan, filter.GetEntity())
+			queryExpr, _ := filter.GetGormQueryExpr()
+			if queryExpr.Args == project && queryExpr.Query == testutils.ProjectQueryPattern {
+				projectFilter = true
+			}
+			if queryExpr.Args == domain && queryExpr.Query == testutils.DomainQueryPattern {
+				domainFilter = true
+			}
+			if queryExpr.Args == state && queryExpr.Query == testutils.StateQueryPattern {
+				activeFilter = true
+			}
+			if queryExpr.Args == "NONE" && queryExpr.Query == "schedule_type <> ?" {
+				scheduledFilter = true
+			}
+		}
+		assert.True(t, projectFilter, "Missing project equality filter")
+		assert.True(t, domainFilter, "Missing domain equality filter")
+		assert.True(t, activeFilter, "Missing active filter")
+		assert.True(t, scheduledFilter, "Missing schedule type filter")
+		assert.Equal(t, 2, input.Limit)
+		assert.Equal(t, 2, input.Offset)
+		assert.Equal(t, "activated_at desc", input.SortParameter.GetGormOrderExpr())
+
+		return interfaces.LaunchPlanCollectionOutput{
+			LaunchPlans: []models.LaunchPlan{
+				{
+					LaunchPlanKey: models.LaunchPlanKey{
+						Project: project,
+						Domain:  domain,
+						Name:    "cron",
+						Version: version,
+					},
+					Spec:         cronSpecBytes,
+					Closure:      closureBytes,
+					State:        &state,
+					ScheduleType: models.LaunchPlanScheduleTypeCRON,
+				},
+				{
+					LaunchPlanKey: models.LaunchPlanKey{
+						Project: project,
+						Domain:  domain,
+						Name:    "rate",
+						Version: version,
+					},
+					Spec:         rateSpecBytes,
+					Closure:      closureBytes,
+					State:        &state,
+					ScheduleType: models.LaunchPlanScheduleTypeRATE,
+				},
+			},
+		}, nil
+	}
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(launchPlanListFunc)
+
+	lpList, err := lpManager.ListActiveLaunchPlansBySchedule(context.Background(), admin.ActiveLaunchPlanListRequest{
+		Project: project,
+		Domain:  domain,
+		Limit:   2,
+		Token:   "2",
+	}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "4", lpList.Token)
+	assert.Len(t, lpList.LaunchPlans, 2)
+	assert.Equal(t, "cron", lpList.LaunchPlans[0].LaunchPlan.Id.Name)
+	assert.Equal(t, "0 * * * *", lpList.LaunchPlans[0].ScheduleExpression)
+	assert.Equal(t, "rate", lpList.LaunchPlans[1].LaunchPlan.Id.Name)
+	assert.Equal(t, "@every 48h", lpList.LaunchPlans[1].ScheduleExpression)
+}
+
+func TestLaunchPlanManager_ListActiveLaunchPlansBySchedule_Unscheduled(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
+
+	launchPlanListFunc := func(input interfaces.ListResourceInput) (
+		interfaces.LaunchPlanCollectionOutput, error) {
+		var unscheduledFilter bool
+		for _, filter := range input.InlineFilters {
+			queryExpr, _ := filter.GetGormQueryExpr()
+			if queryExpr.Args == "NONE" && queryExpr.Query == "schedule_type = ?" {
+				unscheduledFilter = true
+			}
+		}
+		assert.True(t, unscheduledFilter, "Missing schedule type filter")
+		assert.Equal(t, "name asc", input.SortParameter.GetGormOrderExpr())
+		return interfaces.LaunchPlanCollectionOutput{}, nil
+	}
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetListCallback(launchPlanListFunc)
+
+	lpList, err := lpManager.ListActiveLaunchPlansBySchedule(context.Background(), admin.ActiveLaunchPlanListRequest{
+		Project: project,
+		Domain:  domain,
+		Limit:   10,
+		SortBy: &admin.Sort{
+			Direction: admin.Sort_ASCENDING,
+			Key:       "name",
+		},
+	}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, lpList.LaunchPlans)
+	assert.Empty(t, lpList.Token)
+}
+
+func TestLaunchPlanManager_ListActiveLaunchPlansBySchedule_BadRequest(t *testing.T) {
+	repository := getMockRepositoryForLpTest()
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
+	lpList, err := lpManager.ListActiveLaunchPlansBySchedule(context.Background(), admin.ActiveLaunchPlanListRequest{
+		Domain: domain,
+		Limit:  10,
+	}, true)
+	assert.Error(t, err)
+	assert.Nil(t, lpList)
+}
+
+func TestResolveScheduleExpression(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule *admin.Schedule
+		expected string
+	}{
+		{"no schedule", nil, ""},
+		{"cron schedule", &admin.Schedule{ScheduleExpression: &admin.Schedule_CronSchedule{
+			CronSchedule: &admin.CronSchedule{Schedule: "@hourly"}}}, "@hourly"},
+		{"cron expression", &admin.Schedule{ScheduleExpression: &admin.Schedule_CronExpression{
+			CronExpression: "0 12 * * ? *"}}, "0 12 * * ? *"},
+		{"minutes", &admin.Schedule{ScheduleExpression: &admin.Schedule_Rate{
+			Rate: &admin.FixedRate{Value: 5, Unit: admin.FixedRateUnit_MINUTE}}}, "@every 5m"},
+		{"hours", &admin.Schedule{ScheduleExpression: &admin.Schedule_Rate{
+			Rate: &admin.FixedRate{Value: 3, Unit: admin.FixedRateUnit_HOUR}}}, "@every 3h"},
+		{"days", &admin.Schedule{ScheduleExpression: &admin.Schedule_Rate{
+			Rate: &admin.FixedRate{Value: 1, Unit: admin.FixedRateUnit_DAY}}}, "@every 24h"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resolveScheduleExpression(tc.schedule))
+		})
+	}
+}
+
 func TestLaunchPlanManager_ListActiveLaunchPlans_BadRequest(t *testing.T) {
 	repository := getMockRepositoryForLpTest()
-	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope())
+	lpManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), mockScheduler, mockScope.NewTestScope(), nil)
 	lpList, err := lpManager.ListActiveLaunchPlans(context.Background(), admin.ActiveLaunchPlanListRequest{
 		Domain: domain,
 		Limit:  10,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/flyteorg/flytestdlib/contextutils"
 
+	"github.com/flyteorg/flyteadmin/auth"
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -46,6 +47,7 @@ type NamedEntityManager struct {
 
 func (m *NamedEntityManager) UpdateNamedEntity(ctx context.Context, request admin.NamedEntityUpdateRequest) (
 	*admin.NamedEntityUpdateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateNamedEntityUpdateRequest(request); err != nil {
 		logger.Debugf(ctx, "invalid request [%+v]: %v", request, err)
 		return nil, err
@@ -163,6 +165,55 @@ func (m *NamedEntityManager) ListNamedEntities(ctx context.Context, request admi
 
 }
 
+// Entity types searched when a search request doesn't ask for specific ones.
+var defaultSearchResourceTypes = []core.ResourceType{
+	core.ResourceType_WORKFLOW,
+	core.ResourceType_TASK,
+	core.ResourceType_LAUNCH_PLAN,
+}
+
+func (m *NamedEntityManager) SearchNamedEntities(ctx context.Context, request interfaces.SearchNamedEntitiesRequest) (
+	*interfaces.SearchNamedEntitiesResponse, error) {
+	if err := validation.ValidateSearchNamedEntitiesRequest(request); err != nil {
+		logger.Debugf(ctx, "invalid request [%+v]: %v", request, err)
+		return nil, err
+	}
+	resourceTypes := request.ResourceTypes
+	if len(resourceTypes) == 0 {
+		resourceTypes = defaultSearchResourceTypes
+	}
+	offset, err := validation.ValidateToken(request.Token)
+	if err != nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid pagination token %s for SearchNamedEntities", request.Token)
+	}
+	// Callers who can't read every project only find the entities of the projects they can read.
+	projects, limited := auth.AuthorizedProjectsFromContext(ctx)
+	if limited && len(projects) == 0 {
+		return &interfaces.SearchNamedEntitiesResponse{}, nil
+	}
+	output, err := m.db.NamedEntityRepo().Search(ctx, repoInterfaces.SearchNamedEntitiesInput{
+		Query:         strings.TrimSpace(request.Query),
+		ResourceTypes: resourceTypes,
+		Projects:      projects,
+		Limit:         int(request.Limit),
+		Offset:        offset,
+	})
+	if err != nil {
+		logger.Debugf(ctx, "Failed to search named entities for query %s with err %v", request.Query, err)
+		return nil, err
+	}
+
+	var token string
+	if len(output.Entities) == int(request.Limit) {
+		token = strconv.Itoa(offset + len(output.Entities))
+	}
+	return &interfaces.SearchNamedEntitiesResponse{
+		Entities: transformers.FromNamedEntityModels(output.Entities),
+		Token:    token,
+	}, nil
+}
+
 func NewNamedEntityManager(
 	db repositories.RepositoryInterface,
 	config runtimeInterfaces.Configuration,
@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/flyteorg/flyteadmin/auth"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
@@ -159,3 +161,100 @@ func TestNamedEntityManager_Update_BadRequest(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, response)
 }
+
+func TestNamedEntityManager_Search(t *testing.T) {
+	repository := getMockRepositoryForNETest()
+	manager := NewNamedEntityManager(repository, getMockConfigForNETest(), mockScope.NewTestScope())
+
+	// The repository ranks matches, names starting with the query ahead of those merely containing it.
+	ranked := []models.NamedEntity{
+		{NamedEntityKey: models.NamedEntityKey{
+			ResourceType: core.ResourceType_WORKFLOW, Project: "p2", Domain: domain, Name: "train_model"}},
+		{NamedEntityKey: models.NamedEntityKey{
+			ResourceType: core.ResourceType_TASK, Project: "p1", Domain: domain, Name: "pretrain"}},
+	}
+	var searchInput interfaces.SearchNamedEntitiesInput
+	repository.NamedEntityRepo().(*repositoryMocks.MockNamedEntityRepo).SetSearchCallback(
+		func(input interfaces.SearchNamedEntitiesInput) (interfaces.NamedEntityCollectionOutput, error) {
+			searchInput = input
+			return interfaces.NamedEntityCollectionOutput{Entities: ranked}, nil
+		})
+
+	response, err := manager.SearchNamedEntities(context.Background(), managerInterfaces.SearchNamedEntitiesRequest{
+		Query: " train ",
+		Limit: 2,
+		Token: "4",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, interfaces.SearchNamedEntitiesInput{
+		Query: "train",
+		ResourceTypes: []core.ResourceType{
+			core.ResourceType_WORKFLOW, core.ResourceType_TASK, core.ResourceType_LAUNCH_PLAN,
+		},
+		Limit:  2,
+		Offset: 4,
+	}, searchInput)
+	assert.Len(t, response.Entities, 2)
+	assert.Equal(t, "train_model", response.Entities[0].Id.Name)
+	assert.Equal(t, "p2", response.Entities[0].Id.Project)
+	assert.Equal(t, core.ResourceType_TASK, response.Entities[1].ResourceType)
+	assert.Equal(t, "pretrain", response.Entities[1].Id.Name)
+	// A full page may be followed by more matches.
+	assert.Equal(t, "6", response.Token)
+
+	response, err = manager.SearchNamedEntities(context.Background(), managerInterfaces.SearchNamedEntitiesRequest{
+		Query:         "train",
+		ResourceTypes: []core.ResourceType{core.ResourceType_TASK},
+		Limit:         3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []core.ResourceType{core.ResourceType_TASK}, searchInput.ResourceTypes)
+	assert.Len(t, response.Entities, 2)
+	assert.Empty(t, response.Token)
+}
+
+func TestNamedEntityManager_Search_AuthorizedProjects(t *testing.T) {
+	repository := getMockRepositoryForNETest()
+	manager := NewNamedEntityManager(repository, getMockConfigForNETest(), mockScope.NewTestScope())
+	var searchInput *interfaces.SearchNamedEntitiesInput
+	repository.NamedEntityRepo().(*repositoryMocks.MockNamedEntityRepo).SetSearchCallback(
+		func(input interfaces.SearchNamedEntitiesInput) (interfaces.NamedEntityCollectionOutput, error) {
+			searchInput = &input
+			return interfaces.NamedEntityCollectionOutput{}, nil
+		})
+	request := managerInterfaces.SearchNamedEntitiesRequest{
+		Query: "train",
+		Limit: 2,
+	}
+
+	ctx := context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{"p1", "p2"})
+	_, err := manager.SearchNamedEntities(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, searchInput.Projects)
+
+	// Callers who can't read any project find nothing.
+	searchInput = nil
+	ctx = context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{})
+	response, err := manager.SearchNamedEntities(ctx, request)
+	assert.NoError(t, err)
+	assert.Empty(t, response.Entities)
+	assert.Nil(t, searchInput)
+}
+
+func TestNamedEntityManager_Search_BadRequest(t *testing.T) {
+	repository := getMockRepositoryForNETest()
+	manager := NewNamedEntityManager(repository, getMockConfigForNETest(), mockScope.NewTestScope())
+
+	_, err := manager.SearchNamedEntities(context.Background(), managerInterfaces.SearchNamedEntitiesRequest{
+		Query: "",
+		Limit: 2,
+	})
+	assert.Error(t, err)
+
+	_, err = manager.SearchNamedEntities(context.Background(), managerInterfaces.SearchNamedEntitiesRequest{
+		Query: "train",
+		Limit: 2,
+		Token: "bad",
+	})
+	assert.Error(t, err)
+}
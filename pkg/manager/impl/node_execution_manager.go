@@ -2,7 +2,7 @@ package impl
 
 import (
 	"context"
-	"strconv"
+	"sync"
 
 	eventWriter "github.com/flyteorg/flyteadmin/pkg/async/events/interfaces"
 
@@ -39,16 +39,18 @@ import (
 )
 
 type nodeExecutionMetrics struct {
-	Scope                      promutils.Scope
-	ActiveNodeExecutions       prometheus.Gauge
-	NodeExecutionsCreated      prometheus.Counter
-	NodeExecutionsTerminated   prometheus.Counter
-	NodeExecutionEventsCreated prometheus.Counter
-	MissingWorkflowExecution   prometheus.Counter
-	ClosureSizeBytes           prometheus.Summary
-	NodeExecutionInputBytes    prometheus.Summary
-	NodeExecutionOutputBytes   prometheus.Summary
-	PublishEventError          prometheus.Counter
+	Scope                        promutils.Scope
+	ActiveNodeExecutions         prometheus.Gauge
+	NodeExecutionsCreated        prometheus.Counter
+	NodeExecutionsTerminated     prometheus.Counter
+	NodeExecutionEventsCreated   prometheus.Counter
+	MissingWorkflowExecution     prometheus.Counter
+	ClosureSizeBytes             prometheus.Summary
+	NodeExecutionInputBytes      prometheus.Summary
+	NodeExecutionOutputBytes     prometheus.Summary
+	PublishEventError            prometheus.Counter
+	DuplicateNodeExecutionEvents prometheus.Counter
+	StaleNodeExecutionEvents     prometheus.Counter
 }
 
 type NodeExecutionManager struct {
@@ -131,15 +133,27 @@ func (m *NodeExecutionManager) updateNodeExecutionWithEvent(
 	dynamicWorkflowRemoteClosureReference string) (updateNodeExecutionStatus, error) {
 	// If we have an existing execution, check if the phase change is valid
 	nodeExecPhase := core.NodeExecution_Phase(core.NodeExecution_Phase_value[nodeExecutionModel.Phase])
-	if nodeExecPhase == request.Event.Phase {
+	eventOrder := transformers.GetNodeExecutionEventOrder(*nodeExecutionModel, *request)
+	if eventOrder == transformers.EventDuplicate {
+		m.metrics.DuplicateNodeExecutionEvents.Inc()
 		logger.Debugf(ctx, "This phase was already recorded %v for %+v", nodeExecPhase.String(), request.Event.Id)
 		return updateFailed, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
 			"This phase was already recorded %v for %+v", nodeExecPhase.String(), request.Event.Id)
-	} else if common.IsNodeExecutionTerminal(nodeExecPhase) {
+	}
+	if eventOrder == transformers.EventStale {
+		m.metrics.StaleNodeExecutionEvents.Inc()
+	}
+	if common.IsNodeExecutionTerminal(nodeExecPhase) {
 		// Cannot go from a terminal state to anything else
 		logger.Warnf(ctx, "Invalid phase change from %v to %v for node execution %v",
 			nodeExecPhase.String(), request.Event.Phase.String(), request.Event.Id)
 		return alreadyInTerminalStatus, nil
+	} else if eventOrder == transformers.EventStale {
+		logger.Debugf(ctx, "Rejecting stale event moving node execution %v from %v to %v",
+			request.Event.Id, nodeExecPhase.String(), request.Event.Phase.String())
+		return updateFailed, errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"Cannot go from %v to %v for node execution %+v", nodeExecPhase.String(), request.Event.Phase.String(),
+			request.Event.Id)
 	}
 
 	// if this node execution kicked off a workflow, validate that the execution exists
@@ -207,6 +221,7 @@ func (m *NodeExecutionManager) uploadDynamicWorkflowClosure(
 
 func (m *NodeExecutionManager) CreateNodeEvent(ctx context.Context, request admin.NodeExecutionEventRequest) (
 	*admin.NodeExecutionEventResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateNodeExecutionEventRequest(&request, m.config.ApplicationConfiguration().GetRemoteDataConfig().MaxSizeInBytes); err != nil {
 		logger.Debugf(ctx, "CreateNodeEvent called with invalid identifier [%+v]: %v", request.Event.Id, err)
 	}
@@ -319,21 +334,42 @@ func (m *NodeExecutionManager) listNodeExecutions(
 	ctx context.Context, identifierFilters []common.InlineFilter,
 	requestFilters string, limit uint32, requestToken string, sortBy *admin.Sort, mapFilters []common.MapFilter) (
 	*admin.NodeExecutionList, error) {
+	nodeExecutionModels, token, err := m.listNodeExecutionModels(
+		ctx, identifierFilters, requestFilters, limit, requestToken, sortBy, mapFilters)
+	if err != nil {
+		return nil, err
+	}
+	nodeExecutionList, err := transformers.FromNodeExecutionModels(nodeExecutionModels)
+	if err != nil {
+		logger.Debugf(ctx, "failed to transform node execution models for request with err: %v", err)
+		return nil, err
+	}
+
+	return &admin.NodeExecutionList{
+		NodeExecutions: nodeExecutionList,
+		Token:          token,
+	}, nil
+}
 
+// Returns a page of node executions along with the token of the next page.
+func (m *NodeExecutionManager) listNodeExecutionModels(
+	ctx context.Context, identifierFilters []common.InlineFilter,
+	requestFilters string, limit uint32, requestToken string, sortBy *admin.Sort, mapFilters []common.MapFilter) (
+	[]models.NodeExecution, string, error) {
 	filters, err := util.AddRequestFilters(requestFilters, common.NodeExecution, identifierFilters)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	var sortParameter common.SortParameter
 	if sortBy != nil {
 		sortParameter, err = common.NewSortParameter(*sortBy)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
-	offset, err := validation.ValidateToken(requestToken)
+	offset, keysetCursor, err := validation.ValidatePaginationToken(requestToken, sortBy)
 	if err != nil {
-		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+		return nil, "", errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 			"invalid pagination token %s for ListNodeExecutions", requestToken)
 	}
 	listInput := repoInterfaces.ListResourceInput{
@@ -341,29 +377,52 @@ func (m *NodeExecutionManager) listNodeExecutions(
 		Offset:        offset,
 		InlineFilters: filters,
 		SortParameter: sortParameter,
+		KeysetCursor:  keysetCursor,
 	}
 
 	listInput.MapFilters = mapFilters
 	output, err := m.db.NodeExecutionRepo().List(ctx, listInput)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to list node executions for request with err %v", err)
-		return nil, err
+		return nil, "", err
 	}
 
-	var token string
-	if len(output.NodeExecutions) == int(limit) {
-		token = strconv.Itoa(offset + len(output.NodeExecutions))
+	var lastNodeExecution models.BaseModel
+	if len(output.NodeExecutions) > 0 {
+		lastNodeExecution = output.NodeExecutions[len(output.NodeExecutions)-1].BaseModel
 	}
-	nodeExecutionList, err := transformers.FromNodeExecutionModels(output.NodeExecutions)
+	token, err := util.GetNextPageToken(sortBy, offset, len(output.NodeExecutions), limit, lastNodeExecution)
 	if err != nil {
-		logger.Debugf(ctx, "failed to transform node execution models for request with err: %v", err)
-		return nil, err
+		return nil, "", err
 	}
+	return output.NodeExecutions, token, nil
+}
 
-	return &admin.NodeExecutionList{
-		NodeExecutions: nodeExecutionList,
-		Token:          token,
-	}, nil
+// Returns the filters which select the children of the parent node execution of the execution, or its top level node
+// executions when there's no parent.
+func (m *NodeExecutionManager) getNodeExecutionListFilters(ctx context.Context,
+	executionID *core.WorkflowExecutionIdentifier, uniqueParentID string) (
+	[]common.InlineFilter, []common.MapFilter, error) {
+	identifierFilters, err := util.GetWorkflowExecutionIdentifierFilters(ctx, *executionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uniqueParentID == "" {
+		return identifierFilters, []common.MapFilter{isParent}, nil
+	}
+	parentNodeExecution, err := util.GetNodeExecutionModel(ctx, m.db, &core.NodeExecutionIdentifier{
+		ExecutionId: executionID,
+		NodeId:      uniqueParentID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	parentIDFilter, err := common.NewSingleValueFilter(
+		common.NodeExecution, common.Equal, shared.ParentID, parentNodeExecution.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(identifierFilters, parentIDFilter), nil, nil
 }
 
 func (m *NodeExecutionManager) ListNodeExecutions(
@@ -374,32 +433,95 @@ func (m *NodeExecutionManager) ListNodeExecutions(
 	}
 	ctx = getExecutionContext(ctx, request.WorkflowExecutionId)
 
-	identifierFilters, err := util.GetWorkflowExecutionIdentifierFilters(ctx, *request.WorkflowExecutionId)
+	identifierFilters, mapFilters, err := m.getNodeExecutionListFilters(
+		ctx, request.WorkflowExecutionId, request.UniqueParentId)
 	if err != nil {
 		return nil, err
 	}
-	var mapFilters []common.MapFilter
-	if request.UniqueParentId != "" {
-		parentNodeExecution, err := util.GetNodeExecutionModel(ctx, m.db, &core.NodeExecutionIdentifier{
-			ExecutionId: request.WorkflowExecutionId,
-			NodeId:      request.UniqueParentId,
-		})
+	return m.listNodeExecutions(
+		ctx, identifierFilters, request.Filters, request.Limit, request.Token, request.SortBy, mapFilters)
+}
+
+func (m *NodeExecutionManager) ListNodeExecutionTree(
+	ctx context.Context, request interfaces.NodeExecutionTreeListRequest) (*interfaces.NodeExecutionTreeList, error) {
+	maxDepth := m.config.ApplicationConfiguration().GetTopLevelConfig().GetMaxNodeExecutionTreeDepth()
+	if err := validation.ValidateNodeExecutionTreeListRequest(request, maxDepth); err != nil {
+		return nil, err
+	}
+	depth := request.Depth
+	if depth == 0 {
+		depth = maxDepth
+	}
+	ctx = getExecutionContext(ctx, request.WorkflowExecutionId)
+
+	identifierFilters, mapFilters, err := m.getNodeExecutionListFilters(
+		ctx, request.WorkflowExecutionId, request.UniqueParentId)
+	if err != nil {
+		return nil, err
+	}
+	page, token, err := m.listNodeExecutionModels(
+		ctx, identifierFilters, request.Filters, request.Limit, request.Token, request.SortBy, mapFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Descendants are fetched a level at a time, each level with a single query for the children of the previous one.
+	levels := [][]models.NodeExecution{page}
+	for len(levels) <= depth {
+		var parentIDs []uint
+		for _, parent := range levels[len(levels)-1] {
+			if len(parent.ChildNodeExecutions) > 0 {
+				parentIDs = append(parentIDs, parent.ID)
+			}
+		}
+		if len(parentIDs) == 0 {
+			break
+		}
+		children, err := m.db.NodeExecutionRepo().ListChildren(ctx, *request.WorkflowExecutionId, parentIDs)
 		if err != nil {
+			logger.Debugf(ctx, "Failed to list the children of node executions %v with err %v", parentIDs, err)
 			return nil, err
 		}
-		parentIDFilter, err := common.NewSingleValueFilter(
-			common.NodeExecution, common.Equal, shared.ParentID, parentNodeExecution.ID)
+		levels = append(levels, children)
+	}
+
+	childrenByParentID := make(map[uint][]*models.NodeExecution)
+	for _, level := range levels[1:] {
+		for i := range level {
+			parentID := *level[i].ParentID
+			childrenByParentID[parentID] = append(childrenByParentID[parentID], &level[i])
+		}
+	}
+	tree := &interfaces.NodeExecutionTreeList{
+		Token: token,
+	}
+	var addNodeExecution func(nodeExecutionModel *models.NodeExecution, parentNodeID string, nodeDepth int) error
+	addNodeExecution = func(nodeExecutionModel *models.NodeExecution, parentNodeID string, nodeDepth int) error {
+		nodeExecution, err := transformers.FromNodeExecutionModel(*nodeExecutionModel)
 		if err != nil {
-			return nil, err
+			logger.Debugf(ctx, "failed to transform node execution model [%+v] with err: %v",
+				nodeExecutionModel.NodeExecutionKey, err)
+			return err
 		}
-		identifierFilters = append(identifierFilters, parentIDFilter)
-	} else {
-		mapFilters = []common.MapFilter{
-			isParent,
+		tree.NodeExecutions = append(tree.NodeExecutions, interfaces.NodeExecutionTreeNode{
+			NodeExecution:         nodeExecution,
+			ParentNodeId:          parentNodeID,
+			Depth:                 nodeDepth,
+			HasUnexpandedChildren: nodeDepth == depth && len(nodeExecutionModel.ChildNodeExecutions) > 0,
+		})
+		for _, child := range childrenByParentID[nodeExecutionModel.ID] {
+			if err := addNodeExecution(child, nodeExecutionModel.NodeID, nodeDepth+1); err != nil {
+				return err
+			}
 		}
+		return nil
 	}
-	return m.listNodeExecutions(
-		ctx, identifierFilters, request.Filters, request.Limit, request.Token, request.SortBy, mapFilters)
+	for i := range page {
+		if err := addNodeExecution(&page[i], request.UniqueParentId, 0); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
 }
 
 // Filters on node executions matching the execution parameters (execution project, domain, and name) as well as the
@@ -443,10 +565,16 @@ func (m *NodeExecutionManager) GetNodeExecutionData(
 			request.Id, err)
 		return nil, err
 	}
+	return m.getNodeExecutionData(ctx, nodeExecutionModel)
+}
 
+// Reads the inputs, outputs and dynamic workflow of the node execution from storage.
+func (m *NodeExecutionManager) getNodeExecutionData(ctx context.Context, nodeExecutionModel *models.NodeExecution) (
+	*admin.NodeExecutionGetDataResponse, error) {
 	nodeExecution, err := transformers.FromNodeExecutionModel(*nodeExecutionModel)
 	if err != nil {
-		logger.Debugf(ctx, "failed to transform node execution model [%+v] when fetching data: %v", request.Id, err)
+		logger.Debugf(ctx, "failed to transform node execution model [%+v] when fetching data: %v",
+			nodeExecutionModel.NodeExecutionKey, err)
 		return nil, err
 	}
 
@@ -499,6 +627,66 @@ func (m *NodeExecutionManager) GetNodeExecutionData(
 	return response, nil
 }
 
+func (m *NodeExecutionManager) GetNodeExecutionDataBatch(
+	ctx context.Context, request interfaces.NodeExecutionGetDataBatchRequest) (
+	*interfaces.NodeExecutionGetDataBatchResponse, error) {
+	batchConfig := m.config.ApplicationConfiguration().GetTopLevelConfig().GetNodeExecutionDataBatchConfig()
+	if err := validation.ValidateNodeExecutionGetDataBatchRequest(request, batchConfig.MaxBatchSize); err != nil {
+		logger.Debugf(ctx, "GetNodeExecutionDataBatch request failed validation with err: %v", err)
+		return nil, err
+	}
+	executionID := request.Ids[0].ExecutionId
+	ctx = getExecutionContext(ctx, executionID)
+	nodeIDs := make([]string, len(request.Ids))
+	for i, id := range request.Ids {
+		nodeIDs[i] = id.NodeId
+	}
+	nodeExecutionModels, err := m.db.NodeExecutionRepo().GetBatch(ctx, *executionID, nodeIDs)
+	if err != nil {
+		logger.Debugf(ctx, "Failed to get node executions of execution [%+v] with err %v", executionID, err)
+		return nil, err
+	}
+	nodeExecutionsByID := make(map[string]*models.NodeExecution, len(nodeExecutionModels))
+	for i := range nodeExecutionModels {
+		nodeExecutionsByID[nodeExecutionModels[i].NodeID] = &nodeExecutionModels[i]
+	}
+
+	response := &interfaces.NodeExecutionGetDataBatchResponse{
+		Results: make([]interfaces.NodeExecutionDataResult, len(request.Ids)),
+	}
+	concurrency := batchConfig.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i, id := range request.Ids {
+		result := &response.Results[i]
+		result.Id = id
+		nodeExecutionModel, ok := nodeExecutionsByID[id.NodeId]
+		if !ok {
+			result.Error = errors.NewFlyteAdminErrorf(codes.NotFound, "missing node execution %v", id)
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result.Data, result.Error = m.getNodeExecutionData(getNodeExecutionContext(ctx, result.Id),
+				nodeExecutionModel)
+			if result.Error != nil {
+				logger.Debugf(ctx, "Failed to get data of node execution [%+v] in batch with err %v",
+					result.Id, result.Error)
+			}
+		}()
+	}
+	wg.Wait()
+	return response, nil
+}
+
 func NewNodeExecutionManager(db repositories.RepositoryInterface, config runtimeInterfaces.Configuration,
 	storagePrefix []string, storageClient *storage.DataStore, scope promutils.Scope, urlData dataInterfaces.RemoteURLInterface,
 	eventPublisher notificationInterfaces.Publisher, eventWriter eventWriter.NodeExecutionEventWriter) interfaces.NodeExecutionInterface {
@@ -522,6 +710,10 @@ func NewNodeExecutionManager(db repositories.RepositoryInterface, config runtime
 			"size in bytes of serialized node execution outputs"),
 		PublishEventError: scope.MustNewCounter("publish_event_error",
 			"overall count of publish event errors when invoking publish()"),
+		DuplicateNodeExecutionEvents: scope.MustNewCounter("duplicate_node_execution_events",
+			"count of rejected NodeExecutionEventRequest repeating the recorded phase"),
+		StaleNodeExecutionEvents: scope.MustNewCounter("stale_node_execution_events",
+			"count of rejected NodeExecutionEventRequest preceding the recorded phase"),
 	}
 	return &NodeExecutionManager{
 		db:     db,
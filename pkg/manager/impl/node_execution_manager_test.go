@@ -16,16 +16,20 @@ import (
 	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
 	dataMocks "github.com/flyteorg/flyteadmin/pkg/data/mocks"
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 )
@@ -360,6 +364,38 @@ func TestCreateNodeEvent_UpdateDuplicateEventError(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestCreateNodeEvent_UpdateStaleEventError(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	addGetExecutionCallback(t, repository)
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.NodeExecutionResource) (models.NodeExecution, error) {
+			return models.NodeExecution{
+				NodeExecutionKey: models.NodeExecutionKey{
+					NodeID: "node id",
+					ExecutionKey: models.ExecutionKey{
+						Project: "project",
+						Domain:  "domain",
+						Name:    "name",
+					},
+				},
+				Phase:     core.NodeExecution_FAILING.String(),
+				InputURI:  "input uri",
+				StartedAt: &occurredAt,
+			}, nil
+		})
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetUpdateCallback(
+		func(ctx context.Context, nodeExecution *models.NodeExecution) error {
+			assert.Fail(t, "stale events must not be recorded")
+			return nil
+		})
+	nodeExecManager := NewNodeExecutionManager(repository, getMockExecutionsConfigProvider(), make([]string, 0), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockNodeExecutionRemoteURL, nil, &eventWriterMocks.NodeExecutionEventWriter{})
+	resp, err := nodeExecManager.CreateNodeEvent(context.Background(), request)
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(nodeExecManager.(*NodeExecutionManager).metrics.StaleNodeExecutionEvents))
+}
+
 func TestCreateNodeEvent_FirstEventIsTerminal(t *testing.T) {
 	repository := repositoryMocks.NewMockRepository()
 	addGetExecutionCallback(t, repository)
@@ -1087,3 +1123,310 @@ func TestGetNodeExecutionData(t *testing.T) {
 		},
 	}, dataResponse))
 }
+
+func getNodeExecutionManagerForBatchTest(repository repositories.RepositoryInterface) managerInterfaces.NodeExecutionInterface {
+	mockConfig := getMockExecutionsConfigProvider()
+	topLevelConfig := *mockConfig.ApplicationConfiguration().GetTopLevelConfig()
+	topLevelConfig.NodeExecutionDataBatch = runtimeInterfaces.NodeExecutionDataBatchConfig{
+		MaxBatchSize: 3,
+		Concurrency:  2,
+	}
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(topLevelConfig)
+	mockNodeExecutionRemoteURL := dataMocks.NewMockRemoteURL()
+	mockNodeExecutionRemoteURL.(*dataMocks.MockRemoteURL).GetCallback = func(ctx context.Context, uri string) (admin.UrlBlob, error) {
+		return admin.UrlBlob{
+			Url:   uri,
+			Bytes: 100,
+		}, nil
+	}
+	mockStorage := commonMocks.GetMockStorageClient()
+	mockStorage.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb = func(
+		ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+		if reference.String() != "a inputs" {
+			return errors.New("unreadable data")
+		}
+		proto.Merge(msg, &core.LiteralMap{
+			Literals: map[string]*core.Literal{
+				"foo": testutils.MakeStringLiteral("foo-value-1"),
+			},
+		})
+		return nil
+	}
+	return NewNodeExecutionManager(repository, mockConfig, make([]string, 0), mockStorage, mockScope.NewTestScope(), mockNodeExecutionRemoteURL, nil, &eventWriterMocks.NodeExecutionEventWriter{})
+}
+
+func getNodeExecutionIdentifierForBatchTest(nodeID string) *core.NodeExecutionIdentifier {
+	return &core.NodeExecutionIdentifier{
+		NodeId: nodeID,
+		ExecutionId: &core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+	}
+}
+
+func TestGetNodeExecutionDataBatch(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	closureBytes, _ := proto.Marshal(&admin.NodeExecutionClosure{
+		Phase: core.NodeExecution_RUNNING,
+	})
+	var batchReads int
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetGetBatchCallback(
+		func(ctx context.Context, executionID core.WorkflowExecutionIdentifier, nodeIDs []string) (
+			[]models.NodeExecution, error) {
+			batchReads++
+			assert.Equal(t, "name", executionID.Name)
+			assert.Equal(t, []string{"a", "b", "c"}, nodeIDs)
+			var nodeExecutions []models.NodeExecution
+			// Node execution c doesn't exist.
+			for _, nodeID := range []string{"b", "a"} {
+				nodeExecutions = append(nodeExecutions, models.NodeExecution{
+					NodeExecutionKey: models.NodeExecutionKey{
+						NodeID: nodeID,
+						ExecutionKey: models.ExecutionKey{
+							Project: "project",
+							Domain:  "domain",
+							Name:    "name",
+						},
+					},
+					Phase:    core.NodeExecution_RUNNING.String(),
+					InputURI: nodeID + " inputs",
+					Closure:  closureBytes,
+				})
+			}
+			// The dynamic workflow of node execution b can't be read.
+			nodeExecutions[0].DynamicWorkflowRemoteClosureReference = "b dynamic workflow"
+			return nodeExecutions, nil
+		})
+	nodeExecManager := getNodeExecutionManagerForBatchTest(repository)
+
+	response, err := nodeExecManager.GetNodeExecutionDataBatch(context.Background(),
+		managerInterfaces.NodeExecutionGetDataBatchRequest{
+			Ids: []*core.NodeExecutionIdentifier{
+				getNodeExecutionIdentifierForBatchTest("a"),
+				getNodeExecutionIdentifierForBatchTest("b"),
+				getNodeExecutionIdentifierForBatchTest("c"),
+			},
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, batchReads)
+	assert.Len(t, response.Results, 3)
+
+	assert.Equal(t, "a", response.Results[0].Id.NodeId)
+	assert.NoError(t, response.Results[0].Error)
+	assert.Equal(t, "foo-value-1",
+		response.Results[0].Data.FullInputs.Literals["foo"].GetScalar().GetPrimitive().GetStringValue())
+
+	assert.Equal(t, "b", response.Results[1].Id.NodeId)
+	assert.Nil(t, response.Results[1].Data)
+	assert.Equal(t, codes.Internal, response.Results[1].Error.(flyteAdminErrors.FlyteAdminError).Code())
+
+	assert.Equal(t, "c", response.Results[2].Id.NodeId)
+	assert.Nil(t, response.Results[2].Data)
+	assert.Equal(t, codes.NotFound, response.Results[2].Error.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestGetNodeExecutionDataBatch_InvalidBatch(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetGetBatchCallback(
+		func(ctx context.Context, executionID core.WorkflowExecutionIdentifier, nodeIDs []string) (
+			[]models.NodeExecution, error) {
+			assert.Fail(t, "an invalid batch shouldn't be read")
+			return nil, nil
+		})
+	nodeExecManager := getNodeExecutionManagerForBatchTest(repository)
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := nodeExecManager.GetNodeExecutionDataBatch(context.Background(),
+			managerInterfaces.NodeExecutionGetDataBatchRequest{
+				Ids: []*core.NodeExecutionIdentifier{
+					getNodeExecutionIdentifierForBatchTest("a"),
+					getNodeExecutionIdentifierForBatchTest("b"),
+					getNodeExecutionIdentifierForBatchTest("c"),
+					getNodeExecutionIdentifierForBatchTest("d"),
+				},
+			})
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+	t.Run("several executions", func(t *testing.T) {
+		otherExecution := getNodeExecutionIdentifierForBatchTest("b")
+		otherExecution.ExecutionId.Name = "other"
+		_, err := nodeExecManager.GetNodeExecutionDataBatch(context.Background(),
+			managerInterfaces.NodeExecutionGetDataBatchRequest{
+				Ids: []*core.NodeExecutionIdentifier{
+					getNodeExecutionIdentifierForBatchTest("a"),
+					otherExecution,
+				},
+			})
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := nodeExecManager.GetNodeExecutionDataBatch(context.Background(),
+			managerInterfaces.NodeExecutionGetDataBatchRequest{})
+		assert.Error(t, err)
+	})
+}
+
+// A dynamic node n0 which yields a dynamic node n0-0-dn0 and a task node n0-0-dn1, along with a task node n1. The
+// dynamic node n0-0-dn0 yields the task node n0-0-dn0-0-dn0 in turn.
+func getNodeExecutionTreeRepositoryForTest(t *testing.T, childrenListed *[][]uint) repositories.RepositoryInterface {
+	getNodeExecution := func(id uint, nodeID string, parentID *uint, children ...string) models.NodeExecution {
+		nodeExecution := models.NodeExecution{
+			BaseModel: models.BaseModel{
+				ID: id,
+			},
+			NodeExecutionKey: models.NodeExecutionKey{
+				NodeID: nodeID,
+				ExecutionKey: models.ExecutionKey{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "name",
+				},
+			},
+			Phase:    core.NodeExecution_RUNNING.String(),
+			ParentID: parentID,
+		}
+		for _, child := range children {
+			nodeExecution.ChildNodeExecutions = append(nodeExecution.ChildNodeExecutions, models.NodeExecution{
+				NodeExecutionKey: models.NodeExecutionKey{
+					NodeID: child,
+				},
+			})
+		}
+		return nodeExecution
+	}
+	n0, n0dn0 := uint(1), uint(3)
+	topLevel := []models.NodeExecution{
+		getNodeExecution(n0, "n0", nil, "n0-0-dn0", "n0-0-dn1"),
+		getNodeExecution(2, "n1", nil),
+	}
+	children := map[uint][]models.NodeExecution{
+		n0: {
+			getNodeExecution(n0dn0, "n0-0-dn0", &n0, "n0-0-dn0-0-dn0"),
+			getNodeExecution(4, "n0-0-dn1", &n0),
+		},
+		n0dn0: {
+			getNodeExecution(5, "n0-0-dn0-0-dn0", &n0dn0),
+		},
+	}
+
+	repository := repositoryMocks.NewMockRepository()
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.NodeExecutionResource) (models.NodeExecution, error) {
+			assert.Equal(t, "n0", input.NodeExecutionIdentifier.NodeId)
+			return topLevel[0], nil
+		})
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetListCallback(
+		func(ctx context.Context, input interfaces.ListResourceInput) (interfaces.NodeExecutionCollectionOutput, error) {
+			if len(input.MapFilters) > 0 {
+				return interfaces.NodeExecutionCollectionOutput{
+					NodeExecutions: topLevel,
+				}, nil
+			}
+			// The children of n0 are listed when it's the parent.
+			return interfaces.NodeExecutionCollectionOutput{
+				NodeExecutions: children[n0],
+			}, nil
+		})
+	repository.NodeExecutionRepo().(*repositoryMocks.MockNodeExecutionRepo).SetListChildrenCallback(
+		func(ctx context.Context, executionID core.WorkflowExecutionIdentifier, parentIDs []uint) (
+			[]models.NodeExecution, error) {
+			assert.Equal(t, "name", executionID.Name)
+			*childrenListed = append(*childrenListed, parentIDs)
+			var nodeExecutions []models.NodeExecution
+			for _, parentID := range parentIDs {
+				nodeExecutions = append(nodeExecutions, children[parentID]...)
+			}
+			return nodeExecutions, nil
+		})
+	return repository
+}
+
+func getNodeExecutionManagerForTreeTest(repository repositories.RepositoryInterface) managerInterfaces.NodeExecutionInterface {
+	mockConfig := getMockExecutionsConfigProvider()
+	topLevelConfig := *mockConfig.ApplicationConfiguration().GetTopLevelConfig()
+	topLevelConfig.MaxNodeExecutionTreeDepth = 3
+	mockConfig.ApplicationConfiguration().(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(topLevelConfig)
+	return NewNodeExecutionManager(repository, mockConfig, make([]string, 0), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockNodeExecutionRemoteURL, nil, &eventWriterMocks.NodeExecutionEventWriter{})
+}
+
+type treeNodeForTest struct {
+	nodeID                string
+	parentNodeID          string
+	depth                 int
+	hasUnexpandedChildren bool
+}
+
+func getTreeNodesForTest(tree *managerInterfaces.NodeExecutionTreeList) []treeNodeForTest {
+	var nodes []treeNodeForTest
+	for _, node := range tree.NodeExecutions {
+		nodes = append(nodes, treeNodeForTest{
+			nodeID:                node.NodeExecution.Id.NodeId,
+			parentNodeID:          node.ParentNodeId,
+			depth:                 node.Depth,
+			hasUnexpandedChildren: node.HasUnexpandedChildren,
+		})
+	}
+	return nodes
+}
+
+func TestListNodeExecutionTree(t *testing.T) {
+	request := managerInterfaces.NodeExecutionTreeListRequest{
+		WorkflowExecutionId: &workflowExecutionIdentifier,
+		Limit:               10,
+	}
+
+	t.Run("full tree", func(t *testing.T) {
+		var childrenListed [][]uint
+		nodeExecManager := getNodeExecutionManagerForTreeTest(getNodeExecutionTreeRepositoryForTest(t, &childrenListed))
+		tree, err := nodeExecManager.ListNodeExecutionTree(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, []treeNodeForTest{
+			{nodeID: "n0"},
+			{nodeID: "n0-0-dn0", parentNodeID: "n0", depth: 1},
+			{nodeID: "n0-0-dn0-0-dn0", parentNodeID: "n0-0-dn0", depth: 2},
+			{nodeID: "n0-0-dn1", parentNodeID: "n0", depth: 1},
+			{nodeID: "n1"},
+		}, getTreeNodesForTest(tree))
+		// A single query per level.
+		assert.Equal(t, [][]uint{{1}, {3}}, childrenListed)
+	})
+	t.Run("limited depth", func(t *testing.T) {
+		var childrenListed [][]uint
+		nodeExecManager := getNodeExecutionManagerForTreeTest(getNodeExecutionTreeRepositoryForTest(t, &childrenListed))
+		limitedRequest := request
+		limitedRequest.Depth = 1
+		tree, err := nodeExecManager.ListNodeExecutionTree(context.Background(), limitedRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, []treeNodeForTest{
+			{nodeID: "n0"},
+			{nodeID: "n0-0-dn0", parentNodeID: "n0", depth: 1, hasUnexpandedChildren: true},
+			{nodeID: "n0-0-dn1", parentNodeID: "n0", depth: 1},
+			{nodeID: "n1"},
+		}, getTreeNodesForTest(tree))
+		assert.Equal(t, [][]uint{{1}}, childrenListed)
+	})
+	t.Run("subtree", func(t *testing.T) {
+		var childrenListed [][]uint
+		nodeExecManager := getNodeExecutionManagerForTreeTest(getNodeExecutionTreeRepositoryForTest(t, &childrenListed))
+		subtreeRequest := request
+		subtreeRequest.UniqueParentId = "n0"
+		tree, err := nodeExecManager.ListNodeExecutionTree(context.Background(), subtreeRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, []treeNodeForTest{
+			{nodeID: "n0-0-dn0", parentNodeID: "n0"},
+			{nodeID: "n0-0-dn0-0-dn0", parentNodeID: "n0-0-dn0", depth: 1},
+			{nodeID: "n0-0-dn1", parentNodeID: "n0"},
+		}, getTreeNodesForTest(tree))
+		assert.Equal(t, [][]uint{{3}}, childrenListed)
+	})
+	t.Run("too deep", func(t *testing.T) {
+		var childrenListed [][]uint
+		nodeExecManager := getNodeExecutionManagerForTreeTest(getNodeExecutionTreeRepositoryForTest(t, &childrenListed))
+		deepRequest := request
+		deepRequest.Depth = 4
+		_, err := nodeExecManager.ListNodeExecutionTree(context.Background(), deepRequest)
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	})
+}
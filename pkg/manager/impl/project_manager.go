@@ -29,6 +29,7 @@ var alphabeticalSortParam, _ = common.NewSortParameter(admin.Sort{
 
 func (m *ProjectManager) CreateProject(ctx context.Context, request admin.ProjectRegisterRequest) (
 	*admin.ProjectRegisterResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateProjectRegisterRequest(request); err != nil {
 		return nil, err
 	}
@@ -78,9 +79,14 @@ func (m *ProjectManager) ListProjects(ctx context.Context, request admin.Project
 			"invalid pagination token %s for ListProjects", request.Token)
 	}
 
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = m.config.ApplicationConfiguration().GetTopLevelConfig().GetProjectListDefaultLimit()
+	}
+
 	// And finally, query the database
 	listProjectsInput := repoInterfaces.ListResourceInput{
-		Limit:         int(request.Limit),
+		Limit:         limit,
 		Offset:        offset,
 		InlineFilters: filters,
 		SortParameter: sortParameter,
@@ -92,7 +98,7 @@ func (m *ProjectManager) ListProjects(ctx context.Context, request admin.Project
 	projects := transformers.FromProjectModels(projectModels, m.getDomains())
 
 	var token string
-	if len(projects) == int(request.Limit) {
+	if limit > 0 && len(projects) == limit {
 		token = strconv.Itoa(offset + len(projects))
 	}
 
@@ -103,6 +109,7 @@ func (m *ProjectManager) ListProjects(ctx context.Context, request admin.Project
 }
 
 func (m *ProjectManager) UpdateProject(ctx context.Context, projectUpdate admin.Project) (*admin.ProjectUpdateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	var response admin.ProjectUpdateResponse
 	projectRepo := m.db.ProjectRepo()
 
@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
@@ -105,6 +107,40 @@ func TestListProjects_NoToken_NoLimit(t *testing.T) {
 	testListProjects(admin.ProjectListRequest{}, "", "identifier asc", nil, t)
 }
 
+func TestListProjects_DefaultLimit(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ProjectRepo().(*repositoryMocks.MockProjectRepo).ListProjectsFunction = func(
+		ctx context.Context, input interfaces.ListResourceInput) ([]models.Project, error) {
+		assert.Equal(t, 1, input.Limit)
+		activeState := int32(admin.Project_ACTIVE)
+		return []models.Project{
+			{
+				Identifier: "project",
+				Name:       "project",
+				State:      &activeState,
+			},
+		}, nil
+	}
+	applicationConfig := getMockApplicationConfigForProjectManagerTest()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		ProjectListDefaultLimit: 1,
+	})
+	projectManager := NewProjectManager(repository,
+		runtimeMocks.NewMockConfigurationProvider(applicationConfig, nil, nil, nil, nil, nil))
+	resp, err := projectManager.ListProjects(context.Background(), admin.ProjectListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Projects, 1)
+	assert.Equal(t, "1", resp.Token)
+}
+
+func TestListProjects_InvalidToken(t *testing.T) {
+	projectManager := NewProjectManager(repositoryMocks.NewMockRepository(), mockProjectConfigProvider)
+	_, err := projectManager.ListProjects(context.Background(), admin.ProjectListRequest{
+		Token: "not-a-token",
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
 func TestProjectManager_CreateProject(t *testing.T) {
 	mockRepository := repositoryMocks.NewMockRepository()
 	var createFuncCalled bool
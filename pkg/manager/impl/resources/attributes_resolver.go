@@ -0,0 +1,358 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	workflowengineInterfaces "github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AttributesResolver resolves the matchable attributes executions are created with, falling back on the application
+// config where no level sets any, along with the source of each field. Execution creation and
+// GetEffectiveMatchableAttributes share it, so that the effective attributes reported are those executions get.
+type AttributesResolver struct {
+	resourceManager interfaces.ResourceInterface
+	config          runtimeInterfaces.Configuration
+}
+
+// Cluster resource attributes which set the IAM role and kubernetes service account executions of a project and domain
+// run as when neither the execution request nor its launch plan set them.
+const (
+	DefaultIamRoleAttributeKey        = "defaultIamRole"
+	DefaultServiceAccountAttributeKey = "defaultServiceAccount"
+)
+
+type taskResourceField struct {
+	name string
+	get  func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity
+}
+
+// The task resources, in the order of admin.TaskResourceSpec.
+var taskResourceFields = []taskResourceField{
+	{name: "cpu", get: func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity { return &set.CPU }},
+	{name: "gpu", get: func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity { return &set.GPU }},
+	{name: "memory", get: func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity { return &set.Memory }},
+	{name: "storage", get: func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity { return &set.Storage }},
+	{name: "ephemeral_storage", get: func(set *runtimeInterfaces.TaskResourceSet) *resource.Quantity {
+		return &set.EphemeralStorage
+	}},
+}
+
+// ParseQuantityNoError parses the quantity of a resource, logging values which don't parse and leaving them unset.
+func ParseQuantityNoError(ctx context.Context, ownerID, name, value string) resource.Quantity {
+	if len(value) == 0 {
+		return resource.Quantity{}
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		logger.Infof(ctx, "Failed to parse owner's [%s] resource [%s]'s value [%s] with err: %v", ownerID, name, value, err)
+	}
+	return q
+}
+
+// Parses the quantities of a task resource spec, leaving those which don't parse unset.
+func FromAdminProtoTaskResourceSpec(ctx context.Context, spec *admin.TaskResourceSpec) runtimeInterfaces.TaskResourceSet {
+	return runtimeInterfaces.TaskResourceSet{
+		CPU:              ParseQuantityNoError(ctx, "project", "cpu", spec.GetCpu()),
+		GPU:              ParseQuantityNoError(ctx, "project", "gpu", spec.GetGpu()),
+		Memory:           ParseQuantityNoError(ctx, "project", "memory", spec.GetMemory()),
+		Storage:          ParseQuantityNoError(ctx, "project", "storage", spec.GetStorage()),
+		EphemeralStorage: ParseQuantityNoError(ctx, "project", "ephemeral storage", spec.GetEphemeralStorage()),
+	}
+}
+
+func toAdminProtoTaskResourceSpec(set runtimeInterfaces.TaskResourceSet) *admin.TaskResourceSpec {
+	format := func(quantity resource.Quantity) string {
+		if quantity.IsZero() {
+			return ""
+		}
+		return quantity.String()
+	}
+	return &admin.TaskResourceSpec{
+		Cpu:              format(set.CPU),
+		Gpu:              format(set.GPU),
+		Memory:           format(set.Memory),
+		Storage:          format(set.Storage),
+		EphemeralStorage: format(set.EphemeralStorage),
+	}
+}
+
+// Fills in the platform task resource requests and limits left unset by matchable task resource attributes. A request
+// defaults to its limit and, when configured, a limit defaults to its request. Gpu requests aren't inferred from a gpu
+// limit since every task would otherwise be assigned gpus.
+func DefaultTaskResources(taskResources *workflowengineInterfaces.TaskResources, defaultLimitsFromRequests bool) {
+	defaultQuantity := func(request, limit *resource.Quantity, defaultRequestFromLimit bool) {
+		if request.IsZero() && !limit.IsZero() && defaultRequestFromLimit {
+			*request = limit.DeepCopy()
+		} else if limit.IsZero() && !request.IsZero() && defaultLimitsFromRequests {
+			*limit = request.DeepCopy()
+		}
+	}
+	defaultQuantity(&taskResources.Defaults.CPU, &taskResources.Limits.CPU, true)
+	defaultQuantity(&taskResources.Defaults.Memory, &taskResources.Limits.Memory, true)
+	defaultQuantity(&taskResources.Defaults.Storage, &taskResources.Limits.Storage, true)
+	defaultQuantity(&taskResources.Defaults.EphemeralStorage, &taskResources.Limits.EphemeralStorage, true)
+	defaultQuantity(&taskResources.Defaults.GPU, &taskResources.Limits.GPU, false)
+}
+
+// Returns the sources of the resolved task resources, which are those set at the level apart from the ones
+// DefaultTaskResources filled in from their counterpart.
+func getTaskResourceSources(set, resolved workflowengineInterfaces.TaskResources,
+	level interfaces.ResourceLevel) []interfaces.AttributeSource {
+	var sources []interfaces.AttributeSource
+	addSources := func(name, counterpart string, set, resolved *runtimeInterfaces.TaskResourceSet) {
+		for _, field := range taskResourceFields {
+			if field.get(resolved).IsZero() {
+				continue
+			}
+			source := interfaces.AttributeSource{
+				Field: fmt.Sprintf("%s.%s", name, field.name),
+				Level: level,
+			}
+			if field.get(set).IsZero() {
+				source.DefaultedFrom = fmt.Sprintf("%s.%s", counterpart, field.name)
+			}
+			sources = append(sources, source)
+		}
+	}
+	addSources("defaults", "limits", &set.Defaults, &resolved.Defaults)
+	addSources("limits", "defaults", &set.Limits, &resolved.Limits)
+	return sources
+}
+
+// Resolves the platform task resource requests and limits of executions of the workflow. Attributes of the most
+// specific level replace the task resource config altogether, with the requests and limits they leave unset filled in
+// from their counterparts.
+func (r *AttributesResolver) ResolveTaskResources(ctx context.Context, project, domain, workflow string) (
+	workflowengineInterfaces.TaskResources, []interfaces.AttributeSource, error) {
+	resource, err := r.resourceManager.GetResource(ctx, interfaces.ResourceRequest{
+		Project:      project,
+		Domain:       domain,
+		Workflow:     workflow,
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	})
+	if err != nil {
+		logger.Warningf(ctx, "Failed to fetch override values when assigning task resource default values for "+
+			"[%s/%s/%s]: %v", project, domain, workflow, err)
+	}
+
+	taskResourceConfig := r.config.TaskResourceConfiguration()
+	if resource == nil || resource.Attributes.GetTaskResourceAttributes() == nil {
+		taskResources := workflowengineInterfaces.TaskResources{
+			Defaults: taskResourceConfig.GetDefaults(),
+			Limits:   taskResourceConfig.GetLimits(),
+		}
+		return taskResources, getTaskResourceSources(taskResources, taskResources,
+			interfaces.ResourceLevelApplicationConfig), nil
+	}
+	attributes := resource.Attributes.GetTaskResourceAttributes()
+	set := workflowengineInterfaces.TaskResources{
+		Defaults: FromAdminProtoTaskResourceSpec(ctx, attributes.Defaults),
+		Limits:   FromAdminProtoTaskResourceSpec(ctx, attributes.Limits),
+	}
+	taskResources := set
+	DefaultTaskResources(&taskResources, taskResourceConfig.GetDefaultLimitsFromRequests())
+	// Attributes saved before they were validated on update may still hold requests greater than their limits,
+	// which propeller would otherwise only surface as failing pods.
+	if err := validation.ValidateTaskResourceSet(taskResources.Defaults, taskResources.Limits); err != nil {
+		logger.Infof(ctx, "Invalid task resource attributes for [%s/%s/%s]: %v", project, domain, workflow, err)
+		return workflowengineInterfaces.TaskResources{}, nil, err
+	}
+	return taskResources, getTaskResourceSources(set, taskResources, resource.Level), nil
+}
+
+// Resolves the execution config of executions of the project and domain which neither their request nor their launch
+// plan configure. Attributes of workflows don't apply.
+func (r *AttributesResolver) ResolveExecutionConfig(ctx context.Context, project, domain string) (
+	*admin.WorkflowExecutionConfig, []interfaces.AttributeSource, error) {
+	resource, err := r.ResolveMatchingAttributes(ctx, interfaces.ResourceRequest{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get workflow execution config overrides with error: %v", err)
+		return nil, nil, err
+	}
+	if resource != nil && resource.Attributes.GetWorkflowExecutionConfig() != nil {
+		return resource.Attributes.GetWorkflowExecutionConfig(), []interfaces.AttributeSource{
+			{Field: "max_parallelism", Level: resource.Level},
+		}, nil
+	}
+	// Defaults to one from the application config
+	executionConfig := &admin.WorkflowExecutionConfig{
+		MaxParallelism: r.config.ApplicationConfiguration().GetTopLevelConfig().GetMaxParallelism(),
+	}
+	return executionConfig, []interfaces.AttributeSource{
+		{Field: "max_parallelism", Level: interfaces.ResourceLevelApplicationConfig},
+	}, nil
+}
+
+// Resolves the auth role executions of the project and domain run as when neither their request nor their launch plan
+// set one. The cluster resource attributes of the most specific level take precedence over the application config as
+// long as they set either the IAM role or the service account.
+func (r *AttributesResolver) ResolveDefaultAuthRole(ctx context.Context, project, domain string) (
+	*admin.AuthRole, error) {
+	resource, err := r.ResolveMatchingAttributes(ctx, interfaces.ResourceRequest{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the default auth role of project [%s] and domain [%s] with error: %v",
+			project, domain, err)
+		return nil, err
+	}
+	if resource != nil {
+		attributes := resource.Attributes.GetClusterResourceAttributes().GetAttributes()
+		iamRole, serviceAccount := attributes[DefaultIamRoleAttributeKey], attributes[DefaultServiceAccountAttributeKey]
+		if len(iamRole) > 0 || len(serviceAccount) > 0 {
+			return &admin.AuthRole{
+				AssumableIamRole:         iamRole,
+				KubernetesServiceAccount: serviceAccount,
+			}, nil
+		}
+	}
+	topLevelConfig := r.config.ApplicationConfiguration().GetTopLevelConfig()
+	return &admin.AuthRole{
+		AssumableIamRole:         topLevelConfig.GetDefaultIamRole(),
+		KubernetesServiceAccount: topLevelConfig.GetDefaultServiceAccount(),
+	}, nil
+}
+
+// Resolves the environment variables executions of the project and domain set for their tasks, which are those the
+// cluster resource attributes of the most specific level set with env.flyte.org/<NAME> keys. Executions and their launch
+// plans take precedence over these.
+func (r *AttributesResolver) ResolveExecutionEnvs(ctx context.Context, project, domain string) (
+	map[string]string, error) {
+	resource, err := r.ResolveMatchingAttributes(ctx, interfaces.ResourceRequest{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the environment variables of project [%s] and domain [%s] with error: %v",
+			project, domain, err)
+		return nil, err
+	}
+	if resource == nil {
+		return map[string]string{}, nil
+	}
+	return common.GetExecutionEnvs(resource.Attributes.GetClusterResourceAttributes().GetAttributes()), nil
+}
+
+// Resolves the attributes of the most specific level which has any, returning nil when none has. Executions use these
+// as they are, without falling back on the application config.
+func (r *AttributesResolver) ResolveMatchingAttributes(ctx context.Context, request interfaces.ResourceRequest) (
+	*interfaces.ResourceResponse, error) {
+	resource, err := r.resourceManager.GetResource(ctx, request)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resource, nil
+}
+
+// Returns the fields of matching attributes which executions use as they are.
+func getMatchingAttributesFields(attributes *admin.MatchingAttributes) []string {
+	var fields []string
+	switch {
+	case attributes.GetClusterResourceAttributes() != nil:
+		for key := range attributes.GetClusterResourceAttributes().GetAttributes() {
+			fields = append(fields, "attributes."+key)
+		}
+		sort.Strings(fields)
+	case attributes.GetPluginOverrides() != nil:
+		for _, override := range attributes.GetPluginOverrides().GetOverrides() {
+			fields = append(fields, "overrides."+override.GetTaskType())
+		}
+	case attributes.GetExecutionClusterLabel() != nil:
+		fields = append(fields, "value")
+	}
+	return fields
+}
+
+// Resolves the attributes of a resource type executions of the project, domain and workflow, if any, are created
+// with. Execution queues and quality of service depend on the tasks and launch plan of each execution, so they can't
+// be resolved up front.
+func (r *AttributesResolver) ResolveEffectiveAttributes(ctx context.Context,
+	request interfaces.EffectiveAttributesRequest) (*interfaces.EffectiveAttributesResponse, error) {
+	switch request.ResourceType {
+	case admin.MatchableResource_TASK_RESOURCE:
+		taskResources, sources, err := r.ResolveTaskResources(ctx, request.Project, request.Domain, request.Workflow)
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.EffectiveAttributesResponse{
+			Attributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_TaskResourceAttributes{
+					TaskResourceAttributes: &admin.TaskResourceAttributes{
+						Defaults: toAdminProtoTaskResourceSpec(taskResources.Defaults),
+						Limits:   toAdminProtoTaskResourceSpec(taskResources.Limits),
+					},
+				},
+			},
+			Sources: sources,
+		}, nil
+	case admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG:
+		executionConfig, sources, err := r.ResolveExecutionConfig(ctx, request.Project, request.Domain)
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.EffectiveAttributesResponse{
+			Attributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_WorkflowExecutionConfig{
+					WorkflowExecutionConfig: executionConfig,
+				},
+			},
+			Sources: sources,
+		}, nil
+	case admin.MatchableResource_EXECUTION_QUEUE, admin.MatchableResource_QUALITY_OF_SERVICE_SPECIFICATION:
+		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"the effective %s attributes depend on each execution and can't be resolved for a workflow",
+			request.ResourceType.String())
+	}
+	resource, err := r.ResolveMatchingAttributes(ctx, interfaces.ResourceRequest{
+		Project:      request.Project,
+		Domain:       request.Domain,
+		Workflow:     request.Workflow,
+		ResourceType: request.ResourceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.NotFound, "no %s attributes apply to [%s/%s/%s]",
+			request.ResourceType.String(), request.Project, request.Domain, request.Workflow)
+	}
+	response := &interfaces.EffectiveAttributesResponse{
+		Attributes: resource.Attributes,
+	}
+	for _, field := range getMatchingAttributesFields(resource.Attributes) {
+		response.Sources = append(response.Sources, interfaces.AttributeSource{
+			Field: field,
+			Level: resource.Level,
+		})
+	}
+	return response, nil
+}
+
+func NewAttributesResolver(resourceManager interfaces.ResourceInterface,
+	config runtimeInterfaces.Configuration) *AttributesResolver {
+	return &AttributesResolver{
+		resourceManager: resourceManager,
+		config:          config,
+	}
+}
@@ -0,0 +1,232 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func getResolverForTest(attributes *admin.MatchingAttributes, level interfaces.ResourceLevel,
+	taskResourceConfig runtimeInterfaces.TaskResourceConfiguration) *AttributesResolver {
+	resourceManager := managerMocks.MockResourceManager{}
+	resourceManager.GetResourceFunc = func(ctx context.Context,
+		request interfaces.ResourceRequest) (*interfaces.ResourceResponse, error) {
+		if attributes == nil {
+			return nil, errors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		return &interfaces.ResourceResponse{
+			Level:      level,
+			Attributes: attributes,
+		}, nil
+	}
+	applicationConfig := testutils.GetApplicationConfigWithDefaultDomains()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		MaxParallelism:        25,
+		DefaultIamRole:        "config-role",
+		DefaultServiceAccount: "config-sa",
+	})
+	return NewAttributesResolver(&resourceManager, runtimeMocks.NewMockConfigurationProvider(
+		applicationConfig, nil, nil, taskResourceConfig, nil, nil))
+}
+
+func TestFromAdminProtoTaskResourceSpec(t *testing.T) {
+	taskResourceSet := FromAdminProtoTaskResourceSpec(context.TODO(), &admin.TaskResourceSpec{
+		Cpu:              "1",
+		Memory:           "100",
+		Storage:          "200",
+		EphemeralStorage: "300",
+		Gpu:              "2",
+	})
+	assert.EqualValues(t, runtimeInterfaces.TaskResourceSet{
+		CPU:              resource.MustParse("1"),
+		Memory:           resource.MustParse("100"),
+		Storage:          resource.MustParse("200"),
+		EphemeralStorage: resource.MustParse("300"),
+		GPU:              resource.MustParse("2"),
+	}, taskResourceSet)
+}
+
+func TestResolveEffectiveAttributes_TaskResources(t *testing.T) {
+	taskResourceConfig := &runtimeMocks.MockTaskResourceConfiguration{
+		Defaults: runtimeInterfaces.TaskResourceSet{
+			CPU:    resource.MustParse("200m"),
+			Memory: resource.MustParse("200Mi"),
+		},
+		Limits: runtimeInterfaces.TaskResourceSet{
+			CPU: resource.MustParse("1"),
+		},
+	}
+	request := interfaces.EffectiveAttributesRequest{
+		Project:      project,
+		Domain:       domain,
+		Workflow:     workflow,
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	}
+
+	t.Run("application config", func(t *testing.T) {
+		response, err := getResolverForTest(nil, "", taskResourceConfig).ResolveEffectiveAttributes(
+			context.Background(), request)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(&admin.TaskResourceAttributes{
+			Defaults: &admin.TaskResourceSpec{Cpu: "200m", Memory: "200Mi"},
+			Limits:   &admin.TaskResourceSpec{Cpu: "1"},
+		}, response.Attributes.GetTaskResourceAttributes()))
+		assert.Equal(t, []interfaces.AttributeSource{
+			{Field: "defaults.cpu", Level: interfaces.ResourceLevelApplicationConfig},
+			{Field: "defaults.memory", Level: interfaces.ResourceLevelApplicationConfig},
+			{Field: "limits.cpu", Level: interfaces.ResourceLevelApplicationConfig},
+		}, response.Sources)
+	})
+	t.Run("matchable attributes", func(t *testing.T) {
+		limitsFromRequestsConfig := *taskResourceConfig
+		limitsFromRequestsConfig.DefaultLimitsFromRequests = true
+		response, err := getResolverForTest(&admin.MatchingAttributes{
+			Target: &admin.MatchingAttributes_TaskResourceAttributes{
+				TaskResourceAttributes: &admin.TaskResourceAttributes{
+					Defaults: &admin.TaskResourceSpec{Memory: "1Gi"},
+					Limits:   &admin.TaskResourceSpec{Cpu: "2", Gpu: "1"},
+				},
+			},
+		}, interfaces.ResourceLevelProjectDomain, &limitsFromRequestsConfig).ResolveEffectiveAttributes(
+			context.Background(), request)
+		assert.NoError(t, err)
+		// Attributes replace the config altogether, and gpu requests aren't inferred from their limit.
+		assert.True(t, proto.Equal(&admin.TaskResourceAttributes{
+			Defaults: &admin.TaskResourceSpec{Cpu: "2", Memory: "1Gi"},
+			Limits:   &admin.TaskResourceSpec{Cpu: "2", Gpu: "1", Memory: "1Gi"},
+		}, response.Attributes.GetTaskResourceAttributes()))
+		assert.Equal(t, []interfaces.AttributeSource{
+			{Field: "defaults.cpu", Level: interfaces.ResourceLevelProjectDomain, DefaultedFrom: "limits.cpu"},
+			{Field: "defaults.memory", Level: interfaces.ResourceLevelProjectDomain},
+			{Field: "limits.cpu", Level: interfaces.ResourceLevelProjectDomain},
+			{Field: "limits.gpu", Level: interfaces.ResourceLevelProjectDomain},
+			{Field: "limits.memory", Level: interfaces.ResourceLevelProjectDomain, DefaultedFrom: "defaults.memory"},
+		}, response.Sources)
+	})
+	t.Run("invalid matchable attributes", func(t *testing.T) {
+		_, err := getResolverForTest(&admin.MatchingAttributes{
+			Target: &admin.MatchingAttributes_TaskResourceAttributes{
+				TaskResourceAttributes: &admin.TaskResourceAttributes{
+					Defaults: &admin.TaskResourceSpec{Cpu: "2"},
+					Limits:   &admin.TaskResourceSpec{Cpu: "1"},
+				},
+			},
+		}, interfaces.ResourceLevelWorkflow, taskResourceConfig).ResolveEffectiveAttributes(context.Background(), request)
+		assert.EqualError(t, err, "CPU request [2] is greater than the limit [1]")
+	})
+}
+
+func TestResolveEffectiveAttributes_ExecutionConfig(t *testing.T) {
+	request := interfaces.EffectiveAttributesRequest{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG,
+	}
+	response, err := getResolverForTest(nil, "", nil).ResolveEffectiveAttributes(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(25), response.Attributes.GetWorkflowExecutionConfig().MaxParallelism)
+	assert.Equal(t, []interfaces.AttributeSource{
+		{Field: "max_parallelism", Level: interfaces.ResourceLevelApplicationConfig},
+	}, response.Sources)
+
+	response, err = getResolverForTest(&admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_WorkflowExecutionConfig{
+			WorkflowExecutionConfig: &admin.WorkflowExecutionConfig{MaxParallelism: 5},
+		},
+	}, interfaces.ResourceLevelDomain, nil).ResolveEffectiveAttributes(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), response.Attributes.GetWorkflowExecutionConfig().MaxParallelism)
+	assert.Equal(t, []interfaces.AttributeSource{
+		{Field: "max_parallelism", Level: interfaces.ResourceLevelDomain},
+	}, response.Sources)
+}
+
+func TestResolveEffectiveAttributes_MatchingAttributes(t *testing.T) {
+	request := interfaces.EffectiveAttributesRequest{
+		Project:      project,
+		Domain:       domain,
+		Workflow:     workflow,
+		ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+	}
+	response, err := getResolverForTest(&admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+			ClusterResourceAttributes: &admin.ClusterResourceAttributes{
+				Attributes: map[string]string{"projectQuotaCpu": "8", "defaultIamRole": "role"},
+			},
+		},
+	}, interfaces.ResourceLevelOrg, nil).ResolveEffectiveAttributes(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, "8", response.Attributes.GetClusterResourceAttributes().GetAttributes()["projectQuotaCpu"])
+	assert.Equal(t, []interfaces.AttributeSource{
+		{Field: "attributes.defaultIamRole", Level: interfaces.ResourceLevelOrg},
+		{Field: "attributes.projectQuotaCpu", Level: interfaces.ResourceLevelOrg},
+	}, response.Sources)
+
+	_, err = getResolverForTest(nil, "", nil).ResolveEffectiveAttributes(context.Background(), request)
+	assert.EqualError(t, err, "no CLUSTER_RESOURCE attributes apply to [project/domain/workflow]")
+	assert.Equal(t, codes.NotFound, err.(errors.FlyteAdminError).Code())
+
+	request.ResourceType = admin.MatchableResource_EXECUTION_QUEUE
+	_, err = getResolverForTest(nil, "", nil).ResolveEffectiveAttributes(context.Background(), request)
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+}
+
+func TestResolveDefaultAuthRole(t *testing.T) {
+	authRole, err := getResolverForTest(nil, "", nil).ResolveDefaultAuthRole(context.Background(), project, domain)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&admin.AuthRole{
+		AssumableIamRole:         "config-role",
+		KubernetesServiceAccount: "config-sa",
+	}, authRole))
+
+	getClusterResourceAttributes := func(attributes map[string]string) *admin.MatchingAttributes {
+		return &admin.MatchingAttributes{
+			Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+				ClusterResourceAttributes: &admin.ClusterResourceAttributes{Attributes: attributes},
+			},
+		}
+	}
+	// The attributes of a level which sets either one replace the application config altogether.
+	authRole, err = getResolverForTest(getClusterResourceAttributes(map[string]string{
+		DefaultServiceAccountAttributeKey: "project-sa",
+	}), interfaces.ResourceLevelProject, nil).ResolveDefaultAuthRole(context.Background(), project, domain)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&admin.AuthRole{KubernetesServiceAccount: "project-sa"}, authRole))
+
+	authRole, err = getResolverForTest(getClusterResourceAttributes(map[string]string{
+		"projectQuotaCpu": "16",
+	}), interfaces.ResourceLevelProject, nil).ResolveDefaultAuthRole(context.Background(), project, domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "config-role", authRole.AssumableIamRole)
+}
+
+func TestResolveExecutionEnvs(t *testing.T) {
+	envs, err := getResolverForTest(nil, "", nil).ResolveExecutionEnvs(context.Background(), project, domain)
+	assert.NoError(t, err)
+	assert.Empty(t, envs)
+
+	envs, err = getResolverForTest(&admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+			ClusterResourceAttributes: &admin.ClusterResourceAttributes{
+				Attributes: map[string]string{
+					"projectQuotaCpu":         "16",
+					"env.flyte.org/REGION":    "us-east-1",
+					"env.flyte.org/FEATURE_X": "on",
+				},
+			},
+		},
+	}, interfaces.ResourceLevelProjectDomain, nil).ResolveExecutionEnvs(context.Background(), project, domain)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"REGION": "us-east-1", "FEATURE_X": "on"}, envs)
+}
@@ -2,7 +2,9 @@ package resources
 
 import (
 	"context"
+	"sort"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
@@ -24,18 +26,72 @@ import (
 )
 
 type ResourceManager struct {
-	db     repositories.RepositoryInterface
-	config runtimeInterfaces.ApplicationConfiguration
+	db          repositories.RepositoryInterface
+	config      runtimeInterfaces.ApplicationConfiguration
+	queueConfig runtimeInterfaces.QueueConfiguration
+	resolver    *AttributesResolver
 }
 
+// The level of the hierarchy resources of each priority are set at.
+var resourceLevels = map[models.ResourcePriority]interfaces.ResourceLevel{
+	models.ResourcePriorityLaunchPlanLevel:    interfaces.ResourceLevelLaunchPlan,
+	models.ResourcePriorityWorkflowLevel:      interfaces.ResourceLevelWorkflow,
+	models.ResourcePriorityProjectDomainLevel: interfaces.ResourceLevelProjectDomain,
+	models.ResourcePriorityProjectLevel:       interfaces.ResourceLevelProject,
+	models.ResourcePriorityOrgLevel:           interfaces.ResourceLevelOrg,
+	models.ResourcePriorityDomainLevel:        interfaces.ResourceLevelDomain,
+}
+
+func isNotFound(err error) bool {
+	ec, ok := err.(errors.FlyteAdminError)
+	return ok && ec.Code() == codes.NotFound
+}
+
+// Returns the org of the project, which is the value of its org label, or an empty string when orgs aren't configured
+// or the project has none.
+func (m *ResourceManager) getProjectOrg(ctx context.Context, projectID string) (string, error) {
+	if m.config == nil {
+		return "", nil
+	}
+	orgLabel := m.config.GetTopLevelConfig().GetOrgLabel()
+	if len(orgLabel) == 0 || len(projectID) == 0 {
+		return "", nil
+	}
+	project, err := m.db.ProjectRepo().Get(ctx, projectID)
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return transformers.FromProjectModel(project, nil).Labels.GetValues()[orgLabel], nil
+}
+
+// Resolves the attributes of the most specific level which has any: the launch plan, the workflow, the project in the
+// domain, the project in all its domains, the org of the project and finally the domain.
 func (m *ResourceManager) GetResource(ctx context.Context, request interfaces.ResourceRequest) (*interfaces.ResourceResponse, error) {
-	resource, err := m.db.ResourceRepo().Get(ctx, repo_interface.ResourceID{
+	resourceID := repo_interface.ResourceID{
 		ResourceType: request.ResourceType.String(),
 		Project:      request.Project,
 		Domain:       request.Domain,
 		Workflow:     request.Workflow,
 		LaunchPlan:   request.LaunchPlan,
-	})
+	}
+	resource, err := m.db.ResourceRepo().Get(ctx, resourceID)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	// The org of the project is only looked up when the project has no attributes of its own.
+	if err != nil || resource.Priority < models.ResourcePriorityOrgLevel {
+		org, orgErr := m.getProjectOrg(ctx, request.Project)
+		if orgErr != nil {
+			return nil, orgErr
+		}
+		if len(org) > 0 {
+			resourceID.Org = org
+			resource, err = m.db.ResourceRepo().Get(ctx, resourceID)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +108,14 @@ func (m *ResourceManager) GetResource(ctx context.Context, request interfaces.Re
 		Domain:       resource.Domain,
 		Workflow:     resource.Workflow,
 		LaunchPlan:   resource.LaunchPlan,
+		Org:          resource.Org,
+		Level:        resourceLevels[resource.Priority],
 		Attributes:   &attributes,
 	}, nil
 }
 
+// Merges the attributes into those of the same resource type, within a transaction so that concurrent updates aren't
+// lost.
 func (m *ResourceManager) createOrMergeUpdateWorkflowAttributes(
 	ctx context.Context, request admin.WorkflowAttributesUpdateRequest, model models.Resource,
 	resourceType admin.MatchableResource) (*admin.WorkflowAttributesUpdateResponse, error) {
@@ -66,25 +126,9 @@ func (m *ResourceManager) createOrMergeUpdateWorkflowAttributes(
 		LaunchPlan:   model.LaunchPlan,
 		ResourceType: model.ResourceType,
 	}
-	existing, err := m.db.ResourceRepo().GetRaw(ctx, resourceID)
-	if err != nil {
-		ec, ok := err.(errors.FlyteAdminError)
-		if ok && ec.Code() == codes.NotFound {
-			// Proceed with the default CreateOrUpdate call since there's no existing model to update.
-			err = m.db.ResourceRepo().CreateOrUpdate(ctx, model)
-			if err != nil {
-				return nil, err
-			}
-			return &admin.WorkflowAttributesUpdateResponse{}, nil
-		}
-		return nil, err
-	}
-	updatedModel, err := transformers.MergeUpdateWorkflowAttributes(
-		ctx, existing, resourceType, &resourceID, request.Attributes)
-	if err != nil {
-		return nil, err
-	}
-	err = m.db.ResourceRepo().CreateOrUpdate(ctx, updatedModel)
+	err := m.db.ResourceRepo().CreateOrMerge(ctx, model, func(existing models.Resource) (models.Resource, error) {
+		return transformers.MergeUpdateWorkflowAttributes(ctx, existing, resourceType, &resourceID, request.Attributes)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -94,11 +138,16 @@ func (m *ResourceManager) createOrMergeUpdateWorkflowAttributes(
 func (m *ResourceManager) UpdateWorkflowAttributes(
 	ctx context.Context, request admin.WorkflowAttributesUpdateRequest) (
 	*admin.WorkflowAttributesUpdateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	var resource admin.MatchableResource
 	var err error
 	if resource, err = validation.ValidateWorkflowAttributesUpdateRequest(ctx, m.db, m.config, request); err != nil {
 		return nil, err
 	}
+	if err = validation.ValidateExecutionQueueAttributes(
+		ctx, request.Attributes.MatchingAttributes, m.queueConfig); err != nil {
+		return nil, err
+	}
 
 	model, err := transformers.WorkflowAttributesToResourceModel(*request.Attributes, resource)
 	if err != nil {
@@ -137,6 +186,7 @@ func (m *ResourceManager) GetWorkflowAttributes(
 
 func (m *ResourceManager) DeleteWorkflowAttributes(ctx context.Context,
 	request admin.WorkflowAttributesDeleteRequest) (*admin.WorkflowAttributesDeleteResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateWorkflowAttributesDeleteRequest(ctx, m.db, m.config, request); err != nil {
 		return nil, err
 	}
@@ -149,6 +199,8 @@ func (m *ResourceManager) DeleteWorkflowAttributes(ctx context.Context,
 	return &admin.WorkflowAttributesDeleteResponse{}, nil
 }
 
+// Merges the attributes into those of the same resource type, within a transaction so that concurrent updates aren't
+// lost.
 func (m *ResourceManager) createOrMergeUpdateProjectDomainAttributes(
 	ctx context.Context, request admin.ProjectDomainAttributesUpdateRequest, model models.Resource,
 	resourceType admin.MatchableResource) (*admin.ProjectDomainAttributesUpdateResponse, error) {
@@ -159,25 +211,9 @@ func (m *ResourceManager) createOrMergeUpdateProjectDomainAttributes(
 		LaunchPlan:   model.LaunchPlan,
 		ResourceType: model.ResourceType,
 	}
-	existing, err := m.db.ResourceRepo().GetRaw(ctx, resourceID)
-	if err != nil {
-		ec, ok := err.(errors.FlyteAdminError)
-		if ok && ec.Code() == codes.NotFound {
-			// Proceed with the default CreateOrUpdate call since there's no existing model to update.
-			err = m.db.ResourceRepo().CreateOrUpdate(ctx, model)
-			if err != nil {
-				return nil, err
-			}
-			return &admin.ProjectDomainAttributesUpdateResponse{}, nil
-		}
-		return nil, err
-	}
-	updatedModel, err := transformers.MergeUpdateProjectDomainAttributes(
-		ctx, existing, resourceType, &resourceID, request.Attributes)
-	if err != nil {
-		return nil, err
-	}
-	err = m.db.ResourceRepo().CreateOrUpdate(ctx, updatedModel)
+	err := m.db.ResourceRepo().CreateOrMerge(ctx, model, func(existing models.Resource) (models.Resource, error) {
+		return transformers.MergeUpdateProjectDomainAttributes(ctx, existing, resourceType, &resourceID, request.Attributes)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -187,11 +223,16 @@ func (m *ResourceManager) createOrMergeUpdateProjectDomainAttributes(
 func (m *ResourceManager) UpdateProjectDomainAttributes(
 	ctx context.Context, request admin.ProjectDomainAttributesUpdateRequest) (
 	*admin.ProjectDomainAttributesUpdateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	var resource admin.MatchableResource
 	var err error
 	if resource, err = validation.ValidateProjectDomainAttributesUpdateRequest(ctx, m.db, m.config, request); err != nil {
 		return nil, err
 	}
+	if err = validation.ValidateExecutionQueueAttributes(
+		ctx, request.Attributes.MatchingAttributes, m.queueConfig); err != nil {
+		return nil, err
+	}
 	ctx = contextutils.WithProjectDomain(ctx, request.Attributes.Project, request.Attributes.Domain)
 
 	model, err := transformers.ProjectDomainAttributesToResourceModel(*request.Attributes, resource)
@@ -228,8 +269,44 @@ func (m *ResourceManager) GetProjectDomainAttributes(
 	}, nil
 }
 
+func (m *ResourceManager) GetAllProjectDomainAttributes(
+	ctx context.Context, request interfaces.ProjectDomainAttributesGetAllRequest) (
+	*interfaces.ProjectDomainAttributesGetAllResponse, error) {
+	if err := validation.ValidateProjectDomainAttributesGetAllRequest(ctx, m.db, m.config, request); err != nil {
+		return nil, err
+	}
+	resourceTypes := make([]int, 0, len(admin.MatchableResource_name))
+	for resourceType := range admin.MatchableResource_name {
+		resourceTypes = append(resourceTypes, int(resourceType))
+	}
+	sort.Ints(resourceTypes)
+	response := &interfaces.ProjectDomainAttributesGetAllResponse{
+		Attributes: make([]*admin.ProjectDomainAttributes, 0),
+	}
+	for _, resourceType := range resourceTypes {
+		model, err := m.db.ResourceRepo().Get(ctx, repo_interface.ResourceID{
+			Project:      request.Project,
+			Domain:       request.Domain,
+			ResourceType: admin.MatchableResource(resourceType).String(),
+		})
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		attributes, err := transformers.FromResourceModelToProjectDomainAttributes(model)
+		if err != nil {
+			return nil, err
+		}
+		response.Attributes = append(response.Attributes, &attributes)
+	}
+	return response, nil
+}
+
 func (m *ResourceManager) DeleteProjectDomainAttributes(ctx context.Context,
 	request admin.ProjectDomainAttributesDeleteRequest) (*admin.ProjectDomainAttributesDeleteResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateProjectDomainAttributesDeleteRequest(ctx, m.db, m.config, request); err != nil {
 		return nil, err
 	}
@@ -242,6 +319,79 @@ func (m *ResourceManager) DeleteProjectDomainAttributes(ctx context.Context,
 	return &admin.ProjectDomainAttributesDeleteResponse{}, nil
 }
 
+func (m *ResourceManager) UpdateScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesUpdateRequest) error {
+	ctx = common.WithPrimaryReads(ctx)
+	resource, err := validation.ValidateScopedAttributesUpdateRequest(ctx, m.db, m.config, request)
+	if err != nil {
+		return err
+	}
+	if err = validation.ValidateExecutionQueueAttributes(ctx, request.MatchingAttributes, m.queueConfig); err != nil {
+		return err
+	}
+	model, err := transformers.ScopedAttributesToResourceModel(request.Scope, request.MatchingAttributes, resource)
+	if err != nil {
+		return err
+	}
+	if request.MatchingAttributes.GetPluginOverrides() != nil {
+		resourceID := repo_interface.ResourceID{
+			Project:      model.Project,
+			Domain:       model.Domain,
+			Org:          model.Org,
+			ResourceType: model.ResourceType,
+		}
+		return m.db.ResourceRepo().CreateOrMerge(ctx, model, func(existing models.Resource) (models.Resource, error) {
+			return transformers.MergeUpdateMatchingAttributes(ctx, existing, resource, &resourceID,
+				request.MatchingAttributes)
+		})
+	}
+	return m.db.ResourceRepo().CreateOrUpdate(ctx, model)
+}
+
+func (m *ResourceManager) GetScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesGetRequest) (*interfaces.ScopedAttributesGetResponse, error) {
+	if err := validation.ValidateScopedAttributesGetRequest(ctx, m.db, m.config, request); err != nil {
+		return nil, err
+	}
+	model, err := m.db.ResourceRepo().GetRaw(ctx, repo_interface.ResourceID{
+		Project:      request.Scope.Project,
+		Domain:       request.Scope.Domain,
+		Org:          request.Scope.Org,
+		ResourceType: request.ResourceType.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var attributes admin.MatchingAttributes
+	if err = proto.Unmarshal(model.Attributes, &attributes); err != nil {
+		return nil, errors.NewFlyteAdminErrorf(
+			codes.Internal, "Failed to decode resource attribute with err: %v", err)
+	}
+	return &interfaces.ScopedAttributesGetResponse{
+		Scope:              request.Scope,
+		Level:              resourceLevels[model.Priority],
+		MatchingAttributes: &attributes,
+	}, nil
+}
+
+func (m *ResourceManager) DeleteScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesDeleteRequest) error {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateScopedAttributesDeleteRequest(ctx, m.db, m.config, request); err != nil {
+		return err
+	}
+	if err := m.db.ResourceRepo().Delete(ctx, repo_interface.ResourceID{
+		Project:      request.Scope.Project,
+		Domain:       request.Scope.Domain,
+		Org:          request.Scope.Org,
+		ResourceType: request.ResourceType.String(),
+	}); err != nil {
+		return err
+	}
+	logger.Infof(ctx, "Deleted scoped attributes for: %+v (%s)", request.Scope, request.ResourceType.String())
+	return nil
+}
+
 func (m *ResourceManager) ListAll(ctx context.Context, request admin.ListMatchableAttributesRequest) (
 	*admin.ListMatchableAttributesResponse, error) {
 	if err := validation.ValidateListAllMatchableAttributesRequest(request); err != nil {
@@ -264,9 +414,73 @@ func (m *ResourceManager) ListAll(ctx context.Context, request admin.ListMatchab
 	}, nil
 }
 
-func NewResourceManager(db repositories.RepositoryInterface, config runtimeInterfaces.ApplicationConfiguration) interfaces.ResourceInterface {
-	return &ResourceManager{
-		db:     db,
-		config: config,
+// Returns the attributes executions are created with after resolving the hierarchy and falling back on the application
+// config, along with the source of each field.
+func (m *ResourceManager) GetEffectiveMatchableAttributes(ctx context.Context,
+	request interfaces.EffectiveAttributesRequest) (*interfaces.EffectiveAttributesResponse, error) {
+	if err := validation.ValidateEffectiveAttributesRequest(ctx, m.db, m.config, request); err != nil {
+		return nil, err
 	}
+	return m.resolver.ResolveEffectiveAttributes(ctx, request)
+}
+
+func (m *ResourceManager) UpdateActiveExecutionQuota(ctx context.Context, quota interfaces.ActiveExecutionQuota) error {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateActiveExecutionQuota(ctx, m.db, m.config, quota); err != nil {
+		return err
+	}
+	model, err := transformers.ActiveExecutionQuotaToResourceModel(quota)
+	if err != nil {
+		return err
+	}
+	return m.db.ResourceRepo().CreateOrUpdate(ctx, model)
+}
+
+// Returns the quota of the project in the domain, or a NotFound error when it has none and the application config
+// applies.
+func (m *ResourceManager) GetActiveExecutionQuota(ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) (
+	*interfaces.ActiveExecutionQuota, error) {
+	if err := validation.ValidateActiveExecutionQuotaGetRequest(request); err != nil {
+		return nil, err
+	}
+	model, err := m.db.ResourceRepo().GetRaw(ctx, repo_interface.ResourceID{
+		Project:      request.Project,
+		Domain:       request.Domain,
+		ResourceType: models.ActiveExecutionQuotaResourceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	quota, err := transformers.FromResourceModelToActiveExecutionQuota(model)
+	if err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+func (m *ResourceManager) DeleteActiveExecutionQuota(ctx context.Context,
+	request interfaces.ActiveExecutionQuotaRequest) error {
+	ctx = common.WithPrimaryReads(ctx)
+	if err := validation.ValidateActiveExecutionQuotaDeleteRequest(ctx, m.db, m.config, request); err != nil {
+		return err
+	}
+	if err := m.db.ResourceRepo().Delete(ctx, repo_interface.ResourceID{
+		Project:      request.Project,
+		Domain:       request.Domain,
+		ResourceType: models.ActiveExecutionQuotaResourceType,
+	}); err != nil {
+		return err
+	}
+	logger.Infof(ctx, "Deleted the active execution quota of: %s-%s", request.Project, request.Domain)
+	return nil
+}
+
+func NewResourceManager(db repositories.RepositoryInterface, config runtimeInterfaces.Configuration) interfaces.ResourceInterface {
+	manager := &ResourceManager{
+		db:          db,
+		config:      config.ApplicationConfiguration(),
+		queueConfig: config.QueueConfiguration(),
+	}
+	manager.resolver = NewAttributesResolver(manager, config)
+	return manager
 }
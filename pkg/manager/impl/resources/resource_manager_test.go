@@ -15,6 +15,8 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,12 @@ const project = "project"
 const domain = "domain"
 const workflow = "workflow"
 
+func getMockConfigForResourceTest(
+	applicationConfig runtimeInterfaces.ApplicationConfiguration) runtimeInterfaces.Configuration {
+	return runtimeMocks.NewMockConfigurationProvider(applicationConfig, nil, nil,
+		&runtimeMocks.MockTaskResourceConfiguration{}, nil, nil)
+}
+
 func TestUpdateWorkflowAttributes(t *testing.T) {
 	request := admin.WorkflowAttributesUpdateRequest{
 		Attributes: &admin.WorkflowAttributes{
@@ -46,7 +54,7 @@ func TestUpdateWorkflowAttributes(t *testing.T) {
 		createOrUpdateCalled = true
 		return nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	_, err := manager.UpdateWorkflowAttributes(context.Background(), request)
 	assert.Nil(t, err)
 	assert.True(t, createOrUpdateCalled)
@@ -87,7 +95,7 @@ func TestUpdateWorkflowAttributes_CreateOrMerge(t *testing.T) {
 			createOrUpdateCalled = true
 			return nil
 		}
-		manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+		manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 		_, err := manager.UpdateWorkflowAttributes(context.Background(), request)
 		assert.NoError(t, err)
 		assert.True(t, createOrUpdateCalled)
@@ -136,7 +144,7 @@ func TestUpdateWorkflowAttributes_CreateOrMerge(t *testing.T) {
 			createOrUpdateCalled = true
 			return nil
 		}
-		manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+		manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 		_, err := manager.UpdateWorkflowAttributes(context.Background(), request)
 		assert.NoError(t, err)
 		assert.True(t, createOrUpdateCalled)
@@ -166,7 +174,7 @@ func TestGetWorkflowAttributes(t *testing.T) {
 			Attributes:   expectedSerializedAttrs,
 		}, nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	response, err := manager.GetWorkflowAttributes(context.Background(), request)
 	assert.Nil(t, err)
 	assert.True(t, proto.Equal(&admin.WorkflowAttributesGetResponse{
@@ -195,7 +203,7 @@ func TestDeleteWorkflowAttributes(t *testing.T) {
 		assert.Equal(t, admin.MatchableResource_EXECUTION_QUEUE.String(), ID.ResourceType)
 		return nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	_, err := manager.DeleteWorkflowAttributes(context.Background(), request)
 	assert.Nil(t, err)
 }
@@ -221,12 +229,36 @@ func TestUpdateProjectDomainAttributes(t *testing.T) {
 		createOrUpdateCalled = true
 		return nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	_, err := manager.UpdateProjectDomainAttributes(context.Background(), request)
 	assert.Nil(t, err)
 	assert.True(t, createOrUpdateCalled)
 }
 
+func TestUpdateProjectDomainAttributes_UnknownQueueTags(t *testing.T) {
+	request := admin.ProjectDomainAttributesUpdateRequest{
+		Attributes: &admin.ProjectDomainAttributes{
+			Project:            project,
+			Domain:             domain,
+			MatchingAttributes: testutils.ExecutionQueueAttributes,
+		},
+	}
+	db := mocks.NewMockRepository()
+	db.ResourceRepo().(*mocks.MockResourceRepo).CreateOrUpdateFunction = func(
+		ctx context.Context, input models.Resource) error {
+		t.Error("attributes naming unknown tags mustn't be saved")
+		return nil
+	}
+	queueConfig := runtimeMocks.NewMockQueueConfigurationProvider([]runtimeInterfaces.ExecutionQueue{
+		{Dynamic: "foo_dynamic", Attributes: []string{"foo"}},
+	}, nil)
+	queueConfig.(*runtimeMocks.MockQueueConfigurationProvider).RejectUnknownTags = true
+	manager := NewResourceManager(db, runtimeMocks.NewMockConfigurationProvider(
+		testutils.GetApplicationConfigWithDefaultDomains(), queueConfig, nil, nil, nil, nil))
+	_, err := manager.UpdateProjectDomainAttributes(context.Background(), request)
+	assert.EqualError(t, err, "execution queue attributes name tags [bar baz] which no configured execution queue has")
+}
+
 func TestUpdateProjectDomainAttributes_CreateOrMerge(t *testing.T) {
 	request := admin.ProjectDomainAttributesUpdateRequest{
 		Attributes: &admin.ProjectDomainAttributes{
@@ -260,7 +292,7 @@ func TestUpdateProjectDomainAttributes_CreateOrMerge(t *testing.T) {
 			createOrUpdateCalled = true
 			return nil
 		}
-		manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+		manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 		_, err := manager.UpdateProjectDomainAttributes(context.Background(), request)
 		assert.NoError(t, err)
 		assert.True(t, createOrUpdateCalled)
@@ -307,13 +339,112 @@ func TestUpdateProjectDomainAttributes_CreateOrMerge(t *testing.T) {
 			createOrUpdateCalled = true
 			return nil
 		}
-		manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+		manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 		_, err := manager.UpdateProjectDomainAttributes(context.Background(), request)
 		assert.NoError(t, err)
 		assert.True(t, createOrUpdateCalled)
 	})
 }
 
+func TestUpdateProjectDomainAttributes_MergesExistingAttributes(t *testing.T) {
+	request := admin.ProjectDomainAttributesUpdateRequest{
+		Attributes: &admin.ProjectDomainAttributes{
+			Project:            project,
+			Domain:             domain,
+			MatchingAttributes: commonTestUtils.GetPluginOverridesAttributes(map[string][]string{"python": {"plugin a"}}),
+		},
+	}
+	db := mocks.NewMockRepository()
+	var merged models.Resource
+	db.ResourceRepo().(*mocks.MockResourceRepo).CreateOrMergeFunction = func(
+		ctx context.Context, input models.Resource, merge repoInterfaces.MergeResourceFunc) error {
+		assert.Equal(t, admin.MatchableResource_PLUGIN_OVERRIDE.String(), input.ResourceType)
+		existingAttributes, err := proto.Marshal(commonTestUtils.GetPluginOverridesAttributes(map[string][]string{
+			"hive": {"plugin b"},
+		}))
+		assert.NoError(t, err)
+		merged, err = merge(models.Resource{
+			ID:           1,
+			Project:      project,
+			Domain:       domain,
+			ResourceType: input.ResourceType,
+			Priority:     input.Priority,
+			Attributes:   existingAttributes,
+		})
+		return err
+	}
+	db.ResourceRepo().(*mocks.MockResourceRepo).CreateOrUpdateFunction = func(
+		ctx context.Context, input models.Resource) error {
+		t.Error("plugin overrides must be merged rather than replaced")
+		return nil
+	}
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
+	_, err := manager.UpdateProjectDomainAttributes(context.Background(), request)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(1), merged.ID)
+	var mergedAttributes admin.MatchingAttributes
+	assert.NoError(t, proto.Unmarshal(merged.Attributes, &mergedAttributes))
+	taskTypes := make([]string, 0)
+	for _, override := range mergedAttributes.GetPluginOverrides().Overrides {
+		taskTypes = append(taskTypes, override.TaskType)
+	}
+	assert.ElementsMatch(t, []string{"hive", "python"}, taskTypes)
+}
+
+func TestGetAllProjectDomainAttributes(t *testing.T) {
+	db := mocks.NewMockRepository()
+	var requestedTypes []string
+	db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+		ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+		assert.Equal(t, project, ID.Project)
+		assert.Equal(t, domain, ID.Domain)
+		requestedTypes = append(requestedTypes, ID.ResourceType)
+		var attributes *admin.MatchingAttributes
+		switch ID.ResourceType {
+		case admin.MatchableResource_TASK_RESOURCE.String():
+			attributes = &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_TaskResourceAttributes{
+					TaskResourceAttributes: &admin.TaskResourceAttributes{
+						Defaults: &admin.TaskResourceSpec{Cpu: "1"},
+					},
+				},
+			}
+		case admin.MatchableResource_EXECUTION_QUEUE.String():
+			attributes = testutils.ExecutionQueueAttributes
+		default:
+			return models.Resource{}, errors.NewFlyteAdminError(codes.NotFound, "foo")
+		}
+		serializedAttributes, err := proto.Marshal(attributes)
+		assert.NoError(t, err)
+		return models.Resource{
+			Project:      project,
+			Domain:       domain,
+			ResourceType: ID.ResourceType,
+			Attributes:   serializedAttributes,
+		}, nil
+	}
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
+
+	response, err := manager.GetAllProjectDomainAttributes(context.Background(),
+		interfaces.ProjectDomainAttributesGetAllRequest{Project: project, Domain: domain})
+	assert.NoError(t, err)
+	assert.Len(t, requestedTypes, len(admin.MatchableResource_name))
+	assert.Len(t, response.Attributes, 2)
+	assert.NotNil(t, response.Attributes[0].MatchingAttributes.GetTaskResourceAttributes())
+	assert.True(t, proto.Equal(testutils.ExecutionQueueAttributes, response.Attributes[1].MatchingAttributes))
+
+	t.Run("failure", func(t *testing.T) {
+		db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+			ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+			return models.Resource{}, errors.NewFlyteAdminError(codes.Internal, "database unreachable")
+		}
+		_, err := manager.GetAllProjectDomainAttributes(context.Background(),
+			interfaces.ProjectDomainAttributesGetAllRequest{Project: project, Domain: domain})
+		assert.EqualError(t, err, "database unreachable")
+	})
+}
+
 func TestGetProjectDomainAttributes(t *testing.T) {
 	request := admin.ProjectDomainAttributesGetRequest{
 		Project:      project,
@@ -335,7 +466,7 @@ func TestGetProjectDomainAttributes(t *testing.T) {
 			Attributes:   expectedSerializedAttrs,
 		}, nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	response, err := manager.GetProjectDomainAttributes(context.Background(), request)
 	assert.Nil(t, err)
 	assert.True(t, proto.Equal(&admin.ProjectDomainAttributesGetResponse{
@@ -361,7 +492,7 @@ func TestDeleteProjectDomainAttributes(t *testing.T) {
 		assert.Equal(t, admin.MatchableResource_EXECUTION_QUEUE.String(), ID.ResourceType)
 		return nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	_, err := manager.DeleteProjectDomainAttributes(context.Background(), request)
 	assert.Nil(t, err)
 }
@@ -392,7 +523,7 @@ func TestGetResource(t *testing.T) {
 			Attributes:   expectedSerializedAttrs,
 		}, nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	response, err := manager.GetResource(context.Background(), request)
 	assert.Nil(t, err)
 	assert.Equal(t, request.Project, response.Project)
@@ -443,7 +574,7 @@ func TestListAllResources(t *testing.T) {
 			},
 		}, nil
 	}
-	manager := NewResourceManager(db, testutils.GetApplicationConfigWithDefaultDomains())
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
 	response, err := manager.ListAll(context.Background(), admin.ListMatchableAttributesRequest{
 		ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
 	})
@@ -461,3 +592,191 @@ func TestListAllResources(t *testing.T) {
 		Attributes: &workflowAttributes,
 	}, response.Configurations[1]))
 }
+
+func TestGetResource_Org(t *testing.T) {
+	applicationConfig := testutils.GetApplicationConfigWithDefaultDomains()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		OrgLabel: "org",
+	})
+	request := interfaces.ResourceRequest{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_EXECUTION_QUEUE,
+	}
+	serializedAttributes, _ := proto.Marshal(testutils.ExecutionQueueAttributes)
+	labels, _ := proto.Marshal(&admin.Project{Labels: &admin.Labels{Values: map[string]string{"org": "acme"}}})
+	activeState := int32(admin.Project_ACTIVE)
+
+	t.Run("project attributes", func(t *testing.T) {
+		db := mocks.NewMockRepository()
+		db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+			ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+			assert.Empty(t, ID.Org)
+			return models.Resource{
+				Project:      ID.Project,
+				ResourceType: ID.ResourceType,
+				Priority:     models.ResourcePriorityProjectLevel,
+				Attributes:   serializedAttributes,
+			}, nil
+		}
+		db.ProjectRepo().(*mocks.MockProjectRepo).GetFunction = func(
+			ctx context.Context, projectID string) (models.Project, error) {
+			t.Error("the org mustn't be looked up for a project with attributes of its own")
+			return models.Project{}, nil
+		}
+		response, err := NewResourceManager(db, getMockConfigForResourceTest(applicationConfig)).GetResource(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.ResourceLevelProject, response.Level)
+		assert.Empty(t, response.Domain)
+		assert.True(t, proto.Equal(testutils.ExecutionQueueAttributes, response.Attributes))
+	})
+	t.Run("org attributes", func(t *testing.T) {
+		db := mocks.NewMockRepository()
+		db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+			ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+			if ID.Org != "acme" {
+				return models.Resource{
+					Domain:       ID.Domain,
+					ResourceType: ID.ResourceType,
+					Priority:     models.ResourcePriorityDomainLevel,
+				}, nil
+			}
+			return models.Resource{
+				Org:          ID.Org,
+				ResourceType: ID.ResourceType,
+				Priority:     models.ResourcePriorityOrgLevel,
+				Attributes:   serializedAttributes,
+			}, nil
+		}
+		db.ProjectRepo().(*mocks.MockProjectRepo).GetFunction = func(
+			ctx context.Context, projectID string) (models.Project, error) {
+			assert.Equal(t, project, projectID)
+			return models.Project{Identifier: projectID, Labels: labels, State: &activeState}, nil
+		}
+		response, err := NewResourceManager(db, getMockConfigForResourceTest(applicationConfig)).GetResource(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.ResourceLevelOrg, response.Level)
+		assert.Equal(t, "acme", response.Org)
+		assert.True(t, proto.Equal(testutils.ExecutionQueueAttributes, response.Attributes))
+	})
+	t.Run("unregistered project", func(t *testing.T) {
+		db := mocks.NewMockRepository()
+		db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+			ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+			assert.Empty(t, ID.Org)
+			return models.Resource{}, errors.NewFlyteAdminError(codes.NotFound, "not found")
+		}
+		db.ProjectRepo().(*mocks.MockProjectRepo).GetFunction = func(
+			ctx context.Context, projectID string) (models.Project, error) {
+			return models.Project{}, errors.NewFlyteAdminError(codes.NotFound, "project not found")
+		}
+		_, err := NewResourceManager(db, getMockConfigForResourceTest(applicationConfig)).GetResource(context.Background(), request)
+		assert.EqualError(t, err, "not found")
+	})
+	t.Run("project lookup failure", func(t *testing.T) {
+		db := mocks.NewMockRepository()
+		db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+			ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+			return models.Resource{}, errors.NewFlyteAdminError(codes.NotFound, "not found")
+		}
+		db.ProjectRepo().(*mocks.MockProjectRepo).GetFunction = func(
+			ctx context.Context, projectID string) (models.Project, error) {
+			return models.Project{}, errors.NewFlyteAdminError(codes.Unavailable, "database unreachable")
+		}
+		_, err := NewResourceManager(db, getMockConfigForResourceTest(applicationConfig)).GetResource(context.Background(), request)
+		assert.EqualError(t, err, "database unreachable")
+	})
+}
+
+func TestGetEffectiveMatchableAttributes(t *testing.T) {
+	attributes := &admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_ExecutionClusterLabel{
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "gpu"},
+		},
+	}
+	serializedAttributes, _ := proto.Marshal(attributes)
+	db := mocks.NewMockRepository()
+	db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+		ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+		assert.Equal(t, workflow, ID.Workflow)
+		return models.Resource{
+			Project:      ID.Project,
+			Domain:       ID.Domain,
+			Workflow:     ID.Workflow,
+			ResourceType: ID.ResourceType,
+			Priority:     models.ResourcePriorityWorkflowLevel,
+			Attributes:   serializedAttributes,
+		}, nil
+	}
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
+
+	response, err := manager.GetEffectiveMatchableAttributes(context.Background(), interfaces.EffectiveAttributesRequest{
+		Project:      project,
+		Domain:       domain,
+		Workflow:     workflow,
+		ResourceType: admin.MatchableResource_EXECUTION_CLUSTER_LABEL,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(attributes, response.Attributes))
+	assert.Equal(t, []interfaces.AttributeSource{
+		{Field: "value", Level: interfaces.ResourceLevelWorkflow},
+	}, response.Sources)
+
+	_, err = manager.GetEffectiveMatchableAttributes(context.Background(), interfaces.EffectiveAttributesRequest{
+		Project:      project,
+		Domain:       "unknown",
+		ResourceType: admin.MatchableResource_EXECUTION_CLUSTER_LABEL,
+	})
+	assert.EqualError(t, err, "domain [unknown] is unrecognized by system")
+}
+
+func TestActiveExecutionQuota(t *testing.T) {
+	db := mocks.NewMockRepository()
+	stored := make(map[repoInterfaces.ResourceID]models.Resource)
+	db.ResourceRepo().(*mocks.MockResourceRepo).CreateOrUpdateFunction = func(
+		ctx context.Context, input models.Resource) error {
+		assert.Equal(t, models.ResourcePriorityProjectDomainLevel, input.Priority)
+		stored[repoInterfaces.ResourceID{
+			Project:      input.Project,
+			Domain:       input.Domain,
+			ResourceType: input.ResourceType,
+		}] = input
+		return nil
+	}
+	db.ResourceRepo().(*mocks.MockResourceRepo).GetFunction = func(
+		ctx context.Context, ID repoInterfaces.ResourceID) (models.Resource, error) {
+		model, ok := stored[ID]
+		if !ok {
+			return models.Resource{}, errors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		return model, nil
+	}
+	db.ResourceRepo().(*mocks.MockResourceRepo).DeleteFunction = func(
+		ctx context.Context, ID repoInterfaces.ResourceID) error {
+		delete(stored, ID)
+		return nil
+	}
+	manager := NewResourceManager(db, getMockConfigForResourceTest(testutils.GetApplicationConfigWithDefaultDomains()))
+	request := interfaces.ActiveExecutionQuotaRequest{Project: project, Domain: domain}
+	quota := interfaces.ActiveExecutionQuota{Project: project, Domain: domain, MaxActiveExecutions: 200}
+
+	assert.NoError(t, manager.UpdateActiveExecutionQuota(context.Background(), quota))
+	// Quotas are kept apart from the matchable attributes of any resource type.
+	assert.Len(t, stored, 1)
+	for ID := range stored {
+		assert.Equal(t, models.ActiveExecutionQuotaResourceType, ID.ResourceType)
+	}
+	got, err := manager.GetActiveExecutionQuota(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, &quota, got)
+
+	assert.NoError(t, manager.DeleteActiveExecutionQuota(context.Background(), request))
+	_, err = manager.GetActiveExecutionQuota(context.Background(), request)
+	assert.Equal(t, codes.NotFound, err.(errors.FlyteAdminError).Code())
+
+	quota.MaxActiveExecutions = -1
+	err = manager.UpdateActiveExecutionQuota(context.Background(), quota)
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+	_, err = manager.GetActiveExecutionQuota(context.Background(), interfaces.ActiveExecutionQuotaRequest{Project: project})
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+}
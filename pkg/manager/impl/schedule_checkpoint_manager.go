@@ -0,0 +1,113 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+type ScheduleCheckpointManager struct {
+	db repositories.RepositoryInterface
+}
+
+func toScheduleCheckpoint(model models.ScheduleCheckpoint) interfaces.ScheduleCheckpoint {
+	return interfaces.ScheduleCheckpoint{
+		Project:           model.Project,
+		Domain:            model.Domain,
+		Name:              model.Name,
+		Version:           model.Version,
+		LastScheduledAt:   model.LastScheduledAt.UTC(),
+		LastExecutionName: model.LastExecutionName,
+		UpdatedAt:         model.UpdatedAt.UTC(),
+	}
+}
+
+func (m *ScheduleCheckpointManager) ListScheduleCheckpoints(ctx context.Context,
+	request interfaces.ListScheduleCheckpointsRequest) (*interfaces.ScheduleCheckpointList, error) {
+	if err := validation.ValidateListScheduleCheckpointsRequest(request); err != nil {
+		logger.Debugf(ctx, "received invalid list schedule checkpoints request [%+v]: %v", request, err)
+		return nil, err
+	}
+	output, err := m.db.ScheduleCheckpointRepo().List(ctx, repoInterfaces.ListScheduleCheckpointsInput{
+		Project: request.Project,
+		Domain:  request.Domain,
+		Name:    request.Name,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list schedule checkpoints for [%+v] with err: %v", request, err)
+		return nil, err
+	}
+	checkpoints := make([]interfaces.ScheduleCheckpoint, len(output))
+	for i, checkpoint := range output {
+		checkpoints[i] = toScheduleCheckpoint(checkpoint)
+	}
+	return &interfaces.ScheduleCheckpointList{
+		Checkpoints: checkpoints,
+	}, nil
+}
+
+func (m *ScheduleCheckpointManager) UpdateScheduleCheckpoint(ctx context.Context,
+	request interfaces.UpdateScheduleCheckpointRequest) (*interfaces.ScheduleCheckpoint, error) {
+	if err := validation.ValidateUpdateScheduleCheckpointRequest(request); err != nil {
+		logger.Debugf(ctx, "received invalid update schedule checkpoint request [%+v]: %v", request, err)
+		return nil, err
+	}
+	// Checkpoints are only kept for launch plans which exist.
+	if _, err := util.GetLaunchPlanModel(ctx, m.db, core.Identifier{
+		ResourceType: core.ResourceType_LAUNCH_PLAN,
+		Project:      request.Project,
+		Domain:       request.Domain,
+		Name:         request.Name,
+		Version:      request.Version,
+	}); err != nil {
+		return nil, err
+	}
+	key := models.ScheduleCheckpointKey{
+		Project: request.Project,
+		Domain:  request.Domain,
+		Name:    request.Name,
+		Version: request.Version,
+	}
+	if err := m.db.ScheduleCheckpointRepo().Update(ctx, models.ScheduleCheckpoint{
+		ScheduleCheckpointKey: key,
+		LastScheduledAt:       request.LastScheduledAt,
+	}); err != nil {
+		logger.Errorf(ctx, "Failed to update the schedule checkpoint for [%+v] with err: %v", request, err)
+		return nil, err
+	}
+	logger.Infof(ctx, "Set the schedule checkpoint of launch plan [%+v] to %v", key, request.LastScheduledAt)
+
+	// Reads the checkpoint back for the time it was updated at.
+	output, err := m.db.ScheduleCheckpointRepo().List(ctx, repoInterfaces.ListScheduleCheckpointsInput{
+		Project: request.Project,
+		Domain:  request.Domain,
+		Name:    request.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, checkpoint := range output {
+		if checkpoint.ScheduleCheckpointKey == key {
+			updated := toScheduleCheckpoint(checkpoint)
+			return &updated, nil
+		}
+	}
+	return nil, errors.NewFlyteAdminErrorf(codes.Internal, "the schedule checkpoint of [%+v] was not found once updated",
+		key)
+}
+
+func NewScheduleCheckpointManager(db repositories.RepositoryInterface) interfaces.ScheduleCheckpointInterface {
+	return &ScheduleCheckpointManager{
+		db: db,
+	}
+}
@@ -0,0 +1,119 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+var scheduleCheckpointKey = models.ScheduleCheckpointKey{
+	Project: "project",
+	Domain:  "domain",
+	Name:    "name",
+	Version: "version",
+}
+
+func TestListScheduleCheckpoints(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	lastScheduledAt := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	repository.ScheduleCheckpointRepo().(*repositoryMocks.ScheduleCheckpointRepoInterface).OnList(
+		context.Background(), repoInterfaces.ListScheduleCheckpointsInput{
+			Project: "project",
+			Domain:  "domain",
+		}).Return([]models.ScheduleCheckpoint{
+		{
+			ScheduleCheckpointKey: scheduleCheckpointKey,
+			LastScheduledAt:       lastScheduledAt,
+			LastExecutionName:     "execution",
+		},
+	}, nil)
+
+	checkpoints, err := NewScheduleCheckpointManager(repository).ListScheduleCheckpoints(context.Background(),
+		interfaces.ListScheduleCheckpointsRequest{
+			Project: "project",
+			Domain:  "domain",
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []interfaces.ScheduleCheckpoint{
+		{
+			Project:           "project",
+			Domain:            "domain",
+			Name:              "name",
+			Version:           "version",
+			LastScheduledAt:   lastScheduledAt,
+			LastExecutionName: "execution",
+			UpdatedAt:         time.Time{}.UTC(),
+		},
+	}, checkpoints.Checkpoints)
+
+	_, err = NewScheduleCheckpointManager(repository).ListScheduleCheckpoints(context.Background(),
+		interfaces.ListScheduleCheckpointsRequest{Project: "project"})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestUpdateScheduleCheckpoint(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	lastScheduledAt := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	checkpointRepo := repository.ScheduleCheckpointRepo().(*repositoryMocks.ScheduleCheckpointRepoInterface)
+	checkpointRepo.OnUpdate(context.Background(), models.ScheduleCheckpoint{
+		ScheduleCheckpointKey: scheduleCheckpointKey,
+		LastScheduledAt:       lastScheduledAt,
+	}).Return(nil)
+	checkpointRepo.OnListMatch(mock.Anything, mock.Anything).Return([]models.ScheduleCheckpoint{
+		{
+			ScheduleCheckpointKey: models.ScheduleCheckpointKey{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "name",
+				Version: "other",
+			},
+		},
+		{
+			ScheduleCheckpointKey: scheduleCheckpointKey,
+			LastScheduledAt:       lastScheduledAt,
+		},
+	}, nil)
+
+	checkpoint, err := NewScheduleCheckpointManager(repository).UpdateScheduleCheckpoint(context.Background(),
+		interfaces.UpdateScheduleCheckpointRequest{
+			Project:         "project",
+			Domain:          "domain",
+			Name:            "name",
+			Version:         "version",
+			LastScheduledAt: lastScheduledAt,
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "version", checkpoint.Version)
+	assert.Equal(t, lastScheduledAt, checkpoint.LastScheduledAt)
+	checkpointRepo.AssertExpectations(t)
+}
+
+func TestUpdateScheduleCheckpoint_MissingLaunchPlan(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+		func(input repoInterfaces.Identifier) (models.LaunchPlan, error) {
+			return models.LaunchPlan{}, flyteAdminErrors.NewFlyteAdminError(codes.NotFound, "missing")
+		})
+
+	_, err := NewScheduleCheckpointManager(repository).UpdateScheduleCheckpoint(context.Background(),
+		interfaces.UpdateScheduleCheckpointRequest{
+			Project:         "project",
+			Domain:          "domain",
+			Name:            "name",
+			Version:         "version",
+			LastScheduledAt: time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC),
+		})
+	assert.Equal(t, codes.NotFound, err.(flyteAdminErrors.FlyteAdminError).Code())
+	repository.ScheduleCheckpointRepo().(*repositoryMocks.ScheduleCheckpointRepoInterface).AssertNotCalled(t,
+		"Update", mock.Anything, mock.Anything)
+}
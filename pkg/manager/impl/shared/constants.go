@@ -5,6 +5,7 @@ package shared
 const (
 	Project               = "project"
 	Domain                = "domain"
+	Org                   = "org"
 	Name                  = "name"
 	ID                    = "id"
 	Version               = "version"
@@ -22,6 +23,8 @@ const (
 	DefaultInputs         = "default_inputs"
 	Inputs                = "inputs"
 	State                 = "state"
+	ScheduleType          = "schedule_type"
+	ActivatedAt           = "activated_at"
 	ExecutionID           = "execution_id"
 	NodeID                = "node_id"
 	NodeExecutionID       = "node_execution_id"
@@ -32,6 +35,12 @@ const (
 	UserInputs            = "user_inputs"
 	Attributes            = "attributes"
 	MatchingAttributes    = "matching_attributes"
+	Cause                 = "cause"
+	Tags                  = "tags"
+	Tag                   = "tag"
+	Filename              = "filename"
+	Query                 = "query"
+	CreatedBefore         = "created_before"
 	// Parent of a node execution in the node executions table
 	ParentID = "parent_id"
 )
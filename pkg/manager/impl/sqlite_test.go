@@ -0,0 +1,931 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	eventWriterMocks "github.com/flyteorg/flyteadmin/pkg/async/events/mocks"
+	scheduleInterfaces "github.com/flyteorg/flyteadmin/pkg/async/schedule/interfaces"
+	scheduleMocks "github.com/flyteorg/flyteadmin/pkg/async/schedule/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	repositoryConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	repositoryInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// The tests below run managers end to end against the repositories of a migrated SQLite database, which unlike
+// Postgres needs no database server. The database is returned along with the repositories, to inspect its tables.
+func getSQLiteDBAndRepositoryForTest(t *testing.T) (*gorm.DB, repositories.RepositoryInterface) {
+	dbConfig := repositoryConfig.DbConfig{
+		// As when migrating with the migrate command.
+		BaseConfig: repositoryConfig.BaseConfig{
+			DisableForeignKeyConstraintWhenMigrating: true,
+		},
+		Dialect: repositoryConfig.SQLite,
+		SQLite: runtimeInterfaces.DbSQLiteConfig{
+			File: filepath.Join(t.TempDir(), "flyteadmin.db"),
+		},
+	}
+	db, err := repositoryConfig.OpenDbConnection(repositoryConfig.NewSQLiteConfigProvider(dbConfig,
+		mockScope.NewTestScope()))
+	assert.NoError(t, err)
+	assert.NoError(t, gormigrate.New(db, gormigrate.DefaultOptions, repositoryConfig.Migrations).Migrate())
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+
+	repoConfig, err := repositories.GetRepoConfig(dbConfig)
+	assert.NoError(t, err)
+	return db, repositories.GetRepository(repoConfig, dbConfig, mockScope.NewTestScope())
+}
+
+func getSQLiteRepositoryForTest(t *testing.T) repositories.RepositoryInterface {
+	_, repository := getSQLiteDBAndRepositoryForTest(t)
+	return repository
+}
+
+func registerProjectForSQLiteTest(t *testing.T, repository repositories.RepositoryInterface, project string) {
+	projectManager := NewProjectManager(repository, getMockConfigForTaskTest())
+	_, err := projectManager.CreateProject(context.Background(), admin.ProjectRegisterRequest{
+		Project: &admin.Project{
+			Id:   project,
+			Name: project,
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestSQLite_Projects(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	registerProjectForSQLiteTest(t, repository, "project")
+
+	projectManager := NewProjectManager(repository, getMockConfigForTaskTest())
+	_, err := projectManager.CreateProject(context.Background(), admin.ProjectRegisterRequest{
+		Project: &admin.Project{
+			Id:   "project",
+			Name: "project",
+		},
+	})
+	assert.Equal(t, codes.AlreadyExists, err.(errors.FlyteAdminError).Code())
+
+	projects, err := projectManager.ListProjects(context.Background(), admin.ProjectListRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, projects.Projects, 1)
+	assert.Equal(t, "project", projects.Projects[0].Id)
+}
+
+func TestSQLite_Tasks(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	registerProjectForSQLiteTest(t, repository, "project")
+	taskManager := NewTaskManager(repository, getMockConfigForTaskTest(), getMockTaskCompiler(),
+		mockScope.NewTestScope())
+
+	// Writes from concurrent requests are serialized rather than failing on the database lock.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			request := testutils.GetValidTaskRequestWithOverrides("project", "domain", "name", version)
+			_, err := taskManager.CreateTask(context.Background(), request)
+			assert.NoError(t, err)
+		}(fmt.Sprintf("version-%d", i))
+	}
+	wg.Wait()
+
+	task, err := taskManager.GetTask(context.Background(), admin.ObjectGetRequest{
+		Id: &core.Identifier{
+			ResourceType: core.ResourceType_TASK,
+			Project:      "project",
+			Domain:       "domain",
+			Name:         "name",
+			Version:      "version-3",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "version-3", task.Id.Version)
+
+	tasks, err := taskManager.ListTasks(context.Background(), admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+		Limit:   20,
+		SortBy:  &admin.Sort{Key: "version", Direction: admin.Sort_ASCENDING},
+		Filters: "contains(version,version-)",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, tasks.Tasks, 10)
+	assert.Equal(t, "version-0", tasks.Tasks[0].Id.Version)
+
+	// Each task was assigned an id of its own.
+	taskIDs := make(map[uint]bool)
+	for i := 0; i < 10; i++ {
+		task, err := repository.TaskRepo().Get(context.Background(), repositoryInterfaces.Identifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+			Version: fmt.Sprintf("version-%d", i),
+		})
+		assert.NoError(t, err)
+		assert.NotZero(t, task.ID)
+		taskIDs[task.ID] = true
+	}
+	assert.Len(t, taskIDs, 10)
+}
+
+func TestSQLite_ListExecutionsByDuration(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	createdAt := time.Now().Add(-time.Hour)
+	for name, duration := range map[string]time.Duration{"short": time.Minute, "long": 10 * time.Minute} {
+		updatedAt := createdAt.Add(duration)
+		assert.NoError(t, repository.ExecutionRepo().Create(context.Background(), models.Execution{
+			ExecutionKey: models.ExecutionKey{
+				Project: "project",
+				Domain:  "domain",
+				Name:    name,
+			},
+			Phase:              core.WorkflowExecution_SUCCEEDED.String(),
+			Spec:               []byte{},
+			Closure:            []byte{},
+			ExecutionCreatedAt: &createdAt,
+			ExecutionUpdatedAt: &updatedAt,
+		}))
+	}
+
+	sortParameter, err := common.NewSortParameter(admin.Sort{Key: "duration", Direction: admin.Sort_DESCENDING})
+	assert.NoError(t, err)
+	projectFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "project", "project")
+	assert.NoError(t, err)
+	executions, err := repository.ExecutionRepo().List(context.Background(), repositoryInterfaces.ListResourceInput{
+		Limit:         10,
+		SortParameter: sortParameter,
+		InlineFilters: []common.InlineFilter{projectFilter},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, executions.Executions, 2)
+	assert.Equal(t, "long", executions.Executions[0].Name)
+	assert.Equal(t, "short", executions.Executions[1].Name)
+}
+
+func TestSQLite_ExecutionStatistics(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	createExecution := func(project, name string, phase core.WorkflowExecution_Phase, createdAt time.Time,
+		duration time.Duration) {
+		assert.NoError(t, repository.ExecutionRepo().Create(context.Background(), models.Execution{
+			BaseModel: models.BaseModel{
+				CreatedAt: createdAt,
+			},
+			ExecutionKey: models.ExecutionKey{
+				Project: project,
+				Domain:  "domain",
+				Name:    name,
+			},
+			Phase:    phase.String(),
+			Spec:     []byte{},
+			Duration: duration,
+		}))
+	}
+	createExecution("project", "a", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(time.Hour), time.Minute)
+	createExecution("project", "b", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(2*time.Hour), 2*time.Minute)
+	createExecution("project", "c", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(3*time.Hour), 3*time.Minute)
+	createExecution("project", "d", core.WorkflowExecution_FAILED, statisticsStart.Add(23*time.Hour), 10*time.Minute)
+	createExecution("project", "e", core.WorkflowExecution_ABORTED, statisticsStart.Add(50*time.Hour), 30*time.Second)
+	// Executions which are still running, of other projects or outside of the time range aren't aggregated.
+	createExecution("project", "f", core.WorkflowExecution_RUNNING, statisticsStart.Add(time.Hour), 0)
+	createExecution("other", "g", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(time.Hour), time.Hour)
+	createExecution("project", "h", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(-time.Hour), time.Hour)
+	createExecution("project", "i", core.WorkflowExecution_SUCCEEDED, statisticsStart.Add(60*time.Hour), time.Hour)
+
+	statistics, err := getStatisticsManagerForTest(repository).GetExecutionStatistics(context.Background(),
+		getExecutionStatisticsRequestForTest())
+	assert.NoError(t, err)
+	assert.True(t, statistics.Approximate)
+	assert.Len(t, statistics.Buckets, 3)
+	assert.Equal(t, map[string]int64{"SUCCEEDED": 3, "FAILED": 1}, statistics.Buckets[0].PhaseCounts)
+	assert.Equal(t, 0.75, statistics.Buckets[0].SuccessRate)
+	// Each duration falls into a class of its own, such that the approximation is exact.
+	assert.Equal(t, map[string]time.Duration{
+		"p50": 150 * time.Second,
+		"p90": 474 * time.Second,
+		"p95": 537 * time.Second,
+		"p99": 587400 * time.Millisecond,
+	}, statistics.Buckets[0].DurationPercentiles)
+	assert.Equal(t, int64(0), statistics.Buckets[1].Total)
+	assert.Empty(t, statistics.Buckets[1].DurationPercentiles)
+	assert.Equal(t, map[string]int64{"ABORTED": 1}, statistics.Buckets[2].PhaseCounts)
+	assert.Equal(t, float64(0), statistics.Buckets[2].SuccessRate)
+	assert.Equal(t, 30*time.Second, statistics.Buckets[2].DurationPercentiles["p99"])
+
+	// Statistics across projects only aggregate the executions of the projects the caller can read.
+	request := getExecutionStatisticsRequestForTest()
+	request.Project = ""
+	statistics, err = getStatisticsManagerForTest(repository).GetExecutionStatistics(
+		context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{"other"}), request)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"SUCCEEDED": 1}, statistics.Buckets[0].PhaseCounts)
+	assert.Zero(t, statistics.Buckets[2].Total)
+}
+
+func TestSQLite_SearchNamedEntities(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	registerProjectForSQLiteTest(t, repository, "project")
+	taskManager := NewTaskManager(repository, getMockConfigForTaskTest(), getMockTaskCompiler(),
+		mockScope.NewTestScope())
+	for _, name := range []string{"my_task", "myxtask", "other_my_task"} {
+		request := testutils.GetValidTaskRequestWithOverrides("project", "domain", name, "version")
+		_, err := taskManager.CreateTask(context.Background(), request)
+		assert.NoError(t, err)
+	}
+
+	namedEntityManager := NewNamedEntityManager(repository, getMockConfigForTaskTest(), mockScope.NewTestScope())
+	response, err := namedEntityManager.SearchNamedEntities(context.Background(), interfaces.SearchNamedEntitiesRequest{
+		Query: "MY_",
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	// The underscore is matched literally, and names starting with the query rank first.
+	assert.Len(t, response.Entities, 2)
+	assert.Equal(t, "my_task", response.Entities[0].Id.Name)
+	assert.Equal(t, "other_my_task", response.Entities[1].Id.Name)
+	assert.Equal(t, core.ResourceType_TASK, response.Entities[0].ResourceType)
+}
+
+// Counts the rows of a table which belong to an execution.
+func countExecutionRowsForSQLiteTest(t *testing.T, db *gorm.DB, table, name string) int64 {
+	var count int64
+	assert.NoError(t, db.Table(table).Where("execution_project = ? AND execution_domain = ? AND execution_name = ?",
+		"project", "domain", name).Count(&count).Error)
+	return count
+}
+
+func TestSQLite_PurgeExecutions(t *testing.T) {
+	db, repository := getSQLiteDBAndRepositoryForTest(t)
+	ctx := context.Background()
+	oldCreatedAt := time.Now().Add(-48 * time.Hour)
+	createExecution := func(name string, phase core.WorkflowExecution_Phase, createdAt time.Time, sourceExecutionID,
+		parentNodeExecutionID uint) uint {
+		executionKey := models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    name,
+		}
+		assert.NoError(t, repository.ExecutionRepo().Create(ctx, models.Execution{
+			BaseModel: models.BaseModel{
+				CreatedAt: createdAt,
+			},
+			ExecutionKey:          executionKey,
+			Phase:                 phase.String(),
+			Spec:                  []byte{},
+			SourceExecutionID:     sourceExecutionID,
+			ParentNodeExecutionID: parentNodeExecutionID,
+			Tags: []models.ExecutionTag{
+				{ExecutionKey: executionKey, Tag: "tag"},
+			},
+		}))
+		assert.NoError(t, repository.ExecutionEventRepo().Create(ctx, models.ExecutionEvent{
+			ExecutionKey: executionKey,
+			Phase:        core.WorkflowExecution_SUCCEEDED.String(),
+		}))
+		// A node execution, the task execution it ran and a node execution the task execution launched in turn.
+		nodeExecution := &models.NodeExecution{
+			NodeExecutionKey: models.NodeExecutionKey{
+				ExecutionKey: executionKey,
+				NodeID:       "node",
+			},
+		}
+		assert.NoError(t, repository.NodeExecutionRepo().Create(ctx, nodeExecution))
+		assert.NoError(t, repository.NodeExecutionEventRepo().Create(ctx, models.NodeExecutionEvent{
+			NodeExecutionKey: nodeExecution.NodeExecutionKey,
+			Phase:            core.NodeExecution_SUCCEEDED.String(),
+		}))
+		retryAttempt := uint32(0)
+		assert.NoError(t, repository.TaskExecutionRepo().Create(ctx, models.TaskExecution{
+			TaskExecutionKey: models.TaskExecutionKey{
+				TaskKey: models.TaskKey{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "task",
+					Version: "version",
+				},
+				NodeExecutionKey: nodeExecution.NodeExecutionKey,
+				RetryAttempt:     &retryAttempt,
+			},
+		}))
+		taskExecution, err := repository.TaskExecutionRepo().Get(ctx, repositoryInterfaces.GetTaskExecutionInput{
+			TaskExecutionID: core.TaskExecutionIdentifier{
+				TaskId: &core.Identifier{
+					ResourceType: core.ResourceType_TASK,
+					Project:      "project",
+					Domain:       "domain",
+					Name:         "task",
+					Version:      "version",
+				},
+				NodeExecutionId: &core.NodeExecutionIdentifier{
+					NodeId: "node",
+					ExecutionId: &core.WorkflowExecutionIdentifier{
+						Project: "project",
+						Domain:  "domain",
+						Name:    name,
+					},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, repository.NodeExecutionRepo().Create(ctx, &models.NodeExecution{
+			NodeExecutionKey: models.NodeExecutionKey{
+				ExecutionKey: executionKey,
+				NodeID:       "node-child",
+			},
+			ParentID:              &nodeExecution.ID,
+			ParentTaskExecutionID: &taskExecution.ID,
+		}))
+		execution, err := repository.ExecutionRepo().Get(ctx, repositoryInterfaces.Identifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    name,
+		})
+		assert.NoError(t, err)
+		return nodeExecution.ID + execution.ID*0
+	}
+	getExecutionID := func(name string) uint {
+		execution, err := repository.ExecutionRepo().Get(ctx, repositoryInterfaces.Identifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    name,
+		})
+		assert.NoError(t, err)
+		return execution.ID
+	}
+
+	succeeded := core.WorkflowExecution_SUCCEEDED
+	createExecution("expired", succeeded, oldCreatedAt, 0, 0)
+	createExecution("recovered", succeeded, oldCreatedAt, 0, 0)
+	createExecution("recovery", succeeded, time.Now(), getExecutionID("recovered"), 0)
+	parentNodeExecutionID := createExecution("parent", succeeded, oldCreatedAt, 0, 0)
+	createExecution("launched", succeeded, time.Now(), 0, parentNodeExecutionID)
+	createExecution("recent", succeeded, time.Now(), 0, 0)
+	// Executions which haven't terminated may still be running in their cluster.
+	createExecution("running", core.WorkflowExecution_RUNNING, oldCreatedAt, 0, 0)
+	createExecution("queued", core.WorkflowExecution_QUEUED, oldCreatedAt, 0, 0)
+
+	executionManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(ctx),
+		mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil, nil,
+		&eventWriterMocks.WorkflowExecutionEventWriter{}, nil, nil, nil)
+	request := interfaces.PurgeExecutionsRequest{
+		Domain:        "domain",
+		CreatedBefore: time.Now().Add(-24 * time.Hour),
+		BatchSize:     1,
+		DryRun:        true,
+	}
+	response, err := executionManager.PurgeExecutions(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Executions)
+	assert.Equal(t, map[string]int64{
+		"executions":            1,
+		"execution_tags":        1,
+		"execution_events":      1,
+		"node_executions":       2,
+		"node_execution_events": 1,
+		"task_executions":       1,
+	}, response.DeletedRows)
+	assert.Equal(t, int64(2), countExecutionRowsForSQLiteTest(t, db, "node_executions", "expired"))
+
+	request.DryRun = false
+	response, err = executionManager.PurgeExecutions(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Executions)
+	// Nothing recorded about the purged execution is left behind.
+	for _, table := range []string{"executions", "execution_tags", "execution_events", "node_executions",
+		"node_execution_events", "task_executions"} {
+		assert.Zero(t, countExecutionRowsForSQLiteTest(t, db, table, "expired"), table)
+	}
+	// Executions which others were recovered from or launched by are kept, along with everything recorded about them,
+	// as are those which haven't terminated.
+	for _, name := range []string{"recovered", "parent", "recovery", "launched", "recent", "running", "queued"} {
+		assert.Equal(t, int64(1), countExecutionRowsForSQLiteTest(t, db, "executions", name), name)
+		assert.Equal(t, int64(2), countExecutionRowsForSQLiteTest(t, db, "node_executions", name), name)
+		assert.Equal(t, int64(1), countExecutionRowsForSQLiteTest(t, db, "task_executions", name), name)
+	}
+}
+
+func TestSQLite_FailInterruptedLaunches(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	ctx := context.Background()
+	oldCreatedAt := time.Now().Add(-2 * time.Hour)
+	createExecution := func(name string, createdAt time.Time, phase core.WorkflowExecution_Phase,
+		launchAttempted bool) {
+		closure, err := proto.Marshal(&admin.ExecutionClosure{
+			Phase: phase,
+		})
+		assert.NoError(t, err)
+		spec, err := proto.Marshal(&admin.ExecutionSpec{})
+		assert.NoError(t, err)
+		assert.NoError(t, repository.ExecutionRepo().Create(ctx, models.Execution{
+			BaseModel: models.BaseModel{
+				CreatedAt: createdAt,
+			},
+			ExecutionKey: models.ExecutionKey{
+				Project: "project",
+				Domain:  "domain",
+				Name:    name,
+			},
+			Phase:           phase.String(),
+			Closure:         closure,
+			Spec:            spec,
+			LaunchAttempted: launchAttempted,
+		}))
+	}
+	createExecution("interrupted", oldCreatedAt, core.WorkflowExecution_QUEUED, false)
+	// Launched into a cluster without an id, as in cluster deployments are, with propeller yet to report on it.
+	createExecution("launched", oldCreatedAt, core.WorkflowExecution_QUEUED, true)
+	createExecution("recent", time.Now(), core.WorkflowExecution_QUEUED, false)
+	createExecution("running", oldCreatedAt, core.WorkflowExecution_RUNNING, true)
+
+	mockDbEventWriter := &eventWriterMocks.WorkflowExecutionEventWriter{}
+	mockDbEventWriter.On("Write", mock.Anything)
+	executionManager := NewExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(ctx),
+		mockScope.NewTestScope(), mockScope.NewTestScope(), &mockPublisher, mockExecutionRemoteURL, nil, nil,
+		&mockPublisher, mockDbEventWriter, nil, nil, nil)
+	failed, err := executionManager.FailInterruptedLaunches(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failed)
+
+	for name, phase := range map[string]core.WorkflowExecution_Phase{
+		"interrupted": core.WorkflowExecution_FAILED,
+		"launched":    core.WorkflowExecution_QUEUED,
+		"recent":      core.WorkflowExecution_QUEUED,
+		"running":     core.WorkflowExecution_RUNNING,
+	} {
+		execution, err := executionManager.GetExecution(ctx, admin.WorkflowExecutionGetRequest{
+			Id: &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: name},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, phase, execution.GetClosure().GetPhase(), name)
+		if phase == core.WorkflowExecution_FAILED {
+			assert.Equal(t, "LaunchInterrupted", execution.GetClosure().GetError().GetCode())
+			assert.Equal(t, core.ExecutionError_SYSTEM, execution.GetClosure().GetError().GetKind())
+		}
+	}
+	// Failed executions are no longer interrupted.
+	failed, err = executionManager.FailInterruptedLaunches(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Zero(t, failed)
+}
+
+func TestSQLite_ResourcePrecedence(t *testing.T) {
+	ctx := context.Background()
+	repository := getSQLiteRepositoryForTest(t)
+	projectManager := NewProjectManager(repository, getMockConfigForTaskTest())
+	for project, org := range map[string]string{"project": "acme", "sibling": "acme", "other": "globex", "unlabelled": ""} {
+		var labels *admin.Labels
+		if len(org) > 0 {
+			labels = &admin.Labels{Values: map[string]string{"org": org}}
+		}
+		_, err := projectManager.CreateProject(ctx, admin.ProjectRegisterRequest{
+			Project: &admin.Project{Id: project, Name: project, Labels: labels},
+		})
+		assert.NoError(t, err)
+	}
+	applicationConfig := testutils.GetApplicationConfigWithDefaultDomains()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		OrgLabel: "org",
+	})
+	resourceManager := resources.NewResourceManager(repository, runtimeMocks.NewMockConfigurationProvider(
+		applicationConfig, nil, nil, nil, nil, nil))
+
+	taskResources := func(cpu string) *admin.MatchingAttributes {
+		return &admin.MatchingAttributes{
+			Target: &admin.MatchingAttributes_TaskResourceAttributes{
+				TaskResourceAttributes: &admin.TaskResourceAttributes{
+					Defaults: &admin.TaskResourceSpec{Cpu: cpu},
+				},
+			},
+		}
+	}
+	// Returns the level the task resources of the launch plan resolve from, along with their cpu.
+	resolve := func(project, domain string) (interfaces.ResourceLevel, string) {
+		response, err := resourceManager.GetResource(ctx, interfaces.ResourceRequest{
+			Project:      project,
+			Domain:       domain,
+			Workflow:     "workflow",
+			LaunchPlan:   "launch_plan",
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+		if err != nil {
+			assert.Equal(t, codes.NotFound, err.(errors.FlyteAdminError).Code())
+			return "", ""
+		}
+		return response.Level, response.Attributes.GetTaskResourceAttributes().GetDefaults().GetCpu()
+	}
+	type resolution struct {
+		project, domain string
+		level           interfaces.ResourceLevel
+		cpu             string
+	}
+	steps := []struct {
+		name     string
+		apply    func() error
+		expected []resolution
+	}{
+		{
+			name:  "nothing set",
+			apply: func() error { return nil },
+			expected: []resolution{
+				{"project", "domain", "", ""},
+			},
+		},
+		{
+			name: "domain",
+			apply: func() error {
+				return resourceManager.UpdateScopedAttributes(ctx, interfaces.ScopedAttributesUpdateRequest{
+					Scope:              interfaces.AttributesScope{Domain: "domain"},
+					MatchingAttributes: taskResources("1"),
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelDomain, "1"},
+				{"project", "development", "", ""},
+				{"unlabelled", "domain", interfaces.ResourceLevelDomain, "1"},
+			},
+		},
+		{
+			name: "org",
+			apply: func() error {
+				return resourceManager.UpdateScopedAttributes(ctx, interfaces.ScopedAttributesUpdateRequest{
+					Scope:              interfaces.AttributesScope{Org: "acme"},
+					MatchingAttributes: taskResources("2"),
+				})
+			},
+			expected: []resolution{
+				// The org takes precedence over the domain, and applies to its projects in all domains.
+				{"project", "domain", interfaces.ResourceLevelOrg, "2"},
+				{"project", "development", interfaces.ResourceLevelOrg, "2"},
+				{"sibling", "domain", interfaces.ResourceLevelOrg, "2"},
+				{"other", "domain", interfaces.ResourceLevelDomain, "1"},
+				{"unlabelled", "domain", interfaces.ResourceLevelDomain, "1"},
+			},
+		},
+		{
+			name: "project",
+			apply: func() error {
+				return resourceManager.UpdateScopedAttributes(ctx, interfaces.ScopedAttributesUpdateRequest{
+					Scope:              interfaces.AttributesScope{Project: "project"},
+					MatchingAttributes: taskResources("3"),
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelProject, "3"},
+				{"project", "development", interfaces.ResourceLevelProject, "3"},
+				{"sibling", "domain", interfaces.ResourceLevelOrg, "2"},
+			},
+		},
+		{
+			name: "project domain",
+			apply: func() error {
+				_, err := resourceManager.UpdateProjectDomainAttributes(ctx, admin.ProjectDomainAttributesUpdateRequest{
+					Attributes: &admin.ProjectDomainAttributes{
+						Project:            "project",
+						Domain:             "domain",
+						MatchingAttributes: taskResources("4"),
+					},
+				})
+				return err
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelProjectDomain, "4"},
+				{"project", "development", interfaces.ResourceLevelProject, "3"},
+			},
+		},
+		{
+			name: "workflow",
+			apply: func() error {
+				_, err := resourceManager.UpdateWorkflowAttributes(ctx, admin.WorkflowAttributesUpdateRequest{
+					Attributes: &admin.WorkflowAttributes{
+						Project:            "project",
+						Domain:             "domain",
+						Workflow:           "workflow",
+						MatchingAttributes: taskResources("5"),
+					},
+				})
+				return err
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelWorkflow, "5"},
+				{"project", "development", interfaces.ResourceLevelProject, "3"},
+			},
+		},
+		{
+			name: "launch plan",
+			apply: func() error {
+				attributes, err := proto.Marshal(taskResources("6"))
+				assert.NoError(t, err)
+				return repository.ResourceRepo().CreateOrUpdate(ctx, models.Resource{
+					Project:      "project",
+					Domain:       "domain",
+					Workflow:     "workflow",
+					LaunchPlan:   "launch_plan",
+					ResourceType: admin.MatchableResource_TASK_RESOURCE.String(),
+					Priority:     models.ResourcePriorityLaunchPlanLevel,
+					Attributes:   attributes,
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelLaunchPlan, "6"},
+			},
+		},
+		{
+			name: "without launch plan",
+			apply: func() error {
+				return repository.ResourceRepo().Delete(ctx, repositoryInterfaces.ResourceID{
+					Project:      "project",
+					Domain:       "domain",
+					Workflow:     "workflow",
+					LaunchPlan:   "launch_plan",
+					ResourceType: admin.MatchableResource_TASK_RESOURCE.String(),
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelWorkflow, "5"},
+			},
+		},
+		{
+			name: "without workflow",
+			apply: func() error {
+				_, err := resourceManager.DeleteWorkflowAttributes(ctx, admin.WorkflowAttributesDeleteRequest{
+					Project:      "project",
+					Domain:       "domain",
+					Workflow:     "workflow",
+					ResourceType: admin.MatchableResource_TASK_RESOURCE,
+				})
+				return err
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelProjectDomain, "4"},
+			},
+		},
+		{
+			name: "without project domain",
+			apply: func() error {
+				_, err := resourceManager.DeleteProjectDomainAttributes(ctx, admin.ProjectDomainAttributesDeleteRequest{
+					Project:      "project",
+					Domain:       "domain",
+					ResourceType: admin.MatchableResource_TASK_RESOURCE,
+				})
+				return err
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelProject, "3"},
+			},
+		},
+		{
+			name: "without project",
+			apply: func() error {
+				return resourceManager.DeleteScopedAttributes(ctx, interfaces.ScopedAttributesDeleteRequest{
+					Scope:        interfaces.AttributesScope{Project: "project"},
+					ResourceType: admin.MatchableResource_TASK_RESOURCE,
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelOrg, "2"},
+			},
+		},
+		{
+			name: "without org",
+			apply: func() error {
+				return resourceManager.DeleteScopedAttributes(ctx, interfaces.ScopedAttributesDeleteRequest{
+					Scope:        interfaces.AttributesScope{Org: "acme"},
+					ResourceType: admin.MatchableResource_TASK_RESOURCE,
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", interfaces.ResourceLevelDomain, "1"},
+				{"project", "development", "", ""},
+			},
+		},
+		{
+			name: "without domain",
+			apply: func() error {
+				return resourceManager.DeleteScopedAttributes(ctx, interfaces.ScopedAttributesDeleteRequest{
+					Scope:        interfaces.AttributesScope{Domain: "domain"},
+					ResourceType: admin.MatchableResource_TASK_RESOURCE,
+				})
+			},
+			expected: []resolution{
+				{"project", "domain", "", ""},
+			},
+		},
+	}
+	for _, step := range steps {
+		assert.NoError(t, step.apply(), step.name)
+		for _, expected := range step.expected {
+			level, cpu := resolve(expected.project, expected.domain)
+			assert.Equal(t, expected.level, level, "%s: %s-%s", step.name, expected.project, expected.domain)
+			assert.Equal(t, expected.cpu, cpu, "%s: %s-%s", step.name, expected.project, expected.domain)
+		}
+	}
+}
+
+func TestSQLite_ScopedAttributes(t *testing.T) {
+	ctx := context.Background()
+	repository := getSQLiteRepositoryForTest(t)
+	registerProjectForSQLiteTest(t, repository, "project")
+	applicationConfig := testutils.GetApplicationConfigWithDefaultDomains()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		OrgLabel: "org",
+	})
+	resourceManager := resources.NewResourceManager(repository, runtimeMocks.NewMockConfigurationProvider(
+		applicationConfig, nil, nil, nil, nil, nil))
+
+	for _, cpu := range []string{"1", "2"} {
+		assert.NoError(t, resourceManager.UpdateScopedAttributes(ctx, interfaces.ScopedAttributesUpdateRequest{
+			Scope: interfaces.AttributesScope{Org: "acme"},
+			MatchingAttributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_TaskResourceAttributes{
+					TaskResourceAttributes: &admin.TaskResourceAttributes{
+						Defaults: &admin.TaskResourceSpec{Cpu: cpu},
+					},
+				},
+			},
+		}))
+	}
+	// Updating the attributes of a scope replaces them.
+	response, err := resourceManager.GetScopedAttributes(ctx, interfaces.ScopedAttributesGetRequest{
+		Scope:        interfaces.AttributesScope{Org: "acme"},
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, interfaces.ResourceLevelOrg, response.Level)
+	assert.Equal(t, "2", response.MatchingAttributes.GetTaskResourceAttributes().GetDefaults().GetCpu())
+	// The attributes of orgs don't apply to projects in listings, which can't tell what they're set for.
+	list, err := resourceManager.ListAll(ctx, admin.ListMatchableAttributesRequest{
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, list.Configurations)
+
+	_, err = resourceManager.GetScopedAttributes(ctx, interfaces.ScopedAttributesGetRequest{
+		Scope:        interfaces.AttributesScope{Project: "project"},
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	})
+	assert.Equal(t, codes.NotFound, err.(errors.FlyteAdminError).Code())
+
+	// Plugin overrides of a scope are merged.
+	for _, taskType := range []string{"python", "hive"} {
+		assert.NoError(t, resourceManager.UpdateScopedAttributes(ctx, interfaces.ScopedAttributesUpdateRequest{
+			Scope: interfaces.AttributesScope{Project: "project"},
+			MatchingAttributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_PluginOverrides{
+					PluginOverrides: &admin.PluginOverrides{
+						Overrides: []*admin.PluginOverride{{TaskType: taskType, PluginId: []string{taskType}}},
+					},
+				},
+			},
+		}))
+	}
+	response, err = resourceManager.GetScopedAttributes(ctx, interfaces.ScopedAttributesGetRequest{
+		Scope:        interfaces.AttributesScope{Project: "project"},
+		ResourceType: admin.MatchableResource_PLUGIN_OVERRIDE,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, interfaces.ResourceLevelProject, response.Level)
+	assert.Len(t, response.MatchingAttributes.GetPluginOverrides().Overrides, 2)
+}
+
+func TestSQLite_ConcurrentLaunchPlanActivations(t *testing.T) {
+	repository := getSQLiteRepositoryForTest(t)
+	ctx := context.Background()
+	spec := testutils.GetLaunchPlanRequest().Spec
+	spec.EntityMetadata = &admin.LaunchPlanMetadata{
+		Schedule: &admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronExpression{
+				CronExpression: "* * * * *",
+			},
+		},
+	}
+	specBytes, err := proto.Marshal(spec)
+	assert.NoError(t, err)
+	closureBytes, err := proto.Marshal(&admin.LaunchPlanClosure{})
+	assert.NoError(t, err)
+	inactive := int32(admin.LaunchPlanState_INACTIVE)
+	getLaunchPlanKey := func(i int) models.LaunchPlanKey {
+		return models.LaunchPlanKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+			Version: fmt.Sprintf("version-%d", i),
+		}
+	}
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, repository.LaunchPlanRepo().Create(ctx, models.LaunchPlan{
+			LaunchPlanKey: getLaunchPlanKey(i),
+			Spec:          specBytes,
+			Closure:       closureBytes,
+			State:         &inactive,
+		}))
+	}
+
+	var mutex sync.Mutex
+	schedules := make(map[string]bool)
+	scheduler := scheduleMocks.NewMockEventScheduler()
+	scheduler.(*scheduleMocks.MockEventScheduler).SetAddScheduleFunc(
+		func(ctx context.Context, input scheduleInterfaces.AddScheduleInput) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			schedules[input.Identifier.Version] = true
+			return nil
+		})
+	scheduler.(*scheduleMocks.MockEventScheduler).SetRemoveScheduleFunc(
+		func(ctx context.Context, input scheduleInterfaces.RemoveScheduleInput) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(schedules, input.Identifier.Version)
+			return nil
+		})
+	launchPlanManager := NewLaunchPlanManager(repository, getMockConfigForLpTest(), scheduler,
+		mockScope.NewTestScope(), nil)
+
+	// Concurrent activations of different versions each deactivate the version active when they're applied, so that
+	// a single version ends up active, and scheduled, however they interleave.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(launchPlanKey models.LaunchPlanKey) {
+			defer wg.Done()
+			_, err := launchPlanManager.UpdateLaunchPlan(ctx, admin.LaunchPlanUpdateRequest{
+				Id: &core.Identifier{
+					ResourceType: core.ResourceType_LAUNCH_PLAN,
+					Project:      launchPlanKey.Project,
+					Domain:       launchPlanKey.Domain,
+					Name:         launchPlanKey.Name,
+					Version:      launchPlanKey.Version,
+				},
+				State: admin.LaunchPlanState_ACTIVE,
+			})
+			assert.NoError(t, err)
+		}(getLaunchPlanKey(i))
+	}
+	wg.Wait()
+
+	var activeVersions []string
+	for i := 0; i < 10; i++ {
+		launchPlanKey := getLaunchPlanKey(i)
+		launchPlan, err := repository.LaunchPlanRepo().Get(ctx, repositoryInterfaces.Identifier{
+			Project: launchPlanKey.Project,
+			Domain:  launchPlanKey.Domain,
+			Name:    launchPlanKey.Name,
+			Version: launchPlanKey.Version,
+		})
+		assert.NoError(t, err)
+		if *launchPlan.State == int32(admin.LaunchPlanState_ACTIVE) {
+			activeVersions = append(activeVersions, launchPlan.Version)
+		}
+	}
+	if !assert.Len(t, activeVersions, 1) {
+		return
+	}
+	assert.Equal(t, map[string]bool{activeVersions[0]: true}, schedules)
+
+	// The database refuses a second active version.
+	otherLaunchPlanKey := getLaunchPlanKey(0)
+	if otherLaunchPlanKey.Version == activeVersions[0] {
+		otherLaunchPlanKey = getLaunchPlanKey(1)
+	}
+	otherVersion, err := repository.LaunchPlanRepo().Get(ctx, repositoryInterfaces.Identifier{
+		Project: otherLaunchPlanKey.Project,
+		Domain:  otherLaunchPlanKey.Domain,
+		Name:    otherLaunchPlanKey.Name,
+		Version: otherLaunchPlanKey.Version,
+	})
+	assert.NoError(t, err)
+	active := int32(admin.LaunchPlanState_ACTIVE)
+	otherVersion.State = &active
+	err = repository.LaunchPlanRepo().Update(ctx, otherVersion)
+	if assert.Error(t, err) {
+		assert.Equal(t, codes.AlreadyExists, err.(errors.FlyteAdminError).Code())
+	}
+}
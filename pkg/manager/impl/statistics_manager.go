@@ -0,0 +1,173 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// The duration percentiles reported for each bucket, along with the keys they're reported under.
+var executionStatisticsPercentiles = []float64{0.5, 0.9, 0.95, 0.99}
+var executionStatisticsPercentileKeys = []string{"p50", "p90", "p95", "p99"}
+
+type cachedExecutionStatistics struct {
+	statistics *interfaces.ExecutionStatistics
+	cachedAt   time.Time
+}
+
+type StatisticsManager struct {
+	db     repositories.RepositoryInterface
+	config runtimeInterfaces.Configuration
+	mutex  sync.Mutex
+	// Recent results keyed by request, nil when caching is disabled.
+	cache  *simplelru.LRU
+	_clock clock.Clock
+}
+
+// Statistics limited to the projects a caller can read are keyed by those projects too, such that they're only served
+// to callers who can read the same projects.
+func getExecutionStatisticsCacheKey(request interfaces.ExecutionStatisticsRequest, projects []string) string {
+	return fmt.Sprintf("%s/%s/%d/%d/%d/%s", request.Project, request.Domain, request.Start.UnixNano(),
+		request.End.UnixNano(), request.BucketSize, strings.Join(projects, ","))
+}
+
+func (m *StatisticsManager) getCachedExecutionStatistics(key string) *interfaces.ExecutionStatistics {
+	if m.cache == nil {
+		return nil
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	value, ok := m.cache.Get(key)
+	if !ok {
+		return nil
+	}
+	cached := value.(cachedExecutionStatistics)
+	if m._clock.Since(cached.cachedAt) >= m.config.ApplicationConfiguration().GetTopLevelConfig().
+		GetExecutionStatisticsConfig().CacheTTL.Duration {
+		m.cache.Remove(key)
+		return nil
+	}
+	return cached.statistics
+}
+
+func (m *StatisticsManager) cacheExecutionStatistics(key string, statistics *interfaces.ExecutionStatistics) {
+	if m.cache == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.cache.Add(key, cachedExecutionStatistics{
+		statistics: statistics,
+		cachedAt:   m._clock.Now(),
+	})
+}
+
+// Returns every bucket of the time range, filling in those the repository found executions in.
+func getExecutionStatisticsBuckets(request interfaces.ExecutionStatisticsRequest,
+	output repoInterfaces.ExecutionStatisticsOutput) []interfaces.ExecutionStatisticsBucket {
+	var buckets []interfaces.ExecutionStatisticsBucket
+	for start := request.Start; start.Before(request.End); start = start.Add(request.BucketSize) {
+		end := start.Add(request.BucketSize)
+		if end.After(request.End) {
+			end = request.End
+		}
+		buckets = append(buckets, interfaces.ExecutionStatisticsBucket{
+			Start:               start,
+			End:                 end,
+			PhaseCounts:         make(map[string]int64),
+			DurationPercentiles: make(map[string]time.Duration),
+		})
+	}
+	for _, outputBucket := range output.Buckets {
+		if outputBucket.Index < 0 || outputBucket.Index >= int64(len(buckets)) {
+			continue
+		}
+		bucket := &buckets[outputBucket.Index]
+		for phase, count := range outputBucket.PhaseCounts {
+			bucket.PhaseCounts[phase] = count
+			bucket.Total += count
+		}
+		if bucket.Total > 0 {
+			bucket.SuccessRate = float64(bucket.PhaseCounts[core.WorkflowExecution_SUCCEEDED.String()]) /
+				float64(bucket.Total)
+		}
+		for i, duration := range outputBucket.DurationPercentiles {
+			bucket.DurationPercentiles[executionStatisticsPercentileKeys[i]] = duration
+		}
+	}
+	return buckets
+}
+
+func (m *StatisticsManager) GetExecutionStatistics(ctx context.Context, request interfaces.ExecutionStatisticsRequest) (
+	*interfaces.ExecutionStatistics, error) {
+	statisticsConfig := m.config.ApplicationConfiguration().GetTopLevelConfig().GetExecutionStatisticsConfig()
+	if err := validation.ValidateExecutionStatisticsRequest(request, statisticsConfig.MaxBuckets); err != nil {
+		logger.Debugf(ctx, "received invalid execution statistics request [%+v]: %v", request, err)
+		return nil, err
+	}
+	// Callers who can't read every project only get the statistics of the projects they can read.
+	var projects []string
+	if len(request.Project) == 0 {
+		var limited bool
+		if projects, limited = auth.AuthorizedProjectsFromContext(ctx); limited && len(projects) == 0 {
+			return &interfaces.ExecutionStatistics{
+				Buckets: getExecutionStatisticsBuckets(request, repoInterfaces.ExecutionStatisticsOutput{}),
+			}, nil
+		}
+	}
+	key := getExecutionStatisticsCacheKey(request, projects)
+	if statistics := m.getCachedExecutionStatistics(key); statistics != nil {
+		return statistics, nil
+	}
+
+	output, err := m.db.ExecutionRepo().GetStatistics(ctx, repoInterfaces.ExecutionStatisticsInput{
+		Project:     request.Project,
+		Projects:    projects,
+		Domain:      request.Domain,
+		Start:       request.Start,
+		End:         request.End,
+		BucketSize:  request.BucketSize,
+		Phases:      common.GetTerminalExecutionPhases(),
+		Percentiles: executionStatisticsPercentiles,
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to aggregate execution statistics for [%+v] with err: %v", request, err)
+		return nil, err
+	}
+	statistics := &interfaces.ExecutionStatistics{
+		Buckets:     getExecutionStatisticsBuckets(request, output),
+		Approximate: output.Approximate,
+	}
+	m.cacheExecutionStatistics(key, statistics)
+	return statistics, nil
+}
+
+func NewStatisticsManager(
+	db repositories.RepositoryInterface, config runtimeInterfaces.Configuration) interfaces.StatisticsInterface {
+	manager := &StatisticsManager{
+		db:     db,
+		config: config,
+		_clock: clock.New(),
+	}
+	cacheSize := config.ApplicationConfiguration().GetTopLevelConfig().GetExecutionStatisticsConfig().CacheSize
+	if cacheSize > 0 {
+		// The size is positive, so creating the cache can't fail.
+		manager.cache, _ = simplelru.NewLRU(cacheSize, nil)
+	}
+	return manager
+}
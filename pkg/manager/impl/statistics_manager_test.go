@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+)
+
+var statisticsStart = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func getStatisticsManagerForTest(repository repositories.RepositoryInterface) *StatisticsManager {
+	applicationConfig := runtimeMocks.MockApplicationProvider{}
+	applicationConfig.SetTopLevelConfig(runtimeInterfaces.ApplicationConfig{
+		ExecutionStatistics: runtimeInterfaces.ExecutionStatisticsConfig{
+			MaxBuckets: 30,
+			CacheSize:  10,
+			CacheTTL:   config.Duration{Duration: time.Minute},
+		},
+	})
+	configProvider := runtimeMocks.NewMockConfigurationProvider(&applicationConfig, nil, nil, nil, nil, nil)
+	return NewStatisticsManager(repository, configProvider).(*StatisticsManager)
+}
+
+func getExecutionStatisticsRequestForTest() interfaces.ExecutionStatisticsRequest {
+	return interfaces.ExecutionStatisticsRequest{
+		Project:    "project",
+		Start:      statisticsStart,
+		End:        statisticsStart.Add(60 * time.Hour),
+		BucketSize: 24 * time.Hour,
+	}
+}
+
+func TestGetExecutionStatistics(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	var queries int
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetStatisticsCallback(
+		func(ctx context.Context, input repoInterfaces.ExecutionStatisticsInput) (
+			repoInterfaces.ExecutionStatisticsOutput, error) {
+			queries++
+			assert.Equal(t, repoInterfaces.ExecutionStatisticsInput{
+				Project:     "project",
+				Start:       statisticsStart,
+				End:         statisticsStart.Add(60 * time.Hour),
+				BucketSize:  24 * time.Hour,
+				Phases:      []string{"SUCCEEDED", "FAILED", "ABORTED", "TIMED_OUT"},
+				Percentiles: []float64{0.5, 0.9, 0.95, 0.99},
+			}, input)
+			return repoInterfaces.ExecutionStatisticsOutput{
+				Buckets: []repoInterfaces.ExecutionStatisticsBucket{
+					{
+						Index:       1,
+						PhaseCounts: map[string]int64{"SUCCEEDED": 3, "FAILED": 1},
+						DurationPercentiles: []time.Duration{
+							time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute},
+					},
+				},
+			}, nil
+		})
+	statisticsManager := getStatisticsManagerForTest(repository)
+	mockClock := clock.NewMock()
+	statisticsManager._clock = mockClock
+
+	statistics, err := statisticsManager.GetExecutionStatistics(context.Background(),
+		getExecutionStatisticsRequestForTest())
+	assert.NoError(t, err)
+	assert.False(t, statistics.Approximate)
+	assert.Equal(t, []interfaces.ExecutionStatisticsBucket{
+		{
+			Start:               statisticsStart,
+			End:                 statisticsStart.Add(24 * time.Hour),
+			PhaseCounts:         map[string]int64{},
+			DurationPercentiles: map[string]time.Duration{},
+		},
+		{
+			Start:       statisticsStart.Add(24 * time.Hour),
+			End:         statisticsStart.Add(48 * time.Hour),
+			PhaseCounts: map[string]int64{"SUCCEEDED": 3, "FAILED": 1},
+			Total:       4,
+			SuccessRate: 0.75,
+			DurationPercentiles: map[string]time.Duration{
+				"p50": time.Minute,
+				"p90": 2 * time.Minute,
+				"p95": 3 * time.Minute,
+				"p99": 4 * time.Minute,
+			},
+		},
+		{
+			// The last bucket ends with the time range.
+			Start:               statisticsStart.Add(48 * time.Hour),
+			End:                 statisticsStart.Add(60 * time.Hour),
+			PhaseCounts:         map[string]int64{},
+			DurationPercentiles: map[string]time.Duration{},
+		},
+	}, statistics.Buckets)
+	assert.Equal(t, 1, queries)
+
+	cached, err := statisticsManager.GetExecutionStatistics(context.Background(),
+		getExecutionStatisticsRequestForTest())
+	assert.NoError(t, err)
+	assert.Equal(t, statistics, cached)
+	assert.Equal(t, 1, queries)
+
+	mockClock.Add(time.Minute)
+	_, err = statisticsManager.GetExecutionStatistics(context.Background(), getExecutionStatisticsRequestForTest())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, queries)
+}
+
+func TestGetExecutionStatistics_AuthorizedProjects(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	var statisticsInput *repoInterfaces.ExecutionStatisticsInput
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetStatisticsCallback(
+		func(ctx context.Context, input repoInterfaces.ExecutionStatisticsInput) (
+			repoInterfaces.ExecutionStatisticsOutput, error) {
+			statisticsInput = &input
+			return repoInterfaces.ExecutionStatisticsOutput{}, nil
+		})
+	statisticsManager := getStatisticsManagerForTest(repository)
+	request := getExecutionStatisticsRequestForTest()
+	request.Project = ""
+
+	ctx := context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{"p1", "p2"})
+	_, err := statisticsManager.GetExecutionStatistics(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, statisticsInput.Projects)
+
+	// Statistics cached for callers who can read other projects aren't served.
+	statisticsInput = nil
+	ctx = context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{"p1"})
+	_, err = statisticsManager.GetExecutionStatistics(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1"}, statisticsInput.Projects)
+
+	// Callers who can't read any project get empty buckets.
+	statisticsInput = nil
+	ctx = context.WithValue(context.Background(), auth.ContextKeyAuthorizedProjects, []string{})
+	statistics, err := statisticsManager.GetExecutionStatistics(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, statistics.Buckets, 3)
+	assert.Zero(t, statistics.Buckets[0].Total)
+	assert.Nil(t, statisticsInput)
+}
+
+func TestGetExecutionStatistics_Invalid(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetGetStatisticsCallback(
+		func(ctx context.Context, input repoInterfaces.ExecutionStatisticsInput) (
+			repoInterfaces.ExecutionStatisticsOutput, error) {
+			assert.Fail(t, "unexpected query for an invalid request")
+			return repoInterfaces.ExecutionStatisticsOutput{}, nil
+		})
+	request := getExecutionStatisticsRequestForTest()
+	request.BucketSize = time.Hour
+
+	_, err := getStatisticsManagerForTest(repository).GetExecutionStatistics(context.Background(), request)
+	assert.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
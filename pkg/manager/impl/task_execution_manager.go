@@ -3,7 +3,6 @@ package impl
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	notificationInterfaces "github.com/flyteorg/flyteadmin/pkg/async/notifications/interfaces"
 	"github.com/golang/protobuf/proto"
@@ -33,17 +32,19 @@ import (
 )
 
 type taskExecutionMetrics struct {
-	Scope                      promutils.Scope
-	ActiveTaskExecutions       prometheus.Gauge
-	TaskExecutionsCreated      prometheus.Counter
-	TaskExecutionsTerminated   prometheus.Counter
-	TaskExecutionEventsCreated prometheus.Counter
-	MissingTaskExecution       prometheus.Counter
-	MissingTaskDefinition      prometheus.Counter
-	ClosureSizeBytes           prometheus.Summary
-	TaskExecutionInputBytes    prometheus.Summary
-	TaskExecutionOutputBytes   prometheus.Summary
-	PublishEventError          prometheus.Counter
+	Scope                        promutils.Scope
+	ActiveTaskExecutions         prometheus.Gauge
+	TaskExecutionsCreated        prometheus.Counter
+	TaskExecutionsTerminated     prometheus.Counter
+	TaskExecutionEventsCreated   prometheus.Counter
+	MissingTaskExecution         prometheus.Counter
+	MissingTaskDefinition        prometheus.Counter
+	ClosureSizeBytes             prometheus.Summary
+	TaskExecutionInputBytes      prometheus.Summary
+	TaskExecutionOutputBytes     prometheus.Summary
+	PublishEventError            prometheus.Counter
+	DuplicateTaskExecutionEvents prometheus.Counter
+	StaleTaskExecutionEvents     prometheus.Counter
 }
 
 type TaskExecutionManager struct {
@@ -86,6 +87,7 @@ func (m *TaskExecutionManager) createTaskExecution(
 			Request:               request,
 			InlineEventDataPolicy: m.config.ApplicationConfiguration().GetRemoteDataConfig().InlineEventDataPolicy,
 			StorageClient:         m.storageClient,
+			InfoLimits:            m.getTaskExecutionInfoLimits(),
 		})
 	if err != nil {
 		logger.Debugf(ctx, "failed to transform task execution %+v into database model: %v", request.Event.TaskId, err)
@@ -103,12 +105,21 @@ func (m *TaskExecutionManager) createTaskExecution(
 	return *taskExecutionModel, nil
 }
 
+func (m *TaskExecutionManager) getTaskExecutionInfoLimits() transformers.TaskExecutionInfoLimits {
+	topLevelConfig := m.config.ApplicationConfiguration().GetTopLevelConfig()
+	return transformers.TaskExecutionInfoLimits{
+		MaxExternalResources:     topLevelConfig.GetMaxTaskExternalResources(),
+		MaxCustomInfoSizeInBytes: topLevelConfig.GetMaxTaskCustomInfoSizeInBytes(),
+	}
+}
+
 func (m *TaskExecutionManager) updateTaskExecutionModelState(
 	ctx context.Context, request *admin.TaskExecutionEventRequest, existingTaskExecution *models.TaskExecution) (
 	models.TaskExecution, error) {
 
 	err := transformers.UpdateTaskExecutionModel(ctx, request, existingTaskExecution,
-		m.config.ApplicationConfiguration().GetRemoteDataConfig().InlineEventDataPolicy, m.storageClient)
+		m.config.ApplicationConfiguration().GetRemoteDataConfig().InlineEventDataPolicy, m.storageClient,
+		m.getTaskExecutionInfoLimits())
 	if err != nil {
 		logger.Debugf(ctx, "failed to update task execution model [%+v] with err: %v", request.Event.TaskId, err)
 		return models.TaskExecution{}, err
@@ -126,6 +137,7 @@ func (m *TaskExecutionManager) updateTaskExecutionModelState(
 
 func (m *TaskExecutionManager) CreateTaskExecutionEvent(ctx context.Context, request admin.TaskExecutionEventRequest) (
 	*admin.TaskExecutionEventResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateTaskExecutionRequest(request, m.config.ApplicationConfiguration().GetRemoteDataConfig().MaxSizeInBytes); err != nil {
 		return nil, err
 	}
@@ -164,14 +176,18 @@ func (m *TaskExecutionManager) CreateTaskExecutionEvent(ctx context.Context, req
 
 		return &admin.TaskExecutionEventResponse{}, nil
 	}
-	if taskExecutionModel.Phase == request.Event.Phase.String() &&
-		taskExecutionModel.PhaseVersion >= request.Event.PhaseVersion {
+	eventOrder := transformers.GetTaskExecutionEventOrder(taskExecutionModel, request)
+	if eventOrder == transformers.EventDuplicate {
+		m.metrics.DuplicateTaskExecutionEvents.Inc()
 		logger.Debugf(ctx, "have already recorded task execution phase %s (version: %d) for %v",
 			request.Event.Phase.String(), request.Event.PhaseVersion, taskExecutionID)
 		return nil, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
 			"have already recorded task execution phase %s (version: %d) for %v",
 			request.Event.Phase.String(), request.Event.PhaseVersion, taskExecutionID)
 	}
+	if eventOrder == transformers.EventStale {
+		m.metrics.StaleTaskExecutionEvents.Inc()
+	}
 
 	currentPhase := core.TaskExecution_Phase(core.TaskExecution_Phase_value[taskExecutionModel.Phase])
 	if common.IsTaskExecutionTerminal(currentPhase) {
@@ -180,6 +196,13 @@ func (m *TaskExecutionManager) CreateTaskExecutionEvent(ctx context.Context, req
 		errorMsg := fmt.Sprintf("invalid phase change from %v to %v for task execution %v", taskExecutionModel.Phase, request.Event.Phase, taskExecutionID)
 		logger.Warnf(ctx, errorMsg)
 		return nil, errors.NewAlreadyInTerminalStateError(ctx, errorMsg, curPhase)
+	} else if eventOrder == transformers.EventStale {
+		logger.Debugf(ctx, "rejecting stale task execution event moving %v from %s (version: %d) to %s (version: %d)",
+			taskExecutionID, taskExecutionModel.Phase, taskExecutionModel.PhaseVersion, request.Event.Phase.String(),
+			request.Event.PhaseVersion)
+		return nil, errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"cannot go from %s (version: %d) to %s (version: %d) for task execution %v", taskExecutionModel.Phase,
+			taskExecutionModel.PhaseVersion, request.Event.Phase.String(), request.Event.PhaseVersion, taskExecutionID)
 	}
 
 	taskExecutionModel, err = m.updateTaskExecutionModelState(ctx, &request, &taskExecutionModel)
@@ -255,7 +278,7 @@ func (m *TaskExecutionManager) ListTaskExecutions(
 		}
 	}
 
-	offset, err := validation.ValidateToken(request.Token)
+	offset, keysetCursor, err := validation.ValidatePaginationToken(request.Token, request.SortBy)
 	if err != nil {
 		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 			"invalid pagination token %s for ListTaskExecutions", request.Token)
@@ -266,6 +289,7 @@ func (m *TaskExecutionManager) ListTaskExecutions(
 		Offset:        offset,
 		Limit:         int(request.Limit),
 		SortParameter: sortParameter,
+		KeysetCursor:  keysetCursor,
 	})
 	if err != nil {
 		logger.Debugf(ctx, "Failed to list task executions with request [%+v] with err %v",
@@ -278,9 +302,14 @@ func (m *TaskExecutionManager) ListTaskExecutions(
 		logger.Debugf(ctx, "failed to transform task execution models for request [%+v] with err: %v", request, err)
 		return nil, err
 	}
-	var token string
-	if len(taskExecutionList) == int(request.Limit) {
-		token = strconv.Itoa(offset + len(taskExecutionList))
+	var lastTaskExecution models.BaseModel
+	if len(output.TaskExecutions) > 0 {
+		lastTaskExecution = output.TaskExecutions[len(output.TaskExecutions)-1].BaseModel
+	}
+	token, err := util.GetNextPageToken(
+		request.SortBy, offset, len(taskExecutionList), request.Limit, lastTaskExecution)
+	if err != nil {
+		return nil, err
 	}
 	return &admin.TaskExecutionList{
 		TaskExecutions: taskExecutionList,
@@ -353,6 +382,10 @@ func NewTaskExecutionManager(db repositories.RepositoryInterface, config runtime
 			"size in bytes of serialized node execution outputs"),
 		PublishEventError: scope.MustNewCounter("publish_event_error",
 			"overall count of publish event errors when invoking publish()"),
+		DuplicateTaskExecutionEvents: scope.MustNewCounter("duplicate_task_execution_events",
+			"count of rejected TaskExecutionEventRequest repeating the recorded phase and phase version"),
+		StaleTaskExecutionEvents: scope.MustNewCounter("stale_task_execution_events",
+			"count of rejected TaskExecutionEventRequest preceding the recorded phase or phase version"),
 	}
 	return &TaskExecutionManager{
 		db:                 db,
@@ -25,6 +25,7 @@ import (
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 )
@@ -428,6 +429,53 @@ func TestCreateTaskEvent_UpdateTerminalEventError(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestCreateTaskEvent_StalePhaseVersionError(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	addGetWorkflowExecutionCallback(repository)
+	repository.TaskExecutionRepo().(*repositoryMocks.MockTaskExecutionRepo).SetGetCallback(
+		func(ctx context.Context, input interfaces.GetTaskExecutionInput) (models.TaskExecution, error) {
+			return models.TaskExecution{
+				TaskExecutionKey: models.TaskExecutionKey{
+					TaskKey: models.TaskKey{
+						Project: sampleTaskID.Project,
+						Domain:  sampleTaskID.Domain,
+						Name:    sampleTaskID.Name,
+						Version: sampleTaskID.Version,
+					},
+					NodeExecutionKey: models.NodeExecutionKey{
+						NodeID: sampleNodeExecID.NodeId,
+						ExecutionKey: models.ExecutionKey{
+							Project: sampleNodeExecID.ExecutionId.Project,
+							Domain:  sampleNodeExecID.ExecutionId.Domain,
+							Name:    sampleNodeExecID.ExecutionId.Name,
+						},
+					},
+					RetryAttempt: &retryAttemptValue,
+				},
+				StartedAt:    &taskStartedAt,
+				Phase:        core.TaskExecution_RUNNING.String(),
+				PhaseVersion: uint32(2),
+			}, nil
+		})
+	repository.TaskExecutionRepo().(*repositoryMocks.MockTaskExecutionRepo).SetUpdateCallback(
+		func(ctx context.Context, execution models.TaskExecution) error {
+			assert.Fail(t, "stale events must not be recorded")
+			return nil
+		})
+	staleRequest := admin.TaskExecutionEventRequest{
+		RequestId: taskEventRequest.RequestId,
+		Event:     proto.Clone(taskEventRequest.Event).(*event.TaskExecutionEvent),
+	}
+	staleRequest.Event.Phase = core.TaskExecution_RUNNING
+	staleRequest.Event.PhaseVersion = uint32(1)
+	taskExecManager := NewTaskExecutionManager(repository, getMockExecutionsConfigProvider(), getMockStorageForExecTest(context.Background()), mockScope.NewTestScope(), mockTaskExecutionRemoteURL, nil)
+	resp, err := taskExecManager.CreateTaskExecutionEvent(context.Background(), staleRequest)
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(taskExecManager.(*TaskExecutionManager).metrics.StaleTaskExecutionEvents))
+}
+
 func TestCreateTaskEvent_PhaseVersionChange(t *testing.T) {
 	taskUpdatedAt := taskStartedAt.Add(time.Minute)
 	taskEventUpdatedAtProto, _ := ptypes.TimestampProto(taskUpdatedAt)
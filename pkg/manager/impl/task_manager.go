@@ -3,6 +3,7 @@ package impl
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/validation"
 	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
@@ -39,10 +41,12 @@ type taskMetrics struct {
 }
 
 type TaskManager struct {
-	db       repositories.RepositoryInterface
-	config   runtimeInterfaces.Configuration
-	compiler workflowengine.Compiler
-	metrics  taskMetrics
+	db              repositories.RepositoryInterface
+	config          runtimeInterfaces.Configuration
+	compiler        workflowengine.Compiler
+	resourceManager interfaces.ResourceInterface
+	metrics         taskMetrics
+	countCache      *util.CountCache
 }
 
 func getTaskContext(ctx context.Context, identifier *core.Identifier) context.Context {
@@ -60,14 +64,69 @@ func setDefaults(request admin.TaskCreateRequest) (admin.TaskCreateRequest, erro
 	return request, nil
 }
 
+// Describes the task resource attributes limits were resolved from, by the level they're set at.
+func describeTaskResourceAttributes(resource *interfaces.ResourceResponse, project, domain string) string {
+	switch resource.Level {
+	case interfaces.ResourceLevelProject:
+		return fmt.Sprintf("the task resource attributes for project [%s]", resource.Project)
+	case interfaces.ResourceLevelOrg:
+		return fmt.Sprintf("the task resource attributes for org [%s]", resource.Org)
+	case interfaces.ResourceLevelDomain:
+		return fmt.Sprintf("the task resource attributes for domain [%s]", resource.Domain)
+	default:
+		return fmt.Sprintf("the task resource attributes for project [%s] domain [%s]", project, domain)
+	}
+}
+
+// Checks the task container against the resource limits its executions will be subject to, preferring limits set by
+// matchable task resource attributes over the platform ones. Depending on the configured enforcement, failures
+// either reject the task or are only logged.
+func (t *TaskManager) validateTaskContainer(ctx context.Context, request admin.TaskCreateRequest) error {
+	var attributeLimits runtimeInterfaces.TaskResourceSet
+	var attributeSource string
+	resource, err := t.resourceManager.GetResource(ctx, interfaces.ResourceRequest{
+		Project:      request.Id.Project,
+		Domain:       request.Id.Domain,
+		ResourceType: admin.MatchableResource_TASK_RESOURCE,
+	})
+	if err != nil {
+		ec, ok := err.(errors.FlyteAdminError)
+		if !ok || ec.Code() != codes.NotFound {
+			logger.Warningf(ctx, "Failed to fetch task resource attributes when validating task [%+v]: %v",
+				request.Id, err)
+		}
+	}
+	if resource != nil && resource.Attributes != nil && resource.Attributes.GetTaskResourceAttributes() != nil {
+		attributeLimits = resources.FromAdminProtoTaskResourceSpec(ctx, resource.Attributes.GetTaskResourceAttributes().Limits)
+		attributeSource = describeTaskResourceAttributes(resource, request.Id.Project, request.Id.Domain)
+	}
+	limits := validation.ResolveTaskResourceLimits(t.config.TaskResourceConfiguration().GetLimits(), attributeLimits,
+		attributeSource)
+	err = validation.ValidateTaskContainer(request.Spec.Template, limits)
+	if err == nil {
+		return nil
+	}
+	if t.config.RegistrationValidationConfiguration().GetTaskValidationEnforcement() ==
+		runtimeInterfaces.TaskValidationEnforcementWarn {
+		logger.Warningf(ctx, "Registering task [%+v] which failed container validation: %v", request.Id, err)
+		return nil
+	}
+	return err
+}
+
 func (t *TaskManager) CreateTask(
 	ctx context.Context,
 	request admin.TaskCreateRequest) (*admin.TaskCreateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateTask(ctx, request, t.db, t.config.TaskResourceConfiguration(),
 		t.config.WhitelistConfiguration(), t.config.ApplicationConfiguration()); err != nil {
 		logger.Debugf(ctx, "Task [%+v] failed validation with err: %v", request.Id, err)
 		return nil, err
 	}
+	if err := t.validateTaskContainer(ctx, request); err != nil {
+		logger.Debugf(ctx, "Task [%+v] failed container validation with err: %v", request.Id, err)
+		return nil, err
+	}
 	ctx = getTaskContext(ctx, request.Id)
 	finalizedRequest, err := setDefaults(request)
 	if err != nil {
@@ -197,6 +256,49 @@ func (t *TaskManager) ListTasks(ctx context.Context, request admin.ResourceListR
 	}, nil
 }
 
+func (t *TaskManager) CountTasks(ctx context.Context, request interfaces.CountResourceRequest) (
+	*interfaces.CountResourceResponse, error) {
+	if err := validation.ValidateCountResourceRequest(request); err != nil {
+		logger.Debugf(ctx, "Invalid request [%+v]: %v", request, err)
+		return nil, err
+	}
+	if request.Approximate {
+		count, err := t.db.TaskRepo().Count(ctx, repoInterfaces.CountResourceInput{})
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.CountResourceResponse{
+			Count:       count,
+			Approximate: true,
+		}, nil
+	}
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+	filters, err := util.GetDbFilters(util.FilterSpec{
+		Project:        request.Project,
+		Domain:         request.Domain,
+		RequestFilters: request.Filters,
+	}, common.Task)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey, err := util.GetCountCacheKey(common.Task, filters)
+	if err != nil {
+		return nil, err
+	}
+	count, err := t.countCache.GetOrCount(cacheKey, func() (int64, error) {
+		return t.db.TaskRepo().Count(ctx, repoInterfaces.CountResourceInput{
+			InlineFilters: filters,
+		})
+	})
+	if err != nil {
+		logger.Debugf(ctx, "Failed to count tasks for request [%+v] with err %v", request, err)
+		return nil, err
+	}
+	return &interfaces.CountResourceResponse{
+		Count: count,
+	}, nil
+}
+
 // This queries the unique tasks for the given query parameters.  At least the project and domain must be specified.
 // It will return all tasks, but only the one of each even if there are multiple versions.
 func (t *TaskManager) ListUniqueTaskIdentifiers(ctx context.Context, request admin.NamedEntityIdentifierListRequest) (
@@ -260,9 +362,11 @@ func NewTaskManager(
 		Registered:       labeled.NewCounter("num_registered", "count of registered tasks", scope),
 	}
 	return &TaskManager{
-		db:       db,
-		config:   config,
-		compiler: compiler,
-		metrics:  metrics,
+		db:              db,
+		config:          config,
+		compiler:        compiler,
+		resourceManager: resources.NewResourceManager(db, config),
+		metrics:         metrics,
+		countCache:      util.NewCountCache(config.ApplicationConfiguration().GetTopLevelConfig().GetCountCacheTTL()),
 	}
 }
@@ -11,11 +11,13 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
+	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/golang/protobuf/proto"
 
@@ -50,7 +52,7 @@ var taskIdentifier = core.Identifier{
 }
 
 func init() {
-	labeled.SetMetricKeys(common.RuntimeTypeKey, common.RuntimeVersionKey)
+	labeled.SetMetricKeys(contextutils.ProjectKey, contextutils.DomainKey, common.RuntimeTypeKey, common.RuntimeVersionKey)
 }
 
 func getMockTaskCompiler() workflowengine.Compiler {
@@ -104,6 +106,85 @@ func TestCreateTask_ValidationError(t *testing.T) {
 	assert.Nil(t, response)
 }
 
+func TestCreateTask_ContainerValidation(t *testing.T) {
+	attributes, _ := proto.Marshal(&admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_TaskResourceAttributes{
+			TaskResourceAttributes: &admin.TaskResourceAttributes{
+				Limits: &admin.TaskResourceSpec{
+					Memory: "1Gi",
+				},
+			},
+		},
+	})
+	tests := []struct {
+		enforcement runtimeInterfaces.TaskValidationEnforcement
+		expectError bool
+	}{
+		{runtimeInterfaces.TaskValidationEnforcementReject, true},
+		{runtimeInterfaces.TaskValidationEnforcementWarn, false},
+	}
+	for _, test := range tests {
+		t.Run(string(test.enforcement), func(t *testing.T) {
+			mockRepository := getMockTaskRepository()
+			mockRepository.TaskRepo().(*repositoryMocks.MockTaskRepo).SetGetCallback(
+				func(input interfaces.Identifier) (models.Task, error) {
+					return models.Task{}, adminErrors.NewFlyteAdminError(codes.NotFound, "not found")
+				})
+			mockRepository.ResourceRepo().(*repositoryMocks.MockResourceRepo).GetFunction = func(
+				ctx context.Context, ID interfaces.ResourceID) (models.Resource, error) {
+				assert.Equal(t, admin.MatchableResource_TASK_RESOURCE.String(), ID.ResourceType)
+				assert.Equal(t, "project", ID.Project)
+				assert.Equal(t, "domain", ID.Domain)
+				return models.Resource{
+					Project:    ID.Project,
+					Domain:     ID.Domain,
+					Attributes: attributes,
+				}, nil
+			}
+			var createCalled bool
+			mockRepository.TaskRepo().(*repositoryMocks.MockTaskRepo).SetCreateCallback(func(input models.Task) error {
+				createCalled = true
+				return nil
+			})
+			config := getMockConfigForTaskTest()
+			config.(*runtimeMocks.MockConfigurationProvider).AddRegistrationValidationConfiguration(
+				&runtimeMocks.MockRegistrationValidationProvider{
+					TaskValidationEnforcement: test.enforcement,
+				})
+			taskManager := NewTaskManager(mockRepository, config, getMockTaskCompiler(), mockScope.NewTestScope())
+			request := testutils.GetValidTaskRequest()
+			request.Spec.Template.GetContainer().Resources = &core.Resources{
+				Requests: []*core.Resources_ResourceEntry{
+					{Name: core.Resources_MEMORY, Value: "2Gi"},
+				},
+			}
+			_, err := taskManager.CreateTask(context.Background(), request)
+			if test.expectError {
+				assert.Equal(t, codes.InvalidArgument, err.(adminErrors.FlyteAdminError).Code())
+				assert.Contains(t, err.Error(),
+					"exceeds the limit [1Gi] set in the task resource attributes for project [project] domain [domain]")
+				assert.False(t, createCalled)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, createCalled)
+		})
+	}
+}
+
+func TestDescribeTaskResourceAttributes(t *testing.T) {
+	for level, expected := range map[managerInterfaces.ResourceLevel]string{
+		managerInterfaces.ResourceLevelWorkflow:      "the task resource attributes for project [project] domain [domain]",
+		managerInterfaces.ResourceLevelProjectDomain: "the task resource attributes for project [project] domain [domain]",
+		managerInterfaces.ResourceLevelProject:       "the task resource attributes for project [project]",
+		managerInterfaces.ResourceLevelOrg:           "the task resource attributes for org [acme]",
+		managerInterfaces.ResourceLevelDomain:        "the task resource attributes for domain [domain]",
+	} {
+		resource := &managerInterfaces.ResourceResponse{Level: level, Project: "project", Domain: "domain", Org: "acme"}
+		assert.Equal(t, expected, describeTaskResourceAttributes(resource, "project", "domain"), level)
+	}
+}
+
 func TestCreateTask_CompilerError(t *testing.T) {
 	mockCompiler := workflowMocks.NewMockCompiler()
 	expectedErr := errors.New("expected error")
@@ -295,6 +376,32 @@ func TestListTasks(t *testing.T) {
 	assert.Equal(t, "2", taskList.Token)
 }
 
+func TestCountTasks(t *testing.T) {
+	repository := getMockTaskRepository()
+	repository.TaskRepo().(*repositoryMocks.MockTaskRepo).SetCountCallback(
+		func(input interfaces.CountResourceInput) (int64, error) {
+			assert.Len(t, input.InlineFilters, 3)
+			queryExpr, _ := input.InlineFilters[0].GetGormQueryExpr()
+			assert.Equal(t, testutils.ProjectQueryPattern, queryExpr.Query)
+			assert.Equal(t, projectValue, queryExpr.Args)
+			return 5, nil
+		})
+	taskManager := NewTaskManager(repository, getMockConfigForTaskTest(), getMockTaskCompiler(), mockScope.NewTestScope())
+
+	response, err := taskManager.CountTasks(context.Background(), managerInterfaces.CountResourceRequest{
+		Project: projectValue,
+		Domain:  domainValue,
+		Filters: "eq(name,foo)",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &managerInterfaces.CountResourceResponse{Count: 5}, response)
+
+	_, err = taskManager.CountTasks(context.Background(), managerInterfaces.CountResourceRequest{
+		Domain: domainValue,
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(adminErrors.FlyteAdminError).Code())
+}
+
 func TestListTasks_MissingParameters(t *testing.T) {
 	repository := getMockTaskRepository()
 	taskManager := NewTaskManager(repository, getMockConfigForTaskTest(), getMockTaskCompiler(), mockScope.NewTestScope())
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// Number of distinct filters whose counts are cached at once, the least recently counted are evicted first.
+const countCacheSize = 1000
+
+type cachedCount struct {
+	count     int64
+	countedAt time.Time
+}
+
+// Reuses exact counts of filtered entities for a short time, as the console asks for the same count while paging
+// through a list and counting large tables is expensive. Counts are cached by each admin replica separately.
+type CountCache struct {
+	ttl    time.Duration
+	now    func() time.Time
+	mutex  sync.Mutex
+	counts *simplelru.LRU
+}
+
+// Returns the cached count for the key if it's recent enough, otherwise counts and caches the result.
+func (c *CountCache) GetOrCount(key string, count func() (int64, error)) (int64, error) {
+	if c.ttl <= 0 {
+		return count()
+	}
+	c.mutex.Lock()
+	cached, ok := c.counts.Get(key)
+	c.mutex.Unlock()
+	if ok && c.now().Sub(cached.(cachedCount).countedAt) < c.ttl {
+		return cached.(cachedCount).count, nil
+	}
+	result, err := count()
+	if err != nil {
+		return 0, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.counts.Add(key, cachedCount{
+		count:     result,
+		countedAt: c.now(),
+	})
+	return result, nil
+}
+
+func NewCountCache(ttl time.Duration) *CountCache {
+	// The size is constant and positive, which is the only way creating the cache fails.
+	counts, _ := simplelru.NewLRU(countCacheSize, nil)
+	return &CountCache{
+		ttl:    ttl,
+		now:    time.Now,
+		counts: counts,
+	}
+}
+
+// Normalizes filters into a cache key, such that the same filters in a different order share a key.
+func GetCountCacheKey(entity common.Entity, filters []common.InlineFilter) (string, error) {
+	expressions := make([]string, len(filters))
+	for idx, filter := range filters {
+		gormQueryExpr, err := filter.GetGormQueryExpr()
+		if err != nil {
+			return "", err
+		}
+		expressions[idx] = fmt.Sprintf("%s %s %#v", filter.GetEntity(), gormQueryExpr.Query, gormQueryExpr.Args)
+	}
+	sort.Strings(expressions)
+	return fmt.Sprintf("%s: %s", entity, strings.Join(expressions, " AND ")), nil
+}
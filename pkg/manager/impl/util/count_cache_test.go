@@ -0,0 +1,84 @@
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountCache(t *testing.T) {
+	now := time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC)
+	cache := NewCountCache(time.Minute)
+	cache.now = func() time.Time {
+		return now
+	}
+	var counted int
+	count := func() (int64, error) {
+		counted++
+		return int64(counted * 10), nil
+	}
+
+	result, err := cache.GetOrCount("key", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), result)
+	now = now.Add(30 * time.Second)
+	result, err = cache.GetOrCount("key", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), result)
+	result, err = cache.GetOrCount("other", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), result)
+
+	// Expired counts are counted again.
+	now = now.Add(time.Minute)
+	result, err = cache.GetOrCount("key", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), result)
+
+	// Failures aren't cached.
+	_, err = cache.GetOrCount("failing", func() (int64, error) {
+		return 0, errors.New("foo")
+	})
+	assert.EqualError(t, err, "foo")
+	result, err = cache.GetOrCount("failing", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(40), result)
+}
+
+func TestCountCache_Disabled(t *testing.T) {
+	cache := NewCountCache(0)
+	var counted int64
+	count := func() (int64, error) {
+		counted++
+		return counted, nil
+	}
+	_, _ = cache.GetOrCount("key", count)
+	result, err := cache.GetOrCount("key", count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result)
+}
+
+func TestGetCountCacheKey(t *testing.T) {
+	projectFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "project", "p")
+	assert.NoError(t, err)
+	phaseFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "phase", "RUNNING")
+	assert.NoError(t, err)
+	otherPhaseFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "phase", "FAILED")
+	assert.NoError(t, err)
+
+	key, err := GetCountCacheKey(common.Execution, []common.InlineFilter{projectFilter, phaseFilter})
+	assert.NoError(t, err)
+	reorderedKey, err := GetCountCacheKey(common.Execution, []common.InlineFilter{phaseFilter, projectFilter})
+	assert.NoError(t, err)
+	assert.Equal(t, key, reorderedKey)
+
+	otherKey, err := GetCountCacheKey(common.Execution, []common.InlineFilter{projectFilter, otherPhaseFilter})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+	taskKey, err := GetCountCacheKey(common.Task, []common.InlineFilter{projectFilter, phaseFilter})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, taskKey)
+}
@@ -22,6 +22,48 @@ func shouldFetchOutputData(config *runtimeInterfaces.RemoteDataConfig, urlBlob a
 	return len(outputURI) > 0 && shouldFetchData(config, urlBlob)
 }
 
+func getDataSize(ctx context.Context, storageClient *storage.DataStore, uri string) int64 {
+	metadata, err := storageClient.Head(ctx, storage.DataReference(uri))
+	if err != nil {
+		logger.Warningf(ctx, "Failed to get the size of data at URI [%s] with err: %v", uri, err)
+		return 0
+	}
+	return metadata.Size()
+}
+
+// getURLBlob returns a signed URL blob for the data at uri when signed URLs are enabled. Data which fails to be signed
+// falls back to its raw uri rather than failing the request.
+func getURLBlob(ctx context.Context, urlData dataInterfaces.RemoteURLInterface,
+	remoteDataConfig *runtimeInterfaces.RemoteDataConfig, storageClient *storage.DataStore, uri string) admin.UrlBlob {
+	if !remoteDataConfig.SignedURL.Enabled {
+		return admin.UrlBlob{}
+	}
+	urlBlob, err := urlData.Get(ctx, uri)
+	if err != nil {
+		logger.Warningf(ctx, "Failed to sign URI [%s], returning the raw URI instead. Err: %v", uri, err)
+		return admin.UrlBlob{
+			Url:   uri,
+			Bytes: getDataSize(ctx, storageClient, uri),
+		}
+	}
+	return urlBlob
+}
+
+// withDataSize fills in the size of data which wasn't signed, so that the size guard of cloud storage applies before
+// fetching it.
+func withDataSize(ctx context.Context, remoteDataConfig *runtimeInterfaces.RemoteDataConfig,
+	storageClient *storage.DataStore, urlBlob admin.UrlBlob, uri string) admin.UrlBlob {
+	if len(urlBlob.Url) > 0 || len(uri) == 0 || remoteDataConfig.MaxSizeInBytes == 0 ||
+		len(remoteDataConfig.Scheme) == 0 || remoteDataConfig.Scheme == common.Local ||
+		remoteDataConfig.Scheme == common.None {
+		return urlBlob
+	}
+	return admin.UrlBlob{
+		Url:   uri,
+		Bytes: getDataSize(ctx, storageClient, uri),
+	}
+}
+
 // GetInputs returns an inputs URL blob and if config settings permit, inline inputs data for an execution.
 func GetInputs(ctx context.Context, urlData dataInterfaces.RemoteURLInterface,
 	remoteDataConfig *runtimeInterfaces.RemoteDataConfig, storageClient *storage.DataStore, inputURI string) (
@@ -33,16 +75,9 @@ func GetInputs(ctx context.Context, urlData dataInterfaces.RemoteURLInterface,
 		return &fullInputs, &inputsURLBlob, nil
 	}
 
-	var err error
-	if remoteDataConfig.SignedURL.Enabled {
-		inputsURLBlob, err = urlData.Get(ctx, inputURI)
-		if err != nil {
-			return nil, nil, err
-		}
-	}
-
-	if shouldFetchData(remoteDataConfig, inputsURLBlob) {
-		err = storageClient.ReadProtobuf(ctx, storage.DataReference(inputURI), &fullInputs)
+	inputsURLBlob = getURLBlob(ctx, urlData, remoteDataConfig, storageClient, inputURI)
+	if shouldFetchData(remoteDataConfig, withDataSize(ctx, remoteDataConfig, storageClient, inputsURLBlob, inputURI)) {
+		err := storageClient.ReadProtobuf(ctx, storage.DataReference(inputURI), &fullInputs)
 		if err != nil {
 			// If we fail to read the protobuf from the remote store, we shouldn't fail the request altogether.
 			// Instead we return the signed URL blob so that the client can use that to fetch the input data.
@@ -98,12 +133,8 @@ func GetOutputs(ctx context.Context, urlData dataInterfaces.RemoteURLInterface,
 		return fullOutputs, &outputsURLBlob, nil
 	}
 
-	if len(closure.GetOutputUri()) > 0 && remoteDataConfig.SignedURL.Enabled {
-		var err error
-		outputsURLBlob, err = urlData.Get(ctx, closure.GetOutputUri())
-		if err != nil {
-			return nil, nil, err
-		}
+	if len(closure.GetOutputUri()) > 0 {
+		outputsURLBlob = getURLBlob(ctx, urlData, remoteDataConfig, storageClient, closure.GetOutputUri())
 	}
 
 	if closure.GetOutputData() != nil {
@@ -112,7 +143,9 @@ func GetOutputs(ctx context.Context, urlData dataInterfaces.RemoteURLInterface,
 		} else {
 			logger.Debugf(ctx, "execution closure contains output data that exceeds max data size for responses")
 		}
-	} else if shouldFetchOutputData(remoteDataConfig, outputsURLBlob, closure.GetOutputUri()) {
+	} else if shouldFetchOutputData(remoteDataConfig,
+		withDataSize(ctx, remoteDataConfig, storageClient, outputsURLBlob, closure.GetOutputUri()),
+		closure.GetOutputUri()) {
 		err := storageClient.ReadProtobuf(ctx, storage.DataReference(closure.GetOutputUri()), fullOutputs)
 		if err != nil {
 			// If we fail to read the protobuf from the remote store, we shouldn't fail the request altogether.
@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
@@ -25,6 +26,18 @@ var testLiteralMap = &core.LiteralMap{
 
 const testOutputsURI = "s3://foo/bar/outputs.pb"
 
+type testMetadata struct {
+	size int64
+}
+
+func (m testMetadata) Exists() bool {
+	return true
+}
+
+func (m testMetadata) Size() int64 {
+	return m.size
+}
+
 func TestShouldFetchData(t *testing.T) {
 	t.Run("local config", func(t *testing.T) {
 		assert.True(t, shouldFetchData(&interfaces.RemoteDataConfig{
@@ -154,7 +167,48 @@ func TestGetInputs(t *testing.T) {
 		assert.True(t, proto.Equal(fullInputs, testLiteralMap))
 		assert.Empty(t, inputURLBlob)
 	})
-
+	t.Run("should fall back to the raw URI", func(t *testing.T) {
+		remoteDataConfig.SignedURL = interfaces.SignedURL{
+			Enabled: true,
+		}
+		failingRemoteURL := urlMocks.NewMockRemoteURL()
+		failingRemoteURL.(*urlMocks.MockRemoteURL).GetCallback = func(
+			ctx context.Context, uri string) (admin.UrlBlob, error) {
+			return admin.UrlBlob{}, errors.New("expected error")
+		}
+		mockStorage.ComposedProtobufStore.(*commonMocks.TestDataStore).HeadCb = func(
+			ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+			assert.Equal(t, inputsURI, reference.String())
+			return testMetadata{size: 1000}, nil
+		}
+		fullInputs, inputURLBlob, err := GetInputs(context.TODO(), failingRemoteURL, &remoteDataConfig, mockStorage, inputsURI)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(fullInputs, testLiteralMap))
+		assert.True(t, proto.Equal(inputURLBlob, &admin.UrlBlob{
+			Url:   inputsURI,
+			Bytes: 1000,
+		}))
+	})
+	t.Run("should not inline inputs over the size limit", func(t *testing.T) {
+		remoteDataConfig := interfaces.RemoteDataConfig{
+			Scheme:         common.AWS,
+			MaxSizeInBytes: 2000,
+		}
+		mockStorage := commonMocks.GetMockStorageClient()
+		mockStorage.ComposedProtobufStore.(*commonMocks.TestDataStore).HeadCb = func(
+			ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+			return testMetadata{size: 3000}, nil
+		}
+		mockStorage.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb = func(
+			ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+			t.Fatal("Should not fetch inputs over the size limit")
+			return nil
+		}
+		fullInputs, inputURLBlob, err := GetInputs(context.TODO(), mockRemoteURL, &remoteDataConfig, mockStorage, inputsURI)
+		assert.NoError(t, err)
+		assert.Empty(t, fullInputs.Literals)
+		assert.Empty(t, inputURLBlob)
+	})
 }
 
 func TestGetOutputs(t *testing.T) {
@@ -205,6 +259,29 @@ func TestGetOutputs(t *testing.T) {
 		assert.True(t, proto.Equal(fullOutputs, testLiteralMap))
 		assert.Empty(t, outputURLBlob)
 	})
+	t.Run("offloaded outputs failing to be signed", func(t *testing.T) {
+		remoteDataConfig.SignedURL = interfaces.SignedURL{
+			Enabled: true,
+		}
+		failingRemoteURL := urlMocks.NewMockRemoteURL()
+		failingRemoteURL.(*urlMocks.MockRemoteURL).GetCallback = func(
+			ctx context.Context, uri string) (admin.UrlBlob, error) {
+			return admin.UrlBlob{}, errors.New("expected error")
+		}
+		mockStorage.ComposedProtobufStore.(*commonMocks.TestDataStore).HeadCb = func(
+			ctx context.Context, reference storage.DataReference) (storage.Metadata, error) {
+			return testMetadata{size: 3000}, nil
+		}
+
+		fullOutputs, outputURLBlob, err := GetOutputs(context.TODO(), failingRemoteURL, &remoteDataConfig, mockStorage, closure)
+		assert.NoError(t, err)
+		// The raw URI is returned in place of a signed URL, and outputs over the size limit aren't inlined.
+		assert.Empty(t, fullOutputs.Literals)
+		assert.True(t, proto.Equal(outputURLBlob, &admin.UrlBlob{
+			Url:   testOutputsURI,
+			Bytes: 3000,
+		}))
+	})
 	t.Run("inline outputs", func(t *testing.T) {
 		mockRemoteURL := urlMocks.NewMockRemoteURL()
 		mockRemoteURL.(*urlMocks.MockRemoteURL).GetCallback = func(ctx context.Context, uri string) (admin.UrlBlob, error) {
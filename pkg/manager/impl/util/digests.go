@@ -49,3 +49,16 @@ func GetWorkflowDigest(ctx context.Context, workflowClosure *core.CompiledWorkfl
 
 	return workflowDigest, nil
 }
+
+// Returns a digest of a workflow spec as registered, which is stable across serializations of the same spec. Unlike
+// the digest of the compiled workflow, it's known before compiling the workflow.
+func GetWorkflowSpecDigest(ctx context.Context, spec *admin.WorkflowSpec) ([]byte, error) {
+	specDigest, err := pbhash.ComputeHash(ctx, spec)
+	if err != nil {
+		logger.Warningf(ctx, "failed to hash workflow spec [%+v] to digest with err %v", spec.GetTemplate().GetId(), err)
+		return nil, errors.NewFlyteAdminErrorf(codes.Internal,
+			"failed to hash workflow spec [%+v] to digest with err %v", spec.GetTemplate().GetId(), err)
+	}
+
+	return specDigest, nil
+}
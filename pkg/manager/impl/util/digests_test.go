@@ -170,3 +170,31 @@ func TestGetWorkflowDigest_Unequal(t *testing.T) {
 	assert.NotEqual(t, compiledWorkflowDigest, workflowDigest)
 	assert.Nil(t, err)
 }
+
+func TestGetWorkflowSpecDigest(t *testing.T) {
+	compiledWorkflow, err := getCompiledWorkflow()
+	assert.Nil(t, err)
+	spec := &admin.WorkflowSpec{
+		Template: compiledWorkflow.Primary.Template,
+	}
+	specDigest, err := GetWorkflowSpecDigest(context.Background(), spec)
+	assert.Nil(t, err)
+	assert.Len(t, specDigest, 32)
+
+	// Maps serialize in any order, which the digest doesn't depend on.
+	inputs := spec.Template.Interface.Inputs.Variables
+	spec.Template.Interface.Inputs.Variables = make(map[string]*core.Variable, len(inputs))
+	for name, variable := range inputs {
+		spec.Template.Interface.Inputs.Variables[name] = variable
+	}
+	sameSpecDigest, err := GetWorkflowSpecDigest(context.Background(), spec)
+	assert.Nil(t, err)
+	assert.Equal(t, specDigest, sameSpecDigest)
+
+	spec.Template.Nodes = append(spec.Template.Nodes, &core.Node{
+		Id: "unexpected",
+	})
+	differentSpecDigest, err := GetWorkflowSpecDigest(context.Background(), spec)
+	assert.Nil(t, err)
+	assert.NotEqual(t, specDigest, differentSpecDigest)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/logger"
 
@@ -48,6 +49,13 @@ var durationFields = map[string]bool{
 	"duration": true,
 }
 
+// Error fields of executions are matched regardless of case, e.g. contains(error_message, oomkilled) matches executions
+// which failed with OOMKilled.
+var caseInsensitiveExecutionFields = map[string]bool{
+	"error_kind":    true,
+	"error_message": true,
+}
+
 const filterFieldEntityPrefixFmt = "%s."
 const secondsFormat = "%vs"
 
@@ -61,6 +69,7 @@ var filterFieldEntityPrefix = map[string]common.Entity{
 	"entities":              common.NamedEntity,
 	"named_entity_metadata": common.NamedEntityMetadata,
 	"project":               common.Project,
+	"description_entity":    common.DescriptionEntity,
 }
 
 func parseField(field string, primaryEntity common.Entity) (common.Entity, string) {
@@ -73,6 +82,11 @@ func parseField(field string, primaryEntity common.Entity) (common.Entity, strin
 		}
 	}
 
+	// Execution tags are stored in their own table but filtered on like an execution attribute, e.g. eq(tags, backfill)
+	// selects the executions tagged with backfill.
+	if primaryEntity == common.Execution && field == shared.Tags {
+		return common.ExecutionTag, shared.Tag
+	}
 	return primaryEntity, field
 }
 
@@ -141,6 +155,12 @@ func ParseFilters(filterParams string, primaryEntity common.Entity) ([]common.In
 		if err != nil {
 			return nil, err
 		}
+		if referencedEntity == common.Execution && caseInsensitiveExecutionFields[field] {
+			filter, err = common.NewCaseInsensitiveFilter(filter)
+			if err != nil {
+				return nil, err
+			}
+		}
 		parsedFilters = append(parsedFilters, filter)
 	}
 	return parsedFilters, nil
@@ -221,6 +241,41 @@ func GetDbFilters(spec FilterSpec, primaryEntity common.Entity) ([]common.Inline
 	return filters, nil
 }
 
+// Hides entities whose parent workflow named entity has been archived. Named entities without metadata are active by
+// default, hence the state comparison falls back to active when no metadata row is joined. Callers can opt back into
+// archived entities by including their own filter on named_entity_metadata.state, for example
+// "value_in(named_entity_metadata.state, 0;1)", in which case that filter replaces the default exclusion.
+func AddUnarchivedWorkflowFilter(filters []common.InlineFilter) ([]common.InlineFilter, error) {
+	activeState := strconv.Itoa(int(admin.NamedEntityState_NAMED_ENTITY_ACTIVE))
+	updatedFilters := make([]common.InlineFilter, 0, len(filters)+1)
+	var hasStateFilter bool
+	for _, filter := range filters {
+		if filter.GetEntity() != common.NamedEntityMetadata || filter.GetField() != shared.State {
+			updatedFilters = append(updatedFilters, filter)
+			continue
+		}
+		stateFilter, err := common.NewWithDefaultValueFilter(activeState, filter)
+		if err != nil {
+			return nil, err
+		}
+		updatedFilters = append(updatedFilters, stateFilter)
+		hasStateFilter = true
+	}
+	if hasStateFilter {
+		return updatedFilters, nil
+	}
+	archivedFilter, err := common.NewSingleValueFilter(common.NamedEntityMetadata, common.NotEqual, shared.State,
+		int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED))
+	if err != nil {
+		return nil, err
+	}
+	archivedFilter, err = common.NewWithDefaultValueFilter(activeState, archivedFilter)
+	if err != nil {
+		return nil, err
+	}
+	return append(updatedFilters, archivedFilter), nil
+}
+
 func GetWorkflowExecutionIdentifierFilters(
 	ctx context.Context, workflowExecutionIdentifier core.WorkflowExecutionIdentifier) ([]common.InlineFilter, error) {
 	identifierFilters := make([]common.InlineFilter, 3)
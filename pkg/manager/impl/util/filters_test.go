@@ -2,9 +2,12 @@ package util
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
@@ -88,6 +91,52 @@ func TestParseFilters(t *testing.T) {
 	assert.EqualError(t, err, "unrecognized filter function: invalid_function")
 }
 
+func TestParseFilters_ExecutionTags(t *testing.T) {
+	filters, err := ParseFilters("eq(tags, backfill)+contains(tags, 2024)+eq(phase, RUNNING)", common.Execution)
+	assert.NoError(t, err)
+	assert.Len(t, filters, 3)
+
+	assert.Equal(t, common.ExecutionTag, filters[0].GetEntity())
+	actualFilterExpression, _ := filters[0].GetGormQueryExpr()
+	assert.Equal(t, "tag = ?", actualFilterExpression.Query)
+	assert.Equal(t, "backfill", actualFilterExpression.Args)
+
+	assert.Equal(t, common.ExecutionTag, filters[1].GetEntity())
+	actualFilterExpression, _ = filters[1].GetGormQueryExpr()
+	assert.Equal(t, "tag LIKE ?", actualFilterExpression.Query)
+	assert.Equal(t, "%2024%", actualFilterExpression.Args)
+
+	assert.Equal(t, common.Execution, filters[2].GetEntity())
+
+	// Only executions have tags.
+	filters, err = ParseFilters("eq(tags, backfill)", common.LaunchPlan)
+	assert.NoError(t, err)
+	assert.Equal(t, common.LaunchPlan, filters[0].GetEntity())
+}
+
+func TestParseFilters_ExecutionErrors(t *testing.T) {
+	filters, err := ParseFilters("eq(error_kind, user)+contains(error_message, OOMKilled)+eq(error_code, OOMKilled)",
+		common.Execution)
+	assert.NoError(t, err)
+	assert.Len(t, filters, 3)
+
+	actualFilterExpression, _ := filters[0].GetGormQueryExpr()
+	assert.Equal(t, "LOWER(error_kind) = ?", actualFilterExpression.Query)
+	assert.Equal(t, "user", actualFilterExpression.Args)
+
+	actualFilterExpression, _ = filters[1].GetGormJoinTableQueryExpr("executions")
+	assert.Equal(t, "LOWER(executions.error_message) LIKE ?", actualFilterExpression.Query)
+	assert.Equal(t, "%oomkilled%", actualFilterExpression.Args)
+
+	// Only the kind and message are matched regardless of case.
+	actualFilterExpression, _ = filters[2].GetGormQueryExpr()
+	assert.Equal(t, "error_code = ?", actualFilterExpression.Query)
+	assert.Equal(t, "OOMKilled", actualFilterExpression.Args)
+
+	_, err = ParseFilters(fmt.Sprintf("contains(error_message, %s)", strings.Repeat("a", 256)), common.Execution)
+	assert.Error(t, err)
+}
+
 func TestGetEqualityFilter(t *testing.T) {
 	filter, err := GetSingleValueEqualityFilter(common.Task, "field", "value")
 	assert.NoError(t, err)
@@ -138,6 +187,40 @@ func TestGetDbFilters(t *testing.T) {
 	assert.EqualValues(t, expectedFilters, actualFilters)
 }
 
+func TestAddUnarchivedWorkflowFilter(t *testing.T) {
+	t.Run("excludes archived by default", func(t *testing.T) {
+		filters, err := GetDbFilters(FilterSpec{
+			Project: "project",
+			Domain:  "domain",
+		}, common.LaunchPlan)
+		assert.NoError(t, err)
+		filters, err = AddUnarchivedWorkflowFilter(filters)
+		assert.NoError(t, err)
+		assert.Len(t, filters, 3)
+		assert.Equal(t, common.NamedEntityMetadata, filters[2].GetEntity())
+		expression, err := filters[2].GetGormQueryExpr()
+		assert.NoError(t, err)
+		assert.Equal(t, "COALESCE(state, 0) <> ?", expression.Query)
+		assert.Equal(t, int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED), expression.Args)
+	})
+	t.Run("explicit state filter overrides default", func(t *testing.T) {
+		filters, err := GetDbFilters(FilterSpec{
+			Project:        "project",
+			Domain:         "domain",
+			RequestFilters: "value_in(named_entity_metadata.state, 0;1)",
+		}, common.LaunchPlan)
+		assert.NoError(t, err)
+		filters, err = AddUnarchivedWorkflowFilter(filters)
+		assert.NoError(t, err)
+		assert.Len(t, filters, 3)
+		assert.Equal(t, common.NamedEntityMetadata, filters[2].GetEntity())
+		expression, err := filters[2].GetGormQueryExpr()
+		assert.NoError(t, err)
+		assert.Equal(t, "COALESCE(state, 0) in (?)", expression.Query)
+		assert.EqualValues(t, []interface{}{"0", "1"}, expression.Args)
+	})
+}
+
 func TestGetWorkflowExecutionIdentifierFilters(t *testing.T) {
 	identifierFilters, err := GetWorkflowExecutionIdentifierFilters(
 		context.Background(), core.WorkflowExecutionIdentifier{
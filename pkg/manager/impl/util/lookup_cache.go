@@ -0,0 +1,166 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/golang-lru/simplelru"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type lookupCacheMetrics struct {
+	Hits      prometheus.Counter
+	Misses    prometheus.Counter
+	Evictions prometheus.Counter
+}
+
+type cachedLookup struct {
+	value    interface{}
+	size     int64
+	cachedAt time.Time
+}
+
+// Keeps the launch plan and workflow versions recently looked up to create executions in memory. A version can't
+// change once registered, other than the state of a launch plan version, which is never cached. Launch plan versions
+// are invalidated whenever they're activated or deactivated all the same, such that they're reread along with any
+// column the update rewrites. A nil cache caches nothing.
+type LookupCache struct {
+	config  runtimeInterfaces.LookupCacheConfig
+	now     func() time.Time
+	mutex   sync.Mutex
+	entries *simplelru.LRU
+	// The total size of the cached versions.
+	size    int64
+	metrics lookupCacheMetrics
+}
+
+func getLookupCacheKey(resourceType core.ResourceType, identifier core.Identifier) string {
+	return fmt.Sprintf("%s:%s/%s/%s/%s", resourceType, identifier.Project, identifier.Domain, identifier.Name,
+		identifier.Version)
+}
+
+// Returns the launch plan version, looked up through the cache. Its state is never set, callers which need it read
+// the launch plan from the database instead.
+func (c *LookupCache) GetLaunchPlanModel(
+	ctx context.Context, repo repositories.RepositoryInterface, identifier core.Identifier) (models.LaunchPlan, error) {
+	key := getLookupCacheKey(core.ResourceType_LAUNCH_PLAN, identifier)
+	value, err := c.getOrLoad(key, func() (interface{}, int64, error) {
+		launchPlanModel, err := GetLaunchPlanModel(ctx, repo, identifier)
+		if err != nil {
+			return nil, 0, err
+		}
+		launchPlanModel.State = nil
+		launchPlanModel.ActivatedAt = nil
+		return launchPlanModel, int64(len(launchPlanModel.Spec) + len(launchPlanModel.Closure)), nil
+	})
+	if err != nil {
+		return models.LaunchPlan{}, err
+	}
+	return value.(models.LaunchPlan), nil
+}
+
+// Returns the workflow version, along with its closure, looked up through the cache. The returned workflow is a copy
+// which callers are free to modify.
+func (c *LookupCache) GetWorkflow(ctx context.Context, repo repositories.RepositoryInterface,
+	closures *WorkflowClosureCache, identifier core.Identifier) (*admin.Workflow, error) {
+	key := getLookupCacheKey(core.ResourceType_WORKFLOW, identifier)
+	value, err := c.getOrLoad(key, func() (interface{}, int64, error) {
+		workflow, err := GetWorkflow(ctx, repo, closures, identifier)
+		if err != nil {
+			return nil, 0, err
+		}
+		return workflow, int64(proto.Size(workflow)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(value.(*admin.Workflow)).(*admin.Workflow), nil
+}
+
+// Drops the launch plan version from the cache, which must be called whenever its row is updated.
+func (c *LookupCache) InvalidateLaunchPlan(identifier core.Identifier) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.Remove(getLookupCacheKey(core.ResourceType_LAUNCH_PLAN, identifier))
+}
+
+func (c *LookupCache) getOrLoad(key string, load func() (interface{}, int64, error)) (interface{}, error) {
+	if c == nil {
+		value, _, err := load()
+		return value, err
+	}
+	c.mutex.Lock()
+	cached, ok := c.entries.Get(key)
+	if ok && c.config.TTL.Duration > 0 && c.now().Sub(cached.(cachedLookup).cachedAt) >= c.config.TTL.Duration {
+		c.entries.Remove(key)
+		ok = false
+	}
+	c.mutex.Unlock()
+	if ok {
+		c.metrics.Hits.Inc()
+		return cached.(cachedLookup).value, nil
+	}
+	c.metrics.Misses.Inc()
+
+	value, size, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if size > c.config.MaxSizeInBytes {
+		return value, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.Remove(key)
+	if c.entries.Add(key, cachedLookup{
+		value:    value,
+		size:     size,
+		cachedAt: c.now(),
+	}) {
+		c.metrics.Evictions.Inc()
+	}
+	c.size += size
+	for c.size > c.config.MaxSizeInBytes {
+		c.entries.RemoveOldest()
+		c.metrics.Evictions.Inc()
+	}
+	return value, nil
+}
+
+func (c *LookupCache) onEvict(_ interface{}, value interface{}) {
+	c.size -= value.(cachedLookup).size
+}
+
+// Returns nil, which caches nothing, when caching is disabled.
+func NewLookupCache(config runtimeInterfaces.LookupCacheConfig, scope promutils.Scope) *LookupCache {
+	if config.MaxEntries <= 0 {
+		return nil
+	}
+	cache := &LookupCache{
+		config: config,
+		now:    time.Now,
+		metrics: lookupCacheMetrics{
+			Hits: scope.MustNewCounter("hits",
+				"count of launch plan and workflow versions found in the cache"),
+			Misses: scope.MustNewCounter("misses",
+				"count of launch plan and workflow versions looked up in the database as they weren't cached"),
+			Evictions: scope.MustNewCounter("evictions",
+				"count of launch plan and workflow versions evicted from the cache to make room for others"),
+		},
+	}
+	// The size is positive, which is the only way creating the cache fails.
+	cache.entries, _ = simplelru.NewLRU(config.MaxEntries, cache.onEvict)
+	return cache
+}
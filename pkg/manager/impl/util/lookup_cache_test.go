@@ -0,0 +1,164 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func getLookupCacheRepositoryForTest(reads map[string]int) repositories.RepositoryInterface {
+	repository := repositoryMocks.NewMockRepository()
+	repository.LaunchPlanRepo().(*repositoryMocks.MockLaunchPlanRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.LaunchPlan, error) {
+			reads[input.Version]++
+			state := int32(admin.LaunchPlanState_ACTIVE)
+			activatedAt := time.Now()
+			return models.LaunchPlan{
+				LaunchPlanKey: models.LaunchPlanKey{
+					Project: input.Project,
+					Domain:  input.Domain,
+					Name:    input.Name,
+					Version: input.Version,
+				},
+				Spec:        []byte("spec"),
+				Closure:     []byte("closure"),
+				State:       &state,
+				ActivatedAt: &activatedAt,
+			}, nil
+		})
+	return repository
+}
+
+func getLaunchPlanIdentifierForTest(version string) core.Identifier {
+	return core.Identifier{
+		ResourceType: core.ResourceType_LAUNCH_PLAN,
+		Project:      project,
+		Domain:       domain,
+		Name:         name,
+		Version:      version,
+	}
+}
+
+func TestLookupCache_GetLaunchPlanModel(t *testing.T) {
+	// Each launch plan version takes up 11 bytes.
+	lookupConfig := runtimeInterfaces.LookupCacheConfig{
+		MaxEntries:     2,
+		MaxSizeInBytes: 100,
+	}
+
+	t.Run("cache hit", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		cache := NewLookupCache(lookupConfig, promutils.NewTestScope())
+		for i := 0; i < 2; i++ {
+			launchPlanModel, err := cache.GetLaunchPlanModel(context.Background(), repository,
+				getLaunchPlanIdentifierForTest("v1"))
+			assert.NoError(t, err)
+			assert.Equal(t, "v1", launchPlanModel.Version)
+			assert.Equal(t, []byte("spec"), launchPlanModel.Spec)
+			assert.Nil(t, launchPlanModel.State)
+			assert.Nil(t, launchPlanModel.ActivatedAt)
+		}
+		assert.Equal(t, 1, reads["v1"])
+		assert.Equal(t, float64(1), testutil.ToFloat64(cache.metrics.Hits))
+		assert.Equal(t, float64(1), testutil.ToFloat64(cache.metrics.Misses))
+	})
+	t.Run("invalidated", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		cache := NewLookupCache(lookupConfig, promutils.NewTestScope())
+		_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+		assert.NoError(t, err)
+		cache.InvalidateLaunchPlan(getLaunchPlanIdentifierForTest("v1"))
+		_, err = cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reads["v1"])
+		assert.Equal(t, int64(11), cache.size)
+		assert.Equal(t, float64(0), testutil.ToFloat64(cache.metrics.Evictions))
+	})
+	t.Run("expired", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		expiringConfig := lookupConfig
+		expiringConfig.TTL = config.Duration{Duration: time.Minute}
+		cache := NewLookupCache(expiringConfig, promutils.NewTestScope())
+		now := time.Now()
+		cache.now = func() time.Time {
+			return now
+		}
+		_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+		assert.NoError(t, err)
+		now = now.Add(time.Minute - time.Nanosecond)
+		_, err = cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, reads["v1"])
+		now = now.Add(time.Nanosecond)
+		_, err = cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, reads["v1"])
+	})
+	t.Run("evicted beyond max entries", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		cache := NewLookupCache(lookupConfig, promutils.NewTestScope())
+		for _, version := range []string{"v1", "v2", "v1", "v3", "v1", "v2"} {
+			_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest(version))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, map[string]int{"v1": 1, "v2": 2, "v3": 1}, reads)
+		assert.Equal(t, float64(2), testutil.ToFloat64(cache.metrics.Evictions))
+		assert.Equal(t, int64(22), cache.size)
+	})
+	t.Run("evicted beyond max size", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		sizedConfig := lookupConfig
+		sizedConfig.MaxEntries = 10
+		sizedConfig.MaxSizeInBytes = 21
+		cache := NewLookupCache(sizedConfig, promutils.NewTestScope())
+		for _, version := range []string{"v1", "v2", "v2", "v1"} {
+			_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest(version))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, map[string]int{"v1": 2, "v2": 1}, reads)
+		assert.Equal(t, float64(2), testutil.ToFloat64(cache.metrics.Evictions))
+		assert.Equal(t, int64(11), cache.size)
+	})
+	t.Run("larger than the cache", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		sizedConfig := lookupConfig
+		sizedConfig.MaxSizeInBytes = 10
+		cache := NewLookupCache(sizedConfig, promutils.NewTestScope())
+		for i := 0; i < 2; i++ {
+			_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, reads["v1"])
+		assert.Equal(t, float64(0), testutil.ToFloat64(cache.metrics.Evictions))
+	})
+	t.Run("caching disabled", func(t *testing.T) {
+		reads := make(map[string]int)
+		repository := getLookupCacheRepositoryForTest(reads)
+		cache := NewLookupCache(runtimeInterfaces.LookupCacheConfig{}, promutils.NewTestScope())
+		assert.Nil(t, cache)
+		for i := 0; i < 2; i++ {
+			_, err := cache.GetLaunchPlanModel(context.Background(), repository, getLaunchPlanIdentifierForTest("v1"))
+			assert.NoError(t, err)
+		}
+		cache.InvalidateLaunchPlan(getLaunchPlanIdentifierForTest("v1"))
+		assert.Equal(t, 2, reads["v1"])
+	})
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The cluster resource attribute which pins the namespace of a project and domain, in place of the namespace template.
+const NamespaceAttributeKey = "namespace"
+
+// GetNamespace resolves the kubernetes namespace executions of a project and domain run in. A namespace pinned with
+// the cluster resource attributes of the project and domain takes precedence over the configured namespace template.
+// Both the execution manager and the cluster resource controller resolve namespaces here so that executions land in
+// the namespaces whose resources the controller syncs.
+func GetNamespace(ctx context.Context, resourceManager interfaces.ResourceInterface, template string,
+	project models.Project, domain string) (string, error) {
+	resource, err := resourceManager.GetResource(ctx, interfaces.ResourceRequest{
+		Project:      project.Identifier,
+		Domain:       domain,
+		ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+	})
+	if err != nil {
+		if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
+			return "", err
+		}
+	}
+	var namespace string
+	if resource != nil {
+		namespace = resource.Attributes.GetClusterResourceAttributes().GetAttributes()[NamespaceAttributeKey]
+	}
+	if len(namespace) == 0 {
+		// Labels are stored within the serialized project.
+		serializedProject := &admin.Project{}
+		if err := proto.Unmarshal(project.Labels, serializedProject); err != nil {
+			return "", errors.NewFlyteAdminErrorf(codes.Internal,
+				"failed to read the labels of project [%s]: %v", project.Identifier, err)
+		}
+		namespace, err = common.GetNamespaceName(
+			template, project.Identifier, domain, serializedProject.GetLabels().GetValues())
+		if err != nil {
+			return "", errors.NewFlyteAdminErrorf(codes.FailedPrecondition, err.Error())
+		}
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		logger.Warningf(ctx, "Resolved invalid namespace [%s] for project [%s] and domain [%s]: %v",
+			namespace, project.Identifier, domain, errs)
+		return "", errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"invalid namespace [%s] for project [%s] and domain [%s]: %v", namespace, project.Identifier, domain, errs)
+	}
+	return namespace, nil
+}
+
+// GetProjectNamespace looks up the project before resolving its namespace, see GetNamespace.
+func GetProjectNamespace(ctx context.Context, db repositories.RepositoryInterface,
+	resourceManager interfaces.ResourceInterface, template, project, domain string) (string, error) {
+	projectModel, err := db.ProjectRepo().Get(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	return GetNamespace(ctx, resourceManager, template, projectModel, domain)
+}
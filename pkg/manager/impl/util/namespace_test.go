@@ -0,0 +1,112 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	managerMocks "github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func getNamespaceTestResourceManager(t *testing.T, attributes map[string]string) managerInterfaces.ResourceInterface {
+	resourceManager := managerMocks.MockResourceManager{}
+	resourceManager.GetResourceFunc = func(ctx context.Context,
+		request managerInterfaces.ResourceRequest) (*managerInterfaces.ResourceResponse, error) {
+		assert.Equal(t, managerInterfaces.ResourceRequest{
+			Project:      project,
+			Domain:       domain,
+			ResourceType: admin.MatchableResource_CLUSTER_RESOURCE,
+		}, request)
+		if attributes == nil {
+			return nil, flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "not found")
+		}
+		return &managerInterfaces.ResourceResponse{
+			Attributes: &admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_ClusterResourceAttributes{
+					ClusterResourceAttributes: &admin.ClusterResourceAttributes{
+						Attributes: attributes,
+					},
+				},
+			},
+		}, nil
+	}
+	return &resourceManager
+}
+
+func getNamespaceTestProject(t *testing.T, labels map[string]string) models.Project {
+	serializedProject, err := proto.Marshal(&admin.Project{
+		Id: project,
+		Labels: &admin.Labels{
+			Values: labels,
+		},
+	})
+	assert.NoError(t, err)
+	return models.Project{
+		Identifier: project,
+		Labels:     serializedProject,
+	}
+}
+
+func TestGetNamespace(t *testing.T) {
+	namespace, err := GetNamespace(context.Background(), getNamespaceTestResourceManager(t, nil),
+		"{{ project }}-flyte", getNamespaceTestProject(t, nil), domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "project-flyte", namespace)
+}
+
+func TestGetNamespace_ProjectLabels(t *testing.T) {
+	namespace, err := GetNamespace(context.Background(), getNamespaceTestResourceManager(t, nil),
+		"{{ labels.team }}-{{ domain }}", getNamespaceTestProject(t, map[string]string{"team": "ml"}), domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "ml-domain", namespace)
+
+	_, err = GetNamespace(context.Background(), getNamespaceTestResourceManager(t, nil),
+		"{{ labels.team }}-{{ domain }}", getNamespaceTestProject(t, nil), domain)
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestGetNamespace_Override(t *testing.T) {
+	resourceManager := getNamespaceTestResourceManager(t, map[string]string{
+		NamespaceAttributeKey: "ml-prod",
+		"projectQuotaCpu":     "16",
+	})
+	namespace, err := GetNamespace(context.Background(), resourceManager, "{{ labels.team }}-{{ domain }}",
+		getNamespaceTestProject(t, nil), domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "ml-prod", namespace)
+
+	// Cluster resource attributes without a namespace leave the template in charge.
+	resourceManager = getNamespaceTestResourceManager(t, map[string]string{
+		"projectQuotaCpu": "16",
+	})
+	namespace, err = GetNamespace(context.Background(), resourceManager, "{{ project }}-{{ domain }}",
+		getNamespaceTestProject(t, nil), domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "project-domain", namespace)
+}
+
+func TestGetNamespace_InvalidNamespace(t *testing.T) {
+	_, err := GetNamespace(context.Background(), getNamespaceTestResourceManager(t, nil), "{{ labels.team }}",
+		getNamespaceTestProject(t, map[string]string{"team": "Machine_Learning"}), domain)
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestGetProjectNamespace(t *testing.T) {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ProjectRepo().(*repositoryMocks.MockProjectRepo).GetFunction = func(
+		ctx context.Context, projectID string) (models.Project, error) {
+		assert.Equal(t, project, projectID)
+		return getNamespaceTestProject(t, map[string]string{"team": "ml"}), nil
+	}
+	namespace, err := GetProjectNamespace(context.Background(), repository, getNamespaceTestResourceManager(t, nil),
+		"{{ labels.team }}-{{ project }}-{{ domain }}", project, domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "ml-project-domain", namespace)
+}
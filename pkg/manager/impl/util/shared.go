@@ -3,6 +3,7 @@ package util
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
@@ -72,7 +73,7 @@ func FetchAndGetWorkflowClosure(ctx context.Context,
 func GetWorkflow(
 	ctx context.Context,
 	repo repositories.RepositoryInterface,
-	store *storage.DataStore,
+	closures *WorkflowClosureCache,
 	identifier core.Identifier) (*admin.Workflow, error) {
 	workflowModel, err := GetWorkflowModel(ctx, repo, identifier)
 	if err != nil {
@@ -82,7 +83,7 @@ func GetWorkflow(
 	if err != nil {
 		return nil, err
 	}
-	closure, err := FetchAndGetWorkflowClosure(ctx, store, workflowModel.RemoteClosureIdentifier)
+	closure, err := closures.Get(ctx, workflowModel.RemoteClosureIdentifier)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +177,25 @@ func ListActiveLaunchPlanVersionsFilters(project, domain string) ([]common.Inlin
 	return []common.InlineFilter{projectFilter, domainFilter, activeFilter}, nil
 }
 
+// Returns the set of filters necessary to query launch plan models to find the active versions of launch plans which
+// either have a schedule or, when scheduled is false, don't.
+func ListActiveLaunchPlanVersionsByScheduleFilters(project, domain string, scheduled bool) ([]common.InlineFilter, error) {
+	filters, err := ListActiveLaunchPlanVersionsFilters(project, domain)
+	if err != nil {
+		return nil, err
+	}
+	scheduleFilterExpression := common.Equal
+	if scheduled {
+		scheduleFilterExpression = common.NotEqual
+	}
+	scheduleFilter, err := common.NewSingleValueFilter(common.LaunchPlan, scheduleFilterExpression, shared.ScheduleType,
+		string(models.LaunchPlanScheduleTypeNONE))
+	if err != nil {
+		return nil, err
+	}
+	return append(filters, scheduleFilter), nil
+}
+
 func GetExecutionModel(
 	ctx context.Context, repo repositories.RepositoryInterface, identifier core.WorkflowExecutionIdentifier) (
 	*models.Execution, error) {
@@ -234,3 +254,19 @@ func GetTaskExecutionModel(
 	}
 	return &taskExecutionModel, nil
 }
+
+// Returns the token for the page following one of pageSize rows, the last of which is given, or an empty token if the
+// page wasn't full. Lists sorted by the keyset sort key continue from the last row, all others from the next offset.
+func GetNextPageToken(sortBy *admin.Sort, offset, pageSize int, limit uint32, last models.BaseModel) (string, error) {
+	if pageSize != int(limit) {
+		return "", nil
+	}
+	if !common.IsKeysetSort(sortBy) {
+		return strconv.Itoa(offset + pageSize), nil
+	}
+	return common.EncodeKeysetToken(common.KeysetCursor{
+		Direction: sortBy.Direction,
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID,
+	})
+}
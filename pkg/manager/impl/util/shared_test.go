@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/golang/protobuf/proto"
@@ -236,7 +238,8 @@ func TestGetWorkflow(t *testing.T) {
 			return nil
 		}
 	workflow, err := GetWorkflow(
-		context.Background(), repository, mockStorageClient, core.Identifier{
+		context.Background(), repository, NewWorkflowClosureCache(mockStorageClient,
+			runtimeInterfaces.WorkflowClosureCacheConfig{}), core.Identifier{
 			ResourceType: core.ResourceType_WORKFLOW,
 			Project:      "project",
 			Domain:       "domain",
@@ -474,3 +477,29 @@ func TestListActiveLaunchPlanVersionsFilters(t *testing.T) {
 	assert.Equal(t, activeExpr.Args, int32(admin.LaunchPlanState_ACTIVE))
 	assert.Equal(t, activeExpr.Query, testutils.StateQueryPattern)
 }
+
+func TestGetNextPageToken(t *testing.T) {
+	last := models.BaseModel{ID: 7, CreatedAt: time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC)}
+	t.Run("partial page", func(t *testing.T) {
+		token, err := GetNextPageToken(nil, 10, 3, 5, last)
+		assert.NoError(t, err)
+		assert.Empty(t, token)
+	})
+	t.Run("offset", func(t *testing.T) {
+		token, err := GetNextPageToken(&admin.Sort{Key: "name"}, 10, 5, 5, last)
+		assert.NoError(t, err)
+		assert.Equal(t, "15", token)
+	})
+	t.Run("keyset", func(t *testing.T) {
+		sortBy := admin.Sort{Key: common.KeysetSortKey, Direction: admin.Sort_ASCENDING}
+		token, err := GetNextPageToken(&sortBy, 0, 5, 5, last)
+		assert.NoError(t, err)
+		cursor, err := common.DecodeKeysetToken(token, sortBy)
+		assert.NoError(t, err)
+		assert.Equal(t, common.KeysetCursor{
+			Direction: admin.Sort_ASCENDING,
+			CreatedAt: last.CreatedAt,
+			ID:        7,
+		}, cursor)
+	})
+}
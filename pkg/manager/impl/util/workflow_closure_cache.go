@@ -0,0 +1,87 @@
+package util
+
+import (
+	"context"
+	"sync"
+
+	"github.com/flyteorg/flyteadmin/pkg/async"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/golang-lru/simplelru"
+	"google.golang.org/grpc/codes"
+)
+
+// Bounds the number of closures kept in memory independently of their size, such that many tiny closures don't grow
+// the bookkeeping of the cache unboundedly.
+const workflowClosureCacheEntries = 10000
+
+// Reads compiled workflow closures from the storage they're offloaded to, keeping the most recently read ones in
+// memory. A closure is never rewritten once offloaded, so cached closures are keyed by their location and never go
+// stale.
+type WorkflowClosureCache struct {
+	store    *storage.DataStore
+	config   runtimeInterfaces.WorkflowClosureCacheConfig
+	mutex    sync.Mutex
+	closures *simplelru.LRU
+	// The total serialized size of the cached closures.
+	size int64
+}
+
+// Returns the closure offloaded to the location, read from storage unless it's cached. The returned closure is a copy
+// which callers are free to modify.
+func (c *WorkflowClosureCache) Get(ctx context.Context, remoteLocationIdentifier string) (
+	*admin.WorkflowClosure, error) {
+	c.mutex.Lock()
+	cached, ok := c.closures.Get(remoteLocationIdentifier)
+	c.mutex.Unlock()
+	if ok {
+		return proto.Clone(cached.(*admin.WorkflowClosure)).(*admin.WorkflowClosure), nil
+	}
+
+	var closure *admin.WorkflowClosure
+	err := async.Retry(c.config.FetchRetries, c.config.FetchRetryDelay.Duration, func() error {
+		closure = &admin.WorkflowClosure{}
+		return c.store.ReadProtobuf(ctx, storage.DataReference(remoteLocationIdentifier), closure)
+	})
+	if err != nil {
+		return nil, errors.NewFlyteAdminErrorf(codes.Internal,
+			"Unable to read WorkflowClosure from location %s : %v", remoteLocationIdentifier, err)
+	}
+	c.add(remoteLocationIdentifier, closure)
+	return proto.Clone(closure).(*admin.WorkflowClosure), nil
+}
+
+func (c *WorkflowClosureCache) add(remoteLocationIdentifier string, closure *admin.WorkflowClosure) {
+	size := int64(proto.Size(closure))
+	if size > c.config.MaxSizeInBytes {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closures.Contains(remoteLocationIdentifier) {
+		return
+	}
+	c.closures.Add(remoteLocationIdentifier, closure)
+	c.size += size
+	for c.size > c.config.MaxSizeInBytes {
+		c.closures.RemoveOldest()
+	}
+}
+
+func (c *WorkflowClosureCache) onEvict(_ interface{}, value interface{}) {
+	c.size -= int64(proto.Size(value.(*admin.WorkflowClosure)))
+}
+
+func NewWorkflowClosureCache(
+	store *storage.DataStore, config runtimeInterfaces.WorkflowClosureCacheConfig) *WorkflowClosureCache {
+	cache := &WorkflowClosureCache{
+		store:  store,
+		config: config,
+	}
+	// The size is constant and positive, which is the only way creating the cache fails.
+	cache.closures, _ = simplelru.NewLRU(workflowClosureCacheEntries, cache.onEvict)
+	return cache
+}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func getClosureStorageClientForTest(reads map[string]int) *storage.DataStore {
+	mockStorageClient := commonMocks.GetMockStorageClient()
+	mockStorageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb =
+		func(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+			reads[reference.String()]++
+			proto.Merge(msg, testutils.GetWorkflowClosure())
+			return nil
+		}
+	return mockStorageClient
+}
+
+func TestWorkflowClosureCache_Get(t *testing.T) {
+	closureSize := int64(proto.Size(testutils.GetWorkflowClosure()))
+
+	t.Run("cache hit", func(t *testing.T) {
+		reads := make(map[string]int)
+		cache := NewWorkflowClosureCache(getClosureStorageClientForTest(reads),
+			runtimeInterfaces.WorkflowClosureCacheConfig{MaxSizeInBytes: closureSize})
+		closure, err := cache.Get(context.Background(), "a")
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(testutils.GetWorkflowClosure(), closure))
+		// Callers modifying the returned closure don't change the cached one.
+		closure.CompiledWorkflow = nil
+
+		closure, err = cache.Get(context.Background(), "a")
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(testutils.GetWorkflowClosure(), closure))
+		assert.Equal(t, 1, reads["a"])
+	})
+	t.Run("closure larger than the cache", func(t *testing.T) {
+		reads := make(map[string]int)
+		cache := NewWorkflowClosureCache(getClosureStorageClientForTest(reads),
+			runtimeInterfaces.WorkflowClosureCacheConfig{MaxSizeInBytes: closureSize - 1})
+		for i := 0; i < 2; i++ {
+			closure, err := cache.Get(context.Background(), "a")
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(testutils.GetWorkflowClosure(), closure))
+		}
+		assert.Equal(t, 2, reads["a"])
+	})
+	t.Run("least recently read evicted", func(t *testing.T) {
+		reads := make(map[string]int)
+		cache := NewWorkflowClosureCache(getClosureStorageClientForTest(reads),
+			runtimeInterfaces.WorkflowClosureCacheConfig{MaxSizeInBytes: 2*closureSize + 1})
+		for _, location := range []string{"a", "b", "a", "c", "a", "b"} {
+			_, err := cache.Get(context.Background(), location)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, reads)
+	})
+	t.Run("caching disabled", func(t *testing.T) {
+		reads := make(map[string]int)
+		cache := NewWorkflowClosureCache(getClosureStorageClientForTest(reads),
+			runtimeInterfaces.WorkflowClosureCacheConfig{})
+		for i := 0; i < 2; i++ {
+			_, err := cache.Get(context.Background(), "a")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, reads["a"])
+	})
+}
+
+func TestWorkflowClosureCache_GetRetries(t *testing.T) {
+	var reads int
+	mockStorageClient := commonMocks.GetMockStorageClient()
+	mockStorageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb =
+		func(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
+			reads++
+			if reads < 3 {
+				return errExpected
+			}
+			proto.Merge(msg, testutils.GetWorkflowClosure())
+			return nil
+		}
+	config := runtimeInterfaces.WorkflowClosureCacheConfig{
+		MaxSizeInBytes: int64(proto.Size(testutils.GetWorkflowClosure())),
+		FetchRetries:   2,
+	}
+
+	closure, err := NewWorkflowClosureCache(mockStorageClient, config).Get(context.Background(), "a")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(testutils.GetWorkflowClosure(), closure))
+	assert.Equal(t, 3, reads)
+
+	reads = 0
+	config.FetchRetries = 1
+	closure, err = NewWorkflowClosureCache(mockStorageClient, config).Get(context.Background(), "a")
+	assert.Nil(t, closure)
+	assert.Equal(t, codes.Internal, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.EqualError(t, err, "Unable to read WorkflowClosure from location a : expected error")
+	assert.Equal(t, 2, reads)
+}
@@ -6,19 +6,29 @@ import (
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
 	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var defaultMatchableResource = admin.MatchableResource(-1)
 
+// The longest org scoped attributes can be set for, as long as the column storing it.
+const maxOrgLength = 255
+
 func validateMatchingAttributes(attributes *admin.MatchingAttributes, identifier string) (admin.MatchableResource, error) {
 	if attributes == nil {
 		return defaultMatchableResource, shared.GetMissingArgumentError(shared.MatchingAttributes)
 	}
 	if attributes.GetTaskResourceAttributes() != nil {
+		if err := validateTaskResourceAttributes(attributes.GetTaskResourceAttributes()); err != nil {
+			return defaultMatchableResource, err
+		}
 		return admin.MatchableResource_TASK_RESOURCE, nil
 	} else if attributes.GetClusterResourceAttributes() != nil {
 		return admin.MatchableResource_CLUSTER_RESOURCE, nil
@@ -30,11 +40,85 @@ func validateMatchingAttributes(attributes *admin.MatchingAttributes, identifier
 		return admin.MatchableResource_PLUGIN_OVERRIDE, nil
 	} else if attributes.GetWorkflowExecutionConfig() != nil {
 		return admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG, nil
+	} else if attributes.GetQualityOfService() != nil {
+		if err := validateQualityOfService(attributes.GetQualityOfService()); err != nil {
+			return defaultMatchableResource, err
+		}
+		return admin.MatchableResource_QUALITY_OF_SERVICE_SPECIFICATION, nil
 	}
 	return defaultMatchableResource, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 		"Unrecognized matching attributes type for request %s", identifier)
 }
 
+func parseTaskResourceSpec(spec *admin.TaskResourceSpec, specName string) (runtimeInterfaces.TaskResourceSet, error) {
+	result := runtimeInterfaces.TaskResourceSet{}
+	for _, entry := range []struct {
+		name     core.Resources_ResourceName
+		value    string
+		quantity *resource.Quantity
+	}{
+		{core.Resources_CPU, spec.GetCpu(), &result.CPU},
+		{core.Resources_MEMORY, spec.GetMemory(), &result.Memory},
+		{core.Resources_STORAGE, spec.GetStorage(), &result.Storage},
+		{core.Resources_EPHEMERAL_STORAGE, spec.GetEphemeralStorage(), &result.EphemeralStorage},
+		{core.Resources_GPU, spec.GetGpu(), &result.GPU},
+	} {
+		if len(entry.value) == 0 {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(entry.value)
+		if err != nil {
+			return runtimeInterfaces.TaskResourceSet{}, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"invalid %v %s [%s]: %v", entry.name, specName, entry.value, err)
+		}
+		*entry.quantity = quantity
+	}
+	return result, nil
+}
+
+func validateTaskResourceAttributes(attributes *admin.TaskResourceAttributes) error {
+	requests, err := parseTaskResourceSpec(attributes.GetDefaults(), "default")
+	if err != nil {
+		return err
+	}
+	limits, err := parseTaskResourceSpec(attributes.GetLimits(), "limit")
+	if err != nil {
+		return err
+	}
+	return ValidateTaskResourceSet(requests, limits)
+}
+
+// Validates that the tags of execution queue attributes are those of configured execution queues. Executions whose
+// attributes match no queue silently fall back on the default workflow configs, so attributes naming unknown tags are
+// rejected when the queue config says so, and logged otherwise.
+func ValidateExecutionQueueAttributes(ctx context.Context, attributes *admin.MatchingAttributes,
+	queueConfig runtimeInterfaces.QueueConfiguration) error {
+	if attributes.GetExecutionQueueAttributes() == nil || queueConfig == nil {
+		return nil
+	}
+	knownTags := make(map[string]bool)
+	for _, queue := range queueConfig.GetExecutionQueues() {
+		for _, tag := range queue.Attributes {
+			knownTags[tag] = true
+		}
+	}
+	var unknownTags []string
+	for _, tag := range attributes.GetExecutionQueueAttributes().Tags {
+		if !knownTags[tag] {
+			unknownTags = append(unknownTags, tag)
+		}
+	}
+	if len(unknownTags) == 0 {
+		return nil
+	}
+	if queueConfig.GetRejectUnknownTags() {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"execution queue attributes name tags %v which no configured execution queue has", unknownTags)
+	}
+	logger.Warningf(ctx, "Execution queue attributes name tags %v which no configured execution queue has", unknownTags)
+	return nil
+}
+
 func ValidateProjectDomainAttributesUpdateRequest(ctx context.Context,
 	db repositories.RepositoryInterface, config runtimeInterfaces.ApplicationConfiguration,
 	request admin.ProjectDomainAttributesUpdateRequest) (
@@ -59,6 +143,11 @@ func ValidateProjectDomainAttributesGetRequest(ctx context.Context, db repositor
 	return nil
 }
 
+func ValidateProjectDomainAttributesGetAllRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.ProjectDomainAttributesGetAllRequest) error {
+	return ValidateProjectAndDomain(ctx, db, config, request.Project, request.Domain)
+}
+
 func ValidateProjectDomainAttributesDeleteRequest(ctx context.Context, db repositories.RepositoryInterface,
 	config runtimeInterfaces.ApplicationConfiguration, request admin.ProjectDomainAttributesDeleteRequest) error {
 	if err := ValidateProjectAndDomain(ctx, db, config, request.Project, request.Domain); err != nil {
@@ -115,3 +204,107 @@ func ValidateListAllMatchableAttributesRequest(request admin.ListMatchableAttrib
 	}
 	return nil
 }
+
+// Validates that the scope sets exactly one of its fields, so that it identifies a single level of the hierarchy, and
+// that the project or domain it sets is registered. A project and a domain together identify project domain
+// attributes, which are set as such rather than scoped.
+func validateAttributesScope(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, scope interfaces.AttributesScope) error {
+	var setFields int
+	for _, field := range []string{scope.Project, scope.Domain, scope.Org} {
+		if len(field) > 0 {
+			setFields++
+		}
+	}
+	if setFields != 1 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"exactly one of the project, domain and org of scoped attributes must be set, got [%+v]", scope)
+	}
+	switch {
+	case len(scope.Project) > 0:
+		project, err := db.ProjectRepo().Get(ctx, scope.Project)
+		if err != nil {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"failed to validate that project [%s] is registered, err: [%+v]", scope.Project, err)
+		}
+		if *project.State != int32(admin.Project_ACTIVE) {
+			return errors.NewFlyteAdminErrorf(codes.FailedPrecondition, "project [%s] is not active", scope.Project)
+		}
+		return nil
+	case len(scope.Domain) > 0:
+		return ValidateDomain(config, scope.Domain)
+	default:
+		return ValidateMaxLengthStringField(scope.Org, shared.Org, maxOrgLength)
+	}
+}
+
+func validateAttributesResourceType(resourceType admin.MatchableResource) error {
+	if _, ok := admin.MatchableResource_name[int32(resourceType)]; !ok {
+		return shared.GetInvalidArgumentError(shared.ResourceType)
+	}
+	return nil
+}
+
+func ValidateScopedAttributesUpdateRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.ScopedAttributesUpdateRequest) (
+	admin.MatchableResource, error) {
+	if err := validateAttributesScope(ctx, db, config, request.Scope); err != nil {
+		return defaultMatchableResource, err
+	}
+	// Attributes of orgs would never apply to any project.
+	if len(request.Scope.Org) > 0 && len(config.GetTopLevelConfig().GetOrgLabel()) == 0 {
+		return defaultMatchableResource, errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
+			"cannot set the attributes of org [%s], orgs aren't supported without an org label configured",
+			request.Scope.Org)
+	}
+	return validateMatchingAttributes(request.MatchingAttributes, fmt.Sprintf("%+v", request.Scope))
+}
+
+func ValidateScopedAttributesGetRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.ScopedAttributesGetRequest) error {
+	if err := validateAttributesScope(ctx, db, config, request.Scope); err != nil {
+		return err
+	}
+	return validateAttributesResourceType(request.ResourceType)
+}
+
+func ValidateScopedAttributesDeleteRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.ScopedAttributesDeleteRequest) error {
+	if err := validateAttributesScope(ctx, db, config, request.Scope); err != nil {
+		return err
+	}
+	return validateAttributesResourceType(request.ResourceType)
+}
+
+func ValidateEffectiveAttributesRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.EffectiveAttributesRequest) error {
+	if err := ValidateProjectAndDomain(ctx, db, config, request.Project, request.Domain); err != nil {
+		return err
+	}
+	return validateAttributesResourceType(request.ResourceType)
+}
+
+func ValidateActiveExecutionQuota(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, quota interfaces.ActiveExecutionQuota) error {
+	if err := ValidateProjectAndDomain(ctx, db, config, quota.Project, quota.Domain); err != nil {
+		return err
+	}
+	if quota.MaxActiveExecutions < 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid max active executions [%d], it must be a non-negative integer", quota.MaxActiveExecutions)
+	}
+	return nil
+}
+
+// Quotas are looked up whenever an execution is created, so the project isn't checked to be registered.
+func ValidateActiveExecutionQuotaGetRequest(request interfaces.ActiveExecutionQuotaRequest) error {
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	return ValidateEmptyStringField(request.Domain, shared.Domain)
+}
+
+func ValidateActiveExecutionQuotaDeleteRequest(ctx context.Context, db repositories.RepositoryInterface,
+	config runtimeInterfaces.ApplicationConfiguration, request interfaces.ActiveExecutionQuotaRequest) error {
+	return ValidateProjectAndDomain(ctx, db, config, request.Project, request.Domain)
+}
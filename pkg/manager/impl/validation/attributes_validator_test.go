@@ -3,12 +3,19 @@ package validation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 )
@@ -97,6 +104,38 @@ func TestValidateMatchingAttributes(t *testing.T) {
 			admin.MatchableResource_WORKFLOW_EXECUTION_CONFIG,
 			nil,
 		},
+		{
+			&admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_QualityOfService{
+					QualityOfService: &core.QualityOfService{
+						Designation: &core.QualityOfService_Spec{
+							Spec: &core.QualityOfServiceSpec{
+								QueueingBudget: ptypes.DurationProto(10 * time.Minute),
+							},
+						},
+					},
+				},
+			},
+			"foo",
+			admin.MatchableResource_QUALITY_OF_SERVICE_SPECIFICATION,
+			nil,
+		},
+		{
+			&admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_QualityOfService{
+					QualityOfService: &core.QualityOfService{
+						Designation: &core.QualityOfService_Spec{
+							Spec: &core.QualityOfServiceSpec{
+								QueueingBudget: ptypes.DurationProto(-time.Minute),
+							},
+						},
+					},
+				},
+			},
+			"foo",
+			defaultMatchableResource,
+			errors.NewFlyteAdminErrorf(codes.InvalidArgument, "queueing budget -1m0s must not be negative"),
+		},
 	}
 	for _, tc := range testCases {
 		matchableResource, err := validateMatchingAttributes(tc.attributes, tc.identifier)
@@ -105,6 +144,84 @@ func TestValidateMatchingAttributes(t *testing.T) {
 	}
 }
 
+func TestValidateMatchingAttributes_TaskResourceAttributes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		defaults    *admin.TaskResourceSpec
+		limits      *admin.TaskResourceSpec
+		expectedErr string
+	}{
+		{
+			name:     "valid",
+			defaults: &admin.TaskResourceSpec{Cpu: "1", Memory: "1Gi", EphemeralStorage: "1Gi", Gpu: "1"},
+			limits:   &admin.TaskResourceSpec{Cpu: "2", Memory: "2Gi", EphemeralStorage: "2Gi", Gpu: "1"},
+		},
+		{
+			name:   "only limits",
+			limits: &admin.TaskResourceSpec{Cpu: "2", Gpu: "1"},
+		},
+		{
+			name:        "cpu request greater than limit",
+			defaults:    &admin.TaskResourceSpec{Cpu: "3"},
+			limits:      &admin.TaskResourceSpec{Cpu: "2"},
+			expectedErr: "CPU request [3] is greater than the limit [2]",
+		},
+		{
+			name:        "memory request greater than limit",
+			defaults:    &admin.TaskResourceSpec{Memory: "3Gi"},
+			limits:      &admin.TaskResourceSpec{Memory: "2Gi"},
+			expectedErr: "MEMORY request [3Gi] is greater than the limit [2Gi]",
+		},
+		{
+			name:        "ephemeral storage request greater than limit",
+			defaults:    &admin.TaskResourceSpec{EphemeralStorage: "3Gi"},
+			limits:      &admin.TaskResourceSpec{EphemeralStorage: "2Gi"},
+			expectedErr: "EPHEMERAL_STORAGE request [3Gi] is greater than the limit [2Gi]",
+		},
+		{
+			name:        "gpu request greater than limit",
+			defaults:    &admin.TaskResourceSpec{Gpu: "3"},
+			limits:      &admin.TaskResourceSpec{Gpu: "2"},
+			expectedErr: "GPU request [3] is greater than the limit [2]",
+		},
+		{
+			name:        "fractional gpu request",
+			defaults:    &admin.TaskResourceSpec{Gpu: "0.5"},
+			expectedErr: "GPU request must be a whole number, got: 500m instead",
+		},
+		{
+			name:        "fractional gpu limit",
+			limits:      &admin.TaskResourceSpec{Gpu: "1.5"},
+			expectedErr: "GPU limit must be a whole number, got: 1500m instead",
+		},
+		{
+			name:        "unparseable quantity",
+			defaults:    &admin.TaskResourceSpec{Memory: "lots"},
+			expectedErr: "invalid MEMORY default [lots]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matchableResource, err := validateMatchingAttributes(&admin.MatchingAttributes{
+				Target: &admin.MatchingAttributes_TaskResourceAttributes{
+					TaskResourceAttributes: &admin.TaskResourceAttributes{
+						Defaults: tc.defaults,
+						Limits:   tc.limits,
+					},
+				},
+			}, "foo")
+			if len(tc.expectedErr) == 0 {
+				assert.NoError(t, err)
+				assert.Equal(t, admin.MatchableResource_TASK_RESOURCE, matchableResource)
+				return
+			}
+			assert.Contains(t, err.Error(), tc.expectedErr)
+			assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+			assert.Equal(t, defaultMatchableResource, matchableResource)
+		})
+	}
+}
+
 func TestValidateProjectDomainAttributesUpdateRequest(t *testing.T) {
 	_, err := ValidateProjectDomainAttributesUpdateRequest(context.Background(),
 		testutils.GetRepoWithDefaultProject(), attributesApplicationConfigProvider,
@@ -317,3 +434,148 @@ func TestValidateListAllMatchableAttributesRequest(t *testing.T) {
 	})
 	assert.Nil(t, err)
 }
+
+func TestValidateScopedAttributesUpdateRequest(t *testing.T) {
+	orgApplicationConfigProvider := testutils.GetApplicationConfigWithDefaultDomains()
+	orgApplicationConfigProvider.(*runtimeMocks.MockApplicationProvider).SetTopLevelConfig(
+		runtimeInterfaces.ApplicationConfig{OrgLabel: "org"})
+	matchingAttributes := &admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_ExecutionQueueAttributes{
+			ExecutionQueueAttributes: &admin.ExecutionQueueAttributes{Tags: []string{"tag"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		repo          repositories.RepositoryInterface
+		config        runtimeInterfaces.ApplicationConfiguration
+		scope         interfaces.AttributesScope
+		expectedError string
+	}{
+		{"project", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Project: "project"}, ""},
+		{"domain", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Domain: "development"}, ""},
+		{"org", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Org: "acme"}, ""},
+		{"unscoped", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{},
+			"exactly one of the project, domain and org of scoped attributes must be set, got [{Project: Domain: Org:}]"},
+		{"project and domain", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Project: "project", Domain: "development"},
+			"exactly one of the project, domain and org of scoped attributes must be set, got [{Project:project " +
+				"Domain:development Org:}]"},
+		{"org and domain", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Domain: "development", Org: "acme"},
+			"exactly one of the project, domain and org of scoped attributes must be set, got [{Project: " +
+				"Domain:development Org:acme}]"},
+		{"unregistered project", testutils.GetRepoWithDefaultProjectAndErr(errors.NewFlyteAdminError(
+			codes.NotFound, "project not found")), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Project: "project"},
+			"failed to validate that project [project] is registered, err: [project not found]"},
+		{"unrecognized domain", testutils.GetRepoWithDefaultProject(), orgApplicationConfigProvider,
+			interfaces.AttributesScope{Domain: "qa"}, "domain [qa] is unrecognized by system"},
+		{"orgs unsupported", testutils.GetRepoWithDefaultProject(), attributesApplicationConfigProvider,
+			interfaces.AttributesScope{Org: "acme"},
+			"cannot set the attributes of org [acme], orgs aren't supported without an org label configured"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			matchableResource, err := ValidateScopedAttributesUpdateRequest(context.Background(), tc.repo, tc.config,
+				interfaces.ScopedAttributesUpdateRequest{Scope: tc.scope, MatchingAttributes: matchingAttributes})
+			if len(tc.expectedError) > 0 {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, admin.MatchableResource_EXECUTION_QUEUE, matchableResource)
+		})
+	}
+}
+
+func TestValidateScopedAttributesGetRequest(t *testing.T) {
+	err := ValidateScopedAttributesGetRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.ScopedAttributesGetRequest{
+			Scope:        interfaces.AttributesScope{Org: "acme"},
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+	assert.NoError(t, err)
+
+	err = ValidateScopedAttributesGetRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.ScopedAttributesGetRequest{
+			Scope:        interfaces.AttributesScope{Domain: "development"},
+			ResourceType: admin.MatchableResource(-1),
+		})
+	assert.EqualError(t, err, "invalid value for resource_type")
+}
+
+func TestValidateScopedAttributesDeleteRequest(t *testing.T) {
+	err := ValidateScopedAttributesDeleteRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.ScopedAttributesDeleteRequest{
+			Scope:        interfaces.AttributesScope{Project: "project"},
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+	assert.NoError(t, err)
+
+	err = ValidateScopedAttributesDeleteRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.ScopedAttributesDeleteRequest{
+			Scope:        interfaces.AttributesScope{Project: "project", Org: "acme"},
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+	assert.Error(t, err)
+}
+
+func TestValidateEffectiveAttributesRequest(t *testing.T) {
+	err := ValidateEffectiveAttributesRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.EffectiveAttributesRequest{
+			Project:      "project",
+			Domain:       "development",
+			Workflow:     "workflow",
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+	assert.NoError(t, err)
+
+	err = ValidateEffectiveAttributesRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.EffectiveAttributesRequest{
+			Project:      "project",
+			ResourceType: admin.MatchableResource_TASK_RESOURCE,
+		})
+	assert.EqualError(t, err, "domain [] is unrecognized by system")
+
+	err = ValidateEffectiveAttributesRequest(context.Background(), testutils.GetRepoWithDefaultProject(),
+		attributesApplicationConfigProvider, interfaces.EffectiveAttributesRequest{
+			Project:      "project",
+			Domain:       "development",
+			ResourceType: admin.MatchableResource(-1),
+		})
+	assert.EqualError(t, err, "invalid value for resource_type")
+}
+
+func TestValidateExecutionQueueAttributes(t *testing.T) {
+	queueConfig := runtimeMocks.NewMockQueueConfigurationProvider([]runtimeInterfaces.ExecutionQueue{
+		{Dynamic: "gpu_dynamic", Attributes: []string{"gpu"}},
+		{Dynamic: "critical", Attributes: []string{"critical", "urgent"}},
+	}, nil)
+	getAttributes := func(tags ...string) *admin.MatchingAttributes {
+		return &admin.MatchingAttributes{
+			Target: &admin.MatchingAttributes_ExecutionQueueAttributes{
+				ExecutionQueueAttributes: &admin.ExecutionQueueAttributes{Tags: tags},
+			},
+		}
+	}
+
+	assert.NoError(t, ValidateExecutionQueueAttributes(context.Background(), getAttributes("urgent", "gpu"), queueConfig))
+	assert.NoError(t, ValidateExecutionQueueAttributes(context.Background(), &admin.MatchingAttributes{
+		Target: &admin.MatchingAttributes_ExecutionClusterLabel{
+			ExecutionClusterLabel: &admin.ExecutionClusterLabel{Value: "tpu"},
+		},
+	}, queueConfig))
+	// Unknown tags are only logged by default.
+	assert.NoError(t, ValidateExecutionQueueAttributes(context.Background(), getAttributes("gpu", "tpu"), queueConfig))
+
+	queueConfig.(*runtimeMocks.MockQueueConfigurationProvider).RejectUnknownTags = true
+	err := ValidateExecutionQueueAttributes(context.Background(), getAttributes("tpu", "gpu", "fpga"), queueConfig)
+	assert.EqualError(t, err, "execution queue attributes name tags [tpu fpga] which no configured execution queue has")
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+	assert.NoError(t, ValidateExecutionQueueAttributes(context.Background(), getAttributes("critical"), queueConfig))
+	assert.NoError(t, ValidateExecutionQueueAttributes(context.Background(), getAttributes("tpu"), nil))
+}
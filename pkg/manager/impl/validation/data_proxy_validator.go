@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"crypto/md5" // #nosec
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// Filenames form the last segment of the upload location.
+const maxUploadFilenameLength = 255
+
+func ValidateCreateUploadLocationRequest(request interfaces.CreateUploadLocationRequest, maxSizeInBytes int64) error {
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Domain, shared.Domain); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Filename, shared.Filename); err != nil {
+		return err
+	}
+	if err := ValidateMaxLengthStringField(request.Filename, shared.Filename, maxUploadFilenameLength); err != nil {
+		return err
+	}
+	if strings.ContainsAny(request.Filename, `/\`) || request.Filename == "." || request.Filename == ".." {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid filename [%s]", request.Filename)
+	}
+	if len(request.ContentMD5) != md5.Size {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"content md5 is %d bytes, expected an md5 digest of %d bytes", len(request.ContentMD5), md5.Size)
+	}
+	if request.ContentLength <= 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"content length must be positive, got %d", request.ContentLength)
+	}
+	if request.ContentLength > maxSizeInBytes {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"content length of %d bytes exceeds the limit of %d bytes", request.ContentLength, maxSizeInBytes)
+	}
+	return nil
+}
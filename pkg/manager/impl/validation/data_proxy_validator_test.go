@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"crypto/md5" // #nosec
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+func getCreateUploadLocationRequestForTest() interfaces.CreateUploadLocationRequest {
+	contentMD5 := md5.Sum([]byte("archive")) // #nosec
+	return interfaces.CreateUploadLocationRequest{
+		Project:       "project",
+		Domain:        "domain",
+		Filename:      "fast-registration.tar.gz",
+		ContentMD5:    contentMD5[:],
+		ContentLength: 100,
+	}
+}
+
+func TestValidateCreateUploadLocationRequest(t *testing.T) {
+	assert.NoError(t, ValidateCreateUploadLocationRequest(getCreateUploadLocationRequestForTest(), 100))
+}
+
+func TestValidateCreateUploadLocationRequest_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(request *interfaces.CreateUploadLocationRequest)
+	}{
+		{"missing project", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Project = ""
+		}},
+		{"missing domain", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Domain = ""
+		}},
+		{"missing filename", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Filename = ""
+		}},
+		{"filename with a path", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Filename = "../fast-registration.tar.gz"
+		}},
+		{"relative filename", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Filename = ".."
+		}},
+		{"long filename", func(request *interfaces.CreateUploadLocationRequest) {
+			request.Filename = strings.Repeat("a", maxUploadFilenameLength+1)
+		}},
+		{"missing md5", func(request *interfaces.CreateUploadLocationRequest) {
+			request.ContentMD5 = nil
+		}},
+		{"truncated md5", func(request *interfaces.CreateUploadLocationRequest) {
+			request.ContentMD5 = request.ContentMD5[:8]
+		}},
+		{"empty content", func(request *interfaces.CreateUploadLocationRequest) {
+			request.ContentLength = 0
+		}},
+		{"oversized content", func(request *interfaces.CreateUploadLocationRequest) {
+			request.ContentLength = 101
+		}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := getCreateUploadLocationRequestForTest()
+			testCase.mutate(&request)
+			err := ValidateCreateUploadLocationRequest(request, 100)
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"net/url"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// Long descriptions are stored inline in the database so their size is capped.
+const maxLongDescriptionSizeBytes = 64 * 1024
+
+var supportedDescriptionContentTypes = map[string]bool{
+	"text/markdown": true,
+	"text/plain":    true,
+	"text/x-rst":    true,
+}
+
+// Validates the identifier references a workflow, task or launch plan version.
+func ValidateDescriptionEntityIdentifier(id *core.Identifier) error {
+	if id == nil {
+		return shared.GetMissingArgumentError(shared.ID)
+	}
+	entity, ok := common.ResourceTypeToEntity[id.ResourceType]
+	if !ok {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"description entities are not supported for resource type %s", id.ResourceType.String())
+	}
+	return ValidateIdentifier(id, entity)
+}
+
+func ValidateDescriptionEntity(request interfaces.DescriptionEntity) error {
+	if err := ValidateDescriptionEntityIdentifier(request.ID); err != nil {
+		return err
+	}
+	if len(request.LongDescription) > maxLongDescriptionSizeBytes {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"long description is %d bytes which exceeds the limit of %d bytes",
+			len(request.LongDescription), maxLongDescriptionSizeBytes)
+	}
+	if !supportedDescriptionContentTypes[request.ContentType] {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"unsupported long description content type [%s]", request.ContentType)
+	}
+	if len(request.SourceCodeLink) > 0 {
+		link, err := url.Parse(request.SourceCodeLink)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || len(link.Host) == 0 {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"invalid source code link [%s], expected an http(s) url", request.SourceCodeLink)
+		}
+	}
+	return nil
+}
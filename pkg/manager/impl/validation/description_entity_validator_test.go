@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+func getDescriptionEntityForTest() interfaces.DescriptionEntity {
+	return interfaces.DescriptionEntity{
+		ID: &core.Identifier{
+			ResourceType: core.ResourceType_WORKFLOW,
+			Project:      "project",
+			Domain:       "domain",
+			Name:         "name",
+			Version:      "version",
+		},
+		LongDescription: "# Workflow",
+		ContentType:     "text/markdown",
+		SourceCodeLink:  "https://github.com/flyteorg/flytesnacks/blob/master/workflow.py",
+	}
+}
+
+func TestValidateDescriptionEntity(t *testing.T) {
+	assert.NoError(t, ValidateDescriptionEntity(getDescriptionEntityForTest()))
+
+	request := getDescriptionEntityForTest()
+	request.LongDescription = strings.Repeat("a", maxLongDescriptionSizeBytes)
+	request.SourceCodeLink = ""
+	assert.NoError(t, ValidateDescriptionEntity(request))
+}
+
+func TestValidateDescriptionEntity_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(request *interfaces.DescriptionEntity)
+	}{
+		{"missing identifier", func(request *interfaces.DescriptionEntity) {
+			request.ID = nil
+		}},
+		{"unsupported resource type", func(request *interfaces.DescriptionEntity) {
+			request.ID.ResourceType = core.ResourceType_DATASET
+		}},
+		{"missing version", func(request *interfaces.DescriptionEntity) {
+			request.ID.Version = ""
+		}},
+		{"long description too large", func(request *interfaces.DescriptionEntity) {
+			request.LongDescription = strings.Repeat("a", maxLongDescriptionSizeBytes+1)
+		}},
+		{"missing content type", func(request *interfaces.DescriptionEntity) {
+			request.ContentType = ""
+		}},
+		{"unsupported content type", func(request *interfaces.DescriptionEntity) {
+			request.ContentType = "text/html"
+		}},
+		{"relative source code link", func(request *interfaces.DescriptionEntity) {
+			request.SourceCodeLink = "flytesnacks/workflow.py"
+		}},
+		{"non http source code link", func(request *interfaces.DescriptionEntity) {
+			request.SourceCodeLink = "javascript:alert(1)"
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := getDescriptionEntityForTest()
+			tc.mutate(&request)
+			err := ValidateDescriptionEntity(request)
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+		})
+	}
+}
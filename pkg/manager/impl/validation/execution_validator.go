@@ -2,24 +2,34 @@ package validation
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/validators"
+	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const allowedExecutionNameLength = 20
 
 var executionIDRegex = regexp.MustCompile(`^[a-z][a-z\-0-9]*$`)
 
+const maxExecutionTagLength = 63
+
+// Execution tags start and end with an alphanumeric character, e.g. backfill-2024-06.
+var executionTagRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.\-]*[a-zA-Z0-9])?$`)
+
 var acceptedReferenceLaunchTypes = map[core.ResourceType]interface{}{
 	core.ResourceType_LAUNCH_PLAN: nil,
 	core.ResourceType_TASK:        nil,
@@ -62,6 +72,12 @@ func ValidateExecutionRequest(ctx context.Context, request admin.ExecutionCreate
 	if err := validateLiteralMap(request.Inputs, shared.Inputs); err != nil {
 		return err
 	}
+	if err := validateInputsSize(request.Inputs, config.GetTopLevelConfig()); err != nil {
+		return err
+	}
+	if err := validateQualityOfService(request.Spec.QualityOfService); err != nil {
+		return err
+	}
 	// TODO: Remove redundant validation with the rest of the method.
 	// This final call to validating the request ensures the notification types are expected.
 	if err := request.Validate(); err != nil {
@@ -70,6 +86,61 @@ func ValidateExecutionRequest(ctx context.Context, request admin.ExecutionCreate
 	return nil
 }
 
+const offloadedInputsHint = "large values should be passed as offloaded blob inputs instead"
+
+// Walks collections and maps down to their scalar literals so that the error names the offending element.
+func validateInputLiteralSize(name string, literal *core.Literal, maxSizeInBytes int64) error {
+	switch literal.GetValue().(type) {
+	case *core.Literal_Collection:
+		for idx, element := range literal.GetCollection().GetLiterals() {
+			if err := validateInputLiteralSize(fmt.Sprintf("%s[%d]", name, idx), element, maxSizeInBytes); err != nil {
+				return err
+			}
+		}
+	case *core.Literal_Map:
+		for key, element := range literal.GetMap().GetLiterals() {
+			if err := validateInputLiteralSize(fmt.Sprintf("%s[%s]", name, key), element, maxSizeInBytes); err != nil {
+				return err
+			}
+		}
+	default:
+		if size := int64(proto.Size(literal)); size > maxSizeInBytes {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"input %s is %d bytes which exceeds the maximum of %d bytes for a single literal, %s",
+				name, size, maxSizeInBytes, offloadedInputsHint)
+		}
+	}
+	return nil
+}
+
+// Inputs are written to blob storage with every execution and returned inline by GetExecutionData when small enough,
+// so oversized inline literals are rejected up front.
+func validateInputsSize(inputs *core.LiteralMap, config *runtimeInterfaces.ApplicationConfig) error {
+	if inputs == nil {
+		return nil
+	}
+	if maxSize := config.GetMaxExecutionInputsSizeInBytes(); maxSize > 0 {
+		if size := int64(proto.Size(inputs)); size > maxSize {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"inputs are %d bytes which exceeds the maximum of %d bytes, %s", size, maxSize, offloadedInputsHint)
+		}
+	}
+	if maxSize := config.GetMaxInputLiteralSizeInBytes(); maxSize > 0 {
+		for name, literal := range inputs.GetLiterals() {
+			if err := validateInputLiteralSize(name, literal, maxSize); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRelaunchInputOverrides checks that the inputs to override when relaunching an execution are well formed.
+// Whether they match the launch plan interface is checked once they are merged with the original inputs.
+func ValidateRelaunchInputOverrides(inputOverrides *core.LiteralMap) error {
+	return validateLiteralMap(inputOverrides, shared.Inputs)
+}
+
 func CheckAndFetchInputsForExecution(
 	userInputs *core.LiteralMap, fixedInputs *core.LiteralMap, expectedInputs *core.ParameterMap) (*core.LiteralMap, error) {
 
@@ -95,6 +166,11 @@ func CheckAndFetchInputsForExecution(
 				return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "%s %s missing", shared.ExpectedInputs, name)
 			}
 			executionInputMap[name] = expectedInput.GetDefault()
+		} else if handled, err := validateEnumOrSchemaLiteral(
+			name, executionInputMap[name], expectedInput.GetVar().GetType()); handled {
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			inputType := validators.LiteralTypeForLiteral(executionInputMap[name])
 			if !validators.AreTypesCastable(inputType, expectedInput.GetVar().GetType()) {
@@ -117,6 +193,119 @@ func CheckAndFetchInputsForExecution(
 	}, nil
 }
 
+func ValidateBulkTerminateExecutionsRequest(request interfaces.BulkTerminateExecutionsRequest) error {
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Domain, shared.Domain); err != nil {
+		return err
+	}
+	// Without either, every running execution in the project and domain would be terminated.
+	if request.LaunchPlan == nil && request.Filters == "" {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"either a launch plan or filters are required to select the executions to terminate")
+	}
+	if request.LaunchPlan != nil {
+		if err := ValidateEmptyStringField(request.LaunchPlan.Name, shared.Name); err != nil {
+			return err
+		}
+	}
+	if !request.DryRun {
+		if err := ValidateEmptyStringField(request.Cause, shared.Cause); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ValidatePurgeExecutionsRequest(request interfaces.PurgeExecutionsRequest, now time.Time) error {
+	if err := ValidateEmptyStringField(request.Domain, shared.Domain); err != nil {
+		return err
+	}
+	if request.CreatedBefore.IsZero() {
+		return shared.GetMissingArgumentError(shared.CreatedBefore)
+	}
+	if request.CreatedBefore.After(now) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "cannot purge executions created in the future")
+	}
+	if request.BatchSize < 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid batch size: %d", request.BatchSize)
+	}
+	return nil
+}
+
+func ValidateExecutionTags(tags []string) error {
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if len(tag) > maxExecutionTagLength {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"execution tag [%s] exceeds the maximum length of %d characters", tag, maxExecutionTagLength)
+		}
+		if !executionTagRegex.MatchString(tag) {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"invalid execution tag [%s], tags may only contain alphanumeric characters, '-', '_' and '.' and must "+
+					"start and end with an alphanumeric character", tag)
+		}
+		if seen[tag] {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "duplicate execution tag [%s]", tag)
+		}
+		seen[tag] = true
+	}
+	return nil
+}
+
+func isDeniedExecutionEnv(name string, denylist []string) bool {
+	for _, denied := range denylist {
+		if strings.HasSuffix(denied, "*") && strings.HasPrefix(name, strings.TrimSuffix(denied, "*")) {
+			return true
+		}
+		if name == denied {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks that the environment variables set for the tasks of an execution have valid names which the denylist doesn't
+// cover.
+func ValidateExecutionEnvs(envs map[string]string, denylist []string) error {
+	for name := range envs {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid environment variable name [%s]: %v",
+				name, errs)
+		}
+		if isDeniedExecutionEnv(name, denylist) {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"environment variable [%s] may not be set for executions", name)
+		}
+	}
+	return nil
+}
+
+func ValidateExecutionTagsUpdateRequest(request interfaces.ExecutionTagsUpdateRequest) error {
+	if err := ValidateWorkflowExecutionIdentifier(request.ExecutionID); err != nil {
+		return err
+	}
+	if len(request.Add) == 0 && len(request.Remove) == 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "no execution tags to add or remove")
+	}
+	if err := ValidateExecutionTags(request.Add); err != nil {
+		return err
+	}
+	if err := ValidateExecutionTags(request.Remove); err != nil {
+		return err
+	}
+	for _, tag := range request.Add {
+		for _, removedTag := range request.Remove {
+			if tag == removedTag {
+				return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+					"execution tag [%s] can't be both added and removed", tag)
+			}
+		}
+	}
+	return nil
+}
+
 func CheckValidExecutionID(executionID, fieldName string) error {
 	if len(executionID) > allowedExecutionNameLength {
 		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
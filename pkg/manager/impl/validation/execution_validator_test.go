@@ -3,13 +3,17 @@ package validation
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
@@ -86,6 +90,107 @@ func TestGetExecutionInputs(t *testing.T) {
 	assert.EqualValues(t, expectedMap, *actualInputs)
 }
 
+func TestValidateExecInputsEnumValue(t *testing.T) {
+	defaultInputs := &core.ParameterMap{
+		Parameters: map[string]*core.Parameter{
+			"color": {
+				Var:      &core.Variable{Type: getEnumType("red", "green")},
+				Behavior: &core.Parameter_Required{Required: true},
+			},
+		},
+	}
+	_, err := CheckAndFetchInputsForExecution(&core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"color": getStringLiteral("green"),
+		},
+	}, nil, defaultInputs)
+	assert.NoError(t, err)
+
+	_, err = CheckAndFetchInputsForExecution(&core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"color": getStringLiteral("blue"),
+		},
+	}, nil, defaultInputs)
+	assert.EqualError(t, err, "invalid value [blue] for enum input color, expected one of [red, green]")
+}
+
+func TestValidateInputsSize(t *testing.T) {
+	largeString := strings.Repeat("a", 100)
+	inputs := &core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"small": coreutils.MustMakeLiteral("a"),
+			"large": coreutils.MustMakeLiteral(largeString),
+		},
+	}
+	nestedInputs := &core.LiteralMap{
+		Literals: map[string]*core.Literal{
+			"list": coreutils.MustMakeLiteral([]interface{}{"a", largeString}),
+		},
+	}
+	tests := []struct {
+		name          string
+		inputs        *core.LiteralMap
+		config        runtimeInterfaces.ApplicationConfig
+		expectedError string
+	}{
+		{
+			name:   "no limits",
+			inputs: inputs,
+		},
+		{
+			name:   "no inputs",
+			inputs: nil,
+			config: runtimeInterfaces.ApplicationConfig{
+				MaxExecutionInputsSizeInBytes: 1,
+				MaxInputLiteralSizeInBytes:    1,
+			},
+		},
+		{
+			name:   "within limits",
+			inputs: inputs,
+			config: runtimeInterfaces.ApplicationConfig{
+				MaxExecutionInputsSizeInBytes: 1024,
+				MaxInputLiteralSizeInBytes:    1024,
+			},
+		},
+		{
+			name:   "inputs too large",
+			inputs: inputs,
+			config: runtimeInterfaces.ApplicationConfig{
+				MaxExecutionInputsSizeInBytes: 64,
+			},
+			expectedError: "exceeds the maximum of 64 bytes, large values should be passed as offloaded blob inputs instead",
+		},
+		{
+			name:   "literal too large",
+			inputs: inputs,
+			config: runtimeInterfaces.ApplicationConfig{
+				MaxInputLiteralSizeInBytes: 64,
+			},
+			expectedError: "input large is",
+		},
+		{
+			name:   "nested literal too large",
+			inputs: nestedInputs,
+			config: runtimeInterfaces.ApplicationConfig{
+				MaxInputLiteralSizeInBytes: 64,
+			},
+			expectedError: "input list[1] is",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateInputsSize(test.inputs, &test.config)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
+
 func TestValidateExecInputsWrongType(t *testing.T) {
 	executionRequest := testutils.GetExecutionRequest()
 	lpRequest := testutils.GetLaunchPlanRequest()
@@ -223,3 +328,124 @@ func TestValidateWorkflowExecutionIdentifier_Error(t *testing.T) {
 		Name:   "name",
 	}))
 }
+
+func TestValidateBulkTerminateExecutionsRequest(t *testing.T) {
+	assert.NoError(t, ValidateBulkTerminateExecutionsRequest(interfaces.BulkTerminateExecutionsRequest{
+		Project:    "project",
+		Domain:     "domain",
+		LaunchPlan: &core.Identifier{Name: "lp"},
+		Cause:      "cause",
+	}))
+	assert.NoError(t, ValidateBulkTerminateExecutionsRequest(interfaces.BulkTerminateExecutionsRequest{
+		Project: "project",
+		Domain:  "domain",
+		Filters: "eq(user,alice)",
+		DryRun:  true,
+	}))
+
+	for name, request := range map[string]interfaces.BulkTerminateExecutionsRequest{
+		"missing project":          {Domain: "domain", Filters: "eq(user,alice)", Cause: "cause"},
+		"missing domain":           {Project: "project", Filters: "eq(user,alice)", Cause: "cause"},
+		"no selection":             {Project: "project", Domain: "domain", Cause: "cause"},
+		"missing launch plan name": {Project: "project", Domain: "domain", LaunchPlan: &core.Identifier{}, Cause: "cause"},
+		"missing cause":            {Project: "project", Domain: "domain", Filters: "eq(user,alice)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateBulkTerminateExecutionsRequest(request))
+		})
+	}
+}
+
+func TestValidatePurgeExecutionsRequest(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, ValidatePurgeExecutionsRequest(interfaces.PurgeExecutionsRequest{
+		Domain:        "domain",
+		CreatedBefore: now.Add(-time.Hour),
+	}, now))
+	assert.NoError(t, ValidatePurgeExecutionsRequest(interfaces.PurgeExecutionsRequest{
+		Project:       "project",
+		Domain:        "domain",
+		CreatedBefore: now,
+		BatchSize:     10,
+		DryRun:        true,
+	}, now))
+
+	for expected, request := range map[string]interfaces.PurgeExecutionsRequest{
+		"missing domain":                                {Project: "project", CreatedBefore: now},
+		"missing created_before":                        {Domain: "domain"},
+		"cannot purge executions created in the future": {Domain: "domain", CreatedBefore: now.Add(time.Second)},
+		"invalid batch size: -1":                        {Domain: "domain", CreatedBefore: now, BatchSize: -1},
+	} {
+		t.Run(expected, func(t *testing.T) {
+			assert.EqualError(t, ValidatePurgeExecutionsRequest(request, now), expected)
+		})
+	}
+}
+
+func TestValidateExecutionTags(t *testing.T) {
+	assert.NoError(t, ValidateExecutionTags(nil))
+	assert.NoError(t, ValidateExecutionTags([]string{"backfill-2024-06", "team_a", "v1.2", "x"}))
+
+	for name, tags := range map[string][]string{
+		"empty tag":              {""},
+		"too long":               {strings.Repeat("a", 64)},
+		"whitespace":             {"back fill"},
+		"invalid character":      {"backfill/2024"},
+		"leading separator":      {"-backfill"},
+		"trailing separator":     {"backfill."},
+		"duplicate tag":          {"backfill", "backfill"},
+		"non ascii alphanumeric": {"bäckfill"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateExecutionTags(tags))
+		})
+	}
+}
+
+func TestValidateExecutionEnvs(t *testing.T) {
+	denylist := []string{"AWS_*", "HOME"}
+	assert.NoError(t, ValidateExecutionEnvs(nil, denylist))
+	assert.NoError(t, ValidateExecutionEnvs(map[string]string{
+		"EXPERIMENT_ID": "42",
+		"feature.flag":  "on",
+		"HOMEDIR":       "/tmp",
+	}, denylist))
+
+	for name, envs := range map[string]map[string]string{
+		"empty name":         {"": "a"},
+		"leading digit":      {"1FLAG": "a"},
+		"invalid character":  {"FEATURE=FLAG": "a"},
+		"denied prefix":      {"AWS_SECRET_ACCESS_KEY": "a"},
+		"denied name":        {"HOME": "/tmp"},
+		"denied prefix only": {"AWS_": "a"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateExecutionEnvs(envs, denylist))
+		})
+	}
+}
+
+func TestValidateExecutionTagsUpdateRequest(t *testing.T) {
+	executionID := &core.WorkflowExecutionIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "name",
+	}
+	assert.NoError(t, ValidateExecutionTagsUpdateRequest(interfaces.ExecutionTagsUpdateRequest{
+		ExecutionID: executionID,
+		Add:         []string{"backfill"},
+		Remove:      []string{"adhoc"},
+	}))
+
+	for name, request := range map[string]interfaces.ExecutionTagsUpdateRequest{
+		"missing execution id":      {Add: []string{"backfill"}},
+		"nothing to update":         {ExecutionID: executionID},
+		"invalid added tag":         {ExecutionID: executionID, Add: []string{"back fill"}},
+		"invalid removed tag":       {ExecutionID: executionID, Remove: []string{"back fill"}},
+		"added and removed at once": {ExecutionID: executionID, Add: []string{"backfill"}, Remove: []string{"backfill"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, ValidateExecutionTagsUpdateRequest(request))
+		})
+	}
+}
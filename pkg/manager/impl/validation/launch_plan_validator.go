@@ -2,7 +2,10 @@ package validation
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/async/notifications"
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
@@ -11,6 +14,7 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/validators"
+	"github.com/robfig/cron/v3"
 	"google.golang.org/grpc/codes"
 )
 
@@ -54,12 +58,46 @@ func ValidateLaunchPlan(ctx context.Context,
 	if err := request.Validate(); err != nil {
 		return err
 	}
+	if err := validateNotifications(*config.GetNotificationsConfig(), request.Spec.GetEntityMetadata().GetNotifications()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Checks notification recipients which name a webhook refer to a configured one whose body template renders.
+func validateNotifications(config runtimeInterfaces.NotificationsConfig, notificationList []*admin.Notification) error {
+	for _, notification := range notificationList {
+		var recipients []string
+		if notification.GetEmail() != nil {
+			recipients = notification.GetEmail().GetRecipientsEmail()
+		} else if notification.GetPagerDuty() != nil {
+			recipients = notification.GetPagerDuty().GetRecipientsEmail()
+		} else if notification.GetSlack() != nil {
+			recipients = notification.GetSlack().GetRecipientsEmail()
+		}
+		webhooks, _ := notifications.SplitWebhookRecipients(recipients)
+		for _, webhook := range webhooks {
+			if err := notifications.ValidateWebhook(config, webhook); err != nil {
+				return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid notification: %v", err)
+			}
+		}
+	}
 	return nil
 }
 
 func validateSchedule(request admin.LaunchPlanCreateRequest, expectedInputs *core.ParameterMap) error {
 	schedule := request.GetSpec().GetEntityMetadata().GetSchedule()
-	if schedule.GetCronExpression() != "" || schedule.GetRate() != nil {
+	if err := validateScheduleExpression(schedule); err != nil {
+		return err
+	}
+	if _, err := common.GetScheduleOffset(request.GetSpec().GetAnnotations()); err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "%v", err)
+	}
+	if _, err := common.GetScheduleOverlapPolicy(request.GetSpec().GetAnnotations(),
+		runtimeInterfaces.ScheduleOverlapPolicyAllow); err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "%v", err)
+	}
+	if schedule.GetCronExpression() != "" || schedule.GetCronSchedule() != nil || schedule.GetRate() != nil {
 		for key, value := range expectedInputs.Parameters {
 			if value.GetRequired() && key != schedule.GetKickoffTimeInputArg() {
 				return errors.NewFlyteAdminErrorf(
@@ -82,6 +120,111 @@ func validateSchedule(request admin.LaunchPlanCreateRequest, expectedInputs *cor
 	return nil
 }
 
+// Scheduled launch plans can't fire more often than this.
+const minScheduleInterval = time.Minute
+
+// Field names of the standard cron dialect parsed by the native scheduler, in order.
+var cronScheduleFields = []string{"minute", "hour", "day of month", "month", "day of week"}
+
+// Field names of the deprecated cron expression dialect used by AWS CloudWatch, in order.
+var cronExpressionFields = []string{"minute", "hour", "day of month", "month", "day of week", "year"}
+
+// Only these cron expression fields share their syntax with the standard dialect, the remaining ones accept
+// CloudWatch specific extensions such as L, W and #.
+var cronExpressionSharedFields = []int{0, 1, 3}
+
+func validateScheduleExpression(schedule *admin.Schedule) error {
+	if schedule.GetCronSchedule() != nil {
+		return validateCronSchedule(schedule.GetCronSchedule().GetSchedule())
+	}
+	if schedule.GetCronExpression() != "" {
+		return validateCronExpression(schedule.GetCronExpression())
+	}
+	if schedule.GetRate() != nil {
+		return validateFixedRate(schedule.GetRate())
+	}
+	return nil
+}
+
+// validateCronSchedule parses the schedule the same way the native scheduler does, including the @hourly style
+// descriptors.
+func validateCronSchedule(cronSchedule string) error {
+	fields := strings.Fields(cronSchedule)
+	// A leading time zone is accepted by the parser and isn't a schedule field.
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "TZ=") || strings.HasPrefix(fields[0], "CRON_TZ=")) {
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+		parsed, err := cron.ParseStandard(cronSchedule)
+		if err != nil {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid cron schedule [%s]: %v", cronSchedule, err)
+		}
+		if every, ok := parsed.(cron.ConstantDelaySchedule); ok && every.Delay < minScheduleInterval {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"invalid cron schedule [%s]: schedules can't fire more often than every %v", cronSchedule,
+				minScheduleInterval)
+		}
+		return nil
+	}
+	if len(fields) != len(cronScheduleFields) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid cron schedule [%s]: expected %d fields (%s) but found %d", cronSchedule, len(cronScheduleFields),
+			strings.Join(cronScheduleFields, ", "), len(fields))
+	}
+	for position := range fields {
+		if err := validateCronField(cronSchedule, fields, cronScheduleFields, position); err != nil {
+			return err
+		}
+	}
+	if _, err := cron.ParseStandard(cronSchedule); err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid cron schedule [%s]: %v", cronSchedule, err)
+	}
+	return nil
+}
+
+// validateCronExpression checks the deprecated six field cron dialect, which is only parsed by AWS CloudWatch.
+func validateCronExpression(cronExpression string) error {
+	fields := strings.Fields(cronExpression)
+	if len(fields) != len(cronExpressionFields) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid cron expression [%s]: expected %d fields (%s) but found %d", cronExpression,
+			len(cronExpressionFields), strings.Join(cronExpressionFields, ", "), len(fields))
+	}
+	for _, position := range cronExpressionSharedFields {
+		if err := validateCronField(cronExpression, fields, cronExpressionFields, position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCronField parses the field at the given position on its own, every other field matching anything, so that
+// parse errors can be attributed to the offending field.
+func validateCronField(expression string, fields, fieldNames []string, position int) error {
+	probe := []string{"*", "*", "*", "*", "*"}
+	probe[position] = fields[position]
+	if _, err := cron.ParseStandard(strings.Join(probe, " ")); err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid cron schedule [%s]: %s field at position %d [%s]: %v", expression, fieldNames[position],
+			position+1, fields[position], err)
+	}
+	return nil
+}
+
+func validateFixedRate(rate *admin.FixedRate) error {
+	if rate.GetValue() == 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "fixed rate schedules require a positive value")
+	}
+	switch rate.GetUnit() {
+	case admin.FixedRateUnit_MINUTE, admin.FixedRateUnit_HOUR, admin.FixedRateUnit_DAY:
+		return nil
+	default:
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"unsupported unit [%v] for fixed rate schedules, the finest supported unit is a %v", rate.GetUnit(),
+			admin.FixedRateUnit_MINUTE)
+	}
+}
+
 func checkAndFetchExpectedInputForLaunchPlan(
 	workflowVariableMap *core.VariableMap, fixedInputs *core.LiteralMap, defaultInputs *core.ParameterMap) (*core.ParameterMap, error) {
 	expectedInputMap := map[string]*core.Parameter{}
@@ -117,11 +260,21 @@ func checkAndFetchExpectedInputForLaunchPlan(
 		value, ok := workflowExpectedInputMap[name]
 		if !ok {
 			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "unexpected default_input %s", name)
+		}
+		if handled, err := validateEnumOrSchemaType(name, defaultInput.GetVar().GetType(), value.GetType()); handled {
+			if err != nil {
+				return nil, err
+			}
 		} else if !validators.AreTypesCastable(defaultInput.GetVar().GetType(), value.GetType()) {
 			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
 				"invalid default_input wrong type %s, expected %v, got %v instead",
 				name, defaultInput.GetVar().GetType().String(), value.GetType().String())
 		}
+		if defaultInput.GetDefault() != nil {
+			if _, err := validateEnumOrSchemaLiteral(name, defaultInput.GetDefault(), value.GetType()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	for name, fixedInput := range fixedInputMap {
@@ -129,6 +282,17 @@ func checkAndFetchExpectedInputForLaunchPlan(
 		if !ok {
 			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument, "unexpected fixed_input %s", name)
 		}
+		// An input can either be overridable with a default or pinned to a fixed value, but not both.
+		if _, ok := defaultInputMap[name]; ok {
+			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"input %s cannot be specified in both fixed_inputs and default_inputs", name)
+		}
+		if handled, err := validateEnumOrSchemaLiteral(name, fixedInput, value.GetType()); handled {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
 		inputType := validators.LiteralTypeForLiteral(fixedInput)
 		if !validators.AreTypesCastable(inputType, value.GetType()) {
 			return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
@@ -8,9 +8,14 @@ import (
 
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	runtimeMocks "github.com/flyteorg/flyteadmin/pkg/runtime/mocks"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 var lpApplicationConfig = testutils.GetApplicationConfigWithDefaultDomains()
@@ -276,6 +281,112 @@ func TestGetLpExpectedNoDefaultInput(t *testing.T) {
 	assert.EqualValues(t, expectedMap, *actualMap)
 }
 
+func TestGetLpExpectedInputs_FixedAndDefault(t *testing.T) {
+	stringType := &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_STRING}}
+	workflowInputs := &core.VariableMap{
+		Variables: map[string]*core.Variable{
+			"foo": {Type: stringType},
+			"bar": {Type: stringType},
+			"baz": {Type: stringType, Description: "baz description"},
+		},
+	}
+	fooDefault := &core.Parameter{
+		Var:      &core.Variable{Type: stringType},
+		Behavior: &core.Parameter_Default{Default: coreutils.MustMakeLiteral("foo-value")},
+	}
+	bazRequired := &core.Parameter{
+		Var:      &core.Variable{Type: stringType, Description: "baz description"},
+		Behavior: &core.Parameter_Required{Required: true},
+	}
+	barRequired := &core.Parameter{
+		Var:      &core.Variable{Type: stringType},
+		Behavior: &core.Parameter_Required{Required: true},
+	}
+	fooRequired := &core.Parameter{
+		Var:      &core.Variable{Type: stringType},
+		Behavior: &core.Parameter_Required{Required: true},
+	}
+
+	testCases := []struct {
+		name           string
+		fixedInputs    *core.LiteralMap
+		defaultInputs  *core.ParameterMap
+		expectedParams map[string]*core.Parameter
+	}{
+		{
+			name: "default inputs only",
+			defaultInputs: &core.ParameterMap{
+				Parameters: map[string]*core.Parameter{"foo": fooDefault},
+			},
+			expectedParams: map[string]*core.Parameter{
+				"foo": fooDefault,
+				"bar": barRequired,
+				"baz": bazRequired,
+			},
+		},
+		{
+			name: "fixed inputs only",
+			fixedInputs: &core.LiteralMap{
+				Literals: map[string]*core.Literal{"bar": coreutils.MustMakeLiteral("bar-value")},
+			},
+			expectedParams: map[string]*core.Parameter{
+				"foo": fooRequired,
+				"baz": bazRequired,
+			},
+		},
+		{
+			name: "fixed and default inputs",
+			fixedInputs: &core.LiteralMap{
+				Literals: map[string]*core.Literal{"bar": coreutils.MustMakeLiteral("bar-value")},
+			},
+			defaultInputs: &core.ParameterMap{
+				Parameters: map[string]*core.Parameter{"foo": fooDefault},
+			},
+			expectedParams: map[string]*core.Parameter{
+				"foo": fooDefault,
+				"baz": bazRequired,
+			},
+		},
+		{
+			name: "workflow inputs absent from both",
+			expectedParams: map[string]*core.Parameter{
+				"foo": fooRequired,
+				"bar": barRequired,
+				"baz": bazRequired,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualMap, err := checkAndFetchExpectedInputForLaunchPlan(workflowInputs, tc.fixedInputs, tc.defaultInputs)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectedParams, actualMap.GetParameters())
+		})
+	}
+}
+
+func TestGetLpExpectedInputInBothFixedAndDefault(t *testing.T) {
+	request := testutils.GetLaunchPlanRequest()
+	request.Spec.FixedInputs.Literals["foo"] = coreutils.MustMakeLiteral("fixed-foo-value")
+	actualMap, err := checkAndFetchExpectedInputForLaunchPlan(
+		&core.VariableMap{
+			Variables: map[string]*core.Variable{
+				"foo": {
+					Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_STRING}},
+				},
+				"bar": {
+					Type: &core.LiteralType{Type: &core.LiteralType_Simple{Simple: core.SimpleType_STRING}},
+				},
+			},
+		},
+		request.GetSpec().GetFixedInputs(), request.GetSpec().GetDefaultInputs(),
+	)
+
+	assert.EqualError(t, err, "input foo cannot be specified in both fixed_inputs and default_inputs")
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+	assert.Nil(t, actualMap)
+}
+
 func TestValidateSchedule_NoSchedule(t *testing.T) {
 	request := testutils.GetLaunchPlanRequest()
 	inputMap := &core.ParameterMap{
@@ -382,3 +493,281 @@ func TestValidateSchedule_KickoffTimeBound(t *testing.T) {
 	err := validateSchedule(request, inputMap)
 	assert.Nil(t, err)
 }
+
+func TestValidateSchedule_Offset(t *testing.T) {
+	for _, tc := range []struct {
+		offset string
+		valid  bool
+	}{
+		{"90s", true},
+		{"0s", true},
+		{"-5m", false},
+		{"soon", false},
+	} {
+		t.Run(tc.offset, func(t *testing.T) {
+			request := testutils.GetLaunchPlanRequestWithCronSchedule("* * * * * *")
+			request.Spec.Annotations = &admin.Annotations{
+				Values: map[string]string{common.ScheduleOffsetAnnotation: tc.offset},
+			}
+			err := validateSchedule(request, &core.ParameterMap{})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+			}
+		})
+	}
+}
+
+func TestValidateSchedule_OverlapPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy string
+		valid  bool
+	}{
+		{"SKIP", true},
+		{"replace", true},
+		{"queue", false},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			request := testutils.GetLaunchPlanRequestWithCronSchedule("* * * * * *")
+			request.Spec.Annotations = &admin.Annotations{
+				Values: map[string]string{common.ScheduleOverlapPolicyAnnotation: tc.policy},
+			}
+			err := validateSchedule(request, &core.ParameterMap{})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+			}
+		})
+	}
+}
+
+func TestValidateScheduleExpression(t *testing.T) {
+	cronSchedule := func(schedule string) *admin.Schedule {
+		return &admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{
+				CronSchedule: &admin.CronSchedule{Schedule: schedule},
+			},
+		}
+	}
+	cronExpression := func(expression string) *admin.Schedule {
+		return &admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronExpression{CronExpression: expression},
+		}
+	}
+	rate := func(value uint32, unit admin.FixedRateUnit) *admin.Schedule {
+		return &admin.Schedule{
+			ScheduleExpression: &admin.Schedule_Rate{
+				Rate: &admin.FixedRate{Value: value, Unit: unit},
+			},
+		}
+	}
+	testCases := []struct {
+		name          string
+		schedule      *admin.Schedule
+		expectedError string
+	}{
+		{"no schedule", nil, ""},
+		{"cron schedule", cronSchedule("0 0 * * *"), ""},
+		{"cron schedule with ranges and steps", cronSchedule("*/15 9-17 * JAN-MAR MON-FRI"), ""},
+		{"cron schedule with time zone", cronSchedule("CRON_TZ=America/New_York 0 6 * * *"), ""},
+		{"cron schedule hourly descriptor", cronSchedule("@hourly"), ""},
+		{"cron schedule every descriptor", cronSchedule("@every 1h"), ""},
+		{"cron schedule every descriptor at the floor", cronSchedule("@every 1m"), ""},
+		{"cron schedule every descriptor below the floor", cronSchedule("@every 30s"),
+			"invalid cron schedule [@every 30s]: schedules can't fire more often than every 1m0s"},
+		{"cron schedule unknown descriptor", cronSchedule("@fortnightly"), "invalid cron schedule [@fortnightly]"},
+		{"cron schedule with six fields", cronSchedule("0 0 * * * *"),
+			"invalid cron schedule [0 0 * * * *]: expected 5 fields (minute, hour, day of month, month, day of week) but found 6"},
+		{"cron schedule with four fields", cronSchedule("0 0 * *"), "expected 5 fields"},
+		{"cron schedule empty", cronSchedule(""), "expected 5 fields"},
+		{"cron schedule minute out of range", cronSchedule("60 0 * * *"),
+			"invalid cron schedule [60 0 * * *]: minute field at position 1 [60]"},
+		{"cron schedule invalid month", cronSchedule("0 0 * FOO *"),
+			"invalid cron schedule [0 0 * FOO *]: month field at position 4 [FOO]"},
+		{"cron schedule invalid day of week", cronSchedule("0 0 * * 8"),
+			"invalid cron schedule [0 0 * * 8]: day of week field at position 5 [8]"},
+		{"cron expression", cronExpression("0 10 * * ? *"), ""},
+		{"cron expression with extensions", cronExpression("0 10 L * ? 2021"), ""},
+		{"cron expression with five fields", cronExpression("0 10 * * ?"),
+			"invalid cron expression [0 10 * * ?]: expected 6 fields (minute, hour, day of month, month, day of week, year) but found 5"},
+		{"cron expression hour out of range", cronExpression("0 24 * * ? *"),
+			"invalid cron schedule [0 24 * * ? *]: hour field at position 2 [24]"},
+		{"rate in minutes", rate(1, admin.FixedRateUnit_MINUTE), ""},
+		{"rate in days", rate(7, admin.FixedRateUnit_DAY), ""},
+		{"rate of zero", rate(0, admin.FixedRateUnit_HOUR), "fixed rate schedules require a positive value"},
+		{"rate with unknown unit", rate(1, admin.FixedRateUnit(10)),
+			"unsupported unit [10] for fixed rate schedules, the finest supported unit is a MINUTE"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateScheduleExpression(tc.schedule)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+			assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+		})
+	}
+}
+
+func TestValidateSchedule_CronScheduleKickoffTimeArgDoesNotExist(t *testing.T) {
+	request := testutils.GetLaunchPlanRequest()
+	request.Spec.EntityMetadata = &admin.LaunchPlanMetadata{
+		Schedule: &admin.Schedule{
+			ScheduleExpression: &admin.Schedule_CronSchedule{
+				CronSchedule: &admin.CronSchedule{Schedule: "0 0 * * *"},
+			},
+			KickoffTimeInputArg: "Does not exist",
+		},
+	}
+	err := validateSchedule(request, &core.ParameterMap{Parameters: map[string]*core.Parameter{}})
+	assert.NotNil(t, err)
+}
+
+func TestValidateSchedule_InvalidCronSchedule(t *testing.T) {
+	request := testutils.GetLaunchPlanRequestWithCronSchedule("0 0 * *")
+	err := validateSchedule(request, &core.ParameterMap{Parameters: map[string]*core.Parameter{}})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
+
+func TestGetLpExpectedInputs_EnumAndSchema(t *testing.T) {
+	colorType := getEnumType("red", "green")
+	datasetType := getSchemaType(
+		getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER),
+		getSchemaColumn("b", core.SchemaType_SchemaColumn_STRING))
+	workflowInputs := &core.VariableMap{
+		Variables: map[string]*core.Variable{
+			"color":   {Type: colorType},
+			"dataset": {Type: datasetType},
+		},
+	}
+	tests := []struct {
+		name          string
+		fixedInputs   map[string]*core.Literal
+		defaultInputs map[string]*core.Parameter
+		expectedError string
+	}{
+		{
+			name: "allowed enum fixed input",
+			fixedInputs: map[string]*core.Literal{
+				"color": getStringLiteral("green"),
+			},
+		},
+		{
+			name: "disallowed enum fixed input",
+			fixedInputs: map[string]*core.Literal{
+				"color": getStringLiteral("blue"),
+			},
+			expectedError: "invalid value [blue] for enum input color, expected one of [red, green]",
+		},
+		{
+			name: "disallowed enum default input",
+			defaultInputs: map[string]*core.Parameter{
+				"color": {
+					Var:      &core.Variable{Type: colorType},
+					Behavior: &core.Parameter_Default{Default: getStringLiteral("blue")},
+				},
+			},
+			expectedError: "invalid value [blue] for enum input color, expected one of [red, green]",
+		},
+		{
+			name: "wider enum default input type",
+			defaultInputs: map[string]*core.Parameter{
+				"color": {
+					Var:      &core.Variable{Type: getEnumType("red", "green", "blue")},
+					Behavior: &core.Parameter_Required{Required: true},
+				},
+			},
+			expectedError: "invalid value [blue] for enum input color, expected one of [red, green]",
+		},
+		{
+			name: "schema fixed input with extra columns",
+			fixedInputs: map[string]*core.Literal{
+				"dataset": getSchemaLiteral(getSchemaType(
+					getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER),
+					getSchemaColumn("b", core.SchemaType_SchemaColumn_STRING),
+					getSchemaColumn("c", core.SchemaType_SchemaColumn_FLOAT))),
+			},
+		},
+		{
+			name: "schema fixed input missing a column",
+			fixedInputs: map[string]*core.Literal{
+				"dataset": getSchemaLiteral(getSchemaType(
+					getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER))),
+			},
+			expectedError: "schema input dataset is missing column [b] of type STRING",
+		},
+		{
+			name: "schema default input with a retyped column",
+			defaultInputs: map[string]*core.Parameter{
+				"dataset": {
+					Var: &core.Variable{Type: getSchemaType(
+						getSchemaColumn("a", core.SchemaType_SchemaColumn_FLOAT),
+						getSchemaColumn("b", core.SchemaType_SchemaColumn_STRING))},
+					Behavior: &core.Parameter_Required{Required: true},
+				},
+			},
+			expectedError: "column [a] of schema input dataset has type FLOAT, expected INTEGER",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := checkAndFetchExpectedInputForLaunchPlan(workflowInputs,
+				&core.LiteralMap{Literals: test.fixedInputs}, &core.ParameterMap{Parameters: test.defaultInputs})
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateLpNotificationWebhooks(t *testing.T) {
+	applicationConfig := testutils.GetApplicationConfigWithDefaultDomains()
+	applicationConfig.(*runtimeMocks.MockApplicationProvider).SetNotificationsConfig(runtimeInterfaces.NotificationsConfig{
+		NotificationsWebhookConfig: runtimeInterfaces.NotificationsWebhookConfig{
+			Endpoints: map[string]runtimeInterfaces.WebhookEndpointConfig{
+				"airflow": {
+					Body: `{"execution": {{ json .Name }}}`,
+				},
+				"broken": {
+					Body: `{"execution": {{ .Name }`,
+				},
+				"unknown-field": {
+					Body: `{"execution": {{ .Workflow }}}`,
+				},
+			},
+		},
+	})
+	getRequest := func(recipient string) admin.LaunchPlanCreateRequest {
+		request := testutils.GetLaunchPlanRequest()
+		request.Spec.EntityMetadata = &admin.LaunchPlanMetadata{
+			Notifications: []*admin.Notification{
+				{
+					Phases: []core.WorkflowExecution_Phase{core.WorkflowExecution_FAILED},
+					Type: &admin.Notification_Email{
+						Email: &admin.EmailNotification{
+							RecipientsEmail: []string{"team@example.com", recipient},
+						},
+					},
+				},
+			},
+		}
+		return request
+	}
+
+	err := ValidateLaunchPlan(context.Background(), getRequest("webhook:airflow"),
+		testutils.GetRepoWithDefaultProject(), applicationConfig, getWorkflowInterface())
+	assert.NoError(t, err)
+
+	for _, recipient := range []string{"webhook:missing", "webhook:broken", "webhook:unknown-field"} {
+		err = ValidateLaunchPlan(context.Background(), getRequest(recipient),
+			testutils.GetRepoWithDefaultProject(), applicationConfig, getWorkflowInterface())
+		assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code(), recipient)
+	}
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"google.golang.org/grpc/codes"
+)
+
+// Enum and schema inputs are checked value by value rather than by type castability alone, so that errors can name the
+// offending enum value or schema column. These helpers report whether the expected type was handled this way along
+// with any violation; all other types are left to validators.AreTypesCastable.
+
+func validateEnumValue(inputName, value string, enumType *core.EnumType) error {
+	for _, allowedValue := range enumType.GetValues() {
+		if value == allowedValue {
+			return nil
+		}
+	}
+	return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+		"invalid value [%s] for enum input %s, expected one of [%s]",
+		value, inputName, strings.Join(enumType.GetValues(), ", "))
+}
+
+// Follows the compiler's schema castability rules: a schema without columns binds to any other schema, otherwise every
+// column the expected schema declares must be present in the provided one with the same type. The provided schema may
+// carry additional columns.
+func validateSchemaColumns(inputName string, schemaType, expectedSchemaType *core.SchemaType) error {
+	if len(schemaType.GetColumns()) == 0 || len(expectedSchemaType.GetColumns()) == 0 {
+		return nil
+	}
+	columnTypes := make(map[string]core.SchemaType_SchemaColumn_SchemaColumnType, len(schemaType.GetColumns()))
+	for _, column := range schemaType.GetColumns() {
+		columnTypes[column.Name] = column.Type
+	}
+	for _, expectedColumn := range expectedSchemaType.GetColumns() {
+		columnType, ok := columnTypes[expectedColumn.Name]
+		if !ok {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"schema input %s is missing column [%s] of type %s", inputName, expectedColumn.Name,
+				expectedColumn.Type.String())
+		}
+		if columnType != expectedColumn.Type {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"column [%s] of schema input %s has type %s, expected %s", expectedColumn.Name, inputName,
+				columnType.String(), expectedColumn.Type.String())
+		}
+	}
+	return nil
+}
+
+// validateEnumOrSchemaLiteral checks a literal bound to an enum or schema input. Enum values are provided as string
+// literals.
+func validateEnumOrSchemaLiteral(inputName string, literal *core.Literal, expectedType *core.LiteralType) (
+	bool, error) {
+	if expectedType.GetEnumType() != nil {
+		stringValue, ok := literal.GetScalar().GetPrimitive().GetValue().(*core.Primitive_StringValue)
+		if !ok {
+			return false, nil
+		}
+		return true, validateEnumValue(inputName, stringValue.StringValue, expectedType.GetEnumType())
+	}
+	if expectedType.GetSchema() != nil && literal.GetScalar().GetSchema() != nil {
+		return true, validateSchemaColumns(inputName, literal.GetScalar().GetSchema().GetType(), expectedType.GetSchema())
+	}
+	return false, nil
+}
+
+// validateEnumOrSchemaType checks that an enum or schema input type can be bound to the expected one. An enum may only
+// narrow the values allowed by the expected enum.
+func validateEnumOrSchemaType(inputName string, inputType, expectedType *core.LiteralType) (bool, error) {
+	if expectedType.GetEnumType() != nil && inputType.GetEnumType() != nil {
+		for _, value := range inputType.GetEnumType().GetValues() {
+			if err := validateEnumValue(inputName, value, expectedType.GetEnumType()); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+	if expectedType.GetSchema() != nil && inputType.GetSchema() != nil {
+		return true, validateSchemaColumns(inputName, inputType.GetSchema(), expectedType.GetSchema())
+	}
+	return false, nil
+}
@@ -0,0 +1,164 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func getEnumType(values ...string) *core.LiteralType {
+	return &core.LiteralType{
+		Type: &core.LiteralType_EnumType{
+			EnumType: &core.EnumType{
+				Values: values,
+			},
+		},
+	}
+}
+
+func getSchemaType(columns ...*core.SchemaType_SchemaColumn) *core.LiteralType {
+	return &core.LiteralType{
+		Type: &core.LiteralType_Schema{
+			Schema: &core.SchemaType{
+				Columns: columns,
+			},
+		},
+	}
+}
+
+func getSchemaColumn(name string, columnType core.SchemaType_SchemaColumn_SchemaColumnType) *core.SchemaType_SchemaColumn {
+	return &core.SchemaType_SchemaColumn{
+		Name: name,
+		Type: columnType,
+	}
+}
+
+func getStringLiteral(value string) *core.Literal {
+	return &core.Literal{
+		Value: &core.Literal_Scalar{
+			Scalar: &core.Scalar{
+				Value: &core.Scalar_Primitive{
+					Primitive: &core.Primitive{
+						Value: &core.Primitive_StringValue{
+							StringValue: value,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getSchemaLiteral(schemaType *core.LiteralType) *core.Literal {
+	return &core.Literal{
+		Value: &core.Literal_Scalar{
+			Scalar: &core.Scalar{
+				Value: &core.Scalar_Schema{
+					Schema: &core.Schema{
+						Uri:  "s3://bucket/schema",
+						Type: schemaType.GetSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+var integerLiteral = &core.Literal{
+	Value: &core.Literal_Scalar{
+		Scalar: &core.Scalar{
+			Value: &core.Scalar_Primitive{
+				Primitive: &core.Primitive{
+					Value: &core.Primitive_Integer{
+						Integer: 1,
+					},
+				},
+			},
+		},
+	},
+}
+
+var stringType = &core.LiteralType{
+	Type: &core.LiteralType_Simple{
+		Simple: core.SimpleType_STRING,
+	},
+}
+
+func TestValidateEnumOrSchemaLiteral(t *testing.T) {
+	superset := getSchemaType(
+		getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER),
+		getSchemaColumn("b", core.SchemaType_SchemaColumn_STRING))
+	subset := getSchemaType(getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER))
+	retyped := getSchemaType(getSchemaColumn("a", core.SchemaType_SchemaColumn_FLOAT))
+	tests := []struct {
+		name            string
+		literal         *core.Literal
+		expectedType    *core.LiteralType
+		expectedHandled bool
+		expectedError   string
+	}{
+		{"allowed enum value", getStringLiteral("red"), getEnumType("red", "green"), true, ""},
+		{"disallowed enum value", getStringLiteral("blue"), getEnumType("red", "green"), true,
+			"invalid value [blue] for enum input foo, expected one of [red, green]"},
+		{"empty enum value", getStringLiteral(""), getEnumType("red", "green"), true,
+			"invalid value [] for enum input foo, expected one of [red, green]"},
+		{"non string enum value", integerLiteral, getEnumType("red"), false, ""},
+		{"schema with extra columns", getSchemaLiteral(superset), subset, true, ""},
+		{"schema with identical columns", getSchemaLiteral(subset), subset, true, ""},
+		{"schema missing a column", getSchemaLiteral(subset), superset, true,
+			"schema input foo is missing column [b] of type STRING"},
+		{"schema with a retyped column", getSchemaLiteral(retyped), subset, true,
+			"column [a] of schema input foo has type FLOAT, expected INTEGER"},
+		{"generic schema", getSchemaLiteral(getSchemaType()), superset, true, ""},
+		{"schema to generic schema", getSchemaLiteral(superset), getSchemaType(), true, ""},
+		{"non schema literal", getStringLiteral("s3://bucket/schema"), superset, false, ""},
+		{"string", getStringLiteral("red"), stringType, false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handled, err := validateEnumOrSchemaLiteral("foo", test.literal, test.expectedType)
+			assert.Equal(t, test.expectedHandled, handled)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateEnumOrSchemaType(t *testing.T) {
+	superset := getSchemaType(
+		getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER),
+		getSchemaColumn("b", core.SchemaType_SchemaColumn_STRING))
+	subset := getSchemaType(getSchemaColumn("a", core.SchemaType_SchemaColumn_INTEGER))
+	tests := []struct {
+		name            string
+		inputType       *core.LiteralType
+		expectedType    *core.LiteralType
+		expectedHandled bool
+		expectedError   string
+	}{
+		{"identical enums", getEnumType("red", "green"), getEnumType("red", "green"), true, ""},
+		{"narrower enum", getEnumType("red"), getEnumType("red", "green"), true, ""},
+		{"wider enum", getEnumType("red", "blue"), getEnumType("red", "green"), true,
+			"invalid value [blue] for enum input foo, expected one of [red, green]"},
+		{"string to enum", stringType, getEnumType("red"), false, ""},
+		{"enum to string", getEnumType("red"), stringType, false, ""},
+		{"schema with extra columns", superset, subset, true, ""},
+		{"schema missing a column", subset, superset, true, "schema input foo is missing column [b] of type STRING"},
+		{"string to schema", stringType, superset, false, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handled, err := validateEnumOrSchemaType("foo", test.inputType, test.expectedType)
+			assert.Equal(t, test.expectedHandled, handled)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
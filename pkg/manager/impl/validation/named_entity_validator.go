@@ -1,8 +1,11 @@
 package validation
 
 import (
+	"strings"
+
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"google.golang.org/grpc/codes"
@@ -53,3 +56,28 @@ func ValidateNamedEntityListRequest(request admin.NamedEntityListRequest) error
 	}
 	return nil
 }
+
+// Entities are searched by name, so queries longer than names can't match anything.
+const maxSearchQueryLength = 255
+
+var searchableResourceTypes = map[core.ResourceType]bool{
+	core.ResourceType_WORKFLOW:    true,
+	core.ResourceType_TASK:        true,
+	core.ResourceType_LAUNCH_PLAN: true,
+}
+
+func ValidateSearchNamedEntitiesRequest(request interfaces.SearchNamedEntitiesRequest) error {
+	if err := ValidateEmptyStringField(strings.TrimSpace(request.Query), shared.Query); err != nil {
+		return err
+	}
+	if err := ValidateMaxLengthStringField(request.Query, shared.Query, maxSearchQueryLength); err != nil {
+		return err
+	}
+	for _, resourceType := range request.ResourceTypes {
+		if !searchableResourceTypes[resourceType] {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"resource type %s can't be searched, only workflows, tasks and launch plans", resourceType)
+		}
+	}
+	return ValidateLimit(request.Limit)
+}
@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"google.golang.org/grpc/codes"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -164,3 +166,36 @@ func TestValidateNamedEntityListRequest(t *testing.T) {
 		Domain:       "domain",
 	}))
 }
+
+func TestValidateSearchNamedEntitiesRequest(t *testing.T) {
+	assert.Nil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query: "train",
+		Limit: 10,
+	}))
+
+	assert.Nil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query:         "train",
+		ResourceTypes: []core.ResourceType{core.ResourceType_TASK, core.ResourceType_LAUNCH_PLAN},
+		Limit:         10,
+	}))
+
+	assert.NotNil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query: "  ",
+		Limit: 10,
+	}))
+
+	assert.NotNil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query: strings.Repeat("a", maxSearchQueryLength+1),
+		Limit: 10,
+	}))
+
+	assert.NotNil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query:         "train",
+		ResourceTypes: []core.ResourceType{core.ResourceType_DATASET},
+		Limit:         10,
+	}))
+
+	assert.NotNil(t, ValidateSearchNamedEntitiesRequest(interfaces.SearchNamedEntitiesRequest{
+		Query: "train",
+	}))
+}
@@ -2,9 +2,13 @@ package validation
 
 import (
 	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
 )
 
 func ValidateNodeExecutionIdentifier(identifier *core.NodeExecutionIdentifier) error {
@@ -21,6 +25,28 @@ func ValidateNodeExecutionIdentifier(identifier *core.NodeExecutionIdentifier) e
 	return ValidateWorkflowExecutionIdentifier(identifier.ExecutionId)
 }
 
+// Validates that a batch holds at most maxBatchSize valid node execution identifiers, all of the same execution.
+func ValidateNodeExecutionGetDataBatchRequest(
+	request interfaces.NodeExecutionGetDataBatchRequest, maxBatchSize int) error {
+	if len(request.Ids) == 0 {
+		return shared.GetMissingArgumentError(shared.ID)
+	}
+	if len(request.Ids) > maxBatchSize {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"batch of %d node executions exceeds the maximum of %d", len(request.Ids), maxBatchSize)
+	}
+	for _, id := range request.Ids {
+		if err := ValidateNodeExecutionIdentifier(id); err != nil {
+			return err
+		}
+		if !proto.Equal(id.ExecutionId, request.Ids[0].ExecutionId) {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"node executions of a batch must all belong to execution %v", request.Ids[0].ExecutionId)
+		}
+	}
+	return nil
+}
+
 // Validates that NodeExecutionEventRequests handled by admin include a valid node execution identifier.
 // In the case the event specifies a DynamicWorkflow in the TaskNodeMetadata, this method also validates the contents of
 // the dynamic workflow.
@@ -66,6 +92,20 @@ func ValidateNodeExecutionListRequest(request admin.NodeExecutionListRequest) er
 	return nil
 }
 
+func ValidateNodeExecutionTreeListRequest(request interfaces.NodeExecutionTreeListRequest, maxDepth int) error {
+	if err := ValidateWorkflowExecutionIdentifier(request.WorkflowExecutionId); err != nil {
+		return shared.GetMissingArgumentError(shared.ExecutionID)
+	}
+	if err := ValidateLimit(request.Limit); err != nil {
+		return err
+	}
+	if request.Depth < 0 || request.Depth > maxDepth {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"depth %d must be between 0 and %d", request.Depth, maxDepth)
+	}
+	return nil
+}
+
 func ValidateNodeExecutionForTaskListRequest(request admin.NodeExecutionForTaskListRequest) error {
 	if err := ValidateTaskExecutionIdentifier(request.TaskExecutionId); err != nil {
 		return err
@@ -3,6 +3,8 @@ package validation
 import (
 	"testing"
 
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -33,6 +35,52 @@ func TestValidateNodeExecutionIdentifier(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValidateNodeExecutionTreeListRequest(t *testing.T) {
+	request := interfaces.NodeExecutionTreeListRequest{
+		WorkflowExecutionId: &testExecutionID,
+		Limit:               10,
+		Depth:               2,
+	}
+	assert.NoError(t, ValidateNodeExecutionTreeListRequest(request, 2))
+	assert.EqualError(t, ValidateNodeExecutionTreeListRequest(request, 1), "depth 2 must be between 0 and 1")
+	request.Depth = -1
+	assert.EqualError(t, ValidateNodeExecutionTreeListRequest(request, 1), "depth -1 must be between 0 and 1")
+	request.Depth = 0
+	request.Limit = 0
+	assert.Error(t, ValidateNodeExecutionTreeListRequest(request, 1))
+	request.Limit = 10
+	request.WorkflowExecutionId = nil
+	assert.EqualError(t, ValidateNodeExecutionTreeListRequest(request, 1), "missing execution_id")
+}
+
+func TestValidateNodeExecutionGetDataBatchRequest(t *testing.T) {
+	getID := func(nodeID string, executionID core.WorkflowExecutionIdentifier) *core.NodeExecutionIdentifier {
+		return &core.NodeExecutionIdentifier{
+			ExecutionId: &executionID,
+			NodeId:      nodeID,
+		}
+	}
+	otherExecutionID := testExecutionID
+	otherExecutionID.Name = "other"
+
+	assert.NoError(t, ValidateNodeExecutionGetDataBatchRequest(interfaces.NodeExecutionGetDataBatchRequest{
+		Ids: []*core.NodeExecutionIdentifier{getID("a", testExecutionID), getID("b", testExecutionID)},
+	}, 2))
+	assert.EqualError(t, ValidateNodeExecutionGetDataBatchRequest(interfaces.NodeExecutionGetDataBatchRequest{}, 2),
+		"missing id")
+	assert.EqualError(t, ValidateNodeExecutionGetDataBatchRequest(interfaces.NodeExecutionGetDataBatchRequest{
+		Ids: []*core.NodeExecutionIdentifier{
+			getID("a", testExecutionID), getID("b", testExecutionID), getID("c", testExecutionID),
+		},
+	}, 2), "batch of 3 node executions exceeds the maximum of 2")
+	assert.EqualError(t, ValidateNodeExecutionGetDataBatchRequest(interfaces.NodeExecutionGetDataBatchRequest{
+		Ids: []*core.NodeExecutionIdentifier{getID("a", testExecutionID), getID("", testExecutionID)},
+	}, 2), "missing node_id")
+	assert.Error(t, ValidateNodeExecutionGetDataBatchRequest(interfaces.NodeExecutionGetDataBatchRequest{
+		Ids: []*core.NodeExecutionIdentifier{getID("a", testExecutionID), getID("b", otherExecutionID)},
+	}, 2))
+}
+
 func TestValidateNodeExecutionIdentifier_MissingFields(t *testing.T) {
 	err := ValidateNodeExecutionIdentifier(&core.NodeExecutionIdentifier{
 		NodeId: "node id",
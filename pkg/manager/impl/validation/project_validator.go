@@ -64,19 +64,18 @@ func ValidateProjectAndDomain(
 			projectID, domainID, err)
 	}
 	if *project.State != int32(admin.Project_ACTIVE) {
-		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+		return errors.NewFlyteAdminErrorf(codes.FailedPrecondition,
 			"project [%s] is not active", projectID)
 	}
-	var validDomain bool
-	domains := config.GetDomainsConfig()
-	for _, domain := range *domains {
+	return ValidateDomain(config, domainID)
+}
+
+// Validates that the domain is configured.
+func ValidateDomain(config runtimeInterfaces.ApplicationConfiguration, domainID string) error {
+	for _, domain := range *config.GetDomainsConfig() {
 		if domain.ID == domainID {
-			validDomain = true
-			break
+			return nil
 		}
 	}
-	if !validDomain {
-		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "domain [%s] is unrecognized by system", domainID)
-	}
-	return nil
+	return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "domain [%s] is unrecognized by system", domainID)
 }
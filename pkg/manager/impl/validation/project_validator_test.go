@@ -279,6 +279,7 @@ func TestValidateProjectAndDomainArchivedProject(t *testing.T) {
 		"flyte-project-id", "domain")
 	assert.EqualError(t, err,
 		"project [flyte-project-id] is not active")
+	assert.Equal(t, codes.FailedPrecondition, err.(flyteAdminErrors.FlyteAdminError).Code())
 }
 
 func TestValidateProjectAndDomainError(t *testing.T) {
@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+func ValidateListScheduleCheckpointsRequest(request interfaces.ListScheduleCheckpointsRequest) error {
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	return ValidateEmptyStringField(request.Domain, shared.Domain)
+}
+
+func ValidateUpdateScheduleCheckpointRequest(request interfaces.UpdateScheduleCheckpointRequest) error {
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Domain, shared.Domain); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Name, shared.Name); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.Version, shared.Version); err != nil {
+		return err
+	}
+	if request.LastScheduledAt.IsZero() {
+		return errors.NewFlyteAdminError(codes.InvalidArgument, "the last scheduled time must be set")
+	}
+	return nil
+}
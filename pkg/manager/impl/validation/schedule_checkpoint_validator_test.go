@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+func TestValidateListScheduleCheckpointsRequest(t *testing.T) {
+	assert.NoError(t, ValidateListScheduleCheckpointsRequest(interfaces.ListScheduleCheckpointsRequest{
+		Project: "project",
+		Domain:  "domain",
+	}))
+	err := ValidateListScheduleCheckpointsRequest(interfaces.ListScheduleCheckpointsRequest{
+		Project: "project",
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+}
+
+func TestValidateUpdateScheduleCheckpointRequest(t *testing.T) {
+	request := interfaces.UpdateScheduleCheckpointRequest{
+		Project:         "project",
+		Domain:          "domain",
+		Name:            "name",
+		Version:         "version",
+		LastScheduledAt: time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(t, ValidateUpdateScheduleCheckpointRequest(request))
+
+	testCases := []struct {
+		name   string
+		mutate func(request *interfaces.UpdateScheduleCheckpointRequest)
+	}{
+		{"missing version", func(request *interfaces.UpdateScheduleCheckpointRequest) {
+			request.Version = ""
+		}},
+		{"missing last scheduled time", func(request *interfaces.UpdateScheduleCheckpointRequest) {
+			request.LastScheduledAt = time.Time{}
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invalid := request
+			tc.mutate(&invalid)
+			err := ValidateUpdateScheduleCheckpointRequest(invalid)
+			assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+		})
+	}
+}
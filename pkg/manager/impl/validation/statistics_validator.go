@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// Buckets are aggregated in SQL by whole seconds, and shorter buckets make for more of them than is useful.
+const minStatisticsBucketSize = time.Minute
+
+func ValidateExecutionStatisticsRequest(request interfaces.ExecutionStatisticsRequest, maxBuckets int) error {
+	if request.Start.IsZero() || request.End.IsZero() {
+		return errors.NewFlyteAdminError(codes.InvalidArgument, "both the start and the end of the time range must be set")
+	}
+	if !request.End.After(request.Start) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "the end of the time range %v must be after its start %v",
+			request.End, request.Start)
+	}
+	if request.BucketSize < minStatisticsBucketSize || request.BucketSize%time.Second != 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"bucket size %v must be a whole number of seconds of at least %v", request.BucketSize,
+			minStatisticsBucketSize)
+	}
+	rangeLength := request.End.Sub(request.Start)
+	buckets := int64(rangeLength / request.BucketSize)
+	if rangeLength%request.BucketSize != 0 {
+		buckets++
+	}
+	if buckets > int64(maxBuckets) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"the time range divides into %d buckets, more than the limit of %d", buckets, maxBuckets)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+func getExecutionStatisticsRequestForTest() interfaces.ExecutionStatisticsRequest {
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	return interfaces.ExecutionStatisticsRequest{
+		Project:    "project",
+		Start:      start,
+		End:        start.Add(30 * 24 * time.Hour),
+		BucketSize: 24 * time.Hour,
+	}
+}
+
+func TestValidateExecutionStatisticsRequest(t *testing.T) {
+	assert.NoError(t, ValidateExecutionStatisticsRequest(getExecutionStatisticsRequestForTest(), 30))
+}
+
+func TestValidateExecutionStatisticsRequest_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(request *interfaces.ExecutionStatisticsRequest)
+	}{
+		{"missing start", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.Start = time.Time{}
+		}},
+		{"missing end", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.End = time.Time{}
+		}},
+		{"end before start", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.End = request.Start.Add(-time.Hour)
+		}},
+		{"short buckets", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.BucketSize = time.Second
+		}},
+		{"fractional buckets", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.BucketSize = time.Hour + time.Millisecond
+		}},
+		{"too many buckets", func(request *interfaces.ExecutionStatisticsRequest) {
+			request.End = request.End.Add(time.Hour)
+		}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := getExecutionStatisticsRequestForTest()
+			testCase.mutate(&request)
+			err := ValidateExecutionStatisticsRequest(request, 30)
+			assert.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package validation
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
@@ -236,6 +237,130 @@ func validateTaskResources(
 	return nil
 }
 
+// The platform task resources whose requests and limits are compared, in the order in which violations are reported.
+var platformTaskResourceNames = []core.Resources_ResourceName{
+	core.Resources_CPU,
+	core.Resources_MEMORY,
+	core.Resources_EPHEMERAL_STORAGE,
+	core.Resources_GPU,
+}
+
+// ValidateTaskResourceSet checks that platform task resource requests, such as those resolved from matchable task
+// resource attributes, don't exceed their limits and that gpu quantities are whole numbers.
+func ValidateTaskResourceSet(requests, limits runtimeInterfaces.TaskResourceSet) error {
+	requestQuantities := taskResourceSetToMap(requests)
+	limitQuantities := taskResourceSetToMap(limits)
+	for _, resourceName := range platformTaskResourceNames {
+		requestQuantity, requestOk := requestQuantities[resourceName]
+		limitQuantity, limitOk := limitQuantities[resourceName]
+		if resourceName == core.Resources_GPU {
+			if requestOk && !isWholeNumber(*requestQuantity) {
+				return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+					"%v request must be a whole number, got: %s instead", resourceName, requestQuantity.String())
+			}
+			if limitOk && !isWholeNumber(*limitQuantity) {
+				return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+					"%v limit must be a whole number, got: %s instead", resourceName, limitQuantity.String())
+			}
+		}
+		if requestOk && limitOk && requestQuantity.Cmp(*limitQuantity) > 0 {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"%v request [%v] is greater than the limit [%v]",
+				resourceName, requestQuantity.String(), limitQuantity.String())
+		}
+	}
+	return nil
+}
+
+// The container task type whose command is not filled in by flytekit at execution time.
+const rawContainerTaskType = "raw-container"
+
+// Where platform task resource limits come from when no matchable task resource attributes override them.
+const platformTaskResourceLimitSource = "the platform task resource configuration"
+
+// Matches container image references of the form [registry[:port]/]repository[:tag][@digest].
+var imageReferenceRegex = regexp.MustCompile(
+	`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?` +
+		`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
+// TaskResourceLimit is the upper bound on a single task resource along with where it was configured, so that
+// registration errors can point at the setting to change.
+type TaskResourceLimit struct {
+	Quantity resource.Quantity
+	Source   string
+}
+
+// ResolveTaskResourceLimits overlays the limits set by matchable task resource attributes on top of the platform task
+// resource limits. Resources without a limit in either are left unbounded.
+func ResolveTaskResourceLimits(platformLimits, attributeLimits runtimeInterfaces.TaskResourceSet,
+	attributeSource string) map[core.Resources_ResourceName]TaskResourceLimit {
+	limits := make(map[core.Resources_ResourceName]TaskResourceLimit)
+	for resourceName, quantity := range taskResourceSetToMap(platformLimits) {
+		limits[resourceName] = TaskResourceLimit{
+			Quantity: *quantity,
+			Source:   platformTaskResourceLimitSource,
+		}
+	}
+	for resourceName, quantity := range taskResourceSetToMap(attributeLimits) {
+		limits[resourceName] = TaskResourceLimit{
+			Quantity: *quantity,
+			Source:   attributeSource,
+		}
+	}
+	return limits
+}
+
+func validateContainerResourceLimits(identifier *core.Identifier, kind string,
+	entries []*core.Resources_ResourceEntry, limits map[core.Resources_ResourceName]TaskResourceLimit) error {
+	quantities, err := requestedResourcesToQuantity(identifier, entries)
+	if err != nil {
+		return err
+	}
+	for _, resourceName := range platformTaskResourceNames {
+		quantity, ok := quantities[resourceName]
+		if !ok {
+			continue
+		}
+		limit, ok := limits[resourceName]
+		if ok && quantity.Cmp(limit.Quantity) > 0 {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"Requested %v %s [%v] for task [%+v] exceeds the limit [%v] set in %s",
+				resourceName, kind, quantity.String(), identifier, limit.Quantity.String(), limit.Source)
+		}
+	}
+	return nil
+}
+
+// ValidateTaskContainer checks the container of a task being registered against the resource limits which will apply
+// to it at execution time, as well as the syntax of its image reference and, for raw container tasks, that it has
+// something to run. Tasks without a container aren't checked.
+func ValidateTaskContainer(template *core.TaskTemplate,
+	limits map[core.Resources_ResourceName]TaskResourceLimit) error {
+	container := template.GetContainer()
+	if container == nil {
+		return nil
+	}
+	if !imageReferenceRegex.MatchString(container.Image) {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"invalid image reference [%s] for task [%+v], expected [registry/]repository[:tag][@digest]",
+			container.Image, template.Id)
+	}
+	if template.Type == rawContainerTaskType && len(container.Command) == 0 && len(container.Args) == 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+			"raw container task [%+v] must specify a command or args", template.Id)
+	}
+	if container.Resources == nil {
+		return nil
+	}
+	if err := validateContainerResourceLimits(
+		template.Id, "request", container.Resources.Requests, limits); err != nil {
+		return err
+	}
+	return validateContainerResourceLimits(template.Id, "limit", container.Resources.Limits, limits)
+}
+
 func validateTaskType(taskID core.Identifier, taskType string, whitelistConfig runtime.WhitelistConfiguration) error {
 	taskTypeWhitelist := whitelistConfig.GetTaskTypeWhitelist()
 	if taskTypeWhitelist == nil {
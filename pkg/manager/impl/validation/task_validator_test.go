@@ -438,3 +438,145 @@ func TestIsWholeNumber(t *testing.T) {
 			"%s should not be treated as a whole number", fraction)
 	}
 }
+
+func TestResolveTaskResourceLimits(t *testing.T) {
+	limits := ResolveTaskResourceLimits(runtimeInterfaces.TaskResourceSet{
+		CPU:    resource.MustParse("2"),
+		Memory: resource.MustParse("1Gi"),
+	}, runtimeInterfaces.TaskResourceSet{
+		Memory: resource.MustParse("4Gi"),
+		GPU:    resource.MustParse("1"),
+	}, "attributes")
+	assert.Len(t, limits, 3)
+	assert.True(t, resource.MustParse("2").Equal(limits[core.Resources_CPU].Quantity))
+	assert.Equal(t, platformTaskResourceLimitSource, limits[core.Resources_CPU].Source)
+	assert.True(t, resource.MustParse("4Gi").Equal(limits[core.Resources_MEMORY].Quantity))
+	assert.Equal(t, "attributes", limits[core.Resources_MEMORY].Source)
+	assert.True(t, resource.MustParse("1").Equal(limits[core.Resources_GPU].Quantity))
+	assert.Equal(t, "attributes", limits[core.Resources_GPU].Source)
+}
+
+func TestValidateTaskContainer_Resources(t *testing.T) {
+	limits := ResolveTaskResourceLimits(runtimeInterfaces.TaskResourceSet{
+		CPU:              resource.MustParse("1"),
+		Memory:           resource.MustParse("1Gi"),
+		EphemeralStorage: resource.MustParse("10Gi"),
+	}, runtimeInterfaces.TaskResourceSet{
+		GPU: resource.MustParse("2"),
+	}, "the task resource attributes")
+	tests := []struct {
+		name          string
+		requests      []*core.Resources_ResourceEntry
+		limits        []*core.Resources_ResourceEntry
+		expectedError string
+	}{
+		{
+			name: "within limits",
+			requests: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_CPU, Value: "500m"},
+				{Name: core.Resources_MEMORY, Value: "512Mi"},
+				{Name: core.Resources_EPHEMERAL_STORAGE, Value: "1Gi"},
+				{Name: core.Resources_GPU, Value: "1"},
+			},
+			limits: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_CPU, Value: "1"},
+				{Name: core.Resources_MEMORY, Value: "1Gi"},
+				{Name: core.Resources_EPHEMERAL_STORAGE, Value: "10Gi"},
+				{Name: core.Resources_GPU, Value: "1"},
+			},
+		},
+		{
+			name: "cpu request",
+			requests: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_CPU, Value: "1500m"},
+			},
+			expectedError: "exceeds the limit [1] set in the platform task resource configuration",
+		},
+		{
+			name: "memory limit",
+			limits: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_MEMORY, Value: "2Gi"},
+			},
+			expectedError: "set in the platform task resource configuration",
+		},
+		{
+			name: "ephemeral storage request",
+			requests: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_EPHEMERAL_STORAGE, Value: "20Gi"},
+			},
+			expectedError: "Requested EPHEMERAL_STORAGE request [20Gi]",
+		},
+		{
+			name: "gpu limit",
+			limits: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_GPU, Value: "4"},
+			},
+			expectedError: "exceeds the limit [2] set in the task resource attributes",
+		},
+		{
+			name: "unbounded storage",
+			requests: []*core.Resources_ResourceEntry{
+				{Name: core.Resources_STORAGE, Value: "1Ti"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			template := testutils.GetValidTaskRequest().Spec.Template
+			template.GetContainer().Resources = &core.Resources{
+				Requests: test.requests,
+				Limits:   test.limits,
+			}
+			err := ValidateTaskContainer(template, limits)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
+
+func TestValidateTaskContainer_Image(t *testing.T) {
+	tests := []struct {
+		image string
+		valid bool
+	}{
+		{"image", true},
+		{"ubuntu:20.04", true},
+		{"ghcr.io/flyteorg/flytekit:py3.9-latest", true},
+		{"localhost:5000/my-team/my_image:v1.0.0", true},
+		{"cr.flyte.org/flyteorg/flytekit@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", true},
+		{"", false},
+		{"Ubuntu", false},
+		{"ubuntu:", false},
+		{"ghcr.io/flyteorg/flytekit:tag with spaces", false},
+		{"ubuntu@sha256:xyz", false},
+	}
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			template := testutils.GetValidTaskRequest().Spec.Template
+			template.GetContainer().Image = test.image
+			err := ValidateTaskContainer(template, nil)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateTaskContainer_RawContainerCommand(t *testing.T) {
+	template := testutils.GetValidTaskRequest().Spec.Template
+	template.Type = rawContainerTaskType
+	template.GetContainer().Command = nil
+	template.GetContainer().Args = nil
+	err := ValidateTaskContainer(template, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must specify a command or args")
+
+	template.GetContainer().Args = []string{"echo", "hello"}
+	assert.NoError(t, ValidateTaskContainer(template, nil))
+}
@@ -5,11 +5,13 @@ import (
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/compiler/validators"
@@ -151,6 +153,22 @@ func ValidateResourceListRequest(request admin.ResourceListRequest) error {
 	return nil
 }
 
+// Approximate counts are only estimated for whole tables, other counts are scoped to a project and domain like the
+// list requests they accompany.
+func ValidateCountResourceRequest(request interfaces.CountResourceRequest) error {
+	if request.Approximate {
+		if request.Project != "" || request.Domain != "" || request.Filters != "" {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"approximate counts can't be scoped to a project, domain or filters")
+		}
+		return nil
+	}
+	if err := ValidateEmptyStringField(request.Project, shared.Project); err != nil {
+		return err
+	}
+	return ValidateEmptyStringField(request.Domain, shared.Domain)
+}
+
 func ValidateActiveLaunchPlanRequest(request admin.ActiveLaunchPlanRequest) error {
 	if err := ValidateEmptyStringField(request.Id.Project, shared.Project); err != nil {
 		return err
@@ -204,6 +222,22 @@ func validateLiteralMap(inputMap *core.LiteralMap, fieldName string) error {
 	return nil
 }
 
+// A custom quality of service spec must set a non-negative queueing budget.
+func validateQualityOfService(qualityOfService *core.QualityOfService) error {
+	if qualityOfService.GetSpec() == nil {
+		return nil
+	}
+	queueingBudget, err := ptypes.Duration(qualityOfService.GetSpec().GetQueueingBudget())
+	if err != nil {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "invalid queueing budget: %v", err)
+	}
+	if queueingBudget < 0 {
+		return errors.NewFlyteAdminErrorf(codes.InvalidArgument, "queueing budget %v must not be negative",
+			queueingBudget)
+	}
+	return nil
+}
+
 func validateParameterMap(inputMap *core.ParameterMap, fieldName string) error {
 	if inputMap != nil && len(inputMap.Parameters) > 0 {
 		for name, defaultInput := range inputMap.Parameters {
@@ -222,6 +256,13 @@ func validateParameterMap(inputMap *core.ParameterMap, fieldName string) error {
 			}
 			defaultValue := defaultInput.GetDefault()
 			if defaultValue != nil {
+				if handled, err := validateEnumOrSchemaLiteral(
+					name, defaultValue, defaultInput.GetVar().GetType()); handled {
+					if err != nil {
+						return err
+					}
+					continue
+				}
 				inputType := validators.LiteralTypeForLiteral(defaultValue)
 				if !validators.AreTypesCastable(inputType, defaultInput.GetVar().GetType()) {
 					return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
@@ -250,6 +291,23 @@ func ValidateToken(token string) (int, error) {
 	return offset, nil
 }
 
+// Lists sorted by the keyset sort key are paginated with keyset tokens, which are only valid for the sort direction
+// they were issued for, and all other lists with offset tokens. Returns the offset or the cursor to continue from.
+func ValidatePaginationToken(token string, sortBy *admin.Sort) (int, *common.KeysetCursor, error) {
+	if !common.IsKeysetSort(sortBy) {
+		offset, err := ValidateToken(token)
+		return offset, nil, err
+	}
+	if token == "" {
+		return 0, nil, nil
+	}
+	cursor, err := common.DecodeKeysetToken(token, *sortBy)
+	if err != nil {
+		return 0, nil, err
+	}
+	return 0, &cursor, nil
+}
+
 func ValidateLimit(limit uint32) error {
 	if limit == 0 {
 		return shared.GetInvalidArgumentError(shared.Limit)
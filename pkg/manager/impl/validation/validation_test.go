@@ -2,12 +2,14 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteidl/clients/go/coreutils"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/shared"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
@@ -228,6 +230,68 @@ func TestValidateToken(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestValidatePaginationToken(t *testing.T) {
+	keysetSort := &admin.Sort{Key: common.KeysetSortKey, Direction: admin.Sort_DESCENDING}
+	cursor := common.KeysetCursor{
+		Direction: admin.Sort_DESCENDING,
+		CreatedAt: time.Date(2021, 11, 8, 0, 0, 0, 0, time.UTC),
+		ID:        42,
+	}
+	keysetToken, err := common.EncodeKeysetToken(cursor)
+	assert.NoError(t, err)
+
+	t.Run("offset token", func(t *testing.T) {
+		offset, keysetCursor, err := ValidatePaginationToken("10", &admin.Sort{Key: "name"})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, offset)
+		assert.Nil(t, keysetCursor)
+	})
+	t.Run("first keyset page", func(t *testing.T) {
+		offset, keysetCursor, err := ValidatePaginationToken("", keysetSort)
+		assert.NoError(t, err)
+		assert.Zero(t, offset)
+		assert.Nil(t, keysetCursor)
+	})
+	t.Run("keyset token", func(t *testing.T) {
+		_, keysetCursor, err := ValidatePaginationToken(keysetToken, keysetSort)
+		assert.NoError(t, err)
+		assert.Equal(t, &cursor, keysetCursor)
+	})
+	t.Run("keyset token with a different sort", func(t *testing.T) {
+		_, _, err := ValidatePaginationToken(keysetToken, &admin.Sort{Key: "created_at"})
+		assert.Error(t, err)
+		_, _, err = ValidatePaginationToken(keysetToken, nil)
+		assert.Error(t, err)
+	})
+	t.Run("keyset token with a different direction", func(t *testing.T) {
+		_, _, err := ValidatePaginationToken(keysetToken,
+			&admin.Sort{Key: common.KeysetSortKey, Direction: admin.Sort_ASCENDING})
+		assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+	})
+	t.Run("offset token with the keyset sort", func(t *testing.T) {
+		_, _, err := ValidatePaginationToken("10", keysetSort)
+		assert.Equal(t, codes.InvalidArgument, err.(errors.FlyteAdminError).Code())
+	})
+}
+
+func TestValidateCountResourceRequest(t *testing.T) {
+	assert.NoError(t, ValidateCountResourceRequest(interfaces.CountResourceRequest{
+		Project: "project",
+		Domain:  "domain",
+		Filters: "eq(phase,RUNNING)",
+	}))
+	assert.NoError(t, ValidateCountResourceRequest(interfaces.CountResourceRequest{
+		Approximate: true,
+	}))
+	assert.EqualError(t, ValidateCountResourceRequest(interfaces.CountResourceRequest{
+		Domain: "domain",
+	}), "missing project")
+	assert.EqualError(t, ValidateCountResourceRequest(interfaces.CountResourceRequest{
+		Project:     "project",
+		Approximate: true,
+	}), "approximate counts can't be scoped to a project, domain or filters")
+}
+
 func TestValidateActiveLaunchPlanRequest(t *testing.T) {
 	err := ValidateActiveLaunchPlanRequest(
 		admin.ActiveLaunchPlanRequest{
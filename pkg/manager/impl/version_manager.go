@@ -8,6 +8,9 @@ import (
 	adminversion "github.com/flyteorg/flytestdlib/version"
 )
 
+// Reported for any build attribute which wasn't injected at link time.
+const unknownVersionValue = "unknown"
+
 type VersionManager struct {
 	Version   string
 	Build     string
@@ -24,10 +27,17 @@ func (v *VersionManager) GetVersion(ctx context.Context, r *admin.GetVersionRequ
 	}, nil
 }
 
+func valueOrUnknown(value string) string {
+	if len(value) == 0 {
+		return unknownVersionValue
+	}
+	return value
+}
+
 func NewVersionManager() interfaces.VersionInterface {
 	return &VersionManager{
-		Build:     adminversion.Build,
-		Version:   adminversion.Version,
-		BuildTime: adminversion.BuildTime,
+		Build:     valueOrUnknown(adminversion.Build),
+		Version:   valueOrUnknown(adminversion.Version),
+		BuildTime: valueOrUnknown(adminversion.BuildTime),
 	}
 }
@@ -27,3 +27,21 @@ func TestVersionManager_GetVersion(t *testing.T) {
 	assert.Equal(t, v.ControlPlaneVersion.Build, build)
 	assert.Equal(t, v.ControlPlaneVersion.Version, appversion)
 }
+
+func TestVersionManager_GetVersion_Unset(t *testing.T) {
+	adminversion.Build = ""
+	adminversion.BuildTime = ""
+	adminversion.Version = ""
+	defer func() {
+		adminversion.Build = build
+		adminversion.BuildTime = buildTime
+		adminversion.Version = appversion
+	}()
+	vmanager := NewVersionManager()
+
+	v, err := vmanager.GetVersion(context.Background(), &admin.GetVersionRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "unknown", v.ControlPlaneVersion.BuildTime)
+	assert.Equal(t, "unknown", v.ControlPlaneVersion.Build)
+	assert.Equal(t, "unknown", v.ControlPlaneVersion.Version)
+}
@@ -3,7 +3,10 @@ package impl
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/flyteorg/flytestdlib/contextutils"
@@ -26,6 +29,7 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/codes"
@@ -37,6 +41,7 @@ type workflowMetrics struct {
 	Scope                   promutils.Scope
 	CompilationFailures     prometheus.Counter
 	TypedInterfaceSizeBytes prometheus.Summary
+	IdenticalRegistrations  prometheus.Counter
 }
 
 type WorkflowManager struct {
@@ -46,6 +51,7 @@ type WorkflowManager struct {
 	storageClient *storage.DataStore
 	storagePrefix []string
 	metrics       workflowMetrics
+	closures      *util.WorkflowClosureCache
 }
 
 func getWorkflowContext(ctx context.Context, identifier *core.Identifier) context.Context {
@@ -134,6 +140,7 @@ func (w *WorkflowManager) createDataReference(
 func (w *WorkflowManager) CreateWorkflow(
 	ctx context.Context,
 	request admin.WorkflowCreateRequest) (*admin.WorkflowCreateResponse, error) {
+	ctx = common.WithPrimaryReads(ctx)
 	if err := validation.ValidateWorkflow(ctx, request, w.db, w.config.ApplicationConfiguration()); err != nil {
 		return nil, err
 	}
@@ -143,6 +150,23 @@ func (w *WorkflowManager) CreateWorkflow(
 		logger.Debugf(ctx, "Failed to set defaults for workflow with id [%+v] with err %v", request.Id, err)
 		return nil, err
 	}
+	specDigest, err := util.GetWorkflowSpecDigest(ctx, finalizedRequest.Spec)
+	if err != nil {
+		return nil, err
+	}
+	// Re-registrations of an existing workflow are compared by the digest of their spec, without compiling them.
+	existingMatchingWorkflow, err := util.GetWorkflowModel(ctx, w.db, *request.Id)
+	exists := err == nil
+	if flyteAdminError, ok := err.(errors.FlyteAdminError); !exists && (!ok || flyteAdminError.Code() != codes.NotFound) {
+		logger.Debugf(ctx, "Failed to get workflow for comparison in CreateWorkflow with ID [%+v] with err %v",
+			request.Id, err)
+		return nil, err
+	}
+	if exists && len(existingMatchingWorkflow.SpecDigest) > 0 {
+		return w.reRegisterWorkflow(ctx, finalizedRequest, existingMatchingWorkflow,
+			bytes.Equal(specDigest, existingMatchingWorkflow.SpecDigest))
+	}
+
 	// Validate that the workflow compiles.
 	workflowClosure, err := w.getCompiledWorkflow(ctx, finalizedRequest)
 	if err != nil {
@@ -160,22 +184,10 @@ func (w *WorkflowManager) CreateWorkflow(
 		logger.Errorf(ctx, "failed to compute workflow digest with err %v", err)
 		return nil, err
 	}
-
-	// Assert that a matching workflow doesn't already exist before uploading the workflow closure.
-	existingMatchingWorkflow, err := util.GetWorkflowModel(ctx, w.db, *request.Id)
-	// Check that no identical or conflicting workflows exist.
-	if err == nil {
-		// A workflow's structure is uniquely defined by its collection of nodes.
-		if bytes.Equal(workflowDigest, existingMatchingWorkflow.Digest) {
-			return nil, errors.NewFlyteAdminErrorf(
-				codes.AlreadyExists, "identical workflow already exists with id %v", request.Id)
-		}
-		return nil, errors.NewFlyteAdminErrorf(codes.InvalidArgument,
-			"workflow with different structure already exists with id %v", request.Id)
-	} else if flyteAdminError, ok := err.(errors.FlyteAdminError); !ok || flyteAdminError.Code() != codes.NotFound {
-		logger.Debugf(ctx, "Failed to get workflow for comparison in CreateWorkflow with ID [%+v] with err %v",
-			request.Id, err)
-		return nil, err
+	if exists {
+		// Workflows registered before their spec digest was recorded are compared by their compiled closure.
+		return w.reRegisterWorkflow(ctx, finalizedRequest, existingMatchingWorkflow,
+			bytes.Equal(workflowDigest, existingMatchingWorkflow.Digest))
 	}
 
 	remoteClosureDataRef, err := w.createDataReference(ctx, request.Spec.Template.Id)
@@ -197,7 +209,7 @@ func (w *WorkflowManager) CreateWorkflow(
 	}
 	// Save the workflow & its reference to the offloaded, compiled workflow in the database.
 	workflowModel, err := transformers.CreateWorkflowModel(
-		finalizedRequest, remoteClosureDataRef.String(), workflowDigest)
+		finalizedRequest, remoteClosureDataRef.String(), workflowDigest, specDigest)
 	if err != nil {
 		logger.Errorf(ctx,
 			"Failed to transform workflow model for request [%+v] and remoteClosureIdentifier [%s] with err: %v",
@@ -212,13 +224,105 @@ func (w *WorkflowManager) CreateWorkflow(
 	return &admin.WorkflowCreateResponse{}, nil
 }
 
+// Registering a workflow identical to the existing one with the same identifier succeeds without changing it, while
+// registering a different one fails with a summary of their differences.
+func (w *WorkflowManager) reRegisterWorkflow(ctx context.Context, request admin.WorkflowCreateRequest,
+	existingWorkflow models.Workflow, identical bool) (*admin.WorkflowCreateResponse, error) {
+	if identical {
+		w.metrics.IdenticalRegistrations.Inc()
+		logger.Debugf(ctx, "Identical workflow already exists with id [%+v]", request.Id)
+		return &admin.WorkflowCreateResponse{}, nil
+	}
+	var differences []string
+	existingClosure, err := util.FetchAndGetWorkflowClosure(
+		ctx, w.storageClient, existingWorkflow.RemoteClosureIdentifier)
+	if err != nil {
+		logger.Warningf(ctx, "Failed to fetch the closure of existing workflow [%+v] to compare with err %v",
+			request.Id, err)
+	} else {
+		differences = getWorkflowTemplateDifferences(
+			existingClosure.GetCompiledWorkflow().GetPrimary().GetTemplate(), request.Spec.Template)
+	}
+	if len(differences) == 0 {
+		return nil, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
+			"workflow with different structure already exists with id %v", request.Id)
+	}
+	return nil, errors.NewFlyteAdminErrorf(codes.AlreadyExists,
+		"workflow with different structure already exists with id %v: %s", request.Id, strings.Join(differences, ", "))
+}
+
+// Summarizes how a workflow template differs from the existing one with the same identifier: the nodes added, removed
+// or calling a different entity, and the inputs and outputs added, removed or retyped. Other differences, in the
+// configuration of nodes or in subworkflows, aren't summarized.
+func getWorkflowTemplateDifferences(existing, updated *core.WorkflowTemplate) []string {
+	var differences []string
+	existingNodes := make(map[string]*core.Node, len(existing.GetNodes()))
+	for _, node := range existing.GetNodes() {
+		// The compiler adds these to the stored template.
+		if node.Id != compiler.StartNodeID && node.Id != compiler.EndNodeID {
+			existingNodes[node.Id] = node
+		}
+	}
+	var added, changed []string
+	for _, node := range updated.GetNodes() {
+		existingNode, ok := existingNodes[node.Id]
+		if !ok {
+			added = append(added, node.Id)
+			continue
+		}
+		delete(existingNodes, node.Id)
+		if !proto.Equal(existingNode.GetTaskNode(), node.GetTaskNode()) ||
+			!proto.Equal(existingNode.GetWorkflowNode(), node.GetWorkflowNode()) {
+			changed = append(changed, node.Id)
+		}
+	}
+	removed := make([]string, 0, len(existingNodes))
+	for id := range existingNodes {
+		removed = append(removed, id)
+	}
+	for _, difference := range []struct {
+		description string
+		ids         []string
+	}{
+		{"nodes %v were added", added},
+		{"nodes %v were removed", removed},
+		{"nodes %v call a different entity", changed},
+		{"inputs %v differ", getVariableMapDifferences(
+			existing.GetInterface().GetInputs(), updated.GetInterface().GetInputs())},
+		{"outputs %v differ", getVariableMapDifferences(
+			existing.GetInterface().GetOutputs(), updated.GetInterface().GetOutputs())},
+	} {
+		if len(difference.ids) > 0 {
+			sort.Strings(difference.ids)
+			differences = append(differences, fmt.Sprintf(difference.description, difference.ids))
+		}
+	}
+	return differences
+}
+
+// Returns the names of the variables added, removed or retyped between two variable maps.
+func getVariableMapDifferences(existing, updated *core.VariableMap) []string {
+	var names []string
+	for name, variable := range updated.GetVariables() {
+		if existingVariable, ok := existing.GetVariables()[name]; !ok || !proto.Equal(existingVariable, variable) {
+			names = append(names, name)
+		}
+	}
+	for name := range existing.GetVariables() {
+		if _, ok := updated.GetVariables()[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (w *WorkflowManager) GetWorkflow(ctx context.Context, request admin.ObjectGetRequest) (*admin.Workflow, error) {
 	if err := validation.ValidateIdentifier(request.Id, common.Workflow); err != nil {
 		logger.Debugf(ctx, "invalid identifier [%+v]: %v", request.Id, err)
 		return nil, err
 	}
 	ctx = getWorkflowContext(ctx, request.Id)
-	workflow, err := util.GetWorkflow(ctx, w.db, w.storageClient, *request.Id)
+	workflow, err := util.GetWorkflow(ctx, w.db, w.closures, *request.Id)
 	if err != nil {
 		logger.Infof(ctx, "Failed to get workflow with id [%+v] with err %v", request.Id, err)
 		return nil, err
@@ -342,13 +446,21 @@ func NewWorkflowManager(
 	compiler workflowengineInterfaces.Compiler,
 	storageClient *storage.DataStore,
 	storagePrefix []string,
-	scope promutils.Scope) interfaces.WorkflowInterface {
+	scope promutils.Scope,
+	closures *util.WorkflowClosureCache) interfaces.WorkflowInterface {
+	if closures == nil {
+		// Managers created without the cache the admin service shares between them, as in tests, get their own.
+		closures = util.NewWorkflowClosureCache(
+			storageClient, config.ApplicationConfiguration().GetTopLevelConfig().GetWorkflowClosureCacheConfig())
+	}
 	metrics := workflowMetrics{
 		Scope: scope,
 		CompilationFailures: scope.MustNewCounter(
 			"compilation_failures", "any observed failures when compiling a workflow"),
 		TypedInterfaceSizeBytes: scope.MustNewSummary("typed_interface_size_bytes",
 			"size in bytes of serialized workflow TypedInterface"),
+		IdenticalRegistrations: scope.MustNewCounter("identical_registrations",
+			"count of registrations of workflows identical to an existing one, which succeed without changing it"),
 	}
 	return &WorkflowManager{
 		db:            db,
@@ -357,5 +469,6 @@ func NewWorkflowManager(
 		storageClient: storageClient,
 		storagePrefix: storagePrefix,
 		metrics:       metrics,
+		closures:      closures,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	commonMocks "github.com/flyteorg/flyteadmin/pkg/common/mocks"
 	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/testutils"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
@@ -26,6 +27,7 @@ import (
 	engine "github.com/flyteorg/flytepropeller/pkg/compiler/common"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 )
@@ -122,7 +124,7 @@ func TestSetWorkflowDefaults(t *testing.T) {
 	workflowManager := NewWorkflowManager(
 		getMockRepository(returnWorkflowOnGet),
 		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), commonMocks.GetMockStorageClient(), storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	finalizedRequest, err := workflowManager.(*WorkflowManager).setDefaults(request)
 	assert.NoError(t, err)
@@ -142,7 +144,7 @@ func TestCreateWorkflow(t *testing.T) {
 
 	workflowManager := NewWorkflowManager(
 		repository,
-		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), getMockStorage(), storagePrefix, mockScope.NewTestScope())
+		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), getMockStorage(), storagePrefix, mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
 	assert.NoError(t, err)
@@ -156,7 +158,7 @@ func TestCreateWorkflow_ValidationError(t *testing.T) {
 	workflowManager := NewWorkflowManager(
 		repositoryMocks.NewMockRepository(),
 		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), commonMocks.GetMockStorageClient(), storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	request.Id = nil
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
@@ -164,41 +166,119 @@ func TestCreateWorkflow_ValidationError(t *testing.T) {
 	assert.Nil(t, response)
 }
 
+func getMockRepositoryWithExistingWorkflow(existingWorkflow models.Workflow) repositories.RepositoryInterface {
+	mockRepo := repositoryMocks.NewMockRepository()
+	mockRepo.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetGetCallback(
+		func(input interfaces.Identifier) (models.Workflow, error) {
+			return existingWorkflow, nil
+		})
+	mockRepo.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetCreateCallback(
+		func(input models.Workflow) error {
+			return adminErrors.NewFlyteAdminError(codes.Internal, "existing workflows shouldn't be created again")
+		})
+	return mockRepo
+}
+
+// Fails registrations which compile the workflow.
+func getUnusedMockWorkflowCompiler(t *testing.T) workflowengineInterfaces.Compiler {
+	mockCompiler := workflowengineMocks.NewMockCompiler()
+	mockCompiler.(*workflowengineMocks.MockCompiler).AddGetRequirementCallback(
+		func(fg *core.WorkflowTemplate, subWfs []*core.WorkflowTemplate) (
+			reqs compiler.WorkflowExecutionRequirements, err error) {
+			assert.Fail(t, "re-registered workflows with a spec digest shouldn't be compiled")
+			return compiler.WorkflowExecutionRequirements{}, errors.New("unexpected compilation")
+		})
+	return mockCompiler
+}
+
 func TestCreateWorkflow_ExistingWorkflow(t *testing.T) {
-	mockStorageClient := commonMocks.GetMockStorageClient()
+	request := testutils.GetWorkflowRequest()
+	request.Spec.Template.Id = request.Id
+	specDigest, err := util.GetWorkflowSpecDigest(context.Background(), request.Spec)
+	assert.NoError(t, err)
+	workflowManager := NewWorkflowManager(
+		getMockRepositoryWithExistingWorkflow(models.Workflow{SpecDigest: specDigest}),
+		getMockWorkflowConfigProvider(), getUnusedMockWorkflowCompiler(t), getMockStorage(), storagePrefix,
+		mockScope.NewTestScope(), nil)
+	response, err := workflowManager.CreateWorkflow(context.Background(), testutils.GetWorkflowRequest())
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(workflowManager.(*WorkflowManager).metrics.IdenticalRegistrations))
+}
+
+func TestCreateWorkflow_ExistingWorkflow_NotIdentical(t *testing.T) {
+	mockStorageClient := getMockStorage()
 	mockStorageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb =
 		func(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
-			workflowClosure := testutils.GetWorkflowClosure()
-			bytes, _ := proto.Marshal(workflowClosure)
-			_ = proto.Unmarshal(bytes, msg)
+			_ = proto.Unmarshal(workflowClosureBytes, msg)
 			return nil
 		}
 	workflowManager := NewWorkflowManager(
-		getMockRepository(returnWorkflowOnGet),
-		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix, mockScope.NewTestScope())
+		getMockRepositoryWithExistingWorkflow(models.Workflow{SpecDigest: []byte("different spec digest")}),
+		getMockWorkflowConfigProvider(), getUnusedMockWorkflowCompiler(t), mockStorageClient, storagePrefix,
+		mockScope.NewTestScope(), nil)
+
 	request := testutils.GetWorkflowRequest()
+	request.Spec.Template.Nodes = []*core.Node{
+		{
+			Id: "node 1",
+		},
+		{
+			Id: "node 2",
+			Target: &core.Node_TaskNode{
+				TaskNode: &core.TaskNode{
+					Reference: &core.TaskNode_ReferenceId{
+						ReferenceId: &core.Identifier{Name: "task"},
+					},
+				},
+			},
+		},
+		{
+			Id: "node 3",
+		},
+	}
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
 	assert.EqualError(t, err, "workflow with different structure already exists with id "+
-		"resource_type:WORKFLOW project:\"project\" domain:\"domain\" name:\"name\" version:\"version\" ")
+		"resource_type:WORKFLOW project:\"project\" domain:\"domain\" name:\"name\" version:\"version\" : "+
+		"nodes [node 3] were added, nodes [node 2] call a different entity, inputs [foo] differ, outputs [bar] differ")
+	assert.Equal(t, codes.AlreadyExists, err.(adminErrors.FlyteAdminError).Code())
 	assert.Nil(t, response)
 }
 
-func TestCreateWorkflow_ExistingWorkflow_NotIdentical(t *testing.T) {
-	mockStorageClient := commonMocks.GetMockStorageClient()
+func TestCreateWorkflow_ExistingLegacyWorkflow(t *testing.T) {
+	// Workflows registered before their spec digest was recorded are compiled to compare them.
+	request := testutils.GetWorkflowRequest()
+	request.Spec.Template.Id = request.Id
+	workflowDigest, err := util.GetWorkflowDigest(context.Background(), &core.CompiledWorkflowClosure{
+		Primary: &core.CompiledWorkflow{
+			Template: request.Spec.Template,
+		},
+	})
+	assert.NoError(t, err)
+	workflowManager := NewWorkflowManager(
+		getMockRepositoryWithExistingWorkflow(models.Workflow{Digest: workflowDigest}),
+		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), getMockStorage(), storagePrefix,
+		mockScope.NewTestScope(), nil)
+	response, err := workflowManager.CreateWorkflow(context.Background(), testutils.GetWorkflowRequest())
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
 
+	mockStorageClient := getMockStorage()
 	mockStorageClient.ComposedProtobufStore.(*commonMocks.TestDataStore).ReadProtobufCb =
 		func(ctx context.Context, reference storage.DataReference, msg proto.Message) error {
 			_ = proto.Unmarshal(workflowClosureBytes, msg)
 			return nil
 		}
-	workflowManager := NewWorkflowManager(
-		getMockRepository(returnWorkflowOnGet),
-		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix, mockScope.NewTestScope())
-
-	request := testutils.GetWorkflowRequest()
-	response, err := workflowManager.CreateWorkflow(context.Background(), request)
+	workflowManager = NewWorkflowManager(
+		getMockRepositoryWithExistingWorkflow(models.Workflow{}),
+		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix,
+		mockScope.NewTestScope(), nil)
+	response, err = workflowManager.CreateWorkflow(context.Background(), testutils.GetWorkflowRequest())
 	assert.EqualError(t, err, "workflow with different structure already exists with id "+
-		"resource_type:WORKFLOW project:\"project\" domain:\"domain\" name:\"name\" version:\"version\" ")
+		"resource_type:WORKFLOW project:\"project\" domain:\"domain\" name:\"name\" version:\"version\" : "+
+		"inputs [foo] differ, outputs [bar] differ")
+	assert.Equal(t, codes.AlreadyExists, err.(adminErrors.FlyteAdminError).Code())
 	assert.Nil(t, response)
 }
 
@@ -213,7 +293,7 @@ func TestCreateWorkflow_CompilerGetRequirementsError(t *testing.T) {
 
 	workflowManager := NewWorkflowManager(
 		getMockRepository(!returnWorkflowOnGet),
-		getMockWorkflowConfigProvider(), mockCompiler, getMockStorage(), storagePrefix, mockScope.NewTestScope())
+		getMockWorkflowConfigProvider(), mockCompiler, getMockStorage(), storagePrefix, mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
 	assert.EqualError(t, err, fmt.Sprintf(
@@ -233,7 +313,7 @@ func TestCreateWorkflow_CompileWorkflowError(t *testing.T) {
 
 	workflowManager := NewWorkflowManager(
 		getMockRepository(!returnWorkflowOnGet),
-		getMockWorkflowConfigProvider(), mockCompiler, getMockStorage(), storagePrefix, mockScope.NewTestScope())
+		getMockWorkflowConfigProvider(), mockCompiler, getMockStorage(), storagePrefix, mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
 	assert.EqualError(t, err, fmt.Sprintf(
@@ -252,7 +332,7 @@ func TestCreateWorkflow_DatabaseError(t *testing.T) {
 	repository.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetCreateCallback(workflowCreateFunc)
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), getMockStorage(), storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	request := testutils.GetWorkflowRequest()
 	response, err := workflowManager.CreateWorkflow(context.Background(), request)
 	assert.EqualError(t, err, expectedErr.Error())
@@ -292,7 +372,7 @@ func TestGetWorkflow(t *testing.T) {
 		}
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	workflow, err := workflowManager.GetWorkflow(context.Background(), admin.ObjectGetRequest{
 		Id: &workflowIdentifier,
 	})
@@ -314,7 +394,7 @@ func TestGetWorkflow_DatabaseError(t *testing.T) {
 	repository.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetGetCallback(workflowGetFunc)
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), commonMocks.GetMockStorageClient(),
-		storagePrefix, mockScope.NewTestScope())
+		storagePrefix, mockScope.NewTestScope(), nil)
 	workflow, err := workflowManager.GetWorkflow(context.Background(), admin.ObjectGetRequest{
 		Id: &workflowIdentifier,
 	})
@@ -350,7 +430,7 @@ func TestGetWorkflow_TransformerError(t *testing.T) {
 
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	workflow, err := workflowManager.GetWorkflow(context.Background(), admin.ObjectGetRequest{
 		Id: &workflowIdentifier,
 	})
@@ -421,7 +501,7 @@ func TestListWorkflows(t *testing.T) {
 		}
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 
 	workflowList, err := workflowManager.ListWorkflows(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
@@ -456,7 +536,7 @@ func TestListWorkflows_MissingParameters(t *testing.T) {
 	workflowManager := NewWorkflowManager(
 		repositoryMocks.NewMockRepository(),
 		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), commonMocks.GetMockStorageClient(), storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	_, err := workflowManager.ListWorkflows(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
 			Domain: domainValue,
@@ -488,7 +568,7 @@ func TestListWorkflows_DatabaseError(t *testing.T) {
 	repository.WorkflowRepo().(*repositoryMocks.MockWorkflowRepo).SetListCallback(workflowListFunc)
 	workflowManager := NewWorkflowManager(repository,
 		getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), commonMocks.GetMockStorageClient(), storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 	_, err := workflowManager.ListWorkflows(context.Background(), admin.ResourceListRequest{
 		Id: &admin.NamedEntityIdentifier{
 			Project: projectValue,
@@ -551,7 +631,7 @@ func TestWorkflowManager_ListWorkflowIdentifiers(t *testing.T) {
 		}
 	workflowManager := NewWorkflowManager(
 		repository, getMockWorkflowConfigProvider(), getMockWorkflowCompiler(), mockStorageClient, storagePrefix,
-		mockScope.NewTestScope())
+		mockScope.NewTestScope(), nil)
 
 	workflowList, err := workflowManager.ListWorkflowIdentifiers(context.Background(),
 		admin.NamedEntityIdentifierListRequest{
@@ -0,0 +1,18 @@
+package interfaces
+
+// Counts the entities a list request with the same project, domain and filters would page through.
+type CountResourceRequest struct {
+	Project string
+	Domain  string
+	// Filters in the same format as those of the list request.
+	Filters string
+	// Estimates the number of entities across all projects and domains from table statistics, rather than counting
+	// them. Only valid without a project, domain or filters.
+	Approximate bool
+}
+
+type CountResourceResponse struct {
+	Count int64 `json:"count"`
+	// Whether the count was estimated rather than counted.
+	Approximate bool `json:"approximate"`
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Requests a location to upload an artifact, such as a fast registration archive, to.
+type CreateUploadLocationRequest struct {
+	Project string `json:"project"`
+	Domain  string `json:"domain"`
+	// The name the artifact is stored under, e.g. fast-registration.tar.gz.
+	Filename string `json:"filename"`
+	// The MD5 digest of the artifact, identical artifacts are uploaded to the same location.
+	ContentMD5 []byte `json:"contentMd5"`
+	// The size of the artifact in bytes.
+	ContentLength int64 `json:"contentLength"`
+}
+
+type CreateUploadLocationResponse struct {
+	// The pre-signed url to upload the artifact to with a PUT request.
+	SignedURL string `json:"signedUrl"`
+	// The uri the uploaded artifact is stored at, e.g. s3://bucket/uploads/project/domain/hash/filename.
+	NativeURL string `json:"nativeUrl"`
+	// The time after which the signed url is no longer valid.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Interface for handing out locations to upload artifacts to without direct access to the backing storage.
+type DataProxyInterface interface {
+	CreateUploadLocation(ctx context.Context, request CreateUploadLocationRequest) (*CreateUploadLocationResponse, error)
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// Long form documentation attached to a registered workflow, task or launch plan version.
+type DescriptionEntity struct {
+	// Identifies the workflow, task or launch plan version this documentation describes.
+	ID *core.Identifier `json:"id"`
+	// Long form description, for instance a markdown README.
+	LongDescription string `json:"longDescription"`
+	// Format of the long description, e.g. text/markdown.
+	ContentType string `json:"contentType"`
+	// Link to the source code the entity was registered from.
+	SourceCodeLink string `json:"sourceCodeLink"`
+}
+
+// A page of description entities.
+type DescriptionEntityList struct {
+	DescriptionEntities []*DescriptionEntity `json:"descriptionEntities"`
+	Token               string               `json:"token"`
+}
+
+// Interface for managing the long form documentation of registered entities.
+type DescriptionEntityInterface interface {
+	// Attaches documentation to an already registered workflow, task or launch plan version.
+	CreateDescriptionEntity(ctx context.Context, request DescriptionEntity) error
+	GetDescriptionEntity(ctx context.Context, id core.Identifier) (*DescriptionEntity, error)
+	// Lists the versions of workflows, tasks or launch plans which have documentation.
+	ListDescriptionEntities(ctx context.Context, resourceType core.ResourceType, request admin.ResourceListRequest) (
+		*DescriptionEntityList, error)
+}
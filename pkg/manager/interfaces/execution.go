@@ -5,14 +5,105 @@ import (
 	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
+// BulkTerminateExecutionsRequest selects the executions of a project and domain to terminate, either by launch plan, by
+// filter or both.
+type BulkTerminateExecutionsRequest struct {
+	Project string
+	Domain  string
+	// Only executions launched from this launch plan are selected. Fields left empty match any value, so leaving out the
+	// version selects executions of every version of the launch plan.
+	LaunchPlan *core.Identifier
+	// Filters in the same format as those of ListExecutions.
+	Filters string
+	// Recorded as the abort cause of every terminated execution.
+	Cause string
+	// Only report the executions that would be terminated.
+	DryRun bool
+}
+
+type BulkTerminateExecutionsResponse struct {
+	// The executions that were terminated, or that would have been for a dry run.
+	Terminated []*core.WorkflowExecutionIdentifier
+	// The number of selected executions that had already reached a terminal phase.
+	AlreadyTerminal int
+	// The executions that failed to terminate, keyed by execution name.
+	Failed map[string]error
+}
+
+// PurgeExecutionsRequest selects the executions of a domain, and optionally of a single project, which were created
+// before a cutoff to hard delete along with everything recorded about them.
+type PurgeExecutionsRequest struct {
+	Project string
+	Domain  string
+	// Executions created before this time are purged, unless they haven't terminated.
+	CreatedBefore time.Time
+	// The number of executions purged per transaction, a default applies when unset.
+	BatchSize int
+	// Only report the rows that would be deleted.
+	DryRun bool
+}
+
+type PurgeExecutionsResponse struct {
+	// The number of executions purged, or that would have been for a dry run.
+	Executions int
+	// The number of rows deleted, or that would have been for a dry run, keyed by table.
+	DeletedRows map[string]int64
+}
+
+// ExecutionTagsUpdateRequest attaches tags to, and detaches tags from, an execution which hasn't terminated yet.
+type ExecutionTagsUpdateRequest struct {
+	ExecutionID *core.WorkflowExecutionIdentifier
+	Add         []string
+	Remove      []string
+}
+
+// TimelineSpan is an interval of an execution timeline. Spans which haven't ended yet are open, they have no end and
+// last until the timeline was computed.
+type TimelineSpan struct {
+	Start    time.Time
+	End      *time.Time
+	Duration time.Duration
+	Open     bool
+}
+
+// NodeExecutionTimeline breaks down the time a top level node execution took. Spans are left nil when the timestamps
+// they start from weren't recorded.
+type NodeExecutionTimeline struct {
+	NodeID string
+	Phase  core.NodeExecution_Phase
+	// From the node execution being created until it started running.
+	Queued *TimelineSpan
+	// From the node execution starting to run until it reached a terminal phase.
+	Run *TimelineSpan
+	// The number of times the task of the node was retried.
+	Retries uint32
+}
+
+// ExecutionTimeline breaks down where the time of an execution went, in a shape suited to Gantt charts.
+type ExecutionTimeline struct {
+	// From a scheduled execution being due until admin accepted it, only known for scheduled executions.
+	AcceptLatency *TimelineSpan
+	// From admin accepting the execution until propeller reported it running.
+	StartLatency *TimelineSpan
+	// From the execution starting to run until it reached a terminal phase.
+	RunTime *TimelineSpan
+	// The top level node executions, in the order they were created.
+	Nodes []NodeExecutionTimeline
+}
+
 // Interface for managing Flyte Workflow Executions
 type ExecutionInterface interface {
 	CreateExecution(ctx context.Context, request admin.ExecutionCreateRequest, requestedAt time.Time) (
 		*admin.ExecutionCreateResponse, error)
 	RelaunchExecution(ctx context.Context, request admin.ExecutionRelaunchRequest, requestedAt time.Time) (
 		*admin.ExecutionCreateResponse, error)
+	// Relaunches a previously-run workflow execution with the given inputs merged on top of the original ones. The
+	// merged inputs are validated against the launch plan interface just like those of a new execution.
+	RelaunchExecutionWithInputs(ctx context.Context, request admin.ExecutionRelaunchRequest,
+		inputOverrides *core.LiteralMap, requestedAt time.Time) (*admin.ExecutionCreateResponse, error)
 	// Recreates a previously-run workflow execution that will point to the original execution so that propeller will
 	// only start executing from the last known failure point. Propeller can recover individual workflow execution nodes
 	// which previously succeeded based on the recovery (original) workflow execution id.
@@ -24,6 +115,24 @@ type ExecutionInterface interface {
 	GetExecutionData(ctx context.Context, request admin.WorkflowExecutionGetDataRequest) (
 		*admin.WorkflowExecutionGetDataResponse, error)
 	ListExecutions(ctx context.Context, request admin.ResourceListRequest) (*admin.ExecutionList, error)
+	// Counts the executions ListExecutions pages through.
+	CountExecutions(ctx context.Context, request CountResourceRequest) (*CountResourceResponse, error)
 	TerminateExecution(
 		ctx context.Context, request admin.ExecutionTerminateRequest) (*admin.ExecutionTerminateResponse, error)
+	// Terminates every execution matching the request that is still running. Failing to terminate some of them doesn't
+	// fail the call, the failures are reported in the response.
+	BulkTerminateExecutions(ctx context.Context, request BulkTerminateExecutionsRequest) (
+		*BulkTerminateExecutionsResponse, error)
+	// Adds and removes tags of a non-terminal execution. Executions launched from a launch plan node inherit the tags
+	// their parent execution has when they're created.
+	UpdateExecutionTags(ctx context.Context, request ExecutionTagsUpdateRequest) error
+	// Hard deletes old executions along with their node executions, task executions and events, in batches which
+	// either complete or leave no trace. Executions other executions were recovered or relaunched from, or launched by,
+	// are kept for as long as those executions are.
+	PurgeExecutions(ctx context.Context, request PurgeExecutionsRequest) (*PurgeExecutionsResponse, error)
+	// Computes the timeline of an execution and of its top level node executions.
+	GetExecutionTimeline(ctx context.Context, id *core.WorkflowExecutionIdentifier) (*ExecutionTimeline, error)
+	// Fails the executions recorded as queued before the given time which admin never started to launch, as the
+	// replica which queued them stopped before launching them. Returns the number of executions failed.
+	FailInterruptedLaunches(ctx context.Context, queuedBefore time.Time) (int, error)
 }
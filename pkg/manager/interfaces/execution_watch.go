@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+)
+
+// An update streamed to the watcher of an execution. The first update is always a snapshot of the execution along with
+// its top level node executions, the updates which follow each hold one workflow or node execution event. Events of
+// nested node executions are streamed too.
+type ExecutionWatchUpdate struct {
+	Execution      *admin.Execution
+	NodeExecutions []*admin.NodeExecution
+	WorkflowEvent  *event.WorkflowExecutionEvent
+	NodeEvent      *event.NodeExecutionEvent
+}
+
+// Sends an update to the watcher, failing once it's gone.
+type ExecutionWatchSender func(update ExecutionWatchUpdate) error
+
+// Interface for streaming the updates of an execution to its watchers.
+type ExecutionWatchInterface interface {
+	// WatchExecution sends a snapshot of the execution, followed by the events processed for it, until the execution
+	// reaches a terminal phase, the context is done or sending fails. Only the events processed by this replica are
+	// sent, so a watcher whose events stop arriving, or whose watch fails, is expected to watch again to get a fresh
+	// snapshot.
+	WatchExecution(ctx context.Context, request admin.WorkflowExecutionGetRequest, send ExecutionWatchSender) error
+}
@@ -21,6 +21,23 @@ type LaunchPlanInterface interface {
 		*admin.LaunchPlanList, error)
 	ListActiveLaunchPlans(ctx context.Context, request admin.ActiveLaunchPlanListRequest) (
 		*admin.LaunchPlanList, error)
+	// Lists active launch plans which either have a schedule or, when scheduled is false, don't.
+	ListActiveLaunchPlansBySchedule(ctx context.Context, request admin.ActiveLaunchPlanListRequest, scheduled bool) (
+		*ActiveLaunchPlanList, error)
 	ListLaunchPlanIds(ctx context.Context, request admin.NamedEntityIdentifierListRequest) (
 		*admin.NamedEntityIdentifierList, error)
 }
+
+// An active launch plan along with the expression its schedule resolves to.
+type ActiveLaunchPlan struct {
+	LaunchPlan *admin.LaunchPlan
+	// Cron expression (or "@every" descriptor for fixed rate schedules) the launch plan schedule resolves to.
+	// Empty when the launch plan has no schedule.
+	ScheduleExpression string
+}
+
+// A page of active launch plans.
+type ActiveLaunchPlanList struct {
+	LaunchPlans []ActiveLaunchPlan
+	Token       string
+}
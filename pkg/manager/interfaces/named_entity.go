@@ -4,11 +4,29 @@ import (
 	"context"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
+// Searches the names of workflows, tasks and launch plans across projects.
+type SearchNamedEntitiesRequest struct {
+	// Free text matched case insensitively against entity names.
+	Query string
+	// The resource types to search, workflows, tasks and launch plans alike when empty.
+	ResourceTypes []core.ResourceType
+	Limit         uint32
+	Token         string
+}
+
+// A page of named entities matching a search, names starting with the query ranked first.
+type SearchNamedEntitiesResponse struct {
+	Entities []*admin.NamedEntity
+	Token    string
+}
+
 // Interface for managing metadata associated with NamedEntityIdentifiers
 type NamedEntityInterface interface {
 	GetNamedEntity(ctx context.Context, request admin.NamedEntityGetRequest) (*admin.NamedEntity, error)
 	UpdateNamedEntity(ctx context.Context, request admin.NamedEntityUpdateRequest) (*admin.NamedEntityUpdateResponse, error)
 	ListNamedEntities(ctx context.Context, request admin.NamedEntityListRequest) (*admin.NamedEntityList, error)
+	SearchNamedEntities(ctx context.Context, request SearchNamedEntitiesRequest) (*SearchNamedEntitiesResponse, error)
 }
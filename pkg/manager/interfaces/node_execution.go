@@ -4,8 +4,59 @@ import (
 	"context"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
+// NodeExecutionTreeListRequest lists a page of node executions of an execution along with their descendants. Filters,
+// sorting and pagination only apply to the node executions of the page, all of their descendants are listed.
+type NodeExecutionTreeListRequest struct {
+	WorkflowExecutionId *core.WorkflowExecutionIdentifier
+	// Lists the children of this node execution, along with their descendants, rather than the top level node
+	// executions of the execution.
+	UniqueParentId string
+	// Number of levels of descendants listed below the node executions of the page. Deeper levels than the configured
+	// maximum aren't listed, which is also the default.
+	Depth   int
+	Filters string
+	SortBy  *admin.Sort
+	Limit   uint32
+	Token   string
+}
+
+type NodeExecutionTreeNode struct {
+	NodeExecution *admin.NodeExecution
+	// The unique id of the node execution which spawned this one, empty for top level node executions.
+	ParentNodeId string
+	// How many levels below the node executions of the page this one is, those of the page are at depth 0.
+	Depth int
+	// Whether the node execution has children which weren't listed for being too deep.
+	HasUnexpandedChildren bool
+}
+
+type NodeExecutionTreeList struct {
+	// Each node execution is followed by its descendants.
+	NodeExecutions []NodeExecutionTreeNode
+	// Token of the next page of node executions at depth 0.
+	Token string
+}
+
+// NodeExecutionGetDataBatchRequest selects node executions of a single execution whose data to fetch.
+type NodeExecutionGetDataBatchRequest struct {
+	Ids []*core.NodeExecutionIdentifier
+}
+
+// NodeExecutionDataResult holds either the data of a node execution or the error fetching it.
+type NodeExecutionDataResult struct {
+	Id    *core.NodeExecutionIdentifier
+	Data  *admin.NodeExecutionGetDataResponse
+	Error error
+}
+
+type NodeExecutionGetDataBatchResponse struct {
+	// A result for each requested node execution, in the order they were requested.
+	Results []NodeExecutionDataResult
+}
+
 // Interface for managing Flyte Workflow NodeExecutions
 type NodeExecutionInterface interface {
 	CreateNodeEvent(ctx context.Context, request admin.NodeExecutionEventRequest) (
@@ -13,6 +64,13 @@ type NodeExecutionInterface interface {
 	GetNodeExecution(ctx context.Context, request admin.NodeExecutionGetRequest) (*admin.NodeExecution, error)
 	ListNodeExecutions(ctx context.Context, request admin.NodeExecutionListRequest) (*admin.NodeExecutionList, error)
 	ListNodeExecutionsForTask(ctx context.Context, request admin.NodeExecutionForTaskListRequest) (*admin.NodeExecutionList, error)
+	// Lists node executions like ListNodeExecutions, each followed by its descendants, such as the nodes of the
+	// subworkflows and dynamic workflows it spawned.
+	ListNodeExecutionTree(ctx context.Context, request NodeExecutionTreeListRequest) (*NodeExecutionTreeList, error)
 	GetNodeExecutionData(
 		ctx context.Context, request admin.NodeExecutionGetDataRequest) (*admin.NodeExecutionGetDataResponse, error)
+	// Fetches the data of many node executions of an execution at once. Failing to fetch the data of some of them
+	// doesn't fail the call, each result holds its own error.
+	GetNodeExecutionDataBatch(ctx context.Context, request NodeExecutionGetDataBatchRequest) (
+		*NodeExecutionGetDataBatchResponse, error)
 }
@@ -16,6 +16,8 @@ type ResourceInterface interface {
 		*admin.ProjectDomainAttributesUpdateResponse, error)
 	GetProjectDomainAttributes(ctx context.Context, request admin.ProjectDomainAttributesGetRequest) (
 		*admin.ProjectDomainAttributesGetResponse, error)
+	GetAllProjectDomainAttributes(ctx context.Context, request ProjectDomainAttributesGetAllRequest) (
+		*ProjectDomainAttributesGetAllResponse, error)
 	DeleteProjectDomainAttributes(ctx context.Context, request admin.ProjectDomainAttributesDeleteRequest) (
 		*admin.ProjectDomainAttributesDeleteResponse, error)
 
@@ -25,6 +27,117 @@ type ResourceInterface interface {
 		*admin.WorkflowAttributesGetResponse, error)
 	DeleteWorkflowAttributes(ctx context.Context, request admin.WorkflowAttributesDeleteRequest) (
 		*admin.WorkflowAttributesDeleteResponse, error)
+
+	UpdateScopedAttributes(ctx context.Context, request ScopedAttributesUpdateRequest) error
+	GetScopedAttributes(ctx context.Context, request ScopedAttributesGetRequest) (*ScopedAttributesGetResponse, error)
+	DeleteScopedAttributes(ctx context.Context, request ScopedAttributesDeleteRequest) error
+
+	GetEffectiveMatchableAttributes(ctx context.Context, request EffectiveAttributesRequest) (
+		*EffectiveAttributesResponse, error)
+
+	UpdateActiveExecutionQuota(ctx context.Context, quota ActiveExecutionQuota) error
+	GetActiveExecutionQuota(ctx context.Context, request ActiveExecutionQuotaRequest) (*ActiveExecutionQuota, error)
+	DeleteActiveExecutionQuota(ctx context.Context, request ActiveExecutionQuotaRequest) error
+}
+
+// The level of the matchable attributes hierarchy attributes are set at. GetResource resolves the attributes of the
+// most specific level which has any, in the order below, and callers fall back on the application config otherwise.
+type ResourceLevel string
+
+const (
+	ResourceLevelLaunchPlan    ResourceLevel = "launch_plan"
+	ResourceLevelWorkflow      ResourceLevel = "workflow"
+	ResourceLevelProjectDomain ResourceLevel = "project_domain"
+	// A project in all its domains.
+	ResourceLevelProject ResourceLevel = "project"
+	// All the projects labelled with the org, see the orgLabel application config.
+	ResourceLevelOrg    ResourceLevel = "org"
+	ResourceLevelDomain ResourceLevel = "domain"
+	// Not a level of the hierarchy, but the fallback of attributes no level sets.
+	ResourceLevelApplicationConfig ResourceLevel = "application_config"
+)
+
+// Identifies attributes set above the project-domain level, which admin.ProjectDomainAttributes can't express.
+// Exactly one of the fields is set: the project for attributes of the project in all its domains, the domain for
+// those of a domain, or the org for those of an org.
+type AttributesScope struct {
+	Project string
+	Domain  string
+	Org     string
+}
+
+type ScopedAttributesUpdateRequest struct {
+	Scope              AttributesScope
+	MatchingAttributes *admin.MatchingAttributes
+}
+
+type ScopedAttributesGetRequest struct {
+	Scope        AttributesScope
+	ResourceType admin.MatchableResource
+}
+
+type ScopedAttributesGetResponse struct {
+	Scope              AttributesScope
+	Level              ResourceLevel
+	MatchingAttributes *admin.MatchingAttributes
+}
+
+type ScopedAttributesDeleteRequest struct {
+	Scope        AttributesScope
+	ResourceType admin.MatchableResource
+}
+
+// Requests the attributes executions of a workflow, or of any workflow of the project and domain when unset, are
+// created with.
+type EffectiveAttributesRequest struct {
+	Project      string
+	Domain       string
+	Workflow     string
+	ResourceType admin.MatchableResource
+}
+
+// Where a field of effective attributes comes from.
+type AttributeSource struct {
+	// The field, such as limits.cpu for task resources.
+	Field string        `json:"field"`
+	Level ResourceLevel `json:"level"`
+	// Set when the field wasn't set at the level but defaults to this other field, such as a request to its limit.
+	DefaultedFrom string `json:"defaulted_from,omitempty"`
+}
+
+type EffectiveAttributesResponse struct {
+	// The attributes as applied to executions, after falling back on the application config and filling in defaults.
+	Attributes *admin.MatchingAttributes
+	// The source of each field which is set, in the order of the fields.
+	Sources []AttributeSource
+}
+
+// ProjectDomainAttributesGetAllRequest requests the attributes of a project and domain of every matchable resource type
+// at once, since admin.ProjectDomainAttributesGetRequest can't leave the resource type unspecified.
+type ProjectDomainAttributesGetAllRequest struct {
+	Project string
+	Domain  string
+}
+
+type ProjectDomainAttributesGetAllResponse struct {
+	// The attributes of each matchable resource type which has any, ordered by type. As for a single type, attributes
+	// set for the whole domain apply to projects without their own.
+	Attributes []*admin.ProjectDomainAttributes
+}
+
+// The most executions a project may have active in a domain at once, which takes precedence over the
+// activeExecutionQuota application config. It's stored and matched like the attributes of matchable resources, but
+// kept apart from them since admin.MatchableResource has no type for it.
+type ActiveExecutionQuota struct {
+	Project string `json:"project"`
+	Domain  string `json:"domain"`
+	// A value of 0 lifts the limit of the application config.
+	MaxActiveExecutions int32 `json:"max_active_executions"`
+}
+
+type ActiveExecutionQuotaRequest struct {
+	Project string
+	Domain  string
 }
 
 // TODO we can move this to flyteidl, once we are exposing an endpoint
@@ -42,5 +155,8 @@ type ResourceResponse struct {
 	Workflow     string
 	LaunchPlan   string
 	ResourceType string
-	Attributes   *admin.MatchingAttributes
+	Org          string
+	// The level the attributes were resolved from.
+	Level      ResourceLevel
+	Attributes *admin.MatchingAttributes
 }
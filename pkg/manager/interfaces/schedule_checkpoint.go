@@ -0,0 +1,44 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Selects the checkpoints of the scheduled launch plans of a project and domain, of a launch plan when its name is set.
+type ListScheduleCheckpointsRequest struct {
+	Project string
+	Domain  string
+	Name    string
+}
+
+// Sets the checkpoint of a scheduled launch plan version to the scheduled time given.
+type UpdateScheduleCheckpointRequest struct {
+	Project         string
+	Domain          string
+	Name            string
+	Version         string
+	LastScheduledAt time.Time
+}
+
+// The latest scheduled time an execution was created for, or which an admin set, for a launch plan version.
+type ScheduleCheckpoint struct {
+	Project         string    `json:"project"`
+	Domain          string    `json:"domain"`
+	Name            string    `json:"name"`
+	Version         string    `json:"version"`
+	LastScheduledAt time.Time `json:"lastScheduledAt"`
+	// Empty when the checkpoint was set by an admin rather than by creating an execution.
+	LastExecutionName string    `json:"lastExecutionName"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+type ScheduleCheckpointList struct {
+	Checkpoints []ScheduleCheckpoint `json:"checkpoints"`
+}
+
+// Interface for reading and setting the checkpoints the scheduler starts scheduled launch plans from.
+type ScheduleCheckpointInterface interface {
+	ListScheduleCheckpoints(ctx context.Context, request ListScheduleCheckpointsRequest) (*ScheduleCheckpointList, error)
+	UpdateScheduleCheckpoint(ctx context.Context, request UpdateScheduleCheckpointRequest) (*ScheduleCheckpoint, error)
+}
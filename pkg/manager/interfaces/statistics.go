@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Selects the executions, of a project and of a domain when set, which were created within a time range to aggregate
+// into statistics, along with the length of the buckets the range is divided into.
+type ExecutionStatisticsRequest struct {
+	Project    string
+	Domain     string
+	Start      time.Time
+	End        time.Time
+	BucketSize time.Duration
+}
+
+// The terminated executions which were created within a bucket of the time range.
+type ExecutionStatisticsBucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// The number of executions, keyed by terminal phase.
+	PhaseCounts map[string]int64 `json:"phaseCounts"`
+	Total       int64            `json:"total"`
+	// The share of executions which succeeded, 0 for buckets without executions.
+	SuccessRate float64 `json:"successRate"`
+	// Durations in nanoseconds keyed by percentile, such as p95. Empty for buckets without executions.
+	DurationPercentiles map[string]time.Duration `json:"durationPercentiles"`
+}
+
+type ExecutionStatistics struct {
+	// Every bucket of the time range, in order, including those without executions.
+	Buckets []ExecutionStatisticsBucket `json:"buckets"`
+	// Whether percentiles are approximated, which they are on databases without percentile functions.
+	Approximate bool `json:"approximate"`
+}
+
+// Interface for aggregating statistics on the usage of projects and domains.
+type StatisticsInterface interface {
+	GetExecutionStatistics(ctx context.Context, request ExecutionStatisticsRequest) (*ExecutionStatistics, error)
+}
@@ -11,6 +11,8 @@ type TaskInterface interface {
 	CreateTask(ctx context.Context, request admin.TaskCreateRequest) (*admin.TaskCreateResponse, error)
 	GetTask(ctx context.Context, request admin.ObjectGetRequest) (*admin.Task, error)
 	ListTasks(ctx context.Context, request admin.ResourceListRequest) (*admin.TaskList, error)
+	// Counts the task versions ListTasks pages through.
+	CountTasks(ctx context.Context, request CountResourceRequest) (*CountResourceResponse, error)
 	ListUniqueTaskIdentifiers(ctx context.Context, request admin.NamedEntityIdentifierListRequest) (
 		*admin.NamedEntityIdentifierList, error)
 }
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+type CreateUploadLocationFunc func(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+	*interfaces.CreateUploadLocationResponse, error)
+
+type DataProxyManager struct {
+	CreateUploadLocationFunc CreateUploadLocationFunc
+}
+
+func (m *DataProxyManager) CreateUploadLocation(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+	*interfaces.CreateUploadLocationResponse, error) {
+	if m.CreateUploadLocationFunc != nil {
+		return m.CreateUploadLocationFunc(ctx, request)
+	}
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+type CreateDescriptionEntityFunc func(ctx context.Context, request interfaces.DescriptionEntity) error
+type GetDescriptionEntityFunc func(ctx context.Context, id core.Identifier) (*interfaces.DescriptionEntity, error)
+type ListDescriptionEntitiesFunc func(ctx context.Context, resourceType core.ResourceType,
+	request admin.ResourceListRequest) (*interfaces.DescriptionEntityList, error)
+
+type DescriptionEntityManager struct {
+	CreateDescriptionEntityFunc CreateDescriptionEntityFunc
+	GetDescriptionEntityFunc    GetDescriptionEntityFunc
+	ListDescriptionEntitiesFunc ListDescriptionEntitiesFunc
+}
+
+func (m *DescriptionEntityManager) CreateDescriptionEntity(
+	ctx context.Context, request interfaces.DescriptionEntity) error {
+	if m.CreateDescriptionEntityFunc != nil {
+		return m.CreateDescriptionEntityFunc(ctx, request)
+	}
+	return nil
+}
+
+func (m *DescriptionEntityManager) GetDescriptionEntity(ctx context.Context, id core.Identifier) (
+	*interfaces.DescriptionEntity, error) {
+	if m.GetDescriptionEntityFunc != nil {
+		return m.GetDescriptionEntityFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *DescriptionEntityManager) ListDescriptionEntities(
+	ctx context.Context, resourceType core.ResourceType, request admin.ResourceListRequest) (
+	*interfaces.DescriptionEntityList, error) {
+	if m.ListDescriptionEntitiesFunc != nil {
+		return m.ListDescriptionEntitiesFunc(ctx, resourceType, request)
+	}
+	return nil, nil
+}
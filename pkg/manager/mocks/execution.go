@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
 type CreateExecutionFunc func(
@@ -13,6 +15,9 @@ type CreateExecutionFunc func(
 type RelaunchExecutionFunc func(
 	ctx context.Context, request admin.ExecutionRelaunchRequest, requestedAt time.Time) (
 	*admin.ExecutionCreateResponse, error)
+type RelaunchExecutionWithInputsFunc func(
+	ctx context.Context, request admin.ExecutionRelaunchRequest, inputOverrides *core.LiteralMap,
+	requestedAt time.Time) (*admin.ExecutionCreateResponse, error)
 type RecoverExecutionFunc func(ctx context.Context, request admin.ExecutionRecoverRequest, requestedAt time.Time) (
 	*admin.ExecutionCreateResponse, error)
 type CreateExecutionEventFunc func(ctx context.Context, request admin.WorkflowExecutionEventRequest) (
@@ -21,18 +26,35 @@ type GetExecutionFunc func(ctx context.Context, request admin.WorkflowExecutionG
 type GetExecutionDataFunc func(ctx context.Context, request admin.WorkflowExecutionGetDataRequest) (
 	*admin.WorkflowExecutionGetDataResponse, error)
 type ListExecutionFunc func(ctx context.Context, request admin.ResourceListRequest) (*admin.ExecutionList, error)
+type CountExecutionsFunc func(ctx context.Context, request interfaces.CountResourceRequest) (
+	*interfaces.CountResourceResponse, error)
 type TerminateExecutionFunc func(
 	ctx context.Context, request admin.ExecutionTerminateRequest) (*admin.ExecutionTerminateResponse, error)
+type BulkTerminateExecutionsFunc func(ctx context.Context, request interfaces.BulkTerminateExecutionsRequest) (
+	*interfaces.BulkTerminateExecutionsResponse, error)
+type UpdateExecutionTagsFunc func(ctx context.Context, request interfaces.ExecutionTagsUpdateRequest) error
+type PurgeExecutionsFunc func(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+	*interfaces.PurgeExecutionsResponse, error)
+type GetExecutionTimelineFunc func(ctx context.Context, id *core.WorkflowExecutionIdentifier) (
+	*interfaces.ExecutionTimeline, error)
+type FailInterruptedLaunchesFunc func(ctx context.Context, queuedBefore time.Time) (int, error)
 
 type MockExecutionManager struct {
 	createExecutionFunc      CreateExecutionFunc
 	relaunchExecutionFunc    RelaunchExecutionFunc
+	relaunchWithInputsFunc   RelaunchExecutionWithInputsFunc
 	RecoverExecutionFunc     RecoverExecutionFunc
 	createExecutionEventFunc CreateExecutionEventFunc
 	getExecutionFunc         GetExecutionFunc
 	getExecutionDataFunc     GetExecutionDataFunc
 	listExecutionFunc        ListExecutionFunc
+	countExecutionsFunc      CountExecutionsFunc
 	terminateExecutionFunc   TerminateExecutionFunc
+	bulkTerminateFunc        BulkTerminateExecutionsFunc
+	updateTagsFunc           UpdateExecutionTagsFunc
+	purgeExecutionsFunc      PurgeExecutionsFunc
+	getTimelineFunc          GetExecutionTimelineFunc
+	failInterruptedFunc      FailInterruptedLaunchesFunc
 }
 
 func (m *MockExecutionManager) SetCreateCallback(createFunction CreateExecutionFunc) {
@@ -61,6 +83,19 @@ func (m *MockExecutionManager) RelaunchExecution(
 	return nil, nil
 }
 
+func (m *MockExecutionManager) SetRelaunchWithInputsCallback(relaunchFunction RelaunchExecutionWithInputsFunc) {
+	m.relaunchWithInputsFunc = relaunchFunction
+}
+
+func (m *MockExecutionManager) RelaunchExecutionWithInputs(
+	ctx context.Context, request admin.ExecutionRelaunchRequest, inputOverrides *core.LiteralMap,
+	requestedAt time.Time) (*admin.ExecutionCreateResponse, error) {
+	if m.relaunchWithInputsFunc != nil {
+		return m.relaunchWithInputsFunc(ctx, request, inputOverrides, requestedAt)
+	}
+	return nil, nil
+}
+
 func (m *MockExecutionManager) SetCreateEventCallback(createEventFunc CreateExecutionEventFunc) {
 	m.createExecutionEventFunc = createEventFunc
 }
@@ -118,6 +153,18 @@ func (m *MockExecutionManager) ListExecutions(
 	return nil, nil
 }
 
+func (m *MockExecutionManager) SetCountExecutionsCallback(countExecutionsFunc CountExecutionsFunc) {
+	m.countExecutionsFunc = countExecutionsFunc
+}
+
+func (m *MockExecutionManager) CountExecutions(
+	ctx context.Context, request interfaces.CountResourceRequest) (*interfaces.CountResourceResponse, error) {
+	if m.countExecutionsFunc != nil {
+		return m.countExecutionsFunc(ctx, request)
+	}
+	return nil, nil
+}
+
 func (m *MockExecutionManager) SetTerminateExecutionCallback(terminateExecutionFunc TerminateExecutionFunc) {
 	m.terminateExecutionFunc = terminateExecutionFunc
 }
@@ -129,3 +176,63 @@ func (m *MockExecutionManager) TerminateExecution(
 	}
 	return nil, nil
 }
+
+func (m *MockExecutionManager) SetBulkTerminateExecutionsCallback(bulkTerminateFunc BulkTerminateExecutionsFunc) {
+	m.bulkTerminateFunc = bulkTerminateFunc
+}
+
+func (m *MockExecutionManager) BulkTerminateExecutions(
+	ctx context.Context, request interfaces.BulkTerminateExecutionsRequest) (
+	*interfaces.BulkTerminateExecutionsResponse, error) {
+	if m.bulkTerminateFunc != nil {
+		return m.bulkTerminateFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionManager) SetUpdateExecutionTagsCallback(updateTagsFunc UpdateExecutionTagsFunc) {
+	m.updateTagsFunc = updateTagsFunc
+}
+
+func (m *MockExecutionManager) UpdateExecutionTags(
+	ctx context.Context, request interfaces.ExecutionTagsUpdateRequest) error {
+	if m.updateTagsFunc != nil {
+		return m.updateTagsFunc(ctx, request)
+	}
+	return nil
+}
+
+func (m *MockExecutionManager) SetPurgeExecutionsCallback(purgeExecutionsFunc PurgeExecutionsFunc) {
+	m.purgeExecutionsFunc = purgeExecutionsFunc
+}
+
+func (m *MockExecutionManager) PurgeExecutions(
+	ctx context.Context, request interfaces.PurgeExecutionsRequest) (*interfaces.PurgeExecutionsResponse, error) {
+	if m.purgeExecutionsFunc != nil {
+		return m.purgeExecutionsFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionManager) SetGetTimelineCallback(getTimelineFunc GetExecutionTimelineFunc) {
+	m.getTimelineFunc = getTimelineFunc
+}
+
+func (m *MockExecutionManager) GetExecutionTimeline(
+	ctx context.Context, id *core.WorkflowExecutionIdentifier) (*interfaces.ExecutionTimeline, error) {
+	if m.getTimelineFunc != nil {
+		return m.getTimelineFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockExecutionManager) SetFailInterruptedLaunchesCallback(failInterruptedFunc FailInterruptedLaunchesFunc) {
+	m.failInterruptedFunc = failInterruptedFunc
+}
+
+func (m *MockExecutionManager) FailInterruptedLaunches(ctx context.Context, queuedBefore time.Time) (int, error) {
+	if m.failInterruptedFunc != nil {
+		return m.failInterruptedFunc(ctx, queuedBefore)
+	}
+	return 0, nil
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+type WatchExecutionFunc func(
+	ctx context.Context, request admin.WorkflowExecutionGetRequest, send interfaces.ExecutionWatchSender) error
+
+type ExecutionWatchManager struct {
+	WatchExecutionFunc WatchExecutionFunc
+}
+
+func (m *ExecutionWatchManager) WatchExecution(
+	ctx context.Context, request admin.WorkflowExecutionGetRequest, send interfaces.ExecutionWatchSender) error {
+	if m.WatchExecutionFunc != nil {
+		return m.WatchExecutionFunc(ctx, request, send)
+	}
+	return nil
+}
@@ -22,15 +22,18 @@ type ListLaunchPlanIdsFunc func(ctx context.Context, request admin.NamedEntityId
 	*admin.NamedEntityIdentifierList, error)
 type ListActiveLaunchPlansFunc func(ctx context.Context, request admin.ActiveLaunchPlanListRequest) (
 	*admin.LaunchPlanList, error)
+type ListActiveLaunchPlansByScheduleFunc func(
+	ctx context.Context, request admin.ActiveLaunchPlanListRequest, scheduled bool) (*interfaces.ActiveLaunchPlanList, error)
 
 type MockLaunchPlanManager struct {
-	createLaunchPlanFunc      CreateLaunchPlanFunc
-	updateLaunchPlanFunc      UpdateLaunchPlanFunc
-	getLaunchPlanFunc         GetLaunchPlanFunc
-	getActiveLaunchPlanFunc   GetActiveLaunchPlanFunc
-	listLaunchPlansFunc       ListLaunchPlansFunc
-	listLaunchPlanIdsFunc     ListLaunchPlanIdsFunc
-	listActiveLaunchPlansFunc ListActiveLaunchPlansFunc
+	createLaunchPlanFunc                CreateLaunchPlanFunc
+	updateLaunchPlanFunc                UpdateLaunchPlanFunc
+	getLaunchPlanFunc                   GetLaunchPlanFunc
+	getActiveLaunchPlanFunc             GetActiveLaunchPlanFunc
+	listLaunchPlansFunc                 ListLaunchPlansFunc
+	listLaunchPlanIdsFunc               ListLaunchPlanIdsFunc
+	listActiveLaunchPlansFunc           ListActiveLaunchPlansFunc
+	listActiveLaunchPlansByScheduleFunc ListActiveLaunchPlansByScheduleFunc
 }
 
 func (r *MockLaunchPlanManager) SetCreateCallback(createFunction CreateLaunchPlanFunc) {
@@ -102,6 +105,20 @@ func (r *MockLaunchPlanManager) ListActiveLaunchPlans(ctx context.Context, reque
 	return nil, nil
 }
 
+func (r *MockLaunchPlanManager) SetListActiveLaunchPlansByScheduleCallback(
+	plansFunc ListActiveLaunchPlansByScheduleFunc) {
+	r.listActiveLaunchPlansByScheduleFunc = plansFunc
+}
+
+func (r *MockLaunchPlanManager) ListActiveLaunchPlansBySchedule(
+	ctx context.Context, request admin.ActiveLaunchPlanListRequest, scheduled bool) (
+	*interfaces.ActiveLaunchPlanList, error) {
+	if r.listActiveLaunchPlansByScheduleFunc != nil {
+		return r.listActiveLaunchPlansByScheduleFunc(ctx, request, scheduled)
+	}
+	return nil, nil
+}
+
 func (r *MockLaunchPlanManager) ListLaunchPlanIds(ctx context.Context, request admin.NamedEntityIdentifierListRequest) (
 	*admin.NamedEntityIdentifierList, error) {
 	if r.listLaunchPlanIdsFunc != nil {
@@ -3,17 +3,21 @@ package mocks
 import (
 	"context"
 
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
 
 type GetNamedEntityFunc func(ctx context.Context, request admin.NamedEntityGetRequest) (*admin.NamedEntity, error)
 type UpdateNamedEntityFunc func(ctx context.Context, request admin.NamedEntityUpdateRequest) (*admin.NamedEntityUpdateResponse, error)
 type ListNamedEntitiesFunc func(ctx context.Context, request admin.NamedEntityListRequest) (*admin.NamedEntityList, error)
+type SearchNamedEntitiesFunc func(ctx context.Context, request interfaces.SearchNamedEntitiesRequest) (
+	*interfaces.SearchNamedEntitiesResponse, error)
 
 type NamedEntityManager struct {
-	GetNamedEntityFunc    GetNamedEntityFunc
-	UpdateNamedEntityFunc UpdateNamedEntityFunc
-	ListNamedEntitiesFunc ListNamedEntitiesFunc
+	GetNamedEntityFunc      GetNamedEntityFunc
+	UpdateNamedEntityFunc   UpdateNamedEntityFunc
+	ListNamedEntitiesFunc   ListNamedEntitiesFunc
+	SearchNamedEntitiesFunc SearchNamedEntitiesFunc
 }
 
 func (m *NamedEntityManager) GetNamedEntity(ctx context.Context, request admin.NamedEntityGetRequest) (*admin.NamedEntity, error) {
@@ -36,3 +40,11 @@ func (m *NamedEntityManager) ListNamedEntities(ctx context.Context, request admi
 	}
 	return nil, nil
 }
+
+func (m *NamedEntityManager) SearchNamedEntities(ctx context.Context, request interfaces.SearchNamedEntitiesRequest) (
+	*interfaces.SearchNamedEntitiesResponse, error) {
+	if m.SearchNamedEntitiesFunc != nil {
+		return m.SearchNamedEntitiesFunc(ctx, request)
+	}
+	return nil, nil
+}
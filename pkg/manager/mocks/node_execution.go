@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
 
@@ -15,6 +16,10 @@ type ListNodeExecutionsForTaskFunc func(ctx context.Context, request admin.NodeE
 	*admin.NodeExecutionList, error)
 type GetNodeExecutionDataFunc func(
 	ctx context.Context, request admin.NodeExecutionGetDataRequest) (*admin.NodeExecutionGetDataResponse, error)
+type ListNodeExecutionTreeFunc func(ctx context.Context, request interfaces.NodeExecutionTreeListRequest) (
+	*interfaces.NodeExecutionTreeList, error)
+type GetNodeExecutionDataBatchFunc func(ctx context.Context, request interfaces.NodeExecutionGetDataBatchRequest) (
+	*interfaces.NodeExecutionGetDataBatchResponse, error)
 
 type MockNodeExecutionManager struct {
 	createNodeEventFunc           CreateNodeEventFunc
@@ -22,6 +27,8 @@ type MockNodeExecutionManager struct {
 	listNodeExecutionsFunc        ListNodeExecutionsFunc
 	listNodeExecutionsForTaskFunc ListNodeExecutionsForTaskFunc
 	getNodeExecutionDataFunc      GetNodeExecutionDataFunc
+	getNodeExecutionDataBatchFunc GetNodeExecutionDataBatchFunc
+	listNodeExecutionTreeFunc     ListNodeExecutionTreeFunc
 }
 
 func (m *MockNodeExecutionManager) SetCreateNodeEventCallback(createNodeEventFunc CreateNodeEventFunc) {
@@ -73,6 +80,18 @@ func (m *MockNodeExecutionManager) ListNodeExecutionsForTask(
 	return nil, nil
 }
 
+func (m *MockNodeExecutionManager) SetListNodeExecutionTreeFunc(listNodeExecutionTreeFunc ListNodeExecutionTreeFunc) {
+	m.listNodeExecutionTreeFunc = listNodeExecutionTreeFunc
+}
+
+func (m *MockNodeExecutionManager) ListNodeExecutionTree(
+	ctx context.Context, request interfaces.NodeExecutionTreeListRequest) (*interfaces.NodeExecutionTreeList, error) {
+	if m.listNodeExecutionTreeFunc != nil {
+		return m.listNodeExecutionTreeFunc(ctx, request)
+	}
+	return nil, nil
+}
+
 func (m *MockNodeExecutionManager) SetGetNodeExecutionDataFunc(getNodeExecutionDataFunc GetNodeExecutionDataFunc) {
 	m.getNodeExecutionDataFunc = getNodeExecutionDataFunc
 }
@@ -84,3 +103,17 @@ func (m *MockNodeExecutionManager) GetNodeExecutionData(
 	}
 	return nil, nil
 }
+
+func (m *MockNodeExecutionManager) SetGetNodeExecutionDataBatchFunc(
+	getNodeExecutionDataBatchFunc GetNodeExecutionDataBatchFunc) {
+	m.getNodeExecutionDataBatchFunc = getNodeExecutionDataBatchFunc
+}
+
+func (m *MockNodeExecutionManager) GetNodeExecutionDataBatch(
+	ctx context.Context, request interfaces.NodeExecutionGetDataBatchRequest) (
+	*interfaces.NodeExecutionGetDataBatchResponse, error) {
+	if m.getNodeExecutionDataBatchFunc != nil {
+		return m.getNodeExecutionDataBatchFunc(ctx, request)
+	}
+	return nil, nil
+}
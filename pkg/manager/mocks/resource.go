@@ -14,16 +14,36 @@ type GetProjectDomainFunc func(ctx context.Context, request admin.ProjectDomainA
 	*admin.ProjectDomainAttributesGetResponse, error)
 type DeleteProjectDomainFunc func(ctx context.Context, request admin.ProjectDomainAttributesDeleteRequest) (
 	*admin.ProjectDomainAttributesDeleteResponse, error)
+type GetAllProjectDomainFunc func(ctx context.Context, request interfaces.ProjectDomainAttributesGetAllRequest) (
+	*interfaces.ProjectDomainAttributesGetAllResponse, error)
 type ListResourceFunc func(ctx context.Context, request admin.ListMatchableAttributesRequest) (
 	*admin.ListMatchableAttributesResponse, error)
 type GetResourceFunc func(ctx context.Context, request interfaces.ResourceRequest) (*interfaces.ResourceResponse, error)
+type UpdateScopedFunc func(ctx context.Context, request interfaces.ScopedAttributesUpdateRequest) error
+type GetScopedFunc func(ctx context.Context, request interfaces.ScopedAttributesGetRequest) (
+	*interfaces.ScopedAttributesGetResponse, error)
+type DeleteScopedFunc func(ctx context.Context, request interfaces.ScopedAttributesDeleteRequest) error
+type GetEffectiveFunc func(ctx context.Context, request interfaces.EffectiveAttributesRequest) (
+	*interfaces.EffectiveAttributesResponse, error)
+type UpdateQuotaFunc func(ctx context.Context, quota interfaces.ActiveExecutionQuota) error
+type GetQuotaFunc func(ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) (
+	*interfaces.ActiveExecutionQuota, error)
+type DeleteQuotaFunc func(ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) error
 
 type MockResourceManager struct {
 	updateProjectDomainFunc UpdateProjectDomainFunc
 	GetFunc                 GetProjectDomainFunc
+	GetAllFunc              GetAllProjectDomainFunc
 	DeleteFunc              DeleteProjectDomainFunc
 	ListFunc                ListResourceFunc
 	GetResourceFunc         GetResourceFunc
+	UpdateScopedFunc        UpdateScopedFunc
+	GetScopedFunc           GetScopedFunc
+	DeleteScopedFunc        DeleteScopedFunc
+	GetEffectiveFunc        GetEffectiveFunc
+	UpdateQuotaFunc         UpdateQuotaFunc
+	GetQuotaFunc            GetQuotaFunc
+	DeleteQuotaFunc         DeleteQuotaFunc
 }
 
 func (m *MockResourceManager) GetResource(ctx context.Context, request interfaces.ResourceRequest) (*interfaces.ResourceResponse, error) {
@@ -86,3 +106,68 @@ func (m *MockResourceManager) ListAll(ctx context.Context, request admin.ListMat
 	}
 	return nil, nil
 }
+
+func (m *MockResourceManager) GetAllProjectDomainAttributes(
+	ctx context.Context, request interfaces.ProjectDomainAttributesGetAllRequest) (
+	*interfaces.ProjectDomainAttributesGetAllResponse, error) {
+	if m.GetAllFunc != nil {
+		return m.GetAllFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockResourceManager) UpdateScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesUpdateRequest) error {
+	if m.UpdateScopedFunc != nil {
+		return m.UpdateScopedFunc(ctx, request)
+	}
+	return nil
+}
+
+func (m *MockResourceManager) GetScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesGetRequest) (*interfaces.ScopedAttributesGetResponse, error) {
+	if m.GetScopedFunc != nil {
+		return m.GetScopedFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockResourceManager) DeleteScopedAttributes(
+	ctx context.Context, request interfaces.ScopedAttributesDeleteRequest) error {
+	if m.DeleteScopedFunc != nil {
+		return m.DeleteScopedFunc(ctx, request)
+	}
+	return nil
+}
+
+func (m *MockResourceManager) GetEffectiveMatchableAttributes(
+	ctx context.Context, request interfaces.EffectiveAttributesRequest) (*interfaces.EffectiveAttributesResponse, error) {
+	if m.GetEffectiveFunc != nil {
+		return m.GetEffectiveFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockResourceManager) UpdateActiveExecutionQuota(
+	ctx context.Context, quota interfaces.ActiveExecutionQuota) error {
+	if m.UpdateQuotaFunc != nil {
+		return m.UpdateQuotaFunc(ctx, quota)
+	}
+	return nil
+}
+
+func (m *MockResourceManager) GetActiveExecutionQuota(
+	ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) (*interfaces.ActiveExecutionQuota, error) {
+	if m.GetQuotaFunc != nil {
+		return m.GetQuotaFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *MockResourceManager) DeleteActiveExecutionQuota(
+	ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) error {
+	if m.DeleteQuotaFunc != nil {
+		return m.DeleteQuotaFunc(ctx, request)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+type ListScheduleCheckpointsFunc func(ctx context.Context, request interfaces.ListScheduleCheckpointsRequest) (
+	*interfaces.ScheduleCheckpointList, error)
+type UpdateScheduleCheckpointFunc func(ctx context.Context, request interfaces.UpdateScheduleCheckpointRequest) (
+	*interfaces.ScheduleCheckpoint, error)
+
+type ScheduleCheckpointManager struct {
+	ListScheduleCheckpointsFunc  ListScheduleCheckpointsFunc
+	UpdateScheduleCheckpointFunc UpdateScheduleCheckpointFunc
+}
+
+func (m *ScheduleCheckpointManager) ListScheduleCheckpoints(ctx context.Context,
+	request interfaces.ListScheduleCheckpointsRequest) (*interfaces.ScheduleCheckpointList, error) {
+	if m.ListScheduleCheckpointsFunc != nil {
+		return m.ListScheduleCheckpointsFunc(ctx, request)
+	}
+	return nil, nil
+}
+
+func (m *ScheduleCheckpointManager) UpdateScheduleCheckpoint(ctx context.Context,
+	request interfaces.UpdateScheduleCheckpointRequest) (*interfaces.ScheduleCheckpoint, error) {
+	if m.UpdateScheduleCheckpointFunc != nil {
+		return m.UpdateScheduleCheckpointFunc(ctx, request)
+	}
+	return nil, nil
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+type GetExecutionStatisticsFunc func(ctx context.Context, request interfaces.ExecutionStatisticsRequest) (
+	*interfaces.ExecutionStatistics, error)
+
+type StatisticsManager struct {
+	GetExecutionStatisticsFunc GetExecutionStatisticsFunc
+}
+
+func (m *StatisticsManager) GetExecutionStatistics(ctx context.Context, request interfaces.ExecutionStatisticsRequest) (
+	*interfaces.ExecutionStatistics, error) {
+	if m.GetExecutionStatisticsFunc != nil {
+		return m.GetExecutionStatisticsFunc(ctx, request)
+	}
+	return nil, nil
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
 
@@ -35,6 +36,11 @@ func (r *MockTaskManager) ListTasks(ctx context.Context, request admin.ResourceL
 	return nil, nil
 }
 
+func (r *MockTaskManager) CountTasks(ctx context.Context, request interfaces.CountResourceRequest) (
+	*interfaces.CountResourceResponse, error) {
+	return nil, nil
+}
+
 func (r *MockTaskManager) SetListUniqueIdsFunc(fn ListUniqueIdsFunc) {
 	r.listUniqueIdsFunc = fn
 }
@@ -0,0 +1,190 @@
+// Token bucket rate limiting of gRPC requests keyed by method and caller.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const unknownCaller = "unknown"
+
+// Idle limiters are only swept once the number of tracked buckets exceeds this threshold.
+const sweepThreshold = 10000
+
+var readOnlyMethodPrefixes = []string{"Get", "List"}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type limiter struct {
+	cfg           config.RateLimitOptions
+	exemptMethods map[string]bool
+	throttled     *prometheus.CounterVec
+	now           func() time.Time
+
+	mutex    sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+func isReadOnly(method string) bool {
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *limiter) isExempt(fullMethod string) bool {
+	return l.exemptMethods[fullMethod] || l.exemptMethods[common.ShortMethodName(fullMethod)]
+}
+
+// A bucket which holds no tokens rejects every request, so specs which leave the burst unset allow bursts of one
+// second's worth of requests instead.
+func withDefaultBurst(spec config.RateLimitSpec) config.RateLimitSpec {
+	if spec.Burst <= 0 {
+		spec.Burst = int(math.Max(1, math.Ceil(spec.RequestsPerSecond)))
+	}
+	return spec
+}
+
+func (l *limiter) specForMethod(fullMethod string) config.RateLimitSpec {
+	if spec, ok := l.cfg.Methods[fullMethod]; ok {
+		return withDefaultBurst(spec)
+	}
+	method := common.ShortMethodName(fullMethod)
+	if spec, ok := l.cfg.Methods[method]; ok {
+		return withDefaultBurst(spec)
+	}
+	if isReadOnly(method) {
+		return withDefaultBurst(l.cfg.Read)
+	}
+	return withDefaultBurst(l.cfg.Write)
+}
+
+// Identifies the caller by the authenticated user or app and falls back to the peer address when auth is disabled.
+func callerFromContext(ctx context.Context) string {
+	identityContext := auth.IdentityContextFromContext(ctx)
+	if len(identityContext.UserID()) > 0 {
+		return identityContext.UserID()
+	}
+	if len(identityContext.AppID()) > 0 {
+		return identityContext.AppID()
+	}
+	if peerInfo, ok := peer.FromContext(ctx); ok && peerInfo.Addr != nil {
+		host, _, err := net.SplitHostPort(peerInfo.Addr.String())
+		if err != nil {
+			return peerInfo.Addr.String()
+		}
+		return host
+	}
+	return unknownCaller
+}
+
+// Drops limiters which have been idle long enough for their bucket to refill completely. These are equivalent to a
+// freshly created limiter and so can be safely recreated on demand.
+func (l *limiter) sweep(now time.Time) {
+	for key, entry := range l.limiters {
+		refill := time.Duration(float64(entry.limiter.Burst()) / float64(entry.limiter.Limit()) * float64(time.Second))
+		if now.Sub(entry.lastSeen) > refill {
+			delete(l.limiters, key)
+		}
+	}
+}
+
+func (l *limiter) getLimiter(key string, spec config.RateLimitSpec, now time.Time) *rate.Limiter {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	entry, ok := l.limiters[key]
+	if !ok {
+		if len(l.limiters) >= sweepThreshold {
+			l.sweep(now)
+		}
+		entry = &limiterEntry{
+			limiter: rate.NewLimiter(rate.Limit(spec.RequestsPerSecond), spec.Burst),
+		}
+		l.limiters[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+func (l *limiter) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	if l.isExempt(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	spec := l.specForMethod(info.FullMethod)
+	if spec.RequestsPerSecond <= 0 {
+		// A non-positive rate disables limiting for the method.
+		return handler(ctx, req)
+	}
+
+	now := l.now()
+	caller := callerFromContext(ctx)
+	reservation := l.getLimiter(info.FullMethod+"|"+caller, spec, now).ReserveN(now, 1)
+	if !reservation.OK() {
+		l.throttled.WithLabelValues(common.ShortMethodName(info.FullMethod)).Inc()
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		l.throttled.WithLabelValues(common.ShortMethodName(info.FullMethod)).Inc()
+		logger.Debugf(ctx, "Throttling request to [%s] from caller [%s]", info.FullMethod, caller)
+		return nil, resourceExhaustedError(info.FullMethod, delay)
+	}
+	return handler(ctx, req)
+}
+
+func resourceExhaustedError(fullMethod string, retryAfter time.Duration) error {
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %s, retry after %v", fullMethod, retryAfter)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: ptypes.DurationProto(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func newLimiter(cfg config.RateLimitOptions, scope promutils.Scope) *limiter {
+	exemptMethods := make(map[string]bool, len(cfg.ExemptMethods))
+	for _, method := range cfg.ExemptMethods {
+		exemptMethods[method] = true
+	}
+	return &limiter{
+		cfg:           cfg,
+		exemptMethods: exemptMethods,
+		throttled: scope.MustNewCounterVec("throttled_requests",
+			"requests rejected because the caller exceeded its rate limit", "method"),
+		now:      time.Now,
+		limiters: make(map[string]*limiterEntry),
+	}
+}
+
+// NewUnaryServerInterceptor returns an interceptor which rejects requests with ResourceExhausted once a caller has
+// exhausted its token bucket for a method. The returned status carries a RetryInfo detail with the time until a
+// token becomes available.
+func NewUnaryServerInterceptor(cfg config.RateLimitOptions, scope promutils.Scope) grpc.UnaryServerInterceptor {
+	return newLimiter(cfg, scope).intercept
+}
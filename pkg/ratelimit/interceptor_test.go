@@ -0,0 +1,153 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const listExecutionsMethod = "/flyteidl.service.AdminService/ListExecutions"
+const createExecutionMethod = "/flyteidl.service.AdminService/CreateExecution"
+const healthCheckMethod = "/grpc.health.v1.Health/Check"
+
+var okHandler = func(ctx context.Context, req interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func getTestLimiter() *limiter {
+	l := newLimiter(config.RateLimitOptions{
+		Read: config.RateLimitSpec{
+			RequestsPerSecond: 1,
+			Burst:             3,
+		},
+		Write: config.RateLimitSpec{
+			RequestsPerSecond: 1,
+			Burst:             1,
+		},
+		ExemptMethods: []string{healthCheckMethod},
+	}, promutils.NewTestScope())
+	now := time.Date(2020, time.January, 5, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time {
+		return now
+	}
+	return l
+}
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 12345},
+	})
+}
+
+func call(l *limiter, ctx context.Context, method string) error {
+	_, err := l.intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, okHandler)
+	return err
+}
+
+func TestIntercept_Burst(t *testing.T) {
+	l := getTestLimiter()
+	ctx := peerContext("192.0.2.1")
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, call(l, ctx, listExecutionsMethod))
+	}
+	err := call(l, ctx, listExecutionsMethod)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	retryDelay, convErr := ptypes.Duration(details[0].(*errdetails.RetryInfo).RetryDelay)
+	assert.NoError(t, convErr)
+	assert.Equal(t, time.Second, retryDelay)
+	assert.Equal(t, float64(1), testutil.ToFloat64(l.throttled.WithLabelValues("ListExecutions")))
+
+	// Once a token is replenished the caller may proceed again.
+	now := l.now().Add(time.Second)
+	l.now = func() time.Time {
+		return now
+	}
+	assert.NoError(t, call(l, ctx, listExecutionsMethod))
+}
+
+func TestIntercept_ReadWriteLimits(t *testing.T) {
+	l := getTestLimiter()
+	ctx := peerContext("192.0.2.1")
+	assert.NoError(t, call(l, ctx, createExecutionMethod))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(l, ctx, createExecutionMethod)))
+	// Reads are tracked in a separate bucket.
+	assert.NoError(t, call(l, ctx, listExecutionsMethod))
+}
+
+func TestIntercept_PerCaller(t *testing.T) {
+	l := getTestLimiter()
+	assert.NoError(t, call(l, peerContext("192.0.2.1"), createExecutionMethod))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(l, peerContext("192.0.2.1"), createExecutionMethod)))
+	assert.NoError(t, call(l, peerContext("192.0.2.2"), createExecutionMethod))
+
+	identityCtx := auth.NewIdentityContext("", "user", "", time.Time{}, sets.NewString(), nil).WithContext(
+		peerContext("192.0.2.1"))
+	assert.NoError(t, call(l, identityCtx, createExecutionMethod))
+}
+
+func TestIntercept_Exempt(t *testing.T) {
+	l := getTestLimiter()
+	ctx := peerContext("192.0.2.1")
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, call(l, ctx, healthCheckMethod))
+	}
+}
+
+func TestIntercept_MethodOverride(t *testing.T) {
+	l := getTestLimiter()
+	l.cfg.Methods = map[string]config.RateLimitSpec{
+		"ListExecutions": {
+			RequestsPerSecond: 1,
+			Burst:             1,
+		},
+		"CreateExecution": {},
+	}
+	ctx := peerContext("192.0.2.1")
+	assert.NoError(t, call(l, ctx, listExecutionsMethod))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(l, ctx, listExecutionsMethod)))
+	// A zero rate disables limiting for the method.
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, call(l, ctx, createExecutionMethod))
+	}
+}
+
+func TestIntercept_DefaultBurst(t *testing.T) {
+	l := getTestLimiter()
+	l.cfg.Methods = map[string]config.RateLimitSpec{
+		"ListExecutions": {
+			RequestsPerSecond: 2.5,
+		},
+	}
+	ctx := peerContext("192.0.2.1")
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, call(l, ctx, listExecutionsMethod))
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(l, ctx, listExecutionsMethod)))
+
+	l.cfg.Write.Burst = 0
+	assert.NoError(t, call(l, ctx, createExecutionMethod))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call(l, ctx, createExecutionMethod)))
+}
+
+func TestCallerFromContext(t *testing.T) {
+	assert.Equal(t, unknownCaller, callerFromContext(context.Background()))
+	assert.Equal(t, "192.0.2.1", callerFromContext(peerContext("192.0.2.1")))
+	assert.Equal(t, "app", callerFromContext(auth.NewIdentityContext("", "", "app", time.Time{}, sets.NewString(),
+		nil).WithContext(context.Background())))
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 
 	gormLogger "gorm.io/gorm/logger"
@@ -9,12 +11,25 @@ import (
 // Database config. Contains values necessary to open a database connection.
 type DbConfig struct {
 	BaseConfig
+	// Either Postgres, MySQL or SQLite, Postgres when empty.
+	Dialect      string `json:"dialect"`
 	Host         string `json:"host"`
 	Port         int    `json:"port"`
 	DbName       string `json:"dbname"`
 	User         string `json:"user"`
 	Password     string `json:"password"`
 	ExtraOptions string `json:"options"`
+	// Limits the connections kept to the database.
+	ConnectionPool interfaces.DbConnectionPoolConfig `json:"connectionPool"`
+	// An optional replica which read only queries are sent to.
+	ReadReplica interfaces.DbReadReplicaConfig `json:"readReplica"`
+	// Session limits on how long statements run and wait for locks, unlimited when zero.
+	StatementTimeout time.Duration `json:"statementTimeout"`
+	LockTimeout      time.Duration `json:"lockTimeout"`
+	// How often the stats of the connection pools are emitted, never when zero.
+	PoolStatsInterval time.Duration `json:"poolStatsInterval"`
+	// The database file used by the SQLite dialect.
+	SQLite interfaces.DbSQLiteConfig `json:"sqlite"`
 }
 
 func NewDbConfig(dbConfigValues interfaces.DbConfig) DbConfig {
@@ -26,11 +41,18 @@ func NewDbConfig(dbConfigValues interfaces.DbConfig) DbConfig {
 		BaseConfig: BaseConfig{
 			LogLevel: dbLogLevel,
 		},
-		Host:         dbConfigValues.Host,
-		Port:         dbConfigValues.Port,
-		DbName:       dbConfigValues.DbName,
-		User:         dbConfigValues.User,
-		Password:     dbConfigValues.Password,
-		ExtraOptions: dbConfigValues.ExtraOptions,
+		Dialect:           dbConfigValues.Dialect,
+		Host:              dbConfigValues.Host,
+		Port:              dbConfigValues.Port,
+		DbName:            dbConfigValues.DbName,
+		User:              dbConfigValues.User,
+		Password:          dbConfigValues.Password,
+		ExtraOptions:      dbConfigValues.ExtraOptions,
+		ConnectionPool:    dbConfigValues.ConnectionPool,
+		ReadReplica:       dbConfigValues.ReadReplica,
+		StatementTimeout:  dbConfigValues.StatementTimeout,
+		LockTimeout:       dbConfigValues.LockTimeout,
+		PoolStatsInterval: dbConfigValues.PoolStatsInterval,
+		SQLite:            dbConfigValues.SQLite,
 	}
 }
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	schedulerModels "github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
 	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"github.com/golang/protobuf/proto"
 	"gorm.io/gorm"
 )
 
@@ -117,7 +124,7 @@ var Migrations = []*gormigrate.Migration{
 			return tx.AutoMigrate(&models.Execution{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE executions DROP COLUMN IF EXISTS cluster").Error
+			return dropColumnIfExists(tx, &models.Execution{}, "cluster")
 		},
 	},
 	// Update projects table to add description column
@@ -127,7 +134,7 @@ var Migrations = []*gormigrate.Migration{
 			return tx.AutoMigrate(&models.Project{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE projects DROP COLUMN IF EXISTS description").Error
+			return dropColumnIfExists(tx, &models.Project{}, "description")
 		},
 	},
 	// Add offloaded URIs to table
@@ -137,7 +144,10 @@ var Migrations = []*gormigrate.Migration{
 			return tx.AutoMigrate(&models.Execution{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE executions DROP COLUMN IF EXISTS InputsURI, DROP COLUMN IF EXISTS UserInputsURI").Error
+			if err := dropColumnIfExists(tx, &models.Execution{}, "InputsURI"); err != nil {
+				return err
+			}
+			return dropColumnIfExists(tx, &models.Execution{}, "UserInputsURI")
 		},
 	},
 	// Create named_entity_metadata table.
@@ -167,7 +177,7 @@ var Migrations = []*gormigrate.Migration{
 			return tx.AutoMigrate(&models.Task{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE tasks DROP COLUMN IF EXISTS type").Error
+			return dropColumnIfExists(tx, &models.Task{}, "type")
 		},
 	},
 	// Add state to name entity model
@@ -194,10 +204,13 @@ var Migrations = []*gormigrate.Migration{
 	{
 		ID: "2020-04-03-workflow-state",
 		Migrate: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE workflows DROP COLUMN IF EXISTS state").Error
+			return dropColumnIfExists(tx, &models.Workflow{}, "state")
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE workflows ADD COLUMN IF NOT EXISTS state integer;").Error
+			if tx.Migrator().HasColumn(&models.Workflow{}, "state") {
+				return nil
+			}
+			return tx.Exec("ALTER TABLE workflows ADD COLUMN state integer").Error
 		},
 	},
 	// Modify the executions & node_execution table, if necessary
@@ -229,7 +242,7 @@ var Migrations = []*gormigrate.Migration{
 			return tx.AutoMigrate(&models.Execution{})
 		},
 		Rollback: func(tx *gorm.DB) error {
-			return tx.Exec("ALTER TABLE executions DROP COLUMN IF EXISTS task_id").Error
+			return dropColumnIfExists(tx, &models.Execution{}, "task_id")
 		},
 	},
 
@@ -329,4 +342,416 @@ var Migrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable(&schedulerModels.ScheduleEntitiesSnapshot{}, "schedulable_entities_snapshot")
 		},
 	},
+
+	{
+		ID: "2021-10-20-schedulable_entities_catch_up_policy",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&schedulerModels.SchedulableEntity{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Model(&schedulerModels.SchedulableEntity{}).Migrator().DropColumn(
+				&schedulerModels.SchedulableEntity{}, "catch_up_policy")
+		},
+	},
+
+	{
+		ID: "2021-10-22-schedule-overlap-policy",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&models.LaunchPlan{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&models.Execution{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Execution{}).Migrator().DropColumn(
+				&models.Execution{}, "active_scheduled_launch_plan_id"); err != nil {
+				return err
+			}
+			return tx.Model(&models.LaunchPlan{}).Migrator().DropColumn(&models.LaunchPlan{}, "schedule_overlap_policy")
+		},
+	},
+
+	{
+		ID: "2021-10-25-launch-plan-activated-at",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.LaunchPlan{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Model(&models.LaunchPlan{}).Migrator().DropColumn(&models.LaunchPlan{}, "activated_at")
+		},
+	},
+
+	{
+		ID: "2021-10-27-description-entities",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.DescriptionEntity{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("description_entities")
+		},
+	},
+
+	{
+		ID: "2021-11-02-execution-tags",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.ExecutionTag{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("execution_tags")
+		},
+	},
+
+	{
+		ID: "2021-11-05-execution-error-message",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.Execution{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Model(&models.Execution{}).Migrator().DropColumn(&models.Execution{}, "error_message")
+		},
+	},
+
+	// Keyset paginated lists seek these indexes rather than scanning past an offset.
+	{
+		ID: "2021-11-08-keyset-pagination-indexes",
+		Migrate: func(tx *gorm.DB) error {
+			for _, table := range []string{"executions", "node_executions", "task_executions"} {
+				if err := createIndexIfNotExists(tx, table, fmt.Sprintf("idx_%s_created_at_id", table),
+					"created_at, id"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, table := range []string{"executions", "node_executions", "task_executions"} {
+				if err := dropIndexIfExists(tx, table, fmt.Sprintf("idx_%s_created_at_id", table)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+
+	// Searching entity names by substring seeks trigram indexes rather than scanning the tables. Without the privilege
+	// to create the pg_trgm extension, and on other databases, names are indexed for prefix matches only.
+	{
+		ID: "2021-11-10-named-entity-search-indexes",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Dialector.Name() != Postgres {
+				for _, table := range []string{"workflows", "tasks", "launch_plans"} {
+					if err := createIndexIfNotExists(tx, table, fmt.Sprintf("idx_%s_lower_name", table),
+						"(LOWER(name))"); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			indexFmt := "CREATE INDEX IF NOT EXISTS idx_%[1]s_lower_name_trgm ON %[1]s USING gin (LOWER(name) gin_trgm_ops)"
+			if err := tx.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+				logger.Warningf(context.Background(),
+					"Failed to create the pg_trgm extension, indexing entity names for prefix search only: %v", err)
+				indexFmt = "CREATE INDEX IF NOT EXISTS idx_%[1]s_lower_name ON %[1]s (LOWER(name) text_pattern_ops)"
+			}
+			for _, table := range []string{"workflows", "tasks", "launch_plans"} {
+				if err := tx.Exec(fmt.Sprintf(indexFmt, table)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, table := range []string{"workflows", "tasks", "launch_plans"} {
+				if err := dropIndexIfExists(tx, table, fmt.Sprintf("idx_%s_lower_name_trgm", table)); err != nil {
+					return err
+				}
+				if err := dropIndexIfExists(tx, table, fmt.Sprintf("idx_%s_lower_name", table)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	// Resources are upserted, which relies on the columns identifying them being unique together. Duplicates, which
+	// concurrent updates could insert until now, are deleted first, keeping the oldest one since reads returned it.
+	{
+		ID: "2021-11-15-resources-unique-index",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec("DELETE FROM resources WHERE id NOT IN (SELECT id FROM (SELECT MIN(id) AS id " +
+				"FROM resources GROUP BY project, domain, workflow, launch_plan, resource_type) AS oldest)").Error; err != nil {
+				return err
+			}
+			return createUniqueIndexIfNotExists(tx, "resources", "resource_idx",
+				"project, domain, workflow, launch_plan, resource_type")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropIndexIfExists(tx, "resources", "resource_idx")
+		},
+	},
+
+	// Attributes can be set for all the projects of an org, which identifies them along with the other columns.
+	{
+		ID: "2021-11-17-resources-org",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&models.Resource{}, "org") {
+				if err := tx.Migrator().AddColumn(&models.Resource{}, "Org"); err != nil {
+					return err
+				}
+			}
+			if err := dropIndexIfExists(tx, "resources", "resource_idx"); err != nil {
+				return err
+			}
+			return createUniqueIndexIfNotExists(tx, "resources", "resource_idx",
+				"project, domain, workflow, launch_plan, resource_type, org")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// Attributes of orgs, and of projects in all their domains, are meaningless to older versions.
+			if err := tx.Exec("DELETE FROM resources WHERE org <> '' OR domain = ''").Error; err != nil {
+				return err
+			}
+			if err := dropIndexIfExists(tx, "resources", "resource_idx"); err != nil {
+				return err
+			}
+			if err := dropColumnIfExists(tx, &models.Resource{}, "org"); err != nil {
+				return err
+			}
+			return createUniqueIndexIfNotExists(tx, "resources", "resource_idx",
+				"project, domain, workflow, launch_plan, resource_type")
+		},
+	},
+
+	// Executions record the execution queue their tasks were assigned to.
+	{
+		ID: "2021-11-19-execution-queue",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&models.Execution{}, "queue") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&models.Execution{}, "Queue")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &models.Execution{}, "queue")
+		},
+	},
+
+	// Counting the active executions of a project and domain, to enforce their quota, seeks this index.
+	{
+		ID: "2021-11-20-executions-project-domain-phase-index",
+		Migrate: func(tx *gorm.DB) error {
+			return createIndexIfNotExists(tx, "executions", "idx_executions_project_domain_phase",
+				"execution_project, execution_domain, phase")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropIndexIfExists(tx, "executions", "idx_executions_project_domain_phase")
+		},
+	},
+
+	// Workflows record the digest of their spec, which re-registrations are compared to before compiling them.
+	{
+		ID: "2021-11-21-workflows-spec-digest",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&models.Workflow{}, "spec_digest") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&models.Workflow{}, "SpecDigest")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &models.Workflow{}, "spec_digest")
+		},
+	},
+
+	// At most one version of a launch plan is active. Versions which concurrent activations left active until now are
+	// deactivated first, keeping the latest one active. MySQL doesn't support partial indexes, there activations rely
+	// on the rows they lock alone.
+	{
+		ID: "2021-11-22-launch-plans-single-active-version",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec("UPDATE launch_plans SET state = ? WHERE state = ? AND id NOT IN (SELECT id FROM "+
+				"(SELECT MAX(id) AS id FROM launch_plans WHERE state = ? GROUP BY project, domain, name) AS latest)",
+				int32(admin.LaunchPlanState_INACTIVE), int32(admin.LaunchPlanState_ACTIVE),
+				int32(admin.LaunchPlanState_ACTIVE)).Error; err != nil {
+				return err
+			}
+			if tx.Dialector.Name() == MySQL {
+				return nil
+			}
+			return tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS idx_launch_plans_active_version "+
+				"ON launch_plans (project, domain, name) WHERE state = %d", admin.LaunchPlanState_ACTIVE)).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropIndexIfExists(tx, "launch_plans", "idx_launch_plans_active_version")
+		},
+	},
+
+	// Schedules are delayed past their nominal times by their jitter and offset.
+	{
+		ID: "2021-11-23-schedulable-entities-schedule-offset",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&schedulerModels.SchedulableEntity{}, "schedule_offset") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&schedulerModels.SchedulableEntity{}, "ScheduleOffset")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &schedulerModels.SchedulableEntity{}, "schedule_offset")
+		},
+	},
+
+	// Scheduler replicas claim the invocations they fire, and catch up from the last fired on restart.
+	{
+		ID: "2021-11-24-schedule-firings",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&schedulerModels.ScheduleFiring{}); err != nil {
+				return err
+			}
+			// Claims are pruned by their scheduled time.
+			return createIndexIfNotExists(tx, "schedule_firings", "idx_schedule_firings_scheduled_at", "scheduled_at")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("schedule_firings")
+		},
+	},
+
+	// Scheduled launch plans are checkpointed as their executions are created.
+	{
+		ID: "2021-11-25-schedule-checkpoints",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.ScheduleCheckpoint{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("schedule_checkpoints")
+		},
+	},
+
+	// Executions record the max parallelism they were launched with.
+	{
+		ID: "2021-11-26-execution-max-parallelism",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&models.Execution{}, "max_parallelism") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&models.Execution{}, "MaxParallelism")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &models.Execution{}, "max_parallelism")
+		},
+	},
+
+	// Launch plans on a CronSchedule were recorded without a schedule type until they were listed by it, and those
+	// registered before the column was added have none at all.
+	{
+		ID: "2021-11-27-launch-plans-schedule-type-backfill",
+		Migrate: func(tx *gorm.DB) error {
+			var launchPlans []models.LaunchPlan
+			return tx.Select("id", "spec", "schedule_type").
+				Where("schedule_type IS NULL OR schedule_type IN ?", []models.LaunchPlanScheduleType{
+					"", models.LaunchPlanScheduleTypeNONE}).
+				FindInBatches(&launchPlans, 100, func(batch *gorm.DB, _ int) error {
+					for _, launchPlan := range launchPlans {
+						var spec admin.LaunchPlanSpec
+						if err := proto.Unmarshal(launchPlan.Spec, &spec); err != nil {
+							return fmt.Errorf("failed to unmarshal the spec of launch plan %d: %w", launchPlan.ID, err)
+						}
+						scheduleType := models.GetLaunchPlanScheduleType(&spec)
+						if scheduleType == launchPlan.ScheduleType {
+							continue
+						}
+						if err := tx.Model(&models.LaunchPlan{}).Where("id = ?", launchPlan.ID).
+							UpdateColumn("schedule_type", scheduleType).Error; err != nil {
+							return err
+						}
+					}
+					return nil
+				}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The backfilled types are what the launch plans would be registered with now, there's nothing to undo.
+			return nil
+		},
+	},
+
+	// Executions record the namespace their workflow was created in, which they're terminated in.
+	{
+		ID: "2021-11-28-execution-namespace",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&models.Execution{}, "namespace") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&models.Execution{}, "Namespace")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &models.Execution{}, "namespace")
+		},
+	},
+
+	// Error messages are only matched on case insensitive substrings, which their index can't serve.
+	{
+		ID: "2021-11-29-drop-execution-error-message-index",
+		Migrate: func(tx *gorm.DB) error {
+			return dropIndexIfExists(tx, "executions", "idx_executions_error_message")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return createIndexIfNotExists(tx, "executions", "idx_executions_error_message", "error_message")
+		},
+	},
+
+	// Jobs which only one replica runs at a time, such as the execution retention job, are leased.
+	{
+		ID: "2021-11-30-job-leases",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&models.JobLease{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("job_leases")
+		},
+	},
+
+	// Executions queued to be launched in the background record when admin starts to create their workflow, so that
+	// those it never launched can be told apart from those propeller has yet to report on.
+	{
+		ID: "2021-12-01-execution-launch-attempted",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&models.Execution{}, "launch_attempted") {
+				if err := tx.Migrator().AddColumn(&models.Execution{}, "LaunchAttempted"); err != nil {
+					return err
+				}
+			}
+			// Executions queued before the column existed may have been launched, so they're taken to have been.
+			return tx.Model(&models.Execution{}).Where("phase = ?", core.WorkflowExecution_QUEUED.String()).
+				UpdateColumn("launch_attempted", true).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return dropColumnIfExists(tx, &models.Execution{}, "launch_attempted")
+		},
+	},
+}
+
+// The helpers below stand in for IF [NOT] EXISTS clauses, which MySQL doesn't support for columns and indexes.
+
+func dropColumnIfExists(tx *gorm.DB, model interface{}, column string) error {
+	if !tx.Migrator().HasColumn(model, column) {
+		return nil
+	}
+	return tx.Migrator().DropColumn(model, column)
+}
+
+func createIndexIfNotExists(tx *gorm.DB, table, index, columns string) error {
+	if tx.Migrator().HasIndex(table, index) {
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("CREATE INDEX %s ON %s (%s)", index, table, columns)).Error
+}
+
+func createUniqueIndexIfNotExists(tx *gorm.DB, table, index, columns string) error {
+	if tx.Migrator().HasIndex(table, index) {
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", index, table, columns)).Error
+}
+
+func dropIndexIfExists(tx *gorm.DB, table, index string) error {
+	if !tx.Migrator().HasIndex(table, index) {
+		return nil
+	}
+	return tx.Migrator().DropIndex(table, index)
 }
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// Describes which of the registered migrations a database applied.
+type MigrationStatus struct {
+	// The last registered migration the database applied, empty when it applied none.
+	Version string `json:"version"`
+	// The registered migrations the database applied and those it didn't, in the order they're registered in.
+	Applied []string `json:"applied"`
+	Pending []string `json:"pending"`
+	// Migrations the database applied which aren't registered, presumably by a newer flyteadmin.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// Migrator applies and rolls back registered migrations, refusing to do either against a database which a newer
+// flyteadmin migrated.
+type Migrator struct {
+	db         *gorm.DB
+	options    *gormigrate.Options
+	migrations []*gormigrate.Migration
+}
+
+// Returns the status of the registered migrations in the database.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	status := MigrationStatus{}
+	appliedIDs := make(map[string]bool)
+	if m.db.Migrator().HasTable(m.options.TableName) {
+		var ids []string
+		if err := m.db.Table(m.options.TableName).Pluck(m.options.IDColumnName, &ids).Error; err != nil {
+			return MigrationStatus{}, err
+		}
+		for _, id := range ids {
+			appliedIDs[id] = true
+		}
+	}
+	for _, migration := range m.migrations {
+		if appliedIDs[migration.ID] {
+			status.Applied = append(status.Applied, migration.ID)
+			status.Version = migration.ID
+			delete(appliedIDs, migration.ID)
+		} else {
+			status.Pending = append(status.Pending, migration.ID)
+		}
+	}
+	for id := range appliedIDs {
+		status.Unknown = append(status.Unknown, id)
+	}
+	sort.Strings(status.Unknown)
+	return status, nil
+}
+
+// Returns the status of the database, or an error when a newer flyteadmin migrated it.
+func (m *Migrator) getKnownStatus() (MigrationStatus, error) {
+	status, err := m.Status()
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	if len(status.Unknown) > 0 {
+		return MigrationStatus{}, fmt.Errorf(
+			"the database applied migrations %v unknown to this version of flyteadmin, which is older than the one "+
+				"that migrated it", status.Unknown)
+	}
+	return status, nil
+}
+
+// Applies the pending migrations and returns them.
+func (m *Migrator) Migrate() ([]string, error) {
+	status, err := m.getKnownStatus()
+	if err != nil {
+		return nil, err
+	}
+	if len(status.Pending) == 0 {
+		return nil, nil
+	}
+	if err := gormigrate.New(m.db, m.options, m.migrations).Migrate(); err != nil {
+		return nil, err
+	}
+	return status.Pending, nil
+}
+
+// Rolls back the last steps applied migrations, latest first, and returns those rolled back. Migrations which can't be
+// rolled back, such as backfills, stop the rollback.
+func (m *Migrator) Rollback(steps int) ([]string, error) {
+	status, err := m.getKnownStatus()
+	if err != nil {
+		return nil, err
+	}
+	if steps <= 0 || steps > len(status.Applied) {
+		return nil, fmt.Errorf("cannot roll back %d migrations, %d are applied", steps, len(status.Applied))
+	}
+	migrationsByID := make(map[string]*gormigrate.Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrationsByID[migration.ID] = migration
+	}
+	gormigrator := gormigrate.New(m.db, m.options, m.migrations)
+	var rolledBack []string
+	for i := len(status.Applied) - 1; i >= len(status.Applied)-steps; i-- {
+		id := status.Applied[i]
+		if err := gormigrator.RollbackMigration(migrationsByID[id]); err != nil {
+			return rolledBack, fmt.Errorf("failed to roll back migration [%s]: %w", id, err)
+		}
+		rolledBack = append(rolledBack, id)
+	}
+	return rolledBack, nil
+}
+
+func NewMigrator(db *gorm.DB, migrations []*gormigrate.Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		options:    gormigrate.DefaultOptions,
+		migrations: migrations,
+	}
+}
@@ -0,0 +1,423 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	gormigrate "github.com/go-gormigrate/gormigrate/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func getSQLiteDBForMigrationTest(t *testing.T) *gorm.DB {
+	db, err := OpenDbConnection(NewSQLiteConfigProvider(DbConfig{
+		BaseConfig: BaseConfig{
+			DisableForeignKeyConstraintWhenMigrating: true,
+		},
+		SQLite: interfaces.DbSQLiteConfig{
+			File: filepath.Join(t.TempDir(), "flyteadmin.db"),
+		},
+	}, mockScope.NewTestScope()))
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+	return db
+}
+
+func getMigrationIDs(migrations []*gormigrate.Migration) []string {
+	ids := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		ids = append(ids, migration.ID)
+	}
+	return ids
+}
+
+func TestMigrations_UniqueIDs(t *testing.T) {
+	seen := make(map[string]bool, len(Migrations))
+	for _, id := range getMigrationIDs(Migrations) {
+		assert.NotEmpty(t, id)
+		assert.False(t, seen[id], "migration [%s] is registered twice", id)
+		seen[id] = true
+	}
+}
+
+func TestMigrator_MigrateAndRollback(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	migrator := NewMigrator(db, Migrations)
+	ids := getMigrationIDs(Migrations)
+	last := ids[len(ids)-1]
+
+	status, err := migrator.Status()
+	assert.NoError(t, err)
+	assert.Empty(t, status.Version)
+	assert.Empty(t, status.Applied)
+	assert.Equal(t, ids, status.Pending)
+
+	applied, err := migrator.Migrate()
+	assert.NoError(t, err)
+	assert.Equal(t, ids, applied)
+	status, err = migrator.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, last, status.Version)
+	assert.Empty(t, status.Pending)
+
+	// Migrating again is a no-op.
+	applied, err = migrator.Migrate()
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	rolledBack, err := migrator.Rollback(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ids[len(ids)-1], ids[len(ids)-2], ids[len(ids)-3]}, rolledBack)
+	status, err = migrator.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ids[len(ids)-4], status.Version)
+	assert.Equal(t, ids[len(ids)-3:], status.Pending)
+
+	applied, err = migrator.Migrate()
+	assert.NoError(t, err)
+	assert.Equal(t, ids[len(ids)-3:], applied)
+
+	_, err = migrator.Rollback(len(ids) + 1)
+	assert.EqualError(t, err, fmt.Sprintf("cannot roll back %d migrations, %d are applied", len(ids)+1, len(ids)))
+	_, err = migrator.Rollback(0)
+	assert.Error(t, err)
+}
+
+func TestMigrator_RollbackRevertsMigrations(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	migrator := NewMigrator(db, []*gormigrate.Migration{
+		{
+			ID: "projects",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&models.Project{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("projects")
+			},
+		},
+		{
+			ID: "tasks",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&models.Task{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("tasks")
+			},
+		},
+	})
+	_, err := migrator.Migrate()
+	assert.NoError(t, err)
+	assert.True(t, db.Migrator().HasTable("tasks"))
+
+	rolledBack, err := migrator.Rollback(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tasks"}, rolledBack)
+	assert.False(t, db.Migrator().HasTable("tasks"))
+	assert.True(t, db.Migrator().HasTable("projects"))
+}
+
+func TestMigrator_RollbackImpossible(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	migrator := NewMigrator(db, []*gormigrate.Migration{
+		{
+			ID: "first",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&models.Project{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable("projects")
+			},
+		},
+		{
+			ID: "backfill",
+			Migrate: func(tx *gorm.DB) error {
+				return nil
+			},
+		},
+		{
+			ID: "last",
+			Migrate: func(tx *gorm.DB) error {
+				return nil
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return nil
+			},
+		},
+	})
+	_, err := migrator.Migrate()
+	assert.NoError(t, err)
+
+	rolledBack, err := migrator.Rollback(3)
+	assert.EqualError(t, err, "failed to roll back migration [backfill]: "+gormigrate.ErrRollbackImpossible.Error())
+	assert.Equal(t, []string{"last"}, rolledBack)
+	status, err := migrator.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, "backfill", status.Version)
+}
+
+func TestMigrator_NewerDatabase(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	migrator := NewMigrator(db, Migrations)
+	_, err := migrator.Migrate()
+	assert.NoError(t, err)
+	// As a newer flyteadmin would have recorded a migration of its own.
+	assert.NoError(t, db.Exec("INSERT INTO migrations (id) VALUES (?)", "2099-01-01-from-the-future").Error)
+
+	status, err := migrator.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2099-01-01-from-the-future"}, status.Unknown)
+
+	expected := "the database applied migrations [2099-01-01-from-the-future] unknown to this version of " +
+		"flyteadmin, which is older than the one that migrated it"
+	_, err = migrator.Migrate()
+	assert.EqualError(t, err, expected)
+	_, err = migrator.Rollback(1)
+	assert.EqualError(t, err, expected)
+}
+
+func TestSeedProjects(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	_, err := NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+
+	created, err := SeedProjects(db, []string{"flytesnacks", "flytetester"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"flytesnacks", "flytetester"}, created)
+
+	// Seeding is idempotent.
+	created, err = SeedProjects(db, []string{"flytesnacks", "examples"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"examples"}, created)
+
+	var projects []models.Project
+	assert.NoError(t, db.Order("identifier").Find(&projects).Error)
+	assert.Len(t, projects, 3)
+	assert.Equal(t, "examples", projects[0].Identifier)
+	assert.Equal(t, "examples description", projects[0].Description)
+}
+
+func TestMigrations_ResourcesUniqueIndex(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	ids := getMigrationIDs(Migrations)
+	var index int
+	for i, id := range ids {
+		if id == "2021-11-15-resources-unique-index" {
+			index = i
+		}
+	}
+	_, err := NewMigrator(db, Migrations[:index]).Migrate()
+	assert.NoError(t, err)
+	// Older databases could hold duplicate resources, which the index didn't prevent.
+	assert.NoError(t, dropIndexIfExists(db, "resources", "resource_idx"))
+	for _, attributes := range []string{"oldest", "newer", "newest"} {
+		assert.NoError(t, db.Create(&models.Resource{
+			Project:      "project",
+			Domain:       "domain",
+			ResourceType: "TASK_RESOURCE",
+			Priority:     models.ResourcePriorityProjectDomainLevel,
+			Attributes:   []byte(attributes),
+		}).Error)
+	}
+	assert.NoError(t, db.Create(&models.Resource{
+		Project:      "project",
+		Domain:       "domain",
+		ResourceType: "EXECUTION_QUEUE",
+		Priority:     models.ResourcePriorityProjectDomainLevel,
+	}).Error)
+
+	_, err = NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+	var resources []models.Resource
+	assert.NoError(t, db.Order("resource_type").Find(&resources).Error)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "EXECUTION_QUEUE", resources[0].ResourceType)
+	assert.Equal(t, "oldest", string(resources[1].Attributes))
+	assert.True(t, db.Migrator().HasIndex("resources", "resource_idx"))
+	assert.Error(t, db.Create(&models.Resource{
+		Project:      "project",
+		Domain:       "domain",
+		ResourceType: "TASK_RESOURCE",
+		Priority:     models.ResourcePriorityProjectDomainLevel,
+	}).Error)
+
+	_, err = NewMigrator(db, Migrations).Rollback(len(ids) - index)
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasIndex("resources", "resource_idx"))
+}
+
+func TestMigrations_ResourcesOrg(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	ids := getMigrationIDs(Migrations)
+	var index int
+	for i, id := range ids {
+		if id == "2021-11-17-resources-org" {
+			index = i
+		}
+	}
+	_, err := NewMigrator(db, Migrations[:index]).Migrate()
+	assert.NoError(t, err)
+	// As resources were before orgs.
+	assert.NoError(t, dropIndexIfExists(db, "resources", "resource_idx"))
+	assert.NoError(t, dropColumnIfExists(db, &models.Resource{}, "org"))
+	assert.NoError(t, createUniqueIndexIfNotExists(db, "resources", "resource_idx",
+		"project, domain, workflow, launch_plan, resource_type"))
+	assert.NoError(t, db.Exec("INSERT INTO resources (project, domain, workflow, launch_plan, resource_type, priority) "+
+		"VALUES ('project', 'domain', '', '', 'TASK_RESOURCE', ?)", models.ResourcePriorityProjectDomainLevel).Error)
+
+	_, err = NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+	var existing models.Resource
+	assert.NoError(t, db.Where("project = ? AND org = ?", "project", "").Take(&existing).Error)
+	for _, org := range []string{"acme", "globex"} {
+		assert.NoError(t, db.Create(&models.Resource{
+			Org:          org,
+			ResourceType: "TASK_RESOURCE",
+			Priority:     models.ResourcePriorityOrgLevel,
+		}).Error)
+	}
+	assert.Error(t, db.Create(&models.Resource{
+		Org:          "acme",
+		ResourceType: "TASK_RESOURCE",
+		Priority:     models.ResourcePriorityOrgLevel,
+	}).Error)
+
+	rolledBack, err := NewMigrator(db, Migrations).Rollback(len(ids) - index)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-11-17-resources-org", rolledBack[len(rolledBack)-1])
+	assert.False(t, db.Migrator().HasColumn(&models.Resource{}, "org"))
+	assert.True(t, db.Migrator().HasIndex("resources", "resource_idx"))
+	var count int64
+	assert.NoError(t, db.Table("resources").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMigrations_LaunchPlansScheduleTypeBackfill(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	ids := getMigrationIDs(Migrations)
+	var index int
+	for i, id := range ids {
+		if id == "2021-11-27-launch-plans-schedule-type-backfill" {
+			index = i
+		}
+	}
+	_, err := NewMigrator(db, Migrations[:index]).Migrate()
+	assert.NoError(t, err)
+	getSpec := func(schedule *admin.Schedule) []byte {
+		spec, err := proto.Marshal(&admin.LaunchPlanSpec{
+			EntityMetadata: &admin.LaunchPlanMetadata{Schedule: schedule},
+		})
+		assert.NoError(t, err)
+		return spec
+	}
+	for version, launchPlan := range map[string]struct {
+		spec         []byte
+		scheduleType models.LaunchPlanScheduleType
+	}{
+		"cron-schedule": {
+			spec: getSpec(&admin.Schedule{ScheduleExpression: &admin.Schedule_CronSchedule{
+				CronSchedule: &admin.CronSchedule{Schedule: "@hourly"}}}),
+			scheduleType: models.LaunchPlanScheduleTypeNONE,
+		},
+		"unrecorded": {
+			spec: getSpec(&admin.Schedule{ScheduleExpression: &admin.Schedule_Rate{
+				Rate: &admin.FixedRate{Value: 1, Unit: admin.FixedRateUnit_HOUR}}}),
+		},
+		"unscheduled": {
+			spec: getSpec(nil),
+		},
+		"recorded": {
+			spec: getSpec(&admin.Schedule{ScheduleExpression: &admin.Schedule_CronExpression{
+				CronExpression: "0 * * * *"}}),
+			scheduleType: models.LaunchPlanScheduleTypeCRON,
+		},
+	} {
+		assert.NoError(t, db.Create(&models.LaunchPlan{
+			LaunchPlanKey: models.LaunchPlanKey{Project: "project", Domain: "domain", Name: "name", Version: version},
+			Spec:          launchPlan.spec,
+			Closure:       []byte{},
+			ScheduleType:  launchPlan.scheduleType,
+		}).Error)
+	}
+
+	_, err = NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+	scheduleTypes := make(map[string]models.LaunchPlanScheduleType)
+	var launchPlans []models.LaunchPlan
+	assert.NoError(t, db.Find(&launchPlans).Error)
+	for _, launchPlan := range launchPlans {
+		scheduleTypes[launchPlan.Version] = launchPlan.ScheduleType
+	}
+	assert.Equal(t, map[string]models.LaunchPlanScheduleType{
+		"cron-schedule": models.LaunchPlanScheduleTypeCRON,
+		"unrecorded":    models.LaunchPlanScheduleTypeRATE,
+		"unscheduled":   models.LaunchPlanScheduleTypeNONE,
+		"recorded":      models.LaunchPlanScheduleTypeCRON,
+	}, scheduleTypes)
+}
+
+func TestMigrations_DropExecutionErrorMessageIndex(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	ids := getMigrationIDs(Migrations)
+	var index int
+	for i, id := range ids {
+		if id == "2021-11-29-drop-execution-error-message-index" {
+			index = i
+		}
+	}
+	_, err := NewMigrator(db, Migrations[:index]).Migrate()
+	assert.NoError(t, err)
+	// Databases migrated while error messages were indexed hold the index.
+	assert.NoError(t, createIndexIfNotExists(db, "executions", "idx_executions_error_message", "error_message"))
+
+	_, err = NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasIndex("executions", "idx_executions_error_message"))
+
+	_, err = NewMigrator(db, Migrations).Rollback(len(ids) - index)
+	assert.NoError(t, err)
+	assert.True(t, db.Migrator().HasIndex("executions", "idx_executions_error_message"))
+}
+
+func TestMigrations_ExecutionLaunchAttempted(t *testing.T) {
+	db := getSQLiteDBForMigrationTest(t)
+	ids := getMigrationIDs(Migrations)
+	var index int
+	for i, id := range ids {
+		if id == "2021-12-01-execution-launch-attempted" {
+			index = i
+		}
+	}
+	_, err := NewMigrator(db, Migrations[:index]).Migrate()
+	assert.NoError(t, err)
+	// As executions were before the launch was recorded.
+	assert.NoError(t, dropColumnIfExists(db, &models.Execution{}, "launch_attempted"))
+	for name, phase := range map[string]string{"queued": "QUEUED", "running": "RUNNING"} {
+		assert.NoError(t, db.Exec("INSERT INTO executions (execution_project, execution_domain, execution_name, "+
+			"phase, spec) VALUES ('project', 'domain', ?, ?, '')", name, phase).Error)
+	}
+
+	_, err = NewMigrator(db, Migrations).Migrate()
+	assert.NoError(t, err)
+	launchAttempted := make(map[string]bool)
+	var executions []models.Execution
+	assert.NoError(t, db.Find(&executions).Error)
+	for _, execution := range executions {
+		launchAttempted[execution.Name] = execution.LaunchAttempted
+	}
+	// Executions queued before the column existed may have been launched.
+	assert.Equal(t, map[string]bool{"queued": true, "running": false}, launchAttempted)
+
+	_, err = NewMigrator(db, Migrations).Rollback(len(ids) - index)
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&models.Execution{}, "launch_attempted"))
+}
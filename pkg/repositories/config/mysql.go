@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/go-sql-driver/mysql"
+	gormMySQL "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+const MySQL = "mysql"
+
+// MySQL implementation for DbConnectionConfigProvider.
+type MySQLConfigProvider struct {
+	config DbConfig
+	scope  promutils.Scope
+}
+
+func NewMySQLConfigProvider(config DbConfig, scope promutils.Scope) DbConnectionConfigProvider {
+	return &MySQLConfigProvider{
+		config: config,
+		scope:  scope,
+	}
+}
+
+func (p *MySQLConfigProvider) GetType() string {
+	return MySQL
+}
+
+// Builds a DSN such as user:password@tcp(host:port)/dbname?parseTime=true, followed by the extra options which are
+// expected in the query string format, e.g. tls=true&charset=utf8mb4.
+func (p *MySQLConfigProvider) GetDSN() string {
+	mysqlConfig := mysql.NewConfig()
+	mysqlConfig.User = p.config.User
+	mysqlConfig.Passwd = p.config.Password
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = fmt.Sprintf("%s:%d", p.config.Host, p.config.Port)
+	mysqlConfig.DBName = p.config.DbName
+	// Timestamps are scanned into time.Time rather than []byte.
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Params = getMySQLSessionTimeouts(p.config)
+	dsn := mysqlConfig.FormatDSN()
+	if len(p.config.ExtraOptions) > 0 {
+		dsn += "&" + strings.TrimPrefix(p.config.ExtraOptions, "?")
+	}
+	return dsn
+}
+
+// The driver sets parameters it doesn't know as session variables on each connection it opens. MySQL only limits the
+// execution time of selects, and counts lock waits in whole seconds.
+func getMySQLSessionTimeouts(config DbConfig) map[string]string {
+	params := make(map[string]string)
+	if config.StatementTimeout > 0 {
+		params["max_execution_time"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
+	}
+	if config.LockTimeout > 0 {
+		params["innodb_lock_wait_timeout"] = strconv.FormatInt(int64(math.Ceil(config.LockTimeout.Seconds())), 10)
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+func (p *MySQLConfigProvider) GetDialector() gorm.Dialector {
+	return gormMySQL.Open(p.GetDSN())
+}
+
+func (p *MySQLConfigProvider) GetDBConfig() DbConfig {
+	return p.config
+}
+
+// Provides the config necessary to open connections to the read replica of a MySQL database.
+type MySQLReadReplicaConfigProvider struct {
+	config DbConfig
+	scope  promutils.Scope
+}
+
+func NewMySQLReadReplicaConfigProvider(config DbConfig, scope promutils.Scope) DbConnectionConfigProvider {
+	return &MySQLReadReplicaConfigProvider{
+		config: config,
+		scope:  scope,
+	}
+}
+
+// The replica's DSN is used as configured, in the format described by https://github.com/go-sql-driver/mysql#dsn-data-source-name.
+func (p *MySQLReadReplicaConfigProvider) GetDSN() string {
+	return p.config.ReadReplica.DSN
+}
+
+func (p *MySQLReadReplicaConfigProvider) GetDialector() gorm.Dialector {
+	return gormMySQL.Open(p.GetDSN())
+}
+
+func (p *MySQLReadReplicaConfigProvider) GetDBConfig() DbConfig {
+	replicaConfig := p.config
+	replicaConfig.ConnectionPool = p.config.ReadReplica.ConnectionPool
+	return replicaConfig
+}
+
+// Returns the provider of connections to the database of the configured dialect.
+func NewDbConnectionConfigProvider(config DbConfig, scope promutils.Scope) (DbConnectionConfigProvider, error) {
+	switch strings.ToLower(config.Dialect) {
+	case "", Postgres:
+		return NewPostgresConfigProvider(config, scope), nil
+	case MySQL:
+		return NewMySQLConfigProvider(config, scope), nil
+	case SQLite:
+		return NewSQLiteConfigProvider(config, scope), nil
+	default:
+		return nil, fmt.Errorf("unsupported database dialect [%s], expected %s, %s or %s", config.Dialect, Postgres,
+			MySQL, SQLite)
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLConfigProvider_GetDSN(t *testing.T) {
+	mysqlConfigProvider := NewMySQLConfigProvider(DbConfig{
+		Host:         "localhost",
+		Port:         3306,
+		DbName:       "flyteadmin",
+		User:         "root",
+		Password:     "pass",
+		ExtraOptions: "tls=true",
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, MySQL, mysqlConfigProvider.(*MySQLConfigProvider).GetType())
+	assert.Equal(t, "root:pass@tcp(localhost:3306)/flyteadmin?parseTime=true&tls=true", mysqlConfigProvider.GetDSN())
+	assert.Equal(t, MySQL, mysqlConfigProvider.GetDialector().Name())
+}
+
+func TestMySQLConfigProvider_SessionTimeouts(t *testing.T) {
+	mysqlConfigProvider := NewMySQLConfigProvider(DbConfig{
+		Host:             "localhost",
+		Port:             3306,
+		DbName:           "flyteadmin",
+		User:             "root",
+		StatementTimeout: 30 * time.Second,
+		LockTimeout:      1500 * time.Millisecond,
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, "root@tcp(localhost:3306)/flyteadmin?parseTime=true&innodb_lock_wait_timeout=2&max_execution_time=30000",
+		mysqlConfigProvider.GetDSN())
+}
+
+func TestNewDbConnectionConfigProvider(t *testing.T) {
+	for dialect, expected := range map[string]string{
+		"":         Postgres,
+		"postgres": Postgres,
+		"MySQL":    MySQL,
+		"sqlite":   SQLite,
+	} {
+		provider, err := NewDbConnectionConfigProvider(DbConfig{Dialect: dialect}, mockScope.NewTestScope())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, provider.GetDialector().Name())
+	}
+
+	_, err := NewDbConnectionConfigProvider(DbConfig{Dialect: "oracle"}, mockScope.NewTestScope())
+	assert.EqualError(t, err, "unsupported database dialect [oracle], expected postgres, mysql or sqlite")
+}
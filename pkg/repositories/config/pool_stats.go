@@ -0,0 +1,68 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// Gauges of the state of a database connection pool, the wait stats are totals since the pool was opened.
+type connectionPoolMetrics struct {
+	OpenConnections prometheus.Gauge
+	InUse           prometheus.Gauge
+	Idle            prometheus.Gauge
+	WaitCount       prometheus.Gauge
+	WaitDuration    prometheus.Gauge
+}
+
+func (m connectionPoolMetrics) emit(stats sql.DBStats) {
+	m.OpenConnections.Set(float64(stats.OpenConnections))
+	m.InUse.Set(float64(stats.InUse))
+	m.Idle.Set(float64(stats.Idle))
+	m.WaitCount.Set(float64(stats.WaitCount))
+	m.WaitDuration.Set(stats.WaitDuration.Seconds())
+}
+
+func newConnectionPoolMetrics(scope promutils.Scope) connectionPoolMetrics {
+	return connectionPoolMetrics{
+		OpenConnections: scope.MustNewGauge("open_connections",
+			"number of established connections, both in use and idle"),
+		InUse: scope.MustNewGauge("in_use",
+			"number of connections currently in use"),
+		Idle: scope.MustNewGauge("idle",
+			"number of idle connections"),
+		WaitCount: scope.MustNewGauge("wait_count",
+			"total number of connections waited for since the pool was opened"),
+		WaitDuration: scope.MustNewGauge("wait_duration_seconds",
+			"total time blocked waiting for a new connection since the pool was opened"),
+	}
+}
+
+// Emits the stats of the database's connection pool to the scope every interval until the context is cancelled.
+func EmitConnectionPoolStats(ctx context.Context, db *gorm.DB, scope promutils.Scope, interval time.Duration) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	emitConnectionPoolStats(ctx, sqlDB, newConnectionPoolMetrics(scope), interval)
+	return nil
+}
+
+func emitConnectionPoolStats(ctx context.Context, sqlDB *sql.DB, metrics connectionPoolMetrics, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			metrics.emit(sqlDB.Stats())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestEmitConnectionPoolStats(t *testing.T) {
+	mocket.Catcher.Register()
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: mocket.DriverName}))
+	assert.NoError(t, err)
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxIdleConns(2)
+	// Opens a connection, which is idle once the ping returns.
+	assert.NoError(t, sqlDB.Ping())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metrics := newConnectionPoolMetrics(mockScope.NewTestScope())
+	emitConnectionPoolStats(ctx, sqlDB, metrics, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.OpenConnections) == 1 && testutil.ToFloat64(metrics.Idle) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.InUse))
+
+	conn, err := sqlDB.Conn(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.InUse) == 1 && testutil.ToFloat64(metrics.Idle) == 0
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, EmitConnectionPoolStats(ctx, db, mockScope.NewTestScope(), time.Millisecond))
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
 
 	"gorm.io/driver/postgres"
@@ -49,11 +51,34 @@ func (p *PostgresConfigProvider) GetType() string {
 func (p *PostgresConfigProvider) GetDSN() string {
 	if p.config.Password == "" {
 		// Switch for development
-		return fmt.Sprintf("host=%s port=%d dbname=%s user=%s sslmode=disable",
-			p.config.Host, p.config.Port, p.config.DbName, p.config.User)
+		return withSessionTimeouts(fmt.Sprintf("host=%s port=%d dbname=%s user=%s sslmode=disable",
+			p.config.Host, p.config.Port, p.config.DbName, p.config.User), p.config)
 	}
-	return fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s %s",
-		p.config.Host, p.config.Port, p.config.DbName, p.config.User, p.config.Password, p.config.ExtraOptions)
+	return withSessionTimeouts(fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s %s",
+		p.config.Host, p.config.Port, p.config.DbName, p.config.User, p.config.Password, p.config.ExtraOptions), p.config)
+}
+
+// Sets the statement and lock timeouts of the sessions opened with the DSN. The driver passes settings it doesn't know
+// to the server as session parameters, so they hold for every connection in the pool.
+func withSessionTimeouts(dsn string, config DbConfig) string {
+	var settings []string
+	if config.StatementTimeout > 0 {
+		settings = append(settings, fmt.Sprintf("statement_timeout=%d", config.StatementTimeout.Milliseconds()))
+	}
+	if config.LockTimeout > 0 {
+		settings = append(settings, fmt.Sprintf("lock_timeout=%d", config.LockTimeout.Milliseconds()))
+	}
+	if len(settings) == 0 {
+		return dsn
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		return dsn + separator + strings.Join(settings, "&")
+	}
+	return strings.TrimSpace(dsn) + " " + strings.Join(settings, " ")
 }
 
 func (p *PostgresConfigProvider) GetDialector() gorm.Dialector {
@@ -64,15 +89,78 @@ func (p *PostgresConfigProvider) GetDBConfig() DbConfig {
 	return p.config
 }
 
+// Provides the config necessary to open connections to the read replica of a PostgreSQL database.
+type PostgresReadReplicaConfigProvider struct {
+	config DbConfig
+	scope  promutils.Scope
+}
+
+func NewPostgresReadReplicaConfigProvider(config DbConfig, scope promutils.Scope) DbConnectionConfigProvider {
+	return &PostgresReadReplicaConfigProvider{
+		config: config,
+		scope:  scope,
+	}
+}
+
+func (p *PostgresReadReplicaConfigProvider) GetDSN() string {
+	return withSessionTimeouts(p.config.ReadReplica.DSN, p.config)
+}
+
+func (p *PostgresReadReplicaConfigProvider) GetDialector() gorm.Dialector {
+	return postgres.Open(p.GetDSN())
+}
+
+// The replica shares the primary's logging settings but has a connection pool of its own.
+func (p *PostgresReadReplicaConfigProvider) GetDBConfig() DbConfig {
+	replicaConfig := p.config
+	replicaConfig.ConnectionPool = p.config.ReadReplica.ConnectionPool
+	return replicaConfig
+}
+
 // Opens a connection to the database specified in the config.
 // You must call CloseDbConnection at the end of your session!
 func OpenDbConnection(config DbConnectionConfigProvider) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(config.GetDSN()), &gorm.Config{
+	if err := validateConnectionPoolConfig(config.GetDBConfig().ConnectionPool); err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(config.GetDialector(), &gorm.Config{
 		Logger:                                   logger.Default.LogMode(config.GetDBConfig().LogLevel),
 		DisableForeignKeyConstraintWhenMigrating: config.GetDBConfig().DisableForeignKeyConstraintWhenMigrating,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := applyConnectionPoolConfig(db, config.GetDBConfig().ConnectionPool); err != nil {
+		return nil, err
+	}
 	return db, nil
 }
+
+func validateConnectionPoolConfig(poolConfig interfaces.DbConnectionPoolConfig) error {
+	if poolConfig.MaxOpenConns < 0 || poolConfig.MaxIdleConns < 0 || poolConfig.ConnMaxLifetime.Duration < 0 {
+		return fmt.Errorf("invalid connection pool config %+v: settings can't be negative", poolConfig)
+	}
+	if poolConfig.MaxOpenConns > 0 && poolConfig.MaxIdleConns > poolConfig.MaxOpenConns {
+		return fmt.Errorf("invalid connection pool config: maxIdleConns %d exceeds maxOpenConns %d",
+			poolConfig.MaxIdleConns, poolConfig.MaxOpenConns)
+	}
+	return nil
+}
+
+// Unset pool settings keep the database/sql defaults.
+func applyConnectionPoolConfig(db *gorm.DB, poolConfig interfaces.DbConnectionPoolConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if poolConfig.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(poolConfig.MaxOpenConns)
+	}
+	if poolConfig.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(poolConfig.MaxIdleConns)
+	}
+	if poolConfig.ConnMaxLifetime.Duration > 0 {
+		sqlDB.SetConnMaxLifetime(poolConfig.ConnMaxLifetime.Duration)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/flyteorg/flyteadmin/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	queryTracerName = "query_tracer"
+	// Holds the span of the statement being executed.
+	querySpanKey = queryTracerName + ":span"
+)
+
+// Traces the statements executed against a database, as children of the span of the context they're executed with.
+type queryTracer struct{}
+
+func (t *queryTracer) Name() string {
+	return queryTracerName
+}
+
+func (t *queryTracer) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register(queryTracerName+":create", t.start("create")); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register(queryTracerName+":create_end", t.end); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register(queryTracerName+":query", t.start("query")); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register(queryTracerName+":query_end", t.end); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register(queryTracerName+":update", t.start("update")); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register(queryTracerName+":update_end", t.end); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register(queryTracerName+":delete", t.start("delete")); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register(queryTracerName+":delete_end", t.end); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register(queryTracerName+":row", t.start("row")); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register(queryTracerName+":row_end", t.end); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register(queryTracerName+":raw", t.start("raw")); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register(queryTracerName+":raw_end", t.end)
+}
+
+func (t *queryTracer) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		statement := db.Statement
+		ctx, span := tracing.Tracer().Start(statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemKey.String(db.Dialector.Name()),
+				semconv.DBOperationKey.String(operation),
+				semconv.DBSQLTableKey.String(statement.Table),
+			))
+		statement.Context = ctx
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+func (t *queryTracer) end(db *gorm.DB) {
+	value, ok := db.InstanceGet(querySpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	// The table is only known once the statement is built for queries without an explicit model.
+	if table := db.Statement.Table; len(table) > 0 {
+		span.SetAttributes(semconv.DBSQLTableKey.String(table))
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", db.RowsAffected))
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Missing records are reported to callers rather than a failure of the database.
+		err = nil
+	}
+	tracing.End(span, err)
+}
+
+// Returns a plugin which traces the statements executed against the database it's used by.
+func NewQueryTracer() gorm.Plugin {
+	return &queryTracer{}
+}
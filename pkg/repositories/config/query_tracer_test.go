@@ -0,0 +1,86 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/flyteadmin/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type tracedModel struct {
+	ID   uint
+	Name string
+}
+
+func getTracedDbForTest(t *testing.T) (*gorm.DB, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		tracing.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+	mocket.Catcher.Register()
+	mocket.Catcher.Reset()
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: mocket.DriverName}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(NewQueryTracer()))
+	return db, recorder
+}
+
+func getSpanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestQueryTracer(t *testing.T) {
+	db, recorder := getTracedDbForTest(t)
+	ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+
+	var models []tracedModel
+	assert.NoError(t, db.WithContext(ctx).Where("name = ?", "foo").Find(&models).Error)
+	assert.NoError(t, db.WithContext(ctx).Exec(`UPDATE "traced_models" SET name = ?`, "bar").Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	query := spans[0]
+	assert.Equal(t, "gorm.query", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind())
+	attributes := getSpanAttributes(query)
+	assert.Equal(t, "postgres", attributes["db.system"].AsString())
+	assert.Equal(t, "query", attributes["db.operation"].AsString())
+	assert.Equal(t, "traced_models", attributes["db.sql.table"].AsString())
+
+	raw := spans[1]
+	assert.Equal(t, "gorm.raw", raw.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), raw.Parent().SpanID())
+	assert.Equal(t, codes.Unset, raw.Status().Code)
+}
+
+func TestQueryTracer_Errors(t *testing.T) {
+	db, recorder := getTracedDbForTest(t)
+	mocket.Catcher.NewMock().WithQuery(`UPDATE "traced_models"`).WithExecException()
+
+	assert.Error(t, db.Exec(`UPDATE "traced_models" SET name = ?`, "bar").Error)
+	var model tracedModel
+	assert.ErrorIs(t, db.First(&model).Error, gorm.ErrRecordNotFound)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+	// Missing records aren't failures of the database.
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"gorm.io/gorm"
+)
+
+const (
+	readReplicaRouterName = "read_replica_router"
+	// Remembers the pool a statement was routed away from, so that the statement can be reused for writes.
+	routedFromKey = readReplicaRouterName + ":routed_from"
+)
+
+// Routes the queries of a database to a read replica. Only statements which read through the primary's pool outside of
+// a transaction are routed, writes, reads within transactions, locking reads and reads made with a context from
+// common.WithPrimaryReads are served by the primary.
+type readReplicaRouter struct {
+	replica gorm.ConnPool
+}
+
+func (r *readReplicaRouter) Name() string {
+	return readReplicaRouterName
+}
+
+func (r *readReplicaRouter) Initialize(db *gorm.DB) error {
+	// Queries cover finds and counts, rows cover raw selects.
+	if err := db.Callback().Query().Before("gorm:query").Register(readReplicaRouterName+":query", r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register(readReplicaRouterName+":query_restore", r.restore); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(readReplicaRouterName+":row", r.route); err != nil {
+		return err
+	}
+	return db.Callback().Row().After("gorm:row").Register(readReplicaRouterName+":row_restore", r.restore)
+}
+
+func (r *readReplicaRouter) route(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	statement := db.Statement
+	if statement.ConnPool != db.Config.ConnPool {
+		// The statement runs in a transaction.
+		return
+	}
+	if _, locking := statement.Clauses["FOR"]; locking {
+		return
+	}
+	if common.ReadsFromPrimary(statement.Context) {
+		return
+	}
+	db.InstanceSet(routedFromKey, statement.ConnPool)
+	statement.ConnPool = r.replica
+}
+
+func (r *readReplicaRouter) restore(db *gorm.DB) {
+	if routedFrom, ok := db.InstanceGet(routedFromKey); ok {
+		db.Statement.ConnPool = routedFrom.(gorm.ConnPool)
+	}
+}
+
+// Returns a plugin which sends the reads of the database it's used by to the given replica.
+func NewReadReplicaRouter(replica *gorm.DB) gorm.Plugin {
+	return &readReplicaRouter{
+		replica: replica.ConnPool,
+	}
+}
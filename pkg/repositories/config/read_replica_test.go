@@ -0,0 +1,157 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Records the statements sent to a database, statements within transactions are only recorded as the transaction's
+// BEGIN.
+type recordingConnPool struct {
+	*sql.DB
+	mutex      sync.Mutex
+	statements []string
+}
+
+func (p *recordingConnPool) record(query string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.statements = append(p.statements, query)
+}
+
+func (p *recordingConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.record(query)
+	return p.DB.ExecContext(ctx, query, args...)
+}
+
+func (p *recordingConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	p.record(query)
+	return p.DB.QueryContext(ctx, query, args...)
+}
+
+func (p *recordingConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	p.record(query)
+	return p.DB.QueryRowContext(ctx, query, args...)
+}
+
+func (p *recordingConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	p.record("BEGIN")
+	return p.DB.BeginTx(ctx, opts)
+}
+
+func (p *recordingConnPool) reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.statements = nil
+}
+
+func newRecordingConnPool(t *testing.T, dsn string) *recordingConnPool {
+	db, err := sql.Open(mocket.DriverName, dsn)
+	assert.NoError(t, err)
+	return &recordingConnPool{DB: db}
+}
+
+type replicatedModel struct {
+	ID   uint
+	Name string
+}
+
+func getReplicatedDbsForTest(t *testing.T) (*gorm.DB, *recordingConnPool, *recordingConnPool) {
+	mocket.Catcher.Register()
+	mocket.Catcher.Reset()
+	primaryPool := newRecordingConnPool(t, "primary")
+	replicaPool := newRecordingConnPool(t, "replica")
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: primaryPool.DB}), &gorm.Config{})
+	assert.NoError(t, err)
+	db.ConnPool = primaryPool
+	db.Statement.ConnPool = primaryPool
+	assert.NoError(t, db.Use(NewReadReplicaRouter(&gorm.DB{Config: &gorm.Config{ConnPool: replicaPool}})))
+	return db, primaryPool, replicaPool
+}
+
+func TestReadReplicaRouter(t *testing.T) {
+	db, primaryPool, replicaPool := getReplicatedDbsForTest(t)
+	ctx := context.Background()
+
+	t.Run("finds are served by the replica", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var models []replicatedModel
+		assert.NoError(t, db.WithContext(ctx).Where("name = ?", "foo").Find(&models).Error)
+		assert.Empty(t, primaryPool.statements)
+		assert.Equal(t, []string{`SELECT * FROM "replicated_models" WHERE name = $1`}, replicaPool.statements)
+	})
+	t.Run("counts are served by the replica", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var count int64
+		assert.NoError(t, db.WithContext(ctx).Model(&replicatedModel{}).Count(&count).Error)
+		assert.Empty(t, primaryPool.statements)
+		assert.Equal(t, []string{`SELECT count(*) FROM "replicated_models"`}, replicaPool.statements)
+	})
+	t.Run("raw selects are served by the replica", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var estimate int64
+		assert.NoError(t, db.WithContext(ctx).Raw("SELECT reltuples FROM pg_class").Scan(&estimate).Error)
+		assert.Empty(t, primaryPool.statements)
+		assert.Equal(t, []string{"SELECT reltuples FROM pg_class"}, replicaPool.statements)
+	})
+	t.Run("writes are sent to the primary", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		assert.NoError(t, db.WithContext(ctx).Exec(`UPDATE "replicated_models" SET name = ?`, "bar").Error)
+		assert.NoError(t, db.WithContext(ctx).Model(&replicatedModel{ID: 1}).Update("name", "bar").Error)
+		// Gorm runs the update within a transaction.
+		assert.Equal(t, []string{`UPDATE "replicated_models" SET name = $1`, "BEGIN"}, primaryPool.statements)
+		assert.Empty(t, replicaPool.statements)
+	})
+	t.Run("reads which must be consistent are served by the primary", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var model replicatedModel
+		assert.NoError(t, db.WithContext(common.WithPrimaryReads(ctx)).Where("id = ?", 1).Find(&model).Error)
+		assert.Equal(t, []string{`SELECT * FROM "replicated_models" WHERE id = $1`}, primaryPool.statements)
+		assert.Empty(t, replicaPool.statements)
+	})
+	t.Run("locking reads are served by the primary", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var model replicatedModel
+		assert.NoError(t, db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Find(&model).Error)
+		assert.Equal(t, []string{`SELECT * FROM "replicated_models" FOR UPDATE`}, primaryPool.statements)
+		assert.Empty(t, replicaPool.statements)
+	})
+	t.Run("reads within transactions are served by the primary", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		assert.NoError(t, db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var model replicatedModel
+			if err := tx.Where("id = ?", 1).Find(&model).Error; err != nil {
+				return err
+			}
+			return tx.Model(&replicatedModel{ID: 1}).Update("name", "bar").Error
+		}))
+		assert.Equal(t, []string{"BEGIN"}, primaryPool.statements)
+		assert.Empty(t, replicaPool.statements)
+	})
+	t.Run("statements reused after a read write to the primary", func(t *testing.T) {
+		primaryPool.reset()
+		replicaPool.reset()
+		var count int64
+		tx := db.WithContext(ctx).Model(&replicatedModel{}).Where("id = ?", 1)
+		assert.NoError(t, tx.Count(&count).Error)
+		assert.NoError(t, tx.Update("name", "bar").Error)
+		assert.Equal(t, []string{`SELECT count(*) FROM "replicated_models" WHERE id = $1`}, replicaPool.statements)
+		assert.Equal(t, []string{"BEGIN"}, primaryPool.statements)
+	})
+}
@@ -9,20 +9,31 @@ import (
 	"gorm.io/gorm"
 )
 
-// Returns a function to seed the database with default values.
-func SeedProjects(db *gorm.DB, projects []string) error {
+// Seeds the database with the given projects, leaving those which already exist untouched, and returns the projects
+// it created.
+func SeedProjects(db *gorm.DB, projects []string) ([]string, error) {
+	var created []string
 	tx := db.Begin()
 	for _, project := range projects {
+		var existing int64
+		if err := tx.Model(&models.Project{}).Where(&models.Project{Identifier: project}).Count(&existing).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if existing > 0 {
+			continue
+		}
 		projectModel := models.Project{
 			Identifier:  project,
 			Name:        project,
 			Description: fmt.Sprintf("%s description", project),
 		}
-		if err := tx.Where(models.Project{Identifier: project}).Omit("id").FirstOrCreate(&projectModel).Error; err != nil {
+		if err := tx.Omit("id").Create(&projectModel).Error; err != nil {
 			logger.Warningf(context.Background(), "failed to save project [%s]", project)
 			tx.Rollback()
-			return err
+			return nil, err
 		}
+		created = append(created, project)
 	}
-	return tx.Commit().Error
+	return created, tx.Commit().Error
 }
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	// Registers the pure Go SQLite driver, so that binaries built without cgo can open SQLite databases too.
+	_ "modernc.org/sqlite"
+)
+
+const SQLite = "sqlite"
+
+// The name the pure Go driver is registered with, rather than the default one of the cgo driver.
+const sqliteDriverName = "sqlite"
+
+// How long connections wait for another to release the database when no lock timeout is configured. Unlike the cgo
+// driver, the pure Go one doesn't wait by default.
+const sqliteDefaultBusyTimeout = 5 * time.Second
+
+// Every connection to an in-memory database opens a database of its own, so a single connection is kept open for the
+// lifetime of the pool.
+var sqliteInMemoryConnectionPool = interfaces.DbConnectionPoolConfig{
+	MaxOpenConns: 1,
+	MaxIdleConns: 1,
+}
+
+// SQLite implementation for DbConnectionConfigProvider.
+type SQLiteConfigProvider struct {
+	config DbConfig
+	scope  promutils.Scope
+}
+
+func NewSQLiteConfigProvider(config DbConfig, scope promutils.Scope) DbConnectionConfigProvider {
+	return &SQLiteConfigProvider{
+		config: config,
+		scope:  scope,
+	}
+}
+
+func (p *SQLiteConfigProvider) GetType() string {
+	return SQLite
+}
+
+// Builds a DSN of the database file, or an in-memory database when no file is configured, followed by the driver
+// options described in https://pkg.go.dev/modernc.org/sqlite#Driver.Open. Each connection runs the pragmas as it's
+// opened, in order. Foreign keys are enforced and LIKE is case sensitive, as they are in Postgres.
+func (p *SQLiteConfigProvider) GetDSN() string {
+	params := url.Values{}
+	// The lock timeout bounds how long a connection waits for another to release the database. It's set first so that
+	// it applies to the pragmas following it.
+	busyTimeout := sqliteDefaultBusyTimeout
+	if p.config.LockTimeout > 0 {
+		busyTimeout = p.config.LockTimeout
+	}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%s)", strconv.FormatInt(busyTimeout.Milliseconds(), 10)))
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "case_sensitive_like(1)")
+	// Transactions take the write lock as they begin, so that they don't fail upgrading their read lock when another
+	// process wrote in the meantime.
+	params.Set("_txlock", "immediate")
+	// Times are written in a format SQLite's date and time functions understand, rather than as Go formats them.
+	params.Set("_time_format", "sqlite")
+	file := p.config.SQLite.File
+	if len(file) == 0 {
+		file = ":memory:"
+	} else {
+		// Readers don't block the writer, nor does it block them.
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+	dsn := fmt.Sprintf("file:%s?%s", file, params.Encode())
+	if len(p.config.ExtraOptions) > 0 {
+		dsn += "&" + strings.TrimPrefix(p.config.ExtraOptions, "?")
+	}
+	return dsn
+}
+
+func (p *SQLiteConfigProvider) GetDialector() gorm.Dialector {
+	return &sqliteDialector{
+		Dialector: &sqlite.Dialector{DriverName: sqliteDriverName, DSN: p.GetDSN()},
+	}
+}
+
+func (p *SQLiteConfigProvider) GetDBConfig() DbConfig {
+	if len(p.config.SQLite.File) > 0 {
+		return p.config
+	}
+	inMemoryConfig := p.config
+	inMemoryConfig.ConnectionPool = sqliteInMemoryConnectionPool
+	return inMemoryConfig
+}
+
+// SQLite only auto increments the integer primary key of a table, whereas the models embed an auto incremented id
+// alongside primary keys of their own. The dialector declares such ids as plain integers, which keeps the primary keys
+// of the models, and assigns them as rows are created.
+type sqliteDialector struct {
+	*sqlite.Dialector
+}
+
+func (d *sqliteDialector) Initialize(db *gorm.DB) error {
+	if err := d.Dialector.Initialize(db); err != nil {
+		return err
+	}
+	return db.Callback().Create().Before("gorm:create").Register("sqlite:assign_ids", assignAutoIncrementedIDs)
+}
+
+// The migrator is built around this dialector, rather than the embedded one, to create the columns it declares.
+func (d *sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqlite.Migrator{
+		Migrator: migrator.Migrator{
+			Config: migrator.Config{
+				DB:                          db,
+				Dialector:                   d,
+				CreateIndexAfterCreateTable: true,
+			},
+		},
+	}
+}
+
+func (d *sqliteDialector) DataTypeOf(field *schema.Field) string {
+	if isAutoIncrementedID(field) {
+		return "integer"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+func isAutoIncrementedID(field *schema.Field) bool {
+	return field.AutoIncrement && !field.PrimaryKey
+}
+
+// Assigns ids following the largest one in the table to the rows being created without one. The ids are unique since
+// rows are created within transactions, which hold the database's write lock from the start. They stand in for the
+// database default the repositories rely on, so they're written even when the statement omits them.
+func assignAutoIncrementedIDs(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	for _, field := range db.Statement.Schema.Fields {
+		if !isAutoIncrementedID(field) {
+			continue
+		}
+		var rows []reflect.Value
+		switch db.Statement.ReflectValue.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+				rows = append(rows, db.Statement.ReflectValue.Index(i))
+			}
+		case reflect.Struct:
+			rows = append(rows, db.Statement.ReflectValue)
+		}
+		var lastID int64
+		queried := false
+		for _, row := range rows {
+			if _, isZero := field.ValueOf(row); !isZero {
+				continue
+			}
+			if !queried {
+				query := fmt.Sprintf("SELECT COALESCE(MAX(%s), 0) FROM %s", db.Statement.Quote(field.DBName),
+					db.Statement.Quote(db.Statement.Table))
+				if err := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, query).Scan(&lastID); err != nil {
+					_ = db.AddError(err)
+					return
+				}
+				queried = true
+			}
+			lastID++
+			if err := field.Set(row, lastID); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+		if queried {
+			unomit(db.Statement, field)
+		}
+	}
+}
+
+func unomit(statement *gorm.Statement, field *schema.Field) {
+	omits := statement.Omits[:0]
+	for _, omit := range statement.Omits {
+		if omit != field.Name && omit != field.DBName {
+			omits = append(omits, omit)
+		}
+	}
+	statement.Omits = omits
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteConfigProvider_File(t *testing.T) {
+	sqliteConfigProvider := NewSQLiteConfigProvider(DbConfig{
+		SQLite: interfaces.DbSQLiteConfig{
+			File: "/var/lib/flyteadmin.db",
+		},
+		LockTimeout:  2 * time.Second,
+		ExtraOptions: "_pragma=synchronous(NORMAL)",
+		ConnectionPool: interfaces.DbConnectionPoolConfig{
+			MaxOpenConns: 4,
+		},
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, "file:/var/lib/flyteadmin.db?_pragma=busy_timeout%282000%29&_pragma=foreign_keys%281%29&"+
+		"_pragma=case_sensitive_like%281%29&_pragma=journal_mode%28WAL%29&_time_format=sqlite&_txlock=immediate&"+
+		"_pragma=synchronous(NORMAL)", sqliteConfigProvider.GetDSN())
+	assert.Equal(t, 4, sqliteConfigProvider.GetDBConfig().ConnectionPool.MaxOpenConns)
+	assert.Equal(t, SQLite, sqliteConfigProvider.GetDialector().Name())
+}
+
+func TestSQLiteConfigProvider_InMemory(t *testing.T) {
+	sqliteConfigProvider := NewSQLiteConfigProvider(DbConfig{
+		ConnectionPool: interfaces.DbConnectionPoolConfig{
+			MaxOpenConns: 4,
+		},
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, "file::memory:?_pragma=busy_timeout%285000%29&_pragma=foreign_keys%281%29&"+
+		"_pragma=case_sensitive_like%281%29&_time_format=sqlite&_txlock=immediate", sqliteConfigProvider.GetDSN())
+	assert.Equal(t, sqliteInMemoryConnectionPool, sqliteConfigProvider.GetDBConfig().ConnectionPool)
+}
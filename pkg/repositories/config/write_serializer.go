@@ -0,0 +1,92 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+const writeSerializerName = "write_serializer"
+
+// Serializes the writes to a database which only supports a single writer at a time, such as SQLite, rather than
+// having concurrent writers fail once they're done waiting for the database lock. Transactions hold the lock from
+// begin until commit or rollback, since they may write at any point, statements outside of transactions only while
+// they execute. Queries outside of transactions aren't serialized.
+type writeSerializer struct {
+	mutex sync.Mutex
+}
+
+func (s *writeSerializer) Name() string {
+	return writeSerializerName
+}
+
+func (s *writeSerializer) Initialize(db *gorm.DB) error {
+	sqlDB, ok := db.ConnPool.(*sql.DB)
+	if !ok {
+		return fmt.Errorf("can't serialize the writes of a %T connection pool", db.ConnPool)
+	}
+	pool := &serializedConnPool{
+		DB:    sqlDB,
+		mutex: &s.mutex,
+	}
+	db.ConnPool = pool
+	db.Statement.ConnPool = pool
+	return nil
+}
+
+type serializedConnPool struct {
+	*sql.DB
+	mutex *sync.Mutex
+}
+
+func (p *serializedConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.DB.ExecContext(ctx, query, args...)
+}
+
+func (p *serializedConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.mutex.Lock()
+	tx, err := p.DB.BeginTx(ctx, opts)
+	if err != nil {
+		p.mutex.Unlock()
+		return nil, err
+	}
+	return &serializedTx{
+		Tx:     tx,
+		unlock: p.mutex.Unlock,
+	}, nil
+}
+
+// Lets gorm reach the underlying pool, e.g. to configure it or check its health.
+func (p *serializedConnPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+type serializedTx struct {
+	*sql.Tx
+	once   sync.Once
+	unlock func()
+}
+
+func (t *serializedTx) release() {
+	t.once.Do(t.unlock)
+}
+
+func (t *serializedTx) Commit() error {
+	defer t.release()
+	return t.Tx.Commit()
+}
+
+func (t *serializedTx) Rollback() error {
+	defer t.release()
+	return t.Tx.Rollback()
+}
+
+// Returns a plugin which serializes the writes of the database it's used by.
+func NewWriteSerializer() gorm.Plugin {
+	return &writeSerializer{}
+}
@@ -0,0 +1,51 @@
+package errors
+
+// The entities related by a foreign key constraint, which explain its violations.
+type foreignKeyEntities struct {
+	// The entity the constraint references, e.g. a parent node execution.
+	Referenced string
+	// The entity holding the reference, e.g. a child node execution.
+	Referencing string
+}
+
+// Maps the foreign key constraints, named as gorm names them when migrating the models, to the entities they relate.
+// Constraints missing here are reported in terms of the database.
+var foreignKeyConstraintEntities = map[string]foreignKeyEntities{
+	"fk_node_executions_child_node_executions": {
+		Referenced:  "parent node execution",
+		Referencing: "child node execution",
+	},
+	"fk_node_executions_launched_execution": {
+		Referenced:  "parent node execution",
+		Referencing: "launched execution",
+	},
+	"fk_task_executions_child_node_execution": {
+		Referenced:  "parent task execution",
+		Referencing: "child node execution",
+	},
+}
+
+// Maps tables to the entities they store, for the tables missing here the table name is reported instead.
+var tableEntities = map[string]string{
+	"description_entities":  "description entity",
+	"executions":            "execution",
+	"execution_events":      "execution event",
+	"execution_tags":        "execution tag",
+	"launch_plans":          "launch plan",
+	"named_entity_metadata": "named entity",
+	"node_executions":       "node execution",
+	"node_execution_events": "node execution event",
+	"projects":              "project",
+	"resources":             "resource",
+	"schedulable_entities":  "schedulable entity",
+	"tasks":                 "task",
+	"task_executions":       "task execution",
+	"workflows":             "workflow",
+}
+
+func getTableEntity(table string) string {
+	if entity, ok := tableEntities[table]; ok {
+		return entity
+	}
+	return table
+}
@@ -0,0 +1,114 @@
+// MySQL-specific implementation of an ErrorTransformer.
+// This errors utility translates MySQL server error numbers into the same internal error types the Postgres error
+// transformer does. MySQL documents its server error numbers here:
+// 		https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/go-sql-driver/mysql"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// MySQL error numbers
+const (
+	mysqlDuplicateEntry         = 1062
+	mysqlNoSuchTable            = 1146
+	mysqlDataTooLong            = 1406
+	mysqlForeignKeyViolation    = 1452
+	mysqlForeignKeyParentExists = 1451
+)
+
+// Error message format strings
+const (
+	foreignKeyViolation = "referenced entity does not exist (%s)"
+	foreignKeyInUse     = "entity is still referenced (%s)"
+	valueTooLong        = "value is too long (%s)"
+	defaultMySQLError   = "failed database operation with %s"
+)
+
+type mysqlErrorTransformerMetrics struct {
+	Scope               promutils.Scope
+	NotFound            prometheus.Counter
+	GormError           prometheus.Counter
+	AlreadyExistsError  prometheus.Counter
+	UndefinedTable      prometheus.Counter
+	ForeignKeyViolation prometheus.Counter
+	ValueTooLong        prometheus.Counter
+	MySQLError          prometheus.Counter
+}
+
+type mysqlErrorTransformer struct {
+	metrics mysqlErrorTransformerMetrics
+}
+
+func (m *mysqlErrorTransformer) fromGormError(err error) flyteAdminErrors.FlyteAdminError {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		m.metrics.NotFound.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "entry not found")
+	}
+	m.metrics.GormError.Inc()
+	return flyteAdminErrors.NewFlyteAdminErrorf(codes.Internal, unexpectedType, err)
+}
+
+func (m *mysqlErrorTransformer) ToFlyteAdminError(err error) flyteAdminErrors.FlyteAdminError {
+	var mysqlError *mysql.MySQLError
+	if !errors.As(err, &mysqlError) {
+		logger.Debugf(context.Background(), "Unable to cast to mysql.MySQLError. Error type: [%v]",
+			reflect.TypeOf(err))
+		return m.fromGormError(err)
+	}
+
+	switch mysqlError.Number {
+	case mysqlDuplicateEntry:
+		m.metrics.AlreadyExistsError.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.AlreadyExists, uniqueConstraintViolation, mysqlError.Message)
+	case mysqlNoSuchTable:
+		m.metrics.UndefinedTable.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, unsupportedTableOperation, mysqlError.Message)
+	case mysqlForeignKeyViolation:
+		m.metrics.ForeignKeyViolation.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, foreignKeyViolation, mysqlError.Message)
+	case mysqlForeignKeyParentExists:
+		m.metrics.ForeignKeyViolation.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.FailedPrecondition, foreignKeyInUse, mysqlError.Message)
+	case mysqlDataTooLong:
+		m.metrics.ValueTooLong.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, valueTooLong, mysqlError.Message)
+	default:
+		m.metrics.MySQLError.Inc()
+		return flyteAdminErrors.NewFlyteAdminError(codes.Unknown, fmt.Sprintf(defaultMySQLError, mysqlError.Message))
+	}
+}
+
+func NewMySQLErrorTransformer(scope promutils.Scope) ErrorTransformer {
+	metrics := mysqlErrorTransformerMetrics{
+		Scope: scope,
+		NotFound: scope.MustNewCounter("not_found",
+			"count of all queries for entities not found in the database"),
+		GormError: scope.MustNewCounter("gorm_error",
+			"unspecified gorm error returned by database operation"),
+		AlreadyExistsError: scope.MustNewCounter("already_exists",
+			"counts for when a unique constraint was violated in a database operation"),
+		UndefinedTable: scope.MustNewCounter("undefined_table",
+			"database operations referencing an undefined table"),
+		ForeignKeyViolation: scope.MustNewCounter("foreign_key_violation",
+			"counts for when a foreign key constraint was violated in a database operation"),
+		ValueTooLong: scope.MustNewCounter("value_too_long",
+			"counts for when a value didn't fit its column in a database operation"),
+		MySQLError: scope.MustNewCounter("mysql_error",
+			"unspecified mysql error returned in a database operation"),
+	}
+	return &mysqlErrorTransformer{
+		metrics: metrics,
+	}
+}
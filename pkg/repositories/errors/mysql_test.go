@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	flyteAdminError "github.com/flyteorg/flyteadmin/pkg/errors"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+func TestMySQLToFlyteAdminError(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{
+			name:    "duplicate entry",
+			err:     &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'p-d-n' for key 'PRIMARY'"},
+			code:    codes.AlreadyExists,
+			message: "value with matching already exists (Duplicate entry 'p-d-n' for key 'PRIMARY')",
+		},
+		{
+			name:    "foreign key violation",
+			err:     &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"},
+			code:    codes.InvalidArgument,
+			message: "referenced entity does not exist (Cannot add or update a child row)",
+		},
+		{
+			name:    "referenced parent",
+			err:     &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row"},
+			code:    codes.FailedPrecondition,
+			message: "entity is still referenced (Cannot delete or update a parent row)",
+		},
+		{
+			name:    "value too long",
+			err:     &mysql.MySQLError{Number: 1406, Message: "Data too long for column 'name' at row 1"},
+			code:    codes.InvalidArgument,
+			message: "value is too long (Data too long for column 'name' at row 1)",
+		},
+		{
+			name:    "undefined table",
+			err:     &mysql.MySQLError{Number: 1146, Message: "Table 'flyte.foo' doesn't exist"},
+			code:    codes.InvalidArgument,
+			message: "cannot query with specified table attributes: Table 'flyte.foo' doesn't exist",
+		},
+		{
+			name:    "unrecognized error",
+			err:     &mysql.MySQLError{Number: 1213, Message: "Deadlock found"},
+			code:    codes.Unknown,
+			message: "failed database operation with Deadlock found",
+		},
+		{
+			name:    "wrapped error",
+			err:     fmt.Errorf("failed to create: %w", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}),
+			code:    codes.AlreadyExists,
+			message: "value with matching already exists (Duplicate entry)",
+		},
+		{
+			name:    "record not found",
+			err:     gorm.ErrRecordNotFound,
+			code:    codes.NotFound,
+			message: "entry not found",
+		},
+		{
+			name:    "other error",
+			err:     errors.New("foo"),
+			code:    codes.Internal,
+			message: "unexpected error type for: foo",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transformedErr := NewMySQLErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(tc.err)
+			assert.Equal(t, tc.code, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+			assert.Equal(t, tc.message, transformedErr.Error())
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flytestdlib/logger"
@@ -25,7 +26,14 @@ import (
 // Postgres error codes
 const (
 	uniqueConstraintViolationCode = "23505"
+	foreignKeyViolationCode       = "23503"
+	notNullViolationCode          = "23502"
 	undefinedTable                = "42P01"
+	serializationFailure          = "40001"
+	deadlockDetected              = "40P01"
+	lockNotAvailable              = "55P03"
+	// All connection exception codes belong to this class.
+	connectionExceptionClass = "08"
 )
 
 // Error message format strings
@@ -34,15 +42,30 @@ const (
 	uniqueConstraintViolation = "value with matching already exists (%s)"
 	defaultPgError            = "failed database operation with %s"
 	unsupportedTableOperation = "cannot query with specified table attributes: %s"
+	concurrentUpdateConflict  = "conflicted with a concurrent update, please retry (%s)"
+	databaseUnavailable       = "database is unavailable, please retry (%s)"
+	referencedEntityMissing   = "referenced %s does not exist (%s)"
+	referencedEntityInUse     = "%s is still referenced by a %s (%s)"
+	missingRequiredValue      = "%s of the %s is required"
 )
 
+// The detail of a foreign key violation raised by deleting, or updating the key of, a row which is still referenced, as
+// opposed to raised by referencing a row which doesn't exist.
+const foreignKeyInUseDetail = "is still referenced"
+
 type postgresErrorTransformerMetrics struct {
-	Scope              promutils.Scope
-	NotFound           prometheus.Counter
-	GormError          prometheus.Counter
-	AlreadyExistsError prometheus.Counter
-	UndefinedTable     prometheus.Counter
-	PostgresError      prometheus.Counter
+	Scope               promutils.Scope
+	NotFound            prometheus.Counter
+	GormError           prometheus.Counter
+	AlreadyExistsError  prometheus.Counter
+	UndefinedTable      prometheus.Counter
+	ForeignKeyViolation prometheus.Counter
+	NotNullViolation    prometheus.Counter
+	Deadlock            prometheus.Counter
+	SerializationError  prometheus.Counter
+	LockNotAvailable    prometheus.Counter
+	ConnectionError     prometheus.Counter
+	PostgresError       prometheus.Counter
 }
 
 type postgresErrorTransformer struct {
@@ -61,6 +84,28 @@ func (p *postgresErrorTransformer) fromGormError(err error) flyteAdminErrors.Fly
 	}
 }
 
+// Explains the violation in terms of the entities the constraint relates, when they're known.
+func (p *postgresErrorTransformer) fromForeignKeyViolation(pqError *pgconn.PgError) flyteAdminErrors.FlyteAdminError {
+	p.metrics.ForeignKeyViolation.Inc()
+	detail := pqError.Detail
+	if len(detail) == 0 {
+		detail = pqError.Message
+	}
+	entities, known := foreignKeyConstraintEntities[pqError.ConstraintName]
+	if strings.Contains(pqError.Detail, foreignKeyInUseDetail) {
+		if !known {
+			return flyteAdminErrors.NewFlyteAdminErrorf(codes.FailedPrecondition, foreignKeyInUse, detail)
+		}
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.FailedPrecondition, referencedEntityInUse,
+			entities.Referenced, entities.Referencing, detail)
+	}
+	if !known {
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, foreignKeyViolation, detail)
+	}
+	return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, referencedEntityMissing, entities.Referenced,
+		detail)
+}
+
 func (p *postgresErrorTransformer) ToFlyteAdminError(err error) flyteAdminErrors.FlyteAdminError {
 	if unwrappedErr := errors.Unwrap(err); unwrappedErr != nil {
 		err = unwrappedErr
@@ -80,7 +125,30 @@ func (p *postgresErrorTransformer) ToFlyteAdminError(err error) flyteAdminErrors
 	case undefinedTable:
 		p.metrics.UndefinedTable.Inc()
 		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, unsupportedTableOperation, pqError.Message)
+	case foreignKeyViolationCode:
+		return p.fromForeignKeyViolation(pqError)
+	case notNullViolationCode:
+		p.metrics.NotNullViolation.Inc()
+		if len(pqError.ColumnName) == 0 {
+			return flyteAdminErrors.NewFlyteAdminError(codes.InvalidArgument, fmt.Sprintf(defaultPgError, pqError.Message))
+		}
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, missingRequiredValue, pqError.ColumnName,
+			getTableEntity(pqError.TableName))
+	// The errors below abort the transaction without it having had an effect, so that it may be retried.
+	case deadlockDetected:
+		p.metrics.Deadlock.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.Aborted, concurrentUpdateConflict, pqError.Message)
+	case serializationFailure:
+		p.metrics.SerializationError.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.Aborted, concurrentUpdateConflict, pqError.Message)
+	case lockNotAvailable:
+		p.metrics.LockNotAvailable.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.Aborted, concurrentUpdateConflict, pqError.Message)
 	default:
+		if strings.HasPrefix(pqError.Code, connectionExceptionClass) {
+			p.metrics.ConnectionError.Inc()
+			return flyteAdminErrors.NewFlyteAdminErrorf(codes.Unavailable, databaseUnavailable, pqError.Message)
+		}
 		p.metrics.PostgresError.Inc()
 		return flyteAdminErrors.NewFlyteAdminError(codes.Unknown, fmt.Sprintf(defaultPgError, pqError.Message))
 	}
@@ -97,6 +165,18 @@ func NewPostgresErrorTransformer(scope promutils.Scope) ErrorTransformer {
 			"counts for when a unique constraint was violated in a database operation"),
 		UndefinedTable: scope.MustNewCounter("undefined_table",
 			"database operations referencing an undefined table"),
+		ForeignKeyViolation: scope.MustNewCounter("foreign_key_violation",
+			"counts for when a foreign key constraint was violated in a database operation"),
+		NotNullViolation: scope.MustNewCounter("not_null_violation",
+			"counts for when a required value was missing in a database operation"),
+		Deadlock: scope.MustNewCounter("deadlock",
+			"counts for when a database operation was aborted to resolve a deadlock"),
+		SerializationError: scope.MustNewCounter("serialization_failure",
+			"counts for when a database operation couldn't be serialized with concurrent ones"),
+		LockNotAvailable: scope.MustNewCounter("lock_not_available",
+			"counts for when a database operation couldn't acquire a lock"),
+		ConnectionError: scope.MustNewCounter("connection_error",
+			"counts for when a database operation failed to reach the database"),
 		PostgresError: scope.MustNewCounter("postgres_error",
 			"unspecified postgres error returned in a database operation"),
 	}
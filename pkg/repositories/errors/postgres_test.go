@@ -40,3 +40,123 @@ func TestToFlyteAdminError_UnrecognizedPostgresError(t *testing.T) {
 	assert.Equal(t, "failed database operation with message",
 		transformedErr.(flyteAdminError.FlyteAdminError).Error())
 }
+
+func TestToFlyteAdminError_RetryablePostgresErrors(t *testing.T) {
+	for code, expectedCode := range map[string]codes.Code{
+		"40P01": codes.Aborted,
+		"40001": codes.Aborted,
+		"55P03": codes.Aborted,
+		"08006": codes.Unavailable,
+		"08000": codes.Unavailable,
+	} {
+		t.Run(code, func(t *testing.T) {
+			err := &pgconn.PgError{
+				Code:    code,
+				Message: "message",
+			}
+			transformedErr := NewPostgresErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(err)
+			assert.Equal(t, expectedCode, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+		})
+	}
+}
+
+func TestToFlyteAdminError_Deadlock(t *testing.T) {
+	err := &pgconn.PgError{
+		Code:    "40P01",
+		Message: "deadlock detected",
+	}
+	transformedErr := NewPostgresErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(err)
+	assert.Equal(t, "conflicted with a concurrent update, please retry (deadlock detected)",
+		transformedErr.(flyteAdminError.FlyteAdminError).Error())
+}
+
+func TestToFlyteAdminError_ForeignKeyViolation(t *testing.T) {
+	for _, test := range []struct {
+		constraint      string
+		detail          string
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			constraint:   "fk_node_executions_child_node_executions",
+			detail:       `Key (parent_id)=(1) is not present in table "node_executions".`,
+			expectedCode: codes.InvalidArgument,
+			expectedMessage: `referenced parent node execution does not exist (Key (parent_id)=(1) is not present in ` +
+				`table "node_executions".)`,
+		},
+		{
+			constraint:   "fk_node_executions_launched_execution",
+			detail:       `Key (parent_node_execution_id)=(1) is not present in table "node_executions".`,
+			expectedCode: codes.InvalidArgument,
+			expectedMessage: `referenced parent node execution does not exist (Key (parent_node_execution_id)=(1) is ` +
+				`not present in table "node_executions".)`,
+		},
+		{
+			constraint:   "fk_task_executions_child_node_execution",
+			detail:       `Key (parent_task_execution_id)=(1) is not present in table "task_executions".`,
+			expectedCode: codes.InvalidArgument,
+			expectedMessage: `referenced parent task execution does not exist (Key (parent_task_execution_id)=(1) is ` +
+				`not present in table "task_executions".)`,
+		},
+		{
+			constraint:   "fk_task_executions_child_node_execution",
+			detail:       `Key (id)=(1) is still referenced from table "node_executions".`,
+			expectedCode: codes.FailedPrecondition,
+			expectedMessage: `parent task execution is still referenced by a child node execution (Key (id)=(1) is ` +
+				`still referenced from table "node_executions".)`,
+		},
+		{
+			constraint:      "fk_unknown",
+			detail:          `Key (id)=(1) is not present in table "unknown".`,
+			expectedCode:    codes.InvalidArgument,
+			expectedMessage: `referenced entity does not exist (Key (id)=(1) is not present in table "unknown".)`,
+		},
+		{
+			constraint:      "fk_unknown",
+			detail:          `Key (id)=(1) is still referenced from table "unknown".`,
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: `entity is still referenced (Key (id)=(1) is still referenced from table "unknown".)`,
+		},
+	} {
+		t.Run(test.constraint, func(t *testing.T) {
+			err := &pgconn.PgError{
+				Code:           "23503",
+				Message:        "message",
+				ConstraintName: test.constraint,
+				Detail:         test.detail,
+			}
+			transformedErr := NewPostgresErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(err)
+			assert.Equal(t, test.expectedCode, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+			assert.Equal(t, test.expectedMessage, transformedErr.(flyteAdminError.FlyteAdminError).Error())
+		})
+	}
+}
+
+func TestToFlyteAdminError_ForeignKeyViolationWithoutDetail(t *testing.T) {
+	err := &pgconn.PgError{
+		Code:    "23503",
+		Message: "message",
+	}
+	transformedErr := NewPostgresErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(err)
+	assert.Equal(t, codes.InvalidArgument, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+	assert.Equal(t, "referenced entity does not exist (message)", transformedErr.(flyteAdminError.FlyteAdminError).Error())
+}
+
+func TestToFlyteAdminError_NotNullViolation(t *testing.T) {
+	for table, expectedMessage := range map[string]string{
+		"launch_plans": "workflow_id of the launch plan is required",
+		"unknown":      "workflow_id of the unknown is required",
+	} {
+		t.Run(table, func(t *testing.T) {
+			err := &pgconn.PgError{
+				Code:       "23502",
+				Message:    "message",
+				TableName:  table,
+				ColumnName: "workflow_id",
+			}
+			transformedErr := NewPostgresErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(err)
+			assert.Equal(t, codes.InvalidArgument, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+			assert.Equal(t, expectedMessage, transformedErr.(flyteAdminError.FlyteAdminError).Error())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// SQLite-specific implementation of an ErrorTransformer.
+// This errors utility translates the SQLite errors into the same internal error types the Postgres error transformer
+// does. The errors are recognized by their messages, which SQLite keeps stable, rather than by the error type of the
+// driver, so that they're recognized whichever driver opened the database. SQLite documents its result codes here:
+//
+//	https://www.sqlite.org/rescode.html
+package errors
+
+import (
+	"errors"
+	"strings"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+// Fragments of SQLite error messages
+const (
+	sqliteUniqueConstraintFailed     = "UNIQUE constraint failed"
+	sqlitePrimaryKeyConstraintFailed = "PRIMARY KEY constraint failed"
+	sqliteForeignKeyConstraintFailed = "FOREIGN KEY constraint failed"
+	sqliteNoSuchTable                = "no such table"
+)
+
+// Error message format strings
+const (
+	defaultSQLiteError = "failed database operation with %s"
+)
+
+type sqliteErrorTransformerMetrics struct {
+	Scope               promutils.Scope
+	NotFound            prometheus.Counter
+	AlreadyExistsError  prometheus.Counter
+	UndefinedTable      prometheus.Counter
+	ForeignKeyViolation prometheus.Counter
+	SQLiteError         prometheus.Counter
+}
+
+type sqliteErrorTransformer struct {
+	metrics sqliteErrorTransformerMetrics
+}
+
+func (s *sqliteErrorTransformer) ToFlyteAdminError(err error) flyteAdminErrors.FlyteAdminError {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.metrics.NotFound.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.NotFound, "entry not found")
+	}
+
+	message := err.Error()
+	switch {
+	case strings.Contains(message, sqliteUniqueConstraintFailed),
+		strings.Contains(message, sqlitePrimaryKeyConstraintFailed):
+		s.metrics.AlreadyExistsError.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.AlreadyExists, uniqueConstraintViolation, message)
+	case strings.Contains(message, sqliteForeignKeyConstraintFailed):
+		// SQLite doesn't tell whether the referenced row is missing or the row is still referenced.
+		s.metrics.ForeignKeyViolation.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, foreignKeyViolation, message)
+	case strings.Contains(message, sqliteNoSuchTable):
+		s.metrics.UndefinedTable.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, unsupportedTableOperation, message)
+	default:
+		s.metrics.SQLiteError.Inc()
+		return flyteAdminErrors.NewFlyteAdminErrorf(codes.Unknown, defaultSQLiteError, message)
+	}
+}
+
+func NewSQLiteErrorTransformer(scope promutils.Scope) ErrorTransformer {
+	metrics := sqliteErrorTransformerMetrics{
+		Scope: scope,
+		NotFound: scope.MustNewCounter("not_found",
+			"count of all queries for entities not found in the database"),
+		AlreadyExistsError: scope.MustNewCounter("already_exists",
+			"counts for when a unique constraint was violated in a database operation"),
+		UndefinedTable: scope.MustNewCounter("undefined_table",
+			"database operations referencing an undefined table"),
+		ForeignKeyViolation: scope.MustNewCounter("foreign_key_violation",
+			"counts for when a foreign key constraint was violated in a database operation"),
+		SQLiteError: scope.MustNewCounter("sqlite_error",
+			"unspecified sqlite error returned in a database operation"),
+	}
+	return &sqliteErrorTransformer{
+		metrics: metrics,
+	}
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	flyteAdminError "github.com/flyteorg/flyteadmin/pkg/errors"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+func TestSQLiteToFlyteAdminError(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{
+			name:    "unique constraint",
+			err:     errors.New("UNIQUE constraint failed: tasks.project, tasks.domain, tasks.name, tasks.version"),
+			code:    codes.AlreadyExists,
+			message: "value with matching already exists (UNIQUE constraint failed: tasks.project, tasks.domain, tasks.name, tasks.version)",
+		},
+		{
+			name:    "primary key constraint",
+			err:     errors.New("PRIMARY KEY constraint failed"),
+			code:    codes.AlreadyExists,
+			message: "value with matching already exists (PRIMARY KEY constraint failed)",
+		},
+		{
+			name:    "foreign key constraint",
+			err:     errors.New("FOREIGN KEY constraint failed"),
+			code:    codes.InvalidArgument,
+			message: "referenced entity does not exist (FOREIGN KEY constraint failed)",
+		},
+		{
+			name:    "undefined table",
+			err:     errors.New("no such table: foo"),
+			code:    codes.InvalidArgument,
+			message: "cannot query with specified table attributes: no such table: foo",
+		},
+		{
+			name:    "wrapped error",
+			err:     fmt.Errorf("failed to create task: %w", errors.New("UNIQUE constraint failed: tasks.name")),
+			code:    codes.AlreadyExists,
+			message: "value with matching already exists (failed to create task: UNIQUE constraint failed: tasks.name)",
+		},
+		{
+			name:    "not found",
+			err:     gorm.ErrRecordNotFound,
+			code:    codes.NotFound,
+			message: "entry not found",
+		},
+		{
+			name:    "unrecognized error",
+			err:     errors.New("database is locked"),
+			code:    codes.Unknown,
+			message: "failed database operation with database is locked",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transformedErr := NewSQLiteErrorTransformer(mockScope.NewTestScope()).ToFlyteAdminError(tc.err)
+			assert.Equal(t, tc.code, transformedErr.(flyteAdminError.FlyteAdminError).Code())
+			assert.Equal(t, tc.message, transformedErr.Error())
+		})
+	}
+}
@@ -1,23 +1,44 @@
 package repositories
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	schedulerInterfaces "github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"gorm.io/gorm"
 )
 
 type RepoConfig int32
 
 const (
 	POSTGRES RepoConfig = 0
+	MYSQL    RepoConfig = 1
+	SQLITE   RepoConfig = 2
 )
 
 var RepositoryConfigurationName = map[int32]string{
 	0: "POSTGRES",
+	1: "MYSQL",
+	2: "SQLITE",
+}
+
+// Returns the repository config matching the database dialect configured.
+func GetRepoConfig(dbConfig config.DbConfig) (RepoConfig, error) {
+	switch strings.ToLower(dbConfig.Dialect) {
+	case "", config.Postgres:
+		return POSTGRES, nil
+	case config.MySQL:
+		return MYSQL, nil
+	case config.SQLite:
+		return SQLITE, nil
+	default:
+		return 0, fmt.Errorf("unsupported database dialect [%s]", dbConfig.Dialect)
+	}
 }
 
 // The RepositoryInterface indicates the methods that each Repository must support.
@@ -35,23 +56,78 @@ type RepositoryInterface interface {
 	NodeExecutionEventRepo() interfaces.NodeExecutionEventRepoInterface
 	TaskExecutionRepo() interfaces.TaskExecutionRepoInterface
 	NamedEntityRepo() interfaces.NamedEntityRepoInterface
+	DescriptionEntityRepo() interfaces.DescriptionEntityRepoInterface
+	ScheduleCheckpointRepo() interfaces.ScheduleCheckpointRepoInterface
+	JobLeaseRepo() interfaces.JobLeaseRepoInterface
 	SchedulableEntityRepo() schedulerInterfaces.SchedulableEntityRepoInterface
 	ScheduleEntitiesSnapshotRepo() schedulerInterfaces.ScheduleEntitiesSnapShotRepoInterface
+	ScheduleFiringRepo() schedulerInterfaces.ScheduleFiringRepoInterface
+
+	// HealthCheck issues a trivial query to verify the database is reachable.
+	HealthCheck(ctx context.Context) error
 }
 
 func GetRepository(repoType RepoConfig, dbConfig config.DbConfig, scope promutils.Scope) RepositoryInterface {
+	var dialectScope promutils.Scope
+	var configProvider, readReplicaConfigProvider config.DbConnectionConfigProvider
+	var errorTransformer errors.ErrorTransformer
 	switch repoType {
 	case POSTGRES:
-		postgresScope := scope.NewSubScope("postgres")
-		db, err := config.OpenDbConnection(config.NewPostgresConfigProvider(dbConfig, postgresScope))
+		dialectScope = scope.NewSubScope("postgres")
+		configProvider = config.NewPostgresConfigProvider(dbConfig, dialectScope)
+		readReplicaConfigProvider = config.NewPostgresReadReplicaConfigProvider(dbConfig, dialectScope)
+		errorTransformer = errors.NewPostgresErrorTransformer(dialectScope.NewSubScope("errors"))
+	case MYSQL:
+		dialectScope = scope.NewSubScope("mysql")
+		configProvider = config.NewMySQLConfigProvider(dbConfig, dialectScope)
+		readReplicaConfigProvider = config.NewMySQLReadReplicaConfigProvider(dbConfig, dialectScope)
+		errorTransformer = errors.NewMySQLErrorTransformer(dialectScope.NewSubScope("errors"))
+	case SQLITE:
+		dialectScope = scope.NewSubScope("sqlite")
+		configProvider = config.NewSQLiteConfigProvider(dbConfig, dialectScope)
+		errorTransformer = errors.NewSQLiteErrorTransformer(dialectScope.NewSubScope("errors"))
+	default:
+		panic(fmt.Sprintf("Invalid repoType %v", repoType))
+	}
+
+	db, err := config.OpenDbConnection(configProvider)
+	if err != nil {
+		panic(err)
+	}
+	if err = db.Use(config.NewQueryTracer()); err != nil {
+		panic(err)
+	}
+	if repoType == SQLITE {
+		// SQLite only supports a single writer at a time.
+		if err = db.Use(config.NewWriteSerializer()); err != nil {
+			panic(err)
+		}
+	}
+	emitPoolStats(db, dbConfig, dialectScope.NewSubScope("pool"))
+	var readReplica *gorm.DB
+	if len(dbConfig.ReadReplica.DSN) > 0 {
+		if readReplicaConfigProvider == nil {
+			panic(fmt.Sprintf("Read replicas aren't supported for repoType %v", repoType))
+		}
+		readReplica, err = config.OpenDbConnection(readReplicaConfigProvider)
 		if err != nil {
 			panic(err)
 		}
-		return NewPostgresRepo(
-			db,
-			errors.NewPostgresErrorTransformer(postgresScope.NewSubScope("errors")),
-			postgresScope.NewSubScope("repositories"))
-	default:
-		panic(fmt.Sprintf("Invalid repoType %v", repoType))
+		if err = db.Use(config.NewReadReplicaRouter(readReplica)); err != nil {
+			panic(err)
+		}
+		emitPoolStats(readReplica, dbConfig, dialectScope.NewSubScope("read_replica_pool"))
+	}
+	// The gorm repositories serve either dialect.
+	return NewPostgresRepoWithReadReplica(db, readReplica, errorTransformer, dialectScope.NewSubScope("repositories"))
+}
+
+// The pools are kept open for the lifetime of the process, and so are their stats emitted.
+func emitPoolStats(db *gorm.DB, dbConfig config.DbConfig, scope promutils.Scope) {
+	if dbConfig.PoolStatsInterval <= 0 {
+		return
+	}
+	if err := config.EmitConnectionPoolStats(context.Background(), db, scope, dbConfig.PoolStatsInterval); err != nil {
+		panic(err)
 	}
 }
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepoConfig(t *testing.T) {
+	repoConfig, err := GetRepoConfig(config.DbConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, POSTGRES, repoConfig)
+
+	repoConfig, err = GetRepoConfig(config.DbConfig{Dialect: "MySQL"})
+	assert.NoError(t, err)
+	assert.Equal(t, MYSQL, repoConfig)
+
+	repoConfig, err = GetRepoConfig(config.DbConfig{Dialect: "sqlite"})
+	assert.NoError(t, err)
+	assert.Equal(t, SQLITE, repoConfig)
+
+	_, err = GetRepoConfig(config.DbConfig{Dialect: "oracle"})
+	assert.EqualError(t, err, "unsupported database dialect [oracle]")
+}
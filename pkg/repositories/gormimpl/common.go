@@ -1,12 +1,14 @@
 package gormimpl
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 
 	"google.golang.org/grpc/codes"
 	"gorm.io/gorm"
@@ -21,6 +23,8 @@ const State = "state"
 const ID = "id"
 
 const executionTableName = "executions"
+const executionTagTableName = "execution_tags"
+const executionEventTableName = "execution_events"
 const namedEntityMetadataTableName = "named_entity_metadata"
 const nodeExecutionTableName = "node_executions"
 const nodeExecutionEventTableName = "node_event_executions"
@@ -30,6 +34,11 @@ const taskTableName = "tasks"
 const limit = "limit"
 const filters = "filters"
 
+// Names of the gorm dialectors, for the few queries which differ between databases.
+const postgresDialect = "postgres"
+const mysqlDialect = "mysql"
+const sqliteDialect = "sqlite"
+
 var identifierGroupBy = fmt.Sprintf("%s, %s, %s", Project, Domain, Name)
 
 var entityToTableName = map[common.Entity]string{
@@ -42,6 +51,8 @@ var entityToTableName = map[common.Entity]string{
 	common.Workflow:            "workflows",
 	common.NamedEntity:         "entities",
 	common.NamedEntityMetadata: "named_entity_metadata",
+	common.DescriptionEntity:   "description_entities",
+	common.ExecutionTag:        "execution_tags",
 }
 
 var innerJoinNodeExecToNodeEvents = fmt.Sprintf(
@@ -112,3 +123,55 @@ func applyScopedFilters(tx *gorm.DB, inlineFilters []common.InlineFilter, mapFil
 	}
 	return tx, nil
 }
+
+// Whether to order and paginate the list by creation time and id, see common.KeysetSortKey.
+func isKeysetPaginated(input interfaces.ListResourceInput) bool {
+	return input.SortParameter != nil && input.SortParameter.GetKey() == common.KeysetSortKey
+}
+
+// Orders rows by creation time and then id, and continues past the cursor if there is one. Comparing the tuple lets
+// postgres seek an index on (created_at, id) to the start of the page rather than scanning and discarding an offset.
+func applyKeysetPagination(tx *gorm.DB, tableName string, input interfaces.ListResourceInput) *gorm.DB {
+	createdAt := fmt.Sprintf("%s.created_at", tableName)
+	id := fmt.Sprintf("%s.id", tableName)
+	if cursor := input.KeysetCursor; cursor != nil {
+		comparison := "<"
+		if cursor.Direction == admin.Sort_ASCENDING {
+			comparison = ">"
+		}
+		tx = tx.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", createdAt, id, comparison), cursor.CreatedAt, cursor.ID)
+	}
+	return tx.Order(input.SortParameter.GetGormOrderExprFor(createdAt)).Order(
+		input.SortParameter.GetGormOrderExprFor(id))
+}
+
+// Queries the row count the database keeps in its statistics for a table.
+var estimatedCountQueries = map[string]string{
+	postgresDialect: "SELECT reltuples::bigint FROM pg_class WHERE relname = ?",
+	mysqlDialect:    "SELECT table_rows FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?",
+}
+
+// Estimates the number of rows in a table from the statistics the database keeps for the query planner, which is much
+// cheaper than counting a large table but only as current as its last vacuum or analyze. Tables without statistics
+// yet are counted.
+func estimateCount(ctx context.Context, db *gorm.DB, metrics gormMetrics, errorTransformer errors.ErrorTransformer,
+	tableName string) (int64, error) {
+	var estimate int64
+	timer := metrics.CountDuration.Start()
+	defer timer.Stop()
+	tx := db.WithContext(ctx)
+	if query, ok := estimatedCountQueries[db.Dialector.Name()]; ok {
+		tx = tx.Raw(query, tableName).Scan(&estimate)
+	}
+	if tx.Error != nil {
+		return 0, errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	if estimate > 0 {
+		return estimate, nil
+	}
+	var count int64
+	if tx = db.WithContext(ctx).Table(tableName).Count(&count); tx.Error != nil {
+		return 0, errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return count, nil
+}
@@ -0,0 +1,101 @@
+package gormimpl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"gorm.io/gorm"
+
+	flyteAdminDbErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+// Implementation of DescriptionEntityRepoInterface.
+type DescriptionEntityRepo struct {
+	db               *gorm.DB
+	errorTransformer flyteAdminDbErrors.ErrorTransformer
+	metrics          gormMetrics
+}
+
+func (r *DescriptionEntityRepo) Create(ctx context.Context, input models.DescriptionEntity) error {
+	timer := r.metrics.CreateDuration.Start()
+	tx := r.db.WithContext(ctx).Omit("id").Create(&input)
+	timer.Stop()
+	if tx.Error != nil {
+		return r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return nil
+}
+
+func (r *DescriptionEntityRepo) Get(
+	ctx context.Context, input interfaces.GetDescriptionEntityInput) (models.DescriptionEntity, error) {
+	var descriptionEntity models.DescriptionEntity
+	timer := r.metrics.GetDuration.Start()
+	tx := r.db.WithContext(ctx).Where(&models.DescriptionEntity{
+		DescriptionEntityKey: models.DescriptionEntityKey{
+			ResourceType: input.ResourceType,
+			Project:      input.Project,
+			Domain:       input.Domain,
+			Name:         input.Name,
+			Version:      input.Version,
+		},
+	}).Take(&descriptionEntity)
+	timer.Stop()
+	if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		return models.DescriptionEntity{}, flyteAdminDbErrors.GetMissingEntityError("description entity", &core.Identifier{
+			ResourceType: input.ResourceType,
+			Project:      input.Project,
+			Domain:       input.Domain,
+			Name:         input.Name,
+			Version:      input.Version,
+		})
+	}
+	if tx.Error != nil {
+		return models.DescriptionEntity{}, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return descriptionEntity, nil
+}
+
+func (r *DescriptionEntityRepo) List(
+	ctx context.Context, input interfaces.ListResourceInput) (interfaces.DescriptionEntityCollectionOutput, error) {
+	// First validate input.
+	if err := ValidateListInput(input); err != nil {
+		return interfaces.DescriptionEntityCollectionOutput{}, err
+	}
+	var descriptionEntities []models.DescriptionEntity
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
+
+	// Apply filters
+	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
+	if err != nil {
+		return interfaces.DescriptionEntityCollectionOutput{}, err
+	}
+	// Apply sort ordering.
+	if input.SortParameter != nil {
+		tx = tx.Order(input.SortParameter.GetGormOrderExpr())
+	}
+	timer := r.metrics.ListDuration.Start()
+	tx.Find(&descriptionEntities)
+	timer.Stop()
+	if tx.Error != nil {
+		return interfaces.DescriptionEntityCollectionOutput{}, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return interfaces.DescriptionEntityCollectionOutput{
+		Entities: descriptionEntities,
+	}, nil
+}
+
+// Returns an instance of DescriptionEntityRepoInterface
+func NewDescriptionEntityRepo(
+	db *gorm.DB, errorTransformer flyteAdminDbErrors.ErrorTransformer,
+	scope promutils.Scope) interfaces.DescriptionEntityRepoInterface {
+	metrics := newMetrics(scope)
+	return &DescriptionEntityRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		metrics:          metrics,
+	}
+}
@@ -0,0 +1,131 @@
+package gormimpl
+
+import (
+	"context"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+const longDescription = "# Workflow\nDoes things."
+
+func getMockDescriptionEntityResponseFromDb(version string) map[string]interface{} {
+	descriptionEntity := make(map[string]interface{})
+	descriptionEntity["resource_type"] = core.ResourceType_WORKFLOW
+	descriptionEntity["project"] = project
+	descriptionEntity["domain"] = domain
+	descriptionEntity["name"] = name
+	descriptionEntity["version"] = version
+	descriptionEntity["long_description"] = []byte(longDescription)
+	descriptionEntity["content_type"] = "text/markdown"
+	descriptionEntity["source_code_link"] = "https://github.com/flyteorg/flytesnacks"
+	return descriptionEntity
+}
+
+func TestCreateDescriptionEntity(t *testing.T) {
+	descriptionEntityRepo := NewDescriptionEntityRepo(
+		GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	mockQuery := GlobalMock.NewMock()
+	mockQuery.WithQuery(`INSERT INTO "description_entities"`)
+
+	err := descriptionEntityRepo.Create(context.Background(), models.DescriptionEntity{
+		DescriptionEntityKey: models.DescriptionEntityKey{
+			ResourceType: core.ResourceType_WORKFLOW,
+			Project:      project,
+			Domain:       domain,
+			Name:         name,
+			Version:      version,
+		},
+		LongDescription: []byte(longDescription),
+		ContentType:     "text/markdown",
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+}
+
+func TestGetDescriptionEntity(t *testing.T) {
+	descriptionEntityRepo := NewDescriptionEntityRepo(
+		GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	input := interfaces.GetDescriptionEntityInput{
+		ResourceType: core.ResourceType_WORKFLOW,
+		Project:      project,
+		Domain:       domain,
+		Name:         name,
+		Version:      version,
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	_, err := descriptionEntityRepo.Get(context.Background(), input)
+	assert.Equal(t, codes.NotFound, err.(adminErrors.FlyteAdminError).Code())
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "description_entities" WHERE "description_entities"."resource_type" = $1 AND "description_entities"."project" = $2 AND "description_entities"."domain" = $3 AND "description_entities"."name" = $4 AND "description_entities"."version" = $5 LIMIT 1`).
+		WithReply([]map[string]interface{}{getMockDescriptionEntityResponseFromDb(version)})
+	output, err := descriptionEntityRepo.Get(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ResourceType_WORKFLOW, output.ResourceType)
+	assert.Equal(t, version, output.Version)
+	assert.Equal(t, []byte(longDescription), output.LongDescription)
+	assert.Equal(t, "text/markdown", output.ContentType)
+	assert.Equal(t, "https://github.com/flyteorg/flytesnacks", output.SourceCodeLink)
+}
+
+func TestListDescriptionEntities(t *testing.T) {
+	descriptionEntityRepo := NewDescriptionEntityRepo(
+		GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	mockQuery := GlobalMock.NewMock()
+	mockQuery.WithQuery(
+		`SELECT * FROM "description_entities" WHERE resource_type = $1 AND project = $2 AND domain = $3 AND name = $4 ORDER BY created_at desc LIMIT 10`).
+		WithReply([]map[string]interface{}{
+			getMockDescriptionEntityResponseFromDb("v1"),
+			getMockDescriptionEntityResponseFromDb("v2"),
+		})
+
+	sortParameter, _ := common.NewSortParameter(admin.Sort{
+		Direction: admin.Sort_DESCENDING,
+		Key:       "created_at",
+	})
+	output, err := descriptionEntityRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.DescriptionEntity, "resource_type", core.ResourceType_WORKFLOW),
+			getEqualityFilter(common.DescriptionEntity, "project", project),
+			getEqualityFilter(common.DescriptionEntity, "domain", domain),
+			getEqualityFilter(common.DescriptionEntity, "name", name),
+		},
+		SortParameter: sortParameter,
+		Limit:         10,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+	assert.Len(t, output.Entities, 2)
+	assert.Equal(t, "v1", output.Entities[0].Version)
+	assert.Equal(t, "v2", output.Entities[1].Version)
+}
+
+func TestListDescriptionEntities_MissingParameters(t *testing.T) {
+	descriptionEntityRepo := NewDescriptionEntityRepo(
+		GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	_, err := descriptionEntityRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.DescriptionEntity, "name", name),
+		},
+	})
+	assert.EqualError(t, err, "missing and/or invalid parameters: limit")
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	adminErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
@@ -13,6 +16,128 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var existsExecutionTagFmt = fmt.Sprintf(
+	"EXISTS (SELECT 1 FROM %s WHERE %s.execution_project = %s.execution_project AND "+
+		"%s.execution_domain = %s.execution_domain AND %s.execution_name = %s.execution_name AND %%s)",
+	executionTagTableName, executionTagTableName, executionTableName, executionTagTableName, executionTableName,
+	executionTagTableName, executionTableName)
+
+// Terminated executions end with their last event, executions which are still going end now.
+var executionEndExpr = fmt.Sprintf(
+	"CASE WHEN %s.phase IN ('%s', '%s', '%s', '%s') THEN %s.execution_updated_at ELSE %%s END",
+	executionTableName, core.WorkflowExecution_SUCCEEDED, core.WorkflowExecution_FAILED,
+	core.WorkflowExecution_TIMED_OUT, core.WorkflowExecution_ABORTED, executionTableName)
+
+// The duration of executions, in each dialect's way of subtracting timestamps.
+var executionDurationExprs = map[string]string{
+	postgresDialect: fmt.Sprintf("(%s - %s.execution_created_at)",
+		fmt.Sprintf(executionEndExpr, "NOW()"), executionTableName),
+	mysqlDialect: fmt.Sprintf("TIMESTAMPDIFF(MICROSECOND, %s.execution_created_at, %s)",
+		executionTableName, fmt.Sprintf(executionEndExpr, "NOW()")),
+	sqliteDialect: fmt.Sprintf("(julianday(%s) - julianday(%s.execution_created_at))",
+		fmt.Sprintf(executionEndExpr, "CURRENT_TIMESTAMP"), executionTableName),
+}
+
+const durationSortKey = "duration"
+
+// Sort keys which don't directly name an executions column, mapped to what they sort by. The last_updated_at key
+// refers to the time of the last execution event, as shown in the execution closure, whereas updated_at keeps sorting
+// by the last write of the row.
+var executionSortKeyExpressions = map[string]string{
+	"last_updated_at": fmt.Sprintf("%s.execution_updated_at", executionTableName),
+}
+
+func getExecutionSortKeyExpression(dialect, key string) (string, bool) {
+	if key == durationSortKey {
+		if expression, ok := executionDurationExprs[dialect]; ok {
+			return expression, true
+		}
+		return executionDurationExprs[postgresDialect], true
+	}
+	expression, ok := executionSortKeyExpressions[key]
+	return expression, ok
+}
+
+// Qualifies sort keys naming an executions column, which would be ambiguous once the tables filtered on are joined.
+func getExecutionSortColumn(key string) string {
+	if strings.Contains(key, ".") {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", executionTableName, key)
+}
+
+// Joins the tables filtered on and applies the filters, alike for listing and counting executions.
+func applyExecutionFilters(tx *gorm.DB, filters []common.InlineFilter, mapFilters []common.MapFilter,
+	joinTableEntities map[common.Entity]bool) (*gorm.DB, error) {
+	// And add join condition as required by user-specified filters (which can potentially include join table attrs).
+	if ok := joinTableEntities[common.LaunchPlan]; ok {
+		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.launch_plan_id = %s.id",
+			launchPlanTableName, executionTableName, launchPlanTableName))
+	}
+	// Filtering on named entity metadata refers to the state of the workflow the execution was launched from. Lists
+	// are filtered on it by default, so unless the workflow itself is filtered on it's left joined, which keeps the
+	// executions without a workflow row.
+	if joinTableEntities[common.Workflow] {
+		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.workflow_id = %s.id",
+			workflowTableName, executionTableName, workflowTableName))
+	} else if joinTableEntities[common.NamedEntityMetadata] {
+		tx = tx.Joins(fmt.Sprintf("LEFT JOIN %s ON %s.workflow_id = %s.id",
+			workflowTableName, executionTableName, workflowTableName))
+	}
+	if ok := joinTableEntities[common.NamedEntityMetadata]; ok {
+		tx = tx.Joins(leftJoinWorkflowNameToMetadata)
+	}
+	if ok := joinTableEntities[common.Task]; ok {
+		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.task_id = %s.id",
+			taskTableName, executionTableName, taskTableName))
+	}
+
+	// Tag filters match executions with at least one matching tag. Unlike a join, the subquery never returns an
+	// execution once per matching tag, which would also throw off the page size.
+	inlineFilters := make([]common.InlineFilter, 0, len(filters))
+	for _, filter := range filters {
+		if filter.GetEntity() != common.ExecutionTag {
+			inlineFilters = append(inlineFilters, filter)
+			continue
+		}
+		gormQueryExpr, err := filter.GetGormJoinTableQueryExpr(executionTagTableName)
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Where(fmt.Sprintf(existsExecutionTagFmt, gormQueryExpr.Query), gormQueryExpr.Args)
+	}
+
+	return applyScopedFilters(tx, inlineFilters, mapFilters)
+}
+
+// The tables holding the children of executions, keyed by the execution they belong to, in the order they're purged.
+// Node executions are purged ahead of the task executions which launched them.
+var executionChildTableNames = []string{
+	executionTagTableName,
+	executionEventTableName,
+	entityToTableName[common.NodeExecutionEvent],
+	nodeExecutionTableName,
+	taskExecutionTableName,
+}
+
+// Selects the rows of an execution child table which belong to the executions of a batch.
+const executionChildrenOfBatchFmt = "EXISTS (SELECT 1 FROM executions WHERE executions.id IN (?) AND " +
+	"executions.execution_project = %[1]s.execution_project AND " +
+	"executions.execution_domain = %[1]s.execution_domain AND executions.execution_name = %[1]s.execution_name)"
+
+// Executions which other executions were recovered or relaunched from, or launched by one of the nodes of, are left
+// for as long as those executions remain.
+const (
+	notSourceExecution = "NOT EXISTS (SELECT 1 FROM executions AS derived_executions WHERE " +
+		"derived_executions.source_execution_id = executions.id)"
+	notParentExecution = "NOT EXISTS (SELECT 1 FROM executions AS launched_executions INNER JOIN node_executions ON " +
+		"launched_executions.parent_node_execution_id = node_executions.id WHERE " +
+		"node_executions.execution_project = executions.execution_project AND " +
+		"node_executions.execution_domain = executions.execution_domain AND " +
+		"node_executions.execution_name = executions.execution_name)"
 )
 
 // Implementation of ExecutionInterface.
@@ -24,10 +149,36 @@ type ExecutionRepo struct {
 
 func (r *ExecutionRepo) Create(ctx context.Context, input models.Execution) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&input)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if input.ReplacedExecution != nil && input.ActiveScheduledLaunchPlanID != nil {
+			// Should a concurrent replacement have taken the launch plan over first, the unique index fails the insert
+			// below.
+			err := tx.Model(&models.Execution{}).
+				Where(&models.Execution{
+					ExecutionKey:                *input.ReplacedExecution,
+					ActiveScheduledLaunchPlanID: input.ActiveScheduledLaunchPlanID,
+				}).
+				Update("active_scheduled_launch_plan_id", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit("id").Create(&input).Error; err != nil {
+			return err
+		}
+		if len(input.Tags) > 0 {
+			if err := tx.Omit("id").Create(&input.Tags).Error; err != nil {
+				return err
+			}
+		}
+		if input.ScheduleCheckpoint == nil {
+			return nil
+		}
+		return advanceScheduleCheckpoint(tx, *input.ScheduleCheckpoint)
+	})
 	timer.Stop()
-	if tx.Error != nil {
-		return r.errorTransformer.ToFlyteAdminError(tx.Error)
+	if err != nil {
+		return r.errorTransformer.ToFlyteAdminError(err)
 	}
 	return nil
 }
@@ -35,7 +186,7 @@ func (r *ExecutionRepo) Create(ctx context.Context, input models.Execution) erro
 func (r *ExecutionRepo) Get(ctx context.Context, input interfaces.Identifier) (models.Execution, error) {
 	var execution models.Execution
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.Execution{
+	tx := r.db.WithContext(ctx).Where(&models.Execution{
 		ExecutionKey: models.ExecutionKey{
 			Project: input.Project,
 			Domain:  input.Domain,
@@ -58,7 +209,24 @@ func (r *ExecutionRepo) Get(ctx context.Context, input interfaces.Identifier) (m
 
 func (r *ExecutionRepo) Update(ctx context.Context, execution models.Execution) error {
 	timer := r.metrics.UpdateDuration.Start()
-	tx := r.db.Model(&execution).Updates(execution)
+	tx := r.db.WithContext(ctx).Model(&execution).Updates(execution)
+	timer.Stop()
+	if err := tx.Error; err != nil {
+		return r.errorTransformer.ToFlyteAdminError(err)
+	}
+	return nil
+}
+
+func (r *ExecutionRepo) ClearActiveScheduledLaunchPlan(ctx context.Context, input interfaces.Identifier) error {
+	timer := r.metrics.UpdateDuration.Start()
+	// Update, unlike Updates, also writes the nil value.
+	tx := r.db.WithContext(ctx).Model(&models.Execution{}).Where(&models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: input.Project,
+			Domain:  input.Domain,
+			Name:    input.Name,
+		},
+	}).Update("active_scheduled_launch_plan_id", nil)
 	timer.Stop()
 	if err := tx.Error; err != nil {
 		return r.errorTransformer.ToFlyteAdminError(err)
@@ -73,30 +241,25 @@ func (r *ExecutionRepo) List(ctx context.Context, input interfaces.ListResourceI
 		return interfaces.ExecutionCollectionOutput{}, err
 	}
 	var executions []models.Execution
-	tx := r.db.Limit(input.Limit).Offset(input.Offset)
-	// And add join condition as required by user-specified filters (which can potentially include join table attrs).
-	if ok := input.JoinTableEntities[common.LaunchPlan]; ok {
-		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.launch_plan_id = %s.id",
-			launchPlanTableName, executionTableName, launchPlanTableName))
-	}
-	if ok := input.JoinTableEntities[common.Workflow]; ok {
-		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.workflow_id = %s.id",
-			workflowTableName, executionTableName, workflowTableName))
-	}
-	if ok := input.JoinTableEntities[common.Task]; ok {
-		tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.task_id = %s.id",
-			taskTableName, executionTableName, taskTableName))
-	}
-
-	// Apply filters
-	tx, err := applyScopedFilters(tx, input.InlineFilters, input.MapFilters)
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
+	tx, err := applyExecutionFilters(tx, input.InlineFilters, input.MapFilters, input.JoinTableEntities)
 	if err != nil {
 		return interfaces.ExecutionCollectionOutput{}, err
 	}
 
 	// Apply sort ordering.
-	if input.SortParameter != nil {
-		tx = tx.Order(input.SortParameter.GetGormOrderExpr())
+	if isKeysetPaginated(input) {
+		tx = applyKeysetPagination(tx, executionTableName, input)
+	} else if input.SortParameter != nil {
+		if expression, ok := getExecutionSortKeyExpression(r.db.Dialector.Name(),
+			input.SortParameter.GetKey()); ok {
+			// Computed keys tie more often than columns, break ties by id so that pages fetched with offset based
+			// tokens neither overlap nor skip executions.
+			tx = tx.Order(input.SortParameter.GetGormOrderExprFor(expression)).Order(
+				fmt.Sprintf("%s.id asc", executionTableName))
+		} else {
+			tx = tx.Order(input.SortParameter.GetGormOrderExprFor(getExecutionSortColumn(input.SortParameter.GetKey())))
+		}
 	}
 
 	timer := r.metrics.ListDuration.Start()
@@ -110,6 +273,362 @@ func (r *ExecutionRepo) List(ctx context.Context, input interfaces.ListResourceI
 	}, nil
 }
 
+func (r *ExecutionRepo) Count(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+	if len(input.InlineFilters) == 0 && len(input.MapFilters) == 0 {
+		return estimateCount(ctx, r.db, r.metrics, r.errorTransformer, executionTableName)
+	}
+	tx, err := applyExecutionFilters(r.db.WithContext(ctx).Model(&models.Execution{}), input.InlineFilters,
+		input.MapFilters, input.JoinTableEntities)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	timer := r.metrics.CountDuration.Start()
+	tx = tx.Count(&count)
+	timer.Stop()
+	if tx.Error != nil {
+		return 0, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return count, nil
+}
+
+func (r *ExecutionRepo) GetTags(ctx context.Context, input interfaces.Identifier) ([]models.ExecutionTag, error) {
+	var tags []models.ExecutionTag
+	timer := r.metrics.ListDuration.Start()
+	tx := r.db.WithContext(ctx).Where(&models.ExecutionTag{
+		ExecutionKey: models.ExecutionKey{
+			Project: input.Project,
+			Domain:  input.Domain,
+			Name:    input.Name,
+		},
+	}).Order("tag asc").Find(&tags)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return tags, nil
+}
+
+func (r *ExecutionRepo) UpdateTags(ctx context.Context, input interfaces.Identifier, added, removed []string) error {
+	executionKey := models.ExecutionKey{
+		Project: input.Project,
+		Domain:  input.Domain,
+		Name:    input.Name,
+	}
+	timer := r.metrics.UpdateDuration.Start()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(removed) > 0 {
+			if err := tx.Where(&models.ExecutionTag{ExecutionKey: executionKey}).Where("tag IN (?)", removed).Delete(
+				&models.ExecutionTag{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+		tags := make([]models.ExecutionTag, len(added))
+		for idx, tag := range added {
+			tags[idx] = models.ExecutionTag{
+				ExecutionKey: executionKey,
+				Tag:          tag,
+			}
+		}
+		// Adding a tag the execution already carries is a no-op.
+		return tx.Omit("id").Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	})
+	timer.Stop()
+	if err != nil {
+		return r.errorTransformer.ToFlyteAdminError(err)
+	}
+	return nil
+}
+
+func (r *ExecutionRepo) CountByCluster(ctx context.Context, phases []string) (map[string]int64, error) {
+	var clusterCounts []struct {
+		Cluster string
+		Count   int64
+	}
+	timer := r.metrics.ListDuration.Start()
+	tx := r.db.WithContext(ctx).Model(&models.Execution{}).Select("cluster, count(*) AS count").Where(
+		"phase IN (?)", phases).Group("cluster").Scan(&clusterCounts)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	counts := make(map[string]int64, len(clusterCounts))
+	for _, clusterCount := range clusterCounts {
+		counts[clusterCount.Cluster] = clusterCount.Count
+	}
+	return counts, nil
+}
+
+func (r *ExecutionRepo) PurgeBatch(ctx context.Context, input interfaces.PurgeExecutionsInput) (
+	interfaces.PurgeExecutionsOutput, error) {
+	output := interfaces.PurgeExecutionsOutput{
+		DeletedRows: make(map[string]int64),
+	}
+	timer := r.metrics.DeleteDuration.Start()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Executions which haven't terminated are kept however old they are, as their workflows may still be running
+		// and reporting events.
+		query := tx.Model(&models.Execution{}).Where("executions.created_at < ? AND executions.id > ?",
+			input.CreatedBefore, input.AfterID).Where("executions.phase NOT IN (?)",
+			common.GetNonTerminalExecutionPhases()).Where(notSourceExecution).Where(notParentExecution)
+		if len(input.Project) > 0 {
+			query = query.Where("executions.execution_project = ?", input.Project)
+		}
+		if len(input.Domain) > 0 {
+			query = query.Where("executions.execution_domain = ?", input.Domain)
+		}
+		var ids []uint
+		if err := query.Order("executions.id asc").Limit(input.Limit).Pluck("executions.id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		output.Executions = len(ids)
+		output.LastID = ids[len(ids)-1]
+
+		for _, tableName := range executionChildTableNames {
+			childrenOfBatch := fmt.Sprintf(executionChildrenOfBatchFmt, tableName)
+			if input.DryRun {
+				var count int64
+				if err := tx.Table(tableName).Where(childrenOfBatch, ids).Count(&count).Error; err != nil {
+					return err
+				}
+				output.DeletedRows[tableName] = count
+				continue
+			}
+			deleted := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s", tableName, childrenOfBatch), ids)
+			if deleted.Error != nil {
+				return deleted.Error
+			}
+			output.DeletedRows[tableName] = deleted.RowsAffected
+		}
+		output.DeletedRows[executionTableName] = int64(len(ids))
+		if input.DryRun {
+			return nil
+		}
+		return tx.Where("id IN (?)", ids).Delete(&models.Execution{}).Error
+	})
+	timer.Stop()
+	if err != nil {
+		return interfaces.PurgeExecutionsOutput{}, r.errorTransformer.ToFlyteAdminError(err)
+	}
+	return output, nil
+}
+
+// The index of the statistics bucket of executions, from the seconds between the start of the time range and their
+// creation, in each dialect's way of reading a timestamp as seconds since the epoch. Filtering and bucketing on
+// created_at, rather than on execution_created_at, lets time ranges seek its index.
+var executionStatisticsBucketExprs = map[string]string{
+	postgresDialect: fmt.Sprintf("CAST(FLOOR((EXTRACT(EPOCH FROM %s.created_at) - ?) / ?) AS BIGINT)",
+		executionTableName),
+	mysqlDialect:  fmt.Sprintf("FLOOR((UNIX_TIMESTAMP(%s.created_at) - ?) / ?)", executionTableName),
+	sqliteDialect: fmt.Sprintf("(CAST(strftime('%%s', %s.created_at) AS INTEGER) - ?) / ?", executionTableName),
+}
+
+// Databases without percentile functions approximate percentiles from a histogram of durations, whose classes end at
+// these durations, with a last class for anything longer.
+var executionStatisticsDurationBounds = []time.Duration{
+	time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second,
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 30 * time.Minute,
+	time.Hour, 2 * time.Hour, 4 * time.Hour, 8 * time.Hour, 12 * time.Hour, 24 * time.Hour, 48 * time.Hour,
+	7 * 24 * time.Hour,
+}
+
+func getExecutionStatisticsDurationClassExpr() string {
+	var expr strings.Builder
+	expr.WriteString("CASE")
+	for class, bound := range executionStatisticsDurationBounds {
+		fmt.Fprintf(&expr, " WHEN %s.duration <= %d THEN %d", executionTableName, bound.Nanoseconds(), class)
+	}
+	fmt.Fprintf(&expr, " ELSE %d END", len(executionStatisticsDurationBounds))
+	return expr.String()
+}
+
+// The executions of a histogram class of durations within a bucket.
+type executionDurationClass struct {
+	Count       int64
+	MinDuration int64
+	MaxDuration int64
+}
+
+// Interpolates the duration at a percentile between the durations of the executions ranked around it, as
+// percentile_cont does, assuming the durations of each class are spread evenly between its shortest and longest.
+func getApproximateDurationPercentile(classes []executionDurationClass, percentile float64) time.Duration {
+	var total int64
+	for _, class := range classes {
+		total += class.Count
+	}
+	if total == 0 {
+		return 0
+	}
+	rank := percentile * float64(total-1)
+	var ranked int64
+	for i, class := range classes {
+		if rank >= float64(ranked+class.Count) {
+			ranked += class.Count
+			continue
+		}
+		position := rank - float64(ranked)
+		if last := float64(class.Count - 1); position > last {
+			// The percentile falls between the longest execution of this class and the shortest of the next one.
+			next := classes[i+1].MinDuration
+			return time.Duration(math.Round(float64(class.MaxDuration) + (position-last)*float64(next-class.MaxDuration)))
+		}
+		if class.Count == 1 {
+			return time.Duration(class.MinDuration)
+		}
+		return time.Duration(math.Round(float64(class.MinDuration) +
+			position/float64(class.Count-1)*float64(class.MaxDuration-class.MinDuration)))
+	}
+	return time.Duration(classes[len(classes)-1].MaxDuration)
+}
+
+func (r *ExecutionRepo) getExecutionStatisticsQuery(ctx context.Context, input interfaces.ExecutionStatisticsInput,
+	selection string) *gorm.DB {
+	bucketExpr, ok := executionStatisticsBucketExprs[r.db.Dialector.Name()]
+	if !ok {
+		bucketExpr = executionStatisticsBucketExprs[postgresDialect]
+	}
+	tx := r.db.WithContext(ctx).Model(&models.Execution{}).Select(
+		fmt.Sprintf("%s AS bucket, %s", bucketExpr, selection), input.Start.Unix(), int64(input.BucketSize.Seconds())).
+		Where("executions.created_at >= ? AND executions.created_at < ?", input.Start, input.End).
+		Where("executions.phase IN (?)", input.Phases)
+	if len(input.Project) > 0 {
+		tx = tx.Where("executions.execution_project = ?", input.Project)
+	}
+	if len(input.Projects) > 0 {
+		tx = tx.Where("executions.execution_project IN (?)", input.Projects)
+	}
+	if len(input.Domain) > 0 {
+		tx = tx.Where("executions.execution_domain = ?", input.Domain)
+	}
+	return tx
+}
+
+// Computes the duration percentiles of each bucket with percentile_cont, in the same order as the buckets.
+func (r *ExecutionRepo) getExecutionDurationPercentiles(ctx context.Context, input interfaces.ExecutionStatisticsInput,
+	buckets []interfaces.ExecutionStatisticsBucket) error {
+	selections := make([]string, len(input.Percentiles))
+	for i, percentile := range input.Percentiles {
+		selections[i] = fmt.Sprintf("PERCENTILE_CONT(%g) WITHIN GROUP (ORDER BY %s.duration) AS p%d",
+			percentile, executionTableName, i)
+	}
+	rows, err := r.getExecutionStatisticsQuery(ctx, input, strings.Join(selections, ", ")).Group("bucket").
+		Order("bucket").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	for i := 0; rows.Next(); i++ {
+		var index int64
+		percentiles := make([]float64, len(input.Percentiles))
+		// Destinations are matched to columns by name, rather than assuming the order of the selection.
+		destinations := make([]interface{}, len(columns))
+		for j, column := range columns {
+			if column == "bucket" {
+				destinations[j] = &index
+				continue
+			}
+			var percentile int
+			if _, err := fmt.Sscanf(column, "p%d", &percentile); err != nil || percentile >= len(percentiles) {
+				return fmt.Errorf("unexpected statistics column %s", column)
+			}
+			destinations[j] = &percentiles[percentile]
+		}
+		if err := rows.Scan(destinations...); err != nil {
+			return err
+		}
+		if i >= len(buckets) || buckets[i].Index != index {
+			return fmt.Errorf("unexpected statistics bucket %d", index)
+		}
+		for _, percentile := range percentiles {
+			buckets[i].DurationPercentiles = append(buckets[i].DurationPercentiles, time.Duration(math.Round(percentile)))
+		}
+	}
+	return rows.Err()
+}
+
+// Approximates the duration percentiles of each bucket from a histogram of durations, in the same order as the buckets.
+func (r *ExecutionRepo) getApproximateExecutionDurationPercentiles(ctx context.Context,
+	input interfaces.ExecutionStatisticsInput, buckets []interfaces.ExecutionStatisticsBucket) error {
+	var classes []struct {
+		Bucket      int64
+		Count       int64
+		MinDuration int64
+		MaxDuration int64
+	}
+	err := r.getExecutionStatisticsQuery(ctx, input, fmt.Sprintf(
+		"%s AS class, COUNT(*) AS count, MIN(%s.duration) AS min_duration, MAX(%s.duration) AS max_duration",
+		getExecutionStatisticsDurationClassExpr(), executionTableName, executionTableName)).
+		Group("bucket").Group("class").Order("bucket").Order("class").Scan(&classes).Error
+	if err != nil {
+		return err
+	}
+	bucketClasses := make(map[int64][]executionDurationClass, len(buckets))
+	for _, class := range classes {
+		bucketClasses[class.Bucket] = append(bucketClasses[class.Bucket], executionDurationClass{
+			Count:       class.Count,
+			MinDuration: class.MinDuration,
+			MaxDuration: class.MaxDuration,
+		})
+	}
+	for i := range buckets {
+		for _, percentile := range input.Percentiles {
+			buckets[i].DurationPercentiles = append(buckets[i].DurationPercentiles,
+				getApproximateDurationPercentile(bucketClasses[buckets[i].Index], percentile))
+		}
+	}
+	return nil
+}
+
+func (r *ExecutionRepo) GetStatistics(ctx context.Context, input interfaces.ExecutionStatisticsInput) (
+	interfaces.ExecutionStatisticsOutput, error) {
+	var phaseCounts []struct {
+		Bucket int64
+		Phase  string
+		Count  int64
+	}
+	timer := r.metrics.ListDuration.Start()
+	defer timer.Stop()
+	err := r.getExecutionStatisticsQuery(ctx, input, "executions.phase, COUNT(*) AS count").Group("bucket").
+		Group("executions.phase").Order("bucket").Scan(&phaseCounts).Error
+	if err != nil {
+		return interfaces.ExecutionStatisticsOutput{}, r.errorTransformer.ToFlyteAdminError(err)
+	}
+	output := interfaces.ExecutionStatisticsOutput{
+		Approximate: r.db.Dialector.Name() != postgresDialect,
+	}
+	for _, phaseCount := range phaseCounts {
+		if len(output.Buckets) == 0 || output.Buckets[len(output.Buckets)-1].Index != phaseCount.Bucket {
+			output.Buckets = append(output.Buckets, interfaces.ExecutionStatisticsBucket{
+				Index:       phaseCount.Bucket,
+				PhaseCounts: make(map[string]int64),
+			})
+		}
+		output.Buckets[len(output.Buckets)-1].PhaseCounts[phaseCount.Phase] = phaseCount.Count
+	}
+	if len(output.Buckets) == 0 || len(input.Percentiles) == 0 {
+		return output, nil
+	}
+	if output.Approximate {
+		err = r.getApproximateExecutionDurationPercentiles(ctx, input, output.Buckets)
+	} else {
+		err = r.getExecutionDurationPercentiles(ctx, input, output.Buckets)
+	}
+	if err != nil {
+		return interfaces.ExecutionStatisticsOutput{}, r.errorTransformer.ToFlyteAdminError(err)
+	}
+	return output, nil
+}
+
 // Returns an instance of ExecutionRepoInterface
 func NewExecutionRepo(
 	db *gorm.DB, errorTransformer adminErrors.ErrorTransformer, scope promutils.Scope) interfaces.ExecutionRepoInterface {
@@ -3,6 +3,7 @@ package gormimpl
 import (
 	"context"
 	"database/sql/driver"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,6 +43,72 @@ func TestCreateExecution(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateExecution_WithTags(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	tagsCreated := false
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "execution_tags"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			tagsCreated = true
+		},
+	)
+
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	executionKey := models.ExecutionKey{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "1",
+	}
+	err := executionRepo.Create(context.Background(), models.Execution{
+		ExecutionKey: executionKey,
+		Phase:        core.WorkflowExecution_UNDEFINED.String(),
+		Spec:         []byte{3, 4},
+		Tags: []models.ExecutionTag{
+			{ExecutionKey: executionKey, Tag: "backfill"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, tagsCreated)
+}
+
+func TestCreateExecution_ReplacedExecution(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	var queries []string
+	GlobalMock.NewMock().WithQuery(`UPDATE "executions" SET "active_scheduled_launch_plan_id"=$1`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.Contains(t, s, `"executions"."active_scheduled_launch_plan_id" = `)
+			assert.Nil(t, values[0].Value)
+			queries = append(queries, "release")
+		},
+	)
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "executions"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			queries = append(queries, "create")
+		},
+	)
+
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	launchPlanID := uint(2)
+	err := executionRepo.Create(context.Background(), models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "2",
+		},
+		LaunchPlanID:                launchPlanID,
+		Phase:                       core.WorkflowExecution_UNDEFINED.String(),
+		Spec:                        []byte{3, 4},
+		ActiveScheduledLaunchPlanID: &launchPlanID,
+		ReplacedExecution: &models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "1",
+		},
+	})
+	assert.NoError(t, err)
+	// The replaced execution hands the launch plan over before the new one claims it.
+	assert.Equal(t, []string{"release", "create"}, queries)
+}
+
 func TestUpdateExecution(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
@@ -80,6 +147,28 @@ func TestUpdateExecution(t *testing.T) {
 	assert.True(t, updated)
 }
 
+func TestClearActiveScheduledLaunchPlan(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	cleared := false
+
+	GlobalMock.NewMock().WithQuery(`UPDATE "executions" SET "active_scheduled_launch_plan_id"=$1`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.Nil(t, values[0].Value)
+			cleared = true
+		},
+	)
+
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	err := executionRepo.ClearActiveScheduledLaunchPlan(context.Background(), interfaces.Identifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, cleared)
+}
+
 func getMockExecutionResponseFromDb(expected models.Execution) map[string]interface{} {
 	execution := make(map[string]interface{})
 	execution["id"] = expected.ID
@@ -268,6 +357,350 @@ func TestListExecutions_Order(t *testing.T) {
 	assert.True(t, mockQuery.Triggered)
 }
 
+func TestListExecutions_SortByComputedKeys(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	executions := make([]map[string]interface{}, 0)
+	for _, executionName := range []string{"1", "2"} {
+		executions = append(executions, getMockExecutionResponseFromDb(models.Execution{
+			ExecutionKey: models.ExecutionKey{
+				Project: project,
+				Domain:  domain,
+				Name:    executionName,
+			},
+			Phase:   core.WorkflowExecution_SUCCEEDED.String(),
+			Closure: []byte{1, 2},
+			Spec:    []byte{3, 4},
+		}))
+	}
+
+	testCases := []struct {
+		sort          admin.Sort
+		expectedOrder string
+	}{
+		{
+			sort: admin.Sort{Direction: admin.Sort_DESCENDING, Key: "duration"},
+			expectedOrder: `ORDER BY (CASE WHEN executions.phase IN ('SUCCEEDED', 'FAILED', 'TIMED_OUT', 'ABORTED') ` +
+				`THEN executions.execution_updated_at ELSE NOW() END - executions.execution_created_at) desc,` +
+				`executions.id asc LIMIT 2 OFFSET 4`,
+		},
+		{
+			sort:          admin.Sort{Direction: admin.Sort_ASCENDING, Key: "last_updated_at"},
+			expectedOrder: `ORDER BY executions.execution_updated_at asc,executions.id asc LIMIT 2 OFFSET 4`,
+		},
+		{
+			sort:          admin.Sort{Direction: admin.Sort_ASCENDING, Key: "updated_at"},
+			expectedOrder: `ORDER BY executions.updated_at asc LIMIT 2 OFFSET 4`,
+		},
+		{
+			sort:          admin.Sort{Direction: admin.Sort_DESCENDING, Key: "created_at"},
+			expectedOrder: `ORDER BY executions.created_at desc LIMIT 2 OFFSET 4`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.sort.Key, func(t *testing.T) {
+			GlobalMock := mocket.Catcher.Reset()
+			mockQuery := GlobalMock.NewMock().WithQuery(
+				`SELECT * FROM "executions" WHERE executions.execution_project = $1 ` + testCase.expectedOrder)
+			mockQuery.WithReply(executions)
+
+			sortParameter, err := common.NewSortParameter(testCase.sort)
+			assert.NoError(t, err)
+			// The third page of two executions each.
+			collection, err := executionRepo.List(context.Background(), interfaces.ListResourceInput{
+				SortParameter: sortParameter,
+				InlineFilters: []common.InlineFilter{
+					getEqualityFilter(common.Execution, "project", project),
+				},
+				Limit:  2,
+				Offset: 4,
+			})
+			assert.NoError(t, err)
+			assert.True(t, mockQuery.Triggered)
+			assert.Len(t, collection.Executions, 2)
+		})
+	}
+}
+
+func TestListExecutions_KeysetPagination(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	sortParameter, err := common.NewSortParameter(admin.Sort{Direction: admin.Sort_DESCENDING, Key: common.KeysetSortKey})
+	assert.NoError(t, err)
+	input := interfaces.ListResourceInput{
+		SortParameter: sortParameter,
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.Execution, "project", project),
+		},
+		Limit: 2,
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		mockQuery := GlobalMock.NewMock().WithQuery(`SELECT * FROM "executions" WHERE executions.execution_project = $1 ` +
+			`ORDER BY executions.created_at desc,executions.id desc LIMIT 2`)
+		_, err := executionRepo.List(context.Background(), input)
+		assert.NoError(t, err)
+		assert.True(t, mockQuery.Triggered)
+	})
+	t.Run("next page", func(t *testing.T) {
+		GlobalMock := mocket.Catcher.Reset()
+		// The page starts past the cursor rather than at an offset.
+		mockQuery := GlobalMock.NewMock().WithQuery(`SELECT * FROM "executions" WHERE ` +
+			`executions.execution_project = $1 AND (executions.created_at, executions.id) < ($2, $3) ` +
+			`ORDER BY executions.created_at desc,executions.id desc LIMIT 2`)
+		keysetInput := input
+		keysetInput.KeysetCursor = &common.KeysetCursor{
+			Direction: admin.Sort_DESCENDING,
+			CreatedAt: createdAt,
+			ID:        100,
+		}
+		_, err := executionRepo.List(context.Background(), keysetInput)
+		assert.NoError(t, err)
+		assert.True(t, mockQuery.Triggered)
+	})
+}
+
+func TestListExecutions_ErrorFilters(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "executions" WHERE (LOWER(executions.error_kind) = $1) AND (LOWER(executions.error_message) LIKE $2) LIMIT 20`)
+
+	kindFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "error_kind", "USER")
+	assert.NoError(t, err)
+	kindFilter, err = common.NewCaseInsensitiveFilter(kindFilter)
+	assert.NoError(t, err)
+	messageFilter, err := common.NewSingleValueFilter(common.Execution, common.Contains, "error_message", "OOMKilled")
+	assert.NoError(t, err)
+	messageFilter, err = common.NewCaseInsensitiveFilter(messageFilter)
+	assert.NoError(t, err)
+	_, err = executionRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{kindFilter, messageFilter},
+		Limit:         20,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+}
+
+func TestListExecutions_HidesArchivedWorkflows(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	executions := make([]map[string]interface{}, 0)
+	execution := getMockExecutionResponseFromDb(models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: project,
+			Domain:  domain,
+			Name:    name,
+		},
+		WorkflowID: uint(3),
+		Phase:      core.WorkflowExecution_SUCCEEDED.String(),
+		Closure:    []byte{1, 2},
+		Spec:       []byte{3, 4},
+	})
+	executions = append(executions, execution)
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`FROM "executions" LEFT JOIN workflows ON executions.workflow_id = workflows.id LEFT JOIN named_entity_metadata ON named_entity_metadata.resource_type = 2 AND named_entity_metadata.project = workflows.project AND named_entity_metadata.domain = workflows.domain AND named_entity_metadata.name = workflows.name WHERE executions.execution_project = $1 AND COALESCE(named_entity_metadata.state, 0) <> $2 LIMIT 10 OFFSET 10`).WithReply(executions)
+
+	archivedFilter, err := common.NewWithDefaultValueFilter("0", getNotEqualityFilter(
+		common.NamedEntityMetadata, "state", int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED)))
+	assert.NoError(t, err)
+	collection, err := executionRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.Execution, "project", project),
+			archivedFilter,
+		},
+		JoinTableEntities: map[common.Entity]bool{
+			common.Execution:           true,
+			common.NamedEntityMetadata: true,
+		},
+		Limit:  10,
+		Offset: 10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, collection.Executions, 1)
+}
+
+func TestListExecutions_TagFilters(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	executions := make([]map[string]interface{}, 0)
+	for _, executionName := range []string{"1", "2"} {
+		executions = append(executions, getMockExecutionResponseFromDb(models.Execution{
+			ExecutionKey: models.ExecutionKey{
+				Project: project,
+				Domain:  domain,
+				Name:    executionName,
+			},
+			Phase:   core.WorkflowExecution_RUNNING.String(),
+			Closure: []byte{1, 2},
+			Spec:    []byte{3, 4},
+		}))
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	// Tags are matched through a subquery rather than a join, so that executions with several matching tags are only
+	// returned, and counted towards the limit, once.
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`FROM "executions" WHERE (EXISTS (SELECT 1 FROM execution_tags WHERE execution_tags.execution_project = executions.execution_project AND execution_tags.execution_domain = executions.execution_domain AND execution_tags.execution_name = executions.execution_name AND execution_tags.tag = $1)) AND (EXISTS (SELECT 1 FROM execution_tags WHERE execution_tags.execution_project = executions.execution_project AND execution_tags.execution_domain = executions.execution_domain AND execution_tags.execution_name = executions.execution_name AND execution_tags.tag LIKE $2)) AND executions.execution_project = $3 LIMIT 2 OFFSET 2`).WithReply(executions)
+
+	containsFilter, err := common.NewSingleValueFilter(common.ExecutionTag, common.Contains, "tag", "2024")
+	assert.NoError(t, err)
+	collection, err := executionRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.Execution, "project", project),
+			getEqualityFilter(common.ExecutionTag, "tag", "backfill"),
+			containsFilter,
+		},
+		JoinTableEntities: map[common.Entity]bool{
+			common.Execution:    true,
+			common.ExecutionTag: true,
+		},
+		Limit:  2,
+		Offset: 2,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+	assert.Len(t, collection.Executions, 2)
+	assert.Equal(t, "1", collection.Executions[0].Name)
+	assert.Equal(t, "2", collection.Executions[1].Name)
+}
+
+func TestGetExecutionTags(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	tags := []map[string]interface{}{
+		{
+			"execution_project": project,
+			"execution_domain":  domain,
+			"execution_name":    name,
+			"tag":               "backfill",
+		},
+	}
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "execution_tags" WHERE "execution_tags"."execution_project" = $1 AND "execution_tags"."execution_domain" = $2 AND "execution_tags"."execution_name" = $3 ORDER BY tag asc`).WithReply(tags)
+
+	output, err := executionRepo.GetTags(context.Background(), interfaces.Identifier{
+		Project: project,
+		Domain:  domain,
+		Name:    name,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output, 1)
+	assert.Equal(t, "backfill", output[0].Tag)
+	assert.Equal(t, name, output[0].Name)
+}
+
+func TestUpdateExecutionTags(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	removed := false
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "execution_tags" WHERE "execution_tags"."execution_project" = $1 AND "execution_tags"."execution_domain" = $2 AND "execution_tags"."execution_name" = $3 AND tag IN ($4)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			removed = true
+		},
+	)
+	added := false
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "execution_tags"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.Contains(t, s, "ON CONFLICT DO NOTHING")
+			added = true
+		},
+	)
+
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	err := executionRepo.UpdateTags(context.Background(), interfaces.Identifier{
+		Project: project,
+		Domain:  domain,
+		Name:    name,
+	}, []string{"backfill-2024-06"}, []string{"adhoc"})
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	assert.True(t, added)
+}
+
+func TestCountExecutionsByCluster(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT cluster, count(*) AS count FROM "executions" WHERE phase IN ($1,$2) GROUP BY "cluster"`).WithReply(
+		[]map[string]interface{}{
+			{"cluster": "cluster-1", "count": 3},
+			{"cluster": "cluster-2", "count": 1},
+		})
+
+	counts, err := executionRepo.CountByCluster(context.Background(), []string{
+		core.WorkflowExecution_QUEUED.String(), core.WorkflowExecution_RUNNING.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"cluster-1": 3, "cluster-2": 1}, counts)
+}
+
+func TestCountExecutions(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	var queries []string
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(`SELECT count(*) FROM "executions"`).WithCallback(
+		func(query string, _ []driver.NamedValue) {
+			queries = append(queries, query)
+		}).WithReply([]map[string]interface{}{{"count": 3}})
+	GlobalMock.NewMock().WithQuery(`FROM "executions"`).WithCallback(func(query string, _ []driver.NamedValue) {
+		queries = append(queries, query)
+	})
+
+	archivedFilter, err := common.NewWithDefaultValueFilter("0", getNotEqualityFilter(
+		common.NamedEntityMetadata, "state", int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED)))
+	assert.NoError(t, err)
+	filters := []common.InlineFilter{
+		getEqualityFilter(common.Execution, "project", project),
+		getEqualityFilter(common.ExecutionTag, "tag", "backfill"),
+		archivedFilter,
+	}
+	joinTableEntities := map[common.Entity]bool{
+		common.Execution:           true,
+		common.ExecutionTag:        true,
+		common.NamedEntityMetadata: true,
+	}
+	_, err = executionRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters:     filters,
+		JoinTableEntities: joinTableEntities,
+		Limit:             10,
+	})
+	assert.NoError(t, err)
+	count, err := executionRepo.Count(context.Background(), interfaces.CountResourceInput{
+		InlineFilters:     filters,
+		JoinTableEntities: joinTableEntities,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	// Executions are counted with the same joins and filters they are listed with.
+	assert.Len(t, queries, 2)
+	assert.True(t, strings.HasPrefix(queries[0], `SELECT "executions"."id"`))
+	assert.True(t, strings.HasPrefix(queries[1], `SELECT count(*) FROM "executions" `))
+	getConditions := func(query string) string {
+		return query[strings.Index(query, ` FROM "executions" `):strings.Index(query, "$3")]
+	}
+	assert.Contains(t, getConditions(queries[0]), "COALESCE(named_entity_metadata.state, 0) <> ")
+	assert.Equal(t, getConditions(queries[0]), getConditions(queries[1]))
+}
+
+func TestCountExecutions_Estimated(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(`SELECT reltuples::bigint FROM pg_class WHERE relname = $1`).WithArgs(
+		"executions").WithReply([]map[string]interface{}{{"reltuples": 1000000}})
+
+	count, err := executionRepo.Count(context.Background(), interfaces.CountResourceInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000000), count)
+}
+
 func TestListExecutions_MissingParameters(t *testing.T) {
 	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 	_, err := executionRepo.List(context.Background(), interfaces.ListResourceInput{
@@ -310,7 +743,7 @@ func TestListExecutionsForWorkflow(t *testing.T) {
 	GlobalMock.Logging = true
 
 	// Only match on queries that append expected filters
-	GlobalMock.NewMock().WithQuery(`SELECT "executions"."id","executions"."created_at","executions"."updated_at","executions"."deleted_at","executions"."execution_project","executions"."execution_domain","executions"."execution_name","executions"."launch_plan_id","executions"."workflow_id","executions"."task_id","executions"."phase","executions"."closure","executions"."spec","executions"."started_at","executions"."execution_created_at","executions"."execution_updated_at","executions"."duration","executions"."abort_cause","executions"."mode","executions"."source_execution_id","executions"."parent_node_execution_id","executions"."cluster","executions"."inputs_uri","executions"."user_inputs_uri","executions"."error_kind","executions"."error_code","executions"."user" FROM "executions" INNER JOIN workflows ON executions.workflow_id = workflows.id INNER JOIN tasks ON executions.task_id = tasks.id WHERE executions.execution_project = $1 AND executions.execution_domain = $2 AND executions.execution_name = $3 AND (workflows.name = $4) AND tasks.name = $5 LIMIT`).WithReply(executions)
+	GlobalMock.NewMock().WithQuery(`SELECT "executions"."id","executions"."created_at","executions"."updated_at","executions"."deleted_at","executions"."execution_project","executions"."execution_domain","executions"."execution_name","executions"."launch_plan_id","executions"."workflow_id","executions"."task_id","executions"."phase","executions"."closure","executions"."spec","executions"."started_at","executions"."execution_created_at","executions"."execution_updated_at","executions"."duration","executions"."abort_cause","executions"."mode","executions"."source_execution_id","executions"."parent_node_execution_id","executions"."cluster","executions"."namespace","executions"."launch_attempted","executions"."queue","executions"."max_parallelism","executions"."inputs_uri","executions"."user_inputs_uri","executions"."error_kind","executions"."error_code","executions"."error_message","executions"."user","executions"."active_scheduled_launch_plan_id" FROM "executions" INNER JOIN workflows ON executions.workflow_id = workflows.id INNER JOIN tasks ON executions.task_id = tasks.id WHERE executions.execution_project = $1 AND executions.execution_domain = $2 AND executions.execution_name = $3 AND (workflows.name = $4) AND tasks.name = $5 LIMIT`).WithReply(executions)
 
 	collection, err := executionRepo.List(context.Background(), interfaces.ListResourceInput{
 		InlineFilters: []common.InlineFilter{
@@ -343,3 +776,151 @@ func TestListExecutionsForWorkflow(t *testing.T) {
 		assert.Equal(t, time.Hour, execution.Duration)
 	}
 }
+
+func TestPurgeExecutionsBatch(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	var selectQuery string
+	GlobalMock.NewMock().WithQuery(`SELECT "executions"."id" FROM "executions"`).WithCallback(
+		func(query string, _ []driver.NamedValue) {
+			selectQuery = query
+		}).WithReply([]map[string]interface{}{{"id": 3}, {"id": 5}})
+	var deleteQueries []string
+	GlobalMock.NewMock().WithQuery(`DELETE FROM`).WithCallback(func(query string, _ []driver.NamedValue) {
+		deleteQueries = append(deleteQueries, query)
+	}).WithRowsNum(2)
+
+	output, err := executionRepo.PurgeBatch(context.Background(), interfaces.PurgeExecutionsInput{
+		Domain:        domain,
+		CreatedBefore: createdAt,
+		AfterID:       1,
+		Limit:         2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, output.Executions)
+	assert.Equal(t, uint(5), output.LastID)
+	assert.Contains(t, selectQuery, `SELECT "executions"."id" FROM "executions" WHERE (executions.created_at < $1 AND executions.id > $2) AND `+
+		`executions.phase NOT IN ($3,$4,$5,$6,$7) AND NOT EXISTS (SELECT 1 FROM executions AS derived_executions WHERE derived_executions.source_execution_id = `+
+		`executions.id) AND (NOT EXISTS (SELECT 1 FROM executions AS launched_executions INNER JOIN node_executions ON `+
+		`launched_executions.parent_node_execution_id = node_executions.id WHERE node_executions.execution_project = `+
+		`executions.execution_project AND node_executions.execution_domain = executions.execution_domain AND `+
+		`node_executions.execution_name = executions.execution_name)) AND executions.execution_domain = $8 `+
+		`ORDER BY executions.id asc LIMIT 2`)
+	// The children of the executions are deleted ahead of them, node executions ahead of task executions.
+	assert.Len(t, deleteQueries, 6)
+	for idx, table := range []string{"execution_tags", "execution_events", "node_execution_events", "node_executions",
+		"task_executions"} {
+		assert.True(t, strings.HasPrefix(deleteQueries[idx], "DELETE FROM "+table+" WHERE EXISTS"), deleteQueries[idx])
+		assert.Equal(t, int64(2), output.DeletedRows[table])
+	}
+	assert.Equal(t, `DELETE FROM "executions" WHERE id IN ($1,$2)`, deleteQueries[5])
+	assert.Equal(t, int64(2), output.DeletedRows["executions"])
+}
+
+func TestPurgeExecutionsBatch_DryRun(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	GlobalMock.NewMock().WithQuery(`SELECT "executions"."id" FROM "executions"`).WithReply(
+		[]map[string]interface{}{{"id": 3}})
+	GlobalMock.NewMock().WithQuery(`SELECT count(*) FROM "node_executions"`).WithReply(
+		[]map[string]interface{}{{"count": 4}})
+	deleted := false
+	GlobalMock.NewMock().WithQuery(`DELETE FROM`).WithCallback(func(query string, _ []driver.NamedValue) {
+		deleted = true
+	})
+
+	output, err := executionRepo.PurgeBatch(context.Background(), interfaces.PurgeExecutionsInput{
+		Project:       project,
+		Domain:        domain,
+		CreatedBefore: createdAt,
+		Limit:         2,
+		DryRun:        true,
+	})
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assert.Equal(t, 1, output.Executions)
+	assert.Equal(t, int64(4), output.DeletedRows["node_executions"])
+	assert.Equal(t, int64(1), output.DeletedRows["executions"])
+}
+
+func TestPurgeExecutionsBatch_NothingToPurge(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	deleted := false
+	GlobalMock.NewMock().WithQuery(`DELETE FROM`).WithCallback(func(query string, _ []driver.NamedValue) {
+		deleted = true
+	})
+
+	output, err := executionRepo.PurgeBatch(context.Background(), interfaces.PurgeExecutionsInput{
+		Domain:        domain,
+		CreatedBefore: createdAt,
+		Limit:         2,
+	})
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assert.Zero(t, output.Executions)
+	assert.Empty(t, output.DeletedRows)
+}
+
+func TestGetExecutionStatistics(t *testing.T) {
+	executionRepo := NewExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT CAST(FLOOR((EXTRACT(EPOCH FROM executions.created_at) - $1) / $2) AS BIGINT) AS bucket, executions.phase, COUNT(*) AS count FROM "executions" WHERE (executions.created_at >= $3 AND executions.created_at < $4) AND executions.phase IN ($5,$6) AND executions.execution_project = $7 GROUP BY "bucket","executions"."phase" ORDER BY bucket`).
+		WithReply([]map[string]interface{}{
+			{"bucket": 0, "phase": "SUCCEEDED", "count": 3},
+			{"bucket": 0, "phase": "FAILED", "count": 1},
+			{"bucket": 2, "phase": "SUCCEEDED", "count": 2},
+		})
+	GlobalMock.NewMock().WithQuery(
+		`PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY executions.duration) AS p0, PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY executions.duration) AS p1 FROM "executions"`).
+		WithReply([]map[string]interface{}{
+			{"bucket": 0, "p0": float64(time.Minute), "p1": float64(time.Hour)},
+			{"bucket": 2, "p0": float64(time.Second), "p1": float64(2 * time.Second)},
+		})
+
+	output, err := executionRepo.GetStatistics(context.Background(), interfaces.ExecutionStatisticsInput{
+		Project:     project,
+		Start:       start,
+		End:         start.Add(72 * time.Hour),
+		BucketSize:  24 * time.Hour,
+		Phases:      []string{core.WorkflowExecution_SUCCEEDED.String(), core.WorkflowExecution_FAILED.String()},
+		Percentiles: []float64{0.5, 0.95},
+	})
+	assert.NoError(t, err)
+	assert.False(t, output.Approximate)
+	assert.Equal(t, []interfaces.ExecutionStatisticsBucket{
+		{
+			Index:               0,
+			PhaseCounts:         map[string]int64{"SUCCEEDED": 3, "FAILED": 1},
+			DurationPercentiles: []time.Duration{time.Minute, time.Hour},
+		},
+		{
+			Index:               2,
+			PhaseCounts:         map[string]int64{"SUCCEEDED": 2},
+			DurationPercentiles: []time.Duration{time.Second, 2 * time.Second},
+		},
+	}, output.Buckets)
+}
+
+func TestGetApproximateDurationPercentile(t *testing.T) {
+	classes := []executionDurationClass{
+		{Count: 1, MinDuration: 10, MaxDuration: 10},
+		{Count: 3, MinDuration: 20, MaxDuration: 40},
+		{Count: 1, MinDuration: 100, MaxDuration: 100},
+	}
+	// The five executions rank at percentiles 0, 0.25, 0.5, 0.75 and 1.
+	assert.Equal(t, time.Duration(10), getApproximateDurationPercentile(classes, 0))
+	assert.Equal(t, time.Duration(15), getApproximateDurationPercentile(classes, 0.125))
+	assert.Equal(t, time.Duration(30), getApproximateDurationPercentile(classes, 0.5))
+	assert.Equal(t, time.Duration(70), getApproximateDurationPercentile(classes, 0.875))
+	assert.Equal(t, time.Duration(100), getApproximateDurationPercentile(classes, 1))
+	assert.Equal(t, time.Duration(0), getApproximateDurationPercentile(nil, 0.5))
+}
@@ -0,0 +1,74 @@
+package gormimpl
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Implementation of JobLeaseRepoInterface.
+type JobLeaseRepo struct {
+	db               *gorm.DB
+	errorTransformer errors.ErrorTransformer
+	metrics          gormMetrics
+}
+
+func (r *JobLeaseRepo) Acquire(ctx context.Context, lease models.JobLease, now time.Time) (bool, error) {
+	// Times are stored in UTC so that they compare in order regardless of the dialect.
+	lease.ExpiresAt = lease.ExpiresAt.UTC()
+	timer := r.metrics.CreateDuration.Start()
+	tx := r.db.WithContext(ctx).Omit("id").Clauses(clause.OnConflict{DoNothing: true}).Create(&lease)
+	timer.Stop()
+	if tx.Error != nil {
+		return false, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	if tx.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// The lease is renewed by its holder, or taken over once it has expired. The conditional update is atomic, so only
+	// one of the replicas racing to take over the lease succeeds.
+	timer = r.metrics.UpdateDuration.Start()
+	tx = r.db.WithContext(ctx).Model(&models.JobLease{}).
+		Where(&models.JobLease{Name: lease.Name}).
+		Where("holder = ? OR expires_at < ?", lease.Holder, now.UTC()).
+		Updates(map[string]interface{}{
+			"holder":     lease.Holder,
+			"expires_at": lease.ExpiresAt,
+		})
+	timer.Stop()
+	if tx.Error != nil {
+		return false, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+func (r *JobLeaseRepo) Release(ctx context.Context, lease models.JobLease) error {
+	timer := r.metrics.DeleteDuration.Start()
+	tx := r.db.WithContext(ctx).Unscoped().
+		Where(&models.JobLease{Name: lease.Name, Holder: lease.Holder}).
+		Delete(&models.JobLease{})
+	timer.Stop()
+	if tx.Error != nil {
+		return r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return nil
+}
+
+// Returns an instance of JobLeaseRepoInterface.
+func NewJobLeaseRepo(
+	db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.JobLeaseRepoInterface {
+	metrics := newMetrics(scope)
+	return &JobLeaseRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		metrics:          metrics,
+	}
+}
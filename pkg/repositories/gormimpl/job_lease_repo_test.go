@@ -0,0 +1,61 @@
+package gormimpl
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireJobLease(t *testing.T) {
+	// Leases are acquired with conditional writes, which are run against a real database.
+	db, err := config.OpenDbConnection(config.NewSQLiteConfigProvider(config.DbConfig{
+		SQLite: interfaces.DbSQLiteConfig{File: filepath.Join(t.TempDir(), "flyteadmin.db")},
+	}, mockScope.NewTestScope()))
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&models.JobLease{}))
+	leaseRepo := NewJobLeaseRepo(db, errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	ctx := context.Background()
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	getLease := func(holder string, expiresAt time.Time) models.JobLease {
+		return models.JobLease{Name: "job", Holder: holder, ExpiresAt: expiresAt}
+	}
+
+	held, err := leaseRepo.Acquire(ctx, getLease("replica-0", now.Add(time.Hour)), now)
+	assert.NoError(t, err)
+	assert.True(t, held)
+
+	// Another replica can't take the lease over until it expires.
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-1", now.Add(2*time.Hour)), now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, held)
+
+	// The holder renews it.
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-0", now.Add(2*time.Hour)), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, held)
+
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-1", now.Add(4*time.Hour)), now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, held)
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-0", now.Add(5*time.Hour)), now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, held)
+
+	// Only the holder releases the lease, after which another replica acquires it before it would have expired.
+	assert.NoError(t, leaseRepo.Release(ctx, getLease("replica-0", time.Time{})))
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-0", now.Add(5*time.Hour)), now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, held)
+	assert.NoError(t, leaseRepo.Release(ctx, getLease("replica-1", time.Time{})))
+	held, err = leaseRepo.Acquire(ctx, getLease("replica-0", now.Add(5*time.Hour)), now.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, held)
+}
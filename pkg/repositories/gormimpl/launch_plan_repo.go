@@ -5,14 +5,17 @@ import (
 	"errors"
 	"time"
 
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytestdlib/promutils"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	adminErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	"github.com/flyteorg/flytestdlib/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const launchPlanTableName = "launch_plans"
@@ -27,11 +30,12 @@ type LaunchPlanRepo struct {
 	errorTransformer  adminErrors.ErrorTransformer
 	metrics           gormMetrics
 	launchPlanMetrics launchPlanMetrics
+	retrier           transactionRetrier
 }
 
 func (r *LaunchPlanRepo) Create(ctx context.Context, input models.LaunchPlan) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&input)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -41,7 +45,7 @@ func (r *LaunchPlanRepo) Create(ctx context.Context, input models.LaunchPlan) er
 
 func (r *LaunchPlanRepo) Update(ctx context.Context, input models.LaunchPlan) error {
 	timer := r.metrics.UpdateDuration.Start()
-	tx := r.db.Model(&input).Updates(input)
+	tx := r.db.WithContext(ctx).Model(&input).Updates(input)
 	timer.Stop()
 	if err := tx.Error; err != nil {
 		return r.errorTransformer.ToFlyteAdminError(err)
@@ -52,7 +56,7 @@ func (r *LaunchPlanRepo) Update(ctx context.Context, input models.LaunchPlan) er
 func (r *LaunchPlanRepo) Get(ctx context.Context, input interfaces.Identifier) (models.LaunchPlan, error) {
 	var launchPlan models.LaunchPlan
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.LaunchPlan{
+	tx := r.db.WithContext(ctx).Where(&models.LaunchPlan{
 		LaunchPlanKey: models.LaunchPlanKey{
 			Project: input.Project,
 			Domain:  input.Domain,
@@ -76,35 +80,48 @@ func (r *LaunchPlanRepo) Get(ctx context.Context, input interfaces.Identifier) (
 	return launchPlan, nil
 }
 
-// This operation is performed as a two-step transaction because only one launch plan version can be active at a time.
-// Transactional semantics are used to guarantee that setting the desired launch plan to active also disables
-// the existing launch plan version (if any).
-func (r *LaunchPlanRepo) SetActive(
-	ctx context.Context, toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error {
+// Only one launch plan version can be active at a time, so setting the desired launch plan to active also disables
+// the existing launch plan version (if any) in the same transaction. The versions of the launch plan are locked first,
+// so that concurrent activations of the same launch plan wait for each other rather than both deactivating the version
+// they found active. Since the transaction sets the states outright it's retried when it conflicts with a concurrent
+// one.
+func (r *LaunchPlanRepo) SetActive(ctx context.Context, toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
 	timer := r.launchPlanMetrics.SetActiveDuration.Start()
 	defer timer.Stop()
-	// Use a transaction to guarantee no partial updates.
-	tx := r.db.Begin()
-
-	// There is a launch plan to disable as part of this transaction
-	if toDisable != nil {
-		tx.Model(&toDisable).UpdateColumns(toDisable)
-		if err := tx.Error; err != nil {
-			tx.Rollback()
-			return r.errorTransformer.ToFlyteAdminError(err)
+	var formerlyActive *models.LaunchPlan
+	err := r.retrier.transaction(ctx, func(tx *gorm.DB) error {
+		formerlyActive = nil
+		launchPlanConditions := &models.LaunchPlan{
+			LaunchPlanKey: models.LaunchPlanKey{
+				Project: toEnable.Project,
+				Domain:  toEnable.Domain,
+				Name:    toEnable.Name,
+			},
 		}
+		// Only the ids are read, the point being to lock the rows.
+		var versionIDs []uint
+		if err := tx.Model(&models.LaunchPlan{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where(
+			launchPlanConditions).Order(ID).Pluck(ID, &versionIDs).Error; err != nil {
+			return err
+		}
+		var activeVersions []models.LaunchPlan
+		if err := tx.Where(launchPlanConditions).Where(State+" = ? AND version <> ?",
+			int32(admin.LaunchPlanState_ACTIVE), toEnable.Version).Order(ID).Find(&activeVersions).Error; err != nil {
+			return err
+		}
+		for i := range activeVersions {
+			if err := tx.Model(&activeVersions[i]).UpdateColumn(State,
+				int32(admin.LaunchPlanState_INACTIVE)).Error; err != nil {
+				return err
+			}
+			formerlyActive = &activeVersions[i]
+		}
+		return tx.Model(&toEnable).UpdateColumns(toEnable).Error
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	// And update the desired version.
-	tx.Model(&toEnable).UpdateColumns(toEnable)
-	if err := tx.Error; err != nil {
-		tx.Rollback()
-		return r.errorTransformer.ToFlyteAdminError(err)
-	}
-	if err := tx.Commit().Error; err != nil {
-		return r.errorTransformer.ToFlyteAdminError(err)
-	}
-	return nil
+	return formerlyActive, nil
 }
 
 func (r *LaunchPlanRepo) List(ctx context.Context, input interfaces.ListResourceInput) (
@@ -114,10 +131,13 @@ func (r *LaunchPlanRepo) List(ctx context.Context, input interfaces.ListResource
 		return interfaces.LaunchPlanCollectionOutput{}, err
 	}
 	var launchPlans []models.LaunchPlan
-	tx := r.db.Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
 
 	// Add join conditions
 	tx = tx.Joins("inner join workflows on launch_plans.workflow_id = workflows.id")
+	if ok := input.JoinTableEntities[common.NamedEntityMetadata]; ok {
+		tx = tx.Joins(leftJoinWorkflowNameToMetadata)
+	}
 
 	// Apply filters
 	tx, err := applyScopedFilters(tx, input.InlineFilters, input.MapFilters)
@@ -151,7 +171,7 @@ func (r *LaunchPlanRepo) ListLaunchPlanIdentifiers(ctx context.Context, input in
 		return interfaces.LaunchPlanCollectionOutput{}, err
 	}
 
-	tx := r.db.Model(models.LaunchPlan{}).Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Model(models.LaunchPlan{}).Limit(input.Limit).Offset(input.Offset)
 
 	// Apply filters
 	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
@@ -193,5 +213,6 @@ func NewLaunchPlanRepo(
 		errorTransformer:  errorTransformer,
 		metrics:           metrics,
 		launchPlanMetrics: launchPlanMetrics,
+		retrier:           newTransactionRetrier(db, errorTransformer, scope),
 	}
 }
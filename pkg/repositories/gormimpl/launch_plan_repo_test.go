@@ -13,6 +13,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -121,21 +122,13 @@ func TestSetInactiveLaunchPlan(t *testing.T) {
 	assert.True(t, updated)
 }
 
-func TestSetActiveLaunchPlan(t *testing.T) {
-	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+const lockLaunchPlanVersionsQuery = `SELECT "id" FROM "launch_plans" WHERE "launch_plans"."project" = $1 AND "launch_plans"."domain" = $2 AND "launch_plans"."name" = $3 ORDER BY id FOR UPDATE`
+const getActiveLaunchPlanVersionsQuery = `SELECT * FROM "launch_plans" WHERE "launch_plans"."project" = $1 AND "launch_plans"."domain" = $2 AND "launch_plans"."name" = $3 AND (state = $4 AND version <> $5) ORDER BY id`
+const deactivateLaunchPlanQuery = `UPDATE "launch_plans" SET "state"=$1 WHERE "project" = $2 AND "domain" = $3 AND "name" = $4 AND "version" = $5`
+const activateLaunchPlanQuery = `UPDATE "launch_plans" SET "id"=$1,"project"=$2,"domain"=$3,"name"=$4,"version"=$5,"closure"=$6,"state"=$7 WHERE "project" = $8 AND "domain" = $9 AND "name" = $10 AND "version" = $11`
 
-	GlobalMock := mocket.Catcher.Reset()
-	GlobalMock.Logging = true
-	mockQuery := GlobalMock.NewMock()
-	updated := false
-	mockQuery.WithQuery(
-		`UPDATE "launch_plans" SET "id"=$1,"project"=$2,"domain"=$3,"name"=$4,"version"=$5,"closure"=$6,"state"=$7 WHERE "project" = $8 AND "domain" = $9 AND "name" = $10 AND "version" = $11`).WithCallback(
-		func(s string, values []driver.NamedValue) {
-			updated = true
-		},
-	)
-
-	err := launchPlanRepo.SetActive(context.Background(), models.LaunchPlan{
+func getLaunchPlanToActivate() models.LaunchPlan {
+	return models.LaunchPlan{
 		BaseModel: models.BaseModel{
 			ID: 1,
 		},
@@ -147,21 +140,55 @@ func TestSetActiveLaunchPlan(t *testing.T) {
 		},
 		Closure: []byte{5, 6},
 		State:   &active,
-	}, &models.LaunchPlan{
-		BaseModel: models.BaseModel{
-			ID: 2,
+	}
+}
+
+func TestSetActiveLaunchPlan(t *testing.T) {
+	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	locked := false
+	GlobalMock.NewMock().WithQuery(lockLaunchPlanVersionsQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			locked = true
 		},
-		LaunchPlanKey: models.LaunchPlanKey{
-			Project: project,
-			Domain:  domain,
-			Name:    name,
-			Version: "old version",
+	).WithReply([]map[string]interface{}{{"id": 1}, {"id": 2}})
+	GlobalMock.NewMock().WithQuery(getActiveLaunchPlanVersionsQuery).WithReply([]map[string]interface{}{
+		{
+			"id":      2,
+			"project": project,
+			"domain":  domain,
+			"name":    name,
+			"version": "old version",
+			"state":   active,
 		},
-		Closure: []byte{5, 6},
-		State:   &inactive,
 	})
+	var deactivated []driver.NamedValue
+	GlobalMock.NewMock().WithQuery(deactivateLaunchPlanQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			deactivated = values
+		},
+	)
+	activated := false
+	GlobalMock.NewMock().WithQuery(activateLaunchPlanQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			activated = true
+		},
+	)
+
+	formerlyActive, err := launchPlanRepo.SetActive(context.Background(), getLaunchPlanToActivate())
 	assert.NoError(t, err)
-	assert.True(t, updated)
+	assert.True(t, locked)
+	if assert.Len(t, deactivated, 5) {
+		assert.EqualValues(t, inactive, deactivated[0].Value)
+		assert.Equal(t, "old version", deactivated[4].Value)
+	}
+	assert.True(t, activated)
+	if assert.NotNil(t, formerlyActive) {
+		assert.Equal(t, "old version", formerlyActive.Version)
+		assert.Equal(t, inactive, *formerlyActive.State)
+	}
 }
 
 func TestSetActiveLaunchPlan_NoCurrentlyActiveLaunchPlan(t *testing.T) {
@@ -169,29 +196,67 @@ func TestSetActiveLaunchPlan_NoCurrentlyActiveLaunchPlan(t *testing.T) {
 
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
-	mockQuery := GlobalMock.NewMock()
-	updated := false
-	mockQuery.WithQuery(
-		`UPDATE "launch_plans" SET "id"=$1,"project"=$2,"domain"=$3,"name"=$4,"version"=$5,"closure"=$6,"state"=$7 WHERE "project" = $8 AND "domain" = $9 AND "name" = $10 AND "version" = $11`).WithCallback(
+	deactivated := false
+	GlobalMock.NewMock().WithQuery(deactivateLaunchPlanQuery).WithCallback(
 		func(s string, values []driver.NamedValue) {
-			updated = true
+			deactivated = true
 		},
 	)
-	err := launchPlanRepo.SetActive(context.Background(), models.LaunchPlan{
-		BaseModel: models.BaseModel{
-			ID: 1,
+	activated := false
+	GlobalMock.NewMock().WithQuery(activateLaunchPlanQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			activated = true
 		},
-		LaunchPlanKey: models.LaunchPlanKey{
-			Project: project,
-			Domain:  domain,
-			Name:    name,
-			Version: "new version",
+	)
+	formerlyActive, err := launchPlanRepo.SetActive(context.Background(), getLaunchPlanToActivate())
+	assert.NoError(t, err)
+	assert.False(t, deactivated)
+	assert.True(t, activated)
+	assert.Nil(t, formerlyActive)
+}
+
+func TestSetActiveLaunchPlan_RetriesDeadlock(t *testing.T) {
+	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewPostgresErrorTransformer(mockScope.NewTestScope()),
+		mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	GlobalMock.NewMock().WithQuery(getActiveLaunchPlanVersionsQuery).WithReply([]map[string]interface{}{
+		{
+			"id":      2,
+			"project": project,
+			"domain":  domain,
+			"name":    name,
+			"version": "old version",
+			"state":   active,
 		},
-		Closure: []byte{5, 6},
-		State:   &active,
-	}, nil)
+	})
+	deadlockQuery := GlobalMock.NewMock().WithQuery(activateLaunchPlanQuery).WithError(&pgconn.PgError{
+		Code:    "40P01",
+		Message: "deadlock detected",
+	}).OneTime()
+	deactivations := 0
+	GlobalMock.NewMock().WithQuery(deactivateLaunchPlanQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			deactivations++
+		},
+	)
+	activations := 0
+	GlobalMock.NewMock().WithQuery(activateLaunchPlanQuery).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			activations++
+		},
+	)
+
+	formerlyActive, err := launchPlanRepo.SetActive(context.Background(), getLaunchPlanToActivate())
 	assert.NoError(t, err)
-	assert.True(t, updated)
+	assert.True(t, deadlockQuery.Triggered)
+	// The formerly active launch plan is deactivated again by the retried transaction.
+	assert.Equal(t, 2, deactivations)
+	assert.Equal(t, 1, activations)
+	if assert.NotNil(t, formerlyActive) {
+		assert.Equal(t, "old version", formerlyActive.Version)
+	}
 }
 
 func TestListLaunchPlans(t *testing.T) {
@@ -264,7 +329,7 @@ func TestListLaunchPlans_Pagination(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 
 	GlobalMock.NewMock().WithQuery(
-		`SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 LIMIT 2 OFFSET 1`).WithReply(launchPlans)
+		`SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type","launch_plans"."schedule_overlap_policy","launch_plans"."activated_at" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 LIMIT 2 OFFSET 1`).WithReply(launchPlans)
 
 	collection, err := launchPlanRepo.List(context.Background(), interfaces.ListResourceInput{
 		InlineFilters: []common.InlineFilter{
@@ -290,6 +355,49 @@ func TestListLaunchPlans_Pagination(t *testing.T) {
 	}
 }
 
+func TestListLaunchPlans_HidesArchivedWorkflows(t *testing.T) {
+	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	launchPlans := make([]map[string]interface{}, 0)
+	versions := []string{"ABC", "DEF"}
+	for _, version := range versions {
+		launchPlan := getMockLaunchPlanResponseFromDb(models.LaunchPlan{
+			LaunchPlanKey: models.LaunchPlanKey{
+				Project: project,
+				Domain:  domain,
+				Name:    name,
+				Version: version,
+			},
+			Spec:       launchPlanSpec,
+			WorkflowID: workflowID,
+			Closure:    launchPlanClosure,
+			State:      &inactive,
+		})
+		launchPlans = append(launchPlans, launchPlan)
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`inner join workflows on launch_plans.workflow_id = workflows.id LEFT JOIN named_entity_metadata ON named_entity_metadata.resource_type = 2 AND named_entity_metadata.project = workflows.project AND named_entity_metadata.domain = workflows.domain AND named_entity_metadata.name = workflows.name WHERE launch_plans.project = $1 AND COALESCE(named_entity_metadata.state, 0) <> $2 LIMIT 2 OFFSET 1`).WithReply(launchPlans)
+
+	archivedFilter, err := common.NewWithDefaultValueFilter("0", getNotEqualityFilter(
+		common.NamedEntityMetadata, "state", int32(admin.NamedEntityState_NAMED_ENTITY_ARCHIVED)))
+	assert.NoError(t, err)
+	collection, err := launchPlanRepo.List(context.Background(), interfaces.ListResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.LaunchPlan, "project", project),
+			archivedFilter,
+		},
+		JoinTableEntities: map[common.Entity]bool{
+			common.NamedEntityMetadata: true,
+		},
+		Limit:  2,
+		Offset: 1,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, collection.LaunchPlans, 2)
+}
+
 func TestListLaunchPlans_Filters(t *testing.T) {
 	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 
@@ -311,7 +419,7 @@ func TestListLaunchPlans_Filters(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
 	// Only match on queries that append the name filter
-	GlobalMock.NewMock().WithQuery(`SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND launch_plans.version = $4 LIMIT 20`).WithReply(launchPlans[0:1])
+	GlobalMock.NewMock().WithQuery(`SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type","launch_plans"."schedule_overlap_policy","launch_plans"."activated_at" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND launch_plans.version = $4 LIMIT 20`).WithReply(launchPlans[0:1])
 
 	collection, err := launchPlanRepo.List(context.Background(), interfaces.ListResourceInput{
 		InlineFilters: []common.InlineFilter{
@@ -363,6 +471,75 @@ func TestListLaunchPlans_Order(t *testing.T) {
 	assert.True(t, mockQuery.Triggered)
 }
 
+func TestListLaunchPlans_ActiveBySchedule(t *testing.T) {
+	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	activatedAtSort, _ := common.NewSortParameter(admin.Sort{
+		Direction: admin.Sort_DESCENDING,
+		Key:       "activated_at",
+	})
+
+	testCases := []struct {
+		name                   string
+		scheduleTypeExpression common.FilterExpression
+		expectedQuery          string
+	}{
+		{
+			name:                   "scheduled",
+			scheduleTypeExpression: common.NotEqual,
+			expectedQuery:          `WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.state = $3 AND launch_plans.schedule_type <> $4 ORDER BY activated_at desc LIMIT 10 OFFSET 10`,
+		},
+		{
+			name:                   "unscheduled",
+			scheduleTypeExpression: common.Equal,
+			expectedQuery:          `WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.state = $3 AND launch_plans.schedule_type = $4 ORDER BY activated_at desc LIMIT 10 OFFSET 10`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Only the active version of the launch plan is returned, even when a newer version is inactive.
+			launchPlans := []map[string]interface{}{
+				getMockLaunchPlanResponseFromDb(models.LaunchPlan{
+					LaunchPlanKey: models.LaunchPlanKey{
+						Project: project,
+						Domain:  domain,
+						Name:    name,
+						Version: "older",
+					},
+					Spec:       launchPlanSpec,
+					WorkflowID: workflowID,
+					Closure:    launchPlanClosure,
+					State:      &active,
+				}),
+			}
+
+			GlobalMock := mocket.Catcher.Reset()
+			GlobalMock.Logging = true
+			mockQuery := GlobalMock.NewMock()
+			mockQuery.WithQuery(tc.expectedQuery).WithReply(launchPlans)
+
+			scheduleTypeFilter, err := common.NewSingleValueFilter(
+				common.LaunchPlan, tc.scheduleTypeExpression, "schedule_type", "NONE")
+			assert.NoError(t, err)
+			collection, err := launchPlanRepo.List(context.Background(), interfaces.ListResourceInput{
+				InlineFilters: []common.InlineFilter{
+					getEqualityFilter(common.LaunchPlan, "project", project),
+					getEqualityFilter(common.LaunchPlan, "domain", domain),
+					getEqualityFilter(common.LaunchPlan, "state", active),
+					scheduleTypeFilter,
+				},
+				SortParameter: activatedAtSort,
+				Limit:         10,
+				Offset:        10,
+			})
+			assert.NoError(t, err)
+			assert.True(t, mockQuery.Triggered)
+			assert.Len(t, collection.LaunchPlans, 1)
+			assert.Equal(t, "older", collection.LaunchPlans[0].Version)
+			assert.Equal(t, active, *collection.LaunchPlans[0].State)
+		})
+	}
+}
+
 func TestListLaunchPlans_MissingParameters(t *testing.T) {
 	launchPlanRepo := NewLaunchPlanRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 	_, err := launchPlanRepo.List(context.Background(), interfaces.ListResourceInput{
@@ -403,8 +580,8 @@ func TestListLaunchPlansForWorkflow(t *testing.T) {
 	// HACK: gorm orders the filters on join clauses non-deterministically. Ordering of filters doesn't affect
 	// correctness, but because the mocket library only pattern matches on substrings, both variations of the (valid)
 	// SQL that gorm produces are checked below.
-	query := `SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND (workflows.deleted_at = $4) LIMIT 20`
-	alternateQuery := `SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND (workflows.deleted_at = $4) LIMIT 20`
+	query := `SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type","launch_plans"."schedule_overlap_policy","launch_plans"."activated_at" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND (workflows.deleted_at = $4) LIMIT 20`
+	alternateQuery := `SELECT "launch_plans"."id","launch_plans"."created_at","launch_plans"."updated_at","launch_plans"."deleted_at","launch_plans"."project","launch_plans"."domain","launch_plans"."name","launch_plans"."version","launch_plans"."spec","launch_plans"."workflow_id","launch_plans"."closure","launch_plans"."state","launch_plans"."digest","launch_plans"."schedule_type","launch_plans"."schedule_overlap_policy","launch_plans"."activated_at" FROM "launch_plans" inner join workflows on launch_plans.workflow_id = workflows.id WHERE launch_plans.project = $1 AND launch_plans.domain = $2 AND launch_plans.name = $3 AND (workflows.deleted_at = $4) LIMIT 20`
 	GlobalMock.NewMock().WithQuery(query).WithReply(launchPlans)
 	GlobalMock.NewMock().WithQuery(alternateQuery).WithReply(launchPlans)
 
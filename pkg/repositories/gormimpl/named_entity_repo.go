@@ -3,6 +3,7 @@ package gormimpl
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"google.golang.org/grpc/codes"
@@ -104,29 +105,47 @@ func getNamedEntityFilters(resourceType core.ResourceType, project string, domai
 	return filters, nil
 }
 
+// Matches the entity names of one resource table, ranking names which start with the query before those which only
+// contain it. Names are deduplicated across versions.
+const searchNamedEntitiesFmt = "SELECT %[1]s.project, %[1]s.domain, %[1]s.name, %[2]d AS resource_type, " +
+	"CASE WHEN LOWER(%[1]s.name) LIKE ? ESCAPE '!' THEN 0 ELSE 1 END AS match_rank FROM %[1]s " +
+	"WHERE LOWER(%[1]s.name) LIKE ? ESCAPE '!'%[3]s GROUP BY %[1]s.project, %[1]s.domain, %[1]s.name"
+
+const searchNamedEntitiesProjectsFmt = " AND %s.project IN (?)"
+
+const searchNamedEntitiesOrder = "ORDER BY matches.match_rank, matches.name, matches.project, matches.domain, " +
+	"matches.resource_type LIMIT ? OFFSET ?"
+
+var leftJoinMatchesToMetadata = fmt.Sprintf(
+	"LEFT JOIN %[1]s ON %[1]s.resource_type = matches.resource_type AND %[1]s.project = matches.project AND "+
+		"%[1]s.domain = matches.domain AND %[1]s.name = matches.name", namedEntityMetadataTableName)
+
+// Databases disagree on whether LIKE patterns have an escape character by default, so patterns name theirs.
+var likePatternEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
 // Implementation of NamedEntityRepoInterface.
 type NamedEntityRepo struct {
 	db               *gorm.DB
 	errorTransformer errors.ErrorTransformer
 	metrics          gormMetrics
+	retrier          transactionRetrier
 }
 
+// The metadata is assigned outright, so the update is retried when it conflicts with a concurrent one.
 func (r *NamedEntityRepo) Update(ctx context.Context, input models.NamedEntity) error {
 	timer := r.metrics.UpdateDuration.Start()
-	var metadata models.NamedEntityMetadata
-	tx := r.db.Where(&models.NamedEntityMetadata{
-		NamedEntityMetadataKey: models.NamedEntityMetadataKey{
-			ResourceType: input.ResourceType,
-			Project:      input.Project,
-			Domain:       input.Domain,
-			Name:         input.Name,
-		},
-	}).Assign(input.NamedEntityMetadataFields).Omit("id").FirstOrCreate(&metadata)
-	timer.Stop()
-	if tx.Error != nil {
-		return r.errorTransformer.ToFlyteAdminError(tx.Error)
-	}
-	return nil
+	defer timer.Stop()
+	return r.retrier.transaction(ctx, func(tx *gorm.DB) error {
+		var metadata models.NamedEntityMetadata
+		return tx.Where(&models.NamedEntityMetadata{
+			NamedEntityMetadataKey: models.NamedEntityMetadataKey{
+				ResourceType: input.ResourceType,
+				Project:      input.Project,
+				Domain:       input.Domain,
+				Name:         input.Name,
+			},
+		}).Assign(input.NamedEntityMetadataFields).Omit("id").FirstOrCreate(&metadata).Error
+	})
 }
 
 func (r *NamedEntityRepo) Get(ctx context.Context, input interfaces.GetNamedEntityInput) (models.NamedEntity, error) {
@@ -143,7 +162,7 @@ func (r *NamedEntityRepo) Get(ctx context.Context, input interfaces.GetNamedEnti
 		return models.NamedEntity{}, adminErrors.NewFlyteAdminErrorf(codes.InvalidArgument, "Cannot get NamedEntityMetadata for resource type: %v", input.ResourceType)
 	}
 
-	tx := r.db.Table(tableName).Joins(joinString)
+	tx := r.db.WithContext(ctx).Table(tableName).Joins(joinString)
 
 	// Apply filters
 	tx, err = applyScopedFilters(tx, filters, nil)
@@ -182,7 +201,7 @@ func (r *NamedEntityRepo) List(ctx context.Context, input interfaces.ListNamedEn
 			"Cannot list entity names for resource type: %v", input.ResourceType)
 	}
 
-	tx := getSubQueryJoin(r.db, tableName, input)
+	tx := getSubQueryJoin(r.db.WithContext(ctx), tableName, input)
 
 	// Apply filters
 	tx, err := applyScopedFilters(tx, input.InlineFilters, input.MapFilters)
@@ -211,6 +230,48 @@ func (r *NamedEntityRepo) List(ctx context.Context, input interfaces.ListNamedEn
 	}, nil
 }
 
+func (r *NamedEntityRepo) Search(ctx context.Context, input interfaces.SearchNamedEntitiesInput) (
+	interfaces.NamedEntityCollectionOutput, error) {
+	if len(input.Query) == 0 {
+		return interfaces.NamedEntityCollectionOutput{}, errors.GetInvalidInputError("query")
+	}
+	if input.Limit == 0 {
+		return interfaces.NamedEntityCollectionOutput{}, errors.GetInvalidInputError(limit)
+	}
+	query := likePatternEscaper.Replace(strings.ToLower(input.Query))
+	var projectsFilter string
+	matches := make([]string, 0, len(input.ResourceTypes))
+	args := make([]interface{}, 0, 3*len(input.ResourceTypes)+2)
+	for _, resourceType := range input.ResourceTypes {
+		tableName, tableFound := resourceTypeToTableName[resourceType]
+		if !tableFound {
+			return interfaces.NamedEntityCollectionOutput{}, adminErrors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"Cannot search entity names for resource type: %v", resourceType)
+		}
+		args = append(args, query+"%", "%"+query+"%")
+		if len(input.Projects) > 0 {
+			projectsFilter = fmt.Sprintf(searchNamedEntitiesProjectsFmt, tableName)
+			args = append(args, input.Projects)
+		}
+		matches = append(matches, fmt.Sprintf(searchNamedEntitiesFmt, tableName, resourceType, projectsFilter))
+	}
+	args = append(args, input.Limit, input.Offset)
+
+	var entities []models.NamedEntity
+	timer := r.metrics.ListDuration.Start()
+	tx := r.db.WithContext(ctx).Raw(fmt.Sprintf("SELECT matches.project, matches.domain, matches.name, "+
+		"matches.resource_type, %[1]s.description, %[1]s.state FROM (%[2]s) AS matches %[3]s %[4]s",
+		namedEntityMetadataTableName, strings.Join(matches, " UNION ALL "), leftJoinMatchesToMetadata,
+		searchNamedEntitiesOrder), args...).Scan(&entities)
+	timer.Stop()
+	if tx.Error != nil {
+		return interfaces.NamedEntityCollectionOutput{}, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return interfaces.NamedEntityCollectionOutput{
+		Entities: entities,
+	}, nil
+}
+
 // Returns an instance of NamedEntityRepoInterface
 func NewNamedEntityRepo(
 	db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.NamedEntityRepoInterface {
@@ -220,5 +281,6 @@ func NewNamedEntityRepo(
 		db:               db,
 		errorTransformer: errorTransformer,
 		metrics:          metrics,
+		retrier:          newTransactionRetrier(db, errorTransformer, scope),
 	}
 }
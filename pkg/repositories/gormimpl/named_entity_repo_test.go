@@ -2,17 +2,20 @@ package gormimpl
 
 import (
 	"context"
+	"database/sql/driver"
 	"testing"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 
 	mocket "github.com/Selvatico/go-mocket"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -133,6 +136,35 @@ func TestUpdateNamedEntity_CreateNew(t *testing.T) {
 	assert.True(t, mockQuery.Triggered)
 }
 
+func TestUpdateNamedEntity_RetriesDeadlock(t *testing.T) {
+	metadataRepo := NewNamedEntityRepo(GetDbForTest(t), errors.NewPostgresErrorTransformer(mockScope.NewTestScope()),
+		mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	insertQuery := `INSERT INTO "named_entity_metadata" ("created_at","updated_at","deleted_at","resource_type","project","domain","name","description","state") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	deadlockQuery := GlobalMock.NewMock().WithQuery(insertQuery).WithError(&pgconn.PgError{
+		Code:    "40P01",
+		Message: "deadlock detected",
+	}).OneTime()
+	mockQuery := GlobalMock.NewMock().WithQuery(insertQuery)
+
+	err := metadataRepo.Update(context.Background(), models.NamedEntity{
+		NamedEntityKey: models.NamedEntityKey{
+			ResourceType: resourceType,
+			Project:      project,
+			Domain:       domain,
+			Name:         name,
+		},
+		NamedEntityMetadataFields: models.NamedEntityMetadataFields{
+			Description: "updated description",
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, deadlockQuery.Triggered)
+	assert.True(t, mockQuery.Triggered)
+}
+
 func TestListNamedEntity(t *testing.T) {
 	metadataRepo := NewNamedEntityRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 
@@ -173,3 +205,70 @@ func TestListNamedEntity(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, output.Entities, 1)
 }
+
+func TestSearchNamedEntities(t *testing.T) {
+	metadataRepo := NewNamedEntityRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	results := make([]map[string]interface{}, 0)
+	for _, resultName := range []string{"my_wf", "other_my_wf"} {
+		results = append(results, getMockNamedEntityResponseFromDb(models.NamedEntity{
+			NamedEntityKey: models.NamedEntityKey{
+				ResourceType: core.ResourceType_WORKFLOW,
+				Project:      project,
+				Domain:       domain,
+				Name:         resultName,
+			},
+		}))
+	}
+
+	var args []interface{}
+	GlobalMock := mocket.Catcher.Reset()
+	// Names starting with the query rank before those which only contain it, and pages are cut from the ranked
+	// matches of all resource types.
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`SELECT matches.project, matches.domain, matches.name, matches.resource_type, named_entity_metadata.description, named_entity_metadata.state FROM (` +
+			`SELECT workflows.project, workflows.domain, workflows.name, 2 AS resource_type, CASE WHEN LOWER(workflows.name) LIKE $1 ESCAPE '!' THEN 0 ELSE 1 END AS match_rank FROM workflows WHERE LOWER(workflows.name) LIKE $2 ESCAPE '!' AND workflows.project IN ($3,$4) GROUP BY workflows.project, workflows.domain, workflows.name UNION ALL ` +
+			`SELECT launch_plans.project, launch_plans.domain, launch_plans.name, 3 AS resource_type, CASE WHEN LOWER(launch_plans.name) LIKE $5 ESCAPE '!' THEN 0 ELSE 1 END AS match_rank FROM launch_plans WHERE LOWER(launch_plans.name) LIKE $6 ESCAPE '!' AND launch_plans.project IN ($7,$8) GROUP BY launch_plans.project, launch_plans.domain, launch_plans.name` +
+			`) AS matches LEFT JOIN named_entity_metadata ON named_entity_metadata.resource_type = matches.resource_type AND named_entity_metadata.project = matches.project AND named_entity_metadata.domain = matches.domain AND named_entity_metadata.name = matches.name ` +
+			`ORDER BY matches.match_rank, matches.name, matches.project, matches.domain, matches.resource_type LIMIT $9 OFFSET $10`).WithCallback(
+		func(query string, values []driver.NamedValue) {
+			for _, value := range values {
+				args = append(args, value.Value)
+			}
+		}).WithReply(results)
+
+	output, err := metadataRepo.Search(context.Background(), interfaces.SearchNamedEntitiesInput{
+		Query:         "My_WF",
+		ResourceTypes: []core.ResourceType{core.ResourceType_WORKFLOW, core.ResourceType_LAUNCH_PLAN},
+		Projects:      []string{"project", "other"},
+		Limit:         2,
+		Offset:        4,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+	// The query is matched case insensitively and literally.
+	assert.Equal(t, []interface{}{
+		`my!_wf%`, `%my!_wf%`, "project", "other",
+		`my!_wf%`, `%my!_wf%`, "project", "other",
+		int64(2), int64(4),
+	}, args)
+	assert.Len(t, output.Entities, 2)
+	assert.Equal(t, "my_wf", output.Entities[0].Name)
+	assert.Equal(t, core.ResourceType_WORKFLOW, output.Entities[0].ResourceType)
+}
+
+func TestSearchNamedEntities_InvalidInput(t *testing.T) {
+	metadataRepo := NewNamedEntityRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	_, err := metadataRepo.Search(context.Background(), interfaces.SearchNamedEntitiesInput{
+		ResourceTypes: []core.ResourceType{core.ResourceType_WORKFLOW},
+		Limit:         2,
+	})
+	assert.Error(t, err)
+	_, err = metadataRepo.Search(context.Background(), interfaces.SearchNamedEntitiesInput{
+		Query:         "wf",
+		ResourceTypes: []core.ResourceType{core.ResourceType_DATASET},
+		Limit:         2,
+	})
+	assert.Error(t, err)
+}
@@ -24,7 +24,7 @@ type NodeExecutionRepo struct {
 
 func (r *NodeExecutionRepo) Create(ctx context.Context, execution *models.NodeExecution) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&execution)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&execution)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -35,7 +35,7 @@ func (r *NodeExecutionRepo) Create(ctx context.Context, execution *models.NodeEx
 func (r *NodeExecutionRepo) Get(ctx context.Context, input interfaces.NodeExecutionResource) (models.NodeExecution, error) {
 	var nodeExecution models.NodeExecution
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.NodeExecution{
+	tx := r.db.WithContext(ctx).Where(&models.NodeExecution{
 		NodeExecutionKey: models.NodeExecutionKey{
 			NodeID: input.NodeExecutionIdentifier.NodeId,
 			ExecutionKey: models.ExecutionKey{
@@ -64,9 +64,83 @@ func (r *NodeExecutionRepo) Get(ctx context.Context, input interfaces.NodeExecut
 	return nodeExecution, nil
 }
 
+func (r *NodeExecutionRepo) GetBatch(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	nodeIDs []string) ([]models.NodeExecution, error) {
+	var nodeExecutions []models.NodeExecution
+	timer := r.metrics.GetDuration.Start()
+	tx := r.db.WithContext(ctx).Where(&models.NodeExecution{
+		NodeExecutionKey: models.NodeExecutionKey{
+			ExecutionKey: models.ExecutionKey{
+				Project: executionID.Project,
+				Domain:  executionID.Domain,
+				Name:    executionID.Name,
+			},
+		},
+	}).Where("node_id IN (?)", nodeIDs).Preload("ChildNodeExecutions").Find(&nodeExecutions)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return nodeExecutions, nil
+}
+
+func (r *NodeExecutionRepo) ListChildren(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	parentIDs []uint) ([]models.NodeExecution, error) {
+	var nodeExecutions []models.NodeExecution
+	timer := r.metrics.ListDuration.Start()
+	tx := r.db.WithContext(ctx).Where(&models.NodeExecution{
+		NodeExecutionKey: models.NodeExecutionKey{
+			ExecutionKey: models.ExecutionKey{
+				Project: executionID.Project,
+				Domain:  executionID.Domain,
+				Name:    executionID.Name,
+			},
+		},
+	}).Where("parent_id IN (?)", parentIDs).Order(ID).Preload("ChildNodeExecutions").Find(&nodeExecutions)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return nodeExecutions, nil
+}
+
+const nodeExecutionTimelineSelect = "node_executions.node_id, node_executions.phase, " +
+	"node_executions.node_execution_created_at AS created_at, node_executions.started_at, " +
+	"node_executions.node_execution_updated_at AS updated_at, node_executions.duration, " +
+	"COALESCE(MAX(task_executions.retry_attempt), 0) AS retries"
+
+const nodeExecutionTimelineJoin = "LEFT JOIN task_executions ON " +
+	"task_executions.execution_project = node_executions.execution_project AND " +
+	"task_executions.execution_domain = node_executions.execution_domain AND " +
+	"task_executions.execution_name = node_executions.execution_name AND " +
+	"task_executions.node_id = node_executions.node_id"
+
+const nodeExecutionTimelineGroupBy = "node_executions.id, node_executions.node_id, node_executions.phase, " +
+	"node_executions.node_execution_created_at, node_executions.started_at, " +
+	"node_executions.node_execution_updated_at, node_executions.duration"
+
+func (r *NodeExecutionRepo) ListTimeline(ctx context.Context, executionID core.WorkflowExecutionIdentifier) (
+	[]interfaces.NodeExecutionTimelineEntry, error) {
+	var entries []interfaces.NodeExecutionTimelineEntry
+	timer := r.metrics.ListDuration.Start()
+	tx := r.db.WithContext(ctx).Model(&models.NodeExecution{}).Select(nodeExecutionTimelineSelect).
+		Joins(nodeExecutionTimelineJoin).
+		Where("node_executions.execution_project = ? AND node_executions.execution_domain = ? AND "+
+			"node_executions.execution_name = ?", executionID.Project, executionID.Domain, executionID.Name).
+		Where("node_executions.parent_id IS NULL AND node_executions.parent_task_execution_id IS NULL").
+		Group(nodeExecutionTimelineGroupBy).
+		Order("node_executions.node_execution_created_at, node_executions.id").
+		Scan(&entries)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return entries, nil
+}
+
 func (r *NodeExecutionRepo) Update(ctx context.Context, nodeExecution *models.NodeExecution) error {
 	timer := r.metrics.UpdateDuration.Start()
-	tx := r.db.Model(&nodeExecution).Updates(nodeExecution)
+	tx := r.db.WithContext(ctx).Model(&nodeExecution).Updates(nodeExecution)
 	timer.Stop()
 	if err := tx.Error; err != nil {
 		return r.errorTransformer.ToFlyteAdminError(err)
@@ -81,7 +155,7 @@ func (r *NodeExecutionRepo) List(ctx context.Context, input interfaces.ListResou
 		return interfaces.NodeExecutionCollectionOutput{}, err
 	}
 	var nodeExecutions []models.NodeExecution
-	tx := r.db.Limit(input.Limit).Offset(input.Offset).Preload("ChildNodeExecutions")
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset).Preload("ChildNodeExecutions")
 	// And add join condition (joining multiple tables is fine even we only filter on a subset of table attributes).
 	// (this query isn't called for deletes).
 	tx = tx.Joins(fmt.Sprintf("INNER JOIN %s ON %s.execution_project = %s.execution_project AND "+
@@ -95,7 +169,9 @@ func (r *NodeExecutionRepo) List(ctx context.Context, input interfaces.ListResou
 		return interfaces.NodeExecutionCollectionOutput{}, err
 	}
 	// Apply sort ordering.
-	if input.SortParameter != nil {
+	if isKeysetPaginated(input) {
+		tx = applyKeysetPagination(tx, nodeExecutionTableName, input)
+	} else if input.SortParameter != nil {
 		tx = tx.Order(input.SortParameter.GetGormOrderExpr())
 	}
 
@@ -117,7 +193,7 @@ func (r *NodeExecutionRepo) ListEvents(
 		return interfaces.NodeExecutionEventCollectionOutput{}, err
 	}
 	var nodeExecutionEvents []models.NodeExecutionEvent
-	tx := r.db.Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
 	// And add join condition (joining multiple tables is fine even we only filter on a subset of table attributes).
 	// (this query isn't called for deletes).
 	tx = tx.Joins(innerJoinNodeExecToNodeEvents)
@@ -147,7 +223,7 @@ func (r *NodeExecutionRepo) ListEvents(
 func (r *NodeExecutionRepo) Exists(ctx context.Context, input interfaces.NodeExecutionResource) (bool, error) {
 	var nodeExecution models.NodeExecution
 	timer := r.metrics.ExistsDuration.Start()
-	tx := r.db.Select(ID).Where(&models.NodeExecution{
+	tx := r.db.WithContext(ctx).Select(ID).Where(&models.NodeExecution{
 		NodeExecutionKey: models.NodeExecutionKey{
 			NodeID: input.NodeExecutionIdentifier.NodeId,
 			ExecutionKey: models.ExecutionKey{
@@ -150,6 +150,111 @@ func TestGetNodeExecution(t *testing.T) {
 	assert.EqualValues(t, expectedNodeExecution, output)
 }
 
+func TestGetNodeExecutionBatch(t *testing.T) {
+	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	nodeExecutions := make([]map[string]interface{}, 0)
+	for _, nodeID := range []string{"1", "2"} {
+		nodeExecutions = append(nodeExecutions, getMockNodeExecutionResponseFromDb(models.NodeExecution{
+			NodeExecutionKey: models.NodeExecutionKey{
+				NodeID: nodeID,
+				ExecutionKey: models.ExecutionKey{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "1",
+				},
+			},
+			Phase:   nodePhase,
+			Closure: []byte("closure"),
+		}))
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "node_executions" WHERE "node_executions"."execution_project" = $1 AND "node_executions"."execution_domain" = $2 AND "node_executions"."execution_name" = $3 AND node_id IN ($4,$5,$6)`).WithReply(nodeExecutions)
+	output, err := nodeExecutionRepo.GetBatch(context.Background(), core.WorkflowExecutionIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "1",
+	}, []string{"1", "2", "3"})
+	assert.NoError(t, err)
+	assert.Len(t, output, 2)
+	assert.Equal(t, "1", output[0].NodeID)
+	assert.Equal(t, "2", output[1].NodeID)
+}
+
+func TestListNodeExecutionChildren(t *testing.T) {
+	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	parentID := uint(10)
+	nodeExecutions := []map[string]interface{}{
+		getMockNodeExecutionResponseFromDb(models.NodeExecution{
+			NodeExecutionKey: models.NodeExecutionKey{
+				NodeID: "n0-0-dn0",
+				ExecutionKey: models.ExecutionKey{
+					Project: "project",
+					Domain:  "domain",
+					Name:    "1",
+				},
+			},
+			Phase:    nodePhase,
+			Closure:  []byte("closure"),
+			ParentID: &parentID,
+		}),
+	}
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "node_executions" WHERE "node_executions"."execution_project" = $1 AND "node_executions"."execution_domain" = $2 AND "node_executions"."execution_name" = $3 AND parent_id IN ($4,$5) ORDER BY id`).WithReply(nodeExecutions)
+	output, err := nodeExecutionRepo.ListChildren(context.Background(), core.WorkflowExecutionIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "1",
+	}, []uint{10, 11})
+	assert.NoError(t, err)
+	assert.Len(t, output, 1)
+	assert.Equal(t, "n0-0-dn0", output[0].NodeID)
+	assert.Equal(t, parentID, *output[0].ParentID)
+}
+
+func TestListNodeExecutionTimeline(t *testing.T) {
+	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+	createdAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	startedAt := createdAt.Add(time.Second)
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(
+		`LEFT JOIN task_executions ON task_executions.execution_project = node_executions.execution_project`).WithReply(
+		[]map[string]interface{}{
+			{
+				"node_id":    "n0",
+				"phase":      nodePhase,
+				"created_at": createdAt,
+				"started_at": startedAt,
+				"updated_at": startedAt.Add(time.Minute),
+				"duration":   int64(time.Minute),
+				"retries":    2,
+			},
+			{
+				"node_id":    "n1",
+				"phase":      nodePhase,
+				"created_at": createdAt,
+			},
+		})
+	output, err := nodeExecutionRepo.ListTimeline(context.Background(), core.WorkflowExecutionIdentifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "1",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, output, 2)
+	assert.Equal(t, "n0", output[0].NodeID)
+	assert.Equal(t, startedAt, *output[0].StartedAt)
+	assert.Equal(t, time.Minute, output[0].Duration)
+	assert.Equal(t, uint32(2), output[0].Retries)
+	assert.Equal(t, "n1", output[1].NodeID)
+	assert.Nil(t, output[1].StartedAt)
+	assert.Equal(t, uint32(0), output[1].Retries)
+}
+
 func TestListNodeExecutions(t *testing.T) {
 	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 
@@ -226,6 +331,35 @@ func TestListNodeExecutions_Order(t *testing.T) {
 	assert.True(t, mockQuery.Triggered)
 }
 
+func TestListNodeExecutions_KeysetPagination(t *testing.T) {
+	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	// The joined executions table has the same columns, the keyset refers to those of node executions.
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`AND (node_executions.created_at, node_executions.id) > ($2, $3) ` +
+			`ORDER BY node_executions.created_at asc,node_executions.id asc LIMIT 20`)
+
+	sortParameter, _ := common.NewSortParameter(admin.Sort{
+		Direction: admin.Sort_ASCENDING,
+		Key:       common.KeysetSortKey,
+	})
+	_, err := nodeExecutionRepo.List(context.Background(), interfaces.ListResourceInput{
+		SortParameter: sortParameter,
+		KeysetCursor: &common.KeysetCursor{
+			Direction: admin.Sort_ASCENDING,
+			CreatedAt: time.Now(),
+			ID:        100,
+		},
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.NodeExecution, "phase", nodePhase),
+		},
+		Limit: 20,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+}
+
 func TestListNodeExecutions_MissingParameters(t *testing.T) {
 	nodeExecutionRepo := NewNodeExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 	_, err := nodeExecutionRepo.List(context.Background(), interfaces.ListResourceInput{
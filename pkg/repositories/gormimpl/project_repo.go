@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 	"google.golang.org/grpc/codes"
 
@@ -17,6 +18,8 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 )
 
+const projectStateField = "state"
+
 type ProjectRepo struct {
 	db               *gorm.DB
 	errorTransformer flyteAdminDbErrors.ErrorTransformer
@@ -25,7 +28,7 @@ type ProjectRepo struct {
 
 func (r *ProjectRepo) Create(ctx context.Context, project models.Project) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&project)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&project)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -36,7 +39,7 @@ func (r *ProjectRepo) Create(ctx context.Context, project models.Project) error
 func (r *ProjectRepo) Get(ctx context.Context, projectID string) (models.Project, error) {
 	var project models.Project
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.Project{
+	tx := r.db.WithContext(ctx).Where(&models.Project{
 		Identifier: projectID,
 	}).Take(&project)
 	timer.Stop()
@@ -54,21 +57,19 @@ func (r *ProjectRepo) Get(ctx context.Context, projectID string) (models.Project
 func (r *ProjectRepo) List(ctx context.Context, input interfaces.ListResourceInput) ([]models.Project, error) {
 	var projects []models.Project
 
-	tx := r.db.Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Offset(input.Offset)
 	if input.Limit != 0 {
 		tx = tx.Limit(input.Limit)
 	}
 
 	// Apply filters
-	// If no filter provided, default to filtering out archived projects
-	if len(input.InlineFilters) == 0 && len(input.MapFilters) == 0 {
+	// Unless the caller explicitly filters on project state, default to filtering out archived projects
+	if !hasStateFilter(input.InlineFilters) {
 		tx = tx.Where("state != ?", int32(admin.Project_ARCHIVED))
-	} else {
-		var err error
-		tx, err = applyFilters(tx, input.InlineFilters, input.MapFilters)
-		if err != nil {
-			return nil, err
-		}
+	}
+	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
+	if err != nil {
+		return nil, err
 	}
 
 	// Apply sort ordering
@@ -86,6 +87,15 @@ func (r *ProjectRepo) List(ctx context.Context, input interfaces.ListResourceInp
 	return projects, nil
 }
 
+func hasStateFilter(filters []common.InlineFilter) bool {
+	for _, filter := range filters {
+		if filter.GetField() == projectStateField {
+			return true
+		}
+	}
+	return false
+}
+
 func NewProjectRepo(db *gorm.DB, errorTransformer flyteAdminDbErrors.ErrorTransformer,
 	scope promutils.Scope) interfaces.ProjectRepoInterface {
 	metrics := newMetrics(scope)
@@ -98,7 +108,7 @@ func NewProjectRepo(db *gorm.DB, errorTransformer flyteAdminDbErrors.ErrorTransf
 
 func (r *ProjectRepo) UpdateProject(ctx context.Context, projectUpdate models.Project) error {
 	// Use gorm client to update the two fields that are changed.
-	writeTx := r.db.Model(&projectUpdate).Updates(projectUpdate)
+	writeTx := r.db.WithContext(ctx).Model(&projectUpdate).Updates(projectUpdate)
 
 	// Return error if applies.
 	if writeTx.Error != nil {
@@ -100,7 +100,18 @@ func TestListProjects(t *testing.T) {
 		Limit:         1,
 		InlineFilters: []common.InlineFilter{filter},
 		SortParameter: alphabeticalSortParam,
-	}, `SELECT * FROM "projects" WHERE name = $1 ORDER BY identifier asc LIMIT 1`, t)
+	}, `SELECT * FROM "projects" WHERE state != $1 AND name = $2 ORDER BY identifier asc LIMIT 1`, t)
+}
+
+func TestListProjects_StateFilter(t *testing.T) {
+	filter, err := common.NewSingleValueFilter(common.Project, common.Equal, "state", int32(admin.Project_ARCHIVED))
+	assert.Nil(t, err)
+	testListProjects(interfaces.ListResourceInput{
+		Offset:        0,
+		Limit:         1,
+		InlineFilters: []common.InlineFilter{filter},
+		SortParameter: alphabeticalSortParam,
+	}, `SELECT * FROM "projects" WHERE state = $1 ORDER BY identifier asc LIMIT 1`, t)
 }
 
 func TestListProjects_NoFilters(t *testing.T) {
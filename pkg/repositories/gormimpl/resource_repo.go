@@ -11,6 +11,7 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils"
 	"google.golang.org/grpc/codes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
 )
@@ -19,77 +20,155 @@ type ResourceRepo struct {
 	db               *gorm.DB
 	errorTransformer flyteAdminDbErrors.ErrorTransformer
 	metrics          gormMetrics
+	retrier          transactionRetrier
 }
 
 const priorityDescending = "priority desc"
 
+// The columns identifying a resource, which are unique together.
+var resourceKeyColumns = []clause.Column{
+	{Name: "project"}, {Name: "domain"}, {Name: "workflow"}, {Name: "launch_plan"}, {Name: "resource_type"},
+	{Name: "org"},
+}
+
+// Conditions matching exactly the resource the ID identifies, including its empty columns.
+func getResourceKeyConditions(ID interfaces.ResourceID) map[string]interface{} {
+	return map[string]interface{}{
+		"project":       ID.Project,
+		"domain":        ID.Domain,
+		"workflow":      ID.Workflow,
+		"launch_plan":   ID.LaunchPlan,
+		"resource_type": ID.ResourceType,
+		"org":           ID.Org,
+	}
+}
+
+func getResourceID(input models.Resource) interfaces.ResourceID {
+	return interfaces.ResourceID{
+		Project:      input.Project,
+		Domain:       input.Domain,
+		Workflow:     input.Workflow,
+		LaunchPlan:   input.LaunchPlan,
+		ResourceType: input.ResourceType,
+		Org:          input.Org,
+	}
+}
+
 /*
 	The data in the Resource repo maps to the following rules:
-	* Domain and ResourceType can never be empty.
+	* ResourceType can never be empty.
 	* Empty string can be interpreted as all. Example: "" for Project field can be interpreted as all Projects for a domain.
-	* One cannot provide specific value for Project, unless a specific value for Domain is provided.
-	** Project is always scoped within a domain.
-	**	Example: Domain="" Project="Lyft" is invalid.
+	* Either Project or Domain must be provided, unless Org is.
+	** Domain="" Project="Lyft" applies to the project in all its domains.
 	* One cannot provide specific value for Workflow, unless a specific value for Domain and Project is provided.
 	** Workflow is always scoped within a domain and project.
 	**	Example: Domain="staging" Project="" Workflow="W1" is invalid.
 	* One cannot provide specific value for Launch plan, unless a specific value for Domain, Project and Workflow is provided.
 	** Launch plan is always scoped within a domain, project and workflow.
 	**	Example: Domain="staging" Project="Lyft" Workflow="" LaunchPlan= "l1" is invalid.
+	* Org applies to all the projects of the org in all their domains, so no other field can be provided along with it.
 */
-func validateCreateOrUpdateResourceInput(project, domain, workflow, launchPlan, resourceType string) bool {
-	if domain == "" || resourceType == "" {
+func validateCreateOrUpdateResourceInput(ID interfaces.ResourceID) bool {
+	if ID.ResourceType == "" {
+		return false
+	}
+	if ID.Org != "" {
+		return ID.Project == "" && ID.Domain == "" && ID.Workflow == "" && ID.LaunchPlan == ""
+	}
+	if ID.Project == "" && ID.Domain == "" {
 		return false
 	}
-	if project == "" && (workflow != "" || launchPlan != "") {
+	if (ID.Project == "" || ID.Domain == "") && (ID.Workflow != "" || ID.LaunchPlan != "") {
 		return false
 	}
-	if workflow == "" && launchPlan != "" {
+	if ID.Workflow == "" && ID.LaunchPlan != "" {
 		return false
 	}
 	return true
 }
 
 func (r *ResourceRepo) CreateOrUpdate(ctx context.Context, input models.Resource) error {
-	if !validateCreateOrUpdateResourceInput(input.Project, input.Domain, input.Workflow, input.LaunchPlan, input.ResourceType) {
+	if !validateCreateOrUpdateResourceInput(getResourceID(input)) {
 		return flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("%v", input))
 	}
 	if input.Priority == 0 {
 		return flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("invalid priority %v", input))
 	}
-	timer := r.metrics.GetDuration.Start()
-	var record models.Resource
-	tx := r.db.Omit("id").FirstOrCreate(&record, models.Resource{
-		Project:      input.Project,
-		Domain:       input.Domain,
-		Workflow:     input.Workflow,
-		LaunchPlan:   input.LaunchPlan,
-		ResourceType: input.ResourceType,
-		Priority:     input.Priority,
-	})
+	// A single upsert, so that concurrent updates of a resource neither fail nor insert it twice.
+	timer := r.metrics.UpdateDuration.Start()
+	tx := r.db.WithContext(ctx).Omit("id").Clauses(clause.OnConflict{
+		Columns:   resourceKeyColumns,
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "priority", "attributes"}),
+	}).Create(&input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
 	}
+	return nil
+}
 
-	timer = r.metrics.UpdateDuration.Start()
-	record.Attributes = input.Attributes
-	tx = r.db.Save(&record)
-	timer.Stop()
-	if tx.Error != nil {
-		return r.errorTransformer.ToFlyteAdminError(tx.Error)
+// Merges are read-modify-writes of the existing resource, which is locked until the merged resource is saved so that
+// concurrent merges can't overwrite each other. Since merges are repeatable, the transaction is retried when it
+// conflicts with a concurrent one.
+func (r *ResourceRepo) CreateOrMerge(
+	ctx context.Context, input models.Resource, merge interfaces.MergeResourceFunc) error {
+	if !validateCreateOrUpdateResourceInput(getResourceID(input)) {
+		return flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("%v", input))
 	}
-	return nil
+	if input.Priority == 0 {
+		return flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("invalid priority %v", input))
+	}
+	timer := r.metrics.UpdateDuration.Start()
+	defer timer.Stop()
+	// Errors of the merge are returned as they are rather than transformed as database errors.
+	var mergeErr error
+	err := r.retrier.transaction(ctx, func(tx *gorm.DB) error {
+		created := input
+		result := tx.Omit("id").Clauses(clause.OnConflict{DoNothing: true}).Create(&created)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		var existing models.Resource
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(
+			getResourceKeyConditions(getResourceID(input))).Take(&existing).Error; err != nil {
+			return err
+		}
+		var merged models.Resource
+		if merged, mergeErr = merge(existing); mergeErr != nil {
+			return mergeErr
+		}
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"priority":   merged.Priority,
+			"attributes": merged.Attributes,
+		}).Error
+	})
+	if mergeErr != nil {
+		return mergeErr
+	}
+	return err
 }
 
+// Returns the resource of the highest priority among those matching the ID, including those of the org when it's set.
 func (r *ResourceRepo) Get(ctx context.Context, ID interfaces.ResourceID) (models.Resource, error) {
-	if !validateCreateOrUpdateResourceInput(ID.Project, ID.Domain, ID.Workflow, ID.LaunchPlan, ID.ResourceType) {
+	withoutOrg := ID
+	withoutOrg.Org = ""
+	if !validateCreateOrUpdateResourceInput(withoutOrg) {
 		return models.Resource{}, r.errorTransformer.ToFlyteAdminError(flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("%v", ID)))
 	}
 	var resources []models.Resource
 	timer := r.metrics.GetDuration.Start()
 
-	txWhereClause := "resource_type = ? AND domain = ? AND project IN (?) AND workflow IN (?) AND launch_plan IN (?)"
+	txWhereClause := "resource_type = ? AND org IN (?) AND domain IN (?) AND project IN (?) AND workflow IN (?) AND launch_plan IN (?)"
+	org := []string{""}
+	if ID.Org != "" {
+		org = append(org, ID.Org)
+	}
+
+	domain := []string{""}
+	if ID.Domain != "" {
+		domain = append(domain, ID.Domain)
+	}
+
 	project := []string{""}
 	if ID.Project != "" {
 		project = append(project, ID.Project)
@@ -105,7 +184,7 @@ func (r *ResourceRepo) Get(ctx context.Context, ID interfaces.ResourceID) (model
 		launchPlan = append(launchPlan, ID.LaunchPlan)
 	}
 
-	tx := r.db.Where(txWhereClause, ID.ResourceType, ID.Domain, project, workflow, launchPlan)
+	tx := r.db.WithContext(ctx).Where(txWhereClause, ID.ResourceType, org, domain, project, workflow, launchPlan)
 	tx.Order(priorityDescending).First(&resources)
 	timer.Stop()
 
@@ -119,18 +198,12 @@ func (r *ResourceRepo) Get(ctx context.Context, ID interfaces.ResourceID) (model
 }
 
 func (r *ResourceRepo) GetRaw(ctx context.Context, ID interfaces.ResourceID) (models.Resource, error) {
-	if ID.Domain == "" || ID.ResourceType == "" {
+	if !validateCreateOrUpdateResourceInput(ID) {
 		return models.Resource{}, r.errorTransformer.ToFlyteAdminError(flyteAdminDbErrors.GetInvalidInputError(fmt.Sprintf("%v", ID)))
 	}
 	var model models.Resource
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.Resource{
-		Project:      ID.Project,
-		Domain:       ID.Domain,
-		Workflow:     ID.Workflow,
-		LaunchPlan:   ID.LaunchPlan,
-		ResourceType: ID.ResourceType,
-	}).First(&model)
+	tx := r.db.WithContext(ctx).Where(getResourceKeyConditions(ID)).First(&model)
 	timer.Stop()
 
 	if tx.Error != nil && errors.Is(tx.Error, gorm.ErrRecordNotFound) {
@@ -146,7 +219,8 @@ func (r *ResourceRepo) ListAll(ctx context.Context, resourceType string) ([]mode
 	var resources []models.Resource
 	timer := r.metrics.ListDuration.Start()
 
-	tx := r.db.Where(&models.Resource{ResourceType: resourceType}).Order(priorityDescending).Find(&resources)
+	tx := r.db.WithContext(ctx).Where(&models.Resource{ResourceType: resourceType}).Where("org = ?", "").Order(
+		priorityDescending).Find(&resources)
 	timer.Stop()
 
 	if tx.Error != nil {
@@ -158,13 +232,7 @@ func (r *ResourceRepo) ListAll(ctx context.Context, resourceType string) ([]mode
 func (r *ResourceRepo) Delete(ctx context.Context, ID interfaces.ResourceID) error {
 	var tx *gorm.DB
 	r.metrics.DeleteDuration.Time(func() {
-		tx = r.db.Where(&models.Resource{
-			Project:      ID.Project,
-			Domain:       ID.Domain,
-			Workflow:     ID.Workflow,
-			LaunchPlan:   ID.LaunchPlan,
-			ResourceType: ID.ResourceType,
-		}).Unscoped().Delete(models.Resource{})
+		tx = r.db.WithContext(ctx).Where(getResourceKeyConditions(ID)).Unscoped().Delete(models.Resource{})
 	})
 
 	if tx.Error != nil && errors.Is(tx.Error, gorm.ErrRecordNotFound) {
@@ -182,5 +250,6 @@ func NewResourceRepo(db *gorm.DB, errorTransformer flyteAdminDbErrors.ErrorTrans
 		db:               db,
 		errorTransformer: errorTransformer,
 		metrics:          metrics,
+		retrier:          newTransactionRetrier(db, errorTransformer, scope),
 	}
 }
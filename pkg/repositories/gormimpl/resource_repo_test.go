@@ -2,15 +2,25 @@ package gormimpl
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 
 	mocket "github.com/Selvatico/go-mocket"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
 )
 
 const resourceTestWorkflowName = "workflow"
@@ -22,7 +32,7 @@ func TestCreateWorkflowAttributes(t *testing.T) {
 	query := GlobalMock.NewMock()
 	GlobalMock.Logging = true
 	query.WithQuery(
-		`INSERT INTO "resources" ("created_at","updated_at","deleted_at","project","domain","workflow","launch_plan","resource_type","priority","attributes") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING "id"`)
+		`INSERT INTO "resources" ("created_at","updated_at","deleted_at","project","domain","workflow","launch_plan","resource_type","org","priority","attributes") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT ("project","domain","workflow","launch_plan","resource_type","org") DO UPDATE SET "updated_at"="excluded"."updated_at","priority"="excluded"."priority","attributes"="excluded"."attributes" RETURNING "id"`)
 
 	err := resourceRepo.CreateOrUpdate(context.Background(), models.Resource{
 		Project:      "project",
@@ -48,7 +58,7 @@ func TestGetWorkflowAttributes(t *testing.T) {
 	response["attributes"] = []byte("attrs")
 
 	query := GlobalMock.NewMock()
-	query.WithQuery(`SELECT * FROM "resources" WHERE resource_type = $1 AND domain = $2 AND project IN ($3,$4) AND workflow IN ($5,$6) AND launch_plan IN ($7) ORDER BY priority desc,"resources"."id" LIMIT 1`).WithReply(
+	query.WithQuery(`SELECT * FROM "resources" WHERE resource_type = $1 AND org IN ($2) AND domain IN ($3,$4) AND project IN ($5,$6) AND workflow IN ($7,$8) AND launch_plan IN ($9) ORDER BY priority desc,"resources"."id" LIMIT 1`).WithReply(
 		[]map[string]interface{}{
 			response,
 		})
@@ -73,7 +83,7 @@ func TestProjectDomainAttributes(t *testing.T) {
 	response["attributes"] = []byte("attrs")
 
 	query := GlobalMock.NewMock()
-	query.WithQuery(`SELECT * FROM "resources" WHERE resource_type = $1 AND domain = $2 AND project IN ($3,$4) AND workflow IN ($5) AND launch_plan IN ($6) ORDER BY priority desc,"resources"."id" LIMIT 1`).WithReply(
+	query.WithQuery(`SELECT * FROM "resources" WHERE resource_type = $1 AND org IN ($2) AND domain IN ($3,$4) AND project IN ($5,$6) AND workflow IN ($7) AND launch_plan IN ($8) ORDER BY priority desc,"resources"."id" LIMIT 1`).WithReply(
 		[]map[string]interface{}{
 			response,
 		})
@@ -100,7 +110,7 @@ func TestGetRawWorkflowAttributes(t *testing.T) {
 	response["attributes"] = []byte("attrs")
 
 	query := GlobalMock.NewMock()
-	query.WithQuery(`SELECT * FROM "resources" WHERE "resources"."project" = $1 AND "resources"."domain" = $2 AND "resources"."workflow" = $3 AND "resources"."launch_plan" = $4 AND "resources"."resource_type" = $5 ORDER BY "resources"."id" LIMIT 1`).WithReply(
+	query.WithQuery(`SELECT * FROM "resources" WHERE "domain" = $1 AND "launch_plan" = $2 AND "org" = $3 AND "project" = $4 AND "resource_type" = $5 AND "workflow" = $6 ORDER BY "resources"."id" LIMIT 1`).WithReply(
 		[]map[string]interface{}{
 			response,
 		})
@@ -121,7 +131,7 @@ func TestDeleteWorkflowAttributes(t *testing.T) {
 	GlobalMock.Logging = true
 	query := GlobalMock.NewMock()
 	fakeResponse := query.WithQuery(
-		`DELETE FROM "resources" WHERE "resources"."project" = $1 AND "resources"."domain" = $2 AND "resources"."workflow" = $3 AND "resources"."launch_plan" = $4 AND "resources"."resource_type" = $5`)
+		`DELETE FROM "resources" WHERE "domain" = $1 AND "launch_plan" = $2 AND "org" = $3 AND "project" = $4 AND "resource_type" = $5 AND "workflow" = $6`)
 
 	err := resourceRepo.Delete(context.Background(), interfaces.ResourceID{Project: "project", Domain: "domain", Workflow: "workflow", LaunchPlan: "launch_plan", ResourceType: "resource"})
 	assert.Nil(t, err)
@@ -143,7 +153,7 @@ func TestListAll(t *testing.T) {
 	response["launch_plan"] = "launch_plan"
 	response["attributes"] = []byte("attrs")
 
-	fakeResponse := query.WithQuery(`SELECT * FROM "resources" WHERE "resources"."resource_type" = $1 ORDER BY priority desc`).WithReply(
+	fakeResponse := query.WithQuery(`SELECT * FROM "resources" WHERE "resources"."resource_type" = $1 AND (org = $2) ORDER BY priority desc`).WithReply(
 		[]map[string]interface{}{response})
 	output, err := resourceRepo.ListAll(context.Background(), "resource")
 	assert.Nil(t, err)
@@ -156,3 +166,217 @@ func TestListAll(t *testing.T) {
 	assert.Equal(t, []byte("attrs"), output[0].Attributes)
 	assert.True(t, fakeResponse.Triggered)
 }
+
+// Returns a resource repo backed by a migrated SQLite database, which unlike the mock database applies transactions.
+func getSQLiteResourceRepoForTest(t *testing.T) (interfaces.ResourceRepoInterface, *gorm.DB) {
+	db, err := config.OpenDbConnection(config.NewSQLiteConfigProvider(config.DbConfig{
+		BaseConfig: config.BaseConfig{
+			DisableForeignKeyConstraintWhenMigrating: true,
+		},
+		SQLite: runtimeInterfaces.DbSQLiteConfig{
+			File: filepath.Join(t.TempDir(), "flyteadmin.db"),
+		},
+	}, mockScope.NewTestScope()))
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+	_, err = config.NewMigrator(db, config.Migrations).Migrate()
+	assert.NoError(t, err)
+	return NewResourceRepo(db, errors.NewSQLiteErrorTransformer(mockScope.NewTestScope()), mockScope.NewTestScope()), db
+}
+
+func getProjectDomainResource(resourceType string, attributes string) models.Resource {
+	return models.Resource{
+		Project:      project,
+		Domain:       domain,
+		ResourceType: resourceType,
+		Priority:     models.ResourcePriorityProjectDomainLevel,
+		Attributes:   []byte(attributes),
+	}
+}
+
+func TestCreateOrUpdate_ConcurrentUpdates(t *testing.T) {
+	resourceRepo, db := getSQLiteResourceRepoForTest(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, resourceRepo.CreateOrUpdate(context.Background(),
+				getProjectDomainResource("TASK_RESOURCE", fmt.Sprintf("attributes-%d", i))))
+		}(i)
+	}
+	wg.Wait()
+
+	var count int64
+	assert.NoError(t, db.Model(&models.Resource{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCreateOrUpdate_LeavesOtherTypes(t *testing.T) {
+	resourceRepo, _ := getSQLiteResourceRepoForTest(t)
+	ctx := context.Background()
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, getProjectDomainResource("TASK_RESOURCE", "tasks")))
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, getProjectDomainResource("EXECUTION_QUEUE", "queues")))
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, getProjectDomainResource("TASK_RESOURCE", "updated tasks")))
+
+	taskResource, err := resourceRepo.Get(ctx, interfaces.ResourceID{
+		Project: project, Domain: domain, ResourceType: "TASK_RESOURCE"})
+	assert.NoError(t, err)
+	assert.Equal(t, "updated tasks", string(taskResource.Attributes))
+	executionQueue, err := resourceRepo.Get(ctx, interfaces.ResourceID{
+		Project: project, Domain: domain, ResourceType: "EXECUTION_QUEUE"})
+	assert.NoError(t, err)
+	assert.Equal(t, "queues", string(executionQueue.Attributes))
+
+	assert.NoError(t, resourceRepo.Delete(ctx, interfaces.ResourceID{
+		Project: project, Domain: domain, ResourceType: "TASK_RESOURCE"}))
+	_, err = resourceRepo.Get(ctx, interfaces.ResourceID{Project: project, Domain: domain, ResourceType: "TASK_RESOURCE"})
+	assert.Error(t, err)
+	_, err = resourceRepo.Get(ctx, interfaces.ResourceID{Project: project, Domain: domain, ResourceType: "EXECUTION_QUEUE"})
+	assert.NoError(t, err)
+}
+
+func TestCreateOrMerge_ConcurrentMerges(t *testing.T) {
+	resourceRepo, db := getSQLiteResourceRepoForTest(t)
+	// Each merge adds its own entry to the attributes, all of which must survive. Merges take a while, so that
+	// concurrent ones would overlap unless they're serialized.
+	merge := func(entry string) interfaces.MergeResourceFunc {
+		return func(existing models.Resource) (models.Resource, error) {
+			time.Sleep(5 * time.Millisecond)
+			existing.Attributes = []byte(string(existing.Attributes) + "," + entry)
+			return existing, nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	expected := make([]string, 0, 10)
+	for i := 0; i < 10; i++ {
+		entry := fmt.Sprintf("override-%d", i)
+		expected = append(expected, entry)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, resourceRepo.CreateOrMerge(context.Background(),
+				getProjectDomainResource("PLUGIN_OVERRIDE", entry), merge(entry)))
+		}()
+	}
+	wg.Wait()
+
+	resource, err := resourceRepo.GetRaw(context.Background(), interfaces.ResourceID{
+		Project: project, Domain: domain, ResourceType: "PLUGIN_OVERRIDE"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expected, strings.Split(string(resource.Attributes), ","))
+	var count int64
+	assert.NoError(t, db.Model(&models.Resource{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCreateOrMerge_MergeError(t *testing.T) {
+	resourceRepo, _ := getSQLiteResourceRepoForTest(t)
+	ctx := context.Background()
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, getProjectDomainResource("PLUGIN_OVERRIDE", "existing")))
+
+	mergeErr := adminErrors.NewFlyteAdminError(codes.Internal, "unable to unmarshal existing attributes")
+	err := resourceRepo.CreateOrMerge(ctx, getProjectDomainResource("PLUGIN_OVERRIDE", "new"),
+		func(existing models.Resource) (models.Resource, error) {
+			return models.Resource{}, mergeErr
+		})
+	assert.Equal(t, mergeErr, err)
+	resource, err := resourceRepo.GetRaw(ctx, interfaces.ResourceID{
+		Project: project, Domain: domain, ResourceType: "PLUGIN_OVERRIDE"})
+	assert.NoError(t, err)
+	assert.Equal(t, "existing", string(resource.Attributes))
+}
+
+func TestValidateCreateOrUpdateResourceInput(t *testing.T) {
+	for _, tc := range []struct {
+		ID    interfaces.ResourceID
+		valid bool
+	}{
+		{interfaces.ResourceID{Domain: domain, ResourceType: "TASK_RESOURCE"}, true},
+		{interfaces.ResourceID{Project: project, ResourceType: "TASK_RESOURCE"}, true},
+		{interfaces.ResourceID{Project: project, Domain: domain, ResourceType: "TASK_RESOURCE"}, true},
+		{interfaces.ResourceID{Project: project, Domain: domain, Workflow: "workflow", LaunchPlan: "lp",
+			ResourceType: "TASK_RESOURCE"}, true},
+		{interfaces.ResourceID{Org: "acme", ResourceType: "TASK_RESOURCE"}, true},
+		{interfaces.ResourceID{ResourceType: "TASK_RESOURCE"}, false},
+		{interfaces.ResourceID{Project: project, Domain: domain}, false},
+		{interfaces.ResourceID{Project: project, Workflow: "workflow", ResourceType: "TASK_RESOURCE"}, false},
+		{interfaces.ResourceID{Domain: domain, Workflow: "workflow", ResourceType: "TASK_RESOURCE"}, false},
+		{interfaces.ResourceID{Project: project, Domain: domain, LaunchPlan: "lp", ResourceType: "TASK_RESOURCE"}, false},
+		{interfaces.ResourceID{Org: "acme", Domain: domain, ResourceType: "TASK_RESOURCE"}, false},
+		{interfaces.ResourceID{Org: "acme", Project: project, ResourceType: "TASK_RESOURCE"}, false},
+	} {
+		assert.Equal(t, tc.valid, validateCreateOrUpdateResourceInput(tc.ID), "%+v", tc.ID)
+	}
+}
+
+func TestGet_Precedence(t *testing.T) {
+	resourceRepo, _ := getSQLiteResourceRepoForTest(t)
+	ctx := context.Background()
+	for _, resource := range []models.Resource{
+		{Domain: domain, Priority: models.ResourcePriorityDomainLevel},
+		{Org: "acme", Priority: models.ResourcePriorityOrgLevel},
+		{Org: "globex", Priority: models.ResourcePriorityOrgLevel},
+		{Project: project, Priority: models.ResourcePriorityProjectLevel},
+		{Project: "other", Priority: models.ResourcePriorityProjectLevel},
+		{Project: project, Domain: domain, Priority: models.ResourcePriorityProjectDomainLevel},
+		{Project: project, Domain: "other", Priority: models.ResourcePriorityProjectDomainLevel},
+		{Project: project, Domain: domain, Workflow: "workflow", Priority: models.ResourcePriorityWorkflowLevel},
+	} {
+		resource.ResourceType = "TASK_RESOURCE"
+		resource.Attributes = []byte(fmt.Sprintf("%s/%s/%s/%s", resource.Org, resource.Project, resource.Domain,
+			resource.Workflow))
+		assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, resource))
+	}
+
+	for _, tc := range []struct {
+		ID       interfaces.ResourceID
+		expected string
+	}{
+		{interfaces.ResourceID{Project: project, Domain: domain, Workflow: "workflow", LaunchPlan: "lp"},
+			"/project/domain/workflow"},
+		{interfaces.ResourceID{Project: project, Domain: domain, Workflow: "other", Org: "acme"}, "/project/domain/"},
+		{interfaces.ResourceID{Project: project, Domain: "development", Org: "acme"}, "/project//"},
+		{interfaces.ResourceID{Project: "unknown", Domain: domain, Org: "acme"}, "acme///"},
+		{interfaces.ResourceID{Project: "unknown", Domain: domain, Org: "globex"}, "globex///"},
+		{interfaces.ResourceID{Project: "unknown", Domain: domain}, "//domain/"},
+		{interfaces.ResourceID{Project: "unknown", Domain: "development", Org: "acme"}, "acme///"},
+		{interfaces.ResourceID{Project: "other"}, "/other//"},
+	} {
+		tc.ID.ResourceType = "TASK_RESOURCE"
+		resource, err := resourceRepo.Get(ctx, tc.ID)
+		assert.NoError(t, err, "%+v", tc.ID)
+		assert.Equal(t, tc.expected, string(resource.Attributes), "%+v", tc.ID)
+	}
+
+	_, err := resourceRepo.Get(ctx, interfaces.ResourceID{Project: "unknown", Domain: "development",
+		ResourceType: "TASK_RESOURCE"})
+	assert.Equal(t, codes.NotFound, err.(adminErrors.FlyteAdminError).Code())
+	// The attributes of an org are only resolved for a project.
+	_, err = resourceRepo.Get(ctx, interfaces.ResourceID{Org: "acme", ResourceType: "TASK_RESOURCE"})
+	assert.Error(t, err)
+}
+
+func TestDelete_Exact(t *testing.T) {
+	resourceRepo, db := getSQLiteResourceRepoForTest(t)
+	ctx := context.Background()
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, getProjectDomainResource("TASK_RESOURCE", "project domain")))
+	assert.NoError(t, resourceRepo.CreateOrUpdate(ctx, models.Resource{
+		Domain:       domain,
+		ResourceType: "TASK_RESOURCE",
+		Priority:     models.ResourcePriorityDomainLevel,
+	}))
+
+	// Deleting the attributes of the domain leaves those of its projects.
+	assert.NoError(t, resourceRepo.Delete(ctx, interfaces.ResourceID{Domain: domain, ResourceType: "TASK_RESOURCE"}))
+	var resources []models.Resource
+	assert.NoError(t, db.Find(&resources).Error)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, "project domain", string(resources[0].Attributes))
+}
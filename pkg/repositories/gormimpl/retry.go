@@ -0,0 +1,81 @@
+package gormimpl
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+const (
+	// How many times a transaction failing with a retryable error is retried before the error is returned.
+	maxTransactionRetries = 3
+	// The delay before the first retry, which doubles with each subsequent one.
+	transactionRetryBackoff = 20 * time.Millisecond
+)
+
+// Retries transactions which fail with errors that abort them without effect, such as deadlocks and serialization
+// failures between concurrent transactions or lost connections. Only transactions which are safe to repeat, e.g.
+// because they set rather than increment values, should be retried.
+type transactionRetrier struct {
+	db               *gorm.DB
+	errorTransformer adminErrors.ErrorTransformer
+	maxRetries       int
+	backoff          time.Duration
+	// Counts the retries by the class of error which caused them.
+	retries *prometheus.CounterVec
+}
+
+func isRetryable(err flyteAdminErrors.FlyteAdminError) bool {
+	return err.Code() == codes.Aborted || err.Code() == codes.Unavailable
+}
+
+// Returns a randomized delay of the given retry, so that the transactions which conflicted are unlikely to conflict
+// again.
+func (r *transactionRetrier) getBackoff(retry int) time.Duration {
+	backoff := r.backoff << retry
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff))) // #nosec
+}
+
+// Runs fn within a transaction, retrying it as long as it fails with a retryable error and retries are left. The error
+// returned, if any, is already transformed.
+func (r *transactionRetrier) transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	for retry := 0; ; retry++ {
+		err := r.db.WithContext(ctx).Transaction(fn)
+		if err == nil {
+			return nil
+		}
+		adminErr := r.errorTransformer.ToFlyteAdminError(err)
+		if !isRetryable(adminErr) || retry >= r.maxRetries {
+			return adminErr
+		}
+		errorClass := strings.ToLower(adminErr.Code().String())
+		r.retries.WithLabelValues(errorClass).Inc()
+		logger.Infof(ctx, "Retrying transaction after attempt [%d] failed with a retryable error: %v", retry+1, err)
+		select {
+		case <-ctx.Done():
+			return adminErr
+		case <-time.After(r.getBackoff(retry)):
+		}
+	}
+}
+
+func newTransactionRetrier(db *gorm.DB, errorTransformer adminErrors.ErrorTransformer,
+	scope promutils.Scope) transactionRetrier {
+	return transactionRetrier{
+		db:               db,
+		errorTransformer: errorTransformer,
+		maxRetries:       maxTransactionRetries,
+		backoff:          transactionRetryBackoff,
+		retries: scope.MustNewCounterVec("transaction_retries",
+			"count of transactions retried after failing with a retryable error", "error_class"),
+	}
+}
@@ -0,0 +1,63 @@
+package gormimpl
+
+import (
+	"context"
+	"testing"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+func TestTransactionRetrier(t *testing.T) {
+	deadlock := &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+	t.Run("succeeds after retrying", func(t *testing.T) {
+		retrier := newTransactionRetrier(GetDbForTest(t), errors.NewPostgresErrorTransformer(mockScope.NewTestScope()),
+			mockScope.NewTestScope())
+		attempts := 0
+		err := retrier.transaction(context.Background(), func(tx *gorm.DB) error {
+			attempts++
+			if attempts == 1 {
+				return deadlock
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+	t.Run("gives up after max retries", func(t *testing.T) {
+		retrier := newTransactionRetrier(GetDbForTest(t), errors.NewPostgresErrorTransformer(mockScope.NewTestScope()),
+			mockScope.NewTestScope())
+		attempts := 0
+		err := retrier.transaction(context.Background(), func(tx *gorm.DB) error {
+			attempts++
+			return deadlock
+		})
+		assert.Equal(t, codes.Aborted, err.(flyteAdminErrors.FlyteAdminError).Code())
+		assert.Equal(t, maxTransactionRetries+1, attempts)
+	})
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		retrier := newTransactionRetrier(GetDbForTest(t), errors.NewPostgresErrorTransformer(mockScope.NewTestScope()),
+			mockScope.NewTestScope())
+		attempts := 0
+		err := retrier.transaction(context.Background(), func(tx *gorm.DB) error {
+			attempts++
+			return &pgconn.PgError{Code: "23505", Message: "duplicate key"}
+		})
+		assert.Equal(t, codes.AlreadyExists, err.(flyteAdminErrors.FlyteAdminError).Code())
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestTransactionRetrier_GetBackoff(t *testing.T) {
+	retrier := transactionRetrier{backoff: transactionRetryBackoff}
+	for retry := 0; retry < maxTransactionRetries; retry++ {
+		backoff := retrier.getBackoff(retry)
+		assert.True(t, backoff >= (transactionRetryBackoff<<retry)/2)
+		assert.True(t, backoff < (transactionRetryBackoff<<retry)*3/2)
+	}
+}
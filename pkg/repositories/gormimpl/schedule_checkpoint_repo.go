@@ -0,0 +1,100 @@
+package gormimpl
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	schedulerModels "github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var scheduleCheckpointKeyColumns = []clause.Column{{Name: "project"}, {Name: "domain"}, {Name: "name"},
+	{Name: "version"}}
+
+// Implementation of ScheduleCheckpointRepoInterface.
+type ScheduleCheckpointRepo struct {
+	db               *gorm.DB
+	errorTransformer errors.ErrorTransformer
+	metrics          gormMetrics
+}
+
+func (r *ScheduleCheckpointRepo) List(ctx context.Context, input interfaces.ListScheduleCheckpointsInput) (
+	[]models.ScheduleCheckpoint, error) {
+	var checkpoints []models.ScheduleCheckpoint
+	timer := r.metrics.ListDuration.Start()
+	// Zero valued fields of the struct aren't filtered on.
+	tx := r.db.WithContext(ctx).Where(&models.ScheduleCheckpoint{
+		ScheduleCheckpointKey: models.ScheduleCheckpointKey{
+			Project: input.Project,
+			Domain:  input.Domain,
+			Name:    input.Name,
+		},
+	}).Order("project, domain, name, version").Find(&checkpoints)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return checkpoints, nil
+}
+
+func (r *ScheduleCheckpointRepo) Update(ctx context.Context, input models.ScheduleCheckpoint) error {
+	input.LastScheduledAt = input.LastScheduledAt.UTC()
+	timer := r.metrics.UpdateDuration.Start()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("id").Clauses(clause.OnConflict{
+			Columns:   scheduleCheckpointKeyColumns,
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "last_scheduled_at", "last_execution_name"}),
+		}).Create(&input).Error; err != nil {
+			return err
+		}
+		return tx.Where(&schedulerModels.ScheduleFiring{
+			ScheduleFiringKey: schedulerModels.ScheduleFiringKey{
+				SchedulableEntityKey: schedulerModels.SchedulableEntityKey{
+					Project: input.Project,
+					Domain:  input.Domain,
+					Name:    input.Name,
+					Version: input.Version,
+				},
+			},
+		}).Where("scheduled_at > ?", input.LastScheduledAt).Delete(&schedulerModels.ScheduleFiring{}).Error
+	})
+	timer.Stop()
+	if err != nil {
+		return r.errorTransformer.ToFlyteAdminError(err)
+	}
+	return nil
+}
+
+// Moves the checkpoint of a launch plan version forwards to the scheduled time of an execution created in the
+// transaction given. Executions created for earlier scheduled times, say while catching up after a rewind, leave the
+// checkpoint where it is.
+func advanceScheduleCheckpoint(tx *gorm.DB, checkpoint models.ScheduleCheckpoint) error {
+	checkpoint.LastScheduledAt = checkpoint.LastScheduledAt.UTC()
+	result := tx.Omit("id").Clauses(clause.OnConflict{DoNothing: true}).Create(&checkpoint)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	return tx.Model(&models.ScheduleCheckpoint{}).
+		Where(&models.ScheduleCheckpoint{ScheduleCheckpointKey: checkpoint.ScheduleCheckpointKey}).
+		Where("last_scheduled_at < ?", checkpoint.LastScheduledAt).
+		Updates(map[string]interface{}{
+			"last_scheduled_at":   checkpoint.LastScheduledAt,
+			"last_execution_name": checkpoint.LastExecutionName,
+		}).Error
+}
+
+// Returns an instance of ScheduleCheckpointRepoInterface
+func NewScheduleCheckpointRepo(
+	db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.ScheduleCheckpointRepoInterface {
+	metrics := newMetrics(scope)
+	return &ScheduleCheckpointRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		metrics:          metrics,
+	}
+}
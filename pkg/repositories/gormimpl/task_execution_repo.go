@@ -23,7 +23,7 @@ type TaskExecutionRepo struct {
 
 func (r *TaskExecutionRepo) Create(ctx context.Context, input models.TaskExecution) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&input)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -34,7 +34,7 @@ func (r *TaskExecutionRepo) Create(ctx context.Context, input models.TaskExecuti
 func (r *TaskExecutionRepo) Get(ctx context.Context, input interfaces.GetTaskExecutionInput) (models.TaskExecution, error) {
 	var taskExecution models.TaskExecution
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.TaskExecution{
+	tx := r.db.WithContext(ctx).Where(&models.TaskExecution{
 		TaskExecutionKey: models.TaskExecutionKey{
 			TaskKey: models.TaskKey{
 				Project: input.TaskExecutionID.TaskId.Project,
@@ -81,7 +81,7 @@ func (r *TaskExecutionRepo) Get(ctx context.Context, input interfaces.GetTaskExe
 
 func (r *TaskExecutionRepo) Update(ctx context.Context, execution models.TaskExecution) error {
 	timer := r.metrics.UpdateDuration.Start()
-	tx := r.db.Save(&execution)
+	tx := r.db.WithContext(ctx).Save(&execution)
 	timer.Stop()
 
 	if err := tx.Error; err != nil {
@@ -96,7 +96,7 @@ func (r *TaskExecutionRepo) List(ctx context.Context, input interfaces.ListResou
 	}
 
 	var taskExecutions []models.TaskExecution
-	tx := r.db.Limit(input.Limit).Offset(input.Offset).Preload("ChildNodeExecution")
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset).Preload("ChildNodeExecution")
 
 	// And add three join conditions (joining multiple tables is fine even we only filter on a subset of table attributes).
 	// We are joining on task -> taskExec->NodeExec -> Exec.
@@ -113,7 +113,9 @@ func (r *TaskExecutionRepo) List(ctx context.Context, input interfaces.ListResou
 	}
 
 	// Apply sort ordering.
-	if input.SortParameter != nil {
+	if isKeysetPaginated(input) {
+		tx = applyKeysetPagination(tx, taskExecutionTableName, input)
+	} else if input.SortParameter != nil {
 		tx = tx.Order(input.SortParameter.GetGormOrderExpr())
 	}
 
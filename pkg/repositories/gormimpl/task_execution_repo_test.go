@@ -12,6 +12,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/stretchr/testify/assert"
 )
@@ -160,6 +161,34 @@ func TestListTaskExecutionForExecution(t *testing.T) {
 	}
 }
 
+func TestListTaskExecutions_KeysetPagination(t *testing.T) {
+	taskExecutionRepo := NewTaskExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`WHERE executions.execution_project = $1 AND (task_executions.created_at, task_executions.id) < ($2, $3) ` +
+			`ORDER BY task_executions.created_at desc,task_executions.id desc LIMIT 20`)
+
+	sortParameter, _ := common.NewSortParameter(admin.Sort{
+		Direction: admin.Sort_DESCENDING,
+		Key:       common.KeysetSortKey,
+	})
+	_, err := taskExecutionRepo.List(context.Background(), interfaces.ListResourceInput{
+		SortParameter: sortParameter,
+		KeysetCursor: &common.KeysetCursor{
+			Direction: admin.Sort_DESCENDING,
+			CreatedAt: time.Now(),
+			ID:        100,
+		},
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.Execution, "project", "project_name"),
+		},
+		Limit: 20,
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+}
+
 func TestListTaskExecutionsForTaskExecution(t *testing.T) {
 	taskExecutionRepo := NewTaskExecutionRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 
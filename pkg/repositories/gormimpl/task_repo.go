@@ -23,7 +23,7 @@ type TaskRepo struct {
 
 func (r *TaskRepo) Create(ctx context.Context, input models.Task) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&input)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -34,7 +34,7 @@ func (r *TaskRepo) Create(ctx context.Context, input models.Task) error {
 func (r *TaskRepo) Get(ctx context.Context, input interfaces.Identifier) (models.Task, error) {
 	var task models.Task
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.Task{
+	tx := r.db.WithContext(ctx).Where(&models.Task{
 		TaskKey: models.TaskKey{
 			Project: input.Project,
 			Domain:  input.Domain,
@@ -64,7 +64,7 @@ func (r *TaskRepo) List(
 		return interfaces.TaskCollectionOutput{}, err
 	}
 	var tasks []models.Task
-	tx := r.db.Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
 
 	// Apply filters
 	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
@@ -87,6 +87,24 @@ func (r *TaskRepo) List(
 	}, nil
 }
 
+func (r *TaskRepo) Count(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+	if len(input.InlineFilters) == 0 && len(input.MapFilters) == 0 {
+		return estimateCount(ctx, r.db, r.metrics, r.errorTransformer, taskTableName)
+	}
+	tx, err := applyFilters(r.db.WithContext(ctx).Model(&models.Task{}), input.InlineFilters, input.MapFilters)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	timer := r.metrics.CountDuration.Start()
+	tx = tx.Count(&count)
+	timer.Stop()
+	if tx.Error != nil {
+		return 0, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return count, nil
+}
+
 func (r *TaskRepo) ListTaskIdentifiers(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.TaskCollectionOutput, error) {
 
@@ -95,7 +113,7 @@ func (r *TaskRepo) ListTaskIdentifiers(ctx context.Context, input interfaces.Lis
 		return interfaces.TaskCollectionOutput{}, err
 	}
 
-	tx := r.db.Model(models.Task{}).Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Model(models.Task{}).Limit(input.Limit).Offset(input.Offset)
 
 	// Apply filters
 	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
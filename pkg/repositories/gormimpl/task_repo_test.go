@@ -186,6 +186,41 @@ func TestListTasks_Filters(t *testing.T) {
 	assert.Equal(t, pythonTestTaskType, collection.Tasks[0].Type)
 }
 
+func TestCountTasks(t *testing.T) {
+	taskRepo := NewTaskRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	// Tasks are counted with the same filters they are listed with.
+	mockQuery := GlobalMock.NewMock().WithQuery(
+		`SELECT count(*) FROM "tasks" WHERE project = $1 AND domain = $2 AND name = $3`).WithReply(
+		[]map[string]interface{}{{"count": 4}})
+
+	count, err := taskRepo.Count(context.Background(), interfaces.CountResourceInput{
+		InlineFilters: []common.InlineFilter{
+			getEqualityFilter(common.Task, "project", project),
+			getEqualityFilter(common.Task, "domain", domain),
+			getEqualityFilter(common.Task, "name", name),
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, mockQuery.Triggered)
+	assert.Equal(t, int64(4), count)
+}
+
+func TestCountTasks_Estimated(t *testing.T) {
+	taskRepo := NewTaskRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.NewMock().WithQuery(`SELECT reltuples::bigint FROM pg_class WHERE relname = $1`).WithArgs(
+		"tasks").WithReply([]map[string]interface{}{{"reltuples": 0}})
+	// Tables without statistics are counted.
+	GlobalMock.NewMock().WithQuery(`SELECT count(*) FROM "tasks"`).WithReply([]map[string]interface{}{{"count": 12}})
+
+	count, err := taskRepo.Count(context.Background(), interfaces.CountResourceInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), count)
+}
+
 func TestListTasks_Order(t *testing.T) {
 	taskRepo := NewTaskRepo(GetDbForTest(t), errors.NewTestErrorTransformer(), mockScope.NewTestScope())
 	tasks := make([]map[string]interface{}, 0)
@@ -33,3 +33,8 @@ func getEqualityFilter(entity common.Entity, field string, value interface{}) co
 	filter, _ := common.NewSingleValueFilter(entity, common.Equal, field, value)
 	return filter
 }
+
+func getNotEqualityFilter(entity common.Entity, field string, value interface{}) common.InlineFilter {
+	filter, _ := common.NewSingleValueFilter(entity, common.NotEqual, field, value)
+	return filter
+}
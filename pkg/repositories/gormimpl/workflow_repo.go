@@ -23,7 +23,7 @@ type WorkflowRepo struct {
 
 func (r *WorkflowRepo) Create(ctx context.Context, input models.Workflow) error {
 	timer := r.metrics.CreateDuration.Start()
-	tx := r.db.Omit("id").Create(&input)
+	tx := r.db.WithContext(ctx).Omit("id").Create(&input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -34,7 +34,7 @@ func (r *WorkflowRepo) Create(ctx context.Context, input models.Workflow) error
 func (r *WorkflowRepo) Get(ctx context.Context, input interfaces.Identifier) (models.Workflow, error) {
 	var workflow models.Workflow
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.Workflow{
+	tx := r.db.WithContext(ctx).Where(&models.Workflow{
 		WorkflowKey: models.WorkflowKey{
 			Project: input.Project,
 			Domain:  input.Domain,
@@ -64,7 +64,7 @@ func (r *WorkflowRepo) List(
 		return interfaces.WorkflowCollectionOutput{}, err
 	}
 	var workflows []models.Workflow
-	tx := r.db.Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Limit(input.Limit).Offset(input.Offset)
 
 	// Apply filters
 	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
@@ -94,7 +94,7 @@ func (r *WorkflowRepo) ListIdentifiers(ctx context.Context, input interfaces.Lis
 		return interfaces.WorkflowCollectionOutput{}, err
 	}
 
-	tx := r.db.Model(models.Workflow{}).Limit(input.Limit).Offset(input.Offset)
+	tx := r.db.WithContext(ctx).Model(models.Workflow{}).Limit(input.Limit).Offset(input.Offset)
 
 	// Apply filters
 	tx, err := applyFilters(tx, input.InlineFilters, input.MapFilters)
@@ -22,11 +22,22 @@ type ListResourceInput struct {
 	// pq driver value substitution.
 	MapFilters    []common.MapFilter
 	SortParameter common.SortParameter
+	// For lists sorted by the keyset sort key, the last row of the previous page. Takes the place of the offset.
+	KeysetCursor *common.KeysetCursor
 	// A set of the entities (besides the primary table being queries) that should be joined with when performing
 	// the list query. This enables filtering on non-primary entity attributes.
 	JoinTableEntities map[common.Entity]bool
 }
 
+// Parameters for counting the resources a list query with the same filters would return. Counts without any filters
+// are estimated.
+type CountResourceInput struct {
+	InlineFilters []common.InlineFilter
+	MapFilters    []common.MapFilter
+	// The entities besides the primary table which are filtered on, as for ListResourceInput.
+	JoinTableEntities map[common.Entity]bool
+}
+
 // Describes a set of resources for which to apply attribute updates.
 type UpdateResourceInput struct {
 	Filters    []common.InlineFilter
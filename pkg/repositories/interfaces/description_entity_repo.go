@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+type GetDescriptionEntityInput struct {
+	ResourceType core.ResourceType
+	Project      string
+	Domain       string
+	Name         string
+	Version      string
+}
+
+// Defines the interface for interacting with DescriptionEntity models.
+type DescriptionEntityRepoInterface interface {
+	// Inserts a description entity model into the database store.
+	Create(ctx context.Context, input models.DescriptionEntity) error
+	// Returns a matching description entity if it exists.
+	Get(ctx context.Context, input GetDescriptionEntityInput) (models.DescriptionEntity, error)
+	// Returns description entities matching query parameters. A limit must be provided for the results page size.
+	List(ctx context.Context, input ListResourceInput) (DescriptionEntityCollectionOutput, error)
+}
+
+// Response format for a query on description entities.
+type DescriptionEntityCollectionOutput struct {
+	Entities []models.DescriptionEntity
+}
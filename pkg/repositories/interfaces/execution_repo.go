@@ -2,13 +2,14 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 )
 
 // Defines the interface for interacting with workflow execution models.
 type ExecutionRepoInterface interface {
-	// Inserts a workflow execution model into the database store.
+	// Inserts a workflow execution model, along with its tags, into the database store.
 	Create(ctx context.Context, input models.Execution) error
 	// This updates only an existing execution model with all non-empty fields in the input.
 	Update(ctx context.Context, execution models.Execution) error
@@ -16,6 +17,87 @@ type ExecutionRepoInterface interface {
 	Get(ctx context.Context, input Identifier) (models.Execution, error)
 	// Returns executions matching query parameters. A limit must be provided for the results page size.
 	List(ctx context.Context, input ListResourceInput) (ExecutionCollectionOutput, error)
+	// Returns the number of executions matching query parameters.
+	Count(ctx context.Context, input CountResourceInput) (int64, error)
+	// Clears the active scheduled launch plan of an execution so that a new scheduled execution of its launch plan
+	// can be created.
+	ClearActiveScheduledLaunchPlan(ctx context.Context, input Identifier) error
+	// Returns the tags of an execution, sorted by tag.
+	GetTags(ctx context.Context, input Identifier) ([]models.ExecutionTag, error)
+	// Attaches the added tags to an execution and detaches the removed ones. Tags which are already attached, or which
+	// aren't when removed, are ignored.
+	UpdateTags(ctx context.Context, input Identifier, added, removed []string) error
+	// Returns the number of executions in any of the given phases, keyed by the cluster they were created in.
+	CountByCluster(ctx context.Context, phases []string) (map[string]int64, error)
+	// Hard deletes a batch of executions, along with their tags, events, node executions and task executions, within a
+	// single transaction. Only executions which have terminated are purged. Executions which a remaining execution was
+	// recovered or relaunched from, or launched by one of their nodes, are skipped until that execution is purged
+	// itself.
+	PurgeBatch(ctx context.Context, input PurgeExecutionsInput) (PurgeExecutionsOutput, error)
+	// Divides a time range into buckets of equal length and aggregates the executions created within each of them,
+	// counting them by phase and computing percentiles of their durations.
+	GetStatistics(ctx context.Context, input ExecutionStatisticsInput) (ExecutionStatisticsOutput, error)
+}
+
+// Selects the executions aggregated into statistics.
+type ExecutionStatisticsInput struct {
+	// Restricts the statistics to the executions of a project, when set.
+	Project string
+	// Restricts the statistics to the executions of these projects, all projects are aggregated when empty.
+	Projects []string
+	// Restricts the statistics to the executions of a domain, when set.
+	Domain string
+	// Executions created at or after Start and before End are aggregated.
+	Start time.Time
+	End   time.Time
+	// The length of the buckets, in whole seconds.
+	BucketSize time.Duration
+	// Only executions in these phases are aggregated.
+	Phases []string
+	// The percentiles of durations computed for each bucket, between 0 and 1.
+	Percentiles []float64
+}
+
+type ExecutionStatisticsBucket struct {
+	// The number of bucket lengths from the start of the time range to the start of the bucket.
+	Index int64
+	// The number of executions, keyed by phase.
+	PhaseCounts map[string]int64
+	// The durations at the requested percentiles, in the order they were requested in.
+	DurationPercentiles []time.Duration
+}
+
+type ExecutionStatisticsOutput struct {
+	// The buckets which have executions, ordered by index.
+	Buckets []ExecutionStatisticsBucket
+	// Whether percentiles are approximated, which they are on databases without percentile functions.
+	Approximate bool
+}
+
+// Selects the executions purged by a batch.
+type PurgeExecutionsInput struct {
+	// Restricts the batch to the executions of a project, when set.
+	Project string
+	// Restricts the batch to the executions of a domain, when set.
+	Domain string
+	// Only executions created before this time are purged.
+	CreatedBefore time.Time
+	// Only executions with a larger id are purged, so that consecutive batches can pick up where the previous one
+	// left off.
+	AfterID uint
+	// The maximum number of executions purged.
+	Limit int
+	// Only counts the rows the batch would delete.
+	DryRun bool
+}
+
+type PurgeExecutionsOutput struct {
+	// The number of executions purged, or that would be for a dry run.
+	Executions int
+	// The largest id of the executions purged.
+	LastID uint
+	// The number of rows deleted, or that would be for a dry run, keyed by table.
+	DeletedRows map[string]int64
 }
 
 // Response format for a query on workflows.
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+//go:generate mockery -name=JobLeaseRepoInterface -output=../mocks -case=underscore
+
+// Defines the interface for interacting with the leases of jobs which only one replica runs at a time.
+type JobLeaseRepoInterface interface {
+	// Acquires or renews the lease for its holder, unless another holder's lease is unexpired at the time given.
+	// Returns whether the lease is held.
+	Acquire(ctx context.Context, lease models.JobLease, now time.Time) (bool, error)
+	// Releases the lease, if it's still held by its holder, so that another holder can acquire it right away.
+	Release(ctx context.Context, lease models.JobLease) error
+}
@@ -14,9 +14,9 @@ type LaunchPlanRepoInterface interface {
 	Create(ctx context.Context, input models.LaunchPlan) error
 	// Updates an existing launch plan in the database store.
 	Update(ctx context.Context, input models.LaunchPlan) error
-	// Sets the state to active for an existing launch plan in the database store
-	// (and deactivates the formerly active version if the toDisable model exists).
-	SetActive(ctx context.Context, toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error
+	// Sets the state to active for an existing launch plan in the database store and deactivates the formerly active
+	// version, if any, in the same transaction. Returns the formerly active version.
+	SetActive(ctx context.Context, toEnable models.LaunchPlan) (*models.LaunchPlan, error)
 	// Returns a matching launch plan if it exists.
 	Get(ctx context.Context, input Identifier) (models.LaunchPlan, error)
 	// Returns launch plan revisions matching query parameters. A limit must be provided for the results page size.
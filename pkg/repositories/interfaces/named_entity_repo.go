@@ -23,6 +23,18 @@ type ListNamedEntityInput struct {
 	ResourceType core.ResourceType
 }
 
+// Parameters for searching named entities by name across projects.
+type SearchNamedEntitiesInput struct {
+	// Matched case insensitively against entity names.
+	Query string
+	// The resource types to search.
+	ResourceTypes []core.ResourceType
+	// Limits the search to these projects, all projects are searched when empty.
+	Projects []string
+	Limit    int
+	Offset   int
+}
+
 type NamedEntityCollectionOutput struct {
 	Entities []models.NamedEntity
 }
@@ -36,4 +48,7 @@ type NamedEntityRepoInterface interface {
 	Update(ctx context.Context, input models.NamedEntity) error
 	// Gets metadata (if available) associated with a NamedEntity
 	Get(ctx context.Context, input GetNamedEntityInput) (models.NamedEntity, error)
+	// Returns NamedEntity objects whose name contains the query, those whose name starts with it first. A limit is
+	// required
+	Search(ctx context.Context, input SearchNamedEntitiesInput) (NamedEntityCollectionOutput, error)
 }
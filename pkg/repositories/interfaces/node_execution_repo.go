@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
@@ -15,6 +16,16 @@ type NodeExecutionRepoInterface interface {
 	Update(ctx context.Context, execution *models.NodeExecution) error
 	// Returns a matching execution if it exists.
 	Get(ctx context.Context, input NodeExecutionResource) (models.NodeExecution, error)
+	// Returns the node executions of the execution with the given node ids, in no particular order. Node ids without a
+	// node execution are left out.
+	GetBatch(ctx context.Context, executionID core.WorkflowExecutionIdentifier, nodeIDs []string) (
+		[]models.NodeExecution, error)
+	// Returns the node executions of the execution which the node executions with the given ids are the parents of,
+	// ordered by id. Each comes with its own children.
+	ListChildren(ctx context.Context, executionID core.WorkflowExecutionIdentifier, parentIDs []uint) (
+		[]models.NodeExecution, error)
+	// Returns the timestamps of the top level node executions of the execution, ordered by creation.
+	ListTimeline(ctx context.Context, executionID core.WorkflowExecutionIdentifier) ([]NodeExecutionTimelineEntry, error)
 	// Returns node executions matching query parameters. A limit must be provided for the results page size.
 	List(ctx context.Context, input ListResourceInput) (NodeExecutionCollectionOutput, error)
 	// Return node execution events matching query parameters. A limit must be provided for the results page size.
@@ -27,6 +38,18 @@ type NodeExecutionResource struct {
 	NodeExecutionIdentifier core.NodeExecutionIdentifier
 }
 
+// The timestamps of a node execution, along with the number of times its task was retried.
+type NodeExecutionTimelineEntry struct {
+	NodeID    string
+	Phase     string
+	CreatedAt *time.Time
+	StartedAt *time.Time
+	UpdatedAt *time.Time
+	Duration  time.Duration
+	// The latest retry attempt of the task of the node, which is 0 for nodes which aren't tasks.
+	Retries uint32
+}
+
 // Response format for a query on node executions.
 type NodeExecutionCollectionOutput struct {
 	NodeExecutions []models.NodeExecution
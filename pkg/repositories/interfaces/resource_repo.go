@@ -9,20 +9,27 @@ import (
 type ResourceRepoInterface interface {
 	// Inserts or updates an existing Type model into the database store.
 	CreateOrUpdate(ctx context.Context, input models.Resource) error
+	// Inserts a Type model, or when one exists replaces it with the model merge returns for it.
+	CreateOrMerge(ctx context.Context, input models.Resource, merge MergeResourceFunc) error
 	// Returns a matching Type model based on hierarchical resolution.
 	Get(ctx context.Context, ID ResourceID) (models.Resource, error)
 	// Returns a matching Type model.
 	GetRaw(ctx context.Context, ID ResourceID) (models.Resource, error)
-	// Lists all resources
+	// Lists all resources other than those of orgs
 	ListAll(ctx context.Context, resourceType string) ([]models.Resource, error)
 	// Deletes a matching Type model when it exists.
 	Delete(ctx context.Context, ID ResourceID) error
 }
 
+// Returns the model replacing an existing one.
+type MergeResourceFunc func(existing models.Resource) (models.Resource, error)
+
 type ResourceID struct {
 	Project      string
 	Domain       string
 	Workflow     string
 	LaunchPlan   string
 	ResourceType string
+	// Set to include the attributes of the org in hierarchical resolution, or to identify them on their own.
+	Org string
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+//go:generate mockery -name=ScheduleCheckpointRepoInterface -output=../mocks -case=underscore
+
+// Defines the interface for interacting with the checkpoints of scheduled launch plans. Checkpoints are advanced as
+// scheduled executions are created, see ExecutionRepoInterface.Create.
+type ScheduleCheckpointRepoInterface interface {
+	// Returns the checkpoints matching the input, sorted by launch plan version.
+	List(ctx context.Context, input ListScheduleCheckpointsInput) ([]models.ScheduleCheckpoint, error)
+	// Sets the checkpoint of a launch plan version, moving it backwards or forwards. The invocations the scheduler
+	// claimed after the new checkpoint are released in the same transaction, so that they fire again once the scheduler
+	// restarts from the checkpoint.
+	Update(ctx context.Context, input models.ScheduleCheckpoint) error
+}
+
+// Filters the checkpoints listed. Empty fields match every checkpoint.
+type ListScheduleCheckpointsInput struct {
+	Project string
+	Domain  string
+	Name    string
+}
@@ -14,6 +14,8 @@ type TaskRepoInterface interface {
 	Get(ctx context.Context, input Identifier) (models.Task, error)
 	// Returns task revisions matching query parameters. A limit must be provided for the results page size.
 	List(ctx context.Context, input ListResourceInput) (TaskCollectionOutput, error)
+	// Returns the number of task revisions matching query parameters.
+	Count(ctx context.Context, input CountResourceInput) (int64, error)
 	// Returns tasks with only the project, name, and domain filled in.
 	// A limit must be provided.
 	ListTaskIdentifiers(ctx context.Context, input ListResourceInput) (TaskCollectionOutput, error)
@@ -12,12 +12,28 @@ type UpdateExecutionFunc func(ctx context.Context, execution models.Execution) e
 type GetExecutionFunc func(ctx context.Context, input interfaces.Identifier) (models.Execution, error)
 type ListExecutionFunc func(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.ExecutionCollectionOutput, error)
+type CountExecutionFunc func(ctx context.Context, input interfaces.CountResourceInput) (int64, error)
+type ClearActiveScheduledLaunchPlanFunc func(ctx context.Context, input interfaces.Identifier) error
+type GetExecutionTagsFunc func(ctx context.Context, input interfaces.Identifier) ([]models.ExecutionTag, error)
+type CountExecutionsByClusterFunc func(ctx context.Context, phases []string) (map[string]int64, error)
+type UpdateExecutionTagsFunc func(ctx context.Context, input interfaces.Identifier, added, removed []string) error
+type GetExecutionStatisticsFunc func(ctx context.Context, input interfaces.ExecutionStatisticsInput) (
+	interfaces.ExecutionStatisticsOutput, error)
+type PurgeExecutionsBatchFunc func(ctx context.Context, input interfaces.PurgeExecutionsInput) (
+	interfaces.PurgeExecutionsOutput, error)
 
 type MockExecutionRepo struct {
-	createFunction CreateExecutionFunc
-	updateFunction UpdateExecutionFunc
-	getFunction    GetExecutionFunc
-	listFunction   ListExecutionFunc
+	createFunction                         CreateExecutionFunc
+	updateFunction                         UpdateExecutionFunc
+	getFunction                            GetExecutionFunc
+	listFunction                           ListExecutionFunc
+	countFunction                          CountExecutionFunc
+	clearActiveScheduledLaunchPlanFunction ClearActiveScheduledLaunchPlanFunc
+	getTagsFunction                        GetExecutionTagsFunc
+	updateTagsFunction                     UpdateExecutionTagsFunc
+	countByClusterFunction                 CountExecutionsByClusterFunc
+	purgeBatchFunction                     PurgeExecutionsBatchFunc
+	getStatisticsFunction                  GetExecutionStatisticsFunc
 }
 
 func (r *MockExecutionRepo) Create(ctx context.Context, input models.Execution) error {
@@ -69,6 +85,87 @@ func (r *MockExecutionRepo) SetListCallback(listFunction ListExecutionFunc) {
 	r.listFunction = listFunction
 }
 
+func (r *MockExecutionRepo) ClearActiveScheduledLaunchPlan(ctx context.Context, input interfaces.Identifier) error {
+	if r.clearActiveScheduledLaunchPlanFunction != nil {
+		return r.clearActiveScheduledLaunchPlanFunction(ctx, input)
+	}
+	return nil
+}
+
+func (r *MockExecutionRepo) SetClearActiveScheduledLaunchPlanCallback(
+	clearActiveScheduledLaunchPlanFunction ClearActiveScheduledLaunchPlanFunc) {
+	r.clearActiveScheduledLaunchPlanFunction = clearActiveScheduledLaunchPlanFunction
+}
+
+func (r *MockExecutionRepo) GetTags(ctx context.Context, input interfaces.Identifier) ([]models.ExecutionTag, error) {
+	if r.getTagsFunction != nil {
+		return r.getTagsFunction(ctx, input)
+	}
+	return nil, nil
+}
+
+func (r *MockExecutionRepo) SetGetTagsCallback(getTagsFunction GetExecutionTagsFunc) {
+	r.getTagsFunction = getTagsFunction
+}
+
+func (r *MockExecutionRepo) UpdateTags(
+	ctx context.Context, input interfaces.Identifier, added, removed []string) error {
+	if r.updateTagsFunction != nil {
+		return r.updateTagsFunction(ctx, input, added, removed)
+	}
+	return nil
+}
+
+func (r *MockExecutionRepo) SetUpdateTagsCallback(updateTagsFunction UpdateExecutionTagsFunc) {
+	r.updateTagsFunction = updateTagsFunction
+}
+
+func (r *MockExecutionRepo) Count(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+	if r.countFunction != nil {
+		return r.countFunction(ctx, input)
+	}
+	return 0, nil
+}
+
+func (r *MockExecutionRepo) SetCountCallback(countFunction CountExecutionFunc) {
+	r.countFunction = countFunction
+}
+
+func (r *MockExecutionRepo) CountByCluster(ctx context.Context, phases []string) (map[string]int64, error) {
+	if r.countByClusterFunction != nil {
+		return r.countByClusterFunction(ctx, phases)
+	}
+	return map[string]int64{}, nil
+}
+
+func (r *MockExecutionRepo) SetCountByClusterCallback(countByClusterFunction CountExecutionsByClusterFunc) {
+	r.countByClusterFunction = countByClusterFunction
+}
+
+func (r *MockExecutionRepo) PurgeBatch(ctx context.Context, input interfaces.PurgeExecutionsInput) (
+	interfaces.PurgeExecutionsOutput, error) {
+	if r.purgeBatchFunction != nil {
+		return r.purgeBatchFunction(ctx, input)
+	}
+	return interfaces.PurgeExecutionsOutput{}, nil
+}
+
+func (r *MockExecutionRepo) SetPurgeBatchCallback(purgeBatchFunction PurgeExecutionsBatchFunc) {
+	r.purgeBatchFunction = purgeBatchFunction
+}
+
+func (r *MockExecutionRepo) GetStatistics(ctx context.Context, input interfaces.ExecutionStatisticsInput) (
+	interfaces.ExecutionStatisticsOutput, error) {
+	if r.getStatisticsFunction != nil {
+		return r.getStatisticsFunction(ctx, input)
+	}
+	return interfaces.ExecutionStatisticsOutput{}, nil
+}
+
+func (r *MockExecutionRepo) SetGetStatisticsCallback(getStatisticsFunction GetExecutionStatisticsFunc) {
+	r.getStatisticsFunction = getStatisticsFunction
+}
+
 func NewMockExecutionRepo() interfaces.ExecutionRepoInterface {
 	return &MockExecutionRepo{}
 }
@@ -10,7 +10,7 @@ import (
 
 type CreateLaunchPlanFunc func(input models.LaunchPlan) error
 type UpdateLaunchPlanFunc func(input models.LaunchPlan) error
-type SetActiveLaunchPlanFunc func(toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error
+type SetActiveLaunchPlanFunc func(toEnable models.LaunchPlan) (*models.LaunchPlan, error)
 type GetLaunchPlanFunc func(input interfaces.Identifier) (models.LaunchPlan, error)
 type ListLaunchPlanFunc func(input interfaces.ListResourceInput) (interfaces.LaunchPlanCollectionOutput, error)
 type ListLaunchPlanIdentifiersFunc func(input interfaces.ListResourceInput) (
@@ -48,11 +48,11 @@ func (r *MockLaunchPlanRepo) SetUpdateCallback(updateFunction UpdateLaunchPlanFu
 }
 
 func (r *MockLaunchPlanRepo) SetActive(
-	ctx context.Context, toEnable models.LaunchPlan, toDisable *models.LaunchPlan) error {
+	ctx context.Context, toEnable models.LaunchPlan) (*models.LaunchPlan, error) {
 	if r.setActiveFunction != nil {
-		return r.setActiveFunction(toEnable, toDisable)
+		return r.setActiveFunction(toEnable)
 	}
-	return nil
+	return nil, nil
 }
 
 func (r *MockLaunchPlanRepo) SetSetActiveCallback(setActiveFunction SetActiveLaunchPlanFunc) {
@@ -11,11 +11,14 @@ import (
 type GetNamedEntityFunc func(input interfaces.GetNamedEntityInput) (models.NamedEntity, error)
 type ListNamedEntityFunc func(input interfaces.ListNamedEntityInput) (interfaces.NamedEntityCollectionOutput, error)
 type UpdateNamedEntityFunc func(input models.NamedEntity) error
+type SearchNamedEntitiesFunc func(input interfaces.SearchNamedEntitiesInput) (
+	interfaces.NamedEntityCollectionOutput, error)
 
 type MockNamedEntityRepo struct {
 	getFunction    GetNamedEntityFunc
 	listFunction   ListNamedEntityFunc
 	updateFunction UpdateNamedEntityFunc
+	searchFunction SearchNamedEntitiesFunc
 }
 
 func (r *MockNamedEntityRepo) Update(ctx context.Context, NamedEntity models.NamedEntity) error {
@@ -50,6 +53,18 @@ func (r *MockNamedEntityRepo) List(ctx context.Context, input interfaces.ListNam
 	return interfaces.NamedEntityCollectionOutput{}, nil
 }
 
+func (r *MockNamedEntityRepo) Search(ctx context.Context, input interfaces.SearchNamedEntitiesInput) (
+	interfaces.NamedEntityCollectionOutput, error) {
+	if r.searchFunction != nil {
+		return r.searchFunction(input)
+	}
+	return interfaces.NamedEntityCollectionOutput{}, nil
+}
+
+func (r *MockNamedEntityRepo) SetSearchCallback(searchFunction SearchNamedEntitiesFunc) {
+	r.searchFunction = searchFunction
+}
+
 func (r *MockNamedEntityRepo) SetGetCallback(getFunction GetNamedEntityFunc) {
 	r.getFunction = getFunction
 }
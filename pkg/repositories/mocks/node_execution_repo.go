@@ -5,23 +5,33 @@ import (
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 )
 
 type CreateNodeExecutionFunc func(ctx context.Context, input *models.NodeExecution) error
 type UpdateNodeExecutionFunc func(ctx context.Context, nodeExecution *models.NodeExecution) error
 type GetNodeExecutionFunc func(ctx context.Context, input interfaces.NodeExecutionResource) (models.NodeExecution, error)
+type GetNodeExecutionBatchFunc func(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	nodeIDs []string) ([]models.NodeExecution, error)
+type ListNodeExecutionChildrenFunc func(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	parentIDs []uint) ([]models.NodeExecution, error)
+type ListNodeExecutionTimelineFunc func(ctx context.Context, executionID core.WorkflowExecutionIdentifier) (
+	[]interfaces.NodeExecutionTimelineEntry, error)
 type ListNodeExecutionFunc func(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.NodeExecutionCollectionOutput, error)
 type ListNodeExecutionEventFunc func(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.NodeExecutionEventCollectionOutput, error)
 
 type MockNodeExecutionRepo struct {
-	createFunction    CreateNodeExecutionFunc
-	updateFunction    UpdateNodeExecutionFunc
-	getFunction       GetNodeExecutionFunc
-	listFunction      ListNodeExecutionFunc
-	listEventFunction ListNodeExecutionEventFunc
-	ExistsFunction    func(ctx context.Context, input interfaces.NodeExecutionResource) (bool, error)
+	createFunction       CreateNodeExecutionFunc
+	updateFunction       UpdateNodeExecutionFunc
+	getFunction          GetNodeExecutionFunc
+	getBatchFunction     GetNodeExecutionBatchFunc
+	listChildrenFunction ListNodeExecutionChildrenFunc
+	listTimelineFunction ListNodeExecutionTimelineFunc
+	listFunction         ListNodeExecutionFunc
+	listEventFunction    ListNodeExecutionEventFunc
+	ExistsFunction       func(ctx context.Context, input interfaces.NodeExecutionResource) (bool, error)
 }
 
 func (r *MockNodeExecutionRepo) Create(ctx context.Context, input *models.NodeExecution) error {
@@ -57,6 +67,42 @@ func (r *MockNodeExecutionRepo) SetGetCallback(getFunction GetNodeExecutionFunc)
 	r.getFunction = getFunction
 }
 
+func (r *MockNodeExecutionRepo) GetBatch(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	nodeIDs []string) ([]models.NodeExecution, error) {
+	if r.getBatchFunction != nil {
+		return r.getBatchFunction(ctx, executionID, nodeIDs)
+	}
+	return nil, nil
+}
+
+func (r *MockNodeExecutionRepo) SetGetBatchCallback(getBatchFunction GetNodeExecutionBatchFunc) {
+	r.getBatchFunction = getBatchFunction
+}
+
+func (r *MockNodeExecutionRepo) ListChildren(ctx context.Context, executionID core.WorkflowExecutionIdentifier,
+	parentIDs []uint) ([]models.NodeExecution, error) {
+	if r.listChildrenFunction != nil {
+		return r.listChildrenFunction(ctx, executionID, parentIDs)
+	}
+	return nil, nil
+}
+
+func (r *MockNodeExecutionRepo) SetListChildrenCallback(listChildrenFunction ListNodeExecutionChildrenFunc) {
+	r.listChildrenFunction = listChildrenFunction
+}
+
+func (r *MockNodeExecutionRepo) ListTimeline(ctx context.Context, executionID core.WorkflowExecutionIdentifier) (
+	[]interfaces.NodeExecutionTimelineEntry, error) {
+	if r.listTimelineFunction != nil {
+		return r.listTimelineFunction(ctx, executionID)
+	}
+	return nil, nil
+}
+
+func (r *MockNodeExecutionRepo) SetListTimelineCallback(listTimelineFunction ListNodeExecutionTimelineFunc) {
+	r.listTimelineFunction = listTimelineFunction
+}
+
 func (r *MockNodeExecutionRepo) List(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.NodeExecutionCollectionOutput, error) {
 	if r.listFunction != nil {
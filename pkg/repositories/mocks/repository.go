@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	sIface "github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
@@ -19,8 +21,12 @@ type MockRepository struct {
 	resourceRepo                  interfaces.ResourceRepoInterface
 	taskExecutionRepo             interfaces.TaskExecutionRepoInterface
 	namedEntityRepo               interfaces.NamedEntityRepoInterface
+	descriptionEntityRepo         interfaces.DescriptionEntityRepoInterface
+	scheduleCheckpointRepo        interfaces.ScheduleCheckpointRepoInterface
+	jobLeaseRepo                  interfaces.JobLeaseRepoInterface
 	schedulableEntityRepo         sIface.SchedulableEntityRepoInterface
 	schedulableEntitySnapshotRepo sIface.ScheduleEntitiesSnapShotRepoInterface
+	scheduleFiringRepo            sIface.ScheduleFiringRepoInterface
 }
 
 func (r *MockRepository) SchedulableEntityRepo() sIface.SchedulableEntityRepoInterface {
@@ -31,6 +37,14 @@ func (r *MockRepository) ScheduleEntitiesSnapshotRepo() sIface.ScheduleEntitiesS
 	return r.schedulableEntitySnapshotRepo
 }
 
+func (r *MockRepository) ScheduleFiringRepo() sIface.ScheduleFiringRepoInterface {
+	return r.scheduleFiringRepo
+}
+
+func (r *MockRepository) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
 func (r *MockRepository) TaskRepo() interfaces.TaskRepoInterface {
 	return r.taskRepo
 }
@@ -75,6 +89,18 @@ func (r *MockRepository) NamedEntityRepo() interfaces.NamedEntityRepoInterface {
 	return r.namedEntityRepo
 }
 
+func (r *MockRepository) DescriptionEntityRepo() interfaces.DescriptionEntityRepoInterface {
+	return r.descriptionEntityRepo
+}
+
+func (r *MockRepository) ScheduleCheckpointRepo() interfaces.ScheduleCheckpointRepoInterface {
+	return r.scheduleCheckpointRepo
+}
+
+func (r *MockRepository) JobLeaseRepo() interfaces.JobLeaseRepoInterface {
+	return r.jobLeaseRepo
+}
+
 func NewMockRepository() repositories.RepositoryInterface {
 	return &MockRepository{
 		taskRepo:                      NewMockTaskRepo(),
@@ -86,9 +112,13 @@ func NewMockRepository() repositories.RepositoryInterface {
 		resourceRepo:                  NewMockResourceRepo(),
 		taskExecutionRepo:             NewMockTaskExecutionRepo(),
 		namedEntityRepo:               NewMockNamedEntityRepo(),
+		descriptionEntityRepo:         NewMockDescriptionEntityRepo(),
+		scheduleCheckpointRepo:        &ScheduleCheckpointRepoInterface{},
+		jobLeaseRepo:                  &JobLeaseRepoInterface{},
 		ExecutionEventRepoIface:       &ExecutionEventRepoInterface{},
 		NodeExecutionEventRepoIface:   &NodeExecutionEventRepoInterface{},
 		schedulableEntityRepo:         &sMocks.SchedulableEntityRepoInterface{},
 		schedulableEntitySnapshotRepo: &sMocks.ScheduleEntitiesSnapShotRepoInterface{},
+		scheduleFiringRepo:            &sMocks.ScheduleFiringRepoInterface{},
 	}
 }
@@ -3,11 +3,15 @@ package mocks
 import (
 	"context"
 
+	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"google.golang.org/grpc/codes"
 )
 
 type CreateOrUpdateResourceFunction func(ctx context.Context, input models.Resource) error
+type CreateOrMergeResourceFunction func(
+	ctx context.Context, input models.Resource, merge interfaces.MergeResourceFunc) error
 type GetResourceFunction func(ctx context.Context, ID interfaces.ResourceID) (
 	models.Resource, error)
 type ListAllResourcesFunction func(ctx context.Context, resourceType string) ([]models.Resource, error)
@@ -15,6 +19,7 @@ type DeleteResourceFunction func(ctx context.Context, ID interfaces.ResourceID)
 
 type MockResourceRepo struct {
 	CreateOrUpdateFunction CreateOrUpdateResourceFunction
+	CreateOrMergeFunction  CreateOrMergeResourceFunction
 	GetFunction            GetResourceFunction
 	DeleteFunction         DeleteResourceFunction
 	ListAllFunction        ListAllResourcesFunction
@@ -27,6 +32,33 @@ func (r *MockResourceRepo) CreateOrUpdate(ctx context.Context, input models.Reso
 	return nil
 }
 
+// Without a CreateOrMergeFunction, the input is merged into the resource GetFunction returns, unless it returns a not
+// found error, and the result is saved with CreateOrUpdate.
+func (r *MockResourceRepo) CreateOrMerge(
+	ctx context.Context, input models.Resource, merge interfaces.MergeResourceFunc) error {
+	if r.CreateOrMergeFunction != nil {
+		return r.CreateOrMergeFunction(ctx, input, merge)
+	}
+	existing, err := r.GetRaw(ctx, interfaces.ResourceID{
+		Project:      input.Project,
+		Domain:       input.Domain,
+		Workflow:     input.Workflow,
+		LaunchPlan:   input.LaunchPlan,
+		ResourceType: input.ResourceType,
+	})
+	if err != nil {
+		if adminErr, ok := err.(errors.FlyteAdminError); !ok || adminErr.Code() != codes.NotFound {
+			return err
+		}
+		return r.CreateOrUpdate(ctx, input)
+	}
+	merged, err := merge(existing)
+	if err != nil {
+		return err
+	}
+	return r.CreateOrUpdate(ctx, merged)
+}
+
 func (r *MockResourceRepo) Get(ctx context.Context, ID interfaces.ResourceID) (
 	models.Resource, error) {
 	if r.GetFunction != nil {
@@ -11,12 +11,14 @@ import (
 type CreateTaskFunc func(input models.Task) error
 type GetTaskFunc func(input interfaces.Identifier) (models.Task, error)
 type ListTaskFunc func(input interfaces.ListResourceInput) (interfaces.TaskCollectionOutput, error)
+type CountTaskFunc func(input interfaces.CountResourceInput) (int64, error)
 type ListTaskIdentifiersFunc func(input interfaces.ListResourceInput) (interfaces.TaskCollectionOutput, error)
 
 type MockTaskRepo struct {
 	createFunction            CreateTaskFunc
 	getFunction               GetTaskFunc
 	listFunction              ListTaskFunc
+	countFunction             CountTaskFunc
 	listUniqueTaskIdsFunction ListTaskIdentifiersFunc
 }
 
@@ -61,6 +63,17 @@ func (r *MockTaskRepo) SetListCallback(listFunction ListTaskFunc) {
 	r.listFunction = listFunction
 }
 
+func (r *MockTaskRepo) Count(ctx context.Context, input interfaces.CountResourceInput) (int64, error) {
+	if r.countFunction != nil {
+		return r.countFunction(input)
+	}
+	return 0, nil
+}
+
+func (r *MockTaskRepo) SetCountCallback(countFunction CountTaskFunc) {
+	r.countFunction = countFunction
+}
+
 func (r *MockTaskRepo) ListTaskIdentifiers(ctx context.Context, input interfaces.ListResourceInput) (
 	interfaces.TaskCollectionOutput, error) {
 
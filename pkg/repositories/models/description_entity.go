@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// DescriptionEntity primary key
+type DescriptionEntityKey struct {
+	ResourceType core.ResourceType `gorm:"primary_key;index:description_entity_project_domain_name_version_idx"`
+	Project      string            `gorm:"primary_key;index:description_entity_project_domain_name_version_idx" valid:"length(0|255)"`
+	Domain       string            `gorm:"primary_key;index:description_entity_project_domain_name_version_idx" valid:"length(0|255)"`
+	Name         string            `gorm:"primary_key;index:description_entity_project_domain_name_version_idx" valid:"length(0|255)"`
+	Version      string            `gorm:"primary_key;index:description_entity_project_domain_name_version_idx" valid:"length(0|255)"`
+}
+
+// Database model to encapsulate the long form documentation of a workflow, task or launch plan version.
+type DescriptionEntity struct {
+	BaseModel
+	DescriptionEntityKey
+	// Long form description of the entity, for instance a markdown README.
+	LongDescription []byte
+	// Format of the long description, e.g. text/markdown.
+	ContentType string `valid:"length(0|255)"`
+	// Link to the source code the entity was registered from.
+	SourceCodeLink string `valid:"length(0|2048)"`
+}
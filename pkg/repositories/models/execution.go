@@ -45,6 +45,19 @@ type Execution struct {
 	ParentNodeExecutionID uint
 	// Cluster where execution was triggered
 	Cluster string `valid:"length(0|255)"`
+	// Namespace the workflow of the execution was created in. Empty for executions which predate it being recorded.
+	Namespace string `valid:"length(0|255)"`
+	// Set for executions queued to be launched in the background once admin starts to create their workflow, which
+	// may exist from then on. Queued executions without it were never launched, whatever their cluster.
+	LaunchAttempted bool
+	// The dynamic execution queue the tasks of the execution were assigned to, empty when no queue matched. The
+	// execution closure has no field for it, so it's reported by an annotation on the spec, and kept here so that
+	// executions can be filtered on it.
+	Queue string `valid:"length(0|255)"`
+	// The max parallelism the execution was launched with, resolved from the request, its launch plan, matchable
+	// attributes and the application config. 0 means unlimited, nil that the execution predates it being recorded. The
+	// stored spec only holds the requested value, so that relaunches resolve it anew. nullable
+	MaxParallelism *int32
 	// Offloaded location of inputs LiteralMap. These are the inputs evaluated and contain applied defaults.
 	InputsURI storage.DataReference
 	// User specified inputs. This map might be incomplete and not include defaults applied
@@ -53,7 +66,21 @@ type Execution struct {
 	ErrorKind *string `gorm:"index"`
 	// Execution Error Code nullable
 	ErrorCode *string `valid:"length(0|255)"`
+	// The message of the execution error, truncated to fit, so that executions can be filtered on it. It isn't
+	// indexed, as it's only matched on case insensitive substrings, which a B-tree index can't serve. nullable
+	ErrorMessage *string `valid:"length(0|255)"`
 	// The user responsible for launching this execution.
 	// This is also stored in the spec but promoted as a column for filtering.
 	User string `gorm:"index" valid:"length(0|255)"`
+	// Set for scheduled executions whose launch plan doesn't allow overlapping scheduled executions, until they
+	// terminate. The unique index guarantees at most one such execution per launch plan version.
+	ActiveScheduledLaunchPlanID *uint `gorm:"uniqueIndex"`
+	// Tags are stored in their own table. They're written along with a newly created execution but aren't loaded when
+	// reading executions back.
+	Tags []ExecutionTag `gorm:"-"`
+	// Set for scheduled executions, whose launch plan's checkpoint is advanced along with creating the execution.
+	ScheduleCheckpoint *ScheduleCheckpoint `gorm:"-"`
+	// Set for scheduled executions replacing the active scheduled execution of their launch plan version, which hands
+	// the launch plan over to the new execution as it's created.
+	ReplacedExecution *ExecutionKey `gorm:"-"`
 }
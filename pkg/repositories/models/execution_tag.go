@@ -0,0 +1,9 @@
+package models
+
+// Database model to encapsulate a tag attached to a (workflow) execution. Tags group executions across launch plans,
+// for instance every execution of a backfill.
+type ExecutionTag struct {
+	BaseModel
+	ExecutionKey
+	Tag string `gorm:"primary_key;index" valid:"length(0|255)"`
+}
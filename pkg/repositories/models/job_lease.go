@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Database model to record which replica holds the lease on a job that only one replica runs at a time, and until
+// when. The holder renews the lease each time it runs the job, and other replicas take it over once it expires.
+type JobLease struct {
+	BaseModel
+	Name      string `gorm:"primary_key" valid:"length(0|255)"`
+	Holder    string `valid:"length(0|255)"`
+	ExpiresAt time.Time
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
 // Launch plan primary key
 type LaunchPlanKey struct {
 	Project string `gorm:"primary_key;index:lp_project_domain_name_idx,lp_project_domain_idx" valid:"length(0|255)"`
@@ -31,4 +37,23 @@ type LaunchPlan struct {
 	// Hash of the launch plan
 	Digest       []byte
 	ScheduleType LaunchPlanScheduleType
+	// Determines whether scheduled executions of this launch plan version may overlap.
+	ScheduleOverlapPolicy string
+	// The last time this launch plan version was set to active.
+	ActivatedAt *time.Time `gorm:"index"`
+}
+
+// GetLaunchPlanScheduleType returns the type of schedule, if any, the launch plan spec runs on.
+func GetLaunchPlanScheduleType(spec *admin.LaunchPlanSpec) LaunchPlanScheduleType {
+	schedule := spec.GetEntityMetadata().GetSchedule()
+	if schedule == nil {
+		return LaunchPlanScheduleTypeNONE
+	}
+	if schedule.GetCronExpression() != "" || schedule.GetCronSchedule().GetSchedule() != "" {
+		return LaunchPlanScheduleTypeCRON
+	}
+	if schedule.GetRate() != nil {
+		return LaunchPlanScheduleTypeRATE
+	}
+	return LaunchPlanScheduleTypeNONE
 }
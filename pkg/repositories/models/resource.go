@@ -4,26 +4,35 @@ import "time"
 
 type ResourcePriority int32
 
+// Attributes of a higher priority take precedence over those of a lower one which match as well.
 const (
 	ResourcePriorityDomainLevel        ResourcePriority = 1
+	ResourcePriorityOrgLevel           ResourcePriority = 3
+	ResourcePriorityProjectLevel       ResourcePriority = 5
 	ResourcePriorityProjectDomainLevel ResourcePriority = 10
 	ResourcePriorityWorkflowLevel      ResourcePriority = 100
 	ResourcePriorityLaunchPlanLevel    ResourcePriority = 1000
 )
 
+// The resource type active execution quotas are stored as, which isn't one of admin.MatchableResource.
+const ActiveExecutionQuotaResourceType = "ACTIVE_EXECUTION_QUOTA"
+
 // Represents Flyte resources repository.
-// In this model, the combination of (Project, Domain, Workflow, LaunchPlan, ResourceType) is unique
+// In this model, the combination of (Project, Domain, Workflow, LaunchPlan, ResourceType, Org) is unique
 type Resource struct {
 	ID           int64 `gorm:"AUTO_INCREMENT;column:id;primary_key"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    *time.Time `sql:"index"`
-	Project      string     `gorm:"unique_index:resource_idx" valid:"length(0|255)"`
-	Domain       string     `gorm:"unique_index:resource_idx" valid:"length(0|255)"`
-	Workflow     string     `gorm:"unique_index:resource_idx" valid:"length(0|255)"`
-	LaunchPlan   string     `gorm:"unique_index:resource_idx" valid:"length(0|255)"`
-	ResourceType string     `gorm:"unique_index:resource_idx" valid:"length(0|255)"`
-	Priority     ResourcePriority
-	// Serialized flyteidl.admin.MatchingAttributes.
+	Project      string     `gorm:"uniqueIndex:resource_idx" valid:"length(0|255)"`
+	Domain       string     `gorm:"uniqueIndex:resource_idx" valid:"length(0|255)"`
+	Workflow     string     `gorm:"uniqueIndex:resource_idx" valid:"length(0|255)"`
+	LaunchPlan   string     `gorm:"uniqueIndex:resource_idx" valid:"length(0|255)"`
+	ResourceType string     `gorm:"uniqueIndex:resource_idx" valid:"length(0|255)"`
+	// The org of the projects the attributes apply to, in which case the columns above other than the resource type
+	// are empty. Empty for attributes of any other level.
+	Org      string `gorm:"uniqueIndex:resource_idx;size:255;not null;default:''" valid:"length(0|255)"`
+	Priority ResourcePriority
+	// Serialized flyteidl.admin.MatchingAttributes, or google.protobuf.Int32Value for active execution quotas.
 	Attributes []byte
 }
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Database model to encapsulate the checkpoint of a scheduled launch plan version: the latest scheduled time an
+// execution was created for. Checkpoints only move forward as executions are created, but can be set by an admin to
+// rewind or advance the schedule.
+type ScheduleCheckpoint struct {
+	BaseModel
+	ScheduleCheckpointKey
+	LastScheduledAt time.Time
+	// The name of the execution created for the last scheduled time. Empty once the checkpoint is set by an admin.
+	LastExecutionName string `valid:"length(0|255)"`
+}
+
+// Schedule checkpoint primary key, the scheduled launch plan version
+type ScheduleCheckpointKey struct {
+	Project string `gorm:"primary_key" valid:"length(0|255)"`
+	Domain  string `gorm:"primary_key" valid:"length(0|255)"`
+	Name    string `gorm:"primary_key" valid:"length(0|255)"`
+	Version string `gorm:"primary_key" valid:"length(0|255)"`
+}
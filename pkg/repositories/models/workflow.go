@@ -16,4 +16,7 @@ type Workflow struct {
 	RemoteClosureIdentifier string `gorm:"not null" valid:"length(0|255)"`
 	// Hash of the compiled workflow closure
 	Digest []byte
+	// Hash of the workflow spec as registered, which identical registrations are recognized by without compiling them.
+	// Empty for workflows registered before it was recorded.
+	SpecDigest []byte
 }
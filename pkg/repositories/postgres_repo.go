@@ -1,16 +1,42 @@
 package repositories
 
 import (
+	"context"
+	"time"
+
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/gormimpl"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	schedulerGormImpl "github.com/flyteorg/flyteadmin/scheduler/repositories/gormimpl"
 	schedulerInterfaces "github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
 	"gorm.io/gorm"
 )
 
+// Health of the connections to one of the databases, the primary or the read replica.
+type poolHealthMetrics struct {
+	Healthy             prometheus.Gauge
+	HealthCheckFailures prometheus.Counter
+	HealthCheckDuration promutils.StopWatch
+}
+
+func newPoolHealthMetrics(scope promutils.Scope) poolHealthMetrics {
+	return poolHealthMetrics{
+		Healthy: scope.MustNewGauge("healthy",
+			"1 if the database passed its last health check, 0 otherwise"),
+		HealthCheckFailures: scope.MustNewCounter("health_check_failures",
+			"count of database health checks which failed"),
+		HealthCheckDuration: scope.MustNewStopWatch("health_check_duration",
+			"time taken to check the health of the database", time.Millisecond),
+	}
+}
+
 type PostgresRepo struct {
+	db                           *gorm.DB
+	readReplica                  *gorm.DB
+	primaryHealthMetrics         poolHealthMetrics
+	readReplicaHealthMetrics     poolHealthMetrics
 	executionRepo                interfaces.ExecutionRepoInterface
 	executionEventRepo           interfaces.ExecutionEventRepoInterface
 	namedEntityRepo              interfaces.NamedEntityRepoInterface
@@ -22,8 +48,12 @@ type PostgresRepo struct {
 	taskExecutionRepo            interfaces.TaskExecutionRepoInterface
 	workflowRepo                 interfaces.WorkflowRepoInterface
 	resourceRepo                 interfaces.ResourceRepoInterface
+	descriptionEntityRepo        interfaces.DescriptionEntityRepoInterface
+	scheduleCheckpointRepo       interfaces.ScheduleCheckpointRepoInterface
+	jobLeaseRepo                 interfaces.JobLeaseRepoInterface
 	schedulableEntityRepo        schedulerInterfaces.SchedulableEntityRepoInterface
 	scheduleEntitiesSnapshotRepo schedulerInterfaces.ScheduleEntitiesSnapShotRepoInterface
+	scheduleFiringRepo           schedulerInterfaces.ScheduleFiringRepoInterface
 }
 
 func (p *PostgresRepo) ExecutionRepo() interfaces.ExecutionRepoInterface {
@@ -70,6 +100,18 @@ func (p *PostgresRepo) ResourceRepo() interfaces.ResourceRepoInterface {
 	return p.resourceRepo
 }
 
+func (p *PostgresRepo) DescriptionEntityRepo() interfaces.DescriptionEntityRepoInterface {
+	return p.descriptionEntityRepo
+}
+
+func (p *PostgresRepo) ScheduleCheckpointRepo() interfaces.ScheduleCheckpointRepoInterface {
+	return p.scheduleCheckpointRepo
+}
+
+func (p *PostgresRepo) JobLeaseRepo() interfaces.JobLeaseRepoInterface {
+	return p.jobLeaseRepo
+}
+
 func (p *PostgresRepo) SchedulableEntityRepo() schedulerInterfaces.SchedulableEntityRepoInterface {
 	return p.schedulableEntityRepo
 }
@@ -78,8 +120,47 @@ func (p *PostgresRepo) ScheduleEntitiesSnapshotRepo() schedulerInterfaces.Schedu
 	return p.scheduleEntitiesSnapshotRepo
 }
 
+func (p *PostgresRepo) ScheduleFiringRepo() schedulerInterfaces.ScheduleFiringRepoInterface {
+	return p.scheduleFiringRepo
+}
+
+// Checks the primary and, when one is used, the read replica, either being unreachable fails the check.
+func (p *PostgresRepo) HealthCheck(ctx context.Context) error {
+	if err := checkPoolHealth(ctx, p.db, p.primaryHealthMetrics); err != nil {
+		return err
+	}
+	if p.readReplica != nil {
+		return checkPoolHealth(ctx, p.readReplica, p.readReplicaHealthMetrics)
+	}
+	return nil
+}
+
+func checkPoolHealth(ctx context.Context, db *gorm.DB, metrics poolHealthMetrics) error {
+	timer := metrics.HealthCheckDuration.Start()
+	err := db.WithContext(ctx).Exec("SELECT 1").Error
+	timer.Stop()
+	if err != nil {
+		metrics.Healthy.Set(0)
+		metrics.HealthCheckFailures.Inc()
+		return err
+	}
+	metrics.Healthy.Set(1)
+	return nil
+}
+
 func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) RepositoryInterface {
+	return NewPostgresRepoWithReadReplica(db, nil, errorTransformer, scope)
+}
+
+// Returns a repository whose reads are served by the read replica when one is given. The replica is expected to be
+// routed to by the primary's config.NewReadReplicaRouter plugin, it's only used directly to check its health.
+func NewPostgresRepoWithReadReplica(db, readReplica *gorm.DB, errorTransformer errors.ErrorTransformer,
+	scope promutils.Scope) RepositoryInterface {
 	return &PostgresRepo{
+		db:                           db,
+		readReplica:                  readReplica,
+		primaryHealthMetrics:         newPoolHealthMetrics(scope.NewSubScope("primary")),
+		readReplicaHealthMetrics:     newPoolHealthMetrics(scope.NewSubScope("read_replica")),
 		executionRepo:                gormimpl.NewExecutionRepo(db, errorTransformer, scope.NewSubScope("executions")),
 		executionEventRepo:           gormimpl.NewExecutionEventRepo(db, errorTransformer, scope.NewSubScope("execution_events")),
 		launchPlanRepo:               gormimpl.NewLaunchPlanRepo(db, errorTransformer, scope.NewSubScope("launch_plans")),
@@ -91,7 +172,11 @@ func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scop
 		taskExecutionRepo:            gormimpl.NewTaskExecutionRepo(db, errorTransformer, scope.NewSubScope("task_executions")),
 		workflowRepo:                 gormimpl.NewWorkflowRepo(db, errorTransformer, scope.NewSubScope("workflows")),
 		resourceRepo:                 gormimpl.NewResourceRepo(db, errorTransformer, scope.NewSubScope("resources")),
+		descriptionEntityRepo:        gormimpl.NewDescriptionEntityRepo(db, errorTransformer, scope.NewSubScope("description_entities")),
+		scheduleCheckpointRepo:       gormimpl.NewScheduleCheckpointRepo(db, errorTransformer, scope.NewSubScope("schedule_checkpoints")),
+		jobLeaseRepo:                 gormimpl.NewJobLeaseRepo(db, errorTransformer, scope.NewSubScope("job_leases")),
 		schedulableEntityRepo:        schedulerGormImpl.NewSchedulableEntityRepo(db, errorTransformer, scope.NewSubScope("schedulable_entity")),
 		scheduleEntitiesSnapshotRepo: schedulerGormImpl.NewScheduleEntitiesSnapshotRepo(db, errorTransformer, scope.NewSubScope("schedule_entities_snapshot")),
+		scheduleFiringRepo:           schedulerGormImpl.NewScheduleFiringRepo(db, errorTransformer, scope.NewSubScope("schedule_firing")),
 	}
 }
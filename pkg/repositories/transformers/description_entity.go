@@ -0,0 +1,48 @@
+package transformers
+
+import (
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+// Transforms a DescriptionEntity to a DescriptionEntity model
+func CreateDescriptionEntityModel(descriptionEntity interfaces.DescriptionEntity) models.DescriptionEntity {
+	return models.DescriptionEntity{
+		DescriptionEntityKey: models.DescriptionEntityKey{
+			ResourceType: descriptionEntity.ID.ResourceType,
+			Project:      descriptionEntity.ID.Project,
+			Domain:       descriptionEntity.ID.Domain,
+			Name:         descriptionEntity.ID.Name,
+			Version:      descriptionEntity.ID.Version,
+		},
+		LongDescription: []byte(descriptionEntity.LongDescription),
+		ContentType:     descriptionEntity.ContentType,
+		SourceCodeLink:  descriptionEntity.SourceCodeLink,
+	}
+}
+
+// Transforms a DescriptionEntity model to a DescriptionEntity
+func FromDescriptionEntityModel(descriptionEntityModel models.DescriptionEntity) *interfaces.DescriptionEntity {
+	return &interfaces.DescriptionEntity{
+		ID: &core.Identifier{
+			ResourceType: descriptionEntityModel.ResourceType,
+			Project:      descriptionEntityModel.Project,
+			Domain:       descriptionEntityModel.Domain,
+			Name:         descriptionEntityModel.Name,
+			Version:      descriptionEntityModel.Version,
+		},
+		LongDescription: string(descriptionEntityModel.LongDescription),
+		ContentType:     descriptionEntityModel.ContentType,
+		SourceCodeLink:  descriptionEntityModel.SourceCodeLink,
+	}
+}
+
+func FromDescriptionEntityModels(descriptionEntityModels []models.DescriptionEntity) []*interfaces.DescriptionEntity {
+	descriptionEntities := make([]*interfaces.DescriptionEntity, len(descriptionEntityModels))
+	for idx, descriptionEntityModel := range descriptionEntityModels {
+		descriptionEntities[idx] = FromDescriptionEntityModel(descriptionEntityModel)
+	}
+	return descriptionEntities
+}
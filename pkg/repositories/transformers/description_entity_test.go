@@ -0,0 +1,55 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+var descriptionEntityIdentifier = core.Identifier{
+	ResourceType: core.ResourceType_TASK,
+	Project:      "project",
+	Domain:       "domain",
+	Name:         "name",
+	Version:      "version",
+}
+
+func TestCreateDescriptionEntityModel(t *testing.T) {
+	descriptionEntityModel := CreateDescriptionEntityModel(interfaces.DescriptionEntity{
+		ID:              &descriptionEntityIdentifier,
+		LongDescription: "# Task",
+		ContentType:     "text/markdown",
+		SourceCodeLink:  "https://github.com/flyteorg/flytesnacks",
+	})
+	assert.Equal(t, models.DescriptionEntity{
+		DescriptionEntityKey: models.DescriptionEntityKey{
+			ResourceType: core.ResourceType_TASK,
+			Project:      "project",
+			Domain:       "domain",
+			Name:         "name",
+			Version:      "version",
+		},
+		LongDescription: []byte("# Task"),
+		ContentType:     "text/markdown",
+		SourceCodeLink:  "https://github.com/flyteorg/flytesnacks",
+	}, descriptionEntityModel)
+}
+
+func TestFromDescriptionEntityModels(t *testing.T) {
+	descriptionEntityModel := CreateDescriptionEntityModel(interfaces.DescriptionEntity{
+		ID:              &descriptionEntityIdentifier,
+		LongDescription: "plain text",
+		ContentType:     "text/plain",
+	})
+	descriptionEntities := FromDescriptionEntityModels([]models.DescriptionEntity{descriptionEntityModel})
+	assert.Len(t, descriptionEntities, 1)
+	assert.True(t, proto.Equal(&descriptionEntityIdentifier, descriptionEntities[0].ID))
+	assert.Equal(t, "plain text", descriptionEntities[0].LongDescription)
+	assert.Equal(t, "text/plain", descriptionEntities[0].ContentType)
+	assert.Empty(t, descriptionEntities[0].SourceCodeLink)
+}
@@ -0,0 +1,131 @@
+package transformers
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// How an execution event relates to the state recorded from the events received before it.
+type EventOrder int
+
+const (
+	// The event follows the recorded state and can be applied.
+	EventInOrder EventOrder = iota
+	// The event repeats the recorded phase, e.g. an event redelivered by propeller.
+	EventDuplicate
+	// The event precedes the recorded state, e.g. a RUNNING event arriving after a SUCCEEDING one.
+	EventStale
+)
+
+// Phases which may follow each other in either order share a rank, events moving to a lower ranked phase are stale.
+// Terminal phases, which nothing may follow, rank highest.
+var workflowExecutionPhaseRanks = map[core.WorkflowExecution_Phase]int{
+	core.WorkflowExecution_UNDEFINED:  0,
+	core.WorkflowExecution_QUEUED:     1,
+	core.WorkflowExecution_RUNNING:    2,
+	core.WorkflowExecution_SUCCEEDING: 3,
+	core.WorkflowExecution_FAILING:    3,
+	core.WorkflowExecution_SUCCEEDED:  4,
+	core.WorkflowExecution_FAILED:     4,
+	core.WorkflowExecution_ABORTED:    4,
+	core.WorkflowExecution_TIMED_OUT:  4,
+}
+
+var nodeExecutionPhaseRanks = map[core.NodeExecution_Phase]int{
+	core.NodeExecution_UNDEFINED:       0,
+	core.NodeExecution_QUEUED:          1,
+	core.NodeExecution_RUNNING:         2,
+	core.NodeExecution_DYNAMIC_RUNNING: 2,
+	core.NodeExecution_FAILING:         3,
+	core.NodeExecution_SUCCEEDED:       4,
+	core.NodeExecution_FAILED:          4,
+	core.NodeExecution_ABORTED:         4,
+	core.NodeExecution_SKIPPED:         4,
+	core.NodeExecution_TIMED_OUT:       4,
+	core.NodeExecution_RECOVERED:       4,
+}
+
+var taskExecutionPhaseRanks = map[core.TaskExecution_Phase]int{
+	core.TaskExecution_UNDEFINED:             0,
+	core.TaskExecution_QUEUED:                1,
+	core.TaskExecution_WAITING_FOR_RESOURCES: 1,
+	core.TaskExecution_INITIALIZING:          1,
+	core.TaskExecution_RUNNING:               2,
+	core.TaskExecution_SUCCEEDED:             3,
+	core.TaskExecution_FAILED:                3,
+	core.TaskExecution_ABORTED:               3,
+}
+
+// Events which occurred before the last recorded one are stale. Events without a valid timestamp, or states without
+// a recorded event time, aren't compared.
+func occurredBefore(occurredAt *timestamp.Timestamp, recordedAt *time.Time) bool {
+	if recordedAt == nil {
+		return false
+	}
+	eventTime, err := ptypes.Timestamp(occurredAt)
+	if err != nil {
+		return false
+	}
+	return eventTime.Before(*recordedAt)
+}
+
+// Orders a workflow execution event against the recorded execution. Subsequent queued events announcing a cluster
+// reassignment aren't duplicates.
+func GetWorkflowExecutionEventOrder(execution models.Execution, request admin.WorkflowExecutionEventRequest) EventOrder {
+	recordedPhase := core.WorkflowExecution_Phase(core.WorkflowExecution_Phase_value[execution.Phase])
+	eventPhase := request.Event.Phase
+	if recordedPhase == eventPhase && eventPhase != core.WorkflowExecution_QUEUED {
+		return EventDuplicate
+	}
+	if workflowExecutionPhaseRanks[eventPhase] < workflowExecutionPhaseRanks[recordedPhase] {
+		return EventStale
+	}
+	// Until the first event is recorded, the update time is the creation time of the execution by admin rather than
+	// an event time.
+	if recordedPhase != core.WorkflowExecution_UNDEFINED &&
+		occurredBefore(request.Event.OccurredAt, execution.ExecutionUpdatedAt) {
+		return EventStale
+	}
+	return EventInOrder
+}
+
+// Orders a node execution event against the recorded node execution.
+func GetNodeExecutionEventOrder(nodeExecution models.NodeExecution, request admin.NodeExecutionEventRequest) EventOrder {
+	recordedPhase := core.NodeExecution_Phase(core.NodeExecution_Phase_value[nodeExecution.Phase])
+	eventPhase := request.Event.Phase
+	if recordedPhase == eventPhase {
+		return EventDuplicate
+	}
+	if nodeExecutionPhaseRanks[eventPhase] < nodeExecutionPhaseRanks[recordedPhase] ||
+		occurredBefore(request.Event.OccurredAt, nodeExecution.NodeExecutionUpdatedAt) {
+		return EventStale
+	}
+	return EventInOrder
+}
+
+// Orders a task execution event against the recorded task execution attempt. Within a phase, events are ordered by
+// their phase version.
+func GetTaskExecutionEventOrder(taskExecution models.TaskExecution, request admin.TaskExecutionEventRequest) EventOrder {
+	recordedPhase := core.TaskExecution_Phase(core.TaskExecution_Phase_value[taskExecution.Phase])
+	eventPhase := request.Event.Phase
+	if recordedPhase == eventPhase {
+		switch {
+		case request.Event.PhaseVersion == taskExecution.PhaseVersion:
+			return EventDuplicate
+		case request.Event.PhaseVersion < taskExecution.PhaseVersion:
+			return EventStale
+		default:
+			return EventInOrder
+		}
+	}
+	if taskExecutionPhaseRanks[eventPhase] < taskExecutionPhaseRanks[recordedPhase] ||
+		occurredBefore(request.Event.OccurredAt, taskExecution.TaskExecutionUpdatedAt) {
+		return EventStale
+	}
+	return EventInOrder
+}
@@ -0,0 +1,213 @@
+package transformers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var eventOrderRecordedAt = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+var eventOrderLaterProto, _ = ptypes.TimestampProto(eventOrderRecordedAt.Add(time.Second))
+var eventOrderEarlierProto, _ = ptypes.TimestampProto(eventOrderRecordedAt.Add(-time.Second))
+
+func getWorkflowExecutionEventOrder(recordedPhase, eventPhase core.WorkflowExecution_Phase, earlier bool) EventOrder {
+	occurredAt := eventOrderLaterProto
+	if earlier {
+		occurredAt = eventOrderEarlierProto
+	}
+	return GetWorkflowExecutionEventOrder(models.Execution{
+		Phase:              recordedPhase.String(),
+		ExecutionUpdatedAt: &eventOrderRecordedAt,
+	}, admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase:      eventPhase,
+			OccurredAt: occurredAt,
+		},
+	})
+}
+
+func TestGetWorkflowExecutionEventOrder(t *testing.T) {
+	tests := []struct {
+		recorded core.WorkflowExecution_Phase
+		event    core.WorkflowExecution_Phase
+		expected EventOrder
+	}{
+		{core.WorkflowExecution_UNDEFINED, core.WorkflowExecution_QUEUED, EventInOrder},
+		{core.WorkflowExecution_UNDEFINED, core.WorkflowExecution_RUNNING, EventInOrder},
+		{core.WorkflowExecution_QUEUED, core.WorkflowExecution_QUEUED, EventInOrder},
+		{core.WorkflowExecution_QUEUED, core.WorkflowExecution_RUNNING, EventInOrder},
+		{core.WorkflowExecution_QUEUED, core.WorkflowExecution_FAILED, EventInOrder},
+		{core.WorkflowExecution_RUNNING, core.WorkflowExecution_QUEUED, EventStale},
+		{core.WorkflowExecution_RUNNING, core.WorkflowExecution_RUNNING, EventDuplicate},
+		{core.WorkflowExecution_RUNNING, core.WorkflowExecution_SUCCEEDING, EventInOrder},
+		{core.WorkflowExecution_RUNNING, core.WorkflowExecution_SUCCEEDED, EventInOrder},
+		{core.WorkflowExecution_SUCCEEDING, core.WorkflowExecution_RUNNING, EventStale},
+		{core.WorkflowExecution_SUCCEEDING, core.WorkflowExecution_FAILING, EventInOrder},
+		{core.WorkflowExecution_SUCCEEDING, core.WorkflowExecution_SUCCEEDED, EventInOrder},
+		{core.WorkflowExecution_FAILING, core.WorkflowExecution_FAILED, EventInOrder},
+		{core.WorkflowExecution_SUCCEEDED, core.WorkflowExecution_SUCCEEDED, EventDuplicate},
+		{core.WorkflowExecution_ABORTED, core.WorkflowExecution_ABORTED, EventDuplicate},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s to %s", test.recorded, test.event), func(t *testing.T) {
+			assert.Equal(t, test.expected, getWorkflowExecutionEventOrder(test.recorded, test.event, false))
+		})
+	}
+}
+
+func TestGetWorkflowExecutionEventOrder_Terminal(t *testing.T) {
+	for recordedPhase := range core.WorkflowExecution_Phase_name {
+		recorded := core.WorkflowExecution_Phase(recordedPhase)
+		if !common.IsExecutionTerminal(recorded) {
+			continue
+		}
+		for eventPhase := range core.WorkflowExecution_Phase_name {
+			event := core.WorkflowExecution_Phase(eventPhase)
+			// Other terminal phases are rejected by the managers as the execution already terminated.
+			if !common.IsExecutionTerminal(event) {
+				assert.Equal(t, EventStale, getWorkflowExecutionEventOrder(recorded, event, false),
+					"%s to %s", recorded, event)
+			}
+		}
+	}
+}
+
+func TestGetWorkflowExecutionEventOrder_OccurredAt(t *testing.T) {
+	assert.Equal(t, EventStale, getWorkflowExecutionEventOrder(
+		core.WorkflowExecution_RUNNING, core.WorkflowExecution_SUCCEEDED, true))
+	assert.Equal(t, EventStale, getWorkflowExecutionEventOrder(
+		core.WorkflowExecution_QUEUED, core.WorkflowExecution_QUEUED, true))
+	assert.Equal(t, EventDuplicate, getWorkflowExecutionEventOrder(
+		core.WorkflowExecution_RUNNING, core.WorkflowExecution_RUNNING, true))
+	// The update time of executions without events is their creation time by admin.
+	assert.Equal(t, EventInOrder, getWorkflowExecutionEventOrder(
+		core.WorkflowExecution_UNDEFINED, core.WorkflowExecution_QUEUED, true))
+
+	assert.Equal(t, EventInOrder, GetWorkflowExecutionEventOrder(models.Execution{
+		Phase: core.WorkflowExecution_RUNNING.String(),
+	}, admin.WorkflowExecutionEventRequest{
+		Event: &event.WorkflowExecutionEvent{
+			Phase: core.WorkflowExecution_SUCCEEDED,
+		},
+	}))
+}
+
+func getNodeExecutionEventOrder(recordedPhase, eventPhase core.NodeExecution_Phase, earlier bool) EventOrder {
+	occurredAt := eventOrderLaterProto
+	if earlier {
+		occurredAt = eventOrderEarlierProto
+	}
+	return GetNodeExecutionEventOrder(models.NodeExecution{
+		Phase:                  recordedPhase.String(),
+		NodeExecutionUpdatedAt: &eventOrderRecordedAt,
+	}, admin.NodeExecutionEventRequest{
+		Event: &event.NodeExecutionEvent{
+			Phase:      eventPhase,
+			OccurredAt: occurredAt,
+		},
+	})
+}
+
+func TestGetNodeExecutionEventOrder(t *testing.T) {
+	tests := []struct {
+		recorded core.NodeExecution_Phase
+		event    core.NodeExecution_Phase
+		expected EventOrder
+	}{
+		{core.NodeExecution_QUEUED, core.NodeExecution_QUEUED, EventDuplicate},
+		{core.NodeExecution_QUEUED, core.NodeExecution_RUNNING, EventInOrder},
+		{core.NodeExecution_QUEUED, core.NodeExecution_SKIPPED, EventInOrder},
+		{core.NodeExecution_RUNNING, core.NodeExecution_QUEUED, EventStale},
+		{core.NodeExecution_RUNNING, core.NodeExecution_RUNNING, EventDuplicate},
+		{core.NodeExecution_RUNNING, core.NodeExecution_DYNAMIC_RUNNING, EventInOrder},
+		{core.NodeExecution_RUNNING, core.NodeExecution_FAILING, EventInOrder},
+		{core.NodeExecution_RUNNING, core.NodeExecution_SUCCEEDED, EventInOrder},
+		{core.NodeExecution_DYNAMIC_RUNNING, core.NodeExecution_RUNNING, EventInOrder},
+		{core.NodeExecution_DYNAMIC_RUNNING, core.NodeExecution_SUCCEEDED, EventInOrder},
+		{core.NodeExecution_FAILING, core.NodeExecution_RUNNING, EventStale},
+		{core.NodeExecution_FAILING, core.NodeExecution_FAILED, EventInOrder},
+		{core.NodeExecution_SUCCEEDED, core.NodeExecution_SUCCEEDED, EventDuplicate},
+		{core.NodeExecution_SUCCEEDED, core.NodeExecution_RUNNING, EventStale},
+		{core.NodeExecution_RECOVERED, core.NodeExecution_QUEUED, EventStale},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s to %s", test.recorded, test.event), func(t *testing.T) {
+			assert.Equal(t, test.expected, getNodeExecutionEventOrder(test.recorded, test.event, false))
+		})
+	}
+}
+
+func TestGetNodeExecutionEventOrder_OccurredAt(t *testing.T) {
+	assert.Equal(t, EventStale, getNodeExecutionEventOrder(
+		core.NodeExecution_RUNNING, core.NodeExecution_SUCCEEDED, true))
+	assert.Equal(t, EventDuplicate, getNodeExecutionEventOrder(
+		core.NodeExecution_RUNNING, core.NodeExecution_RUNNING, true))
+}
+
+func getTaskExecutionEventOrder(recordedPhase core.TaskExecution_Phase, recordedPhaseVersion uint32,
+	eventPhase core.TaskExecution_Phase, eventPhaseVersion uint32, earlier bool) EventOrder {
+	occurredAt := eventOrderLaterProto
+	if earlier {
+		occurredAt = eventOrderEarlierProto
+	}
+	return GetTaskExecutionEventOrder(models.TaskExecution{
+		Phase:                  recordedPhase.String(),
+		PhaseVersion:           recordedPhaseVersion,
+		TaskExecutionUpdatedAt: &eventOrderRecordedAt,
+	}, admin.TaskExecutionEventRequest{
+		Event: &event.TaskExecutionEvent{
+			Phase:        eventPhase,
+			PhaseVersion: eventPhaseVersion,
+			OccurredAt:   occurredAt,
+		},
+	})
+}
+
+func TestGetTaskExecutionEventOrder(t *testing.T) {
+	tests := []struct {
+		recorded        core.TaskExecution_Phase
+		recordedVersion uint32
+		event           core.TaskExecution_Phase
+		eventVersion    uint32
+		expected        EventOrder
+	}{
+		{core.TaskExecution_QUEUED, 0, core.TaskExecution_QUEUED, 0, EventDuplicate},
+		{core.TaskExecution_QUEUED, 0, core.TaskExecution_WAITING_FOR_RESOURCES, 0, EventInOrder},
+		{core.TaskExecution_WAITING_FOR_RESOURCES, 0, core.TaskExecution_QUEUED, 0, EventInOrder},
+		{core.TaskExecution_QUEUED, 0, core.TaskExecution_INITIALIZING, 0, EventInOrder},
+		{core.TaskExecution_INITIALIZING, 0, core.TaskExecution_RUNNING, 0, EventInOrder},
+		{core.TaskExecution_RUNNING, 0, core.TaskExecution_QUEUED, 0, EventStale},
+		{core.TaskExecution_RUNNING, 0, core.TaskExecution_INITIALIZING, 0, EventStale},
+		{core.TaskExecution_RUNNING, 0, core.TaskExecution_RUNNING, 0, EventDuplicate},
+		{core.TaskExecution_RUNNING, 1, core.TaskExecution_RUNNING, 2, EventInOrder},
+		{core.TaskExecution_RUNNING, 2, core.TaskExecution_RUNNING, 2, EventDuplicate},
+		{core.TaskExecution_RUNNING, 2, core.TaskExecution_RUNNING, 1, EventStale},
+		{core.TaskExecution_RUNNING, 2, core.TaskExecution_SUCCEEDED, 0, EventInOrder},
+		{core.TaskExecution_SUCCEEDED, 0, core.TaskExecution_SUCCEEDED, 0, EventDuplicate},
+		{core.TaskExecution_SUCCEEDED, 0, core.TaskExecution_RUNNING, 3, EventStale},
+		{core.TaskExecution_FAILED, 0, core.TaskExecution_QUEUED, 0, EventStale},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s (%d) to %s (%d)", test.recorded, test.recordedVersion, test.event, test.eventVersion),
+			func(t *testing.T) {
+				assert.Equal(t, test.expected, getTaskExecutionEventOrder(
+					test.recorded, test.recordedVersion, test.event, test.eventVersion, false))
+			})
+	}
+}
+
+func TestGetTaskExecutionEventOrder_OccurredAt(t *testing.T) {
+	assert.Equal(t, EventStale, getTaskExecutionEventOrder(
+		core.TaskExecution_RUNNING, 0, core.TaskExecution_SUCCEEDED, 0, true))
+	// Within a phase, the phase version rather than the event time orders events.
+	assert.Equal(t, EventInOrder, getTaskExecutionEventOrder(
+		core.TaskExecution_RUNNING, 0, core.TaskExecution_RUNNING, 1, true))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -24,6 +25,9 @@ import (
 
 var clusterReassignablePhases = sets.NewString(core.WorkflowExecution_UNDEFINED.String(), core.WorkflowExecution_QUEUED.String())
 
+// Error messages are stored, truncated to the size of the column, to filter executions on.
+const maxErrorMessageLength = 255
+
 // CreateExecutionModelInput encapsulates request parameters for calls to CreateExecutionModel.
 type CreateExecutionModelInput struct {
 	WorkflowExecutionID   core.WorkflowExecutionIdentifier
@@ -38,6 +42,10 @@ type CreateExecutionModelInput struct {
 	ParentNodeExecutionID uint
 	SourceExecutionID     uint
 	Cluster               string
+	Namespace             string
+	Queue                 string
+	Envs                  map[string]string
+	MaxParallelism        int32
 	InputsURI             storage.DataReference
 	UserInputsURI         storage.DataReference
 }
@@ -51,6 +59,24 @@ func CreateExecutionModel(input CreateExecutionModelInput) (*models.Execution, e
 	requestSpec.Metadata.SystemMetadata = &admin.SystemMetadata{
 		ExecutionCluster: input.Cluster,
 	}
+	if _, ok := requestSpec.GetAnnotations().GetValues()[common.ExecutionQueueAnnotation]; ok || len(input.Queue) > 0 {
+		// The closure has no field for the queue, so it's reported on the spec instead, in place of any queue copied
+		// over from the spec of a previous execution.
+		annotations := common.WithoutAnnotations(requestSpec.GetAnnotations().GetValues(), common.ExecutionQueueAnnotation)
+		if len(input.Queue) > 0 {
+			annotations[common.ExecutionQueueAnnotation] = input.Queue
+		}
+		requestSpec.Annotations = &admin.Annotations{Values: annotations}
+	}
+	if len(common.GetExecutionEnvs(requestSpec.GetAnnotations().GetValues())) > 0 || len(input.Envs) > 0 {
+		// Nor has it a field for the environment variables resolved for the tasks, which are likewise reported on the
+		// spec in place of those the execution requested.
+		annotations := common.WithoutExecutionEnvAnnotations(requestSpec.GetAnnotations().GetValues())
+		for name, value := range input.Envs {
+			annotations[common.ExecutionEnvAnnotationPrefix+name] = value
+		}
+		requestSpec.Annotations = &admin.Annotations{Values: annotations}
+	}
 	spec, err := proto.Marshal(requestSpec)
 	if err != nil {
 		return nil, errors.NewFlyteAdminErrorf(codes.Internal, "Failed to serialize execution spec: %v", err)
@@ -91,6 +117,9 @@ func CreateExecutionModel(input CreateExecutionModelInput) (*models.Execution, e
 		ParentNodeExecutionID: input.ParentNodeExecutionID,
 		SourceExecutionID:     input.SourceExecutionID,
 		Cluster:               input.Cluster,
+		Namespace:             input.Namespace,
+		Queue:                 input.Queue,
+		MaxParallelism:        &input.MaxParallelism,
 		InputsURI:             input.InputsURI,
 		UserInputsURI:         input.UserInputsURI,
 		User:                  requestSpec.Metadata.Principal,
@@ -219,6 +248,8 @@ func UpdateExecutionModelState(
 		k := request.Event.GetError().Kind.String()
 		execution.ErrorKind = &k
 		execution.ErrorCode = &request.Event.GetError().Code
+		errorMessage := truncateErrorMessage(request.Event.GetError().Message)
+		execution.ErrorMessage = &errorMessage
 	}
 	marshaledClosure, err := proto.Marshal(&executionClosure)
 	if err != nil {
@@ -228,6 +259,18 @@ func UpdateExecutionModelState(
 	return nil
 }
 
+// Truncates on a rune boundary so that the stored message remains valid utf-8.
+func truncateErrorMessage(message string) string {
+	if len(message) <= maxErrorMessageLength {
+		return message
+	}
+	truncated := message[:maxErrorMessageLength]
+	for len(truncated) > 0 && !utf8.ValidString(truncated) {
+		truncated = truncated[:len(truncated)-1]
+	}
+	return truncated
+}
+
 // The execution abort metadata is recorded but the phase is not actually updated *until* the abort event is propagated
 // by flytepropeller. The metadata is preemptively saved at the time of the abort.
 func SetExecutionAborted(execution *models.Execution, cause, principal string) error {
@@ -259,7 +302,22 @@ func GetExecutionIdentifier(executionModel *models.Execution) core.WorkflowExecu
 	}
 }
 
+// Returns the execution with the max parallelism it was launched with in its spec, where 0 means unlimited. Executions
+// which predate it being recorded keep the value they requested.
 func FromExecutionModel(executionModel models.Execution) (*admin.Execution, error) {
+	execution, err := FromExecutionModelAsRequested(executionModel)
+	if err != nil {
+		return nil, err
+	}
+	if executionModel.MaxParallelism != nil {
+		execution.Spec.MaxParallelism = *executionModel.MaxParallelism
+	}
+	return execution, nil
+}
+
+// Returns the execution with the spec it was requested with, which relaunches and recoveries copy so that they resolve
+// the max parallelism anew rather than pinning the one the execution was launched with.
+func FromExecutionModelAsRequested(executionModel models.Execution) (*admin.Execution, error) {
 	var spec admin.ExecutionSpec
 	err := proto.Unmarshal(executionModel.Spec, &spec)
 	if err != nil {
@@ -3,6 +3,7 @@ package transformers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -113,6 +114,81 @@ func TestCreateExecutionModel(t *testing.T) {
 	assert.Equal(t, expectedClosure, execution.Closure)
 }
 
+func TestCreateExecutionModel_Queue(t *testing.T) {
+	getSpecAnnotations := func(queue string, requestAnnotations map[string]string) map[string]string {
+		execRequest := testutils.GetExecutionRequest()
+		execRequest.Spec.Annotations = &admin.Annotations{Values: requestAnnotations}
+		execution, err := CreateExecutionModel(CreateExecutionModelInput{
+			WorkflowExecutionID: core.WorkflowExecutionIdentifier{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "name",
+			},
+			RequestSpec: execRequest.Spec,
+			Phase:       core.WorkflowExecution_UNDEFINED,
+			CreatedAt:   time.Now(),
+			Queue:       queue,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, queue, execution.Queue)
+		var spec admin.ExecutionSpec
+		assert.NoError(t, proto.Unmarshal(execution.Spec, &spec))
+		return spec.GetAnnotations().GetValues()
+	}
+	t.Run("assigned", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"foo": "bar", common.ExecutionQueueAnnotation: "queue"},
+			getSpecAnnotations("queue", map[string]string{"foo": "bar"}))
+	})
+	t.Run("replaces a copied queue", func(t *testing.T) {
+		assert.Equal(t, map[string]string{common.ExecutionQueueAnnotation: "queue"},
+			getSpecAnnotations("queue", map[string]string{common.ExecutionQueueAnnotation: "previous"}))
+		assert.Empty(t, getSpecAnnotations("", map[string]string{common.ExecutionQueueAnnotation: "previous"}))
+	})
+	t.Run("unassigned", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"foo": "bar"}, getSpecAnnotations("", map[string]string{"foo": "bar"}))
+	})
+}
+
+func TestCreateExecutionModel_Envs(t *testing.T) {
+	getSpecAnnotations := func(envs, requestAnnotations map[string]string) map[string]string {
+		execRequest := testutils.GetExecutionRequest()
+		execRequest.Spec.Annotations = &admin.Annotations{Values: requestAnnotations}
+		execution, err := CreateExecutionModel(CreateExecutionModelInput{
+			WorkflowExecutionID: core.WorkflowExecutionIdentifier{
+				Project: "project",
+				Domain:  "domain",
+				Name:    "name",
+			},
+			RequestSpec: execRequest.Spec,
+			Phase:       core.WorkflowExecution_UNDEFINED,
+			CreatedAt:   time.Now(),
+			Envs:        envs,
+		})
+		assert.NoError(t, err)
+		var spec admin.ExecutionSpec
+		assert.NoError(t, proto.Unmarshal(execution.Spec, &spec))
+		return spec.GetAnnotations().GetValues()
+	}
+	t.Run("resolved", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"foo": "bar",
+			common.ExecutionEnvAnnotationPrefix + "REGION": "us-east-1",
+			common.ExecutionEnvAnnotationPrefix + "FLAG":   "on",
+		}, getSpecAnnotations(map[string]string{"REGION": "us-east-1", "FLAG": "on"}, map[string]string{
+			"foo": "bar",
+			common.ExecutionEnvAnnotationPrefix + "FLAG":  "on",
+			common.ExecutionEnvAnnotationPrefix + "DEBUG": "",
+		}))
+	})
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"foo": "bar"}, getSpecAnnotations(nil, map[string]string{
+			"foo": "bar",
+			common.ExecutionEnvAnnotationPrefix + "DEBUG": "",
+		}))
+		assert.Equal(t, map[string]string{"foo": "bar"}, getSpecAnnotations(nil, map[string]string{"foo": "bar"}))
+	})
+}
+
 func TestUpdateModelState_UnknownToRunning(t *testing.T) {
 
 	createdAt := time.Date(2018, 10, 29, 16, 0, 0, 0, time.UTC)
@@ -243,10 +319,22 @@ func TestUpdateModelState_RunningToFailed(t *testing.T) {
 		ExecutionUpdatedAt: &occurredAt,
 		ErrorCode:          &ec,
 		ErrorKind:          &ekString,
+		ErrorMessage:       &executionError.Message,
 	}
 	assert.EqualValues(t, expectedModel, executionModel)
 }
 
+func TestTruncateErrorMessage(t *testing.T) {
+	assert.Equal(t, "OOMKilled", truncateErrorMessage("OOMKilled"))
+
+	message := strings.Repeat("a", maxErrorMessageLength)
+	assert.Equal(t, message, truncateErrorMessage(message+"b"))
+
+	// A multi-byte rune straddling the limit is dropped entirely.
+	message = strings.Repeat("a", maxErrorMessageLength-1)
+	assert.Equal(t, message, truncateErrorMessage(message+"é"))
+}
+
 func TestUpdateModelState_RunningToSuccess(t *testing.T) {
 	startedAt := time.Now()
 	startedAtProto, _ := ptypes.TimestampProto(startedAt)
@@ -513,6 +601,37 @@ func TestFromExecutionModel(t *testing.T) {
 	}, execution))
 }
 
+func TestFromExecutionModel_MaxParallelism(t *testing.T) {
+	spec := testutils.GetExecutionRequest().Spec
+	spec.MaxParallelism = 0
+	specBytes, _ := proto.Marshal(spec)
+	closureBytes, _ := proto.Marshal(&admin.ExecutionClosure{})
+	executionModel := models.Execution{
+		ExecutionKey: models.ExecutionKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		},
+		Spec:    specBytes,
+		Closure: closureBytes,
+	}
+
+	// Executions which predate the launched value being recorded report the requested one.
+	execution, err := FromExecutionModel(executionModel)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), execution.Spec.MaxParallelism)
+
+	maxParallelism := int32(25)
+	executionModel.MaxParallelism = &maxParallelism
+	execution, err = FromExecutionModel(executionModel)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(25), execution.Spec.MaxParallelism)
+
+	execution, err = FromExecutionModelAsRequested(executionModel)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), execution.Spec.MaxParallelism)
+}
+
 func TestFromExecutionModel_Aborted(t *testing.T) {
 	abortCause := "abort cause"
 	executionClosureBytes, _ := proto.Marshal(&admin.ExecutionClosure{
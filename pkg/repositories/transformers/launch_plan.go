@@ -34,20 +34,16 @@ func CreateLaunchPlanModel(
 	if err != nil {
 		return models.LaunchPlan{}, errors.NewFlyteAdminError(codes.Internal, "Failed to serialize launch plan spec")
 	}
-	closure, err := proto.Marshal(launchPlan.Closure)
+	// The expected inputs are a proto map, serialize deterministically so that re-registering an identical launch plan
+	// always produces the same stored closure.
+	closureBuffer := proto.NewBuffer(nil)
+	closureBuffer.SetDeterministic(true)
+	err = closureBuffer.Marshal(launchPlan.Closure)
+	closure := closureBuffer.Bytes()
 	if err != nil {
 		return models.LaunchPlan{}, errors.NewFlyteAdminError(codes.Internal, "Failed to serialize launch plan closure")
 	}
 
-	scheduleType := models.LaunchPlanScheduleTypeNONE
-	if launchPlan.Spec.EntityMetadata != nil && launchPlan.Spec.EntityMetadata.Schedule != nil {
-		if launchPlan.Spec.EntityMetadata.Schedule.GetCronExpression() != "" {
-			scheduleType = models.LaunchPlanScheduleTypeCRON
-		} else if launchPlan.Spec.EntityMetadata.Schedule.GetRate() != nil {
-			scheduleType = models.LaunchPlanScheduleTypeRATE
-		}
-	}
-
 	state := int32(initState)
 
 	return models.LaunchPlan{
@@ -62,7 +58,7 @@ func CreateLaunchPlanModel(
 		Closure:      closure,
 		WorkflowID:   workflowRepoID,
 		Digest:       digest,
-		ScheduleType: scheduleType,
+		ScheduleType: models.GetLaunchPlanScheduleType(launchPlan.Spec),
 	}, nil
 }
 
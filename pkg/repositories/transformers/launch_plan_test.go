@@ -83,6 +83,34 @@ func TestToLaunchPlanModel(t *testing.T) {
 	assert.Equal(t, launchPlanDigest, launchPlanModel.Digest)
 }
 
+func TestToLaunchPlanModel_StableClosure(t *testing.T) {
+	lpRequest := getRequest()
+	inputs := &core.ParameterMap{
+		Parameters: map[string]*core.Parameter{},
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		inputs.Parameters[name] = &core.Parameter{
+			Var: &core.Variable{Description: name},
+		}
+	}
+	launchPlan := admin.LaunchPlan{
+		Id:   lpRequest.Id,
+		Spec: lpRequest.Spec,
+		Closure: &admin.LaunchPlanClosure{
+			ExpectedInputs:  inputs,
+			ExpectedOutputs: expectedOutputs,
+		},
+	}
+
+	firstModel, err := CreateLaunchPlanModel(launchPlan, uint(11), []byte("launch plan"), admin.LaunchPlanState_INACTIVE)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		model, err := CreateLaunchPlanModel(launchPlan, uint(11), []byte("launch plan"), admin.LaunchPlanState_INACTIVE)
+		assert.NoError(t, err)
+		assert.Equal(t, firstModel.Closure, model.Closure)
+	}
+}
+
 func TestToLaunchPlanModelWithCronSchedule(t *testing.T) {
 	lpRequest := testutils.GetLaunchPlanRequestWithCronSchedule("* * * * *")
 	lpRequest.Spec.DefaultInputs = expectedInputs
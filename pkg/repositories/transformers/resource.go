@@ -3,9 +3,11 @@ package transformers
 import (
 	"context"
 
+	managerInterfaces "github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	repoInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
@@ -56,8 +58,10 @@ func mergeUpdatePluginOverrides(existingAttributes admin.MatchingAttributes,
 	}
 }
 
-func MergeUpdateWorkflowAttributes(ctx context.Context, model models.Resource, resource admin.MatchableResource,
-	resourceID *repoInterfaces.ResourceID, workflowAttributes *admin.WorkflowAttributes) (models.Resource, error) {
+// Merges the matching attributes into those of the model, for the resource types whose attributes are merged on update
+// rather than replaced.
+func MergeUpdateMatchingAttributes(ctx context.Context, model models.Resource, resource admin.MatchableResource,
+	resourceID *repoInterfaces.ResourceID, matchingAttributes *admin.MatchingAttributes) (models.Resource, error) {
 	switch resource {
 	case admin.MatchableResource_PLUGIN_OVERRIDE:
 		var existingAttributes admin.MatchingAttributes
@@ -66,7 +70,7 @@ func MergeUpdateWorkflowAttributes(ctx context.Context, model models.Resource, r
 			return models.Resource{}, errors.NewFlyteAdminErrorf(codes.Internal,
 				"Unable to unmarshal existing resource attributes for [%+v] with err: %v", resourceID, err)
 		}
-		updatedAttributes := mergeUpdatePluginOverrides(existingAttributes, workflowAttributes.GetMatchingAttributes())
+		updatedAttributes := mergeUpdatePluginOverrides(existingAttributes, matchingAttributes)
 		marshaledAttributes, err := proto.Marshal(updatedAttributes)
 		if err != nil {
 			return models.Resource{}, errors.NewFlyteAdminErrorf(codes.Internal,
@@ -83,6 +87,11 @@ func MergeUpdateWorkflowAttributes(ctx context.Context, model models.Resource, r
 	}
 }
 
+func MergeUpdateWorkflowAttributes(ctx context.Context, model models.Resource, resource admin.MatchableResource,
+	resourceID *repoInterfaces.ResourceID, workflowAttributes *admin.WorkflowAttributes) (models.Resource, error) {
+	return MergeUpdateMatchingAttributes(ctx, model, resource, resourceID, workflowAttributes.GetMatchingAttributes())
+}
+
 func FromResourceModelToWorkflowAttributes(model models.Resource) (admin.WorkflowAttributes, error) {
 	var attributes admin.MatchingAttributes
 	err := proto.Unmarshal(model.Attributes, &attributes)
@@ -114,29 +123,7 @@ func ProjectDomainAttributesToResourceModel(attributes admin.ProjectDomainAttrib
 
 func MergeUpdateProjectDomainAttributes(ctx context.Context, model models.Resource, resource admin.MatchableResource,
 	resourceID *repoInterfaces.ResourceID, attributes *admin.ProjectDomainAttributes) (models.Resource, error) {
-	switch resource {
-	case admin.MatchableResource_PLUGIN_OVERRIDE:
-		var existingAttributes admin.MatchingAttributes
-		err := proto.Unmarshal(model.Attributes, &existingAttributes)
-		if err != nil {
-			return models.Resource{}, errors.NewFlyteAdminErrorf(codes.Internal,
-				"Unable to unmarshal existing resource attributes for [%+v] with err: %v", resourceID, err)
-		}
-		updatedAttributes := mergeUpdatePluginOverrides(existingAttributes, attributes.GetMatchingAttributes())
-		marshaledAttributes, err := proto.Marshal(updatedAttributes)
-		if err != nil {
-			return models.Resource{}, errors.NewFlyteAdminErrorf(codes.Internal,
-				"Failed to marshal merge-updated attributes for [%+v] with err: %v", resourceID, err)
-		}
-		model.Attributes = marshaledAttributes
-		return model, nil
-	default:
-		logger.Warningf(ctx, "Tried to merge-update an unsupported resource type [%s] for [%+v]",
-			resource.String(), resourceID)
-		return models.Resource{}, errors.NewFlyteAdminErrorf(codes.Internal,
-			"Tried to merge-update an unsupported resource type [%s] for [%+v]",
-			resource.String(), resourceID)
-	}
+	return MergeUpdateMatchingAttributes(ctx, model, resource, resourceID, attributes.GetMatchingAttributes())
 }
 
 func FromResourceModelToProjectDomainAttributes(model models.Resource) (admin.ProjectDomainAttributes, error) {
@@ -153,6 +140,28 @@ func FromResourceModelToProjectDomainAttributes(model models.Resource) (admin.Pr
 	}, nil
 }
 
+func ScopedAttributesToResourceModel(scope managerInterfaces.AttributesScope,
+	attributes *admin.MatchingAttributes, resource admin.MatchableResource) (models.Resource, error) {
+	attributeBytes, err := proto.Marshal(attributes)
+	if err != nil {
+		return models.Resource{}, err
+	}
+	priority := models.ResourcePriorityDomainLevel
+	if len(scope.Project) > 0 {
+		priority = models.ResourcePriorityProjectLevel
+	} else if len(scope.Org) > 0 {
+		priority = models.ResourcePriorityOrgLevel
+	}
+	return models.Resource{
+		Project:      scope.Project,
+		Domain:       scope.Domain,
+		Org:          scope.Org,
+		ResourceType: resource.String(),
+		Priority:     priority,
+		Attributes:   attributeBytes,
+	}, nil
+}
+
 func FromResourceModelToMatchableAttributes(model models.Resource) (admin.MatchableAttributesConfiguration, error) {
 	var attributes admin.MatchingAttributes
 	err := proto.Unmarshal(model.Attributes, &attributes)
@@ -181,3 +190,30 @@ func FromResourceModelsToMatchableAttributes(models []models.Resource) (
 	}
 	return configs, nil
 }
+
+func ActiveExecutionQuotaToResourceModel(quota managerInterfaces.ActiveExecutionQuota) (models.Resource, error) {
+	attributeBytes, err := proto.Marshal(&wrappers.Int32Value{Value: quota.MaxActiveExecutions})
+	if err != nil {
+		return models.Resource{}, err
+	}
+	return models.Resource{
+		Project:      quota.Project,
+		Domain:       quota.Domain,
+		ResourceType: models.ActiveExecutionQuotaResourceType,
+		Priority:     models.ResourcePriorityProjectDomainLevel,
+		Attributes:   attributeBytes,
+	}, nil
+}
+
+func FromResourceModelToActiveExecutionQuota(model models.Resource) (managerInterfaces.ActiveExecutionQuota, error) {
+	var maxActiveExecutions wrappers.Int32Value
+	if err := proto.Unmarshal(model.Attributes, &maxActiveExecutions); err != nil {
+		return managerInterfaces.ActiveExecutionQuota{}, errors.NewFlyteAdminErrorf(
+			codes.Internal, "Failed to decode active execution quota with err: %v", err)
+	}
+	return managerInterfaces.ActiveExecutionQuota{
+		Project:             model.Project,
+		Domain:              model.Domain,
+		MaxActiveExecutions: maxActiveExecutions.Value,
+	}, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -26,10 +27,17 @@ import (
 var empty _struct.Struct
 var jsonEmpty, _ = protojson.Marshal(&empty)
 
+// Bounds the plugin reported info recorded in task execution closures. Limits of 0 are disabled.
+type TaskExecutionInfoLimits struct {
+	MaxExternalResources     int
+	MaxCustomInfoSizeInBytes int64
+}
+
 type CreateTaskExecutionModelInput struct {
 	Request               *admin.TaskExecutionEventRequest
 	InlineEventDataPolicy interfaces.InlineEventDataPolicy
 	StorageClient         *storage.DataStore
+	InfoLimits            TaskExecutionInfoLimits
 }
 
 func addTaskStartedState(request *admin.TaskExecutionEventRequest, taskExecutionModel *models.TaskExecution,
@@ -123,13 +131,16 @@ func CreateTaskExecutionModel(ctx context.Context, input CreateTaskExecutionMode
 	}
 
 	closure := &admin.TaskExecutionClosure{
-		Phase:      input.Request.Event.Phase,
-		UpdatedAt:  input.Request.Event.OccurredAt,
-		CreatedAt:  input.Request.Event.OccurredAt,
-		Logs:       input.Request.Event.Logs,
-		CustomInfo: input.Request.Event.CustomInfo,
-		Reason:     input.Request.Event.Reason,
-		TaskType:   input.Request.Event.TaskType,
+		Phase:     input.Request.Event.Phase,
+		UpdatedAt: input.Request.Event.OccurredAt,
+		CreatedAt: input.Request.Event.OccurredAt,
+		Logs:      input.Request.Event.Logs,
+		CustomInfo: limitCustomInfo(ctx, nil, input.Request.Event.CustomInfo,
+			input.InfoLimits.MaxCustomInfoSizeInBytes),
+		Reason:   input.Request.Event.Reason,
+		TaskType: input.Request.Event.TaskType,
+		Metadata: limitExternalResources(ctx, mergeMetadata(nil, input.Request.Event.Metadata),
+			input.InfoLimits.MaxExternalResources),
 	}
 
 	eventPhase := input.Request.Event.Phase
@@ -240,8 +251,88 @@ func mergeCustom(existing, latest *_struct.Struct) (*_struct.Struct, error) {
 	return &response, nil
 }
 
+// mergeExternalResources returns the unique external resources across the existing ones and the ones sent in a task
+// execution event update, identified by their external id. Resources keep the order in which they were first reported.
+func mergeExternalResources(existing, latest []*event.ExternalResourceInfo) []*event.ExternalResourceInfo {
+	if len(existing) == 0 {
+		return latest
+	}
+	if len(latest) == 0 {
+		return existing
+	}
+	resources := make([]*event.ExternalResourceInfo, 0, len(existing)+len(latest))
+	indices := make(map[string]int, len(existing)+len(latest))
+	for _, reported := range [][]*event.ExternalResourceInfo{existing, latest} {
+		for _, resource := range reported {
+			if index, ok := indices[resource.ExternalId]; ok {
+				// The latest report of a resource takes precedence.
+				resources[index] = resource
+				continue
+			}
+			indices[resource.ExternalId] = len(resources)
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// mergeMetadata merges the metadata sent in a task execution event update into the existing metadata. Attributes
+// which the latest event doesn't set are kept.
+func mergeMetadata(existing, latest *event.TaskExecutionMetadata) *event.TaskExecutionMetadata {
+	if latest == nil {
+		return existing
+	}
+	if existing == nil {
+		return proto.Clone(latest).(*event.TaskExecutionMetadata)
+	}
+	merged := proto.Clone(existing).(*event.TaskExecutionMetadata)
+	if len(latest.GeneratedName) > 0 {
+		merged.GeneratedName = latest.GeneratedName
+	}
+	if len(latest.PluginIdentifier) > 0 {
+		merged.PluginIdentifier = latest.PluginIdentifier
+	}
+	if latest.InstanceClass != event.TaskExecutionMetadata_DEFAULT {
+		merged.InstanceClass = latest.InstanceClass
+	}
+	// Resource pool allocations describe the current state of the task execution rather than accumulate.
+	if len(latest.ResourcePoolInfo) > 0 {
+		merged.ResourcePoolInfo = latest.ResourcePoolInfo
+	}
+	merged.ExternalResources = mergeExternalResources(merged.ExternalResources, latest.ExternalResources)
+	return merged
+}
+
+// limitExternalResources truncates the external resources of task execution metadata to the most recently reported
+// ones.
+func limitExternalResources(ctx context.Context, metadata *event.TaskExecutionMetadata,
+	maxExternalResources int) *event.TaskExecutionMetadata {
+	if maxExternalResources <= 0 || len(metadata.GetExternalResources()) <= maxExternalResources {
+		return metadata
+	}
+	logger.Warningf(ctx, "Truncating %d external resources of task execution [%s] to the latest %d",
+		len(metadata.ExternalResources), metadata.GeneratedName, maxExternalResources)
+	metadata.ExternalResources = metadata.ExternalResources[len(metadata.ExternalResources)-maxExternalResources:]
+	return metadata
+}
+
+// limitCustomInfo keeps the existing custom info when the merged custom info exceeds the size limit, so that oversized
+// updates from a plugin don't bloat the task execution closure.
+func limitCustomInfo(ctx context.Context, existing, merged *_struct.Struct, maxSizeInBytes int64) *_struct.Struct {
+	if maxSizeInBytes <= 0 || merged == nil {
+		return merged
+	}
+	if size := proto.Size(merged); int64(size) > maxSizeInBytes {
+		logger.Warningf(ctx, "Dropping task execution custom info update of %d bytes exceeding the limit of %d bytes",
+			size, maxSizeInBytes)
+		return existing
+	}
+	return merged
+}
+
 func UpdateTaskExecutionModel(ctx context.Context, request *admin.TaskExecutionEventRequest, taskExecutionModel *models.TaskExecution,
-	inlineEventDataPolicy interfaces.InlineEventDataPolicy, storageClient *storage.DataStore) error {
+	inlineEventDataPolicy interfaces.InlineEventDataPolicy, storageClient *storage.DataStore,
+	infoLimits TaskExecutionInfoLimits) error {
 	var taskExecutionClosure admin.TaskExecutionClosure
 	err := proto.Unmarshal(taskExecutionModel.Closure, &taskExecutionClosure)
 	if err != nil {
@@ -270,10 +361,14 @@ func UpdateTaskExecutionModel(ctx context.Context, request *admin.TaskExecutionE
 			return err
 		}
 	}
-	taskExecutionClosure.CustomInfo, err = mergeCustom(taskExecutionClosure.CustomInfo, request.Event.CustomInfo)
+	customInfo, err := mergeCustom(taskExecutionClosure.CustomInfo, request.Event.CustomInfo)
 	if err != nil {
 		return errors.NewFlyteAdminErrorf(codes.Internal, "failed to merge task even custom_info with error: %v", err)
 	}
+	taskExecutionClosure.CustomInfo = limitCustomInfo(
+		ctx, taskExecutionClosure.CustomInfo, customInfo, infoLimits.MaxCustomInfoSizeInBytes)
+	taskExecutionClosure.Metadata = limitExternalResources(ctx,
+		mergeMetadata(taskExecutionClosure.Metadata, request.Event.Metadata), infoLimits.MaxExternalResources)
 	marshaledClosure, err := proto.Marshal(&taskExecutionClosure)
 	if err != nil {
 		return errors.NewFlyteAdminErrorf(
@@ -443,7 +443,7 @@ func TestUpdateTaskExecutionModelRunningToFailed(t *testing.T) {
 	}
 
 	err = UpdateTaskExecutionModel(context.TODO(), failedEventRequest, &existingTaskExecution,
-		interfaces.InlineEventDataPolicyStoreInline, commonMocks.GetMockStorageClient())
+		interfaces.InlineEventDataPolicyStoreInline, commonMocks.GetMockStorageClient(), TaskExecutionInfoLimits{})
 	assert.Nil(t, err)
 
 	expectedClosure := &admin.TaskExecutionClosure{
@@ -843,3 +843,171 @@ func TestMergeCustoms(t *testing.T) {
 
 	})
 }
+
+func getExternalResources(ids ...string) []*event.ExternalResourceInfo {
+	resources := make([]*event.ExternalResourceInfo, len(ids))
+	for idx, id := range ids {
+		resources[idx] = &event.ExternalResourceInfo{ExternalId: id}
+	}
+	return resources
+}
+
+func TestMergeExternalResources(t *testing.T) {
+	testCases := []struct {
+		name     string
+		existing []*event.ExternalResourceInfo
+		latest   []*event.ExternalResourceInfo
+		expected []*event.ExternalResourceInfo
+	}{
+		{
+			name: "nothing to do",
+		},
+		{
+			name:     "use existing",
+			existing: getExternalResources("a"),
+			expected: getExternalResources("a"),
+		},
+		{
+			name:     "use latest",
+			latest:   getExternalResources("a"),
+			expected: getExternalResources("a"),
+		},
+		{
+			name:     "append new resources",
+			existing: getExternalResources("a", "b"),
+			latest:   getExternalResources("c"),
+			expected: getExternalResources("a", "b", "c"),
+		},
+		{
+			name:     "keep the order of first reports",
+			existing: getExternalResources("a", "b"),
+			latest:   getExternalResources("c", "b", "a"),
+			expected: getExternalResources("a", "b", "c"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := mergeExternalResources(testCase.existing, testCase.latest)
+			assert.Len(t, actual, len(testCase.expected))
+			for idx, expected := range testCase.expected {
+				assert.True(t, proto.Equal(expected, actual[idx]))
+			}
+		})
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	existing := &event.TaskExecutionMetadata{
+		GeneratedName:     "generated",
+		PluginIdentifier:  "spark",
+		ExternalResources: getExternalResources("application"),
+		ResourcePoolInfo: []*event.ResourcePoolInfo{
+			{AllocationToken: "token", Namespace: "pool"},
+		},
+	}
+	t.Run("nothing to do", func(t *testing.T) {
+		assert.Nil(t, mergeMetadata(nil, nil))
+		assert.True(t, proto.Equal(existing, mergeMetadata(existing, nil)))
+	})
+	t.Run("use latest", func(t *testing.T) {
+		assert.True(t, proto.Equal(existing, mergeMetadata(nil, existing)))
+	})
+	t.Run("merge", func(t *testing.T) {
+		merged := mergeMetadata(existing, &event.TaskExecutionMetadata{
+			InstanceClass:     event.TaskExecutionMetadata_INTERRUPTIBLE,
+			ExternalResources: getExternalResources("driver"),
+		})
+		assert.True(t, proto.Equal(&event.TaskExecutionMetadata{
+			GeneratedName:     "generated",
+			PluginIdentifier:  "spark",
+			InstanceClass:     event.TaskExecutionMetadata_INTERRUPTIBLE,
+			ExternalResources: getExternalResources("application", "driver"),
+			ResourcePoolInfo: []*event.ResourcePoolInfo{
+				{AllocationToken: "token", Namespace: "pool"},
+			},
+		}, merged))
+		// The existing metadata is left as is.
+		assert.Len(t, existing.ExternalResources, 1)
+	})
+}
+
+func TestUpdateTaskExecutionModel_MergesMetadata(t *testing.T) {
+	limits := TaskExecutionInfoLimits{
+		MaxExternalResources:     3,
+		MaxCustomInfoSizeInBytes: 64,
+	}
+	getRequest := func(retryAttempt uint32, occurredAt time.Time, metadata *event.TaskExecutionMetadata,
+		logs []*core.TaskLog, customInfo map[string]string) *admin.TaskExecutionEventRequest {
+		occurredAtProto, _ := ptypes.TimestampProto(occurredAt)
+		return &admin.TaskExecutionEventRequest{
+			Event: &event.TaskExecutionEvent{
+				TaskId:                sampleTaskID,
+				ParentNodeExecutionId: sampleNodeExecID,
+				Phase:                 core.TaskExecution_RUNNING,
+				RetryAttempt:          retryAttempt,
+				OccurredAt:            occurredAtProto,
+				Metadata:              metadata,
+				Logs:                  logs,
+				CustomInfo:            transformMapToStructPB(t, customInfo),
+			},
+		}
+	}
+	getClosure := func(taskExecutionModel *models.TaskExecution) *admin.TaskExecutionClosure {
+		closure := &admin.TaskExecutionClosure{}
+		assert.NoError(t, proto.Unmarshal(taskExecutionModel.Closure, closure))
+		return closure
+	}
+
+	taskExecutionModel, err := CreateTaskExecutionModel(context.TODO(), CreateTaskExecutionModelInput{
+		Request: getRequest(0, taskEventOccurredAt, &event.TaskExecutionMetadata{
+			GeneratedName:     "attempt-0",
+			ExternalResources: getExternalResources("application"),
+		}, []*core.TaskLog{{Name: "Spark UI", Uri: "http://driver-0"}}, map[string]string{"state": "pending"}),
+		InfoLimits: limits,
+	})
+	assert.NoError(t, err)
+
+	// Successive events for the same attempt add to the recorded external resources and refresh the log links.
+	err = UpdateTaskExecutionModel(context.TODO(), getRequest(0, taskEventOccurredAt.Add(time.Minute),
+		&event.TaskExecutionMetadata{
+			ExternalResources: getExternalResources("driver", "application"),
+		}, []*core.TaskLog{{Name: "Spark UI", Uri: "http://driver-1"}}, map[string]string{"state": "running"}),
+		taskExecutionModel, interfaces.InlineEventDataPolicyStoreInline, nil, limits)
+	assert.NoError(t, err)
+	closure := getClosure(taskExecutionModel)
+	assert.True(t, proto.Equal(&event.TaskExecutionMetadata{
+		GeneratedName:     "attempt-0",
+		ExternalResources: getExternalResources("application", "driver"),
+	}, closure.Metadata))
+	assert.Len(t, closure.Logs, 1)
+	assert.Equal(t, "http://driver-1", closure.Logs[0].Uri)
+	assert.Equal(t, "running", closure.CustomInfo.Fields["state"].GetStringValue())
+
+	// Only the latest external resources are kept, and oversized custom info updates are dropped.
+	err = UpdateTaskExecutionModel(context.TODO(), getRequest(0, taskEventOccurredAt.Add(2*time.Minute),
+		&event.TaskExecutionMetadata{
+			ExternalResources: getExternalResources("executor-1", "executor-2"),
+		}, nil, map[string]string{"state": "running", "stacktrace": string(make([]byte, 64))}),
+		taskExecutionModel, interfaces.InlineEventDataPolicyStoreInline, nil, limits)
+	assert.NoError(t, err)
+	closure = getClosure(taskExecutionModel)
+	assert.True(t, proto.Equal(&event.TaskExecutionMetadata{
+		GeneratedName:     "attempt-0",
+		ExternalResources: getExternalResources("driver", "executor-1", "executor-2"),
+	}, closure.Metadata))
+	assert.Len(t, closure.CustomInfo.Fields, 1)
+
+	// A retry is recorded as a new task execution, which doesn't inherit the resources of previous attempts.
+	retryModel, err := CreateTaskExecutionModel(context.TODO(), CreateTaskExecutionModelInput{
+		Request: getRequest(1, taskEventOccurredAt.Add(3*time.Minute), &event.TaskExecutionMetadata{
+			GeneratedName:     "attempt-1",
+			ExternalResources: getExternalResources("application-retry"),
+		}, nil, map[string]string{"state": "pending"}),
+		InfoLimits: limits,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&event.TaskExecutionMetadata{
+		GeneratedName:     "attempt-1",
+		ExternalResources: getExternalResources("application-retry"),
+	}, getClosure(retryModel).Metadata))
+}
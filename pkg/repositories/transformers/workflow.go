@@ -12,7 +12,7 @@ import (
 
 // Transforms a WorkflowCreateRequest to a workflow model
 func CreateWorkflowModel(request admin.WorkflowCreateRequest, remoteClosureIdentifier string,
-	digest []byte) (models.Workflow, error) {
+	digest, specDigest []byte) (models.Workflow, error) {
 	var typedInterface []byte
 	if request.Spec != nil && request.Spec.Template != nil && request.Spec.Template.Interface != nil {
 		serializedTypedInterface, err := proto.Marshal(request.Spec.Template.Interface)
@@ -31,6 +31,7 @@ func CreateWorkflowModel(request admin.WorkflowCreateRequest, remoteClosureIdent
 		TypedInterface:          typedInterface,
 		RemoteClosureIdentifier: remoteClosureIdentifier,
 		Digest:                  digest,
+		SpecDigest:              specDigest,
 	}, nil
 }
 
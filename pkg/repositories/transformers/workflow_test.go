@@ -18,10 +18,11 @@ import (
 const remoteClosureIdentifier = "remote closure id"
 
 var workflowDigest = []byte("workflow digest")
+var workflowSpecDigest = []byte("workflow spec digest")
 
 func TestCreateWorkflow(t *testing.T) {
 	request := testutils.GetWorkflowRequest()
-	workflow, err := CreateWorkflowModel(request, remoteClosureIdentifier, workflowDigest)
+	workflow, err := CreateWorkflowModel(request, remoteClosureIdentifier, workflowDigest, workflowSpecDigest)
 	assert.NoError(t, err)
 	assert.Equal(t, "project", workflow.Project)
 	assert.Equal(t, "domain", workflow.Domain)
@@ -31,12 +32,13 @@ func TestCreateWorkflow(t *testing.T) {
 	assert.Equal(t, expectedTypedInterface, workflow.TypedInterface)
 	assert.Equal(t, remoteClosureIdentifier, workflow.RemoteClosureIdentifier)
 	assert.Equal(t, workflowDigest, workflow.Digest)
+	assert.Equal(t, workflowSpecDigest, workflow.SpecDigest)
 }
 
 func TestCreateWorkflowEmptyInterface(t *testing.T) {
 	request := testutils.GetWorkflowRequest()
 	request.Spec.Template.Interface = nil
-	workflow, err := CreateWorkflowModel(request, remoteClosureIdentifier, workflowDigest)
+	workflow, err := CreateWorkflowModel(request, remoteClosureIdentifier, workflowDigest, workflowSpecDigest)
 	assert.NoError(t, err)
 	assert.Equal(t, "project", workflow.Project)
 	assert.Equal(t, "domain", workflow.Domain)
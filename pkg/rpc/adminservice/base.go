@@ -4,47 +4,93 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"sync"
+	"time"
 
 	eventWriter "github.com/flyteorg/flyteadmin/pkg/async/events/implementations"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
+	"github.com/flyteorg/flytepropeller/pkg/controller/nodes/task/secretmanager"
 
 	"github.com/flyteorg/flyteadmin/pkg/manager/impl/resources"
 
 	"github.com/flyteorg/flyteadmin/pkg/async/notifications"
 	"github.com/flyteorg/flyteadmin/pkg/async/schedule"
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/data"
 	executionCluster "github.com/flyteorg/flyteadmin/pkg/executioncluster/impl"
 	manager "github.com/flyteorg/flyteadmin/pkg/manager/impl"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/executions"
+	"github.com/flyteorg/flyteadmin/pkg/manager/impl/util"
 	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	repositoryConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/runtime"
+	"github.com/flyteorg/flyteadmin/pkg/server"
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine"
 	workflowengineImpl "github.com/flyteorg/flyteadmin/pkg/workflowengine/impl"
+	workflowengineInterfaces "github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
 	"github.com/flyteorg/flytestdlib/logger"
-	"github.com/flyteorg/flytestdlib/profutils"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/golang/protobuf/proto"
-	gormLogger "gorm.io/gorm/logger"
 )
 
 type AdminService struct {
 	service.UnimplementedAdminServiceServer
-	TaskManager          interfaces.TaskInterface
-	WorkflowManager      interfaces.WorkflowInterface
-	LaunchPlanManager    interfaces.LaunchPlanInterface
-	ExecutionManager     interfaces.ExecutionInterface
-	NodeExecutionManager interfaces.NodeExecutionInterface
-	TaskExecutionManager interfaces.TaskExecutionInterface
-	ProjectManager       interfaces.ProjectInterface
-	ResourceManager      interfaces.ResourceInterface
-	NamedEntityManager   interfaces.NamedEntityInterface
-	VersionManager       interfaces.VersionInterface
-	Metrics              AdminMetrics
+	TaskManager              interfaces.TaskInterface
+	WorkflowManager          interfaces.WorkflowInterface
+	LaunchPlanManager        interfaces.LaunchPlanInterface
+	ExecutionManager         interfaces.ExecutionInterface
+	NodeExecutionManager     interfaces.NodeExecutionInterface
+	TaskExecutionManager     interfaces.TaskExecutionInterface
+	ProjectManager           interfaces.ProjectInterface
+	ResourceManager          interfaces.ResourceInterface
+	NamedEntityManager       interfaces.NamedEntityInterface
+	DescriptionEntityManager interfaces.DescriptionEntityInterface
+	VersionManager           interfaces.VersionInterface
+	// Served over http, there is no data proxy rpc in the admin service definition.
+	DataProxyManager interfaces.DataProxyInterface
+	// Served over http, there is no statistics rpc in the admin service definition.
+	StatisticsManager interfaces.StatisticsInterface
+	// Served over http, there is no schedule checkpoint rpc in the admin service definition.
+	ScheduleCheckpointManager interfaces.ScheduleCheckpointInterface
+	// Streamed over http, there is no execution watch rpc in the admin service definition.
+	ExecutionWatchManager interfaces.ExecutionWatchInterface
+	Metrics               AdminMetrics
+	// Dependency checks backing the readiness endpoint, keyed by name.
+	ReadinessChecks map[string]server.ReadinessCheck
+
+	// Stops the jobs running in the background of the service, see Stop.
+	stopBackgroundJobs context.CancelFunc
+	backgroundJobs     *sync.WaitGroup
+	// Launches the workflows of executions queued by the execution manager, nil unless they're launched in the
+	// background.
+	launcher *executions.Launcher
 }
 
+// How long Stop waits for the executions still queued to be launched before failing them.
+const launcherStopTimeout = 30 * time.Second
+
+// Stop stops the jobs running in the background of the service and waits for them to return. It's called once the
+// service no longer serves requests. The executions queued by the service are launched first, while the clusters they
+// launch into are still watched.
+func (m *AdminService) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), launcherStopTimeout)
+	defer cancel()
+	m.launcher.Stop(ctx)
+	if m.stopBackgroundJobs == nil {
+		return
+	}
+	m.stopBackgroundJobs()
+	m.backgroundJobs.Wait()
+}
+
+const (
+	ReadinessCheckDatabase = "database"
+	ReadinessCheckCluster  = "cluster"
+)
+
 // Intercepts all admin requests to handle panics during execution.
 func (m *AdminService) interceptPanic(ctx context.Context, request proto.Message) {
 	err := recover()
@@ -52,8 +98,10 @@ func (m *AdminService) interceptPanic(ctx context.Context, request proto.Message
 		return
 	}
 
-	m.Metrics.PanicCounter.Inc()
-	logger.Fatalf(ctx, "panic-ed for request: [%+v] with err: %v with Stack: %v", request, err, string(debug.Stack()))
+	logger.Errorf(ctx, "panic-ed for request: [%+v] with err: %v with Stack: %v", request, err, string(debug.Stack()))
+	// Propagate to the server's recovery interceptor which counts the panic and surfaces the failure to the caller as an
+	// Internal error.
+	panic(err)
 }
 
 const defaultRetries = 3
@@ -73,26 +121,21 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		}
 	}()
 
-	dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
-	dbLogLevel := gormLogger.Silent
-	if dbConfigValues.Debug {
-		dbLogLevel = gormLogger.Info
+	if err := common.ValidateNamespaceTemplate(configuration.NamespaceMappingConfiguration().GetNamespaceTemplate()); err != nil {
+		panic(err)
 	}
-	dbConfig := repositoryConfig.DbConfig{
-		BaseConfig: repositoryConfig.BaseConfig{
-			LogLevel: dbLogLevel,
-		},
-		Host:         dbConfigValues.Host,
-		Port:         dbConfigValues.Port,
-		DbName:       dbConfigValues.DbName,
-		User:         dbConfigValues.User,
-		Password:     dbConfigValues.Password,
-		ExtraOptions: dbConfigValues.ExtraOptions,
+
+	dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
+	dbConfig := repositoryConfig.NewDbConfig(dbConfigValues)
+	repoConfig, err := repositories.GetRepoConfig(dbConfig)
+	if err != nil {
+		panic(err)
 	}
-	db := repositories.GetRepository(
-		repositories.POSTGRES, dbConfig, adminScope.NewSubScope("database"))
+	db := repositories.GetRepository(repoConfig, dbConfig, adminScope.NewSubScope("database"))
 	storeConfig := storage.GetConfig()
+	backgroundCtx, stopBackgroundJobs := context.WithCancel(context.Background())
 	execCluster := executionCluster.GetExecutionCluster(
+		backgroundCtx,
 		adminScope.NewSubScope("executor").NewSubScope("cluster"),
 		kubeConfig,
 		master,
@@ -100,7 +143,9 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		db)
 	workflowBuilder := workflowengineImpl.NewFlyteWorkflowBuilder(
 		adminScope.NewSubScope("builder").NewSubScope("flytepropeller"))
-	workflowExecutor := workflowengineImpl.NewK8sWorkflowExecutor(execCluster, workflowBuilder)
+	workflowExecutor := workflowengineImpl.NewK8sWorkflowExecutor(execCluster, workflowBuilder,
+		configuration.ClusterConfiguration().GetWorkflowCreateRetryConfig(),
+		configuration.ClusterConfiguration().GetServiceAccountCheckConfig())
 	logger.Info(context.Background(), "Successfully created a workflow executor engine")
 	workflowengine.GetRegistry().RegisterDefault(workflowExecutor)
 
@@ -110,9 +155,17 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		panic(err)
 	}
 
-	publisher := notifications.NewNotificationsPublisher(*configuration.ApplicationConfiguration().GetNotificationsConfig(), adminScope)
-	processor := notifications.NewNotificationsProcessor(*configuration.ApplicationConfiguration().GetNotificationsConfig(), adminScope)
-	eventPublisher := notifications.NewEventsPublisher(*configuration.ApplicationConfiguration().GetExternalEventsConfig(), adminScope)
+	secretManager := secretmanager.NewFileEnvSecretManager(secretmanager.GetConfig())
+	publisher := notifications.NewNotificationsPublisher(*configuration.ApplicationConfiguration().GetNotificationsConfig(),
+		secretManager, adminScope)
+	publisher = notifications.NewSlackNotificationsPublisher(*configuration.ApplicationConfiguration().GetNotificationsConfig(),
+		secretManager, publisher, adminScope)
+	publisher = notifications.NewWebhookNotificationsPublisher(*configuration.ApplicationConfiguration().GetNotificationsConfig(),
+		secretManager, publisher, adminScope)
+	processor := notifications.NewNotificationsProcessor(*configuration.ApplicationConfiguration().GetNotificationsConfig(),
+		secretManager, adminScope)
+	eventPublisher := notifications.NewEventsPublisher(*configuration.ApplicationConfiguration().GetExternalEventsConfig(),
+		secretManager, adminScope)
 	go func() {
 		logger.Info(context.Background(), "Started processing notifications.")
 		processor.StartProcessing()
@@ -127,8 +180,11 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 	})
 
 	eventScheduler := workflowScheduler.GetEventScheduler()
+	// Shared by the managers which look launch plans up to create executions and which activate or deactivate them.
+	lookupCache := util.NewLookupCache(applicationConfiguration.GetExecutionLookupCacheConfig(),
+		adminScope.NewSubScope("execution_lookup_cache"))
 	launchPlanManager := manager.NewLaunchPlanManager(
-		db, configuration, eventScheduler, adminScope.NewSubScope("launch_plan_manager"))
+		db, configuration, eventScheduler, adminScope.NewSubScope("launch_plan_manager"), lookupCache)
 
 	// Configure admin-specific remote data handler (separate from storage)
 	remoteDataConfig := configuration.ApplicationConfiguration().GetRemoteDataConfig()
@@ -141,21 +197,58 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		RemoteDataStoreClient:    dataStorageClient,
 	}).GetRemoteURLInterface()
 
+	// Shared by the managers which read the closures of workflows, so that each closure is read from storage and held in
+	// memory once.
+	workflowClosures := util.NewWorkflowClosureCache(dataStorageClient,
+		applicationConfiguration.GetWorkflowClosureCacheConfig())
 	workflowManager := manager.NewWorkflowManager(
 		db, configuration, workflowengineImpl.NewCompiler(), dataStorageClient, applicationConfiguration.GetMetadataStoragePrefix(),
-		adminScope.NewSubScope("workflow_manager"))
+		adminScope.NewSubScope("workflow_manager"), workflowClosures)
 	namedEntityManager := manager.NewNamedEntityManager(db, configuration, adminScope.NewSubScope("named_entity_manager"))
 
-	executionEventWriter := eventWriter.NewWorkflowExecutionEventWriter(db, applicationConfiguration.GetAsyncEventsBufferSize())
+	// Events are published to the watchers of their execution as they're written, which only reach the watchers
+	// connected to this replica.
+	executionWatchHub := eventWriter.NewExecutionWatchHub(applicationConfiguration.GetExecutionWatchConfig().BufferSize,
+		adminScope.NewSubScope("execution_watch"))
+	executionEventWriter := eventWriter.NewWatchedWorkflowExecutionEventWriter(
+		eventWriter.NewWorkflowExecutionEventWriter(db, applicationConfiguration.GetAsyncEventsBufferSize()),
+		executionWatchHub)
 	go func() {
 		executionEventWriter.Run()
 	}()
 
+	executionManagerScope := adminScope.NewSubScope("execution_manager")
+	asyncLaunchConfig := applicationConfiguration.GetAsyncExecutionLaunchConfig()
+	launcher := executions.NewLauncher(asyncLaunchConfig, executionManagerScope.NewSubScope("execution_launcher"))
 	executionManager := manager.NewExecutionManager(db, configuration, dataStorageClient,
-		adminScope.NewSubScope("execution_manager"), adminScope.NewSubScope("user_execution_metrics"),
-		publisher, urlData, workflowManager, namedEntityManager, eventPublisher, executionEventWriter)
+		executionManagerScope, adminScope.NewSubScope("user_execution_metrics"),
+		publisher, urlData, workflowManager, namedEntityManager, eventPublisher, executionEventWriter, lookupCache,
+		workflowClosures, launcher)
 	versionManager := manager.NewVersionManager()
 
+	backgroundJobs := &sync.WaitGroup{}
+	// Executions queued by replicas which stopped before launching them are only ever failed by the replicas still
+	// running, hence this runs for as long as executions are launched in the background.
+	if launcher != nil && asyncLaunchConfig.InterruptedAfter.Duration > 0 {
+		backgroundJobs.Add(1)
+		go func() {
+			defer backgroundJobs.Done()
+			logger.Info(backgroundCtx, "Starting to fail executions whose launch was interrupted")
+			executions.RecoverInterruptedLaunches(backgroundCtx, executionManager,
+				asyncLaunchConfig.InterruptedAfter.Duration)
+		}()
+	}
+	if retentionConfig := applicationConfiguration.GetExecutionRetentionConfig(); retentionConfig.Enabled {
+		retentionJob := executions.NewRetentionJob(executionManager, db.JobLeaseRepo(), retentionConfig,
+			*configuration.ApplicationConfiguration().GetDomainsConfig(), adminScope.NewSubScope("execution_retention"))
+		backgroundJobs.Add(1)
+		go func() {
+			defer backgroundJobs.Done()
+			logger.Info(backgroundCtx, "Starting the execution retention job")
+			retentionJob.Run(backgroundCtx)
+		}()
+	}
+
 	scheduledWorkflowExecutor := workflowScheduler.GetWorkflowExecutor(executionManager, launchPlanManager)
 	logger.Info(context.Background(), "Successfully initialized a new scheduled workflow executor")
 	go func() {
@@ -163,20 +256,27 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		scheduledWorkflowExecutor.Run()
 	}()
 
-	// Serve profiling endpoints.
-	go func() {
-		err := profutils.StartProfilingServerWithDefaultHandlers(
-			context.Background(), applicationConfiguration.GetProfilerPort(), nil)
-		if err != nil {
-			logger.Panicf(context.Background(), "Failed to Start profiling and Metrics server. Error, %v", err)
-		}
-	}()
-
-	nodeExecutionEventWriter := eventWriter.NewNodeExecutionEventWriter(db, applicationConfiguration.GetAsyncEventsBufferSize())
+	nodeExecutionEventWriter := eventWriter.NewWatchedNodeExecutionEventWriter(
+		eventWriter.NewNodeExecutionEventWriter(db, applicationConfiguration.GetAsyncEventsBufferSize()),
+		executionWatchHub)
 	go func() {
 		nodeExecutionEventWriter.Run()
 	}()
 
+	nodeExecutionManager := manager.NewNodeExecutionManager(db, configuration,
+		applicationConfiguration.GetMetadataStoragePrefix(), dataStorageClient,
+		adminScope.NewSubScope("node_execution_manager"), urlData, eventPublisher, nodeExecutionEventWriter)
+
+	readinessChecks := map[string]server.ReadinessCheck{
+		ReadinessCheckDatabase: db.HealthCheck,
+		ReadinessCheckCluster: func(ctx context.Context) error {
+			if checker, ok := workflowengine.GetRegistry().GetExecutor().(workflowengineInterfaces.HealthChecker); ok {
+				return checker.HealthCheck(ctx)
+			}
+			return nil
+		},
+	}
+
 	logger.Info(context.Background(), "Initializing a new AdminService")
 	return &AdminService{
 		TaskManager: manager.NewTaskManager(db, configuration, workflowengineImpl.NewCompiler(),
@@ -185,13 +285,22 @@ func NewAdminServer(kubeConfig, master string) *AdminService {
 		LaunchPlanManager:  launchPlanManager,
 		ExecutionManager:   executionManager,
 		NamedEntityManager: namedEntityManager,
-		VersionManager:     versionManager,
-		NodeExecutionManager: manager.NewNodeExecutionManager(db, configuration, applicationConfiguration.GetMetadataStoragePrefix(), dataStorageClient,
-			adminScope.NewSubScope("node_execution_manager"), urlData, eventPublisher, nodeExecutionEventWriter),
+		DescriptionEntityManager: manager.NewDescriptionEntityManager(db, configuration,
+			adminScope.NewSubScope("description_entity_manager")),
+		VersionManager:       versionManager,
+		NodeExecutionManager: nodeExecutionManager,
 		TaskExecutionManager: manager.NewTaskExecutionManager(db, configuration, dataStorageClient,
 			adminScope.NewSubScope("task_execution_manager"), urlData, eventPublisher),
-		ProjectManager:  manager.NewProjectManager(db, configuration),
-		ResourceManager: resources.NewResourceManager(db, configuration.ApplicationConfiguration()),
-		Metrics:         InitMetrics(adminScope),
+		ProjectManager:            manager.NewProjectManager(db, configuration),
+		ResourceManager:           resources.NewResourceManager(db, configuration),
+		DataProxyManager:          manager.NewDataProxyManager(db, configuration, dataStorageClient, urlData),
+		StatisticsManager:         manager.NewStatisticsManager(db, configuration),
+		ScheduleCheckpointManager: manager.NewScheduleCheckpointManager(db),
+		ExecutionWatchManager:     manager.NewExecutionWatchManager(executionManager, nodeExecutionManager, executionWatchHub),
+		Metrics:                   InitMetrics(adminScope),
+		ReadinessChecks:           readinessChecks,
+		stopBackgroundJobs:        stopBackgroundJobs,
+		backgroundJobs:            backgroundJobs,
+		launcher:                  launcher,
 	}
 }
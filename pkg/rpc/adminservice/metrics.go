@@ -4,7 +4,6 @@ package adminservice
 import (
 	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice/util"
 	"github.com/flyteorg/flytestdlib/promutils"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 type executionEndpointMetrics struct {
@@ -94,9 +93,14 @@ type workflowEndpointMetrics struct {
 	listIds util.RequestMetrics
 }
 
+type versionEndpointMetrics struct {
+	scope promutils.Scope
+
+	get util.RequestMetrics
+}
+
 type AdminMetrics struct {
-	Scope        promutils.Scope
-	PanicCounter prometheus.Counter
+	Scope promutils.Scope
 
 	executionEndpointMetrics               executionEndpointMetrics
 	launchPlanEndpointMetrics              launchPlanEndpointMetrics
@@ -110,13 +114,12 @@ type AdminMetrics struct {
 	taskEndpointMetrics                    taskEndpointMetrics
 	taskExecutionEndpointMetrics           taskExecutionEndpointMetrics
 	workflowEndpointMetrics                workflowEndpointMetrics
+	versionEndpointMetrics                 versionEndpointMetrics
 }
 
 func InitMetrics(adminScope promutils.Scope) AdminMetrics {
 	return AdminMetrics{
 		Scope: adminScope,
-		PanicCounter: adminScope.MustNewCounter("handler_panic",
-			"panics encountered while handling requests to the admin service"),
 
 		executionEndpointMetrics: executionEndpointMetrics{
 			scope:       adminScope,
@@ -202,5 +205,9 @@ func InitMetrics(adminScope promutils.Scope) AdminMetrics {
 			list:    util.NewRequestMetrics(adminScope, "list_workflow"),
 			listIds: util.NewRequestMetrics(adminScope, "list_workflow_ids"),
 		},
+		versionEndpointMetrics: versionEndpointMetrics{
+			scope: adminScope,
+			get:   util.NewRequestMetrics(adminScope, "get_version"),
+		},
 	}
 }
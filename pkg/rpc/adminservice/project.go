@@ -73,7 +73,7 @@ func (m *AdminService) UpdateProject(ctx context.Context, request *admin.Project
 	}
 	var response *admin.ProjectUpdateResponse
 	var err error
-	m.Metrics.projectEndpointMetrics.register.Time(func() {
+	m.Metrics.projectEndpointMetrics.update.Time(func() {
 		response, err = m.ProjectManager.UpdateProject(ctx, *request)
 	})
 	audit.NewLogBuilder().WithAuthenticatedCtx(ctx).WithRequest(
@@ -88,5 +88,6 @@ func (m *AdminService) UpdateProject(ctx context.Context, request *admin.Project
 		return nil, util.TransformAndRecordError(err, &m.Metrics.projectEndpointMetrics.update)
 	}
 
+	m.Metrics.projectEndpointMetrics.update.Success()
 	return response, nil
 }
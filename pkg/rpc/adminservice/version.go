@@ -3,15 +3,21 @@ package adminservice
 import (
 	"context"
 
+	"github.com/flyteorg/flyteadmin/pkg/rpc/adminservice/util"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
 
 func (m *AdminService) GetVersion(ctx context.Context, request *admin.GetVersionRequest) (*admin.GetVersionResponse, error) {
-
 	defer m.interceptPanic(ctx, request)
-	response, err := m.VersionManager.GetVersion(ctx, request)
+	var response *admin.GetVersionResponse
+	var err error
+	m.Metrics.versionEndpointMetrics.get.Time(func() {
+		response, err = m.VersionManager.GetVersion(ctx, request)
+	})
 	if err != nil {
-		return nil, err
+		return nil, util.TransformAndRecordError(err, &m.Metrics.versionEndpointMetrics.get)
 	}
+
+	m.Metrics.versionEndpointMetrics.get.Success()
 	return response, nil
 }
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
@@ -26,19 +27,65 @@ const KB = 1024
 const MB = KB * KB
 
 var databaseConfig = config.MustRegisterSection(database, &interfaces.DbConfigSection{
-	Port:         5432,
-	User:         postgres,
-	Host:         postgres,
-	DbName:       postgres,
-	ExtraOptions: "sslmode=disable",
+	Port:              5432,
+	User:              postgres,
+	Host:              postgres,
+	DbName:            postgres,
+	ExtraOptions:      "sslmode=disable",
+	PoolStatsInterval: config.Duration{Duration: 10 * time.Second},
 })
 var flyteAdminConfig = config.MustRegisterSection(flyteAdmin, &interfaces.ApplicationConfig{
-	ProfilerPort:          metricPort,
-	MetricsScope:          "flyte:",
-	MetadataStoragePrefix: []string{"metadata", "admin"},
-	EventVersion:          2,
-	AsyncEventsBufferSize: 100,
-	MaxParallelism:        25,
+	ProfilerPort:                  metricPort,
+	MetricsScope:                  "flyte:",
+	MetadataStoragePrefix:         []string{"metadata", "admin"},
+	EventVersion:                  2,
+	AsyncEventsBufferSize:         100,
+	MaxParallelism:                25,
+	MaxExecutionInputsSizeInBytes: 10 * MB,
+	MaxInputLiteralSizeInBytes:    2 * MB,
+	MaxTaskExternalResources:      100,
+	MaxTaskCustomInfoSizeInBytes:  256 * KB,
+	CountCacheTTL:                 config.Duration{Duration: 30 * time.Second},
+	ExecutionRetention: interfaces.ExecutionRetentionConfig{
+		BatchSize: 100,
+		Interval:  config.Duration{Duration: time.Hour},
+	},
+	ActiveExecutionQuota: interfaces.ActiveExecutionQuotaConfig{
+		CountCacheTTL: config.Duration{Duration: 5 * time.Second},
+	},
+	WorkflowClosureCache: interfaces.WorkflowClosureCacheConfig{
+		MaxSizeInBytes:  100 * MB,
+		FetchRetries:    2,
+		FetchRetryDelay: config.Duration{Duration: 100 * time.Millisecond},
+	},
+	ExecutionLookupCache: interfaces.LookupCacheConfig{
+		MaxEntries:     1000,
+		MaxSizeInBytes: 50 * MB,
+		TTL:            config.Duration{Duration: 10 * time.Minute},
+	},
+	MaxNodeExecutionTreeDepth: 10,
+	NodeExecutionDataBatch: interfaces.NodeExecutionDataBatchConfig{
+		MaxBatchSize: 500,
+		Concurrency:  20,
+	},
+	AsyncExecutionLaunch: interfaces.AsyncExecutionLaunchConfig{
+		Workers:          10,
+		QueueSize:        1000,
+		Retries:          3,
+		RetryDelay:       config.Duration{Duration: time.Second},
+		InterruptedAfter: config.Duration{Duration: time.Hour},
+	},
+	ExecutionStatistics: interfaces.ExecutionStatisticsConfig{
+		MaxBuckets: 1000,
+		CacheSize:  100,
+		CacheTTL:   config.Duration{Duration: time.Minute},
+	},
+	ExecutionMetrics: interfaces.ExecutionMetricsConfig{
+		MaxLabeledProjects: 100,
+	},
+	ExecutionWatch: interfaces.ExecutionWatchConfig{
+		BufferSize: 100,
+	},
 })
 
 var schedulerConfig = config.MustRegisterSection(scheduler, &interfaces.SchedulerConfig{
@@ -46,14 +93,25 @@ var schedulerConfig = config.MustRegisterSection(scheduler, &interfaces.Schedule
 	EventSchedulerConfig: interfaces.EventSchedulerConfig{
 		Scheme:               common.Local,
 		FlyteSchedulerConfig: &interfaces.FlyteSchedulerConfig{},
+		CatchUpPolicy:        interfaces.CatchUpPolicyAll,
+		OverlapPolicy:        interfaces.ScheduleOverlapPolicyAllow,
 	},
 	WorkflowExecutorConfig: interfaces.WorkflowExecutorConfig{
 		Scheme: common.Local,
+		CatchUpThreshold: config.Duration{
+			Duration: 5 * time.Minute,
+		},
 		FlyteWorkflowExecutorConfig: &interfaces.FlyteWorkflowExecutorConfig{
 			AdminRateLimit: &interfaces.AdminRateLimit{
 				Tps:   100,
 				Burst: 10,
 			},
+			ClaimTimeout: config.Duration{
+				Duration: 10 * time.Minute,
+			},
+			ClaimRetention: config.Duration{
+				Duration: 7 * 24 * time.Hour,
+			},
 		},
 	},
 })
@@ -62,11 +120,43 @@ var remoteDataConfig = config.MustRegisterSection(remoteData, &interfaces.Remote
 	MaxSizeInBytes:        2 * MB,
 	InlineEventDataPolicy: interfaces.InlineEventDataPolicyOffload,
 	SignedURL: interfaces.SignedURL{
-		Enabled: false,
+		Enabled:         false,
+		DurationMinutes: 60,
+	},
+	Upload: interfaces.DataUploadConfig{
+		StoragePrefix:  "uploads",
+		MaxSizeInBytes: 100 * MB,
+		MaxExpiresIn:   config.Duration{Duration: time.Hour},
 	},
 })
 var notificationsConfig = config.MustRegisterSection(notifications, &interfaces.NotificationsConfig{
 	Type: common.Local,
+	NotificationsLocalConfig: interfaces.NotificationsLocalConfig{
+		QueueSize: 1000,
+		Workers:   4,
+	},
+	NotificationsEmailerConfig: interfaces.NotificationsEmailerConfig{
+		Templates: interfaces.EmailTemplatesConfig{
+			MaxErrorLength: 1000,
+		},
+	},
+	NotificationsSlackConfig: interfaces.NotificationsSlackConfig{
+		Message:      "Execution {{ project }}/{{ domain }}/{{ name }} has {{ phase }}.{{ error }}",
+		MaxAttempts:  5,
+		RetryBackoff: config.Duration{Duration: time.Second},
+		Workers:      10,
+		QueueSize:    1000,
+	},
+	NotificationsWebhookConfig: interfaces.NotificationsWebhookConfig{
+		MaxAttempts:  5,
+		RetryBackoff: config.Duration{Duration: time.Second},
+		Workers:      10,
+		QueueSize:    1000,
+	},
+	NotificationsRateLimitConfig: interfaces.NotificationsRateLimitConfig{
+		Window:    config.Duration{Duration: time.Hour},
+		CacheSize: 10000,
+	},
 })
 var domainsConfig = config.MustRegisterSection(domains, &interfaces.DomainsConfig{
 	{
@@ -83,7 +173,8 @@ var domainsConfig = config.MustRegisterSection(domains, &interfaces.DomainsConfi
 	},
 })
 var externalEventsConfig = config.MustRegisterSection(externalEvents, &interfaces.ExternalEventsConfig{
-	Type: common.Local,
+	Type:     common.Local,
+	Encoding: "proto",
 })
 
 // Implementation of an interfaces.ApplicationConfiguration
@@ -107,13 +198,20 @@ func (p *ApplicationConfigurationProvider) GetDbConfig() interfaces.DbConfig {
 		password = strings.TrimSpace(string(passwordVal))
 	}
 	return interfaces.DbConfig{
-		Host:         dbConfigSection.Host,
-		Port:         dbConfigSection.Port,
-		DbName:       dbConfigSection.DbName,
-		User:         dbConfigSection.User,
-		Password:     password,
-		ExtraOptions: dbConfigSection.ExtraOptions,
-		Debug:        dbConfigSection.Debug,
+		Dialect:           dbConfigSection.Dialect,
+		Host:              dbConfigSection.Host,
+		Port:              dbConfigSection.Port,
+		DbName:            dbConfigSection.DbName,
+		User:              dbConfigSection.User,
+		Password:          password,
+		ExtraOptions:      dbConfigSection.ExtraOptions,
+		Debug:             dbConfigSection.Debug,
+		ConnectionPool:    dbConfigSection.ConnectionPool,
+		ReadReplica:       dbConfigSection.ReadReplica,
+		StatementTimeout:  dbConfigSection.StatementTimeout.Duration,
+		LockTimeout:       dbConfigSection.LockTimeout.Duration,
+		PoolStatsInterval: dbConfigSection.PoolStatsInterval.Duration,
+		SQLite:            dbConfigSection.SQLite,
 	}
 }
 
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 
@@ -12,7 +13,17 @@ import (
 
 const clustersKey = "clusters"
 
-var clusterConfig = config.MustRegisterSection(clustersKey, &interfaces.Clusters{})
+var clusterConfig = config.MustRegisterSection(clustersKey, &interfaces.Clusters{
+	ActiveExecutionCountsRefreshInterval: config.Duration{Duration: 10 * time.Second},
+	WorkflowCreateRetry: interfaces.WorkflowCreateRetryConfig{
+		InitialBackoff: config.Duration{Duration: 100 * time.Millisecond},
+		MaxBackoff:     config.Duration{Duration: 2 * time.Second},
+		Budget:         config.Duration{Duration: 10 * time.Second},
+	},
+	ServiceAccountCheck: interfaces.ServiceAccountCheckConfig{
+		CacheTTL: config.Duration{Duration: time.Minute},
+	},
+})
 
 // Implementation of an interfaces.ClusterConfiguration
 type ClusterConfigurationProvider struct{}
@@ -35,6 +46,48 @@ func (p *ClusterConfigurationProvider) GetClusterConfigs() []interfaces.ClusterC
 	return make([]interfaces.ClusterConfig, 0)
 }
 
+func (p *ClusterConfigurationProvider) GetPlacementStrategy() interfaces.ClusterPlacementStrategy {
+	if clusterConfig != nil {
+		clusters := clusterConfig.GetConfig().(*interfaces.Clusters)
+		if clusters.PlacementStrategy != "" {
+			return clusters.PlacementStrategy
+		}
+	}
+	return interfaces.ClusterPlacementWeightedRandom
+}
+
+func (p *ClusterConfigurationProvider) GetHealthCheckInterval() time.Duration {
+	if clusterConfig != nil {
+		clusters := clusterConfig.GetConfig().(*interfaces.Clusters)
+		return clusters.HealthCheckInterval.Duration
+	}
+	return 0
+}
+
+func (p *ClusterConfigurationProvider) GetActiveExecutionCountsRefreshInterval() time.Duration {
+	if clusterConfig != nil {
+		clusters := clusterConfig.GetConfig().(*interfaces.Clusters)
+		return clusters.ActiveExecutionCountsRefreshInterval.Duration
+	}
+	return 0
+}
+
+func (p *ClusterConfigurationProvider) GetWorkflowCreateRetryConfig() interfaces.WorkflowCreateRetryConfig {
+	if clusterConfig != nil {
+		clusters := clusterConfig.GetConfig().(*interfaces.Clusters)
+		return clusters.WorkflowCreateRetry
+	}
+	return interfaces.WorkflowCreateRetryConfig{}
+}
+
+func (p *ClusterConfigurationProvider) GetServiceAccountCheckConfig() interfaces.ServiceAccountCheckConfig {
+	if clusterConfig != nil {
+		clusters := clusterConfig.GetConfig().(*interfaces.Clusters)
+		return clusters.ServiceAccountCheck
+	}
+	return interfaces.ServiceAccountCheckConfig{}
+}
+
 func NewClusterConfigurationProvider() interfaces.ClusterConfiguration {
 	clusterConfigProvider := ClusterConfigurationProvider{}
 	clusterNameMap := make(map[string]bool)
@@ -24,6 +24,10 @@ func (p *QueueConfigurationProvider) GetWorkflowConfigs() []interfaces.WorkflowC
 	return executionQueuesConfig.GetConfig().(*interfaces.QueueConfig).WorkflowConfigs
 }
 
+func (p *QueueConfigurationProvider) GetRejectUnknownTags() bool {
+	return executionQueuesConfig.GetConfig().(*interfaces.QueueConfig).RejectUnknownTags
+}
+
 func NewQueueConfigurationProvider() interfaces.QueueConfiguration {
 	return &QueueConfigurationProvider{}
 }
@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/flyteorg/flytestdlib/config"
 	"golang.org/x/time/rate"
 )
@@ -9,6 +11,8 @@ import (
 // entities (e.g. workflows, tasks, launch plans...)
 // This struct specifically maps to the flyteadmin config yaml structure.
 type DbConfigSection struct {
+	// The database to connect to, either postgres (the default), mysql or sqlite.
+	Dialect string `json:"dialect"`
 	// The host name of the database server
 	Host string `json:"host"`
 	// The port name of the database server
@@ -25,19 +29,65 @@ type DbConfigSection struct {
 	ExtraOptions string `json:"options"`
 	// Whether or not to start the database connection with debug mode enabled.
 	Debug bool `json:"debug"`
+	// Limits the connections kept to the primary database.
+	ConnectionPool DbConnectionPoolConfig `json:"connectionPool"`
+	// An optional replica of the database which read only queries are sent to.
+	ReadReplica DbReadReplicaConfig `json:"readReplica"`
+	// Aborts statements running for longer than this in every session, statements aren't limited when unset.
+	StatementTimeout config.Duration `json:"statementTimeout"`
+	// Aborts statements waiting for a lock for longer than this in every session, waits aren't limited when unset.
+	LockTimeout config.Duration `json:"lockTimeout"`
+	// How often the stats of the connection pools are emitted, they aren't when unset.
+	PoolStatsInterval config.Duration `json:"poolStatsInterval"`
+	// Configures the database file when the dialect is sqlite, the connection settings above don't apply to it.
+	SQLite DbSQLiteConfig `json:"sqlite"`
+}
+
+// Limits the connections kept to a database, the database/sql defaults apply to values left unset.
+type DbConnectionPoolConfig struct {
+	// The maximum number of open connections to the database.
+	MaxOpenConns int `json:"maxOpenConns"`
+	// The maximum number of idle connections kept open to the database.
+	MaxIdleConns int `json:"maxIdleConns"`
+	// The maximum amount of time a connection may be reused for.
+	ConnMaxLifetime config.Duration `json:"connMaxLifetime"`
+}
+
+// Configures a read replica of the database. Lists, gets and counts which can tolerate replication lag are served by
+// the replica while writes, and reads made while handling a write, are sent to the primary.
+type DbReadReplicaConfig struct {
+	// The data source name of the replica, see https://pkg.go.dev/github.com/jackc/pgx/v4#ParseConfig. No replica is
+	// used when empty.
+	DSN string `json:"dsn"`
+	// Limits the connections kept to the replica, independently of those kept to the primary.
+	ConnectionPool DbConnectionPoolConfig `json:"connectionPool"`
+}
+
+// Configures a SQLite database, meant for sandboxes and local deployments which run a single admin process.
+type DbSQLiteConfig struct {
+	// The path of the database file, which is created when it doesn't exist. The database is only kept in memory, for
+	// as long as it's open, when empty.
+	File string `json:"file"`
 }
 
 // This represents a configuration used for initiating database connections much like DbConfigSection, however the
 // password is *resolved* in this struct and therefore it is used as the value the runtime provider returns to callers
 // requesting the database config.
 type DbConfig struct {
-	Host         string `json:"host"`
-	Port         int    `json:"port"`
-	DbName       string `json:"dbname"`
-	User         string `json:"username"`
-	Password     string `json:"password"`
-	ExtraOptions string `json:"options"`
-	Debug        bool   `json:"debug"`
+	Dialect           string                 `json:"dialect"`
+	Host              string                 `json:"host"`
+	Port              int                    `json:"port"`
+	DbName            string                 `json:"dbname"`
+	User              string                 `json:"username"`
+	Password          string                 `json:"password"`
+	ExtraOptions      string                 `json:"options"`
+	Debug             bool                   `json:"debug"`
+	ConnectionPool    DbConnectionPoolConfig `json:"connectionPool"`
+	ReadReplica       DbReadReplicaConfig    `json:"readReplica"`
+	StatementTimeout  time.Duration          `json:"statementTimeout"`
+	LockTimeout       time.Duration          `json:"lockTimeout"`
+	PoolStatsInterval time.Duration          `json:"poolStatsInterval"`
+	SQLite            DbSQLiteConfig         `json:"sqlite"`
 }
 
 // This configuration is the base configuration to start admin
@@ -48,7 +98,8 @@ type ApplicationConfig struct {
 	RoleNameKey string `json:"roleNameKey"`
 	// Top-level name applied to all metrics emitted by the application.
 	MetricsScope string `json:"metricsScope"`
-	// Determines which port the profiling server used for admin monitoring and application debugging uses.
+	// Determines which port the metrics used for admin monitoring are served on, unless server.profilerPort is set, in
+	// which case they're served along with pprof on that port instead.
 	ProfilerPort int `json:"profilerPort"`
 	// This defines the nested path on the configured external storage provider where workflow closures are remotely
 	// offloaded.
@@ -61,6 +112,196 @@ type ApplicationConfig struct {
 	// This is useful to achieve fairness. Note: MapTasks are regarded as one unit,
 	// and parallelism/concurrency of MapTasks is independent from this.
 	MaxParallelism int32 `json:"maxParallelism"`
+	// Page size applied to ListProjects requests which don't specify a limit. A value of 0 returns all projects.
+	ProjectListDefaultLimit int `json:"projectListDefaultLimit"`
+	// Labels applied to every execution. These have the lowest precedence and are overridden by project, launch plan
+	// and execution labels with the same key.
+	Labels map[string]string `json:"labels"`
+	// Annotations applied to every execution. These have the lowest precedence and are overridden by launch plan and
+	// execution annotations with the same key.
+	Annotations map[string]string `json:"annotations"`
+	// Maximum serialized size in bytes of the inputs accepted when creating an execution. A value of 0 disables the
+	// check.
+	MaxExecutionInputsSizeInBytes int64 `json:"maxExecutionInputsSizeInBytes"`
+	// Names of the environment variables executions, launch plans and the matchable attributes of projects and domains
+	// may not set for the tasks of an execution. A name ending with * denies every name starting with what precedes it,
+	// e.g. AWS_*.
+	ExecutionEnvDenylist []string `json:"executionEnvDenylist"`
+	// Maximum serialized size in bytes of any single scalar input literal, such as a large string or struct, accepted
+	// when creating an execution. A value of 0 disables the check.
+	MaxInputLiteralSizeInBytes int64 `json:"maxInputLiteralSizeInBytes"`
+	// IAM role executions run as when neither the execution request, its launch plan nor the cluster resource
+	// attributes of its project and domain set one.
+	DefaultIamRole string `json:"defaultIamRole"`
+	// Kubernetes service account executions run as when neither the execution request, its launch plan nor the
+	// cluster resource attributes of its project and domain set one.
+	DefaultServiceAccount string `json:"defaultServiceAccount"`
+	// Rejects the creation of executions which resolve neither an IAM role nor a kubernetes service account to run as.
+	RequireAuthRole bool `json:"requireAuthRole"`
+	// Rejects the creation of executions which request a cluster label of their own with PermissionDenied, so that
+	// executions only run in the clusters their project, domain, workflow and launch plan are assigned to.
+	DisableExecutionClusterOverrides bool `json:"disableExecutionClusterOverrides"`
+	// Maximum number of external resources, such as query or job ids, recorded for a task execution attempt. Only the
+	// most recently reported resources are kept. A value of 0 disables the limit.
+	MaxTaskExternalResources int `json:"maxTaskExternalResources"`
+	// Maximum serialized size in bytes of the custom info recorded for a task execution attempt. Updates which would
+	// grow the custom info beyond it are dropped. A value of 0 disables the limit.
+	MaxTaskCustomInfoSizeInBytes int64 `json:"maxTaskCustomInfoSizeInBytes"`
+	// How long exact counts of filtered entities are reused for the same filters, as counting large tables is
+	// expensive. A value of 0 disables caching.
+	CountCacheTTL config.Duration `json:"countCacheTTL"`
+	// Configures the background job which purges old executions.
+	ExecutionRetention ExecutionRetentionConfig `json:"executionRetention"`
+	// Caps the executions each project may have running at once in a domain.
+	ActiveExecutionQuota ActiveExecutionQuotaConfig `json:"activeExecutionQuota"`
+	// Configures how compiled workflow closures are read back from the metadata storage they're offloaded to.
+	WorkflowClosureCache WorkflowClosureCacheConfig `json:"workflowClosureCache"`
+	// Configures the cache of the launch plans and workflows looked up when creating executions.
+	ExecutionLookupCache LookupCacheConfig `json:"executionLookupCache"`
+	// Maximum number of levels of descendants listed below each node execution when listing node executions as a
+	// tree.
+	MaxNodeExecutionTreeDepth int `json:"maxNodeExecutionTreeDepth"`
+	// Configures fetching the data of many node executions in a single call.
+	NodeExecutionDataBatch NodeExecutionDataBatchConfig `json:"nodeExecutionDataBatch"`
+	// Configures launching the workflows of new executions in the background.
+	AsyncExecutionLaunch AsyncExecutionLaunchConfig `json:"asyncExecutionLaunch"`
+	// Configures the execution statistics served for capacity planning.
+	ExecutionStatistics ExecutionStatisticsConfig `json:"executionStatistics"`
+	// Bounds the number of projects the execution metrics are labeled with.
+	ExecutionMetrics ExecutionMetricsConfig `json:"executionMetrics"`
+	// Configures the watchers of executions streamed their updates.
+	ExecutionWatch ExecutionWatchConfig `json:"executionWatch"`
+	// The key of the project label whose value is the org of the project. The matchable attributes of an org apply to
+	// its projects which have none of their own, taking precedence over those of their domain. Orgs aren't supported
+	// when empty.
+	OrgLabel string `json:"orgLabel"`
+}
+
+// Configures the purge of executions, along with their node executions, task executions and events, once they're
+// older than the retention period of their domain. Executions which haven't terminated are kept.
+type ExecutionRetentionConfig struct {
+	// Whether admin runs the retention job. Every replica runs it, but only the one holding the job's lease, which it
+	// renews each interval, purges executions.
+	Enabled bool `json:"enabled"`
+	// How long the executions of domains without a retention period of their own are kept. A value of 0 keeps them
+	// forever.
+	RetentionPeriod config.Duration `json:"retentionPeriod"`
+	// Retention periods which take precedence over the default one, keyed by domain.
+	DomainRetentionPeriods map[string]config.Duration `json:"domainRetentionPeriods"`
+	// Number of executions purged per transaction.
+	BatchSize int `json:"batchSize"`
+	// How often expired executions are purged.
+	Interval config.Duration `json:"interval"`
+	// Only logs and counts the rows that would be purged, without deleting them.
+	DryRun bool `json:"dryRun"`
+}
+
+// Returns the retention period of the executions of a domain, or 0 if they're kept forever.
+func (e ExecutionRetentionConfig) GetRetentionPeriod(domain string) time.Duration {
+	if period, ok := e.DomainRetentionPeriods[domain]; ok {
+		return period.Duration
+	}
+	return e.RetentionPeriod.Duration
+}
+
+// Caps the non-terminal executions of each project in a domain, such that creating executions beyond the cap fails. The
+// active execution quota set for a project and domain takes precedence over these limits.
+type ActiveExecutionQuotaConfig struct {
+	// Maximum number of active executions of a project in domains without a limit of their own. A value of 0 disables
+	// the limit.
+	MaxActiveExecutions int `json:"maxActiveExecutions"`
+	// Limits which take precedence over the default one, keyed by domain.
+	DomainMaxActiveExecutions map[string]int `json:"domainMaxActiveExecutions"`
+	// How long the count of active executions of a project and domain is reused for. Executions created or terminated
+	// meanwhile aren't accounted for, so the limit is approximate when set. A value of 0 counts on every creation.
+	CountCacheTTL config.Duration `json:"countCacheTTL"`
+}
+
+// Returns the maximum number of active executions of a project in a domain, or 0 if they aren't limited.
+func (q ActiveExecutionQuotaConfig) GetMaxActiveExecutions(domain string) int {
+	if maxActiveExecutions, ok := q.DomainMaxActiveExecutions[domain]; ok {
+		return maxActiveExecutions
+	}
+	return q.MaxActiveExecutions
+}
+
+// Compiled workflow closures are immutable once offloaded, so each admin replica keeps the most recently read ones in
+// memory rather than fetching them from storage for every GetWorkflow and execution creation.
+type WorkflowClosureCacheConfig struct {
+	// Maximum total serialized size in bytes of the closures kept in memory, the least recently read are evicted first.
+	// Closures larger than it aren't cached. A value of 0 disables caching.
+	MaxSizeInBytes int64 `json:"maxSizeInBytes"`
+	// Number of times reading a closure from storage is retried before failing.
+	FetchRetries int `json:"fetchRetries"`
+	// How long to wait between reads of a closure from storage.
+	FetchRetryDelay config.Duration `json:"fetchRetryDelay"`
+}
+
+// Versions of launch plans and workflows are immutable once registered, so each admin replica keeps those it recently
+// looked up to create executions in memory, which spares high-frequency scheduled launches most of their reads.
+type LookupCacheConfig struct {
+	// Maximum number of versions kept in memory, the least recently looked up are evicted first. A value of 0 disables
+	// caching.
+	MaxEntries int `json:"maxEntries"`
+	// Maximum total size in bytes of the versions kept in memory. Versions larger than it aren't cached.
+	MaxSizeInBytes int64 `json:"maxSizeInBytes"`
+	// How long a version is kept in memory for after it was looked up. A value of 0 keeps it until it's evicted.
+	TTL config.Duration `json:"ttl"`
+}
+
+// Bounds the batches of node executions whose data is fetched in a single call.
+type NodeExecutionDataBatchConfig struct {
+	// Maximum number of node executions whose data is fetched in a single call.
+	MaxBatchSize int `json:"maxBatchSize"`
+	// Number of node executions whose data is read from storage at once.
+	Concurrency int `json:"concurrency"`
+}
+
+// Creating the workflow of an execution in its cluster can take a while, so executions may be recorded as queued and
+// returned to their caller right away, leaving a pool of workers to launch their workflows.
+type AsyncExecutionLaunchConfig struct {
+	// Whether executions are launched in the background. They're launched before CreateExecution returns otherwise.
+	Enabled bool `json:"enabled"`
+	// Number of workflows launched at once.
+	Workers int `json:"workers"`
+	// Maximum number of executions waiting to be launched. Executions created while the queue is full are launched
+	// before CreateExecution returns.
+	QueueSize int `json:"queueSize"`
+	// Number of times launching a workflow is retried before its execution fails.
+	Retries int `json:"retries"`
+	// How long to wait between attempts to launch a workflow.
+	RetryDelay config.Duration `json:"retryDelay"`
+	// How long an execution may stay queued without being launched before it's failed. Launch queues are kept in
+	// memory, so executions queued by a replica which stopped before launching them are never launched otherwise.
+	InterruptedAfter config.Duration `json:"interruptedAfter"`
+}
+
+// Execution statistics aggregate every execution created within their time range, so requests are bounded and their
+// results kept in memory for a while.
+type ExecutionStatisticsConfig struct {
+	// Maximum number of buckets a time range may be divided into.
+	MaxBuckets int `json:"maxBuckets"`
+	// Maximum number of results kept in memory. A value of 0 disables caching.
+	CacheSize int `json:"cacheSize"`
+	// How long a result is kept in memory for.
+	CacheTTL config.Duration `json:"cacheTTL"`
+}
+
+// The execution metrics are labeled with the project and domain of the execution. Projects creating so many executions,
+// or so many projects, that they'd blow up the number of series stored are labeled as "other" instead.
+type ExecutionMetricsConfig struct {
+	// Projects whose metrics are aggregated as "other".
+	AggregatedProjects []string `json:"aggregatedProjects"`
+	// Maximum number of projects labeled by name, the metrics of any other project are aggregated as "other". A value of
+	// 0 labels every project by name.
+	MaxLabeledProjects int `json:"maxLabeledProjects"`
+}
+
+// Execution watchers are streamed the events of their execution as they're processed, and dropped rather than slowing
+// down event processing when they fall behind.
+type ExecutionWatchConfig struct {
+	// Maximum number of events buffered for a watcher before it's dropped.
+	BufferSize int `json:"bufferSize"`
 }
 
 func (a *ApplicationConfig) GetRoleNameKey() string {
@@ -91,6 +332,94 @@ func (a *ApplicationConfig) GetMaxParallelism() int32 {
 	return a.MaxParallelism
 }
 
+func (a *ApplicationConfig) GetProjectListDefaultLimit() int {
+	return a.ProjectListDefaultLimit
+}
+
+func (a *ApplicationConfig) GetLabels() map[string]string {
+	return a.Labels
+}
+
+func (a *ApplicationConfig) GetAnnotations() map[string]string {
+	return a.Annotations
+}
+
+func (a *ApplicationConfig) GetMaxExecutionInputsSizeInBytes() int64 {
+	return a.MaxExecutionInputsSizeInBytes
+}
+
+func (a *ApplicationConfig) GetMaxInputLiteralSizeInBytes() int64 {
+	return a.MaxInputLiteralSizeInBytes
+}
+
+func (a *ApplicationConfig) GetMaxTaskExternalResources() int {
+	return a.MaxTaskExternalResources
+}
+
+func (a *ApplicationConfig) GetMaxTaskCustomInfoSizeInBytes() int64 {
+	return a.MaxTaskCustomInfoSizeInBytes
+}
+
+func (a *ApplicationConfig) GetCountCacheTTL() time.Duration {
+	return a.CountCacheTTL.Duration
+}
+
+func (a *ApplicationConfig) GetExecutionRetentionConfig() ExecutionRetentionConfig {
+	return a.ExecutionRetention
+}
+
+func (a *ApplicationConfig) GetActiveExecutionQuotaConfig() ActiveExecutionQuotaConfig {
+	return a.ActiveExecutionQuota
+}
+
+func (a *ApplicationConfig) GetWorkflowClosureCacheConfig() WorkflowClosureCacheConfig {
+	return a.WorkflowClosureCache
+}
+
+func (a *ApplicationConfig) GetExecutionLookupCacheConfig() LookupCacheConfig {
+	return a.ExecutionLookupCache
+}
+
+func (a *ApplicationConfig) GetMaxNodeExecutionTreeDepth() int {
+	return a.MaxNodeExecutionTreeDepth
+}
+
+func (a *ApplicationConfig) GetNodeExecutionDataBatchConfig() NodeExecutionDataBatchConfig {
+	return a.NodeExecutionDataBatch
+}
+
+func (a *ApplicationConfig) GetAsyncExecutionLaunchConfig() AsyncExecutionLaunchConfig {
+	return a.AsyncExecutionLaunch
+}
+
+func (a *ApplicationConfig) GetExecutionStatisticsConfig() ExecutionStatisticsConfig {
+	return a.ExecutionStatistics
+}
+
+func (a *ApplicationConfig) GetExecutionMetricsConfig() ExecutionMetricsConfig {
+	return a.ExecutionMetrics
+}
+
+func (a *ApplicationConfig) GetExecutionWatchConfig() ExecutionWatchConfig {
+	return a.ExecutionWatch
+}
+
+func (a *ApplicationConfig) GetOrgLabel() string {
+	return a.OrgLabel
+}
+
+func (a *ApplicationConfig) GetDefaultIamRole() string {
+	return a.DefaultIamRole
+}
+
+func (a *ApplicationConfig) GetDefaultServiceAccount() string {
+	return a.DefaultServiceAccount
+}
+
+func (a *ApplicationConfig) GetRequireAuthRole() bool {
+	return a.RequireAuthRole
+}
+
 // This section holds common config for AWS
 type AWSConfig struct {
 	Region string `json:"region"`
@@ -101,6 +430,43 @@ type GCPConfig struct {
 	ProjectID string `json:"projectId"`
 }
 
+// This section holds common config for Kafka
+type KafkaConfig struct {
+	Brokers []string `json:"brokers"`
+	// Kafka version of the brokers, e.g. 2.6.0. Defaults to the oldest version supporting record headers, 0.11.0.
+	Version string          `json:"version"`
+	TLS     KafkaTLSConfig  `json:"tls"`
+	SASL    KafkaSASLConfig `json:"sasl"`
+	// Time to buffer published messages for before sending them in a batch. Messages are sent right away if unset.
+	FlushInterval config.Duration `json:"flushInterval"`
+	// Number of buffered messages which triggers sending a batch before the flush interval elapses.
+	FlushMessages int `json:"flushMessages"`
+	// Number of published messages buffered while they wait to be sent, 256 by default. Publishing fails while the
+	// buffer is full.
+	BufferSize int `json:"bufferSize"`
+	// By default startup fails when the brokers are unreachable. When set, publishers connect on their first
+	// publish instead.
+	LazyConnect bool `json:"lazyConnect"`
+	// Consumer group the notifications processor joins to read notifications. Defaults to flyteadmin.
+	ConsumerGroup string `json:"consumerGroup"`
+}
+
+type KafkaTLSConfig struct {
+	Enable bool `json:"enable"`
+	// Name of the secret holding the PEM encoded CA certificates to verify the brokers with, in place of the system
+	// ones.
+	CACertSecretName   string `json:"caCertSecretName"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify"`
+}
+
+// Configures SASL/PLAIN authentication with the brokers.
+type KafkaSASLConfig struct {
+	Enable bool   `json:"enable"`
+	User   string `json:"user"`
+	// Name of the secret holding the password of the user.
+	PasswordSecretName string `json:"passwordSecretName"`
+}
+
 // This section holds configuration for the event scheduler used to schedule workflow executions.
 type EventSchedulerConfig struct {
 	// Defines the cloud provider that backs the scheduler. In the absence of a specification the no-op, 'local'
@@ -117,7 +483,44 @@ type EventSchedulerConfig struct {
 	ScheduleNamePrefix   string                `json:"scheduleNamePrefix"`
 	AWSSchedulerConfig   *AWSSchedulerConfig   `json:"aws"`
 	FlyteSchedulerConfig *FlyteSchedulerConfig `json:"local"`
-}
+	// Determines how schedules activated from now on handle the invocations they missed while the scheduler was down.
+	// In the absence of a specification every missed invocation fires.
+	CatchUpPolicy CatchUpPolicy `json:"catchUpPolicy"`
+	// Determines whether launch plans registered from now on allow scheduled executions to overlap, unless they request
+	// a policy of their own with the flyte.org/schedule-overlap-policy annotation. In the absence of a specification
+	// they do.
+	OverlapPolicy ScheduleOverlapPolicy `json:"overlapPolicy"`
+	// Schedules activated from now on fire up to this long after their nominal time, by a jitter derived from their
+	// launch plan so that it's stable across restarts. This spreads out the schedules sharing a cron expression. In the
+	// absence of a specification schedules fire on time.
+	MaxJitter config.Duration `json:"maxJitter"`
+}
+
+// CatchUpPolicy determines which of the invocations a schedule missed, for example while the scheduler was down, still
+// fire once they are older than the workflow executor catch up threshold.
+type CatchUpPolicy string
+
+const (
+	// Stale invocations are dropped.
+	CatchUpPolicyNone CatchUpPolicy = "NONE"
+	// Only the most recent stale invocation fires.
+	CatchUpPolicyLast CatchUpPolicy = "LAST"
+	// Every stale invocation fires with its original kickoff time.
+	CatchUpPolicyAll CatchUpPolicy = "ALL"
+)
+
+// ScheduleOverlapPolicy determines what happens when a schedule fires while a previous scheduled execution of the same
+// launch plan version hasn't terminated yet.
+type ScheduleOverlapPolicy string
+
+const (
+	// The new execution runs alongside the previous one.
+	ScheduleOverlapPolicyAllow ScheduleOverlapPolicy = "ALLOW"
+	// The new execution isn't created.
+	ScheduleOverlapPolicySkip ScheduleOverlapPolicy = "SKIP"
+	// The previous execution is terminated before the new one is created.
+	ScheduleOverlapPolicyReplace ScheduleOverlapPolicy = "REPLACE"
+)
 
 func (e *EventSchedulerConfig) GetScheme() string {
 	return e.Scheme
@@ -147,6 +550,24 @@ func (e *EventSchedulerConfig) GetFlyteSchedulerConfig() *FlyteSchedulerConfig {
 	return e.FlyteSchedulerConfig
 }
 
+func (e *EventSchedulerConfig) GetCatchUpPolicy() CatchUpPolicy {
+	if len(e.CatchUpPolicy) == 0 {
+		return CatchUpPolicyAll
+	}
+	return e.CatchUpPolicy
+}
+
+func (e *EventSchedulerConfig) GetOverlapPolicy() ScheduleOverlapPolicy {
+	if len(e.OverlapPolicy) == 0 {
+		return ScheduleOverlapPolicyAllow
+	}
+	return e.OverlapPolicy
+}
+
+func (e *EventSchedulerConfig) GetMaxJitter() time.Duration {
+	return e.MaxJitter.Duration
+}
+
 type AWSSchedulerConfig struct {
 	// Some cloud providers require a region to be set.
 	Region string `json:"region"`
@@ -192,6 +613,9 @@ type WorkflowExecutorConfig struct {
 	AccountID                   string                       `json:"accountId"`
 	AWSWorkflowExecutorConfig   *AWSWorkflowExecutorConfig   `json:"aws"`
 	FlyteWorkflowExecutorConfig *FlyteWorkflowExecutorConfig `json:"local"`
+	// Scheduled invocations whose kickoff time is older than this are stale and fire according to the catch up policy
+	// of their schedule.
+	CatchUpThreshold config.Duration `json:"catchUpThreshold"`
 }
 
 func (w *WorkflowExecutorConfig) GetScheme() string {
@@ -218,6 +642,10 @@ func (w *WorkflowExecutorConfig) GetFlyteWorkflowExecutorConfig() *FlyteWorkflow
 	return w.FlyteWorkflowExecutorConfig
 }
 
+func (w *WorkflowExecutorConfig) GetCatchUpThreshold() time.Duration {
+	return w.CatchUpThreshold.Duration
+}
+
 type AWSWorkflowExecutorConfig struct {
 	// Some cloud providers require a region to be set.
 	Region string `json:"region"`
@@ -240,18 +668,48 @@ func (a *AWSWorkflowExecutorConfig) GetAccountID() string {
 	return a.AccountID
 }
 
+const defaultClaimTimeout = 10 * time.Minute
+const defaultClaimRetention = 7 * 24 * time.Hour
+
 // FlyteWorkflowExecutorConfig specifies the workflow executor configuration for the native flyte scheduler
 type FlyteWorkflowExecutorConfig struct {
 	// This allows to control the number of TPS that hit admin using the scheduler.
 	// eg : 100 TPS will send at the max 100 schedule requests to admin per sec.
 	// Burst specifies burst traffic count
 	AdminRateLimit *AdminRateLimit `json:"adminRateLimit"`
+	// Identifies this scheduler replica in the claims it records to fire scheduled invocations, so that replicas don't
+	// fire the same invocation. Defaults to the hostname.
+	ReplicaID string `json:"replicaId"`
+	// How long the claim of a replica to fire an invocation holds. Invocations which haven't fired by then, say because
+	// their replica died, may be claimed by other replicas.
+	ClaimTimeout config.Duration `json:"claimTimeout"`
+	// How long the claims of fired invocations are kept. On restart, the scheduler catches up on each schedule from the
+	// last invocation claimed and fired, or from its snapshot once those claims are pruned.
+	ClaimRetention config.Duration `json:"claimRetention"`
 }
 
 func (f *FlyteWorkflowExecutorConfig) GetAdminRateLimit() *AdminRateLimit {
 	return f.AdminRateLimit
 }
 
+func (f *FlyteWorkflowExecutorConfig) GetReplicaID() string {
+	return f.ReplicaID
+}
+
+func (f *FlyteWorkflowExecutorConfig) GetClaimTimeout() time.Duration {
+	if f.ClaimTimeout.Duration <= 0 {
+		return defaultClaimTimeout
+	}
+	return f.ClaimTimeout.Duration
+}
+
+func (f *FlyteWorkflowExecutorConfig) GetClaimRetention() time.Duration {
+	if f.ClaimRetention.Duration <= 0 {
+		return defaultClaimRetention
+	}
+	return f.ClaimRetention.Duration
+}
+
 type AdminRateLimit struct {
 	Tps   rate.Limit `json:"tps"`
 	Burst int        `json:"burst"`
@@ -296,7 +754,7 @@ func (s *SchedulerConfig) GetReconnectDelaySeconds() int {
 // Configuration specific to setting up signed urls.
 type SignedURL struct {
 	// Whether signed urls should even be returned with GetExecutionData, GetNodeExecutionData and GetTaskExecutionData
-	// response objects.
+	// response objects. Data which fails to be signed is returned with its raw uri instead.
 	Enabled bool `json:"enabled" pflag:",Whether signed urls should even be returned with GetExecutionData, GetNodeExecutionData and GetTaskExecutionData response objects."`
 	// The amount of time for which a signed URL is valid.
 	DurationMinutes int `json:"durationMinutes"`
@@ -325,9 +783,22 @@ type RemoteDataConfig struct {
 	Region    string    `json:"region"`
 	SignedURL SignedURL `json:"signedUrls"`
 	// Specifies the max size in bytes for which execution data such as inputs and outputs will be populated in line.
+	// Only applies to cloud storage schemes, with the no-op and local schemes data is always populated in line.
 	MaxSizeInBytes int64 `json:"maxSizeInBytes"`
 	// Specifies how inline execution event data should be saved in the backend
 	InlineEventDataPolicy InlineEventDataPolicy `json:"inlineEventDataPolicy" pflag:",Specifies how inline execution event data should be saved in the backend"`
+	// Configures the locations handed out for uploading artifacts such as fast registration archives.
+	Upload DataUploadConfig `json:"upload"`
+}
+
+// This section handles configuration for uploading artifacts through signed urls.
+type DataUploadConfig struct {
+	// The prefix, within the metadata bucket, under which uploaded artifacts are stored.
+	StoragePrefix string `json:"storagePrefix"`
+	// Uploads larger than this are rejected.
+	MaxSizeInBytes int64 `json:"maxSizeInBytes"`
+	// The maximum amount of time for which an upload url is valid.
+	MaxExpiresIn config.Duration `json:"maxExpiresIn"`
 }
 
 // This section handles configuration for the workflow notifications pipeline.
@@ -338,7 +809,8 @@ type NotificationsPublisherConfig struct {
 
 // This section handles configuration for processing workflow events.
 type NotificationsProcessorConfig struct {
-	// The name of the queue onto which workflow notifications will enqueue.
+	// The name of the queue onto which workflow notifications will enqueue. With Kafka, the topic notifications are
+	// read from.
 	QueueName string `json:"queueName"`
 	// The account id (according to whichever cloud provider scheme is used) that has permission to read from the above
 	// queue.
@@ -362,6 +834,107 @@ type NotificationsEmailerConfig struct {
 	Sender string `json:"sender"`
 	// The optionally templatized body the sender used in notification emails.
 	Body string `json:"body"`
+	// Go templates of notification emails, used instead of the subject and body above when set.
+	Templates EmailTemplatesConfig `json:"templates"`
+}
+
+// Go templates of notification emails, each either inline or read from a file at startup. The templates can
+// reference the execution's .Project, .Domain, .Name, .Workflow, .LaunchPlan, .Phase, .ErrorKind, .Error, .Duration
+// and .ConsoleURL. Variables without a value, such as the error of a successful execution, render empty.
+type EmailTemplatesConfig struct {
+	Subject     string `json:"subject"`
+	SubjectFile string `json:"subjectFile"`
+	// The HTML body, which escapes the variables it references.
+	HTMLBody     string `json:"htmlBody"`
+	HTMLBodyFile string `json:"htmlBodyFile"`
+	// The plain text body, sent as an alternative to the HTML body when both are set.
+	TextBody     string `json:"textBody"`
+	TextBodyFile string `json:"textBodyFile"`
+	// Base URL of the console .ConsoleURL links executions in. .ConsoleURL is empty when unset.
+	ConsoleURL string `json:"consoleUrl"`
+	// Length error messages are truncated to.
+	MaxErrorLength int `json:"maxErrorLength"`
+}
+
+// A Slack channel notifications are posted to through an incoming webhook.
+type SlackChannelConfig struct {
+	// Name of the secret, read through the secret manager, which holds the incoming webhook URL of the channel.
+	WebhookURLSecretName string `json:"webhookUrlSecretName"`
+}
+
+// This section handles the configuration of notifications posted to Slack through incoming webhooks.
+type NotificationsSlackConfig struct {
+	// Slack channels keyed by the recipient which names them in Slack notifications. Slack notifications to any other
+	// recipient are emailed.
+	Channels map[string]SlackChannelConfig `json:"channels"`
+	// The optionally templatized message posted to channels.
+	Message string `json:"message"`
+	// Base URL of the console, used to link messages to their execution. No link is added when unset.
+	ConsoleURL string `json:"consoleUrl"`
+	// Number of attempts made to post a message before it's dropped.
+	MaxAttempts int `json:"maxAttempts"`
+	// Backoff before the first retry of a failed post, doubled on each further retry.
+	RetryBackoff config.Duration `json:"retryBackoff"`
+	// Number of notifications delivered concurrently.
+	Workers int `json:"workers"`
+	// Number of notifications buffered for delivery. Notifications published while the queue is full are dropped.
+	QueueSize int `json:"queueSize"`
+}
+
+// An HTTP endpoint notifications are sent to.
+type WebhookEndpointConfig struct {
+	URL string `json:"url"`
+	// HTTP method used to send notifications, POST by default.
+	Method string `json:"method"`
+	// Static headers sent with every notification.
+	Headers map[string]string `json:"headers"`
+	// Headers sent with every notification whose values are secrets, keyed by header with the name of the secret,
+	// read through the secret manager, as value.
+	SecretHeaders map[string]string `json:"secretHeaders"`
+	// Go template of the JSON body sent to the webhook. The template has access to the execution project, domain and
+	// name, its phase, error message and output URI, see the notifications package for details.
+	Body string `json:"body"`
+	// Name of the secret, read through the secret manager, holding the key used to sign bodies with HMAC-SHA256.
+	// Bodies aren't signed when unset.
+	SigningKeySecretName string `json:"signingKeySecretName"`
+	// Time to wait for the webhook to respond.
+	Timeout config.Duration `json:"timeout"`
+}
+
+// This section handles the configuration of notifications sent to webhooks.
+type NotificationsWebhookConfig struct {
+	// Webhooks keyed by the name notification recipients refer to them with, as "webhook:<name>".
+	Endpoints map[string]WebhookEndpointConfig `json:"endpoints"`
+	// Number of attempts made to send a notification before it's dropped.
+	MaxAttempts int `json:"maxAttempts"`
+	// Backoff before the first retry of a failed notification, doubled on each further retry.
+	RetryBackoff config.Duration `json:"retryBackoff"`
+	// Number of notifications delivered concurrently.
+	Workers int `json:"workers"`
+	// Number of notifications buffered for delivery. Notifications published while the queue is full are dropped.
+	QueueSize int `json:"queueSize"`
+}
+
+// This section handles the in-process notifications queue of the local type, which delivers notifications without an
+// external queue.
+type NotificationsLocalConfig struct {
+	// Number of notifications buffered for delivery. Notifications published while the queue is full are dropped.
+	QueueSize int `json:"queueSize"`
+	// Number of notifications delivered concurrently.
+	Workers int `json:"workers"`
+}
+
+// This section handles rate limiting notifications, so that a flapping workflow doesn't flood its recipients.
+// Notifications are counted per launch plan, notification type, phase and recipients on each admin replica separately.
+type NotificationsRateLimitConfig struct {
+	// Number of notifications sent within a window, beyond which they're suppressed until the window ends. A single
+	// digest summarizing the suppressed notifications is sent at the end of the window. Zero disables rate limiting.
+	Limit int `json:"limit"`
+	// Length of the window notifications are counted in.
+	Window config.Duration `json:"window"`
+	// Maximum number of launch plan, notification type, phase and recipients combinations tracked at once. The least
+	// recently notified combinations are forgotten first, sending their pending digests early.
+	CacheSize int `json:"cacheSize"`
 }
 
 // This section handles configuration for the workflow notifications pipeline.
@@ -379,8 +952,15 @@ type ExternalEventsConfig struct {
 	Type      string    `json:"type"`
 	AWSConfig AWSConfig `json:"aws"`
 	GCPConfig GCPConfig `json:"gcp"`
+	// Kafka brokers events are published to when the type is kafka, the topic is the events publisher topic.
+	KafkaConfig KafkaConfig `json:"kafka"`
 	// Publish events to a pubsub tops
 	EventsPublisherConfig EventsPublisherConfig `json:"eventsPublisher"`
+	// Encoding of published events, either "proto" for the serialized admin event requests or "cloudevents" for
+	// CloudEvents v1.0 JSON envelopes carrying the event requests along with the identifiers of their execution.
+	Encoding string `json:"encoding"`
+	// The CloudEvents source attribute of events published with the cloudevents encoding.
+	CloudEventsSource string `json:"cloudEventsSource"`
 	// Number of times to attempt recreating a notifications processor client should there be any disruptions.
 	ReconnectAttempts int `json:"reconnectAttempts"`
 	// Specifies the time interval to wait before attempting to reconnect the notifications processor client.
@@ -396,9 +976,14 @@ type NotificationsConfig struct {
 	Region                       string                       `json:"region"`
 	AWSConfig                    AWSConfig                    `json:"aws"`
 	GCPConfig                    GCPConfig                    `json:"gcp"`
+	KafkaConfig                  KafkaConfig                  `json:"kafka"`
+	NotificationsLocalConfig     NotificationsLocalConfig     `json:"local"`
 	NotificationsPublisherConfig NotificationsPublisherConfig `json:"publisher"`
 	NotificationsProcessorConfig NotificationsProcessorConfig `json:"processor"`
 	NotificationsEmailerConfig   NotificationsEmailerConfig   `json:"emailer"`
+	NotificationsSlackConfig     NotificationsSlackConfig     `json:"slack"`
+	NotificationsWebhookConfig   NotificationsWebhookConfig   `json:"webhook"`
+	NotificationsRateLimitConfig NotificationsRateLimitConfig `json:"rateLimit"`
 	// Number of times to attempt recreating a notifications processor client should there be any disruptions.
 	ReconnectAttempts int `json:"reconnectAttempts"`
 	// Specifies the time interval to wait before attempting to reconnect the notifications processor client.
@@ -2,7 +2,9 @@ package interfaces
 
 import (
 	"io/ioutil"
+	"time"
 
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/pkg/errors"
 )
 
@@ -41,9 +43,50 @@ func (auth Auth) GetToken() (string, error) {
 	return string(token), nil
 }
 
+// ClusterPlacementStrategy determines which of the candidate clusters of an execution it is created in.
+type ClusterPlacementStrategy string
+
+const (
+	// Picks a cluster at random according to the weights of the label cluster map. All clusters weigh the same for
+	// executions without a cluster label.
+	ClusterPlacementWeightedRandom ClusterPlacementStrategy = "WEIGHTED_RANDOM"
+	// Cycles through the candidate clusters.
+	ClusterPlacementRoundRobin ClusterPlacementStrategy = "ROUND_ROBIN"
+	// Picks the candidate cluster running the fewest non-terminal executions.
+	ClusterPlacementLeastActive ClusterPlacementStrategy = "LEAST_ACTIVE"
+)
+
 type Clusters struct {
 	ClusterConfigs  []ClusterConfig            `json:"clusterConfigs"`
 	LabelClusterMap map[string][]ClusterEntity `json:"labelClusterMap"`
+	// Defaults to WEIGHTED_RANDOM.
+	PlacementStrategy ClusterPlacementStrategy `json:"placementStrategy"`
+	// How often the API server of each enabled cluster is pinged. New executions aren't placed in clusters failing
+	// the ping until they respond again. Health checks are disabled when unset.
+	HealthCheckInterval config.Duration `json:"healthCheckInterval"`
+	// How long the LEAST_ACTIVE strategy goes by the execution counts of clusters before counting them again. Clusters
+	// are counted for every placement when zero.
+	ActiveExecutionCountsRefreshInterval config.Duration `json:"activeExecutionCountsRefreshInterval"`
+	// Retries of transient failures to create workflow CRDs in execution clusters.
+	WorkflowCreateRetry WorkflowCreateRetryConfig `json:"workflowCreateRetry"`
+	// Checks the service account of an execution exists in its namespace before creating its workflow CRD.
+	ServiceAccountCheck ServiceAccountCheckConfig `json:"serviceAccountCheck"`
+}
+
+// Holds the exponential backoff of workflow CRD creation retries. Creation isn't retried when the budget is zero.
+type WorkflowCreateRetryConfig struct {
+	InitialBackoff config.Duration `json:"initialBackoff"`
+	MaxBackoff     config.Duration `json:"maxBackoff"`
+	// Total time spent creating a workflow CRD, across all attempts.
+	Budget config.Duration `json:"budget"`
+}
+
+// Holds whether execution service accounts are checked, which costs a request to the API server of the execution
+// cluster for every execution whose service account isn't cached.
+type ServiceAccountCheckConfig struct {
+	Enabled bool `json:"enabled"`
+	// How long service accounts found to exist are cached.
+	CacheTTL config.Duration `json:"cacheTTL"`
 }
 
 // Provides values set in runtime configuration files.
@@ -54,4 +97,19 @@ type ClusterConfiguration interface {
 
 	// Returns label cluster map for routing
 	GetLabelClusterMap() map[string][]ClusterEntity
+
+	// Returns the strategy placing executions among their candidate clusters.
+	GetPlacementStrategy() ClusterPlacementStrategy
+
+	// Returns how often clusters are health checked, zero when health checks are disabled.
+	GetHealthCheckInterval() time.Duration
+
+	// Returns how long the execution counts of clusters are placed by before they're counted again.
+	GetActiveExecutionCountsRefreshInterval() time.Duration
+
+	// Returns the retry policy of workflow CRD creation.
+	GetWorkflowCreateRetryConfig() WorkflowCreateRetryConfig
+
+	// Returns whether and how execution service accounts are checked.
+	GetServiceAccountCheckConfig() ServiceAccountCheckConfig
 }
@@ -24,6 +24,8 @@ type WorkflowConfigs []WorkflowConfig
 type QueueConfig struct {
 	ExecutionQueues ExecutionQueues `json:"executionQueues"`
 	WorkflowConfigs WorkflowConfigs `json:"workflowConfigs"`
+	// Whether execution queue attributes naming tags of no execution queue are rejected, rather than only logged.
+	RejectUnknownTags bool `json:"rejectUnknownTags"`
 }
 
 // Provides values set in runtime configuration files.
@@ -33,4 +35,6 @@ type QueueConfiguration interface {
 	GetExecutionQueues() []ExecutionQueue
 	// Returns workflow configurations defined in runtime configuration files.
 	GetWorkflowConfigs() []WorkflowConfig
+	// Whether execution queue attributes naming tags of no execution queue are rejected when they're set.
+	GetRejectUnknownTags() bool
 }
@@ -5,12 +5,27 @@ type RegistrationValidationConfig struct {
 	MaxLabelEntries      int    `json:"maxLabelEntries"`
 	MaxAnnotationEntries int    `json:"maxAnnotationEntries"`
 	WorkflowSizeLimit    string `json:"workflowSizeLimit"`
+	// Determines whether tasks failing container checks at registration, such as resources exceeding their limits or
+	// malformed image references, are rejected or registered with a warning. In the absence of a specification they
+	// are rejected.
+	TaskValidationEnforcement TaskValidationEnforcement `json:"taskValidationEnforcement"`
 }
 
+// TaskValidationEnforcement determines how task container validation failures are handled at registration.
+type TaskValidationEnforcement string
+
+const (
+	// Tasks failing validation are rejected.
+	TaskValidationEnforcementReject TaskValidationEnforcement = "REJECT"
+	// Validation failures are logged and the task is registered anyway.
+	TaskValidationEnforcementWarn TaskValidationEnforcement = "WARN"
+)
+
 // Provides validation limits used at entity registration
 type RegistrationValidationConfiguration interface {
 	GetWorkflowNodeLimit() int
 	GetMaxLabelEntries() int
 	GetMaxAnnotationEntries() int
 	GetWorkflowSizeLimit() string
+	GetTaskValidationEnforcement() TaskValidationEnforcement
 }
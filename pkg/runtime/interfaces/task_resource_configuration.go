@@ -14,4 +14,6 @@ type TaskResourceSet struct {
 type TaskResourceConfiguration interface {
 	GetDefaults() TaskResourceSet
 	GetLimits() TaskResourceSet
+	// Whether task resource attributes which only set a request should have the limit default to that request.
+	GetDefaultLimitsFromRequests() bool
 }
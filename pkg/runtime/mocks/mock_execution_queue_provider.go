@@ -5,6 +5,8 @@ import "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 type MockQueueConfigurationProvider struct {
 	executionQueues []interfaces.ExecutionQueue
 	workflowConfigs []interfaces.WorkflowConfig
+	// Whether execution queue attributes naming unknown tags are rejected.
+	RejectUnknownTags bool
 }
 
 func (p *MockQueueConfigurationProvider) GetExecutionQueues() []interfaces.ExecutionQueue {
@@ -15,6 +17,10 @@ func (p *MockQueueConfigurationProvider) GetWorkflowConfigs() []interfaces.Workf
 	return p.workflowConfigs
 }
 
+func (p *MockQueueConfigurationProvider) GetRejectUnknownTags() bool {
+	return p.RejectUnknownTags
+}
+
 func NewMockQueueConfigurationProvider(
 	executionQueues []interfaces.ExecutionQueue,
 	workflowConfigs []interfaces.WorkflowConfig) interfaces.QueueConfiguration {
@@ -3,10 +3,11 @@ package mocks
 import "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 
 type MockRegistrationValidationProvider struct {
-	WorkflowNodeLimit    int
-	MaxLabelEntries      int
-	MaxAnnotationEntries int
-	WorkflowSizeLimit    string
+	WorkflowNodeLimit         int
+	MaxLabelEntries           int
+	MaxAnnotationEntries      int
+	WorkflowSizeLimit         string
+	TaskValidationEnforcement interfaces.TaskValidationEnforcement
 }
 
 func (c *MockRegistrationValidationProvider) GetWorkflowNodeLimit() int {
@@ -25,6 +26,10 @@ func (c *MockRegistrationValidationProvider) GetWorkflowSizeLimit() string {
 	return c.WorkflowSizeLimit
 }
 
+func (c *MockRegistrationValidationProvider) GetTaskValidationEnforcement() interfaces.TaskValidationEnforcement {
+	return c.TaskValidationEnforcement
+}
+
 func NewMockRegistrationValidationProvider() interfaces.RegistrationValidationConfiguration {
 	return &MockRegistrationValidationProvider{}
 }
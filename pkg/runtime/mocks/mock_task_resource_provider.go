@@ -3,8 +3,9 @@ package mocks
 import "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 
 type MockTaskResourceConfiguration struct {
-	Defaults interfaces.TaskResourceSet
-	Limits   interfaces.TaskResourceSet
+	Defaults                  interfaces.TaskResourceSet
+	Limits                    interfaces.TaskResourceSet
+	DefaultLimitsFromRequests bool
 }
 
 func (c *MockTaskResourceConfiguration) GetDefaults() interfaces.TaskResourceSet {
@@ -13,6 +14,9 @@ func (c *MockTaskResourceConfiguration) GetDefaults() interfaces.TaskResourceSet
 func (c *MockTaskResourceConfiguration) GetLimits() interfaces.TaskResourceSet {
 	return c.Limits
 }
+func (c *MockTaskResourceConfiguration) GetDefaultLimitsFromRequests() bool {
+	return c.DefaultLimitsFromRequests
+}
 
 func NewMockTaskResourceConfiguration(defaults, limits interfaces.TaskResourceSet) interfaces.TaskResourceConfiguration {
 	return &MockTaskResourceConfiguration{
@@ -9,7 +9,8 @@ import (
 const registration = "registration"
 
 var registrationValidationConfig = config.MustRegisterSection(registration, &interfaces.RegistrationValidationConfig{
-	MaxWorkflowNodes: 100,
+	MaxWorkflowNodes:          100,
+	TaskValidationEnforcement: interfaces.TaskValidationEnforcementReject,
 })
 
 // Implementation of an interfaces.TaskResourceConfiguration
@@ -31,6 +32,10 @@ func (p *RegistrationValidationProvider) GetWorkflowSizeLimit() string {
 	return registrationValidationConfig.GetConfig().(*interfaces.RegistrationValidationConfig).WorkflowSizeLimit
 }
 
+func (p *RegistrationValidationProvider) GetTaskValidationEnforcement() interfaces.TaskValidationEnforcement {
+	return registrationValidationConfig.GetConfig().(*interfaces.RegistrationValidationConfig).TaskValidationEnforcement
+}
+
 func NewRegistrationValidationProvider() interfaces.RegistrationValidationConfiguration {
 	return &RegistrationValidationProvider{}
 }
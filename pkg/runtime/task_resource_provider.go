@@ -23,6 +23,8 @@ var taskResourceConfig = config.MustRegisterSection(taskResourceKey, &TaskResour
 type TaskResourceSpec struct {
 	Defaults interfaces.TaskResourceSet `json:"defaults"`
 	Limits   interfaces.TaskResourceSet `json:"limits"`
+	// When task resource attributes only set a request for a resource, the limit defaults to that request.
+	DefaultLimitsFromRequests bool `json:"defaultLimitsFromRequests"`
 }
 
 // Implementation of an interfaces.TaskResourceConfiguration
@@ -36,6 +38,10 @@ func (p *TaskResourceProvider) GetLimits() interfaces.TaskResourceSet {
 	return taskResourceConfig.GetConfig().(*TaskResourceSpec).Limits
 }
 
+func (p *TaskResourceProvider) GetDefaultLimitsFromRequests() bool {
+	return taskResourceConfig.GetConfig().(*TaskResourceSpec).DefaultLimitsFromRequests
+}
+
 func NewTaskResourceProvider() interfaces.TaskResourceConfiguration {
 	return &TaskResourceProvider{}
 }
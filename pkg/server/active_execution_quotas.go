@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path the active execution quota of a project and domain is read from, e.g.
+// GET /api/v1/active_execution_quotas?project=p&domain=d, and which admins set it at with PUT and a body of
+// {"max_active_executions": 200}, or remove it at with DELETE, so that the application config applies again.
+const ActiveExecutionQuotasPath = "/api/v1/active_execution_quotas"
+
+// The admin service methods requests to the active execution quotas are authorized as.
+const (
+	getActiveExecutionQuotaMethod    = "GetActiveExecutionQuota"
+	updateActiveExecutionQuotaMethod = "UpdateActiveExecutionQuota"
+	deleteActiveExecutionQuotaMethod = "DeleteActiveExecutionQuota"
+)
+
+type activeExecutionQuotasHandler struct {
+	resources interfaces.ResourceInterface
+}
+
+type updateActiveExecutionQuotaBody struct {
+	MaxActiveExecutions int32 `json:"max_active_executions"`
+}
+
+func (h *activeExecutionQuotasHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	scope := authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return getActiveExecutionQuotaMethod, scope
+	case http.MethodDelete:
+		return deleteActiveExecutionQuotaMethod, scope
+	}
+	return updateActiveExecutionQuotaMethod, scope
+}
+
+func (h *activeExecutionQuotasHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := interfaces.ActiveExecutionQuotaRequest{
+		Project: query.Get("project"),
+		Domain:  query.Get("domain"),
+	}
+	var response interface{}
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		response, err = h.resources.GetActiveExecutionQuota(r.Context(), request)
+	case http.MethodPut:
+		var body updateActiveExecutionQuotaBody
+		if decodeErr := json.NewDecoder(r.Body).Decode(&body); decodeErr != nil {
+			http.Error(w, "invalid active execution quota: "+decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		quota := interfaces.ActiveExecutionQuota{
+			Project:             request.Project,
+			Domain:              request.Domain,
+			MaxActiveExecutions: body.MaxActiveExecutions,
+		}
+		response, err = quota, h.resources.UpdateActiveExecutionQuota(r.Context(), quota)
+	case http.MethodDelete:
+		response, err = struct{}{}, h.resources.DeleteActiveExecutionQuota(r.Context(), request)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut+", "+http.MethodDelete)
+		http.Error(w, "only GET, PUT and DELETE requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewActiveExecutionQuotasHandler returns a handler reading and setting the active execution quotas of projects as
+// JSON. It stands in for a matchable resource type of quotas until there's one in the admin service definition, and
+// implements auth.AuthorizedHTTPHandler so that only admins can set quotas.
+func NewActiveExecutionQuotasHandler(resources interfaces.ResourceInterface) http.Handler {
+	return &activeExecutionQuotasHandler{
+		resources: resources,
+	}
+}
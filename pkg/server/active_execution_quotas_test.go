@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const activeExecutionQuotasQuery = ActiveExecutionQuotasPath + "?project=project&domain=domain"
+
+func TestActiveExecutionQuotasHandler(t *testing.T) {
+	var quota *interfaces.ActiveExecutionQuota
+	resources := mocks.MockResourceManager{
+		UpdateQuotaFunc: func(ctx context.Context, update interfaces.ActiveExecutionQuota) error {
+			quota = &update
+			return nil
+		},
+		GetQuotaFunc: func(ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) (
+			*interfaces.ActiveExecutionQuota, error) {
+			assert.Equal(t, interfaces.ActiveExecutionQuotaRequest{Project: "project", Domain: "domain"}, request)
+			if quota == nil {
+				return nil, errors.NewFlyteAdminError(codes.NotFound, "missing")
+			}
+			return quota, nil
+		},
+		DeleteQuotaFunc: func(ctx context.Context, request interfaces.ActiveExecutionQuotaRequest) error {
+			quota = nil
+			return nil
+		},
+	}
+	handler := NewActiveExecutionQuotasHandler(&resources)
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, activeExecutionQuotasQuery, strings.NewReader(body)))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, `{"max_active_executions": 200}`).Code)
+	recorder := serve(http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var got interfaces.ActiveExecutionQuota
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	assert.Equal(t, interfaces.ActiveExecutionQuota{Project: "project", Domain: "domain", MaxActiveExecutions: 200}, got)
+	assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "").Code)
+	assert.Nil(t, quota)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "200").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, "").Code)
+}
+
+func TestActiveExecutionQuotasHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewActiveExecutionQuotasHandler(&mocks.MockResourceManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	for method, expected := range map[string]string{
+		http.MethodGet:    "GetActiveExecutionQuota",
+		http.MethodPut:    "UpdateActiveExecutionQuota",
+		http.MethodDelete: "DeleteActiveExecutionQuota",
+	} {
+		authorizationMethod, scope := handler.AuthorizationMethod(
+			httptest.NewRequest(method, activeExecutionQuotasQuery, nil))
+		assert.Equal(t, expected, authorizationMethod)
+		assert.Equal(t, "project", scope.Project)
+		assert.Equal(t, "domain", scope.Domain)
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// The path clients terminate the executions of a project and domain in bulk at, e.g.
+// POST /api/v1/executions/bulk_terminate?project=p&domain=d with a body of
+// {"launchPlan": {"name": "lp", "version": "v1"}, "filters": "eq(phase,RUNNING)", "cause": "bad version"}. Setting
+// "dryRun" to true only reports the executions that would be terminated.
+const BulkTerminateExecutionsPath = "/api/v1/executions/bulk_terminate"
+
+// The admin service method requests to terminate executions in bulk are authorized as.
+const bulkTerminateExecutionsMethod = "BulkTerminateExecutions"
+
+type bulkTerminateExecutionsHandler struct {
+	executions interfaces.ExecutionInterface
+}
+
+type bulkTerminateExecutionsBody struct {
+	LaunchPlan *core.Identifier `json:"launchPlan"`
+	Filters    string           `json:"filters"`
+	Cause      string           `json:"cause"`
+	DryRun     bool             `json:"dryRun"`
+}
+
+type bulkTerminateExecutionsResponse struct {
+	Terminated      []*core.WorkflowExecutionIdentifier `json:"terminated"`
+	AlreadyTerminal int                                 `json:"alreadyTerminal"`
+	// The reason each execution that failed to terminate did, keyed by execution name.
+	Failed map[string]string `json:"failed"`
+}
+
+func (h *bulkTerminateExecutionsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return bulkTerminateExecutionsMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *bulkTerminateExecutionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var body bulkTerminateExecutionsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid bulk terminate request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	response, err := h.executions.BulkTerminateExecutions(r.Context(), interfaces.BulkTerminateExecutionsRequest{
+		Project:    query.Get("project"),
+		Domain:     query.Get("domain"),
+		LaunchPlan: body.LaunchPlan,
+		Filters:    body.Filters,
+		Cause:      body.Cause,
+		DryRun:     body.DryRun,
+	})
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	failed := make(map[string]string, len(response.Failed))
+	for name, failure := range response.Failed {
+		failed[name] = failure.Error()
+	}
+	writeJSON(w, r, http.StatusOK, bulkTerminateExecutionsResponse{
+		Terminated:      response.Terminated,
+		AlreadyTerminal: response.AlreadyTerminal,
+		Failed:          failed,
+	})
+}
+
+// NewBulkTerminateExecutionsHandler returns a handler terminating the executions of a project and domain selected by
+// launch plan or filters. It stands in for a bulk terminate rpc until it's part of the admin service definition, and
+// implements auth.AuthorizedHTTPHandler so that it requires the same access as terminating an execution of the project.
+func NewBulkTerminateExecutionsHandler(executions interfaces.ExecutionInterface) http.Handler {
+	return &bulkTerminateExecutionsHandler{
+		executions: executions,
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+const bulkTerminateExecutionsQuery = BulkTerminateExecutionsPath + "?project=project&domain=domain"
+
+func TestBulkTerminateExecutionsHandler(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetBulkTerminateExecutionsCallback(func(ctx context.Context,
+		request interfaces.BulkTerminateExecutionsRequest) (*interfaces.BulkTerminateExecutionsResponse, error) {
+		assert.Equal(t, "project", request.Project)
+		assert.Equal(t, "domain", request.Domain)
+		if request.LaunchPlan == nil {
+			return nil, adminErrors.NewFlyteAdminError(codes.InvalidArgument, "no launch plan")
+		}
+		assert.Equal(t, "lp", request.LaunchPlan.Name)
+		assert.Equal(t, "v1", request.LaunchPlan.Version)
+		assert.Equal(t, "bad version", request.Cause)
+		assert.True(t, request.DryRun)
+		return &interfaces.BulkTerminateExecutionsResponse{
+			Terminated: []*core.WorkflowExecutionIdentifier{
+				{Project: "project", Domain: "domain", Name: "e1"},
+			},
+			AlreadyTerminal: 2,
+			Failed: map[string]error{
+				"e2": errors.New("cluster unreachable"),
+			},
+		}, nil
+	})
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		NewBulkTerminateExecutionsHandler(&executions).ServeHTTP(recorder,
+			httptest.NewRequest(method, bulkTerminateExecutionsQuery, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := serve(http.MethodPost,
+		`{"launchPlan": {"name": "lp", "version": "v1"}, "cause": "bad version", "dryRun": true}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"name":"e1"`)
+	assert.Contains(t, recorder.Body.String(), `"alreadyTerminal":2`)
+	assert.Contains(t, recorder.Body.String(), `"failed":{"e2":"cluster unreachable"}`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"filters": "eq(phase,RUNNING)"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `not json`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "").Code)
+}
+
+func TestBulkTerminateExecutionsHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewBulkTerminateExecutionsHandler(&mocks.MockExecutionManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(
+		httptest.NewRequest(http.MethodPost, bulkTerminateExecutionsQuery, nil))
+	assert.Equal(t, "BulkTerminateExecutions", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// CertificateReloader serves a TLS certificate which is reloaded from disk whenever the certificate or key file
+// changes, so that rotated certificates are picked up without restarting the server. Clients of the server in the same
+// process, such as the grpc gateway, trust the reloaded certificate as well.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex       sync.RWMutex
+	cert        *tls.Certificate
+	certPool    *x509.CertPool
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func getModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// GetCertificate returns the most recently loaded certificate. It is meant to be used as tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.cert, nil
+}
+
+func loadCertPool(certFile string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	certPool := x509.NewCertPool()
+	if ok := certPool.AppendCertsFromPEM(data); !ok {
+		return nil, errors.Errorf(ErrCertificate, "failed to load certificate into the pool")
+	}
+	return certPool, nil
+}
+
+// Verifies the certificates presented by the server against the most recently loaded certificate, the way the tls
+// package would against a fixed pool of root certificates.
+func (r *CertificateReloader) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.Errorf(ErrCertificate, "the server presented no certificate")
+	}
+	r.mutex.RLock()
+	certPool := r.certPool
+	r.mutex.RUnlock()
+	serverName := state.ServerName
+	if host, _, err := net.SplitHostPort(serverName); err == nil {
+		serverName = host
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         certPool,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// GetClientTLSConfig returns the configuration for clients dialing the server at serverName, which trust the most
+// recently loaded certificate rather than the one loaded when they were configured.
+func (r *CertificateReloader) GetClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		ServerName: serverName,
+		// The certificate is verified by verifyConnection instead, against the certificate currently loaded.
+		InsecureSkipVerify: true, // nolint:gosec
+		VerifyConnection:   r.verifyConnection,
+	}
+}
+
+// Reload parses the certificate and key files and swaps in the new certificate. On failure the previously loaded
+// certificate continues to be served.
+func (r *CertificateReloader) Reload(ctx context.Context) error {
+	certModTime, err := getModTime(r.certFile)
+	if err != nil {
+		return errors.Wrapf(ErrCertificate, err, "failed to stat certificate file: %s", r.certFile)
+	}
+	keyModTime, err := getModTime(r.keyFile)
+	if err != nil {
+		return errors.Wrapf(ErrCertificate, err, "failed to stat key file: %s", r.keyFile)
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	var certPool *x509.CertPool
+	if err == nil {
+		certPool, err = loadCertPool(r.certFile)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	// Record the attempt even on failure so that a bad pair is not retried until the files change again.
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	if err != nil {
+		return errors.Wrapf(ErrCertificate, err, "failed to load X509 key pair: %s", r.certFile)
+	}
+	r.cert = &cert
+	r.certPool = certPool
+	logger.Infof(ctx, "Loaded TLS certificate from %s", r.certFile)
+	return nil
+}
+
+func (r *CertificateReloader) hasChanged() bool {
+	certModTime, err := getModTime(r.certFile)
+	if err != nil {
+		return false
+	}
+	keyModTime, err := getModTime(r.keyFile)
+	if err != nil {
+		return false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return !certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime)
+}
+
+func (r *CertificateReloader) reloadIfChanged(ctx context.Context) {
+	if !r.hasChanged() {
+		return
+	}
+	if err := r.Reload(ctx); err != nil {
+		logger.Errorf(ctx, "Failed to reload TLS certificate, continuing to serve the previous one: %v", err)
+	}
+}
+
+// Start polls the certificate and key files every interval and reloads the certificate when either changes. Polling
+// stops once ctx is done.
+func (r *CertificateReloader) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.reloadIfChanged(ctx)
+			}
+		}
+	}()
+}
+
+func NewCertificateReloader(ctx context.Context, certFile, keyFile string) (*CertificateReloader, error) {
+	reloader := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	if err := reloader.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testKeyPair struct {
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func generateKeyPair(t *testing.T, serial int64) testKeyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return testKeyPair{
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+// Writes the files and bumps their modification times so that changes are detected regardless of the file system's
+// timestamp granularity.
+func writeKeyPair(t *testing.T, certFile, keyFile string, certPEM, keyPEM []byte, modTime time.Time) {
+	assert.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+	assert.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	assert.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+// Opens a new TLS connection and returns the serial number of the certificate presented by the server.
+func getServedSerial(t *testing.T, address string) int64 {
+	conn, err := tls.Dial("tcp", address, &tls.Config{
+		InsecureSkipVerify: true, // nolint:gosec
+	})
+	assert.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+// Opens a new TLS connection verifying the certificate presented by the server with the config given.
+func dialVerified(address string, config *tls.Config) error {
+	conn, err := tls.Dial("tcp", address, config)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func TestCertificateReloader(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "cert_reloader")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	modTime := time.Now().Add(-time.Hour)
+	original := generateKeyPair(t, 1)
+	writeKeyPair(t, certFile, keyFile, original.certPEM, original.keyPEM, modTime)
+	reloader, err := NewCertificateReloader(ctx, certFile, keyFile)
+	assert.NoError(t, err)
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{GetCertificate: reloader.GetCertificate})
+	assert.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = conn.(*tls.Conn).Handshake()
+				_ = conn.Close()
+			}()
+		}
+	}()
+	assert.Equal(t, int64(1), getServedSerial(t, lis.Addr().String()))
+	clientConfig := reloader.GetClientTLSConfig("localhost:8443")
+	assert.NoError(t, dialVerified(lis.Addr().String(), clientConfig))
+
+	t.Run("unchanged", func(t *testing.T) {
+		assert.False(t, reloader.hasChanged())
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		modTime = modTime.Add(time.Minute)
+		rotated := generateKeyPair(t, 2)
+		writeKeyPair(t, certFile, keyFile, rotated.certPEM, rotated.keyPEM, modTime)
+		reloader.reloadIfChanged(ctx)
+		assert.Equal(t, int64(2), getServedSerial(t, lis.Addr().String()))
+		// Clients configured before the rotation trust the rotated certificate.
+		assert.NoError(t, dialVerified(lis.Addr().String(), clientConfig))
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		otherDir, err := ioutil.TempDir("", "cert_reloader")
+		assert.NoError(t, err)
+		defer os.RemoveAll(otherDir)
+		other := generateKeyPair(t, 5)
+		otherCertFile := filepath.Join(otherDir, "tls.crt")
+		otherKeyFile := filepath.Join(otherDir, "tls.key")
+		writeKeyPair(t, otherCertFile, otherKeyFile, other.certPEM, other.keyPEM, modTime)
+		otherReloader, err := NewCertificateReloader(ctx, otherCertFile, otherKeyFile)
+		assert.NoError(t, err)
+		assert.Error(t, dialVerified(lis.Addr().String(), otherReloader.GetClientTLSConfig("localhost")))
+	})
+
+	t.Run("mismatched key pair", func(t *testing.T) {
+		modTime = modTime.Add(time.Minute)
+		writeKeyPair(t, certFile, keyFile, generateKeyPair(t, 3).certPEM, generateKeyPair(t, 4).keyPEM, modTime)
+		reloader.reloadIfChanged(ctx)
+		// The previously loaded certificate continues to be served.
+		assert.Equal(t, int64(2), getServedSerial(t, lis.Addr().String()))
+		assert.False(t, reloader.hasChanged())
+	})
+}
+
+func TestNewCertificateReloader_MissingFiles(t *testing.T) {
+	_, err := NewCertificateReloader(context.Background(), "/does/not/exist.crt", "/does/not/exist.key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	headerOrigin                        = "Origin"
+	headerVary                          = "Vary"
+	headerAccessControlRequestMethod    = "Access-Control-Request-Method"
+	headerAccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	headerAccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	headerAccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAccessControlMaxAge           = "Access-Control-Max-Age"
+
+	wildcard = "*"
+)
+
+var defaultCorsMethods = []string{"GET", "POST", "DELETE", "HEAD", "PUT", "PATCH"}
+
+// Cached preflight responses are honoured by browsers for up to this many seconds.
+const corsMaxAgeSeconds = 600
+
+type CorsOptions struct {
+	// Origins which may issue cross origin requests. An entry may be "*" to allow all origins or contain a single
+	// wildcard in place of a subdomain, e.g. https://*.example.com. Origins only allowed by "*" never get to include
+	// credentials, whatever AllowCredentials says.
+	AllowedOrigins []string
+	// Request headers which cross origin requests may set.
+	AllowedHeaders []string
+	// Whether cross origin requests may include credentials such as the auth cookies.
+	AllowCredentials bool
+}
+
+type corsHandler struct {
+	options        CorsOptions
+	allowedMethods string
+	allowedHeaders string
+	next           http.Handler
+}
+
+// Matches an origin against a pattern which may contain a wildcard standing in for one or more subdomain labels. The
+// "*" pattern is matched by the caller, since it allows different responses.
+func originMatches(pattern, origin string) bool {
+	idx := strings.Index(pattern, wildcard)
+	if idx < 0 {
+		return strings.EqualFold(pattern, origin)
+	}
+	prefix, suffix := strings.ToLower(pattern[:idx]), strings.ToLower(pattern[idx+1:])
+	origin = strings.ToLower(origin)
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) ||
+		!strings.HasSuffix(origin, suffix) {
+		return false
+	}
+	// The wildcard may only expand to host labels, never to a different scheme, port or path.
+	return !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], ":/")
+}
+
+// Returns whether the origin is allowed, and whether only by "*".
+func (c *corsHandler) isOriginAllowed(origin string) (allowed bool, anyOrigin bool) {
+	for _, pattern := range c.options.AllowedOrigins {
+		if pattern == wildcard {
+			anyOrigin = true
+		} else if originMatches(pattern, origin) {
+			return true, false
+		}
+	}
+	return anyOrigin, anyOrigin
+}
+
+func (c *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(headerOrigin)
+	if len(origin) == 0 {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	isPreflight := r.Method == http.MethodOptions && len(r.Header.Get(headerAccessControlRequestMethod)) > 0
+	w.Header().Add(headerVary, headerOrigin)
+	allowed, anyOrigin := c.isOriginAllowed(origin)
+	if !allowed {
+		if isPreflight {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		// Browsers will refuse to expose the response to the caller without the allow headers.
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	if anyOrigin {
+		// Any site could otherwise make requests carrying the credentials of the user, such as the auth cookies.
+		w.Header().Set(headerAccessControlAllowOrigin, wildcard)
+	} else {
+		// Listed origins are echoed back rather than "*" since the latter is rejected by browsers for credentialed
+		// requests.
+		w.Header().Set(headerAccessControlAllowOrigin, origin)
+		if c.options.AllowCredentials {
+			w.Header().Set(headerAccessControlAllowCredentials, "true")
+		}
+	}
+	if isPreflight {
+		w.Header().Set(headerAccessControlAllowMethods, c.allowedMethods)
+		w.Header().Set(headerAccessControlAllowHeaders, c.allowedHeaders)
+		w.Header().Set(headerAccessControlMaxAge, strconv.Itoa(corsMaxAgeSeconds))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	c.next.ServeHTTP(w, r)
+}
+
+// NewCorsHandler wraps next with a handler which answers CORS preflight requests and annotates responses to allowed
+// origins with the appropriate Access-Control-Allow-* headers.
+func NewCorsHandler(options CorsOptions, next http.Handler) http.Handler {
+	return &corsHandler{
+		options:        options,
+		allowedMethods: strings.Join(defaultCorsMethods, ", "),
+		allowedHeaders: strings.Join(options.AllowedHeaders, ", "),
+		next:           next,
+	}
+}
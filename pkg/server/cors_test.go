@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func getTestCorsHandler(allowCredentials bool) http.Handler {
+	return NewCorsHandler(CorsOptions{
+		AllowedOrigins:   []string{"https://console.example.com", "https://*.flyte.org"},
+		AllowedHeaders:   []string{"Content-Type", "Flyte-Authorization"},
+		AllowCredentials: allowCredentials,
+	}, okHandler)
+}
+
+func preflightRequest(origin string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/projects", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	return req
+}
+
+func TestCorsHandler_AllowedOrigin(t *testing.T) {
+	handler := getTestCorsHandler(true)
+	for _, origin := range []string{"https://console.example.com", "https://sandbox.flyte.org",
+		"https://a.b.flyte.org"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, preflightRequest(origin))
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, origin, recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Content-Type, Flyte-Authorization", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.NotEmpty(t, recorder.Header().Get("Access-Control-Allow-Methods"))
+
+		req := httptest.NewRequest(http.MethodGet, "/login", nil)
+		req.Header.Set("Origin", origin)
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, origin, recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestCorsHandler_DeniedOrigin(t *testing.T) {
+	handler := getTestCorsHandler(true)
+	for _, origin := range []string{"https://evil.com", "http://sandbox.flyte.org", "https://flyte.org",
+		"https://evil.com/.flyte.org", "https://console.example.com.evil.com"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, preflightRequest(origin))
+		assert.Equal(t, http.StatusForbidden, recorder.Code, origin)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+		req.Header.Set("Origin", origin)
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+}
+
+func TestCorsHandler_Credentials(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	getTestCorsHandler(true).ServeHTTP(recorder, preflightRequest("https://console.example.com"))
+	assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+
+	recorder = httptest.NewRecorder()
+	getTestCorsHandler(false).ServeHTTP(recorder, preflightRequest("https://console.example.com"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCorsHandler_AnyOrigin(t *testing.T) {
+	handler := NewCorsHandler(CorsOptions{
+		AllowedOrigins:   []string{"*", "https://console.example.com"},
+		AllowCredentials: true,
+	}, okHandler)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, preflightRequest("https://evil.com"))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Listed origins are still allowed to include credentials.
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, preflightRequest("https://console.example.com"))
+	assert.Equal(t, "https://console.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCorsHandler_NoOrigin(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	getTestCorsHandler(true).ServeHTTP(recorder, httptest.NewRequest(http.MethodOptions, "/healthcheck", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Vary"))
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The paths clients count the executions and tasks a list request would page through from, e.g.
+// GET /api/v1/count/executions?project=p&domain=d&filters=eq(phase,RUNNING). Counts of whole tables are estimated from
+// table statistics with GET /api/v1/count/executions?approximate=true.
+const (
+	ExecutionCountPath = "/api/v1/count/executions"
+	TaskCountPath      = "/api/v1/count/tasks"
+)
+
+// The admin service methods count requests are authorized as.
+const (
+	countExecutionsMethod = "CountExecutions"
+	countTasksMethod      = "CountTasks"
+)
+
+type countHandler struct {
+	method string
+	count  func(ctx context.Context, request interfaces.CountResourceRequest) (*interfaces.CountResourceResponse, error)
+}
+
+// Reads the count request from the query, with the filters in the same format as those of list requests.
+func getCountResourceRequest(r *http.Request) (interfaces.CountResourceRequest, error) {
+	query := r.URL.Query()
+	request := interfaces.CountResourceRequest{
+		Project: query.Get("project"),
+		Domain:  query.Get("domain"),
+		Filters: query.Get("filters"),
+	}
+	if approximate := query.Get("approximate"); len(approximate) > 0 {
+		var err error
+		if request.Approximate, err = strconv.ParseBool(approximate); err != nil {
+			return request, err
+		}
+	}
+	return request, nil
+}
+
+func (h *countHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return h.method, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *countHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	request, err := getCountResourceRequest(r)
+	if err != nil {
+		http.Error(w, "invalid count request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.count(r.Context(), request)
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewExecutionCountHandler returns a handler serving the number of executions matching list filters as JSON. It stands
+// in for a count rpc until one is part of the admin service definition, and implements auth.AuthorizedHTTPHandler so
+// that counting executions requires the same access as listing them.
+func NewExecutionCountHandler(executions interfaces.ExecutionInterface) http.Handler {
+	return &countHandler{
+		method: countExecutionsMethod,
+		count:  executions.CountExecutions,
+	}
+}
+
+// NewTaskCountHandler is the task counterpart of NewExecutionCountHandler.
+func NewTaskCountHandler(tasks interfaces.TaskInterface) http.Handler {
+	return &countHandler{
+		method: countTasksMethod,
+		count:  tasks.CountTasks,
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const executionCountQuery = ExecutionCountPath + "?project=project&domain=domain&filters=eq(phase,RUNNING)"
+
+func TestExecutionCountHandler(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetCountExecutionsCallback(func(ctx context.Context, request interfaces.CountResourceRequest) (
+		*interfaces.CountResourceResponse, error) {
+		assert.Equal(t, interfaces.CountResourceRequest{
+			Project: "project",
+			Domain:  "domain",
+			Filters: "eq(phase,RUNNING)",
+		}, request)
+		return &interfaces.CountResourceResponse{Count: 42}, nil
+	})
+	recorder := httptest.NewRecorder()
+	NewExecutionCountHandler(&executions).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, executionCountQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.CountResourceResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, int64(42), response.Count)
+	assert.False(t, response.Approximate)
+}
+
+func TestExecutionCountHandler_Approximate(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetCountExecutionsCallback(func(ctx context.Context, request interfaces.CountResourceRequest) (
+		*interfaces.CountResourceResponse, error) {
+		assert.True(t, request.Approximate)
+		return &interfaces.CountResourceResponse{Count: 1000, Approximate: true}, nil
+	})
+	recorder := httptest.NewRecorder()
+	NewExecutionCountHandler(&executions).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, ExecutionCountPath+"?approximate=true", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"count":1000,"approximate":true}`, recorder.Body.String())
+}
+
+func TestExecutionCountHandler_Errors(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetCountExecutionsCallback(func(ctx context.Context, request interfaces.CountResourceRequest) (
+		*interfaces.CountResourceResponse, error) {
+		return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "approximate counts can't be filtered")
+	})
+	handler := NewExecutionCountHandler(&executions)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, executionCountQuery+"&approximate=true", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "approximate counts can't be filtered")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ExecutionCountPath+"?approximate=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, executionCountQuery, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestCountHandlers_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewExecutionCountHandler(&mocks.MockExecutionManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, executionCountQuery, nil))
+	assert.Equal(t, "CountExecutions", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+
+	handler, ok = NewTaskCountHandler(&mocks.MockTaskManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, _ = handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, TaskCountPath, nil))
+	assert.Equal(t, "CountTasks", method)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path clients request upload locations from.
+const CreateUploadLocationPath = "/api/v1/dataproxy/artifact_urn"
+
+// The admin service method requests for upload locations are authorized as.
+const createUploadLocationMethod = "CreateUploadLocation"
+
+// Bounds the upload location requests read, which only hold a few short fields.
+const maxUploadLocationBodyBytes = 1 << 16
+
+type createUploadLocationHandler struct {
+	dataProxy interfaces.DataProxyInterface
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Errorf(r.Context(), "failed to write response to %s, error: %v", r.URL.Path, err)
+	}
+}
+
+// The project and domain are read from the body, which is put back for ServeHTTP to decode. Requests whose body can't
+// be decoded are authorized without a project and domain, and rejected once served.
+func (h *createUploadLocationHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxUploadLocationBodyBytes+1))
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil {
+		return createUploadLocationMethod, authInterfaces.ResourceScope{}
+	}
+	var request interfaces.CreateUploadLocationRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return createUploadLocationMethod, authInterfaces.ResourceScope{}
+	}
+	return createUploadLocationMethod, authInterfaces.ResourceScope{
+		Project: request.Project,
+		Domain:  request.Domain,
+	}
+}
+
+func (h *createUploadLocationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var request interfaces.CreateUploadLocationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUploadLocationBodyBytes)).Decode(&request); err != nil {
+		http.Error(w, "invalid upload location request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.dataProxy.CreateUploadLocation(r.Context(), request)
+	if err != nil {
+		// Admin errors carry a grpc status, which is translated the same way the grpc gateway does.
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewCreateUploadLocationHandler returns a handler serving JSON encoded upload location requests. It stands in for a
+// data proxy rpc until one is part of the admin service definition, and implements auth.AuthorizedHTTPHandler so that
+// requesting an upload location requires the same access as calling that rpc would.
+func NewCreateUploadLocationHandler(dataProxy interfaces.DataProxyInterface) http.Handler {
+	return &createUploadLocationHandler{
+		dataProxy: dataProxy,
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+func TestCreateUploadLocationHandler(t *testing.T) {
+	dataProxy := mocks.DataProxyManager{
+		CreateUploadLocationFunc: func(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+			*interfaces.CreateUploadLocationResponse, error) {
+			assert.Equal(t, interfaces.CreateUploadLocationRequest{
+				Project:       "project",
+				Domain:        "domain",
+				Filename:      "fast-registration.tar.gz",
+				ContentMD5:    []byte{1, 2, 3},
+				ContentLength: 100,
+			}, request)
+			return &interfaces.CreateUploadLocationResponse{
+				SignedURL: "https://signed",
+				NativeURL: "s3://bucket/key",
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewCreateUploadLocationHandler(&dataProxy).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost,
+		CreateUploadLocationPath, strings.NewReader(
+			`{"project":"project","domain":"domain","filename":"fast-registration.tar.gz","contentMd5":"AQID","contentLength":100}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.CreateUploadLocationResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "https://signed", response.SignedURL)
+	assert.Equal(t, "s3://bucket/key", response.NativeURL)
+}
+
+func TestCreateUploadLocationHandler_Errors(t *testing.T) {
+	dataProxy := mocks.DataProxyManager{
+		CreateUploadLocationFunc: func(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+			*interfaces.CreateUploadLocationResponse, error) {
+			return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "content length exceeds the limit")
+		},
+	}
+	handler := NewCreateUploadLocationHandler(&dataProxy)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, CreateUploadLocationPath, strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "content length exceeds the limit")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, CreateUploadLocationPath, strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, CreateUploadLocationPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestCreateUploadLocationHandler_AuthorizationMethod(t *testing.T) {
+	dataProxy := mocks.DataProxyManager{
+		CreateUploadLocationFunc: func(ctx context.Context, request interfaces.CreateUploadLocationRequest) (
+			*interfaces.CreateUploadLocationResponse, error) {
+			assert.Equal(t, "project", request.Project)
+			return &interfaces.CreateUploadLocationResponse{}, nil
+		},
+	}
+	handler, ok := NewCreateUploadLocationHandler(&dataProxy).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	request := httptest.NewRequest(http.MethodPost, CreateUploadLocationPath,
+		strings.NewReader(`{"project":"project","domain":"domain","filename":"fast-registration.tar.gz"}`))
+	method, scope := handler.AuthorizationMethod(request)
+	assert.Equal(t, "CreateUploadLocation", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+
+	// The body is still served once it was read for authorization.
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestCreateUploadLocationHandler_BodyTooLarge(t *testing.T) {
+	handler := NewCreateUploadLocationHandler(&mocks.DataProxyManager{})
+	body := `{"project":"` + strings.Repeat("p", maxUploadLocationBodyBytes) + `"}`
+	request := httptest.NewRequest(http.MethodPost, CreateUploadLocationPath, strings.NewReader(body))
+	_, scope := handler.(auth.AuthorizedHTTPHandler).AuthorizationMethod(request)
+	assert.Empty(t, scope.Project)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "request body too large")
+}
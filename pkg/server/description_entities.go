@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// The path the long form documentation of registered entities is served from. A version is fetched with, e.g.
+// GET /api/v1/description_entities?resource_type=WORKFLOW&project=p&domain=d&name=wf&version=v, and leaving out the
+// version lists the versions which have documentation, a page at a time as set by the limit and token parameters.
+// Documentation is attached to a registered version by POSTing a body of
+// {"longDescription": "...", "contentType": "text/markdown", "sourceCodeLink": "https://..."} to the same query.
+const DescriptionEntitiesPath = "/api/v1/description_entities"
+
+// The admin service methods requests to description entities are authorized as.
+const (
+	getDescriptionEntityMethod    = "GetDescriptionEntity"
+	listDescriptionEntitiesMethod = "ListDescriptionEntities"
+	createDescriptionEntityMethod = "CreateDescriptionEntity"
+)
+
+// Bounds the documentation bodies read. The manager enforces the limit on the long description itself, this leaves
+// room for escaping it as JSON.
+const maxDescriptionEntityBodyBytes = 1 << 20
+
+type descriptionEntitiesHandler struct {
+	descriptionEntities interfaces.DescriptionEntityInterface
+}
+
+// Reads the identifier of the entity from the query. The resource type is given by name, e.g. LAUNCH_PLAN.
+func getDescribedEntityID(r *http.Request) (core.Identifier, error) {
+	query := r.URL.Query()
+	resourceType, ok := core.ResourceType_value[query.Get("resource_type")]
+	if !ok {
+		return core.Identifier{}, fmt.Errorf("unknown resource type [%s]", query.Get("resource_type"))
+	}
+	return core.Identifier{
+		ResourceType: core.ResourceType(resourceType),
+		Project:      query.Get("project"),
+		Domain:       query.Get("domain"),
+		Name:         query.Get("name"),
+		Version:      query.Get("version"),
+	}, nil
+}
+
+func (h *descriptionEntitiesHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	scope := authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+	if r.Method != http.MethodGet {
+		return createDescriptionEntityMethod, scope
+	}
+	if len(r.URL.Query().Get("version")) > 0 {
+		return getDescriptionEntityMethod, scope
+	}
+	return listDescriptionEntitiesMethod, scope
+}
+
+// Reads the page of versions to list from the query.
+func getDescriptionEntitiesListRequest(r *http.Request, id core.Identifier) (admin.ResourceListRequest, error) {
+	query := r.URL.Query()
+	request := admin.ResourceListRequest{
+		Id: &admin.NamedEntityIdentifier{
+			Project: id.Project,
+			Domain:  id.Domain,
+			Name:    id.Name,
+		},
+		Token:   query.Get("token"),
+		Filters: query.Get("filters"),
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		parsed, err := strconv.ParseUint(limit, 10, 32)
+		if err != nil {
+			return request, err
+		}
+		request.Limit = uint32(parsed)
+	}
+	return request, nil
+}
+
+func (h *descriptionEntitiesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+		http.Error(w, "only GET and POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := getDescribedEntityID(r)
+	if err != nil {
+		http.Error(w, "invalid description entity request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var response interface{}
+	switch {
+	case r.Method == http.MethodPost:
+		var body interfaces.DescriptionEntity
+		if decodeErr := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDescriptionEntityBodyBytes)).Decode(
+			&body); decodeErr != nil {
+			http.Error(w, "invalid description entity: "+decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		body.ID = &id
+		if err = h.descriptionEntities.CreateDescriptionEntity(r.Context(), body); err == nil {
+			response = &body
+		}
+	case len(id.Version) > 0:
+		response, err = h.descriptionEntities.GetDescriptionEntity(r.Context(), id)
+	default:
+		request, parseErr := getDescriptionEntitiesListRequest(r, id)
+		if parseErr != nil {
+			http.Error(w, "invalid description entity request: "+parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		response, err = h.descriptionEntities.ListDescriptionEntities(r.Context(), id.ResourceType, request)
+	}
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewDescriptionEntitiesHandler returns a handler serving and attaching the long form documentation of registered
+// workflows, tasks and launch plans as JSON. It stands in for description entity rpcs until they're part of the admin
+// service definition, and implements auth.AuthorizedHTTPHandler so that attaching documentation requires the same
+// access as registering.
+func NewDescriptionEntitiesHandler(descriptionEntities interfaces.DescriptionEntityInterface) http.Handler {
+	return &descriptionEntitiesHandler{
+		descriptionEntities: descriptionEntities,
+	}
+}
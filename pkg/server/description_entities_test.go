@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+const descriptionEntitiesQuery = DescriptionEntitiesPath +
+	"?resource_type=WORKFLOW&project=project&domain=domain&name=name"
+
+var describedWorkflowID = core.Identifier{
+	ResourceType: core.ResourceType_WORKFLOW,
+	Project:      "project",
+	Domain:       "domain",
+	Name:         "name",
+	Version:      "version",
+}
+
+func TestDescriptionEntitiesHandler_Get(t *testing.T) {
+	descriptionEntities := mocks.DescriptionEntityManager{
+		GetDescriptionEntityFunc: func(ctx context.Context, id core.Identifier) (*interfaces.DescriptionEntity, error) {
+			assert.Equal(t, describedWorkflowID, id)
+			return &interfaces.DescriptionEntity{
+				ID:              &id,
+				LongDescription: "# README",
+				ContentType:     "text/markdown",
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewDescriptionEntitiesHandler(&descriptionEntities).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery+"&version=version", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.DescriptionEntity
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "# README", response.LongDescription)
+	assert.Equal(t, "version", response.ID.Version)
+}
+
+func TestDescriptionEntitiesHandler_List(t *testing.T) {
+	descriptionEntities := mocks.DescriptionEntityManager{
+		ListDescriptionEntitiesFunc: func(ctx context.Context, resourceType core.ResourceType,
+			request admin.ResourceListRequest) (*interfaces.DescriptionEntityList, error) {
+			assert.Equal(t, core.ResourceType_WORKFLOW, resourceType)
+			assert.Equal(t, "name", request.Id.Name)
+			assert.Equal(t, uint32(10), request.Limit)
+			assert.Equal(t, "20", request.Token)
+			return &interfaces.DescriptionEntityList{
+				DescriptionEntities: []*interfaces.DescriptionEntity{{ID: &describedWorkflowID}},
+				Token:               "30",
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewDescriptionEntitiesHandler(&descriptionEntities).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery+"&limit=10&token=20", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.DescriptionEntityList
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response.DescriptionEntities, 1)
+	assert.Equal(t, "30", response.Token)
+}
+
+func TestDescriptionEntitiesHandler_Create(t *testing.T) {
+	var created bool
+	descriptionEntities := mocks.DescriptionEntityManager{
+		CreateDescriptionEntityFunc: func(ctx context.Context, request interfaces.DescriptionEntity) error {
+			assert.Equal(t, describedWorkflowID, *request.ID)
+			assert.Equal(t, "# README", request.LongDescription)
+			assert.Equal(t, "https://github.com/flyteorg/flytesnacks", request.SourceCodeLink)
+			created = true
+			return nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewDescriptionEntitiesHandler(&descriptionEntities).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodPost, descriptionEntitiesQuery+"&version=version", strings.NewReader(
+			`{"longDescription": "# README", "sourceCodeLink": "https://github.com/flyteorg/flytesnacks"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, created)
+}
+
+func TestDescriptionEntitiesHandler_Errors(t *testing.T) {
+	t.Run("manager error", func(t *testing.T) {
+		descriptionEntities := mocks.DescriptionEntityManager{
+			GetDescriptionEntityFunc: func(ctx context.Context, id core.Identifier) (*interfaces.DescriptionEntity, error) {
+				return nil, errors.NewFlyteAdminError(codes.NotFound, "missing")
+			},
+		}
+		recorder := httptest.NewRecorder()
+		NewDescriptionEntitiesHandler(&descriptionEntities).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery+"&version=version", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "missing")
+	})
+	t.Run("resource type", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewDescriptionEntitiesHandler(&mocks.DescriptionEntityManager{}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, DescriptionEntitiesPath+"?resource_type=WORKFLOWS&project=project", nil))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("limit", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewDescriptionEntitiesHandler(&mocks.DescriptionEntityManager{}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery+"&limit=-1", nil))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("body too large", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewDescriptionEntitiesHandler(&mocks.DescriptionEntityManager{}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodPost, descriptionEntitiesQuery+"&version=version", strings.NewReader(
+				`{"longDescription": "`+strings.Repeat("a", maxDescriptionEntityBodyBytes)+`"}`)))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("method", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewDescriptionEntitiesHandler(&mocks.DescriptionEntityManager{}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodDelete, descriptionEntitiesQuery, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestDescriptionEntitiesHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewDescriptionEntitiesHandler(&mocks.DescriptionEntityManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	for expected, request := range map[string]*http.Request{
+		"GetDescriptionEntity":    httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery+"&version=v", nil),
+		"ListDescriptionEntities": httptest.NewRequest(http.MethodGet, descriptionEntitiesQuery, nil),
+		"CreateDescriptionEntity": httptest.NewRequest(http.MethodPost, descriptionEntitiesQuery+"&version=v", nil),
+	} {
+		method, scope := handler.AuthorizationMethod(request)
+		assert.Equal(t, expected, method)
+		assert.Equal(t, "project", scope.Project)
+		assert.Equal(t, "domain", scope.Domain)
+	}
+}
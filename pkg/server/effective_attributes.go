@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+// The path clients get the matchable attributes executions are created with from, e.g.
+// GET /api/v1/effective_attributes?project=p&domain=d&workflow=w&resource_type=TASK_RESOURCE. The workflow is optional.
+// The response holds the attributes along with the level, or the application config, each of their fields comes from.
+const EffectiveAttributesPath = "/api/v1/effective_attributes"
+
+// The admin service method requests for effective attributes are authorized as.
+const getEffectiveMatchableAttributesMethod = "GetEffectiveMatchableAttributes"
+
+type effectiveAttributesResponse struct {
+	Attributes json.RawMessage              `json:"attributes,omitempty"`
+	Sources    []interfaces.AttributeSource `json:"sources"`
+}
+
+type effectiveAttributesHandler struct {
+	resources interfaces.ResourceInterface
+}
+
+func getEffectiveAttributesRequest(r *http.Request) (interfaces.EffectiveAttributesRequest, error) {
+	query := r.URL.Query()
+	resourceType, ok := admin.MatchableResource_value[query.Get("resource_type")]
+	if !ok {
+		return interfaces.EffectiveAttributesRequest{}, fmt.Errorf("unknown resource type [%s]",
+			query.Get("resource_type"))
+	}
+	return interfaces.EffectiveAttributesRequest{
+		Project:      query.Get("project"),
+		Domain:       query.Get("domain"),
+		Workflow:     query.Get("workflow"),
+		ResourceType: admin.MatchableResource(resourceType),
+	}, nil
+}
+
+func (h *effectiveAttributesHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return getEffectiveMatchableAttributesMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *effectiveAttributesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	request, err := getEffectiveAttributesRequest(r)
+	if err != nil {
+		http.Error(w, "invalid effective attributes request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.resources.GetEffectiveMatchableAttributes(r.Context(), request)
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	body := effectiveAttributesResponse{
+		Sources: response.Sources,
+	}
+	if response.Attributes != nil {
+		if body.Attributes, err = marshalProtoJSON(response.Attributes); err != nil {
+			writeJSON(w, r, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+	}
+	writeJSON(w, r, http.StatusOK, body)
+}
+
+// NewEffectiveAttributesHandler returns a handler serving the matchable attributes executions are created with. It
+// stands in for a GetEffectiveMatchableAttributes rpc until one is part of the admin service definition, and implements
+// auth.AuthorizedHTTPHandler so that getting effective attributes requires the same access as getting attributes.
+func NewEffectiveAttributesHandler(resources interfaces.ResourceInterface) http.Handler {
+	return &effectiveAttributesHandler{
+		resources: resources,
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+const effectiveAttributesQuery = EffectiveAttributesPath +
+	"?project=project&domain=domain&workflow=workflow&resource_type=TASK_RESOURCE"
+
+func TestEffectiveAttributesHandler(t *testing.T) {
+	resources := mocks.MockResourceManager{
+		GetEffectiveFunc: func(ctx context.Context, request interfaces.EffectiveAttributesRequest) (
+			*interfaces.EffectiveAttributesResponse, error) {
+			assert.Equal(t, interfaces.EffectiveAttributesRequest{
+				Project:      "project",
+				Domain:       "domain",
+				Workflow:     "workflow",
+				ResourceType: admin.MatchableResource_TASK_RESOURCE,
+			}, request)
+			return &interfaces.EffectiveAttributesResponse{
+				Attributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_TaskResourceAttributes{
+						TaskResourceAttributes: &admin.TaskResourceAttributes{
+							Defaults: &admin.TaskResourceSpec{Cpu: "1"},
+						},
+					},
+				},
+				Sources: []interfaces.AttributeSource{
+					{Field: "defaults.cpu", Level: interfaces.ResourceLevelWorkflow, DefaultedFrom: "limits.cpu"},
+				},
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewEffectiveAttributesHandler(&resources).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, effectiveAttributesQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response effectiveAttributesResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	var attributes admin.MatchingAttributes
+	assert.NoError(t, jsonpb.UnmarshalString(string(response.Attributes), &attributes))
+	assert.Equal(t, "1", attributes.GetTaskResourceAttributes().Defaults.Cpu)
+	assert.Contains(t, string(response.Attributes), `"task_resource_attributes"`)
+	assert.Equal(t, []interfaces.AttributeSource{
+		{Field: "defaults.cpu", Level: interfaces.ResourceLevelWorkflow, DefaultedFrom: "limits.cpu"},
+	}, response.Sources)
+}
+
+func TestEffectiveAttributesHandler_Errors(t *testing.T) {
+	resources := mocks.MockResourceManager{
+		GetEffectiveFunc: func(ctx context.Context, request interfaces.EffectiveAttributesRequest) (
+			*interfaces.EffectiveAttributesResponse, error) {
+			return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "missing project")
+		},
+	}
+	handler := NewEffectiveAttributesHandler(&resources)
+	for _, test := range []struct {
+		name   string
+		method string
+		path   string
+		code   int
+	}{
+		{"manager error", http.MethodGet, EffectiveAttributesPath + "?resource_type=TASK_RESOURCE",
+			http.StatusBadRequest},
+		{"resource type", http.MethodGet, EffectiveAttributesPath + "?project=p&domain=d&resource_type=NOPE",
+			http.StatusBadRequest},
+		{"method", http.MethodPost, effectiveAttributesQuery, http.StatusMethodNotAllowed},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+			assert.Equal(t, test.code, recorder.Code)
+		})
+	}
+}
+
+func TestEffectiveAttributesHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewEffectiveAttributesHandler(&mocks.MockResourceManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, effectiveAttributesQuery, nil))
+	assert.Equal(t, "GetEffectiveMatchableAttributes", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// The path clients tag executions which haven't terminated yet at, e.g.
+// PUT /api/v1/executions/tags?project=p&domain=d&name=n with a body of {"add": ["retry"], "remove": ["debug"]}.
+const ExecutionTagsPath = "/api/v1/executions/tags"
+
+// The admin service method requests to tag executions are authorized as.
+const updateExecutionTagsMethod = "UpdateExecutionTags"
+
+type executionTagsHandler struct {
+	executions interfaces.ExecutionInterface
+}
+
+type updateExecutionTagsBody struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+func (h *executionTagsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return updateExecutionTagsMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *executionTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", http.MethodPut)
+		http.Error(w, "only PUT requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var body updateExecutionTagsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid execution tags: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	err := h.executions.UpdateExecutionTags(r.Context(), interfaces.ExecutionTagsUpdateRequest{
+		ExecutionID: &core.WorkflowExecutionIdentifier{
+			Project: query.Get("project"),
+			Domain:  query.Get("domain"),
+			Name:    query.Get("name"),
+		},
+		Add:    body.Add,
+		Remove: body.Remove,
+	})
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, struct{}{})
+}
+
+// NewExecutionTagsHandler returns a handler attaching tags to and detaching tags from executions. It stands in for an
+// execution tags rpc until it's part of the admin service definition, and implements auth.AuthorizedHTTPHandler so
+// that it requires the same access as terminating an execution of the project.
+func NewExecutionTagsHandler(executions interfaces.ExecutionInterface) http.Handler {
+	return &executionTagsHandler{
+		executions: executions,
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const executionTagsQuery = ExecutionTagsPath + "?project=project&domain=domain&name=name"
+
+func TestExecutionTagsHandler(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetUpdateExecutionTagsCallback(func(ctx context.Context,
+		request interfaces.ExecutionTagsUpdateRequest) error {
+		assert.Equal(t, "project", request.ExecutionID.Project)
+		assert.Equal(t, "domain", request.ExecutionID.Domain)
+		assert.Equal(t, "name", request.ExecutionID.Name)
+		if len(request.Add) == 0 {
+			return errors.NewFlyteAdminError(codes.FailedPrecondition, "execution terminated")
+		}
+		assert.Equal(t, []string{"retry"}, request.Add)
+		assert.Equal(t, []string{"debug"}, request.Remove)
+		return nil
+	})
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		NewExecutionTagsHandler(&executions).ServeHTTP(recorder,
+			httptest.NewRequest(method, executionTagsQuery, strings.NewReader(body)))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPut, `{"add": ["retry"], "remove": ["debug"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, `{"remove": ["debug"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, `not json`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "").Code)
+}
+
+func TestExecutionTagsHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewExecutionTagsHandler(&mocks.MockExecutionManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodPut, executionTagsQuery, nil))
+	assert.Equal(t, "UpdateExecutionTags", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
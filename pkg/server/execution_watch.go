@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// The path clients watch an execution from, e.g. GET /api/v1/executions/watch?project=p&domain=d&name=n. Updates are
+// streamed as newline delimited JSON. The first line is always a snapshot of the execution and its top level node
+// executions, each line which follows holds a workflow or node execution event. The stream ends once the execution
+// terminates. Only the events processed by the replica serving the watch are streamed, and watchers which fall behind
+// are sent an error line and dropped, so clients are expected to watch again, starting from a fresh snapshot, whenever
+// the stream ends early or goes quiet for too long.
+const ExecutionWatchPath = "/api/v1/executions/watch"
+
+// The admin service method requests to watch executions are authorized as.
+const watchExecutionMethod = "WatchExecution"
+
+// Marshals protos the way the grpc gateway does for the rest of the http api.
+var protoJSONMarshaler = jsonpb.Marshaler{OrigName: true}
+
+type executionWatchLine struct {
+	Execution      json.RawMessage   `json:"execution,omitempty"`
+	NodeExecutions []json.RawMessage `json:"node_executions,omitempty"`
+	WorkflowEvent  json.RawMessage   `json:"workflow_event,omitempty"`
+	NodeEvent      json.RawMessage   `json:"node_event,omitempty"`
+	Error          string            `json:"error,omitempty"`
+}
+
+func marshalProtoJSON(message proto.Message) (json.RawMessage, error) {
+	var buffer bytes.Buffer
+	if err := protoJSONMarshaler.Marshal(&buffer, message); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func getExecutionWatchLine(update interfaces.ExecutionWatchUpdate) (line executionWatchLine, err error) {
+	if update.Execution != nil {
+		if line.Execution, err = marshalProtoJSON(update.Execution); err != nil {
+			return line, err
+		}
+	}
+	for _, nodeExecution := range update.NodeExecutions {
+		nodeExecutionJSON, err := marshalProtoJSON(nodeExecution)
+		if err != nil {
+			return line, err
+		}
+		line.NodeExecutions = append(line.NodeExecutions, nodeExecutionJSON)
+	}
+	if update.WorkflowEvent != nil {
+		if line.WorkflowEvent, err = marshalProtoJSON(update.WorkflowEvent); err != nil {
+			return line, err
+		}
+	}
+	if update.NodeEvent != nil {
+		if line.NodeEvent, err = marshalProtoJSON(update.NodeEvent); err != nil {
+			return line, err
+		}
+	}
+	return line, nil
+}
+
+type executionWatchHandler struct {
+	watches interfaces.ExecutionWatchInterface
+}
+
+func (h *executionWatchHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return watchExecutionMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *executionWatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	request := admin.WorkflowExecutionGetRequest{
+		Id: &core.WorkflowExecutionIdentifier{
+			Project: query.Get("project"),
+			Domain:  query.Get("domain"),
+			Name:    query.Get("name"),
+		},
+	}
+	encoder := json.NewEncoder(w)
+	started := false
+	err := h.watches.WatchExecution(r.Context(), request, func(update interfaces.ExecutionWatchUpdate) error {
+		line, err := getExecutionWatchLine(update)
+		if err != nil {
+			return err
+		}
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err == nil || r.Context().Err() != nil {
+		return
+	}
+	s, _ := status.FromError(err)
+	if !started {
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	// The status has already been sent, the error is streamed as the last line instead.
+	if err := encoder.Encode(executionWatchLine{Error: s.Message()}); err != nil {
+		logger.Infof(r.Context(), "failed to write watch error to %s, error: %v", r.URL.Path, err)
+		return
+	}
+	flusher.Flush()
+}
+
+// NewExecutionWatchHandler returns a handler streaming the updates of executions. It stands in for a WatchExecution
+// rpc until it's part of the admin service definition, and implements auth.AuthorizedHTTPHandler so that watching an
+// execution requires the same access as getting it.
+func NewExecutionWatchHandler(watches interfaces.ExecutionWatchInterface) http.Handler {
+	return &executionWatchHandler{
+		watches: watches,
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/event"
+)
+
+const executionWatchQuery = ExecutionWatchPath + "?project=project&domain=domain&name=name"
+
+func getExecutionWatchLines(t *testing.T, body string) []map[string]json.RawMessage {
+	var lines []map[string]json.RawMessage
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var line map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestExecutionWatchHandler(t *testing.T) {
+	watches := mocks.ExecutionWatchManager{
+		WatchExecutionFunc: func(ctx context.Context, request admin.WorkflowExecutionGetRequest,
+			send interfaces.ExecutionWatchSender) error {
+			assert.Equal(t, "name", request.Id.Name)
+			if err := send(interfaces.ExecutionWatchUpdate{
+				Execution: &admin.Execution{
+					Id:      request.Id,
+					Closure: &admin.ExecutionClosure{Phase: core.WorkflowExecution_RUNNING},
+				},
+				NodeExecutions: []*admin.NodeExecution{
+					{Id: &core.NodeExecutionIdentifier{NodeId: "n0", ExecutionId: request.Id}},
+				},
+			}); err != nil {
+				return err
+			}
+			return send(interfaces.ExecutionWatchUpdate{
+				WorkflowEvent: &event.WorkflowExecutionEvent{
+					ExecutionId: request.Id,
+					Phase:       core.WorkflowExecution_SUCCEEDED,
+				},
+			})
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewExecutionWatchHandler(&watches).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, executionWatchQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+	assert.True(t, recorder.Flushed)
+
+	lines := getExecutionWatchLines(t, recorder.Body.String())
+	assert.Len(t, lines, 2)
+	var execution admin.Execution
+	assert.NoError(t, jsonpb.UnmarshalString(string(lines[0]["execution"]), &execution))
+	assert.Equal(t, "name", execution.Id.Name)
+	assert.Equal(t, core.WorkflowExecution_RUNNING, execution.Closure.Phase)
+	assert.Contains(t, string(lines[0]["node_executions"]), `"node_id":"n0"`)
+	assert.Contains(t, string(lines[1]["workflow_event"]), `"phase":"SUCCEEDED"`)
+	assert.NotContains(t, lines[1], "execution")
+}
+
+func TestExecutionWatchHandler_Errors(t *testing.T) {
+	t.Run("before the snapshot", func(t *testing.T) {
+		watches := mocks.ExecutionWatchManager{
+			WatchExecutionFunc: func(ctx context.Context, request admin.WorkflowExecutionGetRequest,
+				send interfaces.ExecutionWatchSender) error {
+				return errors.NewFlyteAdminError(codes.NotFound, "missing")
+			},
+		}
+		recorder := httptest.NewRecorder()
+		NewExecutionWatchHandler(&watches).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, executionWatchQuery, nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "missing")
+	})
+	t.Run("after the snapshot", func(t *testing.T) {
+		watches := mocks.ExecutionWatchManager{
+			WatchExecutionFunc: func(ctx context.Context, request admin.WorkflowExecutionGetRequest,
+				send interfaces.ExecutionWatchSender) error {
+				if err := send(interfaces.ExecutionWatchUpdate{Execution: &admin.Execution{Id: request.Id}}); err != nil {
+					return err
+				}
+				return errors.NewFlyteAdminError(codes.ResourceExhausted, "fell behind")
+			},
+		}
+		recorder := httptest.NewRecorder()
+		NewExecutionWatchHandler(&watches).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, executionWatchQuery, nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		lines := getExecutionWatchLines(t, recorder.Body.String())
+		assert.Len(t, lines, 2)
+		assert.Equal(t, `"fell behind"`, string(lines[1]["error"]))
+	})
+	t.Run("method", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewExecutionWatchHandler(&mocks.ExecutionWatchManager{}).ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodPost, executionWatchQuery, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestExecutionWatchHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewExecutionWatchHandler(&mocks.ExecutionWatchManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, executionWatchQuery, nil))
+	assert.Equal(t, "WatchExecution", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
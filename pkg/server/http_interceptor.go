@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+)
+
+// The service the methods http stand-ins are intercepted as belong to.
+const adminServiceMethodPrefix = "/flyteidl.service.AdminService/"
+
+type interceptedHTTPHandler struct {
+	handler     auth.AuthorizedHTTPHandler
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// The request the interceptors see for a stand-in request. Like the rpcs they stand in for, it only identifies the
+// project, domain and name acted on, which is all the audit log records of a request.
+type interceptedHTTPRequest struct {
+	project string
+	domain  string
+	name    string
+}
+
+func (r interceptedHTTPRequest) GetProject() string {
+	return r.project
+}
+
+func (r interceptedHTTPRequest) GetDomain() string {
+	return r.domain
+}
+
+func (r interceptedHTTPRequest) GetName() string {
+	return r.name
+}
+
+// Records the status a stand-in responds with, so that the interceptors see the requests it fails as failed calls.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// The inverse of runtime.HTTPStatusFromCode for the statuses stand-ins respond with.
+var httpStatusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusMethodNotAllowed:      codes.Unimplemented,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusPreconditionFailed:    codes.FailedPrecondition,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+}
+
+func codeFromHTTPStatus(statusCode int) codes.Code {
+	if code, ok := httpStatusCodes[statusCode]; ok {
+		return code
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+func (h *interceptedHTTPHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return h.handler.AuthorizationMethod(r)
+}
+
+func (h *interceptedHTTPHandler) LimitsToAuthorizedProjects() bool {
+	limitedHandler, ok := h.handler.(auth.ProjectLimitedHTTPHandler)
+	return ok && limitedHandler.LimitsToAuthorizedProjects()
+}
+
+func (h *interceptedHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	method, _ := h.handler.AuthorizationMethod(r)
+	ctx := r.Context()
+	// Callers of stand-ins are identified by their address like those of rpcs when they aren't authenticated.
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	query := r.URL.Query()
+	request := interceptedHTTPRequest{
+		project: query.Get("project"),
+		domain:  query.Get("domain"),
+		name:    query.Get("name"),
+	}
+	recorder := &statusRecorder{ResponseWriter: w}
+	_, err := h.interceptor(ctx, request, &grpc.UnaryServerInfo{FullMethod: adminServiceMethodPrefix + method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			h.handler.ServeHTTP(recorder, r.WithContext(ctx))
+			if recorder.status >= http.StatusBadRequest {
+				return nil, status.Error(codeFromHTTPStatus(recorder.status), http.StatusText(recorder.status))
+			}
+			return nil, nil
+		})
+	// Requests the interceptors reject, for instance because the caller is rate limited, never reach the stand-in.
+	if err != nil && recorder.status == 0 {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+	}
+}
+
+// NewInterceptedHTTPHandler returns a handler serving the requests of a stand-in through interceptor as calls to the
+// admin service method the stand-in authorizes them as. This way the rate limiting, auditing and timeouts applied to
+// unary rpcs apply to the stand-ins served next to them too. Like the rpcs, it must wrap the handler after
+// authentication so that callers are identified by their principal.
+func NewInterceptedHTTPHandler(handler auth.AuthorizedHTTPHandler,
+	interceptor grpc.UnaryServerInterceptor) auth.AuthorizedHTTPHandler {
+	return &interceptedHTTPHandler{
+		handler:     handler,
+		interceptor: interceptor,
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+)
+
+type testStandInHandler struct {
+	statusCode int
+}
+
+func (h *testStandInHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return "UpdateThing", authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *testStandInHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, h.statusCode, map[string]string{})
+}
+
+func TestInterceptedHTTPHandler(t *testing.T) {
+	var intercepted []error
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		assert.Equal(t, "/flyteidl.service.AdminService/UpdateThing", info.FullMethod)
+		assert.Equal(t, interceptedHTTPRequest{project: "project", domain: "domain", name: "name"}, req)
+		peerInfo, ok := peer.FromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "192.0.2.1:1234", peerInfo.Addr.String())
+		resp, err := handler(ctx, req)
+		intercepted = append(intercepted, err)
+		return resp, err
+	}
+	serve := func(handler http.Handler) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/?project=project&domain=domain&name=name", nil)
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK,
+		serve(NewInterceptedHTTPHandler(&testStandInHandler{statusCode: http.StatusOK}, interceptor)).Code)
+	assert.Equal(t, http.StatusNotFound,
+		serve(NewInterceptedHTTPHandler(&testStandInHandler{statusCode: http.StatusNotFound}, interceptor)).Code)
+	// The interceptors see the requests the stand-in fails as failed calls.
+	assert.Len(t, intercepted, 2)
+	assert.NoError(t, intercepted[0])
+	assert.Equal(t, codes.NotFound, status.Code(intercepted[1]))
+
+	method, scope := NewInterceptedHTTPHandler(&testStandInHandler{}, interceptor).AuthorizationMethod(
+		httptest.NewRequest(http.MethodPost, "/?project=project&domain=domain", nil))
+	assert.Equal(t, "UpdateThing", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.False(t, NewInterceptedHTTPHandler(&testStandInHandler{}, interceptor).(auth.ProjectLimitedHTTPHandler).
+		LimitsToAuthorizedProjects())
+}
+
+func TestInterceptedHTTPHandler_Rejected(t *testing.T) {
+	standIn := &testStandInHandler{statusCode: http.StatusOK}
+	handler := NewInterceptedHTTPHandler(standIn, func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "rate limit exceeded")
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// The path clients search the names of workflows, tasks and launch plans across projects from, e.g.
+// GET /api/v1/named_entities/search?query=train&resource_type=WORKFLOW&limit=20. The resource_type parameter may be
+// repeated, and the token of a page is passed back to get the next one. Pages are written as a NamedEntityList.
+const NamedEntitySearchPath = "/api/v1/named_entities/search"
+
+// The admin service method search requests are authorized as.
+const searchNamedEntitiesMethod = "SearchNamedEntities"
+
+type namedEntitySearchHandler struct {
+	namedEntities interfaces.NamedEntityInterface
+}
+
+func getSearchNamedEntitiesRequest(r *http.Request) (interfaces.SearchNamedEntitiesRequest, error) {
+	query := r.URL.Query()
+	request := interfaces.SearchNamedEntitiesRequest{
+		Query: query.Get("query"),
+		Token: query.Get("token"),
+	}
+	for _, resourceType := range query["resource_type"] {
+		value, ok := core.ResourceType_value[resourceType]
+		if !ok {
+			return request, fmt.Errorf("unknown resource type [%s]", resourceType)
+		}
+		request.ResourceTypes = append(request.ResourceTypes, core.ResourceType(value))
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		parsed, err := strconv.ParseUint(limit, 10, 32)
+		if err != nil {
+			return request, fmt.Errorf("invalid limit [%s]", limit)
+		}
+		request.Limit = uint32(parsed)
+	}
+	return request, nil
+}
+
+// Searches span projects, so they're authorized without a project and domain.
+func (h *namedEntitySearchHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return searchNamedEntitiesMethod, authInterfaces.ResourceScope{}
+}
+
+// Callers who can only search some projects find the entities of those projects.
+func (h *namedEntitySearchHandler) LimitsToAuthorizedProjects() bool {
+	return true
+}
+
+func (h *namedEntitySearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	request, err := getSearchNamedEntitiesRequest(r)
+	if err != nil {
+		http.Error(w, "invalid search request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.namedEntities.SearchNamedEntities(r.Context(), request)
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := protoJSONMarshaler.Marshal(w, &admin.NamedEntityList{
+		Entities: response.Entities,
+		Token:    response.Token,
+	}); err != nil {
+		logger.Errorf(r.Context(), "failed to write response to %s, error: %v", r.URL.Path, err)
+	}
+}
+
+// NewNamedEntitySearchHandler returns a handler serving pages of named entities matching a search. It stands in for a
+// search rpc until one is part of the admin service definition, and implements auth.ProjectLimitedHTTPHandler so that
+// searches only find the entities of the projects the caller can read.
+func NewNamedEntitySearchHandler(namedEntities interfaces.NamedEntityInterface) http.Handler {
+	return &namedEntitySearchHandler{
+		namedEntities: namedEntities,
+	}
+}
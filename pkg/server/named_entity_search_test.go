@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+const namedEntitySearchQuery = NamedEntitySearchPath +
+	"?query=train&resource_type=WORKFLOW&resource_type=LAUNCH_PLAN&limit=20&token=20"
+
+func TestNamedEntitySearchHandler(t *testing.T) {
+	namedEntities := mocks.NamedEntityManager{
+		SearchNamedEntitiesFunc: func(ctx context.Context, request interfaces.SearchNamedEntitiesRequest) (
+			*interfaces.SearchNamedEntitiesResponse, error) {
+			assert.Equal(t, interfaces.SearchNamedEntitiesRequest{
+				Query:         "train",
+				ResourceTypes: []core.ResourceType{core.ResourceType_WORKFLOW, core.ResourceType_LAUNCH_PLAN},
+				Limit:         20,
+				Token:         "20",
+			}, request)
+			return &interfaces.SearchNamedEntitiesResponse{
+				Entities: []*admin.NamedEntity{
+					{
+						ResourceType: core.ResourceType_WORKFLOW,
+						Id:           &admin.NamedEntityIdentifier{Project: "project", Domain: "domain", Name: "train"},
+					},
+				},
+				Token: "40",
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewNamedEntitySearchHandler(&namedEntities).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, namedEntitySearchQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var response admin.NamedEntityList
+	assert.NoError(t, jsonpb.UnmarshalString(recorder.Body.String(), &response))
+	assert.Len(t, response.Entities, 1)
+	assert.Equal(t, "train", response.Entities[0].Id.Name)
+	assert.Equal(t, "40", response.Token)
+	assert.Contains(t, recorder.Body.String(), `"resource_type":"WORKFLOW"`)
+}
+
+func TestNamedEntitySearchHandler_Errors(t *testing.T) {
+	namedEntities := mocks.NamedEntityManager{
+		SearchNamedEntitiesFunc: func(ctx context.Context, request interfaces.SearchNamedEntitiesRequest) (
+			*interfaces.SearchNamedEntitiesResponse, error) {
+			return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "empty query")
+		},
+	}
+	handler := NewNamedEntitySearchHandler(&namedEntities)
+	for _, test := range []struct {
+		name   string
+		method string
+		path   string
+		code   int
+	}{
+		{"manager error", http.MethodGet, NamedEntitySearchPath, http.StatusBadRequest},
+		{"resource type", http.MethodGet, NamedEntitySearchPath + "?query=a&resource_type=NOPE", http.StatusBadRequest},
+		{"limit", http.MethodGet, NamedEntitySearchPath + "?query=a&limit=-1", http.StatusBadRequest},
+		{"method", http.MethodPost, namedEntitySearchQuery, http.StatusMethodNotAllowed},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+			assert.Equal(t, test.code, recorder.Code)
+		})
+	}
+}
+
+func TestNamedEntitySearchHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewNamedEntitySearchHandler(&mocks.NamedEntityManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, namedEntitySearchQuery, nil))
+	assert.Equal(t, "SearchNamedEntities", method)
+	assert.Empty(t, scope.Project)
+	assert.Empty(t, scope.Domain)
+	// Still limited to the projects the caller can read once intercepted.
+	intercepted := NewInterceptedHTTPHandler(handler, nil)
+	assert.True(t, intercepted.(auth.ProjectLimitedHTTPHandler).LimitsToAuthorizedProjects())
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/errors"
+)
+
+const (
+	ErrOpenAPISpec errors.ErrorCode = "OPENAPI_SPEC_FAILURE"
+
+	headerContentType     = "Content-Type"
+	headerContentEncoding = "Content-Encoding"
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerETag            = "ETag"
+	headerIfNoneMatch     = "If-None-Match"
+	headerCacheControl    = "Cache-Control"
+
+	gzipEncoding = "gzip"
+)
+
+// RewriteOpenAPISpec points the host, basePath and schemes of a swagger 2.0 spec at publicURL so that tools generated
+// from the spec reach the deployed service.
+func RewriteOpenAPISpec(spec []byte, publicURL *url.URL) ([]byte, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(spec, &parsed); err != nil {
+		return nil, errors.Wrapf(ErrOpenAPISpec, err, "failed to parse openapi spec")
+	}
+	parsed["host"] = publicURL.Host
+	if len(publicURL.Scheme) > 0 {
+		parsed["schemes"] = []string{publicURL.Scheme}
+	}
+	basePath := publicURL.Path
+	if len(basePath) == 0 {
+		basePath = "/"
+	}
+	parsed["basePath"] = basePath
+	rewritten, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, errors.Wrapf(ErrOpenAPISpec, err, "failed to serialize openapi spec")
+	}
+	return rewritten, nil
+}
+
+type openAPIHandler struct {
+	spec        []byte
+	gzipped     []byte
+	etag        string
+	gzippedETag string
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get(headerAcceptEncoding), ",") {
+		if strings.TrimSpace(strings.SplitN(encoding, ";", 2)[0]) == gzipEncoding {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the If-None-Match header, which may list several entity tags, matches etag.
+func matchesETag(r *http.Request, etag string) bool {
+	for _, candidate := range strings.Split(r.Header.Get(headerIfNoneMatch), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *openAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Each encoding is its own representation and so gets its own entity tag.
+	etag, body := h.etag, h.spec
+	if acceptsGzip(r) {
+		etag, body = h.gzippedETag, h.gzipped
+		w.Header().Set(headerContentEncoding, gzipEncoding)
+	}
+	w.Header().Set(headerETag, etag)
+	w.Header().Add(headerVary, headerAcceptEncoding)
+	// Caches must revalidate the spec before reusing it, so that clients see the spec of an upgraded service right away.
+	w.Header().Set(headerCacheControl, "no-cache")
+	if matchesETag(r, etag) {
+		w.Header().Del(headerContentEncoding)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set(headerContentType, "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// NewOpenAPIHandler serves the given spec, rewritten to point at publicURI when it is set. The served bytes, their
+// gzipped form and the ETag are computed once up front.
+func NewOpenAPIHandler(spec []byte, publicURI string) (http.Handler, error) {
+	if len(publicURI) > 0 {
+		publicURL, err := url.Parse(publicURI)
+		if err != nil {
+			return nil, errors.Wrapf(ErrOpenAPISpec, err, "invalid public uri: %s", publicURI)
+		}
+		spec, err = RewriteOpenAPISpec(spec, publicURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	if _, err := writer.Write(spec); err != nil {
+		return nil, errors.Wrapf(ErrOpenAPISpec, err, "failed to compress openapi spec")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, errors.Wrapf(ErrOpenAPISpec, err, "failed to compress openapi spec")
+	}
+
+	digest := sha256.Sum256(spec)
+	return &openAPIHandler{
+		spec:        spec,
+		gzipped:     gzipped.Bytes(),
+		etag:        fmt.Sprintf("\"%x\"", digest),
+		gzippedETag: fmt.Sprintf("\"%x-%s\"", digest, gzipEncoding),
+	}, nil
+}
+
+var openAPIUITemplate = template.Must(template.New("openapi-ui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>Flyte Admin API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@3/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@3/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: "{{.}}", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`))
+
+// NewOpenAPIUIHandler serves a minimal Swagger UI page which loads the spec from specPath.
+func NewOpenAPIUIHandler(specPath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, "text/html; charset=utf-8")
+		_ = openAPIUITemplate.Execute(w, specPath)
+	})
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSpec = []byte(`{"swagger":"2.0","host":"","schemes":["http","https"],"paths":{}}`)
+
+func TestOpenAPIHandler_HostRewrite(t *testing.T) {
+	handler, err := NewOpenAPIHandler(testSpec, "https://flyte.example.com/admin")
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.NotEmpty(t, recorder.Header().Get("ETag"))
+
+	var spec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &spec))
+	assert.Equal(t, "flyte.example.com", spec["host"])
+	assert.Equal(t, "/admin", spec["basePath"])
+	assert.Equal(t, []interface{}{"https"}, spec["schemes"])
+	assert.Equal(t, "2.0", spec["swagger"])
+}
+
+func TestOpenAPIHandler_NoRewrite(t *testing.T) {
+	handler, err := NewOpenAPIHandler(testSpec, "")
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil))
+	assert.Equal(t, testSpec, recorder.Body.Bytes())
+}
+
+func TestOpenAPIHandler_NotModified(t *testing.T) {
+	handler, err := NewOpenAPIHandler(testSpec, "https://flyte.example.com")
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil))
+	etag := recorder.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil)
+	req.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Empty(t, recorder.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil)
+	req.Header.Set("If-None-Match", "\"stale\", W/"+etag)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil)
+	req.Header.Set("If-None-Match", "\"stale\"")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+
+	// The gzipped spec is tagged separately.
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil)
+	req.Header.Set("If-None-Match", etag)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotEqual(t, etag, recorder.Header().Get("ETag"))
+}
+
+func TestOpenAPIHandler_Gzip(t *testing.T) {
+	handler, err := NewOpenAPIHandler(testSpec, "")
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi", nil)
+	req.Header.Set("Accept-Encoding", "deflate, gzip;q=1.0")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+	reader, err := gzip.NewReader(recorder.Body)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, testSpec, body)
+}
+
+func TestNewOpenAPIHandler_InvalidSpec(t *testing.T) {
+	_, err := NewOpenAPIHandler([]byte("not json"), "https://flyte.example.com")
+	assert.Error(t, err)
+}
+
+func TestOpenAPIUIHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewOpenAPIUIHandler("/api/v1/openapi").ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, "/api/v1/openapi/ui", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "swagger-ui")
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/flyteorg/flytestdlib/profutils"
+	"github.com/flyteorg/flytestdlib/version"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Serves the build of the running binary, like the profiling server of flytestdlib does.
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	_ = profutils.WriteJSONResponse(w, http.StatusOK, profutils.BuildVersion{
+		Build:     version.Build,
+		Version:   version.Version,
+		Timestamp: version.BuildTime,
+	})
+}
+
+// Serves the loaded config, like the profiling server of flytestdlib does.
+func configHandler(w http.ResponseWriter, r *http.Request) {
+	configs, err := config.AllConfigsAsMap(config.GetRootSection())
+	if err != nil {
+		_ = profutils.WriteStringResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_ = profutils.WriteJSONResponse(w, http.StatusOK, configs)
+}
+
+// Registers the metrics along with the /healthcheck, /version and /config endpoints deployments probe the profiling
+// server of flytestdlib for.
+func registerMonitoringHandlers(mux *http.ServeMux) {
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
+		_ = profutils.WriteStringResponse(w, http.StatusOK, http.StatusText(http.StatusOK))
+	})
+	mux.HandleFunc("/version", versionHandler)
+	mux.HandleFunc("/config", configHandler)
+}
+
+// NewProfilerHandler returns a handler serving the pprof endpoints under /debug/pprof/ along with Prometheus metrics.
+// It registers the handlers on a dedicated mux so that it is only reachable on the port it is explicitly served on.
+func NewProfilerHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	registerMonitoringHandlers(mux)
+	return mux
+}
+
+// NewMetricsHandler returns a handler serving the Prometheus metrics alone, along with the endpoints deployments probe,
+// for deployments which don't enable profiling.
+func NewMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	registerMonitoringHandlers(mux)
+	return mux
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path clients get the attributes of a project and domain of every matchable resource type at once from, e.g.
+// GET /api/v1/project_domain_attributes?project=p&domain=d.
+const ProjectDomainAttributesPath = "/api/v1/project_domain_attributes"
+
+// The admin service method requests for the attributes of every matchable resource type are authorized as.
+const getAllProjectDomainAttributesMethod = "GetAllProjectDomainAttributes"
+
+type projectDomainAttributesResponse struct {
+	Attributes []json.RawMessage `json:"attributes"`
+}
+
+type projectDomainAttributesHandler struct {
+	resources interfaces.ResourceInterface
+}
+
+func (h *projectDomainAttributesHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return getAllProjectDomainAttributesMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *projectDomainAttributesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	response, err := h.resources.GetAllProjectDomainAttributes(r.Context(),
+		interfaces.ProjectDomainAttributesGetAllRequest{
+			Project: query.Get("project"),
+			Domain:  query.Get("domain"),
+		})
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	body := projectDomainAttributesResponse{
+		Attributes: make([]json.RawMessage, 0, len(response.Attributes)),
+	}
+	for _, attributes := range response.Attributes {
+		attributesJSON, err := marshalProtoJSON(attributes)
+		if err != nil {
+			writeJSON(w, r, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		body.Attributes = append(body.Attributes, attributesJSON)
+	}
+	writeJSON(w, r, http.StatusOK, body)
+}
+
+// NewProjectDomainAttributesHandler returns a handler serving the attributes of a project and domain of every
+// matchable resource type. It stands in for a GetAllProjectDomainAttributes rpc until one is part of the admin service
+// definition, and implements auth.AuthorizedHTTPHandler so that it requires the same access as getting the attributes
+// of a single type.
+func NewProjectDomainAttributesHandler(resources interfaces.ResourceInterface) http.Handler {
+	return &projectDomainAttributesHandler{
+		resources: resources,
+	}
+}
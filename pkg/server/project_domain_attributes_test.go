@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+const projectDomainAttributesQuery = ProjectDomainAttributesPath + "?project=project&domain=domain"
+
+func TestProjectDomainAttributesHandler(t *testing.T) {
+	resources := mocks.MockResourceManager{
+		GetAllFunc: func(ctx context.Context, request interfaces.ProjectDomainAttributesGetAllRequest) (
+			*interfaces.ProjectDomainAttributesGetAllResponse, error) {
+			if request.Project == "" {
+				return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "missing project")
+			}
+			assert.Equal(t, interfaces.ProjectDomainAttributesGetAllRequest{
+				Project: "project",
+				Domain:  "domain",
+			}, request)
+			return &interfaces.ProjectDomainAttributesGetAllResponse{
+				Attributes: []*admin.ProjectDomainAttributes{
+					{
+						Project: "project",
+						Domain:  "domain",
+						MatchingAttributes: &admin.MatchingAttributes{
+							Target: &admin.MatchingAttributes_ExecutionQueueAttributes{
+								ExecutionQueueAttributes: &admin.ExecutionQueueAttributes{Tags: []string{"gpu"}},
+							},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+	handler := NewProjectDomainAttributesHandler(&resources)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, projectDomainAttributesQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response projectDomainAttributesResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response.Attributes, 1)
+	var attributes admin.ProjectDomainAttributes
+	assert.NoError(t, jsonpb.UnmarshalString(string(response.Attributes[0]), &attributes))
+	assert.Equal(t, []string{"gpu"}, attributes.MatchingAttributes.GetExecutionQueueAttributes().Tags)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ProjectDomainAttributesPath+"?domain=domain", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, projectDomainAttributesQuery, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestProjectDomainAttributesHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewProjectDomainAttributesHandler(&mocks.MockResourceManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(
+		httptest.NewRequest(http.MethodGet, projectDomainAttributesQuery, nil))
+	assert.Equal(t, "GetAllProjectDomainAttributes", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path admins purge the executions of a domain, and optionally of a single project, created before a cutoff at,
+// e.g. POST /api/v1/executions/purge?project=p&domain=d with a body of {"createdBefore": "2022-01-01T00:00:00Z"}.
+// Setting "dryRun" to true only reports the rows that would be deleted.
+const PurgeExecutionsPath = "/api/v1/executions/purge"
+
+// The admin service method requests to purge executions are authorized as.
+const purgeExecutionsMethod = "PurgeExecutions"
+
+type purgeExecutionsHandler struct {
+	executions interfaces.ExecutionInterface
+}
+
+type purgeExecutionsBody struct {
+	CreatedBefore time.Time `json:"createdBefore"`
+	BatchSize     int       `json:"batchSize"`
+	DryRun        bool      `json:"dryRun"`
+}
+
+type purgeExecutionsResponse struct {
+	Executions  int              `json:"executions"`
+	DeletedRows map[string]int64 `json:"deletedRows"`
+}
+
+func (h *purgeExecutionsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return purgeExecutionsMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func (h *purgeExecutionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var body purgeExecutionsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid purge executions request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	response, err := h.executions.PurgeExecutions(r.Context(), interfaces.PurgeExecutionsRequest{
+		Project:       query.Get("project"),
+		Domain:        query.Get("domain"),
+		CreatedBefore: body.CreatedBefore,
+		BatchSize:     body.BatchSize,
+		DryRun:        body.DryRun,
+	})
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, purgeExecutionsResponse{
+		Executions:  response.Executions,
+		DeletedRows: response.DeletedRows,
+	})
+}
+
+// NewPurgeExecutionsHandler returns a handler hard deleting the executions of a domain created before a cutoff, along
+// with everything recorded about them. It stands in for a purge rpc until it's part of the admin service definition,
+// and implements auth.AuthorizedHTTPHandler so that only admins of the domain, or of every domain when no project is
+// given, can purge executions.
+func NewPurgeExecutionsHandler(executions interfaces.ExecutionInterface) http.Handler {
+	return &purgeExecutionsHandler{
+		executions: executions,
+	}
+}
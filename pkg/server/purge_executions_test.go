@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const purgeExecutionsQuery = PurgeExecutionsPath + "?project=project&domain=domain"
+
+func TestPurgeExecutionsHandler(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetPurgeExecutionsCallback(func(ctx context.Context, request interfaces.PurgeExecutionsRequest) (
+		*interfaces.PurgeExecutionsResponse, error) {
+		assert.Equal(t, "project", request.Project)
+		assert.Equal(t, "domain", request.Domain)
+		if request.CreatedBefore.IsZero() {
+			return nil, adminErrors.NewFlyteAdminError(codes.InvalidArgument, "missing created_before")
+		}
+		assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), request.CreatedBefore.UTC())
+		assert.Equal(t, 50, request.BatchSize)
+		assert.True(t, request.DryRun)
+		return &interfaces.PurgeExecutionsResponse{
+			Executions:  2,
+			DeletedRows: map[string]int64{"executions": 2, "node_executions": 6},
+		}, nil
+	})
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		NewPurgeExecutionsHandler(&executions).ServeHTTP(recorder,
+			httptest.NewRequest(method, purgeExecutionsQuery, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := serve(http.MethodPost, `{"createdBefore": "2022-01-01T00:00:00Z", "batchSize": 50, "dryRun": true}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"executions":2`)
+	assert.Contains(t, recorder.Body.String(), `"node_executions":6`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"createdBefore": "yesterday"}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "").Code)
+}
+
+func TestPurgeExecutionsHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewPurgeExecutionsHandler(&mocks.MockExecutionManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodPost, purgeExecutionsQuery, nil))
+	assert.Equal(t, "PurgeExecutions", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
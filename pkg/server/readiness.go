@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+const (
+	checkStatusOK     = "ok"
+	checkStatusFailed = "failed"
+)
+
+// Bounds the checks when no timeout is configured, since a zero timeout would fail them all.
+const defaultReadinessTimeout = 2 * time.Second
+
+// ReadinessCheck verifies that a single dependency required to serve traffic is available.
+type ReadinessCheck func(ctx context.Context) error
+
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type readinessStatus struct {
+	Ready  bool                   `json:"ready"`
+	Checks map[string]checkResult `json:"checks"`
+}
+
+type readinessHandler struct {
+	checks        map[string]ReadinessCheck
+	timeout       time.Duration
+	cacheDuration time.Duration
+	now           func() time.Time
+
+	// Held while evaluating checks so that concurrent probes share a single evaluation.
+	mutex     sync.Mutex
+	cached    *readinessStatus
+	checkedAt time.Time
+}
+
+type namedCheckResult struct {
+	name   string
+	result checkResult
+}
+
+func (h *readinessHandler) runChecks() *readinessStatus {
+	// The checks aren't bound to the probe which triggered them, whose cancellation would otherwise fail them, and
+	// cache the failure for the other probes.
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	status := &readinessStatus{
+		Ready:  true,
+		Checks: make(map[string]checkResult, len(h.checks)),
+	}
+	results := make(chan namedCheckResult, len(h.checks))
+	for name, check := range h.checks {
+		go func(name string, check ReadinessCheck) {
+			result := checkResult{Status: checkStatusOK}
+			if err := check(ctx); err != nil {
+				logger.Warningf(ctx, "Readiness check [%s] failed with err: %v", name, err)
+				result = checkResult{Status: checkStatusFailed, Error: err.Error()}
+			}
+			results <- namedCheckResult{name: name, result: result}
+		}(name, check)
+	}
+	// Checks which don't honor the deadline of their context are given up on rather than waited for.
+	for len(status.Checks) < len(h.checks) {
+		select {
+		case checked := <-results:
+			status.Checks[checked.name] = checked.result
+		case <-ctx.Done():
+			for name := range h.checks {
+				if _, ok := status.Checks[name]; !ok {
+					logger.Warningf(ctx, "Readiness check [%s] didn't complete within %v", name, h.timeout)
+					status.Checks[name] = checkResult{Status: checkStatusFailed, Error: ctx.Err().Error()}
+				}
+			}
+		}
+	}
+	for _, result := range status.Checks {
+		if result.Status != checkStatusOK {
+			status.Ready = false
+		}
+	}
+	return status
+}
+
+func (h *readinessHandler) getStatus() *readinessStatus {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	now := h.now()
+	if h.cached != nil && now.Sub(h.checkedAt) < h.cacheDuration {
+		return h.cached
+	}
+	h.cached = h.runChecks()
+	h.checkedAt = now
+	return h.cached
+}
+
+func (h *readinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := h.getStatus()
+	w.Header().Set("Content-Type", "application/json")
+	if status.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		logger.Errorf(r.Context(), "failed to write readiness status, error: %v", err)
+	}
+}
+
+// NewReadinessHandler returns a handler which reports 200 when all checks pass and 503 otherwise, along with the
+// status of each individual check. Each check is bounded by timeout and results are reused for cacheDuration to avoid
+// stampedes from frequent probes.
+func NewReadinessHandler(checks map[string]ReadinessCheck, timeout, cacheDuration time.Duration) http.Handler {
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
+	return &readinessHandler{
+		checks:        checks,
+		timeout:       timeout,
+		cacheDuration: cacheDuration,
+		now:           time.Now,
+	}
+}
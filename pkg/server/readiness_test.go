@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getReadinessStatus(t *testing.T, handler http.Handler) (int, readinessStatus) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	var status readinessStatus
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	return recorder.Code, status
+}
+
+func TestReadinessHandler_Ready(t *testing.T) {
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			return nil
+		},
+	}, time.Second, time.Second)
+	code, status := getReadinessStatus(t, handler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, status.Ready)
+	assert.Equal(t, checkResult{Status: checkStatusOK}, status.Checks["database"])
+}
+
+func TestReadinessHandler_NotReady(t *testing.T) {
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			return nil
+		},
+		"cluster": func(ctx context.Context) error {
+			return errors.New("connection refused")
+		},
+	}, time.Second, time.Second)
+	code, status := getReadinessStatus(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, status.Ready)
+	assert.Equal(t, checkResult{Status: checkStatusOK}, status.Checks["database"])
+	assert.Equal(t, checkResult{Status: checkStatusFailed, Error: "connection refused"}, status.Checks["cluster"])
+}
+
+func TestReadinessHandler_Timeout(t *testing.T) {
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}, 10*time.Millisecond, time.Second)
+	code, status := getReadinessStatus(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.Checks["database"].Error)
+}
+
+func TestReadinessHandler_IgnoredTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			return nil
+		},
+		"cluster": func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	}, 10*time.Millisecond, time.Second)
+	code, status := getReadinessStatus(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, checkResult{Status: checkStatusOK}, status.Checks["database"])
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.Checks["cluster"].Error)
+}
+
+func TestReadinessHandler_CancelledProbe(t *testing.T) {
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			return ctx.Err()
+		},
+	}, 0, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestReadinessHandler_Cached(t *testing.T) {
+	calls := 0
+	handler := NewReadinessHandler(map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error {
+			calls++
+			return nil
+		},
+	}, time.Second, time.Second).(*readinessHandler)
+	now := time.Date(2020, time.January, 5, 10, 0, 0, 0, time.UTC)
+	handler.now = func() time.Time {
+		return now
+	}
+
+	getReadinessStatus(t, handler)
+	getReadinessStatus(t, handler)
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(time.Second)
+	getReadinessStatus(t, handler)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/flyteorg/flyteadmin/pkg/audit"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor converts panics raised while handling a request into codes.Internal errors so that a single bad
+// request does not tear down the server.
+type RecoveryInterceptor struct {
+	panicCounter *prometheus.CounterVec
+}
+
+func (r *RecoveryInterceptor) recoveredErr(ctx context.Context, method string, req interface{}, p interface{}) error {
+	r.panicCounter.WithLabelValues(method).Inc()
+	if req != nil {
+		logger.Errorf(ctx, "panic-ed handling [%s] for request with identifiers [%+v], err: %v with Stack: %v", method,
+			audit.ParametersFromRequest(req), p, string(debug.Stack()))
+	} else {
+		logger.Errorf(ctx, "panic-ed handling [%s], err: %v with Stack: %v", method, p, string(debug.Stack()))
+	}
+	return status.Errorf(codes.Internal, "internal error handling %s", method)
+}
+
+func (r *RecoveryInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp = nil
+				err = r.recoveredErr(ctx, info.FullMethod, req, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func (r *RecoveryInterceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = r.recoveredErr(stream.Context(), info.FullMethod, nil, p)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+func NewRecoveryInterceptor(scope promutils.Scope) *RecoveryInterceptor {
+	return &RecoveryInterceptor{
+		panicCounter: scope.MustNewCounterVec("panics_total",
+			"panics recovered while handling grpc requests", "method"),
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const checkMethod = "/grpc.health.v1.Health/Check"
+const watchMethod = "/grpc.health.v1.Health/Watch"
+
+type panickingHealthServer struct{}
+
+func (s *panickingHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (
+	*grpc_health_v1.HealthCheckResponse, error) {
+	if req.Service == "panic" {
+		var params map[string]string
+		params["nil"] = "map"
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *panickingHealthServer) Watch(req *grpc_health_v1.HealthCheckRequest,
+	stream grpc_health_v1.Health_WatchServer) error {
+	panic("watch panic")
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	recoveryInterceptor := NewRecoveryInterceptor(promutils.NewTestScope())
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(recoveryInterceptor.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(recoveryInterceptor.StreamServerInterceptor()))
+	grpc_health_v1.RegisterHealthServer(grpcServer, &panickingHealthServer{})
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "panic"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recoveryInterceptor.panicCounter.WithLabelValues(checkMethod)))
+
+	// The server keeps serving subsequent requests.
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recoveryInterceptor.panicCounter.WithLabelValues(watchMethod)))
+}
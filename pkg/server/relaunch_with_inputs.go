@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+// The path clients relaunch executions with some of their inputs replaced at, e.g.
+// POST /api/v1/executions/relaunch_with_inputs?project=p&domain=d&name=n with a body of
+// {"name": "relaunched", "inputs": {"literals": {"x": {"scalar": {"primitive": {"integer": 2}}}}}}. The name of the
+// relaunched execution is optional, and the inputs are a core.LiteralMap in the JSON the rest of the http api uses.
+const RelaunchWithInputsPath = "/api/v1/executions/relaunch_with_inputs"
+
+// The admin service method requests to relaunch executions with inputs are authorized as.
+const relaunchExecutionMethod = "RelaunchExecution"
+
+// Bounds the relaunch requests read, whose inputs are meant to override a few values rather than carry datasets.
+const maxRelaunchWithInputsBodyBytes = 1 << 20
+
+type relaunchWithInputsHandler struct {
+	executions interfaces.ExecutionInterface
+}
+
+type relaunchWithInputsBody struct {
+	Name   string          `json:"name"`
+	Inputs json.RawMessage `json:"inputs"`
+}
+
+func (h *relaunchWithInputsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return relaunchExecutionMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+func readRelaunchWithInputsBody(r io.Reader) (relaunchWithInputsBody, *core.LiteralMap, error) {
+	var body relaunchWithInputsBody
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return body, nil, err
+	}
+	if len(body.Inputs) == 0 {
+		return body, nil, nil
+	}
+	var inputs core.LiteralMap
+	if err := jsonpb.UnmarshalString(string(body.Inputs), &inputs); err != nil {
+		return body, nil, err
+	}
+	return body, &inputs, nil
+}
+
+func (h *relaunchWithInputsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, inputs, err := readRelaunchWithInputsBody(http.MaxBytesReader(w, r.Body, maxRelaunchWithInputsBodyBytes))
+	if err != nil {
+		http.Error(w, "invalid relaunch request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	response, err := h.executions.RelaunchExecutionWithInputs(r.Context(), admin.ExecutionRelaunchRequest{
+		Id: &core.WorkflowExecutionIdentifier{
+			Project: query.Get("project"),
+			Domain:  query.Get("domain"),
+			Name:    query.Get("name"),
+		},
+		Name: body.Name,
+	}, inputs, time.Now())
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	responseJSON, err := marshalProtoJSON(response)
+	if err != nil {
+		writeJSON(w, r, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, responseJSON)
+}
+
+// NewRelaunchWithInputsHandler returns a handler relaunching executions with some of their inputs replaced. It stands
+// in for input overrides on the RelaunchExecution rpc until they're part of the admin service definition, and
+// implements auth.AuthorizedHTTPHandler so that it requires the same access as relaunching an execution.
+func NewRelaunchWithInputsHandler(executions interfaces.ExecutionInterface) http.Handler {
+	return &relaunchWithInputsHandler{
+		executions: executions,
+	}
+}
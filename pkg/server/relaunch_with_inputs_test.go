@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+)
+
+const relaunchWithInputsQuery = RelaunchWithInputsPath + "?project=project&domain=domain&name=name"
+
+func TestRelaunchWithInputsHandler(t *testing.T) {
+	executions := mocks.MockExecutionManager{}
+	executions.SetRelaunchWithInputsCallback(func(ctx context.Context, request admin.ExecutionRelaunchRequest,
+		inputOverrides *core.LiteralMap, requestedAt time.Time) (*admin.ExecutionCreateResponse, error) {
+		assert.True(t, proto.Equal(&core.WorkflowExecutionIdentifier{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "name",
+		}, request.Id))
+		if inputOverrides == nil {
+			return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "no inputs")
+		}
+		assert.Equal(t, int64(2), inputOverrides.Literals["x"].GetScalar().GetPrimitive().GetInteger())
+		return &admin.ExecutionCreateResponse{
+			Id: &core.WorkflowExecutionIdentifier{Project: "project", Domain: "domain", Name: request.Name},
+		}, nil
+	})
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		NewRelaunchWithInputsHandler(&executions).ServeHTTP(recorder,
+			httptest.NewRequest(method, relaunchWithInputsQuery, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := serve(http.MethodPost,
+		`{"name": "relaunched", "inputs": {"literals": {"x": {"scalar": {"primitive": {"integer": 2}}}}}}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"name":"relaunched"`)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"inputs": {"literals": 1}}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "").Code)
+}
+
+func TestRelaunchWithInputsHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewRelaunchWithInputsHandler(&mocks.MockExecutionManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodPost, relaunchWithInputsQuery, nil))
+	assert.Equal(t, "RelaunchExecution", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The metadata key and http header carrying the id correlating the work done for a request.
+	RequestIDMetadataKey = "x-request-id"
+	RequestIDHeader      = "X-Request-Id"
+
+	maxRequestIDLength = 128
+)
+
+// Ids sent by callers are only honored when they're short and printable, so that they can be safely logged and set as
+// annotations.
+func isValidRequestID(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range requestID {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the id sent by the caller when it's valid, a new one otherwise.
+func getOrNewRequestID(requestID string) string {
+	if isValidRequestID(requestID) {
+		return requestID
+	}
+	return uuid.New().String()
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Mentions the request id in the message of a failed request and attaches it as a RequestInfo detail, so that callers
+// can refer to it when reporting the failure.
+func withRequestIDDetail(err error, requestID string) error {
+	st := status.Convert(err)
+	statusProto := st.Proto()
+	statusProto.Message = fmt.Sprintf("%s (request id: %s)", statusProto.Message, requestID)
+	st = status.FromProto(statusProto)
+	detailed, detailErr := st.WithDetails(&errdetails.RequestInfo{
+		RequestId: requestID,
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// Tags the context of each request with a request id, which is echoed in the response header metadata and the errors of
+// failed requests. The id sent by callers in the x-request-id metadata is used when present.
+func RequestIDUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		requestID := getOrNewRequestID(requestIDFromMetadata(ctx))
+		ctx = common.WithRequestID(ctx, requestID)
+		// Nothing has been sent yet, so setting the header can't fail.
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, withRequestIDDetail(err, requestID)
+		}
+		return resp, nil
+	}
+}
+
+// The streaming counterpart of RequestIDUnaryServerInterceptor.
+func RequestIDStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		requestID := getOrNewRequestID(requestIDFromMetadata(stream.Context()))
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = common.WithRequestID(stream.Context(), requestID)
+		_ = stream.SetHeader(metadata.Pairs(RequestIDMetadataKey, requestID))
+		if err := handler(srv, wrapped); err != nil {
+			return withRequestIDDetail(err, requestID)
+		}
+		return nil
+	}
+}
+
+// Tags the context of each http request with a request id, taken from the X-Request-Id header when present, and
+// echoes it in the response headers.
+func NewRequestIDHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := getOrNewRequestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, requestID)
+		handler.ServeHTTP(w, r.WithContext(common.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// Forwards the id of http requests proxied by the grpc gateway, so that the request keeps its id when served over grpc.
+func GetRequestIDMetadata(_ context.Context, r *http.Request) metadata.MD {
+	requestID := common.GetRequestID(r.Context())
+	if len(requestID) == 0 {
+		return nil
+	}
+	return metadata.Pairs(RequestIDMetadataKey, requestID)
+}
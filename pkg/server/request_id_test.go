@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func callWithRequestID(ctx context.Context, handlerErr error) (string, error) {
+	var requestID string
+	_, err := RequestIDUnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: checkMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			requestID = common.GetRequestID(ctx)
+			return nil, handlerErr
+		})
+	return requestID, err
+}
+
+func TestRequestIDUnaryServerInterceptor(t *testing.T) {
+	t.Run("honors the id sent", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "abc-123"))
+		requestID, err := callWithRequestID(ctx, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "abc-123", requestID)
+	})
+	t.Run("assigns new ids", func(t *testing.T) {
+		requestID, err := callWithRequestID(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.Len(t, requestID, 36)
+		otherRequestID, err := callWithRequestID(context.Background(), nil)
+		assert.NoError(t, err)
+		assert.NotEqual(t, requestID, otherRequestID)
+	})
+	t.Run("replaces invalid ids", func(t *testing.T) {
+		for _, invalid := range []string{"with space", "new\nline", strings.Repeat("a", maxRequestIDLength+1)} {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, invalid))
+			requestID, err := callWithRequestID(ctx, nil)
+			assert.NoError(t, err)
+			assert.NotEqual(t, invalid, requestID)
+			assert.True(t, isValidRequestID(requestID))
+		}
+	})
+	t.Run("attaches the id to errors", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "abc-123"))
+		_, err := callWithRequestID(ctx, status.Error(codes.NotFound, "missing"))
+		st := status.Convert(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "missing (request id: abc-123)", st.Message())
+		if assert.Len(t, st.Details(), 1) {
+			assert.Equal(t, "abc-123", st.Details()[0].(*errdetails.RequestInfo).RequestId)
+		}
+	})
+}
+
+func TestNewRequestIDHandler(t *testing.T) {
+	var requestID string
+	var md metadata.MD
+	handler := NewRequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = common.GetRequestID(r.Context())
+		md = GetRequestIDMetadata(r.Context(), r)
+	}))
+
+	t.Run("honors the id sent", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+		request.Header.Set(RequestIDHeader, "abc-123")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, "abc-123", requestID)
+		assert.Equal(t, "abc-123", recorder.Header().Get(RequestIDHeader))
+		assert.Equal(t, []string{"abc-123"}, md.Get(RequestIDMetadataKey))
+	})
+	t.Run("assigns new ids", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil))
+		assert.Len(t, requestID, 36)
+		assert.Equal(t, requestID, recorder.Header().Get(RequestIDHeader))
+	})
+}
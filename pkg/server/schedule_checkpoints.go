@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path clients list the checkpoints of scheduled launch plans from, e.g.
+// GET /api/v1/schedule_checkpoints?project=p&domain=d&name=lp, and admins set the checkpoint of a launch plan version
+// at, e.g. PUT /api/v1/schedule_checkpoints?project=p&domain=d&name=lp&version=v with a body of
+// {"lastScheduledAt": "2021-11-01T00:00:00Z"}.
+const ScheduleCheckpointsPath = "/api/v1/schedule_checkpoints"
+
+// The admin service methods requests to the schedule checkpoints are authorized as.
+const (
+	listScheduleCheckpointsMethod  = "ListScheduleCheckpoints"
+	updateScheduleCheckpointMethod = "UpdateScheduleCheckpoint"
+)
+
+type scheduleCheckpointsHandler struct {
+	checkpoints interfaces.ScheduleCheckpointInterface
+}
+
+type updateScheduleCheckpointBody struct {
+	LastScheduledAt time.Time `json:"lastScheduledAt"`
+}
+
+func (h *scheduleCheckpointsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	scope := authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+	if r.Method == http.MethodGet {
+		return listScheduleCheckpointsMethod, scope
+	}
+	return updateScheduleCheckpointMethod, scope
+}
+
+func (h *scheduleCheckpointsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var response interface{}
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		response, err = h.checkpoints.ListScheduleCheckpoints(r.Context(), interfaces.ListScheduleCheckpointsRequest{
+			Project: query.Get("project"),
+			Domain:  query.Get("domain"),
+			Name:    query.Get("name"),
+		})
+	case http.MethodPut:
+		var body updateScheduleCheckpointBody
+		if decodeErr := json.NewDecoder(r.Body).Decode(&body); decodeErr != nil {
+			http.Error(w, "invalid schedule checkpoint: "+decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		response, err = h.checkpoints.UpdateScheduleCheckpoint(r.Context(), interfaces.UpdateScheduleCheckpointRequest{
+			Project:         query.Get("project"),
+			Domain:          query.Get("domain"),
+			Name:            query.Get("name"),
+			Version:         query.Get("version"),
+			LastScheduledAt: body.LastScheduledAt,
+		})
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+		http.Error(w, "only GET and PUT requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewScheduleCheckpointsHandler returns a handler listing and setting the checkpoints of scheduled launch plans as
+// JSON. It stands in for schedule checkpoint rpcs until they're part of the admin service definition, and implements
+// auth.AuthorizedHTTPHandler so that only admins can set checkpoints.
+func NewScheduleCheckpointsHandler(checkpoints interfaces.ScheduleCheckpointInterface) http.Handler {
+	return &scheduleCheckpointsHandler{
+		checkpoints: checkpoints,
+	}
+}
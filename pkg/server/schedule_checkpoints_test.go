@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const scheduleCheckpointQuery = ScheduleCheckpointsPath + "?project=project&domain=domain&name=name&version=version"
+
+func TestScheduleCheckpointsHandler_List(t *testing.T) {
+	lastScheduledAt := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	checkpoints := mocks.ScheduleCheckpointManager{
+		ListScheduleCheckpointsFunc: func(ctx context.Context, request interfaces.ListScheduleCheckpointsRequest) (
+			*interfaces.ScheduleCheckpointList, error) {
+			assert.Equal(t, interfaces.ListScheduleCheckpointsRequest{
+				Project: "project",
+				Domain:  "domain",
+			}, request)
+			return &interfaces.ScheduleCheckpointList{
+				Checkpoints: []interfaces.ScheduleCheckpoint{
+					{
+						Project:           "project",
+						Domain:            "domain",
+						Name:              "name",
+						Version:           "version",
+						LastScheduledAt:   lastScheduledAt,
+						LastExecutionName: "execution",
+					},
+				},
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewScheduleCheckpointsHandler(&checkpoints).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, ScheduleCheckpointsPath+"?project=project&domain=domain", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.ScheduleCheckpointList
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response.Checkpoints, 1)
+	assert.Equal(t, lastScheduledAt, response.Checkpoints[0].LastScheduledAt)
+	assert.Equal(t, "execution", response.Checkpoints[0].LastExecutionName)
+}
+
+func TestScheduleCheckpointsHandler_Update(t *testing.T) {
+	lastScheduledAt := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	checkpoints := mocks.ScheduleCheckpointManager{
+		UpdateScheduleCheckpointFunc: func(ctx context.Context, request interfaces.UpdateScheduleCheckpointRequest) (
+			*interfaces.ScheduleCheckpoint, error) {
+			assert.Equal(t, interfaces.UpdateScheduleCheckpointRequest{
+				Project:         "project",
+				Domain:          "domain",
+				Name:            "name",
+				Version:         "version",
+				LastScheduledAt: lastScheduledAt,
+			}, request)
+			return &interfaces.ScheduleCheckpoint{
+				Project:         request.Project,
+				Domain:          request.Domain,
+				Name:            request.Name,
+				Version:         request.Version,
+				LastScheduledAt: request.LastScheduledAt,
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewScheduleCheckpointsHandler(&checkpoints).ServeHTTP(recorder, httptest.NewRequest(http.MethodPut,
+		scheduleCheckpointQuery, strings.NewReader(`{"lastScheduledAt": "2021-11-01T00:00:00Z"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.ScheduleCheckpoint
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, lastScheduledAt, response.LastScheduledAt)
+}
+
+func TestScheduleCheckpointsHandler_Errors(t *testing.T) {
+	checkpoints := mocks.ScheduleCheckpointManager{
+		UpdateScheduleCheckpointFunc: func(ctx context.Context, request interfaces.UpdateScheduleCheckpointRequest) (
+			*interfaces.ScheduleCheckpoint, error) {
+			return nil, errors.NewFlyteAdminError(codes.NotFound, "missing launch plan")
+		},
+	}
+	handler := NewScheduleCheckpointsHandler(&checkpoints)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, scheduleCheckpointQuery,
+		strings.NewReader(`{"lastScheduledAt": "2021-11-01T00:00:00Z"}`)))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "missing launch plan")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, scheduleCheckpointQuery,
+		strings.NewReader(`{"lastScheduledAt": "yesterday"}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, scheduleCheckpointQuery, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestScheduleCheckpointsHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewScheduleCheckpointsHandler(&mocks.ScheduleCheckpointManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet, scheduleCheckpointQuery, nil))
+	assert.Equal(t, "ListScheduleCheckpoints", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+
+	method, _ = handler.AuthorizationMethod(httptest.NewRequest(http.MethodPut, scheduleCheckpointQuery, nil))
+	assert.Equal(t, "UpdateScheduleCheckpoint", method)
+}
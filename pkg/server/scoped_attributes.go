@@ -0,0 +1,164 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+// The path the matchable attributes of a project in all its domains, of a domain or of an org are read from, e.g.
+// GET /api/v1/scoped_attributes?domain=d&resource_type=TASK_RESOURCE, and which admins set them at with PUT and a body
+// of matching attributes, e.g. {"task_resource_attributes": {"defaults": {"cpu": "1"}}}, or remove them at with DELETE.
+// Exactly one of the project, domain and org query parameters is given.
+const ScopedAttributesPath = "/api/v1/scoped_attributes"
+
+// The admin service methods requests to scoped attributes are authorized as.
+const (
+	getScopedAttributesMethod    = "GetScopedAttributes"
+	updateScopedAttributesMethod = "UpdateScopedAttributes"
+	deleteScopedAttributesMethod = "DeleteScopedAttributes"
+)
+
+// Scoped attributes can't be much larger than the attributes of a project and domain.
+const maxScopedAttributesBodyBytes = 1 << 20
+
+type scopedAttributesResponse struct {
+	Project    string                   `json:"project,omitempty"`
+	Domain     string                   `json:"domain,omitempty"`
+	Org        string                   `json:"org,omitempty"`
+	Level      interfaces.ResourceLevel `json:"level"`
+	Attributes json.RawMessage          `json:"attributes"`
+}
+
+type scopedAttributesHandler struct {
+	resources interfaces.ResourceInterface
+}
+
+func getAttributesScope(r *http.Request) interfaces.AttributesScope {
+	query := r.URL.Query()
+	return interfaces.AttributesScope{
+		Project: query.Get("project"),
+		Domain:  query.Get("domain"),
+		Org:     query.Get("org"),
+	}
+}
+
+func getScopedAttributesResourceType(r *http.Request) (admin.MatchableResource, error) {
+	resourceType, ok := admin.MatchableResource_value[r.URL.Query().Get("resource_type")]
+	if !ok {
+		return 0, fmt.Errorf("unknown resource type [%s]", r.URL.Query().Get("resource_type"))
+	}
+	return admin.MatchableResource(resourceType), nil
+}
+
+// Attributes of a domain or an org apply to every project in it, so their scope names no project and only bindings which
+// aren't restricted to a project grant access to them.
+func (h *scopedAttributesHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	scope := authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return getScopedAttributesMethod, scope
+	case http.MethodDelete:
+		return deleteScopedAttributesMethod, scope
+	}
+	return updateScopedAttributesMethod, scope
+}
+
+func (h *scopedAttributesHandler) get(w http.ResponseWriter, r *http.Request, scope interfaces.AttributesScope) {
+	resourceType, err := getScopedAttributesResourceType(r)
+	if err != nil {
+		http.Error(w, "invalid scoped attributes request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.resources.GetScopedAttributes(r.Context(), interfaces.ScopedAttributesGetRequest{
+		Scope:        scope,
+		ResourceType: resourceType,
+	})
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	attributes, err := marshalProtoJSON(response.MatchingAttributes)
+	if err != nil {
+		writeJSON(w, r, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, scopedAttributesResponse{
+		Project:    response.Scope.Project,
+		Domain:     response.Scope.Domain,
+		Org:        response.Scope.Org,
+		Level:      response.Level,
+		Attributes: attributes,
+	})
+}
+
+func (h *scopedAttributesHandler) update(w http.ResponseWriter, r *http.Request, scope interfaces.AttributesScope) {
+	var attributes admin.MatchingAttributes
+	if err := jsonpb.Unmarshal(http.MaxBytesReader(w, r.Body, maxScopedAttributesBodyBytes), &attributes); err != nil {
+		http.Error(w, "invalid scoped attributes: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.resources.UpdateScopedAttributes(r.Context(), interfaces.ScopedAttributesUpdateRequest{
+		Scope:              scope,
+		MatchingAttributes: &attributes,
+	}); err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, struct{}{})
+}
+
+func (h *scopedAttributesHandler) delete(w http.ResponseWriter, r *http.Request, scope interfaces.AttributesScope) {
+	resourceType, err := getScopedAttributesResourceType(r)
+	if err != nil {
+		http.Error(w, "invalid scoped attributes request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = h.resources.DeleteScopedAttributes(r.Context(), interfaces.ScopedAttributesDeleteRequest{
+		Scope:        scope,
+		ResourceType: resourceType,
+	}); err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, struct{}{})
+}
+
+func (h *scopedAttributesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	scope := getAttributesScope(r)
+	switch r.Method {
+	case http.MethodGet:
+		h.get(w, r, scope)
+	case http.MethodPut:
+		h.update(w, r, scope)
+	case http.MethodDelete:
+		h.delete(w, r, scope)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut+", "+http.MethodDelete)
+		http.Error(w, "only GET, PUT and DELETE requests are supported", http.StatusMethodNotAllowed)
+	}
+}
+
+// NewScopedAttributesHandler returns a handler reading and setting the matchable attributes of projects in all their
+// domains, of domains and of orgs, which admin.ProjectDomainAttributes can't express. It stands in for scoped
+// attributes rpcs until they're part of the admin service definition, and implements auth.AuthorizedHTTPHandler so
+// that only admins can set attributes, like those of a project and domain.
+func NewScopedAttributesHandler(resources interfaces.ResourceInterface) http.Handler {
+	return &scopedAttributesHandler{
+		resources: resources,
+	}
+}
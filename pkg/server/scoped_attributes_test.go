@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+const scopedAttributesQuery = ScopedAttributesPath + "?domain=domain&resource_type=TASK_RESOURCE"
+
+func TestScopedAttributesHandler(t *testing.T) {
+	var updated *admin.MatchingAttributes
+	deleted := false
+	resources := mocks.MockResourceManager{
+		GetScopedFunc: func(ctx context.Context, request interfaces.ScopedAttributesGetRequest) (
+			*interfaces.ScopedAttributesGetResponse, error) {
+			assert.Equal(t, interfaces.ScopedAttributesGetRequest{
+				Scope:        interfaces.AttributesScope{Domain: "domain"},
+				ResourceType: admin.MatchableResource_TASK_RESOURCE,
+			}, request)
+			return &interfaces.ScopedAttributesGetResponse{
+				Scope: request.Scope,
+				Level: interfaces.ResourceLevelDomain,
+				MatchingAttributes: &admin.MatchingAttributes{
+					Target: &admin.MatchingAttributes_TaskResourceAttributes{
+						TaskResourceAttributes: &admin.TaskResourceAttributes{
+							Defaults: &admin.TaskResourceSpec{Cpu: "1"},
+						},
+					},
+				},
+			}, nil
+		},
+		UpdateScopedFunc: func(ctx context.Context, request interfaces.ScopedAttributesUpdateRequest) error {
+			assert.Equal(t, interfaces.AttributesScope{Domain: "domain"}, request.Scope)
+			updated = request.MatchingAttributes
+			return nil
+		},
+		DeleteScopedFunc: func(ctx context.Context, request interfaces.ScopedAttributesDeleteRequest) error {
+			assert.Equal(t, interfaces.ScopedAttributesDeleteRequest{
+				Scope:        interfaces.AttributesScope{Domain: "domain"},
+				ResourceType: admin.MatchableResource_TASK_RESOURCE,
+			}, request)
+			deleted = true
+			return nil
+		},
+	}
+	handler := NewScopedAttributesHandler(&resources)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, scopedAttributesQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response scopedAttributesResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "domain", response.Domain)
+	assert.Equal(t, interfaces.ResourceLevelDomain, response.Level)
+	var attributes admin.MatchingAttributes
+	assert.NoError(t, jsonpb.UnmarshalString(string(response.Attributes), &attributes))
+	assert.Equal(t, "1", attributes.GetTaskResourceAttributes().Defaults.Cpu)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, ScopedAttributesPath+"?domain=domain",
+		strings.NewReader(`{"task_resource_attributes": {"defaults": {"cpu": "2"}}}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "2", updated.GetTaskResourceAttributes().Defaults.Cpu)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, scopedAttributesQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, deleted)
+}
+
+func TestScopedAttributesHandler_Errors(t *testing.T) {
+	resources := mocks.MockResourceManager{
+		GetScopedFunc: func(ctx context.Context, request interfaces.ScopedAttributesGetRequest) (
+			*interfaces.ScopedAttributesGetResponse, error) {
+			return nil, errors.NewFlyteAdminError(codes.NotFound, "no attributes")
+		},
+		UpdateScopedFunc: func(ctx context.Context, request interfaces.ScopedAttributesUpdateRequest) error {
+			return errors.NewFlyteAdminError(codes.InvalidArgument, "exactly one of the scope fields must be set")
+		},
+	}
+	handler := NewScopedAttributesHandler(&resources)
+	for _, test := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{"not found", http.MethodGet, scopedAttributesQuery, "", http.StatusNotFound},
+		{"resource type", http.MethodGet, ScopedAttributesPath + "?domain=domain&resource_type=NOPE", "",
+			http.StatusBadRequest},
+		{"delete resource type", http.MethodDelete, ScopedAttributesPath + "?domain=domain", "",
+			http.StatusBadRequest},
+		{"invalid attributes", http.MethodPut, ScopedAttributesPath + "?domain=domain", "not json",
+			http.StatusBadRequest},
+		{"manager error", http.MethodPut, ScopedAttributesPath, `{"execution_queue_attributes": {}}`,
+			http.StatusBadRequest},
+		{"method", http.MethodPost, scopedAttributesQuery, "", http.StatusMethodNotAllowed},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+			assert.Equal(t, test.code, recorder.Code)
+		})
+	}
+}
+
+func TestScopedAttributesHandler_AuthorizationMethod(t *testing.T) {
+	handler, ok := NewScopedAttributesHandler(&mocks.MockResourceManager{}).(auth.AuthorizedHTTPHandler)
+	assert.True(t, ok)
+	for method, expected := range map[string]string{
+		http.MethodGet:    "GetScopedAttributes",
+		http.MethodPut:    "UpdateScopedAttributes",
+		http.MethodDelete: "DeleteScopedAttributes",
+	} {
+		authorizationMethod, scope := handler.AuthorizationMethod(
+			httptest.NewRequest(method, ScopedAttributesPath+"?project=project&resource_type=TASK_RESOURCE", nil))
+		assert.Equal(t, expected, authorizationMethod)
+		assert.Equal(t, "project", scope.Project)
+		assert.Empty(t, scope.Domain)
+	}
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/status"
+
+	authInterfaces "github.com/flyteorg/flyteadmin/auth/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+)
+
+// The path clients request execution statistics from, e.g.
+// /api/v1/statistics/executions?project=p&start=2021-06-01T00:00:00Z&end=2021-07-01T00:00:00Z&bucketSize=24h.
+const ExecutionStatisticsPath = "/api/v1/statistics/executions"
+
+// The admin service method statistics requests are authorized as.
+const getExecutionStatisticsMethod = "GetExecutionStatistics"
+
+type executionStatisticsHandler struct {
+	statistics interfaces.StatisticsInterface
+}
+
+// Reads the statistics request from the query, with times in RFC 3339 and the bucket size as a duration such as 24h.
+func getExecutionStatisticsRequest(r *http.Request) (interfaces.ExecutionStatisticsRequest, error) {
+	query := r.URL.Query()
+	request := interfaces.ExecutionStatisticsRequest{
+		Project: query.Get("project"),
+		Domain:  query.Get("domain"),
+	}
+	var err error
+	if request.Start, err = time.Parse(time.RFC3339, query.Get("start")); err != nil {
+		return request, err
+	}
+	if request.End, err = time.Parse(time.RFC3339, query.Get("end")); err != nil {
+		return request, err
+	}
+	if request.BucketSize, err = time.ParseDuration(query.Get("bucketSize")); err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+func (h *executionStatisticsHandler) AuthorizationMethod(r *http.Request) (string, authInterfaces.ResourceScope) {
+	return getExecutionStatisticsMethod, authInterfaces.ResourceScope{
+		Project: r.URL.Query().Get("project"),
+		Domain:  r.URL.Query().Get("domain"),
+	}
+}
+
+// Callers who can only read some projects get the statistics of the executions of those projects when they don't ask
+// for a project.
+func (h *executionStatisticsHandler) LimitsToAuthorizedProjects() bool {
+	return true
+}
+
+func (h *executionStatisticsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	request, err := getExecutionStatisticsRequest(r)
+	if err != nil {
+		http.Error(w, "invalid execution statistics request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := h.statistics.GetExecutionStatistics(r.Context(), request)
+	if err != nil {
+		s, _ := status.FromError(err)
+		writeJSON(w, r, runtime.HTTPStatusFromCode(s.Code()), map[string]string{"error": s.Message()})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, response)
+}
+
+// NewExecutionStatisticsHandler returns a handler serving execution statistics as JSON. It stands in for a statistics
+// rpc until one is part of the admin service definition, and implements auth.ProjectLimitedHTTPHandler so that
+// statistics only aggregate the executions of the projects the caller can read.
+func NewExecutionStatisticsHandler(statistics interfaces.StatisticsInterface) http.Handler {
+	return &executionStatisticsHandler{
+		statistics: statistics,
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/flyteorg/flyteadmin/auth"
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/manager/mocks"
+)
+
+const executionStatisticsQuery = ExecutionStatisticsPath +
+	"?project=project&start=2021-06-01T00:00:00Z&end=2021-06-02T00:00:00Z&bucketSize=24h"
+
+func TestExecutionStatisticsHandler(t *testing.T) {
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	statistics := mocks.StatisticsManager{
+		GetExecutionStatisticsFunc: func(ctx context.Context, request interfaces.ExecutionStatisticsRequest) (
+			*interfaces.ExecutionStatistics, error) {
+			assert.Equal(t, interfaces.ExecutionStatisticsRequest{
+				Project:    "project",
+				Start:      start,
+				End:        start.Add(24 * time.Hour),
+				BucketSize: 24 * time.Hour,
+			}, request)
+			return &interfaces.ExecutionStatistics{
+				Buckets: []interfaces.ExecutionStatisticsBucket{
+					{
+						Start:               start,
+						End:                 start.Add(24 * time.Hour),
+						PhaseCounts:         map[string]int64{"SUCCEEDED": 2},
+						Total:               2,
+						SuccessRate:         1,
+						DurationPercentiles: map[string]time.Duration{"p95": time.Minute},
+					},
+				},
+			}, nil
+		},
+	}
+	recorder := httptest.NewRecorder()
+	NewExecutionStatisticsHandler(&statistics).ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodGet, executionStatisticsQuery, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response interfaces.ExecutionStatistics
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response.Buckets, 1)
+	assert.Equal(t, int64(2), response.Buckets[0].Total)
+	assert.Equal(t, time.Minute, response.Buckets[0].DurationPercentiles["p95"])
+}
+
+func TestExecutionStatisticsHandler_Errors(t *testing.T) {
+	statistics := mocks.StatisticsManager{
+		GetExecutionStatisticsFunc: func(ctx context.Context, request interfaces.ExecutionStatisticsRequest) (
+			*interfaces.ExecutionStatistics, error) {
+			return nil, errors.NewFlyteAdminError(codes.InvalidArgument, "too many buckets")
+		},
+	}
+	handler := NewExecutionStatisticsHandler(&statistics)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, executionStatisticsQuery, nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "too many buckets")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		ExecutionStatisticsPath+"?start=2021-06-01&end=2021-06-02&bucketSize=24h", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, executionStatisticsQuery, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestExecutionStatisticsHandler_AuthorizationMethod(t *testing.T) {
+	handler := NewExecutionStatisticsHandler(&mocks.StatisticsManager{}).(auth.ProjectLimitedHTTPHandler)
+	method, scope := handler.AuthorizationMethod(httptest.NewRequest(http.MethodGet,
+		executionStatisticsQuery+"&domain=domain", nil))
+	assert.Equal(t, "GetExecutionStatistics", method)
+	assert.Equal(t, "project", scope.Project)
+	assert.Equal(t, "domain", scope.Domain)
+	assert.True(t, handler.LimitsToAuthorizedProjects())
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+)
+
+// TimeoutInterceptor bounds the time spent handling each request so that pathological requests cannot hold on to
+// resources such as database connections indefinitely.
+type TimeoutInterceptor struct {
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+	timeouts       *prometheus.CounterVec
+}
+
+func (t *TimeoutInterceptor) timeoutForMethod(fullMethod string) time.Duration {
+	if timeout, ok := t.methodTimeouts[fullMethod]; ok {
+		return timeout
+	}
+	if timeout, ok := t.methodTimeouts[common.ShortMethodName(fullMethod)]; ok {
+		return timeout
+	}
+	return t.defaultTimeout
+}
+
+func (t *TimeoutInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (
+		interface{}, error) {
+		timeout := t.timeoutForMethod(info.FullMethod)
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		// A deadline set by the client still applies when it is shorter than the server side timeout.
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		resp, err := handler(ctx, req)
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			t.timeouts.WithLabelValues(info.FullMethod).Inc()
+			logger.Warningf(ctx, "Request to [%s] exceeded its deadline, err: %v", info.FullMethod, err)
+			return nil, status.Errorf(codes.DeadlineExceeded, "request to %s exceeded its deadline", info.FullMethod)
+		}
+		return resp, err
+	}
+}
+
+// NewTimeoutInterceptor returns an interceptor which applies defaultTimeout to each request unless a timeout is
+// configured for the method, keyed by its short (ListExecutions) or fully qualified name. A non-positive timeout
+// disables enforcement.
+func NewTimeoutInterceptor(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration,
+	scope promutils.Scope) *TimeoutInterceptor {
+	return &TimeoutInterceptor{
+		defaultTimeout: defaultTimeout,
+		methodTimeouts: methodTimeouts,
+		timeouts: scope.MustNewCounterVec("request_deadline_exceeded",
+			"requests which exceeded their deadline while being handled", "method"),
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	repositoryMocks "github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const listExecutionsMethod = "/flyteidl.service.AdminService/ListExecutions"
+const createExecutionMethod = "/flyteidl.service.AdminService/CreateExecution"
+
+// Returns a handler backed by a mock repository whose queries take queryDuration unless their context is cancelled.
+func getSlowRepoHandler(queryDuration time.Duration) grpc.UnaryHandler {
+	repository := repositoryMocks.NewMockRepository()
+	repository.ExecutionRepo().(*repositoryMocks.MockExecutionRepo).SetListCallback(
+		func(ctx context.Context, input interfaces.ListResourceInput) (interfaces.ExecutionCollectionOutput, error) {
+			select {
+			case <-ctx.Done():
+				return interfaces.ExecutionCollectionOutput{}, ctx.Err()
+			case <-time.After(queryDuration):
+				return interfaces.ExecutionCollectionOutput{}, nil
+			}
+		})
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return repository.ExecutionRepo().List(ctx, interfaces.ListResourceInput{})
+	}
+}
+
+func TestTimeoutInterceptor_DeadlineExceeded(t *testing.T) {
+	timeoutInterceptor := NewTimeoutInterceptor(10*time.Millisecond, nil, promutils.NewTestScope())
+	_, err := timeoutInterceptor.UnaryServerInterceptor()(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: listExecutionsMethod}, getSlowRepoHandler(time.Minute))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(timeoutInterceptor.timeouts.WithLabelValues(listExecutionsMethod)))
+}
+
+func TestTimeoutInterceptor_MethodOverride(t *testing.T) {
+	timeoutInterceptor := NewTimeoutInterceptor(10*time.Millisecond, map[string]time.Duration{
+		"CreateExecution": time.Minute,
+	}, promutils.NewTestScope())
+	_, err := timeoutInterceptor.UnaryServerInterceptor()(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: createExecutionMethod}, getSlowRepoHandler(50*time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), testutil.ToFloat64(timeoutInterceptor.timeouts.WithLabelValues(createExecutionMethod)))
+}
+
+func TestTimeoutInterceptor_Disabled(t *testing.T) {
+	timeoutInterceptor := NewTimeoutInterceptor(0, nil, promutils.NewTestScope())
+	_, err := timeoutInterceptor.UnaryServerInterceptor()(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: listExecutionsMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+			return nil, nil
+		})
+	assert.NoError(t, err)
+}
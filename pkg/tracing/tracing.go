@@ -0,0 +1,62 @@
+// Traces the requests served by flyteadmin with OpenTelemetry.
+package tracing
+
+import (
+	"context"
+
+	"github.com/flyteorg/flyteadmin/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/flyteorg/flyteadmin"
+
+// Returns the tracer spans are started with. It's a no-op until a provider is set.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Sets the provider spans are started with, and continues the traces of callers from the W3C trace context they
+// propagate.
+func SetTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Exports the spans started to the collector configured, when tracing is enabled. The returned function flushes the
+// spans yet to be exported and should be called before exiting.
+func Init(ctx context.Context, options config.TracingOptions) (shutdown func(context.Context) error, err error) {
+	if !options.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporterOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(options.ExporterEndpoint)}
+	if options.Insecure {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(options.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SamplerRatio))),
+	)
+	SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Ends a span, marking it as failed when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
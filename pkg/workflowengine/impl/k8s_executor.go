@@ -2,12 +2,20 @@ package impl
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/common"
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
 	execClusterInterfaces "github.com/flyteorg/flyteadmin/pkg/executioncluster/interfaces"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/tracing"
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	"github.com/flyteorg/flytestdlib/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	k8_api_err "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,11 +25,25 @@ var deletePropagationBackground = v1.DeletePropagationBackground
 
 const defaultIdentifier = "DefaultK8sExecutor"
 
+// The annotation set on workflows with the id of the request which created them.
+const requestIDAnnotation = "flyte.org/request-id"
+
+// Attributes of the spans of the calls made to execution clusters.
+const (
+	clusterKey   = attribute.Key("flyte.cluster")
+	namespaceKey = attribute.Key("k8s.namespace.name")
+	nameKey      = attribute.Key("flyte.workflow.name")
+	attemptsKey  = attribute.Key("flyte.attempts")
+)
+
 // K8sWorkflowExecutor directly creates and delete Flyte workflow execution CRD objects using the configured execution
 // cluster interface.
 type K8sWorkflowExecutor struct {
 	executionCluster execClusterInterfaces.ClusterInterface
 	workflowBuilder  interfaces.FlyteWorkflowBuilder
+	createRetry      runtimeInterfaces.WorkflowCreateRetryConfig
+	// Unset when service accounts aren't checked.
+	serviceAccountChecker *serviceAccountChecker
 }
 
 func (e K8sWorkflowExecutor) ID() string {
@@ -40,30 +62,86 @@ func (e K8sWorkflowExecutor) Execute(ctx context.Context, data interfaces.Execut
 	if err != nil {
 		return interfaces.ExecutionResponse{}, err
 	}
+	if requestID := common.GetRequestID(ctx); len(requestID) > 0 {
+		// Lets the logs of propeller be joined with those of the request which launched the workflow.
+		flyteWf.Annotations[requestIDAnnotation] = requestID
+	}
+	// The workflow is named after the execution so that retried creations can't create duplicates.
+	flyteWf.Name = data.ExecutionID.Name
+	flyteWf.GenerateName = ""
 
 	executionTargetSpec := executioncluster.ExecutionTargetSpec{
-		Project:     data.ExecutionID.Project,
-		Domain:      data.ExecutionID.Domain,
-		Workflow:    data.ReferenceWorkflowName,
-		LaunchPlan:  data.ReferenceWorkflowName,
-		ExecutionID: data.ExecutionID.Name,
+		Project:               data.ExecutionID.Project,
+		Domain:                data.ExecutionID.Domain,
+		Workflow:              data.ReferenceWorkflowName,
+		LaunchPlan:            data.ReferenceLaunchPlanName,
+		ExecutionID:           data.ExecutionID.Name,
+		ExecutionClusterLabel: data.ExecutionClusterLabel,
 	}
 	targetCluster, err := e.executionCluster.GetTarget(ctx, &executionTargetSpec)
 	if err != nil {
 		return interfaces.ExecutionResponse{}, errors.NewFlyteAdminErrorf(codes.Internal, "failed to create workflow in propeller %v", err)
 	}
-	_, err = targetCluster.FlyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(data.Namespace).Create(ctx, flyteWf, v1.CreateOptions{})
-	if err != nil {
-		if !k8_api_err.IsAlreadyExists(err) {
-			logger.Debugf(context.TODO(), "Failed to create execution [%+v] in cluster: %s", data.ExecutionID, targetCluster.ID)
-			return interfaces.ExecutionResponse{}, errors.NewFlyteAdminErrorf(codes.Internal, "failed to create workflow in propeller %v", err)
+	if e.serviceAccountChecker != nil {
+		err = e.serviceAccountChecker.check(ctx, targetCluster, data.Namespace, flyteWf.ServiceAccountName)
+		if err != nil {
+			return interfaces.ExecutionResponse{}, err
 		}
 	}
+	err = e.createWorkflow(ctx, targetCluster, data.Namespace, flyteWf)
+	if err != nil {
+		logger.Debugf(context.TODO(), "Failed to create execution [%+v] in cluster: %s", data.ExecutionID, targetCluster.ID)
+		return interfaces.ExecutionResponse{}, errors.NewFlyteAdminErrorf(codes.Internal, "failed to create workflow in propeller %v", err)
+	}
 	return interfaces.ExecutionResponse{
 		Cluster: targetCluster.ID,
 	}, nil
 }
 
+// API server errors which may go away when the request is retried. Errors without an API status, such as failures to
+// connect, are considered transient as well.
+func isTransientCreateError(err error) bool {
+	if _, ok := err.(k8_api_err.APIStatus); !ok {
+		return true
+	}
+	return k8_api_err.IsServerTimeout(err) || k8_api_err.IsTimeout(err) || k8_api_err.IsTooManyRequests(err) ||
+		k8_api_err.IsServiceUnavailable(err) || k8_api_err.IsInternalError(err)
+}
+
+// Creates the workflow CRD, retrying transient failures with exponential backoff until the retry budget is spent. A
+// workflow which already exists was created by an earlier attempt, since workflows are named after their execution.
+func (e K8sWorkflowExecutor) createWorkflow(ctx context.Context, targetCluster *executioncluster.ExecutionTarget,
+	namespace string, flyteWf *v1alpha1.FlyteWorkflow) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "k8s.CreateFlyteWorkflow", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(clusterKey.String(targetCluster.ID), namespaceKey.String(namespace), nameKey.String(flyteWf.Name)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	deadline := time.Now().Add(e.createRetry.Budget.Duration)
+	backoff := e.createRetry.InitialBackoff.Duration
+	for attempt := 1; ; attempt++ {
+		_, err = targetCluster.FlyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace).Create(ctx, flyteWf, v1.CreateOptions{})
+		if err == nil || k8_api_err.IsAlreadyExists(err) {
+			span.SetAttributes(attemptsKey.Int(attempt))
+			return nil
+		}
+		if !isTransientCreateError(err) || time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+		logger.Warningf(ctx, "Attempt %d to create workflow [%s] in cluster %s failed, retrying in %v: %v",
+			attempt, flyteWf.Name, targetCluster.ID, backoff, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if maxBackoff := e.createRetry.MaxBackoff.Duration; maxBackoff > 0 && backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
 func (e K8sWorkflowExecutor) Abort(ctx context.Context, data interfaces.AbortData) error {
 	target, err := e.executionCluster.GetTarget(ctx, &executioncluster.ExecutionTargetSpec{
 		TargetID: data.Cluster,
@@ -71,9 +149,7 @@ func (e K8sWorkflowExecutor) Abort(ctx context.Context, data interfaces.AbortDat
 	if err != nil {
 		return errors.NewFlyteAdminErrorf(codes.Internal, err.Error())
 	}
-	err = target.FlyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(data.Namespace).Delete(ctx, data.ExecutionID.GetName(), v1.DeleteOptions{
-		PropagationPolicy: &deletePropagationBackground,
-	})
+	err = e.deleteWorkflow(ctx, target, data.Namespace, data.ExecutionID.GetName())
 	// An IsNotFound error indicates the resource is already deleted.
 	if err != nil && !k8_api_err.IsNotFound(err) {
 		return errors.NewFlyteAdminErrorf(codes.Internal, "failed to terminate execution: %v with err %v", data.ExecutionID, err)
@@ -81,11 +157,43 @@ func (e K8sWorkflowExecutor) Abort(ctx context.Context, data interfaces.AbortDat
 	return nil
 }
 
+func (e K8sWorkflowExecutor) deleteWorkflow(ctx context.Context, target *executioncluster.ExecutionTarget,
+	namespace, name string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "k8s.DeleteFlyteWorkflow", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(clusterKey.String(target.ID), namespaceKey.String(namespace), nameKey.String(name)))
+	err := target.FlyteClient.FlyteworkflowV1alpha1().FlyteWorkflows(namespace).Delete(ctx, name, v1.DeleteOptions{
+		PropagationPolicy: &deletePropagationBackground,
+	})
+	if k8_api_err.IsNotFound(err) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
+	}
+	return err
+}
+
+// HealthCheck verifies that each valid execution cluster's API server is reachable.
+func (e K8sWorkflowExecutor) HealthCheck(ctx context.Context) error {
+	for _, target := range e.executionCluster.GetAllValidTargets() {
+		err := target.FlyteClient.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+		if err != nil {
+			return fmt.Errorf("failed to reach execution cluster [%s]: %v", target.ID, err)
+		}
+	}
+	return nil
+}
+
 func NewK8sWorkflowExecutor(executionCluster execClusterInterfaces.ClusterInterface,
-	workflowBuilder interfaces.FlyteWorkflowBuilder) *K8sWorkflowExecutor {
+	workflowBuilder interfaces.FlyteWorkflowBuilder, createRetry runtimeInterfaces.WorkflowCreateRetryConfig,
+	serviceAccountCheck runtimeInterfaces.ServiceAccountCheckConfig) *K8sWorkflowExecutor {
 
-	return &K8sWorkflowExecutor{
+	executor := &K8sWorkflowExecutor{
 		executionCluster: executionCluster,
 		workflowBuilder:  workflowBuilder,
+		createRetry:      createRetry,
+	}
+	if serviceAccountCheck.Enabled {
+		executor.serviceAccountChecker = newServiceAccountChecker(serviceAccountCheck)
 	}
+	return executor
 }
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine/mocks"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/mock"
 
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	"github.com/flyteorg/flytestdlib/config"
 	"github.com/stretchr/testify/assert"
 	k8_api_err "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -17,6 +20,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
 	execClusterIfaces "github.com/flyteorg/flyteadmin/pkg/executioncluster/interfaces"
 	clusterMock "github.com/flyteorg/flyteadmin/pkg/executioncluster/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
 	flyteclient "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned"
@@ -171,6 +175,37 @@ func TestExecute(t *testing.T) {
 	assert.Equal(t, resp.Cluster, clusterID)
 }
 
+func TestExecute_ClusterAssignment(t *testing.T) {
+	fakeFlyteWF.flyteWorkflowsCallback = nil
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(flyteWf, nil)
+	fakeCluster := clusterMock.MockCluster{}
+	fakeCluster.SetGetTargetCallback(func(ctx context.Context, spec *executioncluster.ExecutionTargetSpec) (target *executioncluster.ExecutionTarget, e error) {
+		assert.Equal(t, "ref_workflow_name", spec.Workflow)
+		assert.Equal(t, "ref_lp_name", spec.LaunchPlan)
+		assert.True(t, proto.Equal(&admin.ExecutionClusterLabel{Value: "gpu"}, spec.ExecutionClusterLabel))
+		return &executioncluster.ExecutionTarget{
+			ID:          "gpu-cluster",
+			FlyteClient: &FakeK8FlyteClient{},
+		}, nil
+	})
+	executor := K8sWorkflowExecutor{
+		workflowBuilder:  &mockBuilder,
+		executionCluster: &fakeCluster,
+	}
+
+	resp, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:               namespace,
+		ExecutionID:             execID,
+		ReferenceWorkflowName:   "ref_workflow_name",
+		ReferenceLaunchPlanName: "ref_lp_name",
+		WorkflowClosure:         &core.CompiledWorkflowClosure{},
+		ExecutionClusterLabel:   &admin.ExecutionClusterLabel{Value: "gpu"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "gpu-cluster", resp.Cluster)
+}
+
 func TestExecute_AlreadyExists(t *testing.T) {
 	fakeFlyteWorkflow := FakeFlyteWorkflow{}
 	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
@@ -222,6 +257,122 @@ func TestExecute_MiscError(t *testing.T) {
 	assert.EqualError(t, err, "failed to create workflow in propeller call failed")
 }
 
+var testCreateRetry = runtimeInterfaces.WorkflowCreateRetryConfig{
+	InitialBackoff: config.Duration{Duration: time.Millisecond},
+	MaxBackoff:     config.Duration{Duration: 2 * time.Millisecond},
+	Budget:         config.Duration{Duration: 50 * time.Millisecond},
+}
+
+func TestExecute_RetriesTransientErrors(t *testing.T) {
+	failures := []error{
+		k8_api_err.NewServerTimeout(schema.GroupResource{}, "create", 1),
+		k8_api_err.NewTooManyRequests("slow down", 1),
+		errors.New("connection refused"),
+	}
+	var attempts int
+	fakeFlyteWorkflow := FakeFlyteWorkflow{}
+	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
+		assert.Equal(t, execID.Name, flyteWorkflow.Name)
+		attempts++
+		if attempts <= len(failures) {
+			return nil, failures[attempts-1]
+		}
+		return nil, nil
+	}
+	fakeFlyteWF.flyteWorkflowsCallback = func(ns string) v1alpha12.FlyteWorkflowInterface {
+		return &fakeFlyteWorkflow
+	}
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(&v1alpha1.FlyteWorkflow{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: "random-",
+		},
+	}, nil)
+	executor := NewK8sWorkflowExecutor(getFakeExecutionCluster(), &mockBuilder, testCreateRetry,
+		runtimeInterfaces.ServiceAccountCheckConfig{})
+
+	resp, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:   namespace,
+		ExecutionID: execID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, clusterID, resp.Cluster)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestExecute_AlreadyExistsOnRetry(t *testing.T) {
+	var attempts int
+	fakeFlyteWorkflow := FakeFlyteWorkflow{}
+	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
+		attempts++
+		if attempts == 1 {
+			// The creation went through, but the response timed out.
+			return nil, k8_api_err.NewTimeoutError("timed out", 1)
+		}
+		return nil, k8_api_err.NewAlreadyExists(schema.GroupResource{}, flyteWorkflow.Name)
+	}
+	fakeFlyteWF.flyteWorkflowsCallback = func(ns string) v1alpha12.FlyteWorkflowInterface {
+		return &fakeFlyteWorkflow
+	}
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(&v1alpha1.FlyteWorkflow{}, nil)
+	executor := NewK8sWorkflowExecutor(getFakeExecutionCluster(), &mockBuilder, testCreateRetry,
+		runtimeInterfaces.ServiceAccountCheckConfig{})
+
+	_, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:   namespace,
+		ExecutionID: execID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestExecute_RetryBudgetSpent(t *testing.T) {
+	var attempts int
+	fakeFlyteWorkflow := FakeFlyteWorkflow{}
+	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
+		attempts++
+		return nil, k8_api_err.NewServiceUnavailable("unavailable")
+	}
+	fakeFlyteWF.flyteWorkflowsCallback = func(ns string) v1alpha12.FlyteWorkflowInterface {
+		return &fakeFlyteWorkflow
+	}
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(&v1alpha1.FlyteWorkflow{}, nil)
+	executor := NewK8sWorkflowExecutor(getFakeExecutionCluster(), &mockBuilder, testCreateRetry,
+		runtimeInterfaces.ServiceAccountCheckConfig{})
+
+	_, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:   namespace,
+		ExecutionID: execID,
+	})
+	assert.EqualError(t, err, "failed to create workflow in propeller unavailable")
+	assert.True(t, attempts > 1)
+}
+
+func TestExecute_NoRetryOfPermanentErrors(t *testing.T) {
+	var attempts int
+	fakeFlyteWorkflow := FakeFlyteWorkflow{}
+	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
+		attempts++
+		return nil, k8_api_err.NewBadRequest("invalid workflow")
+	}
+	fakeFlyteWF.flyteWorkflowsCallback = func(ns string) v1alpha12.FlyteWorkflowInterface {
+		return &fakeFlyteWorkflow
+	}
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(&v1alpha1.FlyteWorkflow{}, nil)
+	executor := NewK8sWorkflowExecutor(getFakeExecutionCluster(), &mockBuilder, testCreateRetry,
+		runtimeInterfaces.ServiceAccountCheckConfig{})
+
+	_, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:   namespace,
+		ExecutionID: execID,
+	})
+	assert.EqualError(t, err, "failed to create workflow in propeller invalid workflow")
+	assert.Equal(t, 1, attempts)
+}
+
 func TestAbort(t *testing.T) {
 	fakeFlyteWorkflow := FakeFlyteWorkflow{}
 	fakeFlyteWorkflow.deleteCallback = func(name string, options *v1.DeleteOptions) error {
@@ -1,11 +1,14 @@
 package impl
 
 import (
+	"sort"
+
 	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -38,9 +41,42 @@ func addPermissions(securityCtx *core.SecurityContext, roleNameKey string, flyte
 	}
 }
 
+// Sets the environment variables given on the containers of the tasks of the workflow, replacing those the tasks were
+// registered with under the same name. The flyte workflow execution config has no field for them, so they're set on the
+// task templates propeller builds the task pods from instead. Those are copied first, they may be shared with the
+// cached workflow closure the workflow was built from.
+func addEnvs(envs map[string]string, flyteWf *v1alpha1.FlyteWorkflow) {
+	if len(envs) == 0 {
+		return
+	}
+	names := make([]string, 0, len(envs))
+	for name := range envs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for taskID, task := range flyteWf.Tasks {
+		if task == nil || task.TaskTemplate.GetContainer() == nil {
+			continue
+		}
+		template := proto.Clone(task.TaskTemplate).(*core.TaskTemplate)
+		container := template.GetContainer()
+		env := make([]*core.KeyValuePair, 0, len(container.Env)+len(envs))
+		for _, pair := range container.Env {
+			if _, ok := envs[pair.Key]; !ok {
+				env = append(env, pair)
+			}
+		}
+		for _, name := range names {
+			env = append(env, &core.KeyValuePair{Key: name, Value: envs[name]})
+		}
+		container.Env = env
+		flyteWf.Tasks[taskID] = &v1alpha1.TaskSpec{TaskTemplate: template}
+	}
+}
+
 func addExecutionOverrides(taskPluginOverrides []*admin.PluginOverride,
 	workflowExecutionConfig *admin.WorkflowExecutionConfig, recoveryExecution *core.WorkflowExecutionIdentifier,
-	taskResources *interfaces.TaskResources, flyteWf *v1alpha1.FlyteWorkflow) {
+	taskResources *interfaces.TaskResources, envs map[string]string, flyteWf *v1alpha1.FlyteWorkflow) {
 	executionConfig := v1alpha1.ExecutionConfig{
 		TaskPluginImpls: make(map[string]v1alpha1.TaskPluginOverride),
 		RecoveryExecution: v1alpha1.WorkflowExecutionIdentifier{
@@ -54,6 +90,7 @@ func addExecutionOverrides(taskPluginOverrides []*admin.PluginOverride,
 		}
 
 	}
+	addEnvs(envs, flyteWf)
 	if workflowExecutionConfig != nil {
 		executionConfig.MaxParallelism = uint32(workflowExecutionConfig.MaxParallelism)
 	}
@@ -129,7 +166,8 @@ func PrepareFlyteWorkflow(data interfaces.ExecutionData, flyteWorkflow *v1alpha1
 	}
 	flyteWorkflow.WorkflowMeta.EventVersion = v1alpha1.EventVersion(data.ExecutionParameters.EventVersion)
 	addExecutionOverrides(data.ExecutionParameters.TaskPluginOverrides, data.ExecutionParameters.ExecutionConfig,
-		data.ExecutionParameters.RecoveryExecution, data.ExecutionParameters.TaskResources, flyteWorkflow)
+		data.ExecutionParameters.RecoveryExecution, data.ExecutionParameters.TaskResources,
+		data.ExecutionParameters.Envs, flyteWorkflow)
 
 	if data.ExecutionParameters.RawOutputDataConfig != nil {
 		flyteWorkflow.RawOutputDataConfig = v1alpha1.RawOutputDataConfig{
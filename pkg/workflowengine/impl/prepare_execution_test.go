@@ -92,7 +92,7 @@ func TestAddExecutionOverrides(t *testing.T) {
 			},
 		}
 		workflow := &v1alpha1.FlyteWorkflow{}
-		addExecutionOverrides(overrides, nil, nil, nil, workflow)
+		addExecutionOverrides(overrides, nil, nil, nil, nil, workflow)
 		assert.EqualValues(t, workflow.ExecutionConfig.TaskPluginImpls, map[string]v1alpha1.TaskPluginOverride{
 			"taskType1": {
 				PluginIDs:             []string{"Plugin1", "Plugin2"},
@@ -105,7 +105,7 @@ func TestAddExecutionOverrides(t *testing.T) {
 			MaxParallelism: 100,
 		}
 		workflow := &v1alpha1.FlyteWorkflow{}
-		addExecutionOverrides(nil, workflowExecutionConfig, nil, nil, workflow)
+		addExecutionOverrides(nil, workflowExecutionConfig, nil, nil, nil, workflow)
 		assert.EqualValues(t, workflow.ExecutionConfig.MaxParallelism, uint32(100))
 	})
 	t.Run("recovery execution", func(t *testing.T) {
@@ -115,7 +115,7 @@ func TestAddExecutionOverrides(t *testing.T) {
 			Name:    "n",
 		}
 		workflow := &v1alpha1.FlyteWorkflow{}
-		addExecutionOverrides(nil, nil, recoveryExecutionID, nil, workflow)
+		addExecutionOverrides(nil, nil, recoveryExecutionID, nil, nil, workflow)
 		assert.True(t, proto.Equal(recoveryExecutionID, workflow.ExecutionConfig.RecoveryExecution.WorkflowExecutionIdentifier))
 	})
 	t.Run("task resources", func(t *testing.T) {
@@ -132,7 +132,7 @@ func TestAddExecutionOverrides(t *testing.T) {
 				EphemeralStorage: resource.MustParse("1Gi"),
 				GPU:              resource.MustParse("1"),
 			},
-		}, workflow)
+		}, nil, workflow)
 		assert.EqualValues(t, v1alpha1.TaskResourceSpec{
 			CPU:    resource.MustParse("1"),
 			Memory: resource.MustParse("100Gi"),
@@ -146,6 +146,37 @@ func TestAddExecutionOverrides(t *testing.T) {
 			GPU:              resource.MustParse("1"),
 		}, workflow.ExecutionConfig.TaskResources.Limits)
 	})
+	t.Run("envs", func(t *testing.T) {
+		registeredTask := &core.TaskTemplate{
+			Target: &core.TaskTemplate_Container{
+				Container: &core.Container{
+					Env: []*core.KeyValuePair{
+						{Key: "EXPERIMENT_ID", Value: "1"},
+						{Key: "REGION", Value: "us-east-1"},
+					},
+				},
+			},
+		}
+		workflow := &v1alpha1.FlyteWorkflow{
+			Tasks: map[v1alpha1.TaskID]*v1alpha1.TaskSpec{
+				"container": {TaskTemplate: registeredTask},
+				"sql":       {TaskTemplate: &core.TaskTemplate{}},
+			},
+		}
+		addExecutionOverrides(nil, nil, nil, nil, map[string]string{
+			"FEATURE_FLAG":  "on",
+			"EXPERIMENT_ID": "2",
+		}, workflow)
+		assert.EqualValues(t, []*core.KeyValuePair{
+			{Key: "REGION", Value: "us-east-1"},
+			{Key: "EXPERIMENT_ID", Value: "2"},
+			{Key: "FEATURE_FLAG", Value: "on"},
+		}, workflow.Tasks["container"].GetContainer().Env)
+		assert.Nil(t, workflow.Tasks["sql"].GetContainer())
+		// The registered task template is left as is.
+		assert.Len(t, registeredTask.GetContainer().Env, 2)
+		assert.Equal(t, "1", registeredTask.GetContainer().Env[0].Value)
+	})
 }
 
 func TestPrepareFlyteWorkflow(t *testing.T) {
@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	k8_api_err "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Verifies the service account of an execution exists in the namespace it runs in, so that executions fail on creation
+// rather than once propeller launches their pods.
+type serviceAccountChecker struct {
+	config runtimeInterfaces.ServiceAccountCheckConfig
+	now    func() time.Time
+
+	mutex sync.Mutex
+	// Map of [cluster/namespace/service account -> time the service account was found to exist]
+	found map[string]time.Time
+}
+
+func (c *serviceAccountChecker) isCached(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	foundAt, ok := c.found[key]
+	return ok && c.now().Sub(foundAt) < c.config.CacheTTL.Duration
+}
+
+// Fails with InvalidArgument when the service account doesn't exist. Other errors looking the service account up are
+// logged and otherwise ignored, as the check is only meant to surface misconfigurations early.
+func (c *serviceAccountChecker) check(ctx context.Context, target *executioncluster.ExecutionTarget, namespace,
+	serviceAccount string) error {
+	if !c.config.Enabled || len(serviceAccount) == 0 {
+		return nil
+	}
+	key := fmt.Sprintf("%s/%s/%s", target.ID, namespace, serviceAccount)
+	if c.isCached(key) {
+		return nil
+	}
+	err := target.Client.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      serviceAccount,
+	}, &corev1.ServiceAccount{})
+	if err != nil {
+		if k8_api_err.IsNotFound(err) {
+			return errors.NewFlyteAdminErrorf(codes.InvalidArgument,
+				"service account [%s] doesn't exist in namespace [%s]", serviceAccount, namespace)
+		}
+		logger.Warningf(ctx, "Failed to check service account [%s] exists in namespace [%s] of cluster %s: %v",
+			serviceAccount, namespace, target.ID, err)
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.found[key] = c.now()
+	return nil
+}
+
+func newServiceAccountChecker(config runtimeInterfaces.ServiceAccountCheckConfig) *serviceAccountChecker {
+	return &serviceAccountChecker{
+		config: config,
+		now:    time.Now,
+		found:  make(map[string]time.Time),
+	}
+}
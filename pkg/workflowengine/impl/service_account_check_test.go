@@ -0,0 +1,149 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	flyteAdminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	"github.com/flyteorg/flyteadmin/pkg/executioncluster"
+	clusterMock "github.com/flyteorg/flyteadmin/pkg/executioncluster/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/workflowengine/interfaces"
+	"github.com/flyteorg/flyteadmin/pkg/workflowengine/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytepropeller/pkg/apis/flyteworkflow/v1alpha1"
+	v1alpha12 "github.com/flyteorg/flytepropeller/pkg/client/clientset/versioned/typed/flyteworkflow/v1alpha1"
+	"github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const serviceAccount = "flyte-sa"
+
+var testServiceAccountCheck = runtimeInterfaces.ServiceAccountCheckConfig{
+	Enabled:  true,
+	CacheTTL: config.Duration{Duration: time.Minute},
+}
+
+// Counts lookups and optionally fails them, in front of a fake client.
+type countingClient struct {
+	client.Client
+	gets int
+	err  error
+}
+
+func (c *countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.gets++
+	if c.err != nil {
+		return c.err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func getServiceAccountCheckTarget(objects ...client.Object) (*executioncluster.ExecutionTarget, *countingClient) {
+	k8sClient := &countingClient{
+		Client: fake.NewClientBuilder().WithObjects(objects...).Build(),
+	}
+	return &executioncluster.ExecutionTarget{
+		ID:     clusterID,
+		Client: k8sClient,
+	}, k8sClient
+}
+
+func TestServiceAccountCheck_Present(t *testing.T) {
+	target, k8sClient := getServiceAccountCheckTarget(&corev1.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      serviceAccount,
+		},
+	})
+	checker := newServiceAccountChecker(testServiceAccountCheck)
+	now := time.Now()
+	checker.now = func() time.Time {
+		return now
+	}
+
+	assert.NoError(t, checker.check(context.TODO(), target, namespace, serviceAccount))
+	assert.NoError(t, checker.check(context.TODO(), target, namespace, serviceAccount))
+	assert.Equal(t, 1, k8sClient.gets)
+
+	// Positive results are looked up again once they expire.
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, checker.check(context.TODO(), target, namespace, serviceAccount))
+	assert.Equal(t, 2, k8sClient.gets)
+}
+
+func TestServiceAccountCheck_Absent(t *testing.T) {
+	target, k8sClient := getServiceAccountCheckTarget(&corev1.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "other-namespace",
+			Name:      serviceAccount,
+		},
+	})
+	checker := newServiceAccountChecker(testServiceAccountCheck)
+
+	err := checker.check(context.TODO(), target, namespace, serviceAccount)
+	assert.EqualError(t, err, "service account [flyte-sa] doesn't exist in namespace [p-d]")
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+
+	// Negative results aren't cached, so that creating the service account takes effect immediately.
+	assert.Error(t, checker.check(context.TODO(), target, namespace, serviceAccount))
+	assert.Equal(t, 2, k8sClient.gets)
+}
+
+func TestServiceAccountCheck_APIError(t *testing.T) {
+	target, k8sClient := getServiceAccountCheckTarget()
+	k8sClient.err = errors.New("connection refused")
+	checker := newServiceAccountChecker(testServiceAccountCheck)
+
+	assert.NoError(t, checker.check(context.TODO(), target, namespace, serviceAccount))
+	assert.Equal(t, 1, k8sClient.gets)
+}
+
+func TestServiceAccountCheck_NoServiceAccount(t *testing.T) {
+	target, k8sClient := getServiceAccountCheckTarget()
+	checker := newServiceAccountChecker(testServiceAccountCheck)
+
+	assert.NoError(t, checker.check(context.TODO(), target, namespace, ""))
+	assert.Equal(t, 0, k8sClient.gets)
+}
+
+func TestExecute_MissingServiceAccount(t *testing.T) {
+	target, _ := getServiceAccountCheckTarget()
+	target.FlyteClient = &FakeK8FlyteClient{}
+	fakeCluster := clusterMock.MockCluster{}
+	fakeCluster.SetGetTargetCallback(func(ctx context.Context, spec *executioncluster.ExecutionTargetSpec) (*executioncluster.ExecutionTarget, error) {
+		return target, nil
+	})
+	fakeFlyteWorkflow := FakeFlyteWorkflow{}
+	fakeFlyteWorkflow.createCallback = func(flyteWorkflow *v1alpha1.FlyteWorkflow, opts v1.CreateOptions) (*v1alpha1.FlyteWorkflow, error) {
+		t.Fatal("unexpected workflow creation")
+		return nil, nil
+	}
+	fakeFlyteWF.flyteWorkflowsCallback = func(ns string) v1alpha12.FlyteWorkflowInterface {
+		return &fakeFlyteWorkflow
+	}
+	mockBuilder := mocks.FlyteWorkflowBuilder{}
+	mockBuilder.OnBuildMatch(mock.Anything, mock.Anything, mock.Anything, namespace).Return(&v1alpha1.FlyteWorkflow{}, nil)
+	executor := NewK8sWorkflowExecutor(&fakeCluster, &mockBuilder, testCreateRetry, testServiceAccountCheck)
+
+	_, err := executor.Execute(context.TODO(), interfaces.ExecutionData{
+		Namespace:   namespace,
+		ExecutionID: execID,
+		ExecutionParameters: interfaces.ExecutionParameters{
+			SecurityContext: &core.SecurityContext{
+				RunAs: &core.Identity{
+					K8SServiceAccount: serviceAccount,
+				},
+			},
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, err.(flyteAdminErrors.FlyteAdminError).Code())
+}
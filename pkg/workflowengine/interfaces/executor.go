@@ -30,6 +30,11 @@ type ExecutionParameters struct {
 	EventVersion        int
 	RoleNameKey         string
 	RawOutputDataConfig *admin.RawOutputDataConfig
+	// Environment variables set for the tasks of the execution, taking precedence over those they were registered with.
+	Envs map[string]string
+	// How long the execution may wait to be scheduled, resolved from its quality of service. Executions queued to be
+	// launched in the background are failed instead once they've waited longer.
+	QueueingBudget time.Duration
 }
 
 // ExecutionData includes all parameters required to create an execution CRD object.
@@ -46,6 +51,8 @@ type ExecutionData struct {
 	WorkflowClosure *core.CompiledWorkflowClosure
 	// Additional parameters used to build a workflow execution
 	ExecutionParameters ExecutionParameters
+	// Cluster label the execution is assigned to, if any. The execution is created in one of the clusters mapped to it.
+	ExecutionClusterLabel *admin.ExecutionClusterLabel
 }
 
 // ExecutionResponse is returned when a Flyte workflow execution is successfully created.
@@ -73,3 +80,9 @@ type WorkflowExecutor interface {
 	// Abort aborts a running Flyte workflow execution CRD object.
 	Abort(ctx context.Context, data AbortData) error
 }
+
+// HealthChecker is optionally implemented by a WorkflowExecutor which can verify connectivity to the clusters it
+// executes on.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
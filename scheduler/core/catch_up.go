@@ -0,0 +1,36 @@
+package core
+
+import (
+	"time"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+)
+
+// FilterCatchUpTimes applies the catch up policy of a schedule to its missed scheduled times, which are expected in
+// ascending order. A scheduled time is stale when it is older than the threshold relative to now; times which aren't
+// stale always fire. Of the stale times NONE fires none, LAST fires only the most recent and ALL fires every one.
+// Schedules persisted before catch up policies existed have no policy and fire every time.
+// Returns the times to fire along with the number of dropped ones.
+func FilterCatchUpTimes(policy runtimeInterfaces.CatchUpPolicy, scheduledTimes []time.Time, now time.Time,
+	threshold time.Duration) ([]time.Time, int) {
+	if policy != runtimeInterfaces.CatchUpPolicyNone && policy != runtimeInterfaces.CatchUpPolicyLast {
+		return scheduledTimes, 0
+	}
+	var staleCount int
+	for _, scheduledTime := range scheduledTimes {
+		if !IsStale(scheduledTime, now, threshold) {
+			break
+		}
+		staleCount++
+	}
+	keepFrom := staleCount
+	if policy == runtimeInterfaces.CatchUpPolicyLast && staleCount > 0 {
+		keepFrom = staleCount - 1
+	}
+	return scheduledTimes[keepFrom:], keepFrom
+}
+
+// IsStale returns whether the scheduled time is older than the threshold relative to now.
+func IsStale(scheduledTime, now time.Time, threshold time.Duration) bool {
+	return now.Sub(scheduledTime) > threshold
+}
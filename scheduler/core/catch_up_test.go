@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCatchUpTimes(t *testing.T) {
+	threshold := 5 * time.Minute
+	now := time.Date(2021, time.October, 20, 12, 0, 0, 0, time.UTC)
+	overThreshold := now.Add(-threshold - time.Nanosecond)
+	atThreshold := now.Add(-threshold)
+	stale := []time.Time{now.Add(-20 * time.Minute), now.Add(-10 * time.Minute), overThreshold}
+	fresh := []time.Time{atThreshold, now.Add(-time.Minute)}
+	all := append(append([]time.Time{}, stale...), fresh...)
+
+	testCases := []struct {
+		name            string
+		policy          runtimeInterfaces.CatchUpPolicy
+		scheduledTimes  []time.Time
+		expectedTimes   []time.Time
+		expectedDropped int
+	}{
+		{"none drops stale times", runtimeInterfaces.CatchUpPolicyNone, all, fresh, 3},
+		{"last keeps the most recent stale time", runtimeInterfaces.CatchUpPolicyLast, all,
+			append([]time.Time{overThreshold}, fresh...), 2},
+		{"all keeps every time", runtimeInterfaces.CatchUpPolicyAll, all, all, 0},
+		{"unset policy keeps every time", "", all, all, 0},
+		{"none at the threshold", runtimeInterfaces.CatchUpPolicyNone, []time.Time{atThreshold},
+			[]time.Time{atThreshold}, 0},
+		{"none just over the threshold", runtimeInterfaces.CatchUpPolicyNone, []time.Time{overThreshold},
+			[]time.Time{}, 1},
+		{"last just over the threshold", runtimeInterfaces.CatchUpPolicyLast, []time.Time{overThreshold},
+			[]time.Time{overThreshold}, 0},
+		{"last with only fresh times", runtimeInterfaces.CatchUpPolicyLast, fresh, fresh, 0},
+		{"none without times", runtimeInterfaces.CatchUpPolicyNone, nil, nil, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			times, dropped := FilterCatchUpTimes(tc.policy, tc.scheduledTimes, now, threshold)
+			assert.Equal(t, tc.expectedDropped, dropped)
+			assert.Len(t, times, len(tc.expectedTimes))
+			for i := range tc.expectedTimes {
+				assert.True(t, tc.expectedTimes[i].Equal(times[i]))
+			}
+		})
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2021, time.October, 20, 12, 0, 0, 0, time.UTC)
+	assert.False(t, IsStale(now.Add(-time.Minute), now, time.Minute))
+	assert.True(t, IsStale(now.Add(-time.Minute-time.Nanosecond), now, time.Minute))
+	assert.False(t, IsStale(now.Add(time.Minute), now, time.Minute))
+	// A zero threshold treats every past time as stale.
+	assert.True(t, IsStale(now.Add(-time.Nanosecond), now, 0))
+}
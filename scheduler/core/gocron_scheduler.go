@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteadmin/scheduler/executor"
 	"github.com/flyteorg/flyteadmin/scheduler/identifier"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
@@ -25,6 +26,7 @@ type goCronMetrics struct {
 	JobFuncPanicCounter       prometheus.Counter
 	JobScheduledFailedCounter prometheus.Counter
 	CatchupErrCounter         prometheus.Counter
+	CatchupDroppedCounter     prometheus.Counter
 }
 
 // GoCronScheduler this provides a scheduler functionality using the https://github.com/robfig/cron library.
@@ -35,10 +37,20 @@ type GoCronScheduler struct {
 	rateLimiter *rate.Limiter
 	executor    executor.Executor
 	snapshot    snapshoter.Snapshot
+	// Missed scheduled times older than this are subject to the catch up policy of their schedule.
+	catchUpThreshold time.Duration
 }
 
 func (g *GoCronScheduler) GetTimedFuncWithSchedule() TimedFuncWithSchedule {
 	return func(jobCtx context.Context, schedule models.SchedulableEntity, scheduleTime time.Time) error {
+		// The execution is delayed by the offset of the schedule, but keeps the scheduled time as its kickoff time.
+		if schedule.ScheduleOffset > 0 {
+			select {
+			case <-jobCtx.Done():
+				return jobCtx.Err()
+			case <-time.After(time.Until(scheduleTime.Add(schedule.ScheduleOffset))):
+			}
+		}
 		_ = g.rateLimiter.Wait(jobCtx)
 		err := g.executor.Execute(jobCtx, scheduleTime, schedule)
 		if err != nil {
@@ -196,6 +208,13 @@ func (g *GoCronScheduler) CatchUpSingleSchedule(ctx context.Context, s models.Sc
 	if err != nil {
 		return err
 	}
+	catchUpTimes, dropped := FilterCatchUpTimes(runtimeInterfaces.CatchUpPolicy(s.CatchUpPolicy), catchUpTimes, toTime,
+		g.catchUpThreshold)
+	if dropped > 0 {
+		g.metrics.CatchupDroppedCounter.Add(float64(dropped))
+		logger.Infof(ctx, "dropped %d stale invocations of the schedule %+v due to its catch up policy %v",
+			dropped, s, s.CatchUpPolicy)
+	}
 	var catchupTime time.Time
 	for _, catchupTime = range catchUpTimes {
 		_ = g.rateLimiter.Wait(ctx)
@@ -308,17 +327,19 @@ func getFixedRateDurationFromSchedule(unit admin.FixedRateUnit, fixedRateValue u
 }
 
 func NewGoCronScheduler(ctx context.Context, schedules []models.SchedulableEntity, scope promutils.Scope,
-	snapshot snapshoter.Snapshot, rateLimiter *rate.Limiter, executor executor.Executor) Scheduler {
+	snapshot snapshoter.Snapshot, rateLimiter *rate.Limiter, executor executor.Executor,
+	catchUpThreshold time.Duration) Scheduler {
 	// Create the new cron scheduler and start it off
 	c := cron.New()
 	c.Start()
 	scheduler := &GoCronScheduler{
-		cron:        c,
-		jobStore:    sync.Map{},
-		metrics:     getCronMetrics(scope),
-		rateLimiter: rateLimiter,
-		executor:    executor,
-		snapshot:    snapshot,
+		cron:             c,
+		jobStore:         sync.Map{},
+		metrics:          getCronMetrics(scope),
+		rateLimiter:      rateLimiter,
+		executor:         executor,
+		snapshot:         snapshot,
+		catchUpThreshold: catchUpThreshold,
 	}
 	scheduler.BootStrapSchedulesFromSnapShot(ctx, schedules, snapshot)
 	return scheduler
@@ -333,5 +354,7 @@ func getCronMetrics(scope promutils.Scope) goCronMetrics {
 			"count of scheduling failures by the scheduler"),
 		CatchupErrCounter: scope.MustNewCounter("catchup_error_counter",
 			"count of unsuccessful attempts to catchup on the schedules"),
+		CatchupDroppedCounter: scope.MustNewCounter("catchup_dropped_counter",
+			"count of stale scheduled invocations dropped during catchup due to the catch up policy"),
 	}
 }
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/scheduler/executor"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	adminMocks "github.com/flyteorg/flyteidl/clients/go/admin/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
+)
+
+func TestGetTimedFuncWithSchedule_Offset(t *testing.T) {
+	adminClient := new(adminMocks.AdminServiceClient)
+	var request *admin.ExecutionCreateRequest
+	var requestedAt time.Time
+	adminClient.OnCreateExecutionMatch(mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request = args.Get(1).(*admin.ExecutionCreateRequest)
+		requestedAt = time.Now()
+	}).Return(&admin.ExecutionCreateResponse{}, nil)
+	scheduler := &GoCronScheduler{
+		rateLimiter: rate.NewLimiter(rate.Inf, 1),
+		executor:    executor.New(promutils.NewTestScope(), adminClient),
+	}
+	active := true
+	schedule := models.SchedulableEntity{
+		SchedulableEntityKey: models.SchedulableEntityKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "cron_schedule",
+			Version: "v1",
+		},
+		CronExpression:      "0 * * * *",
+		KickoffTimeInputArg: "kickoff_time",
+		Active:              &active,
+		ScheduleOffset:      100 * time.Millisecond,
+	}
+
+	t.Run("delays the execution", func(t *testing.T) {
+		scheduledTime := time.Now()
+		assert.NoError(t, scheduler.GetTimedFuncWithSchedule()(context.Background(), schedule, scheduledTime))
+		if !assert.NotNil(t, request) {
+			return
+		}
+		assert.False(t, requestedAt.Before(scheduledTime.Add(schedule.ScheduleOffset)))
+		// The kickoff time remains the nominal scheduled time.
+		kickoffTime := request.Inputs.Literals["kickoff_time"].GetScalar().GetPrimitive().GetDatetime().AsTime()
+		assert.True(t, scheduledTime.Equal(kickoffTime))
+		assert.True(t, scheduledTime.Equal(request.Spec.Metadata.ScheduledAt.AsTime()))
+	})
+	t.Run("doesn't delay stale invocations further", func(t *testing.T) {
+		request = nil
+		scheduledTime := time.Now().Add(-time.Hour)
+		start := time.Now()
+		assert.NoError(t, scheduler.GetTimedFuncWithSchedule()(context.Background(), schedule, scheduledTime))
+		assert.NotNil(t, request)
+		assert.Less(t, int64(time.Since(start)), int64(schedule.ScheduleOffset))
+	})
+	t.Run("gives up once canceled", func(t *testing.T) {
+		request = nil
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := scheduler.GetTimedFuncWithSchedule()(ctx, schedule, time.Now())
+		assert.Equal(t, context.Canceled, err)
+		assert.Nil(t, request)
+	})
+}
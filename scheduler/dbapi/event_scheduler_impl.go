@@ -7,6 +7,7 @@ import (
 	"github.com/flyteorg/flyteadmin/pkg/async/schedule/interfaces"
 	scheduleInterfaces "github.com/flyteorg/flyteadmin/pkg/async/schedule/interfaces"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	scheduleIdentifier "github.com/flyteorg/flyteadmin/scheduler/identifier"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -26,6 +27,11 @@ func (s *eventScheduler) CreateScheduleInput(ctx context.Context, appConfig *run
 		Identifier:         identifier,
 		ScheduleExpression: *schedule,
 	}
+	if appConfig != nil {
+		addScheduleInput.CatchUpPolicy = appConfig.EventSchedulerConfig.GetCatchUpPolicy()
+		addScheduleInput.Offset = scheduleIdentifier.GetScheduleJitter(ctx, identifier,
+			appConfig.EventSchedulerConfig.GetMaxJitter())
+	}
 	return addScheduleInput, nil
 }
 
@@ -50,6 +56,8 @@ func (s *eventScheduler) AddSchedule(ctx context.Context, input interfaces.AddSc
 		Unit:                fixedRateUnit,
 		KickoffTimeInputArg: input.ScheduleExpression.KickoffTimeInputArg,
 		Active:              &active,
+		CatchUpPolicy:       string(input.CatchUpPolicy),
+		ScheduleOffset:      input.Offset,
 		SchedulableEntityKey: models.SchedulableEntityKey{
 			Project: input.Identifier.Project,
 			Domain:  input.Identifier.Domain,
@@ -3,13 +3,18 @@ package dbapi
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/flyteadmin/pkg/async/schedule/interfaces"
 	"github.com/flyteorg/flyteadmin/pkg/repositories"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/mocks"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	scheduleIdentifier "github.com/flyteorg/flyteadmin/scheduler/identifier"
 	schedMocks "github.com/flyteorg/flyteadmin/scheduler/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/config"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -44,6 +49,73 @@ func TestCreateScheduleInput(t *testing.T) {
 	assert.NotNil(t, addScheduleInput)
 }
 
+func TestCreateScheduleInput_CatchUpPolicy(t *testing.T) {
+	eventScheduler := setupEventScheduler()
+	addScheduleInput, err := eventScheduler.CreateScheduleInput(context.Background(), &runtimeInterfaces.SchedulerConfig{
+		EventSchedulerConfig: runtimeInterfaces.EventSchedulerConfig{
+			CatchUpPolicy: runtimeInterfaces.CatchUpPolicyNone,
+		},
+	}, core.Identifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "scheduled_wroflow",
+		Version: "v1",
+	}, &admin.Schedule{
+		ScheduleExpression: &admin.Schedule_Rate{
+			Rate: &admin.FixedRate{
+				Value: 1,
+				Unit:  admin.FixedRateUnit_MINUTE,
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, runtimeInterfaces.CatchUpPolicyNone, addScheduleInput.CatchUpPolicy)
+
+	scheduleEntitiesRepo := db.SchedulableEntityRepo().(*schedMocks.SchedulableEntityRepoInterface)
+	scheduleEntitiesRepo.OnActivateMatch(mock.Anything, mock.MatchedBy(func(entity models.SchedulableEntity) bool {
+		return entity.CatchUpPolicy == string(runtimeInterfaces.CatchUpPolicyNone)
+	})).Return(nil)
+	assert.Nil(t, eventScheduler.AddSchedule(context.Background(), addScheduleInput))
+}
+
+func TestCreateScheduleInput_Jitter(t *testing.T) {
+	eventScheduler := setupEventScheduler()
+	identifier := core.Identifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "scheduled_wroflow",
+		Version: "v1",
+	}
+	schedulerConfig := &runtimeInterfaces.SchedulerConfig{
+		EventSchedulerConfig: runtimeInterfaces.EventSchedulerConfig{
+			MaxJitter: config.Duration{Duration: time.Hour},
+		},
+	}
+	schedule := &admin.Schedule{
+		ScheduleExpression: &admin.Schedule_CronSchedule{
+			CronSchedule: &admin.CronSchedule{
+				Schedule: "0 * * * *",
+			},
+		},
+	}
+	addScheduleInput, err := eventScheduler.CreateScheduleInput(context.Background(), schedulerConfig, identifier,
+		schedule)
+	assert.Nil(t, err)
+	assert.Equal(t, scheduleIdentifier.GetScheduleJitter(context.Background(), identifier, time.Hour),
+		addScheduleInput.Offset)
+	assert.True(t, addScheduleInput.Offset < time.Hour)
+	// Activating the schedule again assigns it the same jitter.
+	again, err := eventScheduler.CreateScheduleInput(context.Background(), schedulerConfig, identifier, schedule)
+	assert.Nil(t, err)
+	assert.Equal(t, addScheduleInput.Offset, again.Offset)
+
+	scheduleEntitiesRepo := db.SchedulableEntityRepo().(*schedMocks.SchedulableEntityRepoInterface)
+	scheduleEntitiesRepo.OnActivateMatch(mock.Anything, mock.MatchedBy(func(entity models.SchedulableEntity) bool {
+		return entity.ScheduleOffset == addScheduleInput.Offset
+	})).Return(nil)
+	assert.Nil(t, eventScheduler.AddSchedule(context.Background(), addScheduleInput))
+}
+
 func TestRemoveSchedule(t *testing.T) {
 	eventScheduler := setupEventScheduler()
 
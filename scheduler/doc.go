@@ -13,10 +13,12 @@
 //    This component is a singleton and has its source in the current folder and is responsible for reading the schedules
 //    from the DB and running them at the cadence defined by the schedule
 //    The lowest granularity supported is minutes for scheduling through cron and fixed rate scheduler
-// 	  The scheduler should be running in one replica , two at the most during redeployment. Multiple replicas will just
-// 	  duplicate the work since each execution for a scheduleTime will have unique identifier derived from schedule name
-//	  and time of the schedule. The idempotency aspect of the admin for same identifier prevents duplication on the admin
-//	  side.
+// 	  The scheduler can run in multiple replicas. Before firing the invocation of a schedule for a scheduleTime, a
+// 	  replica claims it by inserting a row in the schedule_firings table, keyed by the schedule and the scheduleTime,
+// 	  so that only one replica fires each invocation. The claim records when the invocation fired, and is taken over by
+// 	  another replica if it hasn't fired within the claimTimeout, say because its replica died. Besides, each execution
+// 	  for a scheduleTime has a unique identifier derived from schedule name and time of the schedule, and the
+// 	  idempotency aspect of the admin for same identifier prevents duplication on the admin side.
 //    The scheduler runs continuously in a loop reading the updated schedule entries in the data store and adding or removing
 //    the schedules. Removing a schedule will not alter in-flight go-routines launched by the scheduler.
 //    Thus the behavior of these executions is undefined (most probably will get executed).
@@ -27,6 +29,10 @@
 //			The snapshot is map[string]time.Time which stores a map of schedules names to there last execution times
 // 			During bootup the snapshot is bootstraped from the data store and loaded in memory
 //			The Scheduler use this snapshot to schedule any missed schedules.
+//			On top of the snapshot, the scheduler starts each schedule from its checkpoint when it has one. Admin
+//			advances the checkpoint of a launch plan version in the transaction which creates its scheduled execution,
+//			and admins can set it through the /api/v1/schedule_checkpoints endpoint to rewind or advance the schedule,
+//			which takes effect once the scheduler restarts. A schedule can't be rewound to before its activation.
 //
 //			We cannot use global snapshot time since each time snapshot doesn't contain information on how many schedules
 //			were executed till that point in time. And hence the need to maintain map[string]time.Time of schedules to there
@@ -78,7 +84,7 @@
 //		   by the admin. i.e admin could execute the schedules in this order T2, T1. This is rare case though
 //
 // 		c) Case when the scheduler goes down then once it comes back up it will run catch up on all the schedules using
-//		   the last snapshoted timestamp to time.Now()
+//		   the later of the last snapshoted timestamp and the last fired invocation claimed to time.Now()
 //
 //		d) Case when the snapshoter fails to record the last execution at T2 but has recorded at T1, where T1 < T2 ,
 //		   then new schedules would be created from T1 -> time.Now() during catchup and the idempotency aspect of the admin
@@ -92,9 +98,16 @@
 //		   would run catch from updated_at timestamp till now. It doesn't matter how many activation/deactivations have
 //		   happened during the downtime, but the scheduler will go by the recent activation state.
 //
-//		g) Case there are multiple pod running with the scheduler , then we rely on the idempotency aspect of the executions
-//		   which have a identifier derived from the hash of schedule time + launch plan identifier which would remain the same
-//		   any other instance of the scheduler picks up and admin will return the AlreadyExists error.
+//		g) Case there are multiple pod running with the scheduler , then only the pod claiming an invocation fires it.
+//		   Invocations which fail to fire aren't retried while the pod runs. Only a later catch up covering their
+//		   scheduled time fires them again, such as the catch up from the checkpoint of their schedule on restart: the
+//		   pod may take over its own claims right away, other pods only once the claim expires. Invocations fired
+//		   again rely on the idempotency aspect of the executions which have a identifier derived from the hash of
+//		   schedule time + launch plan identifier and admin will return the AlreadyExists error.
+//
+//		h) Case when catching up on a schedule, scheduled times older than the catchUpThreshold are stale and fire according
+//		   to the catch up policy of the schedule resolved at its activation: NONE drops them, LAST fires only the most
+//		   recent one and ALL fires every one of them with their original scheduled times.
 //
 
 package scheduler
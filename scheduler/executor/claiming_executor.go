@@ -0,0 +1,92 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// claimingExecutor claims each invocation in the db before firing it through the executor it wraps, so that of the
+// scheduler replicas running the same schedules only one fires each invocation. The claims of fired invocations are the
+// checkpoints the scheduler catches up from on restart.
+type claimingExecutor struct {
+	executor     Executor
+	db           interfaces.ScheduleFiringRepoInterface
+	replicaID    string
+	claimTimeout time.Duration
+	metrics      claimingExecutorMetrics
+}
+
+type claimingExecutorMetrics struct {
+	Scope               promutils.Scope
+	ClaimedCounter      prometheus.Counter
+	ClaimSkippedCounter prometheus.Counter
+	ClaimErrCounter     prometheus.Counter
+}
+
+func (c *claimingExecutor) Execute(ctx context.Context, scheduledTime time.Time, s models.SchedulableEntity) error {
+	key := models.ScheduleFiringKey{
+		SchedulableEntityKey: s.SchedulableEntityKey,
+		ScheduledAt:          scheduledTime,
+	}
+	claimed, err := c.db.Claim(ctx, models.ScheduleFiring{
+		ScheduleFiringKey: key,
+		ClaimedBy:         c.replicaID,
+		ClaimedAt:         time.Now(),
+	}, c.claimTimeout)
+	if err != nil {
+		c.metrics.ClaimErrCounter.Inc()
+		logger.Errorf(ctx, "failed to claim the schedule %+v for time %v due to %v", s, scheduledTime, err)
+		return err
+	}
+	if !claimed {
+		c.metrics.ClaimSkippedCounter.Inc()
+		logger.Debugf(ctx, "schedule %+v for time %v has fired or is claimed by another replica", s, scheduledTime)
+		return nil
+	}
+	c.metrics.ClaimedCounter.Inc()
+
+	// Nothing retries an invocation which fails to fire. Its claim is kept unfired, such that a later catch up covering
+	// its scheduled time, say on restart from a checkpoint which only advances once executions are created, fires it
+	// again. Other replicas only take the claim over once it expires.
+	if err := c.executor.Execute(ctx, scheduledTime, s); err != nil {
+		return err
+	}
+	if err := c.db.MarkFired(ctx, key, c.replicaID, time.Now()); err != nil {
+		// The invocation fired without its claim being marked fired, and firing it again on a later catch up is
+		// idempotent.
+		c.metrics.ClaimErrCounter.Inc()
+		logger.Errorf(ctx, "failed to mark the schedule %+v for time %v as fired due to %v", s, scheduledTime, err)
+	}
+	return nil
+}
+
+// NewClaimingExecutor returns an executor which fires the invocations claimed by the replica through the executor.
+func NewClaimingExecutor(scope promutils.Scope, executor Executor, db interfaces.ScheduleFiringRepoInterface,
+	replicaID string, claimTimeout time.Duration) Executor {
+	return &claimingExecutor{
+		executor:     executor,
+		db:           db,
+		replicaID:    replicaID,
+		claimTimeout: claimTimeout,
+		metrics:      getClaimingExecutorMetrics(scope),
+	}
+}
+
+func getClaimingExecutorMetrics(scope promutils.Scope) claimingExecutorMetrics {
+	return claimingExecutorMetrics{
+		Scope: scope,
+		ClaimedCounter: scope.MustNewCounter("claimed_execution_counter",
+			"count of scheduled executions claimed by this replica"),
+		ClaimSkippedCounter: scope.MustNewCounter("claim_skipped_execution_counter",
+			"count of scheduled executions skipped as fired or claimed by another replica"),
+		ClaimErrCounter: scope.MustNewCounter("claim_error_counter",
+			"count of failures to claim scheduled executions or mark them fired"),
+	}
+}
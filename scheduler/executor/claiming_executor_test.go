@@ -0,0 +1,89 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	schedMocks "github.com/flyteorg/flyteadmin/scheduler/repositories/mocks"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClaimingExecutor(t *testing.T) {
+	active := true
+	schedule := models.SchedulableEntity{
+		SchedulableEntityKey: models.SchedulableEntityKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "cron_schedule",
+			Version: "v1",
+		},
+		CronExpression:      "*/1 * * * *",
+		KickoffTimeInputArg: "kickoff_time",
+		Active:              &active,
+	}
+	scheduledTime := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	key := models.ScheduleFiringKey{
+		SchedulableEntityKey: schedule.SchedulableEntityKey,
+		ScheduledAt:          scheduledTime,
+	}
+	isClaim := func(firing models.ScheduleFiring) bool {
+		return firing.ScheduleFiringKey == key && firing.ClaimedBy == "replica"
+	}
+
+	t.Run("fires claimed invocations", func(t *testing.T) {
+		executor := setupExecutor("claimed")
+		repo := &schedMocks.ScheduleFiringRepoInterface{}
+		repo.OnClaimMatch(mock.Anything, mock.MatchedBy(isClaim), time.Minute).Return(true, nil)
+		repo.OnMarkFiredMatch(mock.Anything, key, "replica", mock.Anything).Return(nil)
+		mockAdminClient.OnCreateExecutionMatch(context.Background(), mock.Anything).
+			Return(&admin.ExecutionCreateResponse{}, nil)
+		claimingExecutor := NewClaimingExecutor(promutils.NewScope("claimed_claiming"), executor, repo, "replica",
+			time.Minute)
+		assert.NoError(t, claimingExecutor.Execute(context.Background(), scheduledTime, schedule))
+		mockAdminClient.AssertNumberOfCalls(t, "CreateExecution", 1)
+		repo.AssertNumberOfCalls(t, "MarkFired", 1)
+	})
+
+	t.Run("skips invocations claimed by other replicas", func(t *testing.T) {
+		executor := setupExecutor("skipped")
+		repo := &schedMocks.ScheduleFiringRepoInterface{}
+		repo.OnClaimMatch(mock.Anything, mock.MatchedBy(isClaim), time.Minute).Return(false, nil)
+		claimingExecutor := NewClaimingExecutor(promutils.NewScope("skipped_claiming"), executor, repo, "replica",
+			time.Minute)
+		assert.NoError(t, claimingExecutor.Execute(context.Background(), scheduledTime, schedule))
+		mockAdminClient.AssertNotCalled(t, "CreateExecution", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "MarkFired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails to claim", func(t *testing.T) {
+		executor := setupExecutor("claim_failed")
+		repo := &schedMocks.ScheduleFiringRepoInterface{}
+		repo.OnClaimMatch(mock.Anything, mock.Anything, mock.Anything).Return(false, fmt.Errorf("db unavailable"))
+		claimingExecutor := NewClaimingExecutor(promutils.NewScope("claim_failed_claiming"), executor, repo,
+			"replica", time.Minute)
+		assert.Error(t, claimingExecutor.Execute(context.Background(), scheduledTime, schedule))
+		mockAdminClient.AssertNotCalled(t, "CreateExecution", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps the claim of invocations which failed to fire", func(t *testing.T) {
+		repo := &schedMocks.ScheduleFiringRepoInterface{}
+		repo.OnClaimMatch(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+		claimingExecutor := NewClaimingExecutor(promutils.NewScope("fire_failed_claiming"),
+			failingExecutor{}, repo, "replica", time.Minute)
+		assert.Error(t, claimingExecutor.Execute(context.Background(), scheduledTime, schedule))
+		repo.AssertNotCalled(t, "MarkFired", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+type failingExecutor struct{}
+
+func (failingExecutor) Execute(ctx context.Context, scheduledTime time.Time, s models.SchedulableEntity) error {
+	return fmt.Errorf("failed to fire")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/flyteorg/flyteadmin/pkg/errors"
 	"github.com/flyteorg/flyteadmin/scheduler/identifier"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
@@ -31,6 +32,7 @@ type executorMetrics struct {
 	Scope                      promutils.Scope
 	FailedExecutionCounter     prometheus.Counter
 	SuccessfulExecutionCounter prometheus.Counter
+	DroppedExecutionCounter    prometheus.Counter
 }
 
 func (w *executor) Execute(ctx context.Context, scheduledTime time.Time, s models.SchedulableEntity) error {
@@ -106,6 +108,11 @@ func (w *executor) Execute(ctx context.Context, scheduledTime time.Time, s model
 				logger.Debugf(ctx, "duplicate schedule %+v already exists for schedule", s)
 				return false
 			}
+			// Retrying an execution rejected by the active execution quota of its project would only pile up
+			// executions waiting for the quota to free up. Those which are only rate limited are retried.
+			if errors.IsQuotaExceededError(err) {
+				return false
+			}
 			w.metrics.FailedExecutionCounter.Inc()
 			logger.Error(ctx, "failed to create execution create request %+v due to %v", executionRequest, err)
 			// TODO: Handle the case when admin launch plan state is archived but the schedule is active.
@@ -117,6 +124,11 @@ func (w *executor) Execute(ctx context.Context, scheduledTime time.Time, s model
 			return execErr
 		},
 	)
+	if errors.IsQuotaExceededError(err) {
+		w.metrics.DroppedExecutionCounter.Inc()
+		logger.Warningf(ctx, "dropped execution of schedule %+v for time %v due to %v", s, scheduledTime, err)
+		return nil
+	}
 	if err != nil && status.Code(err) != codes.AlreadyExists {
 		logger.Error(ctx, "failed to create execution create request %+v due to %v after all retries", executionRequest, err)
 		return err
@@ -142,5 +154,7 @@ func getExecutorMetrics(scope promutils.Scope) executorMetrics {
 			"count of unsuccessful attempts to fire execution for a schedules"),
 		SuccessfulExecutionCounter: scope.MustNewCounter("successful_execution_counter",
 			"count of successful attempts to fire execution for a schedules"),
+		DroppedExecutionCounter: scope.MustNewCounter("dropped_execution_counter",
+			"count of scheduled executions dropped because their project exhausted its active execution quota"),
 	}
 }
@@ -82,3 +82,48 @@ func TestExecutorInactiveSchedule(t *testing.T) {
 	err := executor.Execute(context.Background(), time.Now(), schedule)
 	assert.Nil(t, err)
 }
+
+func TestExecutorResourceExhausted(t *testing.T) {
+	executor := setupExecutor("testExecutor4")
+	active := true
+	schedule := models.SchedulableEntity{
+		SchedulableEntityKey: models.SchedulableEntityKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "cron_schedule",
+			Version: "v1",
+		},
+		CronExpression:      "*/1 * * * *",
+		KickoffTimeInputArg: "kickoff_time",
+		Active:              &active,
+	}
+	mockAdminClient.OnCreateExecutionMatch(mock.Anything, mock.Anything).Return(nil,
+		errors.NewQuotaExceededError(context.Background(), "project:domain", "too many active executions"))
+	err := executor.Execute(context.Background(), time.Now(), schedule)
+	assert.Nil(t, err)
+	// The execution is dropped rather than retried.
+	mockAdminClient.AssertNumberOfCalls(t, "CreateExecution", 1)
+}
+
+func TestExecutorRateLimited(t *testing.T) {
+	executor := setupExecutor("testExecutor5")
+	active := true
+	schedule := models.SchedulableEntity{
+		SchedulableEntityKey: models.SchedulableEntityKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "cron_schedule",
+			Version: "v1",
+		},
+		CronExpression:      "*/1 * * * *",
+		KickoffTimeInputArg: "kickoff_time",
+		Active:              &active,
+	}
+	mockAdminClient.OnCreateExecutionMatch(mock.Anything, mock.Anything).Return(nil,
+		errors.NewFlyteAdminErrorf(codes.ResourceExhausted, "rate limited")).Once()
+	mockAdminClient.OnCreateExecutionMatch(mock.Anything, mock.Anything).Return(&admin.ExecutionCreateResponse{}, nil)
+	err := executor.Execute(context.Background(), time.Now(), schedule)
+	assert.Nil(t, err)
+	// Unlike those exceeding a quota, rate limited executions are retried.
+	mockAdminClient.AssertNumberOfCalls(t, "CreateExecution", 2)
+}
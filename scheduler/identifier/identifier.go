@@ -20,6 +20,7 @@ import (
 const (
 	scheduleNameInputsFormat = "%s:%s:%s:%s"
 	executionIDInputsFormat  = scheduleNameInputsFormat + ":%d"
+	launchPlanInputsFormat   = "%s:%s:%s"
 )
 
 // GetScheduleName generate the schedule name to be used as unique identification string within the scheduler
@@ -40,6 +41,26 @@ func GetExecutionIdentifier(ctx context.Context, identifier core.Identifier, sch
 	return uuid.FromBytes(b)
 }
 
+// GetScheduleJitter returns the jitter, in whole seconds below maxJitter, delaying the schedule of the launch plan past
+// its nominal times. It's derived from the hash of the launch plan, regardless of its version, so that it's stable
+// across restarts and activations.
+func GetScheduleJitter(ctx context.Context, identifier core.Identifier, maxJitter time.Duration) time.Duration {
+	maxJitterSeconds := uint64(maxJitter / time.Second)
+	if maxJitterSeconds == 0 {
+		return 0
+	}
+	h := fnv.New64()
+	_, err := h.Write([]byte(fmt.Sprintf(launchPlanInputsFormat, identifier.Project, identifier.Domain,
+		identifier.Name)))
+	if err != nil {
+		// This shouldn't occur.
+		logger.Errorf(ctx, "failed to hash launch plan identifier: %+v to get schedule jitter with err: %v",
+			identifier, err)
+		return 0
+	}
+	return time.Duration(h.Sum64()%maxJitterSeconds) * time.Second
+}
+
 // hashIdentifier returns the hash of the identifier
 func hashIdentifier(ctx context.Context, identifier core.Identifier) uint64 {
 	h := fnv.New64()
@@ -0,0 +1,48 @@
+package identifier
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScheduleJitter(t *testing.T) {
+	ctx := context.Background()
+	identifier := core.Identifier{
+		Project: "project",
+		Domain:  "domain",
+		Name:    "launch_plan",
+		Version: "v1",
+	}
+	maxJitter := 10 * time.Minute
+
+	t.Run("deterministic", func(t *testing.T) {
+		jitter := GetScheduleJitter(ctx, identifier, maxJitter)
+		assert.Equal(t, jitter, GetScheduleJitter(ctx, identifier, maxJitter))
+		assert.True(t, jitter >= 0 && jitter < maxJitter)
+		assert.Zero(t, jitter%time.Second)
+		// Versions of the launch plan share its jitter.
+		otherVersion := identifier
+		otherVersion.Version = "v2"
+		assert.Equal(t, jitter, GetScheduleJitter(ctx, otherVersion, maxJitter))
+	})
+	t.Run("spread across launch plans", func(t *testing.T) {
+		jitters := make(map[time.Duration]bool)
+		for i := 0; i < 100; i++ {
+			launchPlan := identifier
+			launchPlan.Name = fmt.Sprintf("launch_plan_%d", i)
+			jitter := GetScheduleJitter(ctx, launchPlan, maxJitter)
+			assert.True(t, jitter >= 0 && jitter < maxJitter)
+			jitters[jitter] = true
+		}
+		assert.Greater(t, len(jitters), 50)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert.Zero(t, GetScheduleJitter(ctx, identifier, 0))
+		assert.Zero(t, GetScheduleJitter(ctx, identifier, 500*time.Millisecond))
+	})
+}
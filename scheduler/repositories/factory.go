@@ -2,9 +2,11 @@ package repositories
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/flyteorg/flyteadmin/pkg/repositories/config"
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	adminInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
 )
@@ -13,10 +15,28 @@ type RepoConfig int32
 
 const (
 	POSTGRES RepoConfig = 0
+	MYSQL    RepoConfig = 1
+	SQLITE   RepoConfig = 2
 )
 
 var RepositoryConfigurationName = map[int32]string{
 	0: "POSTGRES",
+	1: "MYSQL",
+	2: "SQLITE",
+}
+
+// Returns the repository config matching the database dialect configured.
+func GetRepoConfig(dbConfig config.DbConfig) (RepoConfig, error) {
+	switch strings.ToLower(dbConfig.Dialect) {
+	case "", config.Postgres:
+		return POSTGRES, nil
+	case config.MySQL:
+		return MYSQL, nil
+	case config.SQLite:
+		return SQLITE, nil
+	default:
+		return 0, fmt.Errorf("unsupported database dialect [%s]", dbConfig.Dialect)
+	}
 }
 
 // The SchedulerRepoInterface indicates the methods that each Repository must support.
@@ -27,6 +47,9 @@ var RepositoryConfigurationName = map[int32]string{
 type SchedulerRepoInterface interface {
 	SchedulableEntityRepo() interfaces.SchedulableEntityRepoInterface
 	ScheduleEntitiesSnapshotRepo() interfaces.ScheduleEntitiesSnapShotRepoInterface
+	ScheduleFiringRepo() interfaces.ScheduleFiringRepoInterface
+	// The checkpoints are written by admin as it creates scheduled executions, the scheduler starts from them.
+	ScheduleCheckpointRepo() adminInterfaces.ScheduleCheckpointRepoInterface
 }
 
 func GetRepository(repoType RepoConfig, dbConfig config.DbConfig, scope promutils.Scope) SchedulerRepoInterface {
@@ -41,6 +64,29 @@ func GetRepository(repoType RepoConfig, dbConfig config.DbConfig, scope promutil
 			db,
 			errors.NewPostgresErrorTransformer(postgresScope.NewSubScope("errors")),
 			postgresScope.NewSubScope("repositories"))
+	case MYSQL:
+		mysqlScope := scope.NewSubScope("mysql")
+		db, err := config.OpenDbConnection(config.NewMySQLConfigProvider(dbConfig, mysqlScope))
+		if err != nil {
+			panic(err)
+		}
+		return NewPostgresRepo(
+			db,
+			errors.NewMySQLErrorTransformer(mysqlScope.NewSubScope("errors")),
+			mysqlScope.NewSubScope("repositories"))
+	case SQLITE:
+		sqliteScope := scope.NewSubScope("sqlite")
+		db, err := config.OpenDbConnection(config.NewSQLiteConfigProvider(dbConfig, sqliteScope))
+		if err != nil {
+			panic(err)
+		}
+		if err = db.Use(config.NewWriteSerializer()); err != nil {
+			panic(err)
+		}
+		return NewPostgresRepo(
+			db,
+			errors.NewSQLiteErrorTransformer(sqliteScope.NewSubScope("errors")),
+			sqliteScope.NewSubScope("repositories"))
 	default:
 		panic(fmt.Sprintf("Invalid repoType %v", repoType))
 	}
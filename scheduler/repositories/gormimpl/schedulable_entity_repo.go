@@ -25,7 +25,7 @@ type SchedulableEntityRepo struct {
 func (r *SchedulableEntityRepo) Create(ctx context.Context, input models.SchedulableEntity) error {
 	timer := r.metrics.GetDuration.Start()
 	var record models.SchedulableEntity
-	tx := r.db.Omit("id").FirstOrCreate(&record, input)
+	tx := r.db.WithContext(ctx).Omit("id").FirstOrCreate(&record, input)
 	timer.Stop()
 	if tx.Error != nil {
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
@@ -37,7 +37,7 @@ func (r *SchedulableEntityRepo) Activate(ctx context.Context, input models.Sched
 	var schedulableEntity models.SchedulableEntity
 	timer := r.metrics.GetDuration.Start()
 	// Find the existence of a scheduled entity
-	tx := r.db.Where(&models.SchedulableEntity{
+	tx := r.db.WithContext(ctx).Where(&models.SchedulableEntity{
 		SchedulableEntityKey: models.SchedulableEntityKey{
 			Project: input.Project,
 			Domain:  input.Domain,
@@ -55,13 +55,17 @@ func (r *SchedulableEntityRepo) Activate(ctx context.Context, input models.Sched
 		return r.errorTransformer.ToFlyteAdminError(tx.Error)
 	}
 
-	// Activate the already existing schedule
-	return activateOrDeactivate(r, input.SchedulableEntityKey, true)
+	// Activate the already existing schedule, picking up the catch up policy and offset resolved at this activation.
+	return updateSchedulableEntity(r, input.SchedulableEntityKey, map[string]interface{}{
+		"active":          true,
+		"catch_up_policy": input.CatchUpPolicy,
+		"schedule_offset": input.ScheduleOffset,
+	})
 }
 
 func (r *SchedulableEntityRepo) Deactivate(ctx context.Context, ID models.SchedulableEntityKey) error {
-	// Activate the schedule
-	return activateOrDeactivate(r, ID, false)
+	// Deactivate the schedule
+	return updateSchedulableEntity(r, ID, map[string]interface{}{"active": false})
 }
 
 func (r *SchedulableEntityRepo) GetAll(ctx context.Context) ([]models.SchedulableEntity, error) {
@@ -86,7 +90,7 @@ func (r *SchedulableEntityRepo) GetAll(ctx context.Context) ([]models.Schedulabl
 func (r *SchedulableEntityRepo) Get(ctx context.Context, ID models.SchedulableEntityKey) (models.SchedulableEntity, error) {
 	var schedulableEntity models.SchedulableEntity
 	timer := r.metrics.GetDuration.Start()
-	tx := r.db.Where(&models.SchedulableEntity{
+	tx := r.db.WithContext(ctx).Where(&models.SchedulableEntity{
 		SchedulableEntityKey: models.SchedulableEntityKey{
 			Project: ID.Project,
 			Domain:  ID.Domain,
@@ -112,8 +116,9 @@ func (r *SchedulableEntityRepo) Get(ctx context.Context, ID models.SchedulableEn
 	return schedulableEntity, nil
 }
 
-// Helper function to activate and deactivate a schedule
-func activateOrDeactivate(r *SchedulableEntityRepo, ID models.SchedulableEntityKey, activate bool) error {
+// Helper function to update the columns of a schedule, used to activate and deactivate it
+func updateSchedulableEntity(r *SchedulableEntityRepo, ID models.SchedulableEntityKey,
+	updates map[string]interface{}) error {
 	timer := r.metrics.GetDuration.Start()
 	tx := r.db.Model(&models.SchedulableEntity{}).Where(&models.SchedulableEntity{
 		SchedulableEntityKey: models.SchedulableEntityKey{
@@ -122,7 +127,7 @@ func activateOrDeactivate(r *SchedulableEntityRepo, ID models.SchedulableEntityK
 			Name:    ID.Name,
 			Version: ID.Version,
 		},
-	}).Update("active", activate)
+	}).Updates(updates)
 	timer.Stop()
 	if tx.Error != nil {
 		if errors.Is(tx.Error, gorm.ErrRecordNotFound) {
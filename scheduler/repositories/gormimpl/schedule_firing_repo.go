@@ -0,0 +1,112 @@
+package gormimpl
+
+import (
+	"context"
+	"time"
+
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flytestdlib/promutils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ScheduleFiringRepo Implementation of ScheduleFiringRepoInterface.
+type ScheduleFiringRepo struct {
+	db               *gorm.DB
+	errorTransformer adminErrors.ErrorTransformer
+	metrics          gormMetrics
+}
+
+func (r *ScheduleFiringRepo) Claim(ctx context.Context, input models.ScheduleFiring, claimTimeout time.Duration) (
+	bool, error) {
+	// Times are stored in UTC so that they compare equal, and in order, regardless of the dialect.
+	input.ScheduledAt = input.ScheduledAt.UTC()
+	input.ClaimedAt = input.ClaimedAt.UTC()
+	timer := r.metrics.CreateDuration.Start()
+	tx := r.db.WithContext(ctx).Omit("id").Clauses(clause.OnConflict{DoNothing: true}).Create(&input)
+	timer.Stop()
+	if tx.Error != nil {
+		return false, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	if tx.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// The invocation was claimed already. The claim is taken over if it's the replica's own, say from a failed
+	// attempt, or has expired, as long as the invocation hasn't fired. The conditional update is atomic, so only one of
+	// the replicas racing to take over the claim succeeds.
+	timer = r.metrics.UpdateDuration.Start()
+	tx = r.db.WithContext(ctx).Model(&models.ScheduleFiring{}).
+		Where(&models.ScheduleFiring{ScheduleFiringKey: input.ScheduleFiringKey}).
+		Where("fired_at IS NULL AND (claimed_by = ? OR claimed_at < ?)", input.ClaimedBy,
+			input.ClaimedAt.Add(-claimTimeout)).
+		Updates(map[string]interface{}{
+			"claimed_by": input.ClaimedBy,
+			"claimed_at": input.ClaimedAt,
+		})
+	timer.Stop()
+	if tx.Error != nil {
+		return false, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+func (r *ScheduleFiringRepo) MarkFired(ctx context.Context, ID models.ScheduleFiringKey, claimedBy string,
+	firedAt time.Time) error {
+	ID.ScheduledAt = ID.ScheduledAt.UTC()
+	firedAt = firedAt.UTC()
+	timer := r.metrics.UpdateDuration.Start()
+	// A claim taken over in the meantime is left to the replica holding it now, which fires the invocation again
+	// idempotently.
+	tx := r.db.WithContext(ctx).Model(&models.ScheduleFiring{}).
+		Where(&models.ScheduleFiring{ScheduleFiringKey: ID, ClaimedBy: claimedBy}).
+		Update("fired_at", &firedAt)
+	timer.Stop()
+	if tx.Error != nil {
+		return r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return nil
+}
+
+func (r *ScheduleFiringRepo) GetLastFired(ctx context.Context) ([]models.ScheduleFiringKey, error) {
+	var lastFired []models.ScheduleFiringKey
+	timer := r.metrics.ListDuration.Start()
+	// Rather than aggregating with MAX, which SQLite returns untyped, the invocations are selected which have no later
+	// fired invocation of their schedule.
+	tx := r.db.WithContext(ctx).Table("schedule_firings AS fired").
+		Select("fired.project, fired.domain, fired.name, fired.version, fired.scheduled_at").
+		Where("fired.fired_at IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM schedule_firings AS later WHERE later.project = fired.project AND " +
+			"later.domain = fired.domain AND later.name = fired.name AND later.version = fired.version AND " +
+			"later.fired_at IS NOT NULL AND later.scheduled_at > fired.scheduled_at)").
+		Scan(&lastFired)
+	timer.Stop()
+	if tx.Error != nil {
+		return nil, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return lastFired, nil
+}
+
+func (r *ScheduleFiringRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	timer := r.metrics.DeleteDuration.Start()
+	tx := r.db.WithContext(ctx).Where("scheduled_at < ?", before.UTC()).Delete(&models.ScheduleFiring{})
+	timer.Stop()
+	if tx.Error != nil {
+		return 0, r.errorTransformer.ToFlyteAdminError(tx.Error)
+	}
+	return tx.RowsAffected, nil
+}
+
+// NewScheduleFiringRepo Returns an instance of ScheduleFiringRepoInterface
+func NewScheduleFiringRepo(
+	db *gorm.DB, errorTransformer adminErrors.ErrorTransformer, scope promutils.Scope) interfaces.ScheduleFiringRepoInterface {
+	metrics := newMetrics(scope)
+	return &ScheduleFiringRepo{
+		db:               db,
+		errorTransformer: errorTransformer,
+		metrics:          metrics,
+	}
+}
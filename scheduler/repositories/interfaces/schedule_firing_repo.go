@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+)
+
+//go:generate mockery -name=ScheduleFiringRepoInterface -output=../mocks -case=underscore
+
+// ScheduleFiringRepoInterface : An Interface for interacting with the claims of scheduler replicas to fire schedules
+type ScheduleFiringRepoInterface interface {
+
+	// Claim the invocation for the replica, unless it has fired or another replica holds an unexpired claim on it.
+	// Returns whether the invocation was claimed.
+	Claim(ctx context.Context, input models.ScheduleFiring, claimTimeout time.Duration) (bool, error)
+
+	// MarkFired records that the invocation claimed by the replica has fired.
+	MarkFired(ctx context.Context, ID models.ScheduleFiringKey, claimedBy string, firedAt time.Time) error
+
+	// GetLastFired gets the last fired invocation of each schedule.
+	GetLastFired(ctx context.Context) ([]models.ScheduleFiringKey, error)
+
+	// DeleteBefore deletes the claims of invocations scheduled before the time given, returning how many were deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
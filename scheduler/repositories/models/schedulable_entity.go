@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
 )
@@ -14,6 +16,10 @@ type SchedulableEntity struct {
 	Unit                admin.FixedRateUnit
 	KickoffTimeInputArg string
 	Active              *bool
+	// Determines which stale invocations still fire when the scheduler catches up on the schedule.
+	CatchUpPolicy string
+	// Delays each invocation past its scheduled time, which remains its kickoff time.
+	ScheduleOffset time.Duration
 }
 
 // Schedulable entity primary key
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/repositories/models"
+)
+
+// Database model to record the claim of a scheduler replica to fire an invocation of a schedule, and when it fired.
+type ScheduleFiring struct {
+	models.BaseModel
+	ScheduleFiringKey
+	ClaimedBy string
+	ClaimedAt time.Time
+	FiredAt   *time.Time
+}
+
+// Schedule firing primary key, the invocation of the schedule at its scheduled time
+type ScheduleFiringKey struct {
+	SchedulableEntityKey
+	ScheduledAt time.Time `gorm:"primary_key"`
+}
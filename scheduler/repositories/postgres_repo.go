@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"github.com/flyteorg/flyteadmin/pkg/repositories/errors"
+	adminGormImpl "github.com/flyteorg/flyteadmin/pkg/repositories/gormimpl"
+	adminInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/gormimpl"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories/interfaces"
 	"github.com/flyteorg/flytestdlib/promutils"
@@ -11,6 +13,8 @@ import (
 type PostgresRepo struct {
 	schedulableEntityRepo        interfaces.SchedulableEntityRepoInterface
 	scheduleEntitiesSnapshotRepo interfaces.ScheduleEntitiesSnapShotRepoInterface
+	scheduleFiringRepo           interfaces.ScheduleFiringRepoInterface
+	scheduleCheckpointRepo       adminInterfaces.ScheduleCheckpointRepoInterface
 }
 
 func (p *PostgresRepo) SchedulableEntityRepo() interfaces.SchedulableEntityRepoInterface {
@@ -21,9 +25,19 @@ func (p *PostgresRepo) ScheduleEntitiesSnapshotRepo() interfaces.ScheduleEntitie
 	return p.scheduleEntitiesSnapshotRepo
 }
 
+func (p *PostgresRepo) ScheduleFiringRepo() interfaces.ScheduleFiringRepoInterface {
+	return p.scheduleFiringRepo
+}
+
+func (p *PostgresRepo) ScheduleCheckpointRepo() adminInterfaces.ScheduleCheckpointRepoInterface {
+	return p.scheduleCheckpointRepo
+}
+
 func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) SchedulerRepoInterface {
 	return &PostgresRepo{
 		schedulableEntityRepo:        gormimpl.NewSchedulableEntityRepo(db, errorTransformer, scope.NewSubScope("schedulable_entity")),
 		scheduleEntitiesSnapshotRepo: gormimpl.NewScheduleEntitiesSnapshotRepo(db, errorTransformer, scope.NewSubScope("schedule_entities_snapshot")),
+		scheduleFiringRepo:           gormimpl.NewScheduleFiringRepo(db, errorTransformer, scope.NewSubScope("schedule_firing")),
+		scheduleCheckpointRepo:       adminGormImpl.NewScheduleCheckpointRepo(db, errorTransformer, scope.NewSubScope("schedule_checkpoints")),
 	}
 }
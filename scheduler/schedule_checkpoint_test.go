@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyteadmin/pkg/common"
+	adminErrors "github.com/flyteorg/flyteadmin/pkg/errors"
+	adminRepositories "github.com/flyteorg/flyteadmin/pkg/repositories"
+	adminInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
+	adminModels "github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	schedulerRepositories "github.com/flyteorg/flyteadmin/scheduler/repositories"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// Creates the executions of the invocations fired like admin does, advancing the checkpoint of the schedule in the
+// same transaction, and ignoring executions which exist already.
+type checkpointingExecutor struct {
+	repo    adminRepositories.RepositoryInterface
+	mutex   sync.Mutex
+	created []time.Time
+}
+
+func getExecutionNameForCheckpointTest(scheduledTime time.Time) string {
+	return fmt.Sprintf("f%d", scheduledTime.Unix())
+}
+
+func (e *checkpointingExecutor) Execute(ctx context.Context, scheduledTime time.Time,
+	s models.SchedulableEntity) error {
+	name := getExecutionNameForCheckpointTest(scheduledTime)
+	err := e.repo.ExecutionRepo().Create(ctx, adminModels.Execution{
+		ExecutionKey: adminModels.ExecutionKey{
+			Project: s.Project,
+			Domain:  s.Domain,
+			Name:    name,
+		},
+		Phase: core.WorkflowExecution_QUEUED.String(),
+		Spec:  []byte{},
+		ScheduleCheckpoint: &adminModels.ScheduleCheckpoint{
+			ScheduleCheckpointKey: adminModels.ScheduleCheckpointKey{
+				Project: s.Project,
+				Domain:  s.Domain,
+				Name:    s.Name,
+				Version: s.Version,
+			},
+			LastScheduledAt:   scheduledTime,
+			LastExecutionName: name,
+		},
+	})
+	if adminError, ok := err.(adminErrors.FlyteAdminError); ok && adminError.Code() == codes.AlreadyExists {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.created = append(e.created, scheduledTime.UTC())
+	return nil
+}
+
+func getScheduleCheckpointForTest(t *testing.T, repo adminRepositories.RepositoryInterface) adminModels.ScheduleCheckpoint {
+	checkpoints, err := repo.ScheduleCheckpointRepo().List(context.Background(),
+		adminInterfaces.ListScheduleCheckpointsInput{})
+	assert.NoError(t, err)
+	assert.Len(t, checkpoints, 1)
+	return checkpoints[0]
+}
+
+func TestSQLite_ScheduleCheckpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dbConfig := getSQLiteDbConfigForTest(t)
+	adminRepo := adminRepositories.GetRepository(adminRepositories.SQLITE, dbConfig, promutils.NewTestScope())
+	schedulerRepo := schedulerRepositories.GetRepository(schedulerRepositories.SQLITE, dbConfig,
+		promutils.NewTestScope())
+	schedule := getScheduleForFiringTest()
+	checkpointKey := adminModels.ScheduleCheckpointKey{
+		Project: schedule.Project,
+		Domain:  schedule.Domain,
+		Name:    schedule.Name,
+		Version: schedule.Version,
+	}
+	assertExecutions := func(scheduledTimes []time.Time) {
+		projectFilter, err := common.NewSingleValueFilter(common.Execution, common.Equal, "project", schedule.Project)
+		assert.NoError(t, err)
+		output, err := adminRepo.ExecutionRepo().List(ctx, adminInterfaces.ListResourceInput{
+			InlineFilters: []common.InlineFilter{projectFilter},
+			Limit:         100,
+		})
+		assert.NoError(t, err)
+		var names []string
+		for _, execution := range output.Executions {
+			names = append(names, execution.Name)
+		}
+		var expected []string
+		for _, scheduledTime := range scheduledTimes {
+			expected = append(expected, getExecutionNameForCheckpointTest(scheduledTime))
+		}
+		assert.ElementsMatch(t, expected, names)
+	}
+
+	executor := &checkpointingExecutor{repo: adminRepo}
+	replica := newReplicaForFiringTest(ctx, t, schedulerRepo, "replica", executor,
+		[]models.SchedulableEntity{schedule})
+	assert.True(t, replica.CatchupAll(ctx, scheduleFiringStart.Add(10*time.Minute)))
+	assertExecutions(getScheduledTimesForFiringTest(1, 10))
+	checkpoint := getScheduleCheckpointForTest(t, adminRepo)
+	assert.Equal(t, checkpointKey, checkpoint.ScheduleCheckpointKey)
+	assert.True(t, scheduleFiringStart.Add(10*time.Minute).Equal(checkpoint.LastScheduledAt))
+	assert.Equal(t, getExecutionNameForCheckpointTest(scheduleFiringStart.Add(10*time.Minute)),
+		checkpoint.LastExecutionName)
+
+	// Executions for earlier scheduled times leave the checkpoint where it is.
+	earlier := scheduleFiringStart.Add(-time.Minute)
+	assert.NoError(t, executor.Execute(ctx, earlier, schedule))
+	assert.True(t, scheduleFiringStart.Add(10*time.Minute).Equal(
+		getScheduleCheckpointForTest(t, adminRepo).LastScheduledAt))
+
+	t.Run("rewind then catch up", func(t *testing.T) {
+		assert.NoError(t, adminRepo.ScheduleCheckpointRepo().Update(ctx, adminModels.ScheduleCheckpoint{
+			ScheduleCheckpointKey: checkpointKey,
+			LastScheduledAt:       scheduleFiringStart.Add(5 * time.Minute),
+		}))
+		checkpoint := getScheduleCheckpointForTest(t, adminRepo)
+		assert.True(t, scheduleFiringStart.Add(5*time.Minute).Equal(checkpoint.LastScheduledAt))
+		assert.Empty(t, checkpoint.LastExecutionName)
+
+		// The restarted replica fires the invocations after the checkpoint again, creating only the executions which
+		// are missing.
+		executor := &checkpointingExecutor{repo: adminRepo}
+		restarted := newReplicaForFiringTest(ctx, t, schedulerRepo, "replica", executor,
+			[]models.SchedulableEntity{schedule})
+		assert.True(t, restarted.CatchupAll(ctx, scheduleFiringStart.Add(12*time.Minute)))
+		assert.ElementsMatch(t, getScheduledTimesForFiringTest(11, 12), executor.created)
+		assertExecutions(append(getScheduledTimesForFiringTest(1, 12), earlier))
+		assert.True(t, scheduleFiringStart.Add(12*time.Minute).Equal(
+			getScheduleCheckpointForTest(t, adminRepo).LastScheduledAt))
+	})
+
+	t.Run("advance", func(t *testing.T) {
+		assert.NoError(t, adminRepo.ScheduleCheckpointRepo().Update(ctx, adminModels.ScheduleCheckpoint{
+			ScheduleCheckpointKey: checkpointKey,
+			LastScheduledAt:       scheduleFiringStart.Add(20 * time.Minute),
+		}))
+
+		executor := &checkpointingExecutor{repo: adminRepo}
+		restarted := newReplicaForFiringTest(ctx, t, schedulerRepo, "replica", executor,
+			[]models.SchedulableEntity{schedule})
+		assert.True(t, restarted.CatchupAll(ctx, scheduleFiringStart.Add(22*time.Minute)))
+		assert.ElementsMatch(t, getScheduledTimesForFiringTest(21, 22), executor.created)
+		assertExecutions(append(getScheduledTimesForFiringTest(1, 12), append(getScheduledTimesForFiringTest(21, 22),
+			earlier)...))
+	})
+}
@@ -2,24 +2,30 @@ package scheduler
 
 import (
 	"context"
+	"os"
 	"time"
 
+	adminInterfaces "github.com/flyteorg/flyteadmin/pkg/repositories/interfaces"
 	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
 	"github.com/flyteorg/flyteadmin/scheduler/core"
 	"github.com/flyteorg/flyteadmin/scheduler/executor"
+	"github.com/flyteorg/flyteadmin/scheduler/identifier"
 	"github.com/flyteorg/flyteadmin/scheduler/repositories"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
 	"github.com/flyteorg/flyteadmin/scheduler/snapshoter"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/service"
 	"github.com/flyteorg/flytestdlib/futures"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 
+	"github.com/google/uuid"
 	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 const snapshotWriterDuration = 30 * time.Second
 const scheduleUpdaterDuration = 30 * time.Second
+const scheduleFiringPrunerDuration = time.Hour
 
 const snapShotVersion = 1
 
@@ -31,6 +37,8 @@ type ScheduledExecutor struct {
 	scope                  promutils.Scope
 	adminServiceClient     service.AdminServiceClient
 	workflowExecutorConfig *runtimeInterfaces.FlyteWorkflowExecutorConfig
+	catchUpThreshold       time.Duration
+	replicaID              string
 }
 
 func (w *ScheduledExecutor) Run(ctx context.Context) error {
@@ -48,6 +56,19 @@ func (w *ScheduledExecutor) Run(ctx context.Context) error {
 		return err
 	}
 
+	// Catch up after the invocations which fired since the snapshot was written, as recorded by their claims
+	if err := w.updateSnapshotFromScheduleFirings(ctx, snapshot); err != nil {
+		logger.Errorf(ctx, "unable to read the fired schedules from the db due to %v. Aborting", err)
+		return err
+	}
+
+	// Start from the checkpoints of the schedules, which admin advances as it creates their executions, and which
+	// admins may have set to rewind or advance the schedules
+	if err := w.updateSnapshotFromScheduleCheckpoints(ctx, snapshot); err != nil {
+		logger.Errorf(ctx, "unable to read the schedule checkpoints from the db due to %v. Aborting", err)
+		return err
+	}
+
 	// Read all the schedules from the DB
 	schedules, err := w.db.SchedulableEntityRepo().GetAll(ctx)
 	if err != nil {
@@ -60,14 +81,17 @@ func (w *ScheduledExecutor) Run(ctx context.Context) error {
 	// Set the rate limit on the admin
 	rateLimiter := rate.NewLimiter(adminRateLimit.GetTps(), adminRateLimit.GetBurst())
 
-	// Set the executor to send executions to admin
-	executor := executor.New(w.scope, w.adminServiceClient)
+	// Set the executor to send executions to admin, for the invocations claimed by this replica
+	executor := executor.NewClaimingExecutor(w.scope, executor.New(w.scope, w.adminServiceClient),
+		w.db.ScheduleFiringRepo(), w.replicaID, w.workflowExecutorConfig.GetClaimTimeout())
+	logger.Infof(ctx, "Claiming schedules as replica %v", w.replicaID)
 
 	// Create the scheduler using GoCronScheduler implementation
 	// Also Bootstrap the schedules from the snapshot
 	bootStrapCtx, bootStrapCancel := context.WithCancel(ctx)
 	defer bootStrapCancel()
-	gcronScheduler := core.NewGoCronScheduler(bootStrapCtx, schedules, w.scope, snapshot, rateLimiter, executor)
+	gcronScheduler := core.NewGoCronScheduler(bootStrapCtx, schedules, w.scope, snapshot, rateLimiter, executor,
+		w.catchUpThreshold)
 	w.scheduler = gcronScheduler
 
 	// Start the go routine to write the update schedules periodically
@@ -76,6 +100,11 @@ func (w *ScheduledExecutor) Run(ctx context.Context) error {
 	gcronUpdater := core.NewUpdater(w.db, gcronScheduler)
 	go wait.UntilWithContext(updaterCtx, gcronUpdater.UpdateGoCronSchedules, scheduleUpdaterDuration)
 
+	// Start the go routine to prune the claims of old invocations periodically
+	prunerCtx, prunerCancel := context.WithCancel(ctx)
+	defer prunerCancel()
+	go wait.UntilWithContext(prunerCtx, w.pruneScheduleFirings, scheduleFiringPrunerDuration)
+
 	// Catch up simulataneously on all the schedules in the scheduler
 	currTime := time.Now()
 	af := futures.NewAsyncFuture(ctx, func(ctx context.Context) (interface{}, error) {
@@ -102,6 +131,71 @@ func (w *ScheduledExecutor) Run(ctx context.Context) error {
 	return nil
 }
 
+// Updates the last execution times of the snapshot with the last fired invocations of the schedules.
+func (w *ScheduledExecutor) updateSnapshotFromScheduleFirings(ctx context.Context, snapshot snapshoter.Snapshot) error {
+	lastFired, err := w.db.ScheduleFiringRepo().GetLastFired(ctx)
+	if err != nil {
+		return err
+	}
+	for _, firing := range lastFired {
+		nameOfSchedule := identifier.GetScheduleName(ctx, models.SchedulableEntity{
+			SchedulableEntityKey: firing.SchedulableEntityKey,
+		})
+		scheduledAt := firing.ScheduledAt
+		if lastExecTime := snapshot.GetLastExecutionTime(nameOfSchedule); lastExecTime == nil ||
+			lastExecTime.Before(scheduledAt) {
+			snapshot.UpdateLastExecutionTime(nameOfSchedule, &scheduledAt)
+		}
+	}
+	return nil
+}
+
+// Sets the last execution times of the snapshot to the checkpoints of the schedules. Checkpoints take precedence over
+// both the snapshot and the claims, so that a checkpoint set to an earlier time rewinds the schedule. Invocations which
+// fired after the checkpoint without creating an execution, say because they were dropped, fire again.
+func (w *ScheduledExecutor) updateSnapshotFromScheduleCheckpoints(ctx context.Context,
+	snapshot snapshoter.Snapshot) error {
+	checkpoints, err := w.db.ScheduleCheckpointRepo().List(ctx, adminInterfaces.ListScheduleCheckpointsInput{})
+	if err != nil {
+		return err
+	}
+	for _, checkpoint := range checkpoints {
+		nameOfSchedule := identifier.GetScheduleName(ctx, models.SchedulableEntity{
+			SchedulableEntityKey: models.SchedulableEntityKey{
+				Project: checkpoint.Project,
+				Domain:  checkpoint.Domain,
+				Name:    checkpoint.Name,
+				Version: checkpoint.Version,
+			},
+		})
+		lastScheduledAt := checkpoint.LastScheduledAt
+		snapshot.UpdateLastExecutionTime(nameOfSchedule, &lastScheduledAt)
+	}
+	return nil
+}
+
+// Deletes the claims of invocations older than the claim retention.
+func (w *ScheduledExecutor) pruneScheduleFirings(ctx context.Context) {
+	deleted, err := w.db.ScheduleFiringRepo().DeleteBefore(ctx,
+		time.Now().Add(-w.workflowExecutorConfig.GetClaimRetention()))
+	if err != nil {
+		logger.Errorf(ctx, "failed to prune the claims of fired schedules due to %v", err)
+		return
+	}
+	logger.Debugf(ctx, "pruned %d claims of fired schedules", deleted)
+}
+
+// Returns the replica id configured, or else the hostname, which is unique among the pods of a deployment.
+func getReplicaID(workflowExecutorConfig *runtimeInterfaces.FlyteWorkflowExecutorConfig) string {
+	if len(workflowExecutorConfig.GetReplicaID()) > 0 {
+		return workflowExecutorConfig.GetReplicaID()
+	}
+	if hostname, err := os.Hostname(); err == nil && len(hostname) > 0 {
+		return hostname
+	}
+	return uuid.New().String()
+}
+
 func NewScheduledExecutor(db repositories.SchedulerRepoInterface,
 	workflowExecutorConfig runtimeInterfaces.WorkflowExecutorConfig,
 	scope promutils.Scope, adminServiceClient service.AdminServiceClient) ScheduledExecutor {
@@ -110,6 +204,8 @@ func NewScheduledExecutor(db repositories.SchedulerRepoInterface,
 		scope:                  scope,
 		adminServiceClient:     adminServiceClient,
 		workflowExecutorConfig: workflowExecutorConfig.GetFlyteWorkflowExecutorConfig(),
+		catchUpThreshold:       workflowExecutorConfig.GetCatchUpThreshold(),
+		replicaID:              getReplicaID(workflowExecutorConfig.GetFlyteWorkflowExecutorConfig()),
 		snapshoter:             snapshoter.New(scope, db),
 	}
 }
@@ -58,6 +58,13 @@ func setupScheduleExecutor(t *testing.T, s string) ScheduledExecutor {
 	}
 	snapshotRepo.OnReadMatch(mock.Anything).Return(snapshotModel, nil)
 	snapshotRepo.OnWriteMatch(mock.Anything, mock.Anything).Return(nil)
+	scheduleFiringRepo := db.ScheduleFiringRepo().(*schedMocks.ScheduleFiringRepoInterface)
+	scheduleFiringRepo.OnGetLastFiredMatch(mock.Anything).Return(nil, nil)
+	scheduleFiringRepo.OnClaimMatch(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	scheduleFiringRepo.OnMarkFiredMatch(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	scheduleFiringRepo.OnDeleteBeforeMatch(mock.Anything, mock.Anything).Return(int64(0), nil)
+	scheduleCheckpointRepo := db.ScheduleCheckpointRepo().(*mocks.ScheduleCheckpointRepoInterface)
+	scheduleCheckpointRepo.OnListMatch(mock.Anything, mock.Anything).Return(nil, nil)
 	mockAdminClient.OnCreateExecutionMatch(context.Background(), mock.Anything).
 		Return(&admin.ExecutionCreateResponse{}, nil)
 	return NewScheduledExecutor(db, scheduleExecutorConfig,
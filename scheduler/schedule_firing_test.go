@@ -0,0 +1,297 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	repositoryConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	adminModels "github.com/flyteorg/flyteadmin/pkg/repositories/models"
+	runtimeInterfaces "github.com/flyteorg/flyteadmin/pkg/runtime/interfaces"
+	"github.com/flyteorg/flyteadmin/scheduler/core"
+	"github.com/flyteorg/flyteadmin/scheduler/executor"
+	"github.com/flyteorg/flyteadmin/scheduler/identifier"
+	schedulerRepositories "github.com/flyteorg/flyteadmin/scheduler/repositories"
+	"github.com/flyteorg/flyteadmin/scheduler/repositories/models"
+	"github.com/flyteorg/flyteadmin/scheduler/snapshoter"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// The tests below run the claims of scheduler replicas against a migrated SQLite database, which unlike Postgres needs
+// no database server.
+func getSQLiteDbConfigForTest(t *testing.T) repositoryConfig.DbConfig {
+	dbConfig := repositoryConfig.DbConfig{
+		BaseConfig: repositoryConfig.BaseConfig{
+			DisableForeignKeyConstraintWhenMigrating: true,
+		},
+		Dialect: repositoryConfig.SQLite,
+		SQLite: runtimeInterfaces.DbSQLiteConfig{
+			File: filepath.Join(t.TempDir(), "flyteadmin.db"),
+		},
+	}
+	db, err := repositoryConfig.OpenDbConnection(repositoryConfig.NewSQLiteConfigProvider(dbConfig,
+		promutils.NewTestScope()))
+	assert.NoError(t, err)
+	assert.NoError(t, gormigrate.New(db, gormigrate.DefaultOptions, repositoryConfig.Migrations).Migrate())
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		assert.NoError(t, sqlDB.Close())
+	})
+	return dbConfig
+}
+
+func getSQLiteSchedulerRepoForTest(t *testing.T) schedulerRepositories.SchedulerRepoInterface {
+	return schedulerRepositories.GetRepository(schedulerRepositories.SQLITE, getSQLiteDbConfigForTest(t),
+		promutils.NewTestScope())
+}
+
+// Records the invocations fired, failing those at the times given.
+type recordingExecutor struct {
+	mutex   sync.Mutex
+	fired   map[time.Time]int
+	failing map[time.Time]bool
+}
+
+func (r *recordingExecutor) Execute(ctx context.Context, scheduledTime time.Time, s models.SchedulableEntity) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.failing[scheduledTime.UTC()] {
+		return fmt.Errorf("failed to fire %v", scheduledTime)
+	}
+	r.fired[scheduledTime.UTC()]++
+	return nil
+}
+
+func newRecordingExecutor(failing ...time.Time) *recordingExecutor {
+	r := &recordingExecutor{
+		fired:   make(map[time.Time]int),
+		failing: make(map[time.Time]bool),
+	}
+	for _, t := range failing {
+		r.failing[t.UTC()] = true
+	}
+	return r
+}
+
+var scheduleFiringStart = time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+
+func getScheduleForFiringTest() models.SchedulableEntity {
+	active := true
+	return models.SchedulableEntity{
+		BaseModel: adminModels.BaseModel{
+			UpdatedAt: scheduleFiringStart,
+		},
+		SchedulableEntityKey: models.SchedulableEntityKey{
+			Project: "project",
+			Domain:  "domain",
+			Name:    "schedule",
+			Version: "v1",
+		},
+		FixedRateValue: 1,
+		Unit:           admin.FixedRateUnit_MINUTE,
+		Active:         &active,
+		CatchUpPolicy:  string(runtimeInterfaces.CatchUpPolicyAll),
+	}
+}
+
+// Returns the scheduled times of the schedule after the start, up to the end.
+func getScheduledTimesForFiringTest(from, to int) []time.Time {
+	var scheduledTimes []time.Time
+	for i := from; i <= to; i++ {
+		scheduledTimes = append(scheduledTimes, scheduleFiringStart.Add(time.Duration(i)*time.Minute))
+	}
+	return scheduledTimes
+}
+
+// Creates a scheduler replica which fires the schedules given through the executor, starting from the claims and
+// checkpoints in the database like a booting scheduler.
+func newReplicaForFiringTest(ctx context.Context, t *testing.T, repo schedulerRepositories.SchedulerRepoInterface,
+	replicaID string, recorder executor.Executor, schedules []models.SchedulableEntity) *core.GoCronScheduler {
+	scope := promutils.NewTestScope()
+	snapshot := &snapshoter.SnapshotV1{LastTimes: map[string]*time.Time{}}
+	scheduledExecutor := ScheduledExecutor{db: repo}
+	assert.NoError(t, scheduledExecutor.updateSnapshotFromScheduleFirings(ctx, snapshot))
+	assert.NoError(t, scheduledExecutor.updateSnapshotFromScheduleCheckpoints(ctx, snapshot))
+	claimingExecutor := executor.NewClaimingExecutor(scope, recorder, repo.ScheduleFiringRepo(), replicaID,
+		time.Minute)
+	scheduler := core.NewGoCronScheduler(ctx, schedules, scope, snapshot, rate.NewLimiter(rate.Inf, 1),
+		claimingExecutor, 0)
+	return scheduler.(*core.GoCronScheduler)
+}
+
+func TestSQLite_ScheduleFiringClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := getSQLiteSchedulerRepoForTest(t).ScheduleFiringRepo()
+	key := models.ScheduleFiringKey{
+		SchedulableEntityKey: getScheduleForFiringTest().SchedulableEntityKey,
+		ScheduledAt:          scheduleFiringStart,
+	}
+	claimedAt := time.Now()
+	claim := func(replicaID string, claimedAt time.Time) bool {
+		claimed, err := repo.Claim(ctx, models.ScheduleFiring{
+			ScheduleFiringKey: key,
+			ClaimedBy:         replicaID,
+			ClaimedAt:         claimedAt,
+		}, time.Minute)
+		assert.NoError(t, err)
+		return claimed
+	}
+
+	assert.True(t, claim("a", claimedAt))
+	assert.False(t, claim("b", claimedAt.Add(time.Second)), "claimed by another replica")
+	assert.True(t, claim("a", claimedAt.Add(time.Second)), "replicas retry their own claims")
+	assert.True(t, claim("b", claimedAt.Add(2*time.Minute)), "the claim expired")
+	assert.False(t, claim("a", claimedAt.Add(2*time.Minute)), "the claim was taken over")
+
+	// Only the replica holding the claim marks the invocation fired.
+	assert.NoError(t, repo.MarkFired(ctx, key, "a", claimedAt))
+	lastFired, err := repo.GetLastFired(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, lastFired)
+	assert.NoError(t, repo.MarkFired(ctx, key, "b", claimedAt))
+	lastFired, err = repo.GetLastFired(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, lastFired, 1)
+	assert.Equal(t, key.SchedulableEntityKey, lastFired[0].SchedulableEntityKey)
+	assert.True(t, scheduleFiringStart.Equal(lastFired[0].ScheduledAt))
+
+	// Fired invocations aren't claimed again, even once their claim expires.
+	assert.False(t, claim("b", claimedAt.Add(time.Second)))
+	assert.False(t, claim("c", claimedAt.Add(time.Hour)))
+
+	deleted, err := repo.DeleteBefore(ctx, scheduleFiringStart)
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+	deleted, err = repo.DeleteBefore(ctx, scheduleFiringStart.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.True(t, claim("c", claimedAt), "pruned invocations may be claimed again")
+}
+
+func TestSQLite_ConcurrentScheduleFiringClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := getSQLiteSchedulerRepoForTest(t).ScheduleFiringRepo()
+	key := models.ScheduleFiringKey{
+		SchedulableEntityKey: getScheduleForFiringTest().SchedulableEntityKey,
+		ScheduledAt:          scheduleFiringStart,
+	}
+	const replicas = 10
+	claimedAt := time.Now()
+	var claims sync.WaitGroup
+	var claimed [replicas]bool
+	for i := 0; i < replicas; i++ {
+		claims.Add(1)
+		go func(i int) {
+			defer claims.Done()
+			var err error
+			claimed[i], err = repo.Claim(ctx, models.ScheduleFiring{
+				ScheduleFiringKey: key,
+				ClaimedBy:         fmt.Sprintf("replica-%d", i),
+				ClaimedAt:         claimedAt,
+			}, time.Minute)
+			assert.NoError(t, err)
+		}(i)
+	}
+	claims.Wait()
+	var claimCount int
+	for _, c := range claimed {
+		if c {
+			claimCount++
+		}
+	}
+	assert.Equal(t, 1, claimCount)
+}
+
+func TestSQLite_MultiReplicaCatchUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := getSQLiteSchedulerRepoForTest(t)
+	schedule := getScheduleForFiringTest()
+	recorder := newRecordingExecutor()
+	const replicas = 3
+	var catchUps sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		replica := newReplicaForFiringTest(ctx, t, repo, fmt.Sprintf("replica-%d", i), recorder, nil)
+		catchUps.Add(1)
+		go func() {
+			defer catchUps.Done()
+			assert.NoError(t, replica.CatchUpSingleSchedule(ctx, schedule, scheduleFiringStart,
+				scheduleFiringStart.Add(10*time.Minute)))
+		}()
+	}
+	catchUps.Wait()
+
+	// Each invocation fired once, by whichever replica claimed it.
+	assert.Len(t, recorder.fired, 10)
+	for _, scheduledTime := range getScheduledTimesForFiringTest(1, 10) {
+		assert.Equal(t, 1, recorder.fired[scheduledTime], scheduledTime)
+	}
+}
+
+func TestSQLite_RestartCatchUp(t *testing.T) {
+	schedule := getScheduleForFiringTest()
+
+	t.Run("catches up from the last fired", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		repo := getSQLiteSchedulerRepoForTest(t)
+		recorder := newRecordingExecutor()
+		replica := newReplicaForFiringTest(ctx, t, repo, "replica", recorder, nil)
+		assert.NoError(t, replica.CatchUpSingleSchedule(ctx, schedule, scheduleFiringStart,
+			scheduleFiringStart.Add(5*time.Minute)))
+
+		// The restarted replica catches up after the last invocation fired, rather than from the schedule's activation.
+		restarted := newReplicaForFiringTest(ctx, t, repo, "replica", recorder, []models.SchedulableEntity{schedule})
+		assert.True(t, restarted.CatchupAll(ctx, scheduleFiringStart.Add(10*time.Minute)))
+		assert.Len(t, recorder.fired, 10)
+		for _, scheduledTime := range getScheduledTimesForFiringTest(1, 10) {
+			assert.Equal(t, 1, recorder.fired[scheduledTime], scheduledTime)
+		}
+	})
+
+	t.Run("retries the invocation which failed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		repo := getSQLiteSchedulerRepoForTest(t)
+		failed := scheduleFiringStart.Add(3 * time.Minute)
+		replica := newReplicaForFiringTest(ctx, t, repo, "replica", newRecordingExecutor(failed), nil)
+		assert.Error(t, replica.CatchUpSingleSchedule(ctx, schedule, scheduleFiringStart,
+			scheduleFiringStart.Add(5*time.Minute)))
+
+		// The replica restarts with the claim of the failed invocation still its own.
+		recorder := newRecordingExecutor()
+		restarted := newReplicaForFiringTest(ctx, t, repo, "replica", recorder, []models.SchedulableEntity{schedule})
+		assert.True(t, restarted.CatchupAll(ctx, scheduleFiringStart.Add(5*time.Minute)))
+		assert.Len(t, recorder.fired, 3)
+		for _, scheduledTime := range getScheduledTimesForFiringTest(3, 5) {
+			assert.Equal(t, 1, recorder.fired[scheduledTime], scheduledTime)
+		}
+	})
+
+	t.Run("keeps the later snapshot", func(t *testing.T) {
+		ctx := context.Background()
+		repo := getSQLiteSchedulerRepoForTest(t)
+		replica := newReplicaForFiringTest(ctx, t, repo, "replica", newRecordingExecutor(), nil)
+		assert.NoError(t, replica.CatchUpSingleSchedule(ctx, schedule, scheduleFiringStart,
+			scheduleFiringStart.Add(5*time.Minute)))
+
+		nameOfSchedule := identifier.GetScheduleName(ctx, schedule)
+		lastExecTime := scheduleFiringStart.Add(8 * time.Minute)
+		snapshot := &snapshoter.SnapshotV1{LastTimes: map[string]*time.Time{nameOfSchedule: &lastExecTime}}
+		scheduledExecutor := ScheduledExecutor{db: repo}
+		assert.NoError(t, scheduledExecutor.updateSnapshotFromScheduleFirings(ctx, snapshot))
+		assert.Equal(t, lastExecTime, *snapshot.GetLastExecutionTime(nameOfSchedule))
+
+		snapshot = &snapshoter.SnapshotV1{LastTimes: map[string]*time.Time{}}
+		assert.NoError(t, scheduledExecutor.updateSnapshotFromScheduleFirings(ctx, snapshot))
+		assert.True(t, scheduleFiringStart.Add(5*time.Minute).Equal(*snapshot.GetLastExecutionTime(nameOfSchedule)))
+	})
+}
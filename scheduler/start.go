@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	repositoryCommonConfig "github.com/flyteorg/flyteadmin/pkg/repositories/config"
+	"github.com/flyteorg/flyteadmin/pkg/runtime"
+	schedulerRepoConfig "github.com/flyteorg/flyteadmin/scheduler/repositories"
+	"github.com/flyteorg/flyteidl/clients/go/admin"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+// StartScheduler creates the flyte native scheduler from the configuration and runs it until the context is cancelled.
+// The scheduler launches executions through the admin service configured by the admin client config, and its metrics
+// are under the flytescheduler scope.
+func StartScheduler(ctx context.Context) (err error) {
+	configuration := runtime.NewConfigurationProvider()
+	applicationConfiguration := configuration.ApplicationConfiguration().GetTopLevelConfig()
+
+	// Define the schedulerScope for prometheus metrics
+	schedulerScope := promutils.NewScope(applicationConfiguration.MetricsScope).NewSubScope("flytescheduler")
+	schedulerPanics := schedulerScope.MustNewCounter("initialization_panic",
+		"panics encountered initializing the flyte native scheduler")
+
+	defer func() {
+		if r := recover(); r != nil {
+			schedulerPanics.Inc()
+			logger.Errorf(ctx, "caught panic: %v [%+v]", r, string(debug.Stack()))
+			err = fmt.Errorf("flyte native scheduler panicked: %v", r)
+		}
+	}()
+
+	dbConfigValues := configuration.ApplicationConfiguration().GetDbConfig()
+	dbConfig := repositoryCommonConfig.NewDbConfig(dbConfigValues)
+	repoConfig, err := schedulerRepoConfig.GetRepoConfig(dbConfig)
+	if err != nil {
+		logger.Errorf(ctx, "Flyte native scheduler failed to start due to %v", err)
+		return err
+	}
+	db := schedulerRepoConfig.GetRepository(repoConfig, dbConfig, schedulerScope.NewSubScope("database"))
+
+	clientSet, err := admin.ClientSetBuilder().WithConfig(admin.GetConfig(ctx)).Build(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Flyte native scheduler failed to start due to %v", err)
+		return err
+	}
+	adminServiceClient := clientSet.AdminClient()
+
+	scheduleExecutor := NewScheduledExecutor(db,
+		configuration.ApplicationConfiguration().GetSchedulerConfig().GetWorkflowExecutorConfig(), schedulerScope, adminServiceClient)
+
+	logger.Info(ctx, "Successfully initialized a native flyte scheduler")
+
+	err = scheduleExecutor.Run(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Flyte native scheduler failed to start due to %v", err)
+		return err
+	}
+	return nil
+}